@@ -0,0 +1,525 @@
+// Package bootstrap brings up the application's dependencies in a fixed
+// order and reports the first one that fails as an *Error carrying a hint on
+// how to fix it, instead of panicking halfway through start-up.
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/fazamuttaqien/multifinance/config"
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
+	redisdb "github.com/fazamuttaqien/multifinance/infra/redis"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	notifiersrv "github.com/fazamuttaqien/multifinance/internal/service/notifier"
+	partnersrv "github.com/fazamuttaqien/multifinance/internal/service/partner"
+	statementsrv "github.com/fazamuttaqien/multifinance/internal/service/statement"
+	"github.com/fazamuttaqien/multifinance/pkg/businesshours"
+	cloudinarypkg "github.com/fazamuttaqien/multifinance/pkg/cloudinary"
+	"github.com/fazamuttaqien/multifinance/pkg/httpclient"
+	"github.com/fazamuttaqien/multifinance/pkg/pdf"
+	ratelimiter "github.com/fazamuttaqien/multifinance/pkg/rate-limiter"
+	"github.com/fazamuttaqien/multifinance/pkg/refdata"
+	"github.com/fazamuttaqien/multifinance/pkg/slo"
+	"github.com/fazamuttaqien/multifinance/pkg/telemetry"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/fazamuttaqien/multifinance/pkg/virtualaccount"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+)
+
+type Step string
+
+const (
+	StepConfig      Step = "config"
+	StepTelemetry   Step = "telemetry"
+	StepDatabase    Step = "database"
+	StepRedis       Step = "redis"
+	StepStorage     Step = "storage"
+	StepMigration   Step = "migration"
+	StepSeed        Step = "seed"
+	StepRateLimiter Step = "rate_limiter"
+)
+
+// hints tell the operator where to look when a step fails.
+var hints = map[Step]string{
+	StepConfig:      "check the environment variables or .env file named in the error",
+	StepTelemetry:   "check OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_RESOURCE_ATTRIBUTES",
+	StepDatabase:    "check MYSQL_HOST, MYSQL_PORT, MYSQL_USER, MYSQL_PASSWORD and MYSQL_DBNAME, and that MySQL accepts connections and every database named in TENANTS_FILE exists",
+	StepRedis:       "check REDIS_ADDRESS and REDIS_PASSWORD, and that Redis accepts connections",
+	StepStorage:     "check CLOUDINARY_CLOUD, CLOUDINARY_API_KEY and CLOUDINARY_API_SECRET",
+	StepMigration:   "the database user needs CREATE and ALTER privileges on every tenant database; the schema comes from AutoMigrate over the models in internal/model, which adds tables, columns and indexes but never changes a primary key, so compare the failing table with its model rather than db.sql. A lock timeout means another instance is still migrating, raise BOOTSTRAP_LOCK_TIMEOUT",
+	StepSeed:        "the master data could not be written; set ADMIN_PASSWORD for the first start, check the files in REFERENCE_DATA_DIR and the database logs",
+	StepRateLimiter: "the rate limiter needs a connected Redis client",
+}
+
+// Error is a start-up failure of one step.
+type Error struct {
+	Step Step
+	Err  error
+	Hint string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bootstrap %s: %v", e.Step, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func fail(step Step, err error) *Error {
+	return &Error{Step: step, Err: err, Hint: hints[step]}
+}
+
+// Options changes what Start does. Check stops after every dependency has
+// been reached, without migrating, seeding or touching any data. Seed runs
+// right after the migration.
+type Options struct {
+	Check bool
+	Seed  func(ctx context.Context, app *App) error
+}
+
+// StepResult is one line of the start-up summary.
+type StepResult struct {
+	Step     Step
+	Duration time.Duration
+}
+
+// App holds everything main needs once start-up has succeeded.
+type App struct {
+	Config     *config.Config
+	Telemetry  *telemetry.OpenTelemetry
+	DB         *gorm.DB
+	Redis      *redis.Client
+	Cloudinary *cloudinary.Cloudinary
+	Limiter    *ratelimiter.RateLimiter
+	Tenants    *tenancy.Registry
+	// ReferenceData is read from REFERENCE_DATA_DIR and written by Seed
+	ReferenceData *refdata.Set
+
+	Steps []StepResult
+}
+
+// step is one subsystem in the start-up order. Full steps change data and
+// are skipped in check mode.
+type step struct {
+	step Step
+	run  func(ctx context.Context) error
+	full bool
+}
+
+// Start initialises every subsystem in dependency order. On failure the
+// subsystems already started are closed again and the returned error is an
+// *Error.
+func Start(ctx context.Context, opts Options) (*App, error) {
+	app := &App{}
+	if err := app.run(ctx, app.steps(opts), opts.Check); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// steps lists the subsystems in the order they depend on each other.
+func (a *App) steps(opts Options) []step {
+	return []step{
+		{StepConfig, a.loadConfig, false},
+		{StepTelemetry, a.startTelemetry, false},
+		{StepDatabase, a.connectDatabase, false},
+		{StepRedis, func(ctx context.Context) error { return a.connectRedis(ctx, opts.Check) }, false},
+		{StepStorage, a.connectStorage, false},
+		{StepMigration, a.locked(a.migrate), true},
+		{StepSeed, a.locked(func(ctx context.Context) error {
+			if opts.Seed == nil {
+				return nil
+			}
+			return a.Tenants.Each(ctx, func(ctx context.Context) error {
+				return opts.Seed(ctx, a)
+			})
+		}), true},
+		{StepRateLimiter, a.startRateLimiter, true},
+	}
+}
+
+// run executes the steps in order and stops at the first failure, closing
+// what the earlier steps opened.
+func (a *App) run(ctx context.Context, steps []step, check bool) error {
+	for _, s := range steps {
+		if check && s.full {
+			continue
+		}
+
+		started := time.Now()
+		if err := s.run(ctx); err != nil {
+			a.Close(context.Background())
+			return fail(s.step, err)
+		}
+		a.Steps = append(a.Steps, StepResult{Step: s.step, Duration: time.Since(started)})
+	}
+	return nil
+}
+
+// bootstrapLock serialises migration and seeding across instances that start
+// at the same time against the same database.
+const bootstrapLock = "multifinance.bootstrap"
+
+// locked wraps a step so it runs under the bootstrap lock. Both steps are
+// idempotent, so an instance that waited simply finds the work done.
+func (a *App) locked(run func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return mysqldb.WithLock(ctx, a.DB, bootstrapLock, a.Config.BOOTSTRAP_LOCK_TIMEOUT, run)
+	}
+}
+
+// migrate brings the schema of every tenant up to date. With
+// TRANSACTION_PARTITIONING the transactions table is partitioned by month,
+// which rules out foreign keys from and to it, so only those are skipped.
+func (a *App) migrate(ctx context.Context) error {
+	return a.Tenants.Each(ctx, a.migrateTenant)
+}
+
+func (a *App) migrateTenant(ctx context.Context) error {
+	if !a.Config.TRANSACTION_PARTITIONING {
+		return model.AutoMigrate(a.DB.WithContext(ctx))
+	}
+
+	if err := model.AutoMigratePartitioned(a.DB.WithContext(ctx)); err != nil {
+		return err
+	}
+
+	// Mempartisi tabel besar bisa memakan waktu jauh di atas MYSQL_QUERY_TIMEOUT
+	_, err := model.PartitionTransactions(mysqldb.WithoutQueryTimeout(ctx), a.DB, time.Now(), a.Config.TRANSACTION_PARTITIONS_AHEAD)
+	return err
+}
+
+func (a *App) loadConfig(context.Context) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := validate(cfg); err != nil {
+		return err
+	}
+	tenants, err := loadTenants(cfg)
+	if err != nil {
+		return fmt.Errorf("TENANTS_FILE: %w", err)
+	}
+	// File referensi diperiksa di sini agar -check juga menangkap kesalahannya
+	referenceData, err := refdata.Load(cfg.REFERENCE_DATA_DIR)
+	if err != nil {
+		return fmt.Errorf("REFERENCE_DATA_DIR: %w", err)
+	}
+	a.Config, a.Tenants, a.ReferenceData = cfg, tenants, referenceData
+	return nil
+}
+
+// loadTenants returns the tenants of TENANTS_FILE next to the default one,
+// which is the deployment as the environment configures it.
+func loadTenants(cfg *config.Config) (*tenancy.Registry, error) {
+	registry, err := tenancy.Load(cfg.TENANTS_FILE, tenancy.Tenant{
+		Database: cfg.MYSQL_DBNAME,
+		Branding: tenancy.Branding{
+			CompanyName: cfg.STATEMENT_COMPANY_NAME,
+			Address:     cfg.STATEMENT_COMPANY_ADDRESS,
+			Contact:     cfg.STATEMENT_COMPANY_CONTACT,
+			Color:       cfg.STATEMENT_BRAND_COLOR,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, t := range registry.All() {
+		// Nama perusahaan lain tidak boleh muncul di dokumen tenant white-label
+		if t.Branding.CompanyName == "" {
+			errs = append(errs, fmt.Errorf("tenant %q: branding.company_name is empty", t.ID))
+		}
+		if t.Branding.Color == "" {
+			continue
+		}
+		if _, err := pdf.ParseHexColor(t.Branding.Color); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %q: branding.color: %w", t.ID, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// validate catches settings that would otherwise only fail deep inside the
+// presenter or when the first request arrives. Every problem is reported at
+// once.
+func validate(cfg *config.Config) error {
+	var errs []error
+
+	if cfg.JWT_SECRET_KEY == "" {
+		errs = append(errs, errors.New("JWT_SECRET_KEY is empty"))
+	}
+	if _, err := time.LoadLocation(cfg.BUSINESS_TIMEZONE); err != nil {
+		errs = append(errs, fmt.Errorf("BUSINESS_TIMEZONE: %w", err))
+	} else if _, err := businesshours.Parse(cfg.PARTNER_TRANSACTION_HOURS, cfg.BUSINESS_TIMEZONE); err != nil {
+		errs = append(errs, fmt.Errorf("PARTNER_TRANSACTION_HOURS: %w", err))
+	}
+	if cfg.HTTP_CLIENT_MAX_RETRIES < 0 || cfg.HTTP_CLIENT_MAX_RETRIES > 10 {
+		errs = append(errs, errors.New("HTTP_CLIENT_MAX_RETRIES must be between 0 and 10"))
+	}
+	if cfg.MAX_AGE_AT_TENOR_END < 0 || cfg.MAX_AGE_AT_TENOR_END > 100 {
+		errs = append(errs, errors.New("MAX_AGE_AT_TENOR_END must be between 0 and 100"))
+	}
+	if _, err := partnersrv.ParseMaxRatios(cfg.DSR_MAX_RATIOS); err != nil {
+		errs = append(errs, fmt.Errorf("DSR_MAX_RATIOS: %w", err))
+	}
+	if _, err := telemetry.ParseLogLevels(cfg.LOG_LEVELS, zapcore.InfoLevel); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_LEVELS: %w", err))
+	}
+	if _, err := zapcore.ParseLevel(cfg.LOG_OTLP_LEVEL); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_OTLP_LEVEL: %w", err))
+	}
+	for _, objective := range []slo.Objective{
+		{Name: "SLO_PARTNER_AVAILABILITY", Target: cfg.SLO_PARTNER_AVAILABILITY},
+		{Name: "SLO_PARTNER_LATENCY_TARGET", Target: cfg.SLO_PARTNER_LATENCY_TARGET, Latency: cfg.SLO_PARTNER_LATENCY},
+	} {
+		if err := objective.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if _, err := pdf.ParseHexColor(cfg.STATEMENT_BRAND_COLOR); err != nil {
+		errs = append(errs, fmt.Errorf("STATEMENT_BRAND_COLOR: %w", err))
+	}
+	if _, err := virtualaccount.New(cfg.VIRTUAL_ACCOUNT_PROVIDER, cfg.VIRTUAL_ACCOUNT_BANK, cfg.VIRTUAL_ACCOUNT_PREFIX); err != nil {
+		errs = append(errs, fmt.Errorf("VIRTUAL_ACCOUNT_PROVIDER: %w", err))
+	}
+
+	// Template ikut dibawa di binary, tapi tetap dicek agar --check menangkap build yang rusak
+	if _, err := statementsrv.LoadTemplates(); err != nil {
+		errs = append(errs, fmt.Errorf("statement templates: %w", err))
+	}
+	if _, err := notifiersrv.LoadTemplates(); err != nil {
+		errs = append(errs, fmt.Errorf("notification templates: %w", err))
+	}
+
+	if cfg.CONTRACT_IMPORT_BATCH_SIZE <= 0 {
+		errs = append(errs, errors.New("CONTRACT_IMPORT_BATCH_SIZE must be greater than zero"))
+	}
+	if cfg.TRANSACTION_PARTITIONING && cfg.TRANSACTION_PARTITIONS_AHEAD < 1 {
+		errs = append(errs, errors.New("TRANSACTION_PARTITIONS_AHEAD must be at least 1"))
+	}
+
+	if cfg.BOOTSTRAP_LOCK_TIMEOUT <= 0 {
+		errs = append(errs, errors.New("BOOTSTRAP_LOCK_TIMEOUT must be greater than zero"))
+	}
+	// Password admin awal tidak punya default, admin tidak dibuat tanpa password eksplisit
+	if cfg.ADMIN_PASSWORD != "" && len(cfg.ADMIN_PASSWORD) < 8 {
+		errs = append(errs, errors.New("ADMIN_PASSWORD must be at least 8 characters"))
+	}
+
+	// Batas request per IP per 15 menit, dinaikkan saat load test
+	if cfg.RATE_LIMIT_MAX <= 0 {
+		errs = append(errs, errors.New("RATE_LIMIT_MAX must be greater than zero"))
+	}
+
+	if (cfg.TLS_CERT_FILE == "") != (cfg.TLS_KEY_FILE == "") {
+		errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+	} else if cfg.TLS_CERT_FILE != "" {
+		if _, err := tls.LoadX509KeyPair(cfg.TLS_CERT_FILE, cfg.TLS_KEY_FILE); err != nil {
+			errs = append(errs, fmt.Errorf("TLS_CERT_FILE/TLS_KEY_FILE: %w", err))
+		}
+	}
+	if cfg.TLS_CLIENT_CA_FILE != "" {
+		caPEM, err := os.ReadFile(cfg.TLS_CLIENT_CA_FILE)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("TLS_CLIENT_CA_FILE: %w", err))
+		} else if !x509.NewCertPool().AppendCertsFromPEM(caPEM) {
+			errs = append(errs, errors.New("TLS_CLIENT_CA_FILE contains no certificates"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a *App) startTelemetry(ctx context.Context) error {
+	tel, err := telemetry.New(ctx, a.Config)
+	if err != nil {
+		return err
+	}
+	a.Telemetry = tel
+	return nil
+}
+
+func (a *App) connectDatabase(ctx context.Context) error {
+	db, err := mysqldb.InitializeDatabase(a.Config)
+	if err != nil {
+		return err
+	}
+	a.DB = db
+
+	// Batas waktu dipasang lebih dulu agar span query juga mewarisi deadline-nya
+	if err := mysqldb.EnableQueryTimeout(db, a.Config.MYSQL_QUERY_TIMEOUT); err != nil {
+		return fmt.Errorf("enable query timeout: %w", err)
+	}
+
+	// Span dan durasi per query dicatat di sini, repository tidak lagi membuat span sendiri
+	if err := mysqldb.EnableQueryTracing(db,
+		a.Telemetry.TracerProvider.Tracer("mysql-tracer"),
+		a.Telemetry.MeterProvider.Meter("mysql-meter"),
+		a.Telemetry.Logger(telemetry.ModuleSQL),
+		a.Config.MYSQL_SLOW_QUERY_THRESHOLD,
+	); err != nil {
+		return fmt.Errorf("enable query tracing: %w", err)
+	}
+
+	// Komentar trace di SQL menambah alokasi per query, jadi hanya aktif bila diminta
+	if a.Config.MYSQL_QUERY_COMMENTS {
+		if err := mysqldb.EnableQueryComments(db); err != nil {
+			return fmt.Errorf("enable SQL query comments: %w", err)
+		}
+	}
+
+	// Tenant tambahan mendapat pool ke skemanya sendiri, dipasang setelah
+	// komentar query agar pool tenant ikut diberi komentar
+	if len(a.Tenants.All()) > 1 {
+		if err := mysqldb.EnableTenantRouting(db, mysqldb.NewDatabaseConfig(a.Config), a.Tenants.All()); err != nil {
+			return fmt.Errorf("enable tenant routing: %w", err)
+		}
+	}
+
+	if err := mysqldb.Ping(db, ctx); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+
+	if err := mysqldb.RegisterPoolMetrics(db, a.Telemetry.MeterProvider.Meter("mysql-meter")); err != nil {
+		return fmt.Errorf("register pool metrics: %w", err)
+	}
+
+	return nil
+}
+
+// connectRedis retries like the database does, except in check mode where
+// one answer is enough.
+func (a *App) connectRedis(ctx context.Context, check bool) error {
+	attempts := 5
+	if check {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var client *redis.Client
+		if client, err = redisdb.NewRedis(a.Config); err == nil {
+			a.Redis = client
+			return nil
+		}
+
+		if attempt < attempts {
+			zap.L().Warn("Failed to connect to Redis, retrying",
+				zap.Int("attempt", attempt),
+				zap.Int("max_attempts", attempts),
+				zap.Error(err),
+			)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(2 * time.Second):
+			}
+		}
+	}
+	return fmt.Errorf("failed to connect after %d attempts: %w", attempts, err)
+}
+
+func (a *App) connectStorage(context.Context) error {
+	client := httpclient.New(httpclient.Config{
+		Name:                "cloudinary",
+		Timeout:             a.Config.HTTP_CLIENT_TIMEOUT,
+		MaxRetries:          a.Config.HTTP_CLIENT_MAX_RETRIES,
+		Backoff:             a.Config.HTTP_CLIENT_BACKOFF,
+		MaxIdleConnsPerHost: a.Config.HTTP_CLIENT_MAX_IDLE_CONNS,
+	},
+		a.Telemetry.MeterProvider.Meter("http-client-meter"),
+		a.Telemetry.TracerProvider.Tracer("http-client-trace"),
+		a.Telemetry.Logger(telemetry.ModuleHTTP),
+	)
+
+	cld, err := cloudinarypkg.InitCloudinary(a.Config, client)
+	if err != nil {
+		return err
+	}
+	a.Cloudinary = cld
+	return nil
+}
+
+func (a *App) startRateLimiter(context.Context) error {
+	if a.Redis == nil {
+		return errors.New("redis client is not connected")
+	}
+
+	rps := float64(a.Config.RATE_LIMIT_MAX) / (15 * 60)
+	a.Limiter = ratelimiter.NewRateLimiter(a.Redis, rps, a.Config.RATE_LIMIT_MAX, 15*time.Minute)
+	return nil
+}
+
+// LogSummary writes one line describing what was started and how long each
+// step took.
+func (a *App) LogSummary(log *zap.Logger, check bool) {
+	var total time.Duration
+	steps := make([]zap.Field, 0, len(a.Steps))
+	for _, s := range a.Steps {
+		total += s.Duration
+		steps = append(steps, zap.Duration("step."+string(s.Step), s.Duration))
+	}
+
+	message := "Startup completed"
+	if check {
+		message = "Startup check passed"
+	}
+
+	log.Info(message, append([]zap.Field{
+		zap.String("service", a.Config.SERVICE_NAME),
+		zap.String("version", a.Config.SERVICE_VERSION),
+		zap.String("environment", a.Config.ENVIRONMENT),
+		zap.String("port", a.Config.SERVER_PORT),
+		zap.Bool("tls", a.Config.TLS_CERT_FILE != ""),
+		zap.Bool("maintenance_mode", a.Config.MAINTENANCE_MODE),
+		zap.Duration("total", total),
+	}, steps...)...)
+}
+
+// Close releases whatever Start managed to open, in reverse order.
+func (a *App) Close(ctx context.Context) {
+	if a.Redis != nil {
+		zap.L().Info("Closing Redis connection...")
+		if err := a.Redis.Close(); err != nil {
+			zap.L().Error("Error disconnecting from Redis", zap.Error(err))
+		} else {
+			zap.L().Info("Disconnected from Redis.")
+		}
+	}
+
+	if a.DB != nil {
+		zap.L().Info("Closing MySQL Connection...")
+		if err := mysqldb.Close(a.DB, ctx); err != nil {
+			zap.L().Error("Error disconnecting from MySQL", zap.Error(err))
+		} else {
+			zap.L().Info("Disconnected from MySQL.")
+		}
+	}
+
+	if a.Telemetry != nil {
+		zap.L().Info("Shutting down monitoring...")
+		if err := a.Telemetry.Shutdown(ctx); err != nil {
+			zap.L().Error("Error during monitoring shutdown", zap.Error(err))
+		} else {
+			zap.L().Info("Monitoring shutdown complete.")
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/config"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// validEnv is the smallest environment that passes the config step.
+func validEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("JWT_SECRET_KEY", "test-secret")
+	t.Setenv("REFERENCE_DATA_DIR", "../reference")
+	t.Setenv("TENANTS_FILE", "")
+}
+
+func validConfig(t *testing.T) *config.Config {
+	t.Helper()
+	validEnv(t)
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	return cfg
+}
+
+// fakeSteps keeps the real order of app.steps but replaces every run with
+// one that records its step and fails for the step named in failAt.
+func fakeSteps(app *App, opts Options, failAt Step, cause error, ran *[]Step) []step {
+	steps := app.steps(opts)
+	for i := range steps {
+		name := steps[i].step
+		steps[i].run = func(context.Context) error {
+			*ran = append(*ran, name)
+			if name == failAt {
+				return cause
+			}
+			return nil
+		}
+	}
+	return steps
+}
+
+func stepNames(results []StepResult) []Step {
+	names := make([]Step, len(results))
+	for i, r := range results {
+		names[i] = r.Step
+	}
+	return names
+}
+
+var allSteps = []Step{
+	StepConfig, StepTelemetry, StepDatabase, StepRedis,
+	StepStorage, StepMigration, StepSeed, StepRateLimiter,
+}
+
+func TestSteps_Order(t *testing.T) {
+	steps := (&App{}).steps(Options{})
+
+	names := make([]Step, len(steps))
+	var full []Step
+	for i, s := range steps {
+		names[i] = s.step
+		if s.full {
+			full = append(full, s.step)
+		}
+	}
+	assert.Equal(t, allSteps, names)
+	// Hanya langkah yang mengubah data yang dilewati --check
+	assert.Equal(t, []Step{StepMigration, StepSeed, StepRateLimiter}, full)
+}
+
+func TestHints_CoverEveryStep(t *testing.T) {
+	for _, s := range allSteps {
+		assert.NotEmpty(t, hints[s], "step %s has no hint", s)
+	}
+}
+
+func TestRun_FailureMapsToStepError(t *testing.T) {
+	for i, failAt := range allSteps {
+		t.Run(string(failAt), func(t *testing.T) {
+			cause := errors.New("boom")
+			app := &App{}
+			var ran []Step
+
+			err := app.run(context.Background(), fakeSteps(app, Options{}, failAt, cause, &ran), false)
+
+			var bootErr *Error
+			require.ErrorAs(t, err, &bootErr)
+			assert.Equal(t, failAt, bootErr.Step)
+			assert.Equal(t, hints[failAt], bootErr.Hint)
+			assert.ErrorIs(t, err, cause)
+			assert.Equal(t, "bootstrap "+string(failAt)+": boom", err.Error())
+
+			// Langkah setelah yang gagal tidak dijalankan
+			assert.Equal(t, allSteps[:i+1], ran)
+			assert.Equal(t, allSteps[:i], stepNames(app.Steps))
+		})
+	}
+}
+
+func TestRun_FailureClosesStartedSubsystems(t *testing.T) {
+	app := &App{}
+	steps := []step{
+		{StepRedis, func(context.Context) error {
+			app.Redis = redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+			return nil
+		}, false},
+		{StepStorage, func(context.Context) error { return errors.New("invalid credentials") }, false},
+	}
+
+	err := app.run(context.Background(), steps, false)
+	require.Error(t, err)
+
+	require.NotNil(t, app.Redis)
+	assert.ErrorIs(t, app.Redis.Ping(context.Background()).Err(), redis.ErrClosed)
+}
+
+func TestRun_CheckSkipsDataSteps(t *testing.T) {
+	app := &App{}
+	seeded := false
+	opts := Options{Check: true, Seed: func(context.Context, *App) error {
+		seeded = true
+		return nil
+	}}
+	var ran []Step
+
+	err := app.run(context.Background(), fakeSteps(app, opts, "", nil, &ran), opts.Check)
+	require.NoError(t, err)
+
+	want := []Step{StepConfig, StepTelemetry, StepDatabase, StepRedis, StepStorage}
+	assert.Equal(t, want, ran)
+	assert.Equal(t, want, stepNames(app.Steps))
+	assert.False(t, seeded)
+	// Tanpa rate limiter server tidak bisa dijalankan dari App ini
+	assert.Nil(t, app.Limiter)
+}
+
+func TestRun_CheckStillReportsDependencyFailure(t *testing.T) {
+	app := &App{}
+	var ran []Step
+
+	err := app.run(context.Background(), fakeSteps(app, Options{Check: true}, StepRedis, errors.New("connection refused"), &ran), true)
+
+	var bootErr *Error
+	require.ErrorAs(t, err, &bootErr)
+	assert.Equal(t, StepRedis, bootErr.Step)
+	assert.Equal(t, []Step{StepConfig, StepTelemetry, StepDatabase, StepRedis}, ran)
+}
+
+func TestRun_DataStepsFailClosedWithoutLock(t *testing.T) {
+	for _, failAt := range []Step{StepMigration, StepSeed} {
+		t.Run(string(failAt), func(t *testing.T) {
+			// Tidak ada server di port ini, koneksi untuk GET_LOCK tidak bisa
+			// dibuat. Handle baru per kasus karena run menutupnya saat gagal.
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				DSN:                       "root@tcp(127.0.0.1:1)/loan_system",
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+			require.NoError(t, err)
+
+			seeded := false
+			app := &App{Config: &config.Config{BOOTSTRAP_LOCK_TIMEOUT: time.Second}, DB: db}
+			opts := Options{Seed: func(context.Context, *App) error {
+				seeded = true
+				return nil
+			}}
+
+			var steps []step
+			for _, s := range app.steps(opts) {
+				if s.step == failAt {
+					steps = append(steps, s)
+				}
+			}
+
+			err = app.run(context.Background(), steps, false)
+
+			var bootErr *Error
+			require.ErrorAs(t, err, &bootErr)
+			assert.Equal(t, failAt, bootErr.Step)
+			assert.Equal(t, hints[failAt], bootErr.Hint)
+			assert.ErrorContains(t, err, "failed to reserve lock connection")
+			assert.ErrorContains(t, err, "connection refused")
+			assert.False(t, seeded)
+		})
+	}
+}
+
+func TestStart_ConfigFailure(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "Missing JWT Secret",
+			env:  map[string]string{"JWT_SECRET_KEY": ""},
+			want: "JWT_SECRET_KEY is empty",
+		},
+		{
+			name: "Invalid Pool Size",
+			env:  map[string]string{"MYSQL_MAX_OPEN_CONNS": "lots"},
+			want: `MYSQL_MAX_OPEN_CONNS: invalid integer "lots"`,
+		},
+		{
+			name: "Missing Reference Data",
+			env:  map[string]string{"REFERENCE_DATA_DIR": "does-not-exist"},
+			want: "REFERENCE_DATA_DIR",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			validEnv(t)
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			// --check berhenti di langkah yang sama sebelum menyentuh dependency
+			for _, check := range []bool{false, true} {
+				app, err := Start(context.Background(), Options{Check: check})
+				assert.Nil(t, app)
+
+				var bootErr *Error
+				require.ErrorAs(t, err, &bootErr)
+				assert.Equal(t, StepConfig, bootErr.Step)
+				assert.Equal(t, hints[StepConfig], bootErr.Hint)
+				assert.ErrorContains(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	validEnv(t)
+	app := &App{}
+
+	require.NoError(t, app.loadConfig(context.Background()))
+	assert.NotNil(t, app.Config)
+	assert.NotNil(t, app.ReferenceData)
+	require.Len(t, app.Tenants.All(), 1)
+	assert.Equal(t, app.Config.MYSQL_DBNAME, app.Tenants.All()[0].Database)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, validate(validConfig(t)))
+
+	cases := []struct {
+		name   string
+		mutate func(cfg *config.Config)
+		want   string
+	}{
+		{"Empty JWT Secret", func(cfg *config.Config) { cfg.JWT_SECRET_KEY = "" }, "JWT_SECRET_KEY is empty"},
+		{"Unknown Timezone", func(cfg *config.Config) { cfg.BUSINESS_TIMEZONE = "Mars/Olympus" }, "BUSINESS_TIMEZONE"},
+		{"Too Many Retries", func(cfg *config.Config) { cfg.HTTP_CLIENT_MAX_RETRIES = 11 }, "HTTP_CLIENT_MAX_RETRIES must be between 0 and 10"},
+		{"Invalid Log Level", func(cfg *config.Config) { cfg.LOG_OTLP_LEVEL = "loud" }, "LOG_OTLP_LEVEL"},
+		{"Invalid Brand Color", func(cfg *config.Config) { cfg.STATEMENT_BRAND_COLOR = "blue" }, "STATEMENT_BRAND_COLOR"},
+		{"Zero Lock Timeout", func(cfg *config.Config) { cfg.BOOTSTRAP_LOCK_TIMEOUT = 0 }, "BOOTSTRAP_LOCK_TIMEOUT must be greater than zero"},
+		{"Short Admin Password", func(cfg *config.Config) { cfg.ADMIN_PASSWORD = "short" }, "ADMIN_PASSWORD must be at least 8 characters"},
+		{"Zero Rate Limit", func(cfg *config.Config) { cfg.RATE_LIMIT_MAX = 0 }, "RATE_LIMIT_MAX must be greater than zero"},
+		{"TLS Key Without Cert", func(cfg *config.Config) { cfg.TLS_KEY_FILE = "server.key" }, "TLS_CERT_FILE and TLS_KEY_FILE must be set together"},
+		{"Missing Client CA", func(cfg *config.Config) { cfg.TLS_CLIENT_CA_FILE = "does-not-exist.pem" }, "TLS_CLIENT_CA_FILE"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tc.mutate(cfg)
+			assert.ErrorContains(t, validate(cfg), tc.want)
+		})
+	}
+
+	t.Run("Every Problem Reported", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.JWT_SECRET_KEY = ""
+		cfg.RATE_LIMIT_MAX = 0
+
+		err := validate(cfg)
+		assert.ErrorContains(t, err, "JWT_SECRET_KEY is empty")
+		assert.ErrorContains(t, err, "RATE_LIMIT_MAX must be greater than zero")
+	})
+}
+
+func TestConnectRedis_CheckTriesOnce(t *testing.T) {
+	app := &App{Config: &config.Config{REDIS_ADDRESS: "127.0.0.1:1"}}
+
+	err := app.connectRedis(context.Background(), true)
+	assert.ErrorContains(t, err, "failed to connect after 1 attempts")
+	assert.Nil(t, app.Redis)
+}
+
+func TestStartRateLimiter_RequiresRedis(t *testing.T) {
+	app := &App{Config: &config.Config{RATE_LIMIT_MAX: 100}}
+	assert.EqualError(t, app.startRateLimiter(context.Background()), "redis client is not connected")
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Benchcheck compares `go test -bench` output against the committed baseline
+// and exits non-zero when a benchmark got slower or allocates more than the
+// allowed tolerance.
+//
+//	go test ./internal/service/tests -run '^$' -bench . -benchmem | go run ./cmd/benchcheck
+//
+// Pass -update to rewrite the benchmark section of the baseline from the
+// current run instead, the k6 thresholds are left untouched.
+func main() {
+	baselinePath := flag.String("baseline", "loadtest/baseline.json", "baseline JSON file")
+	update := flag.Bool("update", false, "rewrite the baseline benchmarks from stdin")
+	flag.Parse()
+
+	results, err := parseBenchmarks(os.Stdin, os.Stderr)
+	if err != nil {
+		slog.Error("Failed to read benchmark output", "error", err)
+		os.Exit(1)
+	}
+	if len(results) == 0 {
+		slog.Error("No benchmark results on stdin, is Docker running?")
+		os.Exit(1)
+	}
+
+	baseline, err := loadBaseline(*baselinePath)
+	if err != nil {
+		slog.Error("Failed to load baseline", "path", *baselinePath, "error", err)
+		os.Exit(1)
+	}
+
+	if *update {
+		baseline.Benchmarks = results
+		if err := saveBaseline(*baselinePath, baseline); err != nil {
+			slog.Error("Failed to write baseline", "path", *baselinePath, "error", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Updated %d benchmarks in %s\n", len(results), *baselinePath)
+		return
+	}
+
+	regressions := compare(baseline, results)
+	for _, line := range regressions {
+		fmt.Println(line)
+	}
+	if len(regressions) > 0 {
+		os.Exit(1)
+	}
+	fmt.Printf("%d benchmarks within %.0f%% of baseline\n", len(results), baseline.Tolerance*100)
+}
+
+// Baseline is the committed performance budget. Benchmarks are keyed by name
+// without the -GOMAXPROCS suffix, K6 is read by the k6 scenarios directly.
+type Baseline struct {
+	Tolerance  float64                    `json:"tolerance"`
+	Benchmarks map[string]Result          `json:"benchmarks"`
+	K6         map[string]json.RawMessage `json:"k6"`
+}
+
+type Result struct {
+	NsPerOp     float64 `json:"ns_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+// BenchmarkCheckLimit-8   	    1234	    912345 ns/op	   12345 B/op	     123 allocs/op
+var benchLine = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op(.*)$`)
+var allocsField = regexp.MustCompile(`(\d+) allocs/op`)
+
+// parseBenchmarks reads `go test -bench` output from r, copying every line
+// to echo, and returns the fastest run of each benchmark.
+func parseBenchmarks(r io.Reader, echo io.Writer) (map[string]Result, error) {
+	results := make(map[string]Result)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Tetap teruskan output asli supaya log CI tetap utuh
+		fmt.Fprintln(echo, line)
+
+		match := benchLine.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		ns, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ns/op in %q: %w", line, err)
+		}
+
+		result := Result{NsPerOp: ns}
+		if allocs := allocsField.FindStringSubmatch(match[3]); allocs != nil {
+			result.AllocsPerOp, _ = strconv.ParseInt(allocs[1], 10, 64)
+		}
+		// Dengan -count > 1 ambil run tercepat, paling sedikit terganggu noise
+		if previous, ok := results[match[1]]; ok && previous.NsPerOp < result.NsPerOp {
+			continue
+		}
+		results[match[1]] = result
+	}
+
+	return results, scanner.Err()
+}
+
+// compare lists, sorted by benchmark name, every result that is slower or
+// allocates more than the baseline allows, and every result the baseline
+// does not know yet. Zero baseline values are not checked.
+func compare(baseline *Baseline, results map[string]Result) []string {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	limit := 1 + baseline.Tolerance
+	var regressions []string
+	for _, name := range names {
+		current := results[name]
+		expected, ok := baseline.Benchmarks[name]
+		if !ok {
+			regressions = append(regressions, fmt.Sprintf("%s: missing from baseline, run with -update", name))
+			continue
+		}
+
+		if expected.NsPerOp > 0 && current.NsPerOp > expected.NsPerOp*limit {
+			regressions = append(regressions, fmt.Sprintf("%s: %.0f ns/op, baseline %.0f ns/op (+%.1f%%)",
+				name, current.NsPerOp, expected.NsPerOp, (current.NsPerOp/expected.NsPerOp-1)*100))
+		}
+		if expected.AllocsPerOp > 0 && float64(current.AllocsPerOp) > float64(expected.AllocsPerOp)*limit {
+			regressions = append(regressions, fmt.Sprintf("%s: %d allocs/op, baseline %d allocs/op",
+				name, current.AllocsPerOp, expected.AllocsPerOp))
+		}
+	}
+
+	return regressions
+}
+
+func loadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline := &Baseline{}
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, err
+	}
+	if baseline.Tolerance <= 0 {
+		baseline.Tolerance = 0.2
+	}
+	return baseline, nil
+}
+
+func saveBaseline(path string, baseline *Baseline) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBenchmarks(t *testing.T) {
+	output := strings.Join([]string{
+		"goos: linux",
+		"goarch: amd64",
+		"pkg: github.com/fazamuttaqien/multifinance/internal/service/tests",
+		"BenchmarkCheckLimit-8                 	    1234	    912345 ns/op	   12345 B/op	     123 allocs/op",
+		"BenchmarkCheckLimit-8                 	    1300	    880000.5 ns/op	   12345 B/op	     121 allocs/op",
+		"BenchmarkCheckLimit-8                 	    1100	    990000 ns/op	   12345 B/op	     130 allocs/op",
+		"BenchmarkCheckLimit_Parallel-8        	    5000	    250000 ns/op	   12000 B/op	     120 allocs/op",
+		"BenchmarkCreateTransaction/contended-16	     200	   7500000 ns/op",
+		"BenchmarkNoSuffix	     100	      1000 ns/op	       0 B/op	       0 allocs/op",
+		"PASS",
+		"ok  	github.com/fazamuttaqien/multifinance/internal/service/tests	12.345s",
+	}, "\n")
+
+	var echo bytes.Buffer
+	results, err := parseBenchmarks(strings.NewReader(output), &echo)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]Result{
+		// Dari tiga run diambil yang tercepat
+		"BenchmarkCheckLimit":                  {NsPerOp: 880000.5, AllocsPerOp: 121},
+		"BenchmarkCheckLimit_Parallel":         {NsPerOp: 250000, AllocsPerOp: 120},
+		"BenchmarkCreateTransaction/contended": {NsPerOp: 7500000},
+		"BenchmarkNoSuffix":                    {NsPerOp: 1000},
+	}, results)
+
+	// Output asli diteruskan apa adanya
+	assert.Equal(t, output+"\n", echo.String())
+}
+
+func TestParseBenchmarks_Empty(t *testing.T) {
+	results, err := parseBenchmarks(strings.NewReader("PASS\nok  \tpkg\t0.021s\n"), &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestParseBenchmarks_InvalidNsPerOp(t *testing.T) {
+	_, err := parseBenchmarks(strings.NewReader("BenchmarkCheckLimit-8 \t 10 \t 1.2.3 ns/op\n"), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid ns/op")
+}
+
+func TestCompare(t *testing.T) {
+	baseline := &Baseline{
+		Tolerance: 0.2,
+		Benchmarks: map[string]Result{
+			"BenchmarkA":         {NsPerOp: 1000, AllocsPerOp: 100},
+			"BenchmarkB":         {NsPerOp: 1000, AllocsPerOp: 100},
+			"BenchmarkNoBudget":  {},
+			"BenchmarkUnchanged": {NsPerOp: 500, AllocsPerOp: 10},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		results map[string]Result
+		want    []string
+	}{
+		{
+			name:    "Within Tolerance",
+			results: map[string]Result{"BenchmarkA": {NsPerOp: 1199, AllocsPerOp: 119}},
+		},
+		{
+			name:    "At Tolerance",
+			results: map[string]Result{"BenchmarkA": {NsPerOp: 1200, AllocsPerOp: 120}},
+		},
+		{
+			name:    "Faster Than Baseline",
+			results: map[string]Result{"BenchmarkA": {NsPerOp: 10, AllocsPerOp: 1}},
+		},
+		{
+			name:    "Slower",
+			results: map[string]Result{"BenchmarkA": {NsPerOp: 1500, AllocsPerOp: 100}},
+			want:    []string{"BenchmarkA: 1500 ns/op, baseline 1000 ns/op (+50.0%)"},
+		},
+		{
+			name:    "More Allocations",
+			results: map[string]Result{"BenchmarkA": {NsPerOp: 1000, AllocsPerOp: 121}},
+			want:    []string{"BenchmarkA: 121 allocs/op, baseline 100 allocs/op"},
+		},
+		{
+			name:    "Slower And More Allocations",
+			results: map[string]Result{"BenchmarkA": {NsPerOp: 2000, AllocsPerOp: 300}},
+			want: []string{
+				"BenchmarkA: 2000 ns/op, baseline 1000 ns/op (+100.0%)",
+				"BenchmarkA: 300 allocs/op, baseline 100 allocs/op",
+			},
+		},
+		{
+			name:    "Missing From Baseline",
+			results: map[string]Result{"BenchmarkNew": {NsPerOp: 1}},
+			want:    []string{"BenchmarkNew: missing from baseline, run with -update"},
+		},
+		{
+			name:    "Zero Baseline Is Not Checked",
+			results: map[string]Result{"BenchmarkNoBudget": {NsPerOp: 1e9, AllocsPerOp: 1e6}},
+		},
+		{
+			name: "Sorted By Name",
+			results: map[string]Result{
+				"BenchmarkUnchanged": {NsPerOp: 500, AllocsPerOp: 10},
+				"BenchmarkB":         {NsPerOp: 5000, AllocsPerOp: 100},
+				"BenchmarkA":         {NsPerOp: 5000, AllocsPerOp: 100},
+			},
+			want: []string{
+				"BenchmarkA: 5000 ns/op, baseline 1000 ns/op (+400.0%)",
+				"BenchmarkB: 5000 ns/op, baseline 1000 ns/op (+400.0%)",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, compare(baseline, tc.results))
+		})
+	}
+}
+
+func TestCompare_EmptyBaseline(t *testing.T) {
+	baseline := &Baseline{Tolerance: 0.2, Benchmarks: map[string]Result{}}
+
+	regressions := compare(baseline, map[string]Result{"BenchmarkCheckLimit": {NsPerOp: 1}})
+	assert.Equal(t, []string{"BenchmarkCheckLimit: missing from baseline, run with -update"}, regressions)
+}
+
+func TestBaseline_UpdateKeepsK6(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	k6 := `{"check_limit":{"p95_ms":120,"max_error_rate":0.01}}`
+	require.NoError(t, os.WriteFile(path, []byte(`{"benchmarks":{},"k6":`+k6+`}`), 0o644))
+
+	baseline, err := loadBaseline(path)
+	require.NoError(t, err)
+	// Toleransi kosong memakai default 20%
+	assert.Equal(t, 0.2, baseline.Tolerance)
+
+	baseline.Benchmarks = map[string]Result{"BenchmarkCheckLimit": {NsPerOp: 880000, AllocsPerOp: 121}}
+	require.NoError(t, saveBaseline(path, baseline))
+
+	saved, err := loadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, baseline.Benchmarks, saved.Benchmarks)
+
+	var raw struct {
+		K6 json.RawMessage `json:"k6"`
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.JSONEq(t, k6, string(raw.K6))
+}
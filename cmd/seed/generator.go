@@ -0,0 +1,299 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/password"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Options struct {
+	Customers       int
+	MaxTransactions int
+	Seed            uint64
+	Password        string
+	BatchSize       int
+	// AsOf anchors generated transaction dates so a seed reproduces the same rows.
+	AsOf time.Time
+	// Tenors are created when missing, usually those of the reference data.
+	Tenors []model.Tenor
+}
+
+type Summary struct {
+	Customers    int
+	Limits       int
+	Transactions int
+	// VerifiedNIKs lists customers that received limits, for load scenarios.
+	VerifiedNIKs []string
+}
+
+var assetNames = []string{
+	"Honda Beat", "Yamaha NMAX", "Honda Vario 160", "Samsung Galaxy A55", "iPhone 15",
+	"Asus Vivobook 14", "Lenovo IdeaPad Slim 3", "LG Kulkas 2 Pintu", "Sharp AC 1 PK",
+	"Polytron Smart TV 43", "Daikin AC Inverter", "Kawasaki W175", "Xiaomi Redmi Note 13",
+}
+
+// Run generates the demo dataset inside a single database transaction.
+func Run(db *gorm.DB, opts Options) (*Summary, error) {
+	if opts.Customers <= 0 {
+		return nil, errors.New("customers must be greater than zero")
+	}
+	if len(opts.Tenors) == 0 {
+		return nil, errors.New("no tenors to seed, check the reference data directory")
+	}
+	if opts.MaxTransactions < 0 {
+		return nil, errors.New("max-transactions must not be negative")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+
+	// Satu hash untuk semua customer, bcrypt cost 14 terlalu lambat per baris
+	hashed, err := password.HashPassword(opts.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	faker := gofakeit.New(opts.Seed)
+	summary := &Summary{}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		tenors, err := ensureTenors(tx, opts.Tenors)
+		if err != nil {
+			return err
+		}
+
+		used, err := existingNIKs(tx)
+		if err != nil {
+			return err
+		}
+
+		customers := generateCustomers(faker, opts.Customers, hashed, used)
+		if err := tx.CreateInBatches(&customers, opts.BatchSize).Error; err != nil {
+			return fmt.Errorf("failed to insert customers: %w", err)
+		}
+		summary.Customers = len(customers)
+
+		var limits []model.CustomerLimit
+		var transactions []model.Transaction
+		for _, customer := range customers {
+			if customer.VerificationStatus != model.VerificationVerified {
+				continue
+			}
+
+			summary.VerifiedNIKs = append(summary.VerifiedNIKs, customer.NIK)
+			customerLimits := generateLimits(customer, tenors)
+			limits = append(limits, customerLimits...)
+			transactions = append(transactions, generateTransactions(faker, customer, customerLimits, tenors, opts.MaxTransactions, opts.AsOf)...)
+		}
+
+		if len(limits) > 0 {
+			if err := tx.CreateInBatches(&limits, opts.BatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert limits: %w", err)
+			}
+		}
+		if len(transactions) > 0 {
+			if err := tx.CreateInBatches(&transactions, opts.BatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert transactions: %w", err)
+			}
+			keys := model.TransactionKeys(transactions...)
+			if err := tx.CreateInBatches(&keys, opts.BatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert transaction keys: %w", err)
+			}
+		}
+		summary.Limits = len(limits)
+		summary.Transactions = len(transactions)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func ensureTenors(tx *gorm.DB, master []model.Tenor) ([]model.Tenor, error) {
+	tenors := append([]model.Tenor(nil), master...)
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "duration_months"}},
+		DoNothing: true,
+	}).Create(&tenors).Error; err != nil {
+		return nil, fmt.Errorf("failed to seed tenors: %w", err)
+	}
+
+	var stored []model.Tenor
+	if err := tx.Order("duration_months").Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to load tenors: %w", err)
+	}
+
+	return stored, nil
+}
+
+// existingNIKs returns the NIKs already in the database, which generated
+// customers must not reuse.
+func existingNIKs(tx *gorm.DB) (map[string]struct{}, error) {
+	var existing []string
+	if err := tx.Model(&model.Customer{}).Pluck("nik", &existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to load existing NIKs: %w", err)
+	}
+
+	used := make(map[string]struct{}, len(existing))
+	for _, nik := range existing {
+		used[nik] = struct{}{}
+	}
+	return used, nil
+}
+
+// generateCustomers adds every generated NIK to used, so later calls with the
+// same set never repeat one.
+func generateCustomers(faker *gofakeit.Faker, count int, hashedPassword string, used map[string]struct{}) []model.Customer {
+	customers := make([]model.Customer, 0, count)
+	for len(customers) < count {
+		birthDate := faker.DateRange(
+			time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2004, 12, 31, 0, 0, 0, 0, time.UTC),
+		)
+		nik := generateNIK(faker, birthDate)
+		if _, ok := used[nik]; ok {
+			continue
+		}
+		used[nik] = struct{}{}
+
+		name := faker.Name()
+		customers = append(customers, model.Customer{
+			NIK:                nik,
+			FullName:           name,
+			LegalName:          name,
+			Password:           hashedPassword,
+			Role:               model.CustomerRole,
+			BirthPlace:         faker.City(),
+			BirthDate:          time.Date(birthDate.Year(), birthDate.Month(), birthDate.Day(), 0, 0, 0, 0, time.UTC),
+			Salary:             roundTo(decimal.NewFromFloat(faker.Float64Range(3_500_000, 45_000_000)), 100_000),
+			KtpPhotoUrl:        fmt.Sprintf("https://picsum.photos/seed/ktp-%s/600/400", nik),
+			SelfiePhotoUrl:     fmt.Sprintf("https://picsum.photos/seed/selfie-%s/400/400", nik),
+			VerificationStatus: pickVerificationStatus(faker),
+		})
+	}
+
+	return customers
+}
+
+// generateNIK mengikuti format NIK: 6 digit kode wilayah, 6 digit tanggal lahir, 4 digit urut.
+func generateNIK(faker *gofakeit.Faker, birthDate time.Time) string {
+	return fmt.Sprintf("%02d%02d%02d%02d%02d%02d%04d",
+		faker.IntRange(11, 94), faker.IntRange(1, 79), faker.IntRange(1, 40),
+		birthDate.Day(), int(birthDate.Month()), birthDate.Year()%100,
+		faker.IntRange(1, 9999),
+	)
+}
+
+func pickVerificationStatus(faker *gofakeit.Faker) model.VerificationStatus {
+	switch n := faker.IntRange(1, 100); {
+	case n <= 70:
+		return model.VerificationVerified
+	case n <= 90:
+		return model.VerificationPending
+	default:
+		return model.VerificationRejected
+	}
+}
+
+// Limit per tenor naik seiring durasi, berbasis kelipatan gaji bulanan.
+func generateLimits(customer model.Customer, tenors []model.Tenor) []model.CustomerLimit {
+	limits := make([]model.CustomerLimit, 0, len(tenors))
+	for _, tenor := range tenors {
+		multiplier := decimal.NewFromFloat(0.5 + 0.25*float64(tenor.DurationMonths))
+		limits = append(limits, model.CustomerLimit{
+			CustomerID:  customer.ID,
+			TenorID:     tenor.ID,
+			LimitAmount: roundTo(customer.Salary.Mul(multiplier), 100_000),
+		})
+	}
+	return limits
+}
+
+func generateTransactions(
+	faker *gofakeit.Faker,
+	customer model.Customer,
+	limits []model.CustomerLimit,
+	tenors []model.Tenor,
+	maxTransactions int,
+	asOf time.Time,
+) []model.Transaction {
+	if maxTransactions == 0 || len(limits) == 0 {
+		return nil
+	}
+
+	tenorByID := make(map[uint]model.Tenor, len(tenors))
+	for _, t := range tenors {
+		tenorByID[t.ID] = t
+	}
+
+	used := make(map[uint]decimal.Decimal, len(limits))
+	count := faker.IntRange(0, maxTransactions)
+	transactions := make([]model.Transaction, 0, count)
+
+	for i := 0; i < count; i++ {
+		limit := limits[faker.IntRange(0, len(limits)-1)]
+		tenor := tenorByID[limit.TenorID]
+
+		status := pickTransactionStatus(faker)
+		otr := roundTo(limit.LimitAmount.Mul(decimal.NewFromFloat(faker.Float64Range(0.1, 0.6))), 50_000)
+		adminFee := roundTo(otr.Mul(decimal.RequireFromString("0.02")), 1_000)
+		principal := otr.Add(adminFee)
+
+		// Transaksi aktif tidak boleh melebihi sisa limit, sama seperti aturan partner service
+		if status == model.TransactionActive && used[limit.TenorID].Add(principal).GreaterThan(limit.LimitAmount) {
+			status = model.TransactionPaidOff
+		}
+		if status == model.TransactionActive {
+			used[limit.TenorID] = used[limit.TenorID].Add(principal)
+		}
+
+		totalInterest := money.FlatInterest(otr, tenor.DurationMonths)
+		transactionDate := faker.DateRange(asOf.AddDate(-2, 0, 0), asOf)
+
+		transactions = append(transactions, model.Transaction{
+			ContractNumber:         fmt.Sprintf("KTR-%s-%d-%02d", transactionDate.Format("20060102"), customer.ID, i+1),
+			CustomerID:             customer.ID,
+			TenorID:                tenor.ID,
+			AssetName:              assetNames[faker.IntRange(0, len(assetNames)-1)],
+			OTRAmount:              otr,
+			AdminFee:               adminFee,
+			TotalInterest:          totalInterest,
+			TotalInstallmentAmount: principal.Add(totalInterest),
+			Status:                 status,
+			TransactionDate:        transactionDate,
+		})
+	}
+
+	return transactions
+}
+
+func pickTransactionStatus(faker *gofakeit.Faker) model.TransactionStatus {
+	switch n := faker.IntRange(1, 100); {
+	case n <= 45:
+		return model.TransactionActive
+	case n <= 75:
+		return model.TransactionPaidOff
+	case n <= 85:
+		return model.TransactionCancelled
+	case n <= 93:
+		return model.TransactionPending
+	default:
+		return model.TransactionApproved
+	}
+}
+
+func roundTo(value decimal.Decimal, unit int64) decimal.Decimal {
+	step := decimal.NewFromInt(unit)
+	return value.Div(step).Round(0).Mul(step)
+}
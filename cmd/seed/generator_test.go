@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTenors = []model.Tenor{
+		{ID: 1, DurationMonths: 1},
+		{ID: 2, DurationMonths: 2},
+		{ID: 3, DurationMonths: 3},
+		{ID: 4, DurationMonths: 6},
+	}
+	testAsOf = time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+)
+
+type dataset struct {
+	Customers    []model.Customer
+	Limits       []model.CustomerLimit
+	Transactions []model.Transaction
+}
+
+// generate runs the generators the way Run does, with IDs assigned as the
+// database would.
+func generate(seed uint64, customers, maxTransactions int) dataset {
+	faker := gofakeit.New(seed)
+	var data dataset
+
+	data.Customers = generateCustomers(faker, customers, "hashed", map[string]struct{}{})
+	for i := range data.Customers {
+		data.Customers[i].ID = uint64(i + 1)
+	}
+
+	for _, customer := range data.Customers {
+		if customer.VerificationStatus != model.VerificationVerified {
+			continue
+		}
+		limits := generateLimits(customer, testTenors)
+		data.Limits = append(data.Limits, limits...)
+		data.Transactions = append(data.Transactions, generateTransactions(faker, customer, limits, testTenors, maxTransactions, testAsOf)...)
+	}
+	return data
+}
+
+func TestGenerate_SameSeedSameData(t *testing.T) {
+	first := generate(42, 50, 8)
+	second := generate(42, 50, 8)
+
+	require.NotEmpty(t, first.Transactions)
+	assert.Equal(t, first, second)
+}
+
+func TestGenerate_DifferentSeedDifferentData(t *testing.T) {
+	assert.NotEqual(t, generate(42, 50, 8).Customers, generate(43, 50, 8).Customers)
+}
+
+func TestGenerateCustomers(t *testing.T) {
+	nikFormat := regexp.MustCompile(`^\d{16}$`)
+	used := map[string]struct{}{}
+	customers := generateCustomers(gofakeit.New(7), 200, "hashed", used)
+
+	require.Len(t, customers, 200)
+	assert.Len(t, used, 200)
+
+	statuses := map[model.VerificationStatus]int{}
+	for _, c := range customers {
+		assert.Regexp(t, nikFormat, c.NIK)
+		// Digit 7-12 adalah tanggal lahir DDMMYY
+		assert.Equal(t, c.BirthDate.Format("020106"), c.NIK[6:12])
+		assert.True(t, c.Salary.Mod(decimal.NewFromInt(100_000)).IsZero(), "salary %s", c.Salary)
+		assert.Equal(t, "hashed", c.Password)
+		statuses[c.VerificationStatus]++
+	}
+	assert.Positive(t, statuses[model.VerificationVerified])
+	assert.Positive(t, statuses[model.VerificationPending])
+}
+
+func TestGenerateCustomers_SkipsExistingNIKs(t *testing.T) {
+	// Seed yang sama menghasilkan NIK yang sama, jadi semuanya sudah terpakai
+	existing := map[string]struct{}{}
+	for _, c := range generateCustomers(gofakeit.New(7), 20, "hashed", map[string]struct{}{}) {
+		existing[c.NIK] = struct{}{}
+	}
+
+	used := map[string]struct{}{}
+	for nik := range existing {
+		used[nik] = struct{}{}
+	}
+	customers := generateCustomers(gofakeit.New(7), 20, "hashed", used)
+
+	require.Len(t, customers, 20)
+	for _, c := range customers {
+		assert.NotContains(t, existing, c.NIK)
+	}
+}
+
+func TestGenerateLimits(t *testing.T) {
+	customer := model.Customer{ID: 9, Salary: decimal.NewFromInt(10_000_000)}
+	limits := generateLimits(customer, testTenors)
+
+	require.Len(t, limits, len(testTenors))
+	want := []int64{7_500_000, 10_000_000, 12_500_000, 20_000_000}
+	for i, limit := range limits {
+		assert.Equal(t, uint64(9), limit.CustomerID)
+		assert.Equal(t, testTenors[i].ID, limit.TenorID)
+		assert.True(t, decimal.NewFromInt(want[i]).Equal(limit.LimitAmount), "tenor %d: %s", testTenors[i].DurationMonths, limit.LimitAmount)
+	}
+}
+
+func TestGenerateTransactions_ActivePrincipalWithinLimit(t *testing.T) {
+	var active int
+	for seed := uint64(1); seed <= 50; seed++ {
+		data := generate(seed, 20, 30)
+
+		limits := map[string]decimal.Decimal{}
+		for _, l := range data.Limits {
+			limits[fmt.Sprintf("%d/%d", l.CustomerID, l.TenorID)] = l.LimitAmount
+		}
+
+		used := map[string]decimal.Decimal{}
+		for _, tx := range data.Transactions {
+			principal := tx.OTRAmount.Add(tx.AdminFee)
+			assert.True(t, principal.Add(tx.TotalInterest).Equal(tx.TotalInstallmentAmount))
+			assert.False(t, tx.TransactionDate.After(testAsOf))
+			assert.False(t, tx.TransactionDate.Before(testAsOf.AddDate(-2, 0, 0)))
+
+			if tx.Status != model.TransactionActive {
+				continue
+			}
+			active++
+			key := fmt.Sprintf("%d/%d", tx.CustomerID, tx.TenorID)
+			used[key] = used[key].Add(principal)
+		}
+
+		for key, total := range used {
+			assert.True(t, total.LessThanOrEqual(limits[key]),
+				"seed %d, customer/tenor %s: active principal %s exceeds limit %s", seed, key, total, limits[key])
+		}
+	}
+	require.Positive(t, active)
+}
+
+func TestGenerateTransactions_NoneWithoutBudget(t *testing.T) {
+	customer := model.Customer{ID: 1, Salary: decimal.NewFromInt(10_000_000)}
+	limits := generateLimits(customer, testTenors)
+
+	assert.Empty(t, generateTransactions(gofakeit.New(1), customer, limits, testTenors, 0, testAsOf))
+	assert.Empty(t, generateTransactions(gofakeit.New(1), customer, nil, testTenors, 5, testAsOf))
+}
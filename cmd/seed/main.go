@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/config"
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/pkg/refdata"
+	"github.com/joho/godotenv"
+)
+
+// Seed populates QA/demo databases with generated customers, limits and
+// transaction histories. The same -seed value always produces the same data.
+//
+//	go run ./cmd/seed -customers 200 -max-transactions 8 -seed 42 -as-of 2025-06-30
+//
+// Pass -nik-file to export verified NIKs for the k6 scenarios in loadtest/.
+func main() {
+	opts := Options{}
+	flag.IntVar(&opts.Customers, "customers", 50, "number of customers to generate")
+	flag.IntVar(&opts.MaxTransactions, "max-transactions", 5, "maximum transactions per verified customer")
+	flag.Uint64Var(&opts.Seed, "seed", uint64(time.Now().UnixNano()), "random seed, reuse it to reproduce a dataset")
+	flag.StringVar(&opts.Password, "password", "password123", "plain text password shared by generated customers")
+	flag.IntVar(&opts.BatchSize, "batch-size", 100, "rows per insert batch")
+	nikFile := flag.String("nik-file", "", "write NIKs of verified customers as a JSON array to this path")
+	asOf := flag.String("as-of", time.Now().Format("2006-01-02"), "latest transaction date (YYYY-MM-DD)")
+	referenceDir := flag.String("reference-dir", "reference", "directory with the reference data, see pkg/refdata")
+	flag.Parse()
+
+	parsed, err := time.Parse("2006-01-02", *asOf)
+	if err != nil {
+		slog.Error("Invalid -as-of date", "value", *asOf, "error", err)
+		os.Exit(1)
+	}
+	opts.AsOf = parsed
+
+	referenceData, err := refdata.Load(*referenceDir)
+	if err != nil {
+		slog.Error("Invalid reference data", "dir", *referenceDir, "error", err)
+		os.Exit(1)
+	}
+	for _, t := range referenceData.Tenors {
+		opts.Tenors = append(opts.Tenors, model.Tenor{
+			DurationMonths:  t.DurationMonths,
+			Description:     t.Description,
+			Active:          true,
+			MinAmount:       t.MinAmount,
+			MaxAmount:       t.MaxAmount,
+			MinAge:          t.MinAge,
+			MaxAge:          t.MaxAge,
+			MaxAgeAtEnd:     t.MaxAgeAtEnd,
+			AssetCategories: t.AssetCategories,
+		})
+	}
+
+	if err = godotenv.Load(); err != nil {
+		slog.Warn("No .env file found, using system environment variables", "error", err)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+
+	db, err := mysqldb.InitializeDatabase(cfg)
+	if err != nil {
+		slog.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+
+	if err := model.AutoMigrate(db); err != nil {
+		slog.Error("Failed to migrate database", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Seeding demo data...",
+		"customers", opts.Customers,
+		"max_transactions", opts.MaxTransactions,
+		"seed", opts.Seed,
+	)
+
+	summary, err := Run(db, opts)
+	if err != nil {
+		slog.Error("Failed to seed demo data", "error", err)
+		os.Exit(1)
+	}
+
+	if *nikFile != "" {
+		if err := writeNIKFile(*nikFile, summary.VerifiedNIKs); err != nil {
+			slog.Error("Failed to write NIK file", "path", *nikFile, "error", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("Seeded %d customers, %d limits and %d transactions (seed=%d)\n",
+		summary.Customers, summary.Limits, summary.Transactions, opts.Seed)
+}
+
+func writeNIKFile(path string, niks []string) error {
+	data, err := json.MarshalIndent(niks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -1,35 +1,197 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	SERVICE_NAME                string
-	SERVICE_VERSION             string
-	ENVIRONMENT                 string
-	OTEL_EXPORTER_OTLP_ENDPOINT string
-	OTEL_RESOURCE_ATTRIBUTES    string
-	LOG_LEVEL                   string
-	METRIC_INTERVAL             time.Duration
-	RUNTIME_METRICS             bool
-	REQUESTS_METRIC             bool
-	DEVELOPMENT_MODE            bool
-	SERVER_PORT                 string
-	CLOUDINARY_CLOUD            string
-	CLOUDINARY_API_KEY          string
-	CLOUDINARY_API_SECRET       string
-	MYSQL_HOST                  string
-	MYSQL_PORT                  string
-	MYSQL_USER                  string
-	MYSQL_PASSWORD              string
-	MYSQL_DBNAME                string
-	REDIS_ADDRESS               string
-	REDIS_PASSWORD              string
-	JWT_SECRET_KEY              string
-	SHUTDOWN_TIMEOUT            time.Duration
+	SERVICE_NAME                  string
+	SERVICE_VERSION               string
+	ENVIRONMENT                   string
+	OTEL_EXPORTER_OTLP_ENDPOINT   string
+	OTEL_RESOURCE_ATTRIBUTES      string
+	LOG_LEVEL                     string
+	LOG_LEVELS                    string
+	LOG_REDACT_FIELDS             string
+	LOG_SAMPLING_TICK             time.Duration
+	LOG_SAMPLING_INITIAL          int
+	LOG_SAMPLING_THEREAFTER       int
+	LOG_STDOUT                    bool
+	LOG_OTLP                      bool
+	LOG_OTLP_LEVEL                string
+	SLO_PARTNER_AVAILABILITY      float64
+	SLO_PARTNER_LATENCY           time.Duration
+	SLO_PARTNER_LATENCY_TARGET    float64
+	REQUEST_TIMEOUT_CHECK_LIMIT   time.Duration
+	REQUEST_TIMEOUT_TRANSACTION   time.Duration
+	REQUEST_TIMEOUT_REGISTRATION  time.Duration
+	METRIC_INTERVAL               time.Duration
+	RUNTIME_METRICS               bool
+	REQUESTS_METRIC               bool
+	DEVELOPMENT_MODE              bool
+	SERVER_PORT                   string
+	CLOUDINARY_CLOUD              string
+	CLOUDINARY_API_KEY            string
+	CLOUDINARY_API_SECRET         string
+	MYSQL_HOST                    string
+	MYSQL_PORT                    int
+	MYSQL_USER                    string
+	MYSQL_PASSWORD                string
+	MYSQL_DBNAME                  string
+	MYSQL_CHARSET                 string
+	MYSQL_PARSE_TIME              bool
+	MYSQL_LOC                     string
+	MYSQL_MAX_OPEN_CONNS          int
+	MYSQL_MAX_IDLE_CONNS          int
+	MYSQL_CONN_MAX_LIFETIME       time.Duration
+	MYSQL_QUERY_COMMENTS          bool
+	MYSQL_SLOW_QUERY_THRESHOLD    time.Duration
+	MYSQL_QUERY_TIMEOUT           time.Duration
+	REDIS_ADDRESS                 string
+	REDIS_PASSWORD                string
+	JWT_SECRET_KEY                string
+	SHUTDOWN_TIMEOUT              time.Duration
+	BOOTSTRAP_LOCK_TIMEOUT        time.Duration
+	ADMIN_PASSWORD                string
+	WEBHOOK_MAX_ATTEMPTS          int
+	WEBHOOK_MAX_REPLAYS           int
+	WEBHOOK_BACKOFF               time.Duration
+	WEBHOOK_TIMEOUT               time.Duration
+	HTTP_CLIENT_TIMEOUT           time.Duration
+	HTTP_CLIENT_MAX_RETRIES       int
+	HTTP_CLIENT_BACKOFF           time.Duration
+	HTTP_CLIENT_MAX_IDLE_CONNS    int
+	LIMIT_RECOMMENDATION_RATIO    float64
+	LIMIT_RECOMMENDATION_ROUNDING float64
+	LIMIT_RECOMMENDATION_TIERS    string
+	PENDING_REMIND_AFTER_DAYS     int
+	PENDING_REJECT_AFTER_DAYS     int
+	PENDING_EXPIRY_INTERVAL       time.Duration
+	PENDING_EXPIRY_BATCH          int
+	AGING_SNAPSHOT_INTERVAL       time.Duration
+	FEATURE_FLAG_CACHE_TTL        time.Duration
+	DUE_DATE_CACHE_TTL            time.Duration
+	TENOR_CACHE_TTL               time.Duration
+	CACHE_REMOTE_TTL              time.Duration
+	CACHE_RESUBSCRIBE_INTERVAL    time.Duration
+	PAYMENT_MIDTRANS_SERVER_KEY   string
+	PAYMENT_XENDIT_CALLBACK_KEY   string
+	VIRTUAL_ACCOUNT_PROVIDER      string
+	VIRTUAL_ACCOUNT_BANK          string
+	VIRTUAL_ACCOUNT_PREFIX        string
+	VIRTUAL_ACCOUNT_INTERVAL      time.Duration
+	VIRTUAL_ACCOUNT_BATCH         int
+	DIRECT_DEBIT_INTERVAL         time.Duration
+	DIRECT_DEBIT_MAX_ATTEMPTS     int
+	DIRECT_DEBIT_RETRY_AFTER      time.Duration
+	DIRECT_DEBIT_BATCH            int
+	REFERRAL_IP_WINDOW            time.Duration
+	REFERRAL_MAX_PER_IP           int
+	OTP_TTL                       time.Duration
+	OTP_VERIFICATION_TTL          time.Duration
+	OTP_MAX_ATTEMPTS              int
+	OTP_MAX_REQUESTS              int
+	OTP_REQUEST_WINDOW            time.Duration
+	IMPERSONATION_TTL             time.Duration
+	PARTNER_SIGNATURE_TOLERANCE   time.Duration
+	MAINTENANCE_MODE              bool
+	BUSINESS_TIMEZONE             string
+	PARTNER_TRANSACTION_HOURS     string
+	MAX_AGE_AT_TENOR_END          int
+	DSR_MAX_RATIOS                string
+	PARTNER_QUOTA_ALERT_PERCENT   int
+	TRANSACTION_BATCH_MAX_ITEMS   int
+	TRANSACTION_BATCH_INTERVAL    time.Duration
+	TRANSACTION_BATCH_SIZE        int
+	CONTRACT_IMPORT_MAX_ITEMS     int
+	CONTRACT_IMPORT_BATCH_SIZE    int
+	TRANSACTION_PARTITIONING      bool
+	TRANSACTION_PARTITIONS_AHEAD  int
+	PARTITION_INTERVAL            time.Duration
+	STATEMENT_COMPANY_NAME        string
+	STATEMENT_COMPANY_ADDRESS     string
+	STATEMENT_COMPANY_CONTACT     string
+	STATEMENT_BRAND_COLOR         string
+	STATEMENT_SYNC_INSTALLMENTS   int
+	STATEMENT_FOLDER              string
+	STATEMENT_INTERVAL            time.Duration
+	STATEMENT_BATCH_SIZE          int
+	MONTHLY_STATEMENT_INTERVAL    time.Duration
+	MONTHLY_STATEMENT_BATCH       int
+	EMAIL_PROVIDER                string
+	EMAIL_FROM                    string
+	EMAIL_SMTP_HOST               string
+	EMAIL_SMTP_PORT               int
+	EMAIL_SMTP_USERNAME           string
+	EMAIL_SMTP_PASSWORD           string
+	EMAIL_SES_REGION              string
+	EMAIL_SES_ACCESS_KEY_ID       string
+	EMAIL_SES_SECRET_ACCESS_KEY   string
+	EMAIL_DISPATCH_INTERVAL       time.Duration
+	EMAIL_DISPATCH_BATCH          int
+	EMAIL_MAX_ATTEMPTS            int
+	EMAIL_RETRY_BACKOFF           time.Duration
+	PUSH_PROVIDER                 string
+	PUSH_FCM_CREDENTIALS_FILE     string
+	PAYMENT_REMINDER_DAYS_BEFORE  int
+	PAYMENT_REMINDER_INTERVAL     time.Duration
+	PAYMENT_REMINDER_BATCH        int
+	ATTACHMENT_FOLDER             string
+	INCOME_STATEMENT_FOLDER       string
+	INCOME_STATEMENT_MAX_SIZE     int
+	INCOME_DISCREPANCY_THRESHOLD  float64
+	EXPOSURE_RECONCILE_INTERVAL   time.Duration
+	RESTRICTION_EXPIRY_INTERVAL   time.Duration
+	DORMANCY_INACTIVE_MONTHS      int
+	DORMANCY_INTERVAL             time.Duration
+	DORMANCY_BATCH                int
+	RISK_TIER_INTERVAL            time.Duration
+	RISK_TIER_BATCH               int
+	ACCOUNT_CLOSURE_COOLING_OFF   time.Duration
+	ACCOUNT_CLOSURE_INTERVAL      time.Duration
+	ACCOUNT_CLOSURE_BATCH         int
+	CALENDAR_REMINDER             time.Duration
+	AML_LARGE_AMOUNT              int
+	AML_RAPID_COUNT               int
+	AML_RAPID_WINDOW              time.Duration
+	AML_SCREENING_INTERVAL        time.Duration
+	AML_SCREENING_BATCH           int
+	COLLECTION_MIN_DAYS_PAST_DUE  int
+	COLLECTION_INTERVAL           time.Duration
+	COLLECTION_BATCH              int
+	WRITE_OFF_MIN_DAYS_PAST_DUE   int
+	CONCENTRATION_MIN_PORTFOLIO   int
+	CONCENTRATION_INTERVAL        time.Duration
+	DASHBOARD_REFRESH_INTERVAL    time.Duration
+	DASHBOARD_HEARTBEAT           time.Duration
+	TENANTS_FILE                  string
+	REFERENCE_DATA_DIR            string
+	PARTNER_DEBUG_RETENTION       time.Duration
+	PARTNER_DEBUG_CAP             int
+	PARTNER_DEBUG_MAX_BODY        int
+	PARTNER_DEBUG_PURGE_INTERVAL  time.Duration
+	MEDIA_CLEANUP_INTERVAL        time.Duration
+	MEDIA_CLEANUP_GRACE_PERIOD    time.Duration
+	MEDIA_CLEANUP_BATCH           int
+	ATTACHMENT_MAX_SIZE           int
+	API_V1_DEPRECATED_AT          time.Time
+	API_V1_SUNSET_AT              time.Time
+	BODY_LIMIT_JSON               int
+	BODY_LIMIT_UPLOAD             int
+	JSON_MAX_DEPTH                int
+	CORS_ALLOW_ORIGINS            string
+	CORS_ALLOW_CREDENTIALS        bool
+	HSTS_MAX_AGE                  int
+	HSTS_PRELOAD                  bool
+	TLS_CERT_FILE                 string
+	TLS_KEY_FILE                  string
+	TLS_CLIENT_CA_FILE            string
+	RATE_LIMIT_MAX                int
 }
 
 func LoadConfig() (*Config, error) {
@@ -41,12 +203,22 @@ func LoadConfig() (*Config, error) {
 		return defaultValue
 	}
 
+	// Nilai yang tidak bisa di-parse dikumpulkan agar start-up gagal,
+	// bukan diam-diam kembali ke default
+	var errs []error
+	invalid := func(key, kind, value string) {
+		errs = append(errs, fmt.Errorf("%s: invalid %s %q", key, kind, value))
+	}
+
 	// Helper function to parse Duration from environment variable
 	Duration := func(key string, defaultValue time.Duration) time.Duration {
 		if value := os.Getenv(key); value != "" {
-			if duration, err := time.ParseDuration(value); err == nil {
-				return duration
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				invalid(key, "duration", value)
+				return defaultValue
 			}
+			return duration
 		}
 		return defaultValue
 	}
@@ -54,38 +226,282 @@ func LoadConfig() (*Config, error) {
 	// Helper function to parse boolean from environment variable
 	Bool := func(key string, defaultValue bool) bool {
 		if value := os.Getenv(key); value != "" {
-			if boolValue, err := strconv.ParseBool(value); err == nil {
-				return boolValue
+			boolValue, err := strconv.ParseBool(value)
+			if err != nil {
+				invalid(key, "boolean", value)
+				return defaultValue
 			}
+			return boolValue
 		}
 		return defaultValue
 	}
 
+	// Helper function to parse int from environment variable
+	Int := func(key string, defaultValue int) int {
+		if value := os.Getenv(key); value != "" {
+			intValue, err := strconv.Atoi(value)
+			if err != nil {
+				invalid(key, "integer", value)
+				return defaultValue
+			}
+			return intValue
+		}
+		return defaultValue
+	}
+
+	// Helper function to parse float from environment variable
+	Float := func(key string, defaultValue float64) float64 {
+		if value := os.Getenv(key); value != "" {
+			floatValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				invalid(key, "number", value)
+				return defaultValue
+			}
+			return floatValue
+		}
+		return defaultValue
+	}
+
+	// Helper function to parse a YYYY-MM-DD date from environment variable
+	Date := func(key string) time.Time {
+		if value := os.Getenv(key); value != "" {
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				invalid(key, "date", value)
+				return time.Time{}
+			}
+			return date
+		}
+		return time.Time{}
+	}
+
 	config := &Config{
-		SERVICE_NAME:                Env("SERVICE_NAME", "multifinance"),
-		SERVICE_VERSION:             Env("SERVICE_VERSION", "1.0.0"),
-		ENVIRONMENT:                 Env("ENVIRONMENT", "production"),
-		OTEL_EXPORTER_OTLP_ENDPOINT: Env("OTEL_EXPORTER_OTLP_ENDPOINT", "0.0.0.0:4317"),
-		OTEL_RESOURCE_ATTRIBUTES:    Env("OTEL_RESOURCE_ATTRIBUTES", "service.name=multifinance,service.namespace=multifinance-group,deployment.environment=production"),
-		LOG_LEVEL:                   Env("LOG_LEVEL", "info"),
-		METRIC_INTERVAL:             Duration("METRIC_INTERVAL", 15*time.Second),
-		RUNTIME_METRICS:             Bool("RUNTIME_METRICS", true),
-		REQUESTS_METRIC:             Bool("REQUESTS_METRIC", true),
-		DEVELOPMENT_MODE:            Bool("DEVELOPMENT_MODE", false),
-		SERVER_PORT:                 Env("SERVER_PORT", "3001"),
-		CLOUDINARY_CLOUD:            Env("CLOUDINARY_CLOUD", ""),
-		CLOUDINARY_API_KEY:          Env("CLOUDINARY_API_KEY", ""),
-		CLOUDINARY_API_SECRET:       Env("CLOUDINARY_API_SECRET", ""),
-		MYSQL_HOST:                  Env("MYSQL_HOST", "127.0.0.1"),
-		MYSQL_PORT:                  Env("MYSQL_PORT", "3306"),
-		MYSQL_USER:                  Env("MYSQL_USER", "root"),
-		MYSQL_PASSWORD:              Env("MYSQL_PASSWORD", ""),
-		MYSQL_DBNAME:                Env("MYSQL_DBNAME", "loan_system"),
-		REDIS_ADDRESS:               Env("REDIS_ADDRESS", "localhost:6379"),
-		REDIS_PASSWORD:              Env("REDIS_PASSWORD", ""),
-		JWT_SECRET_KEY:              Env("JWT_SECRET_KEY", ""),
-		SHUTDOWN_TIMEOUT:            Duration("SHUTDOWN_TIMEOUT", 15*time.Second),
+		SERVICE_NAME:                  Env("SERVICE_NAME", "multifinance"),
+		SERVICE_VERSION:               Env("SERVICE_VERSION", "1.0.0"),
+		ENVIRONMENT:                   Env("ENVIRONMENT", "production"),
+		OTEL_EXPORTER_OTLP_ENDPOINT:   Env("OTEL_EXPORTER_OTLP_ENDPOINT", "0.0.0.0:4317"),
+		OTEL_RESOURCE_ATTRIBUTES:      Env("OTEL_RESOURCE_ATTRIBUTES", "service.name=multifinance,service.namespace=multifinance-group,deployment.environment=production"),
+		LOG_LEVEL:                     Env("LOG_LEVEL", "info"),
+		LOG_LEVELS:                    Env("LOG_LEVELS", ""),
+		LOG_REDACT_FIELDS:             Env("LOG_REDACT_FIELDS", "nik,password,birth_date"),
+		LOG_SAMPLING_TICK:             Duration("LOG_SAMPLING_TICK", time.Second),
+		LOG_SAMPLING_INITIAL:          Int("LOG_SAMPLING_INITIAL", 100),
+		LOG_SAMPLING_THEREAFTER:       Int("LOG_SAMPLING_THEREAFTER", 100),
+		LOG_STDOUT:                    Bool("LOG_STDOUT", true),
+		LOG_OTLP:                      Bool("LOG_OTLP", true),
+		LOG_OTLP_LEVEL:                Env("LOG_OTLP_LEVEL", "info"),
+		SLO_PARTNER_AVAILABILITY:      Float("SLO_PARTNER_AVAILABILITY", 0.995),
+		SLO_PARTNER_LATENCY:           Duration("SLO_PARTNER_LATENCY", 500*time.Millisecond),
+		SLO_PARTNER_LATENCY_TARGET:    Float("SLO_PARTNER_LATENCY_TARGET", 0.95),
+		REQUEST_TIMEOUT_CHECK_LIMIT:   Duration("REQUEST_TIMEOUT_CHECK_LIMIT", 2*time.Second),
+		REQUEST_TIMEOUT_TRANSACTION:   Duration("REQUEST_TIMEOUT_TRANSACTION", 5*time.Second),
+		REQUEST_TIMEOUT_REGISTRATION:  Duration("REQUEST_TIMEOUT_REGISTRATION", 10*time.Second),
+		METRIC_INTERVAL:               Duration("METRIC_INTERVAL", 15*time.Second),
+		RUNTIME_METRICS:               Bool("RUNTIME_METRICS", true),
+		REQUESTS_METRIC:               Bool("REQUESTS_METRIC", true),
+		DEVELOPMENT_MODE:              Bool("DEVELOPMENT_MODE", false),
+		SERVER_PORT:                   Env("SERVER_PORT", "3001"),
+		CLOUDINARY_CLOUD:              Env("CLOUDINARY_CLOUD", ""),
+		CLOUDINARY_API_KEY:            Env("CLOUDINARY_API_KEY", ""),
+		CLOUDINARY_API_SECRET:         Env("CLOUDINARY_API_SECRET", ""),
+		MYSQL_HOST:                    Env("MYSQL_HOST", "127.0.0.1"),
+		MYSQL_PORT:                    Int("MYSQL_PORT", 3306),
+		MYSQL_USER:                    Env("MYSQL_USER", "root"),
+		MYSQL_PASSWORD:                Env("MYSQL_PASSWORD", ""),
+		MYSQL_DBNAME:                  Env("MYSQL_DBNAME", "loan_system"),
+		MYSQL_CHARSET:                 Env("MYSQL_CHARSET", "utf8mb4"),
+		MYSQL_PARSE_TIME:              Bool("MYSQL_PARSE_TIME", true),
+		MYSQL_LOC:                     Env("MYSQL_LOC", "UTC"),
+		MYSQL_MAX_OPEN_CONNS:          Int("MYSQL_MAX_OPEN_CONNS", 100),
+		MYSQL_MAX_IDLE_CONNS:          Int("MYSQL_MAX_IDLE_CONNS", 10),
+		MYSQL_CONN_MAX_LIFETIME:       Duration("MYSQL_CONN_MAX_LIFETIME", time.Hour),
+		MYSQL_QUERY_COMMENTS:          Bool("MYSQL_QUERY_COMMENTS", false),
+		MYSQL_SLOW_QUERY_THRESHOLD:    Duration("MYSQL_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+		MYSQL_QUERY_TIMEOUT:           Duration("MYSQL_QUERY_TIMEOUT", 5*time.Second),
+		REDIS_ADDRESS:                 Env("REDIS_ADDRESS", "localhost:6379"),
+		REDIS_PASSWORD:                Env("REDIS_PASSWORD", ""),
+		JWT_SECRET_KEY:                Env("JWT_SECRET_KEY", ""),
+		SHUTDOWN_TIMEOUT:              Duration("SHUTDOWN_TIMEOUT", 15*time.Second),
+		BOOTSTRAP_LOCK_TIMEOUT:        Duration("BOOTSTRAP_LOCK_TIMEOUT", 2*time.Minute),
+		ADMIN_PASSWORD:                Env("ADMIN_PASSWORD", ""),
+		WEBHOOK_MAX_ATTEMPTS:          Int("WEBHOOK_MAX_ATTEMPTS", 3),
+		WEBHOOK_MAX_REPLAYS:           Int("WEBHOOK_MAX_REPLAYS", 5),
+		WEBHOOK_BACKOFF:               Duration("WEBHOOK_BACKOFF", 2*time.Second),
+		WEBHOOK_TIMEOUT:               Duration("WEBHOOK_TIMEOUT", 10*time.Second),
+		HTTP_CLIENT_TIMEOUT:           Duration("HTTP_CLIENT_TIMEOUT", 30*time.Second),
+		HTTP_CLIENT_MAX_RETRIES:       Int("HTTP_CLIENT_MAX_RETRIES", 2),
+		HTTP_CLIENT_BACKOFF:           Duration("HTTP_CLIENT_BACKOFF", 200*time.Millisecond),
+		HTTP_CLIENT_MAX_IDLE_CONNS:    Int("HTTP_CLIENT_MAX_IDLE_CONNS", 16),
+		LIMIT_RECOMMENDATION_RATIO:    Float("LIMIT_RECOMMENDATION_RATIO", 0.3),
+		LIMIT_RECOMMENDATION_ROUNDING: Float("LIMIT_RECOMMENDATION_ROUNDING", 100000),
+		LIMIT_RECOMMENDATION_TIERS:    Env("LIMIT_RECOMMENDATION_TIERS", "LOW:0:10000000,MEDIUM:8000000:50000000,HIGH:20000000:150000000"),
+		PENDING_REMIND_AFTER_DAYS:     Int("PENDING_REMIND_AFTER_DAYS", 7),
+		PENDING_REJECT_AFTER_DAYS:     Int("PENDING_REJECT_AFTER_DAYS", 30),
+		PENDING_EXPIRY_INTERVAL:       Duration("PENDING_EXPIRY_INTERVAL", time.Hour),
+		PENDING_EXPIRY_BATCH:          Int("PENDING_EXPIRY_BATCH", 100),
+		AGING_SNAPSHOT_INTERVAL:       Duration("AGING_SNAPSHOT_INTERVAL", 6*time.Hour),
+		FEATURE_FLAG_CACHE_TTL:        Duration("FEATURE_FLAG_CACHE_TTL", 30*time.Second),
+		DUE_DATE_CACHE_TTL:            Duration("DUE_DATE_CACHE_TTL", 5*time.Minute),
+		TENOR_CACHE_TTL:               Duration("TENOR_CACHE_TTL", 5*time.Minute),
+		CACHE_REMOTE_TTL:              Duration("CACHE_REMOTE_TTL", 10*time.Minute),
+		CACHE_RESUBSCRIBE_INTERVAL:    Duration("CACHE_RESUBSCRIBE_INTERVAL", 5*time.Second),
+		PAYMENT_MIDTRANS_SERVER_KEY:   Env("PAYMENT_MIDTRANS_SERVER_KEY", ""),
+		PAYMENT_XENDIT_CALLBACK_KEY:   Env("PAYMENT_XENDIT_CALLBACK_KEY", ""),
+		VIRTUAL_ACCOUNT_PROVIDER:      Env("VIRTUAL_ACCOUNT_PROVIDER", "stub"),
+		VIRTUAL_ACCOUNT_BANK:          Env("VIRTUAL_ACCOUNT_BANK", "BCA"),
+		VIRTUAL_ACCOUNT_PREFIX:        Env("VIRTUAL_ACCOUNT_PREFIX", "88088"),
+		VIRTUAL_ACCOUNT_INTERVAL:      Duration("VIRTUAL_ACCOUNT_INTERVAL", 5*time.Minute),
+		VIRTUAL_ACCOUNT_BATCH:         Int("VIRTUAL_ACCOUNT_BATCH", 100),
+		DIRECT_DEBIT_INTERVAL:         Duration("DIRECT_DEBIT_INTERVAL", time.Hour),
+		DIRECT_DEBIT_MAX_ATTEMPTS:     Int("DIRECT_DEBIT_MAX_ATTEMPTS", 3),
+		DIRECT_DEBIT_RETRY_AFTER:      Duration("DIRECT_DEBIT_RETRY_AFTER", 24*time.Hour),
+		DIRECT_DEBIT_BATCH:            Int("DIRECT_DEBIT_BATCH", 100),
+		REFERRAL_IP_WINDOW:            Duration("REFERRAL_IP_WINDOW", 24*time.Hour),
+		REFERRAL_MAX_PER_IP:           Int("REFERRAL_MAX_PER_IP", 3),
+		OTP_TTL:                       Duration("OTP_TTL", 5*time.Minute),
+		OTP_VERIFICATION_TTL:          Duration("OTP_VERIFICATION_TTL", 15*time.Minute),
+		OTP_MAX_ATTEMPTS:              Int("OTP_MAX_ATTEMPTS", 5),
+		OTP_MAX_REQUESTS:              Int("OTP_MAX_REQUESTS", 3),
+		OTP_REQUEST_WINDOW:            Duration("OTP_REQUEST_WINDOW", 15*time.Minute),
+		IMPERSONATION_TTL:             Duration("IMPERSONATION_TTL", 15*time.Minute),
+		PARTNER_SIGNATURE_TOLERANCE:   Duration("PARTNER_SIGNATURE_TOLERANCE", 5*time.Minute),
+		MAINTENANCE_MODE:              Bool("MAINTENANCE_MODE", false),
+		BUSINESS_TIMEZONE:             Env("BUSINESS_TIMEZONE", "Asia/Jakarta"),
+		PARTNER_TRANSACTION_HOURS:     Env("PARTNER_TRANSACTION_HOURS", ""),
+		MAX_AGE_AT_TENOR_END:          Int("MAX_AGE_AT_TENOR_END", 60),
+		DSR_MAX_RATIOS:                Env("DSR_MAX_RATIOS", ""),
+		PARTNER_QUOTA_ALERT_PERCENT:   Int("PARTNER_QUOTA_ALERT_PERCENT", 80),
+		TRANSACTION_BATCH_MAX_ITEMS:   Int("TRANSACTION_BATCH_MAX_ITEMS", 100),
+		TRANSACTION_BATCH_INTERVAL:    Duration("TRANSACTION_BATCH_INTERVAL", 10*time.Second),
+		TRANSACTION_BATCH_SIZE:        Int("TRANSACTION_BATCH_SIZE", 10),
+		CONTRACT_IMPORT_MAX_ITEMS:     Int("CONTRACT_IMPORT_MAX_ITEMS", 2000),
+		CONTRACT_IMPORT_BATCH_SIZE:    Int("CONTRACT_IMPORT_BATCH_SIZE", 500),
+		TRANSACTION_PARTITIONING:      Bool("TRANSACTION_PARTITIONING", false),
+		TRANSACTION_PARTITIONS_AHEAD:  Int("TRANSACTION_PARTITIONS_AHEAD", 3),
+		PARTITION_INTERVAL:            Duration("PARTITION_INTERVAL", 24*time.Hour),
+		STATEMENT_COMPANY_NAME:        Env("STATEMENT_COMPANY_NAME", "Multifinance"),
+		STATEMENT_COMPANY_ADDRESS:     Env("STATEMENT_COMPANY_ADDRESS", ""),
+		STATEMENT_COMPANY_CONTACT:     Env("STATEMENT_COMPANY_CONTACT", ""),
+		STATEMENT_BRAND_COLOR:         Env("STATEMENT_BRAND_COLOR", "#0B5FA5"),
+		STATEMENT_SYNC_INSTALLMENTS:   Int("STATEMENT_SYNC_INSTALLMENTS", 12),
+		STATEMENT_FOLDER:              Env("STATEMENT_FOLDER", "statements"),
+		STATEMENT_INTERVAL:            Duration("STATEMENT_INTERVAL", 30*time.Second),
+		STATEMENT_BATCH_SIZE:          Int("STATEMENT_BATCH_SIZE", 10),
+		MONTHLY_STATEMENT_INTERVAL:    Duration("MONTHLY_STATEMENT_INTERVAL", time.Hour),
+		MONTHLY_STATEMENT_BATCH:       Int("MONTHLY_STATEMENT_BATCH", 100),
+		EMAIL_PROVIDER:                Env("EMAIL_PROVIDER", "stub"),
+		EMAIL_FROM:                    Env("EMAIL_FROM", "Multifinance <no-reply@multifinance.local>"),
+		EMAIL_SMTP_HOST:               Env("EMAIL_SMTP_HOST", ""),
+		EMAIL_SMTP_PORT:               Int("EMAIL_SMTP_PORT", 587),
+		EMAIL_SMTP_USERNAME:           Env("EMAIL_SMTP_USERNAME", ""),
+		EMAIL_SMTP_PASSWORD:           Env("EMAIL_SMTP_PASSWORD", ""),
+		EMAIL_SES_REGION:              Env("EMAIL_SES_REGION", ""),
+		EMAIL_SES_ACCESS_KEY_ID:       Env("EMAIL_SES_ACCESS_KEY_ID", ""),
+		EMAIL_SES_SECRET_ACCESS_KEY:   Env("EMAIL_SES_SECRET_ACCESS_KEY", ""),
+		EMAIL_DISPATCH_INTERVAL:       Duration("EMAIL_DISPATCH_INTERVAL", 30*time.Second),
+		EMAIL_DISPATCH_BATCH:          Int("EMAIL_DISPATCH_BATCH", 100),
+		EMAIL_MAX_ATTEMPTS:            Int("EMAIL_MAX_ATTEMPTS", 5),
+		EMAIL_RETRY_BACKOFF:           Duration("EMAIL_RETRY_BACKOFF", time.Minute),
+		PUSH_PROVIDER:                 Env("PUSH_PROVIDER", "stub"),
+		PUSH_FCM_CREDENTIALS_FILE:     Env("PUSH_FCM_CREDENTIALS_FILE", ""),
+		PAYMENT_REMINDER_DAYS_BEFORE:  Int("PAYMENT_REMINDER_DAYS_BEFORE", 3),
+		PAYMENT_REMINDER_INTERVAL:     Duration("PAYMENT_REMINDER_INTERVAL", time.Hour),
+		PAYMENT_REMINDER_BATCH:        Int("PAYMENT_REMINDER_BATCH", 100),
+		ATTACHMENT_FOLDER:             Env("ATTACHMENT_FOLDER", "attachments"),
+		INCOME_STATEMENT_FOLDER:       Env("INCOME_STATEMENT_FOLDER", "bank-statements"),
+		INCOME_STATEMENT_MAX_SIZE:     Int("INCOME_STATEMENT_MAX_SIZE", 5*1024*1024),
+		INCOME_DISCREPANCY_THRESHOLD:  Float("INCOME_DISCREPANCY_THRESHOLD", 0.3),
+		ATTACHMENT_MAX_SIZE:           Int("ATTACHMENT_MAX_SIZE", 5*1024*1024),
+		EXPOSURE_RECONCILE_INTERVAL:   Duration("EXPOSURE_RECONCILE_INTERVAL", 6*time.Hour),
+		RESTRICTION_EXPIRY_INTERVAL:   Duration("RESTRICTION_EXPIRY_INTERVAL", 5*time.Minute),
+		DORMANCY_INACTIVE_MONTHS:      Int("DORMANCY_INACTIVE_MONTHS", 12),
+		DORMANCY_INTERVAL:             Duration("DORMANCY_INTERVAL", 24*time.Hour),
+		DORMANCY_BATCH:                Int("DORMANCY_BATCH", 100),
+		RISK_TIER_INTERVAL:            Duration("RISK_TIER_INTERVAL", 24*time.Hour),
+		RISK_TIER_BATCH:               Int("RISK_TIER_BATCH", 500),
+		ACCOUNT_CLOSURE_COOLING_OFF:   Duration("ACCOUNT_CLOSURE_COOLING_OFF", 30*24*time.Hour),
+		ACCOUNT_CLOSURE_INTERVAL:      Duration("ACCOUNT_CLOSURE_INTERVAL", time.Hour),
+		ACCOUNT_CLOSURE_BATCH:         Int("ACCOUNT_CLOSURE_BATCH", 100),
+		CALENDAR_REMINDER:             Duration("CALENDAR_REMINDER", 24*time.Hour),
+		AML_LARGE_AMOUNT:              Int("AML_LARGE_AMOUNT", 500_000_000),
+		AML_RAPID_COUNT:               Int("AML_RAPID_COUNT", 3),
+		AML_RAPID_WINDOW:              Duration("AML_RAPID_WINDOW", 24*time.Hour),
+		AML_SCREENING_INTERVAL:        Duration("AML_SCREENING_INTERVAL", 15*time.Minute),
+		AML_SCREENING_BATCH:           Int("AML_SCREENING_BATCH", 200),
+		COLLECTION_MIN_DAYS_PAST_DUE:  Int("COLLECTION_MIN_DAYS_PAST_DUE", 90),
+		COLLECTION_INTERVAL:           Duration("COLLECTION_INTERVAL", 24*time.Hour),
+		COLLECTION_BATCH:              Int("COLLECTION_BATCH", 500),
+		WRITE_OFF_MIN_DAYS_PAST_DUE:   Int("WRITE_OFF_MIN_DAYS_PAST_DUE", 180),
+		CONCENTRATION_MIN_PORTFOLIO:   Int("CONCENTRATION_MIN_PORTFOLIO", 1_000_000_000),
+		CONCENTRATION_INTERVAL:        Duration("CONCENTRATION_INTERVAL", time.Hour),
+		DASHBOARD_REFRESH_INTERVAL:    Duration("DASHBOARD_REFRESH_INTERVAL", 5*time.Second),
+		DASHBOARD_HEARTBEAT:           Duration("DASHBOARD_HEARTBEAT", 15*time.Second),
+		TENANTS_FILE:                  Env("TENANTS_FILE", ""),
+		REFERENCE_DATA_DIR:            Env("REFERENCE_DATA_DIR", "reference"),
+		PARTNER_DEBUG_RETENTION:       Duration("PARTNER_DEBUG_RETENTION", 24*time.Hour),
+		PARTNER_DEBUG_CAP:             Int("PARTNER_DEBUG_CAP", 500),
+		PARTNER_DEBUG_MAX_BODY:        Int("PARTNER_DEBUG_MAX_BODY", 16*1024),
+		PARTNER_DEBUG_PURGE_INTERVAL:  Duration("PARTNER_DEBUG_PURGE_INTERVAL", time.Hour),
+		MEDIA_CLEANUP_INTERVAL:        Duration("MEDIA_CLEANUP_INTERVAL", 6*time.Hour),
+		MEDIA_CLEANUP_GRACE_PERIOD:    Duration("MEDIA_CLEANUP_GRACE_PERIOD", 24*time.Hour),
+		MEDIA_CLEANUP_BATCH:           Int("MEDIA_CLEANUP_BATCH", 500),
+		API_V1_DEPRECATED_AT:          Date("API_V1_DEPRECATED_AT"),
+		API_V1_SUNSET_AT:              Date("API_V1_SUNSET_AT"),
+		BODY_LIMIT_JSON:               Int("BODY_LIMIT_JSON", 1024*1024),
+		BODY_LIMIT_UPLOAD:             Int("BODY_LIMIT_UPLOAD", 10*1024*1024),
+		JSON_MAX_DEPTH:                Int("JSON_MAX_DEPTH", 32),
+		CORS_ALLOW_ORIGINS:            Env("CORS_ALLOW_ORIGINS", ""),
+		CORS_ALLOW_CREDENTIALS:        Bool("CORS_ALLOW_CREDENTIALS", false),
+		HSTS_MAX_AGE:                  Int("HSTS_MAX_AGE", 31536000),
+		HSTS_PRELOAD:                  Bool("HSTS_PRELOAD", false),
+		TLS_CERT_FILE:                 Env("TLS_CERT_FILE", ""),
+		TLS_KEY_FILE:                  Env("TLS_KEY_FILE", ""),
+		TLS_CLIENT_CA_FILE:            Env("TLS_CLIENT_CA_FILE", ""),
+		RATE_LIMIT_MAX:                Int("RATE_LIMIT_MAX", 100),
 	}
 
+	// Frontend lokal hanya diizinkan otomatis di luar production
+	if config.CORS_ALLOW_ORIGINS == "" && config.ENVIRONMENT != "production" {
+		config.CORS_ALLOW_ORIGINS = "http://localhost:5000"
+	}
+
+	// Pool yang tidak masuk akal ditolak di sini, database/sql akan
+	// menerimanya tanpa keluhan
+	if config.MYSQL_MAX_OPEN_CONNS < 1 {
+		errs = append(errs, fmt.Errorf("MYSQL_MAX_OPEN_CONNS: must be at least 1, got %d", config.MYSQL_MAX_OPEN_CONNS))
+	}
+	if config.MYSQL_MAX_IDLE_CONNS < 0 || config.MYSQL_MAX_IDLE_CONNS > config.MYSQL_MAX_OPEN_CONNS {
+		errs = append(errs, fmt.Errorf("MYSQL_MAX_IDLE_CONNS: must be between 0 and MYSQL_MAX_OPEN_CONNS (%d), got %d", config.MYSQL_MAX_OPEN_CONNS, config.MYSQL_MAX_IDLE_CONNS))
+	}
+	if config.MYSQL_CONN_MAX_LIFETIME < 0 {
+		errs = append(errs, fmt.Errorf("MYSQL_CONN_MAX_LIFETIME: must not be negative, got %s", config.MYSQL_CONN_MAX_LIFETIME))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
+
+// LogRedactFields splits LOG_REDACT_FIELDS into the log field names that
+// are masked in every log output.
+func (c *Config) LogRedactFields() []string {
+	var fields []string
+	for _, field := range strings.Split(c.LOG_REDACT_FIELDS, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// CORSOrigins splits CORS_ALLOW_ORIGINS into its comma separated origins.
+func (c *Config) CORSOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(c.CORS_ALLOW_ORIGINS, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
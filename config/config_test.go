@@ -0,0 +1,118 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_PoolDefaults(t *testing.T) {
+	for _, key := range []string{"MYSQL_PORT", "MYSQL_MAX_OPEN_CONNS", "MYSQL_MAX_IDLE_CONNS", "MYSQL_CONN_MAX_LIFETIME", "MYSQL_PARSE_TIME"} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, 3306, cfg.MYSQL_PORT)
+	assert.Equal(t, 100, cfg.MYSQL_MAX_OPEN_CONNS)
+	assert.Equal(t, 10, cfg.MYSQL_MAX_IDLE_CONNS)
+	assert.Equal(t, time.Hour, cfg.MYSQL_CONN_MAX_LIFETIME)
+	assert.True(t, cfg.MYSQL_PARSE_TIME)
+}
+
+func TestLoadConfig_PoolOverrides(t *testing.T) {
+	t.Setenv("MYSQL_PORT", "3307")
+	t.Setenv("MYSQL_MAX_OPEN_CONNS", "40")
+	t.Setenv("MYSQL_MAX_IDLE_CONNS", "40")
+	t.Setenv("MYSQL_CONN_MAX_LIFETIME", "15m")
+	t.Setenv("MYSQL_PARSE_TIME", "false")
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, 3307, cfg.MYSQL_PORT)
+	assert.Equal(t, 40, cfg.MYSQL_MAX_OPEN_CONNS)
+	assert.Equal(t, 40, cfg.MYSQL_MAX_IDLE_CONNS)
+	assert.Equal(t, 15*time.Minute, cfg.MYSQL_CONN_MAX_LIFETIME)
+	assert.False(t, cfg.MYSQL_PARSE_TIME)
+}
+
+func TestLoadConfig_InvalidValues(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		want []string
+	}{
+		{
+			name: "Max Open Conns Not An Integer",
+			env:  map[string]string{"MYSQL_MAX_OPEN_CONNS": "lots"},
+			want: []string{`MYSQL_MAX_OPEN_CONNS: invalid integer "lots"`},
+		},
+		{
+			name: "Max Idle Conns Not An Integer",
+			env:  map[string]string{"MYSQL_MAX_IDLE_CONNS": "1.5"},
+			want: []string{`MYSQL_MAX_IDLE_CONNS: invalid integer "1.5"`},
+		},
+		{
+			name: "Lifetime Without Unit",
+			env:  map[string]string{"MYSQL_CONN_MAX_LIFETIME": "3600"},
+			want: []string{`MYSQL_CONN_MAX_LIFETIME: invalid duration "3600"`},
+		},
+		{
+			name: "Port Not An Integer",
+			env:  map[string]string{"MYSQL_PORT": "tcp"},
+			want: []string{`MYSQL_PORT: invalid integer "tcp"`},
+		},
+		{
+			name: "Parse Time Not A Boolean",
+			env:  map[string]string{"MYSQL_PARSE_TIME": "yes"},
+			want: []string{`MYSQL_PARSE_TIME: invalid boolean "yes"`},
+		},
+		{
+			name: "No Open Conns",
+			env:  map[string]string{"MYSQL_MAX_OPEN_CONNS": "0", "MYSQL_MAX_IDLE_CONNS": "0"},
+			want: []string{"MYSQL_MAX_OPEN_CONNS: must be at least 1, got 0"},
+		},
+		{
+			name: "More Idle Than Open",
+			env:  map[string]string{"MYSQL_MAX_OPEN_CONNS": "5", "MYSQL_MAX_IDLE_CONNS": "10"},
+			want: []string{"MYSQL_MAX_IDLE_CONNS: must be between 0 and MYSQL_MAX_OPEN_CONNS (5), got 10"},
+		},
+		{
+			name: "Negative Idle",
+			env:  map[string]string{"MYSQL_MAX_IDLE_CONNS": "-1"},
+			want: []string{"MYSQL_MAX_IDLE_CONNS: must be between 0 and MYSQL_MAX_OPEN_CONNS (100), got -1"},
+		},
+		{
+			name: "Negative Lifetime",
+			env:  map[string]string{"MYSQL_CONN_MAX_LIFETIME": "-1m"},
+			want: []string{"MYSQL_CONN_MAX_LIFETIME: must not be negative, got -1m0s"},
+		},
+		{
+			name: "Every Bad Value Reported",
+			env:  map[string]string{"MYSQL_MAX_OPEN_CONNS": "lots", "MYSQL_CONN_MAX_LIFETIME": "forever"},
+			want: []string{
+				`MYSQL_MAX_OPEN_CONNS: invalid integer "lots"`,
+				`MYSQL_CONN_MAX_LIFETIME: invalid duration "forever"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := LoadConfig()
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+			for _, want := range tc.want {
+				assert.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
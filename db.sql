@@ -1,5 +1,9 @@
 -- #####################################################################
 -- # Skrip SQL untuk Database PT XYZ Multifinance
+-- #
+-- # Skrip ini hanya memuat empat tabel awal dan tidak lagi diperbarui.
+-- # Skema lengkap dibuat aplikasi saat start-up lewat AutoMigrate dari
+-- # model di internal/model, jadi model itulah acuan skema yang berlaku.
 -- #####################################################################
 
 CREATE DATABASE IF NOT EXISTS loan_system;
@@ -23,7 +27,7 @@ CREATE TABLE `customers` (
   `legal_name` VARCHAR(255) NOT NULL,
   `birth_place` VARCHAR(100) NOT NULL,
   `birth_date` DATE NOT NULL,
-  `salary` DECIMAL(15,2) NOT NULL,
+  `salary` DECIMAL(18,2) NOT NULL,
   `password` VARCHAR(255) NOT NULL COMMENT 'Menyimpan password yang sudah di-hash (bcrypt)',
   `ktp_photo_url` VARCHAR(255) NOT NULL,
   `selfie_photo_url` VARCHAR(255) NOT NULL,
@@ -31,6 +35,8 @@ CREATE TABLE `customers` (
   `verification_status` ENUM('PENDING', 'VERIFIED', 'REJECTED') NOT NULL DEFAULT 'PENDING',
   `created_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `documents_submitted_at` TIMESTAMP NULL COMMENT 'Waktu unggah KTP/selfie terakhir, acuan kedaluwarsa registrasi PENDING',
+  `pending_reminder_sent_at` TIMESTAMP NULL,
   PRIMARY KEY (`id`),
   UNIQUE KEY `uq_customers_nik` (`nik`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -64,7 +70,7 @@ INSERT INTO `tenors` (`duration_months`, `description`) VALUES
 CREATE TABLE `customer_limits` (
   `customer_id` BIGINT UNSIGNED NOT NULL,
   `tenor_id` INT UNSIGNED NOT NULL,
-  `limit_amount` DECIMAL(15,2) NOT NULL,
+  `limit_amount` DECIMAL(18,2) NOT NULL,
   PRIMARY KEY (`customer_id`, `tenor_id`),
   CONSTRAINT `fk_customer_limits_customer`
     FOREIGN KEY (`customer_id`)
@@ -87,10 +93,10 @@ CREATE TABLE `transactions` (
   `customer_id` BIGINT UNSIGNED NOT NULL,
   `tenor_id` INT UNSIGNED NOT NULL,
   `asset_name` VARCHAR(255) NOT NULL,
-  `otr_amount` DECIMAL(15,2) NOT NULL COMMENT 'On The Road Price',
-  `admin_fee` DECIMAL(15,2) NOT NULL,
-  `total_interest` DECIMAL(15,2) NOT NULL,
-  `total_installment_amount` DECIMAL(15,2) NOT NULL COMMENT 'Total pinjaman pokok + bunga',
+  `otr_amount` DECIMAL(18,2) NOT NULL COMMENT 'On The Road Price',
+  `admin_fee` DECIMAL(18,2) NOT NULL,
+  `total_interest` DECIMAL(18,2) NOT NULL,
+  `total_installment_amount` DECIMAL(18,2) NOT NULL COMMENT 'Total pinjaman pokok + bunga',
   `status` ENUM('PENDING', 'APPROVED', 'ACTIVE', 'PAID_OFF', 'CANCELLED') NOT NULL DEFAULT 'PENDING',
   `transaction_date` TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
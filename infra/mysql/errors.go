@@ -0,0 +1,29 @@
+package mysqldb
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// erDupEntry is the MySQL error number of a unique key violation.
+const erDupEntry = 1062
+
+// DuplicateKey reports whether err is a unique key violation and returns
+// the name of the violated index, without the table prefix MySQL 8 adds.
+// Repositories use the name to tell which column clashed.
+func DuplicateKey(err error) (string, bool) {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != erDupEntry {
+		return "", false
+	}
+
+	// Pesan berbentuk "Duplicate entry '...' for key 'customers.idx_customers_nik'"
+	_, key, _ := strings.Cut(mysqlErr.Message, "for key '")
+	key = strings.TrimSuffix(key, "'")
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	return key, true
+}
@@ -0,0 +1,148 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeConnector stands in for a MySQL server so the plugins can run real
+// GORM callbacks: Exec reports one affected row, Query returns no rows
+// unless row is set, and a non-nil err fails every statement. Executed SQL
+// is kept in order.
+type fakeConnector struct {
+	mu         sync.Mutex
+	err        error
+	row        []driver.Value
+	statements []string
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+func (c *fakeConnector) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// respond makes every query return row as its single row.
+func (c *fakeConnector) respond(row ...driver.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.row = row
+}
+
+func (c *fakeConnector) record(query string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statements = append(c.statements, query)
+	return c.err
+}
+
+// executed returns the SQL received so far.
+func (c *fakeConnector) executed() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.statements...)
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake driver is opened through its connector")
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake driver does not prepare statements")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.connector.record(query); err != nil {
+		return nil, err
+	}
+	return fakeResult{}, nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.connector.record(query); err != nil {
+		return nil, err
+	}
+
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	if c.connector.row != nil {
+		return &fakeRows{columns: make([]string, len(c.connector.row)), values: [][]driver.Value{c.connector.row}}, nil
+	}
+	return &fakeRows{columns: []string{"id", "name"}}, nil
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 1, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// widget is the model the plugin tests read and write.
+type widget struct {
+	ID   uint64
+	Name string
+}
+
+// newFakeDB opens a GORM handle over a fakeConnector.
+func newFakeDB(t *testing.T) (*gorm.DB, *fakeConnector) {
+	t.Helper()
+
+	connector := &fakeConnector{}
+	sqlDB := sql.OpenDB(connector)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	require.NoError(t, err)
+	return db, connector
+}
@@ -0,0 +1,57 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrLockTimeout is returned by WithLock when another session kept the lock
+// for longer than the timeout.
+var ErrLockTimeout = errors.New("timed out waiting for MySQL named lock")
+
+// WithLock runs fn while holding the MySQL named lock name, so only one
+// instance at a time runs it. GET_LOCK belongs to a session, so the lock is
+// taken on a connection reserved for the whole call; fn itself keeps using
+// the pool. The lock is released when fn returns, or by MySQL when the
+// connection dies with the instance.
+func WithLock(ctx context.Context, db *gorm.DB, name string, timeout time.Duration, fn func(context.Context) error) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reserve lock connection: %w", err)
+	}
+	defer conn.Close()
+
+	// GET_LOCK menerima detik, pembulatan ke atas agar timeout pendek tidak jadi 0
+	seconds := int64(math.Ceil(timeout.Seconds()))
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, seconds).Scan(&acquired); err != nil {
+		return fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	if !acquired.Valid {
+		return fmt.Errorf("failed to acquire lock %s: GET_LOCK returned NULL", name)
+	}
+	if acquired.Int64 != 1 {
+		return fmt.Errorf("%w %s after %s", ErrLockTimeout, name, timeout)
+	}
+
+	defer func() {
+		// Context pemanggil bisa sudah dibatalkan, lock tetap harus dilepas
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		conn.ExecContext(releaseCtx, "SELECT RELEASE_LOCK(?)", name)
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,97 @@
+package mysqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	getLock     = "SELECT GET_LOCK(?, ?)"
+	releaseLock = "SELECT RELEASE_LOCK(?)"
+)
+
+func TestWithLock_Acquired(t *testing.T) {
+	db, connector := newFakeDB(t)
+	connector.respond(int64(1))
+
+	ran := false
+	err := WithLock(context.Background(), db, "multifinance.bootstrap", time.Second, func(context.Context) error {
+		ran = true
+		// Lock sudah dipegang sebelum fn berjalan dan belum dilepas
+		assert.Equal(t, []string{getLock}, connector.executed())
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.True(t, ran)
+	assert.Equal(t, []string{getLock, releaseLock}, connector.executed())
+}
+
+func TestWithLock_FailsClosed(t *testing.T) {
+	cases := []struct {
+		name    string
+		prepare func(connector *fakeConnector)
+		wantIs  error
+		want    string
+	}{
+		{
+			name:    "Held By Another Instance",
+			prepare: func(connector *fakeConnector) { connector.respond(int64(0)) },
+			wantIs:  ErrLockTimeout,
+			want:    "timed out waiting for MySQL named lock multifinance.bootstrap after 2m0s",
+		},
+		{
+			name:    "GET_LOCK Returned NULL",
+			prepare: func(connector *fakeConnector) { connector.respond(nil) },
+			want:    "failed to acquire lock multifinance.bootstrap: GET_LOCK returned NULL",
+		},
+		{
+			name:    "Server Unavailable",
+			prepare: func(connector *fakeConnector) { connector.fail(errors.New("connection refused")) },
+			want:    "failed to acquire lock multifinance.bootstrap: connection refused",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, connector := newFakeDB(t)
+			tc.prepare(connector)
+
+			ran := false
+			err := WithLock(context.Background(), db, "multifinance.bootstrap", 2*time.Minute, func(context.Context) error {
+				ran = true
+				return nil
+			})
+
+			assert.EqualError(t, err, tc.want)
+			if tc.wantIs != nil {
+				assert.ErrorIs(t, err, tc.wantIs)
+			}
+			// Tanpa lock tidak ada migrasi atau seed yang dijalankan
+			assert.False(t, ran)
+			assert.NotContains(t, connector.executed(), releaseLock)
+		})
+	}
+}
+
+func TestWithLock_ReleasedAfterFailure(t *testing.T) {
+	db, connector := newFakeDB(t)
+	connector.respond(int64(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	seedErr := errors.New("no admin user exists and ADMIN_PASSWORD is not set")
+
+	err := WithLock(ctx, db, "multifinance.bootstrap", time.Second, func(context.Context) error {
+		// Context pemanggil dibatalkan, lock tetap harus dilepas
+		cancel()
+		return seedErr
+	})
+
+	assert.ErrorIs(t, err, seedErr)
+	assert.Equal(t, []string{getLock, releaseLock}, connector.executed())
+}
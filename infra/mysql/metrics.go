@@ -0,0 +1,63 @@
+package mysqldb
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+	"gorm.io/gorm"
+)
+
+// RegisterPoolMetrics publishes the sql.DBStats of db as observable
+// instruments, sampled on every metric collection.
+func RegisterPoolMetrics(db *gorm.DB, meter metric.Meter) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	maxOpen, err := meter.Int64ObservableGauge("db.pool.max_open",
+		metric.WithDescription("Maximum number of open connections allowed"))
+	if err != nil {
+		return err
+	}
+	open, err := meter.Int64ObservableGauge("db.pool.open",
+		metric.WithDescription("Number of established connections, in use and idle"))
+	if err != nil {
+		return err
+	}
+	inUse, err := meter.Int64ObservableGauge("db.pool.in_use",
+		metric.WithDescription("Number of connections currently in use"))
+	if err != nil {
+		return err
+	}
+	idle, err := meter.Int64ObservableGauge("db.pool.idle",
+		metric.WithDescription("Number of idle connections"))
+	if err != nil {
+		return err
+	}
+	waitCount, err := meter.Int64ObservableCounter("db.pool.wait_count",
+		metric.WithDescription("Total number of connections waited for"))
+	if err != nil {
+		return err
+	}
+	waitDuration, err := meter.Float64ObservableCounter("db.pool.wait_duration",
+		metric.WithDescription("Total time blocked waiting for a new connection"),
+		metric.WithUnit("ms"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := sqlDB.Stats()
+		o.ObserveInt64(maxOpen, int64(stats.MaxOpenConnections))
+		o.ObserveInt64(open, int64(stats.OpenConnections))
+		o.ObserveInt64(inUse, int64(stats.InUse))
+		o.ObserveInt64(idle, int64(stats.Idle))
+		o.ObserveInt64(waitCount, stats.WaitCount)
+		o.ObserveFloat64(waitDuration, float64(stats.WaitDuration.Microseconds())/1000)
+		return nil
+	}, maxOpen, open, inUse, idle, waitCount, waitDuration)
+
+	return err
+}
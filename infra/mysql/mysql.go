@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strconv"
 	"time"
 
-	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/config"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -23,29 +22,28 @@ type DatabaseConfig struct {
 	Charset      string
 	ParseTime    bool
 	Loc          string
-}
 
-// LoadConfigFromEnv Loads database configuration from environment variables
-func LoadConfigFromEnv() *DatabaseConfig {
-	port, err := strconv.Atoi(common.GetEnv("MYSQL_PORT", "3306"))
-	if err != nil {
-		port = 3306
-	}
-
-	parseTime, err := strconv.ParseBool(common.GetEnv("MYSQL_PARSE_TIME", "true"))
-	if err != nil {
-		parseTime = true
-	}
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
 
+// NewDatabaseConfig builds the database configuration from the
+// application config, which has already validated the pool settings
+func NewDatabaseConfig(cfg *config.Config) *DatabaseConfig {
 	return &DatabaseConfig{
-		Host:         common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		Port:         port,
-		Username:     common.GetEnv("MYSQL_USER", "root"),
-		Password:     common.GetEnv("MYSQL_PASSWORD", ""),
-		DatabaseName: common.GetEnv("MYSQL_NAME", "loan_system"),
-		Charset:      common.GetEnv("MYSQL_CHARSET", "uft8mb4"),
-		ParseTime:    parseTime,
-		Loc:          common.GetEnv("MYSQL_LOC", "Local"),
+		Host:         cfg.MYSQL_HOST,
+		Port:         cfg.MYSQL_PORT,
+		Username:     cfg.MYSQL_USER,
+		Password:     cfg.MYSQL_PASSWORD,
+		DatabaseName: cfg.MYSQL_DBNAME,
+		Charset:      cfg.MYSQL_CHARSET,
+		ParseTime:    cfg.MYSQL_PARSE_TIME,
+		Loc:          cfg.MYSQL_LOC,
+
+		MaxOpenConns:    cfg.MYSQL_MAX_OPEN_CONNS,
+		MaxIdleConns:    cfg.MYSQL_MAX_IDLE_CONNS,
+		ConnMaxLifetime: cfg.MYSQL_CONN_MAX_LIFETIME,
 	}
 }
 
@@ -59,7 +57,11 @@ func CreateConfig(host, username, password, dbname string, port int) *DatabaseCo
 		DatabaseName: dbname,
 		Charset:      "utf8mb4",
 		ParseTime:    true,
-		Loc:          "Local",
+		Loc:          "UTC",
+
+		MaxOpenConns:    100,
+		MaxIdleConns:    10,
+		ConnMaxLifetime: time.Hour,
 	}
 }
 
@@ -87,7 +89,7 @@ func Connect(config *DatabaseConfig) (*gorm.DB, error) {
 			},
 		),
 		NowFunc: func() time.Time {
-			return time.Now().Local()
+			return time.Now().UTC()
 		},
 	}
 
@@ -104,9 +106,9 @@ func Connect(config *DatabaseConfig) (*gorm.DB, error) {
 	}
 
 	// Connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
 
 	return db, nil
 }
@@ -130,13 +132,21 @@ func ConnectWithRetry(config *DatabaseConfig, maxRetries int, retryDelay time.Du
 	return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", maxRetries, err)
 }
 
-// Close closes the database connection
+// Close closes the database connection, and those of the tenants when
+// TenantRouting is enabled
 func Close(db *gorm.DB, ctx context.Context) error {
 	sqlDB, err := db.WithContext(ctx).DB()
 	if err != nil {
 		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
+	if pool, ok := db.ConnPool.(*tenantPool); ok {
+		if err := pool.closeTenants(); err != nil {
+			sqlDB.Close()
+			return fmt.Errorf("failed to close tenant databases: %w", err)
+		}
+	}
+
 	return sqlDB.Close()
 }
 
@@ -150,30 +160,11 @@ func Ping(db *gorm.DB, ctx context.Context) error {
 	return sqlDB.Ping()
 }
 
-// GetStats returns database connection statistics
-func GetStats(db *gorm.DB) map[string]any {
-	sqlDB, err := db.DB()
-	if err != nil {
-		return map[string]any{
-			"error": err.Error(),
-		}
-	}
-
-	stats := sqlDB.Stats()
-	return map[string]any{
-		"max_open_connections": stats.MaxOpenConnections,
-		"open_connections":     stats.OpenConnections,
-		"in_use":               stats.InUse,
-		"idle":                 stats.Idle,
-	}
-}
-
 // Usage functions
 
-// InitializeDatabase initializes database connection with environment config
-func InitializeDatabase() (*gorm.DB, error) {
-	config := LoadConfigFromEnv()
-	return ConnectWithRetry(config, 5, time.Second*2)
+// InitializeDatabase initializes database connection with the application config
+func InitializeDatabase(cfg *config.Config) (*gorm.DB, error) {
+	return ConnectWithRetry(NewDatabaseConfig(cfg), 5, time.Second*2)
 }
 
 // InitializeDatabaseWithConfig initializes database with custom config
@@ -0,0 +1,30 @@
+package mysqldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDatabaseConfig(t *testing.T) {
+	dbConfig := NewDatabaseConfig(&config.Config{
+		MYSQL_HOST:              "db.internal",
+		MYSQL_PORT:              3307,
+		MYSQL_USER:              "app",
+		MYSQL_PASSWORD:          "secret",
+		MYSQL_DBNAME:            "loan_system",
+		MYSQL_CHARSET:           "utf8mb4",
+		MYSQL_PARSE_TIME:        true,
+		MYSQL_LOC:               "UTC",
+		MYSQL_MAX_OPEN_CONNS:    40,
+		MYSQL_MAX_IDLE_CONNS:    8,
+		MYSQL_CONN_MAX_LIFETIME: 15 * time.Minute,
+	})
+
+	assert.Equal(t, 40, dbConfig.MaxOpenConns)
+	assert.Equal(t, 8, dbConfig.MaxIdleConns)
+	assert.Equal(t, 15*time.Minute, dbConfig.ConnMaxLifetime)
+	assert.Equal(t, "app:secret@tcp(db.internal:3307)/loan_system?charset=utf8mb4&parseTime=true&loc=UTC", dbConfig.BuildDSN())
+}
@@ -0,0 +1,137 @@
+package mysqldb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxPartition catches rows past the last monthly partition, so an insert
+// never fails only because the maintenance job fell behind.
+const maxPartition = "pmax"
+
+// PartitionName returns the name of the monthly partition holding month,
+// such as p202610.
+func PartitionName(month time.Time) string {
+	return "p" + month.UTC().Format("200601")
+}
+
+// monthStart returns midnight UTC on the first day of t's month.
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Partitions lists the partitions of table in order, and nil when the table
+// is not partitioned.
+func Partitions(ctx context.Context, db *gorm.DB, table string) ([]string, error) {
+	var names []string
+	err := db.WithContext(ctx).Raw(
+		`SELECT PARTITION_NAME FROM information_schema.PARTITIONS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND PARTITION_NAME IS NOT NULL
+		ORDER BY PARTITION_ORDINAL_POSITION`, table,
+	).Scan(&names).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", table, err)
+	}
+	return names, nil
+}
+
+// monthlyPartitions renders one RANGE COLUMNS partition per month from
+// from through through, each holding the rows before the next month.
+func monthlyPartitions(from, through time.Time) []string {
+	var defs []string
+	for month := monthStart(from); !month.After(through); month = month.AddDate(0, 1, 0) {
+		defs = append(defs, fmt.Sprintf("PARTITION %s VALUES LESS THAN ('%s')",
+			PartitionName(month), month.AddDate(0, 1, 0).Format(time.DateOnly)))
+	}
+	return defs
+}
+
+// PartitionByMonth partitions table by RANGE COLUMNS on column, one partition
+// per month from from through through plus pmax for everything later. The
+// table must already satisfy MySQL's rules for partitioned tables: column is
+// part of every unique key and no foreign key points from or to it.
+func PartitionByMonth(ctx context.Context, db *gorm.DB, table, column string, from, through time.Time) error {
+	defs := append(monthlyPartitions(from, monthStart(through)),
+		fmt.Sprintf("PARTITION %s VALUES LESS THAN (MAXVALUE)", maxPartition))
+
+	statement := fmt.Sprintf("ALTER TABLE `%s` PARTITION BY RANGE COLUMNS(`%s`) (\n%s\n)",
+		table, column, strings.Join(defs, ",\n"))
+	if err := db.WithContext(ctx).Exec(statement).Error; err != nil {
+		return fmt.Errorf("failed to partition %s: %w", table, err)
+	}
+	return nil
+}
+
+// AddMonthlyPartitions splits pmax so that table has a partition for every
+// month up to and including through, and returns the partitions it added.
+// pmax is normally empty, so the split only touches metadata.
+func AddMonthlyPartitions(ctx context.Context, db *gorm.DB, table string, through time.Time) ([]string, error) {
+	partitions, err := Partitions(ctx, db, table)
+	if err != nil {
+		return nil, err
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("table %s is not partitioned", table)
+	}
+
+	// Bulan terakhir dibaca dari nama partisi, pYYYYMM sebelum pmax
+	var last time.Time
+	for _, name := range partitions {
+		if month, err := time.Parse("200601", strings.TrimPrefix(name, "p")); err == nil && month.After(last) {
+			last = month
+		}
+	}
+	if last.IsZero() || partitions[len(partitions)-1] != maxPartition {
+		return nil, fmt.Errorf("table %s is not partitioned by month", table)
+	}
+
+	defs := monthlyPartitions(last.AddDate(0, 1, 0), monthStart(through))
+	if len(defs) == 0 {
+		return nil, nil
+	}
+
+	added := make([]string, len(defs))
+	for i, def := range defs {
+		added[i] = strings.Fields(def)[1]
+	}
+	defs = append(defs, fmt.Sprintf("PARTITION %s VALUES LESS THAN (MAXVALUE)", maxPartition))
+
+	statement := fmt.Sprintf("ALTER TABLE `%s` REORGANIZE PARTITION %s INTO (\n%s\n)",
+		table, maxPartition, strings.Join(defs, ",\n"))
+	if err := db.WithContext(ctx).Exec(statement).Error; err != nil {
+		return nil, fmt.Errorf("failed to add partitions to %s: %w", table, err)
+	}
+	return added, nil
+}
+
+// DropForeignKeys drops every foreign key declared on table and every one
+// referencing it from other tables, since partitioned InnoDB tables support
+// neither.
+func DropForeignKeys(ctx context.Context, db *gorm.DB, table string) error {
+	var constraints []struct {
+		TableName      string
+		ConstraintName string
+	}
+	err := db.WithContext(ctx).Raw(
+		`SELECT TABLE_NAME AS table_name, CONSTRAINT_NAME AS constraint_name
+		FROM information_schema.REFERENTIAL_CONSTRAINTS
+		WHERE CONSTRAINT_SCHEMA = DATABASE() AND (TABLE_NAME = ? OR REFERENCED_TABLE_NAME = ?)`,
+		table, table,
+	).Scan(&constraints).Error
+	if err != nil {
+		return fmt.Errorf("failed to list foreign keys of %s: %w", table, err)
+	}
+
+	for _, c := range constraints {
+		statement := fmt.Sprintf("ALTER TABLE `%s` DROP FOREIGN KEY `%s`", c.TableName, c.ConstraintName)
+		if err := db.WithContext(ctx).Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to drop foreign key %s on %s: %w", c.ConstraintName, c.TableName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+type routeKey struct{}
+
+// WithRoute attaches the route that issued the request to ctx. The route is
+// resolved lazily because the matched route is only known once the router
+// reaches the handler.
+func WithRoute(ctx context.Context, route func() string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+// QueryComments is a GORM plugin that appends a sqlcommenter-style comment,
+// e.g. /*route='%2Fapi%2Fv1%2Fcustomers',traceparent='00-...-...-01'*/, to
+// every statement so slow query logs in MySQL can be matched to their trace.
+type QueryComments struct{}
+
+// Name implements gorm.Plugin.
+func (QueryComments) Name() string {
+	return "mysqldb:query_comments"
+}
+
+// Initialize implements gorm.Plugin.
+func (QueryComments) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	pool := &commentedDB{DB: sqlDB}
+	db.ConnPool = pool
+	db.Statement.ConnPool = pool
+	return nil
+}
+
+// EnableQueryComments registers QueryComments on db.
+func EnableQueryComments(db *gorm.DB) error {
+	return db.Use(QueryComments{})
+}
+
+// commentedDB membungkus *sql.DB, transaksi yang dibuka juga ikut dibungkus
+type commentedDB struct {
+	*sql.DB
+}
+
+func (p *commentedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.DB.PrepareContext(ctx, withQueryComment(ctx, query))
+}
+
+func (p *commentedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return p.DB.ExecContext(ctx, withQueryComment(ctx, query), args...)
+}
+
+func (p *commentedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.DB.QueryContext(ctx, withQueryComment(ctx, query), args...)
+}
+
+func (p *commentedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.DB.QueryRowContext(ctx, withQueryComment(ctx, query), args...)
+}
+
+func (p *commentedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &commentedTx{Tx: tx, db: p.DB}, nil
+}
+
+// GetDBConn implements gorm.GetDBConnector so db.DB() keeps working.
+func (p *commentedDB) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
+type commentedTx struct {
+	*sql.Tx
+	db *sql.DB
+}
+
+func (t *commentedTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.Tx.PrepareContext(ctx, withQueryComment(ctx, query))
+}
+
+func (t *commentedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.Tx.ExecContext(ctx, withQueryComment(ctx, query), args...)
+}
+
+func (t *commentedTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.Tx.QueryContext(ctx, withQueryComment(ctx, query), args...)
+}
+
+func (t *commentedTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.Tx.QueryRowContext(ctx, withQueryComment(ctx, query), args...)
+}
+
+// GetDBConn implements gorm.GetDBConnector so db.DB() keeps working.
+func (t *commentedTx) GetDBConn() (*sql.DB, error) {
+	return t.db, nil
+}
+
+// withQueryComment appends the tags found in ctx to query. Queries that
+// already carry a comment are left alone, as the sqlcommenter spec requires.
+func withQueryComment(ctx context.Context, query string) string {
+	if strings.Contains(query, "/*") || strings.Contains(query, "--") {
+		return query
+	}
+
+	tags := make(map[string]string, 2)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		tags["traceparent"] = "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-" + sc.TraceFlags().String()
+	}
+	if route, ok := ctx.Value(routeKey{}).(func() string); ok {
+		if r := route(); r != "" {
+			tags["route"] = r
+		}
+	}
+	if len(tags) == 0 {
+		return query
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.Grow(len(query) + 96)
+	b.WriteString(query)
+	b.WriteString(" /*")
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteString("='")
+		b.WriteString(strings.ReplaceAll(url.QueryEscape(tags[k]), "+", "%20"))
+		b.WriteByte('\'')
+	}
+	b.WriteString("*/")
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package mysqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+func newCommentedDB(t *testing.T) (*gorm.DB, *fakeConnector) {
+	t.Helper()
+
+	db, connector := newFakeDB(t)
+	require.NoError(t, EnableQueryComments(db))
+	return db, connector
+}
+
+// tracedContext returns a context carrying a sampled span and the route.
+func tracedContext(t *testing.T, route string) (context.Context, trace.SpanContext) {
+	t.Helper()
+
+	tracer := sdktrace.NewTracerProvider().Tracer("test-query-comments")
+	ctx, span := tracer.Start(context.Background(), "handler.GetCustomer")
+	t.Cleanup(func() { span.End() })
+
+	ctx = WithRoute(ctx, func() string { return route })
+	return ctx, span.SpanContext()
+}
+
+func traceparent(sc trace.SpanContext) string {
+	return "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+}
+
+func TestQueryComments_GeneratedSQL(t *testing.T) {
+	db, connector := newCommentedDB(t)
+	ctx, sc := tracedContext(t, "/api/v1/customers/:id")
+
+	var rows []widget
+	require.NoError(t, db.WithContext(ctx).Where("name = ?", "gear").Find(&rows).Error)
+	require.NoError(t, db.WithContext(ctx).Create(&widget{Name: "cog"}).Error)
+
+	comment := " /*route='%2Fapi%2Fv1%2Fcustomers%2F%3Aid',traceparent='" + traceparent(sc) + "'*/"
+	assert.Equal(t, []string{
+		"SELECT * FROM `widgets` WHERE name = ?" + comment,
+		"INSERT INTO `widgets` (`name`) VALUES (?)" + comment,
+	}, connector.executed())
+}
+
+func TestQueryComments_Transaction(t *testing.T) {
+	db, connector := newCommentedDB(t)
+	ctx, sc := tracedContext(t, "/api/v1/transactions")
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Model(&widget{}).Where("id = ?", 1).Update("name", "gear").Error
+	})
+	require.NoError(t, err)
+
+	executed := connector.executed()
+	require.Len(t, executed, 1)
+	assert.Equal(t,
+		"UPDATE `widgets` SET `name`=? WHERE id = ? /*route='%2Fapi%2Fv1%2Ftransactions',traceparent='"+traceparent(sc)+"'*/",
+		executed[0],
+	)
+}
+
+func TestQueryComments_RouteResolvedAtQueryTime(t *testing.T) {
+	db, connector := newCommentedDB(t)
+
+	// Route baru diketahui setelah router mencocokkan handler
+	route := ""
+	ctx := WithRoute(context.Background(), func() string { return route })
+
+	var rows []widget
+	require.NoError(t, db.WithContext(ctx).Find(&rows).Error)
+	route = "/api/v1/limits"
+	require.NoError(t, db.WithContext(ctx).Find(&rows).Error)
+
+	assert.Equal(t, []string{
+		"SELECT * FROM `widgets`",
+		"SELECT * FROM `widgets` /*route='%2Fapi%2Fv1%2Flimits'*/",
+	}, connector.executed())
+}
+
+func TestQueryComments_Untouched(t *testing.T) {
+	db, connector := newCommentedDB(t)
+	ctx, _ := tracedContext(t, "/api/v1/customers")
+
+	var rows []widget
+	require.NoError(t, db.WithContext(context.Background()).Find(&rows).Error)
+	// Query yang sudah punya komentar tidak ditambah komentar kedua
+	require.NoError(t, db.WithContext(ctx).Exec("DELETE FROM widgets /* cleanup */").Error)
+
+	assert.Equal(t, []string{
+		"SELECT * FROM `widgets`",
+		"DELETE FROM widgets /* cleanup */",
+	}, connector.executed())
+}
+
+func TestWithQueryComment_Escaping(t *testing.T) {
+	ctx := WithRoute(context.Background(), func() string { return "/api/v1/search it's" })
+
+	assert.Equal(t,
+		"SELECT 1 /*route='%2Fapi%2Fv1%2Fsearch%20it%27s'*/",
+		withQueryComment(ctx, "SELECT 1"),
+	)
+}
+
+func TestQueryComments_DBStillAvailable(t *testing.T) {
+	db, _ := newCommentedDB(t)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.NoError(t, sqlDB.Ping())
+}
@@ -0,0 +1,136 @@
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"gorm.io/gorm"
+)
+
+// TenantRouting is a GORM plugin that sends every statement to the schema of
+// the tenant in its context, see tenancy.WithTenant. Each tenant other than
+// the default one gets a pool of its own on the same MySQL server; statements
+// without a tenant, and those of the default tenant, keep using the pool db
+// was opened with.
+//
+// Register it after QueryComments so the tenant pools are commented too.
+type TenantRouting struct {
+	Config  *DatabaseConfig
+	Tenants []*tenancy.Tenant
+}
+
+// Name implements gorm.Plugin.
+func (TenantRouting) Name() string {
+	return "mysqldb:tenant_routing"
+}
+
+// Initialize implements gorm.Plugin.
+func (r TenantRouting) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	_, commented := db.ConnPool.(*commentedDB)
+	pool := &tenantPool{ConnPool: db.ConnPool, db: sqlDB, tenants: make(map[string]gorm.ConnPool)}
+	for _, t := range r.Tenants {
+		if t.ID == tenancy.DefaultID {
+			continue
+		}
+
+		config := *r.Config
+		config.DatabaseName = t.Database
+		tenantDB, err := sql.Open("mysql", config.BuildDSN())
+		if err != nil {
+			pool.closeTenants()
+			return fmt.Errorf("tenant %s: %w", t.ID, err)
+		}
+		tenantDB.SetMaxIdleConns(config.MaxIdleConns)
+		tenantDB.SetMaxOpenConns(config.MaxOpenConns)
+		tenantDB.SetConnMaxLifetime(config.ConnMaxLifetime)
+		pool.dbs = append(pool.dbs, tenantDB)
+
+		if err := tenantDB.Ping(); err != nil {
+			pool.closeTenants()
+			return fmt.Errorf("tenant %s: %w", t.ID, err)
+		}
+
+		if commented {
+			pool.tenants[t.ID] = &commentedDB{DB: tenantDB}
+		} else {
+			pool.tenants[t.ID] = tenantDB
+		}
+	}
+
+	db.ConnPool = pool
+	db.Statement.ConnPool = pool
+	return nil
+}
+
+// EnableTenantRouting registers TenantRouting on db.
+func EnableTenantRouting(db *gorm.DB, config *DatabaseConfig, tenants []*tenancy.Tenant) error {
+	return db.Use(TenantRouting{Config: config, Tenants: tenants})
+}
+
+// tenantPool memilih pool per statement; transaksi tetap di pool tempat ia dibuka
+type tenantPool struct {
+	gorm.ConnPool
+	db      *sql.DB
+	tenants map[string]gorm.ConnPool
+	dbs     []*sql.DB
+}
+
+func (p *tenantPool) pool(ctx context.Context) gorm.ConnPool {
+	if pool, ok := p.tenants[tenancy.ID(ctx)]; ok {
+		return pool
+	}
+	return p.ConnPool
+}
+
+func (p *tenantPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.pool(ctx).PrepareContext(ctx, query)
+}
+
+func (p *tenantPool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return p.pool(ctx).ExecContext(ctx, query, args...)
+}
+
+func (p *tenantPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.pool(ctx).QueryContext(ctx, query, args...)
+}
+
+func (p *tenantPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.pool(ctx).QueryRowContext(ctx, query, args...)
+}
+
+func (p *tenantPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	switch beginner := p.pool(ctx).(type) {
+	case gorm.TxBeginner:
+		tx, err := beginner.BeginTx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	case gorm.ConnPoolBeginner:
+		return beginner.BeginTx(ctx, opts)
+	}
+	return nil, gorm.ErrInvalidTransaction
+}
+
+// GetDBConn implements gorm.GetDBConnector so db.DB() keeps working. It
+// returns the pool of the default tenant, so whatever reserves a connection
+// through db.DB(), such as WithLock, works on the default schema.
+func (p *tenantPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+func (p *tenantPool) closeTenants() error {
+	var errs []error
+	for _, db := range p.dbs {
+		errs = append(errs, db.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,98 @@
+package mysqldb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const queryTimeoutKey = "mysqldb:query_timeout"
+
+// QueryTimeout is a GORM plugin that bounds every statement with
+// context.WithTimeout, so a slow query fails with context.DeadlineExceeded
+// instead of holding the request that issued it. A shorter deadline already
+// on the caller's context still wins. A zero Timeout disables the plugin.
+type QueryTimeout struct {
+	Timeout time.Duration
+}
+
+type queryDeadline struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// Name implements gorm.Plugin.
+func (p *QueryTimeout) Name() string {
+	return "mysqldb:query_timeout"
+}
+
+// Initialize implements gorm.Plugin. The callbacks run outermost so the
+// tracing span and the driver both see the bounded context.
+func (p *QueryTimeout) Initialize(db *gorm.DB) error {
+	if p.Timeout <= 0 {
+		return nil
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("mysqldb:timeout_before_create", p.before),
+		cb.Create().After("*").Register("mysqldb:timeout_after_create", p.after(true)),
+		cb.Query().Before("*").Register("mysqldb:timeout_before_query", p.before),
+		cb.Query().After("*").Register("mysqldb:timeout_after_query", p.after(true)),
+		cb.Update().Before("*").Register("mysqldb:timeout_before_update", p.before),
+		cb.Update().After("*").Register("mysqldb:timeout_after_update", p.after(true)),
+		cb.Delete().Before("*").Register("mysqldb:timeout_before_delete", p.before),
+		cb.Delete().After("*").Register("mysqldb:timeout_after_delete", p.after(true)),
+		cb.Raw().Before("*").Register("mysqldb:timeout_before_raw", p.before),
+		cb.Raw().After("*").Register("mysqldb:timeout_after_raw", p.after(true)),
+		// Row dan Rows mengembalikan *sql.Rows yang masih dibaca pemanggil,
+		// jadi context-nya dibiarkan habis sendiri alih-alih dibatalkan di sini
+		cb.Row().Before("*").Register("mysqldb:timeout_before_row", p.before),
+		cb.Row().After("*").Register("mysqldb:timeout_after_row", p.after(false)),
+	)
+}
+
+type noQueryTimeoutKey struct{}
+
+// WithoutQueryTimeout marks ctx so QueryTimeout leaves its statements
+// unbounded, for schema changes that legitimately run for minutes.
+func WithoutQueryTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noQueryTimeoutKey{}, true)
+}
+
+// EnableQueryTimeout registers QueryTimeout on db.
+func EnableQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	return db.Use(&QueryTimeout{Timeout: timeout})
+}
+
+func (p *QueryTimeout) before(db *gorm.DB) {
+	parent := db.Statement.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	if skip, _ := parent.Value(noQueryTimeoutKey{}).(bool); skip {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(parent, p.Timeout)
+	db.Statement.Context = ctx
+	db.InstanceSet(queryTimeoutKey, &queryDeadline{parent: parent, cancel: cancel})
+}
+
+func (p *QueryTimeout) after(cancel bool) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryTimeoutKey)
+		if !ok {
+			return
+		}
+		qd := value.(*queryDeadline)
+
+		// Context statement dikembalikan karena builder yang sama bisa dipakai ulang
+		db.Statement.Context = qd.parent
+		if cancel {
+			qd.cancel()
+		}
+	}
+}
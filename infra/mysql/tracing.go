@@ -0,0 +1,153 @@
+package mysqldb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const queryTraceKey = "mysqldb:query_trace"
+
+// QueryTracing is a GORM plugin that wraps every statement in a client span
+// carrying the SQL, table and affected rows, records its duration in the
+// db.query.duration histogram, and logs statements slower than
+// SlowThreshold. A zero SlowThreshold disables the slow query log; a nil
+// Meter disables the histogram.
+type QueryTracing struct {
+	Tracer        trace.Tracer
+	Meter         metric.Meter
+	Log           *zap.Logger
+	SlowThreshold time.Duration
+
+	duration metric.Float64Histogram
+}
+
+type queryTrace struct {
+	parent context.Context
+	span   trace.Span
+	start  time.Time
+}
+
+// Name implements gorm.Plugin.
+func (p *QueryTracing) Name() string {
+	return "mysqldb:query_tracing"
+}
+
+// Initialize implements gorm.Plugin.
+func (p *QueryTracing) Initialize(db *gorm.DB) error {
+	if p.Meter != nil {
+		duration, err := p.Meter.Float64Histogram(
+			"db.query.duration",
+			metric.WithDescription("Duration of database queries"),
+			metric.WithUnit("ms"),
+		)
+		if err != nil {
+			return err
+		}
+		p.duration = duration
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("mysqldb:before_create", p.before("insert")),
+		cb.Create().After("gorm:create").Register("mysqldb:after_create", p.after("insert")),
+		cb.Query().Before("gorm:query").Register("mysqldb:before_query", p.before("select")),
+		cb.Query().After("gorm:query").Register("mysqldb:after_query", p.after("select")),
+		cb.Update().Before("gorm:update").Register("mysqldb:before_update", p.before("update")),
+		cb.Update().After("gorm:update").Register("mysqldb:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("mysqldb:before_delete", p.before("delete")),
+		cb.Delete().After("gorm:delete").Register("mysqldb:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("mysqldb:before_row", p.before("row")),
+		cb.Row().After("gorm:row").Register("mysqldb:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("mysqldb:before_raw", p.before("raw")),
+		cb.Raw().After("gorm:raw").Register("mysqldb:after_raw", p.after("raw")),
+	)
+}
+
+// EnableQueryTracing registers QueryTracing on db.
+func EnableQueryTracing(db *gorm.DB, tracer trace.Tracer, meter metric.Meter, log *zap.Logger, slowThreshold time.Duration) error {
+	return db.Use(&QueryTracing{Tracer: tracer, Meter: meter, Log: log, SlowThreshold: slowThreshold})
+}
+
+func (p *QueryTracing) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+
+		ctx, span := p.Tracer.Start(parent, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "mysql"),
+				attribute.String("db.operation", operation),
+			),
+		)
+
+		// Context query diganti agar driver (dan komentar SQL) memakai span ini
+		db.Statement.Context = ctx
+		db.InstanceSet(queryTraceKey, &queryTrace{parent: parent, span: span, start: time.Now()})
+	}
+}
+
+func (p *QueryTracing) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryTraceKey)
+		if !ok {
+			return
+		}
+		qt := value.(*queryTrace)
+		db.Statement.Context = qt.parent
+		defer qt.span.End()
+
+		elapsed := time.Since(qt.start)
+		statement := db.Statement.SQL.String()
+		table := db.Statement.Table
+
+		qt.span.SetAttributes(
+			attribute.String("db.statement", statement),
+			attribute.String("db.sql.table", table),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+		)
+
+		status := "success"
+		switch {
+		case errors.Is(db.Error, gorm.ErrRecordNotFound):
+			status = "not_found"
+		case db.Error != nil:
+			status = "error"
+			qt.span.RecordError(db.Error)
+			qt.span.SetStatus(codes.Error, db.Error.Error())
+		}
+
+		if p.duration != nil {
+			p.duration.Record(qt.parent, float64(elapsed.Microseconds())/1000,
+				metric.WithAttributes(
+					attribute.String("operation", operation),
+					attribute.String("table", table),
+					attribute.String("status", status),
+				),
+			)
+		}
+
+		if p.SlowThreshold > 0 && elapsed >= p.SlowThreshold {
+			// SQL dicatat dengan placeholder, nilai parameter tidak ikut ke log
+			p.Log.Warn("Slow query",
+				zap.String("operation", operation),
+				zap.String("table", table),
+				zap.String("sql", statement),
+				zap.Int64("rows_affected", db.RowsAffected),
+				zap.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+				zap.Duration("threshold", p.SlowThreshold),
+				zap.String("trace_id", qt.span.SpanContext().TraceID().String()),
+			)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package mysqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+)
+
+type tracingFixture struct {
+	db        *gorm.DB
+	connector *fakeConnector
+	spans     *tracetest.SpanRecorder
+	tracer    trace.Tracer
+	metrics   *sdkmetric.ManualReader
+	logs      *observer.ObservedLogs
+}
+
+func newTracingFixture(t *testing.T, slowThreshold time.Duration) *tracingFixture {
+	t.Helper()
+
+	db, connector := newFakeDB(t)
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test-query-tracing")
+	metrics := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metrics)).Meter("test-query-tracing")
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	require.NoError(t, EnableQueryTracing(db, tracer, meter, zap.New(core), slowThreshold))
+	return &tracingFixture{db: db, connector: connector, spans: spans, tracer: tracer, metrics: metrics, logs: logs}
+}
+
+// querySpans returns the ended spans opened by the plugin.
+func (f *tracingFixture) querySpans() []sdktrace.ReadOnlySpan {
+	var out []sdktrace.ReadOnlySpan
+	for _, span := range f.spans.Ended() {
+		if span.SpanKind() == trace.SpanKindClient {
+			out = append(out, span)
+		}
+	}
+	return out
+}
+
+// durations returns the recorded db.query.duration points keyed by status.
+func (f *tracingFixture) durations(t *testing.T) map[string]metricdata.HistogramDataPoint[float64] {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, f.metrics.Collect(context.Background(), &rm))
+
+	points := map[string]metricdata.HistogramDataPoint[float64]{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "db.query.duration" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				status, _ := dp.Attributes.Value("status")
+				points[status.AsString()] = dp
+			}
+		}
+	}
+	return points
+}
+
+func attrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	out := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		out[kv.Key] = kv.Value
+	}
+	return out
+}
+
+func TestQueryTracing_SpanAttributes(t *testing.T) {
+	f := newTracingFixture(t, 0)
+
+	ctx, parent := f.tracer.Start(context.Background(), "service.FindWidgets")
+	var rows []widget
+	tx := f.db.WithContext(ctx).Where("name = ?", "gear").Find(&rows)
+	parent.End()
+	require.NoError(t, tx.Error)
+
+	spans := f.querySpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	assert.Equal(t, "db.select", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+
+	got := attrs(span)
+	assert.Equal(t, "mysql", got["db.system"].AsString())
+	assert.Equal(t, "select", got["db.operation"].AsString())
+	assert.Equal(t, "widgets", got["db.sql.table"].AsString())
+	assert.Equal(t, int64(0), got["db.rows_affected"].AsInt64())
+	// Nilai parameter tidak ikut, hanya placeholder
+	assert.Equal(t, "SELECT * FROM `widgets` WHERE name = ?", got["db.statement"].AsString())
+
+	// Context statement dikembalikan ke milik pemanggil setelah query
+	assert.Equal(t, ctx, tx.Statement.Context)
+}
+
+func TestQueryTracing_Operations(t *testing.T) {
+	f := newTracingFixture(t, 0)
+	ctx := context.Background()
+
+	require.NoError(t, f.db.WithContext(ctx).Create(&widget{Name: "gear"}).Error)
+	require.NoError(t, f.db.WithContext(ctx).Model(&widget{}).Where("id = ?", 1).Update("name", "cog").Error)
+	require.NoError(t, f.db.WithContext(ctx).Where("id = ?", 1).Delete(&widget{}).Error)
+	require.NoError(t, f.db.WithContext(ctx).Exec("DELETE FROM widgets").Error)
+
+	spans := f.querySpans()
+	require.Len(t, spans, 4)
+
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name()
+	}
+	assert.Equal(t, []string{"db.insert", "db.update", "db.delete", "db.raw"}, names)
+	assert.Equal(t, int64(1), attrs(spans[0])["db.rows_affected"].AsInt64())
+}
+
+func TestQueryTracing_RecordsErrors(t *testing.T) {
+	f := newTracingFixture(t, 0)
+	f.connector.fail(errors.New("connection reset by peer"))
+
+	var rows []widget
+	err := f.db.WithContext(context.Background()).Find(&rows).Error
+	require.Error(t, err)
+
+	spans := f.querySpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "connection reset by peer", span.Status().Description)
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "exception", span.Events()[0].Name)
+
+	points := f.durations(t)
+	require.Contains(t, points, "error")
+	assert.Equal(t, uint64(1), points["error"].Count)
+}
+
+func TestQueryTracing_NotFoundIsNotAnError(t *testing.T) {
+	f := newTracingFixture(t, 0)
+
+	var row widget
+	err := f.db.WithContext(context.Background()).First(&row, 7).Error
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	spans := f.querySpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Empty(t, spans[0].Events())
+
+	points := f.durations(t)
+	require.Contains(t, points, "not_found")
+	assert.NotContains(t, points, "error")
+}
+
+func TestQueryTracing_Duration(t *testing.T) {
+	f := newTracingFixture(t, 0)
+
+	for i := 0; i < 3; i++ {
+		var rows []widget
+		require.NoError(t, f.db.WithContext(context.Background()).Find(&rows).Error)
+	}
+
+	points := f.durations(t)
+	require.Contains(t, points, "success")
+	point := points["success"]
+	assert.Equal(t, uint64(3), point.Count)
+
+	operation, _ := point.Attributes.Value("operation")
+	table, _ := point.Attributes.Value("table")
+	assert.Equal(t, "select", operation.AsString())
+	assert.Equal(t, "widgets", table.AsString())
+}
+
+func TestQueryTracing_SlowQueryLog(t *testing.T) {
+	t.Run("Above Threshold", func(t *testing.T) {
+		f := newTracingFixture(t, time.Nanosecond)
+
+		var rows []widget
+		require.NoError(t, f.db.WithContext(context.Background()).Where("name = ?", "secret").Find(&rows).Error)
+
+		slow := f.logs.FilterMessage("Slow query").All()
+		require.Len(t, slow, 1)
+		fields := slow[0].ContextMap()
+		assert.Equal(t, "widgets", fields["table"])
+		assert.Equal(t, "SELECT * FROM `widgets` WHERE name = ?", fields["sql"])
+		assert.NotContains(t, fields["sql"], "secret")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		f := newTracingFixture(t, 0)
+
+		var rows []widget
+		require.NoError(t, f.db.WithContext(context.Background()).Find(&rows).Error)
+		assert.Zero(t, f.logs.FilterMessage("Slow query").Len())
+	})
+}
+
+func TestQueryTracing_NilMeter(t *testing.T) {
+	db, _ := newFakeDB(t)
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test-query-tracing")
+	require.NoError(t, EnableQueryTracing(db, tracer, nil, zap.NewNop(), 0))
+
+	var rows []widget
+	require.NoError(t, db.WithContext(context.Background()).Find(&rows).Error)
+	assert.Len(t, spans.Ended(), 1)
+}
@@ -29,10 +29,7 @@ func NewRedis(cfg *config.Config) (*redis.Client, error) {
 
 	pong, err := client.Ping(ctx).Result()
 	if err != nil {
-		zap.L().Fatal(
-			"Failed to ping Redis",
-			zap.Error(err),
-		)
+		client.Close()
 		return nil, err
 	}
 	zap.L().Info("Redis terhubung! Response: " + pong)
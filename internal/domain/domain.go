@@ -1,9 +1,13 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/shopspring/decimal"
 )
 
 type Role string
@@ -23,17 +27,62 @@ type Customer struct {
 	Role               Role
 	BirthPlace         string
 	BirthDate          time.Time
-	Salary             float64
+	Salary             decimal.Decimal
 	KtpUrl             string
 	SelfieUrl          string
 	VerificationStatus VerificationStatus
+	Language           Language
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 
+	// Email and Phone are confirmed by OTP at registration and on every
+	// change. Customers registered earlier may have neither.
+	Email string
+	Phone string
+
+	// DocumentsSubmittedAt is when the KTP and selfie were last uploaded.
+	DocumentsSubmittedAt  *time.Time
+	PendingReminderSentAt *time.Time
+
+	// RegistrationIP and RegistrationDeviceID fingerprint the sign-up request
+	// for referral fraud checks.
+	RegistrationIP       string
+	RegistrationDeviceID string
+
+	// MonthlyStatementOptOut stops the monthly account summary email.
+	MonthlyStatementOptOut bool
+
+	// DormantAt is set when the customer has been inactive for too long.
+	// Dormant customers cannot transact until they reactivate.
+	DormantAt *time.Time
+
+	// ClosedAt is set when the customer closed their account. Closed
+	// accounts cannot log in or transact.
+	ClosedAt *time.Time
+
+	// Version increases on every profile edit. An update must carry the
+	// version it was based on and is rejected when another edit came first.
+	Version uint64
+
+	// MustChangePassword is set on seeded accounts. Their tokens only allow
+	// changing the password until it has been replaced.
+	MustChangePassword bool
+
 	CustomerLimits []CustomerLimit
 	Transactions   []Transaction
 }
 
+// Language is the customer's preferred language for notifications.
+type Language string
+
+const (
+	LanguageIndonesian Language = "id"
+	LanguageEnglish    Language = "en"
+)
+
+// DefaultLanguage is used when a customer has not chosen one.
+const DefaultLanguage = LanguageIndonesian
+
 type VerificationStatus string
 
 const (
@@ -42,36 +91,120 @@ const (
 	VerificationRejected VerificationStatus = "REJECTED"
 )
 
+// Tenor is also the financing product offered for its duration. Amount
+// bounds are in IDR; a zero bound or an empty AssetCategories leaves that
+// rule open. MaxAgeAtEnd caps the customer's age when the contract ends; zero
+// falls back to the configured default.
 type Tenor struct {
-	ID             uint
-	DurationMonths uint8
-	Description    string
+	ID              uint
+	DurationMonths  uint8
+	Description     string
+	Active          bool
+	MinAmount       decimal.Decimal
+	MaxAmount       decimal.Decimal
+	MinAge          uint8
+	MaxAge          uint8
+	MaxAgeAtEnd     uint8
+	AssetCategories []string
 
 	CustomerLimits []CustomerLimit
 	Transactions   []Transaction
 }
 
+// AllowsAsset reports whether the product finances assets of category.
+func (t Tenor) AllowsAsset(category string) bool {
+	if len(t.AssetCategories) == 0 {
+		return true
+	}
+	for _, allowed := range t.AssetCategories {
+		if strings.EqualFold(allowed, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// AgeAt returns the age in whole years of someone born on birthDate.
+func AgeAt(birthDate, now time.Time) int {
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || now.Month() == birthDate.Month() && now.Day() < birthDate.Day() {
+		age--
+	}
+	return age
+}
+
 type CustomerLimit struct {
 	CustomerID  uint64
 	TenorID     uint
-	LimitAmount float64
+	LimitAmount decimal.Decimal
+	Currency    string
+	// Version is 0 for a tenor without a limit yet and increases on every
+	// overwrite, like Customer.Version.
+	Version uint64
+	// EffectiveFrom and EffectiveTo bound the period the limit is in force,
+	// EffectiveTo is nil until a later limit is scheduled after it.
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
 
 	Customer Customer
 	Tenor    Tenor
 }
 
+// InForce reports whether the limit applies at t.
+func (l CustomerLimit) InForce(t time.Time) bool {
+	return !l.EffectiveFrom.After(t) && (l.EffectiveTo == nil || l.EffectiveTo.After(t))
+}
+
+// LimitChange records one overwrite of a customer limit. PreviousAmount is
+// nil when the tenor had no limit before.
+type LimitChange struct {
+	ID               uint64
+	CustomerID       uint64
+	TenorID          uint
+	TenorMonths      uint8
+	PreviousAmount   *decimal.Decimal
+	PreviousCurrency string
+	NewAmount        decimal.Decimal
+	Currency         string
+	ChangedBy        uint64
+	ChangedAt        time.Time
+	// EffectiveFrom is when NewAmount takes effect, later than ChangedAt
+	// for a scheduled change.
+	EffectiveFrom time.Time
+}
+
 type Transaction struct {
 	ID                     uint64
 	ContractNumber         string
 	CustomerID             uint64
 	TenorID                uint
 	AssetName              string
-	OTRAmount              float64
-	AdminFee               float64
-	TotalInterest          float64
-	TotalInstallmentAmount float64
+	AssetCategory          string
+	OTRAmount              decimal.Decimal
+	AdminFee               decimal.Decimal
+	TotalInterest          decimal.Decimal
+	TotalInstallmentAmount decimal.Decimal
+	CommissionAmount       decimal.Decimal
+	DiscountAmount         decimal.Decimal
 	Status                 TransactionStatus
 	TransactionDate        time.Time
+	PartnerID              *uint64
+	IsSandbox              bool
+	Currency               string
+	FxRate                 decimal.Decimal
+	// PaidInstallments is nil until repayments are recorded for the
+	// contract; until then installments are assumed paid on their due date.
+	PaidInstallments *int
+	// VirtualAccountNumber is nil until a virtual account is provisioned.
+	VirtualAccountBank   string
+	VirtualAccountNumber *string
+	// RegionCode and BranchCode are empty when neither the partner nor its
+	// profile named a region. Latitude and Longitude are where the partner
+	// captured the application, when it sent them.
+	RegionCode string
+	BranchCode string
+	Latitude   *float64
+	Longitude  *float64
 
 	Customer Customer
 	Tenor    Tenor
@@ -85,24 +218,1799 @@ const (
 	TransactionActive    TransactionStatus = "ACTIVE"
 	TransactionPaidOff   TransactionStatus = "PAID_OFF"
 	TransactionCancelled TransactionStatus = "CANCELLED"
+	// TransactionWrittenOff is an active contract taken off the books after
+	// its write-off was approved. Payments no longer apply to it, money that
+	// still comes in is recorded as a recovery of the write-off.
+	TransactionWrittenOff TransactionStatus = "WRITTEN_OFF"
+)
+
+type Partner struct {
+	ID               uint64
+	Name             string
+	Email            string
+	Status           PartnerStatus
+	SandboxKeyHash   string
+	SandboxKeyPrefix string
+	LiveKeyHash      string
+	LiveKeyPrefix    string
+	WebhookURL       string
+	PromotedAt       *time.Time
+	Security         PartnerSecurity
+	// RegionCode and BranchCode are applied to transactions that do not
+	// name their own.
+	RegionCode string
+	BranchCode string
+	// SandboxDebugUntil and LiveDebugUntil keep debug recording on for the
+	// requests made with that key until the time passes.
+	SandboxDebugUntil *time.Time
+	LiveDebugUntil    *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// DebugUntil returns when debug recording ends for the sandbox or live key.
+func (p *Partner) DebugUntil(sandbox bool) *time.Time {
+	if sandbox {
+		return p.SandboxDebugUntil
+	}
+	return p.LiveDebugUntil
+}
+
+// Debugging reports whether requests made with the sandbox or live key are
+// recorded at now.
+func (p *Partner) Debugging(sandbox bool, now time.Time) bool {
+	until := p.DebugUntil(sandbox)
+	return until != nil && until.After(now)
+}
+
+// PartnerSecurity restricts where a partner's live key may be used from.
+// Sandbox keys are never restricted so integrations can be tested anywhere.
+type PartnerSecurity struct {
+	AllowedCIDRs      []string
+	RequireClientCert bool
+	// ClientCertFingerprint is the lowercase hex SHA-256 of the DER client
+	// certificate the partner presents over mutual TLS.
+	ClientCertFingerprint string
+	// RequireSignature rejects API key requests that are not signed with
+	// SigningSecret. Partners may sign before it is required.
+	RequireSignature bool
+	SigningSecret    string
+}
+
+// SignedRequest carries the anti-replay headers of a partner request along
+// with the parts of the request they sign.
+type SignedRequest struct {
+	Nonce     string
+	Timestamp string
+	Signature string
+	Method    string
+	Path      string
+	Body      []byte
+}
+
+type PartnerStatus string
+
+const (
+	PartnerSandbox    PartnerStatus = "SANDBOX"
+	PartnerProduction PartnerStatus = "PRODUCTION"
+)
+
+type WebhookDelivery struct {
+	ID            uint64
+	PartnerID     uint64
+	EventType     string
+	URL           string
+	Payload       string
+	Status        DeliveryStatus
+	Attempts      int
+	Replays       int
+	LastError     string
+	LastAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryFailed    DeliveryStatus = "FAILED"
+	DeliveryPoisoned  DeliveryStatus = "POISONED"
+)
+
+type SalaryChange struct {
+	ID              uint64
+	CustomerID      uint64
+	CurrentSalary   decimal.Decimal
+	RequestedSalary decimal.Decimal
+	PayslipUrl      string
+	Status          SalaryChangeStatus
+	ReviewerID      *uint64
+	ReviewNote      string
+	ReviewedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type SalaryChangeStatus string
+
+const (
+	SalaryChangePending  SalaryChangeStatus = "PENDING"
+	SalaryChangeApproved SalaryChangeStatus = "APPROVED"
+	SalaryChangeRejected SalaryChangeStatus = "REJECTED"
+)
+
+// IncomeVerification is the result of reading a bank statement uploaded by a
+// customer. Discrepancy is how far AverageMonthlyInflow strays from
+// DeclaredSalary, as a fraction of the declared salary.
+type IncomeVerification struct {
+	ID                   uint64
+	CustomerID           uint64
+	FileName             string
+	StatementUrl         string
+	DeclaredSalary       decimal.Decimal
+	AverageMonthlyInflow decimal.Decimal
+	TotalInflow          decimal.Decimal
+	PeriodStart          time.Time
+	PeriodEnd            time.Time
+	MonthsCovered        int
+	TransactionCount     int
+	Discrepancy          decimal.Decimal
+	Status               IncomeVerificationStatus
+	ReviewerID           *uint64
+	ReviewNote           string
+	ReviewedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// IncomeVerificationStatus is MATCHED when the statement supports the
+// declared salary and FLAGGED when it needs an admin to look at it. A flagged
+// verification ends ACCEPTED or REJECTED once reviewed.
+type IncomeVerificationStatus string
+
+const (
+	IncomeMatched  IncomeVerificationStatus = "MATCHED"
+	IncomeFlagged  IncomeVerificationStatus = "FLAGGED"
+	IncomeAccepted IncomeVerificationStatus = "ACCEPTED"
+	IncomeRejected IncomeVerificationStatus = "REJECTED"
+)
+
+// RiskTier grades a customer for the limit recommendation and the
+// debt-service ratio check. The names match the tiers those checks are
+// configured with.
+type RiskTier string
+
+const (
+	RiskTierLow    RiskTier = "LOW"
+	RiskTierMedium RiskTier = "MEDIUM"
+	RiskTierHigh   RiskTier = "HIGH"
+)
+
+// RiskTierRule assigns Tier to the customers that fall within every bound
+// it sets. Bounds are inclusive and a nil bound is open. Rules are tried by
+// ascending Priority and the first match wins.
+type RiskTierRule struct {
+	ID             uint64
+	Priority       int
+	Tier           RiskTier
+	MinSalary      *decimal.Decimal
+	MaxSalary      *decimal.Decimal
+	MinScore       *int
+	MaxScore       *int
+	MinDaysPastDue *int
+	MaxDaysPastDue *int
+	UpdatedBy      uint64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Matches reports whether a customer with salary, credit score and days
+// past due falls within the rule. A customer without a score only matches
+// rules that leave the score open.
+func (r RiskTierRule) Matches(salary decimal.Decimal, score *int, daysPastDue int) bool {
+	if r.MinSalary != nil && salary.LessThan(*r.MinSalary) {
+		return false
+	}
+	if r.MaxSalary != nil && salary.GreaterThan(*r.MaxSalary) {
+		return false
+	}
+	if r.MinScore != nil || r.MaxScore != nil {
+		if score == nil {
+			return false
+		}
+		if (r.MinScore != nil && *score < *r.MinScore) || (r.MaxScore != nil && *score > *r.MaxScore) {
+			return false
+		}
+	}
+	if r.MinDaysPastDue != nil && daysPastDue < *r.MinDaysPastDue {
+		return false
+	}
+	if r.MaxDaysPastDue != nil && daysPastDue > *r.MaxDaysPastDue {
+		return false
+	}
+	return true
+}
+
+// CustomerRiskTier is the tier a customer was last given and the inputs it
+// was given on. DaysPastDue is the worst delinquency among the customer's
+// active contracts. Tier is empty when no rule matched, in which case
+// consumers fall back to the salary bands.
+type CustomerRiskTier struct {
+	CustomerID   uint64
+	Tier         RiskTier
+	RuleID       *uint64
+	Salary       decimal.Decimal
+	CreditScore  *int
+	DaysPastDue  int
+	CalculatedAt time.Time
+}
+
+// RiskTierRun summarises one pass of the risk tier job.
+type RiskTierRun struct {
+	Customers int
+	Changed   int
+}
+
+type BlacklistEntry struct {
+	ID        uint64
+	NIK       string
+	FullName  string
+	Reason    string
+	Source    string
+	Severity  BlacklistSeverity
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type BlacklistSeverity string
+
+const (
+	// SeverityBlock menolak aksi, SeverityFlag hanya menandai untuk ditinjau
+	SeverityBlock BlacklistSeverity = "BLOCK"
+	SeverityFlag  BlacklistSeverity = "FLAG"
+)
+
+type ScreeningLog struct {
+	ID         uint64
+	EntryID    uint64
+	CustomerID *uint64
+	NIK        string
+	FullName   string
+	Action     ScreeningAction
+	Outcome    ScreeningOutcome
+	CreatedAt  time.Time
+}
+
+type ScreeningAction string
+
+const (
+	ScreeningRegistration ScreeningAction = "REGISTRATION"
+	ScreeningTransaction  ScreeningAction = "TRANSACTION"
+)
+
+type ScreeningOutcome string
+
+const (
+	ScreeningBlocked ScreeningOutcome = "BLOCKED"
+	ScreeningFlagged ScreeningOutcome = "FLAGGED"
+)
+
+type DuplicateResolution struct {
+	ID          uint64
+	CustomerID  uint64
+	DuplicateID uint64
+	Action      DuplicateAction
+	ResolvedBy  uint64
+	Note        string
+	CreatedAt   time.Time
+}
+
+type DuplicateAction string
+
+const (
+	DuplicateIgnored DuplicateAction = "IGNORED"
+	DuplicateMerged  DuplicateAction = "MERGED"
+)
+
+type Restructuring struct {
+	ID                         uint64
+	TransactionID              uint64
+	CustomerID                 uint64
+	Type                       RestructuringType
+	Status                     RestructuringStatus
+	ContractDate               time.Time
+	PaidInstallments           uint8
+	OriginalTenorID            uint
+	OriginalTenorMonths        uint8
+	OriginalTotalInterest      decimal.Decimal
+	OriginalTotalInstallment   decimal.Decimal
+	OriginalMonthlyInstallment decimal.Decimal
+	NewTenorID                 uint
+	NewTenorMonths             uint8
+	NewTotalInterest           decimal.Decimal
+	NewTotalInstallment        decimal.Decimal
+	NewMonthlyInstallment      decimal.Decimal
+	Reason                     string
+	RequestedBy                uint64
+	ReviewerID                 *uint64
+	ReviewNote                 string
+	ReviewedAt                 *time.Time
+	CreatedAt                  time.Time
+	UpdatedAt                  time.Time
+}
+
+type RestructuringType string
+
+const (
+	RestructuringExtendTenor       RestructuringType = "EXTEND_TENOR"
+	RestructuringReduceInstallment RestructuringType = "REDUCE_INSTALLMENT"
+)
+
+type RestructuringStatus string
+
+const (
+	RestructuringPending  RestructuringStatus = "PENDING"
+	RestructuringApproved RestructuringStatus = "APPROVED"
+	RestructuringRejected RestructuringStatus = "REJECTED"
 )
 
 type JwtCustomClaims struct {
 	UserID uint64 `json:"user_id"`
 	Role   Role   `json:"role"`
+
+	// ImpersonatorID is the admin acting as the customer, set only on tokens
+	// issued by an ImpersonationSession.
+	ImpersonatorID  uint64 `json:"impersonator_id,omitempty"`
+	ImpersonationID uint64 `json:"impersonation_id,omitempty"`
+
+	// MustChangePassword restricts the token to changing the password.
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+
+	// Tenant is the tenant that issued the token, which is the only one it is
+	// accepted by. Admins are therefore admins of their own tenant only.
+	Tenant string `json:"tenant,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
-type Params struct {
-	Status string
-	Page   int
-	Limit  int
+// Impersonated reports whether the token was issued to an admin acting as
+// the customer.
+func (c *JwtCustomClaims) Impersonated() bool {
+	return c.ImpersonatorID != 0
+}
+
+// Params is a validated list request, see query.Spec.
+type Params = query.Query
+
+type Paginated = query.Paginated
+
+type FxRate struct {
+	ID         uint64
+	Currency   string
+	RateDate   time.Time
+	RateToIDR  decimal.Decimal
+	UploadedBy uint64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// InterestAccrual is the flat interest recognised in one month for the
+// contracts of a tenor within a portfolio segment, converted to IDR.
+type InterestAccrual struct {
+	TenorID         uint
+	TenorMonths     uint8
+	Segment         PortfolioSegment
+	ContractCount   int64
+	AccruedInterest decimal.Decimal
+}
+
+// PortfolioSegment groups contracts by the channel they were originated from.
+type PortfolioSegment string
+
+const (
+	PortfolioDirect  PortfolioSegment = "DIRECT"
+	PortfolioPartner PortfolioSegment = "PARTNER"
+)
+
+// AgingBucket is a days-past-due band of the delinquency aging report.
+type AgingBucket string
+
+const (
+	AgingCurrent AgingBucket = "0"
+	Aging1To30   AgingBucket = "1-30"
+	Aging31To60  AgingBucket = "31-60"
+	Aging61To90  AgingBucket = "61-90"
+	AgingOver90  AgingBucket = "90+"
+)
+
+// AgingBuckets lists the bands in reporting order.
+var AgingBuckets = []AgingBucket{AgingCurrent, Aging1To30, Aging31To60, Aging61To90, AgingOver90}
+
+// AgingBucketOf returns the band a contract falls into after daysPastDue days.
+func AgingBucketOf(daysPastDue int) AgingBucket {
+	switch {
+	case daysPastDue <= 0:
+		return AgingCurrent
+	case daysPastDue <= 30:
+		return Aging1To30
+	case daysPastDue <= 60:
+		return Aging31To60
+	case daysPastDue <= 90:
+		return Aging61To90
+	default:
+		return AgingOver90
+	}
+}
+
+// AgingExposure is an active contract as read by the aging report and the
+// risk tier job.
+type AgingExposure struct {
+	TransactionID          uint64
+	CustomerID             uint64
+	TenorMonths            uint8
+	TransactionDate        time.Time
+	PaidInstallments       *int
+	TotalInstallmentAmount decimal.Decimal
+	FxRate                 decimal.Decimal
+	RegionCode             string
+}
+
+// AgingSnapshotRow is one tenor and bucket of a materialised aging snapshot.
+// Outstanding is the unpaid installment balance in IDR.
+type AgingSnapshotRow struct {
+	SnapshotDate  time.Time
+	TenorMonths   uint8
+	Bucket        AgingBucket
+	ContractCount int64
+	Outstanding   decimal.Decimal
+}
+
+// PendingExpiryRun summarises one pass of the stale registration job.
+type PendingExpiryRun struct {
+	Reminded          int
+	Rejected          int
+	Pending           int64
+	OldestSubmittedAt *time.Time
+}
+
+// DormancyRun summarises one pass of the dormancy job.
+type DormancyRun struct {
+	Flagged int
+	Dormant int64
+}
+
+// StoredImage is an uploaded image as listed by the storage provider.
+type StoredImage struct {
+	URL       string
+	CreatedAt time.Time
+}
+
+// StoredImagePage is one page of a storage listing. NextCursor is empty on
+// the last page.
+type StoredImagePage struct {
+	Images     []StoredImage
+	NextCursor string
+}
+
+// MediaCleanupRun summarises one pass of the orphaned image job.
+type MediaCleanupRun struct {
+	Scanned int
+	Deleted int
+	Failed  int
+}
+
+// AccountClosure records a customer closing their account. Their personal
+// data is anonymized once AnonymizeAfter passes, the cooling-off period in
+// which complaints and disputes can still be traced to them.
+type AccountClosure struct {
+	ID             uint64
+	CustomerID     uint64
+	Reason         string
+	ClosedAt       time.Time
+	AnonymizeAfter time.Time
+	AnonymizedAt   *time.Time
+}
+
+// ClosureBlockers is what keeps a customer from closing their account:
+// contracts still running and principal not yet repaid.
+type ClosureBlockers struct {
+	ActiveContracts      int64
+	OutstandingPrincipal decimal.Decimal
+}
+
+// AccountClosureRun summarises one pass of the anonymization job.
+type AccountClosureRun struct {
+	Anonymized int
+}
+
+// CalendarToken grants read access to the installment calendar of one
+// contract without a login, for calendar apps that subscribe to a URL. Only
+// the SHA-256 hash of the token is stored.
+type CalendarToken struct {
+	ID             uint64
+	CustomerID     uint64
+	ContractNumber string
+	TokenHash      string
+	CreatedAt      time.Time
+	RevokedAt      *time.Time
+}
+
+// Holiday is a day on which installments do not fall due. Date is midnight
+// UTC of the calendar day.
+type Holiday struct {
+	Date      time.Time
+	Name      string
+	UpdatedBy uint64
+	UpdatedAt time.Time
+}
+
+// DueDatePolicy moves installment due dates that fall on weekends or
+// holidays and sets how many days an installment may be late before it
+// counts as overdue. The zero policy keeps nominal due dates without grace.
+type DueDatePolicy struct {
+	Shift     installment.Shift
+	GraceDays int
+	UpdatedBy uint64
+	UpdatedAt time.Time
+}
+
+// DueDateCalendar is the policy together with every holiday, loaded at once
+// to build the rules that adjust due dates.
+type DueDateCalendar struct {
+	Policy   DueDatePolicy
+	Holidays []Holiday
+}
+
+// DormancyStats reports dormant accounts for AML housekeeping. Flagged and
+// Reactivated count the customers that entered and left dormancy since Since.
+type DormancyStats struct {
+	Dormant         int64
+	OldestDormantAt *time.Time
+	Since           time.Time
+	Flagged         int64
+	Reactivated     int64
+}
+
+// AMLRule names the screening rule that opened an AML case.
+type AMLRule string
+
+const (
+	AMLLargeTransaction AMLRule = "LARGE_TRANSACTION"
+	AMLRapidSequence    AMLRule = "RAPID_SEQUENCE"
+)
+
+type AMLCaseStatus string
+
+const (
+	AMLCaseOpen     AMLCaseStatus = "OPEN"
+	AMLCaseReviewed AMLCaseStatus = "REVIEWED"
+	AMLCaseReported AMLCaseStatus = "REPORTED"
+)
+
+// CanMoveTo reports whether a case in status s may move to next. A case can
+// be reported with or without a prior review; a reported case is final.
+func (s AMLCaseStatus) CanMoveTo(next AMLCaseStatus) bool {
+	switch s {
+	case AMLCaseOpen:
+		return next == AMLCaseReviewed || next == AMLCaseReported
+	case AMLCaseReviewed:
+		return next == AMLCaseReported
+	default:
+		return false
+	}
+}
+
+// AMLCase is a transaction flagged by a screening rule for compliance
+// review. AmountIDR is the OTR amount converted at the contract's FX rate.
+// Transaction and Customer are only loaded for exports.
+type AMLCase struct {
+	ID              uint64
+	CustomerID      uint64
+	TransactionID   uint64
+	Rule            AMLRule
+	AmountIDR       decimal.Decimal
+	Detail          string
+	Status          AMLCaseStatus
+	ReviewNote      string
+	ReviewedBy      *uint64
+	ReviewedAt      *time.Time
+	ReportReference string
+	ReportedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Transaction *Transaction
+	Customer    *Customer
+}
+
+// AMLScreeningRun summarises one pass of the AML screening job.
+type AMLScreeningRun struct {
+	Screened int
+	Flagged  int
+}
+
+type CollectionCaseStatus string
+
+const (
+	CollectionOpen         CollectionCaseStatus = "OPEN"
+	CollectionInProgress   CollectionCaseStatus = "IN_PROGRESS"
+	CollectionRepossession CollectionCaseStatus = "REPOSSESSION"
+	CollectionRepossessed  CollectionCaseStatus = "REPOSSESSED"
+	CollectionResolved     CollectionCaseStatus = "RESOLVED"
+)
+
+// CollectionActiveStatuses are the statuses of a case still being worked.
+var CollectionActiveStatuses = []CollectionCaseStatus{CollectionOpen, CollectionInProgress, CollectionRepossession}
+
+// Closed reports whether a case in status s is final. A repossessed asset
+// or a customer who caught up closes the case.
+func (s CollectionCaseStatus) Closed() bool {
+	return s == CollectionRepossessed || s == CollectionResolved
+}
+
+// CanMoveTo reports whether a case in status s may move to next. A case can
+// be resolved at any point before it closes, even during repossession.
+func (s CollectionCaseStatus) CanMoveTo(next CollectionCaseStatus) bool {
+	switch s {
+	case CollectionOpen:
+		return next == CollectionInProgress || next == CollectionRepossession || next == CollectionResolved
+	case CollectionInProgress:
+		return next == CollectionRepossession || next == CollectionResolved
+	case CollectionRepossession:
+		return next == CollectionRepossessed || next == CollectionResolved
+	default:
+		return false
+	}
+}
+
+// CollectionCase follows a contract that fell far behind through calls,
+// visits and, when those fail, repossession of the asset. DaysPastDue and
+// OverdueAmount (in IDR) are taken when the case opens. Transaction and
+// Customer are only loaded when reading cases.
+type CollectionCase struct {
+	ID             uint64
+	TransactionID  uint64
+	CustomerID     uint64
+	Status         CollectionCaseStatus
+	CollectorID    *uint64
+	DaysPastDue    int
+	OverdueAmount  decimal.Decimal
+	LastActivityAt *time.Time
+	ClosedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	Transaction *Transaction
+	Customer    *Customer
+}
+
+type CollectionActivityType string
+
+const (
+	CollectionCall         CollectionActivityType = "CALL"
+	CollectionVisit        CollectionActivityType = "VISIT"
+	CollectionPromiseToPay CollectionActivityType = "PROMISE_TO_PAY"
+)
+
+// CollectionActivity is one contact a collector logged on a case. A promise
+// to pay records the amount the customer committed to and the day it is due.
+type CollectionActivity struct {
+	ID            uint64
+	CaseID        uint64
+	Type          CollectionActivityType
+	Note          string
+	PromiseAmount *decimal.Decimal
+	PromiseDate   *time.Time
+	CreatedBy     uint64
+	CreatedAt     time.Time
+}
+
+// CollectionRun summarises one pass of the job that opens collection cases.
+type CollectionRun struct {
+	Scanned int
+	Opened  int
+}
+
+// CollectorLoad is the open work of one collector. PendingPromises counts
+// promises to pay that are not due yet and BrokenPromises the ones whose
+// date passed while the case stayed open.
+type CollectorLoad struct {
+	CollectorID     uint64
+	CollectorName   string
+	ActiveCases     int64
+	OverdueAmount   decimal.Decimal
+	PendingPromises int64
+	BrokenPromises  int64
+}
+
+// CollectionDashboard summarises case loads for collection managers.
+type CollectionDashboard struct {
+	ByStatus   map[CollectionCaseStatus]int64
+	Unassigned int64
+	Collectors []CollectorLoad
+}
+
+// WriteOffStatus tracks the maker-checker review of a write-off.
+type WriteOffStatus string
+
+const (
+	WriteOffPending  WriteOffStatus = "PENDING"
+	WriteOffApproved WriteOffStatus = "APPROVED"
+	WriteOffRejected WriteOffStatus = "REJECTED"
+)
+
+// WriteOff takes a delinquent contract off the books. Amount is the unpaid
+// balance in IDR and DaysPastDue the delinquency when it was proposed.
+// RecoveredAmount adds up the recoveries received after approval.
+// Transaction is only loaded when reading write-offs.
+type WriteOff struct {
+	ID              uint64
+	TransactionID   uint64
+	CustomerID      uint64
+	Status          WriteOffStatus
+	DaysPastDue     int
+	Amount          decimal.Decimal
+	RecoveredAmount decimal.Decimal
+	Reason          string
+	RequestedBy     uint64
+	ReviewerID      *uint64
+	ReviewNote      string
+	ReviewedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Transaction *Transaction
+}
+
+// Outstanding is the part of the written-off amount not recovered yet.
+func (w WriteOff) Outstanding() decimal.Decimal {
+	return w.Amount.Sub(w.RecoveredAmount)
+}
+
+// WriteOffRecovery is money received on a contract after it was written off.
+type WriteOffRecovery struct {
+	ID         uint64
+	WriteOffID uint64
+	Amount     decimal.Decimal
+	ReceivedOn time.Time
+	Reference  string
+	Note       string
+	CreatedBy  uint64
+	CreatedAt  time.Time
+}
+
+// WriteOffPeriod summarises the write-offs approved in one calendar month.
+// Recovered is what came back so far on those contracts, Collected the
+// recoveries received during the month whenever the contract was written
+// off.
+type WriteOffPeriod struct {
+	Period    time.Time
+	Count     int64
+	Amount    decimal.Decimal
+	Recovered decimal.Decimal
+	Collected decimal.Decimal
+}
+
+// RecoveryRate is the share of the period's written-off amount recovered so
+// far, zero for a month without write-offs.
+func (p WriteOffPeriod) RecoveryRate() decimal.Decimal {
+	if !p.Amount.IsPositive() {
+		return decimal.Zero
+	}
+	return p.Recovered.DivRound(p.Amount, 4)
+}
+
+type LedgerAccount string
+
+const (
+	LedgerLoanReceivable  LedgerAccount = "LOAN_RECEIVABLE"
+	LedgerBadDebtExpense  LedgerAccount = "BAD_DEBT_EXPENSE"
+	LedgerCash            LedgerAccount = "CASH"
+	LedgerBadDebtRecovery LedgerAccount = "BAD_DEBT_RECOVERY"
+)
+
+// LedgerReference names the kind of record a ledger posting comes from.
+type LedgerReference string
+
+const (
+	LedgerWriteOff         LedgerReference = "WRITE_OFF"
+	LedgerWriteOffRecovery LedgerReference = "WRITE_OFF_RECOVERY"
+)
+
+// LedgerEntry is one line of a double-entry posting, in IDR. Exactly one of
+// Debit and Credit is non-zero.
+type LedgerEntry struct {
+	ID            uint64
+	ReferenceType LedgerReference
+	ReferenceID   uint64
+	TransactionID uint64
+	Account       LedgerAccount
+	Debit         decimal.Decimal
+	Credit        decimal.Decimal
+	EntryDate     time.Time
+	Description   string
+	CreatedAt     time.Time
+}
+
+// NewPosting returns the balanced pair of entries moving amount from the
+// credit account to the debit account.
+func NewPosting(referenceType LedgerReference, referenceID, transactionID uint64, debit, credit LedgerAccount, amount decimal.Decimal, entryDate time.Time, description string) []LedgerEntry {
+	entry := LedgerEntry{
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		TransactionID: transactionID,
+		Debit:         decimal.Zero,
+		Credit:        decimal.Zero,
+		EntryDate:     entryDate,
+		Description:   description,
+	}
+
+	debitEntry, creditEntry := entry, entry
+	debitEntry.Account, debitEntry.Debit = debit, amount
+	creditEntry.Account, creditEntry.Credit = credit, amount
+	return []LedgerEntry{debitEntry, creditEntry}
+}
+
+// ConcentrationDimension is the contract attribute a concentration limit
+// groups the portfolio by.
+type ConcentrationDimension string
+
+const (
+	ConcentrationAssetCategory ConcentrationDimension = "ASSET_CATEGORY"
+	ConcentrationRegion        ConcentrationDimension = "REGION"
+)
+
+// Normalize returns value the way contracts store it for the dimension:
+// asset categories in lower case, like tenor products, and region codes in
+// upper case.
+func (d ConcentrationDimension) Normalize(value string) string {
+	value = strings.TrimSpace(value)
+	if d == ConcentrationRegion {
+		return strings.ToUpper(value)
+	}
+	return strings.ToLower(value)
+}
+
+// ConcentrationLimit caps the share, in percent, of the active non-sandbox
+// principal held by contracts with Value in Dimension. A limit that is not
+// enforced is only reported when breached; an enforced one also rejects
+// transactions that would take the share over MaxPercent. BreachedAt is set
+// by the evaluation job while the limit is breached.
+type ConcentrationLimit struct {
+	ID         uint64
+	Dimension  ConcentrationDimension
+	Value      string
+	MaxPercent decimal.Decimal
+	Enforce    bool
+	BreachedAt *time.Time
+	UpdatedBy  uint64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Concentration is the active principal, in IDR, held by one value of a
+// dimension and its share of the portfolio in percent. Limit is nil when no
+// limit is set for the value.
+type Concentration struct {
+	Dimension ConcentrationDimension
+	Value     string
+	Principal decimal.Decimal
+	Percent   decimal.Decimal
+	Limit     *ConcentrationLimit
+}
+
+// Breached reports whether the share is over the limit set for the value.
+func (c Concentration) Breached() bool {
+	return c.Limit != nil && c.Percent.GreaterThan(c.Limit.MaxPercent)
+}
+
+// SharePercent returns part as a percentage of total rounded to two
+// decimals, or zero for an empty portfolio.
+func SharePercent(part, total decimal.Decimal) decimal.Decimal {
+	if !total.IsPositive() {
+		return decimal.Zero
+	}
+	return part.Mul(decimal.NewFromInt(100)).DivRound(total, 2)
+}
+
+// ConcentrationReport is the portfolio broken down by asset category and by
+// region. Limits are only evaluated once TotalPrincipal reaches
+// MinPortfolio, so a young portfolio is not held to shares it cannot have
+// yet.
+type ConcentrationReport struct {
+	TotalPrincipal decimal.Decimal
+	MinPortfolio   decimal.Decimal
+	Concentrations []Concentration
+	GeneratedAt    time.Time
+}
+
+// Evaluated reports whether the portfolio is large enough for limits to
+// apply. An empty portfolio never is, whatever the minimum.
+func (r ConcentrationReport) Evaluated() bool {
+	return r.TotalPrincipal.IsPositive() && r.TotalPrincipal.GreaterThanOrEqual(r.MinPortfolio)
+}
+
+// ConcentrationRun summarises one run of the evaluation job: the limits it
+// checked, how many are breached, and how many started or stopped being
+// breached on this run.
+type ConcentrationRun struct {
+	Limits   int
+	Breached int
+	Opened   int
+	Cleared  int
+}
+
+// PartnerDebugRecord is a partner API request and the response it got,
+// stored with credentials and personal data masked while debug recording is
+// on for the key that made it.
+type PartnerDebugRecord struct {
+	ID             uint64
+	PartnerID      uint64
+	Sandbox        bool
+	Method         string
+	Path           string
+	StatusCode     int
+	RequestHeaders map[string]string
+	RequestBody    string
+	ResponseBody   string
+	DurationMs     int64
+	RequestID      string
+	TraceID        string
+	CreatedAt      time.Time
+}
+
+type NotificationTemplate string
+
+const (
+	TemplateVerificationReminder NotificationTemplate = "verification_reminder"
+	TemplateVerificationExpired  NotificationTemplate = "verification_expired"
+	TemplateMonthlyStatement     NotificationTemplate = "monthly_statement"
+	TemplateAccountDormant       NotificationTemplate = "account_dormant"
+	TemplateAccountClosed        NotificationTemplate = "account_closed"
+	TemplatePaymentReminder      NotificationTemplate = "payment_reminder"
+	TemplateVerificationApproved NotificationTemplate = "verification_approved"
+	TemplateVerificationRejected NotificationTemplate = "verification_rejected"
+)
+
+// NotificationTemplates lists every template a notifier must be able to render.
+var NotificationTemplates = []NotificationTemplate{TemplateVerificationReminder, TemplateVerificationExpired, TemplateMonthlyStatement, TemplateAccountDormant, TemplateAccountClosed, TemplatePaymentReminder, TemplateVerificationApproved, TemplateVerificationRejected}
+
+// PushTemplates lists the notifications also delivered to the mobile app.
+// Push is kept to time-sensitive events; everything else is email only.
+var PushTemplates = []NotificationTemplate{TemplatePaymentReminder, TemplateVerificationApproved, TemplateVerificationRejected, TemplateVerificationExpired}
+
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "EMAIL"
+	ChannelSMS   NotificationChannel = "SMS"
+	ChannelPush  NotificationChannel = "PUSH"
+)
+
+// Communication is one notification sent, or attempted, to a customer.
+type Communication struct {
+	ID         uint64
+	CustomerID uint64
+	Channel    NotificationChannel
+	Template   NotificationTemplate
+	Data       map[string]string
+	Status     CommunicationStatus
+	Error      string
+	CreatedAt  time.Time
+}
+
+type CommunicationStatus string
+
+const (
+	CommunicationSent   CommunicationStatus = "SENT"
+	CommunicationFailed CommunicationStatus = "FAILED"
+)
+
+// EmailMessage is a rendered email in the outbound queue. A message that
+// fails is tried again with backoff until it is SENT, or left FAILED once it
+// runs out of attempts or the provider rejects it for good.
+type EmailMessage struct {
+	ID                uint64
+	CustomerID        uint64
+	Recipient         string
+	Template          NotificationTemplate
+	Language          Language
+	Subject           string
+	Body              string
+	Status            EmailStatus
+	Attempts          int
+	NextAttemptAt     time.Time
+	LastError         string
+	Provider          string
+	ProviderMessageID string
+	SentAt            *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type EmailStatus string
+
+const (
+	EmailPending EmailStatus = "PENDING"
+	EmailSent    EmailStatus = "SENT"
+	EmailFailed  EmailStatus = "FAILED"
+)
+
+// RenderedEmail is a notification template rendered without being sent.
+type RenderedEmail struct {
+	Template NotificationTemplate
+	Language Language
+	Subject  string
+	Body     string
+}
+
+// Device is a mobile app installation that receives push notifications. A
+// token belongs to one customer at a time; registering it again under
+// another customer moves it.
+type Device struct {
+	ID         uint64
+	CustomerID uint64
+	Token      string
+	Platform   DevicePlatform
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+type DevicePlatform string
+
+const (
+	PlatformAndroid DevicePlatform = "ANDROID"
+	PlatformIOS     DevicePlatform = "IOS"
+	PlatformWeb     DevicePlatform = "WEB"
+)
+
+// NotificationPreferences are the channels a customer has opted into. A
+// customer who never saved preferences receives every channel.
+type NotificationPreferences struct {
+	CustomerID uint64
+	Email      bool
+	Push       bool
+	UpdatedAt  time.Time
+}
+
+// DefaultNotificationPreferences enables every channel.
+func DefaultNotificationPreferences(customerID uint64) *NotificationPreferences {
+	return &NotificationPreferences{CustomerID: customerID, Email: true, Push: true}
+}
+
+// Allows reports whether channel is enabled. Channels without a preference,
+// such as SMS, are always allowed.
+func (p *NotificationPreferences) Allows(channel NotificationChannel) bool {
+	switch channel {
+	case ChannelEmail:
+		return p.Email
+	case ChannelPush:
+		return p.Push
+	default:
+		return true
+	}
+}
+
+// PaymentReminder records that a customer was reminded about an installment,
+// so each installment is reminded at most once.
+type PaymentReminder struct {
+	ID            uint64
+	TransactionID uint64
+	Installment   int
+	DueDate       time.Time
+	CreatedAt     time.Time
+}
+
+// PaymentReminderRun summarises one pass of the payment reminder job.
+type PaymentReminderRun struct {
+	Due    int
+	Sent   int
+	Failed int
+}
+
+// FeatureFlag switches a capability on without a redeploy. A flag that is not
+// Enabled can still be on for the partners in PartnerIDs, which is how a
+// change is piloted with a few partners before it is rolled out to everyone.
+type FeatureFlag struct {
+	Key         string
+	Description string
+	Enabled     bool
+	PartnerIDs  []uint64
+	UpdatedBy   uint64
+	UpdatedAt   time.Time
+}
+
+// PartnerFeeSchedule is the admin fee and commission negotiated with a
+// partner. TenorMonths zero is the partner's default and a schedule for the
+// contract's own tenor takes precedence over it. Rates are fractions of the
+// OTR amount, so 0.015 is 1.5%.
+type PartnerFeeSchedule struct {
+	ID             uint64
+	PartnerID      uint64
+	TenorMonths    uint8
+	AdminFeeFlat   decimal.Decimal
+	AdminFeeRate   decimal.Decimal
+	CommissionRate decimal.Decimal
+	UpdatedBy      uint64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// PartnerCommission is one partner's contract volume and earned commission
+// over a month, in IDR.
+type PartnerCommission struct {
+	PartnerID     uint64
+	PartnerName   string
+	ContractCount int64
+	OTRAmount     decimal.Decimal
+	AdminFee      decimal.Decimal
+	Commission    decimal.Decimal
+}
+
+type PaymentStatus string
+
+const (
+	PaymentPending PaymentStatus = "PENDING"
+	PaymentPaid    PaymentStatus = "PAID"
+	PaymentFailed  PaymentStatus = "FAILED"
+)
+
+// Payment is a repayment collected by an external payment gateway against a
+// contract. ExternalID is the gateway's own identifier, unique per provider.
+type Payment struct {
+	ID            uint64
+	TransactionID uint64
+	Provider      string
+	ExternalID    string
+	Amount        decimal.Decimal
+	Currency      string
+	Status        PaymentStatus
+	PaidAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type CallbackOutcome string
+
+const (
+	CallbackProcessed CallbackOutcome = "PROCESSED"
+	CallbackDuplicate CallbackOutcome = "DUPLICATE"
+	CallbackUnmatched CallbackOutcome = "UNMATCHED"
+	CallbackRejected  CallbackOutcome = "REJECTED"
+)
+
+// PaymentCallback archives the raw body of every gateway callback we receive.
+// EventID is nil for callbacks that failed verification, so only verified
+// events take part in replay detection.
+type PaymentCallback struct {
+	ID         uint64
+	Provider   string
+	EventID    *string
+	Outcome    CallbackOutcome
+	Error      string
+	Payload    string
+	ReceivedAt time.Time
+}
+
+// DirectDebitMandate is a customer's consent to have installments charged
+// automatically. Token references the payment method stored at the gateway,
+// never the card or account number itself.
+type DirectDebitMandate struct {
+	CustomerID uint64
+	Provider   string
+	Token      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// DirectDebitAttempt is one charge initiated for an installment. Attempt
+// counts from 1 for every installment.
+type DirectDebitAttempt struct {
+	ID            uint64
+	TransactionID uint64
+	Installment   int
+	Attempt       int
+	Provider      string
+	ExternalID    string
+	Amount        decimal.Decimal
+	Currency      string
+	Status        PaymentStatus
+	FailureReason string
+	AttemptedAt   time.Time
+}
+
+// DirectDebitRun summarises one pass of the collection job. Deferred
+// installments are waiting for a pending charge or for the retry delay,
+// exhausted ones have used up every attempt.
+type DirectDebitRun struct {
+	Due       int
+	Charged   int
+	Pending   int
+	Failed    int
+	Deferred  int
+	Exhausted int
+}
+
+// PromotionTarget is the contract charge a promotion discounts.
+type PromotionTarget string
+
+const (
+	PromotionAdminFee PromotionTarget = "ADMIN_FEE"
+	PromotionInterest PromotionTarget = "INTEREST"
+)
+
+// DiscountType says how DiscountValue is read: a fraction of the discounted
+// charge for PERCENT, an IDR amount for FLAT.
+type DiscountType string
+
+const (
+	DiscountPercent DiscountType = "PERCENT"
+	DiscountFlat    DiscountType = "FLAT"
+)
+
+// Promotion is a promo code partners may pass when creating a transaction.
+// A nil PartnerID and a zero TenorMonths match any partner and tenor.
+// MaxDiscount and MinOTRAmount are in IDR. Zero limits and caps are not
+// enforced.
+type Promotion struct {
+	ID             uint64
+	Code           string
+	Description    string
+	Target         PromotionTarget
+	DiscountType   DiscountType
+	DiscountValue  decimal.Decimal
+	MaxDiscount    decimal.Decimal
+	ValidFrom      time.Time
+	ValidUntil     time.Time
+	PartnerID      *uint64
+	TenorMonths    uint8
+	MinOTRAmount   decimal.Decimal
+	MaxRedemptions int
+	MaxPerCustomer int
+	Active         bool
+	UpdatedBy      uint64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// PromotionRedemption records a promotion used on a contract. Amount is the
+// discount granted, in the contract currency.
+type PromotionRedemption struct {
+	ID             uint64
+	PromotionID    uint64
+	CustomerID     uint64
+	TransactionID  uint64
+	DiscountAmount decimal.Decimal
+	Currency       string
+	RedeemedAt     time.Time
+}
+
+// ReferralCode is the code a customer shares to refer new customers. Codes
+// are generated on first request, one per customer.
+type ReferralCode struct {
+	CustomerID uint64
+	Code       string
+	CreatedAt  time.Time
+}
+
+type ReferralStatus string
+
+const (
+	ReferralAccepted ReferralStatus = "ACCEPTED"
+	ReferralRejected ReferralStatus = "REJECTED"
+)
+
+// Referral links a customer who registered with a referral code to the
+// referrer. A referral that failed a fraud check is kept as REJECTED with the
+// reason, so it never counts as a conversion.
+type Referral struct {
+	ID           uint64
+	ReferrerID   uint64
+	RefereeID    uint64
+	Code         string
+	Status       ReferralStatus
+	RejectReason string
+	DeviceID     string
+	IPAddress    string
+	CreatedAt    time.Time
+}
+
+// ReferralConversion is one referrer's referrals registered over a month.
+// Referred counts every registration using the code, Rejected those flagged
+// by fraud checks, and Converted the accepted referees that have since booked
+// a contract.
+type ReferralConversion struct {
+	ReferrerID   uint64
+	ReferrerName string
+	Code         string
+	Referred     int64
+	Rejected     int64
+	Converted    int64
+}
+
+// OTPPurpose is the action an OTP authorises. A verified OTP can only be
+// consumed by the action it was requested for.
+type OTPPurpose string
+
+const (
+	OTPRegistration   OTPPurpose = "REGISTRATION"
+	OTPChangePassword OTPPurpose = "CHANGE_PASSWORD"
+	OTPChangeContact  OTPPurpose = "CHANGE_CONTACT"
+	OTPReactivation   OTPPurpose = "REACTIVATION"
+)
+
+// OTPChallenge is a code sent to Destination and awaiting verification. Only
+// the code hash is kept. CustomerID is zero for registration, where the
+// customer does not exist yet.
+type OTPChallenge struct {
+	ID          string
+	Purpose     OTPPurpose
+	Channel     string
+	Destination string
+	CustomerID  uint64
+	CodeHash    string
+	Attempts    int
+	ExpiresAt   time.Time
+}
+
+// OTPVerification proves a challenge was answered correctly. Token is handed
+// to the client once and exchanged by the guarded action.
+type OTPVerification struct {
+	Token       string
+	Purpose     OTPPurpose
+	Channel     string
+	Destination string
+	CustomerID  uint64
+	VerifiedAt  time.Time
+	ExpiresAt   time.Time
+}
+
+// ImpersonationSession lets an admin see the app as a customer through a
+// short-lived, read-only token. The session ends when it expires or the
+// admin ends it, whichever comes first.
+type ImpersonationSession struct {
+	ID         uint64
+	AdminID    uint64
+	CustomerID uint64
+	Reason     string
+	ExpiresAt  time.Time
+	EndedAt    *time.Time
+	CreatedAt  time.Time
+}
+
+// Active reports whether tokens issued for the session are still accepted.
+func (s ImpersonationSession) Active(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// ImpersonationRequest is one request made with an impersonation token.
+type ImpersonationRequest struct {
+	ID        uint64
+	SessionID uint64
+	Method    string
+	Path      string
+	Status    int
+	CreatedAt time.Time
+}
+
+// GateScope names a group of routes that can be closed for maintenance or
+// outside business hours.
+type GateScope string
+
+const (
+	GatePartnerTransactions GateScope = "partner_transactions"
+)
+
+// GateScopes lists every scope admins can put into maintenance.
+var GateScopes = []GateScope{GatePartnerTransactions}
+
+// MaintenanceMode closes a scope until an admin clears it or Until passes.
+type MaintenanceMode struct {
+	Scope     GateScope
+	Message   string
+	Until     *time.Time
+	UpdatedBy uint64
+	UpdatedAt time.Time
+}
+
+// Active reports whether the mode still closes its scope at now.
+func (m MaintenanceMode) Active(now time.Time) bool {
+	return m.Until == nil || now.Before(*m.Until)
+}
+
+const (
+	GateReasonMaintenance   = "maintenance"
+	GateReasonBusinessHours = "outside_business_hours"
+)
+
+// GateClosure explains why a scope is closed. RetryAt is zero when the
+// reopening time is not known.
+type GateClosure struct {
+	Scope   GateScope
+	Reason  string
+	Message string
+	RetryAt time.Time
+}
+
+// PartnerQuota caps how many transactions a partner may create in one
+// business day and their total OTR value in IDR. A zero cap is unlimited.
+type PartnerQuota struct {
+	PartnerID   uint64
+	DailyCount  int64
+	DailyVolume decimal.Decimal
+	UpdatedBy   uint64
+	UpdatedAt   time.Time
+}
+
+// QuotaUsage is what a partner has used of its quota since the business day
+// started. The counters reset at ResetsAt.
+type QuotaUsage struct {
+	Count    int64
+	Volume   decimal.Decimal
+	ResetsAt time.Time
+}
+
+// APIUsage counts the requests a partner made through its API keys on one
+// UTC day, split by whether the rate limiter let them through.
+type APIUsage struct {
+	Day       time.Time
+	Allowed   int64
+	Throttled int64
+}
+
+// QuotaReservation is the usage taken by one transaction, kept so it can be
+// given back when the transaction is not committed.
+type QuotaReservation struct {
+	PartnerID uint64
+	Day       string
+	Volume    decimal.Decimal
+}
+
+type BatchStatus string
+
+const (
+	BatchPending   BatchStatus = "PENDING"
+	BatchCompleted BatchStatus = "COMPLETED"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemPending    BatchItemStatus = "PENDING"
+	BatchItemProcessing BatchItemStatus = "PROCESSING"
+	BatchItemSucceeded  BatchItemStatus = "SUCCEEDED"
+	BatchItemFailed     BatchItemStatus = "FAILED"
+)
+
+// BatchOwner is whoever submitted a batch: a partner over its API key, or a
+// signed in customer when PartnerID is nil. Only the owner can read it back.
+type BatchOwner struct {
+	PartnerID  *uint64
+	CustomerID *uint64
+	Sandbox    bool
+}
+
+// TransactionBatch is a set of transactions submitted together and created
+// in the background, each item in its own database transaction.
+type TransactionBatch struct {
+	ID          uint64
+	Owner       BatchOwner
+	Status      BatchStatus
+	Items       []TransactionBatchItem
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// TransactionBatchItem is one transaction request of a batch with its
+// outcome. ErrorCode uses the same codes as the single transaction endpoint.
+type TransactionBatchItem struct {
+	ID             uint64
+	BatchID        uint64
+	Sequence       int
+	CustomerNIK    string
+	TenorMonths    uint8
+	AssetName      string
+	OTRAmount      decimal.Decimal
+	AdminFee       *decimal.Decimal
+	Currency       string
+	PromoCode      string
+	Status         BatchItemStatus
+	TransactionID  *uint64
+	ContractNumber string
+	ErrorCode      string
+	ErrorMessage   string
+	ProcessedAt    *time.Time
+}
+
+// OwnedBy reports whether owner submitted the batch. A partner's sandbox and
+// live keys share its batches.
+func (b TransactionBatch) OwnedBy(owner BatchOwner) bool {
+	switch {
+	case b.Owner.PartnerID != nil:
+		return owner.PartnerID != nil && *owner.PartnerID == *b.Owner.PartnerID
+	case b.Owner.CustomerID != nil:
+		return owner.PartnerID == nil && owner.CustomerID != nil && *owner.CustomerID == *b.Owner.CustomerID
+	}
+	return false
+}
+
+type StatementStatus string
+
+const (
+	StatementPending StatementStatus = "PENDING"
+	StatementReady   StatementStatus = "READY"
+	StatementFailed  StatementStatus = "FAILED"
+)
+
+// TransactionStatement is the cached PDF statement of a contract. The
+// Fingerprint identifies the contract state it was rendered from, so a
+// stored file is reused only while the schedule it shows is still current.
+type TransactionStatement struct {
+	ID             uint64
+	ContractNumber string
+	CustomerID     uint64
+	Fingerprint    string
+	Status         StatementStatus
+	URL            string
+	Error          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// MonthlyStatement records the account summary emailed to a customer for one
+// calendar month, so a period is never sent twice. Period is written as
+// YYYY-MM in the business timezone.
+type MonthlyStatement struct {
+	ID         uint64
+	CustomerID uint64
+	Period     string
+	Status     CommunicationStatus
+	URL        string
+	Error      string
+	CreatedAt  time.Time
+}
+
+// MonthlyStatementRun summarises one pass of the monthly statement job.
+type MonthlyStatementRun struct {
+	Period string
+	Sent   int
+	Failed int
+}
+
+// CustomerNote is a free-text annotation an admin keeps on a customer
+// record. Pinned notes are listed first. Revisions holds the versions the
+// note had before each edit, newest first.
+type CustomerNote struct {
+	ID         uint64
+	CustomerID uint64
+	AuthorID   uint64
+	Body       string
+	Pinned     bool
+	UpdatedBy  uint64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Revisions  []CustomerNoteRevision
+}
+
+// CustomerNoteRevision is one earlier version of a note, with the admin who
+// wrote it and when.
+type CustomerNoteRevision struct {
+	ID        uint64
+	NoteID    uint64
+	Body      string
+	Pinned    bool
+	UpdatedBy uint64
+	UpdatedAt time.Time
+}
+
+type AttachmentType string
+
+const (
+	AttachmentInvoice       AttachmentType = "INVOICE"
+	AttachmentDeliveryProof AttachmentType = "DELIVERY_PROOF"
+	AttachmentBPKB          AttachmentType = "BPKB"
+	AttachmentOther         AttachmentType = "OTHER"
+)
+
+// TransactionAttachment is a supporting document a partner uploaded for a
+// transaction, such as an invoice or a BPKB scan. Deleted attachments keep
+// their row with DeletedAt and DeletedBy set and are no longer listed.
+type TransactionAttachment struct {
+	ID            uint64
+	TransactionID uint64
+	Type          AttachmentType
+	FileName      string
+	ContentType   string
+	Size          int64
+	URL           string
+	PartnerID     *uint64
+	CustomerID    *uint64
+	CreatedAt     time.Time
+	DeletedAt     *time.Time
+	DeletedBy     *uint64
+}
+
+// Region is an entry of the regions reference table used to group
+// transactions for management reporting. Code is the short form used in
+// contract numbers, e.g. JKT.
+type Region struct {
+	Code      string
+	Name      string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AssetCategory is a kind of asset that may be financed. Tenors and
+// concentration limits name it by Code, which is stored lowercase.
+type AssetCategory struct {
+	Code      string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReferenceDataResult counts the reference data rows written by one run of
+// the loader.
+type ReferenceDataResult struct {
+	AssetCategories int
+	Regions         int
+	Tenors          int
+	Holidays        int
+}
+
+// RegionVolume is the contract count and OTR volume, in IDR, booked in a
+// region during one month.
+type RegionVolume struct {
+	RegionCode    string
+	ContractCount int64
+	OTRAmount     decimal.Decimal
+}
+
+// CustomerExposure is the active principal, in IDR, a customer owes on one
+// tenor. It is kept in a summary table so limit checks read one row instead
+// of summing every transaction.
+type CustomerExposure struct {
+	CustomerID      uint64
+	TenorID         uint
+	ActivePrincipal decimal.Decimal
+	UpdatedAt       time.Time
+}
+
+// ExposureReconciliation summarises one consistency check of the exposure
+// summary against the transactions it is derived from.
+type ExposureReconciliation struct {
+	Checked  int
+	Repaired int
+}
+
+// CustomerEventType names a step in a customer's lifecycle recorded on the
+// timeline.
+type CustomerEventType string
+
+const (
+	CustomerRegistered         CustomerEventType = "REGISTERED"
+	CustomerVerified           CustomerEventType = "VERIFIED"
+	CustomerRejected           CustomerEventType = "REJECTED"
+	CustomerLimitSet           CustomerEventType = "LIMIT_SET"
+	CustomerTransactionCreated CustomerEventType = "TRANSACTION_CREATED"
+	CustomerPaymentReceived    CustomerEventType = "PAYMENT_RECEIVED"
+	CustomerSuspended          CustomerEventType = "SUSPENDED"
+	CustomerUnsuspended        CustomerEventType = "UNSUSPENDED"
+	CustomerLimitFrozen        CustomerEventType = "LIMIT_FROZEN"
+	CustomerLimitUnfrozen      CustomerEventType = "LIMIT_UNFROZEN"
+	CustomerDormant            CustomerEventType = "DORMANT"
+	CustomerReactivated        CustomerEventType = "REACTIVATED"
+	CustomerWrittenOff         CustomerEventType = "WRITTEN_OFF"
+	CustomerClosed             CustomerEventType = "CLOSED"
+	CustomerAnonymized         CustomerEventType = "ANONYMIZED"
+)
+
+// CustomerEventTypes lists every event type, in lifecycle order.
+var CustomerEventTypes = []CustomerEventType{
+	CustomerRegistered,
+	CustomerVerified,
+	CustomerRejected,
+	CustomerLimitSet,
+	CustomerTransactionCreated,
+	CustomerPaymentReceived,
+	CustomerSuspended,
+	CustomerUnsuspended,
+	CustomerLimitFrozen,
+	CustomerLimitUnfrozen,
+	CustomerDormant,
+	CustomerReactivated,
+	CustomerWrittenOff,
+	CustomerClosed,
+	CustomerAnonymized,
+}
+
+// CustomerEvent is one append-only entry of a customer's activity timeline.
+// It is written in the same database transaction as the change it records.
+// ActorID is the admin behind the change, nil for the customer or a partner.
+type CustomerEvent struct {
+	ID          uint64
+	CustomerID  uint64
+	Type        CustomerEventType
+	ActorID     *uint64
+	ReferenceID string
+	Data        map[string]string
+	OccurredAt  time.Time
+}
+
+// DashboardSnapshot is one update of the realtime admin dashboard.
+// Registrations holds only the sign-ups since the previous snapshot, the
+// counters are taken at GeneratedAt.
+type DashboardSnapshot struct {
+	Registrations         []CustomerEvent
+	VerificationQueue     int64
+	TransactionsPerMinute int64
+	GeneratedAt           time.Time
+}
+
+// RestrictionReason is the code an admin records when suspending a customer
+// or freezing one of their limits.
+type RestrictionReason string
+
+const (
+	RestrictionFraudSuspected  RestrictionReason = "FRAUD_SUSPECTED"
+	RestrictionDelinquency     RestrictionReason = "DELINQUENCY"
+	RestrictionKYCReview       RestrictionReason = "KYC_REVIEW"
+	RestrictionCustomerRequest RestrictionReason = "CUSTOMER_REQUEST"
+	RestrictionLegalHold       RestrictionReason = "LEGAL_HOLD"
+	RestrictionOther           RestrictionReason = "OTHER"
+)
+
+// CustomerRestriction blocks new transactions of a customer. Without a
+// TenorID it suspends the whole account, otherwise it freezes the limit of
+// that tenor only. A restriction stops applying once it is lifted or its
+// ExpiresAt has passed, whichever comes first.
+type CustomerRestriction struct {
+	ID          uint64
+	CustomerID  uint64
+	TenorID     *uint
+	TenorMonths uint8
+	Reason      RestrictionReason
+	Note        string
+	CreatedBy   uint64
+	ExpiresAt   *time.Time
+	LiftedAt    *time.Time
+	LiftedBy    *uint64
+	CreatedAt   time.Time
+}
+
+// IsSuspension reports whether r covers every tenor of the customer.
+func (r CustomerRestriction) IsSuspension() bool {
+	return r.TenorID == nil
+}
+
+// ActiveAt reports whether r still blocks transactions at now.
+func (r CustomerRestriction) ActiveAt(now time.Time) bool {
+	if r.LiftedAt != nil {
+		return false
+	}
+	return r.ExpiresAt == nil || r.ExpiresAt.After(now)
 }
 
-type Paginated struct {
-	Data       any
-	Total      int64
-	Page       int
-	Limit      int
-	TotalPages int
+// BlockingRestriction returns the restriction that stops a transaction on
+// tenorID at now, an account suspension taking precedence over a frozen
+// limit. It returns nil when nothing blocks the transaction.
+func BlockingRestriction(restrictions []CustomerRestriction, tenorID uint, now time.Time) *CustomerRestriction {
+	var frozen *CustomerRestriction
+	for i := range restrictions {
+		r := &restrictions[i]
+		if !r.ActiveAt(now) {
+			continue
+		}
+		if r.IsSuspension() {
+			return r
+		}
+		if *r.TenorID == tenorID && frozen == nil {
+			frozen = r
+		}
+	}
+	return frozen
 }
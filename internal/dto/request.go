@@ -5,6 +5,10 @@ import (
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/otp"
+	"github.com/shopspring/decimal"
 )
 
 type LoginRequest struct {
@@ -13,43 +17,241 @@ type LoginRequest struct {
 }
 
 type CreateProfileRequest struct {
-	NIK         string                `form:"nik" validate:"required,len=16,numeric"`
-	FullName    string                `form:"full_name" validate:"required"`
-	LegalName   string                `form:"legal_name" validate:"required"`
-	Password    string                `form:"password" validate:"required"`
-	BirthPlace  string                `form:"birth_place" validate:"required"`
-	BirthDate   string                `form:"birth_date" validate:"required,datetime=2006-01-02"`
-	Salary      float64               `form:"salary" validate:"required,gt=0"`
-	KtpPhoto    *multipart.FileHeader `form:"ktp_photo" validate:"required"`
-	SelfiePhoto *multipart.FileHeader `form:"selfie_photo" validate:"required"`
+	NIK          string                `form:"nik" validate:"required,len=16,numeric"`
+	FullName     string                `form:"full_name" validate:"required"`
+	LegalName    string                `form:"legal_name" validate:"required"`
+	Password     string                `form:"password" validate:"required"`
+	BirthPlace   string                `form:"birth_place" validate:"required"`
+	BirthDate    string                `form:"birth_date" validate:"required,datetime=2006-01-02"`
+	Salary       decimal.Decimal       `form:"salary" validate:"required,gt=0"`
+	KtpPhoto     *multipart.FileHeader `form:"ktp_photo" validate:"required"`
+	SelfiePhoto  *multipart.FileHeader `form:"selfie_photo" validate:"required"`
+	Language     string                `form:"language" validate:"omitempty,oneof=id en"`
+	Email        string                `form:"email" validate:"required,email,max=255"`
+	Phone        string                `form:"phone" validate:"required,e164"`
+	ReferralCode string                `form:"referral_code" validate:"omitempty,alphanum,max=16"`
+	OTPToken     string                `form:"otp_token" validate:"required,max=64"`
 }
 
 type UpdateProfileRequest struct {
-	FullName string  `json:"full_name" validate:"required"`
-	Salary   float64 `json:"salary" validate:"required,gt=0"`
+	FullName string `json:"full_name" validate:"required"`
+	// Salary hanya boleh sama dengan gaji saat ini, perubahan lewat /me/salary-changes
+	Salary   decimal.Decimal `json:"salary,omitempty" validate:"omitempty,gt=0"`
+	Language string          `json:"language,omitempty" validate:"omitempty,oneof=id en"`
+	// Kontak baru hanya disimpan bila disertai token OTP CHANGE_CONTACT ke alamat itu
+	Email         string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Phone         string `json:"phone,omitempty" validate:"omitempty,e164"`
+	EmailOTPToken string `json:"email_otp_token,omitempty" validate:"omitempty,max=64"`
+	PhoneOTPToken string `json:"phone_otp_token,omitempty" validate:"omitempty,max=64"`
+	// Versi profil yang diedit, boleh diganti header If-Match berisi ETag profil
+	Version *uint64 `json:"version,omitempty"`
 }
 
+type SalaryChangeRequest struct {
+	Salary  decimal.Decimal       `form:"salary" validate:"required,gt=0"`
+	Payslip *multipart.FileHeader `form:"payslip" validate:"required"`
+}
+
+type SalaryChangeReviewRequest struct {
+	Status domain.SalaryChangeStatus `json:"status" validate:"required,oneof=APPROVED REJECTED"`
+	Note   string                    `json:"note" validate:"max=500"`
+}
+
+type IncomeVerificationReviewRequest struct {
+	Status domain.IncomeVerificationStatus `json:"status" validate:"required,oneof=ACCEPTED REJECTED"`
+	Note   string                          `json:"note" validate:"max=500"`
+}
+
+// RiskTierRuleRequest replaces a risk tier rule. A bound left out is open
+// and every bound is inclusive.
+type RiskTierRuleRequest struct {
+	Priority       int              `json:"priority" validate:"min=0,max=10000"`
+	Tier           domain.RiskTier  `json:"tier" validate:"required,oneof=LOW MEDIUM HIGH"`
+	MinSalary      *decimal.Decimal `json:"min_salary,omitempty"`
+	MaxSalary      *decimal.Decimal `json:"max_salary,omitempty"`
+	MinScore       *int             `json:"min_score,omitempty" validate:"omitempty,min=0,max=1000"`
+	MaxScore       *int             `json:"max_score,omitempty" validate:"omitempty,min=0,max=1000"`
+	MinDaysPastDue *int             `json:"min_days_past_due,omitempty" validate:"omitempty,min=0"`
+	MaxDaysPastDue *int             `json:"max_days_past_due,omitempty" validate:"omitempty,min=0"`
+}
+
+// CreditScoreRequest sets the credit bureau score of a customer. A null
+// score clears it.
+type CreditScoreRequest struct {
+	Score *int `json:"score" validate:"omitempty,min=0,max=1000"`
+}
+
+type BlacklistEntryRequest struct {
+	NIK      string                   `json:"nik" validate:"omitempty,len=16,numeric"`
+	FullName string                   `json:"full_name" validate:"required,max=100"`
+	Reason   string                   `json:"reason" validate:"required,max=255"`
+	Source   string                   `json:"source" validate:"required,max=100"`
+	Severity domain.BlacklistSeverity `json:"severity" validate:"required,oneof=BLOCK FLAG"`
+}
+
+type DuplicateResolutionRequest struct {
+	Action domain.DuplicateAction `json:"action" validate:"required,oneof=IGNORED MERGED"`
+	Note   string                 `json:"note" validate:"max=500"`
+}
+
+// RestructuringRequest needs NewTenorMonths for EXTEND_TENOR and
+// TargetInstallment for REDUCE_INSTALLMENT.
+type RestructuringRequest struct {
+	ContractNumber    string                   `json:"contract_number" validate:"required,max=50"`
+	Type              domain.RestructuringType `json:"type" validate:"required,oneof=EXTEND_TENOR REDUCE_INSTALLMENT"`
+	NewTenorMonths    uint8                    `json:"new_tenor_months" validate:"required_if=Type EXTEND_TENOR"`
+	TargetInstallment decimal.Decimal          `json:"target_installment" validate:"required_if=Type REDUCE_INSTALLMENT,omitempty,gt=0"`
+	Reason            string                   `json:"reason" validate:"required,max=500"`
+}
+
+type RestructuringReviewRequest struct {
+	Status domain.RestructuringStatus `json:"status" validate:"required,oneof=APPROVED REJECTED"`
+	Note   string                     `json:"note" validate:"max=500"`
+}
+
+// FxRateUploadRequest carries one day's rates, each quoted as the IDR value
+// of one unit of the currency.
+type FxRateUploadRequest struct {
+	RateDate string              `json:"rate_date" validate:"required,datetime=2006-01-02"`
+	Rates    []FxRateItemRequest `json:"rates" validate:"required,min=1,dive"`
+}
+
+type FxRateItemRequest struct {
+	Currency  string          `json:"currency" validate:"required,len=3,alpha"`
+	RateToIDR decimal.Decimal `json:"rate_to_idr" validate:"required,gt=0"`
+}
+
+// FeatureFlagRequest replaces a flag. PartnerIDs turns the flag on for those
+// partners while Enabled is false.
+type FeatureFlagRequest struct {
+	Description string   `json:"description" validate:"max=255"`
+	Enabled     bool     `json:"enabled"`
+	PartnerIDs  []uint64 `json:"partner_ids" validate:"max=100,dive,gt=0"`
+}
+
+// HolidayRequest names the holiday on the date given in the path.
+type HolidayRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// DueDatePolicyRequest replaces the due date policy. GraceDays are calendar
+// days after the adjusted due date before an installment counts as overdue.
+type DueDatePolicyRequest struct {
+	Shift     installment.Shift `json:"shift" validate:"required,oneof=NONE NEXT_BUSINESS_DAY PREVIOUS_BUSINESS_DAY"`
+	GraceDays int               `json:"grace_days" validate:"min=0,max=30"`
+}
+
+// EmailPreviewRequest renders a template in Language with Data laid over
+// the sample values, so a preview only needs the variables being checked.
+type EmailPreviewRequest struct {
+	Language string            `json:"language,omitempty" validate:"omitempty,oneof=id en"`
+	Data     map[string]string `json:"data,omitempty"`
+}
+
+// FeeScheduleRequest replaces a partner's schedule for TenorMonths, where 0
+// sets the default used by tenors without their own schedule. AdminFeeFlat is
+// in IDR; both rates are fractions of the OTR amount.
+type FeeScheduleRequest struct {
+	TenorMonths    uint8           `json:"tenor_months"`
+	AdminFeeFlat   decimal.Decimal `json:"admin_fee_flat" validate:"gte=0"`
+	AdminFeeRate   decimal.Decimal `json:"admin_fee_rate" validate:"gte=0,lte=1"`
+	CommissionRate decimal.Decimal `json:"commission_rate" validate:"gte=0,lte=1"`
+}
+
+// DirectDebitMandateRequest opts a customer into automatic collection. Token
+// is the payment method reference issued by the gateway after the customer
+// authorised it there.
+type DirectDebitMandateRequest struct {
+	Provider string `json:"provider" validate:"required,max=32"`
+	Token    string `json:"token" validate:"required,max=255"`
+}
+
+// DeviceRequest registers the app installation that receives push
+// notifications. Token is the registration token issued by FCM.
+type DeviceRequest struct {
+	Token    string `json:"token" validate:"required,max=255"`
+	Platform string `json:"platform" validate:"required,oneof=ANDROID IOS WEB"`
+}
+
+// DeviceTokenRequest names the device to unregister, usually on logout.
+type DeviceTokenRequest struct {
+	Token string `json:"token" validate:"required,max=255"`
+}
+
+// NotificationPreferencesRequest switches channels on or off. Omitted
+// channels keep their current setting.
+type NotificationPreferencesRequest struct {
+	Email *bool `json:"email"`
+	Push  *bool `json:"push"`
+}
+
+// PromotionRequest replaces the promotion stored under the code in the path.
+// DiscountValue is a fraction of the discounted charge for PERCENT and an IDR
+// amount for FLAT. MaxDiscount and MinOTRAmount are in IDR; zero limits and
+// caps are not enforced.
+type PromotionRequest struct {
+	Description    string          `json:"description" validate:"max=255"`
+	Target         string          `json:"target" validate:"required,oneof=ADMIN_FEE INTEREST"`
+	DiscountType   string          `json:"discount_type" validate:"required,oneof=PERCENT FLAT"`
+	DiscountValue  decimal.Decimal `json:"discount_value" validate:"gt=0"`
+	MaxDiscount    decimal.Decimal `json:"max_discount" validate:"gte=0"`
+	ValidFrom      time.Time       `json:"valid_from" validate:"required"`
+	ValidUntil     time.Time       `json:"valid_until" validate:"required,gtfield=ValidFrom"`
+	PartnerID      *uint64         `json:"partner_id" validate:"omitempty,gt=0"`
+	TenorMonths    uint8           `json:"tenor_months"`
+	MinOTRAmount   decimal.Decimal `json:"min_otr_amount" validate:"gte=0"`
+	MaxRedemptions int             `json:"max_redemptions" validate:"gte=0"`
+	MaxPerCustomer int             `json:"max_per_customer" validate:"gte=0"`
+	Active         bool            `json:"active"`
+}
+
+// CreateTransactionRequest leaves AdminFee optional for partners with a fee
+// schedule, which then supplies the fee. PromoCode is matched case-insensitively.
+// AssetCategory is required only by tenors that restrict their categories.
 type CreateTransactionRequest struct {
-	CustomerNIK string  `json:"customer_nik" validate:"required,len=16,numeric"`
-	TenorMonths uint8   `json:"tenor_months" validate:"required,gt=0"`
-	AssetName   string  `json:"asset_name" validate:"required"`
-	OTRAmount   float64 `json:"otr_amount" validate:"required,gt=0"`
-	AdminFee    float64 `json:"admin_fee" validate:"required,gte=0"`
+	CustomerNIK   string           `json:"customer_nik" validate:"required,len=16,numeric"`
+	TenorMonths   uint8            `json:"tenor_months" validate:"required,gt=0"`
+	AssetName     string           `json:"asset_name" validate:"required"`
+	AssetCategory string           `json:"asset_category" validate:"omitempty,max=50"`
+	OTRAmount     decimal.Decimal  `json:"otr_amount" validate:"required,gt=0"`
+	AdminFee      *decimal.Decimal `json:"admin_fee" validate:"omitempty,gte=0"`
+	Currency      string           `json:"currency" validate:"omitempty,len=3,alpha"`
+	PromoCode     string           `json:"promo_code" validate:"omitempty,max=32"`
+	// RegionCode dan BranchCode opsional, bila kosong diambil dari profil partner
+	RegionCode string   `json:"region_code" validate:"omitempty,max=8"`
+	BranchCode string   `json:"branch_code" validate:"omitempty,max=32"`
+	Latitude   *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude  *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+
+	// Diisi dari API key partner, bukan dari body request
+	PartnerID *uint64 `json:"-"`
+	Sandbox   bool    `json:"-"`
 }
 
 type LimitItemRequest struct {
-	TenorMonths uint8   `json:"tenor_months" validate:"required,gt=0"`
-	LimitAmount float64 `json:"limit_amount" validate:"required,gte=0"`
+	TenorMonths uint8           `json:"tenor_months" validate:"required,gt=0"`
+	LimitAmount decimal.Decimal `json:"limit_amount" validate:"required,gte=0"`
+	Currency    string          `json:"currency" validate:"omitempty,len=3,alpha"`
+	// Versi limit yang terakhir dibaca, 0 bila tenor belum punya limit
+	Version *uint64 `json:"version" validate:"required"`
+	// Kosong berarti limit langsung berlaku, selain itu dijadwalkan
+	EffectiveFrom *time.Time `json:"effective_from"`
 }
 
 type SetLimits struct {
 	Limits []LimitItemRequest `json:"limits" validate:"required,min=1,dive"`
+	// Force mengizinkan limit di bawah pokok yang sedang berjalan, alasannya
+	// wajib diisi dan dicatat di timeline customer
+	Force  bool   `json:"force"`
+	Reason string `json:"reason" validate:"required_if=Force true,max=255"`
 }
 
 type CheckLimitRequest struct {
-	CustomerNIK       string  `json:"customer_nik" validate:"required,len=16,numeric"`
-	TenorMonths       uint8   `json:"tenor_months" validate:"required,gt=0"`
-	TransactionAmount float64 `json:"transaction_amount" validate:"required,gt=0"`
+	CustomerNIK       string          `json:"customer_nik" validate:"required,len=16,numeric"`
+	TenorMonths       uint8           `json:"tenor_months" validate:"required,gt=0"`
+	TransactionAmount decimal.Decimal `json:"transaction_amount" validate:"required,gt=0"`
+	Currency          string          `json:"currency" validate:"omitempty,len=3,alpha"`
+	AssetCategory     string          `json:"asset_category" validate:"omitempty,max=50"`
 }
 
 type VerificationRequest struct {
@@ -57,10 +259,148 @@ type VerificationRequest struct {
 	Reason string                    `json:"reason,omitempty"`
 }
 
+type RegisterPartnerRequest struct {
+	Name       string `json:"name" validate:"required,max=255"`
+	Email      string `json:"email" validate:"required,email,max=255"`
+	WebhookURL string `json:"webhook_url" validate:"omitempty,url,max=500"`
+}
+
+type PartnerSecurityRequest struct {
+	AllowedCIDRs          []string `json:"allowed_cidrs" validate:"max=50"`
+	RequireClientCert     bool     `json:"require_client_cert"`
+	ClientCertFingerprint string   `json:"client_cert_fingerprint" validate:"required_if=RequireClientCert true"`
+	RequireSignature      bool     `json:"require_signature"`
+	// RotateSigningSecret replaces the signing secret; requests signed with
+	// the old one are rejected right away.
+	RotateSigningSecret bool `json:"rotate_signing_secret"`
+}
+
+type OTPRequest struct {
+	Purpose     string `json:"purpose" validate:"required,oneof=REGISTRATION CHANGE_PASSWORD CHANGE_CONTACT REACTIVATION"`
+	Channel     string `json:"channel" validate:"required,oneof=SMS EMAIL"`
+	Destination string `json:"destination" validate:"required,max=255"`
+}
+
+type OTPVerifyRequest struct {
+	ChallengeID string `json:"challenge_id" validate:"required,hexadecimal,max=64"`
+	Code        string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// ChangePasswordRequest needs an OTP token verified for CHANGE_PASSWORD by
+// the same customer, except for the forced first change of a seeded account.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72,nefield=CurrentPassword"`
+	OTPToken        string `json:"otp_token" validate:"omitempty,max=64"`
+}
+
+// ReactivationRequest lifts dormancy. The birth date must match the one on
+// file and the OTP token must be verified for REACTIVATION on the customer's
+// registered email or phone.
+type ReactivationRequest struct {
+	BirthDate string `json:"birth_date" validate:"required,datetime=2006-01-02"`
+	OTPToken  string `json:"otp_token" validate:"required,max=64"`
+}
+
+// CloseAccountRequest closes the customer's own account. The password is
+// asked again so a session left open cannot be used to close it.
+type CloseAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+	Reason   string `json:"reason" validate:"required,max=500"`
+}
+
+// ImpersonationRequest records why an admin needs to act as a customer. The
+// reason is kept on the session for the audit trail.
+type ImpersonationRequest struct {
+	Reason string `json:"reason" validate:"required,min=10,max=255"`
+}
+
+// MaintenanceRequest closes a scope. Without Until the scope stays closed
+// until an admin clears it.
+type MaintenanceRequest struct {
+	Message string     `json:"message" validate:"required,max=255"`
+	Until   *time.Time `json:"until"`
+}
+
+// TenorProductRequest sets the financing rules of a tenor. Amounts are in
+// IDR; zero bounds and an empty category list leave the rule open. A zero
+// MaxAgeAtEnd applies the configured default age limit at contract end.
+type TenorProductRequest struct {
+	MinAmount       decimal.Decimal `json:"min_amount" validate:"gte=0"`
+	MaxAmount       decimal.Decimal `json:"max_amount" validate:"gte=0"`
+	MinAge          uint8           `json:"min_age" validate:"lte=100"`
+	MaxAge          uint8           `json:"max_age" validate:"lte=100"`
+	MaxAgeAtEnd     uint8           `json:"max_age_at_end" validate:"lte=100"`
+	AssetCategories []string        `json:"asset_categories" validate:"omitempty,dive,required,max=50"`
+}
+
+// CreateTenorRequest adds a tenor. Description defaults to "<n> Months".
+type CreateTenorRequest struct {
+	DurationMonths uint8  `json:"duration_months" validate:"required,gt=0"`
+	Description    string `json:"description" validate:"max=50"`
+	TenorProductRequest
+}
+
+// UpdateTenorRequest edits a tenor and replaces its product rules. The
+// duration cannot change once limits and transactions may refer to the tenor.
+type UpdateTenorRequest struct {
+	Description string `json:"description" validate:"required,max=50"`
+	TenorProductRequest
+}
+
+// CreateTransactionBatchRequest submits transactions to be created in the
+// background. Each item is validated like a single transaction request.
+type CreateTransactionBatchRequest struct {
+	Items []CreateTransactionRequest `json:"items" validate:"required,min=1"`
+}
+
+// ContractImportRequest backfills contracts signed outside the API, such as
+// those carried over from the previous loan system. They are stored as given
+// without limit checks, and from then on count against the customer's limits
+// like any other contract.
+type ContractImportRequest struct {
+	Contracts []ContractImportItemRequest `json:"contracts" validate:"required,min=1,dive"`
+}
+
+type ContractImportItemRequest struct {
+	ContractNumber         string          `json:"contract_number" validate:"required,max=50"`
+	CustomerNIK            string          `json:"customer_nik" validate:"required,len=16,numeric"`
+	TenorMonths            uint8           `json:"tenor_months" validate:"required"`
+	AssetName              string          `json:"asset_name" validate:"required,max=255"`
+	OTRAmount              decimal.Decimal `json:"otr_amount" validate:"required,gt=0"`
+	AdminFee               decimal.Decimal `json:"admin_fee" validate:"gte=0"`
+	TotalInterest          decimal.Decimal `json:"total_interest" validate:"gte=0"`
+	TotalInstallmentAmount decimal.Decimal `json:"total_installment_amount" validate:"required,gt=0"`
+	Status                 string          `json:"status" validate:"required,oneof=ACTIVE PAID_OFF CANCELLED"`
+	TransactionDate        string          `json:"transaction_date" validate:"required,datetime=2006-01-02"`
+	PaidInstallments       *int            `json:"paid_installments" validate:"omitempty,gte=0"`
+}
+
+// PartnerQuotaRequest replaces a partner's daily caps. DailyVolume is the
+// total OTR amount in IDR; a zero cap is unlimited.
+type PartnerQuotaRequest struct {
+	DailyCount  int64           `json:"daily_count" validate:"gte=0"`
+	DailyVolume decimal.Decimal `json:"daily_volume" validate:"gte=0"`
+}
+
+// RegistrationChecks carries the registration inputs that are checked
+// against other services instead of being stored on the customer.
+type RegistrationChecks struct {
+	ReferralCode string
+	OTPToken     string
+}
+
+// ContactChecks carries the OTP tokens that confirm a new email or phone
+// number on profile update.
+type ContactChecks struct {
+	EmailOTPToken string
+	PhoneOTPToken string
+}
+
 // --- Mapping --- //
 
 func RegisterToEntity(req CreateProfileRequest, ktpUrl, selfieUrl string) *domain.Customer {
-	birthDate, _ := time.Parse("2006-01-02", req.BirthDate)
+	birthDate, _ := datetime.ParseDate(req.BirthDate)
 	return &domain.Customer{
 		NIK:                req.NIK,
 		FullName:           req.FullName,
@@ -72,12 +412,135 @@ func RegisterToEntity(req CreateProfileRequest, ktpUrl, selfieUrl string) *domai
 		KtpUrl:             ktpUrl,
 		SelfieUrl:          selfieUrl,
 		VerificationStatus: domain.VerificationPending,
+		Language:           domain.Language(req.Language),
+		Email:              otp.NormalizeDestination(otp.ChannelEmail, req.Email),
+		Phone:              otp.NormalizeDestination(otp.ChannelSMS, req.Phone),
 	}
 }
 
 func UpdateToEntity(req UpdateProfileRequest) domain.Customer {
-	return domain.Customer{
+	customer := domain.Customer{
 		FullName: req.FullName,
 		Salary:   req.Salary,
+		Language: domain.Language(req.Language),
+		Email:    otp.NormalizeDestination(otp.ChannelEmail, req.Email),
+		Phone:    otp.NormalizeDestination(otp.ChannelSMS, req.Phone),
 	}
+	if req.Version != nil {
+		customer.Version = *req.Version
+	}
+	return customer
+}
+
+// MonthlyStatementPreferenceRequest turns the monthly account summary email
+// on or off. Enabled is a pointer so an omitted field is rejected instead of
+// read as false.
+type MonthlyStatementPreferenceRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+// CustomerNoteRequest is the full content of an admin note. Editing a note
+// replaces both fields and keeps the previous version as a revision.
+type CustomerNoteRequest struct {
+	Body   string `json:"body" validate:"required,max=2000"`
+	Pinned bool   `json:"pinned"`
+}
+
+// RestrictionRequest suspends a customer or freezes one of their limits.
+// Without ExpiresAt the restriction stays until an admin lifts it.
+type RestrictionRequest struct {
+	Reason    domain.RestrictionReason `json:"reason" validate:"required,oneof=FRAUD_SUSPECTED DELINQUENCY KYC_REVIEW CUSTOMER_REQUEST LEGAL_HOLD OTHER"`
+	Note      string                   `json:"note" validate:"max=500"`
+	ExpiresAt *time.Time               `json:"expires_at"`
+}
+
+// RegionRequest creates or updates an entry of the regions reference table.
+// Inactive regions stay in reports but cannot be assigned to new
+// transactions.
+type RegionRequest struct {
+	Name   string `json:"name" validate:"required,max=100"`
+	Active *bool  `json:"active" validate:"required"`
+}
+
+// PartnerRegionRequest sets the region and branch applied to a partner's
+// transactions that do not name their own.
+type PartnerRegionRequest struct {
+	RegionCode string `json:"region_code" validate:"required,max=8"`
+	BranchCode string `json:"branch_code" validate:"omitempty,max=32"`
+}
+
+// TransactionAttachmentRequest carries the form fields sent alongside an
+// uploaded transaction document.
+type TransactionAttachmentRequest struct {
+	Type domain.AttachmentType `form:"type" validate:"required,oneof=INVOICE DELIVERY_PROOF BPKB OTHER"`
+}
+
+// AMLCaseStatusRequest moves an AML case forward. Reporting a case needs the
+// reference the FIU issued for the filing.
+type AMLCaseStatusRequest struct {
+	Status          domain.AMLCaseStatus `json:"status" validate:"required,oneof=REVIEWED REPORTED"`
+	Note            string               `json:"note" validate:"max=1000"`
+	ReportReference string               `json:"report_reference" validate:"required_if=Status REPORTED,max=64"`
+}
+
+// CollectionAssignRequest hands a collection case to an admin. A null
+// collector_id takes the case back to the unassigned queue.
+type CollectionAssignRequest struct {
+	CollectorID *uint64 `json:"collector_id"`
+}
+
+type CollectionCaseStatusRequest struct {
+	Status domain.CollectionCaseStatus `json:"status" validate:"required,oneof=IN_PROGRESS REPOSSESSION REPOSSESSED RESOLVED"`
+}
+
+// CollectionActivityRequest logs a contact with the customer. A promise to
+// pay needs the promised amount and the YYYY-MM-DD day it is due.
+type CollectionActivityRequest struct {
+	Type          domain.CollectionActivityType `json:"type" validate:"required,oneof=CALL VISIT PROMISE_TO_PAY"`
+	Note          string                        `json:"note" validate:"required,max=1000"`
+	PromiseAmount *decimal.Decimal              `json:"promise_amount,omitempty"`
+	PromiseDate   string                        `json:"promise_date" validate:"required_if=Type PROMISE_TO_PAY,omitempty,datetime=2006-01-02"`
+}
+
+type WriteOffRequest struct {
+	ContractNumber string `json:"contract_number" validate:"required,max=50"`
+	Reason         string `json:"reason" validate:"required,max=500"`
+}
+
+type WriteOffReviewRequest struct {
+	Status domain.WriteOffStatus `json:"status" validate:"required,oneof=APPROVED REJECTED"`
+	Note   string                `json:"note" validate:"max=500"`
+}
+
+// WriteOffRecoveryRequest records money received on a written-off contract.
+// ReceivedOn is a YYYY-MM-DD day and defaults to today.
+type WriteOffRecoveryRequest struct {
+	Amount     decimal.Decimal `json:"amount" validate:"required,gt=0"`
+	ReceivedOn string          `json:"received_on" validate:"omitempty,datetime=2006-01-02"`
+	Reference  string          `json:"reference" validate:"max=64"`
+	Note       string          `json:"note" validate:"max=500"`
+}
+
+// ConcentrationLimitRequest caps the share of active principal, in percent,
+// one asset category or region may hold.
+type ConcentrationLimitRequest struct {
+	Dimension  domain.ConcentrationDimension `json:"dimension" validate:"required,oneof=ASSET_CATEGORY REGION"`
+	Value      string                        `json:"value" validate:"required,max=50"`
+	MaxPercent decimal.Decimal               `json:"max_percent" validate:"gt=0,lte=100"`
+	Enforce    bool                          `json:"enforce"`
+}
+
+// ConcentrationLimitUpdateRequest changes the cap of an existing limit, its
+// dimension and value are fixed once created.
+type ConcentrationLimitUpdateRequest struct {
+	MaxPercent decimal.Decimal `json:"max_percent" validate:"gt=0,lte=100"`
+	Enforce    bool            `json:"enforce"`
+}
+
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error dpanic panic fatal"`
+}
+
+type PartnerDebugModeRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
 }
@@ -1,18 +1,2025 @@
 package dto
 
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/shopspring/decimal"
+)
+
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token              string `json:"token"`
+	MustChangePassword bool   `json:"must_change_password"`
+}
+
+// CustomerResponse lists the customer fields exposed over the API. The
+// password hash is never included, and the KTP and selfie documents are
+// only reported as uploaded or not; their storage URLs stay internal.
+type CustomerResponse struct {
+	ID                 uint64          `json:"id"`
+	NIK                string          `json:"nik"`
+	FullName           string          `json:"full_name"`
+	LegalName          string          `json:"legal_name"`
+	Role               string          `json:"role"`
+	BirthPlace         string          `json:"birth_place"`
+	BirthDate          time.Time       `json:"birth_date"`
+	Salary             decimal.Decimal `json:"salary"`
+	KtpUploaded        bool            `json:"ktp_uploaded"`
+	SelfieUploaded     bool            `json:"selfie_uploaded"`
+	VerificationStatus string          `json:"verification_status"`
+	Language           string          `json:"language"`
+	Email              string          `json:"email,omitempty"`
+	Phone              string          `json:"phone,omitempty"`
+	MonthlyStatement   bool            `json:"monthly_statement"`
+	DormantAt          *time.Time      `json:"dormant_at,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	Version            uint64          `json:"version"`
+}
+
+// TransactionResponse is a contract as shown to customers and partners,
+// without the nested customer and tenor records.
+type TransactionResponse struct {
+	ID                     uint64          `json:"id"`
+	ContractNumber         string          `json:"contract_number"`
+	CustomerID             uint64          `json:"customer_id"`
+	TenorID                uint            `json:"tenor_id"`
+	AssetName              string          `json:"asset_name"`
+	AssetCategory          string          `json:"asset_category,omitempty"`
+	OTRAmount              decimal.Decimal `json:"otr_amount"`
+	AdminFee               decimal.Decimal `json:"admin_fee"`
+	TotalInterest          decimal.Decimal `json:"total_interest"`
+	TotalInstallmentAmount decimal.Decimal `json:"total_installment_amount"`
+	DiscountAmount         decimal.Decimal `json:"discount_amount"`
+	Status                 string          `json:"status"`
+	TransactionDate        time.Time       `json:"transaction_date"`
+	Currency               string          `json:"currency"`
+	FxRate                 decimal.Decimal `json:"fx_rate"`
+	Sandbox                bool            `json:"sandbox"`
+	RegionCode             string          `json:"region_code,omitempty"`
+	BranchCode             string          `json:"branch_code,omitempty"`
+	Latitude               *float64        `json:"latitude,omitempty"`
+	Longitude              *float64        `json:"longitude,omitempty"`
 }
 
 type LimitDetailResponse struct {
-	TenorMonths    uint8   `json:"tenor_months"`
-	LimitAmount    float64 `json:"limit_amount"`
-	UsedAmount     float64 `json:"used_amount"`
-	RemainingLimit float64 `json:"remaining_limit"`
+	TenorMonths    uint8           `json:"tenor_months"`
+	Currency       string          `json:"currency"`
+	LimitAmount    decimal.Decimal `json:"limit_amount"`
+	UsedAmount     decimal.Decimal `json:"used_amount"`
+	RemainingLimit decimal.Decimal `json:"remaining_limit"`
+	Version        uint64          `json:"version"`
 }
 
 type CheckLimitResponse struct {
-	Status         string  `json:"status"`
-	Message        string  `json:"message"`
-	RemainingLimit float64 `json:"remaining_limit,omitempty"`
+	Status         string          `json:"status"`
+	Message        string          `json:"message"`
+	Currency       string          `json:"currency,omitempty"`
+	RemainingLimit decimal.Decimal `json:"remaining_limit,omitempty"`
+}
+
+type PartnerSecurityResponse struct {
+	PartnerID             uint64   `json:"partner_id"`
+	AllowedCIDRs          []string `json:"allowed_cidrs"`
+	RequireClientCert     bool     `json:"require_client_cert"`
+	ClientCertFingerprint string   `json:"client_cert_fingerprint,omitempty"`
+	RequireSignature      bool     `json:"require_signature"`
+	// SigningSecret is only returned when a new secret was generated
+	SigningSecret string `json:"signing_secret,omitempty"`
+}
+
+type PartnerRegionResponse struct {
+	PartnerID  uint64 `json:"partner_id"`
+	RegionCode string `json:"region_code"`
+	BranchCode string `json:"branch_code"`
+}
+
+type RegionResponse struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Active    bool      `json:"active"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PartnerCredentialsResponse struct {
+	PartnerID uint64 `json:"partner_id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	APIKey    string `json:"api_key"`
+	Sandbox   bool   `json:"sandbox"`
+	Notice    string `json:"notice"`
+}
+
+type LimitRecommendationItem struct {
+	TenorMonths uint8           `json:"tenor_months"`
+	LimitAmount decimal.Decimal `json:"limit_amount"`
+	Capped      bool            `json:"capped"`
+	Version     uint64          `json:"version"`
+}
+
+// LimitRecommendationResponse mirrors the SetLimits body so an admin can
+// submit the suggested limits as-is or after adjusting them.
+type LimitRecommendationResponse struct {
+	CustomerID  uint64                    `json:"customer_id"`
+	Salary      decimal.Decimal           `json:"salary"`
+	RiskTier    string                    `json:"risk_tier"`
+	SalaryRatio float64                   `json:"salary_ratio"`
+	Limits      []LimitRecommendationItem `json:"limits"`
+}
+
+type DuplicateCandidateResponse struct {
+	CustomerID         uint64    `json:"customer_id"`
+	NIK                string    `json:"nik"`
+	LegalName          string    `json:"legal_name"`
+	BirthPlace         string    `json:"birth_place"`
+	BirthDate          time.Time `json:"birth_date"`
+	VerificationStatus string    `json:"verification_status"`
+	NameScore          float64   `json:"name_score"`
+	PlaceScore         float64   `json:"place_score"`
+	Score              float64   `json:"score"`
+}
+
+type InstallmentDetailResponse struct {
+	Sequence int             `json:"sequence"`
+	DueDate  time.Time       `json:"due_date"`
+	Amount   decimal.Decimal `json:"amount"`
+	Status   string          `json:"status"`
+}
+
+// ContractImportResponse lists the imported contracts in request order.
+type ContractImportResponse struct {
+	Imported  int                        `json:"imported"`
+	Contracts []ImportedContractResponse `json:"contracts"`
+}
+
+type ImportedContractResponse struct {
+	ID             uint64 `json:"id"`
+	ContractNumber string `json:"contract_number"`
+	CustomerID     uint64 `json:"customer_id"`
+}
+
+// ContractLookupResponse is what support sees of a contract found by its
+// number: the contract, who it belongs to and where its repayment stands.
+type ContractLookupResponse struct {
+	ID                     uint64                     `json:"id"`
+	ContractNumber         string                     `json:"contract_number"`
+	Status                 string                     `json:"status"`
+	AssetName              string                     `json:"asset_name"`
+	Currency               string                     `json:"currency"`
+	TransactionDate        time.Time                  `json:"transaction_date"`
+	TotalInstallmentAmount decimal.Decimal            `json:"total_installment_amount"`
+	PartnerID              *uint64                    `json:"partner_id,omitempty"`
+	IsSandbox              bool                       `json:"is_sandbox"`
+	Customer               ContractCustomerResponse   `json:"customer"`
+	Installments           InstallmentSummaryResponse `json:"installments"`
+}
+
+type ContractCustomerResponse struct {
+	ID                 uint64 `json:"id"`
+	NIK                string `json:"nik"`
+	FullName           string `json:"full_name"`
+	VerificationStatus string `json:"verification_status"`
+}
+
+type InstallmentSummaryResponse struct {
+	TenorMonths        uint8           `json:"tenor_months"`
+	MonthlyInstallment decimal.Decimal `json:"monthly_installment"`
+	PaidInstallments   int             `json:"paid_installments"`
+	TotalPaid          decimal.Decimal `json:"total_paid"`
+	OutstandingBalance decimal.Decimal `json:"outstanding_balance"`
+	NextDueDate        *time.Time      `json:"next_due_date,omitempty"`
+}
+
+type TransactionDetailResponse struct {
+	ContractNumber         string                      `json:"contract_number"`
+	AssetName              string                      `json:"asset_name"`
+	Status                 string                      `json:"status"`
+	Currency               string                      `json:"currency"`
+	TransactionDate        time.Time                   `json:"transaction_date"`
+	TransactionDateText    string                      `json:"transaction_date_text,omitempty"`
+	TenorMonths            uint8                       `json:"tenor_months"`
+	TenorDescription       string                      `json:"tenor_description"`
+	OTRAmount              decimal.Decimal             `json:"otr_amount"`
+	AdminFee               decimal.Decimal             `json:"admin_fee"`
+	TotalInterest          decimal.Decimal             `json:"total_interest"`
+	TotalInstallmentAmount decimal.Decimal             `json:"total_installment_amount"`
+	MonthlyInstallment     decimal.Decimal             `json:"monthly_installment"`
+	NextDueDate            *time.Time                  `json:"next_due_date,omitempty"`
+	TotalPaid              decimal.Decimal             `json:"total_paid"`
+	OutstandingBalance     decimal.Decimal             `json:"outstanding_balance"`
+	Penalty                decimal.Decimal             `json:"penalty"`
+	VirtualAccountBank     string                      `json:"virtual_account_bank,omitempty"`
+	VirtualAccountNumber   string                      `json:"virtual_account_number,omitempty"`
+	Installments           []InstallmentDetailResponse `json:"installments"`
+}
+
+type InstallmentResponse struct {
+	Sequence int             `json:"sequence"`
+	DueDate  time.Time       `json:"due_date"`
+	Amount   decimal.Decimal `json:"amount"`
+}
+
+// RestructuringResponse carries the remaining installment schedule under the
+// restructured terms, starting after the installments already paid.
+type RestructuringResponse struct {
+	Restructuring *domain.Restructuring `json:"restructuring"`
+	Schedule      []InstallmentResponse `json:"schedule"`
+}
+
+type InterestAccrualRowResponse struct {
+	TenorMonths     uint8           `json:"tenor_months"`
+	Segment         string          `json:"segment"`
+	ContractCount   int64           `json:"contract_count"`
+	AccruedInterest decimal.Decimal `json:"accrued_interest"`
+}
+
+type InterestAccrualTenorResponse struct {
+	TenorMonths     uint8           `json:"tenor_months"`
+	ContractCount   int64           `json:"contract_count"`
+	AccruedInterest decimal.Decimal `json:"accrued_interest"`
+}
+
+type InterestAccrualSegmentResponse struct {
+	Segment         string          `json:"segment"`
+	ContractCount   int64           `json:"contract_count"`
+	AccruedInterest decimal.Decimal `json:"accrued_interest"`
+}
+
+// InterestAccrualReportResponse is the monthly accrual used for the finance
+// close. Every amount is in IDR.
+type InterestAccrualReportResponse struct {
+	Month         string                           `json:"month"`
+	Currency      string                           `json:"currency"`
+	Rows          []InterestAccrualRowResponse     `json:"rows"`
+	ByTenor       []InterestAccrualTenorResponse   `json:"by_tenor"`
+	BySegment     []InterestAccrualSegmentResponse `json:"by_segment"`
+	ContractCount int64                            `json:"contract_count"`
+	TotalInterest decimal.Decimal                  `json:"total_interest"`
+}
+
+type PartnerCommissionRowResponse struct {
+	PartnerID     uint64          `json:"partner_id"`
+	PartnerName   string          `json:"partner_name"`
+	ContractCount int64           `json:"contract_count"`
+	OTRAmount     decimal.Decimal `json:"otr_amount"`
+	AdminFee      decimal.Decimal `json:"admin_fee"`
+	Commission    decimal.Decimal `json:"commission"`
+}
+
+// PartnerCommissionReportResponse is the commission owed to each partner for
+// contracts booked in Month. Every amount is in IDR.
+type PartnerCommissionReportResponse struct {
+	Month           string                         `json:"month"`
+	Currency        string                         `json:"currency"`
+	Rows            []PartnerCommissionRowResponse `json:"rows"`
+	ContractCount   int64                          `json:"contract_count"`
+	TotalOTRAmount  decimal.Decimal                `json:"total_otr_amount"`
+	TotalAdminFee   decimal.Decimal                `json:"total_admin_fee"`
+	TotalCommission decimal.Decimal                `json:"total_commission"`
+}
+
+type ReferralConversionRowResponse struct {
+	ReferrerID     uint64          `json:"referrer_id"`
+	ReferrerName   string          `json:"referrer_name"`
+	Code           string          `json:"code"`
+	Referred       int64           `json:"referred"`
+	Rejected       int64           `json:"rejected"`
+	Converted      int64           `json:"converted"`
+	ConversionRate decimal.Decimal `json:"conversion_rate"`
+}
+
+// ReferralConversionReportResponse counts referrals registered in Month per
+// referrer. ConversionRate is Converted over accepted referrals.
+type ReferralConversionReportResponse struct {
+	Month     string                          `json:"month"`
+	Rows      []ReferralConversionRowResponse `json:"rows"`
+	Referred  int64                           `json:"referred"`
+	Rejected  int64                           `json:"rejected"`
+	Converted int64                           `json:"converted"`
+}
+
+// PaymentCallbackResponse acknowledges a gateway callback. Unmatched and
+// duplicate callbacks are still acknowledged so the gateway stops retrying.
+type PaymentCallbackResponse struct {
+	CallbackID uint64 `json:"callback_id,omitempty"`
+	Outcome    string `json:"outcome"`
+}
+
+type ReferralCodeResponse struct {
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DirectDebitMandateResponse never echoes the full payment token.
+type DirectDebitMandateResponse struct {
+	Provider  string    `json:"provider"`
+	TokenHint string    `json:"token_hint"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func DirectDebitMandateToResponse(data domain.DirectDebitMandate) DirectDebitMandateResponse {
+	hint := "****"
+	if len(data.Token) > 8 {
+		hint += data.Token[len(data.Token)-4:]
+	}
+	return DirectDebitMandateResponse{
+		Provider:  data.Provider,
+		TokenHint: hint,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+type DeviceResponse struct {
+	ID         uint64    `json:"id"`
+	Platform   string    `json:"platform"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+func DeviceToResponse(data domain.Device) DeviceResponse {
+	return DeviceResponse{
+		ID:         data.ID,
+		Platform:   string(data.Platform),
+		CreatedAt:  data.CreatedAt,
+		LastSeenAt: data.LastSeenAt,
+	}
+}
+
+type NotificationPreferencesResponse struct {
+	Email     bool      `json:"email"`
+	Push      bool      `json:"push"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NotificationPreferencesToResponse(data domain.NotificationPreferences) NotificationPreferencesResponse {
+	return NotificationPreferencesResponse{
+		Email:     data.Email,
+		Push:      data.Push,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+type AgingBucketResponse struct {
+	Bucket        string          `json:"bucket"`
+	ContractCount int64           `json:"contract_count"`
+	Outstanding   decimal.Decimal `json:"outstanding"`
+}
+
+type AgingTenorResponse struct {
+	TenorMonths uint8                 `json:"tenor_months"`
+	Buckets     []AgingBucketResponse `json:"buckets"`
+}
+
+// AgingReportResponse buckets active contracts by days past due. Source is
+// "snapshot" when served from the scheduled snapshot taken on AsOf and "live"
+// when computed on request. Every amount is the unpaid balance in IDR.
+type AgingReportResponse struct {
+	AsOf             string                `json:"as_of"`
+	Source           string                `json:"source"`
+	Currency         string                `json:"currency"`
+	Portfolio        []AgingBucketResponse `json:"portfolio"`
+	ByTenor          []AgingTenorResponse  `json:"by_tenor"`
+	ContractCount    int64                 `json:"contract_count"`
+	TotalOutstanding decimal.Decimal       `json:"total_outstanding"`
+}
+
+type RegionReportRowResponse struct {
+	RegionCode            string          `json:"region_code"`
+	RegionName            string          `json:"region_name"`
+	ContractCount         int64           `json:"contract_count"`
+	OTRAmount             decimal.Decimal `json:"otr_amount"`
+	ActiveContracts       int64           `json:"active_contracts"`
+	Outstanding           decimal.Decimal `json:"outstanding"`
+	DelinquentContracts   int64           `json:"delinquent_contracts"`
+	DelinquentOutstanding decimal.Decimal `json:"delinquent_outstanding"`
+	DelinquencyRate       decimal.Decimal `json:"delinquency_rate"`
+}
+
+// RegionReportResponse compares regions for management dashboards. Contract
+// count and OTR amount cover contracts booked in Month; the remaining columns
+// describe active contracts as of AsOf, where delinquent means at least one
+// installment past due. Every amount is in IDR.
+type RegionReportResponse struct {
+	Month                 string                    `json:"month"`
+	AsOf                  string                    `json:"as_of"`
+	Currency              string                    `json:"currency"`
+	Rows                  []RegionReportRowResponse `json:"rows"`
+	ContractCount         int64                     `json:"contract_count"`
+	TotalOTRAmount        decimal.Decimal           `json:"total_otr_amount"`
+	TotalOutstanding      decimal.Decimal           `json:"total_outstanding"`
+	DelinquentOutstanding decimal.Decimal           `json:"delinquent_outstanding"`
+	DelinquencyRate       decimal.Decimal           `json:"delinquency_rate"`
+}
+
+// --- Mapping --- //
+
+func CustomerToResponse(data domain.Customer) CustomerResponse {
+	return CustomerResponse{
+		ID:                 data.ID,
+		NIK:                data.NIK,
+		FullName:           data.FullName,
+		LegalName:          data.LegalName,
+		Role:               string(data.Role),
+		BirthPlace:         data.BirthPlace,
+		BirthDate:          data.BirthDate,
+		Salary:             data.Salary,
+		KtpUploaded:        data.KtpUrl != "",
+		SelfieUploaded:     data.SelfieUrl != "",
+		VerificationStatus: string(data.VerificationStatus),
+		Language:           string(data.Language),
+		Email:              data.Email,
+		Phone:              data.Phone,
+		MonthlyStatement:   !data.MonthlyStatementOptOut,
+		DormantAt:          data.DormantAt,
+		CreatedAt:          data.CreatedAt,
+		UpdatedAt:          data.UpdatedAt,
+		Version:            data.Version,
+	}
+}
+
+func CustomersToResponse(data []domain.Customer) []CustomerResponse {
+	responses := make([]CustomerResponse, len(data))
+	for i, c := range data {
+		responses[i] = CustomerToResponse(c)
+	}
+	return responses
+}
+
+// Localize shows the timestamps in the client's zone. BirthDate is a calendar
+// date and stays as stored.
+func (r CustomerResponse) Localize(locale datetime.Locale) CustomerResponse {
+	r.DormantAt = locale.TimePtr(r.DormantAt)
+	r.CreatedAt = locale.Time(r.CreatedAt)
+	r.UpdatedAt = locale.Time(r.UpdatedAt)
+	return r
+}
+
+func TransactionToResponse(data domain.Transaction) TransactionResponse {
+	return TransactionResponse{
+		ID:                     data.ID,
+		ContractNumber:         data.ContractNumber,
+		CustomerID:             data.CustomerID,
+		TenorID:                data.TenorID,
+		AssetName:              data.AssetName,
+		AssetCategory:          data.AssetCategory,
+		OTRAmount:              data.OTRAmount,
+		AdminFee:               data.AdminFee,
+		TotalInterest:          data.TotalInterest,
+		TotalInstallmentAmount: data.TotalInstallmentAmount,
+		DiscountAmount:         data.DiscountAmount,
+		Status:                 string(data.Status),
+		TransactionDate:        data.TransactionDate,
+		Currency:               data.Currency,
+		FxRate:                 data.FxRate,
+		Sandbox:                data.IsSandbox,
+		RegionCode:             data.RegionCode,
+		BranchCode:             data.BranchCode,
+		Latitude:               data.Latitude,
+		Longitude:              data.Longitude,
+	}
+}
+
+func TransactionsToResponse(data []domain.Transaction) []TransactionResponse {
+	responses := make([]TransactionResponse, len(data))
+	for i, t := range data {
+		responses[i] = TransactionToResponse(t)
+	}
+	return responses
+}
+
+func (r TransactionResponse) Localize(locale datetime.Locale) TransactionResponse {
+	r.TransactionDate = locale.Time(r.TransactionDate)
+	return r
+}
+
+// Localize shows the timestamps in the client's zone and adds the
+// transaction date as display text in the client's language.
+func (r TransactionDetailResponse) Localize(locale datetime.Locale) TransactionDetailResponse {
+	r.TransactionDate = locale.Time(r.TransactionDate)
+	r.TransactionDateText = locale.Format(r.TransactionDate)
+	r.NextDueDate = locale.TimePtr(r.NextDueDate)
+
+	installments := make([]InstallmentDetailResponse, len(r.Installments))
+	for i, installment := range r.Installments {
+		installment.DueDate = locale.Time(installment.DueDate)
+		installments[i] = installment
+	}
+	r.Installments = installments
+	return r
+}
+
+func PartnerSecurityToResponse(data domain.Partner) PartnerSecurityResponse {
+	cidrs := data.Security.AllowedCIDRs
+	if cidrs == nil {
+		cidrs = []string{}
+	}
+	return PartnerSecurityResponse{
+		PartnerID:             data.ID,
+		AllowedCIDRs:          cidrs,
+		RequireClientCert:     data.Security.RequireClientCert,
+		ClientCertFingerprint: data.Security.ClientCertFingerprint,
+		RequireSignature:      data.Security.RequireSignature,
+	}
+}
+
+func PartnerRegionToResponse(data domain.Partner) PartnerRegionResponse {
+	return PartnerRegionResponse{
+		PartnerID:  data.ID,
+		RegionCode: data.RegionCode,
+		BranchCode: data.BranchCode,
+	}
+}
+
+func RegionToResponse(data domain.Region) RegionResponse {
+	return RegionResponse{
+		Code:      data.Code,
+		Name:      data.Name,
+		Active:    data.Active,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func RegionsToResponse(data []domain.Region) []RegionResponse {
+	responses := make([]RegionResponse, len(data))
+	for i, r := range data {
+		responses[i] = RegionToResponse(r)
+	}
+	return responses
+}
+
+func HolidayToResponse(data domain.Holiday) HolidayResponse {
+	return HolidayResponse{
+		Date:      data.Date.Format(time.DateOnly),
+		Name:      data.Name,
+		UpdatedBy: data.UpdatedBy,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func HolidaysToResponse(data []domain.Holiday) []HolidayResponse {
+	responses := make([]HolidayResponse, len(data))
+	for i, h := range data {
+		responses[i] = HolidayToResponse(h)
+	}
+	return responses
+}
+
+func EmailPreviewToResponse(data domain.RenderedEmail) EmailPreviewResponse {
+	return EmailPreviewResponse{
+		Template: string(data.Template),
+		Language: string(data.Language),
+		Subject:  data.Subject,
+		Body:     data.Body,
+	}
+}
+
+// DueDatePolicyToResponse leaves out who changed the policy and when while
+// it still is the default.
+func DueDatePolicyToResponse(data domain.DueDatePolicy) DueDatePolicyResponse {
+	response := DueDatePolicyResponse{
+		Shift:     data.Shift,
+		GraceDays: data.GraceDays,
+		UpdatedBy: data.UpdatedBy,
+	}
+	if !data.UpdatedAt.IsZero() {
+		updatedAt := data.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+type OTPChallengeResponse struct {
+	ChallengeID string    `json:"challenge_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// OTPVerificationResponse carries the token the guarded action expects in
+// its otp_token field. It can be used once.
+type OTPVerificationResponse struct {
+	OTPToken  string    `json:"otp_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ImpersonationResponse carries the read-only token issued for the session.
+// It is sent in the private cookie like a login token.
+type ImpersonationResponse struct {
+	SessionID  uint64    `json:"session_id"`
+	CustomerID uint64    `json:"customer_id"`
+	Token      string    `json:"token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type MaintenanceModeResponse struct {
+	Scope     string     `json:"scope"`
+	Message   string     `json:"message"`
+	Until     *time.Time `json:"until,omitempty"`
+	UpdatedBy uint64     `json:"updated_by"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type ImpersonationRequestResponse struct {
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ImpersonationRequestsToResponse(data []domain.ImpersonationRequest) []ImpersonationRequestResponse {
+	responses := make([]ImpersonationRequestResponse, len(data))
+	for i, request := range data {
+		responses[i] = ImpersonationRequestResponse{
+			Method:    request.Method,
+			Path:      request.Path,
+			Status:    request.Status,
+			CreatedAt: request.CreatedAt,
+		}
+	}
+	return responses
+}
+
+func MaintenanceModeToResponse(data domain.MaintenanceMode) MaintenanceModeResponse {
+	return MaintenanceModeResponse{
+		Scope:     string(data.Scope),
+		Message:   data.Message,
+		Until:     data.Until,
+		UpdatedBy: data.UpdatedBy,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func MaintenanceModesToResponse(data []domain.MaintenanceMode) []MaintenanceModeResponse {
+	responses := make([]MaintenanceModeResponse, len(data))
+	for i, mode := range data {
+		responses[i] = MaintenanceModeToResponse(mode)
+	}
+	return responses
+}
+
+type QuotaUsageResponse struct {
+	Count    int64           `json:"count"`
+	Volume   decimal.Decimal `json:"volume"`
+	ResetsAt time.Time       `json:"resets_at"`
+}
+
+type PartnerQuotaResponse struct {
+	PartnerID   uint64              `json:"partner_id"`
+	DailyCount  int64               `json:"daily_count"`
+	DailyVolume decimal.Decimal     `json:"daily_volume"`
+	UpdatedBy   uint64              `json:"updated_by,omitempty"`
+	UpdatedAt   *time.Time          `json:"updated_at,omitempty"`
+	Usage       *QuotaUsageResponse `json:"usage,omitempty"`
+}
+
+func PartnerQuotaToResponse(data domain.PartnerQuota, usage *domain.QuotaUsage) PartnerQuotaResponse {
+	response := PartnerQuotaResponse{
+		PartnerID:   data.PartnerID,
+		DailyCount:  data.DailyCount,
+		DailyVolume: data.DailyVolume,
+		UpdatedBy:   data.UpdatedBy,
+	}
+	if !data.UpdatedAt.IsZero() {
+		response.UpdatedAt = &data.UpdatedAt
+	}
+	if usage != nil {
+		response.Usage = &QuotaUsageResponse{Count: usage.Count, Volume: usage.Volume, ResetsAt: usage.ResetsAt}
+	}
+	return response
+}
+
+type TransactionBatchItemResponse struct {
+	Sequence       int        `json:"sequence"`
+	CustomerNIK    string     `json:"customer_nik"`
+	Status         string     `json:"status"`
+	TransactionID  *uint64    `json:"transaction_id,omitempty"`
+	ContractNumber string     `json:"contract_number,omitempty"`
+	ErrorCode      string     `json:"error_code,omitempty"`
+	ErrorMessage   string     `json:"error_message,omitempty"`
+	ProcessedAt    *time.Time `json:"processed_at,omitempty"`
+}
+
+type TransactionBatchResponse struct {
+	ID          uint64                         `json:"id"`
+	Status      string                         `json:"status"`
+	Sandbox     bool                           `json:"sandbox"`
+	Total       int                            `json:"total"`
+	Pending     int                            `json:"pending"`
+	Succeeded   int                            `json:"succeeded"`
+	Failed      int                            `json:"failed"`
+	CreatedAt   time.Time                      `json:"created_at"`
+	CompletedAt *time.Time                     `json:"completed_at,omitempty"`
+	Items       []TransactionBatchItemResponse `json:"items"`
+}
+
+func TransactionBatchToResponse(data domain.TransactionBatch) TransactionBatchResponse {
+	response := TransactionBatchResponse{
+		ID:          data.ID,
+		Status:      string(data.Status),
+		Sandbox:     data.Owner.Sandbox,
+		Total:       len(data.Items),
+		CreatedAt:   data.CreatedAt,
+		CompletedAt: data.CompletedAt,
+		Items:       make([]TransactionBatchItemResponse, len(data.Items)),
+	}
+	for i, item := range data.Items {
+		switch item.Status {
+		case domain.BatchItemSucceeded:
+			response.Succeeded++
+		case domain.BatchItemFailed:
+			response.Failed++
+		default:
+			response.Pending++
+		}
+		response.Items[i] = TransactionBatchItemResponse{
+			Sequence:       item.Sequence,
+			CustomerNIK:    item.CustomerNIK,
+			Status:         string(item.Status),
+			TransactionID:  item.TransactionID,
+			ContractNumber: item.ContractNumber,
+			ErrorCode:      item.ErrorCode,
+			ErrorMessage:   item.ErrorMessage,
+			ProcessedAt:    item.ProcessedAt,
+		}
+	}
+	return response
+}
+
+type LimitChangeResponse struct {
+	ID               uint64           `json:"id"`
+	TenorMonths      uint8            `json:"tenor_months"`
+	PreviousAmount   *decimal.Decimal `json:"previous_amount"`
+	PreviousCurrency string           `json:"previous_currency,omitempty"`
+	NewAmount        decimal.Decimal  `json:"new_amount"`
+	Currency         string           `json:"currency"`
+	ChangedBy        uint64           `json:"changed_by"`
+	ChangedAt        time.Time        `json:"changed_at"`
+	EffectiveFrom    time.Time        `json:"effective_from"`
+}
+
+func LimitChangesToResponse(data []domain.LimitChange) []LimitChangeResponse {
+	responses := make([]LimitChangeResponse, len(data))
+	for i, change := range data {
+		responses[i] = LimitChangeResponse{
+			ID:               change.ID,
+			TenorMonths:      change.TenorMonths,
+			PreviousAmount:   change.PreviousAmount,
+			PreviousCurrency: change.PreviousCurrency,
+			NewAmount:        change.NewAmount,
+			Currency:         change.Currency,
+			ChangedBy:        change.ChangedBy,
+			ChangedAt:        change.ChangedAt,
+			EffectiveFrom:    change.EffectiveFrom,
+		}
+	}
+	return responses
+}
+
+// LimitsAtResponse lists the limits in force at At, one per tenor.
+type LimitsAtResponse struct {
+	At     time.Time             `json:"at"`
+	Limits []LimitPeriodResponse `json:"limits"`
+}
+
+type LimitPeriodResponse struct {
+	TenorMonths   uint8           `json:"tenor_months"`
+	LimitAmount   decimal.Decimal `json:"limit_amount"`
+	Currency      string          `json:"currency"`
+	Version       uint64          `json:"version"`
+	EffectiveFrom time.Time       `json:"effective_from"`
+	EffectiveTo   *time.Time      `json:"effective_to"`
+}
+
+func LimitsAtToResponse(at time.Time, data []domain.CustomerLimit) LimitsAtResponse {
+	response := LimitsAtResponse{At: at, Limits: make([]LimitPeriodResponse, len(data))}
+	for i, limit := range data {
+		response.Limits[i] = LimitPeriodResponse{
+			TenorMonths:   limit.Tenor.DurationMonths,
+			LimitAmount:   limit.LimitAmount,
+			Currency:      limit.Currency,
+			Version:       limit.Version,
+			EffectiveFrom: limit.EffectiveFrom,
+			EffectiveTo:   limit.EffectiveTo,
+		}
+	}
+	return response
+}
+
+type TenorResponse struct {
+	ID              uint            `json:"id"`
+	DurationMonths  uint8           `json:"duration_months"`
+	Description     string          `json:"description"`
+	Active          bool            `json:"active"`
+	MinAmount       decimal.Decimal `json:"min_amount"`
+	MaxAmount       decimal.Decimal `json:"max_amount"`
+	MinAge          uint8           `json:"min_age"`
+	MaxAge          uint8           `json:"max_age"`
+	MaxAgeAtEnd     uint8           `json:"max_age_at_end"`
+	AssetCategories []string        `json:"asset_categories"`
+}
+
+func TenorToResponse(data domain.Tenor) TenorResponse {
+	categories := data.AssetCategories
+	if categories == nil {
+		categories = []string{}
+	}
+	return TenorResponse{
+		ID:              data.ID,
+		DurationMonths:  data.DurationMonths,
+		Description:     data.Description,
+		Active:          data.Active,
+		MinAmount:       data.MinAmount,
+		MaxAmount:       data.MaxAmount,
+		MinAge:          data.MinAge,
+		MaxAge:          data.MaxAge,
+		MaxAgeAtEnd:     data.MaxAgeAtEnd,
+		AssetCategories: categories,
+	}
+}
+
+func TenorsToResponse(data []domain.Tenor) []TenorResponse {
+	responses := make([]TenorResponse, len(data))
+	for i, tenor := range data {
+		responses[i] = TenorToResponse(tenor)
+	}
+	return responses
+}
+
+// StatementResponse is returned while a statement is still being generated;
+// the same URL can be polled until it serves the PDF.
+type StatementResponse struct {
+	ContractNumber string    `json:"contract_number"`
+	Status         string    `json:"status"`
+	RequestedAt    time.Time `json:"requested_at"`
+}
+
+func StatementToResponse(data domain.TransactionStatement) StatementResponse {
+	return StatementResponse{
+		ContractNumber: data.ContractNumber,
+		Status:         string(data.Status),
+		RequestedAt:    data.UpdatedAt,
+	}
+}
+
+type CustomerNoteRevisionResponse struct {
+	ID        uint64    `json:"id"`
+	Body      string    `json:"body"`
+	Pinned    bool      `json:"pinned"`
+	UpdatedBy uint64    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CustomerNoteResponse struct {
+	ID         uint64                         `json:"id"`
+	CustomerID uint64                         `json:"customer_id"`
+	AuthorID   uint64                         `json:"author_id"`
+	Body       string                         `json:"body"`
+	Pinned     bool                           `json:"pinned"`
+	UpdatedBy  uint64                         `json:"updated_by"`
+	CreatedAt  time.Time                      `json:"created_at"`
+	UpdatedAt  time.Time                      `json:"updated_at"`
+	Revisions  []CustomerNoteRevisionResponse `json:"revisions"`
+}
+
+func CustomerNoteToResponse(data domain.CustomerNote) CustomerNoteResponse {
+	revisions := make([]CustomerNoteRevisionResponse, len(data.Revisions))
+	for i, revision := range data.Revisions {
+		revisions[i] = CustomerNoteRevisionResponse{
+			ID:        revision.ID,
+			Body:      revision.Body,
+			Pinned:    revision.Pinned,
+			UpdatedBy: revision.UpdatedBy,
+			UpdatedAt: revision.UpdatedAt,
+		}
+	}
+
+	return CustomerNoteResponse{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		AuthorID:   data.AuthorID,
+		Body:       data.Body,
+		Pinned:     data.Pinned,
+		UpdatedBy:  data.UpdatedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+		Revisions:  revisions,
+	}
+}
+
+func CustomerNotesToResponse(data []domain.CustomerNote) []CustomerNoteResponse {
+	responses := make([]CustomerNoteResponse, len(data))
+	for i, note := range data {
+		responses[i] = CustomerNoteToResponse(note)
+	}
+	return responses
+}
+
+type CustomerRestrictionResponse struct {
+	ID          uint64     `json:"id"`
+	CustomerID  uint64     `json:"customer_id"`
+	Scope       string     `json:"scope"`
+	TenorMonths uint8      `json:"tenor_months,omitempty"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note,omitempty"`
+	CreatedBy   uint64     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LiftedAt    *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy    *uint64    `json:"lifted_by,omitempty"`
+	Active      bool       `json:"active"`
+}
+
+// CustomerRestrictionToResponse reports whether data is still in force at
+// now, so restrictions past their expiry show as inactive before the expiry
+// job lifts them.
+func CustomerRestrictionToResponse(data domain.CustomerRestriction, now time.Time) CustomerRestrictionResponse {
+	scope := "TENOR"
+	if data.IsSuspension() {
+		scope = "ACCOUNT"
+	}
+
+	return CustomerRestrictionResponse{
+		ID:          data.ID,
+		CustomerID:  data.CustomerID,
+		Scope:       scope,
+		TenorMonths: data.TenorMonths,
+		Reason:      string(data.Reason),
+		Note:        data.Note,
+		CreatedBy:   data.CreatedBy,
+		CreatedAt:   data.CreatedAt,
+		ExpiresAt:   data.ExpiresAt,
+		LiftedAt:    data.LiftedAt,
+		LiftedBy:    data.LiftedBy,
+		Active:      data.ActiveAt(now),
+	}
+}
+
+func CustomerRestrictionsToResponse(data []domain.CustomerRestriction, now time.Time) []CustomerRestrictionResponse {
+	responses := make([]CustomerRestrictionResponse, len(data))
+	for i, restriction := range data {
+		responses[i] = CustomerRestrictionToResponse(restriction, now)
+	}
+	return responses
+}
+
+type TransactionAttachmentResponse struct {
+	ID            uint64                `json:"id"`
+	TransactionID uint64                `json:"transaction_id"`
+	Type          domain.AttachmentType `json:"type"`
+	FileName      string                `json:"file_name"`
+	ContentType   string                `json:"content_type"`
+	Size          int64                 `json:"size"`
+	URL           string                `json:"url"`
+	CreatedAt     time.Time             `json:"created_at"`
+	DeletedAt     *time.Time            `json:"deleted_at,omitempty"`
+	DeletedBy     *uint64               `json:"deleted_by,omitempty"`
+}
+
+func TransactionAttachmentToResponse(data domain.TransactionAttachment) TransactionAttachmentResponse {
+	return TransactionAttachmentResponse{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Type:          data.Type,
+		FileName:      data.FileName,
+		ContentType:   data.ContentType,
+		Size:          data.Size,
+		URL:           data.URL,
+		CreatedAt:     data.CreatedAt,
+		DeletedAt:     data.DeletedAt,
+		DeletedBy:     data.DeletedBy,
+	}
+}
+
+func TransactionAttachmentsToResponse(data []domain.TransactionAttachment) []TransactionAttachmentResponse {
+	responses := make([]TransactionAttachmentResponse, len(data))
+	for i, attachment := range data {
+		responses[i] = TransactionAttachmentToResponse(attachment)
+	}
+	return responses
+}
+
+type IncomeVerificationResponse struct {
+	ID                   uint64                          `json:"id"`
+	CustomerID           uint64                          `json:"customer_id"`
+	FileName             string                          `json:"file_name"`
+	StatementUrl         string                          `json:"statement_url"`
+	DeclaredSalary       decimal.Decimal                 `json:"declared_salary"`
+	AverageMonthlyInflow decimal.Decimal                 `json:"average_monthly_inflow"`
+	TotalInflow          decimal.Decimal                 `json:"total_inflow"`
+	PeriodStart          string                          `json:"period_start"`
+	PeriodEnd            string                          `json:"period_end"`
+	MonthsCovered        int                             `json:"months_covered"`
+	TransactionCount     int                             `json:"transaction_count"`
+	Discrepancy          decimal.Decimal                 `json:"discrepancy"`
+	Status               domain.IncomeVerificationStatus `json:"status"`
+	ReviewerID           *uint64                         `json:"reviewer_id,omitempty"`
+	ReviewNote           string                          `json:"review_note,omitempty"`
+	ReviewedAt           *time.Time                      `json:"reviewed_at,omitempty"`
+	CreatedAt            time.Time                       `json:"created_at"`
+}
+
+func IncomeVerificationToResponse(data domain.IncomeVerification) IncomeVerificationResponse {
+	return IncomeVerificationResponse{
+		ID:                   data.ID,
+		CustomerID:           data.CustomerID,
+		FileName:             data.FileName,
+		StatementUrl:         data.StatementUrl,
+		DeclaredSalary:       data.DeclaredSalary,
+		AverageMonthlyInflow: data.AverageMonthlyInflow,
+		TotalInflow:          data.TotalInflow,
+		PeriodStart:          data.PeriodStart.Format(time.DateOnly),
+		PeriodEnd:            data.PeriodEnd.Format(time.DateOnly),
+		MonthsCovered:        data.MonthsCovered,
+		TransactionCount:     data.TransactionCount,
+		Discrepancy:          data.Discrepancy,
+		Status:               data.Status,
+		ReviewerID:           data.ReviewerID,
+		ReviewNote:           data.ReviewNote,
+		ReviewedAt:           data.ReviewedAt,
+		CreatedAt:            data.CreatedAt,
+	}
+}
+
+func IncomeVerificationsToResponse(data []domain.IncomeVerification) []IncomeVerificationResponse {
+	responses := make([]IncomeVerificationResponse, len(data))
+	for i, verification := range data {
+		responses[i] = IncomeVerificationToResponse(verification)
+	}
+	return responses
+}
+
+type RiskTierRuleResponse struct {
+	ID             uint64           `json:"id"`
+	Priority       int              `json:"priority"`
+	Tier           domain.RiskTier  `json:"tier"`
+	MinSalary      *decimal.Decimal `json:"min_salary"`
+	MaxSalary      *decimal.Decimal `json:"max_salary"`
+	MinScore       *int             `json:"min_score"`
+	MaxScore       *int             `json:"max_score"`
+	MinDaysPastDue *int             `json:"min_days_past_due"`
+	MaxDaysPastDue *int             `json:"max_days_past_due"`
+	UpdatedBy      uint64           `json:"updated_by"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+func RiskTierRuleToResponse(data domain.RiskTierRule) RiskTierRuleResponse {
+	return RiskTierRuleResponse{
+		ID:             data.ID,
+		Priority:       data.Priority,
+		Tier:           data.Tier,
+		MinSalary:      data.MinSalary,
+		MaxSalary:      data.MaxSalary,
+		MinScore:       data.MinScore,
+		MaxScore:       data.MaxScore,
+		MinDaysPastDue: data.MinDaysPastDue,
+		MaxDaysPastDue: data.MaxDaysPastDue,
+		UpdatedBy:      data.UpdatedBy,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func RiskTierRulesToResponse(data []domain.RiskTierRule) []RiskTierRuleResponse {
+	responses := make([]RiskTierRuleResponse, len(data))
+	for i, rule := range data {
+		responses[i] = RiskTierRuleToResponse(rule)
+	}
+	return responses
+}
+
+// CustomerRiskTierResponse shows the tier a customer was given and the
+// inputs it was given on. Tier is empty when no rule matched.
+type CustomerRiskTierResponse struct {
+	CustomerID   uint64          `json:"customer_id"`
+	Tier         domain.RiskTier `json:"tier"`
+	RuleID       *uint64         `json:"rule_id"`
+	Salary       decimal.Decimal `json:"salary"`
+	CreditScore  *int            `json:"credit_score"`
+	DaysPastDue  int             `json:"days_past_due"`
+	CalculatedAt time.Time       `json:"calculated_at"`
+}
+
+func CustomerRiskTierToResponse(data domain.CustomerRiskTier) CustomerRiskTierResponse {
+	return CustomerRiskTierResponse{
+		CustomerID:   data.CustomerID,
+		Tier:         data.Tier,
+		RuleID:       data.RuleID,
+		Salary:       data.Salary,
+		CreditScore:  data.CreditScore,
+		DaysPastDue:  data.DaysPastDue,
+		CalculatedAt: data.CalculatedAt,
+	}
+}
+
+type CustomerEventResponse struct {
+	ID          uint64                   `json:"id"`
+	Type        domain.CustomerEventType `json:"type"`
+	ActorID     *uint64                  `json:"actor_id,omitempty"`
+	ReferenceID string                   `json:"reference_id,omitempty"`
+	Data        map[string]string        `json:"data,omitempty"`
+	OccurredAt  time.Time                `json:"occurred_at"`
+}
+
+func CustomerEventsToResponse(data []domain.CustomerEvent) []CustomerEventResponse {
+	responses := make([]CustomerEventResponse, len(data))
+	for i, event := range data {
+		responses[i] = CustomerEventResponse{
+			ID:          event.ID,
+			Type:        event.Type,
+			ActorID:     event.ActorID,
+			ReferenceID: event.ReferenceID,
+			Data:        event.Data,
+			OccurredAt:  event.OccurredAt,
+		}
+	}
+	return responses
+}
+
+// WebhookDeliveryResponse is a delivery as shown to the partner it was sent
+// to. Payload is the exact JSON body posted to the webhook URL.
+type WebhookDeliveryResponse struct {
+	ID            uint64          `json:"id"`
+	EventType     string          `json:"event_type"`
+	URL           string          `json:"url"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	LastAttemptAt *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func WebhookDeliveriesToResponse(data []domain.WebhookDelivery) []WebhookDeliveryResponse {
+	responses := make([]WebhookDeliveryResponse, len(data))
+	for i, delivery := range data {
+		payload := json.RawMessage(delivery.Payload)
+		if !json.Valid(payload) {
+			payload = json.RawMessage("null")
+		}
+		responses[i] = WebhookDeliveryResponse{
+			ID:            delivery.ID,
+			EventType:     delivery.EventType,
+			URL:           delivery.URL,
+			Payload:       payload,
+			Status:        string(delivery.Status),
+			Attempts:      delivery.Attempts,
+			LastError:     delivery.LastError,
+			LastAttemptAt: delivery.LastAttemptAt,
+			DeliveredAt:   delivery.DeliveredAt,
+			CreatedAt:     delivery.CreatedAt,
+		}
+	}
+	return responses
+}
+
+type APIUsageDayResponse struct {
+	Date      string `json:"date"`
+	Allowed   int64  `json:"allowed"`
+	Throttled int64  `json:"throttled"`
+}
+
+// PartnerUsageResponse lists request counts per UTC day, oldest first, with
+// the totals over the whole range.
+type PartnerUsageResponse struct {
+	PartnerID uint64                `json:"partner_id"`
+	Allowed   int64                 `json:"allowed"`
+	Throttled int64                 `json:"throttled"`
+	Days      []APIUsageDayResponse `json:"days"`
+}
+
+func PartnerUsageToResponse(partnerID uint64, usage []domain.APIUsage) PartnerUsageResponse {
+	response := PartnerUsageResponse{
+		PartnerID: partnerID,
+		Days:      make([]APIUsageDayResponse, len(usage)),
+	}
+	for i, day := range usage {
+		response.Allowed += day.Allowed
+		response.Throttled += day.Throttled
+		response.Days[i] = APIUsageDayResponse{
+			Date:      day.Day.Format(time.DateOnly),
+			Allowed:   day.Allowed,
+			Throttled: day.Throttled,
+		}
+	}
+	return response
+}
+
+// DormancyStatsResponse reports dormant accounts and the customers that
+// entered or left dormancy in the last Days days.
+type DormancyStatsResponse struct {
+	Dormant         int64      `json:"dormant"`
+	OldestDormantAt *time.Time `json:"oldest_dormant_at,omitempty"`
+	Days            int        `json:"days"`
+	Since           time.Time  `json:"since"`
+	Flagged         int64      `json:"flagged"`
+	Reactivated     int64      `json:"reactivated"`
+}
+
+func DormancyStatsToResponse(data domain.DormancyStats, days int) DormancyStatsResponse {
+	return DormancyStatsResponse{
+		Dormant:         data.Dormant,
+		OldestDormantAt: data.OldestDormantAt,
+		Days:            days,
+		Since:           data.Since,
+		Flagged:         data.Flagged,
+		Reactivated:     data.Reactivated,
+	}
+}
+
+// CalendarLinkResponse is the subscription link of an installment
+// calendar. It is shown only once; a new link replaces it.
+type CalendarLinkResponse struct {
+	URL       string `json:"url"`
+	WebcalURL string `json:"webcal_url"`
+}
+
+// HolidayResponse is one day of the holiday calendar.
+type HolidayResponse struct {
+	Date      string    `json:"date"`
+	Name      string    `json:"name"`
+	UpdatedBy uint64    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DueDatePolicyResponse is the policy applied to every installment due date.
+type DueDatePolicyResponse struct {
+	Shift     installment.Shift `json:"shift"`
+	GraceDays int               `json:"grace_days"`
+	UpdatedBy uint64            `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time        `json:"updated_at,omitempty"`
+}
+
+// EmailPreviewResponse is a template rendered as the customer would
+// receive it.
+type EmailPreviewResponse struct {
+	Template string `json:"template"`
+	Language string `json:"language"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+}
+
+// AccountClosureResponse confirms a closed account and when its personal
+// data will be anonymized.
+type AccountClosureResponse struct {
+	ClosedAt       time.Time `json:"closed_at"`
+	AnonymizeAfter time.Time `json:"anonymize_after"`
+}
+
+func AccountClosureToResponse(data domain.AccountClosure) AccountClosureResponse {
+	return AccountClosureResponse{
+		ClosedAt:       data.ClosedAt,
+		AnonymizeAfter: data.AnonymizeAfter,
+	}
+}
+
+type AMLCaseResponse struct {
+	ID              uint64          `json:"id"`
+	CustomerID      uint64          `json:"customer_id"`
+	CustomerName    string          `json:"customer_name,omitempty"`
+	TransactionID   uint64          `json:"transaction_id"`
+	ContractNumber  string          `json:"contract_number,omitempty"`
+	Rule            string          `json:"rule"`
+	AmountIDR       decimal.Decimal `json:"amount_idr"`
+	Detail          string          `json:"detail,omitempty"`
+	Status          string          `json:"status"`
+	ReviewNote      string          `json:"review_note,omitempty"`
+	ReviewedBy      *uint64         `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	ReportReference string          `json:"report_reference,omitempty"`
+	ReportedAt      *time.Time      `json:"reported_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+func AMLCaseToResponse(data domain.AMLCase) AMLCaseResponse {
+	response := AMLCaseResponse{
+		ID:              data.ID,
+		CustomerID:      data.CustomerID,
+		TransactionID:   data.TransactionID,
+		Rule:            string(data.Rule),
+		AmountIDR:       data.AmountIDR,
+		Detail:          data.Detail,
+		Status:          string(data.Status),
+		ReviewNote:      data.ReviewNote,
+		ReviewedBy:      data.ReviewedBy,
+		ReviewedAt:      data.ReviewedAt,
+		ReportReference: data.ReportReference,
+		ReportedAt:      data.ReportedAt,
+		CreatedAt:       data.CreatedAt,
+	}
+	if data.Customer != nil {
+		response.CustomerName = data.Customer.FullName
+	}
+	if data.Transaction != nil {
+		response.ContractNumber = data.Transaction.ContractNumber
+	}
+	return response
+}
+
+func AMLCasesToResponse(data []domain.AMLCase) []AMLCaseResponse {
+	responses := make([]AMLCaseResponse, len(data))
+	for i, amlCase := range data {
+		responses[i] = AMLCaseToResponse(amlCase)
+	}
+	return responses
+}
+
+type CollectionCaseResponse struct {
+	ID             uint64          `json:"id"`
+	TransactionID  uint64          `json:"transaction_id"`
+	ContractNumber string          `json:"contract_number,omitempty"`
+	CustomerID     uint64          `json:"customer_id"`
+	CustomerName   string          `json:"customer_name,omitempty"`
+	Status         string          `json:"status"`
+	CollectorID    *uint64         `json:"collector_id"`
+	DaysPastDue    int             `json:"days_past_due"`
+	OverdueAmount  decimal.Decimal `json:"overdue_amount"`
+	LastActivityAt *time.Time      `json:"last_activity_at,omitempty"`
+	ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func CollectionCaseToResponse(data domain.CollectionCase) CollectionCaseResponse {
+	response := CollectionCaseResponse{
+		ID:             data.ID,
+		TransactionID:  data.TransactionID,
+		CustomerID:     data.CustomerID,
+		Status:         string(data.Status),
+		CollectorID:    data.CollectorID,
+		DaysPastDue:    data.DaysPastDue,
+		OverdueAmount:  data.OverdueAmount,
+		LastActivityAt: data.LastActivityAt,
+		ClosedAt:       data.ClosedAt,
+		CreatedAt:      data.CreatedAt,
+	}
+	if data.Customer != nil {
+		response.CustomerName = data.Customer.FullName
+	}
+	if data.Transaction != nil {
+		response.ContractNumber = data.Transaction.ContractNumber
+	}
+	return response
+}
+
+func CollectionCasesToResponse(data []domain.CollectionCase) []CollectionCaseResponse {
+	responses := make([]CollectionCaseResponse, len(data))
+	for i, collectionCase := range data {
+		responses[i] = CollectionCaseToResponse(collectionCase)
+	}
+	return responses
+}
+
+type CollectionActivityResponse struct {
+	ID            uint64           `json:"id"`
+	CaseID        uint64           `json:"case_id"`
+	Type          string           `json:"type"`
+	Note          string           `json:"note"`
+	PromiseAmount *decimal.Decimal `json:"promise_amount,omitempty"`
+	PromiseDate   string           `json:"promise_date,omitempty"`
+	CreatedBy     uint64           `json:"created_by"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+func CollectionActivityToResponse(data domain.CollectionActivity) CollectionActivityResponse {
+	response := CollectionActivityResponse{
+		ID:            data.ID,
+		CaseID:        data.CaseID,
+		Type:          string(data.Type),
+		Note:          data.Note,
+		PromiseAmount: data.PromiseAmount,
+		CreatedBy:     data.CreatedBy,
+		CreatedAt:     data.CreatedAt,
+	}
+	if data.PromiseDate != nil {
+		response.PromiseDate = data.PromiseDate.Format("2006-01-02")
+	}
+	return response
+}
+
+func CollectionActivitiesToResponse(data []domain.CollectionActivity) []CollectionActivityResponse {
+	responses := make([]CollectionActivityResponse, len(data))
+	for i, activity := range data {
+		responses[i] = CollectionActivityToResponse(activity)
+	}
+	return responses
+}
+
+type CollectorLoadResponse struct {
+	CollectorID     uint64          `json:"collector_id"`
+	CollectorName   string          `json:"collector_name"`
+	ActiveCases     int64           `json:"active_cases"`
+	OverdueAmount   decimal.Decimal `json:"overdue_amount"`
+	PendingPromises int64           `json:"pending_promises"`
+	BrokenPromises  int64           `json:"broken_promises"`
+}
+
+type CollectionDashboardResponse struct {
+	ByStatus   map[string]int64        `json:"by_status"`
+	Unassigned int64                   `json:"unassigned"`
+	Collectors []CollectorLoadResponse `json:"collectors"`
+}
+
+// CollectionDashboardToResponse lists every status, including the ones
+// without cases, so the dashboard does not have to fill gaps.
+func CollectionDashboardToResponse(data domain.CollectionDashboard) CollectionDashboardResponse {
+	response := CollectionDashboardResponse{
+		ByStatus:   make(map[string]int64),
+		Unassigned: data.Unassigned,
+		Collectors: make([]CollectorLoadResponse, len(data.Collectors)),
+	}
+	for _, status := range []domain.CollectionCaseStatus{
+		domain.CollectionOpen, domain.CollectionInProgress, domain.CollectionRepossession,
+		domain.CollectionRepossessed, domain.CollectionResolved,
+	} {
+		response.ByStatus[string(status)] = data.ByStatus[status]
+	}
+	for i, load := range data.Collectors {
+		response.Collectors[i] = CollectorLoadResponse{
+			CollectorID:     load.CollectorID,
+			CollectorName:   load.CollectorName,
+			ActiveCases:     load.ActiveCases,
+			OverdueAmount:   load.OverdueAmount,
+			PendingPromises: load.PendingPromises,
+			BrokenPromises:  load.BrokenPromises,
+		}
+	}
+	return response
+}
+
+type WriteOffResponse struct {
+	ID              uint64          `json:"id"`
+	TransactionID   uint64          `json:"transaction_id"`
+	ContractNumber  string          `json:"contract_number,omitempty"`
+	CustomerID      uint64          `json:"customer_id"`
+	Status          string          `json:"status"`
+	DaysPastDue     int             `json:"days_past_due"`
+	Amount          decimal.Decimal `json:"amount"`
+	RecoveredAmount decimal.Decimal `json:"recovered_amount"`
+	Outstanding     decimal.Decimal `json:"outstanding"`
+	Reason          string          `json:"reason"`
+	RequestedBy     uint64          `json:"requested_by"`
+	ReviewerID      *uint64         `json:"reviewer_id,omitempty"`
+	ReviewNote      string          `json:"review_note,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+func WriteOffToResponse(data domain.WriteOff) WriteOffResponse {
+	response := WriteOffResponse{
+		ID:              data.ID,
+		TransactionID:   data.TransactionID,
+		CustomerID:      data.CustomerID,
+		Status:          string(data.Status),
+		DaysPastDue:     data.DaysPastDue,
+		Amount:          data.Amount,
+		RecoveredAmount: data.RecoveredAmount,
+		Outstanding:     data.Outstanding(),
+		Reason:          data.Reason,
+		RequestedBy:     data.RequestedBy,
+		ReviewerID:      data.ReviewerID,
+		ReviewNote:      data.ReviewNote,
+		ReviewedAt:      data.ReviewedAt,
+		CreatedAt:       data.CreatedAt,
+	}
+	if data.Transaction != nil {
+		response.ContractNumber = data.Transaction.ContractNumber
+	}
+	return response
+}
+
+func WriteOffsToResponse(data []domain.WriteOff) []WriteOffResponse {
+	responses := make([]WriteOffResponse, len(data))
+	for i, writeOff := range data {
+		responses[i] = WriteOffToResponse(writeOff)
+	}
+	return responses
+}
+
+type WriteOffRecoveryResponse struct {
+	ID         uint64          `json:"id"`
+	WriteOffID uint64          `json:"write_off_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	ReceivedOn string          `json:"received_on"`
+	Reference  string          `json:"reference,omitempty"`
+	Note       string          `json:"note,omitempty"`
+	CreatedBy  uint64          `json:"created_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func WriteOffRecoveryToResponse(data domain.WriteOffRecovery) WriteOffRecoveryResponse {
+	return WriteOffRecoveryResponse{
+		ID:         data.ID,
+		WriteOffID: data.WriteOffID,
+		Amount:     data.Amount,
+		ReceivedOn: data.ReceivedOn.Format("2006-01-02"),
+		Reference:  data.Reference,
+		Note:       data.Note,
+		CreatedBy:  data.CreatedBy,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func WriteOffRecoveriesToResponse(data []domain.WriteOffRecovery) []WriteOffRecoveryResponse {
+	responses := make([]WriteOffRecoveryResponse, len(data))
+	for i, recovery := range data {
+		responses[i] = WriteOffRecoveryToResponse(recovery)
+	}
+	return responses
+}
+
+type LedgerEntryResponse struct {
+	ID            uint64          `json:"id"`
+	ReferenceType string          `json:"reference_type"`
+	ReferenceID   uint64          `json:"reference_id"`
+	Account       string          `json:"account"`
+	Debit         decimal.Decimal `json:"debit"`
+	Credit        decimal.Decimal `json:"credit"`
+	EntryDate     string          `json:"entry_date"`
+	Description   string          `json:"description"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func LedgerEntriesToResponse(data []domain.LedgerEntry) []LedgerEntryResponse {
+	responses := make([]LedgerEntryResponse, len(data))
+	for i, entry := range data {
+		responses[i] = LedgerEntryResponse{
+			ID:            entry.ID,
+			ReferenceType: string(entry.ReferenceType),
+			ReferenceID:   entry.ReferenceID,
+			Account:       string(entry.Account),
+			Debit:         entry.Debit,
+			Credit:        entry.Credit,
+			EntryDate:     entry.EntryDate.Format("2006-01-02"),
+			Description:   entry.Description,
+			CreatedAt:     entry.CreatedAt,
+		}
+	}
+	return responses
+}
+
+type WriteOffPeriodResponse struct {
+	Period       string          `json:"period"`
+	Count        int64           `json:"count"`
+	Amount       decimal.Decimal `json:"amount"`
+	Recovered    decimal.Decimal `json:"recovered"`
+	RecoveryRate decimal.Decimal `json:"recovery_rate"`
+	Collected    decimal.Decimal `json:"collected"`
+}
+
+func WriteOffReportToResponse(data []domain.WriteOffPeriod) []WriteOffPeriodResponse {
+	responses := make([]WriteOffPeriodResponse, len(data))
+	for i, period := range data {
+		responses[i] = WriteOffPeriodResponse{
+			Period:       period.Period.Format("2006-01"),
+			Count:        period.Count,
+			Amount:       period.Amount,
+			Recovered:    period.Recovered,
+			RecoveryRate: period.RecoveryRate(),
+			Collected:    period.Collected,
+		}
+	}
+	return responses
+}
+
+type LogLevelResponse struct {
+	Module   string `json:"module"`
+	Level    string `json:"level"`
+	Previous string `json:"previous,omitempty"`
+}
+
+// LogLevelsToResponse lists the modules in alphabetical order.
+func LogLevelsToResponse(levels map[string]string) []LogLevelResponse {
+	responses := make([]LogLevelResponse, 0, len(levels))
+	for module, level := range levels {
+		responses = append(responses, LogLevelResponse{Module: module, Level: level})
+	}
+	sort.Slice(responses, func(i, j int) bool { return responses[i].Module < responses[j].Module })
+	return responses
+}
+
+// PartnerDebugModeResponse reports debug recording for the key the request
+// was made with.
+type PartnerDebugModeResponse struct {
+	PartnerID uint64     `json:"partner_id"`
+	Sandbox   bool       `json:"sandbox"`
+	Enabled   bool       `json:"enabled"`
+	Until     *time.Time `json:"until,omitempty"`
+}
+
+func PartnerDebugModeToResponse(partnerID uint64, sandbox bool, until *time.Time, now time.Time) PartnerDebugModeResponse {
+	response := PartnerDebugModeResponse{PartnerID: partnerID, Sandbox: sandbox}
+	if until != nil && until.After(now) {
+		response.Enabled = true
+		response.Until = until
+	}
+	return response
+}
+
+type PartnerDebugRecordResponse struct {
+	ID             uint64            `json:"id"`
+	Method         string            `json:"method"`
+	Path           string            `json:"path"`
+	StatusCode     int               `json:"status_code"`
+	DurationMs     int64             `json:"duration_ms"`
+	RequestID      string            `json:"request_id,omitempty"`
+	TraceID        string            `json:"trace_id,omitempty"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	RequestBody    string            `json:"request_body,omitempty"`
+	ResponseBody   string            `json:"response_body,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// PartnerDebugRecordToResponse includes the headers and bodies only when
+// full is set, listings show the summary line of each request.
+func PartnerDebugRecordToResponse(data domain.PartnerDebugRecord, full bool) PartnerDebugRecordResponse {
+	response := PartnerDebugRecordResponse{
+		ID:         data.ID,
+		Method:     data.Method,
+		Path:       data.Path,
+		StatusCode: data.StatusCode,
+		DurationMs: data.DurationMs,
+		RequestID:  data.RequestID,
+		TraceID:    data.TraceID,
+		CreatedAt:  data.CreatedAt,
+	}
+	if full {
+		response.RequestHeaders = data.RequestHeaders
+		response.RequestBody = data.RequestBody
+		response.ResponseBody = data.ResponseBody
+	}
+	return response
+}
+
+func PartnerDebugRecordsToResponse(data []domain.PartnerDebugRecord) []PartnerDebugRecordResponse {
+	responses := make([]PartnerDebugRecordResponse, len(data))
+	for i, record := range data {
+		responses[i] = PartnerDebugRecordToResponse(record, false)
+	}
+	return responses
+}
+
+type ConcentrationLimitResponse struct {
+	ID         uint64          `json:"id"`
+	Dimension  string          `json:"dimension"`
+	Value      string          `json:"value"`
+	MaxPercent decimal.Decimal `json:"max_percent"`
+	Enforce    bool            `json:"enforce"`
+	BreachedAt *time.Time      `json:"breached_at"`
+	UpdatedBy  uint64          `json:"updated_by"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+func ConcentrationLimitToResponse(data domain.ConcentrationLimit) ConcentrationLimitResponse {
+	return ConcentrationLimitResponse{
+		ID:         data.ID,
+		Dimension:  string(data.Dimension),
+		Value:      data.Value,
+		MaxPercent: data.MaxPercent,
+		Enforce:    data.Enforce,
+		BreachedAt: data.BreachedAt,
+		UpdatedBy:  data.UpdatedBy,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func ConcentrationLimitsToResponse(data []domain.ConcentrationLimit) []ConcentrationLimitResponse {
+	responses := make([]ConcentrationLimitResponse, len(data))
+	for i, limit := range data {
+		responses[i] = ConcentrationLimitToResponse(limit)
+	}
+	return responses
+}
+
+// ConcentrationResponse is one asset category or region of the portfolio.
+// MaxPercent and LimitID are null when no limit is set for the value, and
+// an empty value groups the contracts booked without one.
+type ConcentrationResponse struct {
+	Value      string           `json:"value"`
+	Principal  decimal.Decimal  `json:"principal"`
+	Percent    decimal.Decimal  `json:"percent"`
+	LimitID    *uint64          `json:"limit_id"`
+	MaxPercent *decimal.Decimal `json:"max_percent"`
+	Enforce    bool             `json:"enforce"`
+	Breached   bool             `json:"breached"`
+}
+
+// ConcentrationReportResponse breaks the active principal down by asset
+// category and by region. Evaluated is false while the portfolio is below
+// min_portfolio and limits do not apply yet.
+type ConcentrationReportResponse struct {
+	TotalPrincipal  decimal.Decimal         `json:"total_principal"`
+	MinPortfolio    decimal.Decimal         `json:"min_portfolio"`
+	Evaluated       bool                    `json:"evaluated"`
+	AssetCategories []ConcentrationResponse `json:"asset_categories"`
+	Regions         []ConcentrationResponse `json:"regions"`
+	GeneratedAt     time.Time               `json:"generated_at"`
+}
+
+func ConcentrationReportToResponse(data domain.ConcentrationReport) ConcentrationReportResponse {
+	response := ConcentrationReportResponse{
+		TotalPrincipal:  data.TotalPrincipal,
+		MinPortfolio:    data.MinPortfolio,
+		Evaluated:       data.Evaluated(),
+		AssetCategories: []ConcentrationResponse{},
+		Regions:         []ConcentrationResponse{},
+		GeneratedAt:     data.GeneratedAt,
+	}
+	for _, concentration := range data.Concentrations {
+		item := ConcentrationResponse{
+			Value:     concentration.Value,
+			Principal: concentration.Principal,
+			Percent:   concentration.Percent,
+			Breached:  response.Evaluated && concentration.Breached(),
+		}
+		if limit := concentration.Limit; limit != nil {
+			item.LimitID = &limit.ID
+			item.MaxPercent = &limit.MaxPercent
+			item.Enforce = limit.Enforce
+		}
+
+		if concentration.Dimension == domain.ConcentrationRegion {
+			response.Regions = append(response.Regions, item)
+		} else {
+			response.AssetCategories = append(response.AssetCategories, item)
+		}
+	}
+	return response
+}
+
+// MobileHomeResponse is the home screen of the mobile app in one payload:
+// who the customer is, what is left of each limit and the next installment
+// to pay. Fields the app does not show are left out to keep it small on slow
+// networks.
+type MobileHomeResponse struct {
+	FullName           string                     `json:"full_name"`
+	VerificationStatus string                     `json:"verification_status"`
+	Limits             []MobileLimitResponse      `json:"limits"`
+	ActiveContracts    int                        `json:"active_contracts"`
+	NextInstallment    *MobileInstallmentResponse `json:"next_installment,omitempty"`
+}
+
+type MobileLimitResponse struct {
+	TenorMonths    uint8           `json:"tenor_months"`
+	Currency       string          `json:"currency"`
+	LimitAmount    decimal.Decimal `json:"limit_amount"`
+	RemainingLimit decimal.Decimal `json:"remaining_limit"`
+}
+
+// MobileInstallmentResponse is the earliest unpaid installment over all
+// active contracts. Overdue is set when its due date has passed.
+type MobileInstallmentResponse struct {
+	ContractNumber string          `json:"contract_number"`
+	Sequence       int             `json:"sequence"`
+	DueDate        time.Time       `json:"due_date"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+	Overdue        bool            `json:"overdue,omitempty"`
+}
+
+// Localize shows the due date in the client's zone.
+func (r MobileHomeResponse) Localize(locale datetime.Locale) MobileHomeResponse {
+	if r.NextInstallment != nil {
+		next := *r.NextInstallment
+		next.DueDate = locale.Time(next.DueDate)
+		r.NextInstallment = &next
+	}
+	return r
+}
+
+// DashboardSnapshotResponse is the data of one snapshot event on the admin
+// dashboard stream. Registrations only lists the sign-ups since the previous
+// event.
+type DashboardSnapshotResponse struct {
+	Registrations         []DashboardRegistrationResponse `json:"registrations"`
+	VerificationQueue     int64                           `json:"verification_queue"`
+	TransactionsPerMinute int64                           `json:"transactions_per_minute"`
+	GeneratedAt           time.Time                       `json:"generated_at"`
+}
+
+type DashboardRegistrationResponse struct {
+	CustomerID   uint64    `json:"customer_id"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+func DashboardSnapshotToResponse(data domain.DashboardSnapshot) DashboardSnapshotResponse {
+	response := DashboardSnapshotResponse{
+		Registrations:         make([]DashboardRegistrationResponse, len(data.Registrations)),
+		VerificationQueue:     data.VerificationQueue,
+		TransactionsPerMinute: data.TransactionsPerMinute,
+		GeneratedAt:           data.GeneratedAt,
+	}
+	for i, event := range data.Registrations {
+		response.Registrations[i] = DashboardRegistrationResponse{
+			CustomerID:   event.CustomerID,
+			RegisteredAt: event.OccurredAt,
+		}
+	}
+	return response
+}
+
+// TenantResponse is the public branding of the tenant serving the request,
+// for white-label frontends to style themselves with.
+type TenantResponse struct {
+	ID          string `json:"id"`
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address,omitempty"`
+	Contact     string `json:"contact,omitempty"`
+	Color       string `json:"color,omitempty"`
+	LogoURL     string `json:"logo_url,omitempty"`
+}
+
+func TenantToResponse(data *tenancy.Tenant) TenantResponse {
+	return TenantResponse{
+		ID:          data.ID,
+		CompanyName: data.Branding.CompanyName,
+		Address:     data.Branding.Address,
+		Contact:     data.Branding.Contact,
+		Color:       data.Branding.Color,
+		LogoURL:     data.Branding.LogoURL,
+	}
+}
+
+// FeatureFlagResponse is a feature flag as shown to admins. PartnerIDs
+// lists the partners piloting a disabled flag.
+type FeatureFlagResponse struct {
+	Key         string    `json:"key"`
+	Description string    `json:"description"`
+	Enabled     bool      `json:"enabled"`
+	PartnerIDs  []uint64  `json:"partner_ids"`
+	UpdatedBy   uint64    `json:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func FeatureFlagToResponse(data domain.FeatureFlag) FeatureFlagResponse {
+	partnerIDs := data.PartnerIDs
+	if partnerIDs == nil {
+		partnerIDs = []uint64{}
+	}
+	return FeatureFlagResponse{
+		Key:         data.Key,
+		Description: data.Description,
+		Enabled:     data.Enabled,
+		PartnerIDs:  partnerIDs,
+		UpdatedBy:   data.UpdatedBy,
+		UpdatedAt:   data.UpdatedAt,
+	}
+}
+
+func FeatureFlagsToResponse(data []domain.FeatureFlag) []FeatureFlagResponse {
+	responses := make([]FeatureFlagResponse, len(data))
+	for i, flag := range data {
+		responses[i] = FeatureFlagToResponse(flag)
+	}
+	return responses
+}
+
+// PartnerFeeScheduleResponse is a partner's negotiated fees. TenorMonths
+// zero is the partner's default schedule; rates are fractions of the OTR
+// amount.
+type PartnerFeeScheduleResponse struct {
+	ID             uint64          `json:"id"`
+	PartnerID      uint64          `json:"partner_id"`
+	TenorMonths    uint8           `json:"tenor_months"`
+	AdminFeeFlat   decimal.Decimal `json:"admin_fee_flat"`
+	AdminFeeRate   decimal.Decimal `json:"admin_fee_rate"`
+	CommissionRate decimal.Decimal `json:"commission_rate"`
+	UpdatedBy      uint64          `json:"updated_by"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func PartnerFeeScheduleToResponse(data domain.PartnerFeeSchedule) PartnerFeeScheduleResponse {
+	return PartnerFeeScheduleResponse{
+		ID:             data.ID,
+		PartnerID:      data.PartnerID,
+		TenorMonths:    data.TenorMonths,
+		AdminFeeFlat:   data.AdminFeeFlat,
+		AdminFeeRate:   data.AdminFeeRate,
+		CommissionRate: data.CommissionRate,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func PartnerFeeSchedulesToResponse(data []domain.PartnerFeeSchedule) []PartnerFeeScheduleResponse {
+	responses := make([]PartnerFeeScheduleResponse, len(data))
+	for i, schedule := range data {
+		responses[i] = PartnerFeeScheduleToResponse(schedule)
+	}
+	return responses
+}
+
+// PromotionResponse is a promo code with its terms. PartnerID is null for a
+// promotion open to every partner and TenorMonths zero matches any tenor;
+// zero MaxRedemptions or MaxPerCustomer means unlimited.
+type PromotionResponse struct {
+	ID             uint64          `json:"id"`
+	Code           string          `json:"code"`
+	Description    string          `json:"description"`
+	Target         string          `json:"target"`
+	DiscountType   string          `json:"discount_type"`
+	DiscountValue  decimal.Decimal `json:"discount_value"`
+	MaxDiscount    decimal.Decimal `json:"max_discount"`
+	ValidFrom      time.Time       `json:"valid_from"`
+	ValidUntil     time.Time       `json:"valid_until"`
+	PartnerID      *uint64         `json:"partner_id"`
+	TenorMonths    uint8           `json:"tenor_months"`
+	MinOTRAmount   decimal.Decimal `json:"min_otr_amount"`
+	MaxRedemptions int             `json:"max_redemptions"`
+	MaxPerCustomer int             `json:"max_per_customer"`
+	Active         bool            `json:"active"`
+	UpdatedBy      uint64          `json:"updated_by"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func PromotionToResponse(data domain.Promotion) PromotionResponse {
+	return PromotionResponse{
+		ID:             data.ID,
+		Code:           data.Code,
+		Description:    data.Description,
+		Target:         string(data.Target),
+		DiscountType:   string(data.DiscountType),
+		DiscountValue:  data.DiscountValue,
+		MaxDiscount:    data.MaxDiscount,
+		ValidFrom:      data.ValidFrom,
+		ValidUntil:     data.ValidUntil,
+		PartnerID:      data.PartnerID,
+		TenorMonths:    data.TenorMonths,
+		MinOTRAmount:   data.MinOTRAmount,
+		MaxRedemptions: data.MaxRedemptions,
+		MaxPerCustomer: data.MaxPerCustomer,
+		Active:         data.Active,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func PromotionsToResponse(data []domain.Promotion) []PromotionResponse {
+	responses := make([]PromotionResponse, len(data))
+	for i, promotion := range data {
+		responses[i] = PromotionToResponse(promotion)
+	}
+	return responses
+}
+
+// FxRateResponse is the IDR value of one unit of Currency on RateDate.
+type FxRateResponse struct {
+	ID         uint64          `json:"id"`
+	Currency   string          `json:"currency"`
+	RateDate   time.Time       `json:"rate_date"`
+	RateToIDR  decimal.Decimal `json:"rate_to_idr"`
+	UploadedBy uint64          `json:"uploaded_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+func FxRateToResponse(data domain.FxRate) FxRateResponse {
+	return FxRateResponse{
+		ID:         data.ID,
+		Currency:   data.Currency,
+		RateDate:   data.RateDate,
+		RateToIDR:  data.RateToIDR,
+		UploadedBy: data.UploadedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func FxRatesToResponse(data []domain.FxRate) []FxRateResponse {
+	responses := make([]FxRateResponse, len(data))
+	for i, rate := range data {
+		responses[i] = FxRateToResponse(rate)
+	}
+	return responses
+}
+
+// DuplicateResolutionResponse records how an admin settled a possible
+// duplicate pair.
+type DuplicateResolutionResponse struct {
+	ID          uint64    `json:"id"`
+	CustomerID  uint64    `json:"customer_id"`
+	DuplicateID uint64    `json:"duplicate_id"`
+	Action      string    `json:"action"`
+	ResolvedBy  uint64    `json:"resolved_by"`
+	Note        string    `json:"note"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func DuplicateResolutionToResponse(data domain.DuplicateResolution) DuplicateResolutionResponse {
+	return DuplicateResolutionResponse{
+		ID:          data.ID,
+		CustomerID:  data.CustomerID,
+		DuplicateID: data.DuplicateID,
+		Action:      string(data.Action),
+		ResolvedBy:  data.ResolvedBy,
+		Note:        data.Note,
+		CreatedAt:   data.CreatedAt,
+	}
 }
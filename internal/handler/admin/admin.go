@@ -9,7 +9,11 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/dto"
 	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel/attribute"
@@ -74,7 +78,7 @@ func NewAdminHandler(
 
 	return &AdminHandler{
 		adminService:    adminService,
-		validate:        validator.New(validator.WithRequiredStructEnabled()),
+		validate:        money.NewValidator(),
 		meter:           meter,
 		tracer:          tracer,
 		log:             log,
@@ -126,7 +130,7 @@ func (h *AdminHandler) recordError(
 	h.log.Error(message, logFields...)
 
 	// Return HTTP error response
-	return c.Status(statusCode).JSON(fiber.Map{"error": message})
+	return responder.Fail(c, statusCode, errorType, message)
 }
 
 // recordSuccess helper function to record successful responses with observability
@@ -158,7 +162,17 @@ func (h *AdminHandler) recordSuccess(
 	h.log.Info("Request completed successfully", logFields...)
 
 	// Return HTTP success response
-	return c.Status(statusCode).JSON(responseData)
+	return responder.Success(c, statusCode, responseData)
+}
+
+var customerListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status": {
+			Column: "verification_status",
+			Values: []string{string(domain.VerificationPending), string(domain.VerificationVerified), string(domain.VerificationRejected)},
+		},
+	},
+	Sorts: map[string]string{"created_at": "created_at", "full_name": "full_name"},
 }
 
 func (h *AdminHandler) ListCustomers(c *fiber.Ctx) error {
@@ -174,14 +188,13 @@ func (h *AdminHandler) ListCustomers(c *fiber.Ctx) error {
 	h.log.Debug("Received list customers request", zap.String("path", c.Path()))
 	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
 
-	params := domain.Params{
-		Status: c.Query("status"),
-		Page:   c.QueryInt("page", 1),
-		Limit:  c.QueryInt("limit", 10),
+	params, err := customerListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
 	}
 
 	span.SetAttributes(
-		attribute.String("query.status", params.Status),
+		attribute.String("query.status", params.Value("verification_status")),
 		attribute.Int("query.page", params.Page),
 		attribute.Int("query.limit", params.Limit),
 	)
@@ -190,6 +203,8 @@ func (h *AdminHandler) ListCustomers(c *fiber.Ctx) error {
 	if err != nil {
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list customers")
 	}
+	customers, _ := res.Data.([]domain.Customer)
+	res.Data = dto.CustomersToResponse(customers)
 	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res)
 }
 
@@ -220,7 +235,7 @@ func (h *AdminHandler) GetCustomerByID(c *fiber.Ctx) error {
 		}
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get customer")
 	}
-	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, customer)
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.CustomerToResponse(*customer))
 }
 
 func (h *AdminHandler) VerifyCustomer(c *fiber.Ctx) error {
@@ -259,6 +274,9 @@ func (h *AdminHandler) VerifyCustomer(c *fiber.Ctx) error {
 		if errors.Is(err, common.ErrCustomerNotFound) {
 			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Customer not found")
 		}
+		if errors.Is(err, common.ErrIncomeReviewPending) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "income_review_pending", err.Error())
+		}
 		// This can also be an invalid state transition error, which is a client error.
 		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "service_error", err.Error())
 	}
@@ -293,17 +311,29 @@ func (h *AdminHandler) SetLimits(c *fiber.Ctx) error {
 		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", "Validation failed: "+err.Error())
 	}
 
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
 	span.SetAttributes(
 		attribute.Int64("customer.id", int64(customerID)),
 		attribute.Int("limits.count", len(req.Limits)),
+		attribute.Int64("admin.id", int64(claims.UserID)),
 	)
 
-	if err := h.adminService.SetLimits(ctx, customerID, req); err != nil {
+	if err := h.adminService.SetLimits(ctx, customerID, claims.UserID, req); err != nil {
 		switch {
 		case errors.Is(err, common.ErrCustomerNotFound), errors.Is(err, common.ErrTenorNotFound):
 			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", err.Error())
-		case errors.Is(err, common.ErrInvalidLimitAmount):
+		case errors.Is(err, common.ErrInvalidLimitAmount), errors.Is(err, common.ErrInvalidEffectiveDate):
 			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "invalid_request", err.Error())
+		case errors.Is(err, common.ErrVersionConflict):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "version_conflict", err.Error())
+		case errors.Is(err, common.ErrLimitBelowUsage):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "limit_below_usage", err.Error())
+		case errors.Is(err, common.ErrFxRateNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "fx_rate_not_found", err.Error())
 		default:
 			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "An internal server error occurred")
 		}
@@ -313,3 +343,76 @@ func (h *AdminHandler) SetLimits(c *fiber.Ctx) error {
 		"message": "Customer limits updated successfully",
 	})
 }
+
+func (h *AdminHandler) GetLimitHistory(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetLimitHistory")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get limit history request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid customer ID")
+	}
+
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+
+	history, err := h.adminService.GetLimitHistory(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, common.ErrCustomerNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Customer not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get limit history")
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.LimitChangesToResponse(history))
+}
+
+// GetLimitsAt returns the limits in force at ?at=, a date (YYYY-MM-DD, the
+// end of that day in UTC) or an RFC 3339 time, defaulting to now. Dates
+// after today show scheduled limits.
+func (h *AdminHandler) GetLimitsAt(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetLimitsAt")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get limits at request", zap.String("path", c.Path()), zap.String("at", c.Query("at")))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid customer ID")
+	}
+
+	at := time.Now()
+	if v := c.Query("at"); v != "" {
+		if day, err := time.Parse("2006-01-02", v); err == nil {
+			// Tanggal saja berarti limit yang berlaku di akhir hari itu
+			at = day.AddDate(0, 0, 1).Add(-time.Microsecond)
+		} else if at, err = time.Parse(time.RFC3339, v); err != nil {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "at must be a date (YYYY-MM-DD) or an RFC 3339 time")
+		}
+	}
+
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+
+	limits, err := h.adminService.GetLimitsAt(ctx, customerID, at)
+	if err != nil {
+		if errors.Is(err, common.ErrCustomerNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Customer not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get limits")
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.LimitsAtToResponse(at, limits))
+}
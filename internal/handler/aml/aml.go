@@ -0,0 +1,416 @@
+package amlhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AMLHandler struct {
+	amlService      service.AMLServices
+	validate        *validator.Validate
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewAMLHandler(
+	amlService service.AMLServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *AMLHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &AMLHandler{
+		amlService:      amlService,
+		validate:        money.NewValidator(),
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *AMLHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *AMLHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+var amlCaseListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status":      {Column: "status", Values: []string{string(domain.AMLCaseOpen), string(domain.AMLCaseReviewed), string(domain.AMLCaseReported)}},
+		"rule":        {Column: "rule", Values: []string{string(domain.AMLLargeTransaction), string(domain.AMLRapidSequence)}},
+		"customer_id": {Column: "customer_id", Validate: isID},
+	},
+	Sorts: map[string]string{"created_at": "created_at", "amount_idr": "amount_idr"},
+}
+
+func isID(v string) bool {
+	_, err := strconv.ParseUint(v, 10, 64)
+	return err == nil
+}
+
+// ListCases returns AML cases, newest first, optionally filtered by
+// ?status=, ?rule= and ?customer_id=.
+func (h *AMLHandler) ListCases(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListAMLCases")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list AML cases request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	params, err := amlCaseListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	res, err := h.amlService.ListCases(ctx, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list AML cases")
+	}
+	cases, _ := res.Data.([]domain.AMLCase)
+	res.Data = dto.AMLCasesToResponse(cases)
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res)
+}
+
+func (h *AMLHandler) GetCase(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetAMLCase")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get AML case request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	caseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid AML case ID")
+	}
+
+	span.SetAttributes(attribute.Int64("aml_case.id", int64(caseID)))
+
+	amlCase, err := h.amlService.GetCase(ctx, caseID)
+	if err != nil {
+		if errors.Is(err, common.ErrAMLCaseNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "AML case not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get AML case")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.AMLCaseToResponse(*amlCase), zap.Uint64("aml_case_id", caseID))
+}
+
+func (h *AMLHandler) UpdateStatus(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.UpdateAMLCaseStatus")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received update AML case status request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	caseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid AML case ID")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("aml_case.id", int64(caseID)),
+		attribute.Int64("admin.id", int64(claims.UserID)),
+	)
+
+	var req dto.AMLCaseStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	amlCase, err := h.amlService.UpdateStatus(ctx, caseID, claims.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrAMLCaseNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "AML case not found")
+		case errors.Is(err, common.ErrInvalidAMLTransition):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "invalid_transition", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to update AML case")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.AMLCaseToResponse(*amlCase),
+		zap.Uint64("aml_case_id", caseID),
+		zap.String("status", string(amlCase.Status)),
+	)
+}
+
+// Export downloads the cases opened between ?from= and ?to= (YYYY-MM-DD,
+// both inclusive) as a CSV laid out after the goAML transaction schema, for
+// upload to the FIU. ?status= narrows it to one status.
+func (h *AMLHandler) Export(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ExportAMLCases")
+	defer span.End()
+	start := time.Now()
+
+	from, to := c.Query("from"), c.Query("to")
+	status := domain.AMLCaseStatus(strings.ToUpper(c.Query("status")))
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("aml.from", from),
+		attribute.String("aml.to", to),
+	)
+	h.log.Debug("Received export AML cases request", zap.String("path", c.Path()), zap.String("from", from), zap.String("to", to))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	switch status {
+	case "", domain.AMLCaseOpen, domain.AMLCaseReviewed, domain.AMLCaseReported:
+	default:
+		return h.recordError(ctx, span, c, start, fmt.Errorf("unsupported status %q", status), fiber.StatusBadRequest, "validation_error", "Status must be OPEN, REVIEWED or REPORTED")
+	}
+
+	cases, err := h.amlService.Export(ctx, from, to, status)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidAMLExportRange) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to export AML cases")
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(goAMLRecords(cases)); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "csv_error", "Failed to render AML export")
+	}
+
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", fiber.StatusOK),
+	))
+	h.responseSize.Record(ctx, int64(buf.Len()), metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+	))
+
+	span.SetAttributes(
+		attribute.Int("http.status_code", fiber.StatusOK),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	h.log.Info("Request completed successfully",
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", fiber.StatusOK),
+		zap.Float64("duration_ms", duration),
+		zap.String("format", "csv"),
+		zap.Int("cases", len(cases)),
+	)
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Attachment("aml-cases-" + from + "-" + to + ".csv")
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// goAMLRecords writes one line per case. Column names follow the goAML
+// transaction and person elements so the file maps onto the FIU's import
+// template; foreign_amount and exchange_rate stay empty for rupiah
+// contracts.
+func goAMLRecords(cases []domain.AMLCase) [][]string {
+	records := [][]string{{
+		"internal_ref_number", "transactionnumber", "date_transaction", "transaction_description",
+		"amount_local", "currency_code", "foreign_amount", "exchange_rate",
+		"ssn", "full_name", "birthdate", "birth_place",
+		"rule", "status", "report_reference",
+	}}
+	for _, amlCase := range cases {
+		record := make([]string, 0, len(records[0]))
+		record = append(record, strconv.FormatUint(amlCase.ID, 10))
+
+		var contract, date, currency, foreignAmount, rate string
+		if t := amlCase.Transaction; t != nil {
+			contract = t.ContractNumber
+			date = t.TransactionDate.Format("2006-01-02T15:04:05")
+			currency = t.Currency
+			if currency != "" && currency != "IDR" {
+				foreignAmount = t.OTRAmount.StringFixed(2)
+				rate = t.FxRate.String()
+			}
+		}
+		record = append(record, contract, date, amlCase.Detail,
+			amlCase.AmountIDR.StringFixed(2), currency, foreignAmount, rate)
+
+		var nik, name, birthDate, birthPlace string
+		if customer := amlCase.Customer; customer != nil {
+			nik = customer.NIK
+			name = customer.LegalName
+			if name == "" {
+				name = customer.FullName
+			}
+			birthDate = customer.BirthDate.Format("2006-01-02T15:04:05")
+			birthPlace = customer.BirthPlace
+		}
+		record = append(record, nik, name, birthDate, birthPlace,
+			string(amlCase.Rule), string(amlCase.Status), amlCase.ReportReference)
+
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,374 @@
+package attachmenthandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AttachmentHandler struct {
+	attachmentService service.TransactionAttachmentServices
+	validate          *validator.Validate
+	meter             metric.Meter
+	tracer            trace.Tracer
+	log               *zap.Logger
+	requestCount      metric.Int64Counter
+	requestDuration   metric.Float64Histogram
+	errorCount        metric.Int64Counter
+	responseSize      metric.Int64Histogram
+}
+
+func NewAttachmentHandler(
+	attachmentService service.TransactionAttachmentServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *AttachmentHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		validate:          validator.New(validator.WithRequiredStructEnabled()),
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		requestCount:      requestCount,
+		requestDuration:   requestDuration,
+		errorCount:        errorCount,
+		responseSize:      responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *AttachmentHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *AttachmentHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+// owner resolves who uploads or reads attachments: the partner behind an API
+// key, otherwise the customer behind the session.
+func owner(c *fiber.Ctx) (domain.BatchOwner, error) {
+	if partner, sandbox, err := middleware.GetPartnerFromLocals(c); err == nil {
+		return domain.BatchOwner{PartnerID: &partner.ID, Sandbox: sandbox}, nil
+	}
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return domain.BatchOwner{}, err
+	}
+	return domain.BatchOwner{CustomerID: &claims.UserID}, nil
+}
+
+func (h *AttachmentHandler) UploadAttachment(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.UploadTransactionAttachment")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received upload transaction attachment request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	attachmentOwner, err := owner(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "unauthorized", "Unauthorized")
+	}
+
+	transactionID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid transaction ID")
+	}
+	span.SetAttributes(attribute.Int64("transaction.id", int64(transactionID)))
+
+	var req dto.TransactionAttachmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid request body")
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "form_file_error", "File is a required form field")
+	}
+
+	data, err := readFile(file)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "form_file_error", "Cannot read uploaded file")
+	}
+
+	serviceCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	attachment, err := h.attachmentService.UploadAttachment(serviceCtx, attachmentOwner, transactionID, req, file.Filename, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrTransactionNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", err.Error())
+		case errors.Is(err, common.ErrAttachmentTooLarge):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusRequestEntityTooLarge, "too_large", err.Error())
+		case errors.Is(err, common.ErrUnsupportedAttachment):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnsupportedMediaType, "unsupported_type", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to upload attachment")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.TransactionAttachmentToResponse(*attachment),
+		zap.Uint64("attachment_id", attachment.ID),
+		zap.Uint64("transaction_id", transactionID),
+	)
+}
+
+func (h *AttachmentHandler) ListAttachments(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListTransactionAttachments")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list transaction attachments request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	attachmentOwner, err := owner(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "unauthorized", "Unauthorized")
+	}
+
+	transactionID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid transaction ID")
+	}
+	span.SetAttributes(attribute.Int64("transaction.id", int64(transactionID)))
+
+	attachments, err := h.attachmentService.ListAttachments(ctx, attachmentOwner, transactionID)
+	if err != nil {
+		if errors.Is(err, common.ErrTransactionNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", err.Error())
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list attachments")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.TransactionAttachmentsToResponse(attachments),
+		zap.Uint64("transaction_id", transactionID),
+		zap.Int("count", len(attachments)),
+	)
+}
+
+func (h *AttachmentHandler) ListAllAttachments(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListAllTransactionAttachments")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received admin list transaction attachments request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	transactionID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid transaction ID")
+	}
+	span.SetAttributes(attribute.Int64("transaction.id", int64(transactionID)))
+
+	attachments, err := h.attachmentService.ListAllAttachments(ctx, transactionID)
+	if err != nil {
+		if errors.Is(err, common.ErrTransactionNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", err.Error())
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list attachments")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.TransactionAttachmentsToResponse(attachments),
+		zap.Uint64("transaction_id", transactionID),
+		zap.Int("count", len(attachments)),
+	)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.DeleteTransactionAttachment")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received delete transaction attachment request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	transactionID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid transaction ID")
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Params("attachmentId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid attachment ID")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("transaction.id", int64(transactionID)),
+		attribute.Int64("attachment.id", int64(attachmentID)),
+		attribute.Int64("admin.id", int64(claims.UserID)),
+	)
+
+	if err := h.attachmentService.DeleteAttachment(ctx, transactionID, attachmentID, claims.UserID); err != nil {
+		if errors.Is(err, common.ErrAttachmentNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", err.Error())
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to delete attachment")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, fiber.Map{"message": "Attachment deleted successfully"},
+		zap.Uint64("attachment_id", attachmentID),
+		zap.Uint64("transaction_id", transactionID),
+	)
+}
+
+func readFile(file *multipart.FileHeader) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	return io.ReadAll(src)
+}
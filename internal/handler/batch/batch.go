@@ -0,0 +1,285 @@
+package batchhandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type BatchHandler struct {
+	batchService    service.TransactionBatchServices
+	validate        *validator.Validate
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewBatchHandler(
+	batchService service.TransactionBatchServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *BatchHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &BatchHandler{
+		batchService:    batchService,
+		validate:        money.NewValidator(),
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *BatchHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *BatchHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+// itemError describes why one item of a submitted batch was rejected.
+type itemError struct {
+	Sequence int    `json:"sequence"`
+	Error    string `json:"error"`
+}
+
+// owner resolves who submits or reads a batch: the partner behind an API key,
+// otherwise the customer behind the session.
+func owner(c *fiber.Ctx) (domain.BatchOwner, error) {
+	if partner, sandbox, err := middleware.GetPartnerFromLocals(c); err == nil {
+		return domain.BatchOwner{PartnerID: &partner.ID, Sandbox: sandbox}, nil
+	}
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return domain.BatchOwner{}, err
+	}
+	return domain.BatchOwner{CustomerID: &claims.UserID}, nil
+}
+
+func (h *BatchHandler) SubmitBatch(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.SubmitTransactionBatch")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received submit transaction batch request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	batchOwner, err := owner(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "unauthorized", "Unauthorized")
+	}
+
+	var req dto.CreateTransactionBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", "Validation failed", zap.Error(err))
+	}
+
+	span.SetAttributes(attribute.Int("batch.items", len(req.Items)))
+
+	// Semua item divalidasi sebelum batch diterima, agar partner tahu item mana yang harus diperbaiki
+	var invalid []itemError
+	for i, item := range req.Items {
+		if err := h.validate.Struct(item); err != nil {
+			invalid = append(invalid, itemError{Sequence: i + 1, Error: err.Error()})
+		}
+	}
+	if len(invalid) > 0 {
+		h.errorCount.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("endpoint", c.Path()),
+			attribute.String("method", c.Method()),
+			attribute.String("error_type", "validation_error"),
+			attribute.Int("status_code", fiber.StatusBadRequest),
+		))
+		h.log.Warn("Transaction batch has invalid items",
+			zap.Int("items", len(req.Items)),
+			zap.Int("invalid", len(invalid)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+		return responder.FailWithDetails(c, fiber.StatusBadRequest, "validation_error", "Some batch items are invalid", invalid)
+	}
+
+	batch, err := h.batchService.Submit(ctx, batchOwner, req)
+	if err != nil {
+		if errors.Is(err, common.ErrBatchTooLarge) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusRequestEntityTooLarge, "batch_too_large", "Batch has more items than allowed", zap.Int("items", len(req.Items)))
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to submit transaction batch")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusAccepted, dto.TransactionBatchToResponse(*batch),
+		zap.Uint64("batch_id", batch.ID),
+		zap.Int("items", len(batch.Items)),
+	)
+}
+
+func (h *BatchHandler) GetBatch(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetTransactionBatch")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get transaction batch request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	batchOwner, err := owner(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "unauthorized", "Unauthorized")
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid batch ID")
+	}
+
+	span.SetAttributes(attribute.Int64("batch.id", int64(id)))
+
+	batch, err := h.batchService.GetBatch(ctx, id, batchOwner)
+	if err != nil {
+		if errors.Is(err, common.ErrBatchNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Transaction batch not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get transaction batch")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.TransactionBatchToResponse(*batch), zap.Uint64("batch_id", id))
+}
@@ -0,0 +1,353 @@
+package blacklisthandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type BlacklistHandler struct {
+	blacklistService service.BlacklistServices
+	validate         *validator.Validate
+	meter            metric.Meter
+	tracer           trace.Tracer
+	log              *zap.Logger
+	requestCount     metric.Int64Counter
+	requestDuration  metric.Float64Histogram
+	errorCount       metric.Int64Counter
+	responseSize     metric.Int64Histogram
+}
+
+func NewBlacklistHandler(
+	blacklistService service.BlacklistServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *BlacklistHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &BlacklistHandler{
+		blacklistService: blacklistService,
+		validate:         validator.New(validator.WithRequiredStructEnabled()),
+		meter:            meter,
+		tracer:           tracer,
+		log:              log,
+		requestCount:     requestCount,
+		requestDuration:  requestDuration,
+		errorCount:       errorCount,
+		responseSize:     responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *BlacklistHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *BlacklistHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+func (h *BlacklistHandler) CreateEntry(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.CreateBlacklistEntry")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received create blacklist entry request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	var req dto.BlacklistEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	entry, err := h.blacklistService.CreateEntry(ctx, req)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to create blacklist entry")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, entry, zap.Uint64("entry_id", entry.ID))
+}
+
+func (h *BlacklistHandler) GetEntry(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetBlacklistEntry")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get blacklist entry request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	entryID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid blacklist entry ID")
+	}
+
+	entry, err := h.blacklistService.GetEntry(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, common.ErrBlacklistNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Blacklist entry not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get blacklist entry")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, entry)
+}
+
+func (h *BlacklistHandler) UpdateEntry(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.UpdateBlacklistEntry")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received update blacklist entry request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	entryID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid blacklist entry ID")
+	}
+
+	var req dto.BlacklistEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	entry, err := h.blacklistService.UpdateEntry(ctx, entryID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrBlacklistNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Blacklist entry not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to update blacklist entry")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, entry, zap.Uint64("entry_id", entryID))
+}
+
+func (h *BlacklistHandler) DeleteEntry(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.DeleteBlacklistEntry")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received delete blacklist entry request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	entryID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid blacklist entry ID")
+	}
+
+	if err := h.blacklistService.DeleteEntry(ctx, entryID); err != nil {
+		if errors.Is(err, common.ErrBlacklistNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Blacklist entry not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to delete blacklist entry")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, fiber.Map{"message": "Blacklist entry deleted successfully"}, zap.Uint64("entry_id", entryID))
+}
+
+var blacklistEntryQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"severity": {Column: "severity", Values: []string{string(domain.SeverityBlock), string(domain.SeverityFlag)}},
+	},
+	Sorts: map[string]string{"created_at": "created_at"},
+}
+
+func (h *BlacklistHandler) ListEntries(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListBlacklistEntries")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list blacklist entries request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	params, err := blacklistEntryQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	res, err := h.blacklistService.ListEntries(ctx, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list blacklist entries")
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res)
+}
+
+var screeningLogQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"outcome": {Column: "outcome", Values: []string{string(domain.ScreeningBlocked), string(domain.ScreeningFlagged)}},
+	},
+	Sorts: map[string]string{"created_at": "created_at"},
+}
+
+func (h *BlacklistHandler) ListScreeningLogs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListScreeningLogs")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list screening logs request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	params, err := screeningLogQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	res, err := h.blacklistService.ListScreeningLogs(ctx, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list screening logs")
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res)
+}
@@ -0,0 +1,296 @@
+package calendarhandler
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CalendarHandler struct {
+	calendarService service.CalendarServices
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewCalendarHandler(
+	calendarService service.CalendarServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *CalendarHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &CalendarHandler{
+		calendarService: calendarService,
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *CalendarHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *CalendarHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+// CreateLink issues the subscription link of the installment calendar of
+// one of the customer's contracts, revoking the previous link.
+func (h *CalendarHandler) CreateLink(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.CreateCalendarLink")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received create calendar link request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Customer ID not found")
+	}
+
+	contractNumber := c.Params("contractNumber")
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(claims.UserID)),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+
+	token, err := h.calendarService.CreateToken(ctx, claims.UserID, contractNumber)
+	if err != nil {
+		if errors.Is(err, common.ErrTransactionNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Transaction not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to create calendar link")
+	}
+
+	// Link feed dibentuk dari path request ini agar ikut versi API yang dipakai
+	feedURL := c.BaseURL() + strings.TrimSuffix(c.Path(), "calendar-link") + "calendar.ics?token=" + url.QueryEscape(token)
+	response := dto.CalendarLinkResponse{
+		URL:       feedURL,
+		WebcalURL: "webcal://" + strings.TrimPrefix(strings.TrimPrefix(feedURL, "https://"), "http://"),
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, response, zap.Uint64("customer_id", claims.UserID), zap.String("contract_number", contractNumber))
+}
+
+// RevokeLink revokes the calendar subscription link of a contract.
+func (h *CalendarHandler) RevokeLink(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.RevokeCalendarLink")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received revoke calendar link request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Customer ID not found")
+	}
+
+	contractNumber := c.Params("contractNumber")
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(claims.UserID)),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+
+	if err := h.calendarService.RevokeToken(ctx, claims.UserID, contractNumber); err != nil {
+		if errors.Is(err, common.ErrCalendarTokenNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Contract has no active calendar link")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to revoke calendar link")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, fiber.Map{"message": "Calendar link revoked successfully"}, zap.Uint64("customer_id", claims.UserID), zap.String("contract_number", contractNumber))
+}
+
+// Feed serves the installment calendar of a contract to calendar apps. It
+// is authorised by the ?token= of the subscription link, not a login.
+func (h *CalendarHandler) Feed(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetCalendarFeed")
+	defer span.End()
+	start := time.Now()
+
+	contractNumber := c.Params("contractNumber")
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+	h.log.Debug("Received calendar feed request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	feed, err := h.calendarService.Feed(ctx, contractNumber, c.Query("token"))
+	if err != nil {
+		// Link yang dicabut dan yang tidak pernah ada tidak dibedakan
+		if errors.Is(err, common.ErrCalendarTokenInvalid) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Calendar not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get calendar")
+	}
+
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", fiber.StatusOK),
+	))
+	h.responseSize.Record(ctx, int64(len(feed)), metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+	))
+	span.SetAttributes(
+		attribute.Int("http.status_code", fiber.StatusOK),
+		attribute.Float64("request.duration_ms", duration),
+	)
+	h.log.Info("Request completed successfully",
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", fiber.StatusOK),
+		zap.Float64("duration_ms", duration),
+		zap.String("format", "ics"),
+		zap.String("contract_number", contractNumber),
+	)
+
+	// Link berisi token, jadi respons tidak boleh disimpan cache bersama
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="installments-`+contractNumber+`.ics"`)
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return c.Status(fiber.StatusOK).Send(feed)
+}
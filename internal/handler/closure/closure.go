@@ -0,0 +1,238 @@
+package closurehandler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AccountClosureHandler struct {
+	closureService  service.AccountClosureServices
+	validate        *validator.Validate
+	store           *session.Store
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewAccountClosureHandler(
+	closureService service.AccountClosureServices,
+	store *session.Store,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *AccountClosureHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &AccountClosureHandler{
+		closureService:  closureService,
+		validate:        validator.New(validator.WithRequiredStructEnabled()),
+		store:           store,
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *AccountClosureHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *AccountClosureHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+// CloseAccount closes the logged-in customer's account. It is refused while
+// contracts are running or principal is outstanding. On success the auth
+// cookie and session are cleared like on logout.
+func (h *AccountClosureHandler) CloseAccount(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.CloseAccount")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received close account request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Customer ID not found")
+	}
+
+	span.SetAttributes(attribute.Int64("customer.id", int64(claims.UserID)))
+
+	var req dto.CloseAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	closure, err := h.closureService.Close(ctx, claims.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrCustomerNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Customer not found")
+		case errors.Is(err, common.ErrInvalidCredentials):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Password is incorrect")
+		case errors.Is(err, common.ErrAccountClosed):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "account_closed", "Account is already closed")
+		case errors.Is(err, common.ErrActiveContracts):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "active_contracts", "Account has contracts that are still running")
+		case errors.Is(err, common.ErrOutstandingBalance):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "outstanding_balance", "Account still has an outstanding balance")
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to close account")
+		}
+	}
+
+	h.endSession(c)
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.AccountClosureToResponse(*closure), zap.Uint64("customer_id", claims.UserID))
+}
+
+// endSession clears the auth cookie and the CSRF session of this browser.
+// Tokens held elsewhere are refused by the account closure middleware.
+func (h *AccountClosureHandler) endSession(c *fiber.Ctx) {
+	c.Cookie(&fiber.Cookie{
+		Name:     "private",
+		Value:    "",
+		Expires:  time.Now().Add(-time.Hour),
+		HTTPOnly: true,
+		Secure:   false,
+		SameSite: "Strict",
+	})
+
+	if sess, err := h.store.Get(c); err == nil {
+		sess.Destroy()
+	}
+}
@@ -0,0 +1,444 @@
+package collectionhandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CollectionHandler struct {
+	collectionService service.CollectionServices
+	validate          *validator.Validate
+	meter             metric.Meter
+	tracer            trace.Tracer
+	log               *zap.Logger
+	requestCount      metric.Int64Counter
+	requestDuration   metric.Float64Histogram
+	errorCount        metric.Int64Counter
+	responseSize      metric.Int64Histogram
+}
+
+func NewCollectionHandler(
+	collectionService service.CollectionServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *CollectionHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &CollectionHandler{
+		collectionService: collectionService,
+		validate:          money.NewValidator(),
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		requestCount:      requestCount,
+		requestDuration:   requestDuration,
+		errorCount:        errorCount,
+		responseSize:      responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *CollectionHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *CollectionHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+var collectionCaseListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status": {Column: "status", Values: []string{
+			string(domain.CollectionOpen), string(domain.CollectionInProgress), string(domain.CollectionRepossession),
+			string(domain.CollectionRepossessed), string(domain.CollectionResolved),
+		}},
+		"collector_id": {Column: "collector_id", Validate: isID},
+		"customer_id":  {Column: "customer_id", Validate: isID},
+	},
+	Sorts: map[string]string{"created_at": "created_at", "days_past_due": "days_past_due", "overdue_amount": "overdue_amount"},
+}
+
+func isID(v string) bool {
+	_, err := strconv.ParseUint(v, 10, 64)
+	return err == nil
+}
+
+// ListCases returns collection cases, newest first, optionally filtered by
+// ?status=, ?collector_id= and ?customer_id=.
+func (h *CollectionHandler) ListCases(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListCollectionCases")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list collection cases request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	params, err := collectionCaseListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	res, err := h.collectionService.ListCases(ctx, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list collection cases")
+	}
+	cases, _ := res.Data.([]domain.CollectionCase)
+	res.Data = dto.CollectionCasesToResponse(cases)
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res)
+}
+
+func (h *CollectionHandler) GetCase(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetCollectionCase")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get collection case request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	caseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid collection case ID")
+	}
+
+	collectionCase, err := h.collectionService.GetCase(ctx, caseID)
+	if err != nil {
+		if errors.Is(err, common.ErrCollectionCaseNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Collection case not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get collection case")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.CollectionCaseToResponse(*collectionCase), zap.Uint64("collection_case_id", caseID))
+}
+
+func (h *CollectionHandler) Assign(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.AssignCollectionCase")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received assign collection case request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	caseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid collection case ID")
+	}
+
+	var req dto.CollectionAssignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	collectionCase, err := h.collectionService.Assign(ctx, caseID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrCollectionCaseNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Collection case not found")
+		case errors.Is(err, common.ErrCollectorNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		case errors.Is(err, common.ErrCollectionCaseClosed), errors.Is(err, common.ErrVersionConflict):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "conflict", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to assign collection case")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.CollectionCaseToResponse(*collectionCase),
+		zap.Uint64("collection_case_id", caseID),
+		zap.Uint64p("collector_id", collectionCase.CollectorID),
+	)
+}
+
+func (h *CollectionHandler) UpdateStatus(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.UpdateCollectionCaseStatus")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received update collection case status request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	caseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid collection case ID")
+	}
+
+	var req dto.CollectionCaseStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	collectionCase, err := h.collectionService.UpdateStatus(ctx, caseID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrCollectionCaseNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Collection case not found")
+		case errors.Is(err, common.ErrInvalidCollectionMove):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "invalid_transition", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to update collection case")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.CollectionCaseToResponse(*collectionCase),
+		zap.Uint64("collection_case_id", caseID),
+		zap.String("status", string(collectionCase.Status)),
+	)
+}
+
+func (h *CollectionHandler) AddActivity(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.AddCollectionActivity")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received add collection activity request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	caseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid collection case ID")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("collection_case.id", int64(caseID)),
+		attribute.Int64("admin.id", int64(claims.UserID)),
+	)
+
+	var req dto.CollectionActivityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	activity, err := h.collectionService.AddActivity(ctx, caseID, claims.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrCollectionCaseNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Collection case not found")
+		case errors.Is(err, common.ErrInvalidPromiseToPay):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		case errors.Is(err, common.ErrCollectionCaseClosed):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "case_closed", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to add collection activity")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.CollectionActivityToResponse(*activity),
+		zap.Uint64("collection_case_id", caseID),
+		zap.Uint64("activity_id", activity.ID),
+	)
+}
+
+func (h *CollectionHandler) ListActivities(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListCollectionActivities")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list collection activities request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	caseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid collection case ID")
+	}
+
+	activities, err := h.collectionService.ListActivities(ctx, caseID)
+	if err != nil {
+		if errors.Is(err, common.ErrCollectionCaseNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Collection case not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list collection activities")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.CollectionActivitiesToResponse(activities),
+		zap.Uint64("collection_case_id", caseID),
+		zap.Int("activities_count", len(activities)),
+	)
+}
+
+// Dashboard summarises case loads per status and per collector for
+// collection managers.
+func (h *CollectionHandler) Dashboard(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.CollectionDashboard")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received collection dashboard request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	dashboard, err := h.collectionService.Dashboard(ctx, time.Now())
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to build collection dashboard")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.CollectionDashboardToResponse(*dashboard),
+		zap.Int64("unassigned", dashboard.Unassigned),
+	)
+}
@@ -0,0 +1,221 @@
+package contracthandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ContractLookupHandler struct {
+	contractService service.ContractLookupServices
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewContractLookupHandler(
+	contractService service.ContractLookupServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *ContractLookupHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &ContractLookupHandler{
+		contractService: contractService,
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *ContractLookupHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *ContractLookupHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+// minPrefixLength keeps prefix searches selective enough to be useful.
+const minPrefixLength = 3
+
+var contractQuery = query.Spec{DefaultLimit: 20}
+
+// FindByContractNumber looks a contract up by its number for support. The
+// default ?mode=exact returns the one contract; ?mode=prefix pages through
+// every contract whose number starts with :number.
+func (h *ContractLookupHandler) FindByContractNumber(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.FindContractByNumber")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received contract lookup request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	number := strings.ToUpper(strings.TrimSpace(c.Params("number")))
+	mode := c.Query("mode", "exact")
+	span.SetAttributes(
+		attribute.String("transaction.contract_number", number),
+		attribute.String("lookup.mode", mode),
+	)
+
+	switch mode {
+	case "exact":
+		res, err := h.contractService.FindByContractNumber(ctx, number)
+		if err != nil {
+			if errors.Is(err, common.ErrTransactionNotFound) {
+				return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Transaction not found", zap.String("contract_number", number))
+			}
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to find transaction")
+		}
+		return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res, zap.String("contract_number", number))
+
+	case "prefix":
+		if len(number) < minPrefixLength {
+			err := fmt.Errorf("prefix must have at least %d characters", minPrefixLength)
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		}
+		params, err := contractQuery.Parse(c)
+		if err != nil {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		}
+
+		res, err := h.contractService.Search(ctx, number, params)
+		if err != nil {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to search transactions")
+		}
+		return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res, zap.String("prefix", number), zap.Int64("total", res.Total))
+
+	default:
+		err := fmt.Errorf("unsupported mode %q", mode)
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", "mode must be exact or prefix")
+	}
+}
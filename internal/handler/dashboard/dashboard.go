@@ -0,0 +1,280 @@
+package dashboardhandler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// frameWriteTimeout bounds the write of a single event, in place of the
+	// server's WriteTimeout that would otherwise cut the stream off.
+	frameWriteTimeout = 15 * time.Second
+	// retryFrame tells the browser to wait 5 seconds before reconnecting.
+	retryFrame = "retry: 5000\n\n"
+)
+
+type DashboardHandler struct {
+	dashboardService service.DashboardServices
+	heartbeat        time.Duration
+	meter            metric.Meter
+	tracer           trace.Tracer
+	log              *zap.Logger
+	requestCount     metric.Int64Counter
+	requestDuration  metric.Float64Histogram
+	errorCount       metric.Int64Counter
+	responseSize     metric.Int64Histogram
+}
+
+// NewDashboardHandler returns the handler of the realtime dashboard stream.
+// A heartbeat comment is sent every heartbeat while there is nothing else to
+// send.
+func NewDashboardHandler(
+	dashboardService service.DashboardServices,
+	heartbeat time.Duration,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *DashboardHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+		heartbeat:        heartbeat,
+		meter:            meter,
+		tracer:           tracer,
+		log:              log,
+		requestCount:     requestCount,
+		requestDuration:  requestDuration,
+		errorCount:       errorCount,
+		responseSize:     responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *DashboardHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// Stream pushes dashboard snapshots to the admin as server-sent events. The
+// stream ends when the client goes away, when the admin's token expires, or
+// when the server shuts down; the browser's EventSource reconnects by itself
+// in the last case, after a token expiry the client has to log in again.
+func (h *DashboardHandler) Stream(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.DashboardStream")
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received dashboard stream request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		defer span.End()
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+	span.SetAttributes(attribute.Int64("admin.id", int64(claims.UserID)))
+
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	// Path, method dan koneksi disalin karena fiber.Ctx sudah dipakai ulang saat stream berjalan
+	endpoint, method, conn := c.Path(), c.Method(), c.Context().Conn()
+	ctx = context.WithoutCancel(ctx)
+
+	updates, unsubscribe := h.dashboardService.Subscribe(ctx)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// Nginx tidak boleh menahan event di buffer-nya
+	c.Set("X-Accel-Buffering", "no")
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer span.End()
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(h.heartbeat)
+		defer heartbeat.Stop()
+
+		var expired <-chan time.Time
+		if !expiresAt.IsZero() {
+			timer := time.NewTimer(time.Until(expiresAt))
+			defer timer.Stop()
+			expired = timer.C
+		}
+
+		out := &frameWriter{conn: conn, w: w}
+		events, reason := 0, ""
+		err := out.send(retryFrame)
+		for err == nil && reason == "" {
+			select {
+			case snapshot, ok := <-updates:
+				if ok {
+					err = out.event("snapshot", dto.DashboardSnapshotToResponse(snapshot))
+					events++
+				} else {
+					reason = "closed"
+				}
+			case <-heartbeat.C:
+				err = out.send(": heartbeat\n\n")
+			case <-expired:
+				reason = "token_expired"
+				err = out.event("expired", fiber.Map{"message": "Session expired, log in again to keep receiving updates"})
+			}
+		}
+		if err != nil {
+			// Tulis gagal berarti klien sudah menutup koneksi
+			reason = "client_gone"
+		}
+
+		duration := float64(time.Since(start).Nanoseconds()) / 1e6
+		h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+			attribute.String("endpoint", endpoint),
+			attribute.String("method", method),
+			attribute.Int("status_code", fiber.StatusOK),
+		))
+		h.responseSize.Record(ctx, out.written, metric.WithAttributes(
+			attribute.String("endpoint", endpoint),
+			attribute.String("method", method),
+		))
+		span.SetAttributes(
+			attribute.Int("http.status_code", fiber.StatusOK),
+			attribute.Float64("request.duration_ms", duration),
+			attribute.Int("stream.events", events),
+			attribute.String("stream.end_reason", reason),
+		)
+		span.SetStatus(codes.Ok, "Stream ended")
+
+		h.log.Info("Dashboard stream ended",
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+			zap.Uint64("admin_id", claims.UserID),
+			zap.String("reason", reason),
+			zap.Int("events", events),
+			zap.Int64("bytes", out.written),
+			zap.Float64("duration_ms", duration),
+		)
+	})
+	return nil
+}
+
+// frameWriter writes and flushes one server-sent event at a time. The
+// server sets its write deadline once per response, so it is pushed forward
+// before every frame or the stream would be cut off after WriteTimeout.
+type frameWriter struct {
+	conn    net.Conn
+	w       *bufio.Writer
+	written int64
+}
+
+func (f *frameWriter) send(frame string) error {
+	if f.conn != nil {
+		_ = f.conn.SetWriteDeadline(time.Now().Add(frameWriteTimeout))
+	}
+	n, err := f.w.WriteString(frame)
+	f.written += int64(n)
+	if err != nil {
+		return err
+	}
+	return f.w.Flush()
+}
+
+func (f *frameWriter) event(name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return f.send("event: " + name + "\ndata: " + string(payload) + "\n\n")
+}
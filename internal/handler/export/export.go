@@ -0,0 +1,273 @@
+package exporthandler
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MaxTransactionExportDays is the longest range ExportTransactions covers.
+const MaxTransactionExportDays = 366
+
+type ExportHandler struct {
+	exportService   service.ExportServices
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewExportHandler(
+	exportService service.ExportServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *ExportHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &ExportHandler{
+		exportService:   exportService,
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *ExportHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// countingWriter counts the bytes written through to the response stream.
+type countingWriter struct {
+	*bufio.Writer
+	written int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// stream sends a CSV attachment written by export after the handler returns.
+// The status and headers are committed before the first row, so an export
+// that fails midway can only be logged and leaves the client a truncated
+// file. span ends once the stream does.
+func (h *ExportHandler) stream(
+	ctx context.Context, span trace.Span, c *fiber.Ctx, start time.Time, filename string,
+	export func(ctx context.Context, w *countingWriter) (int, error)) error {
+	// Path dan method disalin karena fiber.Ctx sudah dipakai ulang saat stream berjalan
+	endpoint, method := c.Path(), c.Method()
+	ctx = context.WithoutCancel(ctx)
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Attachment(filename)
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer span.End()
+
+		out := &countingWriter{Writer: w}
+		rows, err := export(ctx, out)
+		if err == nil {
+			err = w.Flush()
+		}
+
+		duration := float64(time.Since(start).Nanoseconds()) / 1e6
+		h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+			attribute.String("endpoint", endpoint),
+			attribute.String("method", method),
+			attribute.Int("status_code", fiber.StatusOK),
+		))
+		h.responseSize.Record(ctx, out.written, metric.WithAttributes(
+			attribute.String("endpoint", endpoint),
+			attribute.String("method", method),
+		))
+		span.SetAttributes(
+			attribute.Int("http.status_code", fiber.StatusOK),
+			attribute.Float64("request.duration_ms", duration),
+			attribute.Int("export.rows", rows),
+			attribute.Int64("export.bytes", out.written),
+		)
+
+		logFields := []zap.Field{
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+			zap.String("endpoint", endpoint),
+			zap.Float64("duration_ms", duration),
+			zap.Int("rows", rows),
+			zap.Int64("bytes", out.written),
+		}
+		if err != nil {
+			h.errorCount.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("endpoint", endpoint),
+				attribute.String("method", method),
+				attribute.String("error_type", "stream_error"),
+				attribute.Int("status_code", fiber.StatusOK),
+			))
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			h.log.Error("Export stream aborted", append(logFields, zap.Error(err))...)
+			return
+		}
+		h.log.Info("Request completed successfully", append(logFields, zap.String("format", "csv"))...)
+	})
+	return nil
+}
+
+// ExportCustomers downloads every customer account as CSV, streamed while it
+// is read. ?status= narrows it to one verification status.
+func (h *ExportHandler) ExportCustomers(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ExportCustomers")
+	start := time.Now()
+
+	status := domain.VerificationStatus(strings.ToUpper(c.Query("status")))
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("filter.status", string(status)),
+	)
+	h.log.Debug("Received export customers request", zap.String("path", c.Path()), zap.String("status", string(status)))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	switch status {
+	case "", domain.VerificationPending, domain.VerificationVerified, domain.VerificationRejected:
+	default:
+		defer span.End()
+		return h.recordError(ctx, span, c, start, fmt.Errorf("unsupported status %q", status), fiber.StatusBadRequest, "validation_error", "Status must be PENDING, VERIFIED or REJECTED")
+	}
+
+	filename := "customers.csv"
+	if status != "" {
+		filename = "customers-" + strings.ToLower(string(status)) + ".csv"
+	}
+	return h.stream(ctx, span, c, start, filename, func(ctx context.Context, w *countingWriter) (int, error) {
+		return h.exportService.ExportCustomers(ctx, w, status)
+	})
+}
+
+// ExportTransactions downloads the non-sandbox contracts dated between ?from=
+// and ?to= (YYYY-MM-DD, both inclusive) as CSV, streamed while it is read.
+func (h *ExportHandler) ExportTransactions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ExportTransactions")
+	start := time.Now()
+
+	from, to := c.Query("from"), c.Query("to")
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("export.from", from),
+		attribute.String("export.to", to),
+	)
+	h.log.Debug("Received export transactions request", zap.String("path", c.Path()), zap.String("from", from), zap.String("to", to))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	// Rentang divalidasi sebelum header terkirim; setelah itu status tidak bisa diubah
+	fromDate, fromErr := datetime.ParseDate(from)
+	toDate, toErr := datetime.ParseDate(to)
+	// Tanggal akhir ikut dihitung sampai akhir harinya
+	toDate = toDate.AddDate(0, 0, 1)
+	if fromErr != nil || toErr != nil || !toDate.After(fromDate) || toDate.Sub(fromDate) > MaxTransactionExportDays*24*time.Hour {
+		defer span.End()
+		return h.recordError(ctx, span, c, start, common.ErrInvalidExportRange, fiber.StatusBadRequest, "validation_error", common.ErrInvalidExportRange.Error())
+	}
+
+	return h.stream(ctx, span, c, start, "transactions-"+from+"-"+to+".csv", func(ctx context.Context, w *countingWriter) (int, error) {
+		return h.exportService.ExportTransactions(ctx, w, fromDate, toDate)
+	})
+}
@@ -0,0 +1,286 @@
+package graphqlhandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/graphql"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// maxQueryDepth bounds how deep a query may nest, so one request cannot
+// fan out into customers → transactions → ... without limit.
+const maxQueryDepth = 6
+
+// maxQueryComplexity bounds how many fields one query may select, aliases
+// and fragment spreads included.
+const maxQueryComplexity = 200
+
+type GraphQLHandler struct {
+	adminService    service.AdminServices
+	profileService  service.ProfileServices
+	contractService service.ContractLookupServices
+	reportService   service.ReportServices
+	schema          *graphql.Schema
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewGraphQLHandler(
+	adminService service.AdminServices,
+	profileService service.ProfileServices,
+	contractService service.ContractLookupServices,
+	reportService service.ReportServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *GraphQLHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	h := &GraphQLHandler{
+		adminService:    adminService,
+		profileService:  profileService,
+		contractService: contractService,
+		reportService:   reportService,
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+	h.schema = h.buildSchema()
+	return h
+}
+
+// recordError helper function to record errors with observability
+func (h *GraphQLHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordResult records the outcome of an executed query and sends the
+// GraphQL response as is, without the REST envelope, since GraphQL
+// clients expect {data, errors} at the top level.
+func (h *GraphQLHandler) recordResult(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, res *graphql.Response, fields ...zap.Field) error {
+	body, err := json.Marshal(res)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "encode_error", "Failed to encode GraphQL response")
+	}
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+	h.responseSize.Record(ctx, int64(len(body)), metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+	))
+
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Int("graphql.error_count", len(res.Errors)),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Int("graphql_errors", len(res.Errors)),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	if len(res.Errors) > 0 {
+		codes := make([]string, len(res.Errors))
+		for i, e := range res.Errors {
+			codes[i] = e.Code()
+		}
+		h.errorCount.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("endpoint", c.Path()),
+			attribute.String("method", c.Method()),
+			attribute.String("error_type", "graphql_error"),
+			attribute.Int("status_code", statusCode),
+		))
+		h.log.Warn("GraphQL request completed with errors", append(logFields, zap.Strings("error_codes", codes))...)
+	} else {
+		h.log.Info("Request completed successfully", logFields...)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Status(statusCode).Send(body)
+}
+
+// Query executes a GraphQL query over the admin and customer read models.
+// The query comes in a JSON body on POST, or in the query, operationName
+// and variables parameters on GET; every query is read-only, so GET is
+// also what an impersonation session, limited to safe methods, can use.
+// A request that fails before execution, e.g. a syntax error, is answered
+// with 400; once executed the answer is 200 and field failures are listed
+// in errors next to the data that did resolve.
+func (h *GraphQLHandler) Query(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GraphQLQuery")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received GraphQL request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: User ID not found")
+	}
+
+	var req graphql.Request
+	if c.Method() == fiber.MethodGet {
+		req.Query, req.OperationName = c.Query("query"), c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse variables")
+			}
+		}
+	} else if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		err := errors.New("query is required")
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.String("graphql.operation.name", req.OperationName),
+		attribute.String("user.role", string(claims.Role)),
+	)
+
+	ctx = withViewer(ctx, viewer{claims: claims, locale: datetime.Current(c)})
+	res := h.schema.Execute(ctx, req)
+
+	status := fiber.StatusOK
+	if !res.Executed() {
+		status = fiber.StatusBadRequest
+	}
+	return h.recordResult(ctx, span, c, start, status, res,
+		zap.Uint64("user_id", claims.UserID),
+		zap.String("operation_name", req.OperationName),
+	)
+}
+
+// viewer is the caller a query runs for.
+type viewer struct {
+	claims *domain.JwtCustomClaims
+	locale datetime.Locale
+}
+
+type viewerKey struct{}
+
+func withViewer(ctx context.Context, v viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, v)
+}
+
+func viewerFrom(ctx context.Context) viewer {
+	v, _ := ctx.Value(viewerKey{}).(viewer)
+	if v.claims == nil {
+		v.claims = &domain.JwtCustomClaims{}
+	}
+	return v
+}
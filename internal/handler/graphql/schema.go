@@ -0,0 +1,353 @@
+package graphqlhandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/graphql"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"go.uber.org/zap"
+)
+
+// codeForbidden is reported in extensions.code when the role of the
+// caller may not read a field.
+const (
+	codeForbidden = "FORBIDDEN"
+)
+
+// The list arguments accept the same filters and sorts as the REST list
+// endpoints they mirror.
+var (
+	customerListQuery = query.Spec{
+		Filters: map[string]query.Filter{
+			"status": {
+				Column: "verification_status",
+				Values: []string{string(domain.VerificationPending), string(domain.VerificationVerified), string(domain.VerificationRejected)},
+			},
+		},
+		Sorts: map[string]string{"created_at": "created_at", "full_name": "full_name"},
+	}
+	transactionListQuery = query.Spec{
+		Filters: map[string]query.Filter{
+			"status": {
+				Column: "status",
+				Values: []string{
+					string(domain.TransactionPending), string(domain.TransactionApproved), string(domain.TransactionActive),
+					string(domain.TransactionPaidOff), string(domain.TransactionCancelled), string(domain.TransactionWrittenOff),
+				},
+			},
+		},
+		Sorts: map[string]string{"transaction_date": "transaction_date", "otr_amount": "otr_amount"},
+	}
+	contractQuery = query.Spec{DefaultLimit: 20}
+)
+
+// minPrefixLength matches the REST prefix lookup.
+const minPrefixLength = 3
+
+// buildSchema declares the query root. Admins read any customer, contract
+// and report; customers read themselves through me. Relations such as a
+// customer's limits are resolved on demand, so a query only pays for the
+// fields it selects.
+func (h *GraphQLHandler) buildSchema() *graphql.Schema {
+	limit := graphql.ObjectOf(dto.LimitDetailResponse{})
+	transaction := graphql.ObjectOf(dto.TransactionResponse{})
+	contract := graphql.ObjectOf(dto.ContractLookupResponse{})
+
+	customer := graphql.ObjectOf(dto.CustomerResponse{})
+	customer.Fields["limits"] = &graphql.Field{
+		Type:    graphql.ListOf(graphql.NonNullOf(limit)),
+		Resolve: h.ownerOrAdmin(h.resolveLimits),
+	}
+	customer.Fields["transactions"] = &graphql.Field{
+		Type: pageOf("TransactionPage", transaction),
+		Args: listArgs(map[string]*graphql.Arg{
+			"status": {Type: graphql.String},
+		}),
+		Resolve: h.ownerOrAdmin(h.resolveTransactions),
+	}
+
+	reports := &graphql.Object{Name: "Reports", Fields: map[string]*graphql.Field{
+		"interest_accrual": {
+			Type: graphql.ObjectOf(dto.InterestAccrualReportResponse{}),
+			Args: map[string]*graphql.Arg{"month": {Type: graphql.NonNullOf(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				res, err := h.reportService.InterestAccrual(p.Context, p.Args["month"].(string))
+				return h.result(p.Context, res, err, "Failed to compute interest accrual")
+			},
+		},
+		"aging": {
+			Type: graphql.ObjectOf(dto.AgingReportResponse{}),
+			Args: map[string]*graphql.Arg{"date": {Type: graphql.String, Default: ""}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				date, _ := p.Args["date"].(string)
+				res, err := h.reportService.Aging(p.Context, date)
+				return h.result(p.Context, res, err, "Failed to compute aging report")
+			},
+		},
+		"partner_commission": {
+			Type: graphql.ObjectOf(dto.PartnerCommissionReportResponse{}),
+			Args: map[string]*graphql.Arg{"month": {Type: graphql.NonNullOf(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				res, err := h.reportService.PartnerCommission(p.Context, p.Args["month"].(string))
+				return h.result(p.Context, res, err, "Failed to compute partner commission")
+			},
+		},
+		"referral_conversion": {
+			Type: graphql.ObjectOf(dto.ReferralConversionReportResponse{}),
+			Args: map[string]*graphql.Arg{"month": {Type: graphql.NonNullOf(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				res, err := h.reportService.ReferralConversion(p.Context, p.Args["month"].(string))
+				return h.result(p.Context, res, err, "Failed to compute referral conversions")
+			},
+		},
+		"region_performance": {
+			Type: graphql.ObjectOf(dto.RegionReportResponse{}),
+			Args: map[string]*graphql.Arg{"month": {Type: graphql.NonNullOf(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				res, err := h.reportService.RegionPerformance(p.Context, p.Args["month"].(string))
+				return h.result(p.Context, res, err, "Failed to compute region performance")
+			},
+		},
+	}}
+
+	root := &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"me": {
+			Type:    customer,
+			Resolve: h.customerOnly(h.resolveMe),
+		},
+		"customer": {
+			Type:    customer,
+			Args:    map[string]*graphql.Arg{"id": {Type: graphql.NonNullOf(graphql.ID)}},
+			Resolve: h.adminOnly(h.resolveCustomer),
+		},
+		"customers": {
+			Type: pageOf("CustomerPage", customer),
+			Args: listArgs(map[string]*graphql.Arg{
+				"status": {Type: graphql.String},
+			}),
+			Resolve: h.adminOnly(h.resolveCustomers),
+		},
+		"contract": {
+			Type:    contract,
+			Args:    map[string]*graphql.Arg{"contract_number": {Type: graphql.NonNullOf(graphql.String)}},
+			Resolve: h.adminOnly(h.resolveContract),
+		},
+		"contracts": {
+			Type: pageOf("ContractPage", contract),
+			Args: listArgs(map[string]*graphql.Arg{
+				"prefix": {Type: graphql.NonNullOf(graphql.String)},
+			}),
+			Resolve: h.adminOnly(h.resolveContracts),
+		},
+		"reports": {
+			Type: reports,
+			Resolve: h.adminOnly(func(graphql.ResolveParams) (any, error) {
+				// Laporan di-resolve per field, objek ini hanya pengelompokan
+				return struct{}{}, nil
+			}),
+		},
+	}}
+
+	return &graphql.Schema{Query: root, MaxDepth: maxQueryDepth, MaxComplexity: maxQueryComplexity}
+}
+
+// listArgs adds page, limit and sort to args.
+func listArgs(args map[string]*graphql.Arg) map[string]*graphql.Arg {
+	args["page"] = &graphql.Arg{Type: graphql.Int, Default: 1}
+	args["limit"] = &graphql.Arg{Type: graphql.Int}
+	args["sort"] = &graphql.Arg{Type: graphql.String, Default: ""}
+	return args
+}
+
+// pageOf is a page of item, the GraphQL counterpart of the pagination
+// envelope of the REST list endpoints.
+func pageOf(name string, item *graphql.Object) *graphql.Object {
+	paginated := func(p graphql.ResolveParams) *domain.Paginated {
+		return p.Source.(*domain.Paginated)
+	}
+	return &graphql.Object{Name: name, Fields: map[string]*graphql.Field{
+		"items": {
+			Type:    graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(item))),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return paginated(p).Data, nil },
+		},
+		"total": {
+			Type:    graphql.NonNullOf(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return paginated(p).Total, nil },
+		},
+		"page": {
+			Type:    graphql.NonNullOf(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return paginated(p).Page, nil },
+		},
+		"limit": {
+			Type:    graphql.NonNullOf(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return paginated(p).Limit, nil },
+		},
+		"total_pages": {
+			Type:    graphql.NonNullOf(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return paginated(p).TotalPages, nil },
+		},
+	}}
+}
+
+// listParams validates the list arguments against spec.
+func listParams(spec query.Spec, p graphql.ResolveParams) (domain.Params, error) {
+	filters := map[string]string{}
+	for name := range spec.Filters {
+		if v, ok := p.Args[name].(string); ok {
+			filters[name] = v
+		}
+	}
+	page, _ := p.Args["page"].(int)
+	limit, _ := p.Args["limit"].(int)
+	sort, _ := p.Args["sort"].(string)
+	params, err := spec.Build(page, limit, filters, sort)
+	if err != nil {
+		return params, graphql.NewError(graphql.CodeBadUserInput, err.Error(), err)
+	}
+	return params, nil
+}
+
+var (
+	errAdminOnly    = graphql.NewError(codeForbidden, "Forbidden: admin role required", nil)
+	errCustomerOnly = graphql.NewError(codeForbidden, "Forbidden: customer role required", nil)
+	errNotOwner     = graphql.NewError(codeForbidden, "Forbidden: not your customer record", nil)
+)
+
+func (h *GraphQLHandler) adminOnly(resolve graphql.ResolveFunc) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (any, error) {
+		if viewerFrom(p.Context).claims.Role != domain.AdminRole {
+			return nil, errAdminOnly
+		}
+		return resolve(p)
+	}
+}
+
+func (h *GraphQLHandler) customerOnly(resolve graphql.ResolveFunc) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (any, error) {
+		if viewerFrom(p.Context).claims.Role != domain.CustomerRole {
+			return nil, errCustomerOnly
+		}
+		return resolve(p)
+	}
+}
+
+// ownerOrAdmin guards the relations of a customer: admins read any
+// customer's, customers only their own.
+func (h *GraphQLHandler) ownerOrAdmin(resolve graphql.ResolveFunc) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (any, error) {
+		claims := viewerFrom(p.Context).claims
+		switch {
+		case claims.Role == domain.AdminRole:
+		case claims.Role == domain.CustomerRole && claims.UserID == p.Source.(dto.CustomerResponse).ID:
+		default:
+			return nil, errNotOwner
+		}
+		return resolve(p)
+	}
+}
+
+// result maps a service error to a GraphQL error. Not found yields null,
+// invalid input is shown to the caller and anything else is logged and
+// reported as an internal error.
+func (h *GraphQLHandler) result(ctx context.Context, value any, err error, message string) (any, error) {
+	switch {
+	case err == nil:
+		return value, nil
+	case errors.Is(err, common.ErrCustomerNotFound), errors.Is(err, common.ErrTransactionNotFound):
+		return nil, nil
+	case errors.Is(err, common.ErrInvalidReportMonth), errors.Is(err, common.ErrInvalidReportDate),
+		errors.Is(err, query.ErrInvalidFilter), errors.Is(err, query.ErrInvalidSort):
+		return nil, graphql.NewError(graphql.CodeBadUserInput, err.Error(), err)
+	}
+	h.log.Error(message, zap.Uint64("user_id", viewerFrom(ctx).claims.UserID), zap.Error(err))
+	return nil, err
+}
+
+func (h *GraphQLHandler) resolveMe(p graphql.ResolveParams) (any, error) {
+	v := viewerFrom(p.Context)
+	customer, err := h.profileService.GetMyProfile(p.Context, v.claims.UserID)
+	if err != nil {
+		return h.result(p.Context, nil, err, "Failed to get profile")
+	}
+	return dto.CustomerToResponse(*customer).Localize(v.locale), nil
+}
+
+func (h *GraphQLHandler) resolveCustomer(p graphql.ResolveParams) (any, error) {
+	id, err := strconv.ParseUint(p.Args["id"].(string), 10, 64)
+	if err != nil {
+		return nil, graphql.NewError(graphql.CodeBadUserInput, "Invalid customer ID", err)
+	}
+	customer, err := h.adminService.GetCustomerByID(p.Context, id)
+	if err != nil {
+		return h.result(p.Context, nil, err, "Failed to get customer")
+	}
+	return dto.CustomerToResponse(*customer).Localize(viewerFrom(p.Context).locale), nil
+}
+
+func (h *GraphQLHandler) resolveCustomers(p graphql.ResolveParams) (any, error) {
+	params, err := listParams(customerListQuery, p)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.adminService.ListCustomers(p.Context, params)
+	if err != nil {
+		return h.result(p.Context, nil, err, "Failed to list customers")
+	}
+	customers, _ := res.Data.([]domain.Customer)
+	locale := viewerFrom(p.Context).locale
+	items := dto.CustomersToResponse(customers)
+	for i := range items {
+		items[i] = items[i].Localize(locale)
+	}
+	res.Data = items
+	return res, nil
+}
+
+func (h *GraphQLHandler) resolveLimits(p graphql.ResolveParams) (any, error) {
+	limits, err := h.profileService.GetMyLimits(p.Context, p.Source.(dto.CustomerResponse).ID)
+	return h.result(p.Context, limits, err, "Failed to get limits")
+}
+
+func (h *GraphQLHandler) resolveTransactions(p graphql.ResolveParams) (any, error) {
+	params, err := listParams(transactionListQuery, p)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.profileService.GetMyTransactions(p.Context, p.Source.(dto.CustomerResponse).ID, params)
+	if err != nil {
+		return h.result(p.Context, nil, err, "Failed to get transactions")
+	}
+	transactions, _ := res.Data.([]domain.Transaction)
+	locale := viewerFrom(p.Context).locale
+	items := dto.TransactionsToResponse(transactions)
+	for i := range items {
+		items[i] = items[i].Localize(locale)
+	}
+	res.Data = items
+	return res, nil
+}
+
+func (h *GraphQLHandler) resolveContract(p graphql.ResolveParams) (any, error) {
+	number := strings.ToUpper(strings.TrimSpace(p.Args["contract_number"].(string)))
+	res, err := h.contractService.FindByContractNumber(p.Context, number)
+	return h.result(p.Context, res, err, "Failed to find transaction")
+}
+
+func (h *GraphQLHandler) resolveContracts(p graphql.ResolveParams) (any, error) {
+	prefix := strings.ToUpper(strings.TrimSpace(p.Args["prefix"].(string)))
+	if len(prefix) < minPrefixLength {
+		return nil, graphql.NewError(graphql.CodeBadUserInput, "prefix must have at least "+strconv.Itoa(minPrefixLength)+" characters", nil)
+	}
+	params, err := listParams(contractQuery, p)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.contractService.Search(p.Context, prefix, params)
+	return h.result(p.Context, res, err, "Failed to search transactions")
+}
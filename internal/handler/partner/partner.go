@@ -7,7 +7,10 @@ import (
 
 	"github.com/fazamuttaqien/multifinance/internal/dto"
 	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel/attribute"
@@ -18,6 +21,7 @@ import (
 
 type PartnerHandler struct {
 	partnerService  service.PartnerServices
+	deliveryService service.DeliveryServices
 	validate        *validator.Validate
 	meter           metric.Meter
 	tracer          trace.Tracer
@@ -30,6 +34,7 @@ type PartnerHandler struct {
 
 func NewPartnerHandler(
 	partnerService service.PartnerServices,
+	deliveryService service.DeliveryServices,
 	meter metric.Meter,
 	tracer trace.Tracer,
 	log *zap.Logger,
@@ -72,7 +77,8 @@ func NewPartnerHandler(
 
 	return &PartnerHandler{
 		partnerService:  partnerService,
-		validate:        validator.New(validator.WithRequiredStructEnabled()),
+		deliveryService: deliveryService,
+		validate:        money.NewValidator(),
 		meter:           meter,
 		tracer:          tracer,
 		log:             log,
@@ -122,8 +128,13 @@ func (p *PartnerHandler) recordError(
 
 	p.log.Error(message, logFields...)
 
+	// Kesalahan per field dikirim sebagai details agar partner tahu nilai mana yang harus diperbaiki
+	if details := responder.FieldErrors(err); details != nil {
+		return responder.FailWithDetails(c, statusCode, errorType, message, details)
+	}
+
 	// Return HTTP error response
-	return c.Status(statusCode).JSON(fiber.Map{"error": message})
+	return responder.Fail(c, statusCode, errorType, message)
 }
 
 // recordSuccess helper function to record successful responses with observability
@@ -155,7 +166,7 @@ func (p *PartnerHandler) recordSuccess(
 	p.log.Info("Request completed successfully", logFields...)
 
 	// Return HTTP success response
-	return c.Status(statusCode).JSON(responseData)
+	return responder.Success(c, statusCode, responseData)
 }
 
 func (p *PartnerHandler) CheckLimit(c *fiber.Ctx) error {
@@ -231,6 +242,30 @@ func (p *PartnerHandler) CheckLimit(c *fiber.Ctx) error {
 			return p.recordError(
 				ctx, span, c, start, err,
 				fiber.StatusNotFound, "limit_not_set", "Limit not set", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrFxRateNotFound):
+			return p.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "fx_rate_not_found", "No exchange rate available for currency", zap.String("currency", req.Currency))
+		case errors.Is(err, common.ErrAmountNotPositive):
+			return p.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "invalid_amount", "Transaction amount must be greater than zero", zap.Stringer("amount", req.TransactionAmount))
+		case errors.Is(err, common.ErrAmountOutsideProduct):
+			return p.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "amount_outside_product", "Transaction amount is outside the tenor's allowed range", zap.Stringer("amount", req.TransactionAmount))
+		case errors.Is(err, common.ErrAgeNotEligible):
+			return p.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "age_not_eligible", "Customer age is not eligible for this tenor", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrAgeAtTenorEnd):
+			return p.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "age_at_tenor_end", "Customer would exceed the maximum age before the contract ends", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrAssetNotAllowed):
+			return p.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "asset_not_allowed", "Asset category is not financed under this tenor", zap.String("asset_category", req.AssetCategory))
 		default:
 			return p.recordError(
 				ctx, span, c, start, err,
@@ -295,17 +330,28 @@ func (h *PartnerHandler) CreateTransaction(c *fiber.Ctx) error {
 			fiber.StatusBadRequest, "validation_error", "Validation failed", zap.Error(err))
 	}
 
+	// Request lewat API key partner: catat partner dan tandai transaksi sandbox
+	partner, sandbox, partnerErr := middleware.GetPartnerFromLocals(c)
+	if partnerErr == nil {
+		req.PartnerID = &partner.ID
+		req.Sandbox = sandbox
+		span.SetAttributes(
+			attribute.Int64("partner.id", int64(partner.ID)),
+			attribute.Bool("partner.sandbox", sandbox),
+		)
+	}
+
 	span.SetAttributes(
 		attribute.String("customer.nik", req.CustomerNIK),
 		attribute.Int("tenor.months", int(req.TenorMonths)),
-		attribute.Float64("transaction.amount", req.OTRAmount),
+		attribute.Float64("transaction.amount", req.OTRAmount.InexactFloat64()),
 		attribute.String("transaction.asset_name", req.AssetName),
 	)
 
 	h.log.Debug("Processing create transaction",
 		zap.String("nik", req.CustomerNIK),
 		zap.Int("tenor_months", int(req.TenorMonths)),
-		zap.Float64("amount", req.OTRAmount),
+		zap.Stringer("amount", req.OTRAmount),
 		zap.String("asset_name", req.AssetName),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
@@ -329,11 +375,91 @@ func (h *PartnerHandler) CreateTransaction(c *fiber.Ctx) error {
 		case errors.Is(err, common.ErrInsufficientLimit):
 			return h.recordError(
 				ctx, span, c, start, err,
-				fiber.StatusUnprocessableEntity, "insufficient_limit", "Insufficient limit", zap.String("nik", req.CustomerNIK), zap.Float64("amount", req.OTRAmount))
+				fiber.StatusUnprocessableEntity, "insufficient_limit", "Insufficient limit", zap.String("nik", req.CustomerNIK), zap.Stringer("amount", req.OTRAmount))
 		case errors.Is(err, common.ErrLimitNotSet):
 			return h.recordError(
 				ctx, span, c, start, err,
 				fiber.StatusUnprocessableEntity, "limit_not_set", "Limit not set", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrCustomerSuspended):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "customer_suspended", "Customer account is suspended", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrLimitFrozen):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "limit_frozen", "Limit for this tenor is frozen", zap.String("nik", req.CustomerNIK), zap.Int("tenor_months", int(req.TenorMonths)))
+		case errors.Is(err, common.ErrCustomerDormant):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "customer_dormant", "Customer account is dormant", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrAccountClosed):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "account_closed", "Customer account is closed", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrBlacklisted):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusForbidden, "blacklisted", "Transaction blocked by screening", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrFxRateNotFound):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "fx_rate_not_found", "No exchange rate available for currency", zap.String("currency", req.Currency))
+		case errors.Is(err, common.ErrAmountNotPositive), errors.Is(err, common.ErrNegativeAdminFee):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "invalid_amount", "OTR amount must be greater than zero and admin fee cannot be negative", zap.Stringer("amount", req.OTRAmount))
+		case errors.Is(err, common.ErrAmountOutsideProduct):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "amount_outside_product", "Transaction amount is outside the tenor's allowed range", zap.Stringer("amount", req.OTRAmount))
+		case errors.Is(err, common.ErrAgeNotEligible):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "age_not_eligible", "Customer age is not eligible for this tenor", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrAgeAtTenorEnd):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "age_at_tenor_end", "Customer would exceed the maximum age before the contract ends", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrDebtServiceRatioExceeded):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "debt_service_ratio_exceeded", "Monthly installments would exceed the allowed share of the customer's salary", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrAssetNotAllowed):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "asset_not_allowed", "Asset category is not financed under this tenor", zap.String("asset_category", req.AssetCategory))
+		case errors.Is(err, common.ErrAdminFeeRequired):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusBadRequest, "admin_fee_required", "Admin fee is required", zap.String("nik", req.CustomerNIK))
+		case errors.Is(err, common.ErrPromotionNotFound), errors.Is(err, common.ErrPromotionNotApplicable):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "invalid_promo_code", "Promo code is not valid for this transaction", zap.String("promo_code", req.PromoCode))
+		case errors.Is(err, common.ErrPromotionExhausted):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "promo_code_exhausted", "Promo code has reached its redemption limit", zap.String("promo_code", req.PromoCode))
+		case errors.Is(err, common.ErrDailyCountQuotaExceeded):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusTooManyRequests, "daily_quota_exceeded", "Partner has reached its daily transaction quota")
+		case errors.Is(err, common.ErrDailyVolumeQuotaExceeded):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "daily_volume_exceeded", "Transaction would exceed the partner's daily volume quota", zap.Stringer("amount", req.OTRAmount))
+		case errors.Is(err, common.ErrDuplicateContract):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusConflict, "duplicate_contract", "Could not allocate a free contract number, please retry")
+		case errors.Is(err, common.ErrRegionNotFound):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "region_not_found", "Region not found or inactive", zap.String("region_code", req.RegionCode))
+		case errors.Is(err, common.ErrConcentrationExceeded):
+			return h.recordError(
+				ctx, span, c, start, err,
+				fiber.StatusUnprocessableEntity, "concentration_exceeded", "Transaction would exceed the portfolio concentration limit", zap.String("asset_category", req.AssetCategory), zap.String("region_code", req.RegionCode))
 		default:
 			return h.recordError(
 				ctx, span, c, start, err,
@@ -349,10 +475,23 @@ func (h *PartnerHandler) CreateTransaction(c *fiber.Ctx) error {
 		)
 	}
 
+	// Notifikasi webhook dikirim di background agar tidak menahan response
+	if partnerErr == nil && partner.WebhookURL != "" && h.deliveryService != nil {
+		go h.deliveryService.Dispatch(context.WithoutCancel(ctx), partner.ID, partner.WebhookURL, "transaction.created", fiber.Map{
+			"contract_number":          createdTx.ContractNumber,
+			"customer_id":              createdTx.CustomerID,
+			"asset_name":               createdTx.AssetName,
+			"otr_amount":               createdTx.OTRAmount,
+			"total_installment_amount": createdTx.TotalInstallmentAmount,
+			"status":                   createdTx.Status,
+			"sandbox":                  sandbox,
+		})
+	}
+
 	// 6. Kirim response sukses
-	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, createdTx,
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.TransactionToResponse(*createdTx),
 		zap.String("nik", req.CustomerNIK),
-		zap.Float64("amount", req.OTRAmount),
+		zap.Stringer("amount", req.OTRAmount),
 		zap.String("asset_name", req.AssetName),
 	)
 }
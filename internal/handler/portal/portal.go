@@ -0,0 +1,351 @@
+package portalhandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type PartnerPortalHandler struct {
+	portalService   service.PartnerPortalServices
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewPartnerPortalHandler(
+	portalService service.PartnerPortalServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *PartnerPortalHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &PartnerPortalHandler{
+		portalService:   portalService,
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *PartnerPortalHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *PartnerPortalHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+var partnerTransactionListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status": {
+			Column: "status",
+			Values: []string{
+				string(domain.TransactionPending), string(domain.TransactionApproved), string(domain.TransactionActive),
+				string(domain.TransactionPaidOff), string(domain.TransactionCancelled), string(domain.TransactionWrittenOff),
+			},
+		},
+	},
+	Sorts: map[string]string{"transaction_date": "transaction_date", "otr_amount": "otr_amount"},
+}
+
+var partnerDeliveryListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status": {
+			Column: "status",
+			Values: []string{string(domain.DeliveryPending), string(domain.DeliveryDelivered), string(domain.DeliveryFailed), string(domain.DeliveryPoisoned)},
+		},
+		"event_type": {Column: "event_type"},
+	},
+	Sorts: map[string]string{"created_at": "created_at", "attempts": "attempts"},
+}
+
+// defaultUsageDays is the range reported when ?days= is not given.
+const defaultUsageDays = 7
+
+func (h *PartnerPortalHandler) ListTransactions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListPartnerTransactions")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list partner transactions request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	partner, sandbox, err := middleware.GetPartnerFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Partner not found")
+	}
+
+	params, err := partnerTransactionListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partner.ID)),
+		attribute.Bool("partner.sandbox", sandbox),
+	)
+
+	res, err := h.portalService.ListTransactions(ctx, partner.ID, sandbox, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list transactions")
+	}
+
+	transactions, _ := res.Data.([]domain.Transaction)
+	res.Data = dto.TransactionsToResponse(transactions)
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res,
+		zap.Uint64("partner_id", partner.ID),
+		zap.Int64("total", res.Total),
+	)
+}
+
+func (h *PartnerPortalHandler) ListDeliveries(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListPartnerDeliveries")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list partner deliveries request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	partner, _, err := middleware.GetPartnerFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Partner not found")
+	}
+
+	params, err := partnerDeliveryListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	span.SetAttributes(attribute.Int64("partner.id", int64(partner.ID)))
+
+	res, err := h.portalService.ListDeliveries(ctx, partner.ID, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list deliveries")
+	}
+
+	deliveries, _ := res.Data.([]domain.WebhookDelivery)
+	res.Data = dto.WebhookDeliveriesToResponse(deliveries)
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res,
+		zap.Uint64("partner_id", partner.ID),
+		zap.Int64("total", res.Total),
+	)
+}
+
+func (h *PartnerPortalHandler) GetUsage(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetPartnerUsage")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get partner usage request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	partner, _, err := middleware.GetPartnerFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Partner not found")
+	}
+
+	days := defaultUsageDays
+	if raw := c.Query("days"); raw != "" {
+		if days, err = strconv.Atoi(raw); err != nil {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid days")
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partner.ID)),
+		attribute.Int("usage.days", days),
+	)
+
+	usage, err := h.portalService.GetUsage(ctx, partner.ID, days, time.Now())
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidUsageDays) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get API usage")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.PartnerUsageToResponse(partner.ID, usage),
+		zap.Uint64("partner_id", partner.ID),
+		zap.Int("days", days),
+	)
+}
+
+func (h *PartnerPortalHandler) RegenerateAPIKey(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.RegeneratePartnerAPIKey")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received regenerate partner API key request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	partner, sandbox, err := middleware.GetPartnerFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Partner not found")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partner.ID)),
+		attribute.Bool("partner.sandbox", sandbox),
+	)
+
+	res, err := h.portalService.RegenerateAPIKey(ctx, partner.ID, sandbox)
+	if err != nil {
+		if errors.Is(err, common.ErrPartnerNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Partner not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to regenerate API key")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res,
+		zap.Uint64("partner_id", partner.ID),
+		zap.Bool("sandbox", sandbox),
+	)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/service"
 	"github.com/fazamuttaqien/multifinance/middleware"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -35,19 +36,22 @@ type PrivateHandler struct {
 func (h *PrivateHandler) Login(c *fiber.Ctx) error {
 	var req dto.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return responder.Fail(c, fiber.StatusBadRequest, "parse_error", err.Error())
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return responder.Fail(c, fiber.StatusBadRequest, "validation_error", err.Error())
 	}
 
 	res, err := h.privateService.Login(c.Context(), req)
 	if err != nil {
 		if errors.Is(err, common.ErrInvalidCredentials) {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", err.Error())
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		if errors.Is(err, common.ErrAccountClosed) {
+			return responder.Fail(c, fiber.StatusForbidden, "account_closed", err.Error())
+		}
+		return responder.Fail(c, fiber.StatusInternalServerError, "service_error", err.Error())
 	}
 
 	c.Cookie(&fiber.Cookie{
@@ -61,26 +65,32 @@ func (h *PrivateHandler) Login(c *fiber.Ctx) error {
 
 	csrfToken, err := middleware.GenerateCSRFToken()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate CSRF token"})
+		return responder.Fail(c, fiber.StatusInternalServerError, "csrf_error", "Failed to generate CSRF token")
 	}
 
 	sess, err := h.store.Get(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Session error"})
+		return responder.Fail(c, fiber.StatusInternalServerError, "session_error", "Session error")
 	}
 
 	sess.Set("csrf_token", csrfToken)
 	if err := sess.Save(); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save session"})
+		return responder.Fail(c, fiber.StatusInternalServerError, "session_error", "Failed to save session")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message":    "Login successful",
-		"csrf_token": csrfToken,
+	return responder.Success(c, fiber.StatusOK, fiber.Map{
+		"message":              "Login successful",
+		"csrf_token":           csrfToken,
+		"must_change_password": res.MustChangePassword,
 	})
 }
 
 func (h *PrivateHandler) Logout(c *fiber.Ctx) error {
+	h.endSession(c)
+	return responder.Success(c, fiber.StatusOK, fiber.Map{"message": "Logout successful"})
+}
+
+func (h *PrivateHandler) endSession(c *fiber.Ctx) {
 	c.Cookie(&fiber.Cookie{
 		Name:     "private",
 		Value:    "",
@@ -94,8 +104,43 @@ func (h *PrivateHandler) Logout(c *fiber.Ctx) error {
 	if err == nil {
 		sess.Destroy()
 	}
+}
+
+func (h *PrivateHandler) ChangePassword(c *fiber.Ctx) error {
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Customer ID not found")
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responder.Fail(c, fiber.StatusBadRequest, "parse_error", err.Error())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return responder.Fail(c, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	if err := h.privateService.ChangePassword(c.UserContext(), claims.UserID, req); err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidCredentials):
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Current password is incorrect")
+		case errors.Is(err, common.ErrOTPRequired):
+			return responder.Fail(c, fiber.StatusForbidden, "otp_required", "A valid OTP verification is required")
+		case errors.Is(err, common.ErrCustomerNotFound):
+			return responder.Fail(c, fiber.StatusNotFound, "not_found", err.Error())
+		}
+		return responder.Fail(c, fiber.StatusInternalServerError, "service_error", "Failed to change password")
+	}
+
+	if claims.MustChangePassword {
+		// Token lama masih membawa flag wajib ganti password, jadi sesi
+		// diakhiri dan user login ulang dengan password baru
+		h.endSession(c)
+		return responder.Success(c, fiber.StatusOK, fiber.Map{"message": "Password changed successfully, please log in again"})
+	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Logout successful"})
+	return responder.Success(c, fiber.StatusOK, fiber.Map{"message": "Password changed successfully"})
 }
 
 func NewPrivateHandler(
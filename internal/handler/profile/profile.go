@@ -12,6 +12,11 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/service"
 	"github.com/fazamuttaqien/multifinance/middleware"
 	"github.com/fazamuttaqien/multifinance/pkg/cloudinary"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -22,6 +27,16 @@ import (
 	"go.uber.org/zap"
 )
 
+// deviceIDHeader carries the app installation ID, stored at registration for
+// referral fraud checks.
+const (
+	deviceIDHeader    = "X-Device-ID"
+	maxDeviceIDLength = 128
+)
+
+// discardTimeout bounds the deletion of uploads left by a failed request.
+const discardTimeout = 10 * time.Second
+
 type ProfileHandler struct {
 	profileService    service.ProfileServices
 	validate          *validator.Validate
@@ -80,7 +95,7 @@ func NewProfileHandler(
 
 	return &ProfileHandler{
 		profileService:    profileService,
-		validate:          validator.New(validator.WithRequiredStructEnabled()),
+		validate:          money.NewValidator(),
 		cloudinaryService: cloudinaryService,
 		meter:             meter,
 		tracer:            tracer,
@@ -133,7 +148,7 @@ func (h *ProfileHandler) recordError(
 	h.log.Error(message, logFields...)
 
 	// Return HTTP error response
-	return c.Status(statusCode).JSON(fiber.Map{"error": message})
+	return responder.Fail(c, statusCode, errorType, message)
 }
 
 // recordSuccess helper function to record successful responses with observability
@@ -165,7 +180,55 @@ func (h *ProfileHandler) recordSuccess(
 	h.log.Info("Request completed successfully", logFields...)
 
 	// Return HTTP success response
-	return c.Status(statusCode).JSON(responseData)
+	return responder.Success(c, statusCode, responseData)
+}
+
+// recordConditional answers 304 when the client already holds the current
+// representation of responseData, and falls back to recordSuccess otherwise.
+func (h *ProfileHandler) recordConditional(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, responseData interface{}, fields ...zap.Field) error {
+	etag, err := responder.ETag(responseData)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "internal_error", "Failed to render response")
+	}
+	if !responder.NotModified(c, etag) {
+		return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, responseData, fields...)
+	}
+
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", fiber.StatusNotModified),
+	))
+	span.SetAttributes(
+		attribute.Int("http.status_code", fiber.StatusNotModified),
+		attribute.Float64("request.duration_ms", duration),
+	)
+	h.log.Debug("Request answered not modified", append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("etag", etag),
+	}, fields...)...)
+
+	return c.SendStatus(fiber.StatusNotModified)
+}
+
+// discardUploads deletes images uploaded for a request that then failed.
+// It runs on its own deadline so a client that hung up does not cancel it;
+// what is left behind is picked up by the orphaned media job.
+func (h *ProfileHandler) discardUploads(ctx context.Context, urls ...string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), discardTimeout)
+	defer cancel()
+
+	for _, url := range urls {
+		if err := h.cloudinaryService.DeleteImage(ctx, url); err != nil {
+			h.log.Warn("Failed to delete upload of failed registration",
+				zap.String("url", url),
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 func (h *ProfileHandler) Register(c *fiber.Ctx) error {
@@ -239,12 +302,13 @@ func (h *ProfileHandler) Register(c *fiber.Ctx) error {
 	}()
 
 	var ktpUrl, selfieUrl string
-	var uploadErrors []string
+	var uploadErrors, uploaded []string
 	for result := range resultChan {
 		if result.Error != nil {
 			uploadErrors = append(uploadErrors, fmt.Sprintf("%s upload failed: %v", result.Type, result.Error))
 			continue
 		}
+		uploaded = append(uploaded, result.URL)
 		if result.Type == "ktp" {
 			ktpUrl = result.URL
 		} else {
@@ -253,20 +317,44 @@ func (h *ProfileHandler) Register(c *fiber.Ctx) error {
 	}
 
 	if len(uploadErrors) > 0 {
+		h.discardUploads(ctx, uploaded...)
 		err := fmt.Errorf("upload errors: %v", uploadErrors)
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "upload_error", "One or more file uploads failed", zap.Strings("upload_errors", uploadErrors))
 	}
 
 	dtoRegister := dto.RegisterToEntity(req, ktpUrl, selfieUrl)
-	newCustomer, err := h.profileService.Create(serviceCtx, dtoRegister)
+	dtoRegister.RegistrationIP = c.IP()
+	dtoRegister.RegistrationDeviceID = c.Get(deviceIDHeader)
+	if len(dtoRegister.RegistrationDeviceID) > maxDeviceIDLength {
+		dtoRegister.RegistrationDeviceID = dtoRegister.RegistrationDeviceID[:maxDeviceIDLength]
+	}
+
+	newCustomer, err := h.profileService.Create(serviceCtx, dtoRegister, dto.RegistrationChecks{
+		ReferralCode: req.ReferralCode,
+		OTPToken:     req.OTPToken,
+	})
 	if err != nil {
-		if err.Error() == "nik already registered" || errors.Is(err, gorm.ErrRecordNotFound) {
+		// Registrasi gagal, foto yang sudah terunggah tidak punya pemilik
+		h.discardUploads(ctx, uploaded...)
+		if errors.Is(err, common.ErrNIKExists) || errors.Is(err, gorm.ErrRecordNotFound) {
 			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "conflict_error", "NIK already registered", zap.String("nik", req.NIK))
 		}
+		if errors.Is(err, common.ErrBlacklisted) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusForbidden, "blacklisted", "Registration blocked by screening", zap.String("nik", req.NIK))
+		}
+		if errors.Is(err, common.ErrReferralCodeNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "invalid_referral_code", "Referral code not found", zap.String("referral_code", req.ReferralCode))
+		}
+		if errors.Is(err, common.ErrEmailExists) || errors.Is(err, common.ErrPhoneExists) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "conflict_error", err.Error(), zap.String("nik", req.NIK))
+		}
+		if errors.Is(err, common.ErrOTPRequired) || errors.Is(err, common.ErrContactNotVerified) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusForbidden, "otp_required", "A valid OTP verification is required", zap.String("nik", req.NIK))
+		}
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Could not process registration")
 	}
 
-	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, newCustomer, zap.String("nik", newCustomer.NIK))
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.CustomerToResponse(*newCustomer), zap.String("nik", newCustomer.NIK))
 }
 
 func (h *ProfileHandler) GetMyProfile(c *fiber.Ctx) error {
@@ -293,7 +381,7 @@ func (h *ProfileHandler) GetMyProfile(c *fiber.Ctx) error {
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get profile")
 	}
 
-	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, customer)
+	return h.recordConditional(ctx, span, c, start, dto.CustomerToResponse(*customer).Localize(datetime.Current(c)))
 }
 
 func (h *ProfileHandler) UpdateMyProfile(c *fiber.Ctx) error {
@@ -324,14 +412,62 @@ func (h *ProfileHandler) UpdateMyProfile(c *fiber.Ctx) error {
 		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
 	}
 
+	// Tanpa versi di body, If-Match berisi ETag dari GET /me/profile dipakai
+	if req.Version == nil {
+		ifMatch := c.Get(fiber.HeaderIfMatch)
+		if ifMatch == "" {
+			return h.recordError(ctx, span, c, start, fiber.ErrPreconditionRequired, fiber.StatusPreconditionRequired, "version_required", "Send the profile version or an If-Match header")
+		}
+		version, err := h.ifMatchVersion(c, claims.UserID, ifMatch)
+		if err != nil {
+			if errors.Is(err, common.ErrVersionConflict) {
+				return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "version_conflict", err.Error())
+			}
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to update profile")
+		}
+		req.Version = &version
+	}
+	span.SetAttributes(attribute.Int64("customer.version", int64(*req.Version)))
+
 	dtoUpdate := dto.UpdateToEntity(req)
-	if err := h.profileService.Update(c.Context(), claims.UserID, dtoUpdate); err != nil {
+	checks := dto.ContactChecks{EmailOTPToken: req.EmailOTPToken, PhoneOTPToken: req.PhoneOTPToken}
+	if err := h.profileService.Update(c.Context(), claims.UserID, dtoUpdate, checks); err != nil {
+		switch {
+		case errors.Is(err, common.ErrCustomerNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", err.Error())
+		case errors.Is(err, common.ErrVersionConflict):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "version_conflict", err.Error())
+		case errors.Is(err, common.ErrSalaryNeedsProof):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "salary_needs_proof", err.Error())
+		case errors.Is(err, common.ErrEmailExists), errors.Is(err, common.ErrPhoneExists):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "conflict_error", err.Error())
+		case errors.Is(err, common.ErrOTPRequired), errors.Is(err, common.ErrContactNotVerified):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusForbidden, "otp_required", "New contact details must be confirmed with an OTP")
+		}
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to update profile")
 	}
 
 	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, fiber.Map{"message": "Profile updated successfully"})
 }
 
+// ifMatchVersion returns the version of the profile the client last read
+// when ifMatch still names its ETag, and ErrVersionConflict otherwise.
+func (h *ProfileHandler) ifMatchVersion(c *fiber.Ctx, customerID uint64, ifMatch string) (uint64, error) {
+	current, err := h.profileService.GetMyProfile(c.Context(), customerID)
+	if err != nil {
+		return 0, err
+	}
+	// ETag dihitung dari representasi yang sama dengan GET, termasuk zona waktunya
+	etag, err := responder.ETag(dto.CustomerToResponse(*current).Localize(datetime.Current(c)))
+	if err != nil {
+		return 0, err
+	}
+	if !responder.Matches(ifMatch, etag) {
+		return 0, common.ErrVersionConflict
+	}
+	return current.Version, nil
+}
+
 func (h *ProfileHandler) GetMyLimits(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	ctx, span := h.tracer.Start(ctx, "handler.GetMyLimits")
@@ -356,7 +492,20 @@ func (h *ProfileHandler) GetMyLimits(c *fiber.Ctx) error {
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get limits")
 	}
 
-	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, limits)
+	return h.recordConditional(ctx, span, c, start, limits)
+}
+
+var transactionListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status": {
+			Column: "status",
+			Values: []string{
+				string(domain.TransactionPending), string(domain.TransactionApproved), string(domain.TransactionActive),
+				string(domain.TransactionPaidOff), string(domain.TransactionCancelled), string(domain.TransactionWrittenOff),
+			},
+		},
+	},
+	Sorts: map[string]string{"transaction_date": "transaction_date", "otr_amount": "otr_amount"},
 }
 
 func (h *ProfileHandler) GetMyTransactions(c *fiber.Ctx) error {
@@ -378,15 +527,14 @@ func (h *ProfileHandler) GetMyTransactions(c *fiber.Ctx) error {
 	}
 	span.SetAttributes(attribute.Int64("customer.id", int64(claims.UserID)))
 
-	params := domain.Params{
-		Status: c.Query("status"),
-		Page:   c.QueryInt("page", 1),
-		Limit:  c.QueryInt("limit", 10),
+	params, err := transactionListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
 	}
 
 	span.SetAttributes(
 		attribute.Int64("customer.id", int64(claims.UserID)),
-		attribute.String("query.status", params.Status),
+		attribute.String("query.status", params.Value("status")),
 		attribute.Int("query.page", params.Page),
 		attribute.Int("query.limit", params.Limit),
 	)
@@ -395,6 +543,48 @@ func (h *ProfileHandler) GetMyTransactions(c *fiber.Ctx) error {
 	if err != nil {
 		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get transactions")
 	}
+	transactions, _ := response.Data.([]domain.Transaction)
+	locale := datetime.Current(c)
+	items := dto.TransactionsToResponse(transactions)
+	for i := range items {
+		items[i] = items[i].Localize(locale)
+	}
+	response.Data = items
 
 	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, response)
 }
+
+func (h *ProfileHandler) GetMyTransactionDetail(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetMyTransactionDetail")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get my transaction detail request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Customer ID not found")
+	}
+
+	contractNumber := c.Params("contractNumber")
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(claims.UserID)),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+
+	detail, err := h.profileService.GetMyTransactionDetail(ctx, claims.UserID, contractNumber)
+	if err != nil {
+		if errors.Is(err, common.ErrTransactionNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Transaction not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get transaction detail")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, detail.Localize(datetime.Current(c)))
+}
@@ -0,0 +1,523 @@
+package reporthandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	reportService   service.ReportServices
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewReportHandler(
+	reportService service.ReportServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *ReportHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &ReportHandler{
+		reportService:   reportService,
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *ReportHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *ReportHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+// sendCSV records the same observability as recordSuccess but streams the
+// rows as a CSV attachment instead of JSON.
+func (h *ReportHandler) sendCSV(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, filename string, records [][]string, fields ...zap.Field) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "csv_error", "Failed to render CSV report")
+	}
+
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", fiber.StatusOK),
+	))
+	h.responseSize.Record(ctx, int64(buf.Len()), metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+	))
+
+	span.SetAttributes(
+		attribute.Int("http.status_code", fiber.StatusOK),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	h.log.Info("Request completed successfully", append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", fiber.StatusOK),
+		zap.Float64("duration_ms", duration),
+		zap.String("format", "csv"),
+	}, fields...)...)
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Attachment(filename)
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// InterestAccrual returns the interest accrued in ?month=YYYY-MM by tenor and
+// portfolio segment. Pass ?format=csv to download it for the finance close.
+func (h *ReportHandler) InterestAccrual(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.InterestAccrual")
+	defer span.End()
+	start := time.Now()
+
+	month := c.Query("month")
+	format := strings.ToLower(c.Query("format", "json"))
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("report.month", month),
+		attribute.String("report.format", format),
+	)
+	h.log.Debug("Received interest accrual report request", zap.String("path", c.Path()), zap.String("month", month))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	if format != "json" && format != "csv" {
+		return h.recordError(ctx, span, c, start, fmt.Errorf("unsupported format %q", format), fiber.StatusBadRequest, "validation_error", "Format must be json or csv")
+	}
+
+	report, err := h.reportService.InterestAccrual(ctx, month)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidReportMonth):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", common.ErrInvalidReportMonth.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to compute interest accrual")
+		}
+	}
+
+	if format == "csv" {
+		return h.sendCSV(ctx, span, c, start, "interest-accrual-"+report.Month+".csv", interestAccrualRecords(report), zap.String("month", report.Month))
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, report, zap.String("month", report.Month))
+}
+
+// interestAccrualRecords flattens the report into one line per tenor and
+// segment followed by a grand total line.
+func interestAccrualRecords(report *dto.InterestAccrualReportResponse) [][]string {
+	records := make([][]string, 0, len(report.Rows)+2)
+	records = append(records, []string{"month", "tenor_months", "segment", "contract_count", "accrued_interest_idr"})
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			report.Month,
+			strconv.Itoa(int(row.TenorMonths)),
+			row.Segment,
+			strconv.FormatInt(row.ContractCount, 10),
+			row.AccruedInterest.StringFixed(2),
+		})
+	}
+	records = append(records, []string{report.Month, "", "TOTAL", strconv.FormatInt(report.ContractCount, 10), report.TotalInterest.StringFixed(2)})
+	return records
+}
+
+// PartnerCommission returns, per partner, the contracts booked in
+// ?month=YYYY-MM with their admin fee and commission. Pass ?format=csv to
+// download it for partner settlement.
+func (h *ReportHandler) PartnerCommission(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.PartnerCommission")
+	defer span.End()
+	start := time.Now()
+
+	month := c.Query("month")
+	format := strings.ToLower(c.Query("format", "json"))
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("report.month", month),
+		attribute.String("report.format", format),
+	)
+	h.log.Debug("Received partner commission report request", zap.String("path", c.Path()), zap.String("month", month))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	if format != "json" && format != "csv" {
+		return h.recordError(ctx, span, c, start, fmt.Errorf("unsupported format %q", format), fiber.StatusBadRequest, "validation_error", "Format must be json or csv")
+	}
+
+	report, err := h.reportService.PartnerCommission(ctx, month)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidReportMonth):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", common.ErrInvalidReportMonth.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to compute partner commission")
+		}
+	}
+
+	if format == "csv" {
+		return h.sendCSV(ctx, span, c, start, "partner-commission-"+report.Month+".csv", partnerCommissionRecords(report), zap.String("month", report.Month))
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, report, zap.String("month", report.Month))
+}
+
+// partnerCommissionRecords flattens the report into one line per partner
+// followed by a grand total line.
+func partnerCommissionRecords(report *dto.PartnerCommissionReportResponse) [][]string {
+	records := make([][]string, 0, len(report.Rows)+2)
+	records = append(records, []string{"month", "partner_id", "partner_name", "contract_count", "otr_amount_idr", "admin_fee_idr", "commission_idr"})
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			report.Month,
+			strconv.FormatUint(row.PartnerID, 10),
+			row.PartnerName,
+			strconv.FormatInt(row.ContractCount, 10),
+			row.OTRAmount.StringFixed(2),
+			row.AdminFee.StringFixed(2),
+			row.Commission.StringFixed(2),
+		})
+	}
+	records = append(records, []string{
+		report.Month, "", "TOTAL",
+		strconv.FormatInt(report.ContractCount, 10),
+		report.TotalOTRAmount.StringFixed(2),
+		report.TotalAdminFee.StringFixed(2),
+		report.TotalCommission.StringFixed(2),
+	})
+	return records
+}
+
+// Aging returns active contracts bucketed by days past due, overall and per
+// tenor. ?date=YYYY-MM-DD serves the latest snapshot taken on or before that
+// day (today by default); ?format=csv downloads it.
+func (h *ReportHandler) Aging(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.Aging")
+	defer span.End()
+	start := time.Now()
+
+	date := c.Query("date")
+	format := strings.ToLower(c.Query("format", "json"))
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("report.date", date),
+		attribute.String("report.format", format),
+	)
+	h.log.Debug("Received aging report request", zap.String("path", c.Path()), zap.String("date", date))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	if format != "json" && format != "csv" {
+		return h.recordError(ctx, span, c, start, fmt.Errorf("unsupported format %q", format), fiber.StatusBadRequest, "validation_error", "Format must be json or csv")
+	}
+
+	report, err := h.reportService.Aging(ctx, date)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidReportDate):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", common.ErrInvalidReportDate.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to compute aging report")
+		}
+	}
+
+	if format == "csv" {
+		return h.sendCSV(ctx, span, c, start, "aging-"+report.AsOf+".csv", agingRecords(report), zap.String("as_of", report.AsOf), zap.String("source", report.Source))
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, report, zap.String("as_of", report.AsOf), zap.String("source", report.Source))
+}
+
+// agingRecords flattens the report into one line per tenor and bucket
+// followed by the portfolio lines, whose tenor column reads ALL.
+func agingRecords(report *dto.AgingReportResponse) [][]string {
+	records := [][]string{{"as_of", "tenor_months", "bucket", "contract_count", "outstanding_idr"}}
+	line := func(tenor string, bucket dto.AgingBucketResponse) []string {
+		return []string{report.AsOf, tenor, bucket.Bucket, strconv.FormatInt(bucket.ContractCount, 10), bucket.Outstanding.StringFixed(2)}
+	}
+	for _, tenor := range report.ByTenor {
+		for _, bucket := range tenor.Buckets {
+			records = append(records, line(strconv.Itoa(int(tenor.TenorMonths)), bucket))
+		}
+	}
+	for _, bucket := range report.Portfolio {
+		records = append(records, line("ALL", bucket))
+	}
+	return records
+}
+
+// ReferralConversion returns, per referrer, the referrals registered in
+// ?month=YYYY-MM and how many converted into a contract. Pass ?format=csv
+// for a spreadsheet-friendly export.
+func (h *ReportHandler) ReferralConversion(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ReferralConversion")
+	defer span.End()
+	start := time.Now()
+
+	month := c.Query("month")
+	format := strings.ToLower(c.Query("format", "json"))
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("report.month", month),
+		attribute.String("report.format", format),
+	)
+	h.log.Debug("Received referral conversion report request", zap.String("path", c.Path()), zap.String("month", month))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	if format != "json" && format != "csv" {
+		return h.recordError(ctx, span, c, start, fmt.Errorf("unsupported format %q", format), fiber.StatusBadRequest, "validation_error", "Format must be json or csv")
+	}
+
+	report, err := h.reportService.ReferralConversion(ctx, month)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidReportMonth):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", common.ErrInvalidReportMonth.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to compute referral conversions")
+		}
+	}
+
+	if format == "csv" {
+		return h.sendCSV(ctx, span, c, start, "referral-conversion-"+report.Month+".csv", referralConversionRecords(report), zap.String("month", report.Month))
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, report, zap.String("month", report.Month))
+}
+
+func referralConversionRecords(report *dto.ReferralConversionReportResponse) [][]string {
+	records := make([][]string, 0, len(report.Rows)+2)
+	records = append(records, []string{"month", "referrer_id", "referrer_name", "code", "referred", "rejected", "converted", "conversion_rate"})
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			report.Month,
+			strconv.FormatUint(row.ReferrerID, 10),
+			row.ReferrerName,
+			row.Code,
+			strconv.FormatInt(row.Referred, 10),
+			strconv.FormatInt(row.Rejected, 10),
+			strconv.FormatInt(row.Converted, 10),
+			row.ConversionRate.StringFixed(4),
+		})
+	}
+	records = append(records, []string{
+		report.Month, "", "TOTAL", "",
+		strconv.FormatInt(report.Referred, 10),
+		strconv.FormatInt(report.Rejected, 10),
+		strconv.FormatInt(report.Converted, 10),
+		"",
+	})
+	return records
+}
+
+// RegionPerformance compares regions: contracts booked in ?month=YYYY-MM and
+// the current outstanding and delinquency of each region. Pass ?format=csv to
+// download it.
+func (h *ReportHandler) RegionPerformance(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.RegionPerformance")
+	defer span.End()
+	start := time.Now()
+
+	month := c.Query("month")
+	format := strings.ToLower(c.Query("format", "json"))
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+		attribute.String("report.month", month),
+		attribute.String("report.format", format),
+	)
+	h.log.Debug("Received region performance report request", zap.String("path", c.Path()), zap.String("month", month))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	if format != "json" && format != "csv" {
+		return h.recordError(ctx, span, c, start, fmt.Errorf("unsupported format %q", format), fiber.StatusBadRequest, "validation_error", "Format must be json or csv")
+	}
+
+	report, err := h.reportService.RegionPerformance(ctx, month)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidReportMonth):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", common.ErrInvalidReportMonth.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to compute region performance")
+		}
+	}
+
+	if format == "csv" {
+		return h.sendCSV(ctx, span, c, start, "region-performance-"+report.Month+".csv", regionPerformanceRecords(report), zap.String("month", report.Month))
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, report, zap.String("month", report.Month))
+}
+
+func regionPerformanceRecords(report *dto.RegionReportResponse) [][]string {
+	records := make([][]string, 0, len(report.Rows)+2)
+	records = append(records, []string{
+		"month", "as_of", "region_code", "region_name", "contract_count", "otr_amount_idr",
+		"active_contracts", "outstanding_idr", "delinquent_contracts", "delinquent_outstanding_idr", "delinquency_rate",
+	})
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			report.Month,
+			report.AsOf,
+			row.RegionCode,
+			row.RegionName,
+			strconv.FormatInt(row.ContractCount, 10),
+			row.OTRAmount.StringFixed(2),
+			strconv.FormatInt(row.ActiveContracts, 10),
+			row.Outstanding.StringFixed(2),
+			strconv.FormatInt(row.DelinquentContracts, 10),
+			row.DelinquentOutstanding.StringFixed(2),
+			row.DelinquencyRate.StringFixed(4),
+		})
+	}
+	records = append(records, []string{
+		report.Month, report.AsOf, "", "TOTAL",
+		strconv.FormatInt(report.ContractCount, 10),
+		report.TotalOTRAmount.StringFixed(2),
+		"",
+		report.TotalOutstanding.StringFixed(2),
+		"",
+		report.DelinquentOutstanding.StringFixed(2),
+		report.DelinquencyRate.StringFixed(4),
+	})
+	return records
+}
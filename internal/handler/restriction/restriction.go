@@ -0,0 +1,389 @@
+package restrictionhandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CustomerRestrictionHandler struct {
+	restrictionService service.CustomerRestrictionServices
+	validate           *validator.Validate
+	meter              metric.Meter
+	tracer             trace.Tracer
+	log                *zap.Logger
+	requestCount       metric.Int64Counter
+	requestDuration    metric.Float64Histogram
+	errorCount         metric.Int64Counter
+	responseSize       metric.Int64Histogram
+}
+
+func NewCustomerRestrictionHandler(
+	restrictionService service.CustomerRestrictionServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *CustomerRestrictionHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &CustomerRestrictionHandler{
+		restrictionService: restrictionService,
+		validate:           validator.New(validator.WithRequiredStructEnabled()),
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		requestCount:       requestCount,
+		requestDuration:    requestDuration,
+		errorCount:         errorCount,
+		responseSize:       responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *CustomerRestrictionHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *CustomerRestrictionHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+func (h *CustomerRestrictionHandler) Suspend(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.SuspendCustomer")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received suspend customer request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid customer ID")
+	}
+
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+
+	var req dto.RestrictionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	restriction, err := h.restrictionService.Suspend(ctx, customerID, claims.UserID, req)
+	if err != nil {
+		return h.serviceError(ctx, span, c, start, err, "Failed to suspend customer")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.CustomerRestrictionToResponse(*restriction, time.Now()),
+		zap.Uint64("customer_id", customerID),
+		zap.Uint64("restriction_id", restriction.ID),
+		zap.String("reason", string(restriction.Reason)),
+	)
+}
+
+func (h *CustomerRestrictionHandler) Unsuspend(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.UnsuspendCustomer")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received unsuspend customer request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid customer ID")
+	}
+
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+
+	if err := h.restrictionService.Unsuspend(ctx, customerID, claims.UserID); err != nil {
+		return h.serviceError(ctx, span, c, start, err, "Failed to unsuspend customer")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, fiber.Map{"message": "Customer suspension lifted"}, zap.Uint64("customer_id", customerID))
+}
+
+func (h *CustomerRestrictionHandler) FreezeLimit(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.FreezeCustomerLimit")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received freeze limit request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid customer ID")
+	}
+
+	tenorMonths, err := strconv.ParseUint(c.Params("tenorMonths"), 10, 8)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid tenor months")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int("tenor.duration_months", int(tenorMonths)),
+	)
+
+	var req dto.RestrictionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	restriction, err := h.restrictionService.FreezeLimit(ctx, customerID, uint8(tenorMonths), claims.UserID, req)
+	if err != nil {
+		return h.serviceError(ctx, span, c, start, err, "Failed to freeze limit")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.CustomerRestrictionToResponse(*restriction, time.Now()),
+		zap.Uint64("customer_id", customerID),
+		zap.Uint64("tenor_months", tenorMonths),
+		zap.Uint64("restriction_id", restriction.ID),
+		zap.String("reason", string(restriction.Reason)),
+	)
+}
+
+func (h *CustomerRestrictionHandler) UnfreezeLimit(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.UnfreezeCustomerLimit")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received unfreeze limit request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid customer ID")
+	}
+
+	tenorMonths, err := strconv.ParseUint(c.Params("tenorMonths"), 10, 8)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid tenor months")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int("tenor.duration_months", int(tenorMonths)),
+	)
+
+	if err := h.restrictionService.UnfreezeLimit(ctx, customerID, uint8(tenorMonths), claims.UserID); err != nil {
+		return h.serviceError(ctx, span, c, start, err, "Failed to unfreeze limit")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, fiber.Map{"message": "Limit freeze lifted"},
+		zap.Uint64("customer_id", customerID),
+		zap.Uint64("tenor_months", tenorMonths),
+	)
+}
+
+func (h *CustomerRestrictionHandler) ListRestrictions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListCustomerRestrictions")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list customer restrictions request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	customerID, err := strconv.ParseUint(c.Params("customerId"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid customer ID")
+	}
+
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+
+	restrictions, err := h.restrictionService.ListRestrictions(ctx, customerID)
+	if err != nil {
+		return h.serviceError(ctx, span, c, start, err, "Failed to list customer restrictions")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.CustomerRestrictionsToResponse(restrictions, time.Now()),
+		zap.Uint64("customer_id", customerID),
+		zap.Int("count", len(restrictions)),
+	)
+}
+
+// serviceError maps the errors shared by every restriction operation to
+// their HTTP status.
+func (h *CustomerRestrictionHandler) serviceError(ctx context.Context, span trace.Span, c *fiber.Ctx, start time.Time, err error, message string) error {
+	switch {
+	case errors.Is(err, common.ErrCustomerNotFound):
+		return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "customer_not_found", "Customer not found")
+	case errors.Is(err, common.ErrTenorNotFound):
+		return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "tenor_not_found", "Tenor not found")
+	case errors.Is(err, common.ErrRestrictionNotFound):
+		return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "No active restriction of this kind")
+	case errors.Is(err, common.ErrRestrictionExists):
+		return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "restriction_exists", "An active restriction of this kind already exists")
+	case errors.Is(err, common.ErrInvalidRestrictionExpiry):
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "invalid_expiry", "Restriction expiry must be in the future")
+	default:
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", message)
+	}
+}
@@ -0,0 +1,327 @@
+package salarychangehandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type SalaryChangeHandler struct {
+	salaryChangeService service.SalaryChangeServices
+	cloudinaryService   service.CloudinaryService
+	validate            *validator.Validate
+	meter               metric.Meter
+	tracer              trace.Tracer
+	log                 *zap.Logger
+	requestCount        metric.Int64Counter
+	requestDuration     metric.Float64Histogram
+	errorCount          metric.Int64Counter
+	responseSize        metric.Int64Histogram
+}
+
+func NewSalaryChangeHandler(
+	salaryChangeService service.SalaryChangeServices,
+	cloudinaryService service.CloudinaryService,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *SalaryChangeHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &SalaryChangeHandler{
+		salaryChangeService: salaryChangeService,
+		cloudinaryService:   cloudinaryService,
+		validate:            money.NewValidator(),
+		meter:               meter,
+		tracer:              tracer,
+		log:                 log,
+		requestCount:        requestCount,
+		requestDuration:     requestDuration,
+		errorCount:          errorCount,
+		responseSize:        responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *SalaryChangeHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *SalaryChangeHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+// discardPayslip deletes the payslip of a request that was not recorded. A
+// failed deletion is left to the orphaned media job.
+func (h *SalaryChangeHandler) discardPayslip(ctx context.Context, url string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	if err := h.cloudinaryService.DeleteImage(ctx, url); err != nil {
+		h.log.Warn("Failed to delete payslip of failed salary change", zap.String("url", url), zap.Error(err))
+	}
+}
+
+func (h *SalaryChangeHandler) RequestChange(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.RequestSalaryChange")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received salary change request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Customer ID not found")
+	}
+	span.SetAttributes(attribute.Int64("customer.id", int64(claims.UserID)))
+
+	var req dto.SalaryChangeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid request body")
+	}
+
+	payslip, err := c.FormFile("payslip")
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "form_file_error", "Payslip is a required form field")
+	}
+	req.Payslip = payslip
+
+	if err := h.validate.Struct(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	serviceCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	payslipURL, err := h.cloudinaryService.UploadImage(serviceCtx, req.Payslip, "multifinance")
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "upload_error", "Payslip upload failed")
+	}
+
+	change, err := h.salaryChangeService.RequestChange(serviceCtx, claims.UserID, req.Salary, payslipURL)
+	if err != nil {
+		h.discardPayslip(ctx, payslipURL)
+		switch {
+		case errors.Is(err, common.ErrCustomerNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", err.Error())
+		case errors.Is(err, common.ErrSalaryUnchanged):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "salary_unchanged", err.Error())
+		case errors.Is(err, common.ErrSalaryChangeExists):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "change_pending", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to request salary change")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, change, zap.Uint64("salary_change_id", change.ID))
+}
+
+var salaryChangeListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status": {Column: "status", Values: []string{string(domain.SalaryChangePending), string(domain.SalaryChangeApproved), string(domain.SalaryChangeRejected)}},
+	},
+	Sorts: map[string]string{"created_at": "created_at"},
+}
+
+func (h *SalaryChangeHandler) ListChanges(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListSalaryChanges")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list salary changes request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	params, err := salaryChangeListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	res, err := h.salaryChangeService.ListChanges(ctx, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list salary changes")
+	}
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res)
+}
+
+func (h *SalaryChangeHandler) Review(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ReviewSalaryChange")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received review salary change request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	changeID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid salary change ID")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("salary_change.id", int64(changeID)),
+		attribute.Int64("reviewer.id", int64(claims.UserID)),
+	)
+
+	var req dto.SalaryChangeReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	change, err := h.salaryChangeService.Review(ctx, changeID, claims.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrSalaryChangeNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Salary change not found")
+		case errors.Is(err, common.ErrSalaryChangeReviewed):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "already_reviewed", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to review salary change")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, change,
+		zap.Uint64("salary_change_id", changeID),
+		zap.String("status", string(change.Status)),
+	)
+}
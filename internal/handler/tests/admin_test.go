@@ -1,7 +1,10 @@
 package handler_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,15 +12,23 @@ import (
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
 	adminhandler "github.com/fazamuttaqien/multifinance/internal/handler/admin"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
 	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/gofiber/fiber/v2/middleware/session"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
@@ -31,7 +42,7 @@ type AdminHandlerTestSuite struct {
 	suite.Suite
 	app              *fiber.App
 	handler          *adminhandler.AdminHandler
-	mockAdminService *MockAdminService
+	mockAdminService *mocks.MockAdminServices
 
 	store     *session.Store
 	jwtSecret string
@@ -42,7 +53,7 @@ type AdminHandlerTestSuite struct {
 }
 
 func (suite *AdminHandlerTestSuite) SetupTest() {
-	suite.mockAdminService = &MockAdminService{}
+	suite.mockAdminService = mocks.NewMockAdminServices(gomock.NewController(suite.T()))
 
 	// Setup dependensi auth & CSRF
 	suite.store = session.New(session.Config{
@@ -68,6 +79,7 @@ func (suite *AdminHandlerTestSuite) SetupTest() {
 
 func (suite *AdminHandlerTestSuite) setupAdminApp() *fiber.App {
 	app := fiber.New()
+	app.Use(requestid.New())
 
 	jwtAuth := middleware.NewJWTAuthMiddleware(suite.jwtSecret)
 	customCSRF := middleware.NewCustomCSRFMiddleware(suite.store)
@@ -92,6 +104,8 @@ func (suite *AdminHandlerTestSuite) setupAdminApp() *fiber.App {
 		adminGroup.Get("/customers/:customerId", suite.handler.GetCustomerByID)
 		adminGroup.Post("/customers/:customerId/verify", customCSRF, suite.handler.VerifyCustomer)
 		adminGroup.Post("/customers/:customerId/limits", customCSRF, suite.handler.SetLimits)
+		adminGroup.Get("/customers/:customerId/limits", suite.handler.GetLimitsAt)
+		adminGroup.Get("/customers/:customerId/limit-history", suite.handler.GetLimitHistory)
 	}
 
 	return app
@@ -99,21 +113,7 @@ func (suite *AdminHandlerTestSuite) setupAdminApp() *fiber.App {
 
 func (suite *AdminHandlerTestSuite) getAuthCookieAndCsrfToken() (string, []*http.Cookie) {
 	// 1. Buat token JWT untuk user admin (ID 1)
-	claims := &domain.JwtCustomClaims{
-		UserID: 1, // Admin ID
-		Role:   domain.AdminRole,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 1)),
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(suite.jwtSecret))
-	assert.NoError(suite.T(), err)
-
-	jwtCookie := &http.Cookie{
-		Name:  "private", // Gunakan nama cookie yang sesuai dengan implementasi
-		Value: signedToken,
-	}
+	jwtCookie := testutil.AuthCookie(suite.T(), suite.jwtSecret, 1, domain.AdminRole)
 
 	// 2. Dapatkan CSRF token dengan menggunakan cookie JWT yang sudah dibuat
 	csrfReq := httptest.NewRequest(http.MethodGet, "/test/csrf-token", nil)
@@ -140,8 +140,9 @@ func (suite *AdminHandlerTestSuite) getAuthCookieAndCsrfToken() (string, []*http
 func (suite *AdminHandlerTestSuite) TestListCustomers_Success() {
 	// Arrange: Dapatkan auth artifacts
 	_, authCookies := suite.getAuthCookieAndCsrfToken()
-	suite.mockAdminService.MockListCustomersResult = &domain.Paginated{Data: []domain.Customer{{ID: 2}}}
-	suite.mockAdminService.MockError = nil
+	suite.mockAdminService.EXPECT().
+		ListCustomers(gomock.Any(), gomock.Any()).
+		Return(&domain.Paginated{Data: []domain.Customer{{ID: 2, Password: "$2a$10$hash"}}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/admin/customers?status=PENDING", nil)
 	// Tambahkan cookie ke request
@@ -155,13 +156,26 @@ func (suite *AdminHandlerTestSuite) TestListCustomers_Success() {
 
 	// Assert
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.NotContains(suite.T(), strings.ToLower(string(body)), "password")
+
+	var customers []dto.CustomerResponse
+	envelope := testutil.DecodeEnvelope(suite.T(), bytes.NewReader(body), &customers)
+	assert.Equal(suite.T(), resp.Header.Get(fiber.HeaderXRequestID), envelope.Meta.RequestID)
+	assert.NotEmpty(suite.T(), envelope.Meta.RequestID)
+	suite.Require().NotNil(envelope.Meta.Pagination)
+	assert.Equal(suite.T(), int64(1), envelope.Meta.Pagination.Total)
+	assert.Nil(suite.T(), envelope.Error)
+	suite.Require().Len(customers, 1)
+	assert.Equal(suite.T(), uint64(2), customers[0].ID)
 }
 
 func (suite *AdminHandlerTestSuite) TestGetCustomerByID_Success() {
 	// Arrange
 	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken()
-	suite.mockAdminService.MockGetCustomerByIDResult = &domain.Customer{ID: 2, FullName: "Test Customer"}
-	suite.mockAdminService.MockError = nil
+	suite.mockAdminService.EXPECT().
+		GetCustomerByID(gomock.Any(), uint64(2)).
+		Return(&domain.Customer{ID: 2, FullName: "Test Customer"}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/admin/customers/2", nil)
 	req.Header.Set("X-CSRF-Token", csrfToken) // GET tidak perlu, tapi tidak masalah jika ada
@@ -175,15 +189,17 @@ func (suite *AdminHandlerTestSuite) TestGetCustomerByID_Success() {
 
 	// Assert
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
-	var customer domain.Customer
-	json.NewDecoder(resp.Body).Decode(&customer)
+	var customer dto.CustomerResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &customer)
 	assert.Equal(suite.T(), uint64(2), customer.ID)
 }
 
 func (suite *AdminHandlerTestSuite) TestVerifyCustomer_Success() {
 	// Arrange
 	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken()
-	suite.mockAdminService.MockError = nil
+	suite.mockAdminService.EXPECT().
+		VerifyCustomer(gomock.Any(), uint64(2), gomock.Any()).
+		Return(nil)
 
 	body := `{"status": "VERIFIED"}`
 	req := httptest.NewRequest(http.MethodPost, "/admin/customers/2/verify", strings.NewReader(body))
@@ -204,9 +220,11 @@ func (suite *AdminHandlerTestSuite) TestVerifyCustomer_Success() {
 func (suite *AdminHandlerTestSuite) TestSetLimits_Success() {
 	// Arrange
 	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken()
-	suite.mockAdminService.MockError = nil
+	suite.mockAdminService.EXPECT().
+		SetLimits(gomock.Any(), uint64(2), uint64(1), gomock.Any()).
+		Return(nil)
 
-	body := `{"limits": [{"tenor_months": 3, "limit_amount": 1000}]}`
+	body := `{"limits": [{"tenor_months": 3, "limit_amount": 1000, "version": 0}]}`
 	req := httptest.NewRequest(http.MethodPost, "/admin/customers/2/limits", strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-CSRF-Token", csrfToken) // Diperlukan untuk POST
@@ -222,6 +240,177 @@ func (suite *AdminHandlerTestSuite) TestSetLimits_Success() {
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
 }
 
+func (suite *AdminHandlerTestSuite) TestSetLimits_Version() {
+	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken()
+
+	send := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/admin/customers/2/limits", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+		resp, _ := suite.app.Test(req)
+		return resp
+	}
+
+	// Versi wajib dikirim, 0 berarti tenor belum punya limit
+	resp := send(`{"limits": [{"tenor_months": 3, "limit_amount": 1000}]}`)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+
+	suite.mockAdminService.EXPECT().
+		SetLimits(gomock.Any(), uint64(2), uint64(1), gomock.Any()).
+		Return(common.ErrVersionConflict)
+
+	resp = send(`{"limits": [{"tenor_months": 3, "limit_amount": 1000, "version": 3}]}`)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+	if assert.NotNil(suite.T(), envelope.Error) {
+		assert.Equal(suite.T(), "version_conflict", envelope.Error.Code)
+	}
+}
+
+func (suite *AdminHandlerTestSuite) TestSetLimits_BelowUsage() {
+	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken()
+
+	send := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/admin/customers/2/limits", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+		resp, _ := suite.app.Test(req)
+		return resp
+	}
+
+	// Force tanpa alasan ditolak sebelum sampai ke service
+	resp := send(`{"limits": [{"tenor_months": 3, "limit_amount": 1000, "version": 1}], "force": true}`)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+
+	suite.mockAdminService.EXPECT().
+		SetLimits(gomock.Any(), uint64(2), uint64(1), gomock.Any()).
+		Return(fmt.Errorf("%w: 3 months limit 1000 IDR, used 1500 IDR", common.ErrLimitBelowUsage))
+
+	resp = send(`{"limits": [{"tenor_months": 3, "limit_amount": 1000, "version": 1}]}`)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+	if assert.NotNil(suite.T(), envelope.Error) {
+		assert.Equal(suite.T(), "limit_below_usage", envelope.Error.Code)
+	}
+}
+
+func (suite *AdminHandlerTestSuite) TestGetLimitHistory() {
+	_, authCookies := suite.getAuthCookieAndCsrfToken()
+
+	suite.Run("Success", func() {
+		previous := decimal.NewFromInt(1000000)
+		suite.mockAdminService.EXPECT().
+			GetLimitHistory(gomock.Any(), uint64(2)).
+			Return([]domain.LimitChange{
+				{ID: 5, TenorMonths: 6, PreviousAmount: &previous, PreviousCurrency: "IDR", NewAmount: decimal.NewFromInt(1500000), Currency: "IDR", ChangedBy: 1},
+				{ID: 4, TenorMonths: 6, NewAmount: decimal.NewFromInt(1000000), Currency: "IDR", ChangedBy: 1},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/customers/2/limit-history", nil)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		var history []dto.LimitChangeResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &history)
+		require.Len(suite.T(), history, 2)
+		assert.Equal(suite.T(), "1000000", history[0].PreviousAmount.String())
+		assert.Nil(suite.T(), history[1].PreviousAmount)
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockAdminService.EXPECT().
+			GetLimitHistory(gomock.Any(), uint64(99)).
+			Return(nil, common.ErrCustomerNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/customers/99/limit-history", nil)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *AdminHandlerTestSuite) TestGetLimitsAt() {
+	_, authCookies := suite.getAuthCookieAndCsrfToken()
+
+	get := func(path string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+		resp, _ := suite.app.Test(req)
+		return resp
+	}
+
+	suite.Run("Success - End Of Day", func() {
+		endOfDay := time.Date(2025, 1, 31, 23, 59, 59, 999999000, time.UTC)
+		effectiveTo := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		suite.mockAdminService.EXPECT().
+			GetLimitsAt(gomock.Any(), uint64(2), endOfDay).
+			Return([]domain.CustomerLimit{
+				{
+					CustomerID:    2,
+					TenorID:       1,
+					Tenor:         domain.Tenor{ID: 1, DurationMonths: 3},
+					LimitAmount:   decimal.NewFromInt(1000000),
+					Currency:      "IDR",
+					Version:       2,
+					EffectiveFrom: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+					EffectiveTo:   &effectiveTo,
+				},
+			}, nil)
+
+		resp := get("/admin/customers/2/limits?at=2025-01-31")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		var body dto.LimitsAtResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		require.Len(suite.T(), body.Limits, 1)
+		assert.Equal(suite.T(), uint8(3), body.Limits[0].TenorMonths)
+		assert.Equal(suite.T(), uint64(2), body.Limits[0].Version)
+		require.NotNil(suite.T(), body.Limits[0].EffectiveTo)
+		assert.True(suite.T(), body.Limits[0].EffectiveTo.Equal(effectiveTo))
+	})
+
+	suite.Run("Failure - Bad Date", func() {
+		resp := get("/admin/customers/2/limits?at=31-01-2025")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockAdminService.EXPECT().
+			GetLimitsAt(gomock.Any(), uint64(99), gomock.Any()).
+			Return(nil, common.ErrCustomerNotFound)
+
+		resp := get("/admin/customers/99/limits")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
 func (suite *AdminHandlerTestSuite) TestAdminRoutes_FailWithoutAuth() {
 	// Arrange
 	req := httptest.NewRequest(http.MethodGet, "/admin/customers", nil) // Tanpa cookie
@@ -232,6 +421,10 @@ func (suite *AdminHandlerTestSuite) TestAdminRoutes_FailWithoutAuth() {
 
 	// Assert
 	assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode, "Should fail without JWT cookie")
+	envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+	if assert.NotNil(suite.T(), envelope.Error) {
+		assert.Equal(suite.T(), "auth_error", envelope.Error.Code)
+	}
 }
 
 func (suite *AdminHandlerTestSuite) TestAdminRoutes_FailWithWrongRole() {
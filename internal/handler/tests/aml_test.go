@@ -0,0 +1,168 @@
+package handler_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	amlhandler "github.com/fazamuttaqien/multifinance/internal/handler/aml"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const amlJWTSecret = "test-secret-key"
+
+type AMLHandlerTestSuite struct {
+	suite.Suite
+	app            *fiber.App
+	mockAMLService *mocks.MockAMLServices
+}
+
+func (suite *AMLHandlerTestSuite) SetupTest() {
+	suite.mockAMLService = mocks.NewMockAMLServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-aml-handler")
+	handler := amlhandler.NewAMLHandler(suite.mockAMLService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(amlJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/aml-cases", handler.ListCases)
+	suite.app.Get("/admin/aml-cases/export", handler.Export)
+	suite.app.Get("/admin/aml-cases/:id", handler.GetCase)
+	suite.app.Put("/admin/aml-cases/:id/status", jwtAuth, handler.UpdateStatus)
+}
+
+func (suite *AMLHandlerTestSuite) TestListCases() {
+	suite.Run("Success - Filtered By Status", func() {
+		suite.mockAMLService.EXPECT().ListCases(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, params domain.Params) (*domain.Paginated, error) {
+				assert.Equal(suite.T(), "OPEN", params.Value("status"))
+				return params.Paginated([]domain.AMLCase{{ID: 3, Rule: domain.AMLLargeTransaction, Status: domain.AMLCaseOpen}}, 1), nil
+			})
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/aml-cases?status=open", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Rule", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/aml-cases?rule=STRUCTURING", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *AMLHandlerTestSuite) TestGetCase() {
+	suite.Run("Failure - Not Found", func() {
+		suite.mockAMLService.EXPECT().GetCase(gomock.Any(), uint64(9)).Return(nil, common.ErrAMLCaseNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/aml-cases/9", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *AMLHandlerTestSuite) TestUpdateStatus() {
+	adminCookie := testutil.AuthCookie(suite.T(), amlJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success - Reported", func() {
+		req := dto.AMLCaseStatusRequest{Status: domain.AMLCaseReported, ReportReference: "LTKM-2025-0042"}
+		suite.mockAMLService.EXPECT().UpdateStatus(gomock.Any(), uint64(3), uint64(1), req).
+			Return(&domain.AMLCase{ID: 3, Status: domain.AMLCaseReported, ReportReference: "LTKM-2025-0042"}, nil)
+
+		body := map[string]any{"status": "REPORTED", "report_reference": "LTKM-2025-0042"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/aml-cases/3/status", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.AMLCaseResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "REPORTED", data.Status)
+		assert.Equal(suite.T(), "LTKM-2025-0042", data.ReportReference)
+	})
+
+	suite.Run("Failure - Reported Without Reference", func() {
+		body := map[string]any{"status": "REPORTED"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/aml-cases/3/status", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Transition", func() {
+		suite.mockAMLService.EXPECT().UpdateStatus(gomock.Any(), uint64(3), uint64(1), gomock.Any()).Return(nil, common.ErrInvalidAMLTransition)
+
+		body := map[string]any{"status": "REVIEWED"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/aml-cases/3/status", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *AMLHandlerTestSuite) TestExport() {
+	suite.Run("Success - goAML CSV", func() {
+		date := time.Date(2025, 6, 3, 10, 30, 0, 0, time.UTC)
+		suite.mockAMLService.EXPECT().Export(gomock.Any(), "2025-06-01", "2025-06-30", domain.AMLCaseReported).
+			Return([]domain.AMLCase{{
+				ID:              3,
+				Rule:            domain.AMLLargeTransaction,
+				Status:          domain.AMLCaseReported,
+				AmountIDR:       decimal.NewFromInt(800_000_000),
+				ReportReference: "LTKM-2025-0042",
+				Transaction: &domain.Transaction{
+					ContractNumber:  "KTR-0001",
+					TransactionDate: date,
+					Currency:        "USD",
+					OTRAmount:       decimal.NewFromInt(50_000),
+					FxRate:          decimal.NewFromInt(16_000),
+				},
+				Customer: &domain.Customer{NIK: "3171234567890001", FullName: "Budi", BirthPlace: "Jakarta", BirthDate: time.Date(1990, 4, 12, 0, 0, 0, 0, time.UTC)},
+			}}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/aml-cases/export?from=2025-06-01&to=2025-06-30&status=reported", nil))
+		defer resp.Body.Close()
+		require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Contains(suite.T(), resp.Header.Get(fiber.HeaderContentDisposition), "aml-cases-2025-06-01-2025-06-30.csv")
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), records, 2)
+		assert.Equal(suite.T(), "transactionnumber", records[0][1])
+		assert.Equal(suite.T(), []string{
+			"3", "KTR-0001", "2025-06-03T10:30:00", "",
+			"800000000.00", "USD", "50000.00", "16000",
+			"3171234567890001", "Budi", "1990-04-12T00:00:00", "Jakarta",
+			"LARGE_TRANSACTION", "REPORTED", "LTKM-2025-0042",
+		}, records[1])
+	})
+
+	suite.Run("Failure - Invalid Range", func() {
+		suite.mockAMLService.EXPECT().Export(gomock.Any(), "2025-06-30", "2025-06-01", domain.AMLCaseStatus("")).Return(nil, common.ErrInvalidAMLExportRange)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/aml-cases/export?from=2025-06-30&to=2025-06-01", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Status", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/aml-cases/export?from=2025-06-01&to=2025-06-30&status=closed", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestAMLHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AMLHandlerTestSuite))
+}
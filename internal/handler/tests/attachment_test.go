@@ -0,0 +1,149 @@
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	attachmenthandler "github.com/fazamuttaqien/multifinance/internal/handler/attachment"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const attachmentJWTSecret = "test-secret-key"
+
+type AttachmentHandlerTestSuite struct {
+	suite.Suite
+	app                   *fiber.App
+	mockAttachmentService *mocks.MockTransactionAttachmentServices
+}
+
+func (suite *AttachmentHandlerTestSuite) SetupTest() {
+	suite.mockAttachmentService = mocks.NewMockTransactionAttachmentServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-attachment-handler")
+	handler := attachmenthandler.NewAttachmentHandler(suite.mockAttachmentService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(attachmentJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Post("/partners/transactions/:id/attachments", jwtAuth, handler.UploadAttachment)
+	suite.app.Get("/partners/transactions/:id/attachments", jwtAuth, handler.ListAttachments)
+	suite.app.Delete("/admin/transactions/:id/attachments/:attachmentId", jwtAuth, handler.DeleteAttachment)
+}
+
+func (suite *AttachmentHandlerTestSuite) newUploadRequest(attachmentType string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	suite.Require().NoError(writer.WriteField("type", attachmentType))
+	part, err := writer.CreateFormFile("file", "invoice.pdf")
+	suite.Require().NoError(err)
+	_, err = io.WriteString(part, "%PDF-1.4 dummy")
+	suite.Require().NoError(err)
+	suite.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/partners/transactions/9/attachments", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(testutil.AuthCookie(suite.T(), attachmentJWTSecret, 2, domain.CustomerRole))
+	return req
+}
+
+func (suite *AttachmentHandlerTestSuite) TestUploadAttachment() {
+	customerID := uint64(2)
+
+	suite.Run("Success - Created", func() {
+		suite.mockAttachmentService.EXPECT().
+			UploadAttachment(gomock.Any(), domain.BatchOwner{CustomerID: &customerID}, uint64(9),
+				dto.TransactionAttachmentRequest{Type: domain.AttachmentInvoice}, "invoice.pdf", []byte("%PDF-1.4 dummy")).
+			Return(&domain.TransactionAttachment{ID: 1, TransactionID: 9, Type: domain.AttachmentInvoice, ContentType: "application/pdf"}, nil)
+
+		resp, _ := suite.app.Test(suite.newUploadRequest("INVOICE"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var body dto.TransactionAttachmentResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), domain.AttachmentInvoice, body.Type)
+	})
+
+	suite.Run("Failure - Unknown Type", func() {
+		resp, _ := suite.app.Test(suite.newUploadRequest("SELFIE"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unsupported Content", func() {
+		suite.mockAttachmentService.EXPECT().UploadAttachment(gomock.Any(), gomock.Any(), uint64(9), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrUnsupportedAttachment)
+
+		resp, _ := suite.app.Test(suite.newUploadRequest("BPKB"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Transaction Not Owned", func() {
+		suite.mockAttachmentService.EXPECT().UploadAttachment(gomock.Any(), gomock.Any(), uint64(9), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrTransactionNotFound)
+
+		resp, _ := suite.app.Test(suite.newUploadRequest("INVOICE"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *AttachmentHandlerTestSuite) TestListAttachments() {
+	suite.Run("Success - Listed", func() {
+		suite.mockAttachmentService.EXPECT().ListAttachments(gomock.Any(), gomock.Any(), uint64(9)).
+			Return([]domain.TransactionAttachment{{ID: 1, TransactionID: 9}, {ID: 2, TransactionID: 9}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/partners/transactions/9/attachments", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), attachmentJWTSecret, 2, domain.CustomerRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body []dto.TransactionAttachmentResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Len(suite.T(), body, 2)
+	})
+}
+
+func (suite *AttachmentHandlerTestSuite) TestDeleteAttachment() {
+	suite.Run("Success - Deleted", func() {
+		suite.mockAttachmentService.EXPECT().DeleteAttachment(gomock.Any(), uint64(9), uint64(3), uint64(1)).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/transactions/9/attachments/3", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), attachmentJWTSecret, 1, domain.AdminRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockAttachmentService.EXPECT().DeleteAttachment(gomock.Any(), uint64(9), uint64(3), uint64(1)).Return(common.ErrAttachmentNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/transactions/9/attachments/3", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), attachmentJWTSecret, 1, domain.AdminRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestAttachmentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentHandlerTestSuite))
+}
@@ -0,0 +1,150 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	batchhandler "github.com/fazamuttaqien/multifinance/internal/handler/batch"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const batchJWTSecret = "test-secret-key"
+
+type BatchHandlerTestSuite struct {
+	suite.Suite
+	app              *fiber.App
+	mockBatchService *mocks.MockTransactionBatchServices
+}
+
+func (suite *BatchHandlerTestSuite) SetupTest() {
+	suite.mockBatchService = mocks.NewMockTransactionBatchServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-batch-handler")
+	handler := batchhandler.NewBatchHandler(suite.mockBatchService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(batchJWTSecret)
+	partnerKey := func(c *fiber.Ctx) error {
+		c.Locals("partner", &domain.Partner{ID: 7})
+		c.Locals("sandbox", true)
+		return c.Next()
+	}
+
+	suite.app = fiber.New()
+	suite.app.Post("/partners/transactions/batch", jwtAuth, handler.SubmitBatch)
+	suite.app.Get("/partners/batches/:id", jwtAuth, handler.GetBatch)
+	suite.app.Post("/partner-api/transactions/batch", partnerKey, handler.SubmitBatch)
+	suite.app.Get("/partner-api/batches/:id", partnerKey, handler.GetBatch)
+}
+
+func batchItem(nik string) map[string]any {
+	return map[string]any{"customer_nik": nik, "tenor_months": 6, "asset_name": "Motor", "otr_amount": "15000000"}
+}
+
+func (suite *BatchHandlerTestSuite) TestSubmitBatch() {
+	customerCookie := testutil.AuthCookie(suite.T(), batchJWTSecret, 3, domain.CustomerRole)
+
+	suite.Run("Success - Partner API Key", func() {
+		partnerID := uint64(7)
+		suite.mockBatchService.EXPECT().Submit(gomock.Any(), domain.BatchOwner{PartnerID: &partnerID, Sandbox: true}, gomock.Any()).
+			Return(&domain.TransactionBatch{
+				ID:     11,
+				Status: domain.BatchPending,
+				Items: []domain.TransactionBatchItem{
+					{Sequence: 1, CustomerNIK: "1234567890123456", Status: domain.BatchItemPending},
+					{Sequence: 2, CustomerNIK: "6543210987654321", Status: domain.BatchItemPending},
+				},
+			}, nil)
+
+		body := map[string]any{"items": []map[string]any{batchItem("1234567890123456"), batchItem("6543210987654321")}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions/batch", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusAccepted, resp.StatusCode)
+
+		var batch dto.TransactionBatchResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &batch)
+		assert.Equal(suite.T(), uint64(11), batch.ID)
+		assert.Equal(suite.T(), 2, batch.Pending)
+	})
+
+	suite.Run("Success - Customer Session", func() {
+		customerID := uint64(3)
+		suite.mockBatchService.EXPECT().Submit(gomock.Any(), domain.BatchOwner{CustomerID: &customerID}, gomock.Any()).
+			Return(&domain.TransactionBatch{ID: 12, Status: domain.BatchPending}, nil)
+
+		body := map[string]any{"items": []map[string]any{batchItem("1234567890123456")}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/partners/transactions/batch", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusAccepted, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Item", func() {
+		invalid := batchItem("123")
+		body := map[string]any{"items": []map[string]any{batchItem("1234567890123456"), invalid}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions/batch", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Empty Batch", func() {
+		body := map[string]any{"items": []map[string]any{}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions/batch", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Too Large", func() {
+		suite.mockBatchService.EXPECT().Submit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, common.ErrBatchTooLarge)
+
+		body := map[string]any{"items": []map[string]any{batchItem("1234567890123456")}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions/batch", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+}
+
+func (suite *BatchHandlerTestSuite) TestGetBatch() {
+	suite.Run("Success - With Item Results", func() {
+		transactionID := uint64(90)
+		suite.mockBatchService.EXPECT().GetBatch(gomock.Any(), uint64(11), gomock.Any()).
+			Return(&domain.TransactionBatch{
+				ID:     11,
+				Status: domain.BatchCompleted,
+				Items: []domain.TransactionBatchItem{
+					{Sequence: 1, Status: domain.BatchItemSucceeded, TransactionID: &transactionID, ContractNumber: "KTR-90"},
+					{Sequence: 2, Status: domain.BatchItemFailed, ErrorCode: "insufficient_limit", ErrorMessage: "Insufficient limit"},
+				},
+			}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/batches/11", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var batch dto.TransactionBatchResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &batch)
+		assert.Equal(suite.T(), 1, batch.Succeeded)
+		assert.Equal(suite.T(), 1, batch.Failed)
+		assert.Equal(suite.T(), "insufficient_limit", batch.Items[1].ErrorCode)
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockBatchService.EXPECT().GetBatch(gomock.Any(), uint64(12), gomock.Any()).Return(nil, common.ErrBatchNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/batches/12", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBatchHandlerSuite(t *testing.T) {
+	suite.Run(t, new(BatchHandlerTestSuite))
+}
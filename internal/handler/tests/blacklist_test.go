@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	blacklisthandler "github.com/fazamuttaqien/multifinance/internal/handler/blacklist"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type BlacklistHandlerTestSuite struct {
+	suite.Suite
+	app                  *fiber.App
+	mockBlacklistService *mocks.MockBlacklistServices
+}
+
+func (suite *BlacklistHandlerTestSuite) SetupTest() {
+	suite.mockBlacklistService = mocks.NewMockBlacklistServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-blacklist-handler")
+	handler := blacklisthandler.NewBlacklistHandler(suite.mockBlacklistService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/blacklist", handler.ListEntries)
+	suite.app.Post("/admin/blacklist", handler.CreateEntry)
+	suite.app.Get("/admin/blacklist/screening-logs", handler.ListScreeningLogs)
+	suite.app.Put("/admin/blacklist/:id", handler.UpdateEntry)
+	suite.app.Delete("/admin/blacklist/:id", handler.DeleteEntry)
+}
+
+func (suite *BlacklistHandlerTestSuite) TestCreateEntry() {
+	suite.Run("Success", func() {
+		req := dto.BlacklistEntryRequest{
+			NIK:      "3201010101900001",
+			FullName: "Budi Santoso",
+			Reason:   "Fraudulent documents",
+			Source:   "internal",
+			Severity: domain.SeverityBlock,
+		}
+		suite.mockBlacklistService.EXPECT().CreateEntry(gomock.Any(), req).
+			Return(&domain.BlacklistEntry{ID: 1, FullName: "Budi Santoso", Severity: domain.SeverityBlock}, nil)
+
+		body := map[string]any{"nik": req.NIK, "full_name": req.FullName, "reason": req.Reason, "source": req.Source, "severity": "BLOCK"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/blacklist", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Severity", func() {
+		body := map[string]any{"full_name": "Budi Santoso", "reason": "x", "source": "internal", "severity": "WARN"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/blacklist", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *BlacklistHandlerTestSuite) TestUpdateAndDeleteEntry() {
+	suite.Run("Update - Not Found", func() {
+		suite.mockBlacklistService.EXPECT().UpdateEntry(gomock.Any(), uint64(9), gomock.Any()).Return(nil, common.ErrBlacklistNotFound)
+
+		body := map[string]any{"full_name": "Budi Santoso", "reason": "x", "source": "internal", "severity": "FLAG"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/blacklist/9", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Delete - Success", func() {
+		suite.mockBlacklistService.EXPECT().DeleteEntry(gomock.Any(), uint64(3)).Return(nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/blacklist/3", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+}
+
+func (suite *BlacklistHandlerTestSuite) TestListScreeningLogs() {
+	suite.Run("Success - Blocked Filter", func() {
+		suite.mockBlacklistService.EXPECT().
+			ListScreeningLogs(gomock.Any(), domain.Params{Filters: []query.Condition{{Column: "outcome", Value: "BLOCKED"}}, Page: 1, Limit: 10}).
+			Return(&domain.Paginated{Data: []domain.ScreeningLog{{ID: 1}}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/blacklist/screening-logs?outcome=blocked", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Outcome", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/blacklist/screening-logs?outcome=maybe", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestBlacklistHandlerSuite(t *testing.T) {
+	suite.Run(t, new(BlacklistHandlerTestSuite))
+}
@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type BodyLimitTestSuite struct {
+	suite.Suite
+	app *fiber.App
+}
+
+func (suite *BodyLimitTestSuite) SetupTest() {
+	suite.app = fiber.New()
+	suite.app.Use(middleware.NewBodyLimitMiddleware(middleware.BodyLimitConfig{
+		MaxBytes:          256,
+		MultipartMaxBytes: 4096,
+		MaxJSONDepth:      4,
+	}))
+	suite.app.Post("/echo", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+}
+
+func (suite *BodyLimitTestSuite) post(contentType, body string) *http.Response {
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, contentType)
+	resp, err := suite.app.Test(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *BodyLimitTestSuite) TestJSONBody() {
+	suite.Run("Success - Within Limits", func() {
+		resp := suite.post(fiber.MIMEApplicationJSON, `{"a":{"b":[1,2,{"c":"[[[[[["}]}}`)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Too Large", func() {
+		resp := suite.post(fiber.MIMEApplicationJSON, `{"note":"`+strings.Repeat("x", 300)+`"}`)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+		env := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		assert.Equal(suite.T(), "payload_too_large", env.Error.Code)
+	})
+
+	suite.Run("Failure - Too Deep", func() {
+		resp := suite.post(fiber.MIMEApplicationJSON, strings.Repeat("[", 5)+strings.Repeat("]", 5))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *BodyLimitTestSuite) TestMultipartBody() {
+	build := func(size int) (string, string) {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		part, _ := writer.CreateFormFile("ktp_photo", "ktp.jpg")
+		part.Write(bytes.Repeat([]byte{0xff}, size))
+		writer.Close()
+		return writer.FormDataContentType(), buf.String()
+	}
+
+	suite.Run("Success - Upload Allowance", func() {
+		contentType, body := build(1024)
+		resp := suite.post(contentType, body)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Upload Too Large", func() {
+		contentType, body := build(8192)
+		resp := suite.post(contentType, body)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+}
+
+func TestBodyLimitSuite(t *testing.T) {
+	suite.Run(t, new(BodyLimitTestSuite))
+}
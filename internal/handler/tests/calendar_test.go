@@ -0,0 +1,120 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	calendarhandler "github.com/fazamuttaqien/multifinance/internal/handler/calendar"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const calendarJWTSecret = "test-secret-key"
+
+type CalendarHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockCalendarService *mocks.MockCalendarServices
+}
+
+func (suite *CalendarHandlerTestSuite) SetupTest() {
+	suite.mockCalendarService = mocks.NewMockCalendarServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-calendar-handler")
+	handler := calendarhandler.NewCalendarHandler(suite.mockCalendarService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(calendarJWTSecret)
+
+	suite.app = fiber.New()
+	api := suite.app.Group("/api/v1")
+	api.Get("/me/transactions/:contractNumber/calendar.ics", handler.Feed)
+	customersAPI := api.Group("/me", jwtAuth)
+	customersAPI.Post("/transactions/:contractNumber/calendar-link", handler.CreateLink)
+	customersAPI.Delete("/transactions/:contractNumber/calendar-link", handler.RevokeLink)
+}
+
+func (suite *CalendarHandlerTestSuite) TestCreateLink() {
+	customerCookie := testutil.AuthCookie(suite.T(), calendarJWTSecret, 7, domain.CustomerRole)
+
+	suite.Run("Success", func() {
+		suite.mockCalendarService.EXPECT().CreateToken(gomock.Any(), uint64(7), "MF-1").Return("mf_cal_abc", nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/api/v1/me/transactions/MF-1/calendar-link", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var data dto.CalendarLinkResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.True(suite.T(), strings.HasSuffix(data.URL, "/api/v1/me/transactions/MF-1/calendar.ics?token=mf_cal_abc"))
+		assert.True(suite.T(), strings.HasPrefix(data.WebcalURL, "webcal://"))
+	})
+
+	suite.Run("Failure - Contract Not Found", func() {
+		suite.mockCalendarService.EXPECT().CreateToken(gomock.Any(), uint64(7), "MF-2").Return("", common.ErrTransactionNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/api/v1/me/transactions/MF-2/calendar-link", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *CalendarHandlerTestSuite) TestRevokeLink() {
+	customerCookie := testutil.AuthCookie(suite.T(), calendarJWTSecret, 7, domain.CustomerRole)
+
+	suite.Run("Success", func() {
+		suite.mockCalendarService.EXPECT().RevokeToken(gomock.Any(), uint64(7), "MF-1").Return(nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodDelete, "/api/v1/me/transactions/MF-1/calendar-link", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - No Active Link", func() {
+		suite.mockCalendarService.EXPECT().RevokeToken(gomock.Any(), uint64(7), "MF-1").Return(common.ErrCalendarTokenNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodDelete, "/api/v1/me/transactions/MF-1/calendar-link", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *CalendarHandlerTestSuite) TestFeed() {
+	suite.Run("Success - Without Login", func() {
+		feed := []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+		suite.mockCalendarService.EXPECT().Feed(gomock.Any(), "MF-1", "mf_cal_abc").Return(feed, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/me/transactions/MF-1/calendar.ics?token=mf_cal_abc", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "text/calendar; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(suite.T(), "private, no-store", resp.Header.Get(fiber.HeaderCacheControl))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), feed, body)
+	})
+
+	suite.Run("Failure - Revoked Link", func() {
+		suite.mockCalendarService.EXPECT().Feed(gomock.Any(), "MF-1", "mf_cal_old").Return(nil, common.ErrCalendarTokenInvalid)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/me/transactions/MF-1/calendar.ics?token=mf_cal_old", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCalendarHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarHandlerTestSuite))
+}
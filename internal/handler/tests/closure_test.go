@@ -0,0 +1,146 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	closurehandler "github.com/fazamuttaqien/multifinance/internal/handler/closure"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const closureJWTSecret = "test-secret-key"
+
+type AccountClosureHandlerTestSuite struct {
+	suite.Suite
+	app                *fiber.App
+	mockClosureService *mocks.MockAccountClosureServices
+}
+
+func (suite *AccountClosureHandlerTestSuite) SetupTest() {
+	suite.mockClosureService = mocks.NewMockAccountClosureServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-closure-handler")
+	handler := closurehandler.NewAccountClosureHandler(suite.mockClosureService, session.New(), meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(closureJWTSecret)
+	gate := middleware.NewAccountClosureMiddleware(suite.mockClosureService)
+
+	suite.app = fiber.New()
+	customersAPI := suite.app.Group("/me", jwtAuth, gate)
+	customersAPI.Post("/close-account", handler.CloseAccount)
+	customersAPI.Get("/profile", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+}
+
+func (suite *AccountClosureHandlerTestSuite) TestCloseAccount() {
+	customerCookie := testutil.AuthCookie(suite.T(), closureJWTSecret, 7, domain.CustomerRole)
+	body := map[string]any{"password": "secret-pass1", "reason": "No longer need financing"}
+
+	suite.Run("Success - Clears Auth Cookie", func() {
+		closedAt := time.Date(2025, 6, 30, 9, 0, 0, 0, time.UTC)
+		suite.mockClosureService.EXPECT().Authorize(gomock.Any(), uint64(7)).Return(nil)
+		suite.mockClosureService.EXPECT().Close(gomock.Any(), uint64(7), dto.CloseAccountRequest{Password: "secret-pass1", Reason: "No longer need financing"}).
+			Return(&domain.AccountClosure{ID: 1, CustomerID: 7, ClosedAt: closedAt, AnonymizeAfter: closedAt.AddDate(0, 0, 30)}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/close-account", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		cleared := false
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == "private" && cookie.Value == "" {
+				cleared = true
+			}
+		}
+		assert.True(suite.T(), cleared)
+
+		var data dto.AccountClosureResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), closedAt.AddDate(0, 0, 30), data.AnonymizeAfter.UTC())
+	})
+
+	suite.Run("Failure - Reason Required", func() {
+		suite.mockClosureService.EXPECT().Authorize(gomock.Any(), uint64(7)).Return(nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/close-account", map[string]any{"password": "secret-pass1"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Wrong Password", func() {
+		suite.mockClosureService.EXPECT().Authorize(gomock.Any(), uint64(7)).Return(nil)
+		suite.mockClosureService.EXPECT().Close(gomock.Any(), uint64(7), gomock.Any()).Return(nil, common.ErrInvalidCredentials)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/close-account", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Running Contracts", func() {
+		suite.mockClosureService.EXPECT().Authorize(gomock.Any(), uint64(7)).Return(nil)
+		suite.mockClosureService.EXPECT().Close(gomock.Any(), uint64(7), gomock.Any()).Return(nil, common.ErrActiveContracts)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/close-account", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Outstanding Balance", func() {
+		suite.mockClosureService.EXPECT().Authorize(gomock.Any(), uint64(7)).Return(nil)
+		suite.mockClosureService.EXPECT().Close(gomock.Any(), uint64(7), gomock.Any()).Return(nil, common.ErrOutstandingBalance)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/close-account", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func (suite *AccountClosureHandlerTestSuite) TestClosedAccountGate() {
+	customerCookie := testutil.AuthCookie(suite.T(), closureJWTSecret, 7, domain.CustomerRole)
+
+	suite.Run("Closed Account Token Rejected", func() {
+		suite.mockClosureService.EXPECT().Authorize(gomock.Any(), uint64(7)).Return(common.ErrAccountClosed)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
+		req.AddCookie(customerCookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	suite.Run("Check Failure", func() {
+		suite.mockClosureService.EXPECT().Authorize(gomock.Any(), uint64(7)).Return(errors.New("connection refused"))
+
+		req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
+		req.AddCookie(customerCookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
+	})
+
+	suite.Run("Admin Token Not Checked", func() {
+		req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), closureJWTSecret, 1, domain.AdminRole))
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestAccountClosureHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountClosureHandlerTestSuite))
+}
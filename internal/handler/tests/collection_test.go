@@ -0,0 +1,221 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	collectionhandler "github.com/fazamuttaqien/multifinance/internal/handler/collection"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const collectionJWTSecret = "test-secret-key"
+
+type CollectionHandlerTestSuite struct {
+	suite.Suite
+	app                   *fiber.App
+	mockCollectionService *mocks.MockCollectionServices
+	adminCookie           *http.Cookie
+}
+
+func (suite *CollectionHandlerTestSuite) SetupTest() {
+	suite.mockCollectionService = mocks.NewMockCollectionServices(gomock.NewController(suite.T()))
+	suite.adminCookie = testutil.AuthCookie(suite.T(), collectionJWTSecret, 1, domain.AdminRole)
+
+	meter, tracer, log := testutil.Telemetry("test-collection-handler")
+	handler := collectionhandler.NewCollectionHandler(suite.mockCollectionService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(collectionJWTSecret)
+	requireAdmin := middleware.RequireRole(domain.AdminRole)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/collections/dashboard", jwtAuth, requireAdmin, handler.Dashboard)
+	suite.app.Get("/admin/collections/cases", jwtAuth, requireAdmin, handler.ListCases)
+	suite.app.Get("/admin/collections/cases/:id", jwtAuth, requireAdmin, handler.GetCase)
+	suite.app.Put("/admin/collections/cases/:id/assignee", jwtAuth, requireAdmin, handler.Assign)
+	suite.app.Put("/admin/collections/cases/:id/status", jwtAuth, requireAdmin, handler.UpdateStatus)
+	suite.app.Get("/admin/collections/cases/:id/activities", jwtAuth, requireAdmin, handler.ListActivities)
+	suite.app.Post("/admin/collections/cases/:id/activities", jwtAuth, requireAdmin, handler.AddActivity)
+}
+
+func (suite *CollectionHandlerTestSuite) get(path string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.AddCookie(suite.adminCookie)
+
+	resp, _ := suite.app.Test(req)
+	return resp
+}
+
+func (suite *CollectionHandlerTestSuite) TestCases() {
+	suite.Run("Success - List", func() {
+		suite.mockCollectionService.EXPECT().ListCases(gomock.Any(), gomock.Any()).
+			Return(&domain.Paginated{Data: []domain.CollectionCase{{ID: 1, Status: domain.CollectionOpen}}, Total: 1, Page: 1, Limit: 10}, nil)
+
+		resp := suite.get("/admin/collections/cases?status=OPEN")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Status Filter", func() {
+		resp := suite.get("/admin/collections/cases?status=CLOSED")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockCollectionService.EXPECT().GetCase(gomock.Any(), uint64(9)).Return(nil, common.ErrCollectionCaseNotFound)
+
+		resp := suite.get("/admin/collections/cases/9")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *CollectionHandlerTestSuite) TestAssign() {
+	suite.Run("Success", func() {
+		collectorID := uint64(4)
+		suite.mockCollectionService.EXPECT().Assign(gomock.Any(), uint64(7), dto.CollectionAssignRequest{CollectorID: &collectorID}).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionOpen, CollectorID: &collectorID}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/collections/cases/7/assignee", map[string]any{"collector_id": 4}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body dto.CollectionCaseResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), collectorID, *body.CollectorID)
+	})
+
+	suite.Run("Failure - Not A Collector", func() {
+		suite.mockCollectionService.EXPECT().Assign(gomock.Any(), uint64(7), gomock.Any()).Return(nil, common.ErrCollectorNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/collections/cases/7/assignee", map[string]any{"collector_id": 2}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Closed Case", func() {
+		suite.mockCollectionService.EXPECT().Assign(gomock.Any(), uint64(7), gomock.Any()).Return(nil, common.ErrCollectionCaseClosed)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/collections/cases/7/assignee", map[string]any{"collector_id": 4}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *CollectionHandlerTestSuite) TestUpdateStatus() {
+	suite.Run("Success", func() {
+		suite.mockCollectionService.EXPECT().
+			UpdateStatus(gomock.Any(), uint64(7), dto.CollectionCaseStatusRequest{Status: domain.CollectionRepossession}).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionRepossession}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/collections/cases/7/status", map[string]any{"status": "REPOSSESSION"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Back To Open", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/collections/cases/7/status", map[string]any{"status": "OPEN"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Transition", func() {
+		suite.mockCollectionService.EXPECT().UpdateStatus(gomock.Any(), uint64(7), gomock.Any()).Return(nil, common.ErrInvalidCollectionMove)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/collections/cases/7/status", map[string]any{"status": "REPOSSESSED"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *CollectionHandlerTestSuite) TestActivities() {
+	suite.Run("Success - Promise To Pay", func() {
+		amount := decimal.NewFromInt(1_500_000)
+		suite.mockCollectionService.EXPECT().
+			AddActivity(gomock.Any(), uint64(7), uint64(1), dto.CollectionActivityRequest{Type: domain.CollectionPromiseToPay, Note: "Transfer Jumat", PromiseAmount: &amount, PromiseDate: "2030-01-10"}).
+			Return(&domain.CollectionActivity{ID: 3, CaseID: 7, Type: domain.CollectionPromiseToPay, PromiseAmount: &amount}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPost, "/admin/collections/cases/7/activities",
+			map[string]any{"type": "PROMISE_TO_PAY", "note": "Transfer Jumat", "promise_amount": "1500000", "promise_date": "2030-01-10"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var body dto.CollectionActivityResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), uint64(3), body.ID)
+	})
+
+	suite.Run("Failure - Promise Without Date", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPost, "/admin/collections/cases/7/activities",
+			map[string]any{"type": "PROMISE_TO_PAY", "note": "Transfer Jumat", "promise_amount": "1500000"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Closed Case", func() {
+		suite.mockCollectionService.EXPECT().AddActivity(gomock.Any(), uint64(7), uint64(1), gomock.Any()).Return(nil, common.ErrCollectionCaseClosed)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPost, "/admin/collections/cases/7/activities",
+			map[string]any{"type": "VISIT", "note": "Rumah kosong"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Success - List", func() {
+		suite.mockCollectionService.EXPECT().ListActivities(gomock.Any(), uint64(7)).
+			Return([]domain.CollectionActivity{{ID: 2, Type: domain.CollectionCall}, {ID: 1, Type: domain.CollectionVisit}}, nil)
+
+		resp := suite.get("/admin/collections/cases/7/activities")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body []dto.CollectionActivityResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Len(suite.T(), body, 2)
+	})
+}
+
+func (suite *CollectionHandlerTestSuite) TestDashboard() {
+	suite.Run("Success - Every Status Listed", func() {
+		suite.mockCollectionService.EXPECT().Dashboard(gomock.Any(), gomock.Any()).
+			Return(&domain.CollectionDashboard{
+				ByStatus:   map[domain.CollectionCaseStatus]int64{domain.CollectionOpen: 3},
+				Unassigned: 1,
+				Collectors: []domain.CollectorLoad{{CollectorID: 4, CollectorName: "Rina", ActiveCases: 2, PendingPromises: 1}},
+			}, nil)
+
+		resp := suite.get("/admin/collections/dashboard")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body dto.CollectionDashboardResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), int64(3), body.ByStatus["OPEN"])
+		assert.Contains(suite.T(), body.ByStatus, "RESOLVED")
+		assert.Equal(suite.T(), "Rina", body.Collectors[0].CollectorName)
+	})
+
+	suite.Run("Failure - Customer Token", func() {
+		req := httptest.NewRequest(http.MethodGet, "/admin/collections/dashboard", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), collectionJWTSecret, 2, domain.CustomerRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestCollectionHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectionHandlerTestSuite))
+}
@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	communicationhandler "github.com/fazamuttaqien/multifinance/internal/handler/communication"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CommunicationHandlerTestSuite struct {
+	suite.Suite
+	app                      *fiber.App
+	mockCommunicationService *mocks.MockCommunicationServices
+}
+
+func (suite *CommunicationHandlerTestSuite) SetupTest() {
+	suite.mockCommunicationService = mocks.NewMockCommunicationServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-communication-handler")
+	handler := communicationhandler.NewCommunicationHandler(suite.mockCommunicationService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers/:customerId/communications", handler.ListByCustomer)
+}
+
+func (suite *CommunicationHandlerTestSuite) TestListByCustomer() {
+	suite.Run("Success - Filtered", func() {
+		suite.mockCommunicationService.EXPECT().ListByCustomer(gomock.Any(), uint64(2), gomock.Any()).
+			DoAndReturn(func(_ any, _ uint64, params domain.Params) (*domain.Paginated, error) {
+				assert.Equal(suite.T(), "EMAIL", params.Value("channel"))
+				assert.Equal(suite.T(), "verification_reminder", params.Value("template"))
+				return params.Paginated([]domain.Communication{{ID: 1, CustomerID: 2, Channel: domain.ChannelEmail}}, 1), nil
+			})
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/2/communications?channel=email&template=verification_reminder", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data []domain.Communication
+		envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Len(suite.T(), data, 1)
+		suite.Require().NotNil(envelope.Meta.Pagination)
+		assert.Equal(suite.T(), int64(1), envelope.Meta.Pagination.Total)
+	})
+
+	suite.Run("Failure - Unknown Channel", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/2/communications?channel=fax", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockCommunicationService.EXPECT().ListByCustomer(gomock.Any(), uint64(9), gomock.Any()).Return(nil, common.ErrCustomerNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/9/communications", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCommunicationHandlerSuite(t *testing.T) {
+	suite.Run(t, new(CommunicationHandlerTestSuite))
+}
@@ -0,0 +1,157 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	concentrationhandler "github.com/fazamuttaqien/multifinance/internal/handler/concentration"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const concentrationJWTSecret = "test-secret-key"
+
+type ConcentrationHandlerTestSuite struct {
+	suite.Suite
+	app                      *fiber.App
+	mockConcentrationService *mocks.MockConcentrationServices
+	adminCookie              *http.Cookie
+}
+
+func (suite *ConcentrationHandlerTestSuite) SetupTest() {
+	suite.mockConcentrationService = mocks.NewMockConcentrationServices(gomock.NewController(suite.T()))
+	suite.adminCookie = testutil.AuthCookie(suite.T(), concentrationJWTSecret, 1, domain.AdminRole)
+
+	meter, tracer, log := testutil.Telemetry("test-concentration-handler")
+	handler := concentrationhandler.NewConcentrationHandler(suite.mockConcentrationService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(concentrationJWTSecret)
+	requireAdmin := middleware.RequireRole(domain.AdminRole)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/concentration-limits", jwtAuth, requireAdmin, handler.ListLimits)
+	suite.app.Post("/admin/concentration-limits", jwtAuth, requireAdmin, handler.CreateLimit)
+	suite.app.Get("/admin/concentration-limits/report", jwtAuth, requireAdmin, handler.Report)
+	suite.app.Put("/admin/concentration-limits/:id", jwtAuth, requireAdmin, handler.UpdateLimit)
+	suite.app.Delete("/admin/concentration-limits/:id", jwtAuth, requireAdmin, handler.DeleteLimit)
+}
+
+func (suite *ConcentrationHandlerTestSuite) send(method, path string, body map[string]any) *http.Response {
+	resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, method, path, body))
+	return resp
+}
+
+func (suite *ConcentrationHandlerTestSuite) TestCreateLimit() {
+	suite.Run("Success", func() {
+		suite.mockConcentrationService.EXPECT().CreateLimit(gomock.Any(), uint64(1), gomock.Any()).
+			Return(&domain.ConcentrationLimit{ID: 4, Dimension: domain.ConcentrationAssetCategory, Value: "motor", MaxPercent: decimal.NewFromInt(40), Enforce: true}, nil)
+
+		resp := suite.send(http.MethodPost, "/admin/concentration-limits", map[string]any{"dimension": "ASSET_CATEGORY", "value": "motor", "max_percent": "40", "enforce": true})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var body dto.ConcentrationLimitResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), "motor", body.Value)
+		assert.True(suite.T(), body.Enforce)
+	})
+
+	suite.Run("Failure - Percent Above 100", func() {
+		resp := suite.send(http.MethodPost, "/admin/concentration-limits", map[string]any{"dimension": "REGION", "value": "JKT", "max_percent": "120"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Dimension", func() {
+		resp := suite.send(http.MethodPost, "/admin/concentration-limits", map[string]any{"dimension": "DEALER", "value": "x", "max_percent": "20"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Region", func() {
+		suite.mockConcentrationService.EXPECT().CreateLimit(gomock.Any(), uint64(1), gomock.Any()).Return(nil, common.ErrRegionNotFound)
+
+		resp := suite.send(http.MethodPost, "/admin/concentration-limits", map[string]any{"dimension": "REGION", "value": "XXX", "max_percent": "20"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Exists", func() {
+		suite.mockConcentrationService.EXPECT().CreateLimit(gomock.Any(), uint64(1), gomock.Any()).Return(nil, common.ErrConcentrationExists)
+
+		resp := suite.send(http.MethodPost, "/admin/concentration-limits", map[string]any{"dimension": "REGION", "value": "JKT", "max_percent": "20"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *ConcentrationHandlerTestSuite) TestUpdateAndDeleteLimit() {
+	suite.Run("Success - Update", func() {
+		suite.mockConcentrationService.EXPECT().UpdateLimit(gomock.Any(), uint64(4), uint64(1), gomock.Any()).
+			Return(&domain.ConcentrationLimit{ID: 4, MaxPercent: decimal.NewFromInt(35)}, nil)
+
+		resp := suite.send(http.MethodPut, "/admin/concentration-limits/4", map[string]any{"max_percent": "35"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Update Not Found", func() {
+		suite.mockConcentrationService.EXPECT().UpdateLimit(gomock.Any(), uint64(9), uint64(1), gomock.Any()).Return(nil, common.ErrConcentrationNotFound)
+
+		resp := suite.send(http.MethodPut, "/admin/concentration-limits/9", map[string]any{"max_percent": "35"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Delete Not Found", func() {
+		suite.mockConcentrationService.EXPECT().DeleteLimit(gomock.Any(), uint64(9)).Return(common.ErrConcentrationNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/concentration-limits/9", nil)
+		req.AddCookie(suite.adminCookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *ConcentrationHandlerTestSuite) TestReport() {
+	limit := &domain.ConcentrationLimit{ID: 4, Dimension: domain.ConcentrationRegion, Value: "JKT", MaxPercent: decimal.NewFromInt(30), Enforce: true}
+	suite.mockConcentrationService.EXPECT().Report(gomock.Any(), gomock.Any()).Return(&domain.ConcentrationReport{
+		TotalPrincipal: decimal.NewFromInt(10_000_000_000),
+		MinPortfolio:   decimal.NewFromInt(1_000_000_000),
+		Concentrations: []domain.Concentration{
+			{Dimension: domain.ConcentrationAssetCategory, Value: "motor", Principal: decimal.NewFromInt(6_000_000_000), Percent: decimal.NewFromInt(60)},
+			{Dimension: domain.ConcentrationRegion, Value: "JKT", Principal: decimal.NewFromInt(3_500_000_000), Percent: decimal.NewFromInt(35), Limit: limit},
+		},
+		GeneratedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/concentration-limits/report", nil)
+	req.AddCookie(suite.adminCookie)
+	resp, _ := suite.app.Test(req)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var body dto.ConcentrationReportResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+	assert.True(suite.T(), body.Evaluated)
+	assert.Len(suite.T(), body.AssetCategories, 1)
+	assert.False(suite.T(), body.AssetCategories[0].Breached)
+	assert.Len(suite.T(), body.Regions, 1)
+	assert.True(suite.T(), body.Regions[0].Breached)
+}
+
+func TestConcentrationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConcentrationHandlerTestSuite))
+}
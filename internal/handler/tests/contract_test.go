@@ -0,0 +1,176 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	contracthandler "github.com/fazamuttaqien/multifinance/internal/handler/contract"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ContractHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockContractService *mocks.MockContractLookupServices
+}
+
+func (suite *ContractHandlerTestSuite) SetupTest() {
+	suite.mockContractService = mocks.NewMockContractLookupServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-contract-handler")
+	handler := contracthandler.NewContractLookupHandler(suite.mockContractService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/transactions/by-contract/:number", handler.FindByContractNumber)
+}
+
+func (suite *ContractHandlerTestSuite) TestFindByContractNumber() {
+	suite.Run("Success - Exact", func() {
+		suite.mockContractService.EXPECT().FindByContractNumber(gomock.Any(), "KTR-20250101-000001").
+			Return(&dto.ContractLookupResponse{
+				ContractNumber: "KTR-20250101-000001",
+				Customer:       dto.ContractCustomerResponse{ID: 2, FullName: "Budi"},
+				Installments:   dto.InstallmentSummaryResponse{TenorMonths: 6, PaidInstallments: 2},
+			}, nil)
+
+		// Nomor kontrak dinormalisasi ke huruf besar
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/by-contract/ktr-20250101-000001", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.ContractLookupResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "Budi", data.Customer.FullName)
+		assert.Equal(suite.T(), 2, data.Installments.PaidInstallments)
+	})
+
+	suite.Run("Failure - Exact Not Found", func() {
+		suite.mockContractService.EXPECT().FindByContractNumber(gomock.Any(), "KTR-404").Return(nil, common.ErrTransactionNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/by-contract/KTR-404", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Success - Prefix", func() {
+		suite.mockContractService.EXPECT().Search(gomock.Any(), "KTR-JKT", gomock.Any()).
+			DoAndReturn(func(_ any, _ string, params domain.Params) (*domain.Paginated, error) {
+				assert.Equal(suite.T(), 5, params.Limit)
+				return params.Paginated([]dto.ContractLookupResponse{
+					{ContractNumber: "KTR-JKT-20250101-000001"},
+					{ContractNumber: "KTR-JKT-20250101-000002"},
+				}, 2), nil
+			})
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/by-contract/KTR-JKT?mode=prefix&limit=5", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data []dto.ContractLookupResponse
+		envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		suite.Require().Len(data, 2)
+		suite.Require().NotNil(envelope.Meta.Pagination)
+		assert.Equal(suite.T(), int64(2), envelope.Meta.Pagination.Total)
+	})
+
+	suite.Run("Failure - Prefix Too Short", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/by-contract/KT?mode=prefix", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Mode", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/by-contract/KTR?mode=like", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestContractHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ContractHandlerTestSuite))
+}
+
+type ContractImportHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockImportService *mocks.MockContractImportServices
+}
+
+func (suite *ContractImportHandlerTestSuite) SetupTest() {
+	suite.mockImportService = mocks.NewMockContractImportServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-contract-import-handler")
+	handler := contracthandler.NewContractImportHandler(suite.mockImportService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Post("/admin/transactions/import", handler.ImportContracts)
+}
+
+func (suite *ContractImportHandlerTestSuite) TestImportContracts() {
+	body := map[string]interface{}{
+		"contracts": []map[string]interface{}{{
+			"contract_number":          "KTR-OLD-000001",
+			"customer_nik":             "1234567890123456",
+			"tenor_months":             6,
+			"asset_name":               "Honda Vario",
+			"otr_amount":               "20000000",
+			"admin_fee":                "500000",
+			"total_interest":           "2400000",
+			"total_installment_amount": "22900000",
+			"status":                   "ACTIVE",
+			"transaction_date":         "2024-03-15",
+		}},
+	}
+
+	suite.Run("Success", func() {
+		suite.mockImportService.EXPECT().Import(gomock.Any(), gomock.Any()).Return(&dto.ContractImportResponse{
+			Imported:  1,
+			Contracts: []dto.ImportedContractResponse{{ID: 9, ContractNumber: "KTR-OLD-000001", CustomerID: 2}},
+		}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/transactions/import", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var data dto.ContractImportResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), 1, data.Imported)
+		assert.Equal(suite.T(), uint64(9), data.Contracts[0].ID)
+	})
+
+	suite.Run("Failure - Validation", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/transactions/import", map[string]interface{}{"contracts": []interface{}{}}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Contract Number Taken", func() {
+		suite.mockImportService.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil, common.ErrDuplicateContract)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/transactions/import", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Customer", func() {
+		suite.mockImportService.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil, common.ErrCustomerNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/transactions/import", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestContractImportHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ContractImportHandlerTestSuite))
+}
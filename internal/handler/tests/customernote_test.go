@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	customernotehandler "github.com/fazamuttaqien/multifinance/internal/handler/customernote"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const customerNoteJWTSecret = "test-secret-key"
+
+type CustomerNoteHandlerTestSuite struct {
+	suite.Suite
+	app             *fiber.App
+	mockNoteService *mocks.MockCustomerNoteServices
+}
+
+func (suite *CustomerNoteHandlerTestSuite) SetupTest() {
+	suite.mockNoteService = mocks.NewMockCustomerNoteServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-customer-note-handler")
+	handler := customernotehandler.NewCustomerNoteHandler(suite.mockNoteService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(customerNoteJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers/:customerId/notes", handler.ListNotes)
+	suite.app.Post("/admin/customers/:customerId/notes", jwtAuth, handler.CreateNote)
+	suite.app.Put("/admin/customers/:customerId/notes/:noteId", jwtAuth, handler.UpdateNote)
+}
+
+func (suite *CustomerNoteHandlerTestSuite) TestListNotes() {
+	suite.Run("Success - With Revisions", func() {
+		suite.mockNoteService.EXPECT().ListNotes(gomock.Any(), uint64(7)).Return([]domain.CustomerNote{
+			{
+				ID: 3, CustomerID: 7, AuthorID: 1, Body: "Prefers WhatsApp", Pinned: true, UpdatedBy: 2,
+				Revisions: []domain.CustomerNoteRevision{{ID: 9, NoteID: 3, Body: "Prefers email", UpdatedBy: 1, UpdatedAt: time.Now()}},
+			},
+		}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/7/notes", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body []dto.CustomerNoteResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		if assert.Len(suite.T(), body, 1) {
+			assert.True(suite.T(), body[0].Pinned)
+			if assert.Len(suite.T(), body[0].Revisions, 1) {
+				assert.Equal(suite.T(), "Prefers email", body[0].Revisions[0].Body)
+			}
+		}
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockNoteService.EXPECT().ListNotes(gomock.Any(), uint64(99)).Return(nil, common.ErrCustomerNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/99/notes", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *CustomerNoteHandlerTestSuite) TestCreateNote() {
+	adminCookie := testutil.AuthCookie(suite.T(), customerNoteJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		suite.mockNoteService.EXPECT().CreateNote(gomock.Any(), uint64(7), uint64(1), dto.CustomerNoteRequest{Body: "Called about late payment", Pinned: true}).
+			Return(&domain.CustomerNote{ID: 3, CustomerID: 7, AuthorID: 1, Body: "Called about late payment", Pinned: true, UpdatedBy: 1}, nil)
+
+		body := map[string]any{"body": "Called about late payment", "pinned": true}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/notes", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Empty Body", func() {
+		body := map[string]any{"body": "", "pinned": false}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/notes", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *CustomerNoteHandlerTestSuite) TestUpdateNote() {
+	adminCookie := testutil.AuthCookie(suite.T(), customerNoteJWTSecret, 2, domain.AdminRole)
+
+	suite.Run("Failure - Note Of Another Customer", func() {
+		suite.mockNoteService.EXPECT().UpdateNote(gomock.Any(), uint64(7), uint64(3), uint64(2), gomock.Any()).Return(nil, common.ErrCustomerNoteNotFound)
+
+		body := map[string]any{"body": "Updated", "pinned": false}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/customers/7/notes/3", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCustomerNoteHandlerSuite(t *testing.T) {
+	suite.Run(t, new(CustomerNoteHandlerTestSuite))
+}
@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	dashboardhandler "github.com/fazamuttaqien/multifinance/internal/handler/dashboard"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const dashboardJWTSecret = "test-secret-key"
+
+type DashboardHandlerTestSuite struct {
+	suite.Suite
+	app                  *fiber.App
+	mockDashboardService *mocks.MockDashboardServices
+}
+
+func (suite *DashboardHandlerTestSuite) SetupTest() {
+	suite.mockDashboardService = mocks.NewMockDashboardServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-dashboard-handler")
+	handler := dashboardhandler.NewDashboardHandler(suite.mockDashboardService, time.Minute, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/dashboard/stream",
+		middleware.NewJWTAuthMiddleware(dashboardJWTSecret),
+		middleware.RequireRole(domain.AdminRole),
+		handler.Stream,
+	)
+}
+
+func (suite *DashboardHandlerTestSuite) get(role domain.Role) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, "/admin/dashboard/stream", nil)
+	req.AddCookie(testutil.AuthCookie(suite.T(), dashboardJWTSecret, 1, role))
+
+	resp, err := suite.app.Test(req, -1)
+	require.NoError(suite.T(), err)
+	return resp
+}
+
+func (suite *DashboardHandlerTestSuite) TestStream() {
+	suite.Run("Success - Snapshots Until Closed", func() {
+		updates := make(chan domain.DashboardSnapshot, 2)
+		updates <- domain.DashboardSnapshot{
+			Registrations:         []domain.CustomerEvent{{ID: 42, CustomerID: 7, OccurredAt: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)}},
+			VerificationQueue:     5,
+			TransactionsPerMinute: 12,
+		}
+		updates <- domain.DashboardSnapshot{VerificationQueue: 4}
+		close(updates)
+
+		unsubscribed := false
+		suite.mockDashboardService.EXPECT().Subscribe(gomock.Any()).Return((<-chan domain.DashboardSnapshot)(updates), func() { unsubscribed = true })
+
+		resp := suite.get(domain.AdminRole)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "text/event-stream", resp.Header.Get(fiber.HeaderContentType))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), unsubscribed)
+
+		frames := strings.Split(strings.TrimSpace(string(body)), "\n\n")
+		require.Len(suite.T(), frames, 3)
+		assert.Equal(suite.T(), "retry: 5000", frames[0])
+
+		lines := strings.SplitN(frames[1], "\n", 2)
+		assert.Equal(suite.T(), "event: snapshot", lines[0])
+		var snapshot dto.DashboardSnapshotResponse
+		require.NoError(suite.T(), json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &snapshot))
+		require.Len(suite.T(), snapshot.Registrations, 1)
+		assert.Equal(suite.T(), uint64(7), snapshot.Registrations[0].CustomerID)
+		assert.Equal(suite.T(), int64(5), snapshot.VerificationQueue)
+		assert.Equal(suite.T(), int64(12), snapshot.TransactionsPerMinute)
+	})
+
+	suite.Run("Failure - Not Admin", func() {
+		resp := suite.get(domain.CustomerRole)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestDashboardHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DashboardHandlerTestSuite))
+}
@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	deliveryhandler "github.com/fazamuttaqien/multifinance/internal/handler/delivery"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type DeliveryHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockDeliveryService *mocks.MockDeliveryServices
+}
+
+func (suite *DeliveryHandlerTestSuite) SetupTest() {
+	suite.mockDeliveryService = mocks.NewMockDeliveryServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-delivery-handler")
+	handler := deliveryhandler.NewDeliveryHandler(suite.mockDeliveryService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/deliveries", handler.ListDeliveries)
+	suite.app.Post("/admin/deliveries/:id/replay", handler.Replay)
+}
+
+func (suite *DeliveryHandlerTestSuite) TestListDeliveries() {
+	suite.Run("Success - Failed Filter", func() {
+		suite.mockDeliveryService.EXPECT().
+			ListDeliveries(gomock.Any(), domain.Params{Filters: []query.Condition{{Column: "status", Value: "FAILED"}}, Page: 1, Limit: 10}).
+			Return(&domain.Paginated{Data: []domain.WebhookDelivery{{ID: 1}}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/deliveries?status=failed", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Success - Sorted And Limit Clamped", func() {
+		suite.mockDeliveryService.EXPECT().
+			ListDeliveries(gomock.Any(), domain.Params{Sort: []query.Order{{Column: "attempts", Desc: true}}, Page: 2, Limit: 100}).
+			Return(&domain.Paginated{Data: []domain.WebhookDelivery{}, Page: 2, Limit: 100}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/deliveries?sort=-attempts&page=2&limit=500", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Status", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/deliveries?status=lost", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Sort", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/deliveries?sort=payload", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *DeliveryHandlerTestSuite) TestReplay() {
+	suite.Run("Success", func() {
+		suite.mockDeliveryService.EXPECT().Replay(gomock.Any(), uint64(5)).
+			Return(&domain.WebhookDelivery{ID: 5, Status: domain.DeliveryDelivered}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/admin/deliveries/5/replay", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Poisoned", func() {
+		suite.mockDeliveryService.EXPECT().Replay(gomock.Any(), uint64(6)).Return(nil, common.ErrDeliveryPoisoned)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/admin/deliveries/6/replay", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockDeliveryService.EXPECT().Replay(gomock.Any(), uint64(7)).Return(nil, common.ErrDeliveryNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/admin/deliveries/7/replay", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestDeliveryHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryHandlerTestSuite))
+}
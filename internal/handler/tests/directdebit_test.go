@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	directdebithandler "github.com/fazamuttaqien/multifinance/internal/handler/directdebit"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const directDebitJWTSecret = "test-secret-key"
+
+type DirectDebitHandlerTestSuite struct {
+	suite.Suite
+	app                    *fiber.App
+	mockDirectDebitService *mocks.MockDirectDebitServices
+	customerCookie         *http.Cookie
+}
+
+func (suite *DirectDebitHandlerTestSuite) SetupTest() {
+	suite.mockDirectDebitService = mocks.NewMockDirectDebitServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-direct-debit-handler")
+	handler := directdebithandler.NewDirectDebitHandler(suite.mockDirectDebitService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(directDebitJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/me/direct-debit", jwtAuth, handler.GetMandate)
+	suite.app.Put("/me/direct-debit", jwtAuth, handler.SetMandate)
+	suite.app.Delete("/me/direct-debit", jwtAuth, handler.DeleteMandate)
+
+	suite.customerCookie = testutil.AuthCookie(suite.T(), directDebitJWTSecret, 2, domain.CustomerRole)
+}
+
+func (suite *DirectDebitHandlerTestSuite) TestGetMandate() {
+	suite.Run("Failure - Not Enabled", func() {
+		suite.mockDirectDebitService.EXPECT().GetMandate(gomock.Any(), uint64(2)).Return(nil, common.ErrDirectDebitNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/direct-debit", nil)
+		req.AddCookie(suite.customerCookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *DirectDebitHandlerTestSuite) TestSetMandate() {
+	suite.Run("Success", func() {
+		req := dto.DirectDebitMandateRequest{Provider: "stub", Token: "tok_1234567890"}
+		suite.mockDirectDebitService.EXPECT().SetMandate(gomock.Any(), uint64(2), req).
+			Return(&domain.DirectDebitMandate{CustomerID: 2, Provider: "stub", Token: "tok_1234567890"}, nil)
+
+		body := map[string]any{"provider": "stub", "token": "tok_1234567890"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.customerCookie}, http.MethodPut, "/me/direct-debit", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Provider", func() {
+		suite.mockDirectDebitService.EXPECT().SetMandate(gomock.Any(), uint64(2), gomock.Any()).Return(nil, common.ErrUnknownPaymentProvider)
+
+		body := map[string]any{"provider": "bank-x", "token": "tok_1234567890"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.customerCookie}, http.MethodPut, "/me/direct-debit", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Token", func() {
+		body := map[string]any{"provider": "stub"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.customerCookie}, http.MethodPut, "/me/direct-debit", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *DirectDebitHandlerTestSuite) TestDeleteMandate() {
+	suite.Run("Success", func() {
+		suite.mockDirectDebitService.EXPECT().DeleteMandate(gomock.Any(), uint64(2)).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/me/direct-debit", nil)
+		req.AddCookie(suite.customerCookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestDirectDebitHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DirectDebitHandlerTestSuite))
+}
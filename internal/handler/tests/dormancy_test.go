@@ -0,0 +1,118 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	dormancyhandler "github.com/fazamuttaqien/multifinance/internal/handler/dormancy"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const dormancyJWTSecret = "test-secret-key"
+
+type DormancyHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockDormancyService *mocks.MockDormancyServices
+}
+
+func (suite *DormancyHandlerTestSuite) SetupTest() {
+	suite.mockDormancyService = mocks.NewMockDormancyServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-dormancy-handler")
+	handler := dormancyhandler.NewDormancyHandler(suite.mockDormancyService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(dormancyJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Post("/me/reactivate", jwtAuth, handler.Reactivate)
+	suite.app.Get("/admin/reports/dormancy", handler.Stats)
+}
+
+func (suite *DormancyHandlerTestSuite) TestReactivate() {
+	customerCookie := testutil.AuthCookie(suite.T(), dormancyJWTSecret, 7, domain.CustomerRole)
+
+	suite.Run("Success", func() {
+		suite.mockDormancyService.EXPECT().Reactivate(gomock.Any(), uint64(7), dto.ReactivationRequest{BirthDate: "1990-04-12", OTPToken: "token"}).Return(nil)
+
+		body := map[string]any{"birth_date": "1990-04-12", "otp_token": "token"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/reactivate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Birth Date", func() {
+		body := map[string]any{"birth_date": "12-04-1990", "otp_token": "token"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/reactivate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Identity Mismatch", func() {
+		suite.mockDormancyService.EXPECT().Reactivate(gomock.Any(), uint64(7), gomock.Any()).Return(common.ErrIdentityMismatch)
+
+		body := map[string]any{"birth_date": "1990-12-04", "otp_token": "token"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/reactivate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Dormant", func() {
+		suite.mockDormancyService.EXPECT().Reactivate(gomock.Any(), uint64(7), gomock.Any()).Return(common.ErrCustomerNotDormant)
+
+		body := map[string]any{"birth_date": "1990-04-12", "otp_token": "token"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/reactivate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - OTP Not On File", func() {
+		suite.mockDormancyService.EXPECT().Reactivate(gomock.Any(), uint64(7), gomock.Any()).Return(common.ErrContactNotVerified)
+
+		body := map[string]any{"birth_date": "1990-04-12", "otp_token": "token"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/me/reactivate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func (suite *DormancyHandlerTestSuite) TestStats() {
+	suite.Run("Success - Default Window", func() {
+		since := time.Date(2025, 5, 31, 9, 0, 0, 0, time.UTC)
+		suite.mockDormancyService.EXPECT().Stats(gomock.Any(), 30, gomock.Any()).
+			Return(&domain.DormancyStats{Dormant: 6, Since: since, Flagged: 4, Reactivated: 1}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/dormancy", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.DormancyStatsResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), int64(6), data.Dormant)
+		assert.Equal(suite.T(), 30, data.Days)
+		assert.Equal(suite.T(), int64(4), data.Flagged)
+	})
+
+	suite.Run("Failure - Range Too Long", func() {
+		suite.mockDormancyService.EXPECT().Stats(gomock.Any(), 400, gomock.Any()).Return(nil, common.ErrInvalidDormancyDays)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/dormancy?days=400", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDormancyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DormancyHandlerTestSuite))
+}
@@ -0,0 +1,148 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	duedatehandler "github.com/fazamuttaqien/multifinance/internal/handler/duedate"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const dueDateJWTSecret = "test-secret-key"
+
+type DueDateHandlerTestSuite struct {
+	suite.Suite
+	app                *fiber.App
+	mockDueDateService *mocks.MockDueDateServices
+}
+
+func (suite *DueDateHandlerTestSuite) SetupTest() {
+	suite.mockDueDateService = mocks.NewMockDueDateServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-due-date-handler")
+	handler := duedatehandler.NewDueDateHandler(suite.mockDueDateService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(dueDateJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/due-dates/policy", handler.GetPolicy)
+	suite.app.Put("/admin/due-dates/policy", jwtAuth, handler.SetPolicy)
+	suite.app.Get("/admin/due-dates/holidays", handler.ListHolidays)
+	suite.app.Put("/admin/due-dates/holidays/:date", jwtAuth, handler.SetHoliday)
+	suite.app.Delete("/admin/due-dates/holidays/:date", handler.DeleteHoliday)
+}
+
+func (suite *DueDateHandlerTestSuite) TestListHolidays() {
+	suite.Run("Success - Requested Year", func() {
+		suite.mockDueDateService.EXPECT().ListHolidays(gomock.Any(), 2025).
+			Return([]domain.Holiday{{Date: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), Name: "Idul Fitri"}}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/due-dates/holidays?year=2025", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data []dto.HolidayResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), []dto.HolidayResponse{{Date: "2025-03-31", Name: "Idul Fitri"}}, data)
+	})
+
+	suite.Run("Failure - Invalid Year", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/due-dates/holidays?year=twenty", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *DueDateHandlerTestSuite) TestSetHoliday() {
+	adminCookie := testutil.AuthCookie(suite.T(), dueDateJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		suite.mockDueDateService.EXPECT().SetHoliday(gomock.Any(), "2025-03-31", uint64(1), dto.HolidayRequest{Name: "Idul Fitri"}).
+			Return(&domain.Holiday{Date: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), Name: "Idul Fitri", UpdatedBy: 1}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/due-dates/holidays/2025-03-31", map[string]any{"name": "Idul Fitri"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Date", func() {
+		suite.mockDueDateService.EXPECT().SetHoliday(gomock.Any(), "31-03-2025", uint64(1), gomock.Any()).
+			Return(nil, common.ErrInvalidHolidayDate)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/due-dates/holidays/31-03-2025", map[string]any{"name": "Idul Fitri"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Name", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/due-dates/holidays/2025-03-31", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *DueDateHandlerTestSuite) TestDeleteHoliday() {
+	suite.mockDueDateService.EXPECT().DeleteHoliday(gomock.Any(), "2025-03-31").Return(common.ErrHolidayNotFound)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/due-dates/holidays/2025-03-31", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *DueDateHandlerTestSuite) TestPolicy() {
+	adminCookie := testutil.AuthCookie(suite.T(), dueDateJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Get - Default Policy", func() {
+		suite.mockDueDateService.EXPECT().GetPolicy(gomock.Any()).Return(&domain.DueDatePolicy{Shift: installment.ShiftNone}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/due-dates/policy", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.DueDatePolicyResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), installment.ShiftNone, data.Shift)
+		assert.Nil(suite.T(), data.UpdatedAt)
+	})
+
+	suite.Run("Set - Success", func() {
+		req := dto.DueDatePolicyRequest{Shift: installment.ShiftNext, GraceDays: 3}
+		suite.mockDueDateService.EXPECT().SetPolicy(gomock.Any(), uint64(1), req).
+			Return(&domain.DueDatePolicy{Shift: installment.ShiftNext, GraceDays: 3, UpdatedBy: 1, UpdatedAt: time.Now()}, nil)
+
+		body := map[string]any{"shift": "NEXT_BUSINESS_DAY", "grace_days": 3}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/due-dates/policy", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Set - Unknown Shift", func() {
+		body := map[string]any{"shift": "NEAREST_BUSINESS_DAY"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/due-dates/policy", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Set - Grace Period Too Long", func() {
+		body := map[string]any{"shift": "NONE", "grace_days": 45}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/due-dates/policy", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDueDateHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DueDateHandlerTestSuite))
+}
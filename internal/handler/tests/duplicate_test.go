@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	duplicatehandler "github.com/fazamuttaqien/multifinance/internal/handler/duplicate"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const duplicateJWTSecret = "test-secret-key"
+
+type DuplicateHandlerTestSuite struct {
+	suite.Suite
+	app                  *fiber.App
+	mockDuplicateService *mocks.MockDuplicateServices
+}
+
+func (suite *DuplicateHandlerTestSuite) SetupTest() {
+	suite.mockDuplicateService = mocks.NewMockDuplicateServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-duplicate-handler")
+	handler := duplicatehandler.NewDuplicateHandler(suite.mockDuplicateService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(duplicateJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers/:customerId/possible-duplicates", handler.PossibleDuplicates)
+	suite.app.Post("/admin/customers/:customerId/possible-duplicates/:duplicateId/resolve", jwtAuth, handler.Resolve)
+}
+
+func (suite *DuplicateHandlerTestSuite) TestPossibleDuplicates() {
+	suite.Run("Success", func() {
+		suite.mockDuplicateService.EXPECT().FindPossibleDuplicates(gomock.Any(), uint64(1)).
+			Return([]dto.DuplicateCandidateResponse{{CustomerID: 2, LegalName: "Mohammad Jusuf", Score: 0.91}}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/1/possible-duplicates", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var candidates []dto.DuplicateCandidateResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &candidates)
+		suite.Require().Len(candidates, 1)
+		assert.Equal(suite.T(), uint64(2), candidates[0].CustomerID)
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockDuplicateService.EXPECT().FindPossibleDuplicates(gomock.Any(), uint64(99)).
+			Return(nil, common.ErrCustomerNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/99/possible-duplicates", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *DuplicateHandlerTestSuite) TestResolve() {
+	adminCookie := testutil.AuthCookie(suite.T(), duplicateJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success - Merged", func() {
+		suite.mockDuplicateService.EXPECT().
+			Resolve(gomock.Any(), uint64(3), uint64(4), uint64(1), dto.DuplicateResolutionRequest{Action: domain.DuplicateMerged, Note: "same person"}).
+			Return(&domain.DuplicateResolution{ID: 1, CustomerID: 3, DuplicateID: 4, Action: domain.DuplicateMerged}, nil)
+
+		body := map[string]any{"action": "MERGED", "note": "same person"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/3/possible-duplicates/4/resolve", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data map[string]any
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), float64(4), data["duplicate_id"])
+		assert.Equal(suite.T(), "MERGED", data["action"])
+	})
+
+	suite.Run("Failure - Invalid Action", func() {
+		body := map[string]any{"action": "DELETED"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/3/possible-duplicates/4/resolve", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Resolved", func() {
+		suite.mockDuplicateService.EXPECT().Resolve(gomock.Any(), uint64(3), uint64(5), uint64(1), gomock.Any()).
+			Return(nil, common.ErrDuplicateResolved)
+
+		body := map[string]any{"action": "IGNORED"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/3/possible-duplicates/5/resolve", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestDuplicateHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DuplicateHandlerTestSuite))
+}
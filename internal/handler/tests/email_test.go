@@ -0,0 +1,84 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	emailhandler "github.com/fazamuttaqien/multifinance/internal/handler/email"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const emailJWTSecret = "test-secret-key"
+
+type EmailHandlerTestSuite struct {
+	suite.Suite
+	app              *fiber.App
+	mockEmailService *mocks.MockEmailServices
+}
+
+func (suite *EmailHandlerTestSuite) SetupTest() {
+	suite.mockEmailService = mocks.NewMockEmailServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-email-handler")
+	handler := emailhandler.NewEmailHandler(suite.mockEmailService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(emailJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Post("/admin/email-templates/:template/preview", jwtAuth, middleware.RequireRole(domain.AdminRole), handler.PreviewTemplate)
+}
+
+func (suite *EmailHandlerTestSuite) TestPreviewTemplate() {
+	adminCookie := testutil.AuthCookie(suite.T(), emailJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		req := dto.EmailPreviewRequest{Language: "en", Data: map[string]string{"full_name": "Siti"}}
+		suite.mockEmailService.EXPECT().PreviewTemplate(gomock.Any(), domain.TemplateAccountClosed, req).
+			Return(&domain.RenderedEmail{Template: domain.TemplateAccountClosed, Language: domain.LanguageEnglish, Subject: "Your account has been closed", Body: "Hi Siti,"}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/email-templates/account_closed/preview", map[string]any{"language": "en", "data": map[string]string{"full_name": "Siti"}}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.EmailPreviewResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "account_closed", data.Template)
+		assert.Equal(suite.T(), "Your account has been closed", data.Subject)
+	})
+
+	suite.Run("Failure - Unsupported Language", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/email-templates/account_closed/preview", map[string]any{"language": "fr"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Template", func() {
+		suite.mockEmailService.EXPECT().PreviewTemplate(gomock.Any(), domain.NotificationTemplate("password_reset"), gomock.Any()).
+			Return(nil, common.ErrEmailTemplateNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/email-templates/password_reset/preview", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Token", func() {
+		customerCookie := testutil.AuthCookie(suite.T(), emailJWTSecret, 7, domain.CustomerRole)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/admin/email-templates/account_closed/preview", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestEmailHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailHandlerTestSuite))
+}
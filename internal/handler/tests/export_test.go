@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	exporthandler "github.com/fazamuttaqien/multifinance/internal/handler/export"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ExportHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockExportService *mocks.MockExportServices
+}
+
+func (suite *ExportHandlerTestSuite) SetupTest() {
+	suite.mockExportService = mocks.NewMockExportServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-export-handler")
+	handler := exporthandler.NewExportHandler(suite.mockExportService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers/export", handler.ExportCustomers)
+	suite.app.Get("/admin/transactions/export", handler.ExportTransactions)
+}
+
+func (suite *ExportHandlerTestSuite) TestExportCustomers() {
+	suite.Run("Success - Streamed CSV", func() {
+		suite.mockExportService.EXPECT().ExportCustomers(gomock.Any(), gomock.Any(), domain.VerificationPending).
+			DoAndReturn(func(_ context.Context, w io.Writer, _ domain.VerificationStatus) (int, error) {
+				_, err := io.WriteString(w, "id,nik\n1,1234567890123456\n")
+				return 1, err
+			})
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/export?status=pending", nil))
+		defer resp.Body.Close()
+		require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.Contains(suite.T(), resp.Header.Get("Content-Disposition"), "customers-pending.csv")
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), [][]string{{"id", "nik"}, {"1", "1234567890123456"}}, records)
+	})
+
+	suite.Run("Failure - Unknown Status", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/export?status=banned", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *ExportHandlerTestSuite) TestExportTransactions() {
+	suite.Run("Success - Inclusive Range", func() {
+		from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		suite.mockExportService.EXPECT().ExportTransactions(gomock.Any(), gomock.Any(), from, to).
+			DoAndReturn(func(_ context.Context, w io.Writer, _, _ time.Time) (int, error) {
+				_, err := io.WriteString(w, "id,contract_number\n")
+				return 0, err
+			})
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/export?from=2026-09-01&to=2026-09-30", nil))
+		defer resp.Body.Close()
+		require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Contains(suite.T(), resp.Header.Get("Content-Disposition"), "transactions-2026-09-01-2026-09-30.csv")
+	})
+
+	suite.Run("Success - Failure Midway Truncates The File", func() {
+		suite.mockExportService.EXPECT().ExportTransactions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, w io.Writer, _, _ time.Time) (int, error) {
+				_, _ = io.WriteString(w, "id,contract_number\n")
+				return 0, errors.New("connection lost")
+			})
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/export?from=2026-09-01&to=2026-09-30", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Range", func() {
+		for _, query := range []string{"from=2026-09-30&to=2026-09-01", "from=2025-01-01&to=2026-09-01", "from=09/01/2026&to=2026-09-30", "to=2026-09-30"} {
+			resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/transactions/export?"+query, nil))
+			resp.Body.Close()
+			assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
+
+func TestExportHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ExportHandlerTestSuite))
+}
@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	featureflaghandler "github.com/fazamuttaqien/multifinance/internal/handler/featureflag"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const featureFlagJWTSecret = "test-secret-key"
+
+type FeatureFlagHandlerTestSuite struct {
+	suite.Suite
+	app                    *fiber.App
+	mockFeatureFlagService *mocks.MockFeatureFlagServices
+}
+
+func (suite *FeatureFlagHandlerTestSuite) SetupTest() {
+	suite.mockFeatureFlagService = mocks.NewMockFeatureFlagServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-feature-flag-handler")
+	handler := featureflaghandler.NewFeatureFlagHandler(suite.mockFeatureFlagService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(featureFlagJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/feature-flags", handler.ListFlags)
+	suite.app.Put("/admin/feature-flags/:key", jwtAuth, handler.SetFlag)
+	suite.app.Delete("/admin/feature-flags/:key", handler.DeleteFlag)
+}
+
+func (suite *FeatureFlagHandlerTestSuite) TestListFlags() {
+	suite.mockFeatureFlagService.EXPECT().ListFlags(gomock.Any()).
+		Return([]domain.FeatureFlag{{Key: "optimistic_limits", Enabled: true}}, nil)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/feature-flags", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data []map[string]any
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	suite.Require().Len(data, 1)
+	assert.Equal(suite.T(), "optimistic_limits", data[0]["key"])
+	assert.Equal(suite.T(), true, data[0]["enabled"])
+	assert.Equal(suite.T(), []any{}, data[0]["partner_ids"])
+}
+
+func (suite *FeatureFlagHandlerTestSuite) TestSetFlag() {
+	adminCookie := testutil.AuthCookie(suite.T(), featureFlagJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success - Partner Pilot", func() {
+		req := dto.FeatureFlagRequest{Description: "New limit algorithm", PartnerIDs: []uint64{7}}
+		suite.mockFeatureFlagService.EXPECT().SetFlag(gomock.Any(), "optimistic_limits", uint64(1), req).
+			Return(&domain.FeatureFlag{Key: "optimistic_limits", PartnerIDs: []uint64{7}}, nil)
+
+		body := map[string]any{"description": "New limit algorithm", "enabled": false, "partner_ids": []uint64{7}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/feature-flags/optimistic_limits", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.FeatureFlagResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "optimistic_limits", data.Key)
+		assert.Equal(suite.T(), []uint64{7}, data.PartnerIDs)
+	})
+
+	suite.Run("Failure - Invalid Key", func() {
+		suite.mockFeatureFlagService.EXPECT().SetFlag(gomock.Any(), "Optimistic-Limits", uint64(1), gomock.Any()).
+			Return(nil, common.ErrInvalidFeatureFlag)
+
+		body := map[string]any{"enabled": true}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/feature-flags/Optimistic-Limits", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Partner ID", func() {
+		body := map[string]any{"enabled": true, "partner_ids": []int{0}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/feature-flags/optimistic_limits", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *FeatureFlagHandlerTestSuite) TestDeleteFlag() {
+	suite.Run("Failure - Not Found", func() {
+		suite.mockFeatureFlagService.EXPECT().DeleteFlag(gomock.Any(), "unknown_flag").Return(common.ErrFeatureFlagNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/feature-flags/unknown_flag", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestFeatureFlagHandlerSuite(t *testing.T) {
+	suite.Run(t, new(FeatureFlagHandlerTestSuite))
+}
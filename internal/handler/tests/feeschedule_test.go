@@ -0,0 +1,134 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	feeschedulehandler "github.com/fazamuttaqien/multifinance/internal/handler/feeschedule"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const feeScheduleJWTSecret = "test-secret-key"
+
+type FeeScheduleHandlerTestSuite struct {
+	suite.Suite
+	app                    *fiber.App
+	mockFeeScheduleService *mocks.MockFeeScheduleServices
+}
+
+func (suite *FeeScheduleHandlerTestSuite) SetupTest() {
+	suite.mockFeeScheduleService = mocks.NewMockFeeScheduleServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-fee-schedule-handler")
+	handler := feeschedulehandler.NewFeeScheduleHandler(suite.mockFeeScheduleService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(feeScheduleJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/partners/:partnerId/fee-schedules", handler.ListSchedules)
+	suite.app.Put("/admin/partners/:partnerId/fee-schedules", jwtAuth, handler.SetSchedule)
+	suite.app.Delete("/admin/partners/:partnerId/fee-schedules/:tenorMonths", handler.DeleteSchedule)
+}
+
+func (suite *FeeScheduleHandlerTestSuite) TestListSchedules() {
+	suite.Run("Success", func() {
+		suite.mockFeeScheduleService.EXPECT().ListSchedules(gomock.Any(), uint64(7)).
+			Return([]domain.PartnerFeeSchedule{{PartnerID: 7, AdminFeeFlat: decimal.NewFromInt(250000)}}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/partners/7/fee-schedules", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data []map[string]any
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		suite.Require().Len(data, 1)
+		assert.Equal(suite.T(), float64(7), data[0]["partner_id"])
+		assert.Equal(suite.T(), "250000", data[0]["admin_fee_flat"])
+		assert.Contains(suite.T(), data[0], "commission_rate")
+	})
+
+	suite.Run("Failure - Partner Not Found", func() {
+		suite.mockFeeScheduleService.EXPECT().ListSchedules(gomock.Any(), uint64(99)).Return(nil, common.ErrPartnerNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/partners/99/fee-schedules", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *FeeScheduleHandlerTestSuite) TestSetSchedule() {
+	adminCookie := testutil.AuthCookie(suite.T(), feeScheduleJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		suite.mockFeeScheduleService.EXPECT().SetSchedule(gomock.Any(), uint64(7), uint64(1), gomock.Any()).
+			DoAndReturn(func(_ any, partnerID, _ uint64, req dto.FeeScheduleRequest) (*domain.PartnerFeeSchedule, error) {
+				assert.Equal(suite.T(), uint8(12), req.TenorMonths)
+				assert.Equal(suite.T(), "0.015", req.CommissionRate.String())
+				return &domain.PartnerFeeSchedule{PartnerID: partnerID, TenorMonths: req.TenorMonths, CommissionRate: req.CommissionRate}, nil
+			})
+
+		body := map[string]any{"tenor_months": 12, "admin_fee_flat": "250000", "admin_fee_rate": "0.01", "commission_rate": "0.015"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/partners/7/fee-schedules", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PartnerFeeScheduleResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), uint64(7), data.PartnerID)
+		assert.Equal(suite.T(), uint8(12), data.TenorMonths)
+		assert.Equal(suite.T(), "0.015", data.CommissionRate.String())
+	})
+
+	suite.Run("Failure - Rate Above One", func() {
+		body := map[string]any{"admin_fee_rate": "1.5"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/partners/7/fee-schedules", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Negative Flat Fee", func() {
+		body := map[string]any{"admin_fee_flat": "-1"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/partners/7/fee-schedules", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *FeeScheduleHandlerTestSuite) TestDeleteSchedule() {
+	suite.Run("Success", func() {
+		suite.mockFeeScheduleService.EXPECT().DeleteSchedule(gomock.Any(), uint64(7), uint8(0)).Return(nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/partners/7/fee-schedules/0", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockFeeScheduleService.EXPECT().DeleteSchedule(gomock.Any(), uint64(7), uint8(3)).Return(common.ErrFeeScheduleNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/partners/7/fee-schedules/3", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Tenor", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/partners/7/fee-schedules/300", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestFeeScheduleHandlerSuite(t *testing.T) {
+	suite.Run(t, new(FeeScheduleHandlerTestSuite))
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	fxratehandler "github.com/fazamuttaqien/multifinance/internal/handler/fxrate"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const fxRateJWTSecret = "test-secret-key"
+
+type FxRateHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockFxRateService *mocks.MockFxRateServices
+}
+
+func (suite *FxRateHandlerTestSuite) SetupTest() {
+	suite.mockFxRateService = mocks.NewMockFxRateServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-fx-rate-handler")
+	handler := fxratehandler.NewFxRateHandler(suite.mockFxRateService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(fxRateJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/fx-rates", handler.ListRates)
+	suite.app.Post("/admin/fx-rates", jwtAuth, handler.UploadRates)
+}
+
+func (suite *FxRateHandlerTestSuite) TestUploadRates() {
+	adminCookie := testutil.AuthCookie(suite.T(), fxRateJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		req := dto.FxRateUploadRequest{
+			RateDate: "2025-06-30",
+			Rates:    []dto.FxRateItemRequest{{Currency: "USD", RateToIDR: decimal.NewFromInt(16250)}},
+		}
+		suite.mockFxRateService.EXPECT().UploadRates(gomock.Any(), uint64(1), req).
+			Return([]domain.FxRate{{Currency: "USD", RateToIDR: decimal.NewFromInt(16250)}}, nil)
+
+		body := map[string]any{"rate_date": "2025-06-30", "rates": []map[string]any{{"currency": "USD", "rate_to_idr": 16250}}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/fx-rates", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data []map[string]any
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		suite.Require().Len(data, 1)
+		assert.Equal(suite.T(), "USD", data[0]["currency"])
+		assert.Equal(suite.T(), "16250", data[0]["rate_to_idr"])
+	})
+
+	suite.Run("Failure - Invalid Date", func() {
+		body := map[string]any{"rate_date": "30-06-2025", "rates": []map[string]any{{"currency": "USD", "rate_to_idr": 16250}}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/fx-rates", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Base Currency", func() {
+		suite.mockFxRateService.EXPECT().UploadRates(gomock.Any(), uint64(1), gomock.Any()).
+			Return(nil, common.ErrBaseCurrencyRate)
+
+		body := map[string]any{"rate_date": "2025-06-30", "rates": []map[string]any{{"currency": "IDR", "rate_to_idr": 1}}}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/fx-rates", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *FxRateHandlerTestSuite) TestListRates() {
+	suite.Run("Success - Currency Filter", func() {
+		suite.mockFxRateService.EXPECT().
+			ListRates(gomock.Any(), domain.Params{Filters: []query.Condition{{Column: "currency", Value: "USD"}}, Page: 1, Limit: 10}).
+			Return(&domain.Paginated{Data: []domain.FxRate{{ID: 1}}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/fx-rates?currency=usd", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Currency", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/fx-rates?currency=dollar", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestFxRateHandlerSuite(t *testing.T) {
+	suite.Run(t, new(FxRateHandlerTestSuite))
+}
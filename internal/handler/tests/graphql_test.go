@@ -0,0 +1,328 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	graphqlhandler "github.com/fazamuttaqien/multifinance/internal/handler/graphql"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const graphQLJWTSecret = "test-secret-key"
+
+type graphQLResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+type GraphQLHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockAdminService    *mocks.MockAdminServices
+	mockProfileService  *mocks.MockProfileServices
+	mockContractService *mocks.MockContractLookupServices
+	mockReportService   *mocks.MockReportServices
+}
+
+func (suite *GraphQLHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.mockAdminService = mocks.NewMockAdminServices(ctrl)
+	suite.mockProfileService = mocks.NewMockProfileServices(ctrl)
+	suite.mockContractService = mocks.NewMockContractLookupServices(ctrl)
+	suite.mockReportService = mocks.NewMockReportServices(ctrl)
+
+	meter, tracer, log := testutil.Telemetry("test-graphql-handler")
+	handler := graphqlhandler.NewGraphQLHandler(
+		suite.mockAdminService,
+		suite.mockProfileService,
+		suite.mockContractService,
+		suite.mockReportService,
+		meter, tracer, log,
+	)
+
+	suite.app = fiber.New()
+	suite.app.Post("/graphql", middleware.NewJWTAuthMiddleware(graphQLJWTSecret), handler.Query)
+	suite.app.Get("/graphql", middleware.NewJWTAuthMiddleware(graphQLJWTSecret), handler.Query)
+}
+
+func (suite *GraphQLHandlerTestSuite) get(cookie *http.Cookie, params url.Values) (int, graphQLResponse) {
+	req := httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+	req.AddCookie(cookie)
+
+	resp, err := suite.app.Test(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var res graphQLResponse
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&res))
+	return resp.StatusCode, res
+}
+
+func (suite *GraphQLHandlerTestSuite) post(role domain.Role, userID uint64, body map[string]any) (int, graphQLResponse, map[string]json.RawMessage) {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(testutil.AuthCookie(suite.T(), graphQLJWTSecret, userID, role))
+
+	resp, err := suite.app.Test(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var raw map[string]json.RawMessage
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&raw))
+	var res graphQLResponse
+	encoded, _ := json.Marshal(raw)
+	suite.Require().NoError(json.Unmarshal(encoded, &res))
+	return resp.StatusCode, res, raw
+}
+
+func (suite *GraphQLHandlerTestSuite) TestCustomerQueries() {
+	suite.Run("Success - Me With Limits And Transactions", func() {
+		suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(5)).
+			Return(&domain.Customer{ID: 5, FullName: "Budi", Salary: decimal.NewFromInt(8000000)}, nil)
+		suite.mockProfileService.EXPECT().GetMyLimits(gomock.Any(), uint64(5)).
+			Return([]dto.LimitDetailResponse{{TenorMonths: 6, Currency: "IDR", LimitAmount: decimal.NewFromInt(5000000), RemainingLimit: decimal.NewFromInt(3000000)}}, nil)
+		suite.mockProfileService.EXPECT().GetMyTransactions(gomock.Any(), uint64(5), gomock.Any()).
+			DoAndReturn(func(_ any, _ uint64, params domain.Params) (*domain.Paginated, error) {
+				// Filter dan paging argumen GraphQL divalidasi sama seperti query string REST
+				assert.Equal(suite.T(), "ACTIVE", params.Value("status"))
+				assert.Equal(suite.T(), 2, params.Limit)
+				return params.Paginated([]domain.Transaction{{ID: 9, ContractNumber: "KTR-1", CustomerID: 5}}, 3), nil
+			})
+
+		status, res, _ := suite.post(domain.CustomerRole, 5, map[string]any{
+			"query": `query Me($status: String) {
+				me {
+					...profile
+					tenors: limits { tenor_months remaining_limit }
+					transactions(status: $status, limit: 2) { total total_pages items { contract_number } }
+				}
+			}
+			fragment profile on Customer { id full_name salary }`,
+			"variables": map[string]any{"status": "active"},
+		})
+		assert.Equal(suite.T(), http.StatusOK, status)
+		assert.Empty(suite.T(), res.Errors)
+
+		me := res.Data["me"].(map[string]any)
+		assert.Equal(suite.T(), "Budi", me["full_name"])
+		assert.Equal(suite.T(), "8000000", me["salary"])
+		assert.Equal(suite.T(), "3000000", me["tenors"].([]any)[0].(map[string]any)["remaining_limit"])
+		transactions := me["transactions"].(map[string]any)
+		assert.Equal(suite.T(), float64(3), transactions["total"])
+		assert.Equal(suite.T(), float64(2), transactions["total_pages"])
+		assert.Equal(suite.T(), "KTR-1", transactions["items"].([]any)[0].(map[string]any)["contract_number"])
+	})
+
+	suite.Run("Forbidden - Customer Reads Admin Fields", func() {
+		status, res, _ := suite.post(domain.CustomerRole, 5, map[string]any{
+			"query": `{ customers { total } reports { aging { as_of } } }`,
+		})
+		assert.Equal(suite.T(), http.StatusOK, status)
+		suite.Require().Len(res.Errors, 2)
+		for _, e := range res.Errors {
+			assert.Equal(suite.T(), "FORBIDDEN", e.Extensions["code"])
+		}
+		assert.Nil(suite.T(), res.Data["customers"])
+		assert.Nil(suite.T(), res.Data["reports"])
+	})
+
+	suite.Run("Failure - Invalid Filter", func() {
+		suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(5)).Return(&domain.Customer{ID: 5}, nil)
+
+		_, res, _ := suite.post(domain.CustomerRole, 5, map[string]any{
+			"query": `{ me { id transactions(status: "LOST") { total } } }`,
+		})
+		suite.Require().Len(res.Errors, 1)
+		assert.Equal(suite.T(), "BAD_USER_INPUT", res.Errors[0].Extensions["code"])
+		assert.Equal(suite.T(), []any{"me", "transactions"}, res.Errors[0].Path)
+		// Field lain tetap terisi
+		assert.Equal(suite.T(), float64(5), res.Data["me"].(map[string]any)["id"])
+	})
+}
+
+func (suite *GraphQLHandlerTestSuite) TestAdminQueries() {
+	suite.Run("Success - Customer, Contracts And Report", func() {
+		suite.mockAdminService.EXPECT().GetCustomerByID(gomock.Any(), uint64(7)).
+			Return(&domain.Customer{ID: 7, FullName: "Siti"}, nil)
+		suite.mockProfileService.EXPECT().GetMyLimits(gomock.Any(), uint64(7)).Return([]dto.LimitDetailResponse{}, nil)
+		suite.mockContractService.EXPECT().Search(gomock.Any(), "KTR-JKT", gomock.Any()).
+			DoAndReturn(func(_ any, _ string, params domain.Params) (*domain.Paginated, error) {
+				assert.Equal(suite.T(), query.MaxLimit, params.Limit)
+				return params.Paginated([]dto.ContractLookupResponse{{
+					ContractNumber: "KTR-JKT-1",
+					Customer:       dto.ContractCustomerResponse{FullName: "Siti"},
+				}}, 1), nil
+			})
+		suite.mockReportService.EXPECT().InterestAccrual(gomock.Any(), "2025-01").
+			Return(&dto.InterestAccrualReportResponse{Month: "2025-01", ContractCount: 4, TotalInterest: decimal.RequireFromString("12.50")}, nil)
+
+		status, res, _ := suite.post(domain.AdminRole, 1, map[string]any{
+			"query": `query ($id: ID!) {
+				customer(id: $id) { __typename full_name limits { tenor_months } }
+				contracts(prefix: "ktr-jkt", limit: 1000) { items { contract_number customer { full_name } } }
+				reports { interest_accrual(month: "2025-01") { contract_count total_interest } }
+			}`,
+			"variables": map[string]any{"id": 7},
+		})
+		assert.Equal(suite.T(), http.StatusOK, status)
+		assert.Empty(suite.T(), res.Errors)
+
+		customer := res.Data["customer"].(map[string]any)
+		assert.Equal(suite.T(), "Customer", customer["__typename"])
+		assert.Equal(suite.T(), "Siti", customer["full_name"])
+		assert.Equal(suite.T(), []any{}, customer["limits"])
+		contract := res.Data["contracts"].(map[string]any)["items"].([]any)[0].(map[string]any)
+		assert.Equal(suite.T(), "Siti", contract["customer"].(map[string]any)["full_name"])
+		report := res.Data["reports"].(map[string]any)["interest_accrual"].(map[string]any)
+		assert.Equal(suite.T(), float64(4), report["contract_count"])
+		assert.Equal(suite.T(), "12.5", report["total_interest"])
+	})
+
+	suite.Run("Success - Missing Customer Is Null", func() {
+		suite.mockAdminService.EXPECT().GetCustomerByID(gomock.Any(), uint64(404)).Return(nil, common.ErrCustomerNotFound)
+
+		status, res, _ := suite.post(domain.AdminRole, 1, map[string]any{"query": `{ customer(id: "404") { id } }`})
+		assert.Equal(suite.T(), http.StatusOK, status)
+		assert.Empty(suite.T(), res.Errors)
+		assert.Contains(suite.T(), res.Data, "customer")
+		assert.Nil(suite.T(), res.Data["customer"])
+	})
+
+	suite.Run("Failure - Service Errors Stay Per Field", func() {
+		suite.mockReportService.EXPECT().Aging(gomock.Any(), "").Return(nil, errors.New("connection refused"))
+		suite.mockReportService.EXPECT().PartnerCommission(gomock.Any(), "2025-13").Return(nil, common.ErrInvalidReportMonth)
+		suite.mockReportService.EXPECT().ReferralConversion(gomock.Any(), "2025-01").
+			Return(&dto.ReferralConversionReportResponse{Month: "2025-01", Referred: 3}, nil)
+
+		status, res, _ := suite.post(domain.AdminRole, 1, map[string]any{
+			"query": `{ reports {
+				aging { as_of }
+				partner_commission(month: "2025-13") { month }
+				referral_conversion(month: "2025-01") { referred }
+			} }`,
+		})
+		assert.Equal(suite.T(), http.StatusOK, status)
+		suite.Require().Len(res.Errors, 2)
+		// Pesan error internal tidak dibocorkan ke klien
+		assert.Equal(suite.T(), "INTERNAL_SERVER_ERROR", res.Errors[0].Extensions["code"])
+		assert.NotContains(suite.T(), res.Errors[0].Message, "connection refused")
+		assert.Equal(suite.T(), "BAD_USER_INPUT", res.Errors[1].Extensions["code"])
+
+		reports := res.Data["reports"].(map[string]any)
+		assert.Nil(suite.T(), reports["aging"])
+		assert.Equal(suite.T(), float64(3), reports["referral_conversion"].(map[string]any)["referred"])
+	})
+}
+
+func (suite *GraphQLHandlerTestSuite) TestRequestErrors() {
+	cases := []struct {
+		name string
+		body map[string]any
+		code string
+	}{
+		{"Syntax Error", map[string]any{"query": `{ me { id }`}, "GRAPHQL_PARSE_FAILED"},
+		{"Unknown Field", map[string]any{"query": `{ me { password } }`}, "GRAPHQL_VALIDATION_FAILED"},
+		{"Missing Subselection", map[string]any{"query": `{ me }`}, "GRAPHQL_VALIDATION_FAILED"},
+		{"Mutation", map[string]any{"query": `mutation { me { id } }`}, "GRAPHQL_VALIDATION_FAILED"},
+		{"Undefined Variable", map[string]any{"query": `{ customer(id: $id) { id } }`}, "GRAPHQL_VALIDATION_FAILED"},
+		{"Missing Required Variable", map[string]any{"query": `query ($id: ID!) { customer(id: $id) { id } }`}, "BAD_USER_INPUT"},
+		{"Ambiguous Operation", map[string]any{"query": `query A { me { id } } query B { me { id } }`}, "BAD_USER_INPUT"},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			status, res, raw := suite.post(domain.AdminRole, 1, tc.body)
+			assert.Equal(suite.T(), http.StatusBadRequest, status)
+			// Request yang gagal sebelum dieksekusi tidak punya key data
+			assert.NotContains(suite.T(), raw, "data")
+			suite.Require().NotEmpty(res.Errors)
+			assert.Equal(suite.T(), tc.code, res.Errors[0].Extensions["code"])
+		})
+	}
+
+	suite.Run("Empty Query", func() {
+		status, _, _ := suite.post(domain.AdminRole, 1, map[string]any{"query": " "})
+		assert.Equal(suite.T(), http.StatusBadRequest, status)
+	})
+}
+
+func (suite *GraphQLHandlerTestSuite) TestGetQueries() {
+	suite.Run("Success - Query And Variables In Query String", func() {
+		suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(5)).
+			Return(&domain.Customer{ID: 5, FullName: "Budi"}, nil)
+
+		status, res := suite.get(testutil.AuthCookie(suite.T(), graphQLJWTSecret, 5, domain.CustomerRole), url.Values{
+			"query":         {`query A { me { id } } query B($n: Int) { me { full_name } }`},
+			"operationName": {"B"},
+			"variables":     {`{"n": 1}`},
+		})
+		assert.Equal(suite.T(), http.StatusOK, status)
+		assert.Empty(suite.T(), res.Errors)
+		assert.Equal(suite.T(), map[string]any{"full_name": "Budi"}, res.Data["me"])
+	})
+
+	suite.Run("Success - Impersonation Session Reads", func() {
+		suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(7)).
+			Return(&domain.Customer{ID: 7, FullName: "Annisa"}, nil)
+
+		cookie := testutil.ImpersonationCookie(suite.T(), graphQLJWTSecret, 1, 7, 42)
+		status, res := suite.get(cookie, url.Values{"query": {`{ me { full_name } }`}})
+		assert.Equal(suite.T(), http.StatusOK, status)
+		assert.Equal(suite.T(), map[string]any{"full_name": "Annisa"}, res.Data["me"])
+	})
+
+	suite.Run("Failure - Impersonation Session Cannot POST", func() {
+		payload, _ := json.Marshal(map[string]any{"query": `{ me { full_name } }`})
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(testutil.ImpersonationCookie(suite.T(), graphQLJWTSecret, 1, 7, 42))
+
+		resp, err := suite.app.Test(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Malformed Variables", func() {
+		status, _ := suite.get(testutil.AuthCookie(suite.T(), graphQLJWTSecret, 1, domain.AdminRole), url.Values{
+			"query":     {`query($id: ID!) { customer(id: $id) { id } }`},
+			"variables": {`{"id": `},
+		})
+		assert.Equal(suite.T(), http.StatusBadRequest, status)
+	})
+
+	suite.Run("Failure - Too Many Fields", func() {
+		query := "{ me {" + strings.Repeat(" id", 200) + " } }"
+		status, res := suite.get(testutil.AuthCookie(suite.T(), graphQLJWTSecret, 5, domain.CustomerRole), url.Values{"query": {query}})
+		assert.Equal(suite.T(), http.StatusBadRequest, status)
+		suite.Require().Len(res.Errors, 1)
+		assert.Equal(suite.T(), "GRAPHQL_VALIDATION_FAILED", res.Errors[0].Extensions["code"])
+	})
+}
+
+func TestGraphQLHandlerSuite(t *testing.T) {
+	suite.Run(t, new(GraphQLHandlerTestSuite))
+}
@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	impersonationhandler "github.com/fazamuttaqien/multifinance/internal/handler/impersonation"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const impersonationJWTSecret = "test-secret-key"
+
+type ImpersonationHandlerTestSuite struct {
+	suite.Suite
+	app                      *fiber.App
+	mockImpersonationService *mocks.MockImpersonationServices
+}
+
+func (suite *ImpersonationHandlerTestSuite) SetupTest() {
+	suite.mockImpersonationService = mocks.NewMockImpersonationServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-impersonation-handler")
+	handler := impersonationhandler.NewImpersonationHandler(suite.mockImpersonationService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(impersonationJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Post("/admin/customers/:customerId/impersonate", jwtAuth, handler.Start)
+	suite.app.Delete("/admin/impersonations/:id", handler.End)
+	suite.app.Get("/admin/impersonations/:id/requests", handler.ListRequests)
+
+	// Route customer untuk memastikan token impersonasi hanya bisa membaca
+	customersAPI := suite.app.Group("/me", jwtAuth, middleware.NewImpersonationMiddleware(suite.mockImpersonationService))
+	customersAPI.Get("/profile", func(c *fiber.Ctx) error {
+		return responder.Success(c, fiber.StatusOK, fiber.Map{"ok": true})
+	})
+	customersAPI.Put("/profile", func(c *fiber.Ctx) error {
+		return responder.Success(c, fiber.StatusOK, fiber.Map{"ok": true})
+	})
+}
+
+func (suite *ImpersonationHandlerTestSuite) TestStart() {
+	adminCookie := testutil.AuthCookie(suite.T(), impersonationJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		req := dto.ImpersonationRequest{Reason: "Customer reports a missing limit"}
+		suite.mockImpersonationService.EXPECT().Start(gomock.Any(), uint64(1), uint64(7), req).
+			Return(&dto.ImpersonationResponse{SessionID: 42, CustomerID: 7, Token: "token", ExpiresAt: time.Now().Add(15 * time.Minute)}, nil)
+
+		body := map[string]any{"reason": "Customer reports a missing limit"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/impersonate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var result dto.ImpersonationResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &result)
+		assert.Equal(suite.T(), uint64(42), result.SessionID)
+	})
+
+	suite.Run("Failure - Reason Too Short", func() {
+		body := map[string]any{"reason": "help"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/impersonate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not A Customer", func() {
+		suite.mockImpersonationService.EXPECT().Start(gomock.Any(), uint64(1), uint64(2), gomock.Any()).
+			Return(nil, common.ErrImpersonationNotAllowed)
+
+		body := map[string]any{"reason": "Checking the admin dashboard"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/2/impersonate", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func (suite *ImpersonationHandlerTestSuite) TestEnd() {
+	suite.Run("Failure - Already Ended", func() {
+		suite.mockImpersonationService.EXPECT().End(gomock.Any(), uint64(42)).Return(common.ErrImpersonationNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/impersonations/42", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *ImpersonationHandlerTestSuite) TestListRequests() {
+	suite.mockImpersonationService.EXPECT().ListRequests(gomock.Any(), uint64(42)).
+		Return([]domain.ImpersonationRequest{{SessionID: 42, Method: http.MethodGet, Path: "/me/profile", Status: http.StatusOK}}, nil)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/impersonations/42/requests", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var result []dto.ImpersonationRequestResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &result)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "/me/profile", result[0].Path)
+}
+
+func (suite *ImpersonationHandlerTestSuite) TestImpersonatedRequests() {
+	cookie := testutil.ImpersonationCookie(suite.T(), impersonationJWTSecret, 1, 7, 42)
+
+	suite.Run("Success - Read Is Audited", func() {
+		suite.mockImpersonationService.EXPECT().Authorize(gomock.Any(), uint64(42)).Return(nil)
+		suite.mockImpersonationService.EXPECT().RecordRequest(gomock.Any(), domain.ImpersonationRequest{
+			SessionID: 42,
+			Method:    http.MethodGet,
+			Path:      "/me/profile",
+			Status:    http.StatusOK,
+		}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
+		req.AddCookie(cookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Write Is Rejected", func() {
+		body := map[string]any{"address": "Jl. Sudirman 1"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{cookie}, http.MethodPut, "/me/profile", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Session Ended", func() {
+		suite.mockImpersonationService.EXPECT().Authorize(gomock.Any(), uint64(42)).Return(common.ErrImpersonationEnded)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
+		req.AddCookie(cookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	suite.Run("Success - Regular Token Skips Audit", func() {
+		req := httptest.NewRequest(http.MethodPut, "/me/profile", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), impersonationJWTSecret, 7, domain.CustomerRole))
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestImpersonationHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationHandlerTestSuite))
+}
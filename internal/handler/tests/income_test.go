@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	incomehandler "github.com/fazamuttaqien/multifinance/internal/handler/income"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const incomeJWTSecret = "test-secret-key"
+
+type IncomeHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockIncomeService *mocks.MockIncomeVerificationServices
+}
+
+func (suite *IncomeHandlerTestSuite) SetupTest() {
+	suite.mockIncomeService = mocks.NewMockIncomeVerificationServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-income-handler")
+	handler := incomehandler.NewIncomeVerificationHandler(suite.mockIncomeService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(incomeJWTSecret)
+	requireAdmin := middleware.RequireRole(domain.AdminRole)
+
+	suite.app = fiber.New()
+	suite.app.Post("/me/income-verifications", jwtAuth, handler.Upload)
+	suite.app.Get("/me/income-verifications", jwtAuth, handler.ListMine)
+	suite.app.Get("/admin/customers/:customerId/income-verifications", jwtAuth, requireAdmin, handler.ListByCustomer)
+	suite.app.Post("/admin/income-verifications/:id/review", jwtAuth, requireAdmin, handler.Review)
+}
+
+func (suite *IncomeHandlerTestSuite) newUploadRequest(field string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, "mutasi.csv")
+	suite.Require().NoError(err)
+	_, err = io.WriteString(part, "date,description,amount\n2025-01-05,salary,10000000\n")
+	suite.Require().NoError(err)
+	suite.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/me/income-verifications", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(testutil.AuthCookie(suite.T(), incomeJWTSecret, 2, domain.CustomerRole))
+	return req
+}
+
+func (suite *IncomeHandlerTestSuite) TestUpload() {
+	suite.Run("Success - Created", func() {
+		suite.mockIncomeService.EXPECT().
+			Upload(gomock.Any(), uint64(2), "mutasi.csv", []byte("date,description,amount\n2025-01-05,salary,10000000\n")).
+			Return(&domain.IncomeVerification{
+				ID:                   1,
+				CustomerID:           2,
+				AverageMonthlyInflow: decimal.NewFromInt(10000000),
+				Status:               domain.IncomeMatched,
+			}, nil)
+
+		resp, _ := suite.app.Test(suite.newUploadRequest("statement"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var body dto.IncomeVerificationResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), domain.IncomeMatched, body.Status)
+		assert.True(suite.T(), decimal.NewFromInt(10000000).Equal(body.AverageMonthlyInflow))
+	})
+
+	suite.Run("Failure - Missing File", func() {
+		resp, _ := suite.app.Test(suite.newUploadRequest("file"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unreadable Statement", func() {
+		suite.mockIncomeService.EXPECT().Upload(gomock.Any(), uint64(2), gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrStatementUnreadable)
+
+		resp, _ := suite.app.Test(suite.newUploadRequest("statement"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Too Large", func() {
+		suite.mockIncomeService.EXPECT().Upload(gomock.Any(), uint64(2), gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrStatementTooLarge)
+
+		resp, _ := suite.app.Test(suite.newUploadRequest("statement"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+}
+
+func (suite *IncomeHandlerTestSuite) TestList() {
+	suite.Run("Success - Own Verifications", func() {
+		suite.mockIncomeService.EXPECT().ListByCustomer(gomock.Any(), uint64(2)).
+			Return([]domain.IncomeVerification{{ID: 1, CustomerID: 2}, {ID: 2, CustomerID: 2}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/income-verifications", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), incomeJWTSecret, 2, domain.CustomerRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body []dto.IncomeVerificationResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Len(suite.T(), body, 2)
+	})
+
+	suite.Run("Success - Admin Lists Customer", func() {
+		suite.mockIncomeService.EXPECT().ListByCustomer(gomock.Any(), uint64(7)).
+			Return([]domain.IncomeVerification{{ID: 3, CustomerID: 7, Status: domain.IncomeFlagged}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/customers/7/income-verifications", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), incomeJWTSecret, 1, domain.AdminRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+}
+
+func (suite *IncomeHandlerTestSuite) TestReview() {
+	adminCookie := testutil.AuthCookie(suite.T(), incomeJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		suite.mockIncomeService.EXPECT().
+			Review(gomock.Any(), uint64(3), uint64(1), dto.IncomeVerificationReviewRequest{Status: domain.IncomeAccepted, Note: "Bonus"}).
+			Return(&domain.IncomeVerification{ID: 3, Status: domain.IncomeAccepted}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/income-verifications/3/review", map[string]any{"status": "ACCEPTED", "note": "Bonus"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Decision", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/income-verifications/3/review", map[string]any{"status": "FLAGGED"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Reviewed", func() {
+		suite.mockIncomeService.EXPECT().Review(gomock.Any(), uint64(3), uint64(1), gomock.Any()).
+			Return(nil, common.ErrIncomeCheckReviewed)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/income-verifications/3/review", map[string]any{"status": "REJECTED"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Token", func() {
+		customerCookie := testutil.AuthCookie(suite.T(), incomeJWTSecret, 2, domain.CustomerRole)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPost, "/admin/income-verifications/3/review", map[string]any{"status": "ACCEPTED"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestIncomeHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(IncomeHandlerTestSuite))
+}
@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	loglevelhandler "github.com/fazamuttaqien/multifinance/internal/handler/loglevel"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/telemetry"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap/zapcore"
+)
+
+const logLevelJWTSecret = "test-secret-key"
+
+type LogLevelHandlerTestSuite struct {
+	suite.Suite
+	app    *fiber.App
+	levels *telemetry.LogLevels
+}
+
+func (suite *LogLevelHandlerTestSuite) SetupTest() {
+	levels, err := telemetry.ParseLogLevels("repository=warn,sql=error", zapcore.InfoLevel)
+	require.NoError(suite.T(), err)
+	suite.levels = telemetry.NewLogLevels(levels)
+
+	meter, tracer, log := testutil.Telemetry("test-log-level-handler")
+	handler := loglevelhandler.NewLogLevelHandler(suite.levels, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(logLevelJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/log-levels", handler.ListLevels)
+	suite.app.Put("/admin/log-levels/:module", jwtAuth, handler.SetLevel)
+}
+
+func (suite *LogLevelHandlerTestSuite) TestListLevels() {
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/log-levels", nil))
+	defer resp.Body.Close()
+	require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data []dto.LogLevelResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	levels := map[string]string{}
+	for _, level := range data {
+		levels[level.Module] = level.Level
+	}
+	assert.Equal(suite.T(), "info", levels["handler"])
+	assert.Equal(suite.T(), "warn", levels["repository"])
+	assert.Equal(suite.T(), "error", levels["sql"])
+}
+
+func (suite *LogLevelHandlerTestSuite) TestSetLevel() {
+	adminCookie := testutil.AuthCookie(suite.T(), logLevelJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		body := map[string]any{"level": "DEBUG"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/log-levels/repository", body))
+		defer resp.Body.Close()
+		require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.LogLevelResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "debug", data.Level)
+		assert.Equal(suite.T(), "warn", data.Previous)
+		assert.Equal(suite.T(), "debug", suite.levels.Levels()["repository"])
+	})
+
+	suite.Run("Failure - Unknown Module", func() {
+		body := map[string]any{"level": "info"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/log-levels/cache", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Level", func() {
+		body := map[string]any{"level": "verbose"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/log-levels/sql", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(suite.T(), "error", suite.levels.Levels()["sql"])
+	})
+}
+
+func TestLogLevelHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LogLevelHandlerTestSuite))
+}
@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	maintenancehandler "github.com/fazamuttaqien/multifinance/internal/handler/maintenance"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const maintenanceJWTSecret = "test-secret-key"
+
+type MaintenanceHandlerTestSuite struct {
+	suite.Suite
+	app                    *fiber.App
+	mockMaintenanceService *mocks.MockMaintenanceServices
+}
+
+func (suite *MaintenanceHandlerTestSuite) SetupTest() {
+	suite.mockMaintenanceService = mocks.NewMockMaintenanceServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-maintenance-handler")
+	handler := maintenancehandler.NewMaintenanceHandler(suite.mockMaintenanceService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(maintenanceJWTSecret)
+	gate := middleware.NewMaintenanceGateMiddleware(suite.mockMaintenanceService, domain.GatePartnerTransactions)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/maintenance", handler.ListModes)
+	suite.app.Put("/admin/maintenance/:scope", jwtAuth, handler.SetMode)
+	suite.app.Delete("/admin/maintenance/:scope", handler.ClearMode)
+	suite.app.Post("/partners/transactions", gate, func(c *fiber.Ctx) error {
+		return responder.Success(c, fiber.StatusCreated, fiber.Map{"ok": true})
+	})
+}
+
+func (suite *MaintenanceHandlerTestSuite) TestSetMode() {
+	adminCookie := testutil.AuthCookie(suite.T(), maintenanceJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		suite.mockMaintenanceService.EXPECT().SetMode(gomock.Any(), domain.GatePartnerTransactions, uint64(1), gomock.Any()).
+			Return(&domain.MaintenanceMode{Scope: domain.GatePartnerTransactions, Message: "End of day batch", UpdatedBy: 1}, nil)
+
+		body := map[string]any{"message": "End of day batch", "until": time.Now().Add(time.Hour)}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/maintenance/partner_transactions", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Message", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/maintenance/partner_transactions", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Scope", func() {
+		suite.mockMaintenanceService.EXPECT().SetMode(gomock.Any(), domain.GateScope("payments"), uint64(1), gomock.Any()).
+			Return(nil, common.ErrUnknownGateScope)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/maintenance/payments", map[string]any{"message": "Closed"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *MaintenanceHandlerTestSuite) TestClearMode() {
+	suite.mockMaintenanceService.EXPECT().ClearMode(gomock.Any(), domain.GatePartnerTransactions).Return(common.ErrMaintenanceNotFound)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/maintenance/partner_transactions", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *MaintenanceHandlerTestSuite) TestGate() {
+	suite.Run("Open", func() {
+		suite.mockMaintenanceService.EXPECT().Check(gomock.Any(), domain.GatePartnerTransactions, gomock.Any()).Return(nil, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/partners/transactions", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Closed With Retry Information", func() {
+		retryAt := time.Now().Add(10 * time.Minute)
+		suite.mockMaintenanceService.EXPECT().Check(gomock.Any(), domain.GatePartnerTransactions, gomock.Any()).
+			Return(&domain.GateClosure{Scope: domain.GatePartnerTransactions, Reason: domain.GateReasonMaintenance, Message: "End of day batch", RetryAt: retryAt}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/partners/transactions", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusServiceUnavailable, resp.StatusCode)
+		assert.NotEmpty(suite.T(), resp.Header.Get(fiber.HeaderRetryAfter))
+
+		var env responder.Envelope
+		require.NoError(suite.T(), json.NewDecoder(resp.Body).Decode(&env))
+		require.NotNil(suite.T(), env.Error)
+		assert.Equal(suite.T(), domain.GateReasonMaintenance, env.Error.Code)
+		details, ok := env.Error.Details.(map[string]any)
+		require.True(suite.T(), ok)
+		assert.Equal(suite.T(), string(domain.GatePartnerTransactions), details["scope"])
+		assert.NotEmpty(suite.T(), details["retry_at"])
+	})
+
+	suite.Run("Check Error Lets Request Through", func() {
+		suite.mockMaintenanceService.EXPECT().Check(gomock.Any(), domain.GatePartnerTransactions, gomock.Any()).Return(nil, assert.AnError)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/partners/transactions", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+}
+
+func TestMaintenanceHandlerSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceHandlerTestSuite))
+}
@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	mobilehandler "github.com/fazamuttaqien/multifinance/internal/handler/mobile"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const mobileJWTSecret = "test-secret-key"
+
+type MobileHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockMobileService *mocks.MockMobileServices
+	customerCookie    *http.Cookie
+}
+
+func (suite *MobileHandlerTestSuite) SetupTest() {
+	suite.mockMobileService = mocks.NewMockMobileServices(gomock.NewController(suite.T()))
+	suite.customerCookie = testutil.AuthCookie(suite.T(), mobileJWTSecret, 7, domain.CustomerRole)
+
+	meter, tracer, log := testutil.Telemetry("test-mobile-handler")
+	handler := mobilehandler.NewMobileHandler(suite.mockMobileService, meter, tracer, log)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(suite.T(), err)
+
+	suite.app = fiber.New()
+	suite.app.Get("/mobile/home",
+		middleware.NewJWTAuthMiddleware(mobileJWTSecret),
+		middleware.RequireRole(domain.CustomerRole),
+		datetime.Middleware(jakarta, "id", "en"),
+		handler.Home,
+	)
+}
+
+func (suite *MobileHandlerTestSuite) get(etag string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, "/mobile/home", nil)
+	req.AddCookie(suite.customerCookie)
+	if etag != "" {
+		req.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	}
+
+	resp, _ := suite.app.Test(req)
+	return resp
+}
+
+func (suite *MobileHandlerTestSuite) TestHome() {
+	home := &dto.MobileHomeResponse{
+		FullName:           "Budi",
+		VerificationStatus: "VERIFIED",
+		Limits:             []dto.MobileLimitResponse{{TenorMonths: 6, Currency: "IDR", LimitAmount: decimal.NewFromInt(10_000_000), RemainingLimit: decimal.NewFromInt(6_000_000)}},
+		ActiveContracts:    1,
+		NextInstallment: &dto.MobileInstallmentResponse{
+			ContractNumber: "KTR-001",
+			Sequence:       5,
+			DueDate:        time.Date(2025, 6, 10, 17, 30, 0, 0, time.UTC),
+			Amount:         decimal.NewFromInt(1_000_000),
+			Currency:       "IDR",
+		},
+	}
+
+	suite.Run("Success - Localized Due Date", func() {
+		suite.mockMobileService.EXPECT().Home(gomock.Any(), uint64(7), gomock.Any()).Return(home, nil)
+
+		resp := suite.get("")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.NotEmpty(suite.T(), resp.Header.Get(fiber.HeaderETag))
+
+		var body dto.MobileHomeResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		require.NotNil(suite.T(), body.NextInstallment)
+		assert.Equal(suite.T(), "2025-06-11", body.NextInstallment.DueDate.Format(time.DateOnly))
+		assert.Equal(suite.T(), "6000000", body.Limits[0].RemainingLimit.String())
+	})
+
+	suite.Run("Success - Not Modified", func() {
+		suite.mockMobileService.EXPECT().Home(gomock.Any(), uint64(7), gomock.Any()).Return(home, nil).Times(2)
+
+		first := suite.get("")
+		defer first.Body.Close()
+
+		resp := suite.get(first.Header.Get(fiber.HeaderETag))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotModified, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Service Error", func() {
+		suite.mockMobileService.EXPECT().Home(gomock.Any(), uint64(7), gomock.Any()).Return(nil, errors.New("db down"))
+
+		resp := suite.get("")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestMobileHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(MobileHandlerTestSuite))
+}
@@ -0,0 +1,136 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	notificationhandler "github.com/fazamuttaqien/multifinance/internal/handler/notification"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const notificationJWTSecret = "test-secret-key"
+
+type NotificationHandlerTestSuite struct {
+	suite.Suite
+	app                   *fiber.App
+	mockPushService       *mocks.MockPushServices
+	mockPreferenceService *mocks.MockNotificationPreferenceServices
+	cookie                *http.Cookie
+}
+
+func (suite *NotificationHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.mockPushService = mocks.NewMockPushServices(ctrl)
+	suite.mockPreferenceService = mocks.NewMockNotificationPreferenceServices(ctrl)
+
+	meter, tracer, log := testutil.Telemetry("test-notification-handler")
+	handler := notificationhandler.NewNotificationHandler(suite.mockPushService, suite.mockPreferenceService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(notificationJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Post("/me/devices", jwtAuth, handler.RegisterDevice)
+	suite.app.Delete("/me/devices", jwtAuth, handler.UnregisterDevice)
+	suite.app.Get("/me/notification-preferences", jwtAuth, handler.GetPreferences)
+	suite.app.Put("/me/notification-preferences", jwtAuth, handler.SetPreferences)
+
+	suite.cookie = testutil.AuthCookie(suite.T(), notificationJWTSecret, 2, domain.CustomerRole)
+}
+
+func (suite *NotificationHandlerTestSuite) TestRegisterDevice() {
+	suite.Run("Success", func() {
+		suite.mockPushService.EXPECT().RegisterDevice(gomock.Any(), uint64(2), dto.DeviceRequest{Token: "fcm-token", Platform: "ANDROID"}).
+			Return(&domain.Device{ID: 8, CustomerID: 2, Token: "fcm-token", Platform: domain.PlatformAndroid}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodPost, "/me/devices", map[string]any{"token": "fcm-token", "platform": "ANDROID"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var data dto.DeviceResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), uint64(8), data.ID)
+		assert.Equal(suite.T(), "ANDROID", data.Platform)
+	})
+
+	suite.Run("Failure - Unknown Platform", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodPost, "/me/devices", map[string]any{"token": "fcm-token", "platform": "SYMBIAN"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Service Error", func() {
+		suite.mockPushService.EXPECT().RegisterDevice(gomock.Any(), uint64(2), gomock.Any()).Return(nil, errors.New("db down"))
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodPost, "/me/devices", map[string]any{"token": "fcm-token", "platform": "IOS"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func (suite *NotificationHandlerTestSuite) TestUnregisterDevice() {
+	suite.Run("Success", func() {
+		suite.mockPushService.EXPECT().UnregisterDevice(gomock.Any(), uint64(2), "fcm-token").Return(nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodDelete, "/me/devices", map[string]any{"token": "fcm-token"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Registered", func() {
+		suite.mockPushService.EXPECT().UnregisterDevice(gomock.Any(), uint64(2), "other-token").Return(common.ErrDeviceNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodDelete, "/me/devices", map[string]any{"token": "other-token"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Token", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodDelete, "/me/devices", map[string]any{}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *NotificationHandlerTestSuite) TestPreferences() {
+	suite.Run("Get", func() {
+		suite.mockPreferenceService.EXPECT().GetPreferences(gomock.Any(), uint64(2)).
+			Return(&domain.NotificationPreferences{CustomerID: 2, Email: true, Push: true}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodGet, "/me/notification-preferences", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.NotificationPreferencesResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.True(suite.T(), data.Email)
+		assert.True(suite.T(), data.Push)
+	})
+
+	suite.Run("Set", func() {
+		push := false
+		suite.mockPreferenceService.EXPECT().SetPreferences(gomock.Any(), uint64(2), dto.NotificationPreferencesRequest{Push: &push}).
+			Return(&domain.NotificationPreferences{CustomerID: 2, Email: true, Push: false}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.cookie}, http.MethodPut, "/me/notification-preferences", map[string]any{"push": false}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.NotificationPreferencesResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.False(suite.T(), data.Push)
+	})
+}
+
+func TestNotificationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationHandlerTestSuite))
+}
@@ -0,0 +1,287 @@
+package handler_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	onboardinghandler "github.com/fazamuttaqien/multifinance/internal/handler/onboarding"
+	partnerhandler "github.com/fazamuttaqien/multifinance/internal/handler/partner"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/requestsig"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OnboardingHandlerTestSuite struct {
+	suite.Suite
+	app                   *fiber.App
+	mockOnboardingService *mocks.MockPartnerOnboardingServices
+	mockPartnerService    *mocks.MockPartnerServices
+	mockSignatureService  *mocks.MockRequestSignatureServices
+}
+
+func (suite *OnboardingHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.mockOnboardingService = mocks.NewMockPartnerOnboardingServices(ctrl)
+	suite.mockPartnerService = mocks.NewMockPartnerServices(ctrl)
+	suite.mockSignatureService = mocks.NewMockRequestSignatureServices(ctrl)
+
+	meter, tracer, log := testutil.Telemetry("test-onboarding-handler")
+	onboardingHandler := onboardinghandler.NewOnboardingHandler(suite.mockOnboardingService, meter, tracer, log)
+	partnerHandler := partnerhandler.NewPartnerHandler(suite.mockPartnerService, nil, meter, tracer, log)
+	apiKeyAuth := middleware.NewAPIKeyMiddleware(suite.mockOnboardingService)
+	partnerNetwork := middleware.NewPartnerNetworkMiddleware()
+	requestSignature := middleware.NewRequestSignatureMiddleware(suite.mockSignatureService)
+
+	suite.app = fiber.New()
+	suite.app.Post("/partner-api/register", onboardingHandler.Register)
+	suite.app.Post("/partner-api/transactions", apiKeyAuth, partnerNetwork, partnerHandler.CreateTransaction)
+	suite.app.Post("/partner-api/signed/transactions", apiKeyAuth, requestSignature, partnerHandler.CreateTransaction)
+	suite.app.Post("/admin/partners/:partnerId/promote", onboardingHandler.Promote)
+	suite.app.Put("/admin/partners/:partnerId/security", onboardingHandler.UpdateSecurity)
+}
+
+func (suite *OnboardingHandlerTestSuite) TestRegister() {
+	body := map[string]any{"name": "Dealer Motor Jaya", "email": "dealer@example.com"}
+
+	suite.Run("Success - Sandbox Key Issued", func() {
+		suite.mockOnboardingService.EXPECT().
+			Register(gomock.Any(), dto.RegisterPartnerRequest{Name: "Dealer Motor Jaya", Email: "dealer@example.com"}).
+			Return(&dto.PartnerCredentialsResponse{PartnerID: 1, APIKey: "mf_test_abc", Sandbox: true}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/register", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Email Registered", func() {
+		suite.mockOnboardingService.EXPECT().Register(gomock.Any(), gomock.Any()).Return(nil, common.ErrPartnerExists)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/register", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Email", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/register", map[string]any{"name": "X", "email": "nope"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *OnboardingHandlerTestSuite) TestPromote() {
+	suite.Run("Success", func() {
+		suite.mockOnboardingService.EXPECT().Promote(gomock.Any(), uint64(7)).
+			Return(&dto.PartnerCredentialsResponse{PartnerID: 7, APIKey: "mf_live_abc"}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/partners/7/promote", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Promoted", func() {
+		suite.mockOnboardingService.EXPECT().Promote(gomock.Any(), uint64(7)).Return(nil, common.ErrPartnerPromoted)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/partners/7/promote", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *OnboardingHandlerTestSuite) TestTransactionWithSandboxKey() {
+	body := map[string]any{
+		"customer_nik": "1234567890123456",
+		"tenor_months": 6,
+		"asset_name":   "Laptop",
+		"otr_amount":   10000.0,
+		"admin_fee":    500.0,
+	}
+
+	suite.Run("Sandbox Flag Forwarded", func() {
+		suite.mockOnboardingService.EXPECT().Authenticate(gomock.Any(), "mf_test_abc").
+			Return(&domain.Partner{ID: 3, Status: domain.PartnerSandbox}, true, nil)
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Cond(func(req dto.CreateTransactionRequest) bool {
+				return req.Sandbox && req.PartnerID != nil && *req.PartnerID == 3
+			})).
+			Return(&domain.Transaction{ID: 1, IsSandbox: true}, nil)
+
+		req := createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions", body)
+		req.Header.Set(middleware.APIKeyHeader, "mf_test_abc")
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Key", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Key", func() {
+		suite.mockOnboardingService.EXPECT().Authenticate(gomock.Any(), "mf_live_wrong").Return(nil, false, common.ErrInvalidAPIKey)
+
+		req := createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions", body)
+		req.Header.Set(middleware.APIKeyHeader, "mf_live_wrong")
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func (suite *OnboardingHandlerTestSuite) TestPartnerNetworkRestrictions() {
+	body := map[string]any{
+		"customer_nik": "1234567890123456",
+		"tenor_months": 6,
+		"asset_name":   "Laptop",
+		"otr_amount":   10000.0,
+		"admin_fee":    500.0,
+	}
+
+	send := func(key string, partner *domain.Partner, sandbox bool) *http.Response {
+		suite.mockOnboardingService.EXPECT().Authenticate(gomock.Any(), key).Return(partner, sandbox, nil)
+
+		req := createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/transactions", body)
+		req.Header.Set(middleware.APIKeyHeader, key)
+		resp, err := suite.app.Test(req)
+		suite.Require().NoError(err)
+		return resp
+	}
+
+	suite.Run("Success - Address Allowed", func() {
+		// app.Test selalu memakai alamat 0.0.0.0
+		partner := &domain.Partner{ID: 4, Security: domain.PartnerSecurity{AllowedCIDRs: []string{"10.0.0.0/8", "0.0.0.0/32"}}}
+		suite.mockPartnerService.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(&domain.Transaction{ID: 2}, nil)
+
+		resp := send("mf_live_allowed", partner, false)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Success - Sandbox Key Unrestricted", func() {
+		partner := &domain.Partner{ID: 4, Security: domain.PartnerSecurity{AllowedCIDRs: []string{"10.0.0.0/8"}}}
+		suite.mockPartnerService.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(&domain.Transaction{ID: 3, IsSandbox: true}, nil)
+
+		resp := send("mf_test_anywhere", partner, true)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Address Not Allowed", func() {
+		partner := &domain.Partner{ID: 4, Security: domain.PartnerSecurity{AllowedCIDRs: []string{"10.0.0.0/8"}}}
+
+		resp := send("mf_live_blocked", partner, false)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Client Certificate Missing", func() {
+		partner := &domain.Partner{ID: 4, Security: domain.PartnerSecurity{RequireClientCert: true, ClientCertFingerprint: strings.Repeat("ab", 32)}}
+
+		resp := send("mf_live_mtls", partner, false)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("Failure - No Certificate And No Stored Fingerprint", func() {
+		// Tanpa sertifikat sidik jari request kosong, sama dengan yang tersimpan
+		partner := &domain.Partner{ID: 4, Security: domain.PartnerSecurity{RequireClientCert: true}}
+
+		resp := send("mf_live_unpinned", partner, false)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func (suite *OnboardingHandlerTestSuite) TestRequestSignature() {
+	body := map[string]any{
+		"customer_nik": "1234567890123456",
+		"tenor_months": 6,
+		"asset_name":   "Laptop",
+		"otr_amount":   10000.0,
+		"admin_fee":    500.0,
+	}
+	partner := &domain.Partner{ID: 5, Security: domain.PartnerSecurity{RequireSignature: true, SigningSecret: "partner-secret"}}
+
+	send := func(verifyErr error) *http.Response {
+		suite.mockOnboardingService.EXPECT().Authenticate(gomock.Any(), "mf_live_signed").Return(partner, false, nil)
+		suite.mockSignatureService.EXPECT().
+			Verify(gomock.Any(), partner, gomock.Cond(func(req domain.SignedRequest) bool {
+				return req.Nonce == "nonce-0000000001" && req.Timestamp == "1700000000" && req.Signature == "abc" &&
+					req.Path == "/partner-api/signed/transactions" && len(req.Body) > 0
+			})).
+			Return(verifyErr)
+
+		req := createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/partner-api/signed/transactions", body)
+		req.Header.Set(middleware.APIKeyHeader, "mf_live_signed")
+		req.Header.Set(requestsig.HeaderNonce, "nonce-0000000001")
+		req.Header.Set(requestsig.HeaderTimestamp, "1700000000")
+		req.Header.Set(requestsig.HeaderSignature, "abc")
+		resp, err := suite.app.Test(req)
+		suite.Require().NoError(err)
+		return resp
+	}
+
+	suite.Run("Success", func() {
+		suite.mockPartnerService.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(&domain.Transaction{ID: 4}, nil)
+
+		resp := send(nil)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Replayed", func() {
+		resp := send(common.ErrNonceReused)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Signature", func() {
+		resp := send(common.ErrInvalidSignature)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func (suite *OnboardingHandlerTestSuite) TestUpdateSecurity() {
+	suite.Run("Success", func() {
+		req := dto.PartnerSecurityRequest{AllowedCIDRs: []string{"203.0.113.7"}}
+		suite.mockOnboardingService.EXPECT().UpdateSecurity(gomock.Any(), uint64(7), req).
+			Return(&dto.PartnerSecurityResponse{PartnerID: 7, AllowedCIDRs: []string{"203.0.113.7/32"}}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/partners/7/security",
+			map[string]any{"allowed_cidrs": []string{"203.0.113.7"}}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid CIDR", func() {
+		suite.mockOnboardingService.EXPECT().UpdateSecurity(gomock.Any(), uint64(7), gomock.Any()).Return(nil, common.ErrInvalidCIDR)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/partners/7/security",
+			map[string]any{"allowed_cidrs": []string{"not-an-ip"}}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Fingerprint Required", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/partners/7/security",
+			map[string]any{"require_client_cert": true}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestOnboardingHandlerSuite(t *testing.T) {
+	suite.Run(t, new(OnboardingHandlerTestSuite))
+}
@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	otphandler "github.com/fazamuttaqien/multifinance/internal/handler/otp"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const otpJWTSecret = "test-secret-key"
+
+type OTPHandlerTestSuite struct {
+	suite.Suite
+	app            *fiber.App
+	mockOTPService *mocks.MockOTPServices
+}
+
+func (suite *OTPHandlerTestSuite) SetupTest() {
+	suite.mockOTPService = mocks.NewMockOTPServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-otp-handler")
+	handler := otphandler.NewOTPHandler(suite.mockOTPService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	otpAPI := suite.app.Group("/otp", middleware.NewOptionalJWTAuthMiddleware(otpJWTSecret))
+	otpAPI.Post("/request", handler.Request)
+	otpAPI.Post("/verify", handler.Verify)
+}
+
+func (suite *OTPHandlerTestSuite) TestRequest() {
+	suite.Run("Success - Anonymous Registration", func() {
+		req := dto.OTPRequest{Purpose: "REGISTRATION", Channel: "SMS", Destination: "+6281234567890"}
+		suite.mockOTPService.EXPECT().Request(gomock.Any(), uint64(0), req).
+			Return(&domain.OTPChallenge{ID: "a1b2c3", ExpiresAt: time.Now().Add(5 * time.Minute)}, nil)
+
+		body := map[string]any{"purpose": "REGISTRATION", "channel": "SMS", "destination": "+6281234567890"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/otp/request", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var result dto.OTPChallengeResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &result)
+		assert.Equal(suite.T(), "a1b2c3", result.ChallengeID)
+	})
+
+	suite.Run("Success - Logged In Customer", func() {
+		cookie := testutil.AuthCookie(suite.T(), otpJWTSecret, 7, domain.CustomerRole)
+		suite.mockOTPService.EXPECT().Request(gomock.Any(), uint64(7), gomock.Any()).
+			Return(&domain.OTPChallenge{ID: "d4e5f6"}, nil)
+
+		body := map[string]any{"purpose": "CHANGE_PASSWORD", "channel": "EMAIL", "destination": "budi@example.com"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{cookie}, http.MethodPost, "/otp/request", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Login Required", func() {
+		suite.mockOTPService.EXPECT().Request(gomock.Any(), uint64(0), gomock.Any()).Return(nil, common.ErrOTPLoginRequired)
+
+		body := map[string]any{"purpose": "CHANGE_PASSWORD", "channel": "EMAIL", "destination": "budi@example.com"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/otp/request", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Rate Limited", func() {
+		suite.mockOTPService.EXPECT().Request(gomock.Any(), uint64(0), gomock.Any()).Return(nil, common.ErrOTPRateLimited)
+
+		body := map[string]any{"purpose": "REGISTRATION", "channel": "SMS", "destination": "+6281234567890"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/otp/request", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Purpose", func() {
+		body := map[string]any{"purpose": "LOGIN", "channel": "SMS", "destination": "+6281234567890"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/otp/request", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *OTPHandlerTestSuite) TestVerify() {
+	suite.Run("Success", func() {
+		req := dto.OTPVerifyRequest{ChallengeID: "a1b2c3", Code: "123456"}
+		suite.mockOTPService.EXPECT().Verify(gomock.Any(), req).
+			Return(&domain.OTPVerification{Token: "token-1", Purpose: domain.OTPRegistration}, nil)
+
+		body := map[string]any{"challenge_id": "a1b2c3", "code": "123456"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/otp/verify", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var result dto.OTPVerificationResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &result)
+		assert.Equal(suite.T(), "token-1", result.OTPToken)
+	})
+
+	suite.Run("Failure - Wrong Code", func() {
+		suite.mockOTPService.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil, common.ErrOTPInvalid)
+
+		body := map[string]any{"challenge_id": "a1b2c3", "code": "654321"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/otp/verify", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Attempts Exceeded", func() {
+		suite.mockOTPService.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(nil, common.ErrOTPAttemptsExceeded)
+
+		body := map[string]any{"challenge_id": "a1b2c3", "code": "654321"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/otp/verify", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusTooManyRequests, resp.StatusCode)
+	})
+}
+
+func TestOTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(OTPHandlerTestSuite))
+}
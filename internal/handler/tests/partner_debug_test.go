@@ -0,0 +1,168 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	partnerdebughandler "github.com/fazamuttaqien/multifinance/internal/handler/partnerdebug"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PartnerDebugHandlerTestSuite struct {
+	suite.Suite
+	app              *fiber.App
+	mockDebugService *mocks.MockPartnerDebugServices
+	debugUntil       *time.Time
+}
+
+func (suite *PartnerDebugHandlerTestSuite) SetupTest() {
+	suite.mockDebugService = mocks.NewMockPartnerDebugServices(gomock.NewController(suite.T()))
+	suite.debugUntil = nil
+
+	meter, tracer, log := testutil.Telemetry("test-partner-debug-handler")
+	handler := partnerdebughandler.NewPartnerDebugHandler(suite.mockDebugService, meter, tracer, log)
+	partnerKey := func(c *fiber.Ctx) error {
+		c.Locals("partner", &domain.Partner{ID: 7, SandboxDebugUntil: suite.debugUntil})
+		c.Locals("sandbox", true)
+		return c.Next()
+	}
+
+	suite.app = fiber.New()
+	suite.app.Get("/partner-api/me/debug", partnerKey, handler.GetMode)
+	suite.app.Put("/partner-api/me/debug", partnerKey, handler.SetMode)
+	suite.app.Get("/partner-api/me/debug/records", partnerKey, handler.ListRecords)
+	suite.app.Get("/partner-api/me/debug/records/:id", partnerKey, handler.GetRecord)
+	suite.app.Post("/partner-api/check-limit", partnerKey, middleware.NewDebugRecorderMiddleware(suite.mockDebugService), func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": "limit exceeded"})
+	})
+}
+
+func (suite *PartnerDebugHandlerTestSuite) TestGetMode() {
+	until := time.Now().Add(time.Hour)
+	suite.debugUntil = &until
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/debug", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data dto.PartnerDebugModeResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	assert.True(suite.T(), data.Enabled)
+	assert.True(suite.T(), data.Sandbox)
+}
+
+func (suite *PartnerDebugHandlerTestSuite) TestSetMode() {
+	suite.Run("Success - Enabled For Calling Key", func() {
+		until := time.Now().Add(24 * time.Hour)
+		suite.mockDebugService.EXPECT().SetMode(gomock.Any(), uint64(7), true, true, gomock.Any()).Return(&until, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/partner-api/me/debug", strings.NewReader(`{"enabled":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PartnerDebugModeResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.True(suite.T(), data.Enabled)
+		suite.Require().NotNil(data.Until)
+	})
+
+	suite.Run("Failure - Enabled Missing", func() {
+		req := httptest.NewRequest(http.MethodPut, "/partner-api/me/debug", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *PartnerDebugHandlerTestSuite) TestListRecords() {
+	suite.mockDebugService.EXPECT().ListRecords(gomock.Any(), uint64(7), true, gomock.Any()).
+		DoAndReturn(func(_ any, _ uint64, _ bool, params domain.Params) (*domain.Paginated, error) {
+			assert.Equal(suite.T(), fiber.MethodPost, params.Value("method"))
+			return params.Paginated([]domain.PartnerDebugRecord{{ID: 4, Method: fiber.MethodPost, Path: "/partner-api/check-limit", StatusCode: 422, RequestBody: `{"nik":"[REDACTED]"}`}}, 1), nil
+		})
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/debug/records?method=post", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data []dto.PartnerDebugRecordResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	suite.Require().Len(data, 1)
+	assert.Equal(suite.T(), 422, data[0].StatusCode)
+	assert.Empty(suite.T(), data[0].RequestBody, "listing only shows the summary")
+}
+
+func (suite *PartnerDebugHandlerTestSuite) TestGetRecord() {
+	suite.Run("Success - Includes Payloads", func() {
+		suite.mockDebugService.EXPECT().GetRecord(gomock.Any(), uint64(7), true, uint64(4)).
+			Return(&domain.PartnerDebugRecord{ID: 4, RequestBody: `{"amount":1000}`, ResponseBody: `{"error":"limit exceeded"}`}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/debug/records/4", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PartnerDebugRecordResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), `{"amount":1000}`, data.RequestBody)
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockDebugService.EXPECT().GetRecord(gomock.Any(), uint64(7), true, uint64(9)).Return(nil, common.ErrDebugRecordNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/debug/records/9", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *PartnerDebugHandlerTestSuite) TestRecorder() {
+	suite.Run("Records While Debug Mode Is On", func() {
+		until := time.Now().Add(time.Hour)
+		suite.debugUntil = &until
+		suite.mockDebugService.EXPECT().Record(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, record *domain.PartnerDebugRecord) error {
+				assert.Equal(suite.T(), uint64(7), record.PartnerID)
+				assert.True(suite.T(), record.Sandbox)
+				assert.Equal(suite.T(), http.StatusUnprocessableEntity, record.StatusCode)
+				assert.JSONEq(suite.T(), `{"amount":1000}`, record.RequestBody)
+				assert.JSONEq(suite.T(), `{"error":"limit exceeded"}`, record.ResponseBody)
+				assert.Equal(suite.T(), "key", record.RequestHeaders["X-Api-Key"], "masking is left to the service")
+				return nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/partner-api/check-limit", strings.NewReader(`{"amount":1000}`))
+		req.Header.Set(middleware.APIKeyHeader, "key")
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Skipped Once Debug Mode Expired", func() {
+		until := time.Now().Add(-time.Minute)
+		suite.debugUntil = &until
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/partner-api/check-limit", strings.NewReader(`{}`)))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestPartnerDebugHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PartnerDebugHandlerTestSuite))
+}
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -13,6 +14,8 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/dto"
 	partnerhandler "github.com/fazamuttaqien/multifinance/internal/handler/partner"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 	"github.com/fazamuttaqien/multifinance/middleware"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
 	"github.com/golang-jwt/jwt/v5"
@@ -22,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
@@ -35,7 +39,7 @@ type PartnerHandlerTestSuite struct {
 	suite.Suite
 	app                *fiber.App
 	handler            *partnerhandler.PartnerHandler
-	mockPartnerService *MockPartnerService
+	mockPartnerService *mocks.MockPartnerServices
 
 	store     *session.Store
 	jwtSecret string
@@ -48,7 +52,7 @@ type PartnerHandlerTestSuite struct {
 func (suite *PartnerHandlerTestSuite) SetupTest() {
 	rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	suite.mockPartnerService = &MockPartnerService{}
+	suite.mockPartnerService = mocks.NewMockPartnerServices(gomock.NewController(suite.T()))
 	suite.store = session.New(session.Config{KeyLookup: "cookie:test-keylookup-partner"})
 	suite.jwtSecret = "test-partner-secret-key"
 
@@ -60,6 +64,7 @@ func (suite *PartnerHandlerTestSuite) SetupTest() {
 
 	suite.handler = partnerhandler.NewPartnerHandler(
 		suite.mockPartnerService,
+		nil,
 		suite.meter,
 		suite.tracer,
 		suite.log,
@@ -97,18 +102,7 @@ func (suite *PartnerHandlerTestSuite) setupPartnerApp() *fiber.App {
 }
 
 func (suite *PartnerHandlerTestSuite) getAuthCookieAndCsrfToken() (string, []*http.Cookie) {
-	claims := &domain.JwtCustomClaims{
-		UserID: 3,
-		Role:   domain.CustomerRole,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 1)),
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(suite.jwtSecret))
-	assert.NoError(suite.T(), err)
-
-	jwtCookie := &http.Cookie{Name: "private", Value: signedToken}
+	jwtCookie := testutil.AuthCookie(suite.T(), suite.jwtSecret, 3, domain.CustomerRole)
 
 	csrfReq := httptest.NewRequest(http.MethodGet, "/test/csrf-token", nil)
 	csrfReq.AddCookie(jwtCookie)
@@ -138,8 +132,9 @@ func (suite *PartnerHandlerTestSuite) TestCheckLimit() {
 	}
 
 	suite.Run("Success - Limit Approved", func() {
-		suite.mockPartnerService.MockCheckLimitResult = &dto.CheckLimitResponse{Status: "approved"}
-		suite.mockPartnerService.MockError = nil
+		suite.mockPartnerService.EXPECT().
+			CheckLimit(gomock.Any(), gomock.Any()).
+			Return(&dto.CheckLimitResponse{Status: "approved"}, nil)
 		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/check-limit", requestBodyMap)
 		resp, _ := suite.app.Test(req)
 		defer resp.Body.Close()
@@ -147,8 +142,9 @@ func (suite *PartnerHandlerTestSuite) TestCheckLimit() {
 	})
 
 	suite.Run("Success - Limit Rejected", func() {
-		suite.mockPartnerService.MockCheckLimitResult = &dto.CheckLimitResponse{Status: "rejected"}
-		suite.mockPartnerService.MockError = nil
+		suite.mockPartnerService.EXPECT().
+			CheckLimit(gomock.Any(), gomock.Any()).
+			Return(&dto.CheckLimitResponse{Status: "rejected"}, nil)
 		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/check-limit", requestBodyMap)
 
 		resp, _ := suite.app.Test(req)
@@ -158,7 +154,9 @@ func (suite *PartnerHandlerTestSuite) TestCheckLimit() {
 	})
 
 	suite.Run("Failure - Customer Not Found", func() {
-		suite.mockPartnerService.MockError = common.ErrCustomerNotFound
+		suite.mockPartnerService.EXPECT().
+			CheckLimit(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrCustomerNotFound)
 		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/check-limit", requestBodyMap)
 
 		resp, _ := suite.app.Test(req)
@@ -180,8 +178,9 @@ func (suite *PartnerHandlerTestSuite) TestCreateTransaction() {
 	}
 
 	suite.Run("Success - Transaction Created", func() {
-		suite.mockPartnerService.MockCreateTransactionResult = &domain.Transaction{ID: 1, AssetName: "Laptop"}
-		suite.mockPartnerService.MockError = nil
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(&domain.Transaction{ID: 1, AssetName: "Laptop"}, nil)
 		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
 		resp, _ := suite.app.Test(req)
 		defer resp.Body.Close()
@@ -189,7 +188,57 @@ func (suite *PartnerHandlerTestSuite) TestCreateTransaction() {
 	})
 
 	suite.Run("Failure - Insufficient Limit", func() {
-		suite.mockPartnerService.MockError = common.ErrInsufficientLimit
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrInsufficientLimit)
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Suspended", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrCustomerSuspended)
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Dormant", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrCustomerDormant)
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Daily Quota Exceeded", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrDailyCountQuotaExceeded)
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Daily Volume Exceeded", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrDailyVolumeQuotaExceeded)
 		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
 
 		resp, _ := suite.app.Test(req)
@@ -197,6 +246,106 @@ func (suite *PartnerHandlerTestSuite) TestCreateTransaction() {
 
 		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
 	})
+
+	suite.Run("Failure - Invalid Amounts Listed Per Field", func() {
+		body := map[string]any{
+			"customer_nik": nik,
+			"tenor_months": 6,
+			"asset_name":   "Laptop",
+			"otr_amount":   -100.0,
+			"admin_fee":    -1.0,
+		}
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", body)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+		envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		suite.Require().NotNil(envelope.Error)
+		details, ok := envelope.Error.Details.([]any)
+		suite.Require().True(ok)
+		suite.Require().Len(details, 2)
+		assert.Equal(suite.T(), "otr_amount", details[0].(map[string]any)["field"])
+		assert.Equal(suite.T(), "gt", details[0].(map[string]any)["rule"])
+		assert.Equal(suite.T(), "admin_fee", details[1].(map[string]any)["field"])
+	})
+
+	suite.Run("Failure - Amount Outside Product", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, &common.FieldError{Field: "otr_amount", Err: fmt.Errorf("%w: maximum is 5000 IDR", common.ErrAmountOutsideProduct)})
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+		envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		suite.Require().NotNil(envelope.Error)
+		assert.Equal(suite.T(), "amount_outside_product", envelope.Error.Code)
+		assert.Equal(suite.T(), []any{map[string]any{
+			"field":   "otr_amount",
+			"message": "transaction amount is outside the tenor's allowed range: maximum is 5000 IDR",
+		}}, envelope.Error.Details)
+	})
+
+	suite.Run("Failure - Contract Numbers Taken", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("failed to create transaction record: %w", common.ErrDuplicateContract))
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Concentration Limit Exceeded", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrConcentrationExceeded)
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Asset Category Not Allowed", func() {
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrAssetNotAllowed)
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", requestBodyMap)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Admin Fee Required", func() {
+		body := map[string]any{
+			"customer_nik": nik,
+			"tenor_months": 6,
+			"asset_name":   "Laptop",
+			"otr_amount":   10000.0,
+		}
+		suite.mockPartnerService.EXPECT().
+			CreateTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req dto.CreateTransactionRequest) (*domain.Transaction, error) {
+				assert.Nil(suite.T(), req.AdminFee)
+				return nil, common.ErrAdminFeeRequired
+			})
+		req := createJSONRequestWithAuth(suite.T(), csrfToken, authCookies, http.MethodPost, "/partners/transactions", body)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
 }
 
 func (suite *PartnerHandlerTestSuite) TestPartnerRoutes_Security() {
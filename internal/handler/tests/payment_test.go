@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	paymenthandler "github.com/fazamuttaqien/multifinance/internal/handler/payment"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PaymentHandlerTestSuite struct {
+	suite.Suite
+	app                *fiber.App
+	mockPaymentService *mocks.MockPaymentServices
+}
+
+func (suite *PaymentHandlerTestSuite) SetupTest() {
+	suite.mockPaymentService = mocks.NewMockPaymentServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-payment-handler")
+	handler := paymenthandler.NewPaymentHandler(suite.mockPaymentService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Post("/callbacks/payments/:provider", handler.HandleCallback)
+}
+
+func (suite *PaymentHandlerTestSuite) callback(provider string, body []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/callbacks/payments/"+provider, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Callback-Signature", "abc123")
+	return req
+}
+
+func (suite *PaymentHandlerTestSuite) TestHandleCallback() {
+	body := []byte(`{"id":"inv-1","external_id":"CN-001","status":"PAID"}`)
+
+	suite.Run("Success - Passes Raw Body And Headers", func() {
+		suite.mockPaymentService.EXPECT().HandleCallback(gomock.Any(), "xendit", gomock.Any(), body).
+			DoAndReturn(func(_ any, _ string, header http.Header, _ []byte) (*domain.PaymentCallback, error) {
+				assert.Equal(suite.T(), "abc123", header.Get("X-Callback-Signature"))
+				return &domain.PaymentCallback{ID: 9, Outcome: domain.CallbackProcessed}, nil
+			})
+
+		resp, _ := suite.app.Test(suite.callback("xendit", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PaymentCallbackResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), uint64(9), data.CallbackID)
+		assert.Equal(suite.T(), "PROCESSED", data.Outcome)
+	})
+
+	suite.Run("Success - Duplicate Is Acknowledged", func() {
+		suite.mockPaymentService.EXPECT().HandleCallback(gomock.Any(), "xendit", gomock.Any(), gomock.Any()).
+			Return(&domain.PaymentCallback{Outcome: domain.CallbackDuplicate}, nil)
+
+		resp, _ := suite.app.Test(suite.callback("xendit", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	errorCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Unknown Provider", common.ErrUnknownPaymentProvider, http.StatusNotFound},
+		{"Invalid Signature", fmt.Errorf("%w: bad hmac", common.ErrInvalidCallbackSignature), http.StatusUnauthorized},
+		{"Invalid Payload", fmt.Errorf("%w: bad json", common.ErrInvalidCallbackPayload), http.StatusBadRequest},
+		{"Service Error", fmt.Errorf("db down"), http.StatusInternalServerError},
+	}
+	for _, tc := range errorCases {
+		suite.Run("Failure - "+tc.name, func() {
+			suite.mockPaymentService.EXPECT().HandleCallback(gomock.Any(), "xendit", gomock.Any(), gomock.Any()).Return(nil, tc.err)
+
+			resp, _ := suite.app.Test(suite.callback("xendit", body))
+			defer resp.Body.Close()
+			assert.Equal(suite.T(), tc.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestPaymentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PaymentHandlerTestSuite))
+}
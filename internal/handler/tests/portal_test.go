@@ -0,0 +1,148 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	portalhandler "github.com/fazamuttaqien/multifinance/internal/handler/portal"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PortalHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockPortalService *mocks.MockPartnerPortalServices
+}
+
+func (suite *PortalHandlerTestSuite) SetupTest() {
+	suite.mockPortalService = mocks.NewMockPartnerPortalServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-portal-handler")
+	handler := portalhandler.NewPartnerPortalHandler(suite.mockPortalService, meter, tracer, log)
+	partnerKey := func(c *fiber.Ctx) error {
+		c.Locals("partner", &domain.Partner{ID: 7})
+		c.Locals("sandbox", c.Get("X-Sandbox") == "true")
+		return c.Next()
+	}
+
+	suite.app = fiber.New()
+	suite.app.Get("/partner-api/me/transactions", partnerKey, handler.ListTransactions)
+	suite.app.Get("/partner-api/me/deliveries", partnerKey, handler.ListDeliveries)
+	suite.app.Get("/partner-api/me/usage", partnerKey, handler.GetUsage)
+	suite.app.Post("/partner-api/me/api-key/regenerate", partnerKey, handler.RegenerateAPIKey)
+	suite.app.Get("/unauthenticated/usage", handler.GetUsage)
+}
+
+func (suite *PortalHandlerTestSuite) TestListTransactions() {
+	suite.Run("Success - Sandbox Key", func() {
+		suite.mockPortalService.EXPECT().ListTransactions(gomock.Any(), uint64(7), true, gomock.Any()).
+			DoAndReturn(func(_ any, _ uint64, _ bool, params domain.Params) (*domain.Paginated, error) {
+				assert.Equal(suite.T(), string(domain.TransactionActive), params.Value("status"))
+				return params.Paginated([]domain.Transaction{{ID: 3, ContractNumber: "KTR-20250101-000003", IsSandbox: true}}, 1), nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/partner-api/me/transactions?status=active", nil)
+		req.Header.Set("X-Sandbox", "true")
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data []dto.TransactionResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		suite.Require().Len(data, 1)
+		assert.Equal(suite.T(), "KTR-20250101-000003", data[0].ContractNumber)
+		assert.True(suite.T(), data[0].Sandbox)
+	})
+
+	suite.Run("Failure - Unknown Status", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/transactions?status=lost", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *PortalHandlerTestSuite) TestListDeliveries() {
+	suite.mockPortalService.EXPECT().ListDeliveries(gomock.Any(), uint64(7), gomock.Any()).
+		DoAndReturn(func(_ any, _ uint64, params domain.Params) (*domain.Paginated, error) {
+			return params.Paginated([]domain.WebhookDelivery{{
+				ID:        5,
+				PartnerID: 7,
+				EventType: "transaction.created",
+				Payload:   `{"event":"transaction.created","data":{"contract_number":"KTR-20250101-000003"}}`,
+				Status:    domain.DeliveryFailed,
+				Attempts:  5,
+				LastError: "partner endpoint returned 500",
+			}}, 1), nil
+		})
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/deliveries?status=failed", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data []dto.WebhookDeliveryResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	suite.Require().Len(data, 1)
+	assert.Equal(suite.T(), "FAILED", data[0].Status)
+	assert.JSONEq(suite.T(), `{"event":"transaction.created","data":{"contract_number":"KTR-20250101-000003"}}`, string(data[0].Payload))
+}
+
+func (suite *PortalHandlerTestSuite) TestGetUsage() {
+	suite.Run("Success - Default Range", func() {
+		day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+		suite.mockPortalService.EXPECT().GetUsage(gomock.Any(), uint64(7), 7, gomock.Any()).
+			Return([]domain.APIUsage{{Day: day.AddDate(0, 0, -1), Allowed: 4}, {Day: day, Allowed: 9, Throttled: 1}}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/usage", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PartnerUsageResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), int64(13), data.Allowed)
+		assert.Equal(suite.T(), int64(1), data.Throttled)
+		suite.Require().Len(data.Days, 2)
+		assert.Equal(suite.T(), "2025-03-10", data.Days[1].Date)
+	})
+
+	suite.Run("Failure - Range Too Long", func() {
+		suite.mockPortalService.EXPECT().GetUsage(gomock.Any(), uint64(7), 90, gomock.Any()).Return(nil, common.ErrInvalidUsageDays)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/partner-api/me/usage?days=90", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - No Partner", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/unauthenticated/usage", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func (suite *PortalHandlerTestSuite) TestRegenerateAPIKey() {
+	suite.mockPortalService.EXPECT().RegenerateAPIKey(gomock.Any(), uint64(7), false).
+		Return(&dto.PartnerCredentialsResponse{PartnerID: 7, Status: "PRODUCTION", APIKey: "mf_live_new"}, nil)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/partner-api/me/api-key/regenerate", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data dto.PartnerCredentialsResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	assert.Equal(suite.T(), "mf_live_new", data.APIKey)
+}
+
+func TestPortalHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PortalHandlerTestSuite))
+}
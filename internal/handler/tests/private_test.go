@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	privatehandler "github.com/fazamuttaqien/multifinance/internal/handler/private"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const privateJWTSecret = "test-secret-key"
+
+type PrivateHandlerTestSuite struct {
+	suite.Suite
+	app                *fiber.App
+	mockPrivateService *mocks.MockPrivateService
+}
+
+func (suite *PrivateHandlerTestSuite) SetupTest() {
+	suite.mockPrivateService = mocks.NewMockPrivateService(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-private-handler")
+	handler := privatehandler.NewPrivateHandler(suite.mockPrivateService, session.New(), meter, tracer, log)
+	passwordChangeAuth := middleware.NewPasswordChangeAuthMiddleware(privateJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Post("/auth/login", handler.Login)
+	suite.app.Put("/auth/password", passwordChangeAuth, handler.ChangePassword)
+
+	// Route admin biasa untuk memastikan token wajib ganti password ditolak
+	suite.app.Get("/admin/customers", middleware.NewJWTAuthMiddleware(privateJWTSecret), func(c *fiber.Ctx) error {
+		return responder.Success(c, fiber.StatusOK, fiber.Map{"ok": true})
+	})
+}
+
+func (suite *PrivateHandlerTestSuite) TestLogin() {
+	suite.Run("Success - Seeded Admin Must Change Password", func() {
+		req := dto.LoginRequest{NIK: "0000000000000001", Password: "seed-admin-pass"}
+		suite.mockPrivateService.EXPECT().Login(gomock.Any(), req).
+			Return(&dto.LoginResponse{Token: "signed-token", MustChangePassword: true}, nil)
+
+		body := map[string]any{"nik": req.NIK, "password": req.Password}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/auth/login", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var result map[string]any
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &result)
+		assert.Equal(suite.T(), true, result["must_change_password"])
+
+		var cookie *http.Cookie
+		for _, c := range resp.Cookies() {
+			if c.Name == "private" {
+				cookie = c
+			}
+		}
+		require.NotNil(suite.T(), cookie)
+		assert.Equal(suite.T(), "signed-token", cookie.Value)
+	})
+
+	suite.Run("Failure - Wrong Password", func() {
+		suite.mockPrivateService.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, common.ErrInvalidCredentials)
+
+		body := map[string]any{"nik": "0000000000000001", "password": "admin123"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/auth/login", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func (suite *PrivateHandlerTestSuite) TestMustChangePassword() {
+	cookie := testutil.PasswordChangeCookie(suite.T(), privateJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Failure - Other Routes Are Blocked", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{cookie}, http.MethodGet, "/admin/customers", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("Success - Change Password Ends Session", func() {
+		req := dto.ChangePasswordRequest{CurrentPassword: "seed-admin-pass", NewPassword: "a-stronger-pass"}
+		suite.mockPrivateService.EXPECT().ChangePassword(gomock.Any(), uint64(1), req).Return(nil)
+
+		body := map[string]any{"current_password": req.CurrentPassword, "new_password": req.NewPassword}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{cookie}, http.MethodPut, "/auth/password", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var cleared bool
+		for _, c := range resp.Cookies() {
+			if c.Name == "private" && c.Value == "" {
+				cleared = true
+			}
+		}
+		assert.True(suite.T(), cleared)
+	})
+}
+
+func TestPrivateHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PrivateHandlerTestSuite))
+}
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,14 +18,21 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/dto"
 	profilehandler "github.com/fazamuttaqien/multifinance/internal/handler/profile"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 	"github.com/fazamuttaqien/multifinance/middleware"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
@@ -38,8 +46,8 @@ type ProfileHandlerTestSuite struct {
 	suite.Suite
 	app                *fiber.App
 	handler            *profilehandler.ProfileHandler
-	mockProfileService *MockProfileService
-	mockCloudinary     *MockCloudinaryService
+	mockProfileService *mocks.MockProfileServices
+	mockCloudinary     *mocks.MockCloudinaryService
 
 	store     *session.Store
 	jwtSecret string
@@ -52,8 +60,9 @@ type ProfileHandlerTestSuite struct {
 func (suite *ProfileHandlerTestSuite) SetupTest() {
 	rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	suite.mockProfileService = &MockProfileService{}
-	suite.mockCloudinary = &MockCloudinaryService{}
+	ctrl := gomock.NewController(suite.T())
+	suite.mockProfileService = mocks.NewMockProfileServices(ctrl)
+	suite.mockCloudinary = mocks.NewMockCloudinaryService(ctrl)
 
 	suite.store = session.New(session.Config{
 		KeyLookup: "cookie:test-keylookup",
@@ -98,12 +107,13 @@ func (suite *ProfileHandlerTestSuite) setupProfileApp() *fiber.App {
 		return c.JSON(fiber.Map{"csrf_token": token})
 	})
 
-	meApi := app.Group("/me", jwtAuth, requireCustomer)
+	meApi := app.Group("/me", jwtAuth, requireCustomer, datetime.Middleware(time.UTC, "id", "en"))
 	{
 		meApi.Get("/profile", suite.handler.GetMyProfile)
 		meApi.Put("/profile", customCSRF, suite.handler.UpdateMyProfile)
 		meApi.Get("/limits", suite.handler.GetMyLimits)
 		meApi.Get("/transactions", suite.handler.GetMyTransactions)
+		meApi.Get("/transactions/:contractNumber", suite.handler.GetMyTransactionDetail)
 	}
 
 	return app
@@ -123,21 +133,7 @@ func (suite *ProfileHandlerTestSuite) getCsrfToken() (string, []*http.Cookie) {
 }
 
 func (suite *ProfileHandlerTestSuite) getAuthCookieAndCsrfToken(userID uint64, role domain.Role) (string, []*http.Cookie) {
-	claims := &domain.JwtCustomClaims{
-		UserID: userID,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 1)),
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(suite.jwtSecret))
-	assert.NoError(suite.T(), err)
-
-	jwtCookie := &http.Cookie{
-		Name:  "private",
-		Value: signedToken,
-	}
+	jwtCookie := testutil.AuthCookie(suite.T(), suite.jwtSecret, userID, role)
 
 	csrfReq := httptest.NewRequest(http.MethodGet, "/csrf-token", nil)
 	csrfReq.AddCookie(jwtCookie)
@@ -178,27 +174,34 @@ func (suite *ProfileHandlerTestSuite) TestRegister_Success() {
 		"birth_place": "Surabaya",
 		"birth_date":  "1990-05-15",
 		"salary":      "5000000",
+		"email":       "test.user@example.com",
+		"phone":       "+6281234567890",
+		"otp_token":   "otp-token-1",
 	}
 	files := map[string]string{"ktp_photo": "ktp.jpg", "selfie_photo": "selfie.jpg"}
 
-	suite.mockCloudinary.MockUploadURL = "http://fake-url.com/image.jpg"
-	suite.mockCloudinary.MockUploadError = nil
+	uploadURL := "http://fake-url.com/image.jpg"
+	suite.mockCloudinary.EXPECT().
+		UploadImage(gomock.Any(), gomock.Any(), "multifinance").
+		Return(uploadURL, nil).
+		Times(2)
 
 	birthDate, err := time.Parse("2006-01-02", fields["birth_date"])
 	assert.NoError(suite.T(), err)
 
-	suite.mockProfileService.MockRegisterResult = &domain.Customer{
-		ID:         1,
-		NIK:        fields["nik"],
-		FullName:   fields["full_name"],
-		LegalName:  fields["legal_name"],
-		BirthPlace: fields["birth_place"],
-		BirthDate:  birthDate,
-		Salary:     5000000,
-		KtpUrl:     suite.mockCloudinary.MockUploadURL,
-		SelfieUrl:  suite.mockCloudinary.MockUploadURL,
-	}
-	suite.mockProfileService.MockError = nil
+	suite.mockProfileService.EXPECT().
+		Create(gomock.Any(), gomock.Any(), dto.RegistrationChecks{OTPToken: "otp-token-1"}).
+		Return(&domain.Customer{
+			ID:         1,
+			NIK:        fields["nik"],
+			FullName:   fields["full_name"],
+			LegalName:  fields["legal_name"],
+			BirthPlace: fields["birth_place"],
+			BirthDate:  birthDate,
+			Salary:     decimal.NewFromInt(5000000),
+			KtpUrl:     uploadURL,
+			SelfieUrl:  uploadURL,
+		}, nil)
 
 	req, contentType := createMultipartRequest(suite.T(), fields, files)
 	req.Header.Set("Content-Type", contentType)
@@ -212,9 +215,8 @@ func (suite *ProfileHandlerTestSuite) TestRegister_Success() {
 	defer resp.Body.Close()
 
 	assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
-	var result domain.Customer
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	assert.NoError(suite.T(), err)
+	var result dto.CustomerResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &result)
 	assert.Equal(suite.T(), fields["nik"], result.NIK)
 }
 
@@ -229,10 +231,60 @@ func (suite *ProfileHandlerTestSuite) TestRegister_CloudinaryUploadFails() {
 		"birth_place": "Test City",
 		"birth_date":  "2000-01-01",
 		"salary":      "5000000",
+		"email":       "test.user@example.com",
+		"phone":       "+6281234567890",
+		"otp_token":   "otp-token-1",
 	}
 	files := map[string]string{"ktp_photo": "ktp.jpg", "selfie_photo": "selfie.jpg"}
 
-	suite.mockCloudinary.MockUploadError = errors.New("connection timeout")
+	suite.mockCloudinary.EXPECT().
+		UploadImage(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return("", errors.New("connection timeout")).
+		Times(2)
+
+	req, contentType := createMultipartRequest(suite.T(), fields, files)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-CSRF-Token", csrfToken)
+	for _, c := range sessionCookies {
+		req.AddCookie(c)
+	}
+
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
+}
+
+func (suite *ProfileHandlerTestSuite) TestRegister_OneUploadFailsDeletesOther() {
+	csrfToken, sessionCookies := suite.getCsrfToken()
+
+	fields := map[string]string{
+		"nik":         "1234567890123456",
+		"full_name":   "Test User",
+		"legal_name":  "TEST USER",
+		"password":    "testpass123",
+		"birth_place": "Test City",
+		"birth_date":  "2000-01-01",
+		"salary":      "5000000",
+		"email":       "test.user@example.com",
+		"phone":       "+6281234567890",
+		"otp_token":   "otp-token-1",
+	}
+	files := map[string]string{"ktp_photo": "ktp.jpg", "selfie_photo": "selfie.jpg"}
+
+	suite.mockCloudinary.EXPECT().
+		UploadImage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, file *multipart.FileHeader, _ string) (string, error) {
+			if file.Filename == "selfie.jpg" {
+				return "", errors.New("connection timeout")
+			}
+			return "http://fake-url.com/ktp.jpg", nil
+		}).
+		Times(2)
+	suite.mockCloudinary.EXPECT().
+		DeleteImage(gomock.Any(), "http://fake-url.com/ktp.jpg").
+		Return(nil)
 
 	req, contentType := createMultipartRequest(suite.T(), fields, files)
 	req.Header.Set("Content-Type", contentType)
@@ -259,12 +311,23 @@ func (suite *ProfileHandlerTestSuite) TestRegister_ServiceReturnsConflict() {
 		"birth_place": "Test City",
 		"birth_date":  "2000-01-01",
 		"salary":      "5000000",
+		"email":       "test.user@example.com",
+		"phone":       "+6281234567890",
+		"otp_token":   "otp-token-1",
 	}
 	files := map[string]string{"ktp_photo": "ktp.jpg", "selfie_photo": "selfie.jpg"}
 
-	suite.mockCloudinary.MockUploadURL = "http://fake-url.com/image.jpg"
-	suite.mockCloudinary.MockUploadError = nil
-	suite.mockProfileService.MockError = errors.New("nik already registered")
+	suite.mockCloudinary.EXPECT().
+		UploadImage(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return("http://fake-url.com/image.jpg", nil).
+		Times(2)
+	suite.mockProfileService.EXPECT().
+		Create(gomock.Any(), gomock.Any(), dto.RegistrationChecks{OTPToken: "otp-token-1"}).
+		Return(nil, common.ErrNIKExists)
+	suite.mockCloudinary.EXPECT().
+		DeleteImage(gomock.Any(), "http://fake-url.com/image.jpg").
+		Return(nil).
+		Times(2)
 
 	req, contentType := createMultipartRequest(suite.T(), fields, files)
 	req.Header.Set("Content-Type", contentType)
@@ -280,15 +343,102 @@ func (suite *ProfileHandlerTestSuite) TestRegister_ServiceReturnsConflict() {
 	assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
 }
 
+func (suite *ProfileHandlerTestSuite) TestRegister_InvalidReferralCode() {
+	csrfToken, sessionCookies := suite.getCsrfToken()
+
+	fields := map[string]string{
+		"nik":           "1234567890123456",
+		"full_name":     "Test User",
+		"legal_name":    "TEST USER",
+		"password":      "testpass123",
+		"birth_place":   "Test City",
+		"birth_date":    "2000-01-01",
+		"salary":        "5000000",
+		"email":         "test.user@example.com",
+		"phone":         "+6281234567890",
+		"referral_code": "NOPE1234",
+		"otp_token":     "otp-token-1",
+	}
+	files := map[string]string{"ktp_photo": "ktp.jpg", "selfie_photo": "selfie.jpg"}
+
+	suite.mockCloudinary.EXPECT().
+		UploadImage(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return("http://fake-url.com/image.jpg", nil).
+		Times(2)
+	suite.mockProfileService.EXPECT().
+		Create(gomock.Any(), gomock.Any(), dto.RegistrationChecks{ReferralCode: "NOPE1234", OTPToken: "otp-token-1"}).
+		DoAndReturn(func(_ context.Context, customer *domain.Customer, _ dto.RegistrationChecks) (*domain.Customer, error) {
+			assert.Equal(suite.T(), "device-123", customer.RegistrationDeviceID)
+			assert.NotEmpty(suite.T(), customer.RegistrationIP)
+			return nil, common.ErrReferralCodeNotFound
+		})
+	suite.mockCloudinary.EXPECT().DeleteImage(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	req, contentType := createMultipartRequest(suite.T(), fields, files)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-CSRF-Token", csrfToken)
+	req.Header.Set("X-Device-ID", "device-123")
+	for _, c := range sessionCookies {
+		req.AddCookie(c)
+	}
+
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *ProfileHandlerTestSuite) TestRegister_OTPRequired() {
+	csrfToken, sessionCookies := suite.getCsrfToken()
+
+	fields := map[string]string{
+		"nik":         "1234567890123456",
+		"full_name":   "Test User",
+		"legal_name":  "TEST USER",
+		"password":    "testpass123",
+		"birth_place": "Test City",
+		"birth_date":  "2000-01-01",
+		"salary":      "5000000",
+		"email":       "test.user@example.com",
+		"phone":       "+6281234567890",
+		"otp_token":   "already-used",
+	}
+	files := map[string]string{"ktp_photo": "ktp.jpg", "selfie_photo": "selfie.jpg"}
+
+	suite.mockCloudinary.EXPECT().
+		UploadImage(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return("http://fake-url.com/image.jpg", nil).
+		Times(2)
+	suite.mockProfileService.EXPECT().
+		Create(gomock.Any(), gomock.Any(), dto.RegistrationChecks{OTPToken: "already-used"}).
+		Return(nil, common.ErrOTPRequired)
+	suite.mockCloudinary.EXPECT().DeleteImage(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	req, contentType := createMultipartRequest(suite.T(), fields, files)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-CSRF-Token", csrfToken)
+	for _, c := range sessionCookies {
+		req.AddCookie(c)
+	}
+
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+}
+
 func (suite *ProfileHandlerTestSuite) TestGetMyProfile_Success() {
 	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
 
-	suite.mockProfileService.MockGetMyProfileResult = &domain.Customer{
-		ID:       2,
-		FullName: "Alan Smith",
-		Role:     domain.CustomerRole,
-	}
-	suite.mockProfileService.MockError = nil
+	suite.mockProfileService.EXPECT().
+		GetMyProfile(gomock.Any(), uint64(2)).
+		Return(&domain.Customer{
+			ID:       2,
+			FullName: "Alan Smith",
+			Role:     domain.CustomerRole,
+		}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
 	for _, c := range authCookies {
@@ -300,17 +450,61 @@ func (suite *ProfileHandlerTestSuite) TestGetMyProfile_Success() {
 	defer resp.Body.Close()
 
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
-	var customer domain.Customer
-	err = json.NewDecoder(resp.Body).Decode(&customer)
-	assert.NoError(suite.T(), err)
+	var customer dto.CustomerResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &customer)
 	assert.Equal(suite.T(), uint64(2), customer.ID)
 }
 
+func (suite *ProfileHandlerTestSuite) TestGetMyProfile_ETag() {
+	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+
+	customer := &domain.Customer{ID: 2, FullName: "Alan Smith", Role: domain.CustomerRole}
+	suite.mockProfileService.EXPECT().
+		GetMyProfile(gomock.Any(), uint64(2)).
+		Return(customer, nil).
+		Times(3)
+
+	get := func(ifNoneMatch string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := suite.app.Test(req)
+		suite.Require().NoError(err)
+		return resp
+	}
+
+	first := get("")
+	defer first.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, first.StatusCode)
+	etag := first.Header.Get("ETag")
+	assert.NotEmpty(suite.T(), etag)
+	assert.Equal(suite.T(), "private, no-cache", first.Header.Get("Cache-Control"))
+
+	cached := get(`"stale", W/` + etag)
+	defer cached.Body.Close()
+	assert.Equal(suite.T(), http.StatusNotModified, cached.StatusCode)
+	assert.Equal(suite.T(), etag, cached.Header.Get("ETag"))
+	body, _ := io.ReadAll(cached.Body)
+	assert.Empty(suite.T(), body)
+
+	customer.FullName = "Alan Smithee"
+	changed := get(etag)
+	defer changed.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, changed.StatusCode)
+	assert.NotEqual(suite.T(), etag, changed.Header.Get("ETag"))
+}
+
 func (suite *ProfileHandlerTestSuite) TestUpdateMyProfile_Success() {
 	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
-	suite.mockProfileService.MockError = nil
+	suite.mockProfileService.EXPECT().
+		Update(gomock.Any(), uint64(2), gomock.Any(), dto.ContactChecks{}).
+		Return(nil)
 
-	updateBody := `{"full_name": "Jane Doe", "salary": 12000000}`
+	updateBody := `{"version": 1, "full_name": "Jane Doe", "salary": 12000000}`
 
 	req := httptest.NewRequest(http.MethodPut, "/me/profile", strings.NewReader(updateBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -327,25 +521,216 @@ func (suite *ProfileHandlerTestSuite) TestUpdateMyProfile_Success() {
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
 }
 
+func (suite *ProfileHandlerTestSuite) TestUpdateMyProfile_Version() {
+	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+
+	send := func(body, ifMatch string) *http.Response {
+		req := httptest.NewRequest(http.MethodPut, "/me/profile", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+		resp, err := suite.app.Test(req)
+		suite.Require().NoError(err)
+		return resp
+	}
+
+	current := &domain.Customer{ID: 2, FullName: "Alan Smith", Role: domain.CustomerRole, Version: 5}
+	etag, err := responder.ETag(dto.CustomerToResponse(*current))
+	suite.Require().NoError(err)
+
+	suite.Run("Failure - Version Missing", func() {
+		resp := send(`{"full_name": "Jane Doe"}`, "")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusPreconditionRequired, resp.StatusCode)
+		envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		if assert.NotNil(suite.T(), envelope.Error) {
+			assert.Equal(suite.T(), "version_required", envelope.Error.Code)
+		}
+	})
+
+	suite.Run("Success - If-Match Resolves Version", func() {
+		suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(2)).Return(current, nil)
+		suite.mockProfileService.EXPECT().
+			Update(gomock.Any(), uint64(2), gomock.Any(), dto.ContactChecks{}).
+			DoAndReturn(func(_ context.Context, _ uint64, req domain.Customer, _ dto.ContactChecks) error {
+				assert.Equal(suite.T(), uint64(5), req.Version)
+				return nil
+			})
+
+		resp := send(`{"full_name": "Jane Doe"}`, etag)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - If-Match Stale", func() {
+		suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(2)).Return(current, nil)
+
+		resp := send(`{"full_name": "Jane Doe"}`, `"0000"`)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+		envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		if assert.NotNil(suite.T(), envelope.Error) {
+			assert.Equal(suite.T(), "version_conflict", envelope.Error.Code)
+		}
+	})
+
+	suite.Run("Failure - Concurrent Modification", func() {
+		suite.mockProfileService.EXPECT().
+			Update(gomock.Any(), uint64(2), gomock.Any(), dto.ContactChecks{}).
+			Return(common.ErrVersionConflict)
+
+		resp := send(`{"version": 4, "full_name": "Jane Doe"}`, "")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *ProfileHandlerTestSuite) TestUpdateMyProfile_SalaryNeedsProof() {
+	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+	suite.mockProfileService.EXPECT().
+		Update(gomock.Any(), uint64(2), gomock.Any(), dto.ContactChecks{}).
+		Return(common.ErrSalaryNeedsProof)
+
+	updateBody := `{"version": 1, "full_name": "Jane Doe", "salary": 20000000}`
+
+	req := httptest.NewRequest(http.MethodPut, "/me/profile", strings.NewReader(updateBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CSRF-Token", csrfToken)
+
+	for _, c := range authCookies {
+		req.AddCookie(c)
+	}
+
+	resp, err := suite.app.Test(req)
+	assert.NoError(suite.T(), err)
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func (suite *ProfileHandlerTestSuite) TestUpdateMyProfile_Contact() {
+	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+
+	send := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPut, "/me/profile", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+		resp, err := suite.app.Test(req)
+		suite.Require().NoError(err)
+		return resp
+	}
+
+	suite.Run("Success - Normalized Email With Token", func() {
+		suite.mockProfileService.EXPECT().
+			Update(gomock.Any(), uint64(2), gomock.Any(), dto.ContactChecks{EmailOTPToken: "token-1"}).
+			DoAndReturn(func(_ any, _ uint64, customer domain.Customer, _ dto.ContactChecks) error {
+				assert.Equal(suite.T(), "jane@example.com", customer.Email)
+				return nil
+			})
+
+		resp := send(`{"version": 1, "full_name": "Jane Doe", "email": "Jane@Example.com", "email_otp_token": "token-1"}`)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Verified", func() {
+		suite.mockProfileService.EXPECT().
+			Update(gomock.Any(), uint64(2), gomock.Any(), dto.ContactChecks{}).
+			Return(common.ErrOTPRequired)
+
+		resp := send(`{"version": 1, "full_name": "Jane Doe", "phone": "+6281234567890"}`)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Phone Taken", func() {
+		suite.mockProfileService.EXPECT().
+			Update(gomock.Any(), uint64(2), gomock.Any(), gomock.Any()).
+			Return(common.ErrPhoneExists)
+
+		resp := send(`{"version": 1, "full_name": "Jane Doe", "phone": "+6281234567890", "phone_otp_token": "token-2"}`)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Phone", func() {
+		resp := send(`{"version": 1, "full_name": "Jane Doe", "phone": "0812-3456"}`)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *ProfileHandlerTestSuite) TestUpdateMyProfile_Language() {
+	csrfToken, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+
+	suite.Run("Success", func() {
+		suite.mockProfileService.EXPECT().
+			Update(gomock.Any(), uint64(2), gomock.Any(), dto.ContactChecks{}).
+			DoAndReturn(func(_ any, _ uint64, customer domain.Customer, _ dto.ContactChecks) error {
+				assert.Equal(suite.T(), domain.LanguageEnglish, customer.Language)
+				return nil
+			})
+
+		req := httptest.NewRequest(http.MethodPut, "/me/profile", strings.NewReader(`{"version": 1, "full_name": "Jane Doe", "language": "en"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+
+		resp, err := suite.app.Test(req)
+		assert.NoError(suite.T(), err)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Unsupported Language", func() {
+		req := httptest.NewRequest(http.MethodPut, "/me/profile", strings.NewReader(`{"version": 1, "full_name": "Jane Doe", "language": "fr"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-CSRF-Token", csrfToken)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+
+		resp, err := suite.app.Test(req)
+		assert.NoError(suite.T(), err)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
 func (suite *ProfileHandlerTestSuite) TestGetMyLimits_Success() {
 	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
 
 	expectedLimits := []dto.LimitDetailResponse{
 		{
 			TenorMonths:    3,
-			LimitAmount:    1000000,
-			UsedAmount:     200000,
-			RemainingLimit: 800000,
+			LimitAmount:    decimal.NewFromInt(1000000),
+			UsedAmount:     decimal.NewFromInt(200000),
+			RemainingLimit: decimal.NewFromInt(800000),
 		},
 		{
 			TenorMonths:    6,
-			LimitAmount:    2000000,
-			UsedAmount:     0,
-			RemainingLimit: 2000000,
+			LimitAmount:    decimal.NewFromInt(2000000),
+			UsedAmount:     decimal.NewFromInt(0),
+			RemainingLimit: decimal.NewFromInt(2000000),
 		},
 	}
-	suite.mockProfileService.MockGetMyLimitsResult = expectedLimits
-	suite.mockProfileService.MockError = nil
+	suite.mockProfileService.EXPECT().
+		GetMyLimits(gomock.Any(), uint64(2)).
+		Return(expectedLimits, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/me/limits", nil)
 	for _, c := range authCookies {
@@ -359,20 +744,56 @@ func (suite *ProfileHandlerTestSuite) TestGetMyLimits_Success() {
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
 
 	var actualLimits []dto.LimitDetailResponse
-	err = json.NewDecoder(resp.Body).Decode(&actualLimits)
-	assert.NoError(suite.T(), err)
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &actualLimits)
 
 	assert.Len(suite.T(), actualLimits, 2)
 	assert.Equal(suite.T(), uint8(3), actualLimits[0].TenorMonths)
-	assert.Equal(suite.T(), float64(800000), actualLimits[0].RemainingLimit)
+	assert.Equal(suite.T(), "800000", actualLimits[0].RemainingLimit.String())
 	assert.Equal(suite.T(), uint8(6), actualLimits[1].TenorMonths)
 }
 
+func (suite *ProfileHandlerTestSuite) TestGetMyLimits_ETag() {
+	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+
+	limits := []dto.LimitDetailResponse{
+		{TenorMonths: 3, LimitAmount: decimal.NewFromInt(1000000), UsedAmount: decimal.NewFromInt(200000), RemainingLimit: decimal.NewFromInt(800000)},
+	}
+	suite.mockProfileService.EXPECT().
+		GetMyLimits(gomock.Any(), uint64(2)).
+		Return(limits, nil).
+		Times(2)
+
+	req := httptest.NewRequest(http.MethodGet, "/me/limits", nil)
+	for _, c := range authCookies {
+		req.AddCookie(c)
+	}
+	resp, err := suite.app.Test(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	assert.NotEmpty(suite.T(), etag)
+
+	// Pemakaian limit berubah tanpa updated_at, tag tetap harus berganti
+	limits[0].UsedAmount = decimal.NewFromInt(300000)
+	limits[0].RemainingLimit = decimal.NewFromInt(700000)
+
+	req = httptest.NewRequest(http.MethodGet, "/me/limits", nil)
+	for _, c := range authCookies {
+		req.AddCookie(c)
+	}
+	req.Header.Set("If-None-Match", etag)
+	resp, err = suite.app.Test(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+}
+
 func (suite *ProfileHandlerTestSuite) TestGetMyLimits_ServiceReturnsError() {
 	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
 
-	suite.mockProfileService.MockGetMyLimitsResult = nil
-	suite.mockProfileService.MockError = errors.New("Failed to get limits")
+	suite.mockProfileService.EXPECT().
+		GetMyLimits(gomock.Any(), uint64(2)).
+		Return(nil, errors.New("Failed to get limits"))
 
 	req := httptest.NewRequest(http.MethodGet, "/me/limits", nil)
 	for _, c := range authCookies {
@@ -385,10 +806,11 @@ func (suite *ProfileHandlerTestSuite) TestGetMyLimits_ServiceReturnsError() {
 
 	assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
 
-	var bodyMap map[string]string
-	err = json.NewDecoder(resp.Body).Decode(&bodyMap)
-	assert.NoError(suite.T(), err)
-	assert.Contains(suite.T(), bodyMap["error"], "Failed to get limits")
+	envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+	if assert.NotNil(suite.T(), envelope.Error) {
+		assert.Equal(suite.T(), "service_error", envelope.Error.Code)
+		assert.Contains(suite.T(), envelope.Error.Message, "Failed to get limits")
+	}
 }
 
 func (suite *ProfileHandlerTestSuite) TestGetMyTransactions_SuccessWithQueryParameters() {
@@ -403,8 +825,9 @@ func (suite *ProfileHandlerTestSuite) TestGetMyTransactions_SuccessWithQueryPara
 		Limit:      5,
 		TotalPages: 1,
 	}
-	suite.mockProfileService.MockGetMyTransactionsResult = expectedResponse
-	suite.mockProfileService.MockError = nil
+	suite.mockProfileService.EXPECT().
+		GetMyTransactions(gomock.Any(), uint64(2), domain.Params{Filters: []query.Condition{{Column: "status", Value: "ACTIVE"}}, Page: 1, Limit: 5}).
+		Return(expectedResponse, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/me/transactions?status=ACTIVE&page=1&limit=5", nil)
 	for _, c := range authCookies {
@@ -417,34 +840,29 @@ func (suite *ProfileHandlerTestSuite) TestGetMyTransactions_SuccessWithQueryPara
 
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
 
-	var actualResponse struct {
-		Data       []domain.Transaction `json:"data"`
-		Total      int64                `json:"total"`
-		Page       int                  `json:"page"`
-		Limit      int                  `json:"limit"`
-		TotalPages int                  `json:"total_pages"`
-	}
-	err = json.NewDecoder(resp.Body).Decode(&actualResponse)
-	assert.NoError(suite.T(), err)
+	var transactions []dto.TransactionResponse
+	envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, &transactions)
+	suite.Require().NotNil(envelope.Meta.Pagination)
 
-	assert.Equal(suite.T(), int64(1), actualResponse.Total)
-	assert.Equal(suite.T(), 1, actualResponse.Page)
-	assert.Equal(suite.T(), 5, actualResponse.Limit)
-	assert.Len(suite.T(), actualResponse.Data, 1)
-	assert.Equal(suite.T(), "Laptop", actualResponse.Data[0].AssetName)
+	assert.Equal(suite.T(), int64(1), envelope.Meta.Pagination.Total)
+	assert.Equal(suite.T(), 1, envelope.Meta.Pagination.Page)
+	assert.Equal(suite.T(), 5, envelope.Meta.Pagination.Limit)
+	assert.Len(suite.T(), transactions, 1)
+	assert.Equal(suite.T(), "Laptop", transactions[0].AssetName)
 }
 
 func (suite *ProfileHandlerTestSuite) TestGetMyTransactions_SuccessWithoutQueryParameters() {
 	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
 
-	suite.mockProfileService.MockGetMyTransactionsResult = &domain.Paginated{
-		Data:       []domain.Transaction{},
-		Total:      0,
-		Page:       1,
-		Limit:      10, // Default yang disetel di handler
-		TotalPages: 0,
-	}
-	suite.mockProfileService.MockError = nil
+	suite.mockProfileService.EXPECT().
+		GetMyTransactions(gomock.Any(), uint64(2), domain.Params{Page: 1, Limit: 10}).
+		Return(&domain.Paginated{
+			Data:       []domain.Transaction{},
+			Total:      0,
+			Page:       1,
+			Limit:      10, // Default yang disetel di handler
+			TotalPages: 0,
+		}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/me/transactions", nil)
 	for _, c := range authCookies {
@@ -457,11 +875,132 @@ func (suite *ProfileHandlerTestSuite) TestGetMyTransactions_SuccessWithoutQueryP
 
 	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
 
-	var actualResponse domain.Paginated
-	err = json.NewDecoder(resp.Body).Decode(&actualResponse)
+	envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+	suite.Require().NotNil(envelope.Meta.Pagination)
+	assert.Equal(suite.T(), 1, envelope.Meta.Pagination.Page)
+	assert.Equal(suite.T(), 10, envelope.Meta.Pagination.Limit)
+}
+
+func (suite *ProfileHandlerTestSuite) TestGetMyTransactionDetail() {
+	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+
+	suite.Run("Success", func() {
+		suite.mockProfileService.EXPECT().
+			GetMyTransactionDetail(gomock.Any(), uint64(2), "KTR-001").
+			Return(&dto.TransactionDetailResponse{ContractNumber: "KTR-001", TenorMonths: 6, OutstandingBalance: decimal.NewFromInt(7_600_000)}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/transactions/KTR-001", nil)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+
+		resp, err := suite.app.Test(req)
+		assert.NoError(suite.T(), err)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		var detail dto.TransactionDetailResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &detail)
+		assert.Equal(suite.T(), "7600000", detail.OutstandingBalance.String())
+	})
+
+	suite.Run("Not Found", func() {
+		suite.mockProfileService.EXPECT().
+			GetMyTransactionDetail(gomock.Any(), uint64(2), "KTR-404").
+			Return(nil, common.ErrTransactionNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/transactions/KTR-404", nil)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+
+		resp, err := suite.app.Test(req)
+		assert.NoError(suite.T(), err)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *ProfileHandlerTestSuite) TestGetMyTransactionDetail_Locale() {
+	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+	transactionDate := time.Date(2026, 10, 17, 7, 5, 0, 0, time.UTC)
+
+	get := func(headers map[string]string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/me/transactions/KTR-001", nil)
+		for _, c := range authCookies {
+			req.AddCookie(c)
+		}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		resp, err := suite.app.Test(req)
+		assert.NoError(suite.T(), err)
+		return resp
+	}
+
+	suite.Run("Success - Client Zone And Language", func() {
+		suite.mockProfileService.EXPECT().
+			GetMyTransactionDetail(gomock.Any(), uint64(2), "KTR-001").
+			Return(&dto.TransactionDetailResponse{ContractNumber: "KTR-001", TransactionDate: transactionDate}, nil)
+
+		resp := get(map[string]string{"X-Timezone": "Asia/Jakarta", "Accept-Language": "id-ID,id;q=0.9,en;q=0.8"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "id", resp.Header.Get("Content-Language"))
+
+		var detail map[string]any
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &detail)
+		assert.Equal(suite.T(), "2026-10-17T14:05:00+07:00", detail["transaction_date"])
+		assert.Equal(suite.T(), "17 Oktober 2026 14:05 WIB", detail["transaction_date_text"])
+	})
+
+	suite.Run("Success - Defaults To UTC", func() {
+		suite.mockProfileService.EXPECT().
+			GetMyTransactionDetail(gomock.Any(), uint64(2), "KTR-001").
+			Return(&dto.TransactionDetailResponse{ContractNumber: "KTR-001", TransactionDate: transactionDate.In(time.FixedZone("WITA", 8*3600))}, nil)
+
+		resp := get(map[string]string{"Accept-Language": "en-US"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var detail map[string]any
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &detail)
+		assert.Equal(suite.T(), "2026-10-17T07:05:00Z", detail["transaction_date"])
+		assert.Equal(suite.T(), "17 October 2026 07:05 UTC", detail["transaction_date_text"])
+	})
+
+	suite.Run("Failure - Unknown Zone", func() {
+		resp := get(map[string]string{"X-Timezone": "Mars/Olympus"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *ProfileHandlerTestSuite) TestGetMyProfile_BirthDateIgnoresZone() {
+	_, authCookies := suite.getAuthCookieAndCsrfToken(2, domain.CustomerRole)
+
+	suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(2)).Return(&domain.Customer{
+		ID:        2,
+		BirthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+		CreatedAt: time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/me/profile", nil)
+	for _, c := range authCookies {
+		req.AddCookie(c)
+	}
+	req.Header.Set("X-Timezone", "America/New_York")
+
+	resp, err := suite.app.Test(req)
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), 1, actualResponse.Page)
-	assert.Equal(suite.T(), 10, actualResponse.Limit)
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var profile map[string]any
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &profile)
+	assert.Equal(suite.T(), "1990-05-17T00:00:00Z", profile["birth_date"])
+	assert.Equal(suite.T(), "2026-01-31T15:00:00-05:00", profile["created_at"])
 }
 
 func TestProfileHandlerSuite(t *testing.T) {
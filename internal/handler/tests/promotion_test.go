@@ -0,0 +1,128 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	promotionhandler "github.com/fazamuttaqien/multifinance/internal/handler/promotion"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const promotionJWTSecret = "test-secret-key"
+
+type PromotionHandlerTestSuite struct {
+	suite.Suite
+	app                  *fiber.App
+	mockPromotionService *mocks.MockPromotionServices
+}
+
+func (suite *PromotionHandlerTestSuite) SetupTest() {
+	suite.mockPromotionService = mocks.NewMockPromotionServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-promotion-handler")
+	handler := promotionhandler.NewPromotionHandler(suite.mockPromotionService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(promotionJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/promotions", handler.ListPromotions)
+	suite.app.Put("/admin/promotions/:code", jwtAuth, handler.SetPromotion)
+	suite.app.Delete("/admin/promotions/:code", handler.DeactivatePromotion)
+}
+
+func (suite *PromotionHandlerTestSuite) TestListPromotions() {
+	suite.mockPromotionService.EXPECT().ListPromotions(gomock.Any()).
+		Return([]domain.Promotion{{
+			Code:          "HEMAT50",
+			Target:        domain.PromotionAdminFee,
+			DiscountType:  domain.DiscountPercent,
+			DiscountValue: decimal.RequireFromString("0.5"),
+			Active:        true,
+		}}, nil)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/promotions", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data []map[string]any
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	suite.Require().Len(data, 1)
+	assert.Equal(suite.T(), "HEMAT50", data[0]["code"])
+	assert.Equal(suite.T(), "ADMIN_FEE", data[0]["target"])
+	assert.Equal(suite.T(), "PERCENT", data[0]["discount_type"])
+	assert.Equal(suite.T(), "0.5", data[0]["discount_value"])
+	assert.Nil(suite.T(), data[0]["partner_id"])
+}
+
+func (suite *PromotionHandlerTestSuite) TestSetPromotion() {
+	adminCookie := testutil.AuthCookie(suite.T(), promotionJWTSecret, 1, domain.AdminRole)
+	body := map[string]any{
+		"target":           "ADMIN_FEE",
+		"discount_type":    "PERCENT",
+		"discount_value":   "0.5",
+		"valid_from":       "2025-06-01T00:00:00Z",
+		"valid_until":      "2025-07-01T00:00:00Z",
+		"max_per_customer": 1,
+		"active":           true,
+	}
+
+	suite.Run("Success", func() {
+		suite.mockPromotionService.EXPECT().SetPromotion(gomock.Any(), "HEMAT50", uint64(1), gomock.Any()).
+			Return(&domain.Promotion{Code: "HEMAT50", Active: true}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/promotions/HEMAT50", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PromotionResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "HEMAT50", data.Code)
+		assert.True(suite.T(), data.Active)
+	})
+
+	suite.Run("Failure - Window Ends Before Start", func() {
+		invalid := map[string]any{}
+		for k, v := range body {
+			invalid[k] = v
+		}
+		invalid["valid_until"] = "2025-05-01T00:00:00Z"
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/promotions/HEMAT50", invalid))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Code", func() {
+		suite.mockPromotionService.EXPECT().SetPromotion(gomock.Any(), "hemat-50", uint64(1), gomock.Any()).
+			Return(nil, common.ErrInvalidPromoCode)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/promotions/hemat-50", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *PromotionHandlerTestSuite) TestDeactivatePromotion() {
+	suite.Run("Failure - Not Found", func() {
+		suite.mockPromotionService.EXPECT().DeactivatePromotion(gomock.Any(), "UNKNOWN").Return(common.ErrPromotionNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/promotions/UNKNOWN", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestPromotionHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PromotionHandlerTestSuite))
+}
@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	quotahandler "github.com/fazamuttaqien/multifinance/internal/handler/quota"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const quotaJWTSecret = "test-secret-key"
+
+type QuotaHandlerTestSuite struct {
+	suite.Suite
+	app              *fiber.App
+	mockQuotaService *mocks.MockPartnerQuotaServices
+}
+
+func (suite *QuotaHandlerTestSuite) SetupTest() {
+	suite.mockQuotaService = mocks.NewMockPartnerQuotaServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-quota-handler")
+	handler := quotahandler.NewQuotaHandler(suite.mockQuotaService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(quotaJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/partners/:partnerId/quota", handler.GetQuota)
+	suite.app.Put("/admin/partners/:partnerId/quota", jwtAuth, handler.SetQuota)
+	suite.app.Delete("/admin/partners/:partnerId/quota", handler.DeleteQuota)
+}
+
+func (suite *QuotaHandlerTestSuite) TestGetQuota() {
+	suite.Run("Success - With Usage", func() {
+		suite.mockQuotaService.EXPECT().GetQuota(gomock.Any(), uint64(7), gomock.Any()).
+			Return(
+				&domain.PartnerQuota{PartnerID: 7, DailyCount: 100, DailyVolume: decimal.NewFromInt(5000000)},
+				&domain.QuotaUsage{Count: 12, Volume: decimal.NewFromInt(600000), ResetsAt: time.Now().Add(time.Hour)},
+				nil,
+			)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/partners/7/quota", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body dto.PartnerQuotaResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), int64(100), body.DailyCount)
+		if assert.NotNil(suite.T(), body.Usage) {
+			assert.Equal(suite.T(), int64(12), body.Usage.Count)
+		}
+	})
+
+	suite.Run("Failure - Partner Not Found", func() {
+		suite.mockQuotaService.EXPECT().GetQuota(gomock.Any(), uint64(99), gomock.Any()).Return(nil, nil, common.ErrPartnerNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/partners/99/quota", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *QuotaHandlerTestSuite) TestSetQuota() {
+	adminCookie := testutil.AuthCookie(suite.T(), quotaJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		suite.mockQuotaService.EXPECT().SetQuota(gomock.Any(), uint64(7), uint64(1), gomock.Any()).
+			Return(&domain.PartnerQuota{PartnerID: 7, DailyCount: 100, UpdatedBy: 1, UpdatedAt: time.Now()}, nil)
+
+		body := map[string]any{"daily_count": 100, "daily_volume": "0"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/partners/7/quota", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Negative Cap", func() {
+		body := map[string]any{"daily_count": -1}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPut, "/admin/partners/7/quota", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *QuotaHandlerTestSuite) TestDeleteQuota() {
+	suite.Run("Failure - Not Found", func() {
+		suite.mockQuotaService.EXPECT().DeleteQuota(gomock.Any(), uint64(7)).Return(common.ErrPartnerQuotaNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/partners/7/quota", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestQuotaHandlerSuite(t *testing.T) {
+	suite.Run(t, new(QuotaHandlerTestSuite))
+}
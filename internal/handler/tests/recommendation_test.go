@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	recommendationhandler "github.com/fazamuttaqien/multifinance/internal/handler/recommendation"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type RecommendationHandlerTestSuite struct {
+	suite.Suite
+	app                       *fiber.App
+	mockRecommendationService *mocks.MockLimitRecommendationServices
+}
+
+func (suite *RecommendationHandlerTestSuite) SetupTest() {
+	suite.mockRecommendationService = mocks.NewMockLimitRecommendationServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-recommendation-handler")
+	handler := recommendationhandler.NewRecommendationHandler(suite.mockRecommendationService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers/:customerId/limit-recommendations", handler.RecommendLimits)
+}
+
+func (suite *RecommendationHandlerTestSuite) TestRecommendLimits() {
+	suite.Run("Success", func() {
+		suite.mockRecommendationService.EXPECT().Recommend(gomock.Any(), uint64(1)).
+			Return(&dto.LimitRecommendationResponse{
+				CustomerID: 1,
+				RiskTier:   "MEDIUM",
+				Limits:     []dto.LimitRecommendationItem{{TenorMonths: 3, LimitAmount: decimal.NewFromInt(9_000_000), Version: 2}},
+			}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/1/limit-recommendations", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		// Data harus bisa langsung dipakai sebagai request SetLimits
+		var body dto.SetLimits
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		version := uint64(2)
+		assert.Equal(suite.T(), []dto.LimitItemRequest{{TenorMonths: 3, LimitAmount: decimal.NewFromInt(9_000_000), Version: &version}}, body.Limits)
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockRecommendationService.EXPECT().Recommend(gomock.Any(), uint64(9)).Return(nil, common.ErrCustomerNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/9/limit-recommendations", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid ID", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/abc/limit-recommendations", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestRecommendationHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RecommendationHandlerTestSuite))
+}
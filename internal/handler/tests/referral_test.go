@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	referralhandler "github.com/fazamuttaqien/multifinance/internal/handler/referral"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const referralJWTSecret = "test-secret-key"
+
+type ReferralHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockReferralService *mocks.MockReferralServices
+	customerCookie      *http.Cookie
+}
+
+func (suite *ReferralHandlerTestSuite) SetupTest() {
+	suite.mockReferralService = mocks.NewMockReferralServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-referral-handler")
+	handler := referralhandler.NewReferralHandler(suite.mockReferralService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(referralJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/me/referral-code", jwtAuth, handler.GetMyCode)
+
+	suite.customerCookie = testutil.AuthCookie(suite.T(), referralJWTSecret, 2, domain.CustomerRole)
+}
+
+func (suite *ReferralHandlerTestSuite) TestGetMyCode() {
+	suite.Run("Success", func() {
+		suite.mockReferralService.EXPECT().GetMyCode(gomock.Any(), uint64(2)).
+			Return(&domain.ReferralCode{CustomerID: 2, Code: "BUDI2345"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/referral-code", nil)
+		req.AddCookie(suite.customerCookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.ReferralCodeResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "BUDI2345", data.Code)
+	})
+
+	suite.Run("Failure - Service Error", func() {
+		suite.mockReferralService.EXPECT().GetMyCode(gomock.Any(), uint64(2)).Return(nil, errors.New("db down"))
+
+		req := httptest.NewRequest(http.MethodGet, "/me/referral-code", nil)
+		req.AddCookie(suite.customerCookie)
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestReferralHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ReferralHandlerTestSuite))
+}
@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	regionhandler "github.com/fazamuttaqien/multifinance/internal/handler/region"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type RegionHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockRegionService *mocks.MockRegionServices
+}
+
+func (suite *RegionHandlerTestSuite) SetupTest() {
+	suite.mockRegionService = mocks.NewMockRegionServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-region-handler")
+	handler := regionhandler.NewRegionHandler(suite.mockRegionService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/regions", handler.ListRegions)
+	suite.app.Put("/admin/regions/:code", handler.SetRegion)
+	suite.app.Put("/admin/partners/:partnerId/region", handler.SetPartnerRegion)
+}
+
+func (suite *RegionHandlerTestSuite) TestSetRegion() {
+	body := map[string]any{"name": "Jakarta", "active": true}
+
+	suite.Run("Success", func() {
+		suite.mockRegionService.EXPECT().SetRegion(gomock.Any(), "JKT", gomock.Any()).
+			Return(&domain.Region{Code: "JKT", Name: "Jakarta", Active: true}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/regions/JKT", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Active Flag", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/regions/JKT", map[string]any{"name": "Jakarta"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Code", func() {
+		suite.mockRegionService.EXPECT().SetRegion(gomock.Any(), "jkt-1", gomock.Any()).
+			Return(nil, common.ErrInvalidRegionCode)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/regions/jkt-1", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *RegionHandlerTestSuite) TestSetPartnerRegion() {
+	body := map[string]any{"region_code": "JKT", "branch_code": "JKT-01"}
+
+	suite.Run("Success", func() {
+		suite.mockRegionService.EXPECT().SetPartnerRegion(gomock.Any(), uint64(7), gomock.Any()).
+			Return(&domain.Partner{ID: 7, RegionCode: "JKT", BranchCode: "JKT-01"}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/partners/7/region", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PartnerRegionResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "JKT", data.RegionCode)
+		assert.Equal(suite.T(), "JKT-01", data.BranchCode)
+	})
+
+	suite.Run("Failure - Inactive Region", func() {
+		suite.mockRegionService.EXPECT().SetPartnerRegion(gomock.Any(), uint64(7), gomock.Any()).
+			Return(nil, common.ErrRegionNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/partners/7/region", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Partner Not Found", func() {
+		suite.mockRegionService.EXPECT().SetPartnerRegion(gomock.Any(), uint64(99), gomock.Any()).
+			Return(nil, common.ErrPartnerNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/partners/99/region", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestRegionHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RegionHandlerTestSuite))
+}
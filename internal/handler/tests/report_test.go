@@ -0,0 +1,241 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	reporthandler "github.com/fazamuttaqien/multifinance/internal/handler/report"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ReportHandlerTestSuite struct {
+	suite.Suite
+	app               *fiber.App
+	mockReportService *mocks.MockReportServices
+}
+
+func (suite *ReportHandlerTestSuite) SetupTest() {
+	suite.mockReportService = mocks.NewMockReportServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-report-handler")
+	handler := reporthandler.NewReportHandler(suite.mockReportService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/reports/interest-accrual", handler.InterestAccrual)
+	suite.app.Get("/admin/reports/aging", handler.Aging)
+	suite.app.Get("/admin/reports/partner-commission", handler.PartnerCommission)
+	suite.app.Get("/admin/reports/referrals", handler.ReferralConversion)
+}
+
+func (suite *ReportHandlerTestSuite) TestInterestAccrual() {
+	report := &dto.InterestAccrualReportResponse{
+		Month:    "2025-06",
+		Currency: "IDR",
+		Rows: []dto.InterestAccrualRowResponse{
+			{TenorMonths: 3, Segment: "DIRECT", ContractCount: 2, AccruedInterest: decimal.RequireFromString("400000.5")},
+		},
+		ContractCount: 2,
+		TotalInterest: decimal.RequireFromString("400000.5"),
+	}
+
+	suite.Run("Success - JSON", func() {
+		suite.mockReportService.EXPECT().InterestAccrual(gomock.Any(), "2025-06").Return(report, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/interest-accrual?month=2025-06", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Contains(suite.T(), resp.Header.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON)
+	})
+
+	suite.Run("Success - CSV", func() {
+		suite.mockReportService.EXPECT().InterestAccrual(gomock.Any(), "2025-06").Return(report, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/interest-accrual?month=2025-06&format=csv", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Contains(suite.T(), resp.Header.Get(fiber.HeaderContentType), "text/csv")
+		assert.Contains(suite.T(), resp.Header.Get(fiber.HeaderContentDisposition), "interest-accrual-2025-06.csv")
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(suite.T(),
+			"month,tenor_months,segment,contract_count,accrued_interest_idr\n"+
+				"2025-06,3,DIRECT,2,400000.50\n"+
+				"2025-06,,TOTAL,2,400000.50\n",
+			string(body))
+	})
+
+	suite.Run("Failure - Invalid Month", func() {
+		suite.mockReportService.EXPECT().InterestAccrual(gomock.Any(), "june").Return(nil, common.ErrInvalidReportMonth)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/interest-accrual?month=june", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unsupported Format", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/interest-accrual?month=2025-06&format=xlsx", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *ReportHandlerTestSuite) TestPartnerCommission() {
+	report := &dto.PartnerCommissionReportResponse{
+		Month:    "2025-06",
+		Currency: "IDR",
+		Rows: []dto.PartnerCommissionRowResponse{
+			{PartnerID: 7, PartnerName: "Dealer Jaya", ContractCount: 2, OTRAmount: decimal.NewFromInt(20000000), AdminFee: decimal.NewFromInt(400000), Commission: decimal.RequireFromString("200000.5")},
+		},
+		ContractCount:   2,
+		TotalOTRAmount:  decimal.NewFromInt(20000000),
+		TotalAdminFee:   decimal.NewFromInt(400000),
+		TotalCommission: decimal.RequireFromString("200000.5"),
+	}
+
+	suite.Run("Success - JSON", func() {
+		suite.mockReportService.EXPECT().PartnerCommission(gomock.Any(), "2025-06").Return(report, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/partner-commission?month=2025-06", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.PartnerCommissionReportResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Len(suite.T(), data.Rows, 1)
+		assert.Equal(suite.T(), "200000.5", data.TotalCommission.String())
+	})
+
+	suite.Run("Success - CSV", func() {
+		suite.mockReportService.EXPECT().PartnerCommission(gomock.Any(), "2025-06").Return(report, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/partner-commission?month=2025-06&format=csv", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Contains(suite.T(), resp.Header.Get(fiber.HeaderContentDisposition), "partner-commission-2025-06.csv")
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(suite.T(),
+			"month,partner_id,partner_name,contract_count,otr_amount_idr,admin_fee_idr,commission_idr\n"+
+				"2025-06,7,Dealer Jaya,2,20000000.00,400000.00,200000.50\n"+
+				"2025-06,,TOTAL,2,20000000.00,400000.00,200000.50\n",
+			string(body))
+	})
+
+	suite.Run("Failure - Invalid Month", func() {
+		suite.mockReportService.EXPECT().PartnerCommission(gomock.Any(), "june").Return(nil, common.ErrInvalidReportMonth)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/partner-commission?month=june", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *ReportHandlerTestSuite) TestReferralConversion() {
+	report := &dto.ReferralConversionReportResponse{
+		Month: "2025-06",
+		Rows: []dto.ReferralConversionRowResponse{
+			{ReferrerID: 3, ReferrerName: "Budi", Code: "BUDI2345", Referred: 4, Rejected: 1, Converted: 1, ConversionRate: decimal.RequireFromString("0.3333")},
+		},
+		Referred:  4,
+		Rejected:  1,
+		Converted: 1,
+	}
+
+	suite.Run("Success - CSV", func() {
+		suite.mockReportService.EXPECT().ReferralConversion(gomock.Any(), "2025-06").Return(report, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/referrals?month=2025-06&format=csv", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Contains(suite.T(), resp.Header.Get(fiber.HeaderContentDisposition), "referral-conversion-2025-06.csv")
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(suite.T(),
+			"month,referrer_id,referrer_name,code,referred,rejected,converted,conversion_rate\n"+
+				"2025-06,3,Budi,BUDI2345,4,1,1,0.3333\n"+
+				"2025-06,,TOTAL,,4,1,1,\n",
+			string(body))
+	})
+
+	suite.Run("Failure - Invalid Month", func() {
+		suite.mockReportService.EXPECT().ReferralConversion(gomock.Any(), "june").Return(nil, common.ErrInvalidReportMonth)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/referrals?month=june", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *ReportHandlerTestSuite) TestAging() {
+	report := &dto.AgingReportResponse{
+		AsOf:     "2025-06-14",
+		Source:   "snapshot",
+		Currency: "IDR",
+		Portfolio: []dto.AgingBucketResponse{
+			{Bucket: "0", ContractCount: 2, Outstanding: decimal.NewFromInt(200000)},
+			{Bucket: "90+", ContractCount: 1, Outstanding: decimal.RequireFromString("50000.5")},
+		},
+		ByTenor: []dto.AgingTenorResponse{
+			{TenorMonths: 3, Buckets: []dto.AgingBucketResponse{
+				{Bucket: "0", ContractCount: 2, Outstanding: decimal.NewFromInt(200000)},
+				{Bucket: "90+", ContractCount: 1, Outstanding: decimal.RequireFromString("50000.5")},
+			}},
+		},
+		ContractCount:    3,
+		TotalOutstanding: decimal.RequireFromString("250000.5"),
+	}
+
+	suite.Run("Success - JSON", func() {
+		suite.mockReportService.EXPECT().Aging(gomock.Any(), "").Return(report, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/aging", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data dto.AgingReportResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "snapshot", data.Source)
+		assert.Equal(suite.T(), int64(3), data.ContractCount)
+	})
+
+	suite.Run("Success - CSV", func() {
+		suite.mockReportService.EXPECT().Aging(gomock.Any(), "2025-06-15").Return(report, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/aging?date=2025-06-15&format=csv", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Contains(suite.T(), resp.Header.Get(fiber.HeaderContentDisposition), "aging-2025-06-14.csv")
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(suite.T(),
+			"as_of,tenor_months,bucket,contract_count,outstanding_idr\n"+
+				"2025-06-14,3,0,2,200000.00\n"+
+				"2025-06-14,3,90+,1,50000.50\n"+
+				"2025-06-14,ALL,0,2,200000.00\n"+
+				"2025-06-14,ALL,90+,1,50000.50\n",
+			string(body))
+	})
+
+	suite.Run("Failure - Invalid Date", func() {
+		suite.mockReportService.EXPECT().Aging(gomock.Any(), "yesterday").Return(nil, common.ErrInvalidReportDate)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/reports/aging?date=yesterday", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestReportHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ReportHandlerTestSuite))
+}
@@ -0,0 +1,146 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	restrictionhandler "github.com/fazamuttaqien/multifinance/internal/handler/restriction"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const restrictionJWTSecret = "test-secret-key"
+
+type RestrictionHandlerTestSuite struct {
+	suite.Suite
+	app                    *fiber.App
+	mockRestrictionService *mocks.MockCustomerRestrictionServices
+}
+
+func (suite *RestrictionHandlerTestSuite) SetupTest() {
+	suite.mockRestrictionService = mocks.NewMockCustomerRestrictionServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-restriction-handler")
+	handler := restrictionhandler.NewCustomerRestrictionHandler(suite.mockRestrictionService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(restrictionJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers/:customerId/restrictions", handler.ListRestrictions)
+	suite.app.Post("/admin/customers/:customerId/suspension", jwtAuth, handler.Suspend)
+	suite.app.Delete("/admin/customers/:customerId/suspension", jwtAuth, handler.Unsuspend)
+	suite.app.Post("/admin/customers/:customerId/limits/:tenorMonths/freeze", jwtAuth, handler.FreezeLimit)
+	suite.app.Delete("/admin/customers/:customerId/limits/:tenorMonths/freeze", jwtAuth, handler.UnfreezeLimit)
+}
+
+func (suite *RestrictionHandlerTestSuite) TestSuspend() {
+	adminCookie := testutil.AuthCookie(suite.T(), restrictionJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success", func() {
+		suite.mockRestrictionService.EXPECT().Suspend(gomock.Any(), uint64(7), uint64(1), gomock.Any()).
+			DoAndReturn(func(_ any, _, _ uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error) {
+				assert.Equal(suite.T(), domain.RestrictionFraudSuspected, req.Reason)
+				return &domain.CustomerRestriction{ID: 4, CustomerID: 7, Reason: req.Reason, Note: req.Note, CreatedBy: 1}, nil
+			})
+
+		body := map[string]any{"reason": "FRAUD_SUSPECTED", "note": "Chargeback pattern"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/suspension", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var data dto.CustomerRestrictionResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "ACCOUNT", data.Scope)
+		assert.True(suite.T(), data.Active)
+	})
+
+	suite.Run("Failure - Unknown Reason", func() {
+		body := map[string]any{"reason": "BORED"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/suspension", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Suspended", func() {
+		suite.mockRestrictionService.EXPECT().Suspend(gomock.Any(), uint64(7), uint64(1), gomock.Any()).Return(nil, common.ErrRestrictionExists)
+
+		body := map[string]any{"reason": "LEGAL_HOLD"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/suspension", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *RestrictionHandlerTestSuite) TestUnsuspend() {
+	adminCookie := testutil.AuthCookie(suite.T(), restrictionJWTSecret, 2, domain.AdminRole)
+	suite.mockRestrictionService.EXPECT().Unsuspend(gomock.Any(), uint64(7), uint64(2)).Return(common.ErrRestrictionNotFound)
+
+	resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodDelete, "/admin/customers/7/suspension", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *RestrictionHandlerTestSuite) TestFreezeLimit() {
+	adminCookie := testutil.AuthCookie(suite.T(), restrictionJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success - Timed", func() {
+		expiresAt := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+		tenorID := uint(2)
+		suite.mockRestrictionService.EXPECT().FreezeLimit(gomock.Any(), uint64(7), uint8(6), uint64(1), gomock.Any()).
+			DoAndReturn(func(_ any, _ uint64, _ uint8, _ uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error) {
+				assert.True(suite.T(), expiresAt.Equal(*req.ExpiresAt))
+				return &domain.CustomerRestriction{ID: 5, CustomerID: 7, TenorID: &tenorID, TenorMonths: 6, Reason: req.Reason, ExpiresAt: req.ExpiresAt}, nil
+			})
+
+		body := map[string]any{"reason": "DELINQUENCY", "expires_at": expiresAt.Format(time.RFC3339)}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/limits/6/freeze", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var data dto.CustomerRestrictionResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Equal(suite.T(), "TENOR", data.Scope)
+		assert.Equal(suite.T(), uint8(6), data.TenorMonths)
+	})
+
+	suite.Run("Failure - Tenor Not Found", func() {
+		suite.mockRestrictionService.EXPECT().FreezeLimit(gomock.Any(), uint64(7), uint8(9), uint64(1), gomock.Any()).Return(nil, common.ErrTenorNotFound)
+
+		body := map[string]any{"reason": "DELINQUENCY"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/customers/7/limits/9/freeze", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *RestrictionHandlerTestSuite) TestListRestrictions() {
+	past := time.Now().Add(-time.Hour)
+	suite.mockRestrictionService.EXPECT().ListRestrictions(gomock.Any(), uint64(7)).Return([]domain.CustomerRestriction{
+		{ID: 2, CustomerID: 7, Reason: domain.RestrictionKYCReview},
+		{ID: 1, CustomerID: 7, Reason: domain.RestrictionOther, ExpiresAt: &past},
+	}, nil)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/7/restrictions", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var data []dto.CustomerRestrictionResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	suite.Require().Len(data, 2)
+	assert.True(suite.T(), data[0].Active)
+	assert.False(suite.T(), data[1].Active)
+}
+
+func TestRestrictionHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RestrictionHandlerTestSuite))
+}
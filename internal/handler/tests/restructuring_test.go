@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	restructuringhandler "github.com/fazamuttaqien/multifinance/internal/handler/restructuring"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const restructuringJWTSecret = "test-secret-key"
+
+type RestructuringHandlerTestSuite struct {
+	suite.Suite
+	app                      *fiber.App
+	mockRestructuringService *mocks.MockRestructuringServices
+}
+
+func (suite *RestructuringHandlerTestSuite) SetupTest() {
+	suite.mockRestructuringService = mocks.NewMockRestructuringServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-restructuring-handler")
+	handler := restructuringhandler.NewRestructuringHandler(suite.mockRestructuringService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(restructuringJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/restructurings", handler.ListRestructurings)
+	suite.app.Post("/admin/restructurings", jwtAuth, handler.Propose)
+	suite.app.Get("/admin/restructurings/:id", handler.GetRestructuring)
+	suite.app.Post("/admin/restructurings/:id/review", jwtAuth, handler.Review)
+}
+
+func (suite *RestructuringHandlerTestSuite) TestPropose() {
+	makerCookie := testutil.AuthCookie(suite.T(), restructuringJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success - Extend Tenor", func() {
+		req := dto.RestructuringRequest{
+			ContractNumber: "KTR-001",
+			Type:           domain.RestructuringExtendTenor,
+			NewTenorMonths: 12,
+			Reason:         "Customer income dropped",
+		}
+		suite.mockRestructuringService.EXPECT().Propose(gomock.Any(), uint64(1), req).
+			Return(&dto.RestructuringResponse{Restructuring: &domain.Restructuring{ID: 1, Status: domain.RestructuringPending}}, nil)
+
+		body := map[string]any{"contract_number": "KTR-001", "type": "EXTEND_TENOR", "new_tenor_months": 12, "reason": "Customer income dropped"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{makerCookie}, http.MethodPost, "/admin/restructurings", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Target Installment", func() {
+		body := map[string]any{"contract_number": "KTR-001", "type": "REDUCE_INSTALLMENT", "reason": "Customer income dropped"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{makerCookie}, http.MethodPost, "/admin/restructurings", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Transaction Not Active", func() {
+		suite.mockRestructuringService.EXPECT().Propose(gomock.Any(), uint64(1), gomock.Any()).
+			Return(nil, common.ErrTransactionNotActive)
+
+		body := map[string]any{"contract_number": "KTR-002", "type": "EXTEND_TENOR", "new_tenor_months": 12, "reason": "Customer income dropped"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{makerCookie}, http.MethodPost, "/admin/restructurings", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func (suite *RestructuringHandlerTestSuite) TestReview() {
+	checkerCookie := testutil.AuthCookie(suite.T(), restructuringJWTSecret, 2, domain.AdminRole)
+
+	suite.Run("Success - Approved", func() {
+		suite.mockRestructuringService.EXPECT().
+			Review(gomock.Any(), uint64(5), uint64(2), dto.RestructuringReviewRequest{Status: domain.RestructuringApproved, Note: "ok"}).
+			Return(&dto.RestructuringResponse{Restructuring: &domain.Restructuring{ID: 5, Status: domain.RestructuringApproved}}, nil)
+
+		body := map[string]any{"status": "APPROVED", "note": "ok"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{checkerCookie}, http.MethodPost, "/admin/restructurings/5/review", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Maker Cannot Approve", func() {
+		suite.mockRestructuringService.EXPECT().Review(gomock.Any(), uint64(6), uint64(2), gomock.Any()).
+			Return(nil, common.ErrRestructureSelf)
+
+		body := map[string]any{"status": "APPROVED"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{checkerCookie}, http.MethodPost, "/admin/restructurings/6/review", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func (suite *RestructuringHandlerTestSuite) TestListAndGet() {
+	suite.Run("List - Pending Filter", func() {
+		suite.mockRestructuringService.EXPECT().
+			ListRestructurings(gomock.Any(), domain.Params{Filters: []query.Condition{{Column: "status", Value: "PENDING"}}, Page: 1, Limit: 10}).
+			Return(&domain.Paginated{Data: []domain.Restructuring{{ID: 1}}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/restructurings?status=pending", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("List - Invalid Filter", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/restructurings?status=DONE", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Get - Not Found", func() {
+		suite.mockRestructuringService.EXPECT().GetRestructuring(gomock.Any(), uint64(9)).Return(nil, common.ErrRestructureNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/restructurings/9", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestRestructuringHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RestructuringHandlerTestSuite))
+}
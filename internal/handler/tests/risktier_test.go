@@ -0,0 +1,173 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	risktierhandler "github.com/fazamuttaqien/multifinance/internal/handler/risktier"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const riskTierJWTSecret = "test-secret-key"
+
+type RiskTierHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockRiskTierService *mocks.MockRiskTierServices
+	adminCookie         *http.Cookie
+}
+
+func (suite *RiskTierHandlerTestSuite) SetupTest() {
+	suite.mockRiskTierService = mocks.NewMockRiskTierServices(gomock.NewController(suite.T()))
+	suite.adminCookie = testutil.AuthCookie(suite.T(), riskTierJWTSecret, 1, domain.AdminRole)
+
+	meter, tracer, log := testutil.Telemetry("test-risk-tier-handler")
+	handler := risktierhandler.NewRiskTierHandler(suite.mockRiskTierService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(riskTierJWTSecret)
+	requireAdmin := middleware.RequireRole(domain.AdminRole)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/risk-tier-rules", jwtAuth, requireAdmin, handler.ListRules)
+	suite.app.Post("/admin/risk-tier-rules", jwtAuth, requireAdmin, handler.CreateRule)
+	suite.app.Put("/admin/risk-tier-rules/:id", jwtAuth, requireAdmin, handler.UpdateRule)
+	suite.app.Delete("/admin/risk-tier-rules/:id", jwtAuth, requireAdmin, handler.DeleteRule)
+	suite.app.Get("/admin/customers/:customerId/risk-tier", jwtAuth, requireAdmin, handler.GetCustomerTier)
+	suite.app.Put("/admin/customers/:customerId/credit-score", jwtAuth, requireAdmin, handler.SetCreditScore)
+}
+
+func (suite *RiskTierHandlerTestSuite) TestRules() {
+	suite.Run("Success - Create", func() {
+		minScore := 700
+		suite.mockRiskTierService.EXPECT().
+			CreateRule(gomock.Any(), uint64(1), dto.RiskTierRuleRequest{Priority: 2, Tier: domain.RiskTierHigh, MinScore: &minScore}).
+			Return(&domain.RiskTierRule{ID: 4, Priority: 2, Tier: domain.RiskTierHigh, MinScore: &minScore, UpdatedBy: 1}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPost, "/admin/risk-tier-rules", map[string]any{"priority": 2, "tier": "HIGH", "min_score": 700}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var body dto.RiskTierRuleResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), uint64(4), body.ID)
+		assert.Equal(suite.T(), 700, *body.MinScore)
+		assert.Nil(suite.T(), body.MaxScore)
+	})
+
+	suite.Run("Failure - Unknown Tier", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPost, "/admin/risk-tier-rules", map[string]any{"priority": 2, "tier": "PLATINUM"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Bounds", func() {
+		suite.mockRiskTierService.EXPECT().CreateRule(gomock.Any(), uint64(1), gomock.Any()).
+			Return(nil, common.ErrInvalidRiskTierRule)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPost, "/admin/risk-tier-rules", map[string]any{"tier": "LOW", "min_score": 800, "max_score": 700}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Success - List", func() {
+		suite.mockRiskTierService.EXPECT().ListRules(gomock.Any()).
+			Return([]domain.RiskTierRule{{ID: 1, Tier: domain.RiskTierLow}, {ID: 2, Tier: domain.RiskTierHigh}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/risk-tier-rules", nil)
+		req.AddCookie(suite.adminCookie)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body []dto.RiskTierRuleResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Len(suite.T(), body, 2)
+	})
+
+	suite.Run("Failure - Update Not Found", func() {
+		suite.mockRiskTierService.EXPECT().UpdateRule(gomock.Any(), uint64(9), uint64(1), gomock.Any()).
+			Return(nil, common.ErrRiskTierRuleNotFound)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/risk-tier-rules/9", map[string]any{"tier": "MEDIUM"}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Success - Delete", func() {
+		suite.mockRiskTierService.EXPECT().DeleteRule(gomock.Any(), uint64(4)).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/risk-tier-rules/4", nil)
+		req.AddCookie(suite.adminCookie)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Delete Not Found", func() {
+		suite.mockRiskTierService.EXPECT().DeleteRule(gomock.Any(), uint64(9)).Return(common.ErrRiskTierRuleNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/risk-tier-rules/9", nil)
+		req.AddCookie(suite.adminCookie)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *RiskTierHandlerTestSuite) TestCustomerTier() {
+	suite.Run("Failure - Not Calculated", func() {
+		suite.mockRiskTierService.EXPECT().GetCustomerTier(gomock.Any(), uint64(7)).Return(nil, common.ErrRiskTierNotCalculated)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/customers/7/risk-tier", nil)
+		req.AddCookie(suite.adminCookie)
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Success - Set Credit Score", func() {
+		score := 720
+		suite.mockRiskTierService.EXPECT().SetCreditScore(gomock.Any(), uint64(7), dto.CreditScoreRequest{Score: &score}).
+			Return(&domain.CustomerRiskTier{CustomerID: 7, Tier: domain.RiskTierHigh, CreditScore: &score}, nil)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/customers/7/credit-score", map[string]any{"score": 720}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body dto.CustomerRiskTierResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), domain.RiskTierHigh, body.Tier)
+	})
+
+	suite.Run("Failure - Score Out Of Range", func() {
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPut, "/admin/customers/7/credit-score", map[string]any{"score": 1200}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Token", func() {
+		customerCookie := testutil.AuthCookie(suite.T(), riskTierJWTSecret, 7, domain.CustomerRole)
+
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{customerCookie}, http.MethodPut, "/admin/customers/7/credit-score", map[string]any{"score": 720}))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestRiskTierHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RiskTierHandlerTestSuite))
+}
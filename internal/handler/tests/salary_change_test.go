@@ -0,0 +1,158 @@
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	salarychangehandler "github.com/fazamuttaqien/multifinance/internal/handler/salarychange"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const salaryChangeJWTSecret = "test-secret-key"
+
+type SalaryChangeHandlerTestSuite struct {
+	suite.Suite
+	app                     *fiber.App
+	mockSalaryChangeService *mocks.MockSalaryChangeServices
+	mockCloudinary          *mocks.MockCloudinaryService
+}
+
+func (suite *SalaryChangeHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.mockSalaryChangeService = mocks.NewMockSalaryChangeServices(ctrl)
+	suite.mockCloudinary = mocks.NewMockCloudinaryService(ctrl)
+
+	meter, tracer, log := testutil.Telemetry("test-salary-change-handler")
+	handler := salarychangehandler.NewSalaryChangeHandler(suite.mockSalaryChangeService, suite.mockCloudinary, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(salaryChangeJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Post("/me/salary-changes", jwtAuth, handler.RequestChange)
+	suite.app.Get("/admin/salary-changes", jwtAuth, handler.ListChanges)
+	suite.app.Post("/admin/salary-changes/:id/review", jwtAuth, handler.Review)
+}
+
+func (suite *SalaryChangeHandlerTestSuite) newPayslipRequest(salary string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	suite.Require().NoError(writer.WriteField("salary", salary))
+	part, err := writer.CreateFormFile("payslip", "payslip.jpg")
+	suite.Require().NoError(err)
+	_, err = io.WriteString(part, "dummy content")
+	suite.Require().NoError(err)
+	suite.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/me/salary-changes", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(testutil.AuthCookie(suite.T(), salaryChangeJWTSecret, 2, domain.CustomerRole))
+	return req
+}
+
+func (suite *SalaryChangeHandlerTestSuite) TestRequestChange() {
+	suite.Run("Success - Pending Review", func() {
+		suite.mockCloudinary.EXPECT().UploadImage(gomock.Any(), gomock.Any(), "multifinance").Return("https://example.com/payslip.jpg", nil)
+		suite.mockSalaryChangeService.EXPECT().
+			RequestChange(gomock.Any(), uint64(2), decimal.NewFromInt(15000000), "https://example.com/payslip.jpg").
+			Return(&domain.SalaryChange{ID: 1, CustomerID: 2, Status: domain.SalaryChangePending}, nil)
+
+		resp, _ := suite.app.Test(suite.newPayslipRequest("15000000"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Change Already Pending", func() {
+		suite.mockCloudinary.EXPECT().UploadImage(gomock.Any(), gomock.Any(), "multifinance").Return("https://example.com/payslip.jpg", nil)
+		suite.mockSalaryChangeService.EXPECT().RequestChange(gomock.Any(), uint64(2), gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrSalaryChangeExists)
+		suite.mockCloudinary.EXPECT().DeleteImage(gomock.Any(), "https://example.com/payslip.jpg").Return(nil)
+
+		resp, _ := suite.app.Test(suite.newPayslipRequest("15000000"))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Payslip", func() {
+		req := httptest.NewRequest(http.MethodPost, "/me/salary-changes", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), salaryChangeJWTSecret, 2, domain.CustomerRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *SalaryChangeHandlerTestSuite) TestListChanges() {
+	suite.Run("Success - Pending Filter", func() {
+		suite.mockSalaryChangeService.EXPECT().
+			ListChanges(gomock.Any(), domain.Params{Filters: []query.Condition{{Column: "status", Value: "PENDING"}}, Page: 1, Limit: 10}).
+			Return(&domain.Paginated{Data: []domain.SalaryChange{{ID: 1}}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/salary-changes?status=pending", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), salaryChangeJWTSecret, 1, domain.AdminRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Status", func() {
+		req := httptest.NewRequest(http.MethodGet, "/admin/salary-changes?status=lost", nil)
+		req.AddCookie(testutil.AuthCookie(suite.T(), salaryChangeJWTSecret, 1, domain.AdminRole))
+
+		resp, _ := suite.app.Test(req)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *SalaryChangeHandlerTestSuite) TestReview() {
+	adminCookie := testutil.AuthCookie(suite.T(), salaryChangeJWTSecret, 1, domain.AdminRole)
+
+	suite.Run("Success - Approved", func() {
+		suite.mockSalaryChangeService.EXPECT().
+			Review(gomock.Any(), uint64(5), uint64(1), dto.SalaryChangeReviewRequest{Status: domain.SalaryChangeApproved, Note: "slip valid"}).
+			Return(&domain.SalaryChange{ID: 5, Status: domain.SalaryChangeApproved}, nil)
+
+		body := map[string]any{"status": "APPROVED", "note": "slip valid"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/salary-changes/5/review", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid Decision", func() {
+		body := map[string]any{"status": "PENDING"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/salary-changes/5/review", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Reviewed", func() {
+		suite.mockSalaryChangeService.EXPECT().Review(gomock.Any(), uint64(6), uint64(1), gomock.Any()).
+			Return(nil, common.ErrSalaryChangeReviewed)
+
+		body := map[string]any{"status": "REJECTED"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{adminCookie}, http.MethodPost, "/admin/salary-changes/6/review", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestSalaryChangeHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SalaryChangeHandlerTestSuite))
+}
@@ -0,0 +1,89 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SecurityHeadersTestSuite struct {
+	suite.Suite
+}
+
+func newSecuredApp(cfg middleware.SecurityConfig) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.NewSecurityHeadersMiddleware(cfg))
+	app.Use(middleware.NewCORSMiddleware(cfg))
+	app.Get("/ping", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	return app
+}
+
+func (suite *SecurityHeadersTestSuite) TestSecurityHeaders() {
+	app := newSecuredApp(middleware.SecurityConfig{HSTSMaxAge: 31536000})
+
+	suite.Run("HTTPS Request", func() {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(fiber.HeaderXForwardedProto, "https")
+
+		resp, err := app.Test(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+		assert.Equal(suite.T(), "DENY", resp.Header.Get(fiber.HeaderXFrameOptions))
+		assert.Equal(suite.T(), "max-age=31536000; includeSubDomains", resp.Header.Get(fiber.HeaderStrictTransportSecurity))
+	})
+
+	suite.Run("Plain HTTP Has No HSTS", func() {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/ping", nil))
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), "DENY", resp.Header.Get(fiber.HeaderXFrameOptions))
+		assert.Empty(suite.T(), resp.Header.Get(fiber.HeaderStrictTransportSecurity))
+	})
+}
+
+func (suite *SecurityHeadersTestSuite) TestCORS() {
+	preflight := func(app *fiber.App, origin string) *http.Response {
+		req := httptest.NewRequest(http.MethodOptions, "/ping", nil)
+		req.Header.Set(fiber.HeaderOrigin, origin)
+		req.Header.Set(fiber.HeaderAccessControlRequestMethod, http.MethodGet)
+
+		resp, err := app.Test(req)
+		suite.Require().NoError(err)
+		return resp
+	}
+
+	app := newSecuredApp(middleware.SecurityConfig{AllowOrigins: []string{"https://dashboard.example.com"}})
+
+	suite.Run("Allowed Origin", func() {
+		resp := preflight(app, "https://dashboard.example.com")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), "https://dashboard.example.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	})
+
+	suite.Run("Unknown Origin", func() {
+		resp := preflight(app, "https://evil.example.com")
+		defer resp.Body.Close()
+		assert.Empty(suite.T(), resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	})
+
+	suite.Run("No Origins Configured", func() {
+		resp := preflight(newSecuredApp(middleware.SecurityConfig{}), "https://dashboard.example.com")
+		defer resp.Body.Close()
+		assert.Empty(suite.T(), resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	})
+}
+
+func TestSecurityHeadersSuite(t *testing.T) {
+	suite.Run(t, new(SecurityHeadersTestSuite))
+}
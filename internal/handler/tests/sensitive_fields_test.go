@@ -0,0 +1,172 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	adminhandler "github.com/fazamuttaqien/multifinance/internal/handler/admin"
+	partnerhandler "github.com/fazamuttaqien/multifinance/internal/handler/partner"
+	profilehandler "github.com/fazamuttaqien/multifinance/internal/handler/profile"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const sensitiveJWTSecret = "test-sensitive-secret-key"
+
+const (
+	sensitivePasswordHash = "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z3ZxjvhlQKXQDfGHSWpeRtvi"
+	sensitiveKtpURL       = "https://res.cloudinary.com/demo/image/upload/ktp/3201010101900001.jpg"
+	sensitiveSelfieURL    = "https://res.cloudinary.com/demo/image/upload/selfie/3201010101900001.jpg"
+)
+
+// Nama field dan nilai yang tidak boleh muncul di response mana pun
+var sensitiveFragments = []string{
+	"password", "ktp_url", "ktpurl", "selfie_url", "selfieurl", "key_hash", "keyhash",
+	strings.ToLower(sensitivePasswordHash), strings.ToLower(sensitiveKtpURL), strings.ToLower(sensitiveSelfieURL),
+}
+
+type SensitiveFieldsTestSuite struct {
+	suite.Suite
+	app                *fiber.App
+	mockAdminService   *mocks.MockAdminServices
+	mockProfileService *mocks.MockProfileServices
+	mockPartnerService *mocks.MockPartnerServices
+}
+
+func (suite *SensitiveFieldsTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.mockAdminService = mocks.NewMockAdminServices(ctrl)
+	suite.mockProfileService = mocks.NewMockProfileServices(ctrl)
+	suite.mockPartnerService = mocks.NewMockPartnerServices(ctrl)
+
+	meter, tracer, log := testutil.Telemetry("test-sensitive-fields")
+	admin := adminhandler.NewAdminHandler(suite.mockAdminService, meter, tracer, log)
+	profile := profilehandler.NewProfileHandler(suite.mockProfileService, mocks.NewMockCloudinaryService(ctrl), meter, tracer, log)
+	partner := partnerhandler.NewPartnerHandler(suite.mockPartnerService, nil, meter, tracer, log)
+
+	jwtAuth := middleware.NewJWTAuthMiddleware(sensitiveJWTSecret)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers", jwtAuth, admin.ListCustomers)
+	suite.app.Get("/admin/customers/:customerId", jwtAuth, admin.GetCustomerByID)
+	suite.app.Get("/me/profile", jwtAuth, profile.GetMyProfile)
+	suite.app.Get("/me/transactions", jwtAuth, profile.GetMyTransactions)
+	suite.app.Post("/partners/transactions", jwtAuth, partner.CreateTransaction)
+}
+
+func fullCustomer() domain.Customer {
+	return domain.Customer{
+		ID:                 2,
+		NIK:                "3201010101900001",
+		FullName:           "Budi Santoso",
+		LegalName:          "BUDI SANTOSO",
+		Password:           sensitivePasswordHash,
+		Role:               domain.CustomerRole,
+		BirthPlace:         "Bandung",
+		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:             decimal.NewFromInt(10_000_000),
+		KtpUrl:             sensitiveKtpURL,
+		SelfieUrl:          sensitiveSelfieURL,
+		VerificationStatus: domain.VerificationVerified,
+	}
+}
+
+func fullTransaction() domain.Transaction {
+	return domain.Transaction{
+		ID:             1,
+		ContractNumber: "KTR-001",
+		CustomerID:     2,
+		TenorID:        3,
+		AssetName:      "Laptop",
+		OTRAmount:      decimal.NewFromInt(5_000_000),
+		Status:         domain.TransactionActive,
+		Currency:       "IDR",
+		Customer:       fullCustomer(),
+		Tenor:          domain.Tenor{ID: 3, DurationMonths: 6},
+	}
+}
+
+func (suite *SensitiveFieldsTestSuite) TestResponsesHideSensitiveFields() {
+	customer := fullCustomer()
+	transaction := fullTransaction()
+
+	cases := []struct {
+		name   string
+		role   domain.Role
+		method string
+		path   string
+		body   string
+		expect func()
+	}{
+		{
+			name: "Admin List Customers", role: domain.AdminRole, method: http.MethodGet, path: "/admin/customers",
+			expect: func() {
+				suite.mockAdminService.EXPECT().ListCustomers(gomock.Any(), gomock.Any()).
+					Return(&domain.Paginated{Data: []domain.Customer{customer}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
+			},
+		},
+		{
+			name: "Admin Get Customer", role: domain.AdminRole, method: http.MethodGet, path: "/admin/customers/2",
+			expect: func() {
+				suite.mockAdminService.EXPECT().GetCustomerByID(gomock.Any(), uint64(2)).Return(&customer, nil)
+			},
+		},
+		{
+			name: "Profile", role: domain.CustomerRole, method: http.MethodGet, path: "/me/profile",
+			expect: func() {
+				suite.mockProfileService.EXPECT().GetMyProfile(gomock.Any(), uint64(2)).Return(&customer, nil)
+			},
+		},
+		{
+			name: "My Transactions", role: domain.CustomerRole, method: http.MethodGet, path: "/me/transactions",
+			expect: func() {
+				suite.mockProfileService.EXPECT().GetMyTransactions(gomock.Any(), uint64(2), gomock.Any()).
+					Return(&domain.Paginated{Data: []domain.Transaction{transaction}, Total: 1, Page: 1, Limit: 10, TotalPages: 1}, nil)
+			},
+		},
+		{
+			name: "Partner Create Transaction", role: domain.CustomerRole, method: http.MethodPost, path: "/partners/transactions",
+			body: `{"customer_nik":"3201010101900001","tenor_months":6,"asset_name":"Laptop","otr_amount":"5000000","admin_fee":"50000"}`,
+			expect: func() {
+				suite.mockPartnerService.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(&transaction, nil)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			tc.expect()
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			req.AddCookie(testutil.AuthCookie(suite.T(), sensitiveJWTSecret, 2, tc.role))
+
+			resp, err := suite.app.Test(req)
+			suite.Require().NoError(err)
+			defer resp.Body.Close()
+			suite.Require().Less(resp.StatusCode, http.StatusBadRequest)
+
+			body, _ := io.ReadAll(resp.Body)
+			lower := strings.ToLower(string(body))
+			for _, fragment := range sensitiveFragments {
+				assert.NotContains(suite.T(), lower, fragment)
+			}
+		})
+	}
+}
+
+func TestSensitiveFieldsSuite(t *testing.T) {
+	suite.Run(t, new(SensitiveFieldsTestSuite))
+}
@@ -0,0 +1,177 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	statementhandler "github.com/fazamuttaqien/multifinance/internal/handler/statement"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const statementJWTSecret = "test-secret-key"
+
+type StatementHandlerTestSuite struct {
+	suite.Suite
+	app                  *fiber.App
+	mockStatementService *mocks.MockStatementServices
+	customerCookie       *http.Cookie
+}
+
+func (suite *StatementHandlerTestSuite) SetupTest() {
+	suite.mockStatementService = mocks.NewMockStatementServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-statement-handler")
+	handler := statementhandler.NewStatementHandler(suite.mockStatementService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/me/transactions/:contractNumber/statement", middleware.NewJWTAuthMiddleware(statementJWTSecret), handler.GetStatement)
+	suite.app.Put("/me/monthly-statement", middleware.NewJWTAuthMiddleware(statementJWTSecret), handler.SetMonthlyStatement)
+	suite.customerCookie = testutil.AuthCookie(suite.T(), statementJWTSecret, 5, domain.CustomerRole)
+}
+
+func (suite *StatementHandlerTestSuite) get(contractNumber string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, "/me/transactions/"+contractNumber+"/statement", nil)
+	req.AddCookie(suite.customerCookie)
+	resp, err := suite.app.Test(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *StatementHandlerTestSuite) TestGetStatement() {
+	suite.Run("Success - Rendered PDF", func() {
+		document := []byte("%PDF-1.4 test")
+		suite.mockStatementService.EXPECT().GetStatement(gomock.Any(), uint64(5), "CN-1").
+			Return(&domain.TransactionStatement{ContractNumber: "CN-1", Status: domain.StatementReady}, document, nil)
+
+		resp := suite.get("CN-1")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "application/pdf", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(suite.T(), `inline; filename="statement-CN-1.pdf"`, resp.Header.Get(fiber.HeaderContentDisposition))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(suite.T(), document, body)
+	})
+
+	suite.Run("Success - Stored Statement Redirects", func() {
+		suite.mockStatementService.EXPECT().GetStatement(gomock.Any(), uint64(5), "CN-2").
+			Return(&domain.TransactionStatement{ContractNumber: "CN-2", Status: domain.StatementReady, URL: "https://files.example/CN-2.pdf"}, nil, nil)
+
+		resp := suite.get("CN-2")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusFound, resp.StatusCode)
+		assert.Equal(suite.T(), "https://files.example/CN-2.pdf", resp.Header.Get(fiber.HeaderLocation))
+	})
+
+	suite.Run("Success - Still Generating", func() {
+		requestedAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+		suite.mockStatementService.EXPECT().GetStatement(gomock.Any(), uint64(5), "CN-3").
+			Return(&domain.TransactionStatement{ContractNumber: "CN-3", Status: domain.StatementPending, UpdatedAt: requestedAt}, nil, nil)
+
+		resp := suite.get("CN-3")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusAccepted, resp.StatusCode)
+		var statement dto.StatementResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &statement)
+		assert.Equal(suite.T(), "CN-3", statement.ContractNumber)
+		assert.Equal(suite.T(), string(domain.StatementPending), statement.Status)
+		assert.True(suite.T(), requestedAt.Equal(statement.RequestedAt))
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockStatementService.EXPECT().GetStatement(gomock.Any(), uint64(5), "CN-X").
+			Return(nil, nil, common.ErrTransactionNotFound)
+
+		resp := suite.get("CN-X")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Service Error", func() {
+		suite.mockStatementService.EXPECT().GetStatement(gomock.Any(), uint64(5), "CN-4").
+			Return(nil, nil, errors.New("db down"))
+
+		resp := suite.get("CN-4")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusInternalServerError, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unauthenticated", func() {
+		resp, err := suite.app.Test(httptest.NewRequest(http.MethodGet, "/me/transactions/CN-1/statement", nil))
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func (suite *StatementHandlerTestSuite) putPreference(body string) *http.Response {
+	req := httptest.NewRequest(http.MethodPut, "/me/monthly-statement", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.AddCookie(suite.customerCookie)
+	resp, err := suite.app.Test(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *StatementHandlerTestSuite) TestSetMonthlyStatement() {
+	suite.Run("Success - Opt Out", func() {
+		suite.mockStatementService.EXPECT().SetMonthlyStatementOptOut(gomock.Any(), uint64(5), true).Return(nil)
+
+		resp := suite.putPreference(`{"enabled":false}`)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		var body map[string]bool
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.False(suite.T(), body["monthly_statement"])
+	})
+
+	suite.Run("Success - Opt In", func() {
+		suite.mockStatementService.EXPECT().SetMonthlyStatementOptOut(gomock.Any(), uint64(5), false).Return(nil)
+
+		resp := suite.putPreference(`{"enabled":true}`)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Enabled", func() {
+		resp := suite.putPreference(`{}`)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockStatementService.EXPECT().SetMonthlyStatementOptOut(gomock.Any(), uint64(5), true).Return(common.ErrCustomerNotFound)
+
+		resp := suite.putPreference(`{"enabled":false}`)
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestStatementHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(StatementHandlerTestSuite))
+}
@@ -0,0 +1,137 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	tenanthandler "github.com/fazamuttaqien/multifinance/internal/handler/tenant"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const tenantJWTSecret = "test-secret-key"
+
+type TenantHandlerTestSuite struct {
+	suite.Suite
+	app *fiber.App
+}
+
+func (suite *TenantHandlerTestSuite) SetupTest() {
+	tenants, err := tenancy.NewRegistry(
+		tenancy.Tenant{Database: "loan_system", Branding: tenancy.Branding{CompanyName: "Multifinance"}},
+		tenancy.Tenant{
+			ID:       "acme",
+			Hosts:    []string{"acme.example.com"},
+			Database: "loan_acme",
+			Branding: tenancy.Branding{CompanyName: "Acme Finance", Color: "#AA0000"},
+		},
+	)
+	require.NoError(suite.T(), err)
+
+	meter, tracer, log := testutil.Telemetry("test-tenant-handler")
+	handler := tenanthandler.NewTenantHandler(meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Use(middleware.NewTenantMiddleware(tenants))
+	suite.app.Get("/tenant", handler.Branding)
+	suite.app.Get("/admin/ping",
+		middleware.NewJWTAuthMiddleware(tenantJWTSecret),
+		middleware.RequireRole(domain.AdminRole),
+		func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) },
+	)
+}
+
+func (suite *TenantHandlerTestSuite) get(path, host, header string, cookie *http.Cookie) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if host != "" {
+		req.Host = host
+	}
+	if header != "" {
+		req.Header.Set(tenancy.Header, header)
+	}
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := suite.app.Test(req)
+	require.NoError(suite.T(), err)
+	return resp
+}
+
+func (suite *TenantHandlerTestSuite) TestBranding() {
+	suite.Run("Success - Resolved By Host", func() {
+		resp := suite.get("/tenant", "acme.example.com:443", "", nil)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body dto.TenantResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), "acme", body.ID)
+		assert.Equal(suite.T(), "Acme Finance", body.CompanyName)
+		assert.Equal(suite.T(), "#AA0000", body.Color)
+	})
+
+	suite.Run("Success - Resolved By Header", func() {
+		resp := suite.get("/tenant", "api.example.com", "acme", nil)
+		defer resp.Body.Close()
+
+		var body dto.TenantResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), "acme", body.ID)
+	})
+
+	suite.Run("Success - Default Tenant", func() {
+		resp := suite.get("/tenant", "api.example.com", "", nil)
+		defer resp.Body.Close()
+
+		var body dto.TenantResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), tenancy.DefaultID, body.ID)
+		assert.Equal(suite.T(), "Multifinance", body.CompanyName)
+	})
+
+	suite.Run("Failure - Unknown Tenant", func() {
+		resp := suite.get("/tenant", "api.example.com", "globex", nil)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Header Does Not Match Host", func() {
+		resp := suite.get("/tenant", "acme.example.com", tenancy.DefaultID, nil)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *TenantHandlerTestSuite) TestTenantScopedToken() {
+	suite.Run("Success - Token Of The Same Tenant", func() {
+		resp := suite.get("/admin/ping", "acme.example.com", "", testutil.TenantCookie(suite.T(), tenantJWTSecret, "acme", 1, domain.AdminRole))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.Run("Success - Token Without Tenant Belongs To Default", func() {
+		resp := suite.get("/admin/ping", "", "", testutil.AuthCookie(suite.T(), tenantJWTSecret, 1, domain.AdminRole))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Admin Of Another Tenant", func() {
+		resp := suite.get("/admin/ping", "acme.example.com", "", testutil.AuthCookie(suite.T(), tenantJWTSecret, 1, domain.AdminRole))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestTenantHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantHandlerTestSuite))
+}
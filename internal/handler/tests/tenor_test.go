@@ -0,0 +1,119 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	tenorhandler "github.com/fazamuttaqien/multifinance/internal/handler/tenor"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TenorHandlerTestSuite struct {
+	suite.Suite
+	app              *fiber.App
+	mockTenorService *mocks.MockTenorServices
+}
+
+func (suite *TenorHandlerTestSuite) SetupTest() {
+	suite.mockTenorService = mocks.NewMockTenorServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-tenor-handler")
+	handler := tenorhandler.NewTenorHandler(suite.mockTenorService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/tenors", handler.ListTenors)
+	suite.app.Post("/admin/tenors", handler.CreateTenor)
+	suite.app.Put("/admin/tenors/:id", handler.UpdateTenor)
+	suite.app.Post("/admin/tenors/:id/deactivate", handler.DeactivateTenor)
+	suite.app.Delete("/admin/tenors/:id", handler.DeleteTenor)
+}
+
+func (suite *TenorHandlerTestSuite) TestCreateTenor() {
+	suite.Run("Success", func() {
+		suite.mockTenorService.EXPECT().CreateTenor(gomock.Any(), dto.CreateTenorRequest{DurationMonths: 18, Description: "18 Bulan"}).
+			Return(&domain.Tenor{ID: 8, DurationMonths: 18, Description: "18 Bulan", Active: true}, nil)
+
+		body := map[string]any{"duration_months": 18, "description": "18 Bulan"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/tenors", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var tenor dto.TenorResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &tenor)
+		assert.True(suite.T(), tenor.Active)
+	})
+
+	suite.Run("Failure - Duplicate", func() {
+		suite.mockTenorService.EXPECT().CreateTenor(gomock.Any(), gomock.Any()).Return(nil, common.ErrTenorExists)
+
+		body := map[string]any{"duration_months": 6}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/tenors", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Inverted Amount Bounds", func() {
+		suite.mockTenorService.EXPECT().CreateTenor(gomock.Any(), gomock.Any()).Return(nil, common.ErrInvalidTenorProduct)
+
+		body := map[string]any{"duration_months": 24, "min_amount": 9000000, "max_amount": 1000000}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/tenors", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Missing Duration", func() {
+		body := map[string]any{"description": "Tanpa durasi"}
+		resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPost, "/admin/tenors", body))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *TenorHandlerTestSuite) TestUpdateTenor() {
+	suite.mockTenorService.EXPECT().UpdateTenor(gomock.Any(), uint(4), dto.UpdateTenorRequest{Description: "Enam Bulan"}).
+		Return(&domain.Tenor{ID: 4, DurationMonths: 6, Description: "Enam Bulan", Active: true}, nil)
+
+	body := map[string]any{"description": "Enam Bulan"}
+	resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", nil, http.MethodPut, "/admin/tenors/4", body))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+}
+
+func (suite *TenorHandlerTestSuite) TestDeactivateTenor() {
+	suite.mockTenorService.EXPECT().DeactivateTenor(gomock.Any(), uint(4)).
+		Return(&domain.Tenor{ID: 4, DurationMonths: 6}, nil)
+
+	resp, _ := suite.app.Test(httptest.NewRequest(http.MethodPost, "/admin/tenors/4/deactivate", nil))
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+}
+
+func (suite *TenorHandlerTestSuite) TestDeleteTenor() {
+	suite.Run("Failure - In Use", func() {
+		suite.mockTenorService.EXPECT().DeleteTenor(gomock.Any(), uint(4)).Return(common.ErrTenorInUse)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/tenors/4", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Invalid ID", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodDelete, "/admin/tenors/abc", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestTenorHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TenorHandlerTestSuite))
+}
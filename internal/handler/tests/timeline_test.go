@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	timelinehandler "github.com/fazamuttaqien/multifinance/internal/handler/timeline"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TimelineHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockTimelineService *mocks.MockCustomerTimelineServices
+}
+
+func (suite *TimelineHandlerTestSuite) SetupTest() {
+	suite.mockTimelineService = mocks.NewMockCustomerTimelineServices(gomock.NewController(suite.T()))
+
+	meter, tracer, log := testutil.Telemetry("test-timeline-handler")
+	handler := timelinehandler.NewCustomerTimelineHandler(suite.mockTimelineService, meter, tracer, log)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/customers/:customerId/timeline", handler.GetTimeline)
+}
+
+func (suite *TimelineHandlerTestSuite) TestGetTimeline() {
+	suite.Run("Success - Filtered", func() {
+		suite.mockTimelineService.EXPECT().GetTimeline(gomock.Any(), uint64(2), gomock.Any()).
+			DoAndReturn(func(_ any, _ uint64, params domain.Params) (*domain.Paginated, error) {
+				assert.Equal(suite.T(), "PAYMENT_RECEIVED", params.Value("type"))
+				assert.Equal(suite.T(), 50, params.Limit)
+				return params.Paginated([]domain.CustomerEvent{{
+					ID:          7,
+					CustomerID:  2,
+					Type:        domain.CustomerPaymentReceived,
+					ReferenceID: "KTR-20250101-000001",
+					Data:        map[string]string{"amount": "150000"},
+				}}, 1), nil
+			})
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/2/timeline?type=payment_received", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var data []dto.CustomerEventResponse
+		envelope := testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		suite.Require().Len(data, 1)
+		assert.Equal(suite.T(), domain.CustomerPaymentReceived, data[0].Type)
+		assert.Equal(suite.T(), "KTR-20250101-000001", data[0].ReferenceID)
+		assert.Equal(suite.T(), "150000", data[0].Data["amount"])
+		suite.Require().NotNil(envelope.Meta.Pagination)
+		assert.Equal(suite.T(), int64(1), envelope.Meta.Pagination.Total)
+	})
+
+	suite.Run("Failure - Unknown Type", func() {
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/2/timeline?type=logged_in", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Customer Not Found", func() {
+		suite.mockTimelineService.EXPECT().GetTimeline(gomock.Any(), uint64(9), gomock.Any()).Return(nil, common.ErrCustomerNotFound)
+
+		resp, _ := suite.app.Test(httptest.NewRequest(http.MethodGet, "/admin/customers/9/timeline", nil))
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestTimelineHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TimelineHandlerTestSuite))
+}
@@ -0,0 +1,195 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const testRequestTimeout = 20 * time.Millisecond
+
+type TimeoutTestSuite struct {
+	suite.Suite
+	app      *fiber.App
+	reader   *sdkmetric.ManualReader
+	previous metric.MeterProvider
+}
+
+func (suite *TimeoutTestSuite) SetupTest() {
+	// Counter diambil dari meter provider global saat middleware dibuat
+	suite.previous = otel.GetMeterProvider()
+	suite.reader = sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(suite.reader)))
+
+	suite.app = fiber.New()
+	suite.app.Use(middleware.NewTimeoutMiddleware("check-limit", testRequestTimeout))
+
+	suite.app.Get("/fast", func(c *fiber.Ctx) error {
+		deadline, ok := c.UserContext().Deadline()
+		suite.True(ok)
+		suite.WithinDuration(time.Now().Add(testRequestTimeout), deadline, testRequestTimeout)
+		return responder.Success(c, fiber.StatusOK, fiber.Map{"status": "ok"})
+	})
+	// Handler yang menghormati context, seperti service yang menunggu query
+	suite.app.Get("/limits/:id", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.UserContext().Err()
+	})
+	// Handler yang mengubah kegagalan context menjadi 500 sendiri
+	suite.app.Get("/internal-error", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return responder.Fail(c, fiber.StatusInternalServerError, "internal_error", "query canceled")
+	})
+	// Handler yang mengabaikan context dan tetap selesai setelah deadline
+	suite.app.Get("/slow-success", func(c *fiber.Ctx) error {
+		time.Sleep(2 * testRequestTimeout)
+		return responder.Success(c, fiber.StatusOK, fiber.Map{"status": "late"})
+	})
+	suite.app.Get("/slow-not-found", func(c *fiber.Ctx) error {
+		time.Sleep(2 * testRequestTimeout)
+		return responder.Fail(c, fiber.StatusNotFound, "not_found", "customer not found")
+	})
+}
+
+func (suite *TimeoutTestSuite) TearDownTest() {
+	otel.SetMeterProvider(suite.previous)
+}
+
+func (suite *TimeoutTestSuite) get(path string) *http.Response {
+	resp, err := suite.app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	suite.Require().NoError(err)
+	return resp
+}
+
+// timeouts returns the http.server.timeout.count points keyed by route.
+func (suite *TimeoutTestSuite) timeouts() map[string]metricdata.DataPoint[int64] {
+	var rm metricdata.ResourceMetrics
+	suite.Require().NoError(suite.reader.Collect(context.Background(), &rm))
+
+	points := map[string]metricdata.DataPoint[int64]{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "http.server.timeout.count" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				route, _ := dp.Attributes.Value("route")
+				points[route.AsString()] = dp
+			}
+		}
+	}
+	return points
+}
+
+func (suite *TimeoutTestSuite) TestWithinDeadline() {
+	resp := suite.get("/fast")
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	var data map[string]any
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	assert.Equal(suite.T(), "ok", data["status"])
+	assert.Empty(suite.T(), suite.timeouts())
+}
+
+func (suite *TimeoutTestSuite) TestDeadlineExceeded() {
+	suite.Run("Context Error Becomes 504", func() {
+		resp := suite.get("/limits/7")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusGatewayTimeout, resp.StatusCode)
+		env := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		suite.Require().NotNil(env.Error)
+		assert.Equal(suite.T(), "request_timeout", env.Error.Code)
+	})
+
+	suite.Run("Server Error After Deadline Becomes 504", func() {
+		resp := suite.get("/internal-error")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusGatewayTimeout, resp.StatusCode)
+		env := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		suite.Require().NotNil(env.Error)
+		assert.Equal(suite.T(), "request_timeout", env.Error.Code)
+	})
+}
+
+func (suite *TimeoutTestSuite) TestTimeoutCounter() {
+	for i := 0; i < 2; i++ {
+		resp := suite.get("/limits/7")
+		resp.Body.Close()
+	}
+
+	points := suite.timeouts()
+	suite.Require().Contains(points, "/limits/:id")
+	point := points["/limits/:id"]
+	assert.Equal(suite.T(), int64(2), point.Value)
+
+	group, _ := point.Attributes.Value("route_group")
+	method, _ := point.Attributes.Value("method")
+	assert.Equal(suite.T(), "check-limit", group.AsString())
+	assert.Equal(suite.T(), http.MethodGet, method.AsString())
+}
+
+func (suite *TimeoutTestSuite) TestFinishedAfterDeadline() {
+	suite.Run("Success Is Kept", func() {
+		resp := suite.get("/slow-success")
+		defer resp.Body.Close()
+
+		// Respons handler tetap dikirim, hanya dihitung sebagai timeout
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		var data map[string]any
+		env := testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+		assert.Nil(suite.T(), env.Error)
+		assert.Equal(suite.T(), "late", data["status"])
+	})
+
+	suite.Run("Client Error Is Kept", func() {
+		resp := suite.get("/slow-not-found")
+		defer resp.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+		env := testutil.DecodeEnvelope(suite.T(), resp.Body, nil)
+		suite.Require().NotNil(env.Error)
+		assert.Equal(suite.T(), "not_found", env.Error.Code)
+	})
+
+	points := suite.timeouts()
+	assert.Equal(suite.T(), int64(1), points["/slow-success"].Value)
+	assert.Equal(suite.T(), int64(1), points["/slow-not-found"].Value)
+}
+
+func (suite *TimeoutTestSuite) TestDisabled() {
+	app := fiber.New()
+	app.Use(middleware.NewTimeoutMiddleware("registration", 0))
+	app.Get("/register", func(c *fiber.Ctx) error {
+		_, ok := c.UserContext().Deadline()
+		assert.False(suite.T(), ok)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/register", nil))
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	assert.Empty(suite.T(), suite.timeouts())
+}
+
+func TestTimeoutTestSuite(t *testing.T) {
+	suite.Run(t, new(TimeoutTestSuite))
+}
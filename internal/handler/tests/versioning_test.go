@@ -0,0 +1,81 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/apiversion"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type limitV2 struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type VersioningTestSuite struct {
+	suite.Suite
+	app    *fiber.App
+	sunset time.Time
+}
+
+func (suite *VersioningTestSuite) SetupTest() {
+	suite.sunset = time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	versions := []apiversion.Version{
+		{Name: "v1", Deprecation: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Sunset: suite.sunset, Successor: "/api/v2"},
+		{Name: "v2"},
+	}
+
+	suite.app = fiber.New()
+	for _, version := range versions {
+		api := suite.app.Group(version.Prefix(), apiversion.Middleware(version))
+		api.Get("/limits", func(c *fiber.Ctx) error {
+			limit := limitV2{Amount: "5000000", Currency: "IDR"}
+			return responder.Success(c, fiber.StatusOK, apiversion.Map(c, limit, map[string]func(limitV2) any{
+				"v1": func(l limitV2) any { return l.Amount },
+			}))
+		})
+	}
+}
+
+func (suite *VersioningTestSuite) TestDeprecatedVersion() {
+	resp, err := suite.app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/limits", nil))
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(suite.T(), "@1767225600", resp.Header.Get("Deprecation"))
+	assert.Equal(suite.T(), suite.sunset.Format(http.TimeFormat), resp.Header.Get("Sunset"))
+	assert.Equal(suite.T(), `</api/v2/limits>; rel="successor-version"`, resp.Header.Get(fiber.HeaderLink))
+
+	var data string
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	assert.Equal(suite.T(), "5000000", data)
+}
+
+func (suite *VersioningTestSuite) TestCurrentVersion() {
+	resp, err := suite.app.Test(httptest.NewRequest(http.MethodGet, "/api/v2/limits", nil))
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	assert.Empty(suite.T(), resp.Header.Get("Deprecation"))
+	assert.Empty(suite.T(), resp.Header.Get("Sunset"))
+	assert.Empty(suite.T(), resp.Header.Get(fiber.HeaderLink))
+
+	var data limitV2
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &data)
+	assert.Equal(suite.T(), limitV2{Amount: "5000000", Currency: "IDR"}, data)
+}
+
+func TestVersioningSuite(t *testing.T) {
+	suite.Run(t, new(VersioningTestSuite))
+}
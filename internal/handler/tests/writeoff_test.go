@@ -0,0 +1,216 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	writeoffhandler "github.com/fazamuttaqien/multifinance/internal/handler/writeoff"
+	"github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const writeOffJWTSecret = "test-secret-key"
+
+type WriteOffHandlerTestSuite struct {
+	suite.Suite
+	app                 *fiber.App
+	mockWriteOffService *mocks.MockWriteOffServices
+	adminCookie         *http.Cookie
+}
+
+func (suite *WriteOffHandlerTestSuite) SetupTest() {
+	suite.mockWriteOffService = mocks.NewMockWriteOffServices(gomock.NewController(suite.T()))
+	suite.adminCookie = testutil.AuthCookie(suite.T(), writeOffJWTSecret, 1, domain.AdminRole)
+
+	meter, tracer, log := testutil.Telemetry("test-write-off-handler")
+	handler := writeoffhandler.NewWriteOffHandler(suite.mockWriteOffService, meter, tracer, log)
+	jwtAuth := middleware.NewJWTAuthMiddleware(writeOffJWTSecret)
+	requireAdmin := middleware.RequireRole(domain.AdminRole)
+
+	suite.app = fiber.New()
+	suite.app.Get("/admin/write-offs", jwtAuth, requireAdmin, handler.ListWriteOffs)
+	suite.app.Post("/admin/write-offs", jwtAuth, requireAdmin, handler.Propose)
+	suite.app.Get("/admin/write-offs/report", jwtAuth, requireAdmin, handler.Report)
+	suite.app.Get("/admin/write-offs/:id", jwtAuth, requireAdmin, handler.GetWriteOff)
+	suite.app.Post("/admin/write-offs/:id/review", jwtAuth, requireAdmin, handler.Review)
+	suite.app.Get("/admin/write-offs/:id/recoveries", jwtAuth, requireAdmin, handler.ListRecoveries)
+	suite.app.Post("/admin/write-offs/:id/recoveries", jwtAuth, requireAdmin, handler.RecordRecovery)
+	suite.app.Get("/admin/write-offs/:id/ledger", jwtAuth, requireAdmin, handler.Ledger)
+}
+
+func (suite *WriteOffHandlerTestSuite) get(path string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.AddCookie(suite.adminCookie)
+
+	resp, _ := suite.app.Test(req)
+	return resp
+}
+
+func (suite *WriteOffHandlerTestSuite) post(path string, body map[string]any) *http.Response {
+	resp, _ := suite.app.Test(createJSONRequestWithAuth(suite.T(), "", []*http.Cookie{suite.adminCookie}, http.MethodPost, path, body))
+	return resp
+}
+
+func (suite *WriteOffHandlerTestSuite) TestPropose() {
+	suite.Run("Success", func() {
+		suite.mockWriteOffService.EXPECT().Propose(gomock.Any(), uint64(1), dto.WriteOffRequest{ContractNumber: "KTR-010", Reason: "Customer unreachable"}).
+			Return(&domain.WriteOff{ID: 3, TransactionID: 10, Status: domain.WriteOffPending, DaysPastDue: 200, Amount: decimal.NewFromInt(9_000_000)}, nil)
+
+		resp := suite.post("/admin/write-offs", map[string]any{"contract_number": "KTR-010", "reason": "Customer unreachable"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var body dto.WriteOffResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), "PENDING", body.Status)
+		assert.Equal(suite.T(), "9000000", body.Outstanding.String())
+	})
+
+	suite.Run("Failure - Missing Reason", func() {
+		resp := suite.post("/admin/write-offs", map[string]any{"contract_number": "KTR-010"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Eligible", func() {
+		suite.mockWriteOffService.EXPECT().Propose(gomock.Any(), uint64(1), gomock.Any()).Return(nil, common.ErrWriteOffNotEligible)
+
+		resp := suite.post("/admin/write-offs", map[string]any{"contract_number": "KTR-010", "reason": "Customer unreachable"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Pending", func() {
+		suite.mockWriteOffService.EXPECT().Propose(gomock.Any(), uint64(1), gomock.Any()).Return(nil, common.ErrWriteOffExists)
+
+		resp := suite.post("/admin/write-offs", map[string]any{"contract_number": "KTR-010", "reason": "Customer unreachable"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *WriteOffHandlerTestSuite) TestReview() {
+	suite.Run("Success - Approved", func() {
+		suite.mockWriteOffService.EXPECT().Review(gomock.Any(), uint64(3), uint64(1), dto.WriteOffReviewRequest{Status: domain.WriteOffApproved, Note: "Setuju"}).
+			Return(&domain.WriteOff{ID: 3, Status: domain.WriteOffApproved}, nil)
+
+		resp := suite.post("/admin/write-offs/3/review", map[string]any{"status": "APPROVED", "note": "Setuju"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Unknown Status", func() {
+		resp := suite.post("/admin/write-offs/3/review", map[string]any{"status": "PENDING"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Own Proposal", func() {
+		suite.mockWriteOffService.EXPECT().Review(gomock.Any(), uint64(3), uint64(1), gomock.Any()).Return(nil, common.ErrWriteOffSelf)
+
+		resp := suite.post("/admin/write-offs/3/review", map[string]any{"status": "APPROVED"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Already Reviewed", func() {
+		suite.mockWriteOffService.EXPECT().Review(gomock.Any(), uint64(3), uint64(1), gomock.Any()).Return(nil, common.ErrWriteOffReviewed)
+
+		resp := suite.post("/admin/write-offs/3/review", map[string]any{"status": "REJECTED"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (suite *WriteOffHandlerTestSuite) TestRecoveries() {
+	suite.Run("Success - Record", func() {
+		suite.mockWriteOffService.EXPECT().RecordRecovery(gomock.Any(), uint64(3), uint64(1), gomock.Any()).
+			Return(&domain.WriteOffRecovery{ID: 5, WriteOffID: 3, Amount: decimal.NewFromInt(750_000), ReceivedOn: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)}, nil)
+
+		resp := suite.post("/admin/write-offs/3/recoveries", map[string]any{"amount": "750000", "received_on": "2025-06-02", "reference": "TRF-88"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var body dto.WriteOffRecoveryResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), "2025-06-02", body.ReceivedOn)
+	})
+
+	suite.Run("Failure - Exceeds Balance", func() {
+		suite.mockWriteOffService.EXPECT().RecordRecovery(gomock.Any(), uint64(3), uint64(1), gomock.Any()).Return(nil, common.ErrRecoveryExceedsBalance)
+
+		resp := suite.post("/admin/write-offs/3/recoveries", map[string]any{"amount": "99000000"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Approved", func() {
+		suite.mockWriteOffService.EXPECT().RecordRecovery(gomock.Any(), uint64(3), uint64(1), gomock.Any()).Return(nil, common.ErrWriteOffNotApproved)
+
+		resp := suite.post("/admin/write-offs/3/recoveries", map[string]any{"amount": "100000"})
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	suite.Run("Failure - Not Found", func() {
+		suite.mockWriteOffService.EXPECT().ListRecoveries(gomock.Any(), uint64(9)).Return(nil, common.ErrWriteOffNotFound)
+
+		resp := suite.get("/admin/write-offs/9/recoveries")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *WriteOffHandlerTestSuite) TestLedger() {
+	entries := domain.NewPosting(domain.LedgerWriteOff, 3, 10, domain.LedgerBadDebtExpense, domain.LedgerLoanReceivable,
+		decimal.NewFromInt(9_000_000), time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), "Write-off KTR-010")
+	suite.mockWriteOffService.EXPECT().Ledger(gomock.Any(), uint64(3)).Return(entries, nil)
+
+	resp := suite.get("/admin/write-offs/3/ledger")
+	defer resp.Body.Close()
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+	var body []dto.LedgerEntryResponse
+	testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+	assert.Len(suite.T(), body, 2)
+}
+
+func (suite *WriteOffHandlerTestSuite) TestReport() {
+	suite.Run("Success", func() {
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		suite.mockWriteOffService.EXPECT().Report(gomock.Any(), from, to).
+			Return([]domain.WriteOffPeriod{{Period: from, Count: 2, Amount: decimal.NewFromInt(8_000_000), Recovered: decimal.NewFromInt(2_000_000)}}, nil)
+
+		resp := suite.get("/admin/write-offs/report?from=2025-01&to=2025-03")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var body []dto.WriteOffPeriodResponse
+		testutil.DecodeEnvelope(suite.T(), resp.Body, &body)
+		assert.Equal(suite.T(), "2025-01", body[0].Period)
+		assert.Equal(suite.T(), "0.25", body[0].RecoveryRate.String())
+	})
+
+	suite.Run("Failure - Bad Month", func() {
+		resp := suite.get("/admin/write-offs/report?from=2025-13")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestWriteOffHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(WriteOffHandlerTestSuite))
+}
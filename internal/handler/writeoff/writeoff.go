@@ -0,0 +1,506 @@
+package writeoffhandler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type WriteOffHandler struct {
+	writeOffService service.WriteOffServices
+	validate        *validator.Validate
+	meter           metric.Meter
+	tracer          trace.Tracer
+	log             *zap.Logger
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	errorCount      metric.Int64Counter
+	responseSize    metric.Int64Histogram
+}
+
+func NewWriteOffHandler(
+	writeOffService service.WriteOffServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) *WriteOffHandler {
+	requestCount, err := meter.Int64Counter(
+		"api.request.count",
+		metric.WithDescription("Number of API requests received"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request count metric", zap.Error(err))
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"api.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create request duration metric", zap.Error(err))
+	}
+
+	errorCount, err := meter.Int64Counter(
+		"api.error.count",
+		metric.WithDescription("Number of API errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create error count metric", zap.Error(err))
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"api.response.size",
+		metric.WithDescription("Size of API responses in bytes"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create response size metric", zap.Error(err))
+	}
+
+	return &WriteOffHandler{
+		writeOffService: writeOffService,
+		validate:        money.NewValidator(),
+		meter:           meter,
+		tracer:          tracer,
+		log:             log,
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		errorCount:      errorCount,
+		responseSize:    responseSize,
+	}
+}
+
+// recordError helper function to record errors with observability
+func (h *WriteOffHandler) recordError(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, err error, statusCode int, errorType, message string, fields ...zap.Field) error {
+	// Record error metrics
+	h.errorCount.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.String("error_type", errorType),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for error
+	span.SetAttributes(
+		attribute.String("error.type", errorType),
+		attribute.String("error.message", err.Error()),
+		attribute.Int("http.status_code", statusCode),
+	)
+	span.RecordError(err)
+
+	// Log error
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.String("error_type", errorType),
+		zap.Float64("duration_ms", duration),
+		zap.Error(err),
+	}, fields...)
+
+	h.log.Error(message, logFields...)
+
+	// Return HTTP error response
+	return responder.Fail(c, statusCode, errorType, message)
+}
+
+// recordSuccess helper function to record successful responses with observability
+func (h *WriteOffHandler) recordSuccess(
+	ctx context.Context, span trace.Span, c *fiber.Ctx,
+	start time.Time, statusCode int, responseData interface{}, fields ...zap.Field) error {
+	// Record request duration
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6 // Convert to milliseconds
+	h.requestDuration.Record(ctx, duration, metric.WithAttributes(
+		attribute.String("endpoint", c.Path()),
+		attribute.String("method", c.Method()),
+		attribute.Int("status_code", statusCode),
+	))
+
+	// Set span attributes for success
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Float64("request.duration_ms", duration),
+	)
+
+	// Log success
+	logFields := append([]zap.Field{
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+		zap.Int("status_code", statusCode),
+		zap.Float64("duration_ms", duration),
+	}, fields...)
+
+	h.log.Info("Request completed successfully", logFields...)
+
+	// Return HTTP success response
+	return responder.Success(c, statusCode, responseData)
+}
+
+var writeOffListQuery = query.Spec{
+	Filters: map[string]query.Filter{
+		"status":      {Column: "status", Values: []string{string(domain.WriteOffPending), string(domain.WriteOffApproved), string(domain.WriteOffRejected)}},
+		"customer_id": {Column: "customer_id", Validate: isID},
+	},
+	Sorts: map[string]string{"created_at": "created_at", "amount": "amount", "reviewed_at": "reviewed_at"},
+}
+
+func isID(v string) bool {
+	_, err := strconv.ParseUint(v, 10, 64)
+	return err == nil
+}
+
+func (h *WriteOffHandler) Propose(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ProposeWriteOff")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received propose write-off request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	var req dto.WriteOffRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.String("transaction.contract_number", req.ContractNumber),
+		attribute.Int64("maker.id", int64(claims.UserID)),
+	)
+
+	writeOff, err := h.writeOffService.Propose(ctx, claims.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrTransactionNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Transaction not found")
+		case errors.Is(err, common.ErrTransactionNotActive), errors.Is(err, common.ErrWriteOffNotEligible):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "business_rule_violation", err.Error())
+		case errors.Is(err, common.ErrWriteOffExists):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "write_off_pending", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to propose write-off")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.WriteOffToResponse(*writeOff), zap.Uint64("write_off_id", writeOff.ID))
+}
+
+// ListWriteOffs returns write-offs, newest first, optionally filtered by
+// ?status= and ?customer_id=.
+func (h *WriteOffHandler) ListWriteOffs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListWriteOffs")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list write-offs request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	params, err := writeOffListQuery.Parse(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	res, err := h.writeOffService.ListWriteOffs(ctx, params)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list write-offs")
+	}
+	writeOffs, _ := res.Data.([]domain.WriteOff)
+	res.Data = dto.WriteOffsToResponse(writeOffs)
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, res)
+}
+
+func (h *WriteOffHandler) GetWriteOff(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.GetWriteOff")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received get write-off request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	writeOffID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid write-off ID")
+	}
+
+	writeOff, err := h.writeOffService.GetWriteOff(ctx, writeOffID)
+	if err != nil {
+		if errors.Is(err, common.ErrWriteOffNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Write-off not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get write-off")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.WriteOffToResponse(*writeOff), zap.Uint64("write_off_id", writeOffID))
+}
+
+func (h *WriteOffHandler) Review(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ReviewWriteOff")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received review write-off request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	writeOffID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid write-off ID")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("write_off.id", int64(writeOffID)),
+		attribute.Int64("checker.id", int64(claims.UserID)),
+	)
+
+	var req dto.WriteOffReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	writeOff, err := h.writeOffService.Review(ctx, writeOffID, claims.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrWriteOffNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Write-off not found")
+		case errors.Is(err, common.ErrWriteOffSelf):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusForbidden, "self_review", err.Error())
+		case errors.Is(err, common.ErrWriteOffReviewed):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusConflict, "already_reviewed", err.Error())
+		case errors.Is(err, common.ErrTransactionNotActive):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "business_rule_violation", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to review write-off")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.WriteOffToResponse(*writeOff),
+		zap.Uint64("write_off_id", writeOffID),
+		zap.String("decision", string(req.Status)),
+	)
+}
+
+func (h *WriteOffHandler) RecordRecovery(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.RecordWriteOffRecovery")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received record write-off recovery request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	claims, err := middleware.GetClaimsFromLocals(c)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusUnauthorized, "auth_error", "Unauthorized: Admin ID not found")
+	}
+
+	writeOffID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid write-off ID")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("write_off.id", int64(writeOffID)),
+		attribute.Int64("admin.id", int64(claims.UserID)),
+	)
+
+	var req dto.WriteOffRecoveryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Cannot parse request body")
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+	}
+
+	recovery, err := h.writeOffService.RecordRecovery(ctx, writeOffID, claims.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrWriteOffNotFound):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Write-off not found")
+		case errors.Is(err, common.ErrInvalidRecovery):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		case errors.Is(err, common.ErrWriteOffNotApproved), errors.Is(err, common.ErrRecoveryExceedsBalance):
+			return h.recordError(ctx, span, c, start, err, fiber.StatusUnprocessableEntity, "business_rule_violation", err.Error())
+		default:
+			return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to record recovery")
+		}
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusCreated, dto.WriteOffRecoveryToResponse(*recovery),
+		zap.Uint64("write_off_id", writeOffID),
+		zap.Uint64("recovery_id", recovery.ID),
+	)
+}
+
+func (h *WriteOffHandler) ListRecoveries(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.ListWriteOffRecoveries")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received list write-off recoveries request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	writeOffID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid write-off ID")
+	}
+
+	recoveries, err := h.writeOffService.ListRecoveries(ctx, writeOffID)
+	if err != nil {
+		if errors.Is(err, common.ErrWriteOffNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Write-off not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to list recoveries")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.WriteOffRecoveriesToResponse(recoveries), zap.Int("count", len(recoveries)))
+}
+
+// Ledger returns every ledger entry posted for the written-off contract.
+func (h *WriteOffHandler) Ledger(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.WriteOffLedger")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received write-off ledger request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	writeOffID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "parse_error", "Invalid write-off ID")
+	}
+
+	entries, err := h.writeOffService.Ledger(ctx, writeOffID)
+	if err != nil {
+		if errors.Is(err, common.ErrWriteOffNotFound) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusNotFound, "not_found", "Write-off not found")
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to get ledger entries")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.LedgerEntriesToResponse(entries), zap.Int("count", len(entries)))
+}
+
+// Report summarises write-offs and recoveries per month for ?from= to ?to=,
+// both YYYY-MM. Without them it covers the last twelve months.
+func (h *WriteOffHandler) Report(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ctx, span := h.tracer.Start(ctx, "handler.WriteOffReport")
+	defer span.End()
+	start := time.Now()
+
+	span.SetAttributes(
+		attribute.String("http.method", c.Method()),
+		attribute.String("http.route", c.Path()),
+	)
+	h.log.Debug("Received write-off report request", zap.String("path", c.Path()))
+	h.requestCount.Add(ctx, 1, metric.WithAttributes(attribute.String("endpoint", c.Path()), attribute.String("method", c.Method())))
+
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, -11, 0)
+	if v := c.Query("to"); v != "" {
+		parsed, err := time.Parse("2006-01", v)
+		if err != nil {
+			return h.recordError(ctx, span, c, start, common.ErrInvalidWriteOffPeriod, fiber.StatusBadRequest, "validation_error", common.ErrInvalidWriteOffPeriod.Error())
+		}
+		to, from = parsed, parsed.AddDate(0, -11, 0)
+	}
+	if v := c.Query("from"); v != "" {
+		parsed, err := time.Parse("2006-01", v)
+		if err != nil {
+			return h.recordError(ctx, span, c, start, common.ErrInvalidWriteOffPeriod, fiber.StatusBadRequest, "validation_error", common.ErrInvalidWriteOffPeriod.Error())
+		}
+		from = parsed
+	}
+
+	periods, err := h.writeOffService.Report(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidWriteOffPeriod) {
+			return h.recordError(ctx, span, c, start, err, fiber.StatusBadRequest, "validation_error", err.Error())
+		}
+		return h.recordError(ctx, span, c, start, err, fiber.StatusInternalServerError, "service_error", "Failed to build write-off report")
+	}
+
+	return h.recordSuccess(ctx, span, c, start, fiber.StatusOK, dto.WriteOffReportToResponse(periods), zap.Int("periods", len(periods)))
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func AccountClosureFromEntity(data *domain.AccountClosure) AccountClosure {
+	return AccountClosure{
+		ID:             data.ID,
+		CustomerID:     data.CustomerID,
+		Reason:         data.Reason,
+		ClosedAt:       data.ClosedAt,
+		AnonymizeAfter: data.AnonymizeAfter,
+		AnonymizedAt:   data.AnonymizedAt,
+	}
+}
+
+func AccountClosureToEntity(data AccountClosure) *domain.AccountClosure {
+	return &domain.AccountClosure{
+		ID:             data.ID,
+		CustomerID:     data.CustomerID,
+		Reason:         data.Reason,
+		ClosedAt:       data.ClosedAt,
+		AnonymizeAfter: data.AnonymizeAfter,
+		AnonymizedAt:   data.AnonymizedAt,
+	}
+}
+
+func AccountClosuresToEntity(data []AccountClosure) []domain.AccountClosure {
+	closures := make([]domain.AccountClosure, len(data))
+	for i, c := range data {
+		closures[i] = *AccountClosureToEntity(c)
+	}
+	return closures
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func AgingSnapshotsFromEntity(data []domain.AgingSnapshotRow) []AgingSnapshot {
+	snapshots := make([]AgingSnapshot, len(data))
+	for i, r := range data {
+		snapshots[i] = AgingSnapshot{
+			SnapshotDate:      r.SnapshotDate,
+			TenorMonths:       r.TenorMonths,
+			Bucket:            string(r.Bucket),
+			ContractCount:     r.ContractCount,
+			OutstandingAmount: r.Outstanding,
+		}
+	}
+
+	return snapshots
+}
+
+func AgingSnapshotsToEntity(data []AgingSnapshot) []domain.AgingSnapshotRow {
+	responses := make([]domain.AgingSnapshotRow, len(data))
+	for i, s := range data {
+		responses[i] = domain.AgingSnapshotRow{
+			SnapshotDate:  s.SnapshotDate,
+			TenorMonths:   s.TenorMonths,
+			Bucket:        domain.AgingBucket(s.Bucket),
+			ContractCount: s.ContractCount,
+			Outstanding:   s.OutstandingAmount,
+		}
+	}
+
+	return responses
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func AMLCaseFromEntity(data *domain.AMLCase) AMLCase {
+	return AMLCase{
+		ID:              data.ID,
+		CustomerID:      data.CustomerID,
+		TransactionID:   data.TransactionID,
+		Rule:            string(data.Rule),
+		AmountIDR:       data.AmountIDR,
+		Detail:          data.Detail,
+		Status:          string(data.Status),
+		ReviewNote:      data.ReviewNote,
+		ReviewedBy:      data.ReviewedBy,
+		ReviewedAt:      data.ReviewedAt,
+		ReportReference: data.ReportReference,
+		ReportedAt:      data.ReportedAt,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+	}
+}
+
+func AMLCaseToEntity(data AMLCase) *domain.AMLCase {
+	amlCase := &domain.AMLCase{
+		ID:              data.ID,
+		CustomerID:      data.CustomerID,
+		TransactionID:   data.TransactionID,
+		Rule:            domain.AMLRule(data.Rule),
+		AmountIDR:       data.AmountIDR,
+		Detail:          data.Detail,
+		Status:          domain.AMLCaseStatus(data.Status),
+		ReviewNote:      data.ReviewNote,
+		ReviewedBy:      data.ReviewedBy,
+		ReviewedAt:      data.ReviewedAt,
+		ReportReference: data.ReportReference,
+		ReportedAt:      data.ReportedAt,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+	}
+	// Relasi hanya terisi kalau di-preload
+	if data.Transaction.ID != 0 {
+		amlCase.Transaction = TransactionToEntity(data.Transaction)
+	}
+	if data.Customer.ID != 0 {
+		amlCase.Customer = CustomerToEntity(data.Customer)
+	}
+	return amlCase
+}
+
+func AMLCasesToEntity(data []AMLCase) []domain.AMLCase {
+	cases := make([]domain.AMLCase, len(data))
+	for i, c := range data {
+		cases[i] = *AMLCaseToEntity(c)
+	}
+	return cases
+}
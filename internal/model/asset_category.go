@@ -0,0 +1,23 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func AssetCategoryFromEntity(data *domain.AssetCategory) AssetCategory {
+	return AssetCategory{
+		Code:      data.Code,
+		Name:      data.Name,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func AssetCategoryToEntity(data AssetCategory) *domain.AssetCategory {
+	return &domain.AssetCategory{
+		Code:      data.Code,
+		Name:      data.Name,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func BlacklistEntryFromEntity(data *domain.BlacklistEntry) BlacklistEntry {
+	return BlacklistEntry{
+		ID:        data.ID,
+		NIK:       data.NIK,
+		FullName:  data.FullName,
+		Reason:    data.Reason,
+		Source:    data.Source,
+		Severity:  BlacklistSeverity(data.Severity),
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func BlacklistEntryToEntity(data BlacklistEntry) *domain.BlacklistEntry {
+	return &domain.BlacklistEntry{
+		ID:        data.ID,
+		NIK:       data.NIK,
+		FullName:  data.FullName,
+		Reason:    data.Reason,
+		Source:    data.Source,
+		Severity:  domain.BlacklistSeverity(data.Severity),
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func BlacklistEntriesToEntity(data []BlacklistEntry) []domain.BlacklistEntry {
+	responses := make([]domain.BlacklistEntry, len(data))
+	for i, e := range data {
+		responses[i] = *BlacklistEntryToEntity(e)
+	}
+
+	return responses
+}
+
+func ScreeningLogFromEntity(data *domain.ScreeningLog) ScreeningLog {
+	return ScreeningLog{
+		ID:         data.ID,
+		EntryID:    data.EntryID,
+		CustomerID: data.CustomerID,
+		NIK:        data.NIK,
+		FullName:   data.FullName,
+		Action:     ScreeningAction(data.Action),
+		Outcome:    ScreeningOutcome(data.Outcome),
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func ScreeningLogToEntity(data ScreeningLog) *domain.ScreeningLog {
+	return &domain.ScreeningLog{
+		ID:         data.ID,
+		EntryID:    data.EntryID,
+		CustomerID: data.CustomerID,
+		NIK:        data.NIK,
+		FullName:   data.FullName,
+		Action:     domain.ScreeningAction(data.Action),
+		Outcome:    domain.ScreeningOutcome(data.Outcome),
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func ScreeningLogsToEntity(data []ScreeningLog) []domain.ScreeningLog {
+	responses := make([]domain.ScreeningLog, len(data))
+	for i, l := range data {
+		responses[i] = *ScreeningLogToEntity(l)
+	}
+
+	return responses
+}
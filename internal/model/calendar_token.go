@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CalendarTokenFromEntity(data *domain.CalendarToken) CalendarToken {
+	return CalendarToken{
+		ID:             data.ID,
+		CustomerID:     data.CustomerID,
+		ContractNumber: data.ContractNumber,
+		TokenHash:      data.TokenHash,
+		CreatedAt:      data.CreatedAt,
+		RevokedAt:      data.RevokedAt,
+	}
+}
+
+func CalendarTokenToEntity(data CalendarToken) *domain.CalendarToken {
+	return &domain.CalendarToken{
+		ID:             data.ID,
+		CustomerID:     data.CustomerID,
+		ContractNumber: data.ContractNumber,
+		TokenHash:      data.TokenHash,
+		CreatedAt:      data.CreatedAt,
+		RevokedAt:      data.RevokedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CollectionCaseFromEntity(data *domain.CollectionCase) CollectionCase {
+	return CollectionCase{
+		ID:             data.ID,
+		TransactionID:  data.TransactionID,
+		CustomerID:     data.CustomerID,
+		Status:         string(data.Status),
+		CollectorID:    data.CollectorID,
+		DaysPastDue:    data.DaysPastDue,
+		OverdueAmount:  data.OverdueAmount,
+		LastActivityAt: data.LastActivityAt,
+		ClosedAt:       data.ClosedAt,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func CollectionCaseToEntity(data CollectionCase) *domain.CollectionCase {
+	collectionCase := &domain.CollectionCase{
+		ID:             data.ID,
+		TransactionID:  data.TransactionID,
+		CustomerID:     data.CustomerID,
+		Status:         domain.CollectionCaseStatus(data.Status),
+		CollectorID:    data.CollectorID,
+		DaysPastDue:    data.DaysPastDue,
+		OverdueAmount:  data.OverdueAmount,
+		LastActivityAt: data.LastActivityAt,
+		ClosedAt:       data.ClosedAt,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+	// Relasi hanya terisi kalau di-preload
+	if data.Transaction.ID != 0 {
+		collectionCase.Transaction = TransactionToEntity(data.Transaction)
+	}
+	if data.Customer.ID != 0 {
+		collectionCase.Customer = CustomerToEntity(data.Customer)
+	}
+	return collectionCase
+}
+
+func CollectionCasesToEntity(data []CollectionCase) []domain.CollectionCase {
+	cases := make([]domain.CollectionCase, len(data))
+	for i, c := range data {
+		cases[i] = *CollectionCaseToEntity(c)
+	}
+	return cases
+}
+
+func CollectionActivityFromEntity(data *domain.CollectionActivity) CollectionActivity {
+	return CollectionActivity{
+		ID:            data.ID,
+		CaseID:        data.CaseID,
+		Type:          string(data.Type),
+		Note:          data.Note,
+		PromiseAmount: data.PromiseAmount,
+		PromiseDate:   data.PromiseDate,
+		CreatedBy:     data.CreatedBy,
+		CreatedAt:     data.CreatedAt,
+	}
+}
+
+func CollectionActivitiesToEntity(data []CollectionActivity) []domain.CollectionActivity {
+	activities := make([]domain.CollectionActivity, len(data))
+	for i, a := range data {
+		activities[i] = domain.CollectionActivity{
+			ID:            a.ID,
+			CaseID:        a.CaseID,
+			Type:          domain.CollectionActivityType(a.Type),
+			Note:          a.Note,
+			PromiseAmount: a.PromiseAmount,
+			PromiseDate:   a.PromiseDate,
+			CreatedBy:     a.CreatedBy,
+			CreatedAt:     a.CreatedAt,
+		}
+	}
+	return activities
+}
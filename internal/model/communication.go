@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CommunicationFromEntity(data *domain.Communication) Communication {
+	return Communication{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		Channel:    string(data.Channel),
+		Template:   string(data.Template),
+		Data:       data.Data,
+		Status:     CommunicationStatus(data.Status),
+		Error:      data.Error,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func CommunicationToEntity(data Communication) *domain.Communication {
+	return &domain.Communication{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		Channel:    domain.NotificationChannel(data.Channel),
+		Template:   domain.NotificationTemplate(data.Template),
+		Data:       data.Data,
+		Status:     domain.CommunicationStatus(data.Status),
+		Error:      data.Error,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func CommunicationsToEntity(data []Communication) []domain.Communication {
+	responses := make([]domain.Communication, len(data))
+	for i, c := range data {
+		responses[i] = *CommunicationToEntity(c)
+	}
+
+	return responses
+}
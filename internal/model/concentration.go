@@ -0,0 +1,41 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func ConcentrationLimitFromEntity(data *domain.ConcentrationLimit) ConcentrationLimit {
+	return ConcentrationLimit{
+		ID:         data.ID,
+		Dimension:  string(data.Dimension),
+		Value:      data.Value,
+		MaxPercent: data.MaxPercent,
+		Enforce:    data.Enforce,
+		BreachedAt: data.BreachedAt,
+		UpdatedBy:  data.UpdatedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func ConcentrationLimitToEntity(data ConcentrationLimit) *domain.ConcentrationLimit {
+	return &domain.ConcentrationLimit{
+		ID:         data.ID,
+		Dimension:  domain.ConcentrationDimension(data.Dimension),
+		Value:      data.Value,
+		MaxPercent: data.MaxPercent,
+		Enforce:    data.Enforce,
+		BreachedAt: data.BreachedAt,
+		UpdatedBy:  data.UpdatedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func ConcentrationLimitsToEntity(data []ConcentrationLimit) []domain.ConcentrationLimit {
+	responses := make([]domain.ConcentrationLimit, len(data))
+	for i, v := range data {
+		responses[i] = *ConcentrationLimitToEntity(v)
+	}
+	return responses
+}
@@ -17,6 +17,25 @@ func CustomerFromEntity(data *domain.Customer) Customer {
 		KtpPhotoUrl:        data.KtpUrl,
 		SelfiePhotoUrl:     data.SelfieUrl,
 		VerificationStatus: VerificationStatus(data.VerificationStatus),
+		Language:           string(data.Language),
+
+		Email: nullableString(data.Email),
+		Phone: nullableString(data.Phone),
+
+		DocumentsSubmittedAt:  data.DocumentsSubmittedAt,
+		PendingReminderSentAt: data.PendingReminderSentAt,
+
+		RegistrationIP:       data.RegistrationIP,
+		RegistrationDeviceID: data.RegistrationDeviceID,
+
+		MonthlyStatementOptOut: data.MonthlyStatementOptOut,
+
+		DormantAt: data.DormantAt,
+		ClosedAt:  data.ClosedAt,
+
+		Version: data.Version,
+
+		MustChangePassword: data.MustChangePassword,
 	}
 }
 
@@ -34,6 +53,27 @@ func CustomerToEntity(data Customer) *domain.Customer {
 		KtpUrl:             data.KtpPhotoUrl,
 		SelfieUrl:          data.SelfiePhotoUrl,
 		VerificationStatus: domain.VerificationStatus(data.VerificationStatus),
+		Language:           domain.Language(data.Language),
+		CreatedAt:          data.CreatedAt,
+		UpdatedAt:          data.UpdatedAt,
+
+		Email: stringValue(data.Email),
+		Phone: stringValue(data.Phone),
+
+		DocumentsSubmittedAt:  data.DocumentsSubmittedAt,
+		PendingReminderSentAt: data.PendingReminderSentAt,
+
+		RegistrationIP:       data.RegistrationIP,
+		RegistrationDeviceID: data.RegistrationDeviceID,
+
+		MonthlyStatementOptOut: data.MonthlyStatementOptOut,
+
+		DormantAt: data.DormantAt,
+		ClosedAt:  data.ClosedAt,
+
+		Version: data.Version,
+
+		MustChangePassword: data.MustChangePassword,
 	}
 }
 
@@ -53,8 +93,41 @@ func CustomersToEntity(data []Customer) []domain.Customer {
 			KtpUrl:             c.KtpPhotoUrl,
 			SelfieUrl:          c.SelfiePhotoUrl,
 			VerificationStatus: domain.VerificationStatus(c.VerificationStatus),
+			Language:           domain.Language(c.Language),
+			CreatedAt:          c.CreatedAt,
+			UpdatedAt:          c.UpdatedAt,
+
+			Email: stringValue(c.Email),
+			Phone: stringValue(c.Phone),
+
+			DocumentsSubmittedAt:  c.DocumentsSubmittedAt,
+			PendingReminderSentAt: c.PendingReminderSentAt,
+
+			MonthlyStatementOptOut: c.MonthlyStatementOptOut,
+
+			DormantAt: c.DormantAt,
+			ClosedAt:  c.ClosedAt,
+
+			Version: c.Version,
+
+			MustChangePassword: c.MustChangePassword,
 		}
 	}
 
 	return responses
 }
+
+// nullableString stores an empty value as NULL so unique indexes ignore it.
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CustomerEventFromEntity(data *domain.CustomerEvent) CustomerEvent {
+	return CustomerEvent{
+		ID:          data.ID,
+		CustomerID:  data.CustomerID,
+		Type:        string(data.Type),
+		ActorID:     data.ActorID,
+		ReferenceID: data.ReferenceID,
+		Data:        data.Data,
+		OccurredAt:  data.OccurredAt,
+	}
+}
+
+func CustomerEventToEntity(data CustomerEvent) *domain.CustomerEvent {
+	return &domain.CustomerEvent{
+		ID:          data.ID,
+		CustomerID:  data.CustomerID,
+		Type:        domain.CustomerEventType(data.Type),
+		ActorID:     data.ActorID,
+		ReferenceID: data.ReferenceID,
+		Data:        data.Data,
+		OccurredAt:  data.OccurredAt,
+	}
+}
+
+func CustomerEventsToEntity(data []CustomerEvent) []domain.CustomerEvent {
+	events := make([]domain.CustomerEvent, len(data))
+	for i, e := range data {
+		events[i] = *CustomerEventToEntity(e)
+	}
+	return events
+}
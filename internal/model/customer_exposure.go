@@ -0,0 +1,31 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CustomerExposureFromEntity(data domain.CustomerExposure) CustomerExposure {
+	return CustomerExposure{
+		CustomerID:      data.CustomerID,
+		TenorID:         data.TenorID,
+		ActivePrincipal: data.ActivePrincipal,
+		UpdatedAt:       data.UpdatedAt,
+	}
+}
+
+func CustomerExposureToEntity(data CustomerExposure) *domain.CustomerExposure {
+	return &domain.CustomerExposure{
+		CustomerID:      data.CustomerID,
+		TenorID:         data.TenorID,
+		ActivePrincipal: data.ActivePrincipal,
+		UpdatedAt:       data.UpdatedAt,
+	}
+}
+
+func CustomerExposuresToEntity(data []CustomerExposure) []domain.CustomerExposure {
+	exposures := make([]domain.CustomerExposure, len(data))
+	for i, e := range data {
+		exposures[i] = *CustomerExposureToEntity(e)
+	}
+	return exposures
+}
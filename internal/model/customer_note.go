@@ -0,0 +1,53 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CustomerNoteFromEntity(data *domain.CustomerNote) CustomerNote {
+	return CustomerNote{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		AuthorID:   data.AuthorID,
+		Body:       data.Body,
+		Pinned:     data.Pinned,
+		UpdatedBy:  data.UpdatedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func CustomerNoteToEntity(data CustomerNote) *domain.CustomerNote {
+	revisions := make([]domain.CustomerNoteRevision, len(data.Revisions))
+	for i, r := range data.Revisions {
+		revisions[i] = domain.CustomerNoteRevision{
+			ID:        r.ID,
+			NoteID:    r.NoteID,
+			Body:      r.Body,
+			Pinned:    r.Pinned,
+			UpdatedBy: r.UpdatedBy,
+			UpdatedAt: r.UpdatedAt,
+		}
+	}
+
+	return &domain.CustomerNote{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		AuthorID:   data.AuthorID,
+		Body:       data.Body,
+		Pinned:     data.Pinned,
+		UpdatedBy:  data.UpdatedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+		Revisions:  revisions,
+	}
+}
+
+func CustomerNotesToEntity(data []CustomerNote) []domain.CustomerNote {
+	responses := make([]domain.CustomerNote, len(data))
+	for i, n := range data {
+		responses[i] = *CustomerNoteToEntity(n)
+	}
+
+	return responses
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CustomerRestrictionFromEntity(data *domain.CustomerRestriction) CustomerRestriction {
+	return CustomerRestriction{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		TenorID:    data.TenorID,
+		Reason:     string(data.Reason),
+		Note:       data.Note,
+		CreatedBy:  data.CreatedBy,
+		ExpiresAt:  data.ExpiresAt,
+		LiftedAt:   data.LiftedAt,
+		LiftedBy:   data.LiftedBy,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func CustomerRestrictionToEntity(data CustomerRestriction) *domain.CustomerRestriction {
+	restriction := &domain.CustomerRestriction{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		TenorID:    data.TenorID,
+		Reason:     domain.RestrictionReason(data.Reason),
+		Note:       data.Note,
+		CreatedBy:  data.CreatedBy,
+		ExpiresAt:  data.ExpiresAt,
+		LiftedAt:   data.LiftedAt,
+		LiftedBy:   data.LiftedBy,
+		CreatedAt:  data.CreatedAt,
+	}
+	if data.Tenor != nil {
+		restriction.TenorMonths = data.Tenor.DurationMonths
+	}
+	return restriction
+}
+
+func CustomerRestrictionsToEntity(data []CustomerRestriction) []domain.CustomerRestriction {
+	restrictions := make([]domain.CustomerRestriction, len(data))
+	for i, r := range data {
+		restrictions[i] = *CustomerRestrictionToEntity(r)
+	}
+	return restrictions
+}
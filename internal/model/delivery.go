@@ -0,0 +1,50 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func DeliveryFromEntity(data *domain.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:            data.ID,
+		PartnerID:     data.PartnerID,
+		EventType:     data.EventType,
+		URL:           data.URL,
+		Payload:       data.Payload,
+		Status:        DeliveryStatus(data.Status),
+		Attempts:      data.Attempts,
+		Replays:       data.Replays,
+		LastError:     data.LastError,
+		LastAttemptAt: data.LastAttemptAt,
+		DeliveredAt:   data.DeliveredAt,
+		CreatedAt:     data.CreatedAt,
+		UpdatedAt:     data.UpdatedAt,
+	}
+}
+
+func DeliveryToEntity(data WebhookDelivery) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:            data.ID,
+		PartnerID:     data.PartnerID,
+		EventType:     data.EventType,
+		URL:           data.URL,
+		Payload:       data.Payload,
+		Status:        domain.DeliveryStatus(data.Status),
+		Attempts:      data.Attempts,
+		Replays:       data.Replays,
+		LastError:     data.LastError,
+		LastAttemptAt: data.LastAttemptAt,
+		DeliveredAt:   data.DeliveredAt,
+		CreatedAt:     data.CreatedAt,
+		UpdatedAt:     data.UpdatedAt,
+	}
+}
+
+func DeliveriesToEntity(data []WebhookDelivery) []domain.WebhookDelivery {
+	responses := make([]domain.WebhookDelivery, len(data))
+	for i, d := range data {
+		responses[i] = *DeliveryToEntity(d)
+	}
+
+	return responses
+}
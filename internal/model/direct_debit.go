@@ -0,0 +1,66 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func DirectDebitMandateFromEntity(data *domain.DirectDebitMandate) DirectDebitMandate {
+	return DirectDebitMandate{
+		CustomerID: data.CustomerID,
+		Provider:   data.Provider,
+		Token:      data.Token,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func DirectDebitMandateToEntity(data DirectDebitMandate) *domain.DirectDebitMandate {
+	return &domain.DirectDebitMandate{
+		CustomerID: data.CustomerID,
+		Provider:   data.Provider,
+		Token:      data.Token,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func DirectDebitAttemptFromEntity(data *domain.DirectDebitAttempt) DirectDebitAttempt {
+	return DirectDebitAttempt{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Installment:   data.Installment,
+		Attempt:       data.Attempt,
+		Provider:      data.Provider,
+		ExternalID:    data.ExternalID,
+		Amount:        data.Amount,
+		Currency:      data.Currency,
+		Status:        PaymentStatus(data.Status),
+		FailureReason: data.FailureReason,
+		AttemptedAt:   data.AttemptedAt,
+	}
+}
+
+func DirectDebitAttemptToEntity(data DirectDebitAttempt) *domain.DirectDebitAttempt {
+	return &domain.DirectDebitAttempt{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Installment:   data.Installment,
+		Attempt:       data.Attempt,
+		Provider:      data.Provider,
+		ExternalID:    data.ExternalID,
+		Amount:        data.Amount,
+		Currency:      data.Currency,
+		Status:        domain.PaymentStatus(data.Status),
+		FailureReason: data.FailureReason,
+		AttemptedAt:   data.AttemptedAt,
+	}
+}
+
+func DirectDebitAttemptsToEntity(data []DirectDebitAttempt) []domain.DirectDebitAttempt {
+	responses := make([]domain.DirectDebitAttempt, len(data))
+	for i, a := range data {
+		responses[i] = *DirectDebitAttemptToEntity(a)
+	}
+
+	return responses
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+)
+
+// DueDatePolicyID is the primary key of the only due date policy row.
+const DueDatePolicyID = 1
+
+func HolidayFromEntity(data *domain.Holiday) Holiday {
+	return Holiday{
+		Date:      data.Date,
+		Name:      data.Name,
+		UpdatedBy: data.UpdatedBy,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func HolidayToEntity(data Holiday) *domain.Holiday {
+	return &domain.Holiday{
+		Date:      data.Date,
+		Name:      data.Name,
+		UpdatedBy: data.UpdatedBy,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func HolidaysToEntity(data []Holiday) []domain.Holiday {
+	responses := make([]domain.Holiday, len(data))
+	for i, h := range data {
+		responses[i] = *HolidayToEntity(h)
+	}
+
+	return responses
+}
+
+func DueDatePolicyFromEntity(data *domain.DueDatePolicy) DueDatePolicy {
+	return DueDatePolicy{
+		ID:        DueDatePolicyID,
+		Shift:     string(data.Shift),
+		GraceDays: data.GraceDays,
+		UpdatedBy: data.UpdatedBy,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func DueDatePolicyToEntity(data DueDatePolicy) *domain.DueDatePolicy {
+	return &domain.DueDatePolicy{
+		Shift:     installment.Shift(data.Shift),
+		GraceDays: data.GraceDays,
+		UpdatedBy: data.UpdatedBy,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
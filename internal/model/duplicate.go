@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func DuplicateResolutionFromEntity(data *domain.DuplicateResolution) DuplicateResolution {
+	return DuplicateResolution{
+		ID:          data.ID,
+		CustomerID:  data.CustomerID,
+		DuplicateID: data.DuplicateID,
+		Action:      DuplicateAction(data.Action),
+		ResolvedBy:  data.ResolvedBy,
+		Note:        data.Note,
+		CreatedAt:   data.CreatedAt,
+	}
+}
+
+func DuplicateResolutionToEntity(data DuplicateResolution) *domain.DuplicateResolution {
+	return &domain.DuplicateResolution{
+		ID:          data.ID,
+		CustomerID:  data.CustomerID,
+		DuplicateID: data.DuplicateID,
+		Action:      domain.DuplicateAction(data.Action),
+		ResolvedBy:  data.ResolvedBy,
+		Note:        data.Note,
+		CreatedAt:   data.CreatedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func EmailMessageFromEntity(data *domain.EmailMessage) EmailMessage {
+	return EmailMessage{
+		ID:                data.ID,
+		CustomerID:        data.CustomerID,
+		Recipient:         data.Recipient,
+		Template:          string(data.Template),
+		Language:          string(data.Language),
+		Subject:           data.Subject,
+		Body:              data.Body,
+		Status:            string(data.Status),
+		Attempts:          data.Attempts,
+		NextAttemptAt:     data.NextAttemptAt,
+		LastError:         data.LastError,
+		Provider:          data.Provider,
+		ProviderMessageID: data.ProviderMessageID,
+		SentAt:            data.SentAt,
+		CreatedAt:         data.CreatedAt,
+		UpdatedAt:         data.UpdatedAt,
+	}
+}
+
+func EmailMessageToEntity(data EmailMessage) *domain.EmailMessage {
+	return &domain.EmailMessage{
+		ID:                data.ID,
+		CustomerID:        data.CustomerID,
+		Recipient:         data.Recipient,
+		Template:          domain.NotificationTemplate(data.Template),
+		Language:          domain.Language(data.Language),
+		Subject:           data.Subject,
+		Body:              data.Body,
+		Status:            domain.EmailStatus(data.Status),
+		Attempts:          data.Attempts,
+		NextAttemptAt:     data.NextAttemptAt,
+		LastError:         data.LastError,
+		Provider:          data.Provider,
+		ProviderMessageID: data.ProviderMessageID,
+		SentAt:            data.SentAt,
+		CreatedAt:         data.CreatedAt,
+		UpdatedAt:         data.UpdatedAt,
+	}
+}
+
+func EmailMessagesToEntity(data []EmailMessage) []domain.EmailMessage {
+	messages := make([]domain.EmailMessage, len(data))
+	for i, m := range data {
+		messages[i] = *EmailMessageToEntity(m)
+	}
+
+	return messages
+}
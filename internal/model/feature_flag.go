@@ -0,0 +1,36 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func FeatureFlagFromEntity(data *domain.FeatureFlag) FeatureFlag {
+	return FeatureFlag{
+		Key:         data.Key,
+		Description: data.Description,
+		Enabled:     data.Enabled,
+		PartnerIDs:  data.PartnerIDs,
+		UpdatedBy:   data.UpdatedBy,
+		UpdatedAt:   data.UpdatedAt,
+	}
+}
+
+func FeatureFlagToEntity(data FeatureFlag) *domain.FeatureFlag {
+	return &domain.FeatureFlag{
+		Key:         data.Key,
+		Description: data.Description,
+		Enabled:     data.Enabled,
+		PartnerIDs:  data.PartnerIDs,
+		UpdatedBy:   data.UpdatedBy,
+		UpdatedAt:   data.UpdatedAt,
+	}
+}
+
+func FeatureFlagsToEntity(data []FeatureFlag) []domain.FeatureFlag {
+	responses := make([]domain.FeatureFlag, len(data))
+	for i, f := range data {
+		responses[i] = *FeatureFlagToEntity(f)
+	}
+
+	return responses
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func FxRateFromEntity(data *domain.FxRate) FxRate {
+	return FxRate{
+		ID:         data.ID,
+		Currency:   data.Currency,
+		RateDate:   data.RateDate,
+		RateToIDR:  data.RateToIDR,
+		UploadedBy: data.UploadedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func FxRateToEntity(data FxRate) *domain.FxRate {
+	return &domain.FxRate{
+		ID:         data.ID,
+		Currency:   data.Currency,
+		RateDate:   data.RateDate,
+		RateToIDR:  data.RateToIDR,
+		UploadedBy: data.UploadedBy,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func FxRatesToEntity(data []FxRate) []domain.FxRate {
+	responses := make([]domain.FxRate, len(data))
+	for i, r := range data {
+		responses[i] = *FxRateToEntity(r)
+	}
+
+	return responses
+}
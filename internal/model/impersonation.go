@@ -0,0 +1,32 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func ImpersonationSessionToEntity(data ImpersonationSession) *domain.ImpersonationSession {
+	return &domain.ImpersonationSession{
+		ID:         data.ID,
+		AdminID:    data.AdminID,
+		CustomerID: data.CustomerID,
+		Reason:     data.Reason,
+		ExpiresAt:  data.ExpiresAt,
+		EndedAt:    data.EndedAt,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func ImpersonationRequestsToEntity(data []ImpersonationRequest) []domain.ImpersonationRequest {
+	requests := make([]domain.ImpersonationRequest, len(data))
+	for i, r := range data {
+		requests[i] = domain.ImpersonationRequest{
+			ID:        r.ID,
+			SessionID: r.SessionID,
+			Method:    r.Method,
+			Path:      r.Path,
+			Status:    r.Status,
+			CreatedAt: r.CreatedAt,
+		}
+	}
+	return requests
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func IncomeVerificationFromEntity(data *domain.IncomeVerification) IncomeVerification {
+	return IncomeVerification{
+		ID:                   data.ID,
+		CustomerID:           data.CustomerID,
+		FileName:             data.FileName,
+		StatementUrl:         data.StatementUrl,
+		DeclaredSalary:       data.DeclaredSalary,
+		AverageMonthlyInflow: data.AverageMonthlyInflow,
+		TotalInflow:          data.TotalInflow,
+		PeriodStart:          data.PeriodStart,
+		PeriodEnd:            data.PeriodEnd,
+		MonthsCovered:        data.MonthsCovered,
+		TransactionCount:     data.TransactionCount,
+		Discrepancy:          data.Discrepancy,
+		Status:               IncomeVerificationStatus(data.Status),
+		ReviewerID:           data.ReviewerID,
+		ReviewNote:           data.ReviewNote,
+		ReviewedAt:           data.ReviewedAt,
+		CreatedAt:            data.CreatedAt,
+		UpdatedAt:            data.UpdatedAt,
+	}
+}
+
+func IncomeVerificationToEntity(data IncomeVerification) *domain.IncomeVerification {
+	return &domain.IncomeVerification{
+		ID:                   data.ID,
+		CustomerID:           data.CustomerID,
+		FileName:             data.FileName,
+		StatementUrl:         data.StatementUrl,
+		DeclaredSalary:       data.DeclaredSalary,
+		AverageMonthlyInflow: data.AverageMonthlyInflow,
+		TotalInflow:          data.TotalInflow,
+		PeriodStart:          data.PeriodStart,
+		PeriodEnd:            data.PeriodEnd,
+		MonthsCovered:        data.MonthsCovered,
+		TransactionCount:     data.TransactionCount,
+		Discrepancy:          data.Discrepancy,
+		Status:               domain.IncomeVerificationStatus(data.Status),
+		ReviewerID:           data.ReviewerID,
+		ReviewNote:           data.ReviewNote,
+		ReviewedAt:           data.ReviewedAt,
+		CreatedAt:            data.CreatedAt,
+		UpdatedAt:            data.UpdatedAt,
+	}
+}
+
+func IncomeVerificationsToEntity(data []IncomeVerification) []domain.IncomeVerification {
+	responses := make([]domain.IncomeVerification, len(data))
+	for i, v := range data {
+		responses[i] = *IncomeVerificationToEntity(v)
+	}
+
+	return responses
+}
@@ -6,9 +6,14 @@ import (
 
 func LimitToEntity(data CustomerLimit) *domain.CustomerLimit {
 	return &domain.CustomerLimit{
-		CustomerID:  data.CustomerID,
-		TenorID:     data.TenorID,
-		LimitAmount: data.LimitAmount,
+		CustomerID:    data.CustomerID,
+		TenorID:       data.TenorID,
+		LimitAmount:   data.LimitAmount,
+		Currency:      data.Currency,
+		Version:       data.Version,
+		EffectiveFrom: data.EffectiveFrom,
+		EffectiveTo:   data.EffectiveTo,
+		Tenor:         *TenorToEntity(data.Tenor),
 	}
 }
 
@@ -16,11 +21,42 @@ func LimitsToEntity(data []CustomerLimit) []domain.CustomerLimit {
 	responses := make([]domain.CustomerLimit, len(data))
 	for i, c := range data {
 		responses[i] = domain.CustomerLimit{
-			CustomerID:  c.CustomerID,
-			TenorID:     c.TenorID,
-			LimitAmount: c.LimitAmount,
+			CustomerID:    c.CustomerID,
+			TenorID:       c.TenorID,
+			LimitAmount:   c.LimitAmount,
+			Currency:      c.Currency,
+			Version:       c.Version,
+			EffectiveFrom: c.EffectiveFrom,
+			EffectiveTo:   c.EffectiveTo,
+			Tenor:         *TenorToEntity(c.Tenor),
 		}
 	}
 
 	return responses
 }
+
+func LimitHistoryToEntity(data []CustomerLimitHistory) []domain.LimitChange {
+	changes := make([]domain.LimitChange, len(data))
+	for i, h := range data {
+		// Riwayat sebelum limit bertanggal efektif langsung berlaku saat diubah
+		effectiveFrom := h.ChangedAt
+		if h.EffectiveFrom != nil {
+			effectiveFrom = *h.EffectiveFrom
+		}
+		changes[i] = domain.LimitChange{
+			ID:               h.ID,
+			CustomerID:       h.CustomerID,
+			TenorID:          h.TenorID,
+			TenorMonths:      h.Tenor.DurationMonths,
+			PreviousAmount:   h.PreviousAmount,
+			PreviousCurrency: h.PreviousCurrency,
+			NewAmount:        h.NewAmount,
+			Currency:         h.Currency,
+			ChangedBy:        h.ChangedBy,
+			ChangedAt:        h.ChangedAt,
+			EffectiveFrom:    effectiveFrom,
+		}
+	}
+
+	return changes
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"time"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -24,13 +25,36 @@ type Customer struct {
 	Role               Role               `gorm:"type:enum('admin','customer','partner');default:'customer';not null" json:"role"`
 	BirthPlace         string             `gorm:"type:varchar(100);not null" json:"birth_place"`
 	BirthDate          time.Time          `gorm:"type:date;not null" json:"birth_date"`
-	Salary             float64            `gorm:"type:decimal(15,2);not null" json:"salary"`
+	Salary             decimal.Decimal    `gorm:"type:decimal(18,2);not null" json:"salary"`
 	KtpPhotoUrl        string             `gorm:"type:varchar(255);not null" json:"ktp_photo_url"`
 	SelfiePhotoUrl     string             `gorm:"type:varchar(255);not null" json:"selfie_photo_url"`
 	VerificationStatus VerificationStatus `gorm:"type:enum('PENDING','VERIFIED','REJECTED');default:'PENDING';not null" json:"verification_status"`
+	Language           string             `gorm:"type:varchar(2);default:'id';not null" json:"language"`
 	CreatedAt          time.Time          `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt          time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
 
+	// NULL untuk customer yang terdaftar sebelum kontak wajib diisi
+	Email *string `gorm:"type:varchar(255);uniqueIndex" json:"email,omitempty"`
+	Phone *string `gorm:"type:varchar(20);uniqueIndex" json:"phone,omitempty"`
+
+	// Registrasi PENDING dihitung kedaluwarsa sejak dokumen terakhir diunggah
+	DocumentsSubmittedAt  *time.Time `json:"documents_submitted_at,omitempty"`
+	PendingReminderSentAt *time.Time `json:"pending_reminder_sent_at,omitempty"`
+
+	RegistrationIP       string `gorm:"type:varchar(45);not null;default:''" json:"-"`
+	RegistrationDeviceID string `gorm:"type:varchar(128);not null;default:''" json:"-"`
+
+	MonthlyStatementOptOut bool `gorm:"not null;default:false" json:"monthly_statement_opt_out"`
+
+	DormantAt *time.Time `gorm:"index" json:"dormant_at,omitempty"`
+	ClosedAt  *time.Time `gorm:"index" json:"closed_at,omitempty"`
+
+	// Naik setiap kali profil diubah, untuk optimistic locking
+	Version uint64 `gorm:"not null;default:1" json:"version"`
+
+	// Akun hasil seed wajib mengganti password sebelum bisa dipakai
+	MustChangePassword bool `gorm:"not null;default:false" json:"must_change_password"`
+
 	CustomerLimits []CustomerLimit `gorm:"foreignKey:CustomerID" json:"customer_limits,omitempty"`
 	Transactions   []Transaction   `gorm:"foreignKey:CustomerID" json:"transactions,omitempty"`
 }
@@ -49,6 +73,14 @@ type Tenor struct {
 	ID             uint   `gorm:"primaryKey;autoIncrement" json:"id"`
 	DurationMonths uint8  `gorm:"not null;uniqueIndex" json:"duration_months"`
 	Description    string `gorm:"type:varchar(50)" json:"description"`
+	Active         bool   `gorm:"not null;default:true" json:"active"`
+
+	MinAmount       decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"min_amount"`
+	MaxAmount       decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"max_amount"`
+	MinAge          uint8           `gorm:"not null;default:0" json:"min_age"`
+	MaxAge          uint8           `gorm:"not null;default:0" json:"max_age"`
+	MaxAgeAtEnd     uint8           `gorm:"not null;default:0" json:"max_age_at_end"`
+	AssetCategories []string        `gorm:"type:text;serializer:json" json:"asset_categories"`
 
 	CustomerLimits []CustomerLimit `gorm:"foreignKey:TenorID" json:"customer_limits,omitempty"`
 	Transactions   []Transaction   `gorm:"foreignKey:TenorID" json:"transactions,omitempty"`
@@ -56,14 +88,37 @@ type Tenor struct {
 
 // CustomerLimit represents the customer_limits table
 type CustomerLimit struct {
-	CustomerID  uint64  `gorm:"primaryKey" json:"customer_id"`
-	TenorID     uint    `gorm:"primaryKey" json:"tenor_id"`
-	LimitAmount float64 `gorm:"type:decimal(15,2);not null" json:"limit_amount"`
+	CustomerID  uint64          `gorm:"primaryKey" json:"customer_id"`
+	TenorID     uint            `gorm:"primaryKey" json:"tenor_id"`
+	LimitAmount decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"limit_amount"`
+	Currency    string          `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	Version     uint64          `gorm:"not null;default:1" json:"version"`
+	// Presisi mikrodetik mengikuti driver, sehingga limit yang baru ditulis
+	// langsung terbaca berlaku tanpa pembulatan ke depan
+	EffectiveFrom time.Time  `gorm:"primaryKey;type:datetime(6);autoCreateTime" json:"effective_from"`
+	EffectiveTo   *time.Time `gorm:"type:datetime(6)" json:"effective_to,omitempty"`
 
 	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"customer"`
 	Tenor    Tenor    `gorm:"foreignKey:TenorID;constraint:OnDelete:RESTRICT" json:"tenor"`
 }
 
+// CustomerLimitHistory represents the customer_limit_history table
+type CustomerLimitHistory struct {
+	ID               uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID       uint64           `gorm:"not null;index:idx_limit_history_customer,priority:1" json:"customer_id"`
+	TenorID          uint             `gorm:"not null" json:"tenor_id"`
+	PreviousAmount   *decimal.Decimal `gorm:"type:decimal(18,2)" json:"previous_amount,omitempty"`
+	PreviousCurrency string           `gorm:"type:varchar(3)" json:"previous_currency,omitempty"`
+	NewAmount        decimal.Decimal  `gorm:"type:decimal(18,2);not null" json:"new_amount"`
+	Currency         string           `gorm:"type:varchar(3);not null" json:"currency"`
+	ChangedBy        uint64           `gorm:"not null" json:"changed_by"`
+	ChangedAt        time.Time        `gorm:"autoCreateTime;index:idx_limit_history_customer,priority:2" json:"changed_at"`
+	EffectiveFrom    *time.Time       `gorm:"type:datetime(6)" json:"effective_from,omitempty"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+	Tenor    Tenor    `gorm:"foreignKey:TenorID;constraint:OnDelete:RESTRICT" json:"tenor"`
+}
+
 // Transaction represents the transactions table
 type Transaction struct {
 	ID                     uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -71,26 +126,361 @@ type Transaction struct {
 	CustomerID             uint64            `gorm:"not null" json:"customer_id"`
 	TenorID                uint              `gorm:"not null" json:"tenor_id"`
 	AssetName              string            `gorm:"type:varchar(255);not null" json:"asset_name"`
-	OTRAmount              float64           `gorm:"type:decimal(15,2);not null" json:"otr_amount"`
-	AdminFee               float64           `gorm:"type:decimal(15,2);not null" json:"admin_fee"`
-	TotalInterest          float64           `gorm:"type:decimal(15,2);not null" json:"total_interest"`
-	TotalInstallmentAmount float64           `gorm:"type:decimal(15,2);not null" json:"total_installment_amount"`
-	Status                 TransactionStatus `gorm:"type:enum('PENDING','APPROVED','ACTIVE','PAID_OFF','CANCELLED');default:'PENDING';not null" json:"status"`
-	TransactionDate        time.Time         `gorm:"autoCreateTime" json:"transaction_date"`
+	AssetCategory          string            `gorm:"type:varchar(50);not null;default:'';index" json:"asset_category,omitempty"`
+	OTRAmount              decimal.Decimal   `gorm:"type:decimal(18,2);not null" json:"otr_amount"`
+	AdminFee               decimal.Decimal   `gorm:"type:decimal(18,2);not null" json:"admin_fee"`
+	TotalInterest          decimal.Decimal   `gorm:"type:decimal(18,2);not null" json:"total_interest"`
+	TotalInstallmentAmount decimal.Decimal   `gorm:"type:decimal(18,2);not null" json:"total_installment_amount"`
+	CommissionAmount       decimal.Decimal   `gorm:"type:decimal(18,2);not null;default:0" json:"commission_amount"`
+	DiscountAmount         decimal.Decimal   `gorm:"type:decimal(18,2);not null;default:0" json:"discount_amount"`
+	Status                 TransactionStatus `gorm:"type:enum('PENDING','APPROVED','ACTIVE','PAID_OFF','CANCELLED','WRITTEN_OFF');default:'PENDING';not null" json:"status"`
+	TransactionDate        time.Time         `gorm:"not null;autoCreateTime" json:"transaction_date"`
+	PartnerID              *uint64           `gorm:"index" json:"partner_id,omitempty"`
+	IsSandbox              bool              `gorm:"not null;default:false;index" json:"is_sandbox"`
+	Currency               string            `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	FxRate                 decimal.Decimal   `gorm:"type:decimal(18,6);not null;default:1" json:"fx_rate"`
+	PaidInstallments       *int              `json:"paid_installments,omitempty"`
+	VirtualAccountBank     string            `gorm:"type:varchar(32);not null;default:''" json:"virtual_account_bank,omitempty"`
+	VirtualAccountNumber   *string           `gorm:"type:varchar(32);uniqueIndex" json:"virtual_account_number,omitempty"`
+	RegionCode             string            `gorm:"type:varchar(8);not null;default:'';index" json:"region_code,omitempty"`
+	BranchCode             string            `gorm:"type:varchar(32);not null;default:''" json:"branch_code,omitempty"`
+	Latitude               *float64          `gorm:"type:decimal(9,6)" json:"latitude,omitempty"`
+	Longitude              *float64          `gorm:"type:decimal(9,6)" json:"longitude,omitempty"`
+	AMLScreenedAt          *time.Time        `gorm:"index" json:"-"`
 
 	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:RESTRICT" json:"customer"`
 	Tenor    Tenor    `gorm:"foreignKey:TenorID;constraint:OnDelete:RESTRICT" json:"tenor"`
 }
 
+// TransactionKey represents the transaction_keys table. It keeps contract
+// and virtual account numbers unique once transactions is partitioned and
+// its own unique indexes only hold per transaction_date. A key row is
+// written in the same database transaction as the change to transactions.
+type TransactionKey struct {
+	TransactionID        uint64  `gorm:"primaryKey;autoIncrement:false" json:"transaction_id"`
+	ContractNumber       string  `gorm:"type:varchar(50);not null;uniqueIndex" json:"contract_number"`
+	VirtualAccountNumber *string `gorm:"type:varchar(32);uniqueIndex" json:"virtual_account_number,omitempty"`
+}
+
 // TransactionStatus enum for transaction status
 type TransactionStatus string
 
 const (
-	TransactionPending   TransactionStatus = "PENDING"
-	TransactionApproved  TransactionStatus = "APPROVED"
-	TransactionActive    TransactionStatus = "ACTIVE"
-	TransactionPaidOff   TransactionStatus = "PAID_OFF"
-	TransactionCancelled TransactionStatus = "CANCELLED"
+	TransactionPending    TransactionStatus = "PENDING"
+	TransactionApproved   TransactionStatus = "APPROVED"
+	TransactionActive     TransactionStatus = "ACTIVE"
+	TransactionPaidOff    TransactionStatus = "PAID_OFF"
+	TransactionCancelled  TransactionStatus = "CANCELLED"
+	TransactionWrittenOff TransactionStatus = "WRITTEN_OFF"
+)
+
+// Partner represents the partners table
+type Partner struct {
+	ID               uint64        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name             string        `gorm:"type:varchar(255);not null" json:"name"`
+	Email            string        `gorm:"type:varchar(255);not null;uniqueIndex" json:"email"`
+	Status           PartnerStatus `gorm:"type:enum('SANDBOX','PRODUCTION');default:'SANDBOX';not null" json:"status"`
+	SandboxKeyHash   string        `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	SandboxKeyPrefix string        `gorm:"type:varchar(20);not null" json:"sandbox_key_prefix"`
+	LiveKeyHash      *string       `gorm:"type:char(64);uniqueIndex" json:"-"`
+	LiveKeyPrefix    string        `gorm:"type:varchar(20)" json:"live_key_prefix,omitempty"`
+	WebhookURL       string        `gorm:"type:varchar(500)" json:"webhook_url,omitempty"`
+	PromotedAt       *time.Time    `json:"promoted_at,omitempty"`
+	// Daftar CIDR dipisah koma, kosong berarti semua alamat diizinkan
+	AllowedCIDRs          string     `gorm:"type:text" json:"allowed_cidrs,omitempty"`
+	RequireClientCert     bool       `gorm:"not null;default:false" json:"require_client_cert"`
+	ClientCertFingerprint string     `gorm:"type:char(64)" json:"client_cert_fingerprint,omitempty"`
+	RequireSignature      bool       `gorm:"not null;default:false" json:"require_signature"`
+	SigningSecret         string     `gorm:"type:char(64)" json:"-"`
+	RegionCode            string     `gorm:"type:varchar(8);not null;default:''" json:"region_code,omitempty"`
+	BranchCode            string     `gorm:"type:varchar(32);not null;default:''" json:"branch_code,omitempty"`
+	SandboxDebugUntil     *time.Time `json:"sandbox_debug_until,omitempty"`
+	LiveDebugUntil        *time.Time `json:"live_debug_until,omitempty"`
+	CreatedAt             time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt             time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// PartnerStatus enum for partner onboarding stage
+type PartnerStatus string
+
+const (
+	PartnerSandbox    PartnerStatus = "SANDBOX"
+	PartnerProduction PartnerStatus = "PRODUCTION"
+)
+
+// WebhookDelivery represents the webhook_deliveries table, failed rows act as the dead-letter queue
+type WebhookDelivery struct {
+	ID            uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	PartnerID     uint64         `gorm:"not null;index" json:"partner_id"`
+	EventType     string         `gorm:"type:varchar(100);not null" json:"event_type"`
+	URL           string         `gorm:"type:varchar(500);not null" json:"url"`
+	Payload       string         `gorm:"type:text;not null" json:"payload"`
+	Status        DeliveryStatus `gorm:"type:enum('PENDING','DELIVERED','FAILED','POISONED');default:'PENDING';not null;index" json:"status"`
+	Attempts      int            `gorm:"not null;default:0" json:"attempts"`
+	Replays       int            `gorm:"not null;default:0" json:"replays"`
+	LastError     string         `gorm:"type:text" json:"last_error,omitempty"`
+	LastAttemptAt *time.Time     `json:"last_attempt_at,omitempty"`
+	DeliveredAt   *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Partner Partner `gorm:"foreignKey:PartnerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// DeliveryStatus enum for webhook delivery state
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryFailed    DeliveryStatus = "FAILED"
+	DeliveryPoisoned  DeliveryStatus = "POISONED"
+)
+
+// SalaryChange represents the salary_changes table, a customer's salary edit awaiting admin review
+type SalaryChange struct {
+	ID              uint64             `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID      uint64             `gorm:"not null;index" json:"customer_id"`
+	CurrentSalary   decimal.Decimal    `gorm:"type:decimal(18,2);not null" json:"current_salary"`
+	RequestedSalary decimal.Decimal    `gorm:"type:decimal(18,2);not null" json:"requested_salary"`
+	PayslipUrl      string             `gorm:"type:varchar(255);not null" json:"payslip_url"`
+	Status          SalaryChangeStatus `gorm:"type:enum('PENDING','APPROVED','REJECTED');default:'PENDING';not null;index" json:"status"`
+	ReviewerID      *uint64            `json:"reviewer_id,omitempty"`
+	ReviewNote      string             `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+	ReviewedAt      *time.Time         `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// SalaryChangeStatus enum for salary change review
+type SalaryChangeStatus string
+
+const (
+	SalaryChangePending  SalaryChangeStatus = "PENDING"
+	SalaryChangeApproved SalaryChangeStatus = "APPROVED"
+	SalaryChangeRejected SalaryChangeStatus = "REJECTED"
+)
+
+// IncomeVerification represents the income_verifications table, the inflow read from a customer's bank statement
+type IncomeVerification struct {
+	ID                   uint64                   `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID           uint64                   `gorm:"not null;index" json:"customer_id"`
+	FileName             string                   `gorm:"type:varchar(255);not null" json:"file_name"`
+	StatementUrl         string                   `gorm:"type:varchar(255);not null" json:"statement_url"`
+	DeclaredSalary       decimal.Decimal          `gorm:"type:decimal(18,2);not null" json:"declared_salary"`
+	AverageMonthlyInflow decimal.Decimal          `gorm:"type:decimal(18,2);not null" json:"average_monthly_inflow"`
+	TotalInflow          decimal.Decimal          `gorm:"type:decimal(18,2);not null" json:"total_inflow"`
+	PeriodStart          time.Time                `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd            time.Time                `gorm:"type:date;not null" json:"period_end"`
+	MonthsCovered        int                      `gorm:"not null" json:"months_covered"`
+	TransactionCount     int                      `gorm:"not null" json:"transaction_count"`
+	Discrepancy          decimal.Decimal          `gorm:"type:decimal(9,4);not null" json:"discrepancy"`
+	Status               IncomeVerificationStatus `gorm:"type:enum('MATCHED','FLAGGED','ACCEPTED','REJECTED');not null;index" json:"status"`
+	ReviewerID           *uint64                  `json:"reviewer_id,omitempty"`
+	ReviewNote           string                   `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+	ReviewedAt           *time.Time               `json:"reviewed_at,omitempty"`
+	CreatedAt            time.Time                `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt            time.Time                `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// IncomeVerificationStatus enum for bank statement checks
+type IncomeVerificationStatus string
+
+const (
+	IncomeMatched  IncomeVerificationStatus = "MATCHED"
+	IncomeFlagged  IncomeVerificationStatus = "FLAGGED"
+	IncomeAccepted IncomeVerificationStatus = "ACCEPTED"
+	IncomeRejected IncomeVerificationStatus = "REJECTED"
+)
+
+// RiskTierRule represents the risk_tier_rules table, the admin-managed rules that grade customers
+type RiskTierRule struct {
+	ID             uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Priority       int              `gorm:"not null;index" json:"priority"`
+	Tier           string           `gorm:"type:enum('LOW','MEDIUM','HIGH');not null" json:"tier"`
+	MinSalary      *decimal.Decimal `gorm:"type:decimal(18,2)" json:"min_salary,omitempty"`
+	MaxSalary      *decimal.Decimal `gorm:"type:decimal(18,2)" json:"max_salary,omitempty"`
+	MinScore       *int             `json:"min_score,omitempty"`
+	MaxScore       *int             `json:"max_score,omitempty"`
+	MinDaysPastDue *int             `json:"min_days_past_due,omitempty"`
+	MaxDaysPastDue *int             `json:"max_days_past_due,omitempty"`
+	UpdatedBy      uint64           `gorm:"not null" json:"updated_by"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// CustomerRiskTier represents the customer_risk_tiers table, one row per customer graded by the risk tier job
+type CustomerRiskTier struct {
+	CustomerID   uint64          `gorm:"primaryKey;autoIncrement:false" json:"customer_id"`
+	Tier         string          `gorm:"type:varchar(16);not null;default:'';index" json:"tier"`
+	RuleID       *uint64         `json:"rule_id,omitempty"`
+	Salary       decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"salary"`
+	CreditScore  *int            `json:"credit_score,omitempty"`
+	DaysPastDue  int             `gorm:"not null;default:0" json:"days_past_due"`
+	CalculatedAt time.Time       `gorm:"not null" json:"calculated_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BlacklistEntry represents the blacklist_entries table, screened on registration and transactions
+type BlacklistEntry struct {
+	ID        uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	NIK       string            `gorm:"type:varchar(16);index" json:"nik,omitempty"`
+	FullName  string            `gorm:"type:varchar(100);not null;index" json:"full_name"`
+	Reason    string            `gorm:"type:varchar(255);not null" json:"reason"`
+	Source    string            `gorm:"type:varchar(100);not null" json:"source"`
+	Severity  BlacklistSeverity `gorm:"type:enum('BLOCK','FLAG');default:'BLOCK';not null" json:"severity"`
+	CreatedAt time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BlacklistSeverity enum for blacklist hits
+type BlacklistSeverity string
+
+const (
+	SeverityBlock BlacklistSeverity = "BLOCK"
+	SeverityFlag  BlacklistSeverity = "FLAG"
+)
+
+// ScreeningLog represents the screening_logs table, one row per blacklist hit
+type ScreeningLog struct {
+	ID         uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	EntryID    uint64           `gorm:"not null;index" json:"entry_id"`
+	CustomerID *uint64          `gorm:"index" json:"customer_id,omitempty"`
+	NIK        string           `gorm:"type:varchar(16);not null" json:"nik"`
+	FullName   string           `gorm:"type:varchar(100);not null" json:"full_name"`
+	Action     ScreeningAction  `gorm:"type:enum('REGISTRATION','TRANSACTION');not null" json:"action"`
+	Outcome    ScreeningOutcome `gorm:"type:enum('BLOCKED','FLAGGED');not null;index" json:"outcome"`
+	CreatedAt  time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ScreeningAction enum for the screened action
+type ScreeningAction string
+
+const (
+	ScreeningRegistration ScreeningAction = "REGISTRATION"
+	ScreeningTransaction  ScreeningAction = "TRANSACTION"
+)
+
+// ScreeningOutcome enum for screening results
+type ScreeningOutcome string
+
+const (
+	ScreeningBlocked ScreeningOutcome = "BLOCKED"
+	ScreeningFlagged ScreeningOutcome = "FLAGGED"
+)
+
+// DuplicateResolution represents the duplicate_resolutions table, an admin decision on a possible duplicate pair
+type DuplicateResolution struct {
+	ID          uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID  uint64          `gorm:"not null;uniqueIndex:idx_duplicate_pair" json:"customer_id"`
+	DuplicateID uint64          `gorm:"not null;uniqueIndex:idx_duplicate_pair;index" json:"duplicate_id"`
+	Action      DuplicateAction `gorm:"type:enum('IGNORED','MERGED');not null" json:"action"`
+	ResolvedBy  uint64          `gorm:"not null" json:"resolved_by"`
+	Note        string          `gorm:"type:varchar(500)" json:"note,omitempty"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// DuplicateAction enum for duplicate resolutions
+type DuplicateAction string
+
+const (
+	DuplicateIgnored DuplicateAction = "IGNORED"
+	DuplicateMerged  DuplicateAction = "MERGED"
+)
+
+// Restructuring represents the restructurings table, the original and proposed terms of a maker-checker restructuring
+type Restructuring struct {
+	ID                         uint64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID              uint64              `gorm:"not null;index" json:"transaction_id"`
+	CustomerID                 uint64              `gorm:"not null;index" json:"customer_id"`
+	Type                       RestructuringType   `gorm:"type:enum('EXTEND_TENOR','REDUCE_INSTALLMENT');not null" json:"type"`
+	Status                     RestructuringStatus `gorm:"type:enum('PENDING','APPROVED','REJECTED');default:'PENDING';not null;index" json:"status"`
+	ContractDate               time.Time           `gorm:"not null" json:"contract_date"`
+	PaidInstallments           uint8               `gorm:"not null" json:"paid_installments"`
+	OriginalTenorID            uint                `gorm:"not null" json:"original_tenor_id"`
+	OriginalTenorMonths        uint8               `gorm:"not null" json:"original_tenor_months"`
+	OriginalTotalInterest      decimal.Decimal     `gorm:"type:decimal(18,2);not null" json:"original_total_interest"`
+	OriginalTotalInstallment   decimal.Decimal     `gorm:"type:decimal(18,2);not null" json:"original_total_installment"`
+	OriginalMonthlyInstallment decimal.Decimal     `gorm:"type:decimal(18,2);not null" json:"original_monthly_installment"`
+	NewTenorID                 uint                `gorm:"not null" json:"new_tenor_id"`
+	NewTenorMonths             uint8               `gorm:"not null" json:"new_tenor_months"`
+	NewTotalInterest           decimal.Decimal     `gorm:"type:decimal(18,2);not null" json:"new_total_interest"`
+	NewTotalInstallment        decimal.Decimal     `gorm:"type:decimal(18,2);not null" json:"new_total_installment"`
+	NewMonthlyInstallment      decimal.Decimal     `gorm:"type:decimal(18,2);not null" json:"new_monthly_installment"`
+	Reason                     string              `gorm:"type:varchar(500);not null" json:"reason"`
+	RequestedBy                uint64              `gorm:"not null" json:"requested_by"`
+	ReviewerID                 *uint64             `json:"reviewer_id,omitempty"`
+	ReviewNote                 string              `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+	ReviewedAt                 *time.Time          `json:"reviewed_at,omitempty"`
+	CreatedAt                  time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt                  time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+// RestructuringType enum for restructuring requests
+type RestructuringType string
+
+const (
+	RestructuringExtendTenor       RestructuringType = "EXTEND_TENOR"
+	RestructuringReduceInstallment RestructuringType = "REDUCE_INSTALLMENT"
+)
+
+// RestructuringStatus enum for maker-checker review
+type RestructuringStatus string
+
+const (
+	RestructuringPending  RestructuringStatus = "PENDING"
+	RestructuringApproved RestructuringStatus = "APPROVED"
+	RestructuringRejected RestructuringStatus = "REJECTED"
+)
+
+// FxRate represents the fx_rates table, the daily IDR value of one unit of a foreign currency
+type FxRate struct {
+	ID         uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Currency   string          `gorm:"type:varchar(3);not null;uniqueIndex:idx_fx_rate_currency_date" json:"currency"`
+	RateDate   time.Time       `gorm:"type:date;not null;uniqueIndex:idx_fx_rate_currency_date" json:"rate_date"`
+	RateToIDR  decimal.Decimal `gorm:"column:rate_to_idr;type:decimal(18,6);not null" json:"rate_to_idr"`
+	UploadedBy uint64          `gorm:"not null" json:"uploaded_by"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// AgingSnapshot represents the aging_snapshots table, the daily materialised
+// delinquency aging report
+type AgingSnapshot struct {
+	ID                uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	SnapshotDate      time.Time       `gorm:"type:date;not null;uniqueIndex:idx_aging_snapshot_bucket" json:"snapshot_date"`
+	TenorMonths       uint8           `gorm:"not null;uniqueIndex:idx_aging_snapshot_bucket" json:"tenor_months"`
+	Bucket            string          `gorm:"type:varchar(8);not null;uniqueIndex:idx_aging_snapshot_bucket" json:"bucket"`
+	ContractCount     int64           `gorm:"not null" json:"contract_count"`
+	OutstandingAmount decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"outstanding_amount"`
+	CreatedAt         time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Communication represents the communications table, every notification sent to a customer
+type Communication struct {
+	ID         uint64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID uint64              `gorm:"not null;index:idx_communication_customer" json:"customer_id"`
+	Channel    string              `gorm:"type:varchar(16);not null" json:"channel"`
+	Template   string              `gorm:"type:varchar(64);not null" json:"template"`
+	Data       map[string]string   `gorm:"type:text;serializer:json" json:"data"`
+	Status     CommunicationStatus `gorm:"type:enum('SENT','FAILED');not null" json:"status"`
+	Error      string              `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt  time.Time           `gorm:"autoCreateTime;index:idx_communication_customer" json:"created_at"`
+}
+
+// CommunicationStatus enum for the delivery outcome of a communication
+type CommunicationStatus string
+
+const (
+	CommunicationSent   CommunicationStatus = "SENT"
+	CommunicationFailed CommunicationStatus = "FAILED"
 )
 
 // TableName methods to specify custom table names if needed
@@ -106,16 +496,790 @@ func (CustomerLimit) TableName() string {
 	return "customer_limits"
 }
 
+func (CustomerLimitHistory) TableName() string {
+	return "customer_limit_history"
+}
+
 func (Transaction) TableName() string {
 	return "transactions"
 }
 
+func (TransactionKey) TableName() string {
+	return "transaction_keys"
+}
+
+func (Partner) TableName() string {
+	return "partners"
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (IncomeVerification) TableName() string {
+	return "income_verifications"
+}
+
+func (RiskTierRule) TableName() string {
+	return "risk_tier_rules"
+}
+
+func (CustomerRiskTier) TableName() string {
+	return "customer_risk_tiers"
+}
+
+func (SalaryChange) TableName() string {
+	return "salary_changes"
+}
+
+func (BlacklistEntry) TableName() string {
+	return "blacklist_entries"
+}
+
+func (ScreeningLog) TableName() string {
+	return "screening_logs"
+}
+
+func (DuplicateResolution) TableName() string {
+	return "duplicate_resolutions"
+}
+
+func (Restructuring) TableName() string {
+	return "restructurings"
+}
+
+func (FxRate) TableName() string {
+	return "fx_rates"
+}
+
+func (AgingSnapshot) TableName() string {
+	return "aging_snapshots"
+}
+
+func (Communication) TableName() string {
+	return "communications"
+}
+
+func (PartnerQuota) TableName() string {
+	return "partner_quotas"
+}
+
 // Database migration function
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	return db.AutoMigrate(models()...)
+}
+
+// models lists every table owned by the application in migration order.
+func models() []any {
+	return []any{
 		&Customer{},
 		&Tenor{},
 		&CustomerLimit{},
+		&CustomerLimitHistory{},
 		&Transaction{},
-	)
+		&TransactionKey{},
+		&Partner{},
+		&WebhookDelivery{},
+		&SalaryChange{},
+		&IncomeVerification{},
+		&RiskTierRule{},
+		&CustomerRiskTier{},
+		&BlacklistEntry{},
+		&ScreeningLog{},
+		&DuplicateResolution{},
+		&Restructuring{},
+		&FxRate{},
+		&AgingSnapshot{},
+		&Communication{},
+		&FeatureFlag{},
+		&PartnerFeeSchedule{},
+		&Payment{},
+		&PaymentCallback{},
+		&DirectDebitMandate{},
+		&DirectDebitAttempt{},
+		&Promotion{},
+		&PromotionRedemption{},
+		&ReferralCode{},
+		&Referral{},
+		&ImpersonationSession{},
+		&ImpersonationRequest{},
+		&PartnerQuota{},
+		&TransactionBatch{},
+		&TransactionBatchItem{},
+		&TransactionStatement{},
+		&MonthlyStatement{},
+		&CustomerNote{},
+		&CustomerNoteRevision{},
+		&TransactionAttachment{},
+		&Region{},
+		&ContractSequence{},
+		&CustomerExposure{},
+		&CustomerEvent{},
+		&CustomerRestriction{},
+		&AMLCase{},
+		&PartnerDebugRecord{},
+		&AccountClosure{},
+		&CalendarToken{},
+		&Holiday{},
+		&DueDatePolicy{},
+		&EmailMessage{},
+		&CustomerDevice{},
+		&NotificationPreference{},
+		&PaymentReminder{},
+		&CollectionCase{},
+		&CollectionActivity{},
+		&WriteOff{},
+		&WriteOffRecovery{},
+		&LedgerEntry{},
+		&ConcentrationLimit{},
+		&AssetCategory{},
+	}
+}
+
+type FeatureFlag struct {
+	Key         string    `gorm:"type:varchar(64);primaryKey" json:"key"`
+	Description string    `gorm:"type:varchar(255)" json:"description"`
+	Enabled     bool      `gorm:"not null;default:false" json:"enabled"`
+	PartnerIDs  []uint64  `gorm:"type:text;serializer:json" json:"partner_ids"`
+	UpdatedBy   uint64    `gorm:"not null" json:"updated_by"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type PartnerFeeSchedule struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	PartnerID      uint64          `gorm:"not null;uniqueIndex:idx_partner_fee_schedule" json:"partner_id"`
+	TenorMonths    uint8           `gorm:"not null;default:0;uniqueIndex:idx_partner_fee_schedule" json:"tenor_months"`
+	AdminFeeFlat   decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"admin_fee_flat"`
+	AdminFeeRate   decimal.Decimal `gorm:"type:decimal(9,6);not null;default:0" json:"admin_fee_rate"`
+	CommissionRate decimal.Decimal `gorm:"type:decimal(9,6);not null;default:0" json:"commission_rate"`
+	UpdatedBy      uint64          `gorm:"not null" json:"updated_by"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Partner Partner `gorm:"foreignKey:PartnerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type Payment struct {
+	ID            uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID uint64          `gorm:"not null;index" json:"transaction_id"`
+	Provider      string          `gorm:"type:varchar(32);not null;uniqueIndex:idx_payment_external" json:"provider"`
+	ExternalID    string          `gorm:"type:varchar(128);not null;uniqueIndex:idx_payment_external" json:"external_id"`
+	Amount        decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"amount"`
+	Currency      string          `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	Status        PaymentStatus   `gorm:"type:enum('PENDING','PAID','FAILED');default:'PENDING';not null" json:"status"`
+	PaidAt        *time.Time      `json:"paid_at,omitempty"`
+	CreatedAt     time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type PaymentStatus string
+
+const (
+	PaymentPending PaymentStatus = "PENDING"
+	PaymentPaid    PaymentStatus = "PAID"
+	PaymentFailed  PaymentStatus = "FAILED"
+)
+
+type PaymentCallback struct {
+	ID         uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Provider   string          `gorm:"type:varchar(32);not null;uniqueIndex:idx_payment_callback_event" json:"provider"`
+	EventID    *string         `gorm:"type:varchar(191);uniqueIndex:idx_payment_callback_event" json:"event_id,omitempty"`
+	Outcome    CallbackOutcome `gorm:"type:enum('PROCESSED','DUPLICATE','UNMATCHED','REJECTED');not null" json:"outcome"`
+	Error      string          `gorm:"type:varchar(255)" json:"error,omitempty"`
+	Payload    string          `gorm:"type:mediumtext;not null" json:"payload"`
+	ReceivedAt time.Time       `gorm:"autoCreateTime;index" json:"received_at"`
+}
+
+type CallbackOutcome string
+
+const (
+	CallbackProcessed CallbackOutcome = "PROCESSED"
+	CallbackDuplicate CallbackOutcome = "DUPLICATE"
+	CallbackUnmatched CallbackOutcome = "UNMATCHED"
+	CallbackRejected  CallbackOutcome = "REJECTED"
+)
+
+type DirectDebitMandate struct {
+	CustomerID uint64    `gorm:"primaryKey" json:"customer_id"`
+	Provider   string    `gorm:"type:varchar(32);not null" json:"provider"`
+	Token      string    `gorm:"type:varchar(255);not null" json:"-"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type DirectDebitAttempt struct {
+	ID            uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID uint64          `gorm:"not null;uniqueIndex:idx_direct_debit_attempt" json:"transaction_id"`
+	Installment   int             `gorm:"not null;uniqueIndex:idx_direct_debit_attempt" json:"installment"`
+	Attempt       int             `gorm:"not null;uniqueIndex:idx_direct_debit_attempt" json:"attempt"`
+	Provider      string          `gorm:"type:varchar(32);not null" json:"provider"`
+	ExternalID    string          `gorm:"type:varchar(128)" json:"external_id"`
+	Amount        decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"amount"`
+	Currency      string          `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	Status        PaymentStatus   `gorm:"type:enum('PENDING','PAID','FAILED');not null" json:"status"`
+	FailureReason string          `gorm:"type:varchar(255)" json:"failure_reason,omitempty"`
+	AttemptedAt   time.Time       `gorm:"not null;index" json:"attempted_at"`
+
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type Promotion struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code           string          `gorm:"type:varchar(32);not null;uniqueIndex" json:"code"`
+	Description    string          `gorm:"type:varchar(255)" json:"description"`
+	Target         PromotionTarget `gorm:"type:enum('ADMIN_FEE','INTEREST');not null" json:"target"`
+	DiscountType   DiscountType    `gorm:"type:enum('PERCENT','FLAT');not null" json:"discount_type"`
+	DiscountValue  decimal.Decimal `gorm:"type:decimal(18,6);not null" json:"discount_value"`
+	MaxDiscount    decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"max_discount"`
+	ValidFrom      time.Time       `gorm:"not null" json:"valid_from"`
+	ValidUntil     time.Time       `gorm:"not null" json:"valid_until"`
+	PartnerID      *uint64         `gorm:"index" json:"partner_id,omitempty"`
+	TenorMonths    uint8           `gorm:"not null;default:0" json:"tenor_months"`
+	MinOTRAmount   decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"min_otr_amount"`
+	MaxRedemptions int             `gorm:"not null;default:0" json:"max_redemptions"`
+	MaxPerCustomer int             `gorm:"not null;default:0" json:"max_per_customer"`
+	Active         bool            `gorm:"not null;default:true" json:"active"`
+	UpdatedBy      uint64          `gorm:"not null" json:"updated_by"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type PromotionTarget string
+
+const (
+	PromotionAdminFee PromotionTarget = "ADMIN_FEE"
+	PromotionInterest PromotionTarget = "INTEREST"
+)
+
+type DiscountType string
+
+const (
+	DiscountPercent DiscountType = "PERCENT"
+	DiscountFlat    DiscountType = "FLAT"
+)
+
+type PromotionRedemption struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	PromotionID    uint64          `gorm:"not null;index:idx_promotion_redemption_customer" json:"promotion_id"`
+	CustomerID     uint64          `gorm:"not null;index:idx_promotion_redemption_customer" json:"customer_id"`
+	TransactionID  uint64          `gorm:"not null;uniqueIndex" json:"transaction_id"`
+	DiscountAmount decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"discount_amount"`
+	Currency       string          `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	RedeemedAt     time.Time       `gorm:"autoCreateTime" json:"redeemed_at"`
+
+	Promotion   Promotion   `gorm:"foreignKey:PromotionID;constraint:OnDelete:RESTRICT" json:"-"`
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type ReferralCode struct {
+	CustomerID uint64    `gorm:"primaryKey;autoIncrement:false" json:"customer_id"`
+	Code       string    `gorm:"type:varchar(16);not null;uniqueIndex" json:"code"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type ReferralStatus string
+
+const (
+	ReferralAccepted ReferralStatus = "ACCEPTED"
+	ReferralRejected ReferralStatus = "REJECTED"
+)
+
+type Referral struct {
+	ID           uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReferrerID   uint64         `gorm:"not null;index" json:"referrer_id"`
+	RefereeID    uint64         `gorm:"not null;uniqueIndex" json:"referee_id"`
+	Code         string         `gorm:"type:varchar(16);not null" json:"code"`
+	Status       ReferralStatus `gorm:"type:enum('ACCEPTED','REJECTED');not null" json:"status"`
+	RejectReason string         `gorm:"type:varchar(64);not null;default:''" json:"reject_reason,omitempty"`
+	DeviceID     string         `gorm:"type:varchar(128);not null;default:'';index" json:"device_id"`
+	IPAddress    string         `gorm:"type:varchar(45);not null;default:'';index:idx_referral_ip_created" json:"ip_address"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime;index:idx_referral_ip_created" json:"created_at"`
+
+	Referrer Customer `gorm:"foreignKey:ReferrerID;constraint:OnDelete:CASCADE" json:"-"`
+	Referee  Customer `gorm:"foreignKey:RefereeID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type ImpersonationSession struct {
+	ID         uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	AdminID    uint64     `gorm:"not null;index" json:"admin_id"`
+	CustomerID uint64     `gorm:"not null;index" json:"customer_id"`
+	Reason     string     `gorm:"type:varchar(255);not null" json:"reason"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	Admin    Customer `gorm:"foreignKey:AdminID;constraint:OnDelete:RESTRICT" json:"-"`
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type ImpersonationRequest struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	SessionID uint64    `gorm:"not null;index" json:"session_id"`
+	Method    string    `gorm:"type:varchar(8);not null" json:"method"`
+	Path      string    `gorm:"type:varchar(255);not null" json:"path"`
+	Status    int       `gorm:"not null" json:"status"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Session ImpersonationSession `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type PartnerQuota struct {
+	PartnerID   uint64          `gorm:"primaryKey;autoIncrement:false" json:"partner_id"`
+	DailyCount  int64           `gorm:"not null;default:0" json:"daily_count"`
+	DailyVolume decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"daily_volume"`
+	UpdatedBy   uint64          `gorm:"not null" json:"updated_by"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Partner Partner `gorm:"foreignKey:PartnerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type BatchStatus string
+
+const (
+	BatchPending   BatchStatus = "PENDING"
+	BatchCompleted BatchStatus = "COMPLETED"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemPending    BatchItemStatus = "PENDING"
+	BatchItemProcessing BatchItemStatus = "PROCESSING"
+	BatchItemSucceeded  BatchItemStatus = "SUCCEEDED"
+	BatchItemFailed     BatchItemStatus = "FAILED"
+)
+
+type TransactionBatch struct {
+	ID          uint64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	PartnerID   *uint64     `gorm:"index" json:"partner_id,omitempty"`
+	CustomerID  *uint64     `gorm:"index" json:"customer_id,omitempty"`
+	IsSandbox   bool        `gorm:"not null;default:false" json:"is_sandbox"`
+	Status      BatchStatus `gorm:"type:enum('PENDING','COMPLETED');default:'PENDING';not null;index" json:"status"`
+	CreatedAt   time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+
+	Items []TransactionBatchItem `gorm:"foreignKey:BatchID" json:"items,omitempty"`
+}
+
+type TransactionBatchItem struct {
+	ID             uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	BatchID        uint64           `gorm:"not null;uniqueIndex:idx_batch_item_sequence" json:"batch_id"`
+	Sequence       int              `gorm:"not null;uniqueIndex:idx_batch_item_sequence" json:"sequence"`
+	CustomerNIK    string           `gorm:"type:varchar(16);not null" json:"customer_nik"`
+	TenorMonths    uint8            `gorm:"not null" json:"tenor_months"`
+	AssetName      string           `gorm:"type:varchar(255);not null" json:"asset_name"`
+	OTRAmount      decimal.Decimal  `gorm:"type:decimal(18,2);not null" json:"otr_amount"`
+	AdminFee       *decimal.Decimal `gorm:"type:decimal(18,2)" json:"admin_fee,omitempty"`
+	Currency       string           `gorm:"type:varchar(3);not null;default:''" json:"currency"`
+	PromoCode      string           `gorm:"type:varchar(32);not null;default:''" json:"promo_code"`
+	Status         BatchItemStatus  `gorm:"type:enum('PENDING','PROCESSING','SUCCEEDED','FAILED');default:'PENDING';not null;index" json:"status"`
+	TransactionID  *uint64          `json:"transaction_id,omitempty"`
+	ContractNumber string           `gorm:"type:varchar(50);not null;default:''" json:"contract_number,omitempty"`
+	ErrorCode      string           `gorm:"type:varchar(64);not null;default:''" json:"error_code,omitempty"`
+	ErrorMessage   string           `gorm:"type:varchar(255);not null;default:''" json:"error_message,omitempty"`
+	ProcessedAt    *time.Time       `json:"processed_at,omitempty"`
+
+	Batch TransactionBatch `gorm:"foreignKey:BatchID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type StatementStatus string
+
+const (
+	StatementPending StatementStatus = "PENDING"
+	StatementReady   StatementStatus = "READY"
+	StatementFailed  StatementStatus = "FAILED"
+)
+
+type TransactionStatement struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	ContractNumber string          `gorm:"type:varchar(50);not null;uniqueIndex" json:"contract_number"`
+	CustomerID     uint64          `gorm:"not null;index" json:"customer_id"`
+	Fingerprint    string          `gorm:"type:char(64);not null" json:"fingerprint"`
+	Status         StatementStatus `gorm:"type:enum('PENDING','READY','FAILED');default:'PENDING';not null;index" json:"status"`
+	URL            string          `gorm:"type:varchar(512)" json:"url"`
+	Error          string          `gorm:"type:varchar(255)" json:"error"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type MonthlyStatement struct {
+	ID         uint64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID uint64              `gorm:"not null;uniqueIndex:idx_monthly_statement_period" json:"customer_id"`
+	Period     string              `gorm:"type:char(7);not null;uniqueIndex:idx_monthly_statement_period" json:"period"`
+	Status     CommunicationStatus `gorm:"type:enum('SENT','FAILED');not null" json:"status"`
+	URL        string              `gorm:"type:varchar(512)" json:"url"`
+	Error      string              `gorm:"type:varchar(255)" json:"error"`
+	CreatedAt  time.Time           `gorm:"autoCreateTime" json:"created_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type CustomerNote struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID uint64    `gorm:"not null;index" json:"customer_id"`
+	AuthorID   uint64    `gorm:"not null" json:"author_id"`
+	Body       string    `gorm:"type:text;not null" json:"body"`
+	Pinned     bool      `gorm:"not null;default:false" json:"pinned"`
+	UpdatedBy  uint64    `gorm:"not null" json:"updated_by"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Revisions []CustomerNoteRevision `gorm:"foreignKey:NoteID" json:"revisions,omitempty"`
+	Customer  Customer               `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+	Author    Customer               `gorm:"foreignKey:AuthorID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+type CustomerNoteRevision struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	NoteID    uint64    `gorm:"not null;index" json:"note_id"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	Pinned    bool      `gorm:"not null" json:"pinned"`
+	UpdatedBy uint64    `gorm:"not null" json:"updated_by"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+
+	Note CustomerNote `gorm:"foreignKey:NoteID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type AttachmentType string
+
+const (
+	AttachmentInvoice       AttachmentType = "INVOICE"
+	AttachmentDeliveryProof AttachmentType = "DELIVERY_PROOF"
+	AttachmentBPKB          AttachmentType = "BPKB"
+	AttachmentOther         AttachmentType = "OTHER"
+)
+
+type TransactionAttachment struct {
+	ID            uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID uint64         `gorm:"not null;index" json:"transaction_id"`
+	Type          AttachmentType `gorm:"type:enum('INVOICE','DELIVERY_PROOF','BPKB','OTHER');not null" json:"type"`
+	FileName      string         `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType   string         `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size          int64          `gorm:"not null" json:"size"`
+	URL           string         `gorm:"type:varchar(512);not null" json:"url"`
+	PartnerID     *uint64        `gorm:"index" json:"partner_id,omitempty"`
+	CustomerID    *uint64        `json:"customer_id,omitempty"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	DeletedAt     *time.Time     `json:"deleted_at,omitempty"`
+	DeletedBy     *uint64        `json:"deleted_by,omitempty"`
+
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type Region struct {
+	Code      string    `gorm:"type:varchar(8);primaryKey" json:"code"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	Active    bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type AssetCategory struct {
+	Code      string    `gorm:"type:varchar(50);primaryKey" json:"code"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ContractSequence holds the last contract number issued for one region and
+// day. The allocating transaction keeps the row locked until it commits, so
+// numbers are never shared and a rolled back transaction returns its number.
+type ContractSequence struct {
+	Sandbox      bool      `gorm:"primaryKey;autoIncrement:false" json:"sandbox"`
+	RegionCode   string    `gorm:"type:varchar(8);primaryKey" json:"region_code"`
+	SequenceDate string    `gorm:"type:char(8);primaryKey" json:"sequence_date"`
+	LastValue    uint64    `gorm:"not null" json:"last_value"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// CustomerExposure is the summary of active, non-sandbox principal per
+// customer and tenor, in IDR. It is updated in the same database transaction
+// as the contracts it summarises.
+type CustomerExposure struct {
+	CustomerID      uint64          `gorm:"primaryKey;autoIncrement:false" json:"customer_id"`
+	TenorID         uint            `gorm:"primaryKey;autoIncrement:false" json:"tenor_id"`
+	ActivePrincipal decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"active_principal"`
+	UpdatedAt       time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// CustomerEvent is append-only, rows are never updated or deleted by the
+// application.
+type CustomerEvent struct {
+	ID          uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID  uint64            `gorm:"not null;index:idx_customer_event_timeline" json:"customer_id"`
+	Type        string            `gorm:"type:varchar(32);not null;index:idx_customer_event_type" json:"type"`
+	ActorID     *uint64           `json:"actor_id,omitempty"`
+	ReferenceID string            `gorm:"type:varchar(64)" json:"reference_id,omitempty"`
+	Data        map[string]string `gorm:"type:text;serializer:json" json:"data"`
+	OccurredAt  time.Time         `gorm:"autoCreateTime;index:idx_customer_event_timeline;index:idx_customer_event_type" json:"occurred_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CustomerRestriction rows are kept after they are lifted so the history of
+// suspensions and freezes stays visible to admins.
+type CustomerRestriction struct {
+	ID         uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID uint64     `gorm:"not null;index:idx_customer_restriction_active" json:"customer_id"`
+	TenorID    *uint      `json:"tenor_id,omitempty"`
+	Reason     string     `gorm:"type:varchar(32);not null" json:"reason"`
+	Note       string     `gorm:"type:varchar(500)" json:"note,omitempty"`
+	CreatedBy  uint64     `gorm:"not null" json:"created_by"`
+	ExpiresAt  *time.Time `gorm:"index" json:"expires_at,omitempty"`
+	LiftedAt   *time.Time `gorm:"index:idx_customer_restriction_active" json:"lifted_at,omitempty"`
+	LiftedBy   *uint64    `json:"lifted_by,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+	Tenor    *Tenor   `gorm:"foreignKey:TenorID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+// AccountClosure rows outlive the anonymization of the customer they belong
+// to, as the record that the customer asked for it.
+type AccountClosure struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID     uint64     `gorm:"not null;uniqueIndex" json:"customer_id"`
+	Reason         string     `gorm:"type:varchar(500);not null" json:"reason"`
+	ClosedAt       time.Time  `gorm:"not null" json:"closed_at"`
+	AnonymizeAfter time.Time  `gorm:"not null;index:idx_account_closure_due" json:"anonymize_after"`
+	AnonymizedAt   *time.Time `gorm:"index:idx_account_closure_due" json:"anonymized_at,omitempty"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CalendarToken rows are kept after revocation so an old subscription URL
+// keeps failing instead of matching a later token.
+type CalendarToken struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID     uint64     `gorm:"not null;index:idx_calendar_token_contract" json:"customer_id"`
+	ContractNumber string     `gorm:"type:varchar(50);not null;index:idx_calendar_token_contract" json:"contract_number"`
+	TokenHash      string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type Holiday struct {
+	Date      time.Time `gorm:"type:date;primaryKey" json:"date"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	UpdatedBy uint64    `gorm:"not null" json:"updated_by"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// DueDatePolicy holds a single row with ID 1; a missing row means the zero
+// policy.
+type DueDatePolicy struct {
+	ID        uint8     `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	Shift     string    `gorm:"type:enum('NONE','NEXT_BUSINESS_DAY','PREVIOUS_BUSINESS_DAY');default:'NONE';not null" json:"shift"`
+	GraceDays int       `gorm:"not null;default:0" json:"grace_days"`
+	UpdatedBy uint64    `gorm:"not null" json:"updated_by"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// EmailMessage is claimed by bumping Attempts and pushing NextAttemptAt past
+// the send, so a worker that dies mid-send leaves the message to be retried
+// instead of lost.
+type EmailMessage struct {
+	ID                uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID        uint64     `gorm:"not null;index" json:"customer_id"`
+	Recipient         string     `gorm:"type:varchar(255);not null" json:"recipient"`
+	Template          string     `gorm:"type:varchar(64);not null" json:"template"`
+	Language          string     `gorm:"type:varchar(8);not null" json:"language"`
+	Subject           string     `gorm:"type:varchar(255);not null" json:"subject"`
+	Body              string     `gorm:"type:text;not null" json:"body"`
+	Status            string     `gorm:"type:enum('PENDING','SENT','FAILED');default:'PENDING';not null;index:idx_email_message_due" json:"status"`
+	Attempts          int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt     time.Time  `gorm:"not null;index:idx_email_message_due" json:"next_attempt_at"`
+	LastError         string     `gorm:"type:text" json:"last_error,omitempty"`
+	Provider          string     `gorm:"type:varchar(32)" json:"provider,omitempty"`
+	ProviderMessageID string     `gorm:"type:varchar(255)" json:"provider_message_id,omitempty"`
+	SentAt            *time.Time `json:"sent_at,omitempty"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CustomerDevice tokens are unique, a token registered again by another
+// customer on a shared phone moves to that customer.
+type CustomerDevice struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID uint64    `gorm:"not null;index" json:"customer_id"`
+	Token      string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
+	Platform   string    `gorm:"type:enum('ANDROID','IOS','WEB');not null" json:"platform"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	LastSeenAt time.Time `gorm:"not null" json:"last_seen_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type NotificationPreference struct {
+	CustomerID uint64    `gorm:"primaryKey" json:"customer_id"`
+	Email      bool      `gorm:"not null" json:"email"`
+	Push       bool      `gorm:"not null" json:"push"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Customer Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// PaymentReminder is inserted before the reminder goes out and the unique
+// index stops a second instance from reminding about the same installment.
+type PaymentReminder struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID uint64    `gorm:"not null;uniqueIndex:idx_payment_reminder" json:"transaction_id"`
+	Installment   int       `gorm:"not null;uniqueIndex:idx_payment_reminder" json:"installment"`
+	DueDate       time.Time `gorm:"type:date;not null" json:"due_date"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// PartnerDebugRecord rows are capped per key and purged after the retention
+// period, they only exist to settle integration disputes.
+type PartnerDebugRecord struct {
+	ID             uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	PartnerID      uint64            `gorm:"not null;index:idx_partner_debug_record_key" json:"partner_id"`
+	Sandbox        bool              `gorm:"not null;index:idx_partner_debug_record_key" json:"sandbox"`
+	Method         string            `gorm:"type:varchar(10);not null" json:"method"`
+	Path           string            `gorm:"type:varchar(500);not null" json:"path"`
+	StatusCode     int               `gorm:"not null" json:"status_code"`
+	RequestHeaders map[string]string `gorm:"type:text;serializer:json" json:"request_headers"`
+	RequestBody    string            `gorm:"type:mediumtext" json:"request_body"`
+	ResponseBody   string            `gorm:"type:mediumtext" json:"response_body"`
+	DurationMs     int64             `gorm:"not null" json:"duration_ms"`
+	RequestID      string            `gorm:"type:varchar(64)" json:"request_id,omitempty"`
+	TraceID        string            `gorm:"type:char(32)" json:"trace_id,omitempty"`
+	CreatedAt      time.Time         `gorm:"autoCreateTime;index" json:"created_at"`
+
+	Partner Partner `gorm:"foreignKey:PartnerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// AMLCase is opened at most once per transaction and rule, so rescreening a
+// transaction never duplicates a case.
+type AMLCase struct {
+	ID              uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	CustomerID      uint64          `gorm:"not null;index" json:"customer_id"`
+	TransactionID   uint64          `gorm:"not null;uniqueIndex:idx_aml_case_transaction_rule" json:"transaction_id"`
+	Rule            string          `gorm:"type:varchar(32);not null;uniqueIndex:idx_aml_case_transaction_rule" json:"rule"`
+	AmountIDR       decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"amount_idr"`
+	Detail          string          `gorm:"type:varchar(255)" json:"detail,omitempty"`
+	Status          string          `gorm:"type:enum('OPEN','REVIEWED','REPORTED');default:'OPEN';not null;index" json:"status"`
+	ReviewNote      string          `gorm:"type:varchar(1000)" json:"review_note,omitempty"`
+	ReviewedBy      *uint64         `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	ReportReference string          `gorm:"type:varchar(64)" json:"report_reference,omitempty"`
+	ReportedAt      *time.Time      `json:"reported_at,omitempty"`
+	CreatedAt       time.Time       `gorm:"autoCreateTime;index" json:"created_at"`
+	UpdatedAt       time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Customer    Customer    `gorm:"foreignKey:CustomerID;constraint:OnDelete:RESTRICT" json:"-"`
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+// CollectionCase follows one delinquent contract. The job only opens a case
+// for a contract without an active one, a contract that falls behind again
+// after its case closed gets a new case.
+type CollectionCase struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID  uint64          `gorm:"not null;index" json:"transaction_id"`
+	CustomerID     uint64          `gorm:"not null;index" json:"customer_id"`
+	Status         string          `gorm:"type:enum('OPEN','IN_PROGRESS','REPOSSESSION','REPOSSESSED','RESOLVED');default:'OPEN';not null;index" json:"status"`
+	CollectorID    *uint64         `gorm:"index" json:"collector_id,omitempty"`
+	DaysPastDue    int             `gorm:"not null" json:"days_past_due"`
+	OverdueAmount  decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"overdue_amount"`
+	LastActivityAt *time.Time      `json:"last_activity_at,omitempty"`
+	ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime;index" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Customer    Customer    `gorm:"foreignKey:CustomerID;constraint:OnDelete:RESTRICT" json:"-"`
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:RESTRICT" json:"-"`
+	Collector   *Customer   `gorm:"foreignKey:CollectorID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+type CollectionActivity struct {
+	ID            uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	CaseID        uint64           `gorm:"not null;index" json:"case_id"`
+	Type          string           `gorm:"type:enum('CALL','VISIT','PROMISE_TO_PAY');not null" json:"type"`
+	Note          string           `gorm:"type:varchar(1000);not null" json:"note"`
+	PromiseAmount *decimal.Decimal `gorm:"type:decimal(18,2)" json:"promise_amount,omitempty"`
+	PromiseDate   *time.Time       `gorm:"type:date;index" json:"promise_date,omitempty"`
+	CreatedBy     uint64           `gorm:"not null" json:"created_by"`
+	CreatedAt     time.Time        `gorm:"autoCreateTime" json:"created_at"`
+
+	Case CollectionCase `gorm:"foreignKey:CaseID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// WriteOff is reviewed maker-checker like a restructuring. Only an approved
+// write-off changes the contract and posts to the ledger.
+type WriteOff struct {
+	ID              uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID   uint64          `gorm:"not null;index" json:"transaction_id"`
+	CustomerID      uint64          `gorm:"not null;index" json:"customer_id"`
+	Status          string          `gorm:"type:enum('PENDING','APPROVED','REJECTED');default:'PENDING';not null;index" json:"status"`
+	DaysPastDue     int             `gorm:"not null" json:"days_past_due"`
+	Amount          decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"amount"`
+	RecoveredAmount decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"recovered_amount"`
+	Reason          string          `gorm:"type:varchar(500);not null" json:"reason"`
+	RequestedBy     uint64          `gorm:"not null" json:"requested_by"`
+	ReviewerID      *uint64         `json:"reviewer_id,omitempty"`
+	ReviewNote      string          `gorm:"type:varchar(500)" json:"review_note,omitempty"`
+	ReviewedAt      *time.Time      `gorm:"index" json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+type WriteOffRecovery struct {
+	ID         uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	WriteOffID uint64          `gorm:"not null;index" json:"write_off_id"`
+	Amount     decimal.Decimal `gorm:"type:decimal(18,2);not null" json:"amount"`
+	ReceivedOn time.Time       `gorm:"type:date;not null;index" json:"received_on"`
+	Reference  string          `gorm:"type:varchar(64);not null;default:''" json:"reference,omitempty"`
+	Note       string          `gorm:"type:varchar(500)" json:"note,omitempty"`
+	CreatedBy  uint64          `gorm:"not null" json:"created_by"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+
+	WriteOff WriteOff `gorm:"foreignKey:WriteOffID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+// LedgerEntry rows are append-only, a posting is corrected by posting its
+// reverse rather than by editing it.
+type LedgerEntry struct {
+	ID            uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReferenceType string          `gorm:"type:varchar(32);not null;index:idx_ledger_entry_reference" json:"reference_type"`
+	ReferenceID   uint64          `gorm:"not null;index:idx_ledger_entry_reference" json:"reference_id"`
+	TransactionID uint64          `gorm:"not null;index" json:"transaction_id"`
+	Account       string          `gorm:"type:varchar(32);not null;index" json:"account"`
+	Debit         decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"debit"`
+	Credit        decimal.Decimal `gorm:"type:decimal(18,2);not null;default:0" json:"credit"`
+	EntryDate     time.Time       `gorm:"type:date;not null;index" json:"entry_date"`
+	Description   string          `gorm:"type:varchar(255);not null;default:''" json:"description"`
+	CreatedAt     time.Time       `gorm:"autoCreateTime" json:"created_at"`
+
+	Transaction Transaction `gorm:"foreignKey:TransactionID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+// ConcentrationLimit represents the concentration_limits table, one cap per asset category or region
+type ConcentrationLimit struct {
+	ID         uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Dimension  string          `gorm:"type:enum('ASSET_CATEGORY','REGION');not null;uniqueIndex:idx_concentration_limit_value" json:"dimension"`
+	Value      string          `gorm:"type:varchar(50);not null;uniqueIndex:idx_concentration_limit_value" json:"value"`
+	MaxPercent decimal.Decimal `gorm:"type:decimal(5,2);not null" json:"max_percent"`
+	Enforce    bool            `gorm:"not null;default:false" json:"enforce"`
+	BreachedAt *time.Time      `json:"breached_at,omitempty"`
+	UpdatedBy  uint64          `gorm:"not null" json:"updated_by"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func MonthlyStatementFromEntity(data *domain.MonthlyStatement) MonthlyStatement {
+	return MonthlyStatement{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		Period:     data.Period,
+		Status:     CommunicationStatus(data.Status),
+		URL:        data.URL,
+		Error:      data.Error,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func MonthlyStatementToEntity(data MonthlyStatement) *domain.MonthlyStatement {
+	return &domain.MonthlyStatement{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		Period:     data.Period,
+		Status:     domain.CommunicationStatus(data.Status),
+		URL:        data.URL,
+		Error:      data.Error,
+		CreatedAt:  data.CreatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func CustomerDeviceFromEntity(data *domain.Device) CustomerDevice {
+	return CustomerDevice{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		Token:      data.Token,
+		Platform:   string(data.Platform),
+		CreatedAt:  data.CreatedAt,
+		LastSeenAt: data.LastSeenAt,
+	}
+}
+
+func CustomerDeviceToEntity(data CustomerDevice) *domain.Device {
+	return &domain.Device{
+		ID:         data.ID,
+		CustomerID: data.CustomerID,
+		Token:      data.Token,
+		Platform:   domain.DevicePlatform(data.Platform),
+		CreatedAt:  data.CreatedAt,
+		LastSeenAt: data.LastSeenAt,
+	}
+}
+
+func CustomerDevicesToEntity(data []CustomerDevice) []domain.Device {
+	responses := make([]domain.Device, len(data))
+	for i, d := range data {
+		responses[i] = *CustomerDeviceToEntity(d)
+	}
+
+	return responses
+}
+
+func NotificationPreferenceFromEntity(data *domain.NotificationPreferences) NotificationPreference {
+	return NotificationPreference{
+		CustomerID: data.CustomerID,
+		Email:      data.Email,
+		Push:       data.Push,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func NotificationPreferenceToEntity(data NotificationPreference) *domain.NotificationPreferences {
+	return &domain.NotificationPreferences{
+		CustomerID: data.CustomerID,
+		Email:      data.Email,
+		Push:       data.Push,
+		UpdatedAt:  data.UpdatedAt,
+	}
+}
+
+func PaymentReminderFromEntity(data *domain.PaymentReminder) PaymentReminder {
+	return PaymentReminder{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Installment:   data.Installment,
+		DueDate:       data.DueDate,
+		CreatedAt:     data.CreatedAt,
+	}
+}
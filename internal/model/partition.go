@@ -0,0 +1,163 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// TransactionPartitionKey is the column transactions are partitioned on by
+// month. Queries that can bound it let MySQL skip the other partitions.
+const TransactionPartitionKey = "transaction_date"
+
+// PartitionTransactions partitions the transactions table by month of
+// transaction_date and keeps partitions ready for monthsAhead months after
+// now, returning the partitions it created. On a table that is not yet
+// partitioned it first makes the schema eligible: foreign keys from and to
+// transactions are dropped, existing contract and virtual account numbers
+// are copied to transaction_keys, and the primary key and the unique
+// indexes are extended with transaction_date under their existing names so
+// AutoMigrate leaves them alone. Run it after AutoMigratePartitioned.
+func PartitionTransactions(ctx context.Context, db *gorm.DB, now time.Time, monthsAhead int) ([]string, error) {
+	table := Transaction{}.TableName()
+
+	partitions, err := mysqldb.Partitions(ctx, db, table)
+	if err != nil {
+		return nil, err
+	}
+	if len(partitions) > 0 {
+		return ExtendTransactionPartitions(ctx, db, now, monthsAhead)
+	}
+
+	if err := mysqldb.DropForeignKeys(ctx, db, table); err != nil {
+		return nil, err
+	}
+
+	// Setelah rekey hanya transaction_keys yang menjamin nomor tetap unik
+	backfill := fmt.Sprintf("INSERT INTO `%[1]s` (`transaction_id`, `contract_number`, `virtual_account_number`) "+
+		"SELECT t.`id`, t.`contract_number`, t.`virtual_account_number` FROM `%[2]s` t "+
+		"LEFT JOIN `%[1]s` k ON k.`transaction_id` = t.`id` WHERE k.`transaction_id` IS NULL",
+		TransactionKey{}.TableName(), table)
+	if err := db.WithContext(ctx).Exec(backfill).Error; err != nil {
+		return nil, fmt.Errorf("failed to copy keys of %s: %w", table, err)
+	}
+
+	// Setiap unique key wajib memuat kolom partisi
+	rekey := fmt.Sprintf("ALTER TABLE `%[1]s` DROP PRIMARY KEY, ADD PRIMARY KEY (`id`, `%[2]s`), "+
+		"DROP INDEX `idx_transactions_contract_number`, ADD UNIQUE INDEX `idx_transactions_contract_number` (`contract_number`, `%[2]s`), "+
+		"DROP INDEX `idx_transactions_virtual_account_number`, ADD UNIQUE INDEX `idx_transactions_virtual_account_number` (`virtual_account_number`, `%[2]s`)",
+		table, TransactionPartitionKey)
+	if err := db.WithContext(ctx).Exec(rekey).Error; err != nil {
+		return nil, fmt.Errorf("failed to rekey %s: %w", table, err)
+	}
+
+	var first sql.NullTime
+	if err := db.WithContext(ctx).Model(&Transaction{}).Select("MIN(" + TransactionPartitionKey + ")").Row().Scan(&first); err != nil {
+		return nil, fmt.Errorf("failed to find oldest transaction: %w", err)
+	}
+	from := now
+	if first.Valid && first.Time.Before(now) {
+		from = first.Time
+	}
+
+	through := now.UTC().AddDate(0, monthsAhead, 0)
+	if err := mysqldb.PartitionByMonth(ctx, db, table, TransactionPartitionKey, from, through); err != nil {
+		return nil, err
+	}
+	return mysqldb.Partitions(ctx, db, table)
+}
+
+// ExtendTransactionPartitions adds the monthly partitions missing up to
+// monthsAhead months after now to the already partitioned transactions
+// table, and returns their names.
+func ExtendTransactionPartitions(ctx context.Context, db *gorm.DB, now time.Time, monthsAhead int) ([]string, error) {
+	return mysqldb.AddMonthlyPartitions(ctx, db, Transaction{}.TableName(), now.UTC().AddDate(0, monthsAhead, 0))
+}
+
+// AutoMigratePartitioned migrates the schema like AutoMigrate for a
+// database whose transactions table is, or is about to be, partitioned.
+// MySQL allows no foreign keys from or to a partitioned table, so
+// transactions and the tables referencing it are migrated without foreign
+// keys, after which those tables get back their keys to other tables. The
+// rest of the schema keeps its foreign keys.
+func AutoMigratePartitioned(db *gorm.DB) error {
+	table := Transaction{}.TableName()
+
+	// Relasi has-many baru tercatat di tabel anak setelah induknya diparse
+	values := models()
+	schemas := make([]*schema.Schema, len(values))
+	for i, value := range values {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(value); err != nil {
+			return err
+		}
+		schemas[i] = stmt.Schema
+	}
+
+	var linked, plain []any
+	restore := map[any][]string{}
+	for i, value := range values {
+		_, own := value.(*Transaction)
+		partitioned := own
+		var others []string
+		for _, c := range foreignKeys(schemas[i]) {
+			if c.Schema.Table == table || c.ReferenceSchema.Table == table {
+				partitioned = true
+			} else {
+				others = append(others, c.Name)
+			}
+		}
+
+		if !partitioned {
+			plain = append(plain, value)
+			continue
+		}
+		linked = append(linked, value)
+		// Tabel partisi sendiri tidak boleh punya foreign key sama sekali
+		if !own {
+			restore[value] = others
+		}
+	}
+
+	// Tabel ini dibuat lebih dulu agar tabel lain bisa merujuknya
+	unchecked := db.Session(&gorm.Session{})
+	unchecked.Config.DisableForeignKeyConstraintWhenMigrating = true
+	if err := unchecked.AutoMigrate(linked...); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(plain...); err != nil {
+		return err
+	}
+
+	migrator := db.Migrator()
+	for _, value := range linked {
+		for _, name := range restore[value] {
+			if migrator.HasConstraint(value, name) {
+				continue
+			}
+			if err := migrator.CreateConstraint(value, name); err != nil {
+				return fmt.Errorf("failed to create foreign key %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// foreignKeys returns the foreign keys AutoMigrate creates on the table of s.
+func foreignKeys(s *schema.Schema) []*schema.Constraint {
+	var constraints []*schema.Constraint
+	for _, rel := range s.Relationships.Relations {
+		if rel.Field.IgnoreMigration {
+			continue
+		}
+		if c := rel.ParseConstraint(); c != nil && c.Schema == s {
+			constraints = append(constraints, c)
+		}
+	}
+	return constraints
+}
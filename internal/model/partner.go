@@ -0,0 +1,74 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func PartnerFromEntity(data *domain.Partner) Partner {
+	var liveKeyHash *string
+	if data.LiveKeyHash != "" {
+		liveKeyHash = &data.LiveKeyHash
+	}
+
+	return Partner{
+		ID:               data.ID,
+		Name:             data.Name,
+		Email:            data.Email,
+		Status:           PartnerStatus(data.Status),
+		SandboxKeyHash:   data.SandboxKeyHash,
+		SandboxKeyPrefix: data.SandboxKeyPrefix,
+		LiveKeyHash:      liveKeyHash,
+		LiveKeyPrefix:    data.LiveKeyPrefix,
+		WebhookURL:       data.WebhookURL,
+		PromotedAt:       data.PromotedAt,
+		RegionCode:       data.RegionCode,
+		BranchCode:       data.BranchCode,
+		CreatedAt:        data.CreatedAt,
+		UpdatedAt:        data.UpdatedAt,
+
+		SandboxDebugUntil:     data.SandboxDebugUntil,
+		LiveDebugUntil:        data.LiveDebugUntil,
+		AllowedCIDRs:          strings.Join(data.Security.AllowedCIDRs, ","),
+		RequireClientCert:     data.Security.RequireClientCert,
+		ClientCertFingerprint: data.Security.ClientCertFingerprint,
+		RequireSignature:      data.Security.RequireSignature,
+		SigningSecret:         data.Security.SigningSecret,
+	}
+}
+
+func PartnerToEntity(data Partner) *domain.Partner {
+	partner := &domain.Partner{
+		ID:               data.ID,
+		Name:             data.Name,
+		Email:            data.Email,
+		Status:           domain.PartnerStatus(data.Status),
+		SandboxKeyHash:   data.SandboxKeyHash,
+		SandboxKeyPrefix: data.SandboxKeyPrefix,
+		LiveKeyPrefix:    data.LiveKeyPrefix,
+		WebhookURL:       data.WebhookURL,
+		PromotedAt:       data.PromotedAt,
+		RegionCode:       data.RegionCode,
+		BranchCode:       data.BranchCode,
+		CreatedAt:        data.CreatedAt,
+		UpdatedAt:        data.UpdatedAt,
+
+		SandboxDebugUntil: data.SandboxDebugUntil,
+		LiveDebugUntil:    data.LiveDebugUntil,
+		Security: domain.PartnerSecurity{
+			RequireClientCert:     data.RequireClientCert,
+			ClientCertFingerprint: data.ClientCertFingerprint,
+			RequireSignature:      data.RequireSignature,
+			SigningSecret:         data.SigningSecret,
+		},
+	}
+	if data.LiveKeyHash != nil {
+		partner.LiveKeyHash = *data.LiveKeyHash
+	}
+	if data.AllowedCIDRs != "" {
+		partner.Security.AllowedCIDRs = strings.Split(data.AllowedCIDRs, ",")
+	}
+
+	return partner
+}
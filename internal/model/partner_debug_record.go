@@ -0,0 +1,49 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func PartnerDebugRecordFromEntity(data *domain.PartnerDebugRecord) PartnerDebugRecord {
+	return PartnerDebugRecord{
+		ID:             data.ID,
+		PartnerID:      data.PartnerID,
+		Sandbox:        data.Sandbox,
+		Method:         data.Method,
+		Path:           data.Path,
+		StatusCode:     data.StatusCode,
+		RequestHeaders: data.RequestHeaders,
+		RequestBody:    data.RequestBody,
+		ResponseBody:   data.ResponseBody,
+		DurationMs:     data.DurationMs,
+		RequestID:      data.RequestID,
+		TraceID:        data.TraceID,
+		CreatedAt:      data.CreatedAt,
+	}
+}
+
+func PartnerDebugRecordToEntity(data PartnerDebugRecord) *domain.PartnerDebugRecord {
+	return &domain.PartnerDebugRecord{
+		ID:             data.ID,
+		PartnerID:      data.PartnerID,
+		Sandbox:        data.Sandbox,
+		Method:         data.Method,
+		Path:           data.Path,
+		StatusCode:     data.StatusCode,
+		RequestHeaders: data.RequestHeaders,
+		RequestBody:    data.RequestBody,
+		ResponseBody:   data.ResponseBody,
+		DurationMs:     data.DurationMs,
+		RequestID:      data.RequestID,
+		TraceID:        data.TraceID,
+		CreatedAt:      data.CreatedAt,
+	}
+}
+
+func PartnerDebugRecordsToEntity(data []PartnerDebugRecord) []domain.PartnerDebugRecord {
+	records := make([]domain.PartnerDebugRecord, len(data))
+	for i, r := range data {
+		records[i] = *PartnerDebugRecordToEntity(r)
+	}
+	return records
+}
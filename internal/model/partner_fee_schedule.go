@@ -0,0 +1,42 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func PartnerFeeScheduleFromEntity(data *domain.PartnerFeeSchedule) PartnerFeeSchedule {
+	return PartnerFeeSchedule{
+		ID:             data.ID,
+		PartnerID:      data.PartnerID,
+		TenorMonths:    data.TenorMonths,
+		AdminFeeFlat:   data.AdminFeeFlat,
+		AdminFeeRate:   data.AdminFeeRate,
+		CommissionRate: data.CommissionRate,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func PartnerFeeScheduleToEntity(data PartnerFeeSchedule) *domain.PartnerFeeSchedule {
+	return &domain.PartnerFeeSchedule{
+		ID:             data.ID,
+		PartnerID:      data.PartnerID,
+		TenorMonths:    data.TenorMonths,
+		AdminFeeFlat:   data.AdminFeeFlat,
+		AdminFeeRate:   data.AdminFeeRate,
+		CommissionRate: data.CommissionRate,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func PartnerFeeSchedulesToEntity(data []PartnerFeeSchedule) []domain.PartnerFeeSchedule {
+	responses := make([]domain.PartnerFeeSchedule, len(data))
+	for i, s := range data {
+		responses[i] = *PartnerFeeScheduleToEntity(s)
+	}
+
+	return responses
+}
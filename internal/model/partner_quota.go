@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func PartnerQuotaFromEntity(data *domain.PartnerQuota) PartnerQuota {
+	return PartnerQuota{
+		PartnerID:   data.PartnerID,
+		DailyCount:  data.DailyCount,
+		DailyVolume: data.DailyVolume,
+		UpdatedBy:   data.UpdatedBy,
+		UpdatedAt:   data.UpdatedAt,
+	}
+}
+
+func PartnerQuotaToEntity(data PartnerQuota) *domain.PartnerQuota {
+	return &domain.PartnerQuota{
+		PartnerID:   data.PartnerID,
+		DailyCount:  data.DailyCount,
+		DailyVolume: data.DailyVolume,
+		UpdatedBy:   data.UpdatedBy,
+		UpdatedAt:   data.UpdatedAt,
+	}
+}
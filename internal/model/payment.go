@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func PaymentFromEntity(data *domain.Payment) Payment {
+	return Payment{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Provider:      data.Provider,
+		ExternalID:    data.ExternalID,
+		Amount:        data.Amount,
+		Currency:      data.Currency,
+		Status:        PaymentStatus(data.Status),
+		PaidAt:        data.PaidAt,
+		CreatedAt:     data.CreatedAt,
+		UpdatedAt:     data.UpdatedAt,
+	}
+}
+
+func PaymentToEntity(data Payment) *domain.Payment {
+	return &domain.Payment{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Provider:      data.Provider,
+		ExternalID:    data.ExternalID,
+		Amount:        data.Amount,
+		Currency:      data.Currency,
+		Status:        domain.PaymentStatus(data.Status),
+		PaidAt:        data.PaidAt,
+		CreatedAt:     data.CreatedAt,
+		UpdatedAt:     data.UpdatedAt,
+	}
+}
+
+func PaymentCallbackFromEntity(data *domain.PaymentCallback) PaymentCallback {
+	return PaymentCallback{
+		ID:         data.ID,
+		Provider:   data.Provider,
+		EventID:    data.EventID,
+		Outcome:    CallbackOutcome(data.Outcome),
+		Error:      data.Error,
+		Payload:    data.Payload,
+		ReceivedAt: data.ReceivedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func PromotionFromEntity(data *domain.Promotion) Promotion {
+	return Promotion{
+		ID:             data.ID,
+		Code:           data.Code,
+		Description:    data.Description,
+		Target:         PromotionTarget(data.Target),
+		DiscountType:   DiscountType(data.DiscountType),
+		DiscountValue:  data.DiscountValue,
+		MaxDiscount:    data.MaxDiscount,
+		ValidFrom:      data.ValidFrom,
+		ValidUntil:     data.ValidUntil,
+		PartnerID:      data.PartnerID,
+		TenorMonths:    data.TenorMonths,
+		MinOTRAmount:   data.MinOTRAmount,
+		MaxRedemptions: data.MaxRedemptions,
+		MaxPerCustomer: data.MaxPerCustomer,
+		Active:         data.Active,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func PromotionToEntity(data Promotion) *domain.Promotion {
+	return &domain.Promotion{
+		ID:             data.ID,
+		Code:           data.Code,
+		Description:    data.Description,
+		Target:         domain.PromotionTarget(data.Target),
+		DiscountType:   domain.DiscountType(data.DiscountType),
+		DiscountValue:  data.DiscountValue,
+		MaxDiscount:    data.MaxDiscount,
+		ValidFrom:      data.ValidFrom,
+		ValidUntil:     data.ValidUntil,
+		PartnerID:      data.PartnerID,
+		TenorMonths:    data.TenorMonths,
+		MinOTRAmount:   data.MinOTRAmount,
+		MaxRedemptions: data.MaxRedemptions,
+		MaxPerCustomer: data.MaxPerCustomer,
+		Active:         data.Active,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func PromotionsToEntity(data []Promotion) []domain.Promotion {
+	responses := make([]domain.Promotion, len(data))
+	for i, p := range data {
+		responses[i] = *PromotionToEntity(p)
+	}
+
+	return responses
+}
+
+func PromotionRedemptionFromEntity(data *domain.PromotionRedemption) PromotionRedemption {
+	return PromotionRedemption{
+		ID:             data.ID,
+		PromotionID:    data.PromotionID,
+		CustomerID:     data.CustomerID,
+		TransactionID:  data.TransactionID,
+		DiscountAmount: data.DiscountAmount,
+		Currency:       data.Currency,
+		RedeemedAt:     data.RedeemedAt,
+	}
+}
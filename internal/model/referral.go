@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func ReferralCodeToEntity(data ReferralCode) *domain.ReferralCode {
+	return &domain.ReferralCode{
+		CustomerID: data.CustomerID,
+		Code:       data.Code,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func ReferralFromEntity(data *domain.Referral) Referral {
+	return Referral{
+		ID:           data.ID,
+		ReferrerID:   data.ReferrerID,
+		RefereeID:    data.RefereeID,
+		Code:         data.Code,
+		Status:       ReferralStatus(data.Status),
+		RejectReason: data.RejectReason,
+		DeviceID:     data.DeviceID,
+		IPAddress:    data.IPAddress,
+		CreatedAt:    data.CreatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func RegionFromEntity(data *domain.Region) Region {
+	return Region{
+		Code:      data.Code,
+		Name:      data.Name,
+		Active:    data.Active,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func RegionToEntity(data Region) *domain.Region {
+	return &domain.Region{
+		Code:      data.Code,
+		Name:      data.Name,
+		Active:    data.Active,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func RegionsToEntity(data []Region) []domain.Region {
+	regions := make([]domain.Region, len(data))
+	for i, r := range data {
+		regions[i] = *RegionToEntity(r)
+	}
+	return regions
+}
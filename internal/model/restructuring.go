@@ -0,0 +1,72 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func RestructuringFromEntity(data *domain.Restructuring) Restructuring {
+	return Restructuring{
+		ID:                         data.ID,
+		TransactionID:              data.TransactionID,
+		CustomerID:                 data.CustomerID,
+		Type:                       RestructuringType(data.Type),
+		Status:                     RestructuringStatus(data.Status),
+		ContractDate:               data.ContractDate,
+		PaidInstallments:           data.PaidInstallments,
+		OriginalTenorID:            data.OriginalTenorID,
+		OriginalTenorMonths:        data.OriginalTenorMonths,
+		OriginalTotalInterest:      data.OriginalTotalInterest,
+		OriginalTotalInstallment:   data.OriginalTotalInstallment,
+		OriginalMonthlyInstallment: data.OriginalMonthlyInstallment,
+		NewTenorID:                 data.NewTenorID,
+		NewTenorMonths:             data.NewTenorMonths,
+		NewTotalInterest:           data.NewTotalInterest,
+		NewTotalInstallment:        data.NewTotalInstallment,
+		NewMonthlyInstallment:      data.NewMonthlyInstallment,
+		Reason:                     data.Reason,
+		RequestedBy:                data.RequestedBy,
+		ReviewerID:                 data.ReviewerID,
+		ReviewNote:                 data.ReviewNote,
+		ReviewedAt:                 data.ReviewedAt,
+		CreatedAt:                  data.CreatedAt,
+		UpdatedAt:                  data.UpdatedAt,
+	}
+}
+
+func RestructuringToEntity(data Restructuring) *domain.Restructuring {
+	return &domain.Restructuring{
+		ID:                         data.ID,
+		TransactionID:              data.TransactionID,
+		CustomerID:                 data.CustomerID,
+		Type:                       domain.RestructuringType(data.Type),
+		Status:                     domain.RestructuringStatus(data.Status),
+		ContractDate:               data.ContractDate,
+		PaidInstallments:           data.PaidInstallments,
+		OriginalTenorID:            data.OriginalTenorID,
+		OriginalTenorMonths:        data.OriginalTenorMonths,
+		OriginalTotalInterest:      data.OriginalTotalInterest,
+		OriginalTotalInstallment:   data.OriginalTotalInstallment,
+		OriginalMonthlyInstallment: data.OriginalMonthlyInstallment,
+		NewTenorID:                 data.NewTenorID,
+		NewTenorMonths:             data.NewTenorMonths,
+		NewTotalInterest:           data.NewTotalInterest,
+		NewTotalInstallment:        data.NewTotalInstallment,
+		NewMonthlyInstallment:      data.NewMonthlyInstallment,
+		Reason:                     data.Reason,
+		RequestedBy:                data.RequestedBy,
+		ReviewerID:                 data.ReviewerID,
+		ReviewNote:                 data.ReviewNote,
+		ReviewedAt:                 data.ReviewedAt,
+		CreatedAt:                  data.CreatedAt,
+		UpdatedAt:                  data.UpdatedAt,
+	}
+}
+
+func RestructuringsToEntity(data []Restructuring) []domain.Restructuring {
+	responses := make([]domain.Restructuring, len(data))
+	for i, r := range data {
+		responses[i] = *RestructuringToEntity(r)
+	}
+
+	return responses
+}
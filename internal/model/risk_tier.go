@@ -0,0 +1,81 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func RiskTierRuleFromEntity(data *domain.RiskTierRule) RiskTierRule {
+	return RiskTierRule{
+		ID:             data.ID,
+		Priority:       data.Priority,
+		Tier:           string(data.Tier),
+		MinSalary:      data.MinSalary,
+		MaxSalary:      data.MaxSalary,
+		MinScore:       data.MinScore,
+		MaxScore:       data.MaxScore,
+		MinDaysPastDue: data.MinDaysPastDue,
+		MaxDaysPastDue: data.MaxDaysPastDue,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func RiskTierRuleToEntity(data RiskTierRule) *domain.RiskTierRule {
+	return &domain.RiskTierRule{
+		ID:             data.ID,
+		Priority:       data.Priority,
+		Tier:           domain.RiskTier(data.Tier),
+		MinSalary:      data.MinSalary,
+		MaxSalary:      data.MaxSalary,
+		MinScore:       data.MinScore,
+		MaxScore:       data.MaxScore,
+		MinDaysPastDue: data.MinDaysPastDue,
+		MaxDaysPastDue: data.MaxDaysPastDue,
+		UpdatedBy:      data.UpdatedBy,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func RiskTierRulesToEntity(data []RiskTierRule) []domain.RiskTierRule {
+	responses := make([]domain.RiskTierRule, len(data))
+	for i, v := range data {
+		responses[i] = *RiskTierRuleToEntity(v)
+	}
+
+	return responses
+}
+
+func CustomerRiskTierFromEntity(data *domain.CustomerRiskTier) CustomerRiskTier {
+	return CustomerRiskTier{
+		CustomerID:   data.CustomerID,
+		Tier:         string(data.Tier),
+		RuleID:       data.RuleID,
+		Salary:       data.Salary,
+		CreditScore:  data.CreditScore,
+		DaysPastDue:  data.DaysPastDue,
+		CalculatedAt: data.CalculatedAt,
+	}
+}
+
+func CustomerRiskTierToEntity(data CustomerRiskTier) *domain.CustomerRiskTier {
+	return &domain.CustomerRiskTier{
+		CustomerID:   data.CustomerID,
+		Tier:         domain.RiskTier(data.Tier),
+		RuleID:       data.RuleID,
+		Salary:       data.Salary,
+		CreditScore:  data.CreditScore,
+		DaysPastDue:  data.DaysPastDue,
+		CalculatedAt: data.CalculatedAt,
+	}
+}
+
+func CustomerRiskTiersToEntity(data []CustomerRiskTier) []domain.CustomerRiskTier {
+	responses := make([]domain.CustomerRiskTier, len(data))
+	for i, v := range data {
+		responses[i] = *CustomerRiskTierToEntity(v)
+	}
+
+	return responses
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func SalaryChangeFromEntity(data *domain.SalaryChange) SalaryChange {
+	return SalaryChange{
+		ID:              data.ID,
+		CustomerID:      data.CustomerID,
+		CurrentSalary:   data.CurrentSalary,
+		RequestedSalary: data.RequestedSalary,
+		PayslipUrl:      data.PayslipUrl,
+		Status:          SalaryChangeStatus(data.Status),
+		ReviewerID:      data.ReviewerID,
+		ReviewNote:      data.ReviewNote,
+		ReviewedAt:      data.ReviewedAt,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+	}
+}
+
+func SalaryChangeToEntity(data SalaryChange) *domain.SalaryChange {
+	return &domain.SalaryChange{
+		ID:              data.ID,
+		CustomerID:      data.CustomerID,
+		CurrentSalary:   data.CurrentSalary,
+		RequestedSalary: data.RequestedSalary,
+		PayslipUrl:      data.PayslipUrl,
+		Status:          domain.SalaryChangeStatus(data.Status),
+		ReviewerID:      data.ReviewerID,
+		ReviewNote:      data.ReviewNote,
+		ReviewedAt:      data.ReviewedAt,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+	}
+}
+
+func SalaryChangesToEntity(data []SalaryChange) []domain.SalaryChange {
+	responses := make([]domain.SalaryChange, len(data))
+	for i, s := range data {
+		responses[i] = *SalaryChangeToEntity(s)
+	}
+
+	return responses
+}
@@ -4,11 +4,33 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 )
 
+func TenorFromEntity(data *domain.Tenor) Tenor {
+	return Tenor{
+		ID:              data.ID,
+		DurationMonths:  data.DurationMonths,
+		Description:     data.Description,
+		Active:          data.Active,
+		MinAmount:       data.MinAmount,
+		MaxAmount:       data.MaxAmount,
+		MinAge:          data.MinAge,
+		MaxAge:          data.MaxAge,
+		MaxAgeAtEnd:     data.MaxAgeAtEnd,
+		AssetCategories: data.AssetCategories,
+	}
+}
+
 func TenorToEntity(data Tenor) *domain.Tenor {
 	return &domain.Tenor{
-		ID:             data.ID,
-		DurationMonths: data.DurationMonths,
-		Description:    data.Description,
+		ID:              data.ID,
+		DurationMonths:  data.DurationMonths,
+		Description:     data.Description,
+		Active:          data.Active,
+		MinAmount:       data.MinAmount,
+		MaxAmount:       data.MaxAmount,
+		MinAge:          data.MinAge,
+		MaxAge:          data.MaxAge,
+		MaxAgeAtEnd:     data.MaxAgeAtEnd,
+		AssetCategories: data.AssetCategories,
 	}
 }
 
@@ -16,9 +38,16 @@ func TenorsToEntity(data []Tenor) []domain.Tenor {
 	responses := make([]domain.Tenor, len(data))
 	for i, c := range data {
 		responses[i] = domain.Tenor{
-			ID:             c.ID,
-			DurationMonths: c.DurationMonths,
-			Description:    c.Description,
+			ID:              c.ID,
+			DurationMonths:  c.DurationMonths,
+			Description:     c.Description,
+			Active:          c.Active,
+			MinAmount:       c.MinAmount,
+			MaxAmount:       c.MaxAmount,
+			MinAge:          c.MinAge,
+			MaxAge:          c.MaxAge,
+			MaxAgeAtEnd:     c.MaxAgeAtEnd,
+			AssetCategories: c.AssetCategories,
 		}
 	}
 
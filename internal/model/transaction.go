@@ -11,12 +11,26 @@ func TransactionFromEntity(data *domain.Transaction) Transaction {
 		CustomerID:             data.CustomerID,
 		TenorID:                data.TenorID,
 		AssetName:              data.AssetName,
+		AssetCategory:          data.AssetCategory,
 		OTRAmount:              data.OTRAmount,
 		AdminFee:               data.AdminFee,
 		TotalInterest:          data.TotalInterest,
 		TotalInstallmentAmount: data.TotalInstallmentAmount,
+		CommissionAmount:       data.CommissionAmount,
+		DiscountAmount:         data.DiscountAmount,
 		Status:                 TransactionStatus(data.Status),
 		TransactionDate:        data.TransactionDate,
+		PartnerID:              data.PartnerID,
+		IsSandbox:              data.IsSandbox,
+		Currency:               data.Currency,
+		FxRate:                 data.FxRate,
+		PaidInstallments:       data.PaidInstallments,
+		VirtualAccountBank:     data.VirtualAccountBank,
+		VirtualAccountNumber:   data.VirtualAccountNumber,
+		RegionCode:             data.RegionCode,
+		BranchCode:             data.BranchCode,
+		Latitude:               data.Latitude,
+		Longitude:              data.Longitude,
 	}
 }
 
@@ -27,12 +41,26 @@ func TransactionToEntity(data Transaction) *domain.Transaction {
 		CustomerID:             data.CustomerID,
 		TenorID:                data.TenorID,
 		AssetName:              data.AssetName,
+		AssetCategory:          data.AssetCategory,
 		OTRAmount:              data.OTRAmount,
 		AdminFee:               data.AdminFee,
 		TotalInterest:          data.TotalInterest,
 		TotalInstallmentAmount: data.TotalInstallmentAmount,
+		CommissionAmount:       data.CommissionAmount,
+		DiscountAmount:         data.DiscountAmount,
 		Status:                 domain.TransactionStatus(data.Status),
 		TransactionDate:        data.TransactionDate,
+		PartnerID:              data.PartnerID,
+		IsSandbox:              data.IsSandbox,
+		Currency:               data.Currency,
+		FxRate:                 data.FxRate,
+		PaidInstallments:       data.PaidInstallments,
+		VirtualAccountBank:     data.VirtualAccountBank,
+		VirtualAccountNumber:   data.VirtualAccountNumber,
+		RegionCode:             data.RegionCode,
+		BranchCode:             data.BranchCode,
+		Latitude:               data.Latitude,
+		Longitude:              data.Longitude,
 	}
 }
 
@@ -45,14 +73,41 @@ func TransactionsToEntity(data []Transaction) []domain.Transaction {
 			CustomerID:             t.CustomerID,
 			TenorID:                t.TenorID,
 			AssetName:              t.AssetName,
+			AssetCategory:          t.AssetCategory,
 			OTRAmount:              t.OTRAmount,
 			AdminFee:               t.AdminFee,
 			TotalInterest:          t.TotalInterest,
 			TotalInstallmentAmount: t.TotalInstallmentAmount,
+			CommissionAmount:       t.CommissionAmount,
 			Status:                 domain.TransactionStatus(t.Status),
 			TransactionDate:        t.TransactionDate,
+			PartnerID:              t.PartnerID,
+			IsSandbox:              t.IsSandbox,
+			Currency:               t.Currency,
+			FxRate:                 t.FxRate,
+			PaidInstallments:       t.PaidInstallments,
+			VirtualAccountBank:     t.VirtualAccountBank,
+			VirtualAccountNumber:   t.VirtualAccountNumber,
+			RegionCode:             t.RegionCode,
+			BranchCode:             t.BranchCode,
+			Latitude:               t.Latitude,
+			Longitude:              t.Longitude,
 		}
 	}
 
 	return responses
 }
+
+// TransactionKeys returns the key rows of transactions, which must already
+// carry their generated IDs.
+func TransactionKeys(transactions ...Transaction) []TransactionKey {
+	keys := make([]TransactionKey, len(transactions))
+	for i, t := range transactions {
+		keys[i] = TransactionKey{
+			TransactionID:        t.ID,
+			ContractNumber:       t.ContractNumber,
+			VirtualAccountNumber: t.VirtualAccountNumber,
+		}
+	}
+	return keys
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func TransactionAttachmentFromEntity(data *domain.TransactionAttachment) TransactionAttachment {
+	return TransactionAttachment{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Type:          AttachmentType(data.Type),
+		FileName:      data.FileName,
+		ContentType:   data.ContentType,
+		Size:          data.Size,
+		URL:           data.URL,
+		PartnerID:     data.PartnerID,
+		CustomerID:    data.CustomerID,
+		CreatedAt:     data.CreatedAt,
+		DeletedAt:     data.DeletedAt,
+		DeletedBy:     data.DeletedBy,
+	}
+}
+
+func TransactionAttachmentToEntity(data TransactionAttachment) *domain.TransactionAttachment {
+	return &domain.TransactionAttachment{
+		ID:            data.ID,
+		TransactionID: data.TransactionID,
+		Type:          domain.AttachmentType(data.Type),
+		FileName:      data.FileName,
+		ContentType:   data.ContentType,
+		Size:          data.Size,
+		URL:           data.URL,
+		PartnerID:     data.PartnerID,
+		CustomerID:    data.CustomerID,
+		CreatedAt:     data.CreatedAt,
+		DeletedAt:     data.DeletedAt,
+		DeletedBy:     data.DeletedBy,
+	}
+}
+
+func TransactionAttachmentsToEntity(data []TransactionAttachment) []domain.TransactionAttachment {
+	attachments := make([]domain.TransactionAttachment, len(data))
+	for i, d := range data {
+		attachments[i] = *TransactionAttachmentToEntity(d)
+	}
+	return attachments
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func TransactionBatchFromEntity(data *domain.TransactionBatch) TransactionBatch {
+	items := make([]TransactionBatchItem, len(data.Items))
+	for i := range data.Items {
+		items[i] = TransactionBatchItemFromEntity(&data.Items[i])
+	}
+
+	return TransactionBatch{
+		ID:          data.ID,
+		PartnerID:   data.Owner.PartnerID,
+		CustomerID:  data.Owner.CustomerID,
+		IsSandbox:   data.Owner.Sandbox,
+		Status:      BatchStatus(data.Status),
+		CreatedAt:   data.CreatedAt,
+		CompletedAt: data.CompletedAt,
+		Items:       items,
+	}
+}
+
+func TransactionBatchToEntity(data TransactionBatch) *domain.TransactionBatch {
+	items := make([]domain.TransactionBatchItem, len(data.Items))
+	for i, item := range data.Items {
+		items[i] = *TransactionBatchItemToEntity(item)
+	}
+
+	return &domain.TransactionBatch{
+		ID: data.ID,
+		Owner: domain.BatchOwner{
+			PartnerID:  data.PartnerID,
+			CustomerID: data.CustomerID,
+			Sandbox:    data.IsSandbox,
+		},
+		Status:      domain.BatchStatus(data.Status),
+		Items:       items,
+		CreatedAt:   data.CreatedAt,
+		CompletedAt: data.CompletedAt,
+	}
+}
+
+func TransactionBatchesToEntity(data []TransactionBatch) []domain.TransactionBatch {
+	batches := make([]domain.TransactionBatch, len(data))
+	for i, batch := range data {
+		batches[i] = *TransactionBatchToEntity(batch)
+	}
+
+	return batches
+}
+
+func TransactionBatchItemFromEntity(data *domain.TransactionBatchItem) TransactionBatchItem {
+	return TransactionBatchItem{
+		ID:             data.ID,
+		BatchID:        data.BatchID,
+		Sequence:       data.Sequence,
+		CustomerNIK:    data.CustomerNIK,
+		TenorMonths:    data.TenorMonths,
+		AssetName:      data.AssetName,
+		OTRAmount:      data.OTRAmount,
+		AdminFee:       data.AdminFee,
+		Currency:       data.Currency,
+		PromoCode:      data.PromoCode,
+		Status:         BatchItemStatus(data.Status),
+		TransactionID:  data.TransactionID,
+		ContractNumber: data.ContractNumber,
+		ErrorCode:      data.ErrorCode,
+		ErrorMessage:   data.ErrorMessage,
+		ProcessedAt:    data.ProcessedAt,
+	}
+}
+
+func TransactionBatchItemToEntity(data TransactionBatchItem) *domain.TransactionBatchItem {
+	return &domain.TransactionBatchItem{
+		ID:             data.ID,
+		BatchID:        data.BatchID,
+		Sequence:       data.Sequence,
+		CustomerNIK:    data.CustomerNIK,
+		TenorMonths:    data.TenorMonths,
+		AssetName:      data.AssetName,
+		OTRAmount:      data.OTRAmount,
+		AdminFee:       data.AdminFee,
+		Currency:       data.Currency,
+		PromoCode:      data.PromoCode,
+		Status:         domain.BatchItemStatus(data.Status),
+		TransactionID:  data.TransactionID,
+		ContractNumber: data.ContractNumber,
+		ErrorCode:      data.ErrorCode,
+		ErrorMessage:   data.ErrorMessage,
+		ProcessedAt:    data.ProcessedAt,
+	}
+}
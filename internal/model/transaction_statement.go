@@ -0,0 +1,41 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func TransactionStatementFromEntity(data *domain.TransactionStatement) TransactionStatement {
+	return TransactionStatement{
+		ID:             data.ID,
+		ContractNumber: data.ContractNumber,
+		CustomerID:     data.CustomerID,
+		Fingerprint:    data.Fingerprint,
+		Status:         StatementStatus(data.Status),
+		URL:            data.URL,
+		Error:          data.Error,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func TransactionStatementToEntity(data TransactionStatement) *domain.TransactionStatement {
+	return &domain.TransactionStatement{
+		ID:             data.ID,
+		ContractNumber: data.ContractNumber,
+		CustomerID:     data.CustomerID,
+		Fingerprint:    data.Fingerprint,
+		Status:         domain.StatementStatus(data.Status),
+		URL:            data.URL,
+		Error:          data.Error,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+func TransactionStatementsToEntity(data []TransactionStatement) []domain.TransactionStatement {
+	statements := make([]domain.TransactionStatement, len(data))
+	for i, statement := range data {
+		statements[i] = *TransactionStatementToEntity(statement)
+	}
+	return statements
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+func WriteOffFromEntity(data *domain.WriteOff) WriteOff {
+	return WriteOff{
+		ID:              data.ID,
+		TransactionID:   data.TransactionID,
+		CustomerID:      data.CustomerID,
+		Status:          string(data.Status),
+		DaysPastDue:     data.DaysPastDue,
+		Amount:          data.Amount,
+		RecoveredAmount: data.RecoveredAmount,
+		Reason:          data.Reason,
+		RequestedBy:     data.RequestedBy,
+		ReviewerID:      data.ReviewerID,
+		ReviewNote:      data.ReviewNote,
+		ReviewedAt:      data.ReviewedAt,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+	}
+}
+
+func WriteOffToEntity(data WriteOff) *domain.WriteOff {
+	writeOff := &domain.WriteOff{
+		ID:              data.ID,
+		TransactionID:   data.TransactionID,
+		CustomerID:      data.CustomerID,
+		Status:          domain.WriteOffStatus(data.Status),
+		DaysPastDue:     data.DaysPastDue,
+		Amount:          data.Amount,
+		RecoveredAmount: data.RecoveredAmount,
+		Reason:          data.Reason,
+		RequestedBy:     data.RequestedBy,
+		ReviewerID:      data.ReviewerID,
+		ReviewNote:      data.ReviewNote,
+		ReviewedAt:      data.ReviewedAt,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+	}
+	// Relasi hanya terisi kalau di-preload
+	if data.Transaction.ID != 0 {
+		writeOff.Transaction = TransactionToEntity(data.Transaction)
+	}
+	return writeOff
+}
+
+func WriteOffsToEntity(data []WriteOff) []domain.WriteOff {
+	writeOffs := make([]domain.WriteOff, len(data))
+	for i, w := range data {
+		writeOffs[i] = *WriteOffToEntity(w)
+	}
+	return writeOffs
+}
+
+func WriteOffRecoveryFromEntity(data *domain.WriteOffRecovery) WriteOffRecovery {
+	return WriteOffRecovery{
+		ID:         data.ID,
+		WriteOffID: data.WriteOffID,
+		Amount:     data.Amount,
+		ReceivedOn: data.ReceivedOn,
+		Reference:  data.Reference,
+		Note:       data.Note,
+		CreatedBy:  data.CreatedBy,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func WriteOffRecoveriesToEntity(data []WriteOffRecovery) []domain.WriteOffRecovery {
+	recoveries := make([]domain.WriteOffRecovery, len(data))
+	for i, r := range data {
+		recoveries[i] = domain.WriteOffRecovery{
+			ID:         r.ID,
+			WriteOffID: r.WriteOffID,
+			Amount:     r.Amount,
+			ReceivedOn: r.ReceivedOn,
+			Reference:  r.Reference,
+			Note:       r.Note,
+			CreatedBy:  r.CreatedBy,
+			CreatedAt:  r.CreatedAt,
+		}
+	}
+	return recoveries
+}
+
+func LedgerEntriesFromEntity(data []domain.LedgerEntry) []LedgerEntry {
+	entries := make([]LedgerEntry, len(data))
+	for i, e := range data {
+		entries[i] = LedgerEntry{
+			ID:            e.ID,
+			ReferenceType: string(e.ReferenceType),
+			ReferenceID:   e.ReferenceID,
+			TransactionID: e.TransactionID,
+			Account:       string(e.Account),
+			Debit:         e.Debit,
+			Credit:        e.Credit,
+			EntryDate:     e.EntryDate,
+			Description:   e.Description,
+			CreatedAt:     e.CreatedAt,
+		}
+	}
+	return entries
+}
+
+func LedgerEntriesToEntity(data []LedgerEntry) []domain.LedgerEntry {
+	entries := make([]domain.LedgerEntry, len(data))
+	for i, e := range data {
+		entries[i] = domain.LedgerEntry{
+			ID:            e.ID,
+			ReferenceType: domain.LedgerReference(e.ReferenceType),
+			ReferenceID:   e.ReferenceID,
+			TransactionID: e.TransactionID,
+			Account:       domain.LedgerAccount(e.Account),
+			Debit:         e.Debit,
+			Credit:        e.Credit,
+			EntryDate:     e.EntryDate,
+			Description:   e.Description,
+			CreatedAt:     e.CreatedAt,
+		}
+	}
+	return entries
+}
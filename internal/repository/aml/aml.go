@@ -0,0 +1,346 @@
+package amlrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type amlRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsUpdated   metric.Int64Counter
+}
+
+// FindUnscreened implements AMLRepository.
+func (r *amlRepository) FindUnscreened(ctx context.Context, limit int) ([]domain.Transaction, error) {
+	done := r.begin(ctx, "find_unscreened_transactions", "transactions", "select")
+	defer done()
+
+	// Urut dari yang paling lama supaya aturan transaksi beruntun melihat
+	// transaksi sebelumnya lebih dulu
+	var transactions []model.Transaction
+	err := r.db.WithContext(ctx).
+		Where("aml_screened_at IS NULL AND is_sandbox = ?", false).
+		Order("id ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		r.recordError(ctx, "transactions", "select", "Error finding unscreened transactions", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	return model.TransactionsToEntity(transactions), nil
+}
+
+// CountRecent implements AMLRepository.
+func (r *amlRepository) CountRecent(ctx context.Context, customerID uint64, from, to time.Time) (int64, error) {
+	done := r.begin(ctx, "count_recent_transactions", "transactions", "count")
+	defer done()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("customer_id = ? AND is_sandbox = ?", customerID, false).
+		Where("transaction_date > ? AND transaction_date <= ?", from, to).
+		Count(&count).Error
+	if err != nil {
+		r.recordError(ctx, "transactions", "count", "Error counting recent transactions", err, zap.Uint64("customer_id", customerID))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// HasRecentCase implements AMLRepository.
+func (r *amlRepository) HasRecentCase(ctx context.Context, customerID uint64, rule domain.AMLRule, from time.Time) (bool, error) {
+	done := r.begin(ctx, "has_recent_aml_case", "aml_cases", "count")
+	defer done()
+
+	// Jendela dihitung dari tanggal transaksi, bukan waktu kasus dibuat,
+	// karena job bisa saja memproses transaksi lama yang tertunda
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.AMLCase{}).
+		Joins("JOIN transactions ON transactions.id = aml_cases.transaction_id").
+		Where("aml_cases.customer_id = ? AND aml_cases.rule = ?", customerID, string(rule)).
+		Where("transactions.transaction_date > ?", from).
+		Count(&count).Error
+	if err != nil {
+		r.recordError(ctx, "aml_cases", "count", "Error checking recent AML cases", err, zap.Uint64("customer_id", customerID))
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CreateCase implements AMLRepository.
+func (r *amlRepository) CreateCase(ctx context.Context, amlCase *domain.AMLCase) error {
+	done := r.begin(ctx, "create_aml_case", "aml_cases", "insert")
+	defer done()
+
+	if amlCase.Status == "" {
+		amlCase.Status = domain.AMLCaseOpen
+	}
+
+	data := model.AMLCaseFromEntity(amlCase)
+	if err := r.db.WithContext(ctx).Omit("Customer", "Transaction").Create(&data).Error; err != nil {
+		r.recordError(ctx, "aml_cases", "insert", "Error creating AML case", err,
+			zap.Uint64("transaction_id", amlCase.TransactionID),
+			zap.String("rule", string(amlCase.Rule)),
+		)
+		return err
+	}
+
+	amlCase.ID = data.ID
+	amlCase.CreatedAt = data.CreatedAt
+	amlCase.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// MarkScreened implements AMLRepository.
+func (r *amlRepository) MarkScreened(ctx context.Context, transactionID uint64, at time.Time) error {
+	done := r.begin(ctx, "mark_transaction_screened", "transactions", "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("id = ?", transactionID).
+		UpdateColumn("aml_screened_at", at)
+	if result.Error != nil {
+		r.recordError(ctx, "transactions", "update", "Error marking transaction screened", result.Error, zap.Uint64("transaction_id", transactionID))
+		return result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	return nil
+}
+
+// FindCaseByID implements AMLRepository.
+func (r *amlRepository) FindCaseByID(ctx context.Context, id uint64) (*domain.AMLCase, error) {
+	done := r.begin(ctx, "find_aml_case_by_id", "aml_cases", "select")
+	defer done()
+
+	var amlCase model.AMLCase
+	err := r.withRelations(ctx).First(&amlCase, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.recordError(ctx, "aml_cases", "select", "Error finding AML case", err, zap.Uint64("aml_case_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "aml_cases"),
+		),
+	)
+
+	return model.AMLCaseToEntity(amlCase), nil
+}
+
+// FindCasesPaginated implements AMLRepository.
+func (r *amlRepository) FindCasesPaginated(ctx context.Context, params domain.Params) ([]domain.AMLCase, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find AML cases paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("rule", params.Value("rule")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_aml_cases", "aml_cases", "select_paginated")
+	defer done()
+
+	countQuery := params.Filter(r.db.WithContext(ctx).Model(&model.AMLCase{}))
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, "aml_cases", "select_paginated", "Error counting AML cases", err)
+		return nil, 0, err
+	}
+
+	var cases []model.AMLCase
+	if err := params.Paginate(params.Filter(r.withRelations(ctx))).Order("id DESC").Find(&cases).Error; err != nil {
+		r.recordError(ctx, "aml_cases", "select_paginated", "Error finding AML cases", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(cases)),
+		metric.WithAttributes(
+			attribute.String("table", "aml_cases"),
+		),
+	)
+
+	return model.AMLCasesToEntity(cases), total, nil
+}
+
+// UpdateCase implements AMLRepository.
+func (r *amlRepository) UpdateCase(ctx context.Context, amlCase *domain.AMLCase, from domain.AMLCaseStatus) (bool, error) {
+	done := r.begin(ctx, "update_aml_case", "aml_cases", "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).Model(&model.AMLCase{}).
+		Where("id = ? AND status = ?", amlCase.ID, string(from)).
+		Updates(map[string]any{
+			"status":           string(amlCase.Status),
+			"review_note":      amlCase.ReviewNote,
+			"reviewed_by":      amlCase.ReviewedBy,
+			"reviewed_at":      amlCase.ReviewedAt,
+			"report_reference": amlCase.ReportReference,
+			"reported_at":      amlCase.ReportedAt,
+		})
+	if result.Error != nil {
+		r.recordError(ctx, "aml_cases", "update", "Error updating AML case", result.Error, zap.Uint64("aml_case_id", amlCase.ID))
+		return false, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "aml_cases"),
+		),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindForExport implements AMLRepository.
+func (r *amlRepository) FindForExport(ctx context.Context, from, to time.Time, status domain.AMLCaseStatus) ([]domain.AMLCase, error) {
+	done := r.begin(ctx, "find_aml_cases_for_export", "aml_cases", "select")
+	defer done()
+
+	query := r.withRelations(ctx).Where("created_at >= ? AND created_at < ?", from, to)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var cases []model.AMLCase
+	if err := query.Order("id ASC").Find(&cases).Error; err != nil {
+		r.recordError(ctx, "aml_cases", "select", "Error finding AML cases for export", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(cases)),
+		metric.WithAttributes(
+			attribute.String("table", "aml_cases"),
+		),
+	)
+
+	return model.AMLCasesToEntity(cases), nil
+}
+
+// withRelations loads the transaction and customer every case view shows.
+func (r *amlRepository) withRelations(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&model.AMLCase{}).Preload("Transaction").Preload("Customer")
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *amlRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *amlRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewAMLRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.AMLRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsUpdated, _ := meter.Int64Counter(
+		"db.documents.updated",
+		metric.WithDescription("Number of documents updated in the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &amlRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsUpdated:   documentsUpdated,
+	}
+}
@@ -0,0 +1,195 @@
+package apiusagerepo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	ratelimiter "github.com/fazamuttaqien/multifinance/pkg/rate-limiter"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/redis/go-redis/v9"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const usageKeyspace = "rate_limit_usage"
+
+// apiUsageRepository reads the daily counters the rate limiter keeps for
+// partners authenticated by API key.
+type apiUsageRepository struct {
+	client             *redis.Client
+	meter              metric.Meter
+	tracer             trace.Tracer
+	log                *zap.Logger
+	queryDuration      metric.Float64Histogram
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+}
+
+// FindByPartner implements APIUsageRepository.
+func (r *apiUsageRepository) FindByPartner(ctx context.Context, partnerID uint64, days []time.Time) ([]domain.APIUsage, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.FindAPIUsageByPartner")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Int("usage.days", len(days)),
+	)
+
+	done := r.begin(ctx, span, "find_api_usage_by_partner", "hgetall")
+	defer done()
+
+	// Satu round trip untuk semua hari
+	key := tenancy.Key(ctx, ratelimiter.PartnerKey(partnerID))
+	pipe := r.client.Pipeline()
+	results := make([]*redis.MapStringStringCmd, len(days))
+	for i, day := range days {
+		results[i] = pipe.HGetAll(ctx, ratelimiter.UsageKey(key, day))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		r.recordError(ctx, span, start, "hgetall", "Error finding partner API usage", err, zap.Uint64("partner_id", partnerID))
+		return nil, err
+	}
+
+	usage := make([]domain.APIUsage, len(days))
+	for i, day := range days {
+		// Hari tanpa request tidak punya hash, dihitung nol
+		fields := results[i].Val()
+		allowed, _ := strconv.ParseInt(fields[ratelimiter.UsageAllowed], 10, 64)
+		throttled, _ := strconv.ParseInt(fields[ratelimiter.UsageThrottled], 10, 64)
+		day = day.UTC()
+		usage[i] = domain.APIUsage{
+			Day:       time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC),
+			Allowed:   allowed,
+			Throttled: throttled,
+		}
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(usage)),
+		metric.WithAttributes(
+			attribute.String("table", usageKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, "hgetall", "success")
+	span.SetStatus(codes.Ok, "Partner API usage found successfully")
+
+	return usage, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *apiUsageRepository) begin(ctx context.Context, span trace.Span, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", usageKeyspace),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", usageKeyspace),
+		),
+	)
+
+	span.SetAttributes(
+		attribute.String("db.system", "redis"),
+		attribute.String("db.operation", dbOperation),
+		attribute.String("db.table", usageKeyspace),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *apiUsageRepository) recordError(
+	ctx context.Context, span trace.Span, start time.Time,
+	dbOperation, message string, err error, fields ...zap.Field) {
+	span.SetStatus(codes.Error, message)
+	span.RecordError(err)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", usageKeyspace),
+			attribute.String("error", err.Error()),
+		),
+	)
+
+	r.recordDuration(ctx, start, dbOperation, "error")
+}
+
+func (r *apiUsageRepository) recordDuration(ctx context.Context, start time.Time, dbOperation, status string) float64 {
+	duration := float64(time.Since(start).Milliseconds())
+	r.queryDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", usageKeyspace),
+			attribute.String("status", status),
+		),
+	)
+	return duration
+}
+
+func NewAPIUsageRepository(
+	client *redis.Client,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) repository.APIUsageRepository {
+	queryDuration, _ := meter.Float64Histogram(
+		"db.query.duration",
+		metric.WithDescription("Duration of database queries"),
+		metric.WithUnit("ms"),
+	)
+
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &apiUsageRepository{
+		client:             client,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		queryDuration:      queryDuration,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+	}
+}
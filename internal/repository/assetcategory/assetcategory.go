@@ -0,0 +1,146 @@
+package assetcategoryrepo
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const assetCategoriesTable = "asset_categories"
+
+type assetCategoryRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Upsert implements AssetCategoryRepository.
+func (r *assetCategoryRepository) Upsert(ctx context.Context, category *domain.AssetCategory) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_asset_category", assetCategoriesTable, "upsert")
+	defer done()
+
+	data := model.AssetCategoryFromEntity(category)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, assetCategoriesTable, "upsert", "Error upserting asset category", err, zap.String("code", category.Code))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", assetCategoriesTable),
+		),
+	)
+
+	r.log.Debug("Asset category saved",
+		zap.String("code", category.Code),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	category.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *assetCategoryRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *assetCategoryRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewAssetCategoryRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.AssetCategoryRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &assetCategoryRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,254 @@
+package attachmentrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	attachmentsTable  = "transaction_attachments"
+	transactionsTable = "transactions"
+)
+
+type attachmentRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindTransaction implements TransactionAttachmentRepository.
+func (r *attachmentRepository) FindTransaction(ctx context.Context, id uint64) (*domain.Transaction, error) {
+	done := r.begin(ctx, "find_attachment_transaction", transactionsTable, "select")
+	defer done()
+
+	var data model.Transaction
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, transactionsTable, "select", "Error finding transaction", err, zap.Uint64("transaction_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	return model.TransactionToEntity(data), nil
+}
+
+// Create implements TransactionAttachmentRepository.
+func (r *attachmentRepository) Create(ctx context.Context, attachment *domain.TransactionAttachment) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_transaction_attachment", attachmentsTable, "insert")
+	defer done()
+
+	data := model.TransactionAttachmentFromEntity(attachment)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, attachmentsTable, "insert", "Error creating transaction attachment", err, zap.Uint64("transaction_id", attachment.TransactionID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", attachmentsTable),
+		),
+	)
+
+	r.log.Info("Transaction attachment created",
+		zap.Uint64("attachment_id", data.ID),
+		zap.Uint64("transaction_id", attachment.TransactionID),
+		zap.String("type", string(attachment.Type)),
+		zap.Int64("size", attachment.Size),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	attachment.ID = data.ID
+	attachment.CreatedAt = data.CreatedAt
+
+	return nil
+}
+
+// FindByID implements TransactionAttachmentRepository. Deleted attachments
+// are returned too so callers can tell them apart from unknown IDs.
+func (r *attachmentRepository) FindByID(ctx context.Context, id uint64) (*domain.TransactionAttachment, error) {
+	done := r.begin(ctx, "find_transaction_attachment", attachmentsTable, "select")
+	defer done()
+
+	var data model.TransactionAttachment
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, attachmentsTable, "select", "Error finding transaction attachment", err, zap.Uint64("attachment_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", attachmentsTable),
+		),
+	)
+
+	return model.TransactionAttachmentToEntity(data), nil
+}
+
+// FindByTransaction implements TransactionAttachmentRepository.
+func (r *attachmentRepository) FindByTransaction(ctx context.Context, transactionID uint64) ([]domain.TransactionAttachment, error) {
+	done := r.begin(ctx, "find_transaction_attachments", attachmentsTable, "select")
+	defer done()
+
+	var data []model.TransactionAttachment
+	err := r.db.WithContext(ctx).
+		Where("transaction_id = ? AND deleted_at IS NULL", transactionID).
+		Order("created_at ASC, id ASC").
+		Find(&data).Error
+	if err != nil {
+		r.recordError(ctx, attachmentsTable, "select", "Error finding transaction attachments", err, zap.Uint64("transaction_id", transactionID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(data)),
+		metric.WithAttributes(
+			attribute.String("table", attachmentsTable),
+		),
+	)
+
+	return model.TransactionAttachmentsToEntity(data), nil
+}
+
+// Delete implements TransactionAttachmentRepository. The row is kept with
+// the deleting admin recorded; an attachment that is already deleted
+// reports false.
+func (r *attachmentRepository) Delete(ctx context.Context, id, deletedBy uint64, deletedAt time.Time) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "delete_transaction_attachment", attachmentsTable, "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).
+		Model(&model.TransactionAttachment{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]any{"deleted_at": deletedAt, "deleted_by": deletedBy})
+	if result.Error != nil {
+		r.recordError(ctx, attachmentsTable, "update", "Error deleting transaction attachment", result.Error, zap.Uint64("attachment_id", id))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Transaction attachment deleted",
+		zap.Uint64("attachment_id", id),
+		zap.Uint64("deleted_by", deletedBy),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *attachmentRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *attachmentRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewTransactionAttachmentRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.TransactionAttachmentRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &attachmentRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,275 @@
+package batchrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type batchRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements TransactionBatchRepository.
+func (r *batchRepository) Create(ctx context.Context, batch *domain.TransactionBatch) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_transaction_batch", "transaction_batches", "insert")
+	defer done()
+
+	// Batch dan seluruh item-nya disimpan dalam satu insert beruntun milik GORM
+	data := model.TransactionBatchFromEntity(batch)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "transaction_batches", "insert", "Error creating transaction batch", err, zap.Int("items", len(batch.Items)))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, int64(1+len(data.Items)),
+		metric.WithAttributes(
+			attribute.String("table", "transaction_batches"),
+		),
+	)
+
+	r.log.Info("Transaction batch created",
+		zap.Uint64("batch_id", data.ID),
+		zap.Int("items", len(data.Items)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	*batch = *model.TransactionBatchToEntity(data)
+
+	return nil
+}
+
+// FindByID implements TransactionBatchRepository.
+func (r *batchRepository) FindByID(ctx context.Context, id uint64) (*domain.TransactionBatch, error) {
+	done := r.begin(ctx, "find_transaction_batch_by_id", "transaction_batches", "select")
+	defer done()
+
+	var batch model.TransactionBatch
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("sequence ASC") }).
+		First(&batch, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "transaction_batches", "select", "Error finding transaction batch", err, zap.Uint64("batch_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(1+len(batch.Items)),
+		metric.WithAttributes(
+			attribute.String("table", "transaction_batches"),
+		),
+	)
+
+	return model.TransactionBatchToEntity(batch), nil
+}
+
+// FindPending implements TransactionBatchRepository.
+func (r *batchRepository) FindPending(ctx context.Context, limit int) ([]domain.TransactionBatch, error) {
+	done := r.begin(ctx, "find_pending_transaction_batches", "transaction_batches", "select")
+	defer done()
+
+	// Hanya item yang belum diproses yang dimuat, batch tertua didahulukan
+	var batches []model.TransactionBatch
+	err := r.db.WithContext(ctx).
+		Where("status = ?", model.BatchPending).
+		Order("id ASC").
+		Limit(limit).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", model.BatchItemPending).Order("sequence ASC")
+		}).
+		Find(&batches).Error
+	if err != nil {
+		r.recordError(ctx, "transaction_batches", "select", "Error finding pending transaction batches", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(batches)),
+		metric.WithAttributes(
+			attribute.String("table", "transaction_batches"),
+		),
+	)
+
+	return model.TransactionBatchesToEntity(batches), nil
+}
+
+// ClaimItem implements TransactionBatchRepository.
+func (r *batchRepository) ClaimItem(ctx context.Context, id uint64) (bool, error) {
+	done := r.begin(ctx, "claim_transaction_batch_item", "transaction_batch_items", "update")
+	defer done()
+
+	// Update bersyarat mencegah dua worker memproses item yang sama
+	result := r.db.WithContext(ctx).Model(&model.TransactionBatchItem{}).
+		Where("id = ? AND status = ?", id, model.BatchItemPending).
+		Update("status", model.BatchItemProcessing)
+	if result.Error != nil {
+		r.recordError(ctx, "transaction_batch_items", "update", "Error claiming transaction batch item", result.Error, zap.Uint64("item_id", id))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// CompleteItem implements TransactionBatchRepository.
+func (r *batchRepository) CompleteItem(ctx context.Context, item *domain.TransactionBatchItem) error {
+	done := r.begin(ctx, "complete_transaction_batch_item", "transaction_batch_items", "update")
+	defer done()
+
+	err := r.db.WithContext(ctx).Model(&model.TransactionBatchItem{}).
+		Where("id = ?", item.ID).
+		Updates(map[string]any{
+			"status":          model.BatchItemStatus(item.Status),
+			"transaction_id":  item.TransactionID,
+			"contract_number": item.ContractNumber,
+			"error_code":      item.ErrorCode,
+			"error_message":   item.ErrorMessage,
+			"processed_at":    item.ProcessedAt,
+		}).Error
+	if err != nil {
+		r.recordError(ctx, "transaction_batch_items", "update", "Error completing transaction batch item", err, zap.Uint64("item_id", item.ID))
+		return err
+	}
+
+	return nil
+}
+
+// Complete implements TransactionBatchRepository.
+func (r *batchRepository) Complete(ctx context.Context, id uint64, completedAt time.Time) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "complete_transaction_batch", "transaction_batches", "update")
+	defer done()
+
+	// Batch baru selesai bila tidak ada lagi item yang menunggu atau sedang diproses
+	unfinished := r.db.Model(&model.TransactionBatchItem{}).
+		Select("1").
+		Where("batch_id = ? AND status IN ?", id, []model.BatchItemStatus{model.BatchItemPending, model.BatchItemProcessing})
+	result := r.db.WithContext(ctx).Model(&model.TransactionBatch{}).
+		Where("id = ? AND status = ?", id, model.BatchPending).
+		Where("NOT EXISTS (?)", unfinished).
+		Updates(map[string]any{"status": model.BatchCompleted, "completed_at": completedAt})
+	if result.Error != nil {
+		r.recordError(ctx, "transaction_batches", "update", "Error completing transaction batch", result.Error, zap.Uint64("batch_id", id))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Transaction batch completed",
+		zap.Uint64("batch_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *batchRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *batchRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewBatchRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.TransactionBatchRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &batchRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
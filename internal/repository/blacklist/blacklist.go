@@ -0,0 +1,302 @@
+package blacklistrepo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	entriesTable = "blacklist_entries"
+	logsTable    = "screening_logs"
+)
+
+type blacklistRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements BlacklistRepository.
+func (r *blacklistRepository) Create(ctx context.Context, entry *domain.BlacklistEntry) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, entriesTable, "create_blacklist_entry", "insert")
+	defer done()
+
+	data := model.BlacklistEntryFromEntity(entry)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, entriesTable, "insert", "Error creating blacklist entry", err)
+		return err
+	}
+
+	entry.ID = data.ID
+	entry.CreatedAt = data.CreatedAt
+	entry.UpdatedAt = data.UpdatedAt
+
+	r.documentsInserted.Add(ctx, 1, metric.WithAttributes(attribute.String("table", entriesTable)))
+
+	r.log.Info("Blacklist entry created",
+		zap.Uint64("entry_id", entry.ID),
+		zap.String("severity", string(entry.Severity)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// Update implements BlacklistRepository.
+func (r *blacklistRepository) Update(ctx context.Context, entry *domain.BlacklistEntry) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, entriesTable, "update_blacklist_entry", "update")
+	defer done()
+
+	data := model.BlacklistEntryFromEntity(entry)
+	err := r.db.WithContext(ctx).Model(&model.BlacklistEntry{ID: entry.ID}).
+		Select("nik", "full_name", "reason", "source", "severity").
+		Updates(&data).Error
+	if err != nil {
+		r.recordError(ctx, entriesTable, "update", "Error updating blacklist entry", err, zap.Uint64("entry_id", entry.ID))
+		return err
+	}
+
+	r.log.Info("Blacklist entry updated",
+		zap.Uint64("entry_id", entry.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// Delete implements BlacklistRepository.
+func (r *blacklistRepository) Delete(ctx context.Context, id uint64) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, entriesTable, "delete_blacklist_entry", "delete")
+	defer done()
+
+	if err := r.db.WithContext(ctx).Delete(&model.BlacklistEntry{}, id).Error; err != nil {
+		r.recordError(ctx, entriesTable, "delete", "Error deleting blacklist entry", err, zap.Uint64("entry_id", id))
+		return err
+	}
+
+	r.log.Info("Blacklist entry deleted",
+		zap.Uint64("entry_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindByID implements BlacklistRepository.
+func (r *blacklistRepository) FindByID(ctx context.Context, id uint64) (*domain.BlacklistEntry, error) {
+	done := r.begin(ctx, entriesTable, "find_blacklist_entry_by_id", "select")
+	defer done()
+
+	var entry model.BlacklistEntry
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, entriesTable, "select", "Error finding blacklist entry", err, zap.Uint64("entry_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1, metric.WithAttributes(attribute.String("table", entriesTable)))
+
+	return model.BlacklistEntryToEntity(entry), nil
+}
+
+// FindMatches implements BlacklistRepository.
+func (r *blacklistRepository) FindMatches(ctx context.Context, nik, fullName string) ([]domain.BlacklistEntry, error) {
+	done := r.begin(ctx, entriesTable, "find_blacklist_matches", "select")
+	defer done()
+
+	// NIK dicocokkan persis, nama dicocokkan tanpa membedakan huruf besar/kecil
+	var entries []model.BlacklistEntry
+	err := r.db.WithContext(ctx).
+		Where("(nik <> '' AND nik = ?) OR LOWER(full_name) = ?", nik, strings.ToLower(fullName)).
+		Find(&entries).Error
+	if err != nil {
+		r.recordError(ctx, entriesTable, "select", "Error finding blacklist matches", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(entries)), metric.WithAttributes(attribute.String("table", entriesTable)))
+
+	return model.BlacklistEntriesToEntity(entries), nil
+}
+
+// FindPaginated implements BlacklistRepository.
+func (r *blacklistRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.BlacklistEntry, int64, error) {
+	done := r.begin(ctx, entriesTable, "find_paginated_blacklist_entries", "select_paginated")
+	defer done()
+
+	query := r.db.WithContext(ctx).Model(&model.BlacklistEntry{})
+	countQuery := r.db.WithContext(ctx).Model(&model.BlacklistEntry{})
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, entriesTable, "select_paginated", "Error counting blacklist entries", err)
+		return nil, 0, err
+	}
+
+	var entries []model.BlacklistEntry
+	if err := params.Paginate(query).Order("created_at DESC").Find(&entries).Error; err != nil {
+		r.recordError(ctx, entriesTable, "select_paginated", "Error finding blacklist entries", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(entries)), metric.WithAttributes(attribute.String("table", entriesTable)))
+
+	return model.BlacklistEntriesToEntity(entries), total, nil
+}
+
+// CreateScreeningLog implements BlacklistRepository.
+func (r *blacklistRepository) CreateScreeningLog(ctx context.Context, log *domain.ScreeningLog) error {
+	done := r.begin(ctx, logsTable, "create_screening_log", "insert")
+	defer done()
+
+	data := model.ScreeningLogFromEntity(log)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, logsTable, "insert", "Error creating screening log", err, zap.Uint64("entry_id", log.EntryID))
+		return err
+	}
+
+	log.ID = data.ID
+	log.CreatedAt = data.CreatedAt
+
+	r.documentsInserted.Add(ctx, 1, metric.WithAttributes(attribute.String("table", logsTable)))
+
+	return nil
+}
+
+// FindScreeningLogsPaginated implements BlacklistRepository.
+func (r *blacklistRepository) FindScreeningLogsPaginated(ctx context.Context, params domain.Params) ([]domain.ScreeningLog, int64, error) {
+	done := r.begin(ctx, logsTable, "find_paginated_screening_logs", "select_paginated")
+	defer done()
+
+	query := r.db.WithContext(ctx).Model(&model.ScreeningLog{})
+	countQuery := r.db.WithContext(ctx).Model(&model.ScreeningLog{})
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, logsTable, "select_paginated", "Error counting screening logs", err)
+		return nil, 0, err
+	}
+
+	var logs []model.ScreeningLog
+	if err := params.Paginate(query).Order("created_at DESC").Find(&logs).Error; err != nil {
+		r.recordError(ctx, logsTable, "select_paginated", "Error finding screening logs", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(logs)), metric.WithAttributes(attribute.String("table", logsTable)))
+
+	return model.ScreeningLogsToEntity(logs), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *blacklistRepository) begin(ctx context.Context, table, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *blacklistRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewBlacklistRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.BlacklistRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &blacklistRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,179 @@
+package calendarrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const calendarTokensTable = "calendar_tokens"
+
+type calendarTokenRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements CalendarTokenRepository.
+func (r *calendarTokenRepository) Create(ctx context.Context, token *domain.CalendarToken) error {
+	done := r.begin(ctx, "create_calendar_token", "insert")
+	defer done()
+
+	data := model.CalendarTokenFromEntity(token)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error creating calendar token", err, zap.Uint64("customer_id", token.CustomerID), zap.String("contract_number", token.ContractNumber))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", calendarTokensTable),
+		),
+	)
+
+	token.ID = data.ID
+	token.CreatedAt = data.CreatedAt
+	return nil
+}
+
+// Revoke implements CalendarTokenRepository.
+func (r *calendarTokenRepository) Revoke(ctx context.Context, customerID uint64, contractNumber string, at time.Time) (int64, error) {
+	done := r.begin(ctx, "revoke_calendar_tokens", "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).Model(&model.CalendarToken{}).
+		Where("customer_id = ? AND contract_number = ? AND revoked_at IS NULL", customerID, contractNumber).
+		UpdateColumn("revoked_at", at)
+	if result.Error != nil {
+		r.recordError(ctx, "update", "Error revoking calendar tokens", result.Error, zap.Uint64("customer_id", customerID), zap.String("contract_number", contractNumber))
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// FindActiveByHash implements CalendarTokenRepository.
+func (r *calendarTokenRepository) FindActiveByHash(ctx context.Context, hash string) (*domain.CalendarToken, error) {
+	done := r.begin(ctx, "find_active_calendar_token", "select")
+	defer done()
+
+	var token model.CalendarToken
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND revoked_at IS NULL", hash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding calendar token", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", calendarTokensTable),
+		),
+	)
+
+	return model.CalendarTokenToEntity(token), nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *calendarTokenRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", calendarTokensTable),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", calendarTokensTable),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *calendarTokenRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", calendarTokensTable),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewCalendarTokenRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.CalendarTokenRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &calendarTokenRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,274 @@
+package closurerepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	closuresTable  = "account_closures"
+	customersTable = "customers"
+
+	// AnonymizedName replaces the names of an anonymized customer.
+	AnonymizedName = "ANONYMIZED"
+)
+
+// AnonymizedNIK is the placeholder NIK of an anonymized customer. It keeps
+// the column unique and can never match a real NIK, which is all digits.
+func AnonymizedNIK(customerID uint64) string {
+	return fmt.Sprintf("X%015d", customerID)
+}
+
+type closureRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsUpdated   metric.Int64Counter
+}
+
+// Blockers implements AccountClosureRepository.
+func (r *closureRepository) Blockers(ctx context.Context, customerID uint64) (*domain.ClosureBlockers, error) {
+	done := r.begin(ctx, "find_closure_blockers", customersTable, "select_aggregate")
+	defer done()
+
+	// Kontrak sandbox bukan kewajiban customer
+	blockers := &domain.ClosureBlockers{}
+	err := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("customer_id = ? AND is_sandbox = ? AND status IN ?", customerID, false,
+			[]model.TransactionStatus{model.TransactionPending, model.TransactionApproved, model.TransactionActive}).
+		Count(&blockers.ActiveContracts).Error
+	if err != nil {
+		r.recordError(ctx, customersTable, "select_aggregate", "Error counting running contracts", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	var outstanding decimal.NullDecimal
+	err = r.db.WithContext(ctx).Model(&model.CustomerExposure{}).
+		Select("SUM(active_principal)").
+		Where("customer_id = ?", customerID).
+		Scan(&outstanding).Error
+	if err != nil {
+		r.recordError(ctx, customersTable, "select_aggregate", "Error summing outstanding principal", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+	blockers.OutstandingPrincipal = outstanding.Decimal
+
+	return blockers, nil
+}
+
+// Close implements AccountClosureRepository.
+func (r *closureRepository) Close(ctx context.Context, closure *domain.AccountClosure) (bool, error) {
+	done := r.begin(ctx, "close_account", closuresTable, "insert")
+	defer done()
+
+	data := model.AccountClosureFromEntity(closure)
+	closed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Customer{}).
+			Where("id = ? AND closed_at IS NULL", closure.CustomerID).
+			UpdateColumn("closed_at", closure.ClosedAt)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		closed = true
+		return tx.Create(&data).Error
+	})
+	if err != nil {
+		r.recordError(ctx, closuresTable, "insert", "Error closing account", err, zap.Uint64("customer_id", closure.CustomerID))
+		return false, err
+	}
+
+	closure.ID = data.ID
+	return closed, nil
+}
+
+// IsClosed implements AccountClosureRepository.
+func (r *closureRepository) IsClosed(ctx context.Context, customerID uint64) (bool, error) {
+	done := r.begin(ctx, "is_account_closed", customersTable, "select")
+	defer done()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Customer{}).
+		Where("id = ? AND closed_at IS NOT NULL", customerID).
+		Count(&count).Error
+	if err != nil {
+		r.recordError(ctx, customersTable, "select", "Error checking account closure", err, zap.Uint64("customer_id", customerID))
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// FindDue implements AccountClosureRepository.
+func (r *closureRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.AccountClosure, error) {
+	done := r.begin(ctx, "find_due_account_closures", closuresTable, "select")
+	defer done()
+
+	var closures []model.AccountClosure
+	err := r.db.WithContext(ctx).
+		Where("anonymized_at IS NULL AND anonymize_after <= ?", now).
+		Order("anonymize_after ASC, id ASC").
+		Limit(limit).
+		Find(&closures).Error
+	if err != nil {
+		r.recordError(ctx, closuresTable, "select", "Error finding due account closures", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(closures)),
+		metric.WithAttributes(
+			attribute.String("table", closuresTable),
+		),
+	)
+
+	return model.AccountClosuresToEntity(closures), nil
+}
+
+// Anonymize implements AccountClosureRepository.
+func (r *closureRepository) Anonymize(ctx context.Context, closure domain.AccountClosure, at time.Time) (bool, error) {
+	done := r.begin(ctx, "anonymize_customer", customersTable, "update")
+	defer done()
+
+	anonymized := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.AccountClosure{}).
+			Where("id = ? AND anonymized_at IS NULL", closure.ID).
+			UpdateColumn("anonymized_at", at)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		anonymized = true
+
+		// Tahun lahir dan gaji dipertahankan untuk statistik portofolio
+		return tx.Model(&model.Customer{}).
+			Where("id = ? AND closed_at IS NOT NULL", closure.CustomerID).
+			Updates(map[string]any{
+				"nik":                    AnonymizedNIK(closure.CustomerID),
+				"full_name":              AnonymizedName,
+				"legal_name":             AnonymizedName,
+				"password":               "",
+				"birth_place":            "",
+				"birth_date":             gorm.Expr("MAKEDATE(YEAR(birth_date), 1)"),
+				"ktp_photo_url":          "",
+				"selfie_photo_url":       "",
+				"email":                  nil,
+				"phone":                  nil,
+				"registration_ip":        "",
+				"registration_device_id": "",
+			}).Error
+	})
+	if err != nil {
+		r.recordError(ctx, customersTable, "update", "Error anonymizing customer", err, zap.Uint64("customer_id", closure.CustomerID))
+		return false, err
+	}
+
+	if anonymized {
+		r.documentsUpdated.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("table", customersTable),
+			),
+		)
+	}
+
+	return anonymized, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *closureRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *closureRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewAccountClosureRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.AccountClosureRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsUpdated, _ := meter.Int64Counter(
+		"db.documents.updated",
+		metric.WithDescription("Number of documents updated in the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &closureRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsUpdated:   documentsUpdated,
+	}
+}
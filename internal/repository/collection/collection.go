@@ -0,0 +1,435 @@
+package collectionrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	casesTable        = "collection_cases"
+	activitiesTable   = "collection_activities"
+	transactionsTable = "transactions"
+)
+
+var errCaseUnavailable = errors.New("collection case missing or closed")
+
+type collectionRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsUpdated   metric.Int64Counter
+}
+
+// FindExposures implements CollectionRepository.
+func (r *collectionRepository) FindExposures(ctx context.Context, afterID uint64, limit int) ([]domain.AgingExposure, error) {
+	done := r.begin(ctx, "find_collection_exposures", transactionsTable, "select")
+	defer done()
+
+	// Kontrak yang sudah punya kasus aktif tidak perlu dinilai ulang
+	var rows []domain.AgingExposure
+	err := r.db.WithContext(ctx).
+		Table("transactions AS t").
+		Select(`t.id AS transaction_id, t.customer_id, tn.duration_months AS tenor_months, t.transaction_date,
+			t.paid_installments, t.total_installment_amount, t.fx_rate, t.region_code`).
+		Joins("JOIN tenors AS tn ON tn.id = t.tenor_id").
+		Where("t.id > ? AND t.status = ? AND t.is_sandbox = ?", afterID, model.TransactionActive, false).
+		Where("NOT EXISTS (SELECT 1 FROM collection_cases AS cc WHERE cc.transaction_id = t.id AND cc.status IN ?)", activeStatuses()).
+		Order("t.id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select", "Error loading collection exposures", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	return rows, nil
+}
+
+// CreateCase implements CollectionRepository.
+func (r *collectionRepository) CreateCase(ctx context.Context, collectionCase *domain.CollectionCase) error {
+	done := r.begin(ctx, "create_collection_case", casesTable, "insert")
+	defer done()
+
+	if collectionCase.Status == "" {
+		collectionCase.Status = domain.CollectionOpen
+	}
+
+	data := model.CollectionCaseFromEntity(collectionCase)
+	if err := r.db.WithContext(ctx).Omit("Customer", "Transaction", "Collector").Create(&data).Error; err != nil {
+		r.recordError(ctx, casesTable, "insert", "Error creating collection case", err,
+			zap.Uint64("transaction_id", collectionCase.TransactionID),
+		)
+		return err
+	}
+
+	collectionCase.ID = data.ID
+	collectionCase.CreatedAt = data.CreatedAt
+	collectionCase.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// FindCaseByID implements CollectionRepository.
+func (r *collectionRepository) FindCaseByID(ctx context.Context, id uint64) (*domain.CollectionCase, error) {
+	done := r.begin(ctx, "find_collection_case_by_id", casesTable, "select")
+	defer done()
+
+	var collectionCase model.CollectionCase
+	err := r.withRelations(ctx).First(&collectionCase, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.recordError(ctx, casesTable, "select", "Error finding collection case", err, zap.Uint64("collection_case_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", casesTable),
+		),
+	)
+
+	return model.CollectionCaseToEntity(collectionCase), nil
+}
+
+// FindCasesPaginated implements CollectionRepository.
+func (r *collectionRepository) FindCasesPaginated(ctx context.Context, params domain.Params) ([]domain.CollectionCase, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find collection cases paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("collector_id", params.Value("collector_id")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_collection_cases", casesTable, "select_paginated")
+	defer done()
+
+	countQuery := params.Filter(r.db.WithContext(ctx).Model(&model.CollectionCase{}))
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, casesTable, "select_paginated", "Error counting collection cases", err)
+		return nil, 0, err
+	}
+
+	var cases []model.CollectionCase
+	if err := params.Paginate(params.Filter(r.withRelations(ctx))).Order("id DESC").Find(&cases).Error; err != nil {
+		r.recordError(ctx, casesTable, "select_paginated", "Error finding collection cases", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(cases)),
+		metric.WithAttributes(
+			attribute.String("table", casesTable),
+		),
+	)
+
+	return model.CollectionCasesToEntity(cases), total, nil
+}
+
+// UpdateCase implements CollectionRepository.
+func (r *collectionRepository) UpdateCase(ctx context.Context, collectionCase *domain.CollectionCase, from domain.CollectionCaseStatus) (bool, error) {
+	done := r.begin(ctx, "update_collection_case", casesTable, "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).Model(&model.CollectionCase{}).
+		Where("id = ? AND status = ?", collectionCase.ID, string(from)).
+		Updates(map[string]any{
+			"status":       string(collectionCase.Status),
+			"collector_id": collectionCase.CollectorID,
+			"closed_at":    collectionCase.ClosedAt,
+		})
+	if result.Error != nil {
+		r.recordError(ctx, casesTable, "update", "Error updating collection case", result.Error, zap.Uint64("collection_case_id", collectionCase.ID))
+		return false, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", casesTable),
+		),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// AddActivity implements CollectionRepository.
+func (r *collectionRepository) AddActivity(ctx context.Context, activity *domain.CollectionActivity) (bool, error) {
+	done := r.begin(ctx, "add_collection_activity", activitiesTable, "insert")
+	defer done()
+
+	// Kasus dikunci supaya aktivitas tidak tercatat pada kasus yang baru
+	// saja ditutup collector lain
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.CollectionCase
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, activity.CaseID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errCaseUnavailable
+			}
+			return err
+		}
+		if domain.CollectionCaseStatus(current.Status).Closed() {
+			return errCaseUnavailable
+		}
+
+		data := model.CollectionActivityFromEntity(activity)
+		if err := tx.Omit("Case").Create(&data).Error; err != nil {
+			return err
+		}
+		activity.ID = data.ID
+		activity.CreatedAt = data.CreatedAt
+
+		updates := map[string]any{"last_activity_at": data.CreatedAt}
+		// Aktivitas pertama menandai kasus mulai dikerjakan
+		if current.Status == string(domain.CollectionOpen) {
+			updates["status"] = string(domain.CollectionInProgress)
+		}
+		return tx.Model(&current).Updates(updates).Error
+	})
+	if errors.Is(err, errCaseUnavailable) {
+		return false, nil
+	}
+	if err != nil {
+		r.recordError(ctx, activitiesTable, "insert", "Error adding collection activity", err, zap.Uint64("collection_case_id", activity.CaseID))
+		return false, err
+	}
+
+	return true, nil
+}
+
+// FindActivities implements CollectionRepository.
+func (r *collectionRepository) FindActivities(ctx context.Context, caseID uint64) ([]domain.CollectionActivity, error) {
+	done := r.begin(ctx, "find_collection_activities", activitiesTable, "select")
+	defer done()
+
+	var activities []model.CollectionActivity
+	err := r.db.WithContext(ctx).
+		Where("case_id = ?", caseID).
+		Order("id DESC").
+		Find(&activities).Error
+	if err != nil {
+		r.recordError(ctx, activitiesTable, "select", "Error finding collection activities", err, zap.Uint64("collection_case_id", caseID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(activities)),
+		metric.WithAttributes(
+			attribute.String("table", activitiesTable),
+		),
+	)
+
+	return model.CollectionActivitiesToEntity(activities), nil
+}
+
+// Dashboard implements CollectionRepository.
+func (r *collectionRepository) Dashboard(ctx context.Context, today time.Time) (*domain.CollectionDashboard, error) {
+	done := r.begin(ctx, "collection_dashboard", casesTable, "aggregate")
+	defer done()
+
+	db := r.db.WithContext(ctx)
+	active := activeStatuses()
+
+	var statuses []struct {
+		Status string
+		Count  int64
+	}
+	if err := db.Model(&model.CollectionCase{}).Select("status, COUNT(*) AS count").Group("status").Scan(&statuses).Error; err != nil {
+		r.recordError(ctx, casesTable, "aggregate", "Error counting collection cases by status", err)
+		return nil, err
+	}
+
+	dashboard := &domain.CollectionDashboard{ByStatus: make(map[domain.CollectionCaseStatus]int64, len(statuses))}
+	for _, row := range statuses {
+		dashboard.ByStatus[domain.CollectionCaseStatus(row.Status)] = row.Count
+	}
+
+	if err := db.Model(&model.CollectionCase{}).
+		Where("collector_id IS NULL AND status IN ?", active).
+		Count(&dashboard.Unassigned).Error; err != nil {
+		r.recordError(ctx, casesTable, "aggregate", "Error counting unassigned collection cases", err)
+		return nil, err
+	}
+
+	var loads []struct {
+		CollectorID   uint64
+		CollectorName string
+		ActiveCases   int64
+		OverdueAmount decimal.Decimal
+	}
+	err := db.Table("collection_cases AS cc").
+		Select("cc.collector_id, cu.full_name AS collector_name, COUNT(*) AS active_cases, COALESCE(SUM(cc.overdue_amount), 0) AS overdue_amount").
+		Joins("JOIN customers AS cu ON cu.id = cc.collector_id").
+		Where("cc.status IN ?", active).
+		Group("cc.collector_id, cu.full_name").
+		Order("active_cases DESC, cc.collector_id ASC").
+		Scan(&loads).Error
+	if err != nil {
+		r.recordError(ctx, casesTable, "aggregate", "Error summarising collector loads", err)
+		return nil, err
+	}
+
+	// Hanya janji terakhir di setiap kasus yang dihitung, janji lama sudah
+	// digantikan janji yang lebih baru
+	var promises []struct {
+		CollectorID uint64
+		Pending     int64
+		Broken      int64
+	}
+	err = db.Table("collection_activities AS a").
+		Select(`cc.collector_id,
+			COALESCE(SUM(CASE WHEN a.promise_date >= ? THEN 1 ELSE 0 END), 0) AS pending,
+			COALESCE(SUM(CASE WHEN a.promise_date < ? THEN 1 ELSE 0 END), 0) AS broken`, today, today).
+		Joins("JOIN collection_cases AS cc ON cc.id = a.case_id").
+		Where("a.type = ? AND cc.status IN ? AND cc.collector_id IS NOT NULL", string(domain.CollectionPromiseToPay), active).
+		Where("a.id = (SELECT MAX(p.id) FROM collection_activities AS p WHERE p.case_id = a.case_id AND p.type = ?)", string(domain.CollectionPromiseToPay)).
+		Group("cc.collector_id").
+		Scan(&promises).Error
+	if err != nil {
+		r.recordError(ctx, activitiesTable, "aggregate", "Error counting promises to pay", err)
+		return nil, err
+	}
+
+	byCollector := make(map[uint64]int, len(loads))
+	dashboard.Collectors = make([]domain.CollectorLoad, len(loads))
+	for i, load := range loads {
+		byCollector[load.CollectorID] = i
+		dashboard.Collectors[i] = domain.CollectorLoad{
+			CollectorID:   load.CollectorID,
+			CollectorName: load.CollectorName,
+			ActiveCases:   load.ActiveCases,
+			OverdueAmount: load.OverdueAmount,
+		}
+	}
+	for _, promise := range promises {
+		if i, ok := byCollector[promise.CollectorID]; ok {
+			dashboard.Collectors[i].PendingPromises = promise.Pending
+			dashboard.Collectors[i].BrokenPromises = promise.Broken
+		}
+	}
+
+	return dashboard, nil
+}
+
+// withRelations loads the transaction and customer every case view shows.
+func (r *collectionRepository) withRelations(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&model.CollectionCase{}).Preload("Transaction").Preload("Customer")
+}
+
+func activeStatuses() []string {
+	statuses := make([]string, len(domain.CollectionActiveStatuses))
+	for i, status := range domain.CollectionActiveStatuses {
+		statuses[i] = string(status)
+	}
+	return statuses
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *collectionRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *collectionRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewCollectionRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.CollectionRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsUpdated, _ := meter.Int64Counter(
+		"db.documents.updated",
+		metric.WithDescription("Number of documents updated in the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &collectionRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsUpdated:   documentsUpdated,
+	}
+}
@@ -0,0 +1,171 @@
+package communicationrepo
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type communicationRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements CommunicationRepository.
+func (r *communicationRepository) Create(ctx context.Context, communication *domain.Communication) error {
+	done := r.begin(ctx, "create_communication", "insert")
+	defer done()
+
+	data := model.CommunicationFromEntity(communication)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error creating communication", err, zap.Uint64("customer_id", communication.CustomerID))
+		return err
+	}
+
+	communication.ID = data.ID
+	communication.CreatedAt = data.CreatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "communications"),
+		),
+	)
+
+	return nil
+}
+
+// FindByCustomer implements CommunicationRepository.
+func (r *communicationRepository) FindByCustomer(ctx context.Context, customerID uint64, params domain.Params) ([]domain.Communication, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find communications by customer",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_communications_by_customer", "select_paginated")
+	defer done()
+
+	query := params.Filter(r.db.WithContext(ctx).Model(&model.Communication{}).Where("customer_id = ?", customerID))
+	countQuery := params.Filter(r.db.WithContext(ctx).Model(&model.Communication{}).Where("customer_id = ?", customerID))
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error counting communications", err, zap.Uint64("customer_id", customerID))
+		return nil, 0, err
+	}
+
+	var communications []model.Communication
+	if err := params.Paginate(query).Order("created_at DESC, id DESC").Find(&communications).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error finding communications", err, zap.Uint64("customer_id", customerID))
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(communications)),
+		metric.WithAttributes(
+			attribute.String("table", "communications"),
+		),
+	)
+
+	return model.CommunicationsToEntity(communications), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *communicationRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "communications"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "communications"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *communicationRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "communications"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewCommunicationRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.CommunicationRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &communicationRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
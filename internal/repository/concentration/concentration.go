@@ -0,0 +1,369 @@
+package concentrationrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	concentrationLimitsTable = "concentration_limits"
+	transactionsTable        = "transactions"
+)
+
+// activePrincipal sama dengan ringkasan exposure: pokok per kontrak yang
+// sudah dibulatkan ke IDR
+const activePrincipal = "COALESCE(SUM(ROUND((otr_amount + admin_fee) * fx_rate, 2)), 0)"
+
+// dimensionColumns memetakan dimensi ke kolom transaksi yang dikelompokkan
+var dimensionColumns = map[domain.ConcentrationDimension]string{
+	domain.ConcentrationAssetCategory: "asset_category",
+	domain.ConcentrationRegion:        "region_code",
+}
+
+// limitColumns ditulis ulang saat update, BreachedAt hanya diubah oleh job evaluasi
+var limitColumns = []string{"max_percent", "enforce", "updated_by", "updated_at"}
+
+type concentrationRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindLimits implements ConcentrationRepository.
+func (r *concentrationRepository) FindLimits(ctx context.Context) ([]domain.ConcentrationLimit, error) {
+	done := r.begin(ctx, "find_concentration_limits", concentrationLimitsTable, "select")
+	defer done()
+
+	var limits []model.ConcentrationLimit
+	if err := r.db.WithContext(ctx).Order("dimension ASC, value ASC").Find(&limits).Error; err != nil {
+		r.recordError(ctx, concentrationLimitsTable, "select", "Error finding concentration limits", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(limits)),
+		metric.WithAttributes(
+			attribute.String("table", concentrationLimitsTable),
+		),
+	)
+
+	return model.ConcentrationLimitsToEntity(limits), nil
+}
+
+// FindLimitByID implements ConcentrationRepository.
+func (r *concentrationRepository) FindLimitByID(ctx context.Context, id uint64) (*domain.ConcentrationLimit, error) {
+	done := r.begin(ctx, "find_concentration_limit", concentrationLimitsTable, "select")
+	defer done()
+
+	var limit model.ConcentrationLimit
+	if err := r.db.WithContext(ctx).First(&limit, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, concentrationLimitsTable, "select", "Error finding concentration limit", err, zap.Uint64("limit_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", concentrationLimitsTable),
+		),
+	)
+
+	return model.ConcentrationLimitToEntity(limit), nil
+}
+
+// FindLimit implements ConcentrationRepository.
+func (r *concentrationRepository) FindLimit(ctx context.Context, dimension domain.ConcentrationDimension, value string) (*domain.ConcentrationLimit, error) {
+	done := r.begin(ctx, "find_concentration_limit_by_value", concentrationLimitsTable, "select")
+	defer done()
+
+	var limit model.ConcentrationLimit
+	err := r.db.WithContext(ctx).
+		Where("dimension = ? AND value = ?", string(dimension), value).
+		First(&limit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, concentrationLimitsTable, "select", "Error finding concentration limit", err,
+			zap.String("dimension", string(dimension)),
+			zap.String("value", value),
+		)
+		return nil, err
+	}
+
+	return model.ConcentrationLimitToEntity(limit), nil
+}
+
+// CreateLimit implements ConcentrationRepository.
+func (r *concentrationRepository) CreateLimit(ctx context.Context, limit *domain.ConcentrationLimit) error {
+	done := r.begin(ctx, "create_concentration_limit", concentrationLimitsTable, "insert")
+	defer done()
+
+	data := model.ConcentrationLimitFromEntity(limit)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok && errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+			err = fmt.Errorf("%w: %v", gorm.ErrDuplicatedKey, err)
+		}
+		r.recordError(ctx, concentrationLimitsTable, "insert", "Error creating concentration limit", err,
+			zap.String("dimension", string(limit.Dimension)),
+			zap.String("value", limit.Value),
+		)
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", concentrationLimitsTable),
+		),
+	)
+
+	limit.ID = data.ID
+	limit.CreatedAt = data.CreatedAt
+	limit.UpdatedAt = data.UpdatedAt
+	return nil
+}
+
+// UpdateLimit implements ConcentrationRepository.
+func (r *concentrationRepository) UpdateLimit(ctx context.Context, limit *domain.ConcentrationLimit) error {
+	done := r.begin(ctx, "update_concentration_limit", concentrationLimitsTable, "update")
+	defer done()
+
+	data := model.ConcentrationLimitFromEntity(limit)
+	data.UpdatedAt = time.Now()
+	err := r.db.WithContext(ctx).Model(&model.ConcentrationLimit{ID: limit.ID}).
+		Select(limitColumns).
+		Updates(&data).Error
+	if err != nil {
+		r.recordError(ctx, concentrationLimitsTable, "update", "Error updating concentration limit", err, zap.Uint64("limit_id", limit.ID))
+		return err
+	}
+
+	limit.UpdatedAt = data.UpdatedAt
+	return nil
+}
+
+// DeleteLimit implements ConcentrationRepository.
+func (r *concentrationRepository) DeleteLimit(ctx context.Context, id uint64) (bool, error) {
+	done := r.begin(ctx, "delete_concentration_limit", concentrationLimitsTable, "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).Delete(&model.ConcentrationLimit{}, id)
+	if result.Error != nil {
+		r.recordError(ctx, concentrationLimitsTable, "delete", "Error deleting concentration limit", result.Error, zap.Uint64("limit_id", id))
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// SetBreachedAt implements ConcentrationRepository.
+func (r *concentrationRepository) SetBreachedAt(ctx context.Context, id uint64, breachedAt *time.Time) error {
+	done := r.begin(ctx, "set_concentration_limit_breached_at", concentrationLimitsTable, "update")
+	defer done()
+
+	// UpdateColumn agar updated_at tetap menunjukkan perubahan terakhir oleh admin
+	err := r.db.WithContext(ctx).Model(&model.ConcentrationLimit{ID: id}).
+		UpdateColumn("breached_at", breachedAt).Error
+	if err != nil {
+		r.recordError(ctx, concentrationLimitsTable, "update", "Error updating concentration limit breach", err, zap.Uint64("limit_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// TotalPrincipal implements ConcentrationRepository.
+func (r *concentrationRepository) TotalPrincipal(ctx context.Context) (decimal.Decimal, error) {
+	done := r.begin(ctx, "sum_portfolio_principal", transactionsTable, "select_sum")
+	defer done()
+
+	var total decimal.Decimal
+	err := r.activeTransactions(ctx).
+		Select(activePrincipal).
+		Row().
+		Scan(&total)
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select_sum", "Error summing portfolio principal", err)
+		return decimal.Zero, err
+	}
+
+	return total, nil
+}
+
+// SumPrincipal implements ConcentrationRepository.
+func (r *concentrationRepository) SumPrincipal(ctx context.Context, dimension domain.ConcentrationDimension) ([]domain.Concentration, error) {
+	done := r.begin(ctx, "sum_concentration_principal", transactionsTable, "select_sum")
+	defer done()
+
+	column, ok := dimensionColumns[dimension]
+	if !ok {
+		err := fmt.Errorf("unknown concentration dimension %q", dimension)
+		r.recordError(ctx, transactionsTable, "select_sum", "Error summing concentration principal", err)
+		return nil, err
+	}
+
+	var rows []struct {
+		Value     string
+		Principal decimal.Decimal
+	}
+	err := r.activeTransactions(ctx).
+		Select(column + " AS value, " + activePrincipal + " AS principal").
+		Group(column).
+		Order("principal DESC, value ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select_sum", "Error summing concentration principal", err, zap.String("dimension", string(dimension)))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	concentrations := make([]domain.Concentration, len(rows))
+	for i, row := range rows {
+		concentrations[i] = domain.Concentration{Dimension: dimension, Value: row.Value, Principal: row.Principal}
+	}
+	return concentrations, nil
+}
+
+// SumPrincipalFor implements ConcentrationRepository.
+func (r *concentrationRepository) SumPrincipalFor(ctx context.Context, dimension domain.ConcentrationDimension, value string) (decimal.Decimal, error) {
+	done := r.begin(ctx, "sum_concentration_principal_for", transactionsTable, "select_sum")
+	defer done()
+
+	column, ok := dimensionColumns[dimension]
+	if !ok {
+		err := fmt.Errorf("unknown concentration dimension %q", dimension)
+		r.recordError(ctx, transactionsTable, "select_sum", "Error summing concentration principal", err)
+		return decimal.Zero, err
+	}
+
+	var principal decimal.Decimal
+	err := r.activeTransactions(ctx).
+		Where(column+" = ?", value).
+		Select(activePrincipal).
+		Row().
+		Scan(&principal)
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select_sum", "Error summing concentration principal", err,
+			zap.String("dimension", string(dimension)),
+			zap.String("value", value),
+		)
+		return decimal.Zero, err
+	}
+
+	return principal, nil
+}
+
+func (r *concentrationRepository) activeTransactions(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("status = ? AND is_sandbox = ?", model.TransactionActive, false)
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *concentrationRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *concentrationRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewConcentrationRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.ConcentrationRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &concentrationRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
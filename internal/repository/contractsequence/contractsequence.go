@@ -0,0 +1,157 @@
+package contractsequencerepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const contractSequencesTable = "contract_sequences"
+
+type contractSequenceRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Next implements ContractSequenceRepository. The upsert takes the row lock
+// before the value is read back, so concurrent callers for the same region
+// and day wait for each other instead of reading the same number.
+func (r *contractSequenceRepository) Next(ctx context.Context, sandbox bool, regionCode, day string) (uint64, error) {
+	done := r.begin(ctx, "next_contract_sequence", contractSequencesTable, "upsert")
+	defer done()
+
+	data := model.ContractSequence{
+		Sandbox:      sandbox,
+		RegionCode:   regionCode,
+		SequenceDate: day,
+		LastValue:    1,
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "sandbox"}, {Name: "region_code"}, {Name: "sequence_date"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"last_value": gorm.Expr("last_value + 1"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, contractSequencesTable, "upsert", "Error incrementing contract sequence", err, zap.String("region_code", regionCode), zap.String("day", day))
+		return 0, err
+	}
+
+	var current model.ContractSequence
+	err = r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sandbox = ? AND region_code = ? AND sequence_date = ?", sandbox, regionCode, day).
+		First(&current).Error
+	if err != nil {
+		r.recordError(ctx, contractSequencesTable, "select", "Error reading contract sequence", err, zap.String("region_code", regionCode), zap.String("day", day))
+		return 0, err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", contractSequencesTable),
+		),
+	)
+
+	return current.LastValue, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *contractSequenceRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *contractSequenceRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewContractSequenceRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.ContractSequenceRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &contractSequenceRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -4,14 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
+	"strings"
 
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
 
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
@@ -23,9 +24,7 @@ import (
 type customerRepository struct {
 	db                 *gorm.DB
 	meter              metric.Meter
-	tracer             trace.Tracer
 	log                *zap.Logger
-	queryDuration      metric.Float64Histogram
 	queryCount         metric.Int64Counter
 	errorCount         metric.Int64Counter
 	connectionGauge    metric.Int64UpDownCounter
@@ -35,10 +34,7 @@ type customerRepository struct {
 
 // FindByNIKWithLock implements CustomerRepository.
 func (c *customerRepository) FindByNIKWithLock(ctx context.Context, nik string) (*domain.Customer, error) {
-	ctx, span := c.tracer.Start(ctx, "repository.FindByNIKWithLock")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	c.log.Debug("Find customer by NIK with lock",
 		zap.String("nik", nik),
@@ -65,41 +61,20 @@ func (c *customerRepository) FindByNIKWithLock(ctx context.Context, nik string)
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select_for_update"),
-		attribute.String("db.table", "customers"),
-		attribute.String("customer.nik", nik),
-		attribute.String("trace_id", span.SpanContext().TraceID().String()),
-	)
-
 	var customer model.Customer
 
 	// Menggunakan Clauses(clause.Locking{Strength: "UPDATE"}) untuk SELECT ... FOR UPDATE
 	err := c.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("nik = ?", nik).First(&customer).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			span.SetStatus(codes.Ok, "Customer not found")
-
 			c.log.Info("Customer not found by NIK",
 				zap.String("nik", nik),
 				zap.String("trace_id", span.SpanContext().TraceID().String()),
 			)
 
-			duration := float64(time.Since(start).Milliseconds())
-			c.queryDuration.Record(ctx, duration,
-				metric.WithAttributes(
-					attribute.String("operation", "select_for_update"),
-					attribute.String("table", "customers"),
-					attribute.String("status", "not_found"),
-				),
-			)
-
 			return nil, nil
 		}
 
-		span.SetStatus(codes.Error, "Error finding customer by NIK with lock")
-		span.RecordError(err)
-
 		c.log.Error("Error finding customer by NIK with lock",
 			zap.String("nik", nik),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -114,15 +89,6 @@ func (c *customerRepository) FindByNIKWithLock(ctx context.Context, nik string)
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		c.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select_for_update"),
-				attribute.String("table", "customers"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, err
 	}
 
@@ -132,15 +98,6 @@ func (c *customerRepository) FindByNIKWithLock(ctx context.Context, nik string)
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	c.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "select_for_update"),
-			attribute.String("table", "customers"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	c.log.Info("Customer found by NIK with lock",
 		zap.String("nik", nik),
 		zap.Uint64("customer_id", customer.ID),
@@ -148,20 +105,12 @@ func (c *customerRepository) FindByNIKWithLock(ctx context.Context, nik string)
 		zap.String("span_id", span.SpanContext().SpanID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Customer found by NIK with lock")
-	span.SetAttributes(
-		attribute.String("customer.id", fmt.Sprintf("%d", customer.ID)),
-	)
-
 	return model.CustomerToEntity(customer), nil
 }
 
 // FindByID implements CustomerRepository.
 func (c *customerRepository) FindByID(ctx context.Context, id uint64) (*domain.Customer, error) {
-	ctx, span := c.tracer.Start(ctx, "repository.FindByID")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	c.log.Debug("Find customer by ID",
 		zap.Uint64("id", id),
@@ -188,38 +137,17 @@ func (c *customerRepository) FindByID(ctx context.Context, id uint64) (*domain.C
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select"),
-		attribute.String("db.table", "customers"),
-		attribute.String("customer.id", fmt.Sprintf("%d", id)),
-		attribute.String("trace_id", span.SpanContext().TraceID().String()),
-	)
-
 	var customer model.Customer
 	if err := c.db.WithContext(ctx).First(&customer, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			span.SetStatus(codes.Ok, "Customer not found")
-
 			c.log.Info("Customer not found by ID",
 				zap.Uint64("id", id),
 				zap.String("trace_id", span.SpanContext().TraceID().String()),
 			)
 
-			duration := float64(time.Since(start).Milliseconds())
-			c.queryDuration.Record(ctx, duration,
-				metric.WithAttributes(
-					attribute.String("operation", "select"),
-					attribute.String("table", "customers"),
-					attribute.String("status", "not_found"),
-				),
-			)
-
 			return nil, nil
 		}
 
-		span.SetStatus(codes.Error, "Error finding customer by ID")
-		span.RecordError(err)
-
 		c.log.Error("Error finding customer by ID",
 			zap.Uint64("id", id),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -234,15 +162,6 @@ func (c *customerRepository) FindByID(ctx context.Context, id uint64) (*domain.C
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		c.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select"),
-				attribute.String("table", "customers"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, err
 	}
 
@@ -252,15 +171,6 @@ func (c *customerRepository) FindByID(ctx context.Context, id uint64) (*domain.C
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	c.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "select"),
-			attribute.String("table", "customers"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	c.log.Info("Customer found by ID",
 		zap.Uint64("id", id),
 		zap.String("nik", customer.NIK),
@@ -268,20 +178,12 @@ func (c *customerRepository) FindByID(ctx context.Context, id uint64) (*domain.C
 		zap.String("span_id", span.SpanContext().SpanID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Customer found by ID")
-	span.SetAttributes(
-		attribute.String("customer.nik", customer.NIK),
-	)
-
 	return model.CustomerToEntity(customer), nil
 }
 
 // FindByNIK implements CustomerRepository.
 func (c *customerRepository) FindByNIK(ctx context.Context, nik string) (*domain.Customer, error) {
-	ctx, span := c.tracer.Start(ctx, "repository.FindByNIK")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	c.log.Debug("Find customer by NIK",
 		zap.String("nik", nik),
@@ -308,39 +210,18 @@ func (c *customerRepository) FindByNIK(ctx context.Context, nik string) (*domain
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select"),
-		attribute.String("db.table", "customers"),
-		attribute.String("customer.nik", nik),
-		attribute.String("trace_id", span.SpanContext().TraceID().String()),
-	)
-
 	var customer model.Customer
 
 	if err := c.db.WithContext(ctx).Where("nik = ?", nik).First(&customer).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			span.SetStatus(codes.Ok, "Customer not found")
-
 			c.log.Info("Customer not found by NIK",
 				zap.String("nik", nik),
 				zap.String("trace_id", span.SpanContext().TraceID().String()),
 			)
 
-			duration := float64(time.Since(start).Milliseconds())
-			c.queryDuration.Record(ctx, duration,
-				metric.WithAttributes(
-					attribute.String("operation", "select"),
-					attribute.String("table", "customers"),
-					attribute.String("status", "not_found"),
-				),
-			)
-
 			return nil, nil
 		}
 
-		span.SetStatus(codes.Error, "Error finding customer by NIK")
-		span.RecordError(err)
-
 		c.log.Error("Error finding customer by NIK",
 			zap.String("nik", nik),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -355,15 +236,6 @@ func (c *customerRepository) FindByNIK(ctx context.Context, nik string) (*domain
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		c.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select"),
-				attribute.String("table", "customers"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, err
 	}
 
@@ -373,15 +245,6 @@ func (c *customerRepository) FindByNIK(ctx context.Context, nik string) (*domain
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	c.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "select"),
-			attribute.String("table", "customers"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	c.log.Info("Customer found by NIK",
 		zap.String("nik", nik),
 		zap.Uint64("customer_id", customer.ID),
@@ -389,9 +252,68 @@ func (c *customerRepository) FindByNIK(ctx context.Context, nik string) (*domain
 		zap.String("span_id", span.SpanContext().SpanID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Customer found by NIK")
-	span.SetAttributes(
-		attribute.String("customer.id", fmt.Sprintf("%d", customer.ID)),
+	return model.CustomerToEntity(customer), nil
+}
+
+// FindByContact implements CustomerRepository.
+func (c *customerRepository) FindByContact(ctx context.Context, email, phone string) (*domain.Customer, error) {
+	span := trace.SpanFromContext(ctx)
+
+	c.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_by_contact"),
+			attribute.String("table", "customers"),
+		),
+	)
+	defer c.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_by_contact"),
+			attribute.String("table", "customers"),
+		),
+	)
+
+	c.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select"),
+			attribute.String("table", "customers"),
+		),
+	)
+
+	// Nilai kosong tidak dicari, kolom kontak NULL untuk customer lama
+	query := c.db.WithContext(ctx).Where("1 = 0")
+	if email != "" {
+		query = query.Or("email = ?", email)
+	}
+	if phone != "" {
+		query = query.Or("phone = ?", phone)
+	}
+
+	var customer model.Customer
+	if err := query.First(&customer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		c.log.Error("Error finding customer by contact",
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		c.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select"),
+				attribute.String("table", "customers"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return nil, err
+	}
+
+	c.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
 	)
 
 	return model.CustomerToEntity(customer), nil
@@ -399,15 +321,12 @@ func (c *customerRepository) FindByNIK(ctx context.Context, nik string) (*domain
 
 // FindPaginated implements CustomerRepository.
 func (c *customerRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.Customer, int64, error) {
-	ctx, span := c.tracer.Start(ctx, "repository.FindPaginated")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	c.log.Debug("Find customers paginated",
 		zap.Int("page", params.Page),
 		zap.Int("limit", params.Limit),
-		zap.String("status", params.Status),
+		zap.String("status", params.Value("verification_status")),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
@@ -431,15 +350,6 @@ func (c *customerRepository) FindPaginated(ctx context.Context, params domain.Pa
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select_paginated"),
-		attribute.String("db.table", "customers"),
-		attribute.Int("pagination.page", params.Page),
-		attribute.Int("pagination.limit", params.Limit),
-		attribute.String("filter.status", params.Status),
-		attribute.String("trace_id", span.SpanContext().TraceID().String()),
-	)
-
 	var customers []model.Customer
 	var total int64
 
@@ -447,18 +357,13 @@ func (c *customerRepository) FindPaginated(ctx context.Context, params domain.Pa
 	countQuery := c.db.WithContext(ctx).Model(&model.Customer{})
 
 	// Filter berdasarkan status
-	if params.Status != "" {
-		query = query.Where("verification_status = ?", params.Status)
-		countQuery = countQuery.Where("verification_status = ?", params.Status)
-	}
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
 
 	// Hitung total sebelum paginasi
 	if err := countQuery.Count(&total).Error; err != nil {
-		span.SetStatus(codes.Error, "Error counting customers")
-		span.RecordError(err)
-
 		c.log.Error("Error counting customers",
-			zap.String("status", params.Status),
+			zap.String("status", params.Value("verification_status")),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 			zap.Error(err),
 		)
@@ -471,30 +376,17 @@ func (c *customerRepository) FindPaginated(ctx context.Context, params domain.Pa
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		c.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select_paginated"),
-				attribute.String("table", "customers"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, 0, err
 	}
 
 	// Terapkan paginasi
-	offset := (params.Page - 1) * params.Limit
-	query = query.Limit(params.Limit).Offset(offset).Order("created_at DESC")
+	query = params.Paginate(query).Order("created_at DESC")
 
 	if err := query.Find(&customers).Error; err != nil {
-		span.SetStatus(codes.Error, "Error finding customers paginated")
-		span.RecordError(err)
-
 		c.log.Error("Error finding customers paginated",
 			zap.Int("page", params.Page),
 			zap.Int("limit", params.Limit),
-			zap.String("status", params.Status),
+			zap.String("status", params.Value("verification_status")),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 			zap.Error(err),
 		)
@@ -507,15 +399,6 @@ func (c *customerRepository) FindPaginated(ctx context.Context, params domain.Pa
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		c.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select_paginated"),
-				attribute.String("table", "customers"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, 0, err
 	}
 
@@ -525,41 +408,149 @@ func (c *customerRepository) FindPaginated(ctx context.Context, params domain.Pa
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	c.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "select_paginated"),
-			attribute.String("table", "customers"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	c.log.Info("Customers found paginated",
 		zap.Int("page", params.Page),
 		zap.Int("limit", params.Limit),
-		zap.String("status", params.Status),
+		zap.String("status", params.Value("verification_status")),
 		zap.Int64("total", total),
 		zap.Int("retrieved", len(customers)),
-		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 		zap.String("span_id", span.SpanContext().SpanID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Customers found paginated")
-	span.SetAttributes(
-		attribute.Int64("result.total", total),
-		attribute.Int("result.retrieved", len(customers)),
+	return model.CustomersToEntity(customers), total, nil
+}
+
+// StreamForExport implements CustomerRepository.
+func (c *customerRepository) StreamForExport(ctx context.Context, status domain.VerificationStatus, fn func(domain.Customer) error) error {
+	span := trace.SpanFromContext(ctx)
+
+	c.log.Debug("Stream customers for export",
+		zap.String("status", string(status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	c.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "stream_customers"),
+			attribute.String("table", "customers"),
+		),
+	)
+	defer c.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "stream_customers"),
+			attribute.String("table", "customers"),
+		),
 	)
 
-	return model.CustomersToEntity(customers), total, nil
+	c.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select_stream"),
+			attribute.String("table", "customers"),
+		),
+	)
+
+	// Cursor dibaca selama ekspor berlangsung, jauh melewati batas waktu
+	// satu query; berhenti saat fn gagal atau context dibatalkan
+	query := c.db.WithContext(mysqldb.WithoutQueryTimeout(ctx)).Model(&model.Customer{})
+	query = query.Where("role = ?", model.CustomerRole)
+	if status != "" {
+		query = query.Where("verification_status = ?", status)
+	}
+	rows, err := query.Order("id").Rows()
+	if err == nil {
+		defer rows.Close()
+
+		var streamed int64
+		for err == nil && rows.Next() {
+			var data model.Customer
+			if err = c.db.ScanRows(rows, &data); err == nil {
+				err = fn(*model.CustomerToEntity(data))
+				streamed++
+			}
+		}
+		if err == nil {
+			err = rows.Err()
+		}
+
+		c.documentsRetrieved.Add(ctx, streamed,
+			metric.WithAttributes(
+				attribute.String("table", "customers"),
+			),
+		)
+	}
+	if err != nil {
+		c.log.Error("Error streaming customers",
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		c.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select_stream"),
+				attribute.String("table", "customers"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// CountByStatus implements CustomerRepository.
+func (c *customerRepository) CountByStatus(ctx context.Context, status domain.VerificationStatus) (int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	c.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "count_customers_by_status"),
+			attribute.String("table", "customers"),
+		),
+	)
+	defer c.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "count_customers_by_status"),
+			attribute.String("table", "customers"),
+		),
+	)
+
+	c.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "count"),
+			attribute.String("table", "customers"),
+		),
+	)
+
+	var total int64
+	err := c.db.WithContext(ctx).Model(&model.Customer{}).
+		Where("role = ? AND verification_status = ?", model.CustomerRole, status).
+		Count(&total).Error
+	if err != nil {
+		c.log.Error("Error counting customers",
+			zap.String("status", string(status)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		c.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "count"),
+				attribute.String("table", "customers"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return 0, err
+	}
+
+	return total, nil
 }
 
 // CreateCustomer implements CustomerRepository.
 func (c *customerRepository) CreateCustomer(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
-	ctx, span := c.tracer.Start(ctx, "repository.CreateCustomer")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	c.log.Debug("Create customer",
 		zap.String("nik", customer.NIK),
@@ -586,17 +577,9 @@ func (c *customerRepository) CreateCustomer(ctx context.Context, customer *domai
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "insert"),
-		attribute.String("db.table", "customers"),
-		attribute.String("customer.nik", customer.NIK),
-		attribute.String("trace_id", span.SpanContext().TraceID().String()),
-	)
-
 	data := model.CustomerFromEntity(customer)
 	if err := c.db.WithContext(ctx).Create(&data).Error; err != nil {
-		span.SetStatus(codes.Error, "Error creating customer")
-		span.RecordError(err)
+		err = duplicateCustomerError(err)
 
 		c.log.Error("Error creating customer",
 			zap.String("nik", customer.NIK),
@@ -612,15 +595,6 @@ func (c *customerRepository) CreateCustomer(ctx context.Context, customer *domai
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		c.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "insert"),
-				attribute.String("table", "customers"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, err
 	}
 
@@ -630,43 +604,42 @@ func (c *customerRepository) CreateCustomer(ctx context.Context, customer *domai
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	c.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "insert"),
-			attribute.String("table", "customers"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	c.log.Info("Customer created successfully",
 		zap.String("nik", customer.NIK),
 		zap.Uint64("customer_id", data.ID),
-		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 		zap.String("span_id", span.SpanContext().SpanID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Customer created successfully")
-	span.SetAttributes(
-		attribute.String("customer.id", fmt.Sprintf("%d", data.ID)),
-	)
-
 	return model.CustomerToEntity(data), nil
 }
 
+// duplicateCustomerError maps a unique key violation on customers to the
+// error of the clashing column. The index is what keeps two concurrent
+// registrations of the same NIK apart; the service's own lookup only gives
+// the friendlier answer when they do not overlap.
+func duplicateCustomerError(err error) error {
+	key, ok := mysqldb.DuplicateKey(err)
+	if !ok {
+		return err
+	}
+
+	switch {
+	case strings.HasSuffix(key, "nik"):
+		return fmt.Errorf("%w: %v", common.ErrNIKExists, err)
+	case strings.HasSuffix(key, "email"):
+		return fmt.Errorf("%w: %v", common.ErrEmailExists, err)
+	case strings.HasSuffix(key, "phone"):
+		return fmt.Errorf("%w: %v", common.ErrPhoneExists, err)
+	}
+	return fmt.Errorf("%w: %v", gorm.ErrDuplicatedKey, err)
+}
+
 func NewCustomerRepository(
 	db *gorm.DB,
 	meter metric.Meter,
-	tracer trace.Tracer,
 	log *zap.Logger,
 ) repository.CustomerRepository {
-	queryDuration, _ := meter.Float64Histogram(
-		"db.query.duration",
-		metric.WithDescription("Duration of database queries"),
-		metric.WithUnit("ms"),
-	)
-
 	queryCount, _ := meter.Int64Counter(
 		"db.query.count",
 		metric.WithDescription("Number of database queries"),
@@ -700,9 +673,7 @@ func NewCustomerRepository(
 	return &customerRepository{
 		db:                 db,
 		meter:              meter,
-		tracer:             tracer,
 		log:                log,
-		queryDuration:      queryDuration,
 		queryCount:         queryCount,
 		errorCount:         errorCount,
 		connectionGauge:    connectionGauge,
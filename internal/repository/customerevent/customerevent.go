@@ -0,0 +1,234 @@
+package customereventrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const eventsTable = "customer_events"
+
+type customerEventRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Append implements CustomerEventRepository.
+func (r *customerEventRepository) Append(ctx context.Context, event *domain.CustomerEvent) error {
+	done := r.begin(ctx, "append_customer_event", "insert")
+	defer done()
+
+	data := model.CustomerEventFromEntity(event)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error appending customer event", err,
+			zap.Uint64("customer_id", event.CustomerID),
+			zap.String("event_type", string(event.Type)),
+		)
+		return err
+	}
+
+	event.ID = data.ID
+	event.OccurredAt = data.OccurredAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", eventsTable),
+		),
+	)
+
+	return nil
+}
+
+// FindByCustomer implements CustomerEventRepository. Events come oldest
+// first unless params asks for another order.
+func (r *customerEventRepository) FindByCustomer(ctx context.Context, customerID uint64, params domain.Params) ([]domain.CustomerEvent, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find customer events by customer",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_customer_events_by_customer", "select_paginated")
+	defer done()
+
+	query := params.Filter(r.db.WithContext(ctx).Model(&model.CustomerEvent{}).Where("customer_id = ?", customerID))
+	countQuery := params.Filter(r.db.WithContext(ctx).Model(&model.CustomerEvent{}).Where("customer_id = ?", customerID))
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error counting customer events", err, zap.Uint64("customer_id", customerID))
+		return nil, 0, err
+	}
+
+	// id ikut diurutkan karena beberapa event bisa tercatat di detik yang sama
+	var events []model.CustomerEvent
+	if err := params.Paginate(query).Order("occurred_at ASC, id ASC").Find(&events).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error finding customer events", err, zap.Uint64("customer_id", customerID))
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(events)),
+		metric.WithAttributes(
+			attribute.String("table", eventsTable),
+		),
+	)
+
+	return model.CustomerEventsToEntity(events), total, nil
+}
+
+// FindAfter implements CustomerEventRepository.
+func (r *customerEventRepository) FindAfter(ctx context.Context, afterID uint64, types []domain.CustomerEventType, limit int) ([]domain.CustomerEvent, error) {
+	done := r.begin(ctx, "find_customer_events_after", "select")
+	defer done()
+
+	query := r.db.WithContext(ctx).Where("id > ?", afterID)
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	var events []model.CustomerEvent
+	if err := query.Order("id ASC").Limit(limit).Find(&events).Error; err != nil {
+		r.recordError(ctx, "select", "Error finding customer events after cursor", err, zap.Uint64("after_id", afterID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(events)),
+		metric.WithAttributes(
+			attribute.String("table", eventsTable),
+		),
+	)
+
+	return model.CustomerEventsToEntity(events), nil
+}
+
+// LatestID implements CustomerEventRepository.
+func (r *customerEventRepository) LatestID(ctx context.Context) (uint64, error) {
+	done := r.begin(ctx, "latest_customer_event_id", "select_max")
+	defer done()
+
+	var latest uint64
+	if err := r.db.WithContext(ctx).Model(&model.CustomerEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&latest).Error; err != nil {
+		r.recordError(ctx, "select_max", "Error finding latest customer event", err)
+		return 0, err
+	}
+
+	return latest, nil
+}
+
+// CountSince implements CustomerEventRepository.
+func (r *customerEventRepository) CountSince(ctx context.Context, eventType domain.CustomerEventType, since time.Time) (int64, error) {
+	done := r.begin(ctx, "count_customer_events_since", "count")
+	defer done()
+
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.CustomerEvent{}).
+		Where("type = ? AND occurred_at >= ?", eventType, since).
+		Count(&total).Error; err != nil {
+		r.recordError(ctx, "count", "Error counting customer events", err, zap.String("type", string(eventType)))
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *customerEventRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", eventsTable),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", eventsTable),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *customerEventRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", eventsTable),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewCustomerEventRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.CustomerEventRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &customerEventRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
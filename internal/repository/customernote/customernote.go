@@ -0,0 +1,268 @@
+package customernoterepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	notesTable     = "customer_notes"
+	revisionsTable = "customer_note_revisions"
+)
+
+var errNoteMissing = errors.New("customer note missing")
+
+type customerNoteRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements CustomerNoteRepository.
+func (r *customerNoteRepository) Create(ctx context.Context, note *domain.CustomerNote) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_customer_note", notesTable, "insert")
+	defer done()
+
+	data := model.CustomerNoteFromEntity(note)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, notesTable, "insert", "Error creating customer note", err, zap.Uint64("customer_id", note.CustomerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", notesTable),
+		),
+	)
+
+	r.log.Info("Customer note created",
+		zap.Uint64("note_id", data.ID),
+		zap.Uint64("customer_id", note.CustomerID),
+		zap.Uint64("author_id", note.AuthorID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	note.ID = data.ID
+	note.CreatedAt = data.CreatedAt
+	note.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// FindByID implements CustomerNoteRepository.
+func (r *customerNoteRepository) FindByID(ctx context.Context, id uint64) (*domain.CustomerNote, error) {
+	done := r.begin(ctx, "find_customer_note", notesTable, "select")
+	defer done()
+
+	var data model.CustomerNote
+	err := r.db.WithContext(ctx).
+		Preload("Revisions", func(db *gorm.DB) *gorm.DB { return db.Order("id DESC") }).
+		First(&data, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, notesTable, "select", "Error finding customer note", err, zap.Uint64("note_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", notesTable),
+		),
+	)
+
+	return model.CustomerNoteToEntity(data), nil
+}
+
+// FindByCustomer implements CustomerNoteRepository.
+func (r *customerNoteRepository) FindByCustomer(ctx context.Context, customerID uint64) ([]domain.CustomerNote, error) {
+	done := r.begin(ctx, "find_customer_notes", notesTable, "select")
+	defer done()
+
+	var data []model.CustomerNote
+	err := r.db.WithContext(ctx).
+		Preload("Revisions", func(db *gorm.DB) *gorm.DB { return db.Order("id DESC") }).
+		Where("customer_id = ?", customerID).
+		Order("pinned DESC, created_at DESC, id DESC").
+		Find(&data).Error
+	if err != nil {
+		r.recordError(ctx, notesTable, "select", "Error finding customer notes", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(data)),
+		metric.WithAttributes(
+			attribute.String("table", notesTable),
+		),
+	)
+
+	return model.CustomerNotesToEntity(data), nil
+}
+
+// Update implements CustomerNoteRepository.
+func (r *customerNoteRepository) Update(ctx context.Context, note *domain.CustomerNote) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "update_customer_note", notesTable, "update")
+	defer done()
+
+	// Versi lama disalin ke revisi dalam transaksi yang sama dengan update,
+	// sehingga isi catatan tidak pernah hilang tanpa jejak
+	var current model.CustomerNote
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND customer_id = ?", note.ID, note.CustomerID).
+			First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errNoteMissing
+			}
+			return err
+		}
+
+		revision := model.CustomerNoteRevision{
+			NoteID:    current.ID,
+			Body:      current.Body,
+			Pinned:    current.Pinned,
+			UpdatedBy: current.UpdatedBy,
+			UpdatedAt: current.UpdatedAt,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		current.Body = note.Body
+		current.Pinned = note.Pinned
+		current.UpdatedBy = note.UpdatedBy
+		return tx.Model(&current).Select("body", "pinned", "updated_by", "updated_at").Updates(&current).Error
+	})
+	if errors.Is(err, errNoteMissing) {
+		return false, nil
+	}
+	if err != nil {
+		r.recordError(ctx, notesTable, "update", "Error updating customer note", err, zap.Uint64("note_id", note.ID))
+		return false, err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", revisionsTable),
+		),
+	)
+
+	r.log.Info("Customer note updated",
+		zap.Uint64("note_id", note.ID),
+		zap.Uint64("customer_id", note.CustomerID),
+		zap.Uint64("updated_by", note.UpdatedBy),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	note.AuthorID = current.AuthorID
+	note.CreatedAt = current.CreatedAt
+	note.UpdatedAt = current.UpdatedAt
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *customerNoteRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *customerNoteRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewCustomerNoteRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.CustomerNoteRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &customerNoteRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,290 @@
+package deliveryrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type deliveryRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements DeliveryRepository.
+func (d *deliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	span := trace.SpanFromContext(ctx)
+
+	d.log.Debug("Create webhook delivery",
+		zap.Uint64("partner_id", delivery.PartnerID),
+		zap.String("event_type", delivery.EventType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := d.begin(ctx, "create_delivery", "insert")
+	defer done()
+
+	data := model.DeliveryFromEntity(delivery)
+	if err := d.db.WithContext(ctx).Create(&data).Error; err != nil {
+		d.recordError(ctx, "insert", "Error creating webhook delivery", err, zap.Uint64("partner_id", delivery.PartnerID))
+		return err
+	}
+
+	delivery.ID = data.ID
+	delivery.CreatedAt = data.CreatedAt
+	delivery.UpdatedAt = data.UpdatedAt
+
+	d.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "webhook_deliveries"),
+		),
+	)
+
+	d.log.Info("Webhook delivery created",
+		zap.Uint64("delivery_id", delivery.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// Update implements DeliveryRepository.
+func (d *deliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := d.begin(ctx, "update_delivery", "update")
+	defer done()
+
+	data := model.DeliveryFromEntity(delivery)
+	err := d.db.WithContext(ctx).Model(&model.WebhookDelivery{ID: delivery.ID}).
+		Select("status", "attempts", "replays", "last_error", "last_attempt_at", "delivered_at").
+		Updates(&data).Error
+	if err != nil {
+		d.recordError(ctx, "update", "Error updating webhook delivery", err, zap.Uint64("delivery_id", delivery.ID))
+		return err
+	}
+
+	d.log.Info("Webhook delivery updated",
+		zap.Uint64("delivery_id", delivery.ID),
+		zap.String("status", string(delivery.Status)),
+		zap.Int("attempts", delivery.Attempts),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindByID implements DeliveryRepository.
+func (d *deliveryRepository) FindByID(ctx context.Context, id uint64) (*domain.WebhookDelivery, error) {
+	done := d.begin(ctx, "find_delivery_by_id", "select")
+	defer done()
+
+	var delivery model.WebhookDelivery
+	if err := d.db.WithContext(ctx).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		d.recordError(ctx, "select", "Error finding webhook delivery", err, zap.Uint64("delivery_id", id))
+		return nil, err
+	}
+
+	d.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "webhook_deliveries"),
+		),
+	)
+
+	return model.DeliveryToEntity(delivery), nil
+}
+
+// FindPaginated implements DeliveryRepository.
+func (d *deliveryRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.WebhookDelivery, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	d.log.Debug("Find webhook deliveries paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := d.begin(ctx, "find_paginated_deliveries", "select_paginated")
+	defer done()
+
+	query := d.db.WithContext(ctx).Model(&model.WebhookDelivery{})
+	countQuery := d.db.WithContext(ctx).Model(&model.WebhookDelivery{})
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		d.recordError(ctx, "select_paginated", "Error counting webhook deliveries", err)
+		return nil, 0, err
+	}
+
+	var deliveries []model.WebhookDelivery
+	if err := params.Paginate(query).Order("id DESC").Find(&deliveries).Error; err != nil {
+		d.recordError(ctx, "select_paginated", "Error finding webhook deliveries", err)
+		return nil, 0, err
+	}
+
+	d.documentsRetrieved.Add(ctx, int64(len(deliveries)),
+		metric.WithAttributes(
+			attribute.String("table", "webhook_deliveries"),
+		),
+	)
+
+	d.log.Info("Webhook deliveries found paginated",
+		zap.Int64("total", total),
+		zap.Int("retrieved", len(deliveries)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.DeliveriesToEntity(deliveries), total, nil
+}
+
+// FindPaginatedByPartnerID implements DeliveryRepository.
+func (d *deliveryRepository) FindPaginatedByPartnerID(ctx context.Context, partnerID uint64, params domain.Params) ([]domain.WebhookDelivery, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	d.log.Debug("Find webhook deliveries paginated by partner ID",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := d.begin(ctx, "find_paginated_deliveries_by_partner_id", "select_paginated")
+	defer done()
+
+	query := params.Filter(d.db.WithContext(ctx).Model(&model.WebhookDelivery{}).Where("partner_id = ?", partnerID))
+	countQuery := params.Filter(d.db.WithContext(ctx).Model(&model.WebhookDelivery{}).Where("partner_id = ?", partnerID))
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		d.recordError(ctx, "select_paginated", "Error counting webhook deliveries", err, zap.Uint64("partner_id", partnerID))
+		return nil, 0, err
+	}
+
+	var deliveries []model.WebhookDelivery
+	if err := params.Paginate(query).Order("id DESC").Find(&deliveries).Error; err != nil {
+		d.recordError(ctx, "select_paginated", "Error finding webhook deliveries", err, zap.Uint64("partner_id", partnerID))
+		return nil, 0, err
+	}
+
+	d.documentsRetrieved.Add(ctx, int64(len(deliveries)),
+		metric.WithAttributes(
+			attribute.String("table", "webhook_deliveries"),
+		),
+	)
+
+	d.log.Info("Webhook deliveries found paginated",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int64("total", total),
+		zap.Int("retrieved", len(deliveries)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.DeliveriesToEntity(deliveries), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (d *deliveryRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "webhook_deliveries"),
+	)
+	d.connectionGauge.Add(ctx, 1, attrs)
+
+	d.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "webhook_deliveries"),
+		),
+	)
+
+	return func() { d.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (d *deliveryRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	d.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	d.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "webhook_deliveries"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewDeliveryRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.DeliveryRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &deliveryRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
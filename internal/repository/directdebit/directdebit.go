@@ -0,0 +1,274 @@
+package directdebitrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type directDebitRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindMandate implements DirectDebitRepository.
+func (r *directDebitRepository) FindMandate(ctx context.Context, customerID uint64) (*domain.DirectDebitMandate, error) {
+	done := r.begin(ctx, "find_direct_debit_mandate", "direct_debit_mandates", "select")
+	defer done()
+
+	var mandate model.DirectDebitMandate
+	if err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).First(&mandate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "direct_debit_mandates", "select", "Error finding direct debit mandate", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "direct_debit_mandates"),
+		),
+	)
+
+	return model.DirectDebitMandateToEntity(mandate), nil
+}
+
+// UpsertMandate implements DirectDebitRepository.
+func (r *directDebitRepository) UpsertMandate(ctx context.Context, mandate *domain.DirectDebitMandate) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_direct_debit_mandate", "direct_debit_mandates", "upsert")
+	defer done()
+
+	data := model.DirectDebitMandateFromEntity(mandate)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "customer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"provider", "token", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "direct_debit_mandates", "upsert", "Error upserting direct debit mandate", err, zap.Uint64("customer_id", mandate.CustomerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "direct_debit_mandates"),
+		),
+	)
+
+	r.log.Info("Direct debit mandate saved",
+		zap.Uint64("customer_id", mandate.CustomerID),
+		zap.String("provider", mandate.Provider),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	mandate.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// DeleteMandate implements DirectDebitRepository.
+func (r *directDebitRepository) DeleteMandate(ctx context.Context, customerID uint64) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "delete_direct_debit_mandate", "direct_debit_mandates", "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).Where("customer_id = ?", customerID).Delete(&model.DirectDebitMandate{})
+	if result.Error != nil {
+		r.recordError(ctx, "direct_debit_mandates", "delete", "Error deleting direct debit mandate", result.Error, zap.Uint64("customer_id", customerID))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Direct debit mandate deleted",
+		zap.Uint64("customer_id", customerID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// FindCollectible implements DirectDebitRepository.
+func (r *directDebitRepository) FindCollectible(ctx context.Context, afterID uint64, limit int) ([]domain.Transaction, error) {
+	done := r.begin(ctx, "find_collectible_contracts", "transactions", "select")
+	defer done()
+
+	var transactions []model.Transaction
+	err := r.db.WithContext(ctx).
+		Preload("Tenor").
+		Where("id > ? AND status = ? AND is_sandbox = ?", afterID, model.TransactionActive, false).
+		Where("customer_id IN (?)", r.db.Model(&model.DirectDebitMandate{}).Select("customer_id")).
+		Order("id ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		r.recordError(ctx, "transactions", "select", "Error finding collectible contracts", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	result := make([]domain.Transaction, len(transactions))
+	for i, t := range transactions {
+		result[i] = *model.TransactionToEntity(t)
+		result[i].Tenor = *model.TenorToEntity(t.Tenor)
+	}
+
+	return result, nil
+}
+
+// ListAttempts implements DirectDebitRepository.
+func (r *directDebitRepository) ListAttempts(ctx context.Context, transactionID uint64, installment int) ([]domain.DirectDebitAttempt, error) {
+	done := r.begin(ctx, "list_direct_debit_attempts", "direct_debit_attempts", "select")
+	defer done()
+
+	var attempts []model.DirectDebitAttempt
+	err := r.db.WithContext(ctx).
+		Where("transaction_id = ? AND installment = ?", transactionID, installment).
+		Order("attempt ASC").
+		Find(&attempts).Error
+	if err != nil {
+		r.recordError(ctx, "direct_debit_attempts", "select", "Error listing direct debit attempts", err, zap.Uint64("transaction_id", transactionID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(attempts)),
+		metric.WithAttributes(
+			attribute.String("table", "direct_debit_attempts"),
+		),
+	)
+
+	return model.DirectDebitAttemptsToEntity(attempts), nil
+}
+
+// CreateAttempt implements DirectDebitRepository.
+func (r *directDebitRepository) CreateAttempt(ctx context.Context, attempt *domain.DirectDebitAttempt) error {
+	done := r.begin(ctx, "create_direct_debit_attempt", "direct_debit_attempts", "insert")
+	defer done()
+
+	data := model.DirectDebitAttemptFromEntity(attempt)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "direct_debit_attempts", "insert", "Error creating direct debit attempt", err, zap.Uint64("transaction_id", attempt.TransactionID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "direct_debit_attempts"),
+		),
+	)
+
+	attempt.ID = data.ID
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *directDebitRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *directDebitRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewDirectDebitRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.DirectDebitRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &directDebitRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,265 @@
+package dormancyrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Aktivitas dihitung dari event timeline, kontrak baru, dan kontrak yang masih
+// berjalan. Kontrak sandbox tidak dihitung karena bukan aktivitas customer.
+const inactiveSince = `customers.created_at < @cutoff
+	AND NOT EXISTS (
+		SELECT 1 FROM customer_events e
+		WHERE e.customer_id = customers.id AND e.occurred_at >= @cutoff)
+	AND NOT EXISTS (
+		SELECT 1 FROM transactions t
+		WHERE t.customer_id = customers.id AND t.is_sandbox = FALSE
+		AND (t.transaction_date >= @cutoff OR t.status IN @running))`
+
+type dormancyRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsUpdated   metric.Int64Counter
+}
+
+// FindInactive implements DormancyRepository.
+func (r *dormancyRepository) FindInactive(ctx context.Context, cutoff time.Time, limit int) ([]domain.Customer, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Finding inactive customers",
+		zap.Time("cutoff", cutoff),
+		zap.Int("limit", limit),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_inactive_customers", "select")
+	defer done()
+
+	var customers []model.Customer
+	err := r.active(ctx).
+		Where(inactiveSince, inactiveArgs(cutoff)).
+		Order("customers.id ASC").
+		Limit(limit).
+		Find(&customers).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding inactive customers", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(customers)),
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return model.CustomersToEntity(customers), nil
+}
+
+// MarkDormant implements DormancyRepository.
+func (r *dormancyRepository) MarkDormant(ctx context.Context, customerID uint64, cutoff, at time.Time) (bool, error) {
+	done := r.begin(ctx, "mark_customer_dormant", "update")
+	defer done()
+
+	// Syarat tidak aktif dicek ulang supaya transaksi yang masuk setelah
+	// pencarian tidak ikut ditandai dormant
+	result := r.active(ctx).
+		Where("customers.id = ?", customerID).
+		Where(inactiveSince, inactiveArgs(cutoff)).
+		UpdateColumn("dormant_at", at)
+	if result.Error != nil {
+		r.recordError(ctx, "update", "Error marking customer dormant", result.Error, zap.Uint64("customer_id", customerID))
+		return false, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// Reactivate implements DormancyRepository.
+func (r *dormancyRepository) Reactivate(ctx context.Context, customerID uint64) (bool, error) {
+	done := r.begin(ctx, "reactivate_customer", "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).Model(&model.Customer{}).
+		Where("id = ? AND dormant_at IS NOT NULL", customerID).
+		UpdateColumn("dormant_at", nil)
+	if result.Error != nil {
+		r.recordError(ctx, "update", "Error reactivating customer", result.Error, zap.Uint64("customer_id", customerID))
+		return false, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// Stats implements DormancyRepository.
+func (r *dormancyRepository) Stats(ctx context.Context, since time.Time) (*domain.DormancyStats, error) {
+	done := r.begin(ctx, "dormancy_stats", "select_aggregate")
+	defer done()
+
+	var dormant struct {
+		Dormant int64
+		Oldest  *time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&model.Customer{}).
+		Select("COUNT(*) AS dormant, MIN(dormant_at) AS oldest").
+		Where("role = ? AND dormant_at IS NOT NULL", model.CustomerRole).
+		Scan(&dormant).Error
+	if err != nil {
+		r.recordError(ctx, "select_aggregate", "Error counting dormant customers", err)
+		return nil, err
+	}
+
+	var moves []struct {
+		Type  string
+		Total int64
+	}
+	err = r.db.WithContext(ctx).Model(&model.CustomerEvent{}).
+		Select("type, COUNT(*) AS total").
+		Where("type IN ? AND occurred_at >= ?", []domain.CustomerEventType{domain.CustomerDormant, domain.CustomerReactivated}, since).
+		Group("type").
+		Scan(&moves).Error
+	if err != nil {
+		r.recordError(ctx, "select_aggregate", "Error counting dormancy events", err)
+		return nil, err
+	}
+
+	stats := &domain.DormancyStats{
+		Dormant:         dormant.Dormant,
+		OldestDormantAt: dormant.Oldest,
+		Since:           since,
+	}
+	for _, move := range moves {
+		switch domain.CustomerEventType(move.Type) {
+		case domain.CustomerDormant:
+			stats.Flagged = move.Total
+		case domain.CustomerReactivated:
+			stats.Reactivated = move.Total
+		}
+	}
+
+	return stats, nil
+}
+
+// active scopes a query to verified customers that are not dormant yet.
+func (r *dormancyRepository) active(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&model.Customer{}).
+		Where("customers.role = ? AND customers.verification_status = ? AND customers.dormant_at IS NULL",
+			model.CustomerRole, model.VerificationVerified)
+}
+
+func inactiveArgs(cutoff time.Time) map[string]any {
+	return map[string]any{
+		"cutoff":  cutoff,
+		"running": []model.TransactionStatus{model.TransactionPending, model.TransactionApproved, model.TransactionActive},
+	}
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *dormancyRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "customers"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *dormancyRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "customers"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewDormancyRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.DormancyRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsUpdated, _ := meter.Int64Counter(
+		"db.documents.updated",
+		metric.WithDescription("Number of documents updated in the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &dormancyRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsUpdated:   documentsUpdated,
+	}
+}
@@ -0,0 +1,279 @@
+package duedaterepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	holidaysTable = "holidays"
+	policiesTable = "due_date_policies"
+)
+
+type dueDateRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindHolidays implements DueDateRepository.
+func (r *dueDateRepository) FindHolidays(ctx context.Context, from, to time.Time) ([]domain.Holiday, error) {
+	done := r.begin(ctx, "find_holidays", holidaysTable, "select")
+	defer done()
+
+	var holidays []model.Holiday
+	err := r.db.WithContext(ctx).
+		Where("date >= ? AND date < ?", from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Order("date ASC").
+		Find(&holidays).Error
+	if err != nil {
+		r.recordError(ctx, holidaysTable, "select", "Error finding holidays", err)
+		return nil, err
+	}
+
+	return r.retrieved(ctx, holidays), nil
+}
+
+// FindAllHolidays implements DueDateRepository.
+func (r *dueDateRepository) FindAllHolidays(ctx context.Context) ([]domain.Holiday, error) {
+	done := r.begin(ctx, "find_all_holidays", holidaysTable, "select")
+	defer done()
+
+	var holidays []model.Holiday
+	if err := r.db.WithContext(ctx).Order("date ASC").Find(&holidays).Error; err != nil {
+		r.recordError(ctx, holidaysTable, "select", "Error finding holidays", err)
+		return nil, err
+	}
+
+	return r.retrieved(ctx, holidays), nil
+}
+
+// UpsertHoliday implements DueDateRepository.
+func (r *dueDateRepository) UpsertHoliday(ctx context.Context, holiday *domain.Holiday) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_holiday", holidaysTable, "upsert")
+	defer done()
+
+	data := model.HolidayFromEntity(holiday)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "updated_by", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, holidaysTable, "upsert", "Error upserting holiday", err, zap.Time("date", holiday.Date))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", holidaysTable),
+		),
+	)
+
+	r.log.Info("Holiday saved",
+		zap.String("date", holiday.Date.Format(time.DateOnly)),
+		zap.String("name", holiday.Name),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	holiday.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// DeleteHoliday implements DueDateRepository.
+func (r *dueDateRepository) DeleteHoliday(ctx context.Context, date time.Time) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "delete_holiday", holidaysTable, "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).Where("date = ?", date.Format(time.DateOnly)).Delete(&model.Holiday{})
+	if result.Error != nil {
+		r.recordError(ctx, holidaysTable, "delete", "Error deleting holiday", result.Error, zap.Time("date", date))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Holiday deleted",
+		zap.String("date", date.Format(time.DateOnly)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// FindPolicy implements DueDateRepository.
+func (r *dueDateRepository) FindPolicy(ctx context.Context) (*domain.DueDatePolicy, error) {
+	done := r.begin(ctx, "find_due_date_policy", policiesTable, "select")
+	defer done()
+
+	var policy model.DueDatePolicy
+	if err := r.db.WithContext(ctx).First(&policy, model.DueDatePolicyID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, policiesTable, "select", "Error finding due date policy", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", policiesTable),
+		),
+	)
+
+	return model.DueDatePolicyToEntity(policy), nil
+}
+
+// SavePolicy implements DueDateRepository.
+func (r *dueDateRepository) SavePolicy(ctx context.Context, policy *domain.DueDatePolicy) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "save_due_date_policy", policiesTable, "upsert")
+	defer done()
+
+	data := model.DueDatePolicyFromEntity(policy)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"shift", "grace_days", "updated_by", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, policiesTable, "upsert", "Error saving due date policy", err)
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", policiesTable),
+		),
+	)
+
+	r.log.Info("Due date policy saved",
+		zap.String("shift", string(policy.Shift)),
+		zap.Int("grace_days", policy.GraceDays),
+		zap.Uint64("updated_by", policy.UpdatedBy),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	policy.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// retrieved records a successful holiday read and converts the rows.
+func (r *dueDateRepository) retrieved(ctx context.Context, holidays []model.Holiday) []domain.Holiday {
+	r.documentsRetrieved.Add(ctx, int64(len(holidays)),
+		metric.WithAttributes(
+			attribute.String("table", holidaysTable),
+		),
+	)
+
+	return model.HolidaysToEntity(holidays)
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *dueDateRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *dueDateRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewDueDateRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.DueDateRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &dueDateRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
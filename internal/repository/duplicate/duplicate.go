@@ -0,0 +1,220 @@
+package duplicaterepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	customersTable   = "customers"
+	resolutionsTable = "duplicate_resolutions"
+)
+
+type duplicateRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindByBirthDate implements DuplicateRepository.
+func (r *duplicateRepository) FindByBirthDate(ctx context.Context, birthDate time.Time, excludeID uint64) ([]domain.Customer, error) {
+	done := r.begin(ctx, customersTable, "find_customers_by_birth_date", "select")
+	defer done()
+
+	// Tanggal lahir harus sama persis, kemiripan nama dan tempat lahir dinilai di service
+	var customers []model.Customer
+	err := r.db.WithContext(ctx).
+		Where("DATE(birth_date) = ? AND id <> ?", birthDate.Format("2006-01-02"), excludeID).
+		Order("id ASC").
+		Find(&customers).Error
+	if err != nil {
+		r.recordError(ctx, customersTable, "select", "Error finding customers by birth date", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(customers)), metric.WithAttributes(attribute.String("table", customersTable)))
+
+	return model.CustomersToEntity(customers), nil
+}
+
+// FindResolvedIDs implements DuplicateRepository.
+func (r *duplicateRepository) FindResolvedIDs(ctx context.Context, customerID uint64) ([]uint64, error) {
+	done := r.begin(ctx, resolutionsTable, "find_resolved_duplicate_ids", "select")
+	defer done()
+
+	var resolutions []model.DuplicateResolution
+	err := r.db.WithContext(ctx).
+		Where("customer_id = ? OR duplicate_id = ?", customerID, customerID).
+		Find(&resolutions).Error
+	if err != nil {
+		r.recordError(ctx, resolutionsTable, "select", "Error finding duplicate resolutions", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	ids := make([]uint64, 0, len(resolutions))
+	for _, res := range resolutions {
+		if res.CustomerID == customerID {
+			ids = append(ids, res.DuplicateID)
+		} else {
+			ids = append(ids, res.CustomerID)
+		}
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(resolutions)), metric.WithAttributes(attribute.String("table", resolutionsTable)))
+
+	return ids, nil
+}
+
+// FindResolution implements DuplicateRepository.
+func (r *duplicateRepository) FindResolution(ctx context.Context, customerID, duplicateID uint64) (*domain.DuplicateResolution, error) {
+	done := r.begin(ctx, resolutionsTable, "find_duplicate_resolution", "select")
+	defer done()
+
+	// Pasangan dianggap sama dari kedua arah
+	var resolution model.DuplicateResolution
+	err := r.db.WithContext(ctx).
+		Where("(customer_id = ? AND duplicate_id = ?) OR (customer_id = ? AND duplicate_id = ?)", customerID, duplicateID, duplicateID, customerID).
+		First(&resolution).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, resolutionsTable, "select", "Error finding duplicate resolution", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1, metric.WithAttributes(attribute.String("table", resolutionsTable)))
+
+	return model.DuplicateResolutionToEntity(resolution), nil
+}
+
+// CreateResolution implements DuplicateRepository.
+func (r *duplicateRepository) CreateResolution(ctx context.Context, resolution *domain.DuplicateResolution) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, resolutionsTable, "create_duplicate_resolution", "insert")
+	defer done()
+
+	data := model.DuplicateResolutionFromEntity(resolution)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, resolutionsTable, "insert", "Error creating duplicate resolution", err,
+			zap.Uint64("customer_id", resolution.CustomerID),
+			zap.Uint64("duplicate_id", resolution.DuplicateID),
+		)
+		return err
+	}
+
+	resolution.ID = data.ID
+	resolution.CreatedAt = data.CreatedAt
+
+	r.documentsInserted.Add(ctx, 1, metric.WithAttributes(attribute.String("table", resolutionsTable)))
+
+	r.log.Info("Duplicate resolution created",
+		zap.Uint64("resolution_id", resolution.ID),
+		zap.String("action", string(resolution.Action)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *duplicateRepository) begin(ctx context.Context, table, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *duplicateRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewDuplicateRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.DuplicateRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &duplicateRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,222 @@
+package emailrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type emailRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements EmailRepository.
+func (r *emailRepository) Create(ctx context.Context, message *domain.EmailMessage) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_email_message", "insert")
+	defer done()
+
+	data := model.EmailMessageFromEntity(message)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error creating email message", err, zap.Uint64("customer_id", message.CustomerID))
+		return err
+	}
+
+	message.ID = data.ID
+	message.CreatedAt = data.CreatedAt
+	message.UpdatedAt = data.UpdatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "email_messages"),
+		),
+	)
+
+	r.log.Info("Email message queued",
+		zap.Uint64("email_id", message.ID),
+		zap.String("template", string(message.Template)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindDue implements EmailRepository.
+func (r *emailRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.EmailMessage, error) {
+	done := r.begin(ctx, "find_due_email_messages", "select")
+	defer done()
+
+	var messages []model.EmailMessage
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", domain.EmailPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding due email messages", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(messages)),
+		metric.WithAttributes(
+			attribute.String("table", "email_messages"),
+		),
+	)
+
+	return model.EmailMessagesToEntity(messages), nil
+}
+
+// Claim implements EmailRepository.
+func (r *emailRepository) Claim(ctx context.Context, message *domain.EmailMessage, leaseUntil time.Time) (bool, error) {
+	done := r.begin(ctx, "claim_email_message", "update")
+	defer done()
+
+	// Update bersyarat pada jumlah attempt mencegah dua worker mengirim pesan
+	// yang sama, dan lease membuat pesan dicoba lagi bila worker mati di tengah jalan
+	result := r.db.WithContext(ctx).Model(&model.EmailMessage{}).
+		Where("id = ? AND status = ? AND attempts = ?", message.ID, domain.EmailPending, message.Attempts).
+		Updates(map[string]any{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": leaseUntil,
+		})
+	if result.Error != nil {
+		r.recordError(ctx, "update", "Error claiming email message", result.Error, zap.Uint64("email_id", message.ID))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	message.Attempts++
+	message.NextAttemptAt = leaseUntil
+
+	return true, nil
+}
+
+// Update implements EmailRepository.
+func (r *emailRepository) Update(ctx context.Context, message *domain.EmailMessage) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "update_email_message", "update")
+	defer done()
+
+	data := model.EmailMessageFromEntity(message)
+	err := r.db.WithContext(ctx).Model(&model.EmailMessage{ID: message.ID}).
+		Select("status", "next_attempt_at", "last_error", "provider", "provider_message_id", "sent_at").
+		Updates(&data).Error
+	if err != nil {
+		r.recordError(ctx, "update", "Error updating email message", err, zap.Uint64("email_id", message.ID))
+		return err
+	}
+
+	r.log.Info("Email message updated",
+		zap.Uint64("email_id", message.ID),
+		zap.String("status", string(message.Status)),
+		zap.Int("attempts", message.Attempts),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *emailRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "email_messages"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "email_messages"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *emailRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "email_messages"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewEmailRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.EmailRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &emailRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
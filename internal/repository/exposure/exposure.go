@@ -0,0 +1,308 @@
+package exposurerepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const customerExposuresTable = "customer_exposures"
+
+// activePrincipal menjumlahkan pokok per transaksi yang sudah dibulatkan,
+// sama seperti delta yang ditambahkan saat kontrak dibuat atau lunas
+const activePrincipal = "COALESCE(SUM(ROUND((otr_amount + admin_fee) * fx_rate, 2)), 0)"
+
+type customerExposureRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Find implements CustomerExposureRepository.
+func (r *customerExposureRepository) Find(ctx context.Context, customerID uint64, tenorID uint) (*domain.CustomerExposure, error) {
+	done := r.begin(ctx, "find_customer_exposure", customerExposuresTable, "select")
+	defer done()
+
+	var data model.CustomerExposure
+	err := r.db.WithContext(ctx).
+		Where("customer_id = ? AND tenor_id = ?", customerID, tenorID).
+		First(&data).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, customerExposuresTable, "select", "Error finding customer exposure", err, zap.Uint64("customer_id", customerID), zap.Uint("tenor_id", tenorID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", customerExposuresTable),
+		),
+	)
+
+	return model.CustomerExposureToEntity(data), nil
+}
+
+// FindAll implements CustomerExposureRepository.
+func (r *customerExposureRepository) FindAll(ctx context.Context) ([]domain.CustomerExposure, error) {
+	done := r.begin(ctx, "find_customer_exposures", customerExposuresTable, "select")
+	defer done()
+
+	var data []model.CustomerExposure
+	if err := r.db.WithContext(ctx).Order("customer_id ASC, tenor_id ASC").Find(&data).Error; err != nil {
+		r.recordError(ctx, customerExposuresTable, "select", "Error finding customer exposures", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(data)),
+		metric.WithAttributes(
+			attribute.String("table", customerExposuresTable),
+		),
+	)
+
+	return model.CustomerExposuresToEntity(data), nil
+}
+
+// Add implements CustomerExposureRepository. The increment is applied by the
+// database so concurrent writers cannot lose an update. A pair without a row
+// yet is rebuilt from the transactions instead, so contracts booked before
+// the summary existed are not left out.
+func (r *customerExposureRepository) Add(ctx context.Context, customerID uint64, tenorID uint, amount decimal.Decimal) error {
+	done := r.begin(ctx, "add_customer_exposure", customerExposuresTable, "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).Model(&model.CustomerExposure{}).
+		Where("customer_id = ? AND tenor_id = ?", customerID, tenorID).
+		Updates(map[string]any{
+			"active_principal": gorm.Expr("active_principal + ?", amount),
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		r.recordError(ctx, customerExposuresTable, "update", "Error updating customer exposure", result.Error, zap.Uint64("customer_id", customerID), zap.Uint("tenor_id", tenorID))
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return r.Recompute(ctx, customerID)
+	}
+
+	return nil
+}
+
+// Repair implements CustomerExposureRepository. The summary row is locked
+// and the transactions are re-read with a locking read, so a contract
+// committed after the reconciliation snapshot is not mistaken for drift.
+func (r *customerExposureRepository) Repair(ctx context.Context, customerID uint64, tenorID uint) (bool, error) {
+	done := r.begin(ctx, "repair_customer_exposure", customerExposuresTable, "upsert")
+	defer done()
+
+	repaired := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []model.CustomerExposure
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("customer_id = ? AND tenor_id = ?", customerID, tenorID).
+			Find(&current).Error
+		if err != nil {
+			return err
+		}
+
+		var raw decimal.Decimal
+		err = tx.Model(&model.Transaction{}).
+			Clauses(clause.Locking{Strength: "SHARE"}).
+			Select(activePrincipal).
+			Where("customer_id = ? AND tenor_id = ? AND status = ? AND is_sandbox = ?", customerID, tenorID, model.TransactionActive, false).
+			Row().
+			Scan(&raw)
+		if err != nil {
+			return err
+		}
+
+		if (len(current) > 0 && current[0].ActivePrincipal.Equal(raw)) || (len(current) == 0 && raw.IsZero()) {
+			return nil
+		}
+
+		repaired = true
+		data := model.CustomerExposure{CustomerID: customerID, TenorID: tenorID, ActivePrincipal: raw}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "customer_id"}, {Name: "tenor_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"active_principal", "updated_at"}),
+		}).Create(&data).Error
+	})
+	if err != nil {
+		r.recordError(ctx, customerExposuresTable, "upsert", "Error repairing customer exposure", err, zap.Uint64("customer_id", customerID), zap.Uint("tenor_id", tenorID))
+		return false, err
+	}
+
+	return repaired, nil
+}
+
+// Recompute implements CustomerExposureRepository. It rebuilds every row of
+// one customer from its transactions, for changes that move principal
+// between customers or tenors rather than adding or removing it.
+func (r *customerExposureRepository) Recompute(ctx context.Context, customerID uint64) error {
+	done := r.begin(ctx, "recompute_customer_exposure", customerExposuresTable, "upsert")
+	defer done()
+
+	var rows []model.CustomerExposure
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Transaction{}).
+			Clauses(clause.Locking{Strength: "SHARE"}).
+			Select("customer_id, tenor_id, "+activePrincipal+" AS active_principal").
+			Where("customer_id = ? AND status = ? AND is_sandbox = ?", customerID, model.TransactionActive, false).
+			Group("customer_id, tenor_id").
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("customer_id = ?", customerID).Delete(&model.CustomerExposure{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		r.recordError(ctx, customerExposuresTable, "upsert", "Error recomputing customer exposure", err, zap.Uint64("customer_id", customerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", customerExposuresTable),
+		),
+	)
+
+	return nil
+}
+
+// SumTransactions implements CustomerExposureRepository. It is the raw
+// aggregate the summary table is checked against.
+func (r *customerExposureRepository) SumTransactions(ctx context.Context) ([]domain.CustomerExposure, error) {
+	done := r.begin(ctx, "sum_customer_exposures", "transactions", "select_sum")
+	defer done()
+
+	var rows []model.CustomerExposure
+	err := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Select("customer_id, tenor_id, "+activePrincipal+" AS active_principal").
+		Where("status = ? AND is_sandbox = ?", model.TransactionActive, false).
+		Group("customer_id, tenor_id").
+		Order("customer_id ASC, tenor_id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, "transactions", "select_sum", "Error summing customer exposures", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	return model.CustomerExposuresToEntity(rows), nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *customerExposureRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *customerExposureRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewCustomerExposureRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.CustomerExposureRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &customerExposureRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,191 @@
+package featureflagrepo
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type featureFlagRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindAll implements FeatureFlagRepository.
+func (r *featureFlagRepository) FindAll(ctx context.Context) ([]domain.FeatureFlag, error) {
+	done := r.begin(ctx, "find_all_feature_flags", "select")
+	defer done()
+
+	var flags []model.FeatureFlag
+	if err := r.db.WithContext(ctx).Order("`key` ASC").Find(&flags).Error; err != nil {
+		r.recordError(ctx, "select", "Error finding feature flags", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(flags)),
+		metric.WithAttributes(
+			attribute.String("table", "feature_flags"),
+		),
+	)
+
+	return model.FeatureFlagsToEntity(flags), nil
+}
+
+// Upsert implements FeatureFlagRepository.
+func (r *featureFlagRepository) Upsert(ctx context.Context, flag *domain.FeatureFlag) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_feature_flag", "upsert")
+	defer done()
+
+	data := model.FeatureFlagFromEntity(flag)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description", "enabled", "partner_ids", "updated_by", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "upsert", "Error upserting feature flag", err, zap.String("key", flag.Key))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "feature_flags"),
+		),
+	)
+
+	r.log.Info("Feature flag saved",
+		zap.String("key", flag.Key),
+		zap.Bool("enabled", flag.Enabled),
+		zap.Uint64s("partner_ids", flag.PartnerIDs),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	flag.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// Delete implements FeatureFlagRepository.
+func (r *featureFlagRepository) Delete(ctx context.Context, key string) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "delete_feature_flag", "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).Where("`key` = ?", key).Delete(&model.FeatureFlag{})
+	if result.Error != nil {
+		r.recordError(ctx, "delete", "Error deleting feature flag", result.Error, zap.String("key", key))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Feature flag deleted",
+		zap.String("key", key),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *featureFlagRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "feature_flags"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "feature_flags"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *featureFlagRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "feature_flags"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewFeatureFlagRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.FeatureFlagRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &featureFlagRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,223 @@
+package feeschedulerepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type feeScheduleRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindByPartner implements FeeScheduleRepository.
+func (r *feeScheduleRepository) FindByPartner(ctx context.Context, partnerID uint64) ([]domain.PartnerFeeSchedule, error) {
+	done := r.begin(ctx, "find_fee_schedules_by_partner", "select")
+	defer done()
+
+	var schedules []model.PartnerFeeSchedule
+	if err := r.db.WithContext(ctx).Where("partner_id = ?", partnerID).Order("tenor_months ASC").Find(&schedules).Error; err != nil {
+		r.recordError(ctx, "select", "Error finding fee schedules", err, zap.Uint64("partner_id", partnerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(schedules)),
+		metric.WithAttributes(
+			attribute.String("table", "partner_fee_schedules"),
+		),
+	)
+
+	return model.PartnerFeeSchedulesToEntity(schedules), nil
+}
+
+// FindForTenor implements FeeScheduleRepository.
+func (r *feeScheduleRepository) FindForTenor(ctx context.Context, partnerID uint64, tenorMonths uint8) (*domain.PartnerFeeSchedule, error) {
+	done := r.begin(ctx, "find_fee_schedule_for_tenor", "select")
+	defer done()
+
+	// Jadwal khusus tenor didahulukan, baris tenor 0 adalah jadwal default partner
+	var schedule model.PartnerFeeSchedule
+	err := r.db.WithContext(ctx).
+		Where("partner_id = ? AND tenor_months IN ?", partnerID, []uint8{tenorMonths, 0}).
+		Order("tenor_months DESC").
+		First(&schedule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding fee schedule", err, zap.Uint64("partner_id", partnerID), zap.Uint8("tenor_months", tenorMonths))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "partner_fee_schedules"),
+		),
+	)
+
+	return model.PartnerFeeScheduleToEntity(schedule), nil
+}
+
+// Upsert implements FeeScheduleRepository.
+func (r *feeScheduleRepository) Upsert(ctx context.Context, schedule *domain.PartnerFeeSchedule) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_fee_schedule", "upsert")
+	defer done()
+
+	data := model.PartnerFeeScheduleFromEntity(schedule)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "partner_id"}, {Name: "tenor_months"}},
+		DoUpdates: clause.AssignmentColumns([]string{"admin_fee_flat", "admin_fee_rate", "commission_rate", "updated_by", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "upsert", "Error upserting fee schedule", err, zap.Uint64("partner_id", schedule.PartnerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "partner_fee_schedules"),
+		),
+	)
+
+	r.log.Info("Fee schedule saved",
+		zap.Uint64("partner_id", schedule.PartnerID),
+		zap.Uint8("tenor_months", schedule.TenorMonths),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	schedule.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// Delete implements FeeScheduleRepository.
+func (r *feeScheduleRepository) Delete(ctx context.Context, partnerID uint64, tenorMonths uint8) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "delete_fee_schedule", "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).
+		Where("partner_id = ? AND tenor_months = ?", partnerID, tenorMonths).
+		Delete(&model.PartnerFeeSchedule{})
+	if result.Error != nil {
+		r.recordError(ctx, "delete", "Error deleting fee schedule", result.Error, zap.Uint64("partner_id", partnerID))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Fee schedule deleted",
+		zap.Uint64("partner_id", partnerID),
+		zap.Uint8("tenor_months", tenorMonths),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *feeScheduleRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "partner_fee_schedules"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "partner_fee_schedules"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *feeScheduleRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "partner_fee_schedules"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewFeeScheduleRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.FeeScheduleRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &feeScheduleRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,231 @@
+package fxraterepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type fxRateRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// UpsertMany implements FxRateRepository.
+func (r *fxRateRepository) UpsertMany(ctx context.Context, rates []domain.FxRate) error {
+	span := trace.SpanFromContext(ctx)
+
+	if len(rates) == 0 {
+		return nil
+	}
+
+	r.log.Debug("Upserting fx rates",
+		zap.Int("count", len(rates)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "upsert_fx_rates", "upsert")
+	defer done()
+
+	data := make([]model.FxRate, len(rates))
+	for i := range rates {
+		data[i] = model.FxRateFromEntity(&rates[i])
+	}
+
+	// Upload ulang untuk tanggal yang sama menimpa kurs sebelumnya
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}, {Name: "rate_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate_to_idr", "uploaded_by", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "upsert", "Error upserting fx rates", err, zap.Int("count", len(rates)))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, int64(len(data)),
+		metric.WithAttributes(
+			attribute.String("table", "fx_rates"),
+		),
+	)
+
+	r.log.Info("Fx rates upserted",
+		zap.Int("count", len(rates)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindLatest implements FxRateRepository.
+func (r *fxRateRepository) FindLatest(ctx context.Context, currency string, asOf time.Time) (*domain.FxRate, error) {
+	done := r.begin(ctx, "find_latest_fx_rate", "select")
+	defer done()
+
+	// Kurs terakhir yang berlaku pada atau sebelum tanggal yang diminta
+	var rate model.FxRate
+	err := r.db.WithContext(ctx).
+		Where("currency = ? AND rate_date <= ?", currency, asOf.Format("2006-01-02")).
+		Order("rate_date DESC").
+		First(&rate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding fx rate", err, zap.String("currency", currency))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "fx_rates"),
+		),
+	)
+
+	return model.FxRateToEntity(rate), nil
+}
+
+// FindPaginated implements FxRateRepository.
+func (r *fxRateRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.FxRate, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find fx rates paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("currency", params.Value("currency")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_fx_rates", "select_paginated")
+	defer done()
+
+	query := params.Filter(r.db.WithContext(ctx).Model(&model.FxRate{}))
+	countQuery := params.Filter(r.db.WithContext(ctx).Model(&model.FxRate{}))
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error counting fx rates", err)
+		return nil, 0, err
+	}
+
+	var rates []model.FxRate
+	if err := params.Paginate(query).Order("rate_date DESC, currency ASC").Find(&rates).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error finding fx rates", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rates)),
+		metric.WithAttributes(
+			attribute.String("table", "fx_rates"),
+		),
+	)
+
+	r.log.Info("Fx rates found paginated",
+		zap.Int64("total", total),
+		zap.Int("retrieved", len(rates)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.FxRatesToEntity(rates), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *fxRateRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "fx_rates"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "fx_rates"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *fxRateRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "fx_rates"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewFxRateRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.FxRateRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &fxRateRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
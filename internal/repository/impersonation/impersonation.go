@@ -0,0 +1,253 @@
+package impersonationrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	sessionsTable = "impersonation_sessions"
+	requestsTable = "impersonation_requests"
+)
+
+type impersonationRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements ImpersonationRepository.
+func (r *impersonationRepository) Create(ctx context.Context, session *domain.ImpersonationSession) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_impersonation_session", sessionsTable, "insert")
+	defer done()
+
+	data := model.ImpersonationSession{
+		AdminID:    session.AdminID,
+		CustomerID: session.CustomerID,
+		Reason:     session.Reason,
+		ExpiresAt:  session.ExpiresAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, sessionsTable, "insert", "Error creating impersonation session", err, zap.Uint64("admin_id", session.AdminID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", sessionsTable),
+		),
+	)
+
+	r.log.Info("Impersonation session created",
+		zap.Uint64("session_id", data.ID),
+		zap.Uint64("admin_id", session.AdminID),
+		zap.Uint64("customer_id", session.CustomerID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	session.ID = data.ID
+	session.CreatedAt = data.CreatedAt
+
+	return nil
+}
+
+// FindByID implements ImpersonationRepository.
+func (r *impersonationRepository) FindByID(ctx context.Context, id uint64) (*domain.ImpersonationSession, error) {
+	done := r.begin(ctx, "find_impersonation_session", sessionsTable, "select")
+	defer done()
+
+	var data model.ImpersonationSession
+	if err := r.db.WithContext(ctx).First(&data, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, sessionsTable, "select", "Error finding impersonation session", err, zap.Uint64("session_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", sessionsTable),
+		),
+	)
+
+	return model.ImpersonationSessionToEntity(data), nil
+}
+
+// End implements ImpersonationRepository.
+func (r *impersonationRepository) End(ctx context.Context, id uint64, endedAt time.Time) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "end_impersonation_session", sessionsTable, "update")
+	defer done()
+
+	result := r.db.WithContext(ctx).Model(&model.ImpersonationSession{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		r.recordError(ctx, sessionsTable, "update", "Error ending impersonation session", result.Error, zap.Uint64("session_id", id))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Impersonation session ended",
+		zap.Uint64("session_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// CreateRequest implements ImpersonationRepository.
+func (r *impersonationRepository) CreateRequest(ctx context.Context, request *domain.ImpersonationRequest) error {
+	done := r.begin(ctx, "create_impersonation_request", requestsTable, "insert")
+	defer done()
+
+	data := model.ImpersonationRequest{
+		SessionID: request.SessionID,
+		Method:    request.Method,
+		Path:      request.Path,
+		Status:    request.Status,
+	}
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, requestsTable, "insert", "Error recording impersonation request", err, zap.Uint64("session_id", request.SessionID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", requestsTable),
+		),
+	)
+
+	request.ID = data.ID
+	request.CreatedAt = data.CreatedAt
+
+	return nil
+}
+
+// FindRequests implements ImpersonationRepository.
+func (r *impersonationRepository) FindRequests(ctx context.Context, sessionID uint64) ([]domain.ImpersonationRequest, error) {
+	done := r.begin(ctx, "find_impersonation_requests", requestsTable, "select")
+	defer done()
+
+	var data []model.ImpersonationRequest
+	if err := r.db.WithContext(ctx).Where("session_id = ?", sessionID).Order("id ASC").Find(&data).Error; err != nil {
+		r.recordError(ctx, requestsTable, "select", "Error finding impersonation requests", err, zap.Uint64("session_id", sessionID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(data)),
+		metric.WithAttributes(
+			attribute.String("table", requestsTable),
+		),
+	)
+
+	return model.ImpersonationRequestsToEntity(data), nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *impersonationRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *impersonationRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewImpersonationRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.ImpersonationRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &impersonationRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,267 @@
+package incomerepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type incomeVerificationRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements IncomeVerificationRepository.
+func (r *incomeVerificationRepository) Create(ctx context.Context, verification *domain.IncomeVerification) error {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Create income verification",
+		zap.Uint64("customer_id", verification.CustomerID),
+		zap.String("status", string(verification.Status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "create_income_verification", "insert")
+	defer done()
+
+	data := model.IncomeVerificationFromEntity(verification)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error creating income verification", err, zap.Uint64("customer_id", verification.CustomerID))
+		return err
+	}
+
+	verification.ID = data.ID
+	verification.CreatedAt = data.CreatedAt
+	verification.UpdatedAt = data.UpdatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "income_verifications"),
+		),
+	)
+
+	r.log.Info("Income verification created",
+		zap.Uint64("income_verification_id", verification.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// Review implements IncomeVerificationRepository.
+func (r *incomeVerificationRepository) Review(ctx context.Context, verification *domain.IncomeVerification) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "review_income_verification", "update")
+	defer done()
+
+	// Hanya verifikasi yang masih FLAGGED yang boleh diputuskan, dua admin tidak bisa sama-sama menang
+	data := model.IncomeVerificationFromEntity(verification)
+	result := r.db.WithContext(ctx).Model(&model.IncomeVerification{}).
+		Where("id = ? AND status = ?", verification.ID, model.IncomeFlagged).
+		Select("status", "reviewer_id", "review_note", "reviewed_at").
+		Updates(&data)
+	if result.Error != nil {
+		r.recordError(ctx, "update", "Error reviewing income verification", result.Error, zap.Uint64("income_verification_id", verification.ID))
+		return false, result.Error
+	}
+
+	r.log.Info("Income verification reviewed",
+		zap.Uint64("income_verification_id", verification.ID),
+		zap.String("status", string(verification.Status)),
+		zap.Bool("updated", result.RowsAffected > 0),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindByID implements IncomeVerificationRepository.
+func (r *incomeVerificationRepository) FindByID(ctx context.Context, id uint64) (*domain.IncomeVerification, error) {
+	done := r.begin(ctx, "find_income_verification_by_id", "select")
+	defer done()
+
+	var verification model.IncomeVerification
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&verification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding income verification", err, zap.Uint64("income_verification_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "income_verifications"),
+		),
+	)
+
+	return model.IncomeVerificationToEntity(verification), nil
+}
+
+// FindByCustomerID implements IncomeVerificationRepository.
+func (r *incomeVerificationRepository) FindByCustomerID(ctx context.Context, customerID uint64) ([]domain.IncomeVerification, error) {
+	done := r.begin(ctx, "find_income_verifications_by_customer", "select")
+	defer done()
+
+	var verifications []model.IncomeVerification
+	if err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).
+		Order("created_at DESC").Order("id DESC").Find(&verifications).Error; err != nil {
+		r.recordError(ctx, "select", "Error finding income verifications", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(verifications)),
+		metric.WithAttributes(
+			attribute.String("table", "income_verifications"),
+		),
+	)
+
+	return model.IncomeVerificationsToEntity(verifications), nil
+}
+
+// FindPaginated implements IncomeVerificationRepository.
+func (r *incomeVerificationRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.IncomeVerification, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find income verifications paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_income_verifications", "select_paginated")
+	defer done()
+
+	query := r.db.WithContext(ctx).Model(&model.IncomeVerification{})
+	countQuery := r.db.WithContext(ctx).Model(&model.IncomeVerification{})
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error counting income verifications", err)
+		return nil, 0, err
+	}
+
+	var verifications []model.IncomeVerification
+	if err := params.Paginate(query).Order("created_at ASC").Find(&verifications).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error finding income verifications", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(verifications)),
+		metric.WithAttributes(
+			attribute.String("table", "income_verifications"),
+		),
+	)
+
+	r.log.Info("Income verifications found paginated",
+		zap.Int64("total", total),
+		zap.Int("retrieved", len(verifications)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.IncomeVerificationsToEntity(verifications), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *incomeVerificationRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "income_verifications"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "income_verifications"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *incomeVerificationRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "income_verifications"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewIncomeVerificationRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.IncomeVerificationRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &incomeVerificationRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -1,32 +1,536 @@
 package repository
 
+//go:generate mockgen -source=interface.go -destination=mocks/mock_repository.go -package=mocks
+
 import (
 	"context"
+	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/shopspring/decimal"
 )
 
 type CustomerRepository interface {
+	// CreateCustomer reports a NIK, email or phone taken in the meantime as
+	// common.ErrNIKExists, ErrEmailExists or ErrPhoneExists.
 	CreateCustomer(ctx context.Context, customer *domain.Customer) (*domain.Customer, error)
 	FindByNIK(ctx context.Context, nik string) (*domain.Customer, error)
 	FindByNIKWithLock(ctx context.Context, nik string) (*domain.Customer, error)
 	FindByID(ctx context.Context, id uint64) (*domain.Customer, error)
+	FindByContact(ctx context.Context, email, phone string) (*domain.Customer, error)
 	FindPaginated(ctx context.Context, params domain.Params) ([]domain.Customer, int64, error)
+	// StreamForExport calls fn with every customer account, only those with
+	// status unless it is empty, in ID order. Rows are read from a cursor so
+	// memory stays flat however many there are; the first error fn returns
+	// stops the stream and is returned.
+	StreamForExport(ctx context.Context, status domain.VerificationStatus, fn func(domain.Customer) error) error
+	// CountByStatus counts the customer accounts with the verification status.
+	CountByStatus(ctx context.Context, status domain.VerificationStatus) (int64, error)
 }
 
 type TenorRepository interface {
 	FindByDuration(ctx context.Context, durationMonths uint8) (*domain.Tenor, error)
 	FindAll(ctx context.Context) ([]domain.Tenor, error)
+	FindByID(ctx context.Context, id uint) (*domain.Tenor, error)
+	Create(ctx context.Context, tenor *domain.Tenor) error
+	Update(ctx context.Context, tenor domain.Tenor) (bool, error)
+	Delete(ctx context.Context, id uint) (bool, error)
+	IsReferenced(ctx context.Context, id uint) (bool, error)
 }
 
+// LimitRepository stores every period a customer limit is in force, so a
+// limit can be looked up at any point in time.
 type LimitRepository interface {
-	FindByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint) (*domain.CustomerLimit, error)
-	UpsertMany(ctx context.Context, limits []domain.CustomerLimit) error
-	FindAllByCustomerID(ctx context.Context, customerID uint64) ([]domain.CustomerLimit, error)
+	// FindByCustomerIDAndTenorID returns the limit in force at at, nil when
+	// the tenor has none then.
+	FindByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint, at time.Time) (*domain.CustomerLimit, error)
+	// UpsertMany starts each limit at its EffectiveFrom, immediately when
+	// zero, ending the period in force then. Limits already scheduled later
+	// are kept. It reports false without writing anything when a limit's
+	// Version no longer matches the one in force at its EffectiveFrom.
+	UpsertMany(ctx context.Context, limits []domain.CustomerLimit, changedBy uint64) (bool, error)
+	// FindAllByCustomerID returns the limits in force at at, with their tenor.
+	FindAllByCustomerID(ctx context.Context, customerID uint64, at time.Time) ([]domain.CustomerLimit, error)
+	FindHistoryByCustomerID(ctx context.Context, customerID uint64) ([]domain.LimitChange, error)
 }
 
 type TransactionRepository interface {
-	SumActivePrincipalByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint) (float64, error)
+	SumActivePrincipalByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint) (decimal.Decimal, error)
+	// SumActiveMonthlyInstallmentByCustomerID adds up the monthly installment
+	// of every active contract of the customer, in IDR.
+	SumActiveMonthlyInstallmentByCustomerID(ctx context.Context, customerID uint64) (decimal.Decimal, error)
+	// CreateTransaction inserts tx. A contract number that is already taken
+	// is reported as common.ErrDuplicateContract.
 	CreateTransaction(ctx context.Context, tx *domain.Transaction) error
+	// CreateMany inserts transactions batchSize rows per statement in one
+	// database transaction, so a constraint violation in any batch leaves
+	// none of them behind. A taken contract number is reported as
+	// common.ErrDuplicateContract, other duplicate keys as
+	// gorm.ErrDuplicatedKey. The generated IDs are set on transactions.
+	CreateMany(ctx context.Context, transactions []domain.Transaction, batchSize int) error
 	FindPaginatedByCustomerID(ctx context.Context, customerID uint64, params domain.Params) ([]domain.Transaction, int64, error)
+	// FindActiveByCustomerID returns the customer's active, non-sandbox
+	// contracts, oldest first.
+	FindActiveByCustomerID(ctx context.Context, customerID uint64) ([]domain.Transaction, error)
+	FindPaginatedByPartnerID(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) ([]domain.Transaction, int64, error)
+	FindByContractNumber(ctx context.Context, contractNumber string) (*domain.Transaction, error)
+	// FindPaginatedByContractNumberPrefix lists the transactions, sandbox
+	// included, whose contract number starts with prefix, with their
+	// customer loaded.
+	FindPaginatedByContractNumberPrefix(ctx context.Context, prefix string, params domain.Params) ([]domain.Transaction, int64, error)
+	// StreamForExport calls fn with every non-sandbox transaction dated in
+	// [from, to), oldest first, reading them from a cursor like
+	// CustomerRepository.StreamForExport.
+	StreamForExport(ctx context.Context, from, to time.Time, fn func(domain.Transaction) error) error
+}
+
+type PartnerRepository interface {
+	Create(ctx context.Context, partner *domain.Partner) error
+	FindByID(ctx context.Context, id uint64) (*domain.Partner, error)
+	FindByEmail(ctx context.Context, email string) (*domain.Partner, error)
+	FindByAPIKeyHash(ctx context.Context, keyHash string) (*domain.Partner, error)
+	Update(ctx context.Context, partner *domain.Partner) error
+}
+
+type DeliveryRepository interface {
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) error
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+	FindByID(ctx context.Context, id uint64) (*domain.WebhookDelivery, error)
+	FindPaginated(ctx context.Context, params domain.Params) ([]domain.WebhookDelivery, int64, error)
+	FindPaginatedByPartnerID(ctx context.Context, partnerID uint64, params domain.Params) ([]domain.WebhookDelivery, int64, error)
+}
+
+type SalaryChangeRepository interface {
+	Create(ctx context.Context, change *domain.SalaryChange) error
+	Update(ctx context.Context, change *domain.SalaryChange) error
+	FindByID(ctx context.Context, id uint64) (*domain.SalaryChange, error)
+	FindByIDWithLock(ctx context.Context, id uint64) (*domain.SalaryChange, error)
+	FindPendingByCustomerID(ctx context.Context, customerID uint64) (*domain.SalaryChange, error)
+	FindPaginated(ctx context.Context, params domain.Params) ([]domain.SalaryChange, int64, error)
+}
+
+type IncomeVerificationRepository interface {
+	Create(ctx context.Context, verification *domain.IncomeVerification) error
+	// Review records the admin decision only while the verification is still
+	// FLAGGED, reporting false when another review got there first.
+	Review(ctx context.Context, verification *domain.IncomeVerification) (bool, error)
+	FindByID(ctx context.Context, id uint64) (*domain.IncomeVerification, error)
+	FindByCustomerID(ctx context.Context, customerID uint64) ([]domain.IncomeVerification, error)
+	FindPaginated(ctx context.Context, params domain.Params) ([]domain.IncomeVerification, int64, error)
+}
+
+// RiskTierRepository stores the rules that grade customers and the tier
+// each customer was last given. FindRules returns the rules in the order
+// they are tried. FindCustomers pages through open customer accounts by ID
+// for the recalculation job and FindActiveExposures returns the active,
+// non-sandbox contracts of a page of customers.
+type RiskTierRepository interface {
+	FindRules(ctx context.Context) ([]domain.RiskTierRule, error)
+	FindRuleByID(ctx context.Context, id uint64) (*domain.RiskTierRule, error)
+	CreateRule(ctx context.Context, rule *domain.RiskTierRule) error
+	UpdateRule(ctx context.Context, rule *domain.RiskTierRule) error
+	DeleteRule(ctx context.Context, id uint64) (bool, error)
+	FindByCustomerID(ctx context.Context, customerID uint64) (*domain.CustomerRiskTier, error)
+	FindByCustomerIDs(ctx context.Context, customerIDs []uint64) ([]domain.CustomerRiskTier, error)
+	Save(ctx context.Context, tier *domain.CustomerRiskTier) error
+	FindCustomers(ctx context.Context, afterID uint64, limit int) ([]domain.Customer, error)
+	FindActiveExposures(ctx context.Context, customerIDs []uint64) ([]domain.AgingExposure, error)
+}
+
+type BlacklistRepository interface {
+	Create(ctx context.Context, entry *domain.BlacklistEntry) error
+	Update(ctx context.Context, entry *domain.BlacklistEntry) error
+	Delete(ctx context.Context, id uint64) error
+	FindByID(ctx context.Context, id uint64) (*domain.BlacklistEntry, error)
+	FindPaginated(ctx context.Context, params domain.Params) ([]domain.BlacklistEntry, int64, error)
+	FindMatches(ctx context.Context, nik, fullName string) ([]domain.BlacklistEntry, error)
+	CreateScreeningLog(ctx context.Context, log *domain.ScreeningLog) error
+	FindScreeningLogsPaginated(ctx context.Context, params domain.Params) ([]domain.ScreeningLog, int64, error)
+}
+
+type DuplicateRepository interface {
+	FindByBirthDate(ctx context.Context, birthDate time.Time, excludeID uint64) ([]domain.Customer, error)
+	FindResolvedIDs(ctx context.Context, customerID uint64) ([]uint64, error)
+	FindResolution(ctx context.Context, customerID, duplicateID uint64) (*domain.DuplicateResolution, error)
+	CreateResolution(ctx context.Context, resolution *domain.DuplicateResolution) error
+}
+
+type RestructuringRepository interface {
+	Create(ctx context.Context, restructuring *domain.Restructuring) error
+	Update(ctx context.Context, restructuring *domain.Restructuring) error
+	FindByID(ctx context.Context, id uint64) (*domain.Restructuring, error)
+	FindByIDWithLock(ctx context.Context, id uint64) (*domain.Restructuring, error)
+	FindPendingByTransactionID(ctx context.Context, transactionID uint64) (*domain.Restructuring, error)
+	FindPaginated(ctx context.Context, params domain.Params) ([]domain.Restructuring, int64, error)
+}
+
+type FxRateRepository interface {
+	UpsertMany(ctx context.Context, rates []domain.FxRate) error
+	FindLatest(ctx context.Context, currency string, asOf time.Time) (*domain.FxRate, error)
+	FindPaginated(ctx context.Context, params domain.Params) ([]domain.FxRate, int64, error)
+}
+
+type ReportRepository interface {
+	InterestAccrual(ctx context.Context, month time.Time) ([]domain.InterestAccrual, error)
+	PartnerCommissions(ctx context.Context, month time.Time) ([]domain.PartnerCommission, error)
+	ReferralConversions(ctx context.Context, month time.Time) ([]domain.ReferralConversion, error)
+	AgingExposures(ctx context.Context) ([]domain.AgingExposure, error)
+	SaveAgingSnapshot(ctx context.Context, date time.Time, rows []domain.AgingSnapshotRow) error
+	LatestAgingSnapshot(ctx context.Context, onOrBefore time.Time) ([]domain.AgingSnapshotRow, error)
+	RegionVolumes(ctx context.Context, month time.Time) ([]domain.RegionVolume, error)
+}
+
+type PendingExpiryRepository interface {
+	FindUnreminded(ctx context.Context, submittedBefore time.Time, limit int) ([]domain.Customer, error)
+	FindExpired(ctx context.Context, submittedBefore, remindedBefore time.Time, limit int) ([]domain.Customer, error)
+	MarkReminded(ctx context.Context, customerID uint64, at time.Time) error
+	Reject(ctx context.Context, customerID uint64) (bool, error)
+	Stats(ctx context.Context) (pending int64, oldestSubmittedAt *time.Time, err error)
+}
+
+type CommunicationRepository interface {
+	Create(ctx context.Context, communication *domain.Communication) error
+	FindByCustomer(ctx context.Context, customerID uint64, params domain.Params) ([]domain.Communication, int64, error)
+}
+
+type FeatureFlagRepository interface {
+	FindAll(ctx context.Context) ([]domain.FeatureFlag, error)
+	Upsert(ctx context.Context, flag *domain.FeatureFlag) error
+	Delete(ctx context.Context, key string) (bool, error)
+}
+
+type FeeScheduleRepository interface {
+	FindByPartner(ctx context.Context, partnerID uint64) ([]domain.PartnerFeeSchedule, error)
+	FindForTenor(ctx context.Context, partnerID uint64, tenorMonths uint8) (*domain.PartnerFeeSchedule, error)
+	Upsert(ctx context.Context, schedule *domain.PartnerFeeSchedule) error
+	Delete(ctx context.Context, partnerID uint64, tenorMonths uint8) (bool, error)
+}
+
+type PaymentRepository interface {
+	LockContract(ctx context.Context, contractNumber string) (*domain.Transaction, error)
+	LockContractByVirtualAccount(ctx context.Context, number string) (*domain.Transaction, error)
+	ArchiveCallback(ctx context.Context, callback *domain.PaymentCallback) (bool, error)
+	FindByExternalID(ctx context.Context, provider, externalID string) (*domain.Payment, error)
+	Save(ctx context.Context, payment *domain.Payment) error
+	SumPaid(ctx context.Context, transactionID uint64) (decimal.Decimal, error)
+	ApplyRepayment(ctx context.Context, transactionID uint64, paidInstallments int, status domain.TransactionStatus) error
+}
+
+type VirtualAccountRepository interface {
+	FindUnprovisioned(ctx context.Context, limit int) ([]domain.Transaction, error)
+	Assign(ctx context.Context, transactionID uint64, bank, number string) (bool, error)
+}
+
+type DirectDebitRepository interface {
+	FindMandate(ctx context.Context, customerID uint64) (*domain.DirectDebitMandate, error)
+	UpsertMandate(ctx context.Context, mandate *domain.DirectDebitMandate) error
+	DeleteMandate(ctx context.Context, customerID uint64) (bool, error)
+	FindCollectible(ctx context.Context, afterID uint64, limit int) ([]domain.Transaction, error)
+	ListAttempts(ctx context.Context, transactionID uint64, installment int) ([]domain.DirectDebitAttempt, error)
+	CreateAttempt(ctx context.Context, attempt *domain.DirectDebitAttempt) error
+}
+
+type PromotionRepository interface {
+	FindAll(ctx context.Context) ([]domain.Promotion, error)
+	LockByCode(ctx context.Context, code string) (*domain.Promotion, error)
+	Upsert(ctx context.Context, promotion *domain.Promotion) error
+	Deactivate(ctx context.Context, code string) (bool, error)
+	CountRedemptions(ctx context.Context, promotionID, customerID uint64) (total, byCustomer int64, err error)
+	CreateRedemption(ctx context.Context, redemption *domain.PromotionRedemption) error
+}
+
+type ReferralRepository interface {
+	FindCode(ctx context.Context, customerID uint64) (*domain.ReferralCode, error)
+	FindByCode(ctx context.Context, code string) (*domain.ReferralCode, error)
+	CreateCode(ctx context.Context, code *domain.ReferralCode) (bool, error)
+	CountByFingerprint(ctx context.Context, deviceID, ipAddress string, since time.Time) (byDevice, byIP int64, err error)
+	Create(ctx context.Context, referral *domain.Referral) error
+}
+
+type OTPRepository interface {
+	SaveChallenge(ctx context.Context, challenge *domain.OTPChallenge) error
+	FindChallenge(ctx context.Context, id string) (*domain.OTPChallenge, error)
+	IncrementAttempts(ctx context.Context, id string) (int, error)
+	DeleteChallenge(ctx context.Context, id string) (bool, error)
+	SaveVerification(ctx context.Context, verification *domain.OTPVerification) error
+	TakeVerification(ctx context.Context, token string) (*domain.OTPVerification, error)
+	CountRequest(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, session *domain.ImpersonationSession) error
+	FindByID(ctx context.Context, id uint64) (*domain.ImpersonationSession, error)
+	End(ctx context.Context, id uint64, endedAt time.Time) (bool, error)
+	CreateRequest(ctx context.Context, request *domain.ImpersonationRequest) error
+	FindRequests(ctx context.Context, sessionID uint64) ([]domain.ImpersonationRequest, error)
+}
+
+type NonceRepository interface {
+	Claim(ctx context.Context, partnerID uint64, nonce string, ttl time.Duration) (bool, error)
+}
+
+type MaintenanceRepository interface {
+	Find(ctx context.Context, scope domain.GateScope) (*domain.MaintenanceMode, error)
+	FindAll(ctx context.Context) ([]domain.MaintenanceMode, error)
+	Save(ctx context.Context, mode *domain.MaintenanceMode) error
+	Delete(ctx context.Context, scope domain.GateScope) (bool, error)
+}
+
+type PartnerQuotaRepository interface {
+	FindByPartner(ctx context.Context, partnerID uint64) (*domain.PartnerQuota, error)
+	Upsert(ctx context.Context, quota *domain.PartnerQuota) error
+	Delete(ctx context.Context, partnerID uint64) (bool, error)
+}
+
+type QuotaCounterRepository interface {
+	Reserve(ctx context.Context, quota domain.PartnerQuota, day string, volume decimal.Decimal, expiresAt time.Time) (*domain.QuotaUsage, bool, error)
+	Release(ctx context.Context, partnerID uint64, day string, volume decimal.Decimal) error
+	Find(ctx context.Context, partnerID uint64, day string) (*domain.QuotaUsage, error)
+}
+
+type APIUsageRepository interface {
+	FindByPartner(ctx context.Context, partnerID uint64, days []time.Time) ([]domain.APIUsage, error)
+}
+
+type TransactionBatchRepository interface {
+	Create(ctx context.Context, batch *domain.TransactionBatch) error
+	FindByID(ctx context.Context, id uint64) (*domain.TransactionBatch, error)
+	FindPending(ctx context.Context, limit int) ([]domain.TransactionBatch, error)
+	ClaimItem(ctx context.Context, id uint64) (bool, error)
+	CompleteItem(ctx context.Context, item *domain.TransactionBatchItem) error
+	Complete(ctx context.Context, id uint64, completedAt time.Time) (bool, error)
+}
+
+type StatementRepository interface {
+	FindByContractNumber(ctx context.Context, contractNumber string) (*domain.TransactionStatement, error)
+	FindPending(ctx context.Context, limit int) ([]domain.TransactionStatement, error)
+	Save(ctx context.Context, statement *domain.TransactionStatement) error
+}
+
+type EmailRepository interface {
+	Create(ctx context.Context, message *domain.EmailMessage) error
+	FindDue(ctx context.Context, now time.Time, limit int) ([]domain.EmailMessage, error)
+	Claim(ctx context.Context, message *domain.EmailMessage, leaseUntil time.Time) (bool, error)
+	Update(ctx context.Context, message *domain.EmailMessage) error
+}
+
+type NotificationRepository interface {
+	FindDevices(ctx context.Context, customerID uint64) ([]domain.Device, error)
+	UpsertDevice(ctx context.Context, device *domain.Device) error
+	DeleteDevice(ctx context.Context, customerID uint64, token string) (bool, error)
+	DeleteDeviceByToken(ctx context.Context, token string) error
+	FindPreferences(ctx context.Context, customerID uint64) (*domain.NotificationPreferences, error)
+	SavePreferences(ctx context.Context, preferences *domain.NotificationPreferences) error
+}
+
+type PaymentReminderRepository interface {
+	FindUpcoming(ctx context.Context, afterID uint64, limit int) ([]domain.Transaction, error)
+	Claim(ctx context.Context, reminder *domain.PaymentReminder) (bool, error)
+	Release(ctx context.Context, id uint64) error
+}
+
+type MonthlyStatementRepository interface {
+	FindRecipients(ctx context.Context, period string, from, to time.Time, limit int) ([]domain.Customer, error)
+	FindContracts(ctx context.Context, customerID uint64) ([]domain.Transaction, error)
+	FindPayments(ctx context.Context, customerID uint64, from, to time.Time) ([]domain.Payment, error)
+	Create(ctx context.Context, statement *domain.MonthlyStatement) (bool, error)
+	SetOptOut(ctx context.Context, customerID uint64, optOut bool) (bool, error)
+}
+
+type CustomerNoteRepository interface {
+	Create(ctx context.Context, note *domain.CustomerNote) error
+	FindByID(ctx context.Context, id uint64) (*domain.CustomerNote, error)
+	FindByCustomer(ctx context.Context, customerID uint64) ([]domain.CustomerNote, error)
+	Update(ctx context.Context, note *domain.CustomerNote) (bool, error)
+}
+
+type TransactionAttachmentRepository interface {
+	FindTransaction(ctx context.Context, id uint64) (*domain.Transaction, error)
+	Create(ctx context.Context, attachment *domain.TransactionAttachment) error
+	FindByID(ctx context.Context, id uint64) (*domain.TransactionAttachment, error)
+	FindByTransaction(ctx context.Context, transactionID uint64) ([]domain.TransactionAttachment, error)
+	Delete(ctx context.Context, id, deletedBy uint64, deletedAt time.Time) (bool, error)
+}
+
+type RegionRepository interface {
+	FindAll(ctx context.Context) ([]domain.Region, error)
+	FindByCode(ctx context.Context, code string) (*domain.Region, error)
+	Upsert(ctx context.Context, region *domain.Region) error
+}
+
+// AssetCategoryRepository stores the asset categories of the reference data.
+type AssetCategoryRepository interface {
+	Upsert(ctx context.Context, category *domain.AssetCategory) error
+}
+
+// ContractSequenceRepository must be built on the database transaction that
+// stores the contract, the sequence row stays locked until that commits.
+type ContractSequenceRepository interface {
+	Next(ctx context.Context, sandbox bool, regionCode, day string) (uint64, error)
+}
+
+type CustomerExposureRepository interface {
+	Find(ctx context.Context, customerID uint64, tenorID uint) (*domain.CustomerExposure, error)
+	FindAll(ctx context.Context) ([]domain.CustomerExposure, error)
+	Add(ctx context.Context, customerID uint64, tenorID uint, amount decimal.Decimal) error
+	Repair(ctx context.Context, customerID uint64, tenorID uint) (bool, error)
+	Recompute(ctx context.Context, customerID uint64) error
+	SumTransactions(ctx context.Context) ([]domain.CustomerExposure, error)
+}
+
+// CustomerEventRepository is append-only. Build it on the database
+// transaction of the change being recorded so the event commits with it.
+type CustomerEventRepository interface {
+	Append(ctx context.Context, event *domain.CustomerEvent) error
+	FindByCustomer(ctx context.Context, customerID uint64, params domain.Params) ([]domain.CustomerEvent, int64, error)
+	// FindAfter returns up to limit events of the given types with an ID
+	// above afterID, oldest first, so the table can be followed as a feed
+	// starting from LatestID.
+	FindAfter(ctx context.Context, afterID uint64, types []domain.CustomerEventType, limit int) ([]domain.CustomerEvent, error)
+	// LatestID returns the highest event ID, 0 while there are none.
+	LatestID(ctx context.Context) (uint64, error)
+	// CountSince counts the events of eventType that occurred at or after since.
+	CountSince(ctx context.Context, eventType domain.CustomerEventType, since time.Time) (int64, error)
+}
+
+// CustomerRestrictionRepository stores account suspensions and frozen
+// limits. Lift only touches restrictions that have not been lifted yet.
+type CustomerRestrictionRepository interface {
+	Create(ctx context.Context, restriction *domain.CustomerRestriction) error
+	FindActive(ctx context.Context, customerID uint64, now time.Time) ([]domain.CustomerRestriction, error)
+	FindByCustomer(ctx context.Context, customerID uint64) ([]domain.CustomerRestriction, error)
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]domain.CustomerRestriction, error)
+	Lift(ctx context.Context, id uint64, liftedBy *uint64, at time.Time) (bool, error)
+}
+
+// DormancyRepository finds and flags inactive customers. A customer is
+// inactive when nothing happened on their account since a cutoff: no
+// timeline event, no new contract and no contract still running. MarkDormant
+// re-checks the same condition so activity racing the job wins.
+type DormancyRepository interface {
+	FindInactive(ctx context.Context, cutoff time.Time, limit int) ([]domain.Customer, error)
+	MarkDormant(ctx context.Context, customerID uint64, cutoff, at time.Time) (bool, error)
+	Reactivate(ctx context.Context, customerID uint64) (bool, error)
+	Stats(ctx context.Context, since time.Time) (*domain.DormancyStats, error)
+}
+
+// AccountClosureRepository closes customer accounts and later anonymizes
+// them. Close only applies to an account that is not closed yet and
+// Anonymize only to a closure not anonymized yet, so a retried request or
+// job run changes nothing twice.
+type AccountClosureRepository interface {
+	Blockers(ctx context.Context, customerID uint64) (*domain.ClosureBlockers, error)
+	Close(ctx context.Context, closure *domain.AccountClosure) (bool, error)
+	IsClosed(ctx context.Context, customerID uint64) (bool, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]domain.AccountClosure, error)
+	Anonymize(ctx context.Context, closure domain.AccountClosure, at time.Time) (bool, error)
+}
+
+// CalendarTokenRepository stores the tokens of installment calendar
+// subscriptions. Revoke ends every active token of a contract and returns
+// how many there were.
+type CalendarTokenRepository interface {
+	Create(ctx context.Context, token *domain.CalendarToken) error
+	Revoke(ctx context.Context, customerID uint64, contractNumber string, at time.Time) (int64, error)
+	FindActiveByHash(ctx context.Context, hash string) (*domain.CalendarToken, error)
+}
+
+// DueDateRepository stores the holiday calendar and the due date policy.
+// FindHolidays returns the holidays from from up to, but excluding, to.
+// FindPolicy returns nil when no policy has been saved yet.
+type DueDateRepository interface {
+	FindHolidays(ctx context.Context, from, to time.Time) ([]domain.Holiday, error)
+	FindAllHolidays(ctx context.Context) ([]domain.Holiday, error)
+	UpsertHoliday(ctx context.Context, holiday *domain.Holiday) error
+	DeleteHoliday(ctx context.Context, date time.Time) (bool, error)
+	FindPolicy(ctx context.Context) (*domain.DueDatePolicy, error)
+	SavePolicy(ctx context.Context, policy *domain.DueDatePolicy) error
+}
+
+// AMLRepository screens transactions and stores the AML cases they raise.
+// Sandbox transactions are never screened. UpdateCase only applies while
+// the case is still in status from, so two reviewers cannot both move it.
+type AMLRepository interface {
+	FindUnscreened(ctx context.Context, limit int) ([]domain.Transaction, error)
+	CountRecent(ctx context.Context, customerID uint64, from, to time.Time) (int64, error)
+	HasRecentCase(ctx context.Context, customerID uint64, rule domain.AMLRule, from time.Time) (bool, error)
+	CreateCase(ctx context.Context, amlCase *domain.AMLCase) error
+	MarkScreened(ctx context.Context, transactionID uint64, at time.Time) error
+	FindCaseByID(ctx context.Context, id uint64) (*domain.AMLCase, error)
+	FindCasesPaginated(ctx context.Context, params domain.Params) ([]domain.AMLCase, int64, error)
+	UpdateCase(ctx context.Context, amlCase *domain.AMLCase, from domain.AMLCaseStatus) (bool, error)
+	FindForExport(ctx context.Context, from, to time.Time, status domain.AMLCaseStatus) ([]domain.AMLCase, error)
+}
+
+// CollectionRepository opens collection cases for delinquent contracts and
+// stores the work done on them. FindExposures pages through active contracts
+// that have no active case yet. UpdateCase only applies while the case is
+// still in status from, so two collectors cannot both move it. AddActivity
+// returns false when the case is missing or already closed.
+type CollectionRepository interface {
+	FindExposures(ctx context.Context, afterID uint64, limit int) ([]domain.AgingExposure, error)
+	CreateCase(ctx context.Context, collectionCase *domain.CollectionCase) error
+	FindCaseByID(ctx context.Context, id uint64) (*domain.CollectionCase, error)
+	FindCasesPaginated(ctx context.Context, params domain.Params) ([]domain.CollectionCase, int64, error)
+	UpdateCase(ctx context.Context, collectionCase *domain.CollectionCase, from domain.CollectionCaseStatus) (bool, error)
+	AddActivity(ctx context.Context, activity *domain.CollectionActivity) (bool, error)
+	FindActivities(ctx context.Context, caseID uint64) ([]domain.CollectionActivity, error)
+	Dashboard(ctx context.Context, today time.Time) (*domain.CollectionDashboard, error)
+}
+
+// WriteOffRepository stores write-offs and the recoveries received on them.
+// Build it on the database transaction of a review or recovery so the lock
+// taken by FindByIDWithLock holds until commit. Report groups approved
+// write-offs by the month they were reviewed in, from and to being the first
+// day of the first and last month.
+type WriteOffRepository interface {
+	Create(ctx context.Context, writeOff *domain.WriteOff) error
+	Update(ctx context.Context, writeOff *domain.WriteOff) error
+	FindByID(ctx context.Context, id uint64) (*domain.WriteOff, error)
+	FindByIDWithLock(ctx context.Context, id uint64) (*domain.WriteOff, error)
+	FindPendingByTransactionID(ctx context.Context, transactionID uint64) (*domain.WriteOff, error)
+	FindPaginated(ctx context.Context, params domain.Params) ([]domain.WriteOff, int64, error)
+	AddRecovery(ctx context.Context, recovery *domain.WriteOffRecovery) error
+	FindRecoveries(ctx context.Context, writeOffID uint64) ([]domain.WriteOffRecovery, error)
+	Report(ctx context.Context, from, to time.Time) ([]domain.WriteOffPeriod, error)
+}
+
+// LedgerRepository is append-only. Post writes the entries of one posting
+// together, build it on the database transaction of the change being posted.
+type LedgerRepository interface {
+	Post(ctx context.Context, entries []domain.LedgerEntry) error
+	FindByTransactionID(ctx context.Context, transactionID uint64) ([]domain.LedgerEntry, error)
+}
+
+// ConcentrationRepository stores the portfolio concentration limits and
+// sums the active, non-sandbox principal in IDR they are checked against.
+// SumPrincipal groups it by the values of a dimension, largest first, and
+// SumPrincipalFor sums a single value. CreateLimit wraps gorm.ErrDuplicatedKey
+// when the value already has a limit.
+type ConcentrationRepository interface {
+	FindLimits(ctx context.Context) ([]domain.ConcentrationLimit, error)
+	FindLimitByID(ctx context.Context, id uint64) (*domain.ConcentrationLimit, error)
+	FindLimit(ctx context.Context, dimension domain.ConcentrationDimension, value string) (*domain.ConcentrationLimit, error)
+	CreateLimit(ctx context.Context, limit *domain.ConcentrationLimit) error
+	UpdateLimit(ctx context.Context, limit *domain.ConcentrationLimit) error
+	DeleteLimit(ctx context.Context, id uint64) (bool, error)
+	SetBreachedAt(ctx context.Context, id uint64, breachedAt *time.Time) error
+	TotalPrincipal(ctx context.Context) (decimal.Decimal, error)
+	SumPrincipal(ctx context.Context, dimension domain.ConcentrationDimension) ([]domain.Concentration, error)
+	SumPrincipalFor(ctx context.Context, dimension domain.ConcentrationDimension, value string) (decimal.Decimal, error)
+}
+
+// MediaRepository tells which uploaded file URLs are still stored on a
+// record: customer KTP and selfie photos and salary change payslips.
+type MediaRepository interface {
+	FindReferenced(ctx context.Context, urls []string) (map[string]bool, error)
+}
+
+type PartnerDebugRepository interface {
+	SetDebugUntil(ctx context.Context, partnerID uint64, sandbox bool, until *time.Time) (bool, error)
+	Create(ctx context.Context, record *domain.PartnerDebugRecord) error
+	Trim(ctx context.Context, partnerID uint64, sandbox bool, keep int) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	FindByID(ctx context.Context, partnerID uint64, sandbox bool, id uint64) (*domain.PartnerDebugRecord, error)
+	FindPaginated(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) ([]domain.PartnerDebugRecord, int64, error)
 }
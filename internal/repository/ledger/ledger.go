@@ -0,0 +1,175 @@
+package ledgerrepo
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ledgerRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Post implements LedgerRepository.
+func (r *ledgerRepository) Post(ctx context.Context, entries []domain.LedgerEntry) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "post_ledger_entries", "insert")
+	defer done()
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	data := model.LedgerEntriesFromEntity(entries)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error posting ledger entries", err,
+			zap.String("reference_type", string(entries[0].ReferenceType)),
+			zap.Uint64("reference_id", entries[0].ReferenceID),
+		)
+		return err
+	}
+
+	for i := range entries {
+		entries[i].ID = data[i].ID
+		entries[i].CreatedAt = data[i].CreatedAt
+	}
+
+	r.documentsInserted.Add(ctx, int64(len(entries)),
+		metric.WithAttributes(
+			attribute.String("table", "ledger_entries"),
+		),
+	)
+
+	r.log.Info("Ledger entries posted",
+		zap.String("reference_type", string(entries[0].ReferenceType)),
+		zap.Uint64("reference_id", entries[0].ReferenceID),
+		zap.Int("entries", len(entries)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindByTransactionID implements LedgerRepository.
+func (r *ledgerRepository) FindByTransactionID(ctx context.Context, transactionID uint64) ([]domain.LedgerEntry, error) {
+	done := r.begin(ctx, "find_ledger_entries_by_transaction_id", "select")
+	defer done()
+
+	var entries []model.LedgerEntry
+	err := r.db.WithContext(ctx).
+		Where("transaction_id = ?", transactionID).
+		Order("id ASC").
+		Find(&entries).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding ledger entries", err, zap.Uint64("transaction_id", transactionID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(entries)),
+		metric.WithAttributes(
+			attribute.String("table", "ledger_entries"),
+		),
+	)
+
+	return model.LedgerEntriesToEntity(entries), nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *ledgerRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "ledger_entries"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "ledger_entries"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *ledgerRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "ledger_entries"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewLedgerRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.LedgerRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &ledgerRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
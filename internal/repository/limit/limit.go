@@ -3,6 +3,7 @@ package limitrepo
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
@@ -12,7 +13,6 @@ import (
 	"gorm.io/gorm/clause"
 
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -21,9 +21,7 @@ import (
 type limitRepository struct {
 	db                 *gorm.DB
 	meter              metric.Meter
-	tracer             trace.Tracer
 	log                *zap.Logger
-	queryDuration      metric.Float64Histogram
 	queryCount         metric.Int64Counter
 	errorCount         metric.Int64Counter
 	connectionGauge    metric.Int64UpDownCounter
@@ -32,14 +30,12 @@ type limitRepository struct {
 }
 
 // FindAllByCustomerID implements LimitRepository.
-func (l *limitRepository) FindAllByCustomerID(ctx context.Context, customerID uint64) ([]domain.CustomerLimit, error) {
-	ctx, span := l.tracer.Start(ctx, "repository.FindAllByCustomerID")
-	defer span.End()
-
-	start := time.Now()
+func (l *limitRepository) FindAllByCustomerID(ctx context.Context, customerID uint64, at time.Time) ([]domain.CustomerLimit, error) {
+	span := trace.SpanFromContext(ctx)
 
 	l.log.Debug("Find all limits by customer ID",
 		zap.Uint64("customer_id", customerID),
+		zap.Time("at", at),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
@@ -63,18 +59,14 @@ func (l *limitRepository) FindAllByCustomerID(ctx context.Context, customerID ui
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select"),
-		attribute.String("db.table", "customer_limits"),
-		attribute.Int64("customer.id", int64(customerID)),
-	)
-
 	var limits []model.CustomerLimit
-	err := l.db.WithContext(ctx).Where("customer_id = ?", customerID).Find(&limits).Error
+	err := l.db.WithContext(ctx).
+		Preload("Tenor").
+		Where("customer_id = ?", customerID).
+		Where(inForceClause, at, at).
+		Order("tenor_id ASC").
+		Find(&limits).Error
 	if err != nil {
-		span.SetStatus(codes.Error, "Error finding limits by customer ID")
-		span.RecordError(err)
-
 		l.log.Error("Error finding limits by customer ID",
 			zap.Uint64("customer_id", customerID),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -89,15 +81,6 @@ func (l *limitRepository) FindAllByCustomerID(ctx context.Context, customerID ui
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		l.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select"),
-				attribute.String("table", "customer_limits"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, err
 	}
 
@@ -107,40 +90,98 @@ func (l *limitRepository) FindAllByCustomerID(ctx context.Context, customerID ui
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	l.queryDuration.Record(ctx, duration,
+	l.log.Info("Limits found by customer ID",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("retrieved_count", len(limits)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.LimitsToEntity(limits), nil
+}
+
+// FindHistoryByCustomerID implements LimitRepository.
+func (l *limitRepository) FindHistoryByCustomerID(ctx context.Context, customerID uint64) ([]domain.LimitChange, error) {
+	span := trace.SpanFromContext(ctx)
+
+	l.log.Debug("Find limit history by customer ID",
+		zap.Uint64("customer_id", customerID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	l.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_history_by_customer_id"),
+			attribute.String("table", "customer_limit_history"),
+		),
+	)
+	defer l.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_history_by_customer_id"),
+			attribute.String("table", "customer_limit_history"),
+		),
+	)
+
+	l.queryCount.Add(ctx, 1,
 		metric.WithAttributes(
 			attribute.String("operation", "select"),
-			attribute.String("table", "customer_limits"),
-			attribute.String("status", "success"),
+			attribute.String("table", "customer_limit_history"),
 		),
 	)
 
-	l.log.Info("Limits found by customer ID",
+	var history []model.CustomerLimitHistory
+	err := l.db.WithContext(ctx).
+		Preload("Tenor").
+		Where("customer_id = ?", customerID).
+		Order("changed_at DESC, id DESC").
+		Find(&history).Error
+	if err != nil {
+		l.log.Error("Error finding limit history by customer ID",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		l.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select"),
+				attribute.String("table", "customer_limit_history"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return nil, err
+	}
+
+	l.documentsRetrieved.Add(ctx, int64(len(history)),
+		metric.WithAttributes(
+			attribute.String("table", "customer_limit_history"),
+		),
+	)
+
+	l.log.Info("Limit history found by customer ID",
 		zap.Uint64("customer_id", customerID),
-		zap.Int("retrieved_count", len(limits)),
-		zap.Float64("duration_ms", duration),
+		zap.Int("retrieved_count", len(history)),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Limits found successfully")
-	span.SetAttributes(attribute.Int("result.retrieved", len(limits)))
-
-	return model.LimitsToEntity(limits), nil
+	return model.LimitHistoryToEntity(history), nil
 }
 
+// inForceClause selects the limit periods that apply at the time given
+// twice as its arguments.
+const inForceClause = "effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)"
+
+// errStaleVersion rolls back UpsertMany when a limit changed since it was read.
+var errStaleVersion = errors.New("customer limit version is stale")
+
 // UpsertMany implements LimitRepository.
-func (l *limitRepository) UpsertMany(ctx context.Context, limits []domain.CustomerLimit) error {
-	ctx, span := l.tracer.Start(ctx, "repository.UpsertMany")
-	defer span.End()
+func (l *limitRepository) UpsertMany(ctx context.Context, limits []domain.CustomerLimit, changedBy uint64) (bool, error) {
+	span := trace.SpanFromContext(ctx)
 
 	if len(limits) == 0 {
-		span.SetStatus(codes.Ok, "No limits to upsert")
-		return nil
+		return true, nil
 	}
 
-	start := time.Now()
-
 	l.log.Debug("Upserting many limits",
 		zap.Int("count", len(limits)),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -166,24 +207,147 @@ func (l *limitRepository) UpsertMany(ctx context.Context, limits []domain.Custom
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "upsert"),
-		attribute.String("db.table", "customer_limits"),
-		attribute.Int("limits.count", len(limits)),
-	)
+	// Riwayat ditulis dalam transaksi yang sama dengan upsert,
+	// sehingga nilai limit lama tidak pernah hilang tanpa jejak
+	now := time.Now()
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pairs := make([][]any, len(limits))
+		for i, limit := range limits {
+			pairs[i] = []any{limit.CustomerID, limit.TenorID}
+		}
 
-	// Menggunakan OnConflict untuk melakukan UPSERT
-	// Jika terdapat konflik pada composite primary key (customer_id, tenor_id),
-	// perbarui kolom 'limit_amount'
-	err := l.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "customer_id"}, {Name: "tenor_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"limit_amount"}),
-	}).Create(&limits).Error
+		// Semua periode tenor dikunci, termasuk yang sudah lewat dan yang
+		// baru dijadwalkan, karena periode baru bisa menyisip di antaranya
+		var existing []model.CustomerLimit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("(customer_id, tenor_id) IN ?", pairs).
+			Order("effective_from ASC").
+			Find(&existing).Error; err != nil {
+			return err
+		}
 
-	if err != nil {
-		span.SetStatus(codes.Error, "Error upserting limits")
-		span.RecordError(err)
+		periods := make(map[[2]uint64][]model.CustomerLimit, len(limits))
+		for _, limit := range existing {
+			key := [2]uint64{limit.CustomerID, uint64(limit.TenorID)}
+			periods[key] = append(periods[key], limit)
+		}
+
+		var history []model.CustomerLimitHistory
+		for _, limit := range limits {
+			key := [2]uint64{limit.CustomerID, uint64(limit.TenorID)}
+			rows := periods[key]
+
+			from := limit.EffectiveFrom
+			if from.IsZero() {
+				from = now
+			}
+			from = from.Truncate(time.Microsecond)
+
+			// Versi dicek terhadap periode yang berlaku saat limit baru
+			// mulai, tenor tanpa limit saat itu berada di versi 0. Baris
+			// yang ditulis membawa versi berikutnya dari seluruh periode.
+			current := -1
+			var latest uint64
+			for i, row := range rows {
+				if !row.EffectiveFrom.After(from) && (row.EffectiveTo == nil || row.EffectiveTo.After(from)) {
+					current = i
+				}
+				latest = max(latest, row.Version)
+			}
+			var version uint64
+			if current >= 0 {
+				version = rows[current].Version
+			}
+			if limit.Version != version {
+				return errStaleVersion
+			}
+
+			change := model.CustomerLimitHistory{
+				CustomerID:    limit.CustomerID,
+				TenorID:       limit.TenorID,
+				NewAmount:     limit.LimitAmount,
+				Currency:      limit.Currency,
+				ChangedBy:     changedBy,
+				EffectiveFrom: &from,
+			}
+
+			row := model.CustomerLimit{
+				CustomerID:    limit.CustomerID,
+				TenorID:       limit.TenorID,
+				LimitAmount:   limit.LimitAmount,
+				Currency:      limit.Currency,
+				Version:       latest + 1,
+				EffectiveFrom: from,
+			}
+			switch {
+			case current >= 0:
+				prev := rows[current]
+				// Limit yang ditulis ulang dengan nilai sama bukan perubahan
+				if prev.LimitAmount.Equal(limit.LimitAmount) && prev.Currency == limit.Currency {
+					continue
+				}
+				change.PreviousAmount = &prev.LimitAmount
+				change.PreviousCurrency = prev.Currency
+
+				if prev.EffectiveFrom.Equal(from) {
+					// Periode yang mulai di waktu yang sama ditimpa
+					if err := tx.Model(&model.CustomerLimit{}).
+						Where("customer_id = ? AND tenor_id = ? AND effective_from = ?", prev.CustomerID, prev.TenorID, prev.EffectiveFrom).
+						Updates(map[string]any{"limit_amount": row.LimitAmount, "currency": row.Currency, "version": row.Version}).Error; err != nil {
+						return err
+					}
+					row.EffectiveTo = prev.EffectiveTo
+					rows[current] = row
+					break
+				}
+
+				// Periode yang berlaku dipotong, periode baru mewarisi
+				// akhirnya sehingga jadwal berikutnya tetap berlaku
+				if err := tx.Model(&model.CustomerLimit{}).
+					Where("customer_id = ? AND tenor_id = ? AND effective_from = ?", prev.CustomerID, prev.TenorID, prev.EffectiveFrom).
+					Update("effective_to", from).Error; err != nil {
+					return err
+				}
+				row.EffectiveTo = prev.EffectiveTo
+				rows[current].EffectiveTo = &from
+				if err := tx.Create(&row).Error; err != nil {
+					return err
+				}
+				rows = slices.Insert(rows, current+1, row)
+			default:
+				// Belum ada limit saat itu, periode baru berakhir ketika
+				// limit yang sudah dijadwalkan sesudahnya mulai
+				next := slices.IndexFunc(rows, func(r model.CustomerLimit) bool { return r.EffectiveFrom.After(from) })
+				if next >= 0 {
+					until := rows[next].EffectiveFrom
+					row.EffectiveTo = &until
+				} else {
+					next = len(rows)
+				}
+				if err := tx.Create(&row).Error; err != nil {
+					return err
+				}
+				rows = slices.Insert(rows, next, row)
+			}
+			periods[key] = rows
+			history = append(history, change)
+		}
+
+		if len(history) == 0 {
+			return nil
+		}
+		return tx.Create(&history).Error
+	})
 
+	if errors.Is(err, errStaleVersion) {
+		l.log.Debug("Limits not upserted, version is stale",
+			zap.Int("count", len(limits)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+
+		return false, nil
+	}
+	if err != nil {
 		l.log.Error("Error upserting limits",
 			zap.Int("count", len(limits)),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -198,16 +362,7 @@ func (l *limitRepository) UpsertMany(ctx context.Context, limits []domain.Custom
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		l.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "upsert"),
-				attribute.String("table", "customer_limits"),
-				attribute.String("status", "error"),
-			),
-		)
-
-		return err
+		return false, err
 	}
 
 	l.documentsInserted.Add(ctx, int64(len(limits)),
@@ -216,37 +371,23 @@ func (l *limitRepository) UpsertMany(ctx context.Context, limits []domain.Custom
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	l.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "upsert"),
-			attribute.String("table", "customer_limits"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	l.log.Info("Limits upserted successfully",
 		zap.Int("upserted_count", len(limits)),
-		zap.Float64("duration_ms", duration),
+		zap.Uint64("changed_by", changedBy),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Limits upserted successfully")
-	span.SetAttributes(attribute.Int("result.upserted", len(limits)))
-
-	return nil
+	return true, nil
 }
 
 // FindByCustomerIDAndTenorID implements LimitRepository.
-func (l *limitRepository) FindByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint) (*domain.CustomerLimit, error) {
-	ctx, span := l.tracer.Start(ctx, "repository.FindByCustomerIDAndTenorID")
-	defer span.End()
-
-	start := time.Now()
+func (l *limitRepository) FindByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint, at time.Time) (*domain.CustomerLimit, error) {
+	span := trace.SpanFromContext(ctx)
 
 	l.log.Debug("Find limit by customer and tenor ID",
 		zap.Uint64("customer_id", customerID),
 		zap.Uint("tenor_id", tenorID),
+		zap.Time("at", at),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
@@ -270,39 +411,18 @@ func (l *limitRepository) FindByCustomerIDAndTenorID(ctx context.Context, custom
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select"),
-		attribute.String("db.table", "customer_limits"),
-		attribute.Int64("customer.id", int64(customerID)),
-		attribute.Int("tenor.id", int(tenorID)),
-	)
-
 	var limit model.CustomerLimit
-	if err := l.db.WithContext(ctx).Where("customer_id = ? AND tenor_id = ?", customerID, tenorID).First(&limit).Error; err != nil {
+	if err := l.db.WithContext(ctx).Where("customer_id = ? AND tenor_id = ?", customerID, tenorID).Where(inForceClause, at, at).First(&limit).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			span.SetStatus(codes.Ok, "Limit not found")
-
 			l.log.Info("Limit not found by customer and tenor ID",
 				zap.Uint64("customer_id", customerID),
 				zap.Uint("tenor_id", tenorID),
 				zap.String("trace_id", span.SpanContext().TraceID().String()),
 			)
 
-			duration := float64(time.Since(start).Milliseconds())
-			l.queryDuration.Record(ctx, duration,
-				metric.WithAttributes(
-					attribute.String("operation", "select"),
-					attribute.String("table", "customer_limits"),
-					attribute.String("status", "not_found"),
-				),
-			)
-
 			return nil, nil
 		}
 
-		span.SetStatus(codes.Error, "Error finding limit by customer and tenor ID")
-		span.RecordError(err)
-
 		l.log.Error("Error finding limit by customer and tenor ID",
 			zap.Uint64("customer_id", customerID),
 			zap.Uint("tenor_id", tenorID),
@@ -318,15 +438,6 @@ func (l *limitRepository) FindByCustomerIDAndTenorID(ctx context.Context, custom
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		l.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select"),
-				attribute.String("table", "customer_limits"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, err
 	}
 
@@ -336,39 +447,20 @@ func (l *limitRepository) FindByCustomerIDAndTenorID(ctx context.Context, custom
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	l.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "select"),
-			attribute.String("table", "customer_limits"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	l.log.Info("Limit found by customer and tenor ID",
 		zap.Uint64("customer_id", customerID),
 		zap.Uint("tenor_id", tenorID),
-		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Limit found successfully")
-
 	return model.LimitToEntity(limit), nil
 }
 
 func NewLimitRepository(
 	db *gorm.DB,
 	meter metric.Meter,
-	tracer trace.Tracer,
 	log *zap.Logger,
 ) repository.LimitRepository {
-	queryDuration, _ := meter.Float64Histogram(
-		"db.query.duration",
-		metric.WithDescription("Duration of database queries"),
-		metric.WithUnit("ms"),
-	)
-
 	queryCount, _ := meter.Int64Counter(
 		"db.query.count",
 		metric.WithDescription("Number of database queries"),
@@ -402,9 +494,7 @@ func NewLimitRepository(
 	return &limitRepository{
 		db:                 db,
 		meter:              meter,
-		tracer:             tracer,
 		log:                log,
-		queryDuration:      queryDuration,
 		queryCount:         queryCount,
 		errorCount:         errorCount,
 		connectionGauge:    connectionGauge,
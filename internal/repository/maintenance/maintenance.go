@@ -0,0 +1,335 @@
+package maintenancerepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/redis/go-redis/v9"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	modesKeyspace = "maintenance_modes"
+	// Semua mode disimpan dalam satu hash, field-nya adalah scope
+	modesKey = "maintenance:modes"
+)
+
+type maintenanceRepository struct {
+	client             *redis.Client
+	meter              metric.Meter
+	tracer             trace.Tracer
+	log                *zap.Logger
+	queryDuration      metric.Float64Histogram
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+type modeRecord struct {
+	Message   string     `json:"message"`
+	Until     *time.Time `json:"until,omitempty"`
+	UpdatedBy uint64     `json:"updated_by"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (rec modeRecord) toEntity(scope string) domain.MaintenanceMode {
+	return domain.MaintenanceMode{
+		Scope:     domain.GateScope(scope),
+		Message:   rec.Message,
+		Until:     rec.Until,
+		UpdatedBy: rec.UpdatedBy,
+		UpdatedAt: rec.UpdatedAt,
+	}
+}
+
+// Find implements MaintenanceRepository.
+func (r *maintenanceRepository) Find(ctx context.Context, scope domain.GateScope) (*domain.MaintenanceMode, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.FindMaintenanceMode")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("maintenance.scope", string(scope)))
+
+	done := r.begin(ctx, span, "find_maintenance_mode", modesKeyspace, "hget")
+	defer done()
+
+	raw, err := r.client.HGet(ctx, modesKey, string(scope)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			span.SetStatus(codes.Ok, "Maintenance mode not found")
+			r.recordDuration(ctx, start, modesKeyspace, "hget", "not_found")
+			return nil, nil
+		}
+
+		r.recordError(ctx, span, start, modesKeyspace, "hget", "Error finding maintenance mode", err, zap.String("scope", string(scope)))
+		return nil, err
+	}
+
+	var rec modeRecord
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		r.recordError(ctx, span, start, modesKeyspace, "hget", "Error decoding maintenance mode", err, zap.String("scope", string(scope)))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", modesKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, modesKeyspace, "hget", "success")
+	span.SetStatus(codes.Ok, "Maintenance mode found successfully")
+
+	mode := rec.toEntity(string(scope))
+	return &mode, nil
+}
+
+// FindAll implements MaintenanceRepository.
+func (r *maintenanceRepository) FindAll(ctx context.Context) ([]domain.MaintenanceMode, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.FindAllMaintenanceModes")
+	defer span.End()
+
+	start := time.Now()
+
+	done := r.begin(ctx, span, "find_all_maintenance_modes", modesKeyspace, "hgetall")
+	defer done()
+
+	fields, err := r.client.HGetAll(ctx, modesKey).Result()
+	if err != nil {
+		r.recordError(ctx, span, start, modesKeyspace, "hgetall", "Error finding maintenance modes", err)
+		return nil, err
+	}
+
+	modes := make([]domain.MaintenanceMode, 0, len(fields))
+	for scope, raw := range fields {
+		var rec modeRecord
+		if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+			r.recordError(ctx, span, start, modesKeyspace, "hgetall", "Error decoding maintenance mode", err, zap.String("scope", scope))
+			return nil, err
+		}
+		modes = append(modes, rec.toEntity(scope))
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i].Scope < modes[j].Scope })
+
+	r.documentsRetrieved.Add(ctx, int64(len(modes)),
+		metric.WithAttributes(
+			attribute.String("table", modesKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, modesKeyspace, "hgetall", "success")
+	span.SetStatus(codes.Ok, "Maintenance modes found successfully")
+	span.SetAttributes(attribute.Int("result.retrieved", len(modes)))
+
+	return modes, nil
+}
+
+// Save implements MaintenanceRepository.
+func (r *maintenanceRepository) Save(ctx context.Context, mode *domain.MaintenanceMode) error {
+	ctx, span := r.tracer.Start(ctx, "repository.SaveMaintenanceMode")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("maintenance.scope", string(mode.Scope)))
+
+	done := r.begin(ctx, span, "save_maintenance_mode", modesKeyspace, "hset")
+	defer done()
+
+	raw, err := json.Marshal(modeRecord{
+		Message:   mode.Message,
+		Until:     mode.Until,
+		UpdatedBy: mode.UpdatedBy,
+		UpdatedAt: mode.UpdatedAt,
+	})
+	if err != nil {
+		r.recordError(ctx, span, start, modesKeyspace, "hset", "Error encoding maintenance mode", err, zap.String("scope", string(mode.Scope)))
+		return err
+	}
+
+	if err := r.client.HSet(ctx, modesKey, string(mode.Scope), raw).Err(); err != nil {
+		r.recordError(ctx, span, start, modesKeyspace, "hset", "Error saving maintenance mode", err, zap.String("scope", string(mode.Scope)))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", modesKeyspace),
+		),
+	)
+
+	duration := r.recordDuration(ctx, start, modesKeyspace, "hset", "success")
+
+	r.log.Info("Maintenance mode saved",
+		zap.String("scope", string(mode.Scope)),
+		zap.Uint64("updated_by", mode.UpdatedBy),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	span.SetStatus(codes.Ok, "Maintenance mode saved successfully")
+
+	return nil
+}
+
+// Delete implements MaintenanceRepository.
+func (r *maintenanceRepository) Delete(ctx context.Context, scope domain.GateScope) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.DeleteMaintenanceMode")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("maintenance.scope", string(scope)))
+
+	done := r.begin(ctx, span, "delete_maintenance_mode", modesKeyspace, "hdel")
+	defer done()
+
+	deleted, err := r.client.HDel(ctx, modesKey, string(scope)).Result()
+	if err != nil {
+		r.recordError(ctx, span, start, modesKeyspace, "hdel", "Error deleting maintenance mode", err, zap.String("scope", string(scope)))
+		return false, err
+	}
+
+	if deleted == 0 {
+		span.SetStatus(codes.Ok, "Maintenance mode not found")
+		r.recordDuration(ctx, start, modesKeyspace, "hdel", "not_found")
+		return false, nil
+	}
+
+	duration := r.recordDuration(ctx, start, modesKeyspace, "hdel", "success")
+
+	r.log.Info("Maintenance mode cleared",
+		zap.String("scope", string(scope)),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	span.SetStatus(codes.Ok, "Maintenance mode deleted successfully")
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *maintenanceRepository) begin(ctx context.Context, span trace.Span, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	span.SetAttributes(
+		attribute.String("db.system", "redis"),
+		attribute.String("db.operation", dbOperation),
+		attribute.String("db.table", table),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *maintenanceRepository) recordError(
+	ctx context.Context, span trace.Span, start time.Time,
+	table, dbOperation, message string, err error, fields ...zap.Field) {
+	span.SetStatus(codes.Error, message)
+	span.RecordError(err)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+
+	r.recordDuration(ctx, start, table, dbOperation, "error")
+}
+
+func (r *maintenanceRepository) recordDuration(ctx context.Context, start time.Time, table, dbOperation, status string) float64 {
+	duration := float64(time.Since(start).Milliseconds())
+	r.queryDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("status", status),
+		),
+	)
+	return duration
+}
+
+func NewMaintenanceRepository(
+	client *redis.Client,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) repository.MaintenanceRepository {
+	queryDuration, _ := meter.Float64Histogram(
+		"db.query.duration",
+		metric.WithDescription("Duration of database queries"),
+		metric.WithUnit("ms"),
+	)
+
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &maintenanceRepository{
+		client:             client,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		queryDuration:      queryDuration,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
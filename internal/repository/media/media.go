@@ -0,0 +1,138 @@
+package mediarepo
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// referencedQuery lists the URL columns that keep an uploaded file alive.
+// A new column holding upload URLs must be added here, or the cleanup job
+// deletes its files.
+const referencedQuery = `
+SELECT ktp_photo_url FROM customers WHERE ktp_photo_url IN ?
+UNION
+SELECT selfie_photo_url FROM customers WHERE selfie_photo_url IN ?
+UNION
+SELECT payslip_url FROM salary_changes WHERE payslip_url IN ?`
+
+type mediaRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+}
+
+// FindReferenced implements MediaRepository.
+func (r *mediaRepository) FindReferenced(ctx context.Context, urls []string) (map[string]bool, error) {
+	referenced := make(map[string]bool, len(urls))
+	if len(urls) == 0 {
+		return referenced, nil
+	}
+
+	done := r.begin(ctx, "find_referenced_media", "customers", "select")
+	defer done()
+
+	var found []string
+	if err := r.db.WithContext(ctx).Raw(referencedQuery, urls, urls, urls).Scan(&found).Error; err != nil {
+		r.recordError(ctx, "customers", "select", "Error finding referenced media", err, zap.Int("urls", len(urls)))
+		return nil, err
+	}
+	for _, url := range found {
+		referenced[url] = true
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(found)),
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return referenced, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *mediaRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *mediaRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewMediaRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.MediaRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &mediaRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+	}
+}
@@ -0,0 +1,5369 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mocks/mock_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/fazamuttaqien/multifinance/internal/domain"
+	decimal "github.com/shopspring/decimal"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomerRepository is a mock of CustomerRepository interface.
+type MockCustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
+type MockCustomerRepositoryMockRecorder struct {
+	mock *MockCustomerRepository
+}
+
+// NewMockCustomerRepository creates a new mock instance.
+func NewMockCustomerRepository(ctrl *gomock.Controller) *MockCustomerRepository {
+	mock := &MockCustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByStatus mocks base method.
+func (m *MockCustomerRepository) CountByStatus(ctx context.Context, status domain.VerificationStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatus", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatus indicates an expected call of CountByStatus.
+func (mr *MockCustomerRepositoryMockRecorder) CountByStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatus", reflect.TypeOf((*MockCustomerRepository)(nil).CountByStatus), ctx, status)
+}
+
+// CreateCustomer mocks base method.
+func (m *MockCustomerRepository) CreateCustomer(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", ctx, customer)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) CreateCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).CreateCustomer), ctx, customer)
+}
+
+// FindByContact mocks base method.
+func (m *MockCustomerRepository) FindByContact(ctx context.Context, email, phone string) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByContact", ctx, email, phone)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByContact indicates an expected call of FindByContact.
+func (mr *MockCustomerRepositoryMockRecorder) FindByContact(ctx, email, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByContact", reflect.TypeOf((*MockCustomerRepository)(nil).FindByContact), ctx, email, phone)
+}
+
+// FindByID mocks base method.
+func (m *MockCustomerRepository) FindByID(ctx context.Context, id uint64) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCustomerRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCustomerRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByNIK mocks base method.
+func (m *MockCustomerRepository) FindByNIK(ctx context.Context, nik string) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNIK", ctx, nik)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNIK indicates an expected call of FindByNIK.
+func (mr *MockCustomerRepositoryMockRecorder) FindByNIK(ctx, nik any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNIK", reflect.TypeOf((*MockCustomerRepository)(nil).FindByNIK), ctx, nik)
+}
+
+// FindByNIKWithLock mocks base method.
+func (m *MockCustomerRepository) FindByNIKWithLock(ctx context.Context, nik string) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNIKWithLock", ctx, nik)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNIKWithLock indicates an expected call of FindByNIKWithLock.
+func (mr *MockCustomerRepositoryMockRecorder) FindByNIKWithLock(ctx, nik any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNIKWithLock", reflect.TypeOf((*MockCustomerRepository)(nil).FindByNIKWithLock), ctx, nik)
+}
+
+// FindPaginated mocks base method.
+func (m *MockCustomerRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.Customer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockCustomerRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockCustomerRepository)(nil).FindPaginated), ctx, params)
+}
+
+// StreamForExport mocks base method.
+func (m *MockCustomerRepository) StreamForExport(ctx context.Context, status domain.VerificationStatus, fn func(domain.Customer) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamForExport", ctx, status, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamForExport indicates an expected call of StreamForExport.
+func (mr *MockCustomerRepositoryMockRecorder) StreamForExport(ctx, status, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamForExport", reflect.TypeOf((*MockCustomerRepository)(nil).StreamForExport), ctx, status, fn)
+}
+
+// MockTenorRepository is a mock of TenorRepository interface.
+type MockTenorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenorRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTenorRepositoryMockRecorder is the mock recorder for MockTenorRepository.
+type MockTenorRepositoryMockRecorder struct {
+	mock *MockTenorRepository
+}
+
+// NewMockTenorRepository creates a new mock instance.
+func NewMockTenorRepository(ctrl *gomock.Controller) *MockTenorRepository {
+	mock := &MockTenorRepository{ctrl: ctrl}
+	mock.recorder = &MockTenorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenorRepository) EXPECT() *MockTenorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTenorRepository) Create(ctx context.Context, tenor *domain.Tenor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tenor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTenorRepositoryMockRecorder) Create(ctx, tenor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTenorRepository)(nil).Create), ctx, tenor)
+}
+
+// Delete mocks base method.
+func (m *MockTenorRepository) Delete(ctx context.Context, id uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTenorRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTenorRepository)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockTenorRepository) FindAll(ctx context.Context) ([]domain.Tenor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.Tenor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTenorRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTenorRepository)(nil).FindAll), ctx)
+}
+
+// FindByDuration mocks base method.
+func (m *MockTenorRepository) FindByDuration(ctx context.Context, durationMonths uint8) (*domain.Tenor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByDuration", ctx, durationMonths)
+	ret0, _ := ret[0].(*domain.Tenor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByDuration indicates an expected call of FindByDuration.
+func (mr *MockTenorRepositoryMockRecorder) FindByDuration(ctx, durationMonths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByDuration", reflect.TypeOf((*MockTenorRepository)(nil).FindByDuration), ctx, durationMonths)
+}
+
+// FindByID mocks base method.
+func (m *MockTenorRepository) FindByID(ctx context.Context, id uint) (*domain.Tenor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Tenor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTenorRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTenorRepository)(nil).FindByID), ctx, id)
+}
+
+// IsReferenced mocks base method.
+func (m *MockTenorRepository) IsReferenced(ctx context.Context, id uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReferenced", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsReferenced indicates an expected call of IsReferenced.
+func (mr *MockTenorRepositoryMockRecorder) IsReferenced(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReferenced", reflect.TypeOf((*MockTenorRepository)(nil).IsReferenced), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockTenorRepository) Update(ctx context.Context, tenor domain.Tenor) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, tenor)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTenorRepositoryMockRecorder) Update(ctx, tenor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTenorRepository)(nil).Update), ctx, tenor)
+}
+
+// MockLimitRepository is a mock of LimitRepository interface.
+type MockLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLimitRepositoryMockRecorder is the mock recorder for MockLimitRepository.
+type MockLimitRepositoryMockRecorder struct {
+	mock *MockLimitRepository
+}
+
+// NewMockLimitRepository creates a new mock instance.
+func NewMockLimitRepository(ctrl *gomock.Controller) *MockLimitRepository {
+	mock := &MockLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitRepository) EXPECT() *MockLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAllByCustomerID mocks base method.
+func (m *MockLimitRepository) FindAllByCustomerID(ctx context.Context, customerID uint64, at time.Time) ([]domain.CustomerLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByCustomerID", ctx, customerID, at)
+	ret0, _ := ret[0].([]domain.CustomerLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByCustomerID indicates an expected call of FindAllByCustomerID.
+func (mr *MockLimitRepositoryMockRecorder) FindAllByCustomerID(ctx, customerID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCustomerID", reflect.TypeOf((*MockLimitRepository)(nil).FindAllByCustomerID), ctx, customerID, at)
+}
+
+// FindByCustomerIDAndTenorID mocks base method.
+func (m *MockLimitRepository) FindByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint, at time.Time) (*domain.CustomerLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomerIDAndTenorID", ctx, customerID, tenorID, at)
+	ret0, _ := ret[0].(*domain.CustomerLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomerIDAndTenorID indicates an expected call of FindByCustomerIDAndTenorID.
+func (mr *MockLimitRepositoryMockRecorder) FindByCustomerIDAndTenorID(ctx, customerID, tenorID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomerIDAndTenorID", reflect.TypeOf((*MockLimitRepository)(nil).FindByCustomerIDAndTenorID), ctx, customerID, tenorID, at)
+}
+
+// FindHistoryByCustomerID mocks base method.
+func (m *MockLimitRepository) FindHistoryByCustomerID(ctx context.Context, customerID uint64) ([]domain.LimitChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHistoryByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].([]domain.LimitChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHistoryByCustomerID indicates an expected call of FindHistoryByCustomerID.
+func (mr *MockLimitRepositoryMockRecorder) FindHistoryByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHistoryByCustomerID", reflect.TypeOf((*MockLimitRepository)(nil).FindHistoryByCustomerID), ctx, customerID)
+}
+
+// UpsertMany mocks base method.
+func (m *MockLimitRepository) UpsertMany(ctx context.Context, limits []domain.CustomerLimit, changedBy uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMany", ctx, limits, changedBy)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMany indicates an expected call of UpsertMany.
+func (mr *MockLimitRepositoryMockRecorder) UpsertMany(ctx, limits, changedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMany", reflect.TypeOf((*MockLimitRepository)(nil).UpsertMany), ctx, limits, changedBy)
+}
+
+// MockTransactionRepository is a mock of TransactionRepository interface.
+type MockTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionRepositoryMockRecorder is the mock recorder for MockTransactionRepository.
+type MockTransactionRepositoryMockRecorder struct {
+	mock *MockTransactionRepository
+}
+
+// NewMockTransactionRepository creates a new mock instance.
+func NewMockTransactionRepository(ctrl *gomock.Controller) *MockTransactionRepository {
+	mock := &MockTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMany mocks base method.
+func (m *MockTransactionRepository) CreateMany(ctx context.Context, transactions []domain.Transaction, batchSize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, transactions, batchSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockTransactionRepositoryMockRecorder) CreateMany(ctx, transactions, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockTransactionRepository)(nil).CreateMany), ctx, transactions, batchSize)
+}
+
+// CreateTransaction mocks base method.
+func (m *MockTransactionRepository) CreateTransaction(ctx context.Context, tx *domain.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransaction", ctx, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransaction indicates an expected call of CreateTransaction.
+func (mr *MockTransactionRepositoryMockRecorder) CreateTransaction(ctx, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).CreateTransaction), ctx, tx)
+}
+
+// FindActiveByCustomerID mocks base method.
+func (m *MockTransactionRepository) FindActiveByCustomerID(ctx context.Context, customerID uint64) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByCustomerID indicates an expected call of FindActiveByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindActiveByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindActiveByCustomerID), ctx, customerID)
+}
+
+// FindByContractNumber mocks base method.
+func (m *MockTransactionRepository) FindByContractNumber(ctx context.Context, contractNumber string) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByContractNumber", ctx, contractNumber)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByContractNumber indicates an expected call of FindByContractNumber.
+func (mr *MockTransactionRepositoryMockRecorder) FindByContractNumber(ctx, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByContractNumber", reflect.TypeOf((*MockTransactionRepository)(nil).FindByContractNumber), ctx, contractNumber)
+}
+
+// FindPaginatedByContractNumberPrefix mocks base method.
+func (m *MockTransactionRepository) FindPaginatedByContractNumberPrefix(ctx context.Context, prefix string, params domain.Params) ([]domain.Transaction, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginatedByContractNumberPrefix", ctx, prefix, params)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginatedByContractNumberPrefix indicates an expected call of FindPaginatedByContractNumberPrefix.
+func (mr *MockTransactionRepositoryMockRecorder) FindPaginatedByContractNumberPrefix(ctx, prefix, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedByContractNumberPrefix", reflect.TypeOf((*MockTransactionRepository)(nil).FindPaginatedByContractNumberPrefix), ctx, prefix, params)
+}
+
+// FindPaginatedByCustomerID mocks base method.
+func (m *MockTransactionRepository) FindPaginatedByCustomerID(ctx context.Context, customerID uint64, params domain.Params) ([]domain.Transaction, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginatedByCustomerID", ctx, customerID, params)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginatedByCustomerID indicates an expected call of FindPaginatedByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindPaginatedByCustomerID(ctx, customerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindPaginatedByCustomerID), ctx, customerID, params)
+}
+
+// FindPaginatedByPartnerID mocks base method.
+func (m *MockTransactionRepository) FindPaginatedByPartnerID(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) ([]domain.Transaction, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginatedByPartnerID", ctx, partnerID, sandbox, params)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginatedByPartnerID indicates an expected call of FindPaginatedByPartnerID.
+func (mr *MockTransactionRepositoryMockRecorder) FindPaginatedByPartnerID(ctx, partnerID, sandbox, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedByPartnerID", reflect.TypeOf((*MockTransactionRepository)(nil).FindPaginatedByPartnerID), ctx, partnerID, sandbox, params)
+}
+
+// StreamForExport mocks base method.
+func (m *MockTransactionRepository) StreamForExport(ctx context.Context, from, to time.Time, fn func(domain.Transaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamForExport", ctx, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamForExport indicates an expected call of StreamForExport.
+func (mr *MockTransactionRepositoryMockRecorder) StreamForExport(ctx, from, to, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamForExport", reflect.TypeOf((*MockTransactionRepository)(nil).StreamForExport), ctx, from, to, fn)
+}
+
+// SumActiveMonthlyInstallmentByCustomerID mocks base method.
+func (m *MockTransactionRepository) SumActiveMonthlyInstallmentByCustomerID(ctx context.Context, customerID uint64) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumActiveMonthlyInstallmentByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumActiveMonthlyInstallmentByCustomerID indicates an expected call of SumActiveMonthlyInstallmentByCustomerID.
+func (mr *MockTransactionRepositoryMockRecorder) SumActiveMonthlyInstallmentByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumActiveMonthlyInstallmentByCustomerID", reflect.TypeOf((*MockTransactionRepository)(nil).SumActiveMonthlyInstallmentByCustomerID), ctx, customerID)
+}
+
+// SumActivePrincipalByCustomerIDAndTenorID mocks base method.
+func (m *MockTransactionRepository) SumActivePrincipalByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumActivePrincipalByCustomerIDAndTenorID", ctx, customerID, tenorID)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumActivePrincipalByCustomerIDAndTenorID indicates an expected call of SumActivePrincipalByCustomerIDAndTenorID.
+func (mr *MockTransactionRepositoryMockRecorder) SumActivePrincipalByCustomerIDAndTenorID(ctx, customerID, tenorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumActivePrincipalByCustomerIDAndTenorID", reflect.TypeOf((*MockTransactionRepository)(nil).SumActivePrincipalByCustomerIDAndTenorID), ctx, customerID, tenorID)
+}
+
+// MockPartnerRepository is a mock of PartnerRepository interface.
+type MockPartnerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerRepositoryMockRecorder is the mock recorder for MockPartnerRepository.
+type MockPartnerRepositoryMockRecorder struct {
+	mock *MockPartnerRepository
+}
+
+// NewMockPartnerRepository creates a new mock instance.
+func NewMockPartnerRepository(ctrl *gomock.Controller) *MockPartnerRepository {
+	mock := &MockPartnerRepository{ctrl: ctrl}
+	mock.recorder = &MockPartnerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerRepository) EXPECT() *MockPartnerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPartnerRepository) Create(ctx context.Context, partner *domain.Partner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, partner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPartnerRepositoryMockRecorder) Create(ctx, partner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPartnerRepository)(nil).Create), ctx, partner)
+}
+
+// FindByAPIKeyHash mocks base method.
+func (m *MockPartnerRepository) FindByAPIKeyHash(ctx context.Context, keyHash string) (*domain.Partner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAPIKeyHash", ctx, keyHash)
+	ret0, _ := ret[0].(*domain.Partner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAPIKeyHash indicates an expected call of FindByAPIKeyHash.
+func (mr *MockPartnerRepositoryMockRecorder) FindByAPIKeyHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAPIKeyHash", reflect.TypeOf((*MockPartnerRepository)(nil).FindByAPIKeyHash), ctx, keyHash)
+}
+
+// FindByEmail mocks base method.
+func (m *MockPartnerRepository) FindByEmail(ctx context.Context, email string) (*domain.Partner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.Partner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockPartnerRepositoryMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockPartnerRepository)(nil).FindByEmail), ctx, email)
+}
+
+// FindByID mocks base method.
+func (m *MockPartnerRepository) FindByID(ctx context.Context, id uint64) (*domain.Partner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Partner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPartnerRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPartnerRepository)(nil).FindByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPartnerRepository) Update(ctx context.Context, partner *domain.Partner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, partner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPartnerRepositoryMockRecorder) Update(ctx, partner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPartnerRepository)(nil).Update), ctx, partner)
+}
+
+// MockDeliveryRepository is a mock of DeliveryRepository interface.
+type MockDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDeliveryRepositoryMockRecorder is the mock recorder for MockDeliveryRepository.
+type MockDeliveryRepositoryMockRecorder struct {
+	mock *MockDeliveryRepository
+}
+
+// NewMockDeliveryRepository creates a new mock instance.
+func NewMockDeliveryRepository(ctrl *gomock.Controller) *MockDeliveryRepository {
+	mock := &MockDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryRepository) EXPECT() *MockDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeliveryRepositoryMockRecorder) Create(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeliveryRepository)(nil).Create), ctx, delivery)
+}
+
+// FindByID mocks base method.
+func (m *MockDeliveryRepository) FindByID(ctx context.Context, id uint64) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockDeliveryRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockDeliveryRepository)(nil).FindByID), ctx, id)
+}
+
+// FindPaginated mocks base method.
+func (m *MockDeliveryRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.WebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockDeliveryRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockDeliveryRepository)(nil).FindPaginated), ctx, params)
+}
+
+// FindPaginatedByPartnerID mocks base method.
+func (m *MockDeliveryRepository) FindPaginatedByPartnerID(ctx context.Context, partnerID uint64, params domain.Params) ([]domain.WebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginatedByPartnerID", ctx, partnerID, params)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginatedByPartnerID indicates an expected call of FindPaginatedByPartnerID.
+func (mr *MockDeliveryRepositoryMockRecorder) FindPaginatedByPartnerID(ctx, partnerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedByPartnerID", reflect.TypeOf((*MockDeliveryRepository)(nil).FindPaginatedByPartnerID), ctx, partnerID, params)
+}
+
+// Update mocks base method.
+func (m *MockDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeliveryRepositoryMockRecorder) Update(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeliveryRepository)(nil).Update), ctx, delivery)
+}
+
+// MockSalaryChangeRepository is a mock of SalaryChangeRepository interface.
+type MockSalaryChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSalaryChangeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSalaryChangeRepositoryMockRecorder is the mock recorder for MockSalaryChangeRepository.
+type MockSalaryChangeRepositoryMockRecorder struct {
+	mock *MockSalaryChangeRepository
+}
+
+// NewMockSalaryChangeRepository creates a new mock instance.
+func NewMockSalaryChangeRepository(ctrl *gomock.Controller) *MockSalaryChangeRepository {
+	mock := &MockSalaryChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockSalaryChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSalaryChangeRepository) EXPECT() *MockSalaryChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSalaryChangeRepository) Create(ctx context.Context, change *domain.SalaryChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSalaryChangeRepositoryMockRecorder) Create(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSalaryChangeRepository)(nil).Create), ctx, change)
+}
+
+// FindByID mocks base method.
+func (m *MockSalaryChangeRepository) FindByID(ctx context.Context, id uint64) (*domain.SalaryChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.SalaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSalaryChangeRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSalaryChangeRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByIDWithLock mocks base method.
+func (m *MockSalaryChangeRepository) FindByIDWithLock(ctx context.Context, id uint64) (*domain.SalaryChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDWithLock", ctx, id)
+	ret0, _ := ret[0].(*domain.SalaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDWithLock indicates an expected call of FindByIDWithLock.
+func (mr *MockSalaryChangeRepositoryMockRecorder) FindByIDWithLock(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDWithLock", reflect.TypeOf((*MockSalaryChangeRepository)(nil).FindByIDWithLock), ctx, id)
+}
+
+// FindPaginated mocks base method.
+func (m *MockSalaryChangeRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.SalaryChange, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.SalaryChange)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockSalaryChangeRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockSalaryChangeRepository)(nil).FindPaginated), ctx, params)
+}
+
+// FindPendingByCustomerID mocks base method.
+func (m *MockSalaryChangeRepository) FindPendingByCustomerID(ctx context.Context, customerID uint64) (*domain.SalaryChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(*domain.SalaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingByCustomerID indicates an expected call of FindPendingByCustomerID.
+func (mr *MockSalaryChangeRepositoryMockRecorder) FindPendingByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingByCustomerID", reflect.TypeOf((*MockSalaryChangeRepository)(nil).FindPendingByCustomerID), ctx, customerID)
+}
+
+// Update mocks base method.
+func (m *MockSalaryChangeRepository) Update(ctx context.Context, change *domain.SalaryChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSalaryChangeRepositoryMockRecorder) Update(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSalaryChangeRepository)(nil).Update), ctx, change)
+}
+
+// MockIncomeVerificationRepository is a mock of IncomeVerificationRepository interface.
+type MockIncomeVerificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncomeVerificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIncomeVerificationRepositoryMockRecorder is the mock recorder for MockIncomeVerificationRepository.
+type MockIncomeVerificationRepositoryMockRecorder struct {
+	mock *MockIncomeVerificationRepository
+}
+
+// NewMockIncomeVerificationRepository creates a new mock instance.
+func NewMockIncomeVerificationRepository(ctrl *gomock.Controller) *MockIncomeVerificationRepository {
+	mock := &MockIncomeVerificationRepository{ctrl: ctrl}
+	mock.recorder = &MockIncomeVerificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncomeVerificationRepository) EXPECT() *MockIncomeVerificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIncomeVerificationRepository) Create(ctx context.Context, verification *domain.IncomeVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, verification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIncomeVerificationRepositoryMockRecorder) Create(ctx, verification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIncomeVerificationRepository)(nil).Create), ctx, verification)
+}
+
+// FindByCustomerID mocks base method.
+func (m *MockIncomeVerificationRepository) FindByCustomerID(ctx context.Context, customerID uint64) ([]domain.IncomeVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].([]domain.IncomeVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomerID indicates an expected call of FindByCustomerID.
+func (mr *MockIncomeVerificationRepositoryMockRecorder) FindByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomerID", reflect.TypeOf((*MockIncomeVerificationRepository)(nil).FindByCustomerID), ctx, customerID)
+}
+
+// FindByID mocks base method.
+func (m *MockIncomeVerificationRepository) FindByID(ctx context.Context, id uint64) (*domain.IncomeVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.IncomeVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockIncomeVerificationRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIncomeVerificationRepository)(nil).FindByID), ctx, id)
+}
+
+// FindPaginated mocks base method.
+func (m *MockIncomeVerificationRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.IncomeVerification, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.IncomeVerification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockIncomeVerificationRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockIncomeVerificationRepository)(nil).FindPaginated), ctx, params)
+}
+
+// Review mocks base method.
+func (m *MockIncomeVerificationRepository) Review(ctx context.Context, verification *domain.IncomeVerification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, verification)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockIncomeVerificationRepositoryMockRecorder) Review(ctx, verification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockIncomeVerificationRepository)(nil).Review), ctx, verification)
+}
+
+// MockRiskTierRepository is a mock of RiskTierRepository interface.
+type MockRiskTierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskTierRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRiskTierRepositoryMockRecorder is the mock recorder for MockRiskTierRepository.
+type MockRiskTierRepositoryMockRecorder struct {
+	mock *MockRiskTierRepository
+}
+
+// NewMockRiskTierRepository creates a new mock instance.
+func NewMockRiskTierRepository(ctrl *gomock.Controller) *MockRiskTierRepository {
+	mock := &MockRiskTierRepository{ctrl: ctrl}
+	mock.recorder = &MockRiskTierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskTierRepository) EXPECT() *MockRiskTierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockRiskTierRepository) CreateRule(ctx context.Context, rule *domain.RiskTierRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockRiskTierRepositoryMockRecorder) CreateRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockRiskTierRepository)(nil).CreateRule), ctx, rule)
+}
+
+// DeleteRule mocks base method.
+func (m *MockRiskTierRepository) DeleteRule(ctx context.Context, id uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRiskTierRepositoryMockRecorder) DeleteRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRiskTierRepository)(nil).DeleteRule), ctx, id)
+}
+
+// FindActiveExposures mocks base method.
+func (m *MockRiskTierRepository) FindActiveExposures(ctx context.Context, customerIDs []uint64) ([]domain.AgingExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveExposures", ctx, customerIDs)
+	ret0, _ := ret[0].([]domain.AgingExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveExposures indicates an expected call of FindActiveExposures.
+func (mr *MockRiskTierRepositoryMockRecorder) FindActiveExposures(ctx, customerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveExposures", reflect.TypeOf((*MockRiskTierRepository)(nil).FindActiveExposures), ctx, customerIDs)
+}
+
+// FindByCustomerID mocks base method.
+func (m *MockRiskTierRepository) FindByCustomerID(ctx context.Context, customerID uint64) (*domain.CustomerRiskTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(*domain.CustomerRiskTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomerID indicates an expected call of FindByCustomerID.
+func (mr *MockRiskTierRepositoryMockRecorder) FindByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomerID", reflect.TypeOf((*MockRiskTierRepository)(nil).FindByCustomerID), ctx, customerID)
+}
+
+// FindByCustomerIDs mocks base method.
+func (m *MockRiskTierRepository) FindByCustomerIDs(ctx context.Context, customerIDs []uint64) ([]domain.CustomerRiskTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomerIDs", ctx, customerIDs)
+	ret0, _ := ret[0].([]domain.CustomerRiskTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomerIDs indicates an expected call of FindByCustomerIDs.
+func (mr *MockRiskTierRepositoryMockRecorder) FindByCustomerIDs(ctx, customerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomerIDs", reflect.TypeOf((*MockRiskTierRepository)(nil).FindByCustomerIDs), ctx, customerIDs)
+}
+
+// FindCustomers mocks base method.
+func (m *MockRiskTierRepository) FindCustomers(ctx context.Context, afterID uint64, limit int) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCustomers", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCustomers indicates an expected call of FindCustomers.
+func (mr *MockRiskTierRepositoryMockRecorder) FindCustomers(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCustomers", reflect.TypeOf((*MockRiskTierRepository)(nil).FindCustomers), ctx, afterID, limit)
+}
+
+// FindRuleByID mocks base method.
+func (m *MockRiskTierRepository) FindRuleByID(ctx context.Context, id uint64) (*domain.RiskTierRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRuleByID", ctx, id)
+	ret0, _ := ret[0].(*domain.RiskTierRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRuleByID indicates an expected call of FindRuleByID.
+func (mr *MockRiskTierRepositoryMockRecorder) FindRuleByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRuleByID", reflect.TypeOf((*MockRiskTierRepository)(nil).FindRuleByID), ctx, id)
+}
+
+// FindRules mocks base method.
+func (m *MockRiskTierRepository) FindRules(ctx context.Context) ([]domain.RiskTierRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRules", ctx)
+	ret0, _ := ret[0].([]domain.RiskTierRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRules indicates an expected call of FindRules.
+func (mr *MockRiskTierRepositoryMockRecorder) FindRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRules", reflect.TypeOf((*MockRiskTierRepository)(nil).FindRules), ctx)
+}
+
+// Save mocks base method.
+func (m *MockRiskTierRepository) Save(ctx context.Context, tier *domain.CustomerRiskTier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, tier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRiskTierRepositoryMockRecorder) Save(ctx, tier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRiskTierRepository)(nil).Save), ctx, tier)
+}
+
+// UpdateRule mocks base method.
+func (m *MockRiskTierRepository) UpdateRule(ctx context.Context, rule *domain.RiskTierRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockRiskTierRepositoryMockRecorder) UpdateRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockRiskTierRepository)(nil).UpdateRule), ctx, rule)
+}
+
+// MockBlacklistRepository is a mock of BlacklistRepository interface.
+type MockBlacklistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlacklistRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBlacklistRepositoryMockRecorder is the mock recorder for MockBlacklistRepository.
+type MockBlacklistRepositoryMockRecorder struct {
+	mock *MockBlacklistRepository
+}
+
+// NewMockBlacklistRepository creates a new mock instance.
+func NewMockBlacklistRepository(ctrl *gomock.Controller) *MockBlacklistRepository {
+	mock := &MockBlacklistRepository{ctrl: ctrl}
+	mock.recorder = &MockBlacklistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlacklistRepository) EXPECT() *MockBlacklistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBlacklistRepository) Create(ctx context.Context, entry *domain.BlacklistEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBlacklistRepositoryMockRecorder) Create(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBlacklistRepository)(nil).Create), ctx, entry)
+}
+
+// CreateScreeningLog mocks base method.
+func (m *MockBlacklistRepository) CreateScreeningLog(ctx context.Context, log *domain.ScreeningLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScreeningLog", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateScreeningLog indicates an expected call of CreateScreeningLog.
+func (mr *MockBlacklistRepositoryMockRecorder) CreateScreeningLog(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScreeningLog", reflect.TypeOf((*MockBlacklistRepository)(nil).CreateScreeningLog), ctx, log)
+}
+
+// Delete mocks base method.
+func (m *MockBlacklistRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlacklistRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlacklistRepository)(nil).Delete), ctx, id)
+}
+
+// FindByID mocks base method.
+func (m *MockBlacklistRepository) FindByID(ctx context.Context, id uint64) (*domain.BlacklistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.BlacklistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBlacklistRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBlacklistRepository)(nil).FindByID), ctx, id)
+}
+
+// FindMatches mocks base method.
+func (m *MockBlacklistRepository) FindMatches(ctx context.Context, nik, fullName string) ([]domain.BlacklistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMatches", ctx, nik, fullName)
+	ret0, _ := ret[0].([]domain.BlacklistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMatches indicates an expected call of FindMatches.
+func (mr *MockBlacklistRepositoryMockRecorder) FindMatches(ctx, nik, fullName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMatches", reflect.TypeOf((*MockBlacklistRepository)(nil).FindMatches), ctx, nik, fullName)
+}
+
+// FindPaginated mocks base method.
+func (m *MockBlacklistRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.BlacklistEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.BlacklistEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockBlacklistRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockBlacklistRepository)(nil).FindPaginated), ctx, params)
+}
+
+// FindScreeningLogsPaginated mocks base method.
+func (m *MockBlacklistRepository) FindScreeningLogsPaginated(ctx context.Context, params domain.Params) ([]domain.ScreeningLog, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindScreeningLogsPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.ScreeningLog)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindScreeningLogsPaginated indicates an expected call of FindScreeningLogsPaginated.
+func (mr *MockBlacklistRepositoryMockRecorder) FindScreeningLogsPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindScreeningLogsPaginated", reflect.TypeOf((*MockBlacklistRepository)(nil).FindScreeningLogsPaginated), ctx, params)
+}
+
+// Update mocks base method.
+func (m *MockBlacklistRepository) Update(ctx context.Context, entry *domain.BlacklistEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBlacklistRepositoryMockRecorder) Update(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBlacklistRepository)(nil).Update), ctx, entry)
+}
+
+// MockDuplicateRepository is a mock of DuplicateRepository interface.
+type MockDuplicateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDuplicateRepositoryMockRecorder is the mock recorder for MockDuplicateRepository.
+type MockDuplicateRepositoryMockRecorder struct {
+	mock *MockDuplicateRepository
+}
+
+// NewMockDuplicateRepository creates a new mock instance.
+func NewMockDuplicateRepository(ctrl *gomock.Controller) *MockDuplicateRepository {
+	mock := &MockDuplicateRepository{ctrl: ctrl}
+	mock.recorder = &MockDuplicateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateRepository) EXPECT() *MockDuplicateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateResolution mocks base method.
+func (m *MockDuplicateRepository) CreateResolution(ctx context.Context, resolution *domain.DuplicateResolution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResolution", ctx, resolution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateResolution indicates an expected call of CreateResolution.
+func (mr *MockDuplicateRepositoryMockRecorder) CreateResolution(ctx, resolution any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResolution", reflect.TypeOf((*MockDuplicateRepository)(nil).CreateResolution), ctx, resolution)
+}
+
+// FindByBirthDate mocks base method.
+func (m *MockDuplicateRepository) FindByBirthDate(ctx context.Context, birthDate time.Time, excludeID uint64) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBirthDate", ctx, birthDate, excludeID)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBirthDate indicates an expected call of FindByBirthDate.
+func (mr *MockDuplicateRepositoryMockRecorder) FindByBirthDate(ctx, birthDate, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBirthDate", reflect.TypeOf((*MockDuplicateRepository)(nil).FindByBirthDate), ctx, birthDate, excludeID)
+}
+
+// FindResolution mocks base method.
+func (m *MockDuplicateRepository) FindResolution(ctx context.Context, customerID, duplicateID uint64) (*domain.DuplicateResolution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindResolution", ctx, customerID, duplicateID)
+	ret0, _ := ret[0].(*domain.DuplicateResolution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindResolution indicates an expected call of FindResolution.
+func (mr *MockDuplicateRepositoryMockRecorder) FindResolution(ctx, customerID, duplicateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindResolution", reflect.TypeOf((*MockDuplicateRepository)(nil).FindResolution), ctx, customerID, duplicateID)
+}
+
+// FindResolvedIDs mocks base method.
+func (m *MockDuplicateRepository) FindResolvedIDs(ctx context.Context, customerID uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindResolvedIDs", ctx, customerID)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindResolvedIDs indicates an expected call of FindResolvedIDs.
+func (mr *MockDuplicateRepositoryMockRecorder) FindResolvedIDs(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindResolvedIDs", reflect.TypeOf((*MockDuplicateRepository)(nil).FindResolvedIDs), ctx, customerID)
+}
+
+// MockRestructuringRepository is a mock of RestructuringRepository interface.
+type MockRestructuringRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRestructuringRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRestructuringRepositoryMockRecorder is the mock recorder for MockRestructuringRepository.
+type MockRestructuringRepositoryMockRecorder struct {
+	mock *MockRestructuringRepository
+}
+
+// NewMockRestructuringRepository creates a new mock instance.
+func NewMockRestructuringRepository(ctrl *gomock.Controller) *MockRestructuringRepository {
+	mock := &MockRestructuringRepository{ctrl: ctrl}
+	mock.recorder = &MockRestructuringRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRestructuringRepository) EXPECT() *MockRestructuringRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRestructuringRepository) Create(ctx context.Context, restructuring *domain.Restructuring) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, restructuring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRestructuringRepositoryMockRecorder) Create(ctx, restructuring any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRestructuringRepository)(nil).Create), ctx, restructuring)
+}
+
+// FindByID mocks base method.
+func (m *MockRestructuringRepository) FindByID(ctx context.Context, id uint64) (*domain.Restructuring, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Restructuring)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockRestructuringRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRestructuringRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByIDWithLock mocks base method.
+func (m *MockRestructuringRepository) FindByIDWithLock(ctx context.Context, id uint64) (*domain.Restructuring, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDWithLock", ctx, id)
+	ret0, _ := ret[0].(*domain.Restructuring)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDWithLock indicates an expected call of FindByIDWithLock.
+func (mr *MockRestructuringRepositoryMockRecorder) FindByIDWithLock(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDWithLock", reflect.TypeOf((*MockRestructuringRepository)(nil).FindByIDWithLock), ctx, id)
+}
+
+// FindPaginated mocks base method.
+func (m *MockRestructuringRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.Restructuring, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.Restructuring)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockRestructuringRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockRestructuringRepository)(nil).FindPaginated), ctx, params)
+}
+
+// FindPendingByTransactionID mocks base method.
+func (m *MockRestructuringRepository) FindPendingByTransactionID(ctx context.Context, transactionID uint64) (*domain.Restructuring, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingByTransactionID", ctx, transactionID)
+	ret0, _ := ret[0].(*domain.Restructuring)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingByTransactionID indicates an expected call of FindPendingByTransactionID.
+func (mr *MockRestructuringRepositoryMockRecorder) FindPendingByTransactionID(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingByTransactionID", reflect.TypeOf((*MockRestructuringRepository)(nil).FindPendingByTransactionID), ctx, transactionID)
+}
+
+// Update mocks base method.
+func (m *MockRestructuringRepository) Update(ctx context.Context, restructuring *domain.Restructuring) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, restructuring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRestructuringRepositoryMockRecorder) Update(ctx, restructuring any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRestructuringRepository)(nil).Update), ctx, restructuring)
+}
+
+// MockFxRateRepository is a mock of FxRateRepository interface.
+type MockFxRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFxRateRepositoryMockRecorder is the mock recorder for MockFxRateRepository.
+type MockFxRateRepositoryMockRecorder struct {
+	mock *MockFxRateRepository
+}
+
+// NewMockFxRateRepository creates a new mock instance.
+func NewMockFxRateRepository(ctrl *gomock.Controller) *MockFxRateRepository {
+	mock := &MockFxRateRepository{ctrl: ctrl}
+	mock.recorder = &MockFxRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFxRateRepository) EXPECT() *MockFxRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLatest mocks base method.
+func (m *MockFxRateRepository) FindLatest(ctx context.Context, currency string, asOf time.Time) (*domain.FxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatest", ctx, currency, asOf)
+	ret0, _ := ret[0].(*domain.FxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatest indicates an expected call of FindLatest.
+func (mr *MockFxRateRepositoryMockRecorder) FindLatest(ctx, currency, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatest", reflect.TypeOf((*MockFxRateRepository)(nil).FindLatest), ctx, currency, asOf)
+}
+
+// FindPaginated mocks base method.
+func (m *MockFxRateRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.FxRate, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.FxRate)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockFxRateRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockFxRateRepository)(nil).FindPaginated), ctx, params)
+}
+
+// UpsertMany mocks base method.
+func (m *MockFxRateRepository) UpsertMany(ctx context.Context, rates []domain.FxRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMany", ctx, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMany indicates an expected call of UpsertMany.
+func (mr *MockFxRateRepositoryMockRecorder) UpsertMany(ctx, rates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMany", reflect.TypeOf((*MockFxRateRepository)(nil).UpsertMany), ctx, rates)
+}
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AgingExposures mocks base method.
+func (m *MockReportRepository) AgingExposures(ctx context.Context) ([]domain.AgingExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AgingExposures", ctx)
+	ret0, _ := ret[0].([]domain.AgingExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AgingExposures indicates an expected call of AgingExposures.
+func (mr *MockReportRepositoryMockRecorder) AgingExposures(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgingExposures", reflect.TypeOf((*MockReportRepository)(nil).AgingExposures), ctx)
+}
+
+// InterestAccrual mocks base method.
+func (m *MockReportRepository) InterestAccrual(ctx context.Context, month time.Time) ([]domain.InterestAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InterestAccrual", ctx, month)
+	ret0, _ := ret[0].([]domain.InterestAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InterestAccrual indicates an expected call of InterestAccrual.
+func (mr *MockReportRepositoryMockRecorder) InterestAccrual(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InterestAccrual", reflect.TypeOf((*MockReportRepository)(nil).InterestAccrual), ctx, month)
+}
+
+// LatestAgingSnapshot mocks base method.
+func (m *MockReportRepository) LatestAgingSnapshot(ctx context.Context, onOrBefore time.Time) ([]domain.AgingSnapshotRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestAgingSnapshot", ctx, onOrBefore)
+	ret0, _ := ret[0].([]domain.AgingSnapshotRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestAgingSnapshot indicates an expected call of LatestAgingSnapshot.
+func (mr *MockReportRepositoryMockRecorder) LatestAgingSnapshot(ctx, onOrBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestAgingSnapshot", reflect.TypeOf((*MockReportRepository)(nil).LatestAgingSnapshot), ctx, onOrBefore)
+}
+
+// PartnerCommissions mocks base method.
+func (m *MockReportRepository) PartnerCommissions(ctx context.Context, month time.Time) ([]domain.PartnerCommission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartnerCommissions", ctx, month)
+	ret0, _ := ret[0].([]domain.PartnerCommission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PartnerCommissions indicates an expected call of PartnerCommissions.
+func (mr *MockReportRepositoryMockRecorder) PartnerCommissions(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartnerCommissions", reflect.TypeOf((*MockReportRepository)(nil).PartnerCommissions), ctx, month)
+}
+
+// ReferralConversions mocks base method.
+func (m *MockReportRepository) ReferralConversions(ctx context.Context, month time.Time) ([]domain.ReferralConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReferralConversions", ctx, month)
+	ret0, _ := ret[0].([]domain.ReferralConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReferralConversions indicates an expected call of ReferralConversions.
+func (mr *MockReportRepositoryMockRecorder) ReferralConversions(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReferralConversions", reflect.TypeOf((*MockReportRepository)(nil).ReferralConversions), ctx, month)
+}
+
+// RegionVolumes mocks base method.
+func (m *MockReportRepository) RegionVolumes(ctx context.Context, month time.Time) ([]domain.RegionVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegionVolumes", ctx, month)
+	ret0, _ := ret[0].([]domain.RegionVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegionVolumes indicates an expected call of RegionVolumes.
+func (mr *MockReportRepositoryMockRecorder) RegionVolumes(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegionVolumes", reflect.TypeOf((*MockReportRepository)(nil).RegionVolumes), ctx, month)
+}
+
+// SaveAgingSnapshot mocks base method.
+func (m *MockReportRepository) SaveAgingSnapshot(ctx context.Context, date time.Time, rows []domain.AgingSnapshotRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAgingSnapshot", ctx, date, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAgingSnapshot indicates an expected call of SaveAgingSnapshot.
+func (mr *MockReportRepositoryMockRecorder) SaveAgingSnapshot(ctx, date, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAgingSnapshot", reflect.TypeOf((*MockReportRepository)(nil).SaveAgingSnapshot), ctx, date, rows)
+}
+
+// MockPendingExpiryRepository is a mock of PendingExpiryRepository interface.
+type MockPendingExpiryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingExpiryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPendingExpiryRepositoryMockRecorder is the mock recorder for MockPendingExpiryRepository.
+type MockPendingExpiryRepositoryMockRecorder struct {
+	mock *MockPendingExpiryRepository
+}
+
+// NewMockPendingExpiryRepository creates a new mock instance.
+func NewMockPendingExpiryRepository(ctrl *gomock.Controller) *MockPendingExpiryRepository {
+	mock := &MockPendingExpiryRepository{ctrl: ctrl}
+	mock.recorder = &MockPendingExpiryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingExpiryRepository) EXPECT() *MockPendingExpiryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindExpired mocks base method.
+func (m *MockPendingExpiryRepository) FindExpired(ctx context.Context, submittedBefore, remindedBefore time.Time, limit int) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpired", ctx, submittedBefore, remindedBefore, limit)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpired indicates an expected call of FindExpired.
+func (mr *MockPendingExpiryRepositoryMockRecorder) FindExpired(ctx, submittedBefore, remindedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpired", reflect.TypeOf((*MockPendingExpiryRepository)(nil).FindExpired), ctx, submittedBefore, remindedBefore, limit)
+}
+
+// FindUnreminded mocks base method.
+func (m *MockPendingExpiryRepository) FindUnreminded(ctx context.Context, submittedBefore time.Time, limit int) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnreminded", ctx, submittedBefore, limit)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnreminded indicates an expected call of FindUnreminded.
+func (mr *MockPendingExpiryRepositoryMockRecorder) FindUnreminded(ctx, submittedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnreminded", reflect.TypeOf((*MockPendingExpiryRepository)(nil).FindUnreminded), ctx, submittedBefore, limit)
+}
+
+// MarkReminded mocks base method.
+func (m *MockPendingExpiryRepository) MarkReminded(ctx context.Context, customerID uint64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminded", ctx, customerID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReminded indicates an expected call of MarkReminded.
+func (mr *MockPendingExpiryRepositoryMockRecorder) MarkReminded(ctx, customerID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminded", reflect.TypeOf((*MockPendingExpiryRepository)(nil).MarkReminded), ctx, customerID, at)
+}
+
+// Reject mocks base method.
+func (m *MockPendingExpiryRepository) Reject(ctx context.Context, customerID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, customerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockPendingExpiryRepositoryMockRecorder) Reject(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockPendingExpiryRepository)(nil).Reject), ctx, customerID)
+}
+
+// Stats mocks base method.
+func (m *MockPendingExpiryRepository) Stats(ctx context.Context) (int64, *time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockPendingExpiryRepositoryMockRecorder) Stats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockPendingExpiryRepository)(nil).Stats), ctx)
+}
+
+// MockCommunicationRepository is a mock of CommunicationRepository interface.
+type MockCommunicationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommunicationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCommunicationRepositoryMockRecorder is the mock recorder for MockCommunicationRepository.
+type MockCommunicationRepositoryMockRecorder struct {
+	mock *MockCommunicationRepository
+}
+
+// NewMockCommunicationRepository creates a new mock instance.
+func NewMockCommunicationRepository(ctrl *gomock.Controller) *MockCommunicationRepository {
+	mock := &MockCommunicationRepository{ctrl: ctrl}
+	mock.recorder = &MockCommunicationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommunicationRepository) EXPECT() *MockCommunicationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommunicationRepository) Create(ctx context.Context, communication *domain.Communication) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, communication)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommunicationRepositoryMockRecorder) Create(ctx, communication any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommunicationRepository)(nil).Create), ctx, communication)
+}
+
+// FindByCustomer mocks base method.
+func (m *MockCommunicationRepository) FindByCustomer(ctx context.Context, customerID uint64, params domain.Params) ([]domain.Communication, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomer", ctx, customerID, params)
+	ret0, _ := ret[0].([]domain.Communication)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByCustomer indicates an expected call of FindByCustomer.
+func (mr *MockCommunicationRepositoryMockRecorder) FindByCustomer(ctx, customerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomer", reflect.TypeOf((*MockCommunicationRepository)(nil).FindByCustomer), ctx, customerID, params)
+}
+
+// MockFeatureFlagRepository is a mock of FeatureFlagRepository interface.
+type MockFeatureFlagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagRepositoryMockRecorder is the mock recorder for MockFeatureFlagRepository.
+type MockFeatureFlagRepositoryMockRecorder struct {
+	mock *MockFeatureFlagRepository
+}
+
+// NewMockFeatureFlagRepository creates a new mock instance.
+func NewMockFeatureFlagRepository(ctrl *gomock.Controller) *MockFeatureFlagRepository {
+	mock := &MockFeatureFlagRepository{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlagRepository) EXPECT() *MockFeatureFlagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFeatureFlagRepository) Delete(ctx context.Context, key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFeatureFlagRepositoryMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFeatureFlagRepository)(nil).Delete), ctx, key)
+}
+
+// FindAll mocks base method.
+func (m *MockFeatureFlagRepository) FindAll(ctx context.Context) ([]domain.FeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.FeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockFeatureFlagRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFeatureFlagRepository)(nil).FindAll), ctx)
+}
+
+// Upsert mocks base method.
+func (m *MockFeatureFlagRepository) Upsert(ctx context.Context, flag *domain.FeatureFlag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, flag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockFeatureFlagRepositoryMockRecorder) Upsert(ctx, flag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockFeatureFlagRepository)(nil).Upsert), ctx, flag)
+}
+
+// MockFeeScheduleRepository is a mock of FeeScheduleRepository interface.
+type MockFeeScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeScheduleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeeScheduleRepositoryMockRecorder is the mock recorder for MockFeeScheduleRepository.
+type MockFeeScheduleRepositoryMockRecorder struct {
+	mock *MockFeeScheduleRepository
+}
+
+// NewMockFeeScheduleRepository creates a new mock instance.
+func NewMockFeeScheduleRepository(ctrl *gomock.Controller) *MockFeeScheduleRepository {
+	mock := &MockFeeScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockFeeScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeScheduleRepository) EXPECT() *MockFeeScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFeeScheduleRepository) Delete(ctx context.Context, partnerID uint64, tenorMonths uint8) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, partnerID, tenorMonths)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFeeScheduleRepositoryMockRecorder) Delete(ctx, partnerID, tenorMonths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFeeScheduleRepository)(nil).Delete), ctx, partnerID, tenorMonths)
+}
+
+// FindByPartner mocks base method.
+func (m *MockFeeScheduleRepository) FindByPartner(ctx context.Context, partnerID uint64) ([]domain.PartnerFeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPartner", ctx, partnerID)
+	ret0, _ := ret[0].([]domain.PartnerFeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPartner indicates an expected call of FindByPartner.
+func (mr *MockFeeScheduleRepositoryMockRecorder) FindByPartner(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPartner", reflect.TypeOf((*MockFeeScheduleRepository)(nil).FindByPartner), ctx, partnerID)
+}
+
+// FindForTenor mocks base method.
+func (m *MockFeeScheduleRepository) FindForTenor(ctx context.Context, partnerID uint64, tenorMonths uint8) (*domain.PartnerFeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForTenor", ctx, partnerID, tenorMonths)
+	ret0, _ := ret[0].(*domain.PartnerFeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForTenor indicates an expected call of FindForTenor.
+func (mr *MockFeeScheduleRepositoryMockRecorder) FindForTenor(ctx, partnerID, tenorMonths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForTenor", reflect.TypeOf((*MockFeeScheduleRepository)(nil).FindForTenor), ctx, partnerID, tenorMonths)
+}
+
+// Upsert mocks base method.
+func (m *MockFeeScheduleRepository) Upsert(ctx context.Context, schedule *domain.PartnerFeeSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockFeeScheduleRepositoryMockRecorder) Upsert(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockFeeScheduleRepository)(nil).Upsert), ctx, schedule)
+}
+
+// MockPaymentRepository is a mock of PaymentRepository interface.
+type MockPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentRepositoryMockRecorder is the mock recorder for MockPaymentRepository.
+type MockPaymentRepositoryMockRecorder struct {
+	mock *MockPaymentRepository
+}
+
+// NewMockPaymentRepository creates a new mock instance.
+func NewMockPaymentRepository(ctrl *gomock.Controller) *MockPaymentRepository {
+	mock := &MockPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyRepayment mocks base method.
+func (m *MockPaymentRepository) ApplyRepayment(ctx context.Context, transactionID uint64, paidInstallments int, status domain.TransactionStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyRepayment", ctx, transactionID, paidInstallments, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyRepayment indicates an expected call of ApplyRepayment.
+func (mr *MockPaymentRepositoryMockRecorder) ApplyRepayment(ctx, transactionID, paidInstallments, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRepayment", reflect.TypeOf((*MockPaymentRepository)(nil).ApplyRepayment), ctx, transactionID, paidInstallments, status)
+}
+
+// ArchiveCallback mocks base method.
+func (m *MockPaymentRepository) ArchiveCallback(ctx context.Context, callback *domain.PaymentCallback) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCallback", ctx, callback)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCallback indicates an expected call of ArchiveCallback.
+func (mr *MockPaymentRepositoryMockRecorder) ArchiveCallback(ctx, callback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCallback", reflect.TypeOf((*MockPaymentRepository)(nil).ArchiveCallback), ctx, callback)
+}
+
+// FindByExternalID mocks base method.
+func (m *MockPaymentRepository) FindByExternalID(ctx context.Context, provider, externalID string) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByExternalID", ctx, provider, externalID)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByExternalID indicates an expected call of FindByExternalID.
+func (mr *MockPaymentRepositoryMockRecorder) FindByExternalID(ctx, provider, externalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByExternalID", reflect.TypeOf((*MockPaymentRepository)(nil).FindByExternalID), ctx, provider, externalID)
+}
+
+// LockContract mocks base method.
+func (m *MockPaymentRepository) LockContract(ctx context.Context, contractNumber string) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockContract", ctx, contractNumber)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockContract indicates an expected call of LockContract.
+func (mr *MockPaymentRepositoryMockRecorder) LockContract(ctx, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockContract", reflect.TypeOf((*MockPaymentRepository)(nil).LockContract), ctx, contractNumber)
+}
+
+// LockContractByVirtualAccount mocks base method.
+func (m *MockPaymentRepository) LockContractByVirtualAccount(ctx context.Context, number string) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockContractByVirtualAccount", ctx, number)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockContractByVirtualAccount indicates an expected call of LockContractByVirtualAccount.
+func (mr *MockPaymentRepositoryMockRecorder) LockContractByVirtualAccount(ctx, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockContractByVirtualAccount", reflect.TypeOf((*MockPaymentRepository)(nil).LockContractByVirtualAccount), ctx, number)
+}
+
+// Save mocks base method.
+func (m *MockPaymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPaymentRepositoryMockRecorder) Save(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPaymentRepository)(nil).Save), ctx, payment)
+}
+
+// SumPaid mocks base method.
+func (m *MockPaymentRepository) SumPaid(ctx context.Context, transactionID uint64) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPaid", ctx, transactionID)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPaid indicates an expected call of SumPaid.
+func (mr *MockPaymentRepositoryMockRecorder) SumPaid(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPaid", reflect.TypeOf((*MockPaymentRepository)(nil).SumPaid), ctx, transactionID)
+}
+
+// MockVirtualAccountRepository is a mock of VirtualAccountRepository interface.
+type MockVirtualAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualAccountRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVirtualAccountRepositoryMockRecorder is the mock recorder for MockVirtualAccountRepository.
+type MockVirtualAccountRepositoryMockRecorder struct {
+	mock *MockVirtualAccountRepository
+}
+
+// NewMockVirtualAccountRepository creates a new mock instance.
+func NewMockVirtualAccountRepository(ctrl *gomock.Controller) *MockVirtualAccountRepository {
+	mock := &MockVirtualAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockVirtualAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualAccountRepository) EXPECT() *MockVirtualAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockVirtualAccountRepository) Assign(ctx context.Context, transactionID uint64, bank, number string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, transactionID, bank, number)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockVirtualAccountRepositoryMockRecorder) Assign(ctx, transactionID, bank, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockVirtualAccountRepository)(nil).Assign), ctx, transactionID, bank, number)
+}
+
+// FindUnprovisioned mocks base method.
+func (m *MockVirtualAccountRepository) FindUnprovisioned(ctx context.Context, limit int) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnprovisioned", ctx, limit)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnprovisioned indicates an expected call of FindUnprovisioned.
+func (mr *MockVirtualAccountRepositoryMockRecorder) FindUnprovisioned(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnprovisioned", reflect.TypeOf((*MockVirtualAccountRepository)(nil).FindUnprovisioned), ctx, limit)
+}
+
+// MockDirectDebitRepository is a mock of DirectDebitRepository interface.
+type MockDirectDebitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDirectDebitRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDirectDebitRepositoryMockRecorder is the mock recorder for MockDirectDebitRepository.
+type MockDirectDebitRepositoryMockRecorder struct {
+	mock *MockDirectDebitRepository
+}
+
+// NewMockDirectDebitRepository creates a new mock instance.
+func NewMockDirectDebitRepository(ctrl *gomock.Controller) *MockDirectDebitRepository {
+	mock := &MockDirectDebitRepository{ctrl: ctrl}
+	mock.recorder = &MockDirectDebitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDirectDebitRepository) EXPECT() *MockDirectDebitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAttempt mocks base method.
+func (m *MockDirectDebitRepository) CreateAttempt(ctx context.Context, attempt *domain.DirectDebitAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttempt", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttempt indicates an expected call of CreateAttempt.
+func (mr *MockDirectDebitRepositoryMockRecorder) CreateAttempt(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttempt", reflect.TypeOf((*MockDirectDebitRepository)(nil).CreateAttempt), ctx, attempt)
+}
+
+// DeleteMandate mocks base method.
+func (m *MockDirectDebitRepository) DeleteMandate(ctx context.Context, customerID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMandate", ctx, customerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMandate indicates an expected call of DeleteMandate.
+func (mr *MockDirectDebitRepositoryMockRecorder) DeleteMandate(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMandate", reflect.TypeOf((*MockDirectDebitRepository)(nil).DeleteMandate), ctx, customerID)
+}
+
+// FindCollectible mocks base method.
+func (m *MockDirectDebitRepository) FindCollectible(ctx context.Context, afterID uint64, limit int) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCollectible", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCollectible indicates an expected call of FindCollectible.
+func (mr *MockDirectDebitRepositoryMockRecorder) FindCollectible(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCollectible", reflect.TypeOf((*MockDirectDebitRepository)(nil).FindCollectible), ctx, afterID, limit)
+}
+
+// FindMandate mocks base method.
+func (m *MockDirectDebitRepository) FindMandate(ctx context.Context, customerID uint64) (*domain.DirectDebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMandate", ctx, customerID)
+	ret0, _ := ret[0].(*domain.DirectDebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMandate indicates an expected call of FindMandate.
+func (mr *MockDirectDebitRepositoryMockRecorder) FindMandate(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMandate", reflect.TypeOf((*MockDirectDebitRepository)(nil).FindMandate), ctx, customerID)
+}
+
+// ListAttempts mocks base method.
+func (m *MockDirectDebitRepository) ListAttempts(ctx context.Context, transactionID uint64, installment int) ([]domain.DirectDebitAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttempts", ctx, transactionID, installment)
+	ret0, _ := ret[0].([]domain.DirectDebitAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttempts indicates an expected call of ListAttempts.
+func (mr *MockDirectDebitRepositoryMockRecorder) ListAttempts(ctx, transactionID, installment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttempts", reflect.TypeOf((*MockDirectDebitRepository)(nil).ListAttempts), ctx, transactionID, installment)
+}
+
+// UpsertMandate mocks base method.
+func (m *MockDirectDebitRepository) UpsertMandate(ctx context.Context, mandate *domain.DirectDebitMandate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMandate", ctx, mandate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMandate indicates an expected call of UpsertMandate.
+func (mr *MockDirectDebitRepositoryMockRecorder) UpsertMandate(ctx, mandate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMandate", reflect.TypeOf((*MockDirectDebitRepository)(nil).UpsertMandate), ctx, mandate)
+}
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountRedemptions mocks base method.
+func (m *MockPromotionRepository) CountRedemptions(ctx context.Context, promotionID, customerID uint64) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRedemptions", ctx, promotionID, customerID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountRedemptions indicates an expected call of CountRedemptions.
+func (mr *MockPromotionRepositoryMockRecorder) CountRedemptions(ctx, promotionID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRedemptions", reflect.TypeOf((*MockPromotionRepository)(nil).CountRedemptions), ctx, promotionID, customerID)
+}
+
+// CreateRedemption mocks base method.
+func (m *MockPromotionRepository) CreateRedemption(ctx context.Context, redemption *domain.PromotionRedemption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRedemption", ctx, redemption)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRedemption indicates an expected call of CreateRedemption.
+func (mr *MockPromotionRepositoryMockRecorder) CreateRedemption(ctx, redemption any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRedemption", reflect.TypeOf((*MockPromotionRepository)(nil).CreateRedemption), ctx, redemption)
+}
+
+// Deactivate mocks base method.
+func (m *MockPromotionRepository) Deactivate(ctx context.Context, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockPromotionRepositoryMockRecorder) Deactivate(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockPromotionRepository)(nil).Deactivate), ctx, code)
+}
+
+// FindAll mocks base method.
+func (m *MockPromotionRepository) FindAll(ctx context.Context) ([]domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPromotionRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPromotionRepository)(nil).FindAll), ctx)
+}
+
+// LockByCode mocks base method.
+func (m *MockPromotionRepository) LockByCode(ctx context.Context, code string) (*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByCode", ctx, code)
+	ret0, _ := ret[0].(*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByCode indicates an expected call of LockByCode.
+func (mr *MockPromotionRepositoryMockRecorder) LockByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByCode", reflect.TypeOf((*MockPromotionRepository)(nil).LockByCode), ctx, code)
+}
+
+// Upsert mocks base method.
+func (m *MockPromotionRepository) Upsert(ctx context.Context, promotion *domain.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, promotion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockPromotionRepositoryMockRecorder) Upsert(ctx, promotion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockPromotionRepository)(nil).Upsert), ctx, promotion)
+}
+
+// MockReferralRepository is a mock of ReferralRepository interface.
+type MockReferralRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferralRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReferralRepositoryMockRecorder is the mock recorder for MockReferralRepository.
+type MockReferralRepositoryMockRecorder struct {
+	mock *MockReferralRepository
+}
+
+// NewMockReferralRepository creates a new mock instance.
+func NewMockReferralRepository(ctrl *gomock.Controller) *MockReferralRepository {
+	mock := &MockReferralRepository{ctrl: ctrl}
+	mock.recorder = &MockReferralRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferralRepository) EXPECT() *MockReferralRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByFingerprint mocks base method.
+func (m *MockReferralRepository) CountByFingerprint(ctx context.Context, deviceID, ipAddress string, since time.Time) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByFingerprint", ctx, deviceID, ipAddress, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountByFingerprint indicates an expected call of CountByFingerprint.
+func (mr *MockReferralRepositoryMockRecorder) CountByFingerprint(ctx, deviceID, ipAddress, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByFingerprint", reflect.TypeOf((*MockReferralRepository)(nil).CountByFingerprint), ctx, deviceID, ipAddress, since)
+}
+
+// Create mocks base method.
+func (m *MockReferralRepository) Create(ctx context.Context, referral *domain.Referral) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, referral)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReferralRepositoryMockRecorder) Create(ctx, referral any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReferralRepository)(nil).Create), ctx, referral)
+}
+
+// CreateCode mocks base method.
+func (m *MockReferralRepository) CreateCode(ctx context.Context, code *domain.ReferralCode) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCode", ctx, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCode indicates an expected call of CreateCode.
+func (mr *MockReferralRepositoryMockRecorder) CreateCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCode", reflect.TypeOf((*MockReferralRepository)(nil).CreateCode), ctx, code)
+}
+
+// FindByCode mocks base method.
+func (m *MockReferralRepository) FindByCode(ctx context.Context, code string) (*domain.ReferralCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, code)
+	ret0, _ := ret[0].(*domain.ReferralCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockReferralRepositoryMockRecorder) FindByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockReferralRepository)(nil).FindByCode), ctx, code)
+}
+
+// FindCode mocks base method.
+func (m *MockReferralRepository) FindCode(ctx context.Context, customerID uint64) (*domain.ReferralCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCode", ctx, customerID)
+	ret0, _ := ret[0].(*domain.ReferralCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCode indicates an expected call of FindCode.
+func (mr *MockReferralRepositoryMockRecorder) FindCode(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCode", reflect.TypeOf((*MockReferralRepository)(nil).FindCode), ctx, customerID)
+}
+
+// MockOTPRepository is a mock of OTPRepository interface.
+type MockOTPRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOTPRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOTPRepositoryMockRecorder is the mock recorder for MockOTPRepository.
+type MockOTPRepositoryMockRecorder struct {
+	mock *MockOTPRepository
+}
+
+// NewMockOTPRepository creates a new mock instance.
+func NewMockOTPRepository(ctrl *gomock.Controller) *MockOTPRepository {
+	mock := &MockOTPRepository{ctrl: ctrl}
+	mock.recorder = &MockOTPRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOTPRepository) EXPECT() *MockOTPRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountRequest mocks base method.
+func (m *MockOTPRepository) CountRequest(ctx context.Context, key string, window time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRequest", ctx, key, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRequest indicates an expected call of CountRequest.
+func (mr *MockOTPRepositoryMockRecorder) CountRequest(ctx, key, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRequest", reflect.TypeOf((*MockOTPRepository)(nil).CountRequest), ctx, key, window)
+}
+
+// DeleteChallenge mocks base method.
+func (m *MockOTPRepository) DeleteChallenge(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChallenge", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChallenge indicates an expected call of DeleteChallenge.
+func (mr *MockOTPRepositoryMockRecorder) DeleteChallenge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChallenge", reflect.TypeOf((*MockOTPRepository)(nil).DeleteChallenge), ctx, id)
+}
+
+// FindChallenge mocks base method.
+func (m *MockOTPRepository) FindChallenge(ctx context.Context, id string) (*domain.OTPChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChallenge", ctx, id)
+	ret0, _ := ret[0].(*domain.OTPChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChallenge indicates an expected call of FindChallenge.
+func (mr *MockOTPRepositoryMockRecorder) FindChallenge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChallenge", reflect.TypeOf((*MockOTPRepository)(nil).FindChallenge), ctx, id)
+}
+
+// IncrementAttempts mocks base method.
+func (m *MockOTPRepository) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementAttempts", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementAttempts indicates an expected call of IncrementAttempts.
+func (mr *MockOTPRepositoryMockRecorder) IncrementAttempts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAttempts", reflect.TypeOf((*MockOTPRepository)(nil).IncrementAttempts), ctx, id)
+}
+
+// SaveChallenge mocks base method.
+func (m *MockOTPRepository) SaveChallenge(ctx context.Context, challenge *domain.OTPChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChallenge", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChallenge indicates an expected call of SaveChallenge.
+func (mr *MockOTPRepositoryMockRecorder) SaveChallenge(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChallenge", reflect.TypeOf((*MockOTPRepository)(nil).SaveChallenge), ctx, challenge)
+}
+
+// SaveVerification mocks base method.
+func (m *MockOTPRepository) SaveVerification(ctx context.Context, verification *domain.OTPVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveVerification", ctx, verification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveVerification indicates an expected call of SaveVerification.
+func (mr *MockOTPRepositoryMockRecorder) SaveVerification(ctx, verification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveVerification", reflect.TypeOf((*MockOTPRepository)(nil).SaveVerification), ctx, verification)
+}
+
+// TakeVerification mocks base method.
+func (m *MockOTPRepository) TakeVerification(ctx context.Context, token string) (*domain.OTPVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeVerification", ctx, token)
+	ret0, _ := ret[0].(*domain.OTPVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeVerification indicates an expected call of TakeVerification.
+func (mr *MockOTPRepositoryMockRecorder) TakeVerification(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeVerification", reflect.TypeOf((*MockOTPRepository)(nil).TakeVerification), ctx, token)
+}
+
+// MockImpersonationRepository is a mock of ImpersonationRepository interface.
+type MockImpersonationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImpersonationRepositoryMockRecorder is the mock recorder for MockImpersonationRepository.
+type MockImpersonationRepositoryMockRecorder struct {
+	mock *MockImpersonationRepository
+}
+
+// NewMockImpersonationRepository creates a new mock instance.
+func NewMockImpersonationRepository(ctrl *gomock.Controller) *MockImpersonationRepository {
+	mock := &MockImpersonationRepository{ctrl: ctrl}
+	mock.recorder = &MockImpersonationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonationRepository) EXPECT() *MockImpersonationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImpersonationRepository) Create(ctx context.Context, session *domain.ImpersonationSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImpersonationRepositoryMockRecorder) Create(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImpersonationRepository)(nil).Create), ctx, session)
+}
+
+// CreateRequest mocks base method.
+func (m *MockImpersonationRepository) CreateRequest(ctx context.Context, request *domain.ImpersonationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRequest indicates an expected call of CreateRequest.
+func (mr *MockImpersonationRepositoryMockRecorder) CreateRequest(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRequest", reflect.TypeOf((*MockImpersonationRepository)(nil).CreateRequest), ctx, request)
+}
+
+// End mocks base method.
+func (m *MockImpersonationRepository) End(ctx context.Context, id uint64, endedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, id, endedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// End indicates an expected call of End.
+func (mr *MockImpersonationRepositoryMockRecorder) End(ctx, id, endedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockImpersonationRepository)(nil).End), ctx, id, endedAt)
+}
+
+// FindByID mocks base method.
+func (m *MockImpersonationRepository) FindByID(ctx context.Context, id uint64) (*domain.ImpersonationSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.ImpersonationSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockImpersonationRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockImpersonationRepository)(nil).FindByID), ctx, id)
+}
+
+// FindRequests mocks base method.
+func (m *MockImpersonationRepository) FindRequests(ctx context.Context, sessionID uint64) ([]domain.ImpersonationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRequests", ctx, sessionID)
+	ret0, _ := ret[0].([]domain.ImpersonationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRequests indicates an expected call of FindRequests.
+func (mr *MockImpersonationRepositoryMockRecorder) FindRequests(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRequests", reflect.TypeOf((*MockImpersonationRepository)(nil).FindRequests), ctx, sessionID)
+}
+
+// MockNonceRepository is a mock of NonceRepository interface.
+type MockNonceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNonceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNonceRepositoryMockRecorder is the mock recorder for MockNonceRepository.
+type MockNonceRepositoryMockRecorder struct {
+	mock *MockNonceRepository
+}
+
+// NewMockNonceRepository creates a new mock instance.
+func NewMockNonceRepository(ctrl *gomock.Controller) *MockNonceRepository {
+	mock := &MockNonceRepository{ctrl: ctrl}
+	mock.recorder = &MockNonceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNonceRepository) EXPECT() *MockNonceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockNonceRepository) Claim(ctx context.Context, partnerID uint64, nonce string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, partnerID, nonce, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockNonceRepositoryMockRecorder) Claim(ctx, partnerID, nonce, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockNonceRepository)(nil).Claim), ctx, partnerID, nonce, ttl)
+}
+
+// MockMaintenanceRepository is a mock of MaintenanceRepository interface.
+type MockMaintenanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMaintenanceRepositoryMockRecorder is the mock recorder for MockMaintenanceRepository.
+type MockMaintenanceRepositoryMockRecorder struct {
+	mock *MockMaintenanceRepository
+}
+
+// NewMockMaintenanceRepository creates a new mock instance.
+func NewMockMaintenanceRepository(ctrl *gomock.Controller) *MockMaintenanceRepository {
+	mock := &MockMaintenanceRepository{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceRepository) EXPECT() *MockMaintenanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMaintenanceRepository) Delete(ctx context.Context, scope domain.GateScope) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, scope)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMaintenanceRepositoryMockRecorder) Delete(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMaintenanceRepository)(nil).Delete), ctx, scope)
+}
+
+// Find mocks base method.
+func (m *MockMaintenanceRepository) Find(ctx context.Context, scope domain.GateScope) (*domain.MaintenanceMode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, scope)
+	ret0, _ := ret[0].(*domain.MaintenanceMode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockMaintenanceRepositoryMockRecorder) Find(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockMaintenanceRepository)(nil).Find), ctx, scope)
+}
+
+// FindAll mocks base method.
+func (m *MockMaintenanceRepository) FindAll(ctx context.Context) ([]domain.MaintenanceMode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.MaintenanceMode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockMaintenanceRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockMaintenanceRepository)(nil).FindAll), ctx)
+}
+
+// Save mocks base method.
+func (m *MockMaintenanceRepository) Save(ctx context.Context, mode *domain.MaintenanceMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMaintenanceRepositoryMockRecorder) Save(ctx, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMaintenanceRepository)(nil).Save), ctx, mode)
+}
+
+// MockPartnerQuotaRepository is a mock of PartnerQuotaRepository interface.
+type MockPartnerQuotaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerQuotaRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerQuotaRepositoryMockRecorder is the mock recorder for MockPartnerQuotaRepository.
+type MockPartnerQuotaRepositoryMockRecorder struct {
+	mock *MockPartnerQuotaRepository
+}
+
+// NewMockPartnerQuotaRepository creates a new mock instance.
+func NewMockPartnerQuotaRepository(ctrl *gomock.Controller) *MockPartnerQuotaRepository {
+	mock := &MockPartnerQuotaRepository{ctrl: ctrl}
+	mock.recorder = &MockPartnerQuotaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerQuotaRepository) EXPECT() *MockPartnerQuotaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPartnerQuotaRepository) Delete(ctx context.Context, partnerID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, partnerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPartnerQuotaRepositoryMockRecorder) Delete(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPartnerQuotaRepository)(nil).Delete), ctx, partnerID)
+}
+
+// FindByPartner mocks base method.
+func (m *MockPartnerQuotaRepository) FindByPartner(ctx context.Context, partnerID uint64) (*domain.PartnerQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPartner", ctx, partnerID)
+	ret0, _ := ret[0].(*domain.PartnerQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPartner indicates an expected call of FindByPartner.
+func (mr *MockPartnerQuotaRepositoryMockRecorder) FindByPartner(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPartner", reflect.TypeOf((*MockPartnerQuotaRepository)(nil).FindByPartner), ctx, partnerID)
+}
+
+// Upsert mocks base method.
+func (m *MockPartnerQuotaRepository) Upsert(ctx context.Context, quota *domain.PartnerQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockPartnerQuotaRepositoryMockRecorder) Upsert(ctx, quota any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockPartnerQuotaRepository)(nil).Upsert), ctx, quota)
+}
+
+// MockQuotaCounterRepository is a mock of QuotaCounterRepository interface.
+type MockQuotaCounterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuotaCounterRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuotaCounterRepositoryMockRecorder is the mock recorder for MockQuotaCounterRepository.
+type MockQuotaCounterRepositoryMockRecorder struct {
+	mock *MockQuotaCounterRepository
+}
+
+// NewMockQuotaCounterRepository creates a new mock instance.
+func NewMockQuotaCounterRepository(ctrl *gomock.Controller) *MockQuotaCounterRepository {
+	mock := &MockQuotaCounterRepository{ctrl: ctrl}
+	mock.recorder = &MockQuotaCounterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuotaCounterRepository) EXPECT() *MockQuotaCounterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockQuotaCounterRepository) Find(ctx context.Context, partnerID uint64, day string) (*domain.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, partnerID, day)
+	ret0, _ := ret[0].(*domain.QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockQuotaCounterRepositoryMockRecorder) Find(ctx, partnerID, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockQuotaCounterRepository)(nil).Find), ctx, partnerID, day)
+}
+
+// Release mocks base method.
+func (m *MockQuotaCounterRepository) Release(ctx context.Context, partnerID uint64, day string, volume decimal.Decimal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, partnerID, day, volume)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockQuotaCounterRepositoryMockRecorder) Release(ctx, partnerID, day, volume any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockQuotaCounterRepository)(nil).Release), ctx, partnerID, day, volume)
+}
+
+// Reserve mocks base method.
+func (m *MockQuotaCounterRepository) Reserve(ctx context.Context, quota domain.PartnerQuota, day string, volume decimal.Decimal, expiresAt time.Time) (*domain.QuotaUsage, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, quota, day, volume, expiresAt)
+	ret0, _ := ret[0].(*domain.QuotaUsage)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockQuotaCounterRepositoryMockRecorder) Reserve(ctx, quota, day, volume, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockQuotaCounterRepository)(nil).Reserve), ctx, quota, day, volume, expiresAt)
+}
+
+// MockAPIUsageRepository is a mock of APIUsageRepository interface.
+type MockAPIUsageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIUsageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIUsageRepositoryMockRecorder is the mock recorder for MockAPIUsageRepository.
+type MockAPIUsageRepositoryMockRecorder struct {
+	mock *MockAPIUsageRepository
+}
+
+// NewMockAPIUsageRepository creates a new mock instance.
+func NewMockAPIUsageRepository(ctrl *gomock.Controller) *MockAPIUsageRepository {
+	mock := &MockAPIUsageRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIUsageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIUsageRepository) EXPECT() *MockAPIUsageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByPartner mocks base method.
+func (m *MockAPIUsageRepository) FindByPartner(ctx context.Context, partnerID uint64, days []time.Time) ([]domain.APIUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPartner", ctx, partnerID, days)
+	ret0, _ := ret[0].([]domain.APIUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPartner indicates an expected call of FindByPartner.
+func (mr *MockAPIUsageRepositoryMockRecorder) FindByPartner(ctx, partnerID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPartner", reflect.TypeOf((*MockAPIUsageRepository)(nil).FindByPartner), ctx, partnerID, days)
+}
+
+// MockTransactionBatchRepository is a mock of TransactionBatchRepository interface.
+type MockTransactionBatchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionBatchRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionBatchRepositoryMockRecorder is the mock recorder for MockTransactionBatchRepository.
+type MockTransactionBatchRepositoryMockRecorder struct {
+	mock *MockTransactionBatchRepository
+}
+
+// NewMockTransactionBatchRepository creates a new mock instance.
+func NewMockTransactionBatchRepository(ctrl *gomock.Controller) *MockTransactionBatchRepository {
+	mock := &MockTransactionBatchRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionBatchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionBatchRepository) EXPECT() *MockTransactionBatchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimItem mocks base method.
+func (m *MockTransactionBatchRepository) ClaimItem(ctx context.Context, id uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimItem", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimItem indicates an expected call of ClaimItem.
+func (mr *MockTransactionBatchRepositoryMockRecorder) ClaimItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimItem", reflect.TypeOf((*MockTransactionBatchRepository)(nil).ClaimItem), ctx, id)
+}
+
+// Complete mocks base method.
+func (m *MockTransactionBatchRepository) Complete(ctx context.Context, id uint64, completedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id, completedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockTransactionBatchRepositoryMockRecorder) Complete(ctx, id, completedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockTransactionBatchRepository)(nil).Complete), ctx, id, completedAt)
+}
+
+// CompleteItem mocks base method.
+func (m *MockTransactionBatchRepository) CompleteItem(ctx context.Context, item *domain.TransactionBatchItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteItem indicates an expected call of CompleteItem.
+func (mr *MockTransactionBatchRepositoryMockRecorder) CompleteItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteItem", reflect.TypeOf((*MockTransactionBatchRepository)(nil).CompleteItem), ctx, item)
+}
+
+// Create mocks base method.
+func (m *MockTransactionBatchRepository) Create(ctx context.Context, batch *domain.TransactionBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTransactionBatchRepositoryMockRecorder) Create(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionBatchRepository)(nil).Create), ctx, batch)
+}
+
+// FindByID mocks base method.
+func (m *MockTransactionBatchRepository) FindByID(ctx context.Context, id uint64) (*domain.TransactionBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.TransactionBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTransactionBatchRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTransactionBatchRepository)(nil).FindByID), ctx, id)
+}
+
+// FindPending mocks base method.
+func (m *MockTransactionBatchRepository) FindPending(ctx context.Context, limit int) ([]domain.TransactionBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending", ctx, limit)
+	ret0, _ := ret[0].([]domain.TransactionBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockTransactionBatchRepositoryMockRecorder) FindPending(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockTransactionBatchRepository)(nil).FindPending), ctx, limit)
+}
+
+// MockStatementRepository is a mock of StatementRepository interface.
+type MockStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementRepositoryMockRecorder is the mock recorder for MockStatementRepository.
+type MockStatementRepositoryMockRecorder struct {
+	mock *MockStatementRepository
+}
+
+// NewMockStatementRepository creates a new mock instance.
+func NewMockStatementRepository(ctrl *gomock.Controller) *MockStatementRepository {
+	mock := &MockStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRepository) EXPECT() *MockStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByContractNumber mocks base method.
+func (m *MockStatementRepository) FindByContractNumber(ctx context.Context, contractNumber string) (*domain.TransactionStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByContractNumber", ctx, contractNumber)
+	ret0, _ := ret[0].(*domain.TransactionStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByContractNumber indicates an expected call of FindByContractNumber.
+func (mr *MockStatementRepositoryMockRecorder) FindByContractNumber(ctx, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByContractNumber", reflect.TypeOf((*MockStatementRepository)(nil).FindByContractNumber), ctx, contractNumber)
+}
+
+// FindPending mocks base method.
+func (m *MockStatementRepository) FindPending(ctx context.Context, limit int) ([]domain.TransactionStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending", ctx, limit)
+	ret0, _ := ret[0].([]domain.TransactionStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockStatementRepositoryMockRecorder) FindPending(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockStatementRepository)(nil).FindPending), ctx, limit)
+}
+
+// Save mocks base method.
+func (m *MockStatementRepository) Save(ctx context.Context, statement *domain.TransactionStatement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, statement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockStatementRepositoryMockRecorder) Save(ctx, statement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockStatementRepository)(nil).Save), ctx, statement)
+}
+
+// MockEmailRepository is a mock of EmailRepository interface.
+type MockEmailRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailRepositoryMockRecorder is the mock recorder for MockEmailRepository.
+type MockEmailRepositoryMockRecorder struct {
+	mock *MockEmailRepository
+}
+
+// NewMockEmailRepository creates a new mock instance.
+func NewMockEmailRepository(ctrl *gomock.Controller) *MockEmailRepository {
+	mock := &MockEmailRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailRepository) EXPECT() *MockEmailRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockEmailRepository) Claim(ctx context.Context, message *domain.EmailMessage, leaseUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, message, leaseUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockEmailRepositoryMockRecorder) Claim(ctx, message, leaseUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockEmailRepository)(nil).Claim), ctx, message, leaseUntil)
+}
+
+// Create mocks base method.
+func (m *MockEmailRepository) Create(ctx context.Context, message *domain.EmailMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailRepositoryMockRecorder) Create(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailRepository)(nil).Create), ctx, message)
+}
+
+// FindDue mocks base method.
+func (m *MockEmailRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.EmailMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.EmailMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockEmailRepositoryMockRecorder) FindDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockEmailRepository)(nil).FindDue), ctx, now, limit)
+}
+
+// Update mocks base method.
+func (m *MockEmailRepository) Update(ctx context.Context, message *domain.EmailMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEmailRepositoryMockRecorder) Update(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEmailRepository)(nil).Update), ctx, message)
+}
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteDevice mocks base method.
+func (m *MockNotificationRepository) DeleteDevice(ctx context.Context, customerID uint64, token string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDevice", ctx, customerID, token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDevice indicates an expected call of DeleteDevice.
+func (mr *MockNotificationRepositoryMockRecorder) DeleteDevice(ctx, customerID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDevice", reflect.TypeOf((*MockNotificationRepository)(nil).DeleteDevice), ctx, customerID, token)
+}
+
+// DeleteDeviceByToken mocks base method.
+func (m *MockNotificationRepository) DeleteDeviceByToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeviceByToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeviceByToken indicates an expected call of DeleteDeviceByToken.
+func (mr *MockNotificationRepositoryMockRecorder) DeleteDeviceByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeviceByToken", reflect.TypeOf((*MockNotificationRepository)(nil).DeleteDeviceByToken), ctx, token)
+}
+
+// FindDevices mocks base method.
+func (m *MockNotificationRepository) FindDevices(ctx context.Context, customerID uint64) ([]domain.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDevices", ctx, customerID)
+	ret0, _ := ret[0].([]domain.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDevices indicates an expected call of FindDevices.
+func (mr *MockNotificationRepositoryMockRecorder) FindDevices(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDevices", reflect.TypeOf((*MockNotificationRepository)(nil).FindDevices), ctx, customerID)
+}
+
+// FindPreferences mocks base method.
+func (m *MockNotificationRepository) FindPreferences(ctx context.Context, customerID uint64) (*domain.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPreferences", ctx, customerID)
+	ret0, _ := ret[0].(*domain.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPreferences indicates an expected call of FindPreferences.
+func (mr *MockNotificationRepositoryMockRecorder) FindPreferences(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPreferences", reflect.TypeOf((*MockNotificationRepository)(nil).FindPreferences), ctx, customerID)
+}
+
+// SavePreferences mocks base method.
+func (m *MockNotificationRepository) SavePreferences(ctx context.Context, preferences *domain.NotificationPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", ctx, preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockNotificationRepositoryMockRecorder) SavePreferences(ctx, preferences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockNotificationRepository)(nil).SavePreferences), ctx, preferences)
+}
+
+// UpsertDevice mocks base method.
+func (m *MockNotificationRepository) UpsertDevice(ctx context.Context, device *domain.Device) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDevice", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertDevice indicates an expected call of UpsertDevice.
+func (mr *MockNotificationRepositoryMockRecorder) UpsertDevice(ctx, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDevice", reflect.TypeOf((*MockNotificationRepository)(nil).UpsertDevice), ctx, device)
+}
+
+// MockPaymentReminderRepository is a mock of PaymentReminderRepository interface.
+type MockPaymentReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentReminderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentReminderRepositoryMockRecorder is the mock recorder for MockPaymentReminderRepository.
+type MockPaymentReminderRepositoryMockRecorder struct {
+	mock *MockPaymentReminderRepository
+}
+
+// NewMockPaymentReminderRepository creates a new mock instance.
+func NewMockPaymentReminderRepository(ctrl *gomock.Controller) *MockPaymentReminderRepository {
+	mock := &MockPaymentReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentReminderRepository) EXPECT() *MockPaymentReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockPaymentReminderRepository) Claim(ctx context.Context, reminder *domain.PaymentReminder) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, reminder)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockPaymentReminderRepositoryMockRecorder) Claim(ctx, reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockPaymentReminderRepository)(nil).Claim), ctx, reminder)
+}
+
+// FindUpcoming mocks base method.
+func (m *MockPaymentReminderRepository) FindUpcoming(ctx context.Context, afterID uint64, limit int) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUpcoming", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUpcoming indicates an expected call of FindUpcoming.
+func (mr *MockPaymentReminderRepositoryMockRecorder) FindUpcoming(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUpcoming", reflect.TypeOf((*MockPaymentReminderRepository)(nil).FindUpcoming), ctx, afterID, limit)
+}
+
+// Release mocks base method.
+func (m *MockPaymentReminderRepository) Release(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockPaymentReminderRepositoryMockRecorder) Release(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockPaymentReminderRepository)(nil).Release), ctx, id)
+}
+
+// MockMonthlyStatementRepository is a mock of MonthlyStatementRepository interface.
+type MockMonthlyStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonthlyStatementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMonthlyStatementRepositoryMockRecorder is the mock recorder for MockMonthlyStatementRepository.
+type MockMonthlyStatementRepositoryMockRecorder struct {
+	mock *MockMonthlyStatementRepository
+}
+
+// NewMockMonthlyStatementRepository creates a new mock instance.
+func NewMockMonthlyStatementRepository(ctrl *gomock.Controller) *MockMonthlyStatementRepository {
+	mock := &MockMonthlyStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockMonthlyStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMonthlyStatementRepository) EXPECT() *MockMonthlyStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMonthlyStatementRepository) Create(ctx context.Context, statement *domain.MonthlyStatement) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, statement)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMonthlyStatementRepositoryMockRecorder) Create(ctx, statement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMonthlyStatementRepository)(nil).Create), ctx, statement)
+}
+
+// FindContracts mocks base method.
+func (m *MockMonthlyStatementRepository) FindContracts(ctx context.Context, customerID uint64) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindContracts", ctx, customerID)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindContracts indicates an expected call of FindContracts.
+func (mr *MockMonthlyStatementRepositoryMockRecorder) FindContracts(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindContracts", reflect.TypeOf((*MockMonthlyStatementRepository)(nil).FindContracts), ctx, customerID)
+}
+
+// FindPayments mocks base method.
+func (m *MockMonthlyStatementRepository) FindPayments(ctx context.Context, customerID uint64, from, to time.Time) ([]domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPayments", ctx, customerID, from, to)
+	ret0, _ := ret[0].([]domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPayments indicates an expected call of FindPayments.
+func (mr *MockMonthlyStatementRepositoryMockRecorder) FindPayments(ctx, customerID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPayments", reflect.TypeOf((*MockMonthlyStatementRepository)(nil).FindPayments), ctx, customerID, from, to)
+}
+
+// FindRecipients mocks base method.
+func (m *MockMonthlyStatementRepository) FindRecipients(ctx context.Context, period string, from, to time.Time, limit int) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecipients", ctx, period, from, to, limit)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecipients indicates an expected call of FindRecipients.
+func (mr *MockMonthlyStatementRepositoryMockRecorder) FindRecipients(ctx, period, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecipients", reflect.TypeOf((*MockMonthlyStatementRepository)(nil).FindRecipients), ctx, period, from, to, limit)
+}
+
+// SetOptOut mocks base method.
+func (m *MockMonthlyStatementRepository) SetOptOut(ctx context.Context, customerID uint64, optOut bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptOut", ctx, customerID, optOut)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOptOut indicates an expected call of SetOptOut.
+func (mr *MockMonthlyStatementRepositoryMockRecorder) SetOptOut(ctx, customerID, optOut any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptOut", reflect.TypeOf((*MockMonthlyStatementRepository)(nil).SetOptOut), ctx, customerID, optOut)
+}
+
+// MockCustomerNoteRepository is a mock of CustomerNoteRepository interface.
+type MockCustomerNoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerNoteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerNoteRepositoryMockRecorder is the mock recorder for MockCustomerNoteRepository.
+type MockCustomerNoteRepositoryMockRecorder struct {
+	mock *MockCustomerNoteRepository
+}
+
+// NewMockCustomerNoteRepository creates a new mock instance.
+func NewMockCustomerNoteRepository(ctrl *gomock.Controller) *MockCustomerNoteRepository {
+	mock := &MockCustomerNoteRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerNoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerNoteRepository) EXPECT() *MockCustomerNoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomerNoteRepository) Create(ctx context.Context, note *domain.CustomerNote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomerNoteRepositoryMockRecorder) Create(ctx, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerNoteRepository)(nil).Create), ctx, note)
+}
+
+// FindByCustomer mocks base method.
+func (m *MockCustomerNoteRepository) FindByCustomer(ctx context.Context, customerID uint64) ([]domain.CustomerNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomer", ctx, customerID)
+	ret0, _ := ret[0].([]domain.CustomerNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomer indicates an expected call of FindByCustomer.
+func (mr *MockCustomerNoteRepositoryMockRecorder) FindByCustomer(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomer", reflect.TypeOf((*MockCustomerNoteRepository)(nil).FindByCustomer), ctx, customerID)
+}
+
+// FindByID mocks base method.
+func (m *MockCustomerNoteRepository) FindByID(ctx context.Context, id uint64) (*domain.CustomerNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.CustomerNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCustomerNoteRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCustomerNoteRepository)(nil).FindByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockCustomerNoteRepository) Update(ctx context.Context, note *domain.CustomerNote) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, note)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomerNoteRepositoryMockRecorder) Update(ctx, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomerNoteRepository)(nil).Update), ctx, note)
+}
+
+// MockTransactionAttachmentRepository is a mock of TransactionAttachmentRepository interface.
+type MockTransactionAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionAttachmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionAttachmentRepositoryMockRecorder is the mock recorder for MockTransactionAttachmentRepository.
+type MockTransactionAttachmentRepositoryMockRecorder struct {
+	mock *MockTransactionAttachmentRepository
+}
+
+// NewMockTransactionAttachmentRepository creates a new mock instance.
+func NewMockTransactionAttachmentRepository(ctrl *gomock.Controller) *MockTransactionAttachmentRepository {
+	mock := &MockTransactionAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionAttachmentRepository) EXPECT() *MockTransactionAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTransactionAttachmentRepository) Create(ctx context.Context, attachment *domain.TransactionAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTransactionAttachmentRepositoryMockRecorder) Create(ctx, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionAttachmentRepository)(nil).Create), ctx, attachment)
+}
+
+// Delete mocks base method.
+func (m *MockTransactionAttachmentRepository) Delete(ctx context.Context, id, deletedBy uint64, deletedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, deletedBy, deletedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTransactionAttachmentRepositoryMockRecorder) Delete(ctx, id, deletedBy, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransactionAttachmentRepository)(nil).Delete), ctx, id, deletedBy, deletedAt)
+}
+
+// FindByID mocks base method.
+func (m *MockTransactionAttachmentRepository) FindByID(ctx context.Context, id uint64) (*domain.TransactionAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.TransactionAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTransactionAttachmentRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTransactionAttachmentRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByTransaction mocks base method.
+func (m *MockTransactionAttachmentRepository) FindByTransaction(ctx context.Context, transactionID uint64) ([]domain.TransactionAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTransaction", ctx, transactionID)
+	ret0, _ := ret[0].([]domain.TransactionAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTransaction indicates an expected call of FindByTransaction.
+func (mr *MockTransactionAttachmentRepositoryMockRecorder) FindByTransaction(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTransaction", reflect.TypeOf((*MockTransactionAttachmentRepository)(nil).FindByTransaction), ctx, transactionID)
+}
+
+// FindTransaction mocks base method.
+func (m *MockTransactionAttachmentRepository) FindTransaction(ctx context.Context, id uint64) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransaction", ctx, id)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransaction indicates an expected call of FindTransaction.
+func (mr *MockTransactionAttachmentRepositoryMockRecorder) FindTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransaction", reflect.TypeOf((*MockTransactionAttachmentRepository)(nil).FindTransaction), ctx, id)
+}
+
+// MockRegionRepository is a mock of RegionRepository interface.
+type MockRegionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRegionRepositoryMockRecorder is the mock recorder for MockRegionRepository.
+type MockRegionRepositoryMockRecorder struct {
+	mock *MockRegionRepository
+}
+
+// NewMockRegionRepository creates a new mock instance.
+func NewMockRegionRepository(ctrl *gomock.Controller) *MockRegionRepository {
+	mock := &MockRegionRepository{ctrl: ctrl}
+	mock.recorder = &MockRegionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegionRepository) EXPECT() *MockRegionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockRegionRepository) FindAll(ctx context.Context) ([]domain.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRegionRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRegionRepository)(nil).FindAll), ctx)
+}
+
+// FindByCode mocks base method.
+func (m *MockRegionRepository) FindByCode(ctx context.Context, code string) (*domain.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, code)
+	ret0, _ := ret[0].(*domain.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockRegionRepositoryMockRecorder) FindByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockRegionRepository)(nil).FindByCode), ctx, code)
+}
+
+// Upsert mocks base method.
+func (m *MockRegionRepository) Upsert(ctx context.Context, region *domain.Region) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, region)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockRegionRepositoryMockRecorder) Upsert(ctx, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRegionRepository)(nil).Upsert), ctx, region)
+}
+
+// MockAssetCategoryRepository is a mock of AssetCategoryRepository interface.
+type MockAssetCategoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssetCategoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAssetCategoryRepositoryMockRecorder is the mock recorder for MockAssetCategoryRepository.
+type MockAssetCategoryRepositoryMockRecorder struct {
+	mock *MockAssetCategoryRepository
+}
+
+// NewMockAssetCategoryRepository creates a new mock instance.
+func NewMockAssetCategoryRepository(ctrl *gomock.Controller) *MockAssetCategoryRepository {
+	mock := &MockAssetCategoryRepository{ctrl: ctrl}
+	mock.recorder = &MockAssetCategoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssetCategoryRepository) EXPECT() *MockAssetCategoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Upsert mocks base method.
+func (m *MockAssetCategoryRepository) Upsert(ctx context.Context, category *domain.AssetCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockAssetCategoryRepositoryMockRecorder) Upsert(ctx, category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockAssetCategoryRepository)(nil).Upsert), ctx, category)
+}
+
+// MockContractSequenceRepository is a mock of ContractSequenceRepository interface.
+type MockContractSequenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockContractSequenceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockContractSequenceRepositoryMockRecorder is the mock recorder for MockContractSequenceRepository.
+type MockContractSequenceRepositoryMockRecorder struct {
+	mock *MockContractSequenceRepository
+}
+
+// NewMockContractSequenceRepository creates a new mock instance.
+func NewMockContractSequenceRepository(ctrl *gomock.Controller) *MockContractSequenceRepository {
+	mock := &MockContractSequenceRepository{ctrl: ctrl}
+	mock.recorder = &MockContractSequenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContractSequenceRepository) EXPECT() *MockContractSequenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Next mocks base method.
+func (m *MockContractSequenceRepository) Next(ctx context.Context, sandbox bool, regionCode, day string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", ctx, sandbox, regionCode, day)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockContractSequenceRepositoryMockRecorder) Next(ctx, sandbox, regionCode, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockContractSequenceRepository)(nil).Next), ctx, sandbox, regionCode, day)
+}
+
+// MockCustomerExposureRepository is a mock of CustomerExposureRepository interface.
+type MockCustomerExposureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerExposureRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerExposureRepositoryMockRecorder is the mock recorder for MockCustomerExposureRepository.
+type MockCustomerExposureRepositoryMockRecorder struct {
+	mock *MockCustomerExposureRepository
+}
+
+// NewMockCustomerExposureRepository creates a new mock instance.
+func NewMockCustomerExposureRepository(ctrl *gomock.Controller) *MockCustomerExposureRepository {
+	mock := &MockCustomerExposureRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerExposureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerExposureRepository) EXPECT() *MockCustomerExposureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCustomerExposureRepository) Add(ctx context.Context, customerID uint64, tenorID uint, amount decimal.Decimal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, customerID, tenorID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCustomerExposureRepositoryMockRecorder) Add(ctx, customerID, tenorID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCustomerExposureRepository)(nil).Add), ctx, customerID, tenorID, amount)
+}
+
+// Find mocks base method.
+func (m *MockCustomerExposureRepository) Find(ctx context.Context, customerID uint64, tenorID uint) (*domain.CustomerExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, customerID, tenorID)
+	ret0, _ := ret[0].(*domain.CustomerExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCustomerExposureRepositoryMockRecorder) Find(ctx, customerID, tenorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCustomerExposureRepository)(nil).Find), ctx, customerID, tenorID)
+}
+
+// FindAll mocks base method.
+func (m *MockCustomerExposureRepository) FindAll(ctx context.Context) ([]domain.CustomerExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]domain.CustomerExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCustomerExposureRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCustomerExposureRepository)(nil).FindAll), ctx)
+}
+
+// Recompute mocks base method.
+func (m *MockCustomerExposureRepository) Recompute(ctx context.Context, customerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recompute", ctx, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Recompute indicates an expected call of Recompute.
+func (mr *MockCustomerExposureRepositoryMockRecorder) Recompute(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recompute", reflect.TypeOf((*MockCustomerExposureRepository)(nil).Recompute), ctx, customerID)
+}
+
+// Repair mocks base method.
+func (m *MockCustomerExposureRepository) Repair(ctx context.Context, customerID uint64, tenorID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repair", ctx, customerID, tenorID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Repair indicates an expected call of Repair.
+func (mr *MockCustomerExposureRepositoryMockRecorder) Repair(ctx, customerID, tenorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockCustomerExposureRepository)(nil).Repair), ctx, customerID, tenorID)
+}
+
+// SumTransactions mocks base method.
+func (m *MockCustomerExposureRepository) SumTransactions(ctx context.Context) ([]domain.CustomerExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTransactions", ctx)
+	ret0, _ := ret[0].([]domain.CustomerExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumTransactions indicates an expected call of SumTransactions.
+func (mr *MockCustomerExposureRepositoryMockRecorder) SumTransactions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransactions", reflect.TypeOf((*MockCustomerExposureRepository)(nil).SumTransactions), ctx)
+}
+
+// MockCustomerEventRepository is a mock of CustomerEventRepository interface.
+type MockCustomerEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerEventRepositoryMockRecorder is the mock recorder for MockCustomerEventRepository.
+type MockCustomerEventRepositoryMockRecorder struct {
+	mock *MockCustomerEventRepository
+}
+
+// NewMockCustomerEventRepository creates a new mock instance.
+func NewMockCustomerEventRepository(ctrl *gomock.Controller) *MockCustomerEventRepository {
+	mock := &MockCustomerEventRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerEventRepository) EXPECT() *MockCustomerEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockCustomerEventRepository) Append(ctx context.Context, event *domain.CustomerEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockCustomerEventRepositoryMockRecorder) Append(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockCustomerEventRepository)(nil).Append), ctx, event)
+}
+
+// CountSince mocks base method.
+func (m *MockCustomerEventRepository) CountSince(ctx context.Context, eventType domain.CustomerEventType, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSince", ctx, eventType, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSince indicates an expected call of CountSince.
+func (mr *MockCustomerEventRepositoryMockRecorder) CountSince(ctx, eventType, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSince", reflect.TypeOf((*MockCustomerEventRepository)(nil).CountSince), ctx, eventType, since)
+}
+
+// FindAfter mocks base method.
+func (m *MockCustomerEventRepository) FindAfter(ctx context.Context, afterID uint64, types []domain.CustomerEventType, limit int) ([]domain.CustomerEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAfter", ctx, afterID, types, limit)
+	ret0, _ := ret[0].([]domain.CustomerEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAfter indicates an expected call of FindAfter.
+func (mr *MockCustomerEventRepositoryMockRecorder) FindAfter(ctx, afterID, types, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAfter", reflect.TypeOf((*MockCustomerEventRepository)(nil).FindAfter), ctx, afterID, types, limit)
+}
+
+// FindByCustomer mocks base method.
+func (m *MockCustomerEventRepository) FindByCustomer(ctx context.Context, customerID uint64, params domain.Params) ([]domain.CustomerEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomer", ctx, customerID, params)
+	ret0, _ := ret[0].([]domain.CustomerEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByCustomer indicates an expected call of FindByCustomer.
+func (mr *MockCustomerEventRepositoryMockRecorder) FindByCustomer(ctx, customerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomer", reflect.TypeOf((*MockCustomerEventRepository)(nil).FindByCustomer), ctx, customerID, params)
+}
+
+// LatestID mocks base method.
+func (m *MockCustomerEventRepository) LatestID(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestID", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestID indicates an expected call of LatestID.
+func (mr *MockCustomerEventRepositoryMockRecorder) LatestID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestID", reflect.TypeOf((*MockCustomerEventRepository)(nil).LatestID), ctx)
+}
+
+// MockCustomerRestrictionRepository is a mock of CustomerRestrictionRepository interface.
+type MockCustomerRestrictionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRestrictionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerRestrictionRepositoryMockRecorder is the mock recorder for MockCustomerRestrictionRepository.
+type MockCustomerRestrictionRepositoryMockRecorder struct {
+	mock *MockCustomerRestrictionRepository
+}
+
+// NewMockCustomerRestrictionRepository creates a new mock instance.
+func NewMockCustomerRestrictionRepository(ctrl *gomock.Controller) *MockCustomerRestrictionRepository {
+	mock := &MockCustomerRestrictionRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerRestrictionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRestrictionRepository) EXPECT() *MockCustomerRestrictionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomerRestrictionRepository) Create(ctx context.Context, restriction *domain.CustomerRestriction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, restriction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomerRestrictionRepositoryMockRecorder) Create(ctx, restriction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerRestrictionRepository)(nil).Create), ctx, restriction)
+}
+
+// FindActive mocks base method.
+func (m *MockCustomerRestrictionRepository) FindActive(ctx context.Context, customerID uint64, now time.Time) ([]domain.CustomerRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", ctx, customerID, now)
+	ret0, _ := ret[0].([]domain.CustomerRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockCustomerRestrictionRepositoryMockRecorder) FindActive(ctx, customerID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockCustomerRestrictionRepository)(nil).FindActive), ctx, customerID, now)
+}
+
+// FindByCustomer mocks base method.
+func (m *MockCustomerRestrictionRepository) FindByCustomer(ctx context.Context, customerID uint64) ([]domain.CustomerRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomer", ctx, customerID)
+	ret0, _ := ret[0].([]domain.CustomerRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomer indicates an expected call of FindByCustomer.
+func (mr *MockCustomerRestrictionRepositoryMockRecorder) FindByCustomer(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomer", reflect.TypeOf((*MockCustomerRestrictionRepository)(nil).FindByCustomer), ctx, customerID)
+}
+
+// FindExpired mocks base method.
+func (m *MockCustomerRestrictionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]domain.CustomerRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpired", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.CustomerRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpired indicates an expected call of FindExpired.
+func (mr *MockCustomerRestrictionRepositoryMockRecorder) FindExpired(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpired", reflect.TypeOf((*MockCustomerRestrictionRepository)(nil).FindExpired), ctx, now, limit)
+}
+
+// Lift mocks base method.
+func (m *MockCustomerRestrictionRepository) Lift(ctx context.Context, id uint64, liftedBy *uint64, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lift", ctx, id, liftedBy, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lift indicates an expected call of Lift.
+func (mr *MockCustomerRestrictionRepositoryMockRecorder) Lift(ctx, id, liftedBy, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lift", reflect.TypeOf((*MockCustomerRestrictionRepository)(nil).Lift), ctx, id, liftedBy, at)
+}
+
+// MockDormancyRepository is a mock of DormancyRepository interface.
+type MockDormancyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDormancyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDormancyRepositoryMockRecorder is the mock recorder for MockDormancyRepository.
+type MockDormancyRepositoryMockRecorder struct {
+	mock *MockDormancyRepository
+}
+
+// NewMockDormancyRepository creates a new mock instance.
+func NewMockDormancyRepository(ctrl *gomock.Controller) *MockDormancyRepository {
+	mock := &MockDormancyRepository{ctrl: ctrl}
+	mock.recorder = &MockDormancyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDormancyRepository) EXPECT() *MockDormancyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindInactive mocks base method.
+func (m *MockDormancyRepository) FindInactive(ctx context.Context, cutoff time.Time, limit int) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInactive", ctx, cutoff, limit)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInactive indicates an expected call of FindInactive.
+func (mr *MockDormancyRepositoryMockRecorder) FindInactive(ctx, cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInactive", reflect.TypeOf((*MockDormancyRepository)(nil).FindInactive), ctx, cutoff, limit)
+}
+
+// MarkDormant mocks base method.
+func (m *MockDormancyRepository) MarkDormant(ctx context.Context, customerID uint64, cutoff, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDormant", ctx, customerID, cutoff, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDormant indicates an expected call of MarkDormant.
+func (mr *MockDormancyRepositoryMockRecorder) MarkDormant(ctx, customerID, cutoff, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDormant", reflect.TypeOf((*MockDormancyRepository)(nil).MarkDormant), ctx, customerID, cutoff, at)
+}
+
+// Reactivate mocks base method.
+func (m *MockDormancyRepository) Reactivate(ctx context.Context, customerID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactivate", ctx, customerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reactivate indicates an expected call of Reactivate.
+func (mr *MockDormancyRepositoryMockRecorder) Reactivate(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactivate", reflect.TypeOf((*MockDormancyRepository)(nil).Reactivate), ctx, customerID)
+}
+
+// Stats mocks base method.
+func (m *MockDormancyRepository) Stats(ctx context.Context, since time.Time) (*domain.DormancyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, since)
+	ret0, _ := ret[0].(*domain.DormancyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockDormancyRepositoryMockRecorder) Stats(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockDormancyRepository)(nil).Stats), ctx, since)
+}
+
+// MockAccountClosureRepository is a mock of AccountClosureRepository interface.
+type MockAccountClosureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountClosureRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountClosureRepositoryMockRecorder is the mock recorder for MockAccountClosureRepository.
+type MockAccountClosureRepositoryMockRecorder struct {
+	mock *MockAccountClosureRepository
+}
+
+// NewMockAccountClosureRepository creates a new mock instance.
+func NewMockAccountClosureRepository(ctrl *gomock.Controller) *MockAccountClosureRepository {
+	mock := &MockAccountClosureRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountClosureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountClosureRepository) EXPECT() *MockAccountClosureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Anonymize mocks base method.
+func (m *MockAccountClosureRepository) Anonymize(ctx context.Context, closure domain.AccountClosure, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, closure, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockAccountClosureRepositoryMockRecorder) Anonymize(ctx, closure, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockAccountClosureRepository)(nil).Anonymize), ctx, closure, at)
+}
+
+// Blockers mocks base method.
+func (m *MockAccountClosureRepository) Blockers(ctx context.Context, customerID uint64) (*domain.ClosureBlockers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Blockers", ctx, customerID)
+	ret0, _ := ret[0].(*domain.ClosureBlockers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Blockers indicates an expected call of Blockers.
+func (mr *MockAccountClosureRepositoryMockRecorder) Blockers(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blockers", reflect.TypeOf((*MockAccountClosureRepository)(nil).Blockers), ctx, customerID)
+}
+
+// Close mocks base method.
+func (m *MockAccountClosureRepository) Close(ctx context.Context, closure *domain.AccountClosure) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, closure)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockAccountClosureRepositoryMockRecorder) Close(ctx, closure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockAccountClosureRepository)(nil).Close), ctx, closure)
+}
+
+// FindDue mocks base method.
+func (m *MockAccountClosureRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.AccountClosure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.AccountClosure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockAccountClosureRepositoryMockRecorder) FindDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockAccountClosureRepository)(nil).FindDue), ctx, now, limit)
+}
+
+// IsClosed mocks base method.
+func (m *MockAccountClosureRepository) IsClosed(ctx context.Context, customerID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsClosed", ctx, customerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsClosed indicates an expected call of IsClosed.
+func (mr *MockAccountClosureRepositoryMockRecorder) IsClosed(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClosed", reflect.TypeOf((*MockAccountClosureRepository)(nil).IsClosed), ctx, customerID)
+}
+
+// MockCalendarTokenRepository is a mock of CalendarTokenRepository interface.
+type MockCalendarTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarTokenRepositoryMockRecorder is the mock recorder for MockCalendarTokenRepository.
+type MockCalendarTokenRepositoryMockRecorder struct {
+	mock *MockCalendarTokenRepository
+}
+
+// NewMockCalendarTokenRepository creates a new mock instance.
+func NewMockCalendarTokenRepository(ctrl *gomock.Controller) *MockCalendarTokenRepository {
+	mock := &MockCalendarTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarTokenRepository) EXPECT() *MockCalendarTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCalendarTokenRepository) Create(ctx context.Context, token *domain.CalendarToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCalendarTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCalendarTokenRepository)(nil).Create), ctx, token)
+}
+
+// FindActiveByHash mocks base method.
+func (m *MockCalendarTokenRepository) FindActiveByHash(ctx context.Context, hash string) (*domain.CalendarToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByHash", ctx, hash)
+	ret0, _ := ret[0].(*domain.CalendarToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByHash indicates an expected call of FindActiveByHash.
+func (mr *MockCalendarTokenRepositoryMockRecorder) FindActiveByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByHash", reflect.TypeOf((*MockCalendarTokenRepository)(nil).FindActiveByHash), ctx, hash)
+}
+
+// Revoke mocks base method.
+func (m *MockCalendarTokenRepository) Revoke(ctx context.Context, customerID uint64, contractNumber string, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, customerID, contractNumber, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockCalendarTokenRepositoryMockRecorder) Revoke(ctx, customerID, contractNumber, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockCalendarTokenRepository)(nil).Revoke), ctx, customerID, contractNumber, at)
+}
+
+// MockDueDateRepository is a mock of DueDateRepository interface.
+type MockDueDateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDueDateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDueDateRepositoryMockRecorder is the mock recorder for MockDueDateRepository.
+type MockDueDateRepositoryMockRecorder struct {
+	mock *MockDueDateRepository
+}
+
+// NewMockDueDateRepository creates a new mock instance.
+func NewMockDueDateRepository(ctrl *gomock.Controller) *MockDueDateRepository {
+	mock := &MockDueDateRepository{ctrl: ctrl}
+	mock.recorder = &MockDueDateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDueDateRepository) EXPECT() *MockDueDateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteHoliday mocks base method.
+func (m *MockDueDateRepository) DeleteHoliday(ctx context.Context, date time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHoliday", ctx, date)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHoliday indicates an expected call of DeleteHoliday.
+func (mr *MockDueDateRepositoryMockRecorder) DeleteHoliday(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHoliday", reflect.TypeOf((*MockDueDateRepository)(nil).DeleteHoliday), ctx, date)
+}
+
+// FindAllHolidays mocks base method.
+func (m *MockDueDateRepository) FindAllHolidays(ctx context.Context) ([]domain.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllHolidays", ctx)
+	ret0, _ := ret[0].([]domain.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllHolidays indicates an expected call of FindAllHolidays.
+func (mr *MockDueDateRepositoryMockRecorder) FindAllHolidays(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllHolidays", reflect.TypeOf((*MockDueDateRepository)(nil).FindAllHolidays), ctx)
+}
+
+// FindHolidays mocks base method.
+func (m *MockDueDateRepository) FindHolidays(ctx context.Context, from, to time.Time) ([]domain.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHolidays", ctx, from, to)
+	ret0, _ := ret[0].([]domain.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHolidays indicates an expected call of FindHolidays.
+func (mr *MockDueDateRepositoryMockRecorder) FindHolidays(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHolidays", reflect.TypeOf((*MockDueDateRepository)(nil).FindHolidays), ctx, from, to)
+}
+
+// FindPolicy mocks base method.
+func (m *MockDueDateRepository) FindPolicy(ctx context.Context) (*domain.DueDatePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPolicy", ctx)
+	ret0, _ := ret[0].(*domain.DueDatePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPolicy indicates an expected call of FindPolicy.
+func (mr *MockDueDateRepositoryMockRecorder) FindPolicy(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPolicy", reflect.TypeOf((*MockDueDateRepository)(nil).FindPolicy), ctx)
+}
+
+// SavePolicy mocks base method.
+func (m *MockDueDateRepository) SavePolicy(ctx context.Context, policy *domain.DueDatePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePolicy", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePolicy indicates an expected call of SavePolicy.
+func (mr *MockDueDateRepositoryMockRecorder) SavePolicy(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePolicy", reflect.TypeOf((*MockDueDateRepository)(nil).SavePolicy), ctx, policy)
+}
+
+// UpsertHoliday mocks base method.
+func (m *MockDueDateRepository) UpsertHoliday(ctx context.Context, holiday *domain.Holiday) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertHoliday", ctx, holiday)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertHoliday indicates an expected call of UpsertHoliday.
+func (mr *MockDueDateRepositoryMockRecorder) UpsertHoliday(ctx, holiday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertHoliday", reflect.TypeOf((*MockDueDateRepository)(nil).UpsertHoliday), ctx, holiday)
+}
+
+// MockAMLRepository is a mock of AMLRepository interface.
+type MockAMLRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAMLRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAMLRepositoryMockRecorder is the mock recorder for MockAMLRepository.
+type MockAMLRepositoryMockRecorder struct {
+	mock *MockAMLRepository
+}
+
+// NewMockAMLRepository creates a new mock instance.
+func NewMockAMLRepository(ctrl *gomock.Controller) *MockAMLRepository {
+	mock := &MockAMLRepository{ctrl: ctrl}
+	mock.recorder = &MockAMLRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAMLRepository) EXPECT() *MockAMLRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountRecent mocks base method.
+func (m *MockAMLRepository) CountRecent(ctx context.Context, customerID uint64, from, to time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecent", ctx, customerID, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecent indicates an expected call of CountRecent.
+func (mr *MockAMLRepositoryMockRecorder) CountRecent(ctx, customerID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecent", reflect.TypeOf((*MockAMLRepository)(nil).CountRecent), ctx, customerID, from, to)
+}
+
+// CreateCase mocks base method.
+func (m *MockAMLRepository) CreateCase(ctx context.Context, amlCase *domain.AMLCase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCase", ctx, amlCase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCase indicates an expected call of CreateCase.
+func (mr *MockAMLRepositoryMockRecorder) CreateCase(ctx, amlCase any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCase", reflect.TypeOf((*MockAMLRepository)(nil).CreateCase), ctx, amlCase)
+}
+
+// FindCaseByID mocks base method.
+func (m *MockAMLRepository) FindCaseByID(ctx context.Context, id uint64) (*domain.AMLCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCaseByID", ctx, id)
+	ret0, _ := ret[0].(*domain.AMLCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCaseByID indicates an expected call of FindCaseByID.
+func (mr *MockAMLRepositoryMockRecorder) FindCaseByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCaseByID", reflect.TypeOf((*MockAMLRepository)(nil).FindCaseByID), ctx, id)
+}
+
+// FindCasesPaginated mocks base method.
+func (m *MockAMLRepository) FindCasesPaginated(ctx context.Context, params domain.Params) ([]domain.AMLCase, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCasesPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.AMLCase)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindCasesPaginated indicates an expected call of FindCasesPaginated.
+func (mr *MockAMLRepositoryMockRecorder) FindCasesPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCasesPaginated", reflect.TypeOf((*MockAMLRepository)(nil).FindCasesPaginated), ctx, params)
+}
+
+// FindForExport mocks base method.
+func (m *MockAMLRepository) FindForExport(ctx context.Context, from, to time.Time, status domain.AMLCaseStatus) ([]domain.AMLCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForExport", ctx, from, to, status)
+	ret0, _ := ret[0].([]domain.AMLCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForExport indicates an expected call of FindForExport.
+func (mr *MockAMLRepositoryMockRecorder) FindForExport(ctx, from, to, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForExport", reflect.TypeOf((*MockAMLRepository)(nil).FindForExport), ctx, from, to, status)
+}
+
+// FindUnscreened mocks base method.
+func (m *MockAMLRepository) FindUnscreened(ctx context.Context, limit int) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnscreened", ctx, limit)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnscreened indicates an expected call of FindUnscreened.
+func (mr *MockAMLRepositoryMockRecorder) FindUnscreened(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnscreened", reflect.TypeOf((*MockAMLRepository)(nil).FindUnscreened), ctx, limit)
+}
+
+// HasRecentCase mocks base method.
+func (m *MockAMLRepository) HasRecentCase(ctx context.Context, customerID uint64, rule domain.AMLRule, from time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRecentCase", ctx, customerID, rule, from)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRecentCase indicates an expected call of HasRecentCase.
+func (mr *MockAMLRepositoryMockRecorder) HasRecentCase(ctx, customerID, rule, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRecentCase", reflect.TypeOf((*MockAMLRepository)(nil).HasRecentCase), ctx, customerID, rule, from)
+}
+
+// MarkScreened mocks base method.
+func (m *MockAMLRepository) MarkScreened(ctx context.Context, transactionID uint64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkScreened", ctx, transactionID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkScreened indicates an expected call of MarkScreened.
+func (mr *MockAMLRepositoryMockRecorder) MarkScreened(ctx, transactionID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkScreened", reflect.TypeOf((*MockAMLRepository)(nil).MarkScreened), ctx, transactionID, at)
+}
+
+// UpdateCase mocks base method.
+func (m *MockAMLRepository) UpdateCase(ctx context.Context, amlCase *domain.AMLCase, from domain.AMLCaseStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCase", ctx, amlCase, from)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCase indicates an expected call of UpdateCase.
+func (mr *MockAMLRepositoryMockRecorder) UpdateCase(ctx, amlCase, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCase", reflect.TypeOf((*MockAMLRepository)(nil).UpdateCase), ctx, amlCase, from)
+}
+
+// MockCollectionRepository is a mock of CollectionRepository interface.
+type MockCollectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCollectionRepositoryMockRecorder is the mock recorder for MockCollectionRepository.
+type MockCollectionRepositoryMockRecorder struct {
+	mock *MockCollectionRepository
+}
+
+// NewMockCollectionRepository creates a new mock instance.
+func NewMockCollectionRepository(ctrl *gomock.Controller) *MockCollectionRepository {
+	mock := &MockCollectionRepository{ctrl: ctrl}
+	mock.recorder = &MockCollectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionRepository) EXPECT() *MockCollectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddActivity mocks base method.
+func (m *MockCollectionRepository) AddActivity(ctx context.Context, activity *domain.CollectionActivity) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActivity", ctx, activity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddActivity indicates an expected call of AddActivity.
+func (mr *MockCollectionRepositoryMockRecorder) AddActivity(ctx, activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivity", reflect.TypeOf((*MockCollectionRepository)(nil).AddActivity), ctx, activity)
+}
+
+// CreateCase mocks base method.
+func (m *MockCollectionRepository) CreateCase(ctx context.Context, collectionCase *domain.CollectionCase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCase", ctx, collectionCase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCase indicates an expected call of CreateCase.
+func (mr *MockCollectionRepositoryMockRecorder) CreateCase(ctx, collectionCase any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCase", reflect.TypeOf((*MockCollectionRepository)(nil).CreateCase), ctx, collectionCase)
+}
+
+// Dashboard mocks base method.
+func (m *MockCollectionRepository) Dashboard(ctx context.Context, today time.Time) (*domain.CollectionDashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dashboard", ctx, today)
+	ret0, _ := ret[0].(*domain.CollectionDashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dashboard indicates an expected call of Dashboard.
+func (mr *MockCollectionRepositoryMockRecorder) Dashboard(ctx, today any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dashboard", reflect.TypeOf((*MockCollectionRepository)(nil).Dashboard), ctx, today)
+}
+
+// FindActivities mocks base method.
+func (m *MockCollectionRepository) FindActivities(ctx context.Context, caseID uint64) ([]domain.CollectionActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActivities", ctx, caseID)
+	ret0, _ := ret[0].([]domain.CollectionActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActivities indicates an expected call of FindActivities.
+func (mr *MockCollectionRepositoryMockRecorder) FindActivities(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActivities", reflect.TypeOf((*MockCollectionRepository)(nil).FindActivities), ctx, caseID)
+}
+
+// FindCaseByID mocks base method.
+func (m *MockCollectionRepository) FindCaseByID(ctx context.Context, id uint64) (*domain.CollectionCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCaseByID", ctx, id)
+	ret0, _ := ret[0].(*domain.CollectionCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCaseByID indicates an expected call of FindCaseByID.
+func (mr *MockCollectionRepositoryMockRecorder) FindCaseByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCaseByID", reflect.TypeOf((*MockCollectionRepository)(nil).FindCaseByID), ctx, id)
+}
+
+// FindCasesPaginated mocks base method.
+func (m *MockCollectionRepository) FindCasesPaginated(ctx context.Context, params domain.Params) ([]domain.CollectionCase, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCasesPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.CollectionCase)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindCasesPaginated indicates an expected call of FindCasesPaginated.
+func (mr *MockCollectionRepositoryMockRecorder) FindCasesPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCasesPaginated", reflect.TypeOf((*MockCollectionRepository)(nil).FindCasesPaginated), ctx, params)
+}
+
+// FindExposures mocks base method.
+func (m *MockCollectionRepository) FindExposures(ctx context.Context, afterID uint64, limit int) ([]domain.AgingExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExposures", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.AgingExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExposures indicates an expected call of FindExposures.
+func (mr *MockCollectionRepositoryMockRecorder) FindExposures(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExposures", reflect.TypeOf((*MockCollectionRepository)(nil).FindExposures), ctx, afterID, limit)
+}
+
+// UpdateCase mocks base method.
+func (m *MockCollectionRepository) UpdateCase(ctx context.Context, collectionCase *domain.CollectionCase, from domain.CollectionCaseStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCase", ctx, collectionCase, from)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCase indicates an expected call of UpdateCase.
+func (mr *MockCollectionRepositoryMockRecorder) UpdateCase(ctx, collectionCase, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCase", reflect.TypeOf((*MockCollectionRepository)(nil).UpdateCase), ctx, collectionCase, from)
+}
+
+// MockWriteOffRepository is a mock of WriteOffRepository interface.
+type MockWriteOffRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriteOffRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWriteOffRepositoryMockRecorder is the mock recorder for MockWriteOffRepository.
+type MockWriteOffRepositoryMockRecorder struct {
+	mock *MockWriteOffRepository
+}
+
+// NewMockWriteOffRepository creates a new mock instance.
+func NewMockWriteOffRepository(ctrl *gomock.Controller) *MockWriteOffRepository {
+	mock := &MockWriteOffRepository{ctrl: ctrl}
+	mock.recorder = &MockWriteOffRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriteOffRepository) EXPECT() *MockWriteOffRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRecovery mocks base method.
+func (m *MockWriteOffRepository) AddRecovery(ctx context.Context, recovery *domain.WriteOffRecovery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecovery", ctx, recovery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecovery indicates an expected call of AddRecovery.
+func (mr *MockWriteOffRepositoryMockRecorder) AddRecovery(ctx, recovery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecovery", reflect.TypeOf((*MockWriteOffRepository)(nil).AddRecovery), ctx, recovery)
+}
+
+// Create mocks base method.
+func (m *MockWriteOffRepository) Create(ctx context.Context, writeOff *domain.WriteOff) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, writeOff)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWriteOffRepositoryMockRecorder) Create(ctx, writeOff any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWriteOffRepository)(nil).Create), ctx, writeOff)
+}
+
+// FindByID mocks base method.
+func (m *MockWriteOffRepository) FindByID(ctx context.Context, id uint64) (*domain.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockWriteOffRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWriteOffRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByIDWithLock mocks base method.
+func (m *MockWriteOffRepository) FindByIDWithLock(ctx context.Context, id uint64) (*domain.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDWithLock", ctx, id)
+	ret0, _ := ret[0].(*domain.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDWithLock indicates an expected call of FindByIDWithLock.
+func (mr *MockWriteOffRepositoryMockRecorder) FindByIDWithLock(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDWithLock", reflect.TypeOf((*MockWriteOffRepository)(nil).FindByIDWithLock), ctx, id)
+}
+
+// FindPaginated mocks base method.
+func (m *MockWriteOffRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.WriteOff, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, params)
+	ret0, _ := ret[0].([]domain.WriteOff)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockWriteOffRepositoryMockRecorder) FindPaginated(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockWriteOffRepository)(nil).FindPaginated), ctx, params)
+}
+
+// FindPendingByTransactionID mocks base method.
+func (m *MockWriteOffRepository) FindPendingByTransactionID(ctx context.Context, transactionID uint64) (*domain.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingByTransactionID", ctx, transactionID)
+	ret0, _ := ret[0].(*domain.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingByTransactionID indicates an expected call of FindPendingByTransactionID.
+func (mr *MockWriteOffRepositoryMockRecorder) FindPendingByTransactionID(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingByTransactionID", reflect.TypeOf((*MockWriteOffRepository)(nil).FindPendingByTransactionID), ctx, transactionID)
+}
+
+// FindRecoveries mocks base method.
+func (m *MockWriteOffRepository) FindRecoveries(ctx context.Context, writeOffID uint64) ([]domain.WriteOffRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecoveries", ctx, writeOffID)
+	ret0, _ := ret[0].([]domain.WriteOffRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecoveries indicates an expected call of FindRecoveries.
+func (mr *MockWriteOffRepositoryMockRecorder) FindRecoveries(ctx, writeOffID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecoveries", reflect.TypeOf((*MockWriteOffRepository)(nil).FindRecoveries), ctx, writeOffID)
+}
+
+// Report mocks base method.
+func (m *MockWriteOffRepository) Report(ctx context.Context, from, to time.Time) ([]domain.WriteOffPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, from, to)
+	ret0, _ := ret[0].([]domain.WriteOffPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockWriteOffRepositoryMockRecorder) Report(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockWriteOffRepository)(nil).Report), ctx, from, to)
+}
+
+// Update mocks base method.
+func (m *MockWriteOffRepository) Update(ctx context.Context, writeOff *domain.WriteOff) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, writeOff)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWriteOffRepositoryMockRecorder) Update(ctx, writeOff any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriteOffRepository)(nil).Update), ctx, writeOff)
+}
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByTransactionID mocks base method.
+func (m *MockLedgerRepository) FindByTransactionID(ctx context.Context, transactionID uint64) ([]domain.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTransactionID", ctx, transactionID)
+	ret0, _ := ret[0].([]domain.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTransactionID indicates an expected call of FindByTransactionID.
+func (mr *MockLedgerRepositoryMockRecorder) FindByTransactionID(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTransactionID", reflect.TypeOf((*MockLedgerRepository)(nil).FindByTransactionID), ctx, transactionID)
+}
+
+// Post mocks base method.
+func (m *MockLedgerRepository) Post(ctx context.Context, entries []domain.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockLedgerRepositoryMockRecorder) Post(ctx, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockLedgerRepository)(nil).Post), ctx, entries)
+}
+
+// MockConcentrationRepository is a mock of ConcentrationRepository interface.
+type MockConcentrationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConcentrationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConcentrationRepositoryMockRecorder is the mock recorder for MockConcentrationRepository.
+type MockConcentrationRepositoryMockRecorder struct {
+	mock *MockConcentrationRepository
+}
+
+// NewMockConcentrationRepository creates a new mock instance.
+func NewMockConcentrationRepository(ctrl *gomock.Controller) *MockConcentrationRepository {
+	mock := &MockConcentrationRepository{ctrl: ctrl}
+	mock.recorder = &MockConcentrationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConcentrationRepository) EXPECT() *MockConcentrationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLimit mocks base method.
+func (m *MockConcentrationRepository) CreateLimit(ctx context.Context, limit *domain.ConcentrationLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLimit", ctx, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLimit indicates an expected call of CreateLimit.
+func (mr *MockConcentrationRepositoryMockRecorder) CreateLimit(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLimit", reflect.TypeOf((*MockConcentrationRepository)(nil).CreateLimit), ctx, limit)
+}
+
+// DeleteLimit mocks base method.
+func (m *MockConcentrationRepository) DeleteLimit(ctx context.Context, id uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLimit", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLimit indicates an expected call of DeleteLimit.
+func (mr *MockConcentrationRepositoryMockRecorder) DeleteLimit(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLimit", reflect.TypeOf((*MockConcentrationRepository)(nil).DeleteLimit), ctx, id)
+}
+
+// FindLimit mocks base method.
+func (m *MockConcentrationRepository) FindLimit(ctx context.Context, dimension domain.ConcentrationDimension, value string) (*domain.ConcentrationLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLimit", ctx, dimension, value)
+	ret0, _ := ret[0].(*domain.ConcentrationLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLimit indicates an expected call of FindLimit.
+func (mr *MockConcentrationRepositoryMockRecorder) FindLimit(ctx, dimension, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLimit", reflect.TypeOf((*MockConcentrationRepository)(nil).FindLimit), ctx, dimension, value)
+}
+
+// FindLimitByID mocks base method.
+func (m *MockConcentrationRepository) FindLimitByID(ctx context.Context, id uint64) (*domain.ConcentrationLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLimitByID", ctx, id)
+	ret0, _ := ret[0].(*domain.ConcentrationLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLimitByID indicates an expected call of FindLimitByID.
+func (mr *MockConcentrationRepositoryMockRecorder) FindLimitByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLimitByID", reflect.TypeOf((*MockConcentrationRepository)(nil).FindLimitByID), ctx, id)
+}
+
+// FindLimits mocks base method.
+func (m *MockConcentrationRepository) FindLimits(ctx context.Context) ([]domain.ConcentrationLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLimits", ctx)
+	ret0, _ := ret[0].([]domain.ConcentrationLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLimits indicates an expected call of FindLimits.
+func (mr *MockConcentrationRepositoryMockRecorder) FindLimits(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLimits", reflect.TypeOf((*MockConcentrationRepository)(nil).FindLimits), ctx)
+}
+
+// SetBreachedAt mocks base method.
+func (m *MockConcentrationRepository) SetBreachedAt(ctx context.Context, id uint64, breachedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBreachedAt", ctx, id, breachedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBreachedAt indicates an expected call of SetBreachedAt.
+func (mr *MockConcentrationRepositoryMockRecorder) SetBreachedAt(ctx, id, breachedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBreachedAt", reflect.TypeOf((*MockConcentrationRepository)(nil).SetBreachedAt), ctx, id, breachedAt)
+}
+
+// SumPrincipal mocks base method.
+func (m *MockConcentrationRepository) SumPrincipal(ctx context.Context, dimension domain.ConcentrationDimension) ([]domain.Concentration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPrincipal", ctx, dimension)
+	ret0, _ := ret[0].([]domain.Concentration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPrincipal indicates an expected call of SumPrincipal.
+func (mr *MockConcentrationRepositoryMockRecorder) SumPrincipal(ctx, dimension any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPrincipal", reflect.TypeOf((*MockConcentrationRepository)(nil).SumPrincipal), ctx, dimension)
+}
+
+// SumPrincipalFor mocks base method.
+func (m *MockConcentrationRepository) SumPrincipalFor(ctx context.Context, dimension domain.ConcentrationDimension, value string) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPrincipalFor", ctx, dimension, value)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPrincipalFor indicates an expected call of SumPrincipalFor.
+func (mr *MockConcentrationRepositoryMockRecorder) SumPrincipalFor(ctx, dimension, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPrincipalFor", reflect.TypeOf((*MockConcentrationRepository)(nil).SumPrincipalFor), ctx, dimension, value)
+}
+
+// TotalPrincipal mocks base method.
+func (m *MockConcentrationRepository) TotalPrincipal(ctx context.Context) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalPrincipal", ctx)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalPrincipal indicates an expected call of TotalPrincipal.
+func (mr *MockConcentrationRepositoryMockRecorder) TotalPrincipal(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalPrincipal", reflect.TypeOf((*MockConcentrationRepository)(nil).TotalPrincipal), ctx)
+}
+
+// UpdateLimit mocks base method.
+func (m *MockConcentrationRepository) UpdateLimit(ctx context.Context, limit *domain.ConcentrationLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLimit", ctx, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLimit indicates an expected call of UpdateLimit.
+func (mr *MockConcentrationRepositoryMockRecorder) UpdateLimit(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLimit", reflect.TypeOf((*MockConcentrationRepository)(nil).UpdateLimit), ctx, limit)
+}
+
+// MockMediaRepository is a mock of MediaRepository interface.
+type MockMediaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaRepositoryMockRecorder is the mock recorder for MockMediaRepository.
+type MockMediaRepositoryMockRecorder struct {
+	mock *MockMediaRepository
+}
+
+// NewMockMediaRepository creates a new mock instance.
+func NewMockMediaRepository(ctrl *gomock.Controller) *MockMediaRepository {
+	mock := &MockMediaRepository{ctrl: ctrl}
+	mock.recorder = &MockMediaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaRepository) EXPECT() *MockMediaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindReferenced mocks base method.
+func (m *MockMediaRepository) FindReferenced(ctx context.Context, urls []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReferenced", ctx, urls)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReferenced indicates an expected call of FindReferenced.
+func (mr *MockMediaRepositoryMockRecorder) FindReferenced(ctx, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReferenced", reflect.TypeOf((*MockMediaRepository)(nil).FindReferenced), ctx, urls)
+}
+
+// MockPartnerDebugRepository is a mock of PartnerDebugRepository interface.
+type MockPartnerDebugRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerDebugRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerDebugRepositoryMockRecorder is the mock recorder for MockPartnerDebugRepository.
+type MockPartnerDebugRepositoryMockRecorder struct {
+	mock *MockPartnerDebugRepository
+}
+
+// NewMockPartnerDebugRepository creates a new mock instance.
+func NewMockPartnerDebugRepository(ctrl *gomock.Controller) *MockPartnerDebugRepository {
+	mock := &MockPartnerDebugRepository{ctrl: ctrl}
+	mock.recorder = &MockPartnerDebugRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerDebugRepository) EXPECT() *MockPartnerDebugRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPartnerDebugRepository) Create(ctx context.Context, record *domain.PartnerDebugRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPartnerDebugRepositoryMockRecorder) Create(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPartnerDebugRepository)(nil).Create), ctx, record)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockPartnerDebugRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockPartnerDebugRepositoryMockRecorder) DeleteBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockPartnerDebugRepository)(nil).DeleteBefore), ctx, before)
+}
+
+// FindByID mocks base method.
+func (m *MockPartnerDebugRepository) FindByID(ctx context.Context, partnerID uint64, sandbox bool, id uint64) (*domain.PartnerDebugRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, partnerID, sandbox, id)
+	ret0, _ := ret[0].(*domain.PartnerDebugRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPartnerDebugRepositoryMockRecorder) FindByID(ctx, partnerID, sandbox, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPartnerDebugRepository)(nil).FindByID), ctx, partnerID, sandbox, id)
+}
+
+// FindPaginated mocks base method.
+func (m *MockPartnerDebugRepository) FindPaginated(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) ([]domain.PartnerDebugRecord, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, partnerID, sandbox, params)
+	ret0, _ := ret[0].([]domain.PartnerDebugRecord)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockPartnerDebugRepositoryMockRecorder) FindPaginated(ctx, partnerID, sandbox, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockPartnerDebugRepository)(nil).FindPaginated), ctx, partnerID, sandbox, params)
+}
+
+// SetDebugUntil mocks base method.
+func (m *MockPartnerDebugRepository) SetDebugUntil(ctx context.Context, partnerID uint64, sandbox bool, until *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDebugUntil", ctx, partnerID, sandbox, until)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDebugUntil indicates an expected call of SetDebugUntil.
+func (mr *MockPartnerDebugRepositoryMockRecorder) SetDebugUntil(ctx, partnerID, sandbox, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDebugUntil", reflect.TypeOf((*MockPartnerDebugRepository)(nil).SetDebugUntil), ctx, partnerID, sandbox, until)
+}
+
+// Trim mocks base method.
+func (m *MockPartnerDebugRepository) Trim(ctx context.Context, partnerID uint64, sandbox bool, keep int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trim", ctx, partnerID, sandbox, keep)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trim indicates an expected call of Trim.
+func (mr *MockPartnerDebugRepositoryMockRecorder) Trim(ctx, partnerID, sandbox, keep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trim", reflect.TypeOf((*MockPartnerDebugRepository)(nil).Trim), ctx, partnerID, sandbox, keep)
+}
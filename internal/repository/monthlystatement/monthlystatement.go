@@ -0,0 +1,264 @@
+package monthlystatementrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type monthlyStatementRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindRecipients implements MonthlyStatementRepository.
+func (r *monthlyStatementRepository) FindRecipients(ctx context.Context, period string, from, to time.Time, limit int) ([]domain.Customer, error) {
+	done := r.begin(ctx, "find_monthly_statement_recipients", "customers", "select")
+	defer done()
+
+	// Customer aktif: punya kontrak berjalan, kontrak baru, atau pembayaran di periode itu
+	paid := r.db.Model(&model.Payment{}).
+		Select("transaction_id").
+		Where("status = ? AND paid_at >= ? AND paid_at < ?", model.PaymentPaid, from, to)
+	active := r.db.Model(&model.Transaction{}).
+		Select("customer_id").
+		Where("is_sandbox = ?", false).
+		Where(r.db.
+			Where("status IN ?", []model.TransactionStatus{model.TransactionApproved, model.TransactionActive}).
+			Or("transaction_date >= ? AND transaction_date < ?", from, to).
+			Or("id IN (?)", paid))
+	sent := r.db.Model(&model.MonthlyStatement{}).
+		Select("customer_id").
+		Where("period = ?", period)
+
+	var customers []model.Customer
+	err := r.db.WithContext(ctx).
+		Where("role = ? AND monthly_statement_opt_out = ?", model.CustomerRole, false).
+		Where("id IN (?)", active).
+		Where("id NOT IN (?)", sent).
+		Order("id ASC").
+		Limit(limit).
+		Find(&customers).Error
+	if err != nil {
+		r.recordError(ctx, "customers", "select", "Error finding monthly statement recipients", err, zap.String("period", period))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(customers)),
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return model.CustomersToEntity(customers), nil
+}
+
+// FindContracts implements MonthlyStatementRepository.
+func (r *monthlyStatementRepository) FindContracts(ctx context.Context, customerID uint64) ([]domain.Transaction, error) {
+	done := r.begin(ctx, "find_monthly_statement_contracts", "transactions", "select")
+	defer done()
+
+	var transactions []model.Transaction
+	err := r.db.WithContext(ctx).
+		Preload("Tenor").
+		Where("customer_id = ? AND is_sandbox = ?", customerID, false).
+		Order("transaction_date ASC, id ASC").
+		Find(&transactions).Error
+	if err != nil {
+		r.recordError(ctx, "transactions", "select", "Error finding customer contracts", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	result := make([]domain.Transaction, len(transactions))
+	for i, t := range transactions {
+		result[i] = *model.TransactionToEntity(t)
+		result[i].Tenor = *model.TenorToEntity(t.Tenor)
+	}
+
+	return result, nil
+}
+
+// FindPayments implements MonthlyStatementRepository.
+func (r *monthlyStatementRepository) FindPayments(ctx context.Context, customerID uint64, from, to time.Time) ([]domain.Payment, error) {
+	done := r.begin(ctx, "find_monthly_statement_payments", "payments", "select")
+	defer done()
+
+	var payments []model.Payment
+	err := r.db.WithContext(ctx).
+		Joins("JOIN transactions ON transactions.id = payments.transaction_id").
+		Where("transactions.customer_id = ? AND transactions.is_sandbox = ?", customerID, false).
+		Where("payments.status = ? AND payments.paid_at >= ? AND payments.paid_at < ?", model.PaymentPaid, from, to).
+		Order("payments.paid_at ASC, payments.id ASC").
+		Find(&payments).Error
+	if err != nil {
+		r.recordError(ctx, "payments", "select", "Error finding customer payments", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(payments)),
+		metric.WithAttributes(
+			attribute.String("table", "payments"),
+		),
+	)
+
+	result := make([]domain.Payment, len(payments))
+	for i, p := range payments {
+		result[i] = *model.PaymentToEntity(p)
+	}
+
+	return result, nil
+}
+
+// Create implements MonthlyStatementRepository.
+func (r *monthlyStatementRepository) Create(ctx context.Context, statement *domain.MonthlyStatement) (bool, error) {
+	done := r.begin(ctx, "create_monthly_statement", "monthly_statements", "insert")
+	defer done()
+
+	// Periode yang sudah tercatat oleh instance lain tidak ditimpa
+	data := model.MonthlyStatementFromEntity(statement)
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&data)
+	if result.Error != nil {
+		r.recordError(ctx, "monthly_statements", "insert", "Error creating monthly statement", result.Error,
+			zap.Uint64("customer_id", statement.CustomerID),
+			zap.String("period", statement.Period),
+		)
+		return false, result.Error
+	}
+
+	created := result.RowsAffected > 0
+	if created {
+		r.documentsInserted.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("table", "monthly_statements"),
+			),
+		)
+		statement.ID = data.ID
+		statement.CreatedAt = data.CreatedAt
+	}
+
+	return created, nil
+}
+
+// SetOptOut implements MonthlyStatementRepository.
+func (r *monthlyStatementRepository) SetOptOut(ctx context.Context, customerID uint64, optOut bool) (bool, error) {
+	done := r.begin(ctx, "set_monthly_statement_opt_out", "customers", "update")
+	defer done()
+
+	// Kolom updated_at sengaja tidak disentuh, preferensi bukan perubahan profil
+	result := r.db.WithContext(ctx).
+		Model(&model.Customer{}).
+		Where("id = ?", customerID).
+		UpdateColumn("monthly_statement_opt_out", optOut)
+	if result.Error != nil {
+		r.recordError(ctx, "customers", "update", "Error updating monthly statement preference", result.Error, zap.Uint64("customer_id", customerID))
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *monthlyStatementRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *monthlyStatementRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewMonthlyStatementRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.MonthlyStatementRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &monthlyStatementRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,184 @@
+package noncerepo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/redis/go-redis/v9"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const noncesKeyspace = "partner_nonces"
+
+type nonceRepository struct {
+	client             *redis.Client
+	meter              metric.Meter
+	tracer             trace.Tracer
+	log                *zap.Logger
+	queryDuration      metric.Float64Histogram
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Claim implements NonceRepository.
+func (r *nonceRepository) Claim(ctx context.Context, partnerID uint64, nonce string, ttl time.Duration) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.ClaimNonce")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(partnerID)))
+
+	done := r.begin(ctx, span, "claim_nonce", noncesKeyspace, "setnx")
+	defer done()
+
+	// Nonce dibatasi per partner, jadi dua partner boleh kebetulan memakai nilai yang sama
+	key := tenancy.Key(ctx, "partner:nonce:"+strconv.FormatUint(partnerID, 10)+":"+nonce)
+	claimed, err := r.client.SetNX(ctx, key, 1, ttl).Result()
+	if err != nil {
+		r.recordError(ctx, span, start, noncesKeyspace, "setnx", "Error claiming nonce", err, zap.Uint64("partner_id", partnerID))
+		return false, err
+	}
+
+	if !claimed {
+		span.SetStatus(codes.Ok, "Nonce already used")
+		r.recordDuration(ctx, start, noncesKeyspace, "setnx", "duplicate")
+		return false, nil
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", noncesKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, noncesKeyspace, "setnx", "success")
+	span.SetStatus(codes.Ok, "Nonce claimed successfully")
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *nonceRepository) begin(ctx context.Context, span trace.Span, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	span.SetAttributes(
+		attribute.String("db.system", "redis"),
+		attribute.String("db.operation", dbOperation),
+		attribute.String("db.table", table),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *nonceRepository) recordError(
+	ctx context.Context, span trace.Span, start time.Time,
+	table, dbOperation, message string, err error, fields ...zap.Field) {
+	span.SetStatus(codes.Error, message)
+	span.RecordError(err)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+
+	r.recordDuration(ctx, start, table, dbOperation, "error")
+}
+
+func (r *nonceRepository) recordDuration(ctx context.Context, start time.Time, table, dbOperation, status string) float64 {
+	duration := float64(time.Since(start).Milliseconds())
+	r.queryDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("status", status),
+		),
+	)
+	return duration
+}
+
+func NewNonceRepository(
+	client *redis.Client,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) repository.NonceRepository {
+	queryDuration, _ := meter.Float64Histogram(
+		"db.query.duration",
+		metric.WithDescription("Duration of database queries"),
+		metric.WithUnit("ms"),
+	)
+
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &nonceRepository{
+		client:             client,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		queryDuration:      queryDuration,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,269 @@
+package notificationrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type notificationRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindDevices implements NotificationRepository.
+func (r *notificationRepository) FindDevices(ctx context.Context, customerID uint64) ([]domain.Device, error) {
+	done := r.begin(ctx, "find_customer_devices", "customer_devices", "select")
+	defer done()
+
+	var devices []model.CustomerDevice
+	err := r.db.WithContext(ctx).
+		Where("customer_id = ?", customerID).
+		Order("id ASC").
+		Find(&devices).Error
+	if err != nil {
+		r.recordError(ctx, "customer_devices", "select", "Error finding customer devices", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(devices)),
+		metric.WithAttributes(
+			attribute.String("table", "customer_devices"),
+		),
+	)
+
+	return model.CustomerDevicesToEntity(devices), nil
+}
+
+// UpsertDevice implements NotificationRepository.
+func (r *notificationRepository) UpsertDevice(ctx context.Context, device *domain.Device) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_customer_device", "customer_devices", "upsert")
+	defer done()
+
+	// Token yang sudah terdaftar dipindahkan ke customer yang terakhir login di perangkat itu
+	data := model.CustomerDeviceFromEntity(device)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"customer_id", "platform", "last_seen_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "customer_devices", "upsert", "Error upserting customer device", err, zap.Uint64("customer_id", device.CustomerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "customer_devices"),
+		),
+	)
+
+	r.log.Info("Customer device registered",
+		zap.Uint64("customer_id", device.CustomerID),
+		zap.String("platform", string(device.Platform)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	device.ID = data.ID
+	device.CreatedAt = data.CreatedAt
+
+	return nil
+}
+
+// DeleteDevice implements NotificationRepository.
+func (r *notificationRepository) DeleteDevice(ctx context.Context, customerID uint64, token string) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "delete_customer_device", "customer_devices", "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).Where("customer_id = ? AND token = ?", customerID, token).Delete(&model.CustomerDevice{})
+	if result.Error != nil {
+		r.recordError(ctx, "customer_devices", "delete", "Error deleting customer device", result.Error, zap.Uint64("customer_id", customerID))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Customer device unregistered",
+		zap.Uint64("customer_id", customerID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// DeleteDeviceByToken implements NotificationRepository.
+func (r *notificationRepository) DeleteDeviceByToken(ctx context.Context, token string) error {
+	done := r.begin(ctx, "delete_customer_device_by_token", "customer_devices", "delete")
+	defer done()
+
+	if err := r.db.WithContext(ctx).Where("token = ?", token).Delete(&model.CustomerDevice{}).Error; err != nil {
+		r.recordError(ctx, "customer_devices", "delete", "Error deleting customer device by token", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindPreferences implements NotificationRepository.
+func (r *notificationRepository) FindPreferences(ctx context.Context, customerID uint64) (*domain.NotificationPreferences, error) {
+	done := r.begin(ctx, "find_notification_preferences", "notification_preferences", "select")
+	defer done()
+
+	var preference model.NotificationPreference
+	if err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).First(&preference).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "notification_preferences", "select", "Error finding notification preferences", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "notification_preferences"),
+		),
+	)
+
+	return model.NotificationPreferenceToEntity(preference), nil
+}
+
+// SavePreferences implements NotificationRepository.
+func (r *notificationRepository) SavePreferences(ctx context.Context, preferences *domain.NotificationPreferences) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "save_notification_preferences", "notification_preferences", "upsert")
+	defer done()
+
+	data := model.NotificationPreferenceFromEntity(preferences)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "customer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "push", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "notification_preferences", "upsert", "Error saving notification preferences", err, zap.Uint64("customer_id", preferences.CustomerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "notification_preferences"),
+		),
+	)
+
+	r.log.Info("Notification preferences saved",
+		zap.Uint64("customer_id", preferences.CustomerID),
+		zap.Bool("email", preferences.Email),
+		zap.Bool("push", preferences.Push),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	preferences.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *notificationRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *notificationRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewNotificationRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.NotificationRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &notificationRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,449 @@
+package otprepo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/redis/go-redis/v9"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	challengesKeyspace    = "otp_challenges"
+	verificationsKeyspace = "otp_verifications"
+	requestsKeyspace      = "otp_requests"
+)
+
+// incrementAttempts hanya menaikkan percobaan bila challenge masih ada, agar
+// HINCRBY tidak membuat hash baru tanpa TTL setelah challenge kedaluwarsa
+var incrementAttempts = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+`)
+
+type otpRepository struct {
+	client             *redis.Client
+	meter              metric.Meter
+	tracer             trace.Tracer
+	log                *zap.Logger
+	queryDuration      metric.Float64Histogram
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+type verificationRecord struct {
+	Purpose     domain.OTPPurpose `json:"purpose"`
+	Channel     string            `json:"channel"`
+	Destination string            `json:"destination"`
+	CustomerID  uint64            `json:"customer_id"`
+	VerifiedAt  time.Time         `json:"verified_at"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+}
+
+func challengeKey(ctx context.Context, id string) string {
+	return tenancy.Key(ctx, "otp:challenge:"+id)
+}
+
+// Token verifikasi tidak disimpan apa adanya, hanya hash-nya yang jadi key
+func verificationKey(ctx context.Context, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return tenancy.Key(ctx, "otp:verification:"+hex.EncodeToString(sum[:]))
+}
+
+// SaveChallenge implements OTPRepository.
+func (r *otpRepository) SaveChallenge(ctx context.Context, challenge *domain.OTPChallenge) error {
+	ctx, span := r.tracer.Start(ctx, "repository.SaveOTPChallenge")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("otp.purpose", string(challenge.Purpose)),
+		attribute.String("otp.channel", challenge.Channel),
+	)
+
+	done := r.begin(ctx, span, "save_otp_challenge", challengesKeyspace, "hset")
+	defer done()
+
+	key := challengeKey(ctx, challenge.ID)
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"purpose", string(challenge.Purpose),
+			"channel", challenge.Channel,
+			"destination", challenge.Destination,
+			"customer_id", challenge.CustomerID,
+			"code_hash", challenge.CodeHash,
+			"attempts", challenge.Attempts,
+			"expires_at", challenge.ExpiresAt.Unix(),
+		)
+		pipe.ExpireAt(ctx, key, challenge.ExpiresAt)
+		return nil
+	})
+	if err != nil {
+		r.recordError(ctx, span, start, challengesKeyspace, "hset", "Error saving OTP challenge", err)
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", challengesKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, challengesKeyspace, "hset", "success")
+	span.SetStatus(codes.Ok, "OTP challenge saved successfully")
+
+	return nil
+}
+
+// FindChallenge implements OTPRepository.
+func (r *otpRepository) FindChallenge(ctx context.Context, id string) (*domain.OTPChallenge, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.FindOTPChallenge")
+	defer span.End()
+
+	start := time.Now()
+
+	done := r.begin(ctx, span, "find_otp_challenge", challengesKeyspace, "hgetall")
+	defer done()
+
+	fields, err := r.client.HGetAll(ctx, challengeKey(ctx, id)).Result()
+	if err != nil {
+		r.recordError(ctx, span, start, challengesKeyspace, "hgetall", "Error finding OTP challenge", err)
+		return nil, err
+	}
+	if len(fields) == 0 {
+		span.SetStatus(codes.Ok, "OTP challenge not found")
+		r.recordDuration(ctx, start, challengesKeyspace, "hgetall", "not_found")
+		return nil, nil
+	}
+
+	customerID, _ := strconv.ParseUint(fields["customer_id"], 10, 64)
+	attempts, _ := strconv.Atoi(fields["attempts"])
+	expiresAt, _ := strconv.ParseInt(fields["expires_at"], 10, 64)
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", challengesKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, challengesKeyspace, "hgetall", "success")
+	span.SetStatus(codes.Ok, "OTP challenge found successfully")
+
+	return &domain.OTPChallenge{
+		ID:          id,
+		Purpose:     domain.OTPPurpose(fields["purpose"]),
+		Channel:     fields["channel"],
+		Destination: fields["destination"],
+		CustomerID:  customerID,
+		CodeHash:    fields["code_hash"],
+		Attempts:    attempts,
+		ExpiresAt:   time.Unix(expiresAt, 0),
+	}, nil
+}
+
+// IncrementAttempts implements OTPRepository.
+func (r *otpRepository) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.IncrementOTPAttempts")
+	defer span.End()
+
+	start := time.Now()
+
+	done := r.begin(ctx, span, "increment_otp_attempts", challengesKeyspace, "hincrby")
+	defer done()
+
+	attempts, err := incrementAttempts.Run(ctx, r.client, []string{challengeKey(ctx, id)}).Int()
+	if err != nil {
+		r.recordError(ctx, span, start, challengesKeyspace, "hincrby", "Error incrementing OTP attempts", err)
+		return 0, err
+	}
+
+	r.recordDuration(ctx, start, challengesKeyspace, "hincrby", "success")
+
+	span.SetStatus(codes.Ok, "OTP attempts incremented successfully")
+	span.SetAttributes(attribute.Int("result.attempts", attempts))
+
+	return attempts, nil
+}
+
+// DeleteChallenge implements OTPRepository.
+func (r *otpRepository) DeleteChallenge(ctx context.Context, id string) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.DeleteOTPChallenge")
+	defer span.End()
+
+	start := time.Now()
+
+	done := r.begin(ctx, span, "delete_otp_challenge", challengesKeyspace, "del")
+	defer done()
+
+	deleted, err := r.client.Del(ctx, challengeKey(ctx, id)).Result()
+	if err != nil {
+		r.recordError(ctx, span, start, challengesKeyspace, "del", "Error deleting OTP challenge", err)
+		return false, err
+	}
+
+	if deleted == 0 {
+		span.SetStatus(codes.Ok, "OTP challenge not found")
+		r.recordDuration(ctx, start, challengesKeyspace, "del", "not_found")
+		return false, nil
+	}
+
+	r.recordDuration(ctx, start, challengesKeyspace, "del", "success")
+	span.SetStatus(codes.Ok, "OTP challenge deleted successfully")
+
+	return true, nil
+}
+
+// SaveVerification implements OTPRepository.
+func (r *otpRepository) SaveVerification(ctx context.Context, verification *domain.OTPVerification) error {
+	ctx, span := r.tracer.Start(ctx, "repository.SaveOTPVerification")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("otp.purpose", string(verification.Purpose)))
+
+	done := r.begin(ctx, span, "save_otp_verification", verificationsKeyspace, "set")
+	defer done()
+
+	data, err := json.Marshal(verificationRecord{
+		Purpose:     verification.Purpose,
+		Channel:     verification.Channel,
+		Destination: verification.Destination,
+		CustomerID:  verification.CustomerID,
+		VerifiedAt:  verification.VerifiedAt,
+		ExpiresAt:   verification.ExpiresAt,
+	})
+	if err != nil {
+		r.recordError(ctx, span, start, verificationsKeyspace, "set", "Error encoding OTP verification", err)
+		return err
+	}
+
+	if err := r.client.Set(ctx, verificationKey(ctx, verification.Token), data, time.Until(verification.ExpiresAt)).Err(); err != nil {
+		r.recordError(ctx, span, start, verificationsKeyspace, "set", "Error saving OTP verification", err)
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", verificationsKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, verificationsKeyspace, "set", "success")
+	span.SetStatus(codes.Ok, "OTP verification saved successfully")
+
+	return nil
+}
+
+// TakeVerification implements OTPRepository.
+func (r *otpRepository) TakeVerification(ctx context.Context, token string) (*domain.OTPVerification, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.TakeOTPVerification")
+	defer span.End()
+
+	start := time.Now()
+
+	done := r.begin(ctx, span, "take_otp_verification", verificationsKeyspace, "getdel")
+	defer done()
+
+	// GETDEL membuat token hanya bisa dipakai satu kali walau request paralel
+	data, err := r.client.GetDel(ctx, verificationKey(ctx, token)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			span.SetStatus(codes.Ok, "OTP verification not found")
+			r.recordDuration(ctx, start, verificationsKeyspace, "getdel", "not_found")
+			return nil, nil
+		}
+
+		r.recordError(ctx, span, start, verificationsKeyspace, "getdel", "Error taking OTP verification", err)
+		return nil, err
+	}
+
+	var record verificationRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		r.recordError(ctx, span, start, verificationsKeyspace, "getdel", "Error decoding OTP verification", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", verificationsKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, verificationsKeyspace, "getdel", "success")
+	span.SetStatus(codes.Ok, "OTP verification taken successfully")
+
+	return &domain.OTPVerification{
+		Token:       token,
+		Purpose:     record.Purpose,
+		Channel:     record.Channel,
+		Destination: record.Destination,
+		CustomerID:  record.CustomerID,
+		VerifiedAt:  record.VerifiedAt,
+		ExpiresAt:   record.ExpiresAt,
+	}, nil
+}
+
+// CountRequest implements OTPRepository.
+func (r *otpRepository) CountRequest(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.CountOTPRequest")
+	defer span.End()
+
+	start := time.Now()
+
+	done := r.begin(ctx, span, "count_otp_request", requestsKeyspace, "incr")
+	defer done()
+
+	// Window tetap dihitung dari request pertama, request berikutnya tidak
+	// memperpanjang TTL
+	redisKey := tenancy.Key(ctx, "otp:requests:"+key)
+	var count *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, redisKey)
+		pipe.ExpireNX(ctx, redisKey, window)
+		return nil
+	})
+	if err != nil {
+		r.recordError(ctx, span, start, requestsKeyspace, "incr", "Error counting OTP request", err)
+		return 0, err
+	}
+
+	r.recordDuration(ctx, start, requestsKeyspace, "incr", "success")
+
+	span.SetStatus(codes.Ok, "OTP request counted successfully")
+	span.SetAttributes(attribute.Int64("result.count", count.Val()))
+
+	return count.Val(), nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *otpRepository) begin(ctx context.Context, span trace.Span, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	span.SetAttributes(
+		attribute.String("db.system", "redis"),
+		attribute.String("db.operation", dbOperation),
+		attribute.String("db.table", table),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *otpRepository) recordError(
+	ctx context.Context, span trace.Span, start time.Time,
+	table, dbOperation, message string, err error, fields ...zap.Field) {
+	span.SetStatus(codes.Error, message)
+	span.RecordError(err)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+
+	r.recordDuration(ctx, start, table, dbOperation, "error")
+}
+
+func (r *otpRepository) recordDuration(ctx context.Context, start time.Time, table, dbOperation, status string) float64 {
+	duration := float64(time.Since(start).Milliseconds())
+	r.queryDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("status", status),
+		),
+	)
+	return duration
+}
+
+func NewOTPRepository(
+	client *redis.Client,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) repository.OTPRepository {
+	queryDuration, _ := meter.Float64Histogram(
+		"db.query.duration",
+		metric.WithDescription("Duration of database queries"),
+		metric.WithUnit("ms"),
+	)
+
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &otpRepository{
+		client:             client,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		queryDuration:      queryDuration,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
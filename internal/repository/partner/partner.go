@@ -0,0 +1,236 @@
+package partnerrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type partnerRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements PartnerRepository.
+func (p *partnerRepository) Create(ctx context.Context, partner *domain.Partner) error {
+	span := trace.SpanFromContext(ctx)
+
+	p.log.Debug("Create partner",
+		zap.String("email", partner.Email),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := p.begin(ctx, "create_partner", "insert")
+	defer done()
+
+	data := model.PartnerFromEntity(partner)
+	if err := p.db.WithContext(ctx).Create(&data).Error; err != nil {
+		p.recordError(ctx, "insert", "Error creating partner", err, zap.String("email", partner.Email))
+		return err
+	}
+
+	partner.ID = data.ID
+	partner.CreatedAt = data.CreatedAt
+	partner.UpdatedAt = data.UpdatedAt
+
+	p.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "partners"),
+		),
+	)
+
+	p.log.Info("Partner created",
+		zap.Uint64("partner_id", partner.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindByID implements PartnerRepository.
+func (p *partnerRepository) FindByID(ctx context.Context, id uint64) (*domain.Partner, error) {
+	return p.findOne(ctx, "find_partner_by_id", p.db.Where("id = ?", id))
+}
+
+// FindByEmail implements PartnerRepository.
+func (p *partnerRepository) FindByEmail(ctx context.Context, email string) (*domain.Partner, error) {
+	return p.findOne(ctx, "find_partner_by_email", p.db.Where("email = ?", email))
+}
+
+// FindByAPIKeyHash implements PartnerRepository.
+func (p *partnerRepository) FindByAPIKeyHash(ctx context.Context, keyHash string) (*domain.Partner, error) {
+	return p.findOne(ctx, "find_partner_by_api_key",
+		p.db.Where("sandbox_key_hash = ? OR live_key_hash = ?", keyHash, keyHash))
+}
+
+// Update implements PartnerRepository.
+func (p *partnerRepository) Update(ctx context.Context, partner *domain.Partner) error {
+	span := trace.SpanFromContext(ctx)
+
+	p.log.Debug("Update partner",
+		zap.Uint64("partner_id", partner.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := p.begin(ctx, "update_partner", "update")
+	defer done()
+
+	data := model.PartnerFromEntity(partner)
+	err := p.db.WithContext(ctx).Model(&model.Partner{ID: partner.ID}).
+		Select("name", "status", "live_key_hash", "live_key_prefix", "promoted_at",
+			"allowed_cidrs", "require_client_cert", "client_cert_fingerprint",
+			"require_signature", "signing_secret", "region_code", "branch_code").
+		Updates(&data).Error
+	if err != nil {
+		p.recordError(ctx, "update", "Error updating partner", err, zap.Uint64("partner_id", partner.ID))
+		return err
+	}
+
+	p.log.Info("Partner updated",
+		zap.Uint64("partner_id", partner.ID),
+		zap.String("status", string(partner.Status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+func (p *partnerRepository) findOne(ctx context.Context, operation string, query *gorm.DB) (*domain.Partner, error) {
+	span := trace.SpanFromContext(ctx)
+
+	p.log.Debug("Find partner",
+		zap.String("operation", operation),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := p.begin(ctx, operation, "select")
+	defer done()
+
+	var partner model.Partner
+	if err := query.WithContext(ctx).First(&partner).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.log.Info("Partner not found",
+				zap.String("operation", operation),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+			)
+
+			return nil, nil
+		}
+
+		p.recordError(ctx, "select", "Error finding partner", err, zap.String("operation", operation))
+		return nil, err
+	}
+
+	p.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "partners"),
+		),
+	)
+
+	p.log.Info("Partner found",
+		zap.String("operation", operation),
+		zap.Uint64("partner_id", partner.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.PartnerToEntity(partner), nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (p *partnerRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "partners"),
+	)
+	p.connectionGauge.Add(ctx, 1, attrs)
+
+	p.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "partners"),
+		),
+	)
+
+	return func() { p.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (p *partnerRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	p.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	p.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "partners"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewPartnerRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.PartnerRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &partnerRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,284 @@
+package partnerdebugrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type partnerDebugRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsUpdated   metric.Int64Counter
+}
+
+// SetDebugUntil implements PartnerDebugRepository.
+func (r *partnerDebugRepository) SetDebugUntil(ctx context.Context, partnerID uint64, sandbox bool, until *time.Time) (bool, error) {
+	done := r.begin(ctx, "set_partner_debug_until", "partners", "update")
+	defer done()
+
+	column := "live_debug_until"
+	if sandbox {
+		column = "sandbox_debug_until"
+	}
+
+	// Kolom diupdate langsung supaya tidak ikut tertimpa Update partner lain
+	result := r.db.WithContext(ctx).Model(&model.Partner{}).
+		Where("id = ?", partnerID).
+		UpdateColumn(column, until)
+	if result.Error != nil {
+		r.recordError(ctx, "partners", "update", "Error setting partner debug mode", result.Error, zap.Uint64("partner_id", partnerID))
+		return false, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "partners"),
+		),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// Create implements PartnerDebugRepository.
+func (r *partnerDebugRepository) Create(ctx context.Context, record *domain.PartnerDebugRecord) error {
+	done := r.begin(ctx, "create_partner_debug_record", "partner_debug_records", "insert")
+	defer done()
+
+	data := model.PartnerDebugRecordFromEntity(record)
+	if err := r.db.WithContext(ctx).Omit("Partner").Create(&data).Error; err != nil {
+		r.recordError(ctx, "partner_debug_records", "insert", "Error creating partner debug record", err, zap.Uint64("partner_id", record.PartnerID))
+		return err
+	}
+
+	record.ID = data.ID
+	record.CreatedAt = data.CreatedAt
+
+	return nil
+}
+
+// Trim implements PartnerDebugRepository.
+func (r *partnerDebugRepository) Trim(ctx context.Context, partnerID uint64, sandbox bool, keep int) (int64, error) {
+	done := r.begin(ctx, "trim_partner_debug_records", "partner_debug_records", "delete")
+	defer done()
+
+	// MySQL tidak mendukung LIMIT di subquery IN, jadi batasnya dicari dulu
+	var oldest []uint64
+	err := r.db.WithContext(ctx).Model(&model.PartnerDebugRecord{}).
+		Where("partner_id = ? AND sandbox = ?", partnerID, sandbox).
+		Order("id DESC").
+		Offset(keep).
+		Limit(1).
+		Pluck("id", &oldest).Error
+	if err != nil {
+		r.recordError(ctx, "partner_debug_records", "delete", "Error finding partner debug records to trim", err, zap.Uint64("partner_id", partnerID))
+		return 0, err
+	}
+	if len(oldest) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("partner_id = ? AND sandbox = ? AND id <= ?", partnerID, sandbox, oldest[0]).
+		Delete(&model.PartnerDebugRecord{})
+	if result.Error != nil {
+		r.recordError(ctx, "partner_debug_records", "delete", "Error trimming partner debug records", result.Error, zap.Uint64("partner_id", partnerID))
+		return 0, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "partner_debug_records"),
+		),
+	)
+
+	return result.RowsAffected, nil
+}
+
+// DeleteBefore implements PartnerDebugRepository.
+func (r *partnerDebugRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	done := r.begin(ctx, "delete_partner_debug_records_before", "partner_debug_records", "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&model.PartnerDebugRecord{})
+	if result.Error != nil {
+		r.recordError(ctx, "partner_debug_records", "delete", "Error purging partner debug records", result.Error)
+		return 0, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "partner_debug_records"),
+		),
+	)
+
+	return result.RowsAffected, nil
+}
+
+// FindByID implements PartnerDebugRepository.
+func (r *partnerDebugRepository) FindByID(ctx context.Context, partnerID uint64, sandbox bool, id uint64) (*domain.PartnerDebugRecord, error) {
+	done := r.begin(ctx, "find_partner_debug_record_by_id", "partner_debug_records", "select")
+	defer done()
+
+	var record model.PartnerDebugRecord
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND partner_id = ? AND sandbox = ?", id, partnerID, sandbox).
+		First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.recordError(ctx, "partner_debug_records", "select", "Error finding partner debug record", err, zap.Uint64("debug_record_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "partner_debug_records"),
+		),
+	)
+
+	return model.PartnerDebugRecordToEntity(record), nil
+}
+
+// FindPaginated implements PartnerDebugRepository.
+func (r *partnerDebugRepository) FindPaginated(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) ([]domain.PartnerDebugRecord, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find partner debug records paginated",
+		zap.Uint64("partner_id", partnerID),
+		zap.Bool("sandbox", sandbox),
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_partner_debug_records", "partner_debug_records", "select_paginated")
+	defer done()
+
+	query := func() *gorm.DB {
+		return params.Filter(r.db.WithContext(ctx).Model(&model.PartnerDebugRecord{}).
+			Where("partner_id = ? AND sandbox = ?", partnerID, sandbox))
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		r.recordError(ctx, "partner_debug_records", "select_paginated", "Error counting partner debug records", err)
+		return nil, 0, err
+	}
+
+	var records []model.PartnerDebugRecord
+	if err := params.Paginate(query()).Order("id DESC").Find(&records).Error; err != nil {
+		r.recordError(ctx, "partner_debug_records", "select_paginated", "Error finding partner debug records", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(records)),
+		metric.WithAttributes(
+			attribute.String("table", "partner_debug_records"),
+		),
+	)
+
+	return model.PartnerDebugRecordsToEntity(records), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *partnerDebugRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *partnerDebugRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewPartnerDebugRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.PartnerDebugRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsUpdated, _ := meter.Int64Counter(
+		"db.documents.updated",
+		metric.WithDescription("Number of documents updated in the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &partnerDebugRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsUpdated:   documentsUpdated,
+	}
+}
@@ -0,0 +1,296 @@
+package paymentrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type paymentRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// LockContract implements PaymentRepository.
+func (r *paymentRepository) LockContract(ctx context.Context, contractNumber string) (*domain.Transaction, error) {
+	return r.lockContract(ctx, "lock_contract_for_payment", "contract_number = ?", contractNumber)
+}
+
+// LockContractByVirtualAccount implements PaymentRepository.
+func (r *paymentRepository) LockContractByVirtualAccount(ctx context.Context, number string) (*domain.Transaction, error) {
+	return r.lockContract(ctx, "lock_contract_by_virtual_account", "virtual_account_number = ?", number)
+}
+
+func (r *paymentRepository) lockContract(ctx context.Context, operation, condition, value string) (*domain.Transaction, error) {
+	done := r.begin(ctx, operation, "transactions", "select")
+	defer done()
+
+	// SELECT ... FOR UPDATE supaya callback paralel untuk kontrak yang sama
+	// menghitung cicilan terbayar secara berurutan
+	var transaction model.Transaction
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Tenor").
+		Where(condition, value).
+		First(&transaction).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "transactions", "select", "Error locking contract", err, zap.String("operation", operation))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	result := model.TransactionToEntity(transaction)
+	result.Tenor = *model.TenorToEntity(transaction.Tenor)
+
+	return result, nil
+}
+
+// ArchiveCallback implements PaymentRepository.
+func (r *paymentRepository) ArchiveCallback(ctx context.Context, callback *domain.PaymentCallback) (bool, error) {
+	done := r.begin(ctx, "archive_payment_callback", "payment_callbacks", "insert")
+	defer done()
+
+	// Event yang sama ditolak oleh unique index (provider, event_id), MySQL
+	// tidak menganggap NULL bentrok sehingga callback yang gagal verifikasi
+	// tetap tersimpan semua
+	data := model.PaymentCallbackFromEntity(callback)
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&data)
+	if result.Error != nil {
+		r.recordError(ctx, "payment_callbacks", "insert", "Error archiving payment callback", result.Error, zap.String("provider", callback.Provider))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "payment_callbacks"),
+		),
+	)
+
+	callback.ID = data.ID
+	callback.ReceivedAt = data.ReceivedAt
+
+	return true, nil
+}
+
+// FindByExternalID implements PaymentRepository.
+func (r *paymentRepository) FindByExternalID(ctx context.Context, provider, externalID string) (*domain.Payment, error) {
+	done := r.begin(ctx, "find_payment_by_external_id", "payments", "select")
+	defer done()
+
+	var payment model.Payment
+	err := r.db.WithContext(ctx).Where("provider = ? AND external_id = ?", provider, externalID).First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "payments", "select", "Error finding payment", err, zap.String("provider", provider), zap.String("external_id", externalID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "payments"),
+		),
+	)
+
+	return model.PaymentToEntity(payment), nil
+}
+
+// Save implements PaymentRepository.
+func (r *paymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "save_payment", "payments", "upsert")
+	defer done()
+
+	data := model.PaymentFromEntity(payment)
+	if err := r.db.WithContext(ctx).Save(&data).Error; err != nil {
+		r.recordError(ctx, "payments", "upsert", "Error saving payment", err, zap.String("external_id", payment.ExternalID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "payments"),
+		),
+	)
+
+	r.log.Info("Payment saved",
+		zap.Uint64("payment_id", data.ID),
+		zap.Uint64("transaction_id", payment.TransactionID),
+		zap.String("provider", payment.Provider),
+		zap.String("status", string(payment.Status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	payment.ID = data.ID
+	payment.CreatedAt = data.CreatedAt
+	payment.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// SumPaid implements PaymentRepository.
+func (r *paymentRepository) SumPaid(ctx context.Context, transactionID uint64) (decimal.Decimal, error) {
+	done := r.begin(ctx, "sum_paid_payments", "payments", "select")
+	defer done()
+
+	var total decimal.NullDecimal
+	err := r.db.WithContext(ctx).Model(&model.Payment{}).
+		Select("SUM(amount)").
+		Where("transaction_id = ? AND status = ?", transactionID, model.PaymentPaid).
+		Scan(&total).Error
+	if err != nil {
+		r.recordError(ctx, "payments", "select", "Error summing payments", err, zap.Uint64("transaction_id", transactionID))
+		return decimal.Zero, err
+	}
+
+	if !total.Valid {
+		return decimal.Zero, nil
+	}
+
+	return total.Decimal, nil
+}
+
+// ApplyRepayment implements PaymentRepository.
+func (r *paymentRepository) ApplyRepayment(ctx context.Context, transactionID uint64, paidInstallments int, status domain.TransactionStatus) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "apply_repayment", "transactions", "update")
+	defer done()
+
+	err := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("id = ?", transactionID).
+		Updates(map[string]any{
+			"paid_installments": paidInstallments,
+			"status":            status,
+		}).Error
+	if err != nil {
+		r.recordError(ctx, "transactions", "update", "Error applying repayment", err, zap.Uint64("transaction_id", transactionID))
+		return err
+	}
+
+	r.log.Info("Repayment applied",
+		zap.Uint64("transaction_id", transactionID),
+		zap.Int("paid_installments", paidInstallments),
+		zap.String("status", string(status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *paymentRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *paymentRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewPaymentRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.PaymentRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &paymentRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
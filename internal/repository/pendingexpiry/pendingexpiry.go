@@ -0,0 +1,232 @@
+package pendingexpiryrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Customer lama belum punya documents_submitted_at, pakai waktu registrasi
+const submittedAt = "COALESCE(documents_submitted_at, created_at)"
+
+type pendingExpiryRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsUpdated   metric.Int64Counter
+}
+
+// FindUnreminded implements PendingExpiryRepository.
+func (r *pendingExpiryRepository) FindUnreminded(ctx context.Context, submittedBefore time.Time, limit int) ([]domain.Customer, error) {
+	// Pengingat dikirim ulang bila dokumen diunggah lagi setelah pengingat terakhir
+	return r.findPending(ctx, "find_unreminded_pending", submittedBefore, limit,
+		"pending_reminder_sent_at IS NULL OR pending_reminder_sent_at < "+submittedAt)
+}
+
+// FindExpired implements PendingExpiryRepository.
+func (r *pendingExpiryRepository) FindExpired(ctx context.Context, submittedBefore, remindedBefore time.Time, limit int) ([]domain.Customer, error) {
+	// Hanya yang sudah diingatkan untuk dokumen terakhirnya, dan sudah diberi
+	// waktu setelah pengingat itu, yang boleh ditolak
+	return r.findPending(ctx, "find_expired_pending", submittedBefore, limit,
+		"pending_reminder_sent_at >= "+submittedAt+" AND pending_reminder_sent_at < ?", remindedBefore)
+}
+
+func (r *pendingExpiryRepository) findPending(
+	ctx context.Context, operation string,
+	submittedBefore time.Time, limit int, reminderCondition string, args ...any) ([]domain.Customer, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Finding stale pending customers",
+		zap.String("operation", operation),
+		zap.Time("submitted_before", submittedBefore),
+		zap.Int("limit", limit),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, operation, "select")
+	defer done()
+
+	var customers []model.Customer
+	err := r.db.WithContext(ctx).
+		Where("role = ? AND verification_status = ?", model.CustomerRole, model.VerificationPending).
+		Where(submittedAt+" < ?", submittedBefore).
+		Where(reminderCondition, args...).
+		Order(submittedAt + " ASC").
+		Limit(limit).
+		Find(&customers).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding stale pending customers", err, zap.String("operation", operation))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(customers)),
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return model.CustomersToEntity(customers), nil
+}
+
+// MarkReminded implements PendingExpiryRepository.
+func (r *pendingExpiryRepository) MarkReminded(ctx context.Context, customerID uint64, at time.Time) error {
+	done := r.begin(ctx, "mark_pending_reminded", "update")
+	defer done()
+
+	err := r.db.WithContext(ctx).Model(&model.Customer{}).
+		Where("id = ?", customerID).
+		UpdateColumn("pending_reminder_sent_at", at).Error
+	if err != nil {
+		r.recordError(ctx, "update", "Error marking pending reminder", err, zap.Uint64("customer_id", customerID))
+		return err
+	}
+
+	r.documentsUpdated.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return nil
+}
+
+// Reject implements PendingExpiryRepository.
+func (r *pendingExpiryRepository) Reject(ctx context.Context, customerID uint64) (bool, error) {
+	done := r.begin(ctx, "reject_expired_pending", "update")
+	defer done()
+
+	// Syarat status PENDING mencegah menimpa keputusan admin yang masuk lebih dulu
+	result := r.db.WithContext(ctx).Model(&model.Customer{}).
+		Where("id = ? AND verification_status = ?", customerID, model.VerificationPending).
+		Update("verification_status", model.VerificationRejected)
+	if result.Error != nil {
+		r.recordError(ctx, "update", "Error rejecting expired pending customer", result.Error, zap.Uint64("customer_id", customerID))
+		return false, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// Stats implements PendingExpiryRepository.
+func (r *pendingExpiryRepository) Stats(ctx context.Context) (int64, *time.Time, error) {
+	done := r.begin(ctx, "pending_stats", "select_aggregate")
+	defer done()
+
+	var row struct {
+		Pending int64
+		Oldest  *time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&model.Customer{}).
+		Select("COUNT(*) AS pending, MIN("+submittedAt+") AS oldest").
+		Where("role = ? AND verification_status = ?", model.CustomerRole, model.VerificationPending).
+		Scan(&row).Error
+	if err != nil {
+		r.recordError(ctx, "select_aggregate", "Error computing pending stats", err)
+		return 0, nil, err
+	}
+
+	return row.Pending, row.Oldest, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *pendingExpiryRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "customers"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "customers"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *pendingExpiryRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "customers"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewPendingExpiryRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.PendingExpiryRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsUpdated, _ := meter.Int64Counter(
+		"db.documents.updated",
+		metric.WithDescription("Number of documents updated in the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &pendingExpiryRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsUpdated:   documentsUpdated,
+	}
+}
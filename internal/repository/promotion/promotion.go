@@ -0,0 +1,279 @@
+package promotionrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type promotionRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindAll implements PromotionRepository.
+func (r *promotionRepository) FindAll(ctx context.Context) ([]domain.Promotion, error) {
+	done := r.begin(ctx, "find_all_promotions", "promotions", "select")
+	defer done()
+
+	var promotions []model.Promotion
+	if err := r.db.WithContext(ctx).Order("valid_from DESC, code ASC").Find(&promotions).Error; err != nil {
+		r.recordError(ctx, "promotions", "select", "Error finding promotions", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(promotions)),
+		metric.WithAttributes(
+			attribute.String("table", "promotions"),
+		),
+	)
+
+	return model.PromotionsToEntity(promotions), nil
+}
+
+// LockByCode implements PromotionRepository.
+func (r *promotionRepository) LockByCode(ctx context.Context, code string) (*domain.Promotion, error) {
+	done := r.begin(ctx, "lock_promotion_by_code", "promotions", "select_for_update")
+	defer done()
+
+	var promotion model.Promotion
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("code = ?", code).
+		First(&promotion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "promotions", "select_for_update", "Error locking promotion", err, zap.String("code", code))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "promotions"),
+		),
+	)
+
+	return model.PromotionToEntity(promotion), nil
+}
+
+// Upsert implements PromotionRepository.
+func (r *promotionRepository) Upsert(ctx context.Context, promotion *domain.Promotion) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_promotion", "promotions", "upsert")
+	defer done()
+
+	data := model.PromotionFromEntity(promotion)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"description", "target", "discount_type", "discount_value", "max_discount",
+			"valid_from", "valid_until", "partner_id", "tenor_months", "min_otr_amount",
+			"max_redemptions", "max_per_customer", "active", "updated_by", "updated_at",
+		}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "promotions", "upsert", "Error upserting promotion", err, zap.String("code", promotion.Code))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "promotions"),
+		),
+	)
+
+	r.log.Info("Promotion saved",
+		zap.String("code", promotion.Code),
+		zap.Bool("active", promotion.Active),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	promotion.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// Deactivate implements PromotionRepository.
+func (r *promotionRepository) Deactivate(ctx context.Context, code string) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "deactivate_promotion", "promotions", "update")
+	defer done()
+
+	// Promosi tidak dihapus karena masih direferensikan oleh redemption;
+	// updated_at ikut berubah sehingga RowsAffected > 0 selama barisnya ada
+	result := r.db.WithContext(ctx).Model(&model.Promotion{}).Where("code = ?", code).Update("active", false)
+	if result.Error != nil {
+		r.recordError(ctx, "promotions", "update", "Error deactivating promotion", result.Error, zap.String("code", code))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Promotion deactivated",
+		zap.String("code", code),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// CountRedemptions implements PromotionRepository.
+func (r *promotionRepository) CountRedemptions(ctx context.Context, promotionID, customerID uint64) (total, byCustomer int64, err error) {
+	done := r.begin(ctx, "count_promotion_redemptions", "promotion_redemptions", "select")
+	defer done()
+
+	var counts struct {
+		Total      int64
+		ByCustomer int64
+	}
+	err = r.db.WithContext(ctx).Model(&model.PromotionRedemption{}).
+		Select("COUNT(*) AS total, COALESCE(SUM(customer_id = ?), 0) AS by_customer", customerID).
+		Where("promotion_id = ?", promotionID).
+		Scan(&counts).Error
+	if err != nil {
+		r.recordError(ctx, "promotion_redemptions", "select", "Error counting promotion redemptions", err, zap.Uint64("promotion_id", promotionID))
+		return 0, 0, err
+	}
+
+	return counts.Total, counts.ByCustomer, nil
+}
+
+// CreateRedemption implements PromotionRepository.
+func (r *promotionRepository) CreateRedemption(ctx context.Context, redemption *domain.PromotionRedemption) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_promotion_redemption", "promotion_redemptions", "insert")
+	defer done()
+
+	data := model.PromotionRedemptionFromEntity(redemption)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "promotion_redemptions", "insert", "Error creating promotion redemption", err, zap.Uint64("promotion_id", redemption.PromotionID), zap.Uint64("transaction_id", redemption.TransactionID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "promotion_redemptions"),
+		),
+	)
+
+	r.log.Info("Promotion redeemed",
+		zap.Uint64("promotion_id", redemption.PromotionID),
+		zap.Uint64("customer_id", redemption.CustomerID),
+		zap.Uint64("transaction_id", redemption.TransactionID),
+		zap.Stringer("discount_amount", redemption.DiscountAmount),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	redemption.ID = data.ID
+	redemption.RedeemedAt = data.RedeemedAt
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *promotionRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *promotionRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewPromotionRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.PromotionRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &promotionRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
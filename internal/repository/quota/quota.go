@@ -0,0 +1,196 @@
+package quotarepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type quotaRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindByPartner implements PartnerQuotaRepository.
+func (r *quotaRepository) FindByPartner(ctx context.Context, partnerID uint64) (*domain.PartnerQuota, error) {
+	done := r.begin(ctx, "find_partner_quota", "select")
+	defer done()
+
+	var quota model.PartnerQuota
+	if err := r.db.WithContext(ctx).Where("partner_id = ?", partnerID).First(&quota).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding partner quota", err, zap.Uint64("partner_id", partnerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "partner_quotas"),
+		),
+	)
+
+	return model.PartnerQuotaToEntity(quota), nil
+}
+
+// Upsert implements PartnerQuotaRepository.
+func (r *quotaRepository) Upsert(ctx context.Context, quota *domain.PartnerQuota) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_partner_quota", "upsert")
+	defer done()
+
+	data := model.PartnerQuotaFromEntity(quota)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "partner_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"daily_count", "daily_volume", "updated_by", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "upsert", "Error upserting partner quota", err, zap.Uint64("partner_id", quota.PartnerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "partner_quotas"),
+		),
+	)
+
+	r.log.Info("Partner quota saved",
+		zap.Uint64("partner_id", quota.PartnerID),
+		zap.Int64("daily_count", quota.DailyCount),
+		zap.Stringer("daily_volume", quota.DailyVolume),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	quota.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// Delete implements PartnerQuotaRepository.
+func (r *quotaRepository) Delete(ctx context.Context, partnerID uint64) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "delete_partner_quota", "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).Where("partner_id = ?", partnerID).Delete(&model.PartnerQuota{})
+	if result.Error != nil {
+		r.recordError(ctx, "delete", "Error deleting partner quota", result.Error, zap.Uint64("partner_id", partnerID))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Partner quota deleted",
+		zap.Uint64("partner_id", partnerID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *quotaRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "partner_quotas"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "partner_quotas"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *quotaRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "partner_quotas"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewQuotaRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.PartnerQuotaRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &quotaRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,291 @@
+package quotacounterrepo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const countersKeyspace = "partner_quota_counters"
+
+// reserve menaikkan kedua counter hanya bila keduanya masih di bawah cap,
+// sehingga request paralel tidak bisa bersama-sama melewati kuota. Volume
+// disimpan dalam sen agar HINCRBY tetap bekerja dengan bilangan bulat.
+var reserve = redis.NewScript(`
+local count = tonumber(redis.call("HGET", KEYS[1], "count") or "0")
+local volume = tonumber(redis.call("HGET", KEYS[1], "volume") or "0")
+local amount = tonumber(ARGV[1])
+local maxCount = tonumber(ARGV[2])
+local maxVolume = tonumber(ARGV[3])
+if (maxCount > 0 and count + 1 > maxCount) or (maxVolume > 0 and volume + amount > maxVolume) then
+	return {0, count, volume}
+end
+count = redis.call("HINCRBY", KEYS[1], "count", 1)
+volume = redis.call("HINCRBY", KEYS[1], "volume", ARGV[1])
+redis.call("EXPIREAT", KEYS[1], ARGV[4])
+return {1, count, volume}
+`)
+
+// release tidak membuat hash baru bila counter hari itu sudah kedaluwarsa
+var release = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HINCRBY", KEYS[1], "count", -1)
+redis.call("HINCRBY", KEYS[1], "volume", ARGV[1])
+return 1
+`)
+
+type quotaCounterRepository struct {
+	client             *redis.Client
+	meter              metric.Meter
+	tracer             trace.Tracer
+	log                *zap.Logger
+	queryDuration      metric.Float64Histogram
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+func counterKey(ctx context.Context, partnerID uint64, day string) string {
+	return tenancy.Key(ctx, "partner:quota:"+strconv.FormatUint(partnerID, 10)+":"+day)
+}
+
+func toCents(amount decimal.Decimal) int64 {
+	return amount.Shift(2).Round(0).IntPart()
+}
+
+func fromCents(cents int64) decimal.Decimal {
+	return decimal.New(cents, -2)
+}
+
+// Reserve implements QuotaCounterRepository.
+func (r *quotaCounterRepository) Reserve(ctx context.Context, quota domain.PartnerQuota, day string, volume decimal.Decimal, expiresAt time.Time) (*domain.QuotaUsage, bool, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.ReserveQuota")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(quota.PartnerID)),
+		attribute.String("quota.day", day),
+	)
+
+	done := r.begin(ctx, span, "reserve_quota", countersKeyspace, "eval")
+	defer done()
+
+	result, err := reserve.Run(ctx, r.client, []string{counterKey(ctx, quota.PartnerID, day)},
+		toCents(volume), quota.DailyCount, toCents(quota.DailyVolume), expiresAt.Unix(),
+	).Int64Slice()
+	if err != nil {
+		r.recordError(ctx, span, start, countersKeyspace, "eval", "Error reserving partner quota", err, zap.Uint64("partner_id", quota.PartnerID))
+		return nil, false, err
+	}
+
+	usage := &domain.QuotaUsage{Count: result[1], Volume: fromCents(result[2])}
+	if result[0] == 0 {
+		span.SetStatus(codes.Ok, "Partner quota exhausted")
+		r.recordDuration(ctx, start, countersKeyspace, "eval", "exhausted")
+		return usage, false, nil
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", countersKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, countersKeyspace, "eval", "success")
+	span.SetStatus(codes.Ok, "Partner quota reserved successfully")
+
+	return usage, true, nil
+}
+
+// Release implements QuotaCounterRepository.
+func (r *quotaCounterRepository) Release(ctx context.Context, partnerID uint64, day string, volume decimal.Decimal) error {
+	ctx, span := r.tracer.Start(ctx, "repository.ReleaseQuota")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.String("quota.day", day),
+	)
+
+	done := r.begin(ctx, span, "release_quota", countersKeyspace, "eval")
+	defer done()
+
+	if err := release.Run(ctx, r.client, []string{counterKey(ctx, partnerID, day)}, -toCents(volume)).Err(); err != nil {
+		r.recordError(ctx, span, start, countersKeyspace, "eval", "Error releasing partner quota", err, zap.Uint64("partner_id", partnerID))
+		return err
+	}
+
+	r.recordDuration(ctx, start, countersKeyspace, "eval", "success")
+	span.SetStatus(codes.Ok, "Partner quota released successfully")
+
+	return nil
+}
+
+// Find implements QuotaCounterRepository.
+func (r *quotaCounterRepository) Find(ctx context.Context, partnerID uint64, day string) (*domain.QuotaUsage, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.FindQuotaUsage")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.String("quota.day", day),
+	)
+
+	done := r.begin(ctx, span, "find_quota_usage", countersKeyspace, "hgetall")
+	defer done()
+
+	fields, err := r.client.HGetAll(ctx, counterKey(ctx, partnerID, day)).Result()
+	if err != nil {
+		r.recordError(ctx, span, start, countersKeyspace, "hgetall", "Error finding partner quota usage", err, zap.Uint64("partner_id", partnerID))
+		return nil, err
+	}
+
+	// Counter yang belum ada berarti partner belum bertransaksi hari ini
+	count, _ := strconv.ParseInt(fields["count"], 10, 64)
+	cents, _ := strconv.ParseInt(fields["volume"], 10, 64)
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", countersKeyspace),
+		),
+	)
+
+	r.recordDuration(ctx, start, countersKeyspace, "hgetall", "success")
+	span.SetStatus(codes.Ok, "Partner quota usage found successfully")
+
+	return &domain.QuotaUsage{Count: count, Volume: fromCents(cents)}, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *quotaCounterRepository) begin(ctx context.Context, span trace.Span, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	span.SetAttributes(
+		attribute.String("db.system", "redis"),
+		attribute.String("db.operation", dbOperation),
+		attribute.String("db.table", table),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *quotaCounterRepository) recordError(
+	ctx context.Context, span trace.Span, start time.Time,
+	table, dbOperation, message string, err error, fields ...zap.Field) {
+	span.SetStatus(codes.Error, message)
+	span.RecordError(err)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+
+	r.recordDuration(ctx, start, table, dbOperation, "error")
+}
+
+func (r *quotaCounterRepository) recordDuration(ctx context.Context, start time.Time, table, dbOperation, status string) float64 {
+	duration := float64(time.Since(start).Milliseconds())
+	r.queryDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("status", status),
+		),
+	)
+	return duration
+}
+
+func NewQuotaCounterRepository(
+	client *redis.Client,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) repository.QuotaCounterRepository {
+	queryDuration, _ := meter.Float64Histogram(
+		"db.query.duration",
+		metric.WithDescription("Duration of database queries"),
+		metric.WithUnit("ms"),
+	)
+
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &quotaCounterRepository{
+		client:             client,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		queryDuration:      queryDuration,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,252 @@
+package referralrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type referralRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindCode implements ReferralRepository.
+func (r *referralRepository) FindCode(ctx context.Context, customerID uint64) (*domain.ReferralCode, error) {
+	done := r.begin(ctx, "find_referral_code", "referral_codes", "select")
+	defer done()
+
+	var code model.ReferralCode
+	if err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).First(&code).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "referral_codes", "select", "Error finding referral code", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "referral_codes"),
+		),
+	)
+
+	return model.ReferralCodeToEntity(code), nil
+}
+
+// FindByCode implements ReferralRepository.
+func (r *referralRepository) FindByCode(ctx context.Context, code string) (*domain.ReferralCode, error) {
+	done := r.begin(ctx, "find_referral_by_code", "referral_codes", "select")
+	defer done()
+
+	var data model.ReferralCode
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "referral_codes", "select", "Error finding referral code", err, zap.String("code", code))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "referral_codes"),
+		),
+	)
+
+	return model.ReferralCodeToEntity(data), nil
+}
+
+// CreateCode implements ReferralRepository.
+func (r *referralRepository) CreateCode(ctx context.Context, code *domain.ReferralCode) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_referral_code", "referral_codes", "insert")
+	defer done()
+
+	// Bentrok customer_id atau code tidak dianggap error, pemanggil yang
+	// memutuskan membaca ulang atau membuat kode baru
+	data := model.ReferralCode{CustomerID: code.CustomerID, Code: code.Code}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&data)
+	if result.Error != nil {
+		r.recordError(ctx, "referral_codes", "insert", "Error creating referral code", result.Error, zap.Uint64("customer_id", code.CustomerID))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "referral_codes"),
+		),
+	)
+
+	r.log.Info("Referral code created",
+		zap.Uint64("customer_id", code.CustomerID),
+		zap.String("code", code.Code),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	code.CreatedAt = data.CreatedAt
+
+	return true, nil
+}
+
+// CountByFingerprint implements ReferralRepository.
+func (r *referralRepository) CountByFingerprint(ctx context.Context, deviceID, ipAddress string, since time.Time) (byDevice, byIP int64, err error) {
+	done := r.begin(ctx, "count_referrals_by_fingerprint", "referrals", "select")
+	defer done()
+
+	var counts struct {
+		ByDevice int64
+		ByIP     int64
+	}
+	err = r.db.WithContext(ctx).Model(&model.Referral{}).
+		Select("COALESCE(SUM(device_id = ? AND device_id <> ''), 0) AS by_device, COALESCE(SUM(ip_address = ? AND ip_address <> '' AND created_at >= ?), 0) AS by_ip", deviceID, ipAddress, since).
+		Where("device_id = ? OR ip_address = ?", deviceID, ipAddress).
+		Scan(&counts).Error
+	if err != nil {
+		r.recordError(ctx, "referrals", "select", "Error counting referrals by fingerprint", err)
+		return 0, 0, err
+	}
+
+	return counts.ByDevice, counts.ByIP, nil
+}
+
+// Create implements ReferralRepository.
+func (r *referralRepository) Create(ctx context.Context, referral *domain.Referral) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "create_referral", "referrals", "insert")
+	defer done()
+
+	data := model.ReferralFromEntity(referral)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "referrals", "insert", "Error creating referral", err, zap.Uint64("referrer_id", referral.ReferrerID), zap.Uint64("referee_id", referral.RefereeID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "referrals"),
+		),
+	)
+
+	r.log.Info("Referral recorded",
+		zap.Uint64("referrer_id", referral.ReferrerID),
+		zap.Uint64("referee_id", referral.RefereeID),
+		zap.String("status", string(referral.Status)),
+		zap.String("reject_reason", referral.RejectReason),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	referral.ID = data.ID
+	referral.CreatedAt = data.CreatedAt
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *referralRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *referralRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewReferralRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.ReferralRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &referralRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
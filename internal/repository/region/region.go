@@ -0,0 +1,192 @@
+package regionrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const regionsTable = "regions"
+
+type regionRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindAll implements RegionRepository.
+func (r *regionRepository) FindAll(ctx context.Context) ([]domain.Region, error) {
+	done := r.begin(ctx, "find_regions", regionsTable, "select")
+	defer done()
+
+	var data []model.Region
+	if err := r.db.WithContext(ctx).Order("code ASC").Find(&data).Error; err != nil {
+		r.recordError(ctx, regionsTable, "select", "Error finding regions", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(data)),
+		metric.WithAttributes(
+			attribute.String("table", regionsTable),
+		),
+	)
+
+	return model.RegionsToEntity(data), nil
+}
+
+// FindByCode implements RegionRepository.
+func (r *regionRepository) FindByCode(ctx context.Context, code string) (*domain.Region, error) {
+	done := r.begin(ctx, "find_region", regionsTable, "select")
+	defer done()
+
+	var data model.Region
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, regionsTable, "select", "Error finding region", err, zap.String("code", code))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", regionsTable),
+		),
+	)
+
+	return model.RegionToEntity(data), nil
+}
+
+// Upsert implements RegionRepository.
+func (r *regionRepository) Upsert(ctx context.Context, region *domain.Region) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "upsert_region", regionsTable, "upsert")
+	defer done()
+
+	data := model.RegionFromEntity(region)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "active", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, regionsTable, "upsert", "Error upserting region", err, zap.String("code", region.Code))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", regionsTable),
+		),
+	)
+
+	r.log.Info("Region saved",
+		zap.String("code", region.Code),
+		zap.Bool("active", region.Active),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	region.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *regionRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *regionRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewRegionRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.RegionRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &regionRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
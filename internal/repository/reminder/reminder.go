@@ -0,0 +1,188 @@
+package reminderrepo
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type paymentReminderRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindUpcoming implements PaymentReminderRepository.
+func (r *paymentReminderRepository) FindUpcoming(ctx context.Context, afterID uint64, limit int) ([]domain.Transaction, error) {
+	done := r.begin(ctx, "find_contracts_for_payment_reminder", "transactions", "select")
+	defer done()
+
+	// Tanggal jatuh tempo dihitung di service karena bergantung pada kalender libur
+	var transactions []model.Transaction
+	err := r.db.WithContext(ctx).
+		Preload("Tenor").
+		Preload("Customer").
+		Where("id > ? AND status = ? AND is_sandbox = ?", afterID, model.TransactionActive, false).
+		Order("id ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		r.recordError(ctx, "transactions", "select", "Error finding contracts for payment reminder", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	result := make([]domain.Transaction, len(transactions))
+	for i, t := range transactions {
+		result[i] = *model.TransactionToEntity(t)
+		result[i].Tenor = *model.TenorToEntity(t.Tenor)
+		result[i].Customer = *model.CustomerToEntity(t.Customer)
+	}
+
+	return result, nil
+}
+
+// Claim implements PaymentReminderRepository.
+func (r *paymentReminderRepository) Claim(ctx context.Context, reminder *domain.PaymentReminder) (bool, error) {
+	done := r.begin(ctx, "claim_payment_reminder", "payment_reminders", "insert")
+	defer done()
+
+	data := model.PaymentReminderFromEntity(reminder)
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&data)
+	if result.Error != nil {
+		r.recordError(ctx, "payment_reminders", "insert", "Error claiming payment reminder", result.Error, zap.Uint64("transaction_id", reminder.TransactionID))
+		return false, result.Error
+	}
+
+	// Baris yang sudah ada berarti angsuran ini sudah diingatkan
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "payment_reminders"),
+		),
+	)
+
+	reminder.ID = data.ID
+	reminder.CreatedAt = data.CreatedAt
+
+	return true, nil
+}
+
+// Release implements PaymentReminderRepository.
+func (r *paymentReminderRepository) Release(ctx context.Context, id uint64) error {
+	done := r.begin(ctx, "release_payment_reminder", "payment_reminders", "delete")
+	defer done()
+
+	if err := r.db.WithContext(ctx).Delete(&model.PaymentReminder{}, id).Error; err != nil {
+		r.recordError(ctx, "payment_reminders", "delete", "Error releasing payment reminder", err, zap.Uint64("payment_reminder_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *paymentReminderRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *paymentReminderRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewPaymentReminderRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.PaymentReminderRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &paymentReminderRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
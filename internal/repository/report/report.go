@@ -0,0 +1,397 @@
+package reportrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	transactionsTable   = "transactions"
+	agingSnapshotsTable = "aging_snapshots"
+	referralsTable      = "referrals"
+)
+
+type reportRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// InterestAccrual implements ReportRepository.
+//
+// Bunga flat diakui rata setiap bulan selama tenor: cicilan ke-n jatuh tempo
+// n bulan setelah tanggal kontrak, jadi kontrak ikut dihitung pada bulan ke-1
+// sampai ke-N setelah bulan kontrak. Kontrak yang sudah lunas tetap dihitung
+// karena bunga flat ditagih penuh.
+func (r *reportRepository) InterestAccrual(ctx context.Context, month time.Time) ([]domain.InterestAccrual, error) {
+	span := trace.SpanFromContext(ctx)
+
+	period := month.Format("200601")
+
+	r.log.Debug("Computing interest accrual",
+		zap.String("period", period),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "interest_accrual", transactionsTable, "select_aggregate")
+	defer done()
+
+	var rows []domain.InterestAccrual
+	err := r.db.WithContext(ctx).
+		Table("transactions AS t").
+		Select(`t.tenor_id, tn.duration_months AS tenor_months,
+			CASE WHEN t.partner_id IS NULL THEN ? ELSE ? END AS segment,
+			COUNT(*) AS contract_count,
+			ROUND(SUM(t.total_interest / tn.duration_months * t.fx_rate), 2) AS accrued_interest`,
+			domain.PortfolioDirect, domain.PortfolioPartner).
+		Joins("JOIN tenors AS tn ON tn.id = t.tenor_id").
+		Where("t.status IN ? AND t.is_sandbox = ?", []model.TransactionStatus{model.TransactionActive, model.TransactionPaidOff}, false).
+		Where("PERIOD_DIFF(?, DATE_FORMAT(t.transaction_date, '%Y%m')) BETWEEN 1 AND tn.duration_months", period).
+		Group("t.tenor_id, tn.duration_months, segment").
+		Order("tn.duration_months ASC, segment ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select_aggregate", "Error computing interest accrual", err, zap.String("period", period))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	r.log.Info("Interest accrual computed",
+		zap.String("period", period),
+		zap.Int("rows", len(rows)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return rows, nil
+}
+
+// PartnerCommissions implements ReportRepository.
+//
+// Komisi diakui pada bulan kontrak dibuat. Kontrak yang sudah lunas tetap
+// dihitung, kontrak sandbox dan kontrak langsung tanpa partner tidak.
+func (r *reportRepository) PartnerCommissions(ctx context.Context, month time.Time) ([]domain.PartnerCommission, error) {
+	span := trace.SpanFromContext(ctx)
+
+	period := month.Format("200601")
+
+	done := r.begin(ctx, "partner_commissions", transactionsTable, "select_aggregate")
+	defer done()
+
+	var rows []domain.PartnerCommission
+	err := r.db.WithContext(ctx).
+		Table("transactions AS t").
+		Select(`t.partner_id, p.name AS partner_name,
+			COUNT(*) AS contract_count,
+			ROUND(SUM(t.otr_amount * t.fx_rate), 2) AS otr_amount,
+			ROUND(SUM(t.admin_fee * t.fx_rate), 2) AS admin_fee,
+			ROUND(SUM(t.commission_amount * t.fx_rate), 2) AS commission`).
+		Joins("JOIN partners AS p ON p.id = t.partner_id").
+		Where("t.status IN ? AND t.is_sandbox = ?", []model.TransactionStatus{model.TransactionActive, model.TransactionPaidOff}, false).
+		Where("DATE_FORMAT(t.transaction_date, '%Y%m') = ?", period).
+		Group("t.partner_id, p.name").
+		Order("t.partner_id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select_aggregate", "Error computing partner commissions", err, zap.String("period", period))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	r.log.Info("Partner commissions computed",
+		zap.String("period", period),
+		zap.Int("rows", len(rows)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return rows, nil
+}
+
+// ReferralConversions implements ReportRepository.
+//
+// Referral dikelompokkan menurut bulan registrasi referee, konversi dihitung
+// dari kontrak non-sandbox yang pernah aktif tanpa batas bulan.
+func (r *reportRepository) ReferralConversions(ctx context.Context, month time.Time) ([]domain.ReferralConversion, error) {
+	span := trace.SpanFromContext(ctx)
+
+	period := month.Format("200601")
+
+	done := r.begin(ctx, "referral_conversions", referralsTable, "select_aggregate")
+	defer done()
+
+	var rows []domain.ReferralConversion
+	err := r.db.WithContext(ctx).
+		Table("referrals AS r").
+		Select(`r.referrer_id, c.full_name AS referrer_name, COALESCE(rc.code, '') AS code,
+			COUNT(*) AS referred,
+			SUM(r.status = ?) AS rejected,
+			SUM(r.status = ? AND EXISTS (
+				SELECT 1 FROM transactions AS t
+				WHERE t.customer_id = r.referee_id AND t.status IN ? AND t.is_sandbox = ?
+			)) AS converted`,
+			model.ReferralRejected, model.ReferralAccepted,
+			[]model.TransactionStatus{model.TransactionActive, model.TransactionPaidOff}, false).
+		Joins("JOIN customers AS c ON c.id = r.referrer_id").
+		Joins("LEFT JOIN referral_codes AS rc ON rc.customer_id = r.referrer_id").
+		Where("DATE_FORMAT(r.created_at, '%Y%m') = ?", period).
+		Group("r.referrer_id, c.full_name, rc.code").
+		Order("r.referrer_id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, referralsTable, "select_aggregate", "Error computing referral conversions", err, zap.String("period", period))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", referralsTable),
+		),
+	)
+
+	r.log.Info("Referral conversions computed",
+		zap.String("period", period),
+		zap.Int("rows", len(rows)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return rows, nil
+}
+
+// AgingExposures implements ReportRepository.
+func (r *reportRepository) AgingExposures(ctx context.Context) ([]domain.AgingExposure, error) {
+	done := r.begin(ctx, "aging_exposures", transactionsTable, "select")
+	defer done()
+
+	// Kontrak sandbox tidak masuk portofolio
+	var rows []domain.AgingExposure
+	err := r.db.WithContext(ctx).
+		Table("transactions AS t").
+		Select(`t.id AS transaction_id, t.customer_id, tn.duration_months AS tenor_months, t.transaction_date,
+			t.paid_installments, t.total_installment_amount, t.fx_rate, t.region_code`).
+		Joins("JOIN tenors AS tn ON tn.id = t.tenor_id").
+		Where("t.status = ? AND t.is_sandbox = ?", model.TransactionActive, false).
+		Order("t.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select", "Error loading aging exposures", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	return rows, nil
+}
+
+// SaveAgingSnapshot implements ReportRepository.
+func (r *reportRepository) SaveAgingSnapshot(ctx context.Context, date time.Time, rows []domain.AgingSnapshotRow) error {
+	snapshotDate := date.Format("2006-01-02")
+
+	done := r.begin(ctx, "save_aging_snapshot", agingSnapshotsTable, "replace")
+	defer done()
+
+	// Snapshot di tanggal yang sama ditimpa, job boleh jalan beberapa kali sehari
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("snapshot_date = ?", snapshotDate).Delete(&model.AgingSnapshot{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		snapshots := model.AgingSnapshotsFromEntity(rows)
+		return tx.Create(&snapshots).Error
+	})
+	if err != nil {
+		r.recordError(ctx, agingSnapshotsTable, "replace", "Error saving aging snapshot", err, zap.String("snapshot_date", snapshotDate))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", agingSnapshotsTable),
+		),
+	)
+
+	return nil
+}
+
+// LatestAgingSnapshot implements ReportRepository.
+func (r *reportRepository) LatestAgingSnapshot(ctx context.Context, onOrBefore time.Time) ([]domain.AgingSnapshotRow, error) {
+	date := onOrBefore.Format("2006-01-02")
+
+	done := r.begin(ctx, "latest_aging_snapshot", agingSnapshotsTable, "select")
+	defer done()
+
+	var snapshots []model.AgingSnapshot
+	latest := r.db.Model(&model.AgingSnapshot{}).Select("MAX(snapshot_date)").Where("snapshot_date <= ?", date)
+	err := r.db.WithContext(ctx).
+		Where("snapshot_date = (?)", latest).
+		Order("tenor_months ASC, bucket ASC").
+		Find(&snapshots).Error
+	if err != nil {
+		r.recordError(ctx, agingSnapshotsTable, "select", "Error loading aging snapshot", err, zap.String("on_or_before", date))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(snapshots)),
+		metric.WithAttributes(
+			attribute.String("table", agingSnapshotsTable),
+		),
+	)
+
+	return model.AgingSnapshotsToEntity(snapshots), nil
+}
+
+// RegionVolumes implements ReportRepository.
+//
+// Volume diakui pada bulan kontrak dibuat, sama seperti komisi partner.
+// Kontrak tanpa region dikelompokkan dengan kode kosong.
+func (r *reportRepository) RegionVolumes(ctx context.Context, month time.Time) ([]domain.RegionVolume, error) {
+	span := trace.SpanFromContext(ctx)
+
+	period := month.Format("200601")
+
+	done := r.begin(ctx, "region_volumes", transactionsTable, "select_aggregate")
+	defer done()
+
+	var rows []domain.RegionVolume
+	err := r.db.WithContext(ctx).
+		Table("transactions AS t").
+		Select(`t.region_code,
+			COUNT(*) AS contract_count,
+			ROUND(SUM(t.otr_amount * t.fx_rate), 2) AS otr_amount`).
+		Where("t.status IN ? AND t.is_sandbox = ?", []model.TransactionStatus{model.TransactionActive, model.TransactionPaidOff}, false).
+		Where("DATE_FORMAT(t.transaction_date, '%Y%m') = ?", period).
+		Group("t.region_code").
+		Order("t.region_code ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select_aggregate", "Error computing region volumes", err, zap.String("period", period))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	r.log.Info("Region volumes computed",
+		zap.String("period", period),
+		zap.Int("rows", len(rows)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return rows, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *reportRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *reportRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewReportRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.ReportRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &reportRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
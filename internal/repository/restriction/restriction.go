@@ -0,0 +1,245 @@
+package restrictionrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const restrictionsTable = "customer_restrictions"
+
+type customerRestrictionRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+	documentsUpdated   metric.Int64Counter
+}
+
+// Create implements CustomerRestrictionRepository.
+func (r *customerRestrictionRepository) Create(ctx context.Context, restriction *domain.CustomerRestriction) error {
+	done := r.begin(ctx, "create_customer_restriction", "insert")
+	defer done()
+
+	data := model.CustomerRestrictionFromEntity(restriction)
+	if err := r.db.WithContext(ctx).Omit("Customer", "Tenor").Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error creating customer restriction", err,
+			zap.Uint64("customer_id", restriction.CustomerID),
+		)
+		return err
+	}
+
+	restriction.ID = data.ID
+	restriction.CreatedAt = data.CreatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", restrictionsTable),
+		),
+	)
+
+	return nil
+}
+
+// FindActive implements CustomerRestrictionRepository. Restrictions past
+// their expiry are left out even before the expiry job lifts them.
+func (r *customerRestrictionRepository) FindActive(ctx context.Context, customerID uint64, now time.Time) ([]domain.CustomerRestriction, error) {
+	done := r.begin(ctx, "find_active_customer_restrictions", "select")
+	defer done()
+
+	var restrictions []model.CustomerRestriction
+	err := r.db.WithContext(ctx).Preload("Tenor").
+		Where("customer_id = ? AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", customerID, now).
+		Order("id ASC").
+		Find(&restrictions).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding active customer restrictions", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(restrictions)),
+		metric.WithAttributes(
+			attribute.String("table", restrictionsTable),
+		),
+	)
+
+	return model.CustomerRestrictionsToEntity(restrictions), nil
+}
+
+// FindByCustomer implements CustomerRestrictionRepository. Lifted and expired
+// restrictions are included, newest first.
+func (r *customerRestrictionRepository) FindByCustomer(ctx context.Context, customerID uint64) ([]domain.CustomerRestriction, error) {
+	done := r.begin(ctx, "find_customer_restrictions_by_customer", "select")
+	defer done()
+
+	var restrictions []model.CustomerRestriction
+	err := r.db.WithContext(ctx).Preload("Tenor").
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC, id DESC").
+		Find(&restrictions).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding customer restrictions", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(restrictions)),
+		metric.WithAttributes(
+			attribute.String("table", restrictionsTable),
+		),
+	)
+
+	return model.CustomerRestrictionsToEntity(restrictions), nil
+}
+
+// FindExpired implements CustomerRestrictionRepository.
+func (r *customerRestrictionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]domain.CustomerRestriction, error) {
+	done := r.begin(ctx, "find_expired_customer_restrictions", "select")
+	defer done()
+
+	var restrictions []model.CustomerRestriction
+	err := r.db.WithContext(ctx).Preload("Tenor").
+		Where("lifted_at IS NULL AND expires_at IS NOT NULL AND expires_at <= ?", now).
+		Order("expires_at ASC, id ASC").
+		Limit(limit).
+		Find(&restrictions).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding expired customer restrictions", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(restrictions)),
+		metric.WithAttributes(
+			attribute.String("table", restrictionsTable),
+		),
+	)
+
+	return model.CustomerRestrictionsToEntity(restrictions), nil
+}
+
+// Lift implements CustomerRestrictionRepository. A nil liftedBy marks a
+// restriction lifted by its expiry rather than by an admin.
+func (r *customerRestrictionRepository) Lift(ctx context.Context, id uint64, liftedBy *uint64, at time.Time) (bool, error) {
+	done := r.begin(ctx, "lift_customer_restriction", "update")
+	defer done()
+
+	// Syarat lifted_at kosong mencegah admin dan job expiry mencabut dua kali
+	result := r.db.WithContext(ctx).Model(&model.CustomerRestriction{}).
+		Where("id = ? AND lifted_at IS NULL", id).
+		Updates(map[string]any{"lifted_at": at, "lifted_by": liftedBy})
+	if result.Error != nil {
+		r.recordError(ctx, "update", "Error lifting customer restriction", result.Error, zap.Uint64("restriction_id", id))
+		return false, result.Error
+	}
+
+	r.documentsUpdated.Add(ctx, result.RowsAffected,
+		metric.WithAttributes(
+			attribute.String("table", restrictionsTable),
+		),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *customerRestrictionRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", restrictionsTable),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", restrictionsTable),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *customerRestrictionRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", restrictionsTable),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewCustomerRestrictionRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.CustomerRestrictionRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsUpdated, _ := meter.Int64Counter(
+		"db.documents.updated",
+		metric.WithDescription("Number of documents updated in the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &customerRestrictionRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+		documentsUpdated:   documentsUpdated,
+	}
+}
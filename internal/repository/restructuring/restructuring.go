@@ -0,0 +1,261 @@
+package restructuringrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type restructuringRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements RestructuringRepository.
+func (r *restructuringRepository) Create(ctx context.Context, restructuring *domain.Restructuring) error {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Create restructuring",
+		zap.Uint64("transaction_id", restructuring.TransactionID),
+		zap.String("type", string(restructuring.Type)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "create_restructuring", "insert")
+	defer done()
+
+	data := model.RestructuringFromEntity(restructuring)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error creating restructuring", err, zap.Uint64("transaction_id", restructuring.TransactionID))
+		return err
+	}
+
+	restructuring.ID = data.ID
+	restructuring.CreatedAt = data.CreatedAt
+	restructuring.UpdatedAt = data.UpdatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "restructurings"),
+		),
+	)
+
+	r.log.Info("Restructuring created",
+		zap.Uint64("restructuring_id", restructuring.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// Update implements RestructuringRepository.
+func (r *restructuringRepository) Update(ctx context.Context, restructuring *domain.Restructuring) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "update_restructuring", "update")
+	defer done()
+
+	data := model.RestructuringFromEntity(restructuring)
+	err := r.db.WithContext(ctx).Model(&model.Restructuring{ID: restructuring.ID}).
+		Select("status", "reviewer_id", "review_note", "reviewed_at").
+		Updates(&data).Error
+	if err != nil {
+		r.recordError(ctx, "update", "Error updating restructuring", err, zap.Uint64("restructuring_id", restructuring.ID))
+		return err
+	}
+
+	r.log.Info("Restructuring updated",
+		zap.Uint64("restructuring_id", restructuring.ID),
+		zap.String("status", string(restructuring.Status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindByID implements RestructuringRepository.
+func (r *restructuringRepository) FindByID(ctx context.Context, id uint64) (*domain.Restructuring, error) {
+	return r.findOne(ctx, "find_restructuring_by_id", r.db.Where("id = ?", id))
+}
+
+// FindByIDWithLock implements RestructuringRepository.
+func (r *restructuringRepository) FindByIDWithLock(ctx context.Context, id uint64) (*domain.Restructuring, error) {
+	// SELECT ... FOR UPDATE supaya dua admin tidak mereview restrukturisasi yang sama
+	return r.findOne(ctx, "find_restructuring_by_id_with_lock",
+		r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id))
+}
+
+// FindPendingByTransactionID implements RestructuringRepository.
+func (r *restructuringRepository) FindPendingByTransactionID(ctx context.Context, transactionID uint64) (*domain.Restructuring, error) {
+	return r.findOne(ctx, "find_pending_restructuring",
+		r.db.Where("transaction_id = ? AND status = ?", transactionID, model.RestructuringPending))
+}
+
+func (r *restructuringRepository) findOne(ctx context.Context, operation string, query *gorm.DB) (*domain.Restructuring, error) {
+	done := r.begin(ctx, operation, "select")
+	defer done()
+
+	var restructuring model.Restructuring
+	if err := query.WithContext(ctx).First(&restructuring).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding restructuring", err, zap.String("operation", operation))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "restructurings"),
+		),
+	)
+
+	return model.RestructuringToEntity(restructuring), nil
+}
+
+// FindPaginated implements RestructuringRepository.
+func (r *restructuringRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.Restructuring, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find restructurings paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_restructurings", "select_paginated")
+	defer done()
+
+	query := r.db.WithContext(ctx).Model(&model.Restructuring{})
+	countQuery := r.db.WithContext(ctx).Model(&model.Restructuring{})
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error counting restructurings", err)
+		return nil, 0, err
+	}
+
+	var restructurings []model.Restructuring
+	if err := params.Paginate(query).Order("created_at ASC").Find(&restructurings).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error finding restructurings", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(restructurings)),
+		metric.WithAttributes(
+			attribute.String("table", "restructurings"),
+		),
+	)
+
+	r.log.Info("Restructurings found paginated",
+		zap.Int64("total", total),
+		zap.Int("retrieved", len(restructurings)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.RestructuringsToEntity(restructurings), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *restructuringRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "restructurings"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "restructurings"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *restructuringRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "restructurings"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewRestructuringRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.RestructuringRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &restructuringRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,356 @@
+package risktierrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	riskTierRulesTable     = "risk_tier_rules"
+	customerRiskTiersTable = "customer_risk_tiers"
+	customersTable         = "customers"
+	transactionsTable      = "transactions"
+)
+
+// ruleColumns ditulis ulang semua saat update, termasuk batas yang dikosongkan
+var ruleColumns = []string{
+	"priority", "tier", "min_salary", "max_salary", "min_score", "max_score",
+	"min_days_past_due", "max_days_past_due", "updated_by", "updated_at",
+}
+
+type riskTierRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindRules implements RiskTierRepository.
+func (r *riskTierRepository) FindRules(ctx context.Context) ([]domain.RiskTierRule, error) {
+	done := r.begin(ctx, "find_risk_tier_rules", riskTierRulesTable, "select")
+	defer done()
+
+	var rules []model.RiskTierRule
+	if err := r.db.WithContext(ctx).Order("priority ASC, id ASC").Find(&rules).Error; err != nil {
+		r.recordError(ctx, riskTierRulesTable, "select", "Error finding risk tier rules", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rules)),
+		metric.WithAttributes(
+			attribute.String("table", riskTierRulesTable),
+		),
+	)
+
+	return model.RiskTierRulesToEntity(rules), nil
+}
+
+// FindRuleByID implements RiskTierRepository.
+func (r *riskTierRepository) FindRuleByID(ctx context.Context, id uint64) (*domain.RiskTierRule, error) {
+	done := r.begin(ctx, "find_risk_tier_rule", riskTierRulesTable, "select")
+	defer done()
+
+	var rule model.RiskTierRule
+	if err := r.db.WithContext(ctx).First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, riskTierRulesTable, "select", "Error finding risk tier rule", err, zap.Uint64("rule_id", id))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", riskTierRulesTable),
+		),
+	)
+
+	return model.RiskTierRuleToEntity(rule), nil
+}
+
+// CreateRule implements RiskTierRepository.
+func (r *riskTierRepository) CreateRule(ctx context.Context, rule *domain.RiskTierRule) error {
+	done := r.begin(ctx, "create_risk_tier_rule", riskTierRulesTable, "insert")
+	defer done()
+
+	data := model.RiskTierRuleFromEntity(rule)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, riskTierRulesTable, "insert", "Error creating risk tier rule", err, zap.String("tier", string(rule.Tier)))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", riskTierRulesTable),
+		),
+	)
+
+	rule.ID = data.ID
+	rule.CreatedAt = data.CreatedAt
+	rule.UpdatedAt = data.UpdatedAt
+	return nil
+}
+
+// UpdateRule implements RiskTierRepository.
+func (r *riskTierRepository) UpdateRule(ctx context.Context, rule *domain.RiskTierRule) error {
+	done := r.begin(ctx, "update_risk_tier_rule", riskTierRulesTable, "update")
+	defer done()
+
+	data := model.RiskTierRuleFromEntity(rule)
+	data.UpdatedAt = time.Now()
+	err := r.db.WithContext(ctx).Model(&model.RiskTierRule{ID: rule.ID}).
+		Select(ruleColumns).
+		Updates(&data).Error
+	if err != nil {
+		r.recordError(ctx, riskTierRulesTable, "update", "Error updating risk tier rule", err, zap.Uint64("rule_id", rule.ID))
+		return err
+	}
+
+	rule.UpdatedAt = data.UpdatedAt
+	return nil
+}
+
+// DeleteRule implements RiskTierRepository.
+func (r *riskTierRepository) DeleteRule(ctx context.Context, id uint64) (bool, error) {
+	done := r.begin(ctx, "delete_risk_tier_rule", riskTierRulesTable, "delete")
+	defer done()
+
+	result := r.db.WithContext(ctx).Delete(&model.RiskTierRule{}, id)
+	if result.Error != nil {
+		r.recordError(ctx, riskTierRulesTable, "delete", "Error deleting risk tier rule", result.Error, zap.Uint64("rule_id", id))
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindByCustomerID implements RiskTierRepository.
+func (r *riskTierRepository) FindByCustomerID(ctx context.Context, customerID uint64) (*domain.CustomerRiskTier, error) {
+	done := r.begin(ctx, "find_customer_risk_tier", customerRiskTiersTable, "select")
+	defer done()
+
+	var tier model.CustomerRiskTier
+	if err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).First(&tier).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, customerRiskTiersTable, "select", "Error finding customer risk tier", err, zap.Uint64("customer_id", customerID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", customerRiskTiersTable),
+		),
+	)
+
+	return model.CustomerRiskTierToEntity(tier), nil
+}
+
+// FindByCustomerIDs implements RiskTierRepository.
+func (r *riskTierRepository) FindByCustomerIDs(ctx context.Context, customerIDs []uint64) ([]domain.CustomerRiskTier, error) {
+	if len(customerIDs) == 0 {
+		return nil, nil
+	}
+
+	done := r.begin(ctx, "find_customer_risk_tiers", customerRiskTiersTable, "select")
+	defer done()
+
+	var tiers []model.CustomerRiskTier
+	if err := r.db.WithContext(ctx).Where("customer_id IN ?", customerIDs).Find(&tiers).Error; err != nil {
+		r.recordError(ctx, customerRiskTiersTable, "select", "Error finding customer risk tiers", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(tiers)),
+		metric.WithAttributes(
+			attribute.String("table", customerRiskTiersTable),
+		),
+	)
+
+	return model.CustomerRiskTiersToEntity(tiers), nil
+}
+
+// Save implements RiskTierRepository.
+func (r *riskTierRepository) Save(ctx context.Context, tier *domain.CustomerRiskTier) error {
+	done := r.begin(ctx, "save_customer_risk_tier", customerRiskTiersTable, "upsert")
+	defer done()
+
+	data := model.CustomerRiskTierFromEntity(tier)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "customer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tier", "rule_id", "salary", "credit_score", "days_past_due", "calculated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, customerRiskTiersTable, "upsert", "Error saving customer risk tier", err, zap.Uint64("customer_id", tier.CustomerID))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", customerRiskTiersTable),
+		),
+	)
+
+	return nil
+}
+
+// FindCustomers implements RiskTierRepository.
+func (r *riskTierRepository) FindCustomers(ctx context.Context, afterID uint64, limit int) ([]domain.Customer, error) {
+	done := r.begin(ctx, "find_risk_tier_customers", customersTable, "select")
+	defer done()
+
+	var customers []model.Customer
+	err := r.db.WithContext(ctx).
+		Where("id > ? AND role = ? AND closed_at IS NULL", afterID, model.CustomerRole).
+		Order("id ASC").
+		Limit(limit).
+		Find(&customers).Error
+	if err != nil {
+		r.recordError(ctx, customersTable, "select", "Error finding customers to grade", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(customers)),
+		metric.WithAttributes(
+			attribute.String("table", customersTable),
+		),
+	)
+
+	return model.CustomersToEntity(customers), nil
+}
+
+// FindActiveExposures implements RiskTierRepository.
+func (r *riskTierRepository) FindActiveExposures(ctx context.Context, customerIDs []uint64) ([]domain.AgingExposure, error) {
+	if len(customerIDs) == 0 {
+		return nil, nil
+	}
+
+	done := r.begin(ctx, "find_risk_tier_exposures", transactionsTable, "select")
+	defer done()
+
+	// Sama seperti laporan aging, kontrak sandbox tidak dihitung
+	var rows []domain.AgingExposure
+	err := r.db.WithContext(ctx).
+		Table("transactions AS t").
+		Select(`t.id AS transaction_id, t.customer_id, tn.duration_months AS tenor_months, t.transaction_date,
+			t.paid_installments, t.total_installment_amount, t.fx_rate, t.region_code`).
+		Joins("JOIN tenors AS tn ON tn.id = t.tenor_id").
+		Where("t.customer_id IN ? AND t.status = ? AND t.is_sandbox = ?", customerIDs, model.TransactionActive, false).
+		Order("t.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.recordError(ctx, transactionsTable, "select", "Error loading customer exposures", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(rows)),
+		metric.WithAttributes(
+			attribute.String("table", transactionsTable),
+		),
+	)
+
+	return rows, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *riskTierRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *riskTierRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewRiskTierRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.RiskTierRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &riskTierRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
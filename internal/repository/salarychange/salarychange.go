@@ -0,0 +1,261 @@
+package salarychangerepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type salaryChangeRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements SalaryChangeRepository.
+func (r *salaryChangeRepository) Create(ctx context.Context, change *domain.SalaryChange) error {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Create salary change",
+		zap.Uint64("customer_id", change.CustomerID),
+		zap.Stringer("requested_salary", change.RequestedSalary),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "create_salary_change", "insert")
+	defer done()
+
+	data := model.SalaryChangeFromEntity(change)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, "insert", "Error creating salary change", err, zap.Uint64("customer_id", change.CustomerID))
+		return err
+	}
+
+	change.ID = data.ID
+	change.CreatedAt = data.CreatedAt
+	change.UpdatedAt = data.UpdatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "salary_changes"),
+		),
+	)
+
+	r.log.Info("Salary change created",
+		zap.Uint64("salary_change_id", change.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// Update implements SalaryChangeRepository.
+func (r *salaryChangeRepository) Update(ctx context.Context, change *domain.SalaryChange) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "update_salary_change", "update")
+	defer done()
+
+	data := model.SalaryChangeFromEntity(change)
+	err := r.db.WithContext(ctx).Model(&model.SalaryChange{ID: change.ID}).
+		Select("status", "reviewer_id", "review_note", "reviewed_at").
+		Updates(&data).Error
+	if err != nil {
+		r.recordError(ctx, "update", "Error updating salary change", err, zap.Uint64("salary_change_id", change.ID))
+		return err
+	}
+
+	r.log.Info("Salary change updated",
+		zap.Uint64("salary_change_id", change.ID),
+		zap.String("status", string(change.Status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindByID implements SalaryChangeRepository.
+func (r *salaryChangeRepository) FindByID(ctx context.Context, id uint64) (*domain.SalaryChange, error) {
+	return r.findOne(ctx, "find_salary_change_by_id", r.db.Where("id = ?", id))
+}
+
+// FindByIDWithLock implements SalaryChangeRepository.
+func (r *salaryChangeRepository) FindByIDWithLock(ctx context.Context, id uint64) (*domain.SalaryChange, error) {
+	// SELECT ... FOR UPDATE supaya dua admin tidak mereview perubahan yang sama
+	return r.findOne(ctx, "find_salary_change_by_id_with_lock",
+		r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id))
+}
+
+// FindPendingByCustomerID implements SalaryChangeRepository.
+func (r *salaryChangeRepository) FindPendingByCustomerID(ctx context.Context, customerID uint64) (*domain.SalaryChange, error) {
+	return r.findOne(ctx, "find_pending_salary_change",
+		r.db.Where("customer_id = ? AND status = ?", customerID, model.SalaryChangePending))
+}
+
+func (r *salaryChangeRepository) findOne(ctx context.Context, operation string, query *gorm.DB) (*domain.SalaryChange, error) {
+	done := r.begin(ctx, operation, "select")
+	defer done()
+
+	var change model.SalaryChange
+	if err := query.WithContext(ctx).First(&change).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding salary change", err, zap.String("operation", operation))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "salary_changes"),
+		),
+	)
+
+	return model.SalaryChangeToEntity(change), nil
+}
+
+// FindPaginated implements SalaryChangeRepository.
+func (r *salaryChangeRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.SalaryChange, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find salary changes paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_salary_changes", "select_paginated")
+	defer done()
+
+	query := r.db.WithContext(ctx).Model(&model.SalaryChange{})
+	countQuery := r.db.WithContext(ctx).Model(&model.SalaryChange{})
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error counting salary changes", err)
+		return nil, 0, err
+	}
+
+	var changes []model.SalaryChange
+	if err := params.Paginate(query).Order("created_at ASC").Find(&changes).Error; err != nil {
+		r.recordError(ctx, "select_paginated", "Error finding salary changes", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(changes)),
+		metric.WithAttributes(
+			attribute.String("table", "salary_changes"),
+		),
+	)
+
+	r.log.Info("Salary changes found paginated",
+		zap.Int64("total", total),
+		zap.Int("retrieved", len(changes)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.SalaryChangesToEntity(changes), total, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *salaryChangeRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "salary_changes"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "salary_changes"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *salaryChangeRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "salary_changes"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewSalaryChangeRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.SalaryChangeRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &salaryChangeRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
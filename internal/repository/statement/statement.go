@@ -0,0 +1,198 @@
+package statementrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type statementRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindByContractNumber implements StatementRepository.
+func (r *statementRepository) FindByContractNumber(ctx context.Context, contractNumber string) (*domain.TransactionStatement, error) {
+	done := r.begin(ctx, "find_statement_by_contract_number", "select")
+	defer done()
+
+	var statement model.TransactionStatement
+	if err := r.db.WithContext(ctx).Where("contract_number = ?", contractNumber).First(&statement).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, "select", "Error finding statement", err, zap.String("contract_number", contractNumber))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "transaction_statements"),
+		),
+	)
+
+	return model.TransactionStatementToEntity(statement), nil
+}
+
+// FindPending implements StatementRepository.
+func (r *statementRepository) FindPending(ctx context.Context, limit int) ([]domain.TransactionStatement, error) {
+	done := r.begin(ctx, "find_pending_statements", "select")
+	defer done()
+
+	var statements []model.TransactionStatement
+	err := r.db.WithContext(ctx).
+		Where("status = ?", model.StatementPending).
+		Order("updated_at ASC, id ASC").
+		Limit(limit).
+		Find(&statements).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding pending statements", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(statements)),
+		metric.WithAttributes(
+			attribute.String("table", "transaction_statements"),
+		),
+	)
+
+	return model.TransactionStatementsToEntity(statements), nil
+}
+
+// Save implements StatementRepository.
+func (r *statementRepository) Save(ctx context.Context, statement *domain.TransactionStatement) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "save_statement", "upsert")
+	defer done()
+
+	// Satu baris per kontrak, render ulang menimpa hasil sebelumnya
+	data := model.TransactionStatementFromEntity(statement)
+	data.UpdatedAt = time.Now()
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contract_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"customer_id", "fingerprint", "status", "url", "error", "updated_at"}),
+	}).Create(&data).Error
+	if err != nil {
+		r.recordError(ctx, "upsert", "Error saving statement", err, zap.String("contract_number", statement.ContractNumber))
+		return err
+	}
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "transaction_statements"),
+		),
+	)
+
+	r.log.Info("Statement saved",
+		zap.String("contract_number", statement.ContractNumber),
+		zap.String("status", string(statement.Status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	statement.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *statementRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "transaction_statements"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "transaction_statements"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *statementRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "transaction_statements"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewStatementRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.StatementRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &statementRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,127 @@
+package tenorrepo
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/pkg/cache"
+
+	"go.uber.org/zap"
+)
+
+// cachedTenorRepository serves tenor lookups from the shared cache. Tenors
+// are read on every limit check and transaction but change only through the
+// admin API, so every write flushes the cache on all instances.
+type cachedTenorRepository struct {
+	next    repository.TenorRepository
+	tenors  *cache.Cache[*domain.Tenor]
+	catalog *cache.Cache[[]domain.Tenor]
+	log     *zap.Logger
+}
+
+// FindAll implements TenorRepository.
+func (r *cachedTenorRepository) FindAll(ctx context.Context) ([]domain.Tenor, error) {
+	tenors, err := r.catalog.Get(ctx, "all", r.next.FindAll)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Tenor, len(tenors))
+	for i := range tenors {
+		result[i] = *cloneTenor(&tenors[i])
+	}
+	return result, nil
+}
+
+// FindByDuration implements TenorRepository.
+func (r *cachedTenorRepository) FindByDuration(ctx context.Context, durationMonths uint8) (*domain.Tenor, error) {
+	// Tenor yang tidak ada ikut di-cache sebagai nil, hasil yang sama dengan repository
+	tenor, err := r.tenors.Get(ctx, "duration:"+strconv.Itoa(int(durationMonths)), func(ctx context.Context) (*domain.Tenor, error) {
+		return r.next.FindByDuration(ctx, durationMonths)
+	})
+	return cloneTenor(tenor), err
+}
+
+// FindByID implements TenorRepository.
+func (r *cachedTenorRepository) FindByID(ctx context.Context, id uint) (*domain.Tenor, error) {
+	tenor, err := r.tenors.Get(ctx, "id:"+strconv.FormatUint(uint64(id), 10), func(ctx context.Context) (*domain.Tenor, error) {
+		return r.next.FindByID(ctx, id)
+	})
+	return cloneTenor(tenor), err
+}
+
+// Create implements TenorRepository.
+func (r *cachedTenorRepository) Create(ctx context.Context, tenor *domain.Tenor) error {
+	if err := r.next.Create(ctx, tenor); err != nil {
+		return err
+	}
+	r.invalidate(ctx)
+	return nil
+}
+
+// Update implements TenorRepository.
+func (r *cachedTenorRepository) Update(ctx context.Context, tenor domain.Tenor) (bool, error) {
+	updated, err := r.next.Update(ctx, tenor)
+	if err != nil {
+		return false, err
+	}
+	if updated {
+		r.invalidate(ctx)
+	}
+	return updated, nil
+}
+
+// Delete implements TenorRepository.
+func (r *cachedTenorRepository) Delete(ctx context.Context, id uint) (bool, error) {
+	deleted, err := r.next.Delete(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if deleted {
+		r.invalidate(ctx)
+	}
+	return deleted, nil
+}
+
+// IsReferenced implements TenorRepository.
+func (r *cachedTenorRepository) IsReferenced(ctx context.Context, id uint) (bool, error) {
+	return r.next.IsReferenced(ctx, id)
+}
+
+// invalidate flushes both caches on every instance.
+func (r *cachedTenorRepository) invalidate(ctx context.Context) {
+	// Perubahan sudah tersimpan, jadi kegagalan di sini hanya dicatat sampai TTL habis
+	for _, err := range []error{r.tenors.Invalidate(ctx), r.catalog.Invalidate(ctx)} {
+		if err != nil {
+			r.log.Warn("Failed to invalidate tenor cache", zap.Error(err))
+		}
+	}
+}
+
+// cloneTenor copies a cached tenor so callers can modify what they get, as
+// the tenor service does before saving, without touching the cache.
+func cloneTenor(tenor *domain.Tenor) *domain.Tenor {
+	if tenor == nil {
+		return nil
+	}
+	clone := *tenor
+	clone.AssetCategories = slices.Clone(tenor.AssetCategories)
+	return &clone
+}
+
+func NewCachedTenorRepository(
+	next repository.TenorRepository,
+	tenors *cache.Cache[*domain.Tenor],
+	catalog *cache.Cache[[]domain.Tenor],
+	log *zap.Logger,
+) repository.TenorRepository {
+	return &cachedTenorRepository{
+		next:    next,
+		tenors:  tenors,
+		catalog: catalog,
+		log:     log,
+	}
+}
@@ -3,7 +3,6 @@ package tenorrepo
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/model"
@@ -11,7 +10,6 @@ import (
 	"gorm.io/gorm"
 
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -20,9 +18,7 @@ import (
 type tenorRepository struct {
 	db                 *gorm.DB
 	meter              metric.Meter
-	tracer             trace.Tracer
 	log                *zap.Logger
-	queryDuration      metric.Float64Histogram
 	queryCount         metric.Int64Counter
 	errorCount         metric.Int64Counter
 	connectionGauge    metric.Int64UpDownCounter
@@ -31,10 +27,7 @@ type tenorRepository struct {
 
 // FindAll implements TenorRepository.
 func (t *tenorRepository) FindAll(ctx context.Context) ([]domain.Tenor, error) {
-	ctx, span := t.tracer.Start(ctx, "repository.FindAllTenors")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	t.log.Debug("Find all tenors",
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -60,18 +53,10 @@ func (t *tenorRepository) FindAll(ctx context.Context) ([]domain.Tenor, error) {
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select"),
-		attribute.String("db.table", "tenors"),
-	)
-
 	var tenors []model.Tenor
 	err := t.db.WithContext(ctx).Find(&tenors).Error
 
 	if err != nil {
-		span.SetStatus(codes.Error, "Error finding all tenors")
-		span.RecordError(err)
-
 		t.log.Error("Error finding all tenors",
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 			zap.Error(err),
@@ -85,15 +70,6 @@ func (t *tenorRepository) FindAll(ctx context.Context) ([]domain.Tenor, error) {
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		t.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "select"),
-				attribute.String("table", "tenors"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return nil, err
 	}
 
@@ -103,33 +79,17 @@ func (t *tenorRepository) FindAll(ctx context.Context) ([]domain.Tenor, error) {
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	t.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "select"),
-			attribute.String("table", "tenors"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	t.log.Info("All tenors retrieved",
 		zap.Int("retrieved_count", len(tenors)),
-		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Tenors found successfully")
-	span.SetAttributes(attribute.Int("result.retrieved", len(tenors)))
-
 	return model.TenorsToEntity(tenors), nil
 }
 
 // FindByDuration implements TenorRepository.
 func (t *tenorRepository) FindByDuration(ctx context.Context, durationMonths uint8) (*domain.Tenor, error) {
-	ctx, span := t.tracer.Start(ctx, "repository.FindByDuration")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	t.log.Debug("Find tenor by duration",
 		zap.Uint8("duration_months", durationMonths),
@@ -156,37 +116,18 @@ func (t *tenorRepository) FindByDuration(ctx context.Context, durationMonths uin
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select"),
-		attribute.String("db.table", "tenors"),
-		attribute.Int("tenor.duration_months", int(durationMonths)),
-	)
-
 	var tenor model.Tenor
-	if err := t.db.WithContext(ctx).Where("duration_months = ?", durationMonths).First(&tenor).Error; err != nil {
+	// Tenor nonaktif tidak lagi bisa dipakai untuk limit maupun transaksi baru
+	if err := t.db.WithContext(ctx).Where("duration_months = ? AND active = ?", durationMonths, true).First(&tenor).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			span.SetStatus(codes.Ok, "Tenor not found")
-
 			t.log.Info("Tenor not found by duration",
 				zap.Uint8("duration_months", durationMonths),
 				zap.String("trace_id", span.SpanContext().TraceID().String()),
 			)
 
-			duration := float64(time.Since(start).Milliseconds())
-			t.queryDuration.Record(ctx, duration,
-				metric.WithAttributes(
-					attribute.String("operation", "select"),
-					attribute.String("table", "tenors"),
-					attribute.String("status", "not_found"),
-				),
-			)
-
 			return nil, nil
 		}
 
-		span.SetStatus(codes.Error, "Error finding tenor by duration")
-		span.RecordError(err)
-
 		t.log.Error("Error finding tenor by duration",
 			zap.Uint8("duration_months", durationMonths),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -201,12 +142,70 @@ func (t *tenorRepository) FindByDuration(ctx context.Context, durationMonths uin
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		t.queryDuration.Record(ctx, duration,
+		return nil, err
+	}
+
+	t.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	t.log.Info("Tenor found by duration",
+		zap.Uint8("duration_months", durationMonths),
+		zap.Uint("tenor_id", tenor.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.TenorToEntity(tenor), nil
+}
+
+// FindByID implements TenorRepository.
+func (t *tenorRepository) FindByID(ctx context.Context, id uint) (*domain.Tenor, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Find tenor by ID",
+		zap.Uint("tenor_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_tenor_by_id"),
+			attribute.String("table", "tenors"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_tenor_by_id"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	var tenor model.Tenor
+	err := t.db.WithContext(ctx).First(&tenor, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		t.log.Error("Error finding tenor by ID",
+			zap.Uint("tenor_id", id),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
 			metric.WithAttributes(
 				attribute.String("operation", "select"),
 				attribute.String("table", "tenors"),
-				attribute.String("status", "error"),
+				attribute.String("error", err.Error()),
 			),
 		)
 
@@ -219,42 +218,272 @@ func (t *tenorRepository) FindByDuration(ctx context.Context, durationMonths uin
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	t.queryDuration.Record(ctx, duration,
+	t.log.Info("Tenor found by ID",
+		zap.Uint("tenor_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return model.TenorToEntity(tenor), nil
+}
+
+// Create implements TenorRepository.
+func (t *tenorRepository) Create(ctx context.Context, tenor *domain.Tenor) error {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Creating tenor",
+		zap.Uint8("duration_months", tenor.DurationMonths),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
 		metric.WithAttributes(
-			attribute.String("operation", "select"),
+			attribute.String("operation", "create_tenor"),
+			attribute.String("table", "tenors"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "create_tenor"),
 			attribute.String("table", "tenors"),
-			attribute.String("status", "success"),
 		),
 	)
 
-	t.log.Info("Tenor found by duration",
-		zap.Uint8("duration_months", durationMonths),
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "insert"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	data := model.TenorFromEntity(tenor)
+	err := t.db.WithContext(ctx).Create(&data).Error
+	if err != nil {
+		t.log.Error("Error creating tenor",
+			zap.Uint8("duration_months", tenor.DurationMonths),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "insert"),
+				attribute.String("table", "tenors"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return err
+	}
+
+	t.log.Info("Tenor created",
+		zap.Uint8("duration_months", tenor.DurationMonths),
+		zap.Uint("tenor_id", data.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	tenor.ID = data.ID
+
+	return nil
+}
+
+// Update implements TenorRepository.
+func (t *tenorRepository) Update(ctx context.Context, tenor domain.Tenor) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Updating tenor",
 		zap.Uint("tenor_id", tenor.ID),
-		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Tenor found successfully")
-	span.SetAttributes(
-		attribute.Int("tenor.id", int(tenor.ID)),
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "update_tenor"),
+			attribute.String("table", "tenors"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "update_tenor"),
+			attribute.String("table", "tenors"),
+		),
 	)
 
-	return model.TenorToEntity(tenor), nil
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "update"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	// Durasi tidak ikut diperbarui karena menjadi kunci yang dipakai limit dan transaksi
+	data := model.TenorFromEntity(&tenor)
+	result := t.db.WithContext(ctx).
+		Model(&data).
+		Select("description", "active", "min_amount", "max_amount", "min_age", "max_age", "max_age_at_end", "asset_categories").
+		Updates(&data)
+	err := result.Error
+	if err != nil {
+		t.log.Error("Error updating tenor",
+			zap.Uint("tenor_id", tenor.ID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "update"),
+				attribute.String("table", "tenors"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return false, err
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	t.log.Info("Tenor updated",
+		zap.Uint("tenor_id", tenor.ID),
+		zap.Bool("active", tenor.Active),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// Delete implements TenorRepository.
+func (t *tenorRepository) Delete(ctx context.Context, id uint) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Deleting tenor",
+		zap.Uint("tenor_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "delete_tenor"),
+			attribute.String("table", "tenors"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "delete_tenor"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "delete"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	result := t.db.WithContext(ctx).Delete(&model.Tenor{}, id)
+	err := result.Error
+	if err != nil {
+		t.log.Error("Error deleting tenor",
+			zap.Uint("tenor_id", id),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "delete"),
+				attribute.String("table", "tenors"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return false, err
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	t.log.Info("Tenor deleted",
+		zap.Uint("tenor_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// IsReferenced implements TenorRepository.
+func (t *tenorRepository) IsReferenced(ctx context.Context, id uint) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Checking tenor references",
+		zap.Uint("tenor_id", id),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "is_tenor_referenced"),
+			attribute.String("table", "tenors"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "is_tenor_referenced"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select"),
+			attribute.String("table", "tenors"),
+		),
+	)
+
+	// Limit, transaksi, restrukturisasi, dan riwayat limit menyimpan tenor_id
+	var referenced bool
+	err := t.db.WithContext(ctx).Raw(`SELECT
+		EXISTS (SELECT 1 FROM customer_limits WHERE tenor_id = ?) OR
+		EXISTS (SELECT 1 FROM transactions WHERE tenor_id = ?) OR
+		EXISTS (SELECT 1 FROM restructurings WHERE original_tenor_id = ? OR new_tenor_id = ?) OR
+		EXISTS (SELECT 1 FROM customer_limit_history WHERE tenor_id = ?)`,
+		id, id, id, id, id,
+	).Scan(&referenced).Error
+	if err != nil {
+		t.log.Error("Error checking tenor references",
+			zap.Uint("tenor_id", id),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select"),
+				attribute.String("table", "tenors"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return false, err
+	}
+
+	t.log.Info("Tenor references checked",
+		zap.Uint("tenor_id", id),
+		zap.Bool("referenced", referenced),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return referenced, nil
 }
 
 func NewTenorRepository(
 	db *gorm.DB,
 	meter metric.Meter,
-	tracer trace.Tracer,
 	log *zap.Logger,
 ) repository.TenorRepository {
-	queryDuration, _ := meter.Float64Histogram(
-		"db.query.duration",
-		metric.WithDescription("Duration of database queries"),
-		metric.WithUnit("ms"),
-	)
-
 	queryCount, _ := meter.Int64Counter(
 		"db.query.count",
 		metric.WithDescription("Number of database queries"),
@@ -282,9 +511,7 @@ func NewTenorRepository(
 	return &tenorRepository{
 		db:                 db,
 		meter:              meter,
-		tracer:             tracer,
 		log:                log,
-		queryDuration:      queryDuration,
 		queryCount:         queryCount,
 		errorCount:         errorCount,
 		connectionGauge:    connectionGauge,
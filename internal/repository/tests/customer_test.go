@@ -2,7 +2,7 @@ package repository_test
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -11,20 +11,18 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
 	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
-	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
-	"go.opentelemetry.io/otel/trace"
-	noop_trace "go.opentelemetry.io/otel/trace/noop"
 
 	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type CustomerRepositoryTestSuite struct {
@@ -33,86 +31,23 @@ type CustomerRepositoryTestSuite struct {
 	ctx                context.Context
 	customerRepository repository.CustomerRepository
 
-	meter  metric.Meter
-	tracer trace.Tracer
-	log    *zap.Logger
+	meter metric.Meter
+	log   *zap.Logger
 }
 
 func (suite *CustomerRepositoryTestSuite) SetupSuite() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-
-	db, err := sql.Open("mysql", dsn)
-	require.NoError(suite.T(), err)
-
-	testDBName := "loan_system_test"
-
-	_, err = db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-	require.NoError(suite.T(), err)
-
-	_, err = db.Exec(fmt.Sprintf("CREATE DATABASE %s", testDBName))
-	require.NoError(suite.T(), err)
-
-	db.Close()
-
-	testDSN := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-		testDBName,
-	)
-
-	gormDB, err := gorm.Open(mysql.Open(testDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	require.NoError(suite.T(), err)
-
-	suite.db = gormDB
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_repo_customer_test").DB
 	suite.ctx = context.Background()
 
 	suite.log = zap.NewNop()
-	noopTracerProvider := noop_trace.NewTracerProvider()
-	suite.tracer = noopTracerProvider.Tracer("test-customer-repository-tracer")
 	noopMeterProvider := noop_metric.NewMeterProvider()
 	suite.meter = noopMeterProvider.Meter("test-customer-repository-meter")
 
-	err = suite.db.AutoMigrate(
-		&model.Customer{},
-		&model.Tenor{},
-		&model.CustomerLimit{},
-		&model.Transaction{},
-	)
-	require.NoError(suite.T(), err)
-
-	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.tracer, suite.log)
-}
-
-func (suite *CustomerRepositoryTestSuite) TearDownSuite() {
-	testDBName := "loan_system_test"
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-
-	db, err := sql.Open("mysql", dsn)
-	if err == nil {
-		db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-		db.Close()
-	}
+	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.log)
 }
 
 func (suite *CustomerRepositoryTestSuite) SetupTest() {
-	suite.db.Exec("DELETE FROM transactions")
-	suite.db.Exec("DELETE FROM customer_limits")
-	suite.db.Exec("DELETE FROM customers")
-	suite.db.Exec("DELETE FROM tenors")
+	testutil.Reset(suite.T(), suite.db)
 }
 
 func (suite *CustomerRepositoryTestSuite) TestSave_Success() {
@@ -124,7 +59,7 @@ func (suite *CustomerRepositoryTestSuite) TestSave_Success() {
 		Role:               "customer",
 		BirthPlace:         "Jakarta",
 		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-		Salary:             5000000,
+		Salary:             decimal.NewFromInt(5000000),
 		KtpUrl:             "https://example.com/ktp.jpg",
 		SelfieUrl:          "https://example.com/selfie.jpg",
 		VerificationStatus: domain.VerificationPending,
@@ -153,7 +88,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindByID_Success() {
 		Role:               "customer",
 		BirthPlace:         "Jakarta",
 		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-		Salary:             5000000,
+		Salary:             decimal.NewFromInt(5000000),
 		KtpPhotoUrl:        "https://example.com/ktp.jpg",
 		SelfiePhotoUrl:     "https://example.com/selfie.jpg",
 		VerificationStatus: model.VerificationPending,
@@ -190,7 +125,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindByNIK_Success_WithoutLock() {
 		Role:               "customer",
 		BirthPlace:         "Jakarta",
 		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-		Salary:             5000000,
+		Salary:             decimal.NewFromInt(5000000),
 		KtpPhotoUrl:        "https://example.com/ktp.jpg",
 		SelfiePhotoUrl:     "https://example.com/selfie.jpg",
 		VerificationStatus: model.VerificationVerified,
@@ -219,7 +154,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindByNIK_Success_WithLock() {
 		Role:               "customer",
 		BirthPlace:         "Bandung",
 		BirthDate:          time.Date(1992, 5, 15, 0, 0, 0, 0, time.UTC),
-		Salary:             7000000,
+		Salary:             decimal.NewFromInt(7000000),
 		KtpPhotoUrl:        "https://example.com/ktp2.jpg",
 		SelfiePhotoUrl:     "https://example.com/selfie2.jpg",
 		VerificationStatus: model.VerificationVerified,
@@ -245,6 +180,36 @@ func (suite *CustomerRepositoryTestSuite) TestFindByNIK_NotFound() {
 	assert.Nil(suite.T(), result)
 }
 
+func (suite *CustomerRepositoryTestSuite) TestFindByContact() {
+	email, phone := "john@example.com", "+6281234567890"
+	customerModel := model.Customer{
+		NIK:                "1234567890123456",
+		FullName:           "John Doe",
+		LegalName:          "John Doe",
+		Password:           "johndoe123",
+		Role:               "customer",
+		BirthPlace:         "Jakarta",
+		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:             decimal.NewFromInt(5000000),
+		KtpPhotoUrl:        "https://example.com/ktp.jpg",
+		SelfiePhotoUrl:     "https://example.com/selfie.jpg",
+		VerificationStatus: model.VerificationVerified,
+		Email:              &email,
+		Phone:              &phone,
+	}
+	require.NoError(suite.T(), suite.db.Create(&customerModel).Error)
+
+	byPhone, err := suite.customerRepository.FindByContact(suite.ctx, "other@example.com", phone)
+	assert.NoError(suite.T(), err)
+	require.NotNil(suite.T(), byPhone)
+	assert.Equal(suite.T(), email, byPhone.Email)
+
+	// Kontak kosong tidak boleh cocok dengan customer lama yang kolomnya NULL
+	none, err := suite.customerRepository.FindByContact(suite.ctx, "", "")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), none)
+}
+
 func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithoutFilter() {
 	customers := []model.Customer{
 		{
@@ -255,7 +220,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithoutFilte
 			Role:               "customer",
 			BirthPlace:         "Jakarta",
 			BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-			Salary:             5000000,
+			Salary:             decimal.NewFromInt(5000000),
 			VerificationStatus: model.VerificationPending,
 			CreatedAt:          time.Now().Add(-2 * time.Hour),
 			UpdatedAt:          time.Now(),
@@ -268,7 +233,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithoutFilte
 			Role:               "customer",
 			BirthPlace:         "Bandung",
 			BirthDate:          time.Date(1991, 2, 2, 0, 0, 0, 0, time.UTC),
-			Salary:             6000000,
+			Salary:             decimal.NewFromInt(6000000),
 			VerificationStatus: model.VerificationVerified,
 			CreatedAt:          time.Now().Add(-1 * time.Hour),
 			UpdatedAt:          time.Now(),
@@ -281,7 +246,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithoutFilte
 			Role:               "customer",
 			BirthPlace:         "Surabaya",
 			BirthDate:          time.Date(1992, 3, 3, 0, 0, 0, 0, time.UTC),
-			Salary:             7000000,
+			Salary:             decimal.NewFromInt(7000000),
 			VerificationStatus: model.VerificationRejected,
 			CreatedAt:          time.Now(),
 			UpdatedAt:          time.Now(),
@@ -318,7 +283,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithStatusFi
 			Role:               "customer",
 			BirthPlace:         "Jakarta",
 			BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-			Salary:             5000000,
+			Salary:             decimal.NewFromInt(5000000),
 			VerificationStatus: model.VerificationVerified,
 			CreatedAt:          time.Now().Add(-1 * time.Hour),
 			UpdatedAt:          time.Now(),
@@ -331,7 +296,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithStatusFi
 			Role:               "customer",
 			BirthPlace:         "Bandung",
 			BirthDate:          time.Date(1991, 2, 2, 0, 0, 0, 0, time.UTC),
-			Salary:             6000000,
+			Salary:             decimal.NewFromInt(6000000),
 			VerificationStatus: model.VerificationVerified,
 			CreatedAt:          time.Now(),
 			UpdatedAt:          time.Now(),
@@ -344,7 +309,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithStatusFi
 			Role:               "customer",
 			BirthPlace:         "Surabaya",
 			BirthDate:          time.Date(1992, 3, 3, 0, 0, 0, 0, time.UTC),
-			Salary:             7000000,
+			Salary:             decimal.NewFromInt(7000000),
 			VerificationStatus: model.VerificationPending,
 			CreatedAt:          time.Now(),
 			UpdatedAt:          time.Now(),
@@ -357,9 +322,9 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithStatusFi
 	}
 
 	params := domain.Params{
-		Status: string(domain.VerificationVerified),
-		Page:   1,
-		Limit:  10,
+		Filters: []query.Condition{{Column: "verification_status", Value: string(domain.VerificationVerified)}},
+		Page:    1,
+		Limit:   10,
 	}
 
 	result, total, err := suite.customerRepository.FindPaginated(suite.ctx, params)
@@ -374,9 +339,9 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_Success_WithStatusFi
 
 func (suite *CustomerRepositoryTestSuite) TestFindPaginated_EmptyResult() {
 	params := domain.Params{
-		Status: string(domain.VerificationVerified),
-		Page:   1,
-		Limit:  10,
+		Filters: []query.Condition{{Column: "verification_status", Value: string(domain.VerificationVerified)}},
+		Page:    1,
+		Limit:   10,
 	}
 
 	result, total, err := suite.customerRepository.FindPaginated(suite.ctx, params)
@@ -397,7 +362,7 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_SecondPage() {
 			Role:               "customer",
 			BirthPlace:         "Jakarta",
 			BirthDate:          time.Date(1990+i, 1, 1, 0, 0, 0, 0, time.UTC),
-			Salary:             float64(5000000 + i*1000000),
+			Salary:             decimal.NewFromInt(int64(5000000 + i*1000000)),
 			VerificationStatus: model.VerificationVerified,
 			CreatedAt:          time.Now().Add(time.Duration(-i) * time.Hour),
 			UpdatedAt:          time.Now(),
@@ -418,6 +383,69 @@ func (suite *CustomerRepositoryTestSuite) TestFindPaginated_SecondPage() {
 	assert.Len(suite.T(), result, 2)
 }
 
+func (suite *CustomerRepositoryTestSuite) TestStreamForExport() {
+	// Arrange
+	testutil.NewCustomer().WithNIK("1111111111111111").WithStatus(model.VerificationVerified).Create(suite.T(), suite.db)
+	testutil.NewCustomer().WithNIK("2222222222222222").WithStatus(model.VerificationPending).Create(suite.T(), suite.db)
+	testutil.NewCustomer().WithNIK("3333333333333333").WithStatus(model.VerificationVerified).Create(suite.T(), suite.db)
+	testutil.NewCustomer().WithNIK("4444444444444444").WithRole(model.AdminRole).Create(suite.T(), suite.db)
+
+	suite.Run("All Customers In ID Order", func() {
+		// Act
+		var niks []string
+		err := suite.customerRepository.StreamForExport(suite.ctx, "", func(customer domain.Customer) error {
+			niks = append(niks, customer.NIK)
+			return nil
+		})
+
+		// Assert
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"1111111111111111", "2222222222222222", "3333333333333333"}, niks)
+	})
+
+	suite.Run("Filtered By Status", func() {
+		// Act
+		var niks []string
+		err := suite.customerRepository.StreamForExport(suite.ctx, domain.VerificationVerified, func(customer domain.Customer) error {
+			niks = append(niks, customer.NIK)
+			return nil
+		})
+
+		// Assert
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"1111111111111111", "3333333333333333"}, niks)
+	})
+
+	suite.Run("Stops At The First Callback Error", func() {
+		// Act
+		stop := errors.New("client went away")
+		calls := 0
+		err := suite.customerRepository.StreamForExport(suite.ctx, "", func(domain.Customer) error {
+			calls++
+			return stop
+		})
+
+		// Assert
+		assert.ErrorIs(suite.T(), err, stop)
+		assert.Equal(suite.T(), 1, calls)
+	})
+}
+
+func (suite *CustomerRepositoryTestSuite) TestCountByStatus() {
+	// Arrange
+	testutil.NewCustomer().WithNIK("1111111111111111").WithStatus(model.VerificationPending).Create(suite.T(), suite.db)
+	testutil.NewCustomer().WithNIK("2222222222222222").WithStatus(model.VerificationPending).Create(suite.T(), suite.db)
+	testutil.NewCustomer().WithNIK("3333333333333333").WithStatus(model.VerificationVerified).Create(suite.T(), suite.db)
+	testutil.NewCustomer().WithNIK("4444444444444444").WithStatus(model.VerificationPending).WithRole(model.AdminRole).Create(suite.T(), suite.db)
+
+	// Act
+	pending, err := suite.customerRepository.CountByStatus(suite.ctx, domain.VerificationPending)
+
+	// Assert
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), pending)
+}
+
 func TestCustomerRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(CustomerRepositoryTestSuite))
 }
@@ -2,8 +2,6 @@ package repository_test
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
 	"testing"
 	"time"
 
@@ -11,20 +9,17 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
 	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
-	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
-	"go.opentelemetry.io/otel/trace"
-	noop_trace "go.opentelemetry.io/otel/trace/noop"
 
 	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type LimitRepositoryTestSuite struct {
@@ -36,144 +31,196 @@ type LimitRepositoryTestSuite struct {
 	testCustomer model.Customer
 	testTenors   []model.Tenor
 
-	meter  metric.Meter
-	tracer trace.Tracer
-	log    *zap.Logger
+	meter metric.Meter
+	log   *zap.Logger
 }
 
 func (suite *LimitRepositoryTestSuite) SetupSuite() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-	sqlDB, err := sql.Open("mysql", dsn)
-	require.NoError(suite.T(), err)
-
-	testDBName := "loan_system_limit_test"
-	_, err = sqlDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-	require.NoError(suite.T(), err)
-	_, err = sqlDB.Exec(fmt.Sprintf("CREATE DATABASE %s", testDBName))
-	require.NoError(suite.T(), err)
-	sqlDB.Close()
-
-	testDSN := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-		testDBName,
-	)
-	gormDB, err := gorm.Open(mysql.Open(testDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	require.NoError(suite.T(), err)
-
-	suite.db = gormDB
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_repo_limit_test").DB
 	suite.ctx = context.Background()
 
 	suite.log = zap.NewNop()
-	noopTracerProvider := noop_trace.NewTracerProvider()
-	suite.tracer = noopTracerProvider.Tracer("test-limit-repository-tracer")
 	noopMeterProvider := noop_metric.NewMeterProvider()
 	suite.meter = noopMeterProvider.Meter("test-limit-repository-meter")
 
-	err = suite.db.AutoMigrate(&model.Customer{}, &model.Tenor{}, &model.CustomerLimit{}, &model.Transaction{})
-	require.NoError(suite.T(), err)
-
-	suite.limitRepository = limitrepo.NewLimitRepository(suite.db, suite.meter, suite.tracer, suite.log)
-}
-
-func (suite *LimitRepositoryTestSuite) TearDownSuite() {
-	testDBName := "loan_system_limit_test"
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-	sqlDB, err := sql.Open("mysql", dsn)
-	if err == nil {
-		sqlDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-		sqlDB.Close()
-	}
+	suite.limitRepository = limitrepo.NewLimitRepository(suite.db, suite.meter, suite.log)
 }
 
 func (suite *LimitRepositoryTestSuite) SetupTest() {
-	// Bersihkan data dari tabel untuk memastikan isolasi tes
-	suite.db.Exec("DELETE FROM customer_limits")
-	suite.db.Exec("DELETE FROM transactions")
-	suite.db.Exec("DELETE FROM customers")
-	suite.db.Exec("DELETE FROM tenors")
+	testutil.Reset(suite.T(), suite.db)
 
 	// Buat data prasyarat (customer dan tenor) yang akan digunakan di banyak tes
-	suite.testCustomer = model.Customer{
-		NIK:                "1112223334445556",
-		FullName:           "Alan Smith",
-		LegalName:          "Alan Smith",
-		Password:           "alansmith123",
-		Role:               "customer",
-		BirthPlace:         "Jakarta",
-		BirthDate:          time.Date(1995, 5, 20, 0, 0, 0, 0, time.UTC),
-		Salary:             10000000,
-		KtpPhotoUrl:        "https://res.cloudinary.com/test/image/upload/v1/ktp_placeholder.jpg",
-		SelfiePhotoUrl:     "https://res.cloudinary.com/test/image/upload/v1/selfie_placeholder.jpg",
-		VerificationStatus: model.VerificationVerified,
-	}
-	// Membuat record customer di database untuk digunakan sebagai foreign key
-	require.NoError(suite.T(), suite.db.Create(&suite.testCustomer).Error)
+	suite.testCustomer = *testutil.NewCustomer().
+		WithNIK("1112223334445556").
+		WithName("Alan Smith").
+		WithSalary(decimal.NewFromInt(10000000)).
+		Create(suite.T(), suite.db)
 
 	// Membuat record tenor di database untuk digunakan sebagai foreign key
-	suite.testTenors = []model.Tenor{
-		{DurationMonths: 3},
-		{DurationMonths: 6},
-		{DurationMonths: 12},
-	}
-	require.NoError(suite.T(), suite.db.Create(&suite.testTenors).Error)
+	suite.testTenors = testutil.SeedTenors(suite.T(), suite.db, 3, 6, 12)
 }
 
 func (suite *LimitRepositoryTestSuite) TestUpsertMany_Success() {
 	limitsToInsert := []domain.CustomerLimit{
-		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: 1000000},
-		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: 2000000},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: decimal.NewFromInt(1000000)},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: decimal.NewFromInt(2000000)},
 	}
 
-	err := suite.limitRepository.UpsertMany(suite.ctx, limitsToInsert)
+	applied, err := suite.limitRepository.UpsertMany(suite.ctx, limitsToInsert, 1)
 
 	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), applied)
 	var count int64
 	suite.db.Model(&model.CustomerLimit{}).Where("customer_id = ?", suite.testCustomer.ID).Count(&count)
 	assert.Equal(suite.T(), int64(2), count)
 
 	limitsToUpdate := []domain.CustomerLimit{
-		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: 2500000},
-		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[2].ID, LimitAmount: 5000000},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: decimal.NewFromInt(2500000), Version: 1},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[2].ID, LimitAmount: decimal.NewFromInt(5000000)},
 	}
 
-	err = suite.limitRepository.UpsertMany(suite.ctx, limitsToUpdate)
+	applied, err = suite.limitRepository.UpsertMany(suite.ctx, limitsToUpdate, 1)
 
 	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), applied)
+	current, err := suite.limitRepository.FindAllByCustomerID(suite.ctx, suite.testCustomer.ID, time.Now())
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), current, 3, "Total limits in force should be 3 after upserting new and updating old")
+
+	// Limit lama tetap tersimpan sebagai periode yang sudah berakhir
 	suite.db.Model(&model.CustomerLimit{}).Where("customer_id = ?", suite.testCustomer.ID).Count(&count)
-	assert.Equal(suite.T(), int64(3), count, "Total limits should be 3 after upserting new and updating old")
+	assert.Equal(suite.T(), int64(4), count)
 
-	var updatedLimit model.CustomerLimit
-	suite.db.Where("customer_id = ? AND tenor_id = ?", suite.testCustomer.ID, suite.testTenors[1].ID).First(&updatedLimit)
-	assert.Equal(suite.T(), 2500000.0, updatedLimit.LimitAmount, "Limit amount should be updated")
+	updatedLimit, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, suite.testTenors[1].ID, time.Now())
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2500000", updatedLimit.LimitAmount.String(), "Limit amount should be updated")
+	assert.Equal(suite.T(), uint64(2), updatedLimit.Version, "Overwrite should bump the version")
+	assert.Nil(suite.T(), updatedLimit.EffectiveTo)
+}
+
+func (suite *LimitRepositoryTestSuite) TestUpsertMany_Scheduled() {
+	tenorID := suite.testTenors[0].ID
+	initial := []domain.CustomerLimit{
+		{CustomerID: suite.testCustomer.ID, TenorID: tenorID, LimitAmount: decimal.NewFromInt(1000000), Currency: "IDR"},
+	}
+	applied, err := suite.limitRepository.UpsertMany(suite.ctx, initial, 1)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), applied)
+
+	from := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	scheduled := []domain.CustomerLimit{
+		{CustomerID: suite.testCustomer.ID, TenorID: tenorID, LimitAmount: decimal.NewFromInt(3000000), Currency: "IDR", Version: 1, EffectiveFrom: from},
+	}
+	applied, err = suite.limitRepository.UpsertMany(suite.ctx, scheduled, 2)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), applied)
+
+	// Perubahan langsung sesudahnya tetap dicek terhadap limit yang berlaku sekarang
+	immediate := []domain.CustomerLimit{
+		{CustomerID: suite.testCustomer.ID, TenorID: tenorID, LimitAmount: decimal.NewFromInt(1500000), Currency: "IDR", Version: 1},
+	}
+	applied, err = suite.limitRepository.UpsertMany(suite.ctx, immediate, 3)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), applied)
+
+	now, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, tenorID, time.Now())
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1500000", now.LimitAmount.String())
+	require.NotNil(suite.T(), now.EffectiveTo)
+	assert.True(suite.T(), now.EffectiveTo.Equal(from), "The immediate change ends where the scheduled one starts")
+
+	later, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, tenorID, from)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "3000000", later.LimitAmount.String())
+	assert.Nil(suite.T(), later.EffectiveTo)
+
+	past, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, tenorID, time.Now().Add(-time.Hour))
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), past, "No limit was in force before the first one was set")
+
+	history, err := suite.limitRepository.FindHistoryByCustomerID(suite.ctx, suite.testCustomer.ID)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), history, 3)
+	assert.True(suite.T(), history[1].EffectiveFrom.Equal(from))
+}
+
+func (suite *LimitRepositoryTestSuite) TestUpsertMany_StaleVersion() {
+	initial := []domain.CustomerLimit{
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: decimal.NewFromInt(1000000), Currency: "IDR"},
+	}
+	applied, err := suite.limitRepository.UpsertMany(suite.ctx, initial, 1)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), applied)
+
+	// Versi 0 berarti admin mengira tenor belum punya limit, padahal sudah di versi 1
+	stale := []domain.CustomerLimit{
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: decimal.NewFromInt(2000000), Currency: "IDR"},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: decimal.NewFromInt(1500000), Currency: "IDR"},
+	}
+	applied, err = suite.limitRepository.UpsertMany(suite.ctx, stale, 2)
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), applied)
+
+	limits, err := suite.limitRepository.FindAllByCustomerID(suite.ctx, suite.testCustomer.ID, time.Now())
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), limits, 1, "A stale batch must not write any of its limits")
+	assert.Equal(suite.T(), "1000000", limits[0].LimitAmount.String())
+	assert.Equal(suite.T(), uint64(1), limits[0].Version)
+
+	history, err := suite.limitRepository.FindHistoryByCustomerID(suite.ctx, suite.testCustomer.ID)
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), history, 1)
 }
 
 func (suite *LimitRepositoryTestSuite) TestUpsertMany_EmptySlice() {
 	emptyLimits := []domain.CustomerLimit{}
 
-	err := suite.limitRepository.UpsertMany(suite.ctx, emptyLimits)
+	applied, err := suite.limitRepository.UpsertMany(suite.ctx, emptyLimits, 1)
 
 	assert.NoError(suite.T(), err, "Upserting an empty slice should not return an error")
+	assert.True(suite.T(), applied)
+}
+
+func (suite *LimitRepositoryTestSuite) TestUpsertMany_RecordsHistory() {
+	initial := []domain.CustomerLimit{
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: decimal.NewFromInt(1000000), Currency: "IDR"},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: decimal.NewFromInt(2000000), Currency: "IDR"},
+	}
+	_, err := suite.limitRepository.UpsertMany(suite.ctx, initial, 1)
+	require.NoError(suite.T(), err)
+
+	// Tenor pertama tidak berubah, sehingga hanya tenor kedua yang tercatat
+	changed := []domain.CustomerLimit{
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: decimal.NewFromInt(1000000), Currency: "IDR", Version: 1},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: decimal.NewFromInt(2500000), Currency: "IDR", Version: 1},
+	}
+	applied, err := suite.limitRepository.UpsertMany(suite.ctx, changed, 2)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), applied)
+
+	history, err := suite.limitRepository.FindHistoryByCustomerID(suite.ctx, suite.testCustomer.ID)
+
+	assert.NoError(suite.T(), err)
+	require.Len(suite.T(), history, 3)
+
+	latest := history[0]
+	assert.Equal(suite.T(), uint8(6), latest.TenorMonths)
+	assert.Equal(suite.T(), uint64(2), latest.ChangedBy)
+	require.NotNil(suite.T(), latest.PreviousAmount)
+	assert.Equal(suite.T(), "2000000", latest.PreviousAmount.String())
+	assert.Equal(suite.T(), "2500000", latest.NewAmount.String())
+
+	for _, first := range history[1:] {
+		assert.Nil(suite.T(), first.PreviousAmount, "First limit of a tenor has no previous amount")
+		assert.Equal(suite.T(), uint64(1), first.ChangedBy)
+	}
 }
 
 func (suite *LimitRepositoryTestSuite) TestFindAllByCustomerID_Success() {
 	limits := []model.CustomerLimit{
-		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: 100},
-		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: 200},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: decimal.NewFromInt(100)},
+		{CustomerID: suite.testCustomer.ID, TenorID: suite.testTenors[1].ID, LimitAmount: decimal.NewFromInt(200)},
 	}
 	require.NoError(suite.T(), suite.db.Create(&limits).Error)
 
@@ -184,28 +231,28 @@ func (suite *LimitRepositoryTestSuite) TestFindAllByCustomerID_Success() {
 		Role:       "customer",
 		BirthPlace: "Jakarta",
 		BirthDate:  time.Date(1995, 5, 20, 0, 0, 0, 0, time.UTC),
-		Salary:     10000000,
+		Salary:     decimal.NewFromInt(10000000),
 	}
 	require.NoError(suite.T(), suite.db.Create(&otherCustomer).Error)
-	otherLimit := model.CustomerLimit{CustomerID: otherCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: 999}
+	otherLimit := model.CustomerLimit{CustomerID: otherCustomer.ID, TenorID: suite.testTenors[0].ID, LimitAmount: decimal.NewFromInt(999)}
 	require.NoError(suite.T(), suite.db.Create(&otherLimit).Error)
 
-	result, err := suite.limitRepository.FindAllByCustomerID(suite.ctx, suite.testCustomer.ID)
+	result, err := suite.limitRepository.FindAllByCustomerID(suite.ctx, suite.testCustomer.ID, time.Now())
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Len(suite.T(), result, 2, "Should only return limits for the specified customer")
-	assert.Equal(suite.T(), float64(100), result[0].LimitAmount)
-	assert.Equal(suite.T(), float64(200), result[1].LimitAmount)
+	assert.Equal(suite.T(), "100", result[0].LimitAmount.String())
+	assert.Equal(suite.T(), "200", result[1].LimitAmount.String())
 }
 
 func (suite *LimitRepositoryTestSuite) TestFindAllByCustomerID_NotFound() {
-	result, err := suite.limitRepository.FindAllByCustomerID(suite.ctx, suite.testCustomer.ID)
+	result, err := suite.limitRepository.FindAllByCustomerID(suite.ctx, suite.testCustomer.ID, time.Now())
 
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), result, 0, "Result should be an empty slice for a customer with no limits")
 
-	result, err = suite.limitRepository.FindAllByCustomerID(suite.ctx, 9999)
+	result, err = suite.limitRepository.FindAllByCustomerID(suite.ctx, 9999, time.Now())
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), result, 0, "Result should be an empty slice for a non-existent customer")
 }
@@ -214,21 +261,21 @@ func (suite *LimitRepositoryTestSuite) TestFindByCustomerIDAndTenorID_Success()
 	limitModel := model.CustomerLimit{
 		CustomerID:  suite.testCustomer.ID,
 		TenorID:     suite.testTenors[0].ID,
-		LimitAmount: 123456.78,
+		LimitAmount: decimal.RequireFromString("123456.78"),
 	}
 	require.NoError(suite.T(), suite.db.Create(&limitModel).Error)
 
-	result, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, suite.testTenors[0].ID)
+	result, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, suite.testTenors[0].ID, time.Now())
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Equal(suite.T(), suite.testCustomer.ID, result.CustomerID)
 	assert.Equal(suite.T(), suite.testTenors[0].ID, result.TenorID)
-	assert.Equal(suite.T(), 123456.78, result.LimitAmount)
+	assert.Equal(suite.T(), "123456.78", result.LimitAmount.String())
 }
 
 func (suite *LimitRepositoryTestSuite) TestFindByCustomerIDAndTenorID_NotFound() {
-	result, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, suite.testTenors[0].ID)
+	result, err := suite.limitRepository.FindByCustomerIDAndTenorID(suite.ctx, suite.testCustomer.ID, suite.testTenors[0].ID, time.Now())
 
 	assert.NoError(suite.T(), err)
 	assert.Nil(suite.T(), result, "Result should be nil when the specific limit is not found")
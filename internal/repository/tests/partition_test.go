@@ -0,0 +1,212 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
+	virtualaccountrepo "github.com/fazamuttaqien/multifinance/internal/repository/virtualaccount"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TransactionPartitionTestSuite struct {
+	suite.Suite
+	db                    *gorm.DB
+	ctx                   context.Context
+	now                   time.Time
+	transactionRepository repository.TransactionRepository
+	virtualAccountRepo    repository.VirtualAccountRepository
+
+	customerID uint64
+	tenorID    uint
+}
+
+func (suite *TransactionPartitionTestSuite) SetupSuite() {
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_repo_partition_test").DB
+	suite.ctx = context.Background()
+	suite.now = time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	meter, _, log := testutil.Telemetry("test-transaction-partition")
+	suite.transactionRepository = transactionrepo.NewTransactionRepository(suite.db, meter, log)
+	suite.virtualAccountRepo = virtualaccountrepo.NewVirtualAccountRepository(suite.db, meter, log)
+
+	// Transaksi lama menentukan partisi pertama
+	customer := testutil.NewCustomer().Create(suite.T(), suite.db)
+	tenor := testutil.SeedTenors(suite.T(), suite.db, 12)[0]
+	suite.customerID, suite.tenorID = customer.ID, tenor.ID
+	old := model.Transaction{
+		ContractNumber:         "KTR-JKT-20260805-000001",
+		CustomerID:             customer.ID,
+		TenorID:                tenor.ID,
+		AssetName:              "Honda Beat",
+		OTRAmount:              decimal.NewFromInt(20000000),
+		AdminFee:               decimal.NewFromInt(500000),
+		TotalInterest:          decimal.NewFromInt(4800000),
+		TotalInstallmentAmount: decimal.NewFromInt(25300000),
+		Status:                 model.TransactionActive,
+		TransactionDate:        time.Date(2026, 8, 5, 3, 0, 0, 0, time.UTC),
+	}
+	require.NoError(suite.T(), suite.db.Create(&old).Error)
+
+	require.NoError(suite.T(), model.AutoMigratePartitioned(suite.db))
+	_, err := model.PartitionTransactions(suite.ctx, suite.db, suite.now, 2)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *TransactionPartitionTestSuite) newTransaction(contractNumber string, date time.Time) domain.Transaction {
+	return domain.Transaction{
+		ContractNumber:         contractNumber,
+		CustomerID:             suite.customerID,
+		TenorID:                suite.tenorID,
+		AssetName:              "Yamaha NMAX",
+		OTRAmount:              decimal.NewFromInt(30000000),
+		AdminFee:               decimal.NewFromInt(500000),
+		TotalInterest:          decimal.NewFromInt(7200000),
+		TotalInstallmentAmount: decimal.NewFromInt(37700000),
+		Status:                 domain.TransactionActive,
+		TransactionDate:        date,
+	}
+}
+
+// foreignKeys counts the foreign keys declared on table.
+func (suite *TransactionPartitionTestSuite) foreignKeys(table string) int64 {
+	var count int64
+	require.NoError(suite.T(), suite.db.Raw(
+		"SELECT COUNT(*) FROM information_schema.REFERENTIAL_CONSTRAINTS WHERE CONSTRAINT_SCHEMA = DATABASE() AND TABLE_NAME = ?",
+		table).Scan(&count).Error)
+	return count
+}
+
+func (suite *TransactionPartitionTestSuite) TestPartitionsCoverOldestThroughAhead() {
+	// Act
+	partitions, err := mysqldb.Partitions(suite.ctx, suite.db, "transactions")
+
+	// Assert
+	require.NoError(suite.T(), err)
+	require.GreaterOrEqual(suite.T(), len(partitions), 6)
+	assert.Equal(suite.T(), []string{"p202608", "p202609", "p202610", "p202611", "p202612"}, partitions[:5])
+	assert.Equal(suite.T(), "pmax", partitions[len(partitions)-1])
+}
+
+func (suite *TransactionPartitionTestSuite) TestPartitionTransactionsIsIdempotent() {
+	// Act
+	added, err := model.PartitionTransactions(suite.ctx, suite.db, suite.now, 2)
+
+	// Assert
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), added)
+}
+
+func (suite *TransactionPartitionTestSuite) TestExtendAddsFuturePartitions() {
+	// Act
+	added, err := model.ExtendTransactionPartitions(suite.ctx, suite.db, suite.now.AddDate(0, 2, 0), 2)
+	partitions, listErr := mysqldb.Partitions(suite.ctx, suite.db, "transactions")
+
+	// Assert
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), listErr)
+	assert.Equal(suite.T(), []string{"p202701", "p202702"}, added)
+	assert.Equal(suite.T(), "pmax", partitions[len(partitions)-1])
+}
+
+func (suite *TransactionPartitionTestSuite) TestFindByContractNumberOnPartitionedTable() {
+	// Act
+	found, err := suite.transactionRepository.FindByContractNumber(suite.ctx, "KTR-JKT-20260805-000001")
+
+	// Assert
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), found)
+	assert.Equal(suite.T(), "Honda Beat", found.AssetName)
+}
+
+func (suite *TransactionPartitionTestSuite) TestAutoMigrateKeepsPartitionedSchema() {
+	// Act
+	err := model.AutoMigratePartitioned(suite.db)
+	partitions, listErr := mysqldb.Partitions(suite.ctx, suite.db, "transactions")
+
+	// Assert
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), listErr)
+	assert.NotEmpty(suite.T(), partitions)
+}
+
+func (suite *TransactionPartitionTestSuite) TestForeignKeysKeptOutsideTransactions() {
+	// Act
+	require.NoError(suite.T(), model.AutoMigratePartitioned(suite.db))
+
+	// Assert
+	assert.Zero(suite.T(), suite.foreignKeys("transactions"))
+	assert.Positive(suite.T(), suite.foreignKeys("customer_limits"))
+	assert.Positive(suite.T(), suite.foreignKeys("customer_limit_history"))
+}
+
+func (suite *TransactionPartitionTestSuite) TestDuplicateContractRejected() {
+	suite.Run("Existing Contract In Another Partition", func() {
+		// Arrange: nomor kontrak yang sama di bulan lain jatuh ke partisi lain
+		transaction := suite.newTransaction("KTR-JKT-20260805-000001", suite.now)
+
+		// Act
+		err := suite.transactionRepository.CreateTransaction(suite.ctx, &transaction)
+
+		// Assert
+		assert.ErrorIs(suite.T(), err, common.ErrDuplicateContract)
+	})
+
+	suite.Run("Batch", func() {
+		// Arrange
+		transactions := []domain.Transaction{
+			suite.newTransaction("KTR-JKT-20260917-000001", time.Date(2026, 9, 17, 3, 0, 0, 0, time.UTC)),
+			suite.newTransaction("KTR-JKT-20260917-000001", suite.now),
+		}
+
+		// Act
+		err := suite.transactionRepository.CreateMany(suite.ctx, transactions, 10)
+
+		// Assert
+		assert.ErrorIs(suite.T(), err, common.ErrDuplicateContract)
+		found, findErr := suite.transactionRepository.FindByContractNumber(suite.ctx, "KTR-JKT-20260917-000001")
+		// Baris pertama ikut dibatalkan bersama batch-nya
+		assert.NoError(suite.T(), findErr)
+		assert.Nil(suite.T(), found)
+	})
+}
+
+func (suite *TransactionPartitionTestSuite) TestDuplicateVirtualAccountRejected() {
+	// Arrange
+	first := suite.newTransaction("KTR-JKT-20260901-000001", time.Date(2026, 9, 1, 3, 0, 0, 0, time.UTC))
+	second := suite.newTransaction("KTR-JKT-20261017-000001", suite.now)
+	require.NoError(suite.T(), suite.transactionRepository.CreateTransaction(suite.ctx, &first))
+	require.NoError(suite.T(), suite.transactionRepository.CreateTransaction(suite.ctx, &second))
+
+	assigned, err := suite.virtualAccountRepo.Assign(suite.ctx, first.ID, "BCA", "8808001234567890")
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), assigned)
+
+	// Act
+	assigned, err = suite.virtualAccountRepo.Assign(suite.ctx, second.ID, "BCA", "8808001234567890")
+
+	// Assert
+	_, duplicate := mysqldb.DuplicateKey(err)
+	assert.True(suite.T(), duplicate, "got %v", err)
+	assert.False(suite.T(), assigned)
+
+	var stored model.Transaction
+	require.NoError(suite.T(), suite.db.First(&stored, "id = ?", second.ID).Error)
+	assert.Nil(suite.T(), stored.VirtualAccountNumber)
+}
+
+func TestTransactionPartitionTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionPartitionTestSuite))
+}
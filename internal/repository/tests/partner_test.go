@@ -0,0 +1,106 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	partnerrepo "github.com/fazamuttaqien/multifinance/internal/repository/partner"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/apikey"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PartnerRepositoryTestSuite struct {
+	suite.Suite
+	db                *gorm.DB
+	ctx               context.Context
+	partnerRepository repository.PartnerRepository
+}
+
+func (suite *PartnerRepositoryTestSuite) SetupSuite() {
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_repo_partner_test").DB
+	suite.ctx = context.Background()
+
+	meter, _, log := testutil.Telemetry("test-partner-repository")
+	suite.partnerRepository = partnerrepo.NewPartnerRepository(suite.db, meter, log)
+}
+
+func (suite *PartnerRepositoryTestSuite) SetupTest() {
+	testutil.Reset(suite.T(), suite.db)
+}
+
+func (suite *PartnerRepositoryTestSuite) createPartner(email string) *domain.Partner {
+	_, hash, display, err := apikey.Generate(apikey.SandboxPrefix)
+	require.NoError(suite.T(), err)
+
+	partner := &domain.Partner{
+		Name:             "Dealer Motor Jaya",
+		Email:            email,
+		Status:           domain.PartnerSandbox,
+		SandboxKeyHash:   hash,
+		SandboxKeyPrefix: display,
+	}
+	require.NoError(suite.T(), suite.partnerRepository.Create(suite.ctx, partner))
+	return partner
+}
+
+func (suite *PartnerRepositoryTestSuite) TestCreateAndFind() {
+	partner := suite.createPartner("dealer@example.com")
+	assert.NotZero(suite.T(), partner.ID)
+
+	byEmail, err := suite.partnerRepository.FindByEmail(suite.ctx, "dealer@example.com")
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), byEmail)
+	assert.Equal(suite.T(), partner.ID, byEmail.ID)
+
+	byKey, err := suite.partnerRepository.FindByAPIKeyHash(suite.ctx, partner.SandboxKeyHash)
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), byKey)
+	assert.Equal(suite.T(), domain.PartnerSandbox, byKey.Status)
+	assert.Empty(suite.T(), byKey.LiveKeyHash)
+}
+
+func (suite *PartnerRepositoryTestSuite) TestFind_NotFound() {
+	partner, err := suite.partnerRepository.FindByID(suite.ctx, 999)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), partner)
+
+	partner, err = suite.partnerRepository.FindByAPIKeyHash(suite.ctx, apikey.Hash("mf_test_unknown"))
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), partner)
+}
+
+func (suite *PartnerRepositoryTestSuite) TestUpdate_PromoteToProduction() {
+	partner := suite.createPartner("promote@example.com")
+
+	_, liveHash, liveDisplay, err := apikey.Generate(apikey.LivePrefix)
+	require.NoError(suite.T(), err)
+
+	now := time.Now()
+	partner.Status = domain.PartnerProduction
+	partner.LiveKeyHash = liveHash
+	partner.LiveKeyPrefix = liveDisplay
+	partner.PromotedAt = &now
+	require.NoError(suite.T(), suite.partnerRepository.Update(suite.ctx, partner))
+
+	byLiveKey, err := suite.partnerRepository.FindByAPIKeyHash(suite.ctx, liveHash)
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), byLiveKey)
+	assert.Equal(suite.T(), domain.PartnerProduction, byLiveKey.Status)
+	assert.NotNil(suite.T(), byLiveKey.PromotedAt)
+
+	bySandboxKey, err := suite.partnerRepository.FindByAPIKeyHash(suite.ctx, partner.SandboxKeyHash)
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), bySandboxKey, "sandbox key should stay usable after promotion")
+}
+
+func TestPartnerRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PartnerRepositoryTestSuite))
+}
@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
+	"github.com/fazamuttaqien/multifinance/pkg/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCachedTenorRepository(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*mocks.MockTenorRepository, repository.TenorRepository) {
+		next := mocks.NewMockTenorRepository(gomock.NewController(t))
+		// Tanpa Redis hanya cache lokal yang dipakai
+		return next, tenorrepo.NewCachedTenorRepository(
+			next,
+			cache.New[*domain.Tenor](nil, cache.Options{Name: "tenors", LocalTTL: time.Minute}),
+			cache.New[[]domain.Tenor](nil, cache.Options{Name: "tenor_catalog", LocalTTL: time.Minute}),
+			zap.NewNop(),
+		)
+	}
+
+	t.Run("Lookups Are Served From Cache", func(t *testing.T) {
+		next, cached := setup(t)
+
+		next.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&domain.Tenor{ID: 1, DurationMonths: 6}, nil).Times(1)
+		next.EXPECT().FindByDuration(gomock.Any(), uint8(12)).Return(nil, nil).Times(1)
+		next.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{{ID: 1, DurationMonths: 6}}, nil).Times(1)
+
+		for range 3 {
+			tenor, err := cached.FindByID(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, uint8(6), tenor.DurationMonths)
+
+			missing, err := cached.FindByDuration(ctx, 12)
+			require.NoError(t, err)
+			assert.Nil(t, missing)
+
+			tenors, err := cached.FindAll(ctx)
+			require.NoError(t, err)
+			assert.Len(t, tenors, 1)
+		}
+	})
+
+	t.Run("Callers Get Their Own Copy", func(t *testing.T) {
+		next, cached := setup(t)
+
+		next.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&domain.Tenor{ID: 1, Description: "6 bulan", AssetCategories: []string{"motor"}}, nil).Times(1)
+
+		tenor, err := cached.FindByID(ctx, 1)
+		require.NoError(t, err)
+		tenor.Description = "changed"
+		tenor.AssetCategories[0] = "mobil"
+
+		again, err := cached.FindByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "6 bulan", again.Description)
+		assert.Equal(t, []string{"motor"}, again.AssetCategories)
+	})
+
+	t.Run("Writes Invalidate", func(t *testing.T) {
+		next, cached := setup(t)
+
+		gomock.InOrder(
+			next.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&domain.Tenor{ID: 1, Active: true}, nil),
+			next.EXPECT().Update(gomock.Any(), domain.Tenor{ID: 1, Active: false}).Return(true, nil),
+			next.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&domain.Tenor{ID: 1, Active: false}, nil),
+		)
+
+		tenor, err := cached.FindByID(ctx, 1)
+		require.NoError(t, err)
+		assert.True(t, tenor.Active)
+
+		updated, err := cached.Update(ctx, domain.Tenor{ID: 1, Active: false})
+		require.NoError(t, err)
+		assert.True(t, updated)
+
+		tenor, err = cached.FindByID(ctx, 1)
+		require.NoError(t, err)
+		assert.False(t, tenor.Active)
+	})
+
+	t.Run("Errors Are Not Cached", func(t *testing.T) {
+		next, cached := setup(t)
+
+		gomock.InOrder(
+			next.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("connection refused")),
+			next.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{{ID: 1}}, nil),
+		)
+
+		_, err := cached.FindAll(ctx)
+		assert.Error(t, err)
+
+		tenors, err := cached.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tenors, 1)
+	})
+}
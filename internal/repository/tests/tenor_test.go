@@ -2,27 +2,22 @@ package repository_test
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
 	"testing"
 
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
 	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
-	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
-	"go.opentelemetry.io/otel/trace"
-	noop_trace "go.opentelemetry.io/otel/trace/noop"
 
 	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type TenorRepositoryTestSuite struct {
@@ -33,76 +28,23 @@ type TenorRepositoryTestSuite struct {
 
 	testTenors []model.Tenor
 
-	meter  metric.Meter
-	tracer trace.Tracer
-	log    *zap.Logger
+	meter metric.Meter
+	log   *zap.Logger
 }
 
 func (suite *TenorRepositoryTestSuite) SetupSuite() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-	sqlDB, err := sql.Open("mysql", dsn)
-	require.NoError(suite.T(), err)
-
-	testDBName := "loan_system_tenor_test"
-	_, err = sqlDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-	require.NoError(suite.T(), err)
-	_, err = sqlDB.Exec(fmt.Sprintf("CREATE DATABASE %s", testDBName))
-	require.NoError(suite.T(), err)
-	sqlDB.Close()
-
-	testDSN := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-		testDBName,
-	)
-	gormDB, err := gorm.Open(mysql.Open(testDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	require.NoError(suite.T(), err)
-
-	suite.db = gormDB
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_repo_tenor_test").DB
 	suite.ctx = context.Background()
 
 	suite.log = zap.NewNop()
-	noopTracerProvider := noop_trace.NewTracerProvider()
-	suite.tracer = noopTracerProvider.Tracer("test-tenor-repository-tracer")
 	noopMeterProvider := noop_metric.NewMeterProvider()
 	suite.meter = noopMeterProvider.Meter("test-tenor-repository-meter")
 
-	err = suite.db.AutoMigrate(&model.Customer{}, &model.Tenor{}, &model.CustomerLimit{}, &model.Transaction{})
-	require.NoError(suite.T(), err)
-
-	suite.tenorRepository = tenorrepo.NewTenorRepository(suite.db, suite.meter, suite.tracer, suite.log)
-}
-
-func (suite *TenorRepositoryTestSuite) TearDownSuite() {
-	testDBName := "loan_system_tenor_test"
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-	sqlDB, err := sql.Open("mysql", dsn)
-	if err == nil {
-		sqlDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-		sqlDB.Close()
-	}
+	suite.tenorRepository = tenorrepo.NewTenorRepository(suite.db, suite.meter, suite.log)
 }
 
 func (suite *TenorRepositoryTestSuite) SetupTest() {
-	// Bersihkan data dari tabel
-	suite.db.Exec("DELETE FROM customer_limits")
-	suite.db.Exec("DELETE FROM transactions")
-	suite.db.Exec("DELETE FROM customers")
-	suite.db.Exec("DELETE FROM tenors")
+	testutil.Reset(suite.T(), suite.db)
 
 	suite.testTenors = []model.Tenor{
 		{DurationMonths: 3, Description: "3 Bulan"},
@@ -123,8 +65,34 @@ func (suite *TenorRepositoryTestSuite) TestFindAll_Success() {
 	assert.Equal(suite.T(), "6 Bulan", result[1].Description)
 }
 
+func (suite *TenorRepositoryTestSuite) TestFindByDuration_SkipsInactive() {
+	require.NoError(suite.T(), suite.db.Model(&model.Tenor{}).Where("id = ?", suite.testTenors[1].ID).Update("active", false).Error)
+
+	result, err := suite.tenorRepository.FindByDuration(suite.ctx, 6)
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result, "Inactive tenors should not be found by duration")
+}
+
+func (suite *TenorRepositoryTestSuite) TestIsReferenced() {
+	customer := testutil.NewCustomer().Create(suite.T(), suite.db)
+	testutil.SeedLimit(suite.T(), suite.db, customer.ID, suite.testTenors[0].ID, decimal.NewFromInt(1000000))
+
+	referenced, err := suite.tenorRepository.IsReferenced(suite.ctx, suite.testTenors[0].ID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), referenced)
+
+	referenced, err = suite.tenorRepository.IsReferenced(suite.ctx, suite.testTenors[2].ID)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), referenced)
+
+	deleted, err := suite.tenorRepository.Delete(suite.ctx, suite.testTenors[2].ID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), deleted)
+}
+
 func (suite *TenorRepositoryTestSuite) TestFindAll_EmptyResult() {
-	suite.db.Exec("DELETE FROM tenors")
+	testutil.Reset(suite.T(), suite.db)
 
 	result, err := suite.tenorRepository.FindAll(suite.ctx)
 
@@ -0,0 +1,115 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+const testQueryTimeout = 300 * time.Millisecond
+
+type QueryTimeoutTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	ctx             context.Context
+	tenorRepository repository.TenorRepository
+}
+
+func (suite *QueryTimeoutTestSuite) SetupSuite() {
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_repo_timeout_test").DB
+	suite.ctx = context.Background()
+
+	meter, tracer, log := testutil.Telemetry("test-query-timeout")
+	require.NoError(suite.T(), mysqldb.EnableQueryTimeout(suite.db, testQueryTimeout))
+	require.NoError(suite.T(), mysqldb.EnableQueryTracing(suite.db, tracer, meter, log, 0))
+
+	suite.tenorRepository = tenorrepo.NewTenorRepository(suite.db, meter, log)
+}
+
+func (suite *QueryTimeoutTestSuite) SetupTest() {
+	testutil.Reset(suite.T(), suite.db)
+	testutil.SeedTenors(suite.T(), suite.db, 3, 6)
+}
+
+func (suite *QueryTimeoutTestSuite) TestSlowQueryIsAborted() {
+	suite.Run("Scan", func() {
+		var slept int
+		start := time.Now()
+		err := suite.db.WithContext(suite.ctx).Raw("SELECT SLEEP(5)").Scan(&slept).Error
+
+		assert.Error(suite.T(), err)
+		assert.Less(suite.T(), time.Since(start), 2*time.Second, "Query should stop at the timeout, not when MySQL finishes")
+	})
+
+	suite.Run("Exec", func() {
+		start := time.Now()
+		err := suite.db.WithContext(suite.ctx).Exec("DO SLEEP(5)").Error
+
+		assert.Error(suite.T(), err)
+		assert.Less(suite.T(), time.Since(start), 2*time.Second)
+	})
+}
+
+func (suite *QueryTimeoutTestSuite) TestCancelledContextAbortsRepositoryCall() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+
+	result, err := suite.tenorRepository.FindAll(ctx)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Nil(suite.T(), result)
+}
+
+func (suite *QueryTimeoutTestSuite) TestShorterCallerDeadlineWins() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := suite.db.WithContext(ctx).Exec("DO SLEEP(5)").Error
+
+	assert.Error(suite.T(), err)
+	assert.Less(suite.T(), time.Since(start), testQueryTimeout)
+}
+
+func (suite *QueryTimeoutTestSuite) TestFastQueriesAreUnaffected() {
+	// Builder yang sama dipakai dua kali, context statement harus sudah dipulihkan
+	query := suite.db.WithContext(suite.ctx).Model(&model.Tenor{}).Where("active = ?", true)
+
+	var count int64
+	require.NoError(suite.T(), query.Count(&count).Error)
+
+	var tenors []model.Tenor
+	require.NoError(suite.T(), query.Find(&tenors).Error)
+	assert.Equal(suite.T(), int64(2), count)
+	assert.Len(suite.T(), tenors, 2)
+
+	rows, err := suite.db.WithContext(suite.ctx).Model(&model.Tenor{}).Select("duration_months").Rows()
+	require.NoError(suite.T(), err)
+	defer rows.Close()
+
+	read := 0
+	for rows.Next() {
+		read++
+	}
+	assert.NoError(suite.T(), rows.Err())
+	assert.Equal(suite.T(), 2, read)
+
+	found, err := suite.tenorRepository.FindByDuration(suite.ctx, 6)
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), found)
+}
+
+func TestQueryTimeoutTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTimeoutTestSuite))
+}
@@ -2,7 +2,6 @@ package repository_test
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"testing"
 	"time"
@@ -11,22 +10,20 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
 	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
-	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
-	"go.opentelemetry.io/otel/trace"
-	noop_trace "go.opentelemetry.io/otel/trace/noop"
 
 	"go.uber.org/zap"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type TransactionRepositoryTestSuite struct {
@@ -35,7 +32,6 @@ type TransactionRepositoryTestSuite struct {
 	ctx context.Context
 
 	meter                 metric.Meter
-	tracer                trace.Tracer
 	log                   *zap.Logger
 	transactionRepository repository.TransactionRepository
 
@@ -44,80 +40,18 @@ type TransactionRepositoryTestSuite struct {
 }
 
 func (suite *TransactionRepositoryTestSuite) SetupSuite() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-
-	db, err := sql.Open("mysql", dsn)
-	require.NoError(suite.T(), err)
-
-	testDBName := "loan_system_test"
-
-	_, err = db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-	require.NoError(suite.T(), err)
-
-	_, err = db.Exec(fmt.Sprintf("CREATE DATABASE %s", testDBName))
-	require.NoError(suite.T(), err)
-
-	db.Close()
-
-	testDSN := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-		testDBName,
-	)
-
-	gormDB, err := gorm.Open(mysql.Open(testDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	require.NoError(suite.T(), err)
-
-	suite.db = gormDB
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_repo_transaction_test").DB
 	suite.ctx = context.Background()
 
 	suite.log = zap.NewNop()
-	noopTracerProvider := noop_trace.NewTracerProvider()
-	suite.tracer = noopTracerProvider.Tracer("test-customer-repository-tracer")
 	noopMeterProvider := noop_metric.NewMeterProvider()
 	suite.meter = noopMeterProvider.Meter("test-customer-repository-meter")
 
-	err = suite.db.AutoMigrate(
-		&model.Customer{},
-		&model.Tenor{},
-		&model.CustomerLimit{},
-		&model.Transaction{},
-	)
-	require.NoError(suite.T(), err)
-
-	suite.transactionRepository = transactionrepo.NewTransactionRepository(suite.db, suite.meter, suite.tracer, suite.log)
-}
-
-func (suite *TransactionRepositoryTestSuite) TearDownSuite() {
-	testDBName := "loan_system_test"
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-
-	db, err := sql.Open("mysql", dsn)
-	if err == nil {
-		db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDBName))
-		db.Close()
-	}
+	suite.transactionRepository = transactionrepo.NewTransactionRepository(suite.db, suite.meter, suite.log)
 }
 
 func (suite *TransactionRepositoryTestSuite) SetupTest() {
-	suite.db.Exec("DELETE FROM transactions")
-	suite.db.Exec("DELETE FROM customer_limits")
-	suite.db.Exec("DELETE FROM customers")
-	suite.db.Exec("DELETE FROM tenors")
+	testutil.Reset(suite.T(), suite.db)
 
 	suite.setupTestData()
 }
@@ -132,7 +66,7 @@ func (suite *TransactionRepositoryTestSuite) setupTestData() {
 		Role:               "customer",
 		BirthPlace:         "Jakarta",
 		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-		Salary:             5000000,
+		Salary:             decimal.NewFromInt(5000000),
 		KtpPhotoUrl:        "https://example.com/ktp.jpg",
 		SelfiePhotoUrl:     "https://example.com/selfie.jpg",
 		VerificationStatus: model.VerificationVerified,
@@ -159,10 +93,10 @@ func (suite *TransactionRepositoryTestSuite) TestCreateTransaction_Success() {
 		CustomerID:             suite.customerID,
 		TenorID:                suite.tenorID,
 		AssetName:              "Honda Beat",
-		OTRAmount:              15000000,
-		AdminFee:               500000,
-		TotalInterest:          2000000,
-		TotalInstallmentAmount: 17500000,
+		OTRAmount:              decimal.NewFromInt(15000000),
+		AdminFee:               decimal.NewFromInt(500000),
+		TotalInterest:          decimal.NewFromInt(2000000),
+		TotalInstallmentAmount: decimal.NewFromInt(17500000),
 		Status:                 domain.TransactionPending,
 		TransactionDate:        time.Now(),
 	}
@@ -187,10 +121,10 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Succe
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Beat",
-			OTRAmount:              15000000,
-			AdminFee:               500000,
-			TotalInterest:          2000000,
-			TotalInstallmentAmount: 17500000,
+			OTRAmount:              decimal.NewFromInt(15000000),
+			AdminFee:               decimal.NewFromInt(500000),
+			TotalInterest:          decimal.NewFromInt(2000000),
+			TotalInstallmentAmount: decimal.NewFromInt(17500000),
 			Status:                 model.TransactionPending,
 			TransactionDate:        time.Now().Add(-2 * time.Hour),
 		},
@@ -199,10 +133,10 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Succe
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Vario",
-			OTRAmount:              18000000,
-			AdminFee:               600000,
-			TotalInterest:          2500000,
-			TotalInstallmentAmount: 21100000,
+			OTRAmount:              decimal.NewFromInt(18000000),
+			AdminFee:               decimal.NewFromInt(600000),
+			TotalInterest:          decimal.NewFromInt(2500000),
+			TotalInstallmentAmount: decimal.NewFromInt(21100000),
 			Status:                 model.TransactionApproved,
 			TransactionDate:        time.Now().Add(-1 * time.Hour),
 		},
@@ -211,10 +145,10 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Succe
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Scoopy",
-			OTRAmount:              16000000,
-			AdminFee:               550000,
-			TotalInterest:          2200000,
-			TotalInstallmentAmount: 18750000,
+			OTRAmount:              decimal.NewFromInt(16000000),
+			AdminFee:               decimal.NewFromInt(550000),
+			TotalInterest:          decimal.NewFromInt(2200000),
+			TotalInstallmentAmount: decimal.NewFromInt(18750000),
 			Status:                 model.TransactionActive,
 			TransactionDate:        time.Now(),
 		},
@@ -247,10 +181,10 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Succe
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Beat",
-			OTRAmount:              15000000,
-			AdminFee:               500000,
-			TotalInterest:          2000000,
-			TotalInstallmentAmount: 17500000,
+			OTRAmount:              decimal.NewFromInt(15000000),
+			AdminFee:               decimal.NewFromInt(500000),
+			TotalInterest:          decimal.NewFromInt(2000000),
+			TotalInstallmentAmount: decimal.NewFromInt(17500000),
 			Status:                 model.TransactionActive,
 			TransactionDate:        time.Now().Add(-1 * time.Hour),
 		},
@@ -259,10 +193,10 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Succe
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Vario",
-			OTRAmount:              18000000,
-			AdminFee:               600000,
-			TotalInterest:          2500000,
-			TotalInstallmentAmount: 21100000,
+			OTRAmount:              decimal.NewFromInt(18000000),
+			AdminFee:               decimal.NewFromInt(600000),
+			TotalInterest:          decimal.NewFromInt(2500000),
+			TotalInstallmentAmount: decimal.NewFromInt(21100000),
 			Status:                 model.TransactionActive,
 			TransactionDate:        time.Now(),
 		},
@@ -271,10 +205,10 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Succe
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Scoopy",
-			OTRAmount:              16000000,
-			AdminFee:               550000,
-			TotalInterest:          2200000,
-			TotalInstallmentAmount: 18750000,
+			OTRAmount:              decimal.NewFromInt(16000000),
+			AdminFee:               decimal.NewFromInt(550000),
+			TotalInterest:          decimal.NewFromInt(2200000),
+			TotalInstallmentAmount: decimal.NewFromInt(18750000),
 			Status:                 model.TransactionPending,
 			TransactionDate:        time.Now(),
 		},
@@ -286,9 +220,9 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Succe
 	}
 
 	params := domain.Params{
-		Status: string(model.TransactionActive),
-		Page:   1,
-		Limit:  10,
+		Filters: []query.Condition{{Column: "status", Value: string(model.TransactionActive)}},
+		Page:    1,
+		Limit:   10,
 	}
 
 	result, total, err := suite.transactionRepository.FindPaginatedByCustomerID(suite.ctx, suite.customerID, params)
@@ -326,10 +260,10 @@ func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByCustomerID_Secon
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              fmt.Sprintf("Asset %d", i+1),
-			OTRAmount:              float64(15000000 + i*1000000),
-			AdminFee:               500000,
-			TotalInterest:          2000000,
-			TotalInstallmentAmount: float64(17500000 + i*1000000),
+			OTRAmount:              decimal.NewFromInt(int64(15000000 + i*1000000)),
+			AdminFee:               decimal.NewFromInt(500000),
+			TotalInterest:          decimal.NewFromInt(2000000),
+			TotalInstallmentAmount: decimal.NewFromInt(int64(17500000 + i*1000000)),
 			Status:                 model.TransactionActive,
 			TransactionDate:        time.Now().Add(time.Duration(-i) * time.Hour),
 		}
@@ -362,10 +296,10 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Beat",
-			OTRAmount:              15000000,
-			AdminFee:               500000,
-			TotalInterest:          2000000,
-			TotalInstallmentAmount: 17500000,
+			OTRAmount:              decimal.NewFromInt(15000000),
+			AdminFee:               decimal.NewFromInt(500000),
+			TotalInterest:          decimal.NewFromInt(2000000),
+			TotalInstallmentAmount: decimal.NewFromInt(17500000),
 			Status:                 model.TransactionActive,
 			TransactionDate:        time.Now(),
 		},
@@ -374,10 +308,10 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Vario",
-			OTRAmount:              18000000,
-			AdminFee:               600000,
-			TotalInterest:          2500000,
-			TotalInstallmentAmount: 21100000,
+			OTRAmount:              decimal.NewFromInt(18000000),
+			AdminFee:               decimal.NewFromInt(600000),
+			TotalInterest:          decimal.NewFromInt(2500000),
+			TotalInstallmentAmount: decimal.NewFromInt(21100000),
 			Status:                 model.TransactionActive,
 			TransactionDate:        time.Now(),
 		},
@@ -386,10 +320,10 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 			CustomerID:             suite.customerID,
 			TenorID:                suite.tenorID,
 			AssetName:              "Honda Scoopy",
-			OTRAmount:              16000000,
-			AdminFee:               550000,
-			TotalInterest:          2200000,
-			TotalInstallmentAmount: 18750000,
+			OTRAmount:              decimal.NewFromInt(16000000),
+			AdminFee:               decimal.NewFromInt(550000),
+			TotalInterest:          decimal.NewFromInt(2200000),
+			TotalInstallmentAmount: decimal.NewFromInt(18750000),
 			Status:                 model.TransactionPending, // Should not be included
 			TransactionDate:        time.Now(),
 		},
@@ -406,7 +340,7 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 	// Assert
 	assert.NoError(suite.T(), err)
 	// Expected: (15000000 + 500000) + (18000000 + 600000) = 34100000
-	assert.Equal(suite.T(), float64(34100000), totalUsed)
+	assert.Equal(suite.T(), "34100000", totalUsed.String())
 }
 
 func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDAndTenorID_NoActiveTransactions() {
@@ -416,10 +350,10 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 		CustomerID:             suite.customerID,
 		TenorID:                suite.tenorID,
 		AssetName:              "Honda Beat",
-		OTRAmount:              15000000,
-		AdminFee:               500000,
-		TotalInterest:          2000000,
-		TotalInstallmentAmount: 17500000,
+		OTRAmount:              decimal.NewFromInt(15000000),
+		AdminFee:               decimal.NewFromInt(500000),
+		TotalInterest:          decimal.NewFromInt(2000000),
+		TotalInstallmentAmount: decimal.NewFromInt(17500000),
 		Status:                 model.TransactionPending,
 		TransactionDate:        time.Now(),
 	}
@@ -431,7 +365,7 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 
 	// Assert
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), float64(0), totalUsed)
+	assert.Equal(suite.T(), "0", totalUsed.String())
 }
 
 func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDAndTenorID_DifferentCustomerAndTenor() {
@@ -443,7 +377,7 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 		LegalName:          "Another Customer Legal",
 		BirthPlace:         "Bandung",
 		BirthDate:          time.Date(1992, 1, 1, 0, 0, 0, 0, time.UTC),
-		Salary:             6000000,
+		Salary:             decimal.NewFromInt(6000000),
 		VerificationStatus: model.VerificationVerified,
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
@@ -465,8 +399,8 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 			CustomerID:      suite.customerID,
 			TenorID:         suite.tenorID,
 			AssetName:       "Honda Beat",
-			OTRAmount:       15000000,
-			AdminFee:        500000,
+			OTRAmount:       decimal.NewFromInt(15000000),
+			AdminFee:        decimal.NewFromInt(500000),
 			Status:          model.TransactionActive,
 			TransactionDate: time.Now(),
 		},
@@ -475,8 +409,8 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 			CustomerID:      customer2.ID,
 			TenorID:         suite.tenorID,
 			AssetName:       "Honda Vario",
-			OTRAmount:       18000000,
-			AdminFee:        600000,
+			OTRAmount:       decimal.NewFromInt(18000000),
+			AdminFee:        decimal.NewFromInt(600000),
 			Status:          model.TransactionActive,
 			TransactionDate: time.Now(),
 		},
@@ -485,8 +419,8 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 			CustomerID:      suite.customerID,
 			TenorID:         tenor2.ID,
 			AssetName:       "Honda Scoopy",
-			OTRAmount:       16000000,
-			AdminFee:        550000,
+			OTRAmount:       decimal.NewFromInt(16000000),
+			AdminFee:        decimal.NewFromInt(550000),
 			Status:          model.TransactionActive,
 			TransactionDate: time.Now(),
 		},
@@ -503,7 +437,32 @@ func (suite *TransactionRepositoryTestSuite) TestSumActivePrincipalByCustomerIDA
 	// Assert
 	assert.NoError(suite.T(), err)
 	// Only CONTRACT001 should be included: 15000000 + 500000 = 15500000
-	assert.Equal(suite.T(), float64(15500000), totalUsed)
+	assert.Equal(suite.T(), "15500000", totalUsed.String())
+}
+
+func (suite *TransactionRepositoryTestSuite) TestFindActiveByCustomerID() {
+	now := time.Now()
+	transactions := []model.Transaction{
+		{ContractNumber: "ACTIVE-NEW", Status: model.TransactionActive, TransactionDate: now},
+		{ContractNumber: "ACTIVE-OLD", Status: model.TransactionActive, TransactionDate: now.AddDate(0, -2, 0)},
+		{ContractNumber: "PAID-OFF", Status: model.TransactionPaidOff, TransactionDate: now.AddDate(-1, 0, 0)},
+		{ContractNumber: "SANDBOX", Status: model.TransactionActive, TransactionDate: now, IsSandbox: true},
+	}
+	for _, transaction := range transactions {
+		transaction.CustomerID = suite.customerID
+		transaction.TenorID = suite.tenorID
+		transaction.AssetName = "Honda Beat"
+		transaction.OTRAmount = decimal.NewFromInt(15000000)
+		transaction.TotalInstallmentAmount = decimal.NewFromInt(17500000)
+		require.NoError(suite.T(), suite.db.Create(&transaction).Error)
+	}
+
+	active, err := suite.transactionRepository.FindActiveByCustomerID(suite.ctx, suite.customerID)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), active, 2)
+	assert.Equal(suite.T(), "ACTIVE-OLD", active[0].ContractNumber)
+	assert.Equal(suite.T(), "ACTIVE-NEW", active[1].ContractNumber)
 }
 
 func (suite *TransactionRepositoryTestSuite) TestCreateTransaction_ValidationError() {
@@ -513,10 +472,10 @@ func (suite *TransactionRepositoryTestSuite) TestCreateTransaction_ValidationErr
 		CustomerID:             999999, // Non-existent customer
 		TenorID:                suite.tenorID,
 		AssetName:              "Honda Beat",
-		OTRAmount:              15000000,
-		AdminFee:               500000,
-		TotalInterest:          2000000,
-		TotalInstallmentAmount: 17500000,
+		OTRAmount:              decimal.NewFromInt(15000000),
+		AdminFee:               decimal.NewFromInt(500000),
+		TotalInterest:          decimal.NewFromInt(2000000),
+		TotalInstallmentAmount: decimal.NewFromInt(17500000),
 		Status:                 domain.TransactionPending,
 		TransactionDate:        time.Now(),
 	}
@@ -529,6 +488,160 @@ func (suite *TransactionRepositoryTestSuite) TestCreateTransaction_ValidationErr
 	assert.Error(suite.T(), err)
 }
 
+func (suite *TransactionRepositoryTestSuite) newBulkTransaction(contractNumber string) domain.Transaction {
+	return domain.Transaction{
+		ContractNumber:         contractNumber,
+		CustomerID:             suite.customerID,
+		TenorID:                suite.tenorID,
+		AssetName:              "Honda Vario",
+		OTRAmount:              decimal.NewFromInt(20000000),
+		AdminFee:               decimal.NewFromInt(500000),
+		TotalInterest:          decimal.NewFromInt(2400000),
+		TotalInstallmentAmount: decimal.NewFromInt(22900000),
+		Status:                 domain.TransactionActive,
+		TransactionDate:        time.Now(),
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestCreateMany() {
+	// Arrange
+	transactions := []domain.Transaction{
+		suite.newBulkTransaction("BULK001"),
+		suite.newBulkTransaction("BULK002"),
+		suite.newBulkTransaction("BULK003"),
+	}
+
+	// Act
+	err := suite.transactionRepository.CreateMany(suite.ctx, transactions, 2)
+
+	// Assert
+	require.NoError(suite.T(), err)
+	var count int64
+	require.NoError(suite.T(), suite.db.Model(&model.Transaction{}).Where("contract_number LIKE ?", "BULK%").Count(&count).Error)
+	assert.Equal(suite.T(), int64(3), count)
+	for _, transaction := range transactions {
+		assert.NotZero(suite.T(), transaction.ID)
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestCreateManyRollsBackOnDuplicateMidBatch() {
+	// Arrange
+	existing := suite.newBulkTransaction("BULK-TAKEN")
+	require.NoError(suite.T(), suite.transactionRepository.CreateTransaction(suite.ctx, &existing))
+
+	// Batch pertama sukses, batch kedua bentrok dengan nomor yang sudah ada
+	transactions := []domain.Transaction{
+		suite.newBulkTransaction("BULK001"),
+		suite.newBulkTransaction("BULK002"),
+		suite.newBulkTransaction("BULK-TAKEN"),
+		suite.newBulkTransaction("BULK004"),
+	}
+
+	// Act
+	err := suite.transactionRepository.CreateMany(suite.ctx, transactions, 2)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+	var count int64
+	require.NoError(suite.T(), suite.db.Model(&model.Transaction{}).Where("contract_number LIKE ?", "BULK%").Count(&count).Error)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func (suite *TransactionRepositoryTestSuite) TestStreamForExport() {
+	// Arrange
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	dated := func(contractNumber string, date time.Time, sandbox bool) domain.Transaction {
+		transaction := suite.newBulkTransaction(contractNumber)
+		transaction.TransactionDate = date
+		transaction.IsSandbox = sandbox
+		return transaction
+	}
+	transactions := []domain.Transaction{
+		dated("EXP-LATE", to.Add(-time.Minute), false),
+		dated("EXP-EARLY", from, false),
+		dated("EXP-BEFORE", from.Add(-time.Minute), false),
+		dated("EXP-AFTER", to, false),
+		dated("EXP-SANDBOX", from.AddDate(0, 0, 3), true),
+	}
+	require.NoError(suite.T(), suite.transactionRepository.CreateMany(suite.ctx, transactions, 10))
+
+	// Act
+	var numbers []string
+	err := suite.transactionRepository.StreamForExport(suite.ctx, from, to, func(transaction domain.Transaction) error {
+		numbers = append(numbers, transaction.ContractNumber)
+		return nil
+	})
+
+	// Assert
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"EXP-EARLY", "EXP-LATE"}, numbers)
+}
+
+func (suite *TransactionRepositoryTestSuite) TestFindByContractNumber() {
+	// Arrange
+	transaction := domain.Transaction{
+		ContractNumber:         "CONTRACT010",
+		CustomerID:             suite.customerID,
+		TenorID:                suite.tenorID,
+		AssetName:              "Yamaha NMAX",
+		OTRAmount:              decimal.NewFromInt(30000000),
+		AdminFee:               decimal.NewFromInt(600000),
+		TotalInterest:          decimal.NewFromInt(7200000),
+		TotalInstallmentAmount: decimal.NewFromInt(37800000),
+		Status:                 domain.TransactionActive,
+		TransactionDate:        time.Now(),
+	}
+	require.NoError(suite.T(), suite.transactionRepository.CreateTransaction(suite.ctx, &transaction))
+
+	// Act
+	found, err := suite.transactionRepository.FindByContractNumber(suite.ctx, "CONTRACT010")
+	missing, missingErr := suite.transactionRepository.FindByContractNumber(suite.ctx, "CONTRACT404")
+
+	// Assert
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), found)
+	assert.Equal(suite.T(), transaction.ID, found.ID)
+	assert.Equal(suite.T(), domain.TransactionActive, found.Status)
+	assert.NoError(suite.T(), missingErr)
+	assert.Nil(suite.T(), missing)
+}
+
+func (suite *TransactionRepositoryTestSuite) TestFindPaginatedByContractNumberPrefix() {
+	// Arrange
+	for _, number := range []string{"KTR-JKT-000001", "KTR-JKT-000002", "KTR_JKT-000003", "KTR-BDG-000001"} {
+		require.NoError(suite.T(), suite.transactionRepository.CreateTransaction(suite.ctx, &domain.Transaction{
+			ContractNumber:         number,
+			CustomerID:             suite.customerID,
+			TenorID:                suite.tenorID,
+			AssetName:              "Honda Beat",
+			OTRAmount:              decimal.NewFromInt(15000000),
+			AdminFee:               decimal.NewFromInt(500000),
+			TotalInterest:          decimal.NewFromInt(2000000),
+			TotalInstallmentAmount: decimal.NewFromInt(17500000),
+			Status:                 domain.TransactionActive,
+			TransactionDate:        time.Now(),
+		}))
+	}
+
+	// Act
+	firstPage, total, err := suite.transactionRepository.FindPaginatedByContractNumberPrefix(suite.ctx, "KTR-JKT", domain.Params{Page: 1, Limit: 1})
+	// "_" adalah wildcard LIKE dan harus dicocokkan apa adanya
+	underscore, underscoreTotal, underscoreErr := suite.transactionRepository.FindPaginatedByContractNumberPrefix(suite.ctx, "KTR_", domain.Params{Page: 1, Limit: 10})
+
+	// Assert
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), total)
+	require.Len(suite.T(), firstPage, 1)
+	assert.Equal(suite.T(), "KTR-JKT-000001", firstPage[0].ContractNumber)
+	assert.Equal(suite.T(), "John Doe", firstPage[0].Customer.FullName)
+
+	require.NoError(suite.T(), underscoreErr)
+	assert.Equal(suite.T(), int64(1), underscoreTotal)
+	require.Len(suite.T(), underscore, 1)
+	assert.Equal(suite.T(), "KTR_JKT-000003", underscore[0].ContractNumber)
+}
+
 func TestTransactionRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(TransactionRepositoryTestSuite))
 }
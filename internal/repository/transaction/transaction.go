@@ -2,15 +2,20 @@ package transactionrepo
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -19,9 +24,7 @@ import (
 type transactionRepository struct {
 	db                 *gorm.DB
 	meter              metric.Meter
-	tracer             trace.Tracer
 	log                *zap.Logger
-	queryDuration      metric.Float64Histogram
 	queryCount         metric.Int64Counter
 	errorCount         metric.Int64Counter
 	connectionGauge    metric.Int64UpDownCounter
@@ -31,16 +34,13 @@ type transactionRepository struct {
 
 // FindPaginatedByCustomerID implements TransactionRepository.
 func (t *transactionRepository) FindPaginatedByCustomerID(ctx context.Context, customerID uint64, params domain.Params) ([]domain.Transaction, int64, error) {
-	ctx, span := t.tracer.Start(ctx, "repository.FindPaginatedByCustomerID")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	t.log.Debug("Find transactions paginated by customer ID",
 		zap.Uint64("customer_id", customerID),
 		zap.Int("page", params.Page),
 		zap.Int("limit", params.Limit),
-		zap.String("status", params.Status),
+		zap.String("status", params.Value("status")),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
@@ -64,36 +64,23 @@ func (t *transactionRepository) FindPaginatedByCustomerID(ctx context.Context, c
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select_paginated"),
-		attribute.String("db.table", "transactions"),
-		attribute.Int64("customer.id", int64(customerID)),
-		attribute.Int("pagination.page", params.Page),
-		attribute.Int("pagination.limit", params.Limit),
-		attribute.String("filter.status", params.Status),
-	)
-
 	var transactions []model.Transaction
 	var total int64
 
 	// Buat query dasar
-	query := t.db.WithContext(ctx).Model(&model.Transaction{}).Where("customer_id = ?", customerID)
-	countQuery := t.db.WithContext(ctx).Model(&model.Transaction{}).Where("customer_id = ?", customerID)
+	// Transaksi sandbox partner tidak pernah tampil di riwayat customer
+	query := t.db.WithContext(ctx).Model(&model.Transaction{}).Where("customer_id = ? AND is_sandbox = ?", customerID, false)
+	countQuery := t.db.WithContext(ctx).Model(&model.Transaction{}).Where("customer_id = ? AND is_sandbox = ?", customerID, false)
 
 	// Terapkan filter status jika ada
-	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
-		countQuery = countQuery.Where("status = ?", params.Status)
-	}
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
 
 	// Hitung total record (sebelum limit dan offset)
 	if err := countQuery.Count(&total).Error; err != nil {
-		span.SetStatus(codes.Error, "Error counting transactions")
-		span.RecordError(err)
-
 		t.log.Error("Error counting transactions",
 			zap.Uint64("customer_id", customerID),
-			zap.String("status", params.Status),
+			zap.String("status", params.Value("status")),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 			zap.Error(err),
 		)
@@ -106,46 +93,122 @@ func (t *transactionRepository) FindPaginatedByCustomerID(ctx context.Context, c
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		t.queryDuration.Record(ctx, duration,
+		return nil, 0, err
+	}
+
+	// Terapkan paginasi
+	query = params.Paginate(query).Order("transaction_date DESC")
+
+	if err := query.Find(&transactions).Error; err != nil {
+		t.log.Error("Error finding transactions paginated",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
 			metric.WithAttributes(
 				attribute.String("operation", "select_paginated"),
 				attribute.String("table", "transactions"),
-				attribute.String("status", "error"),
+				attribute.String("error", err.Error()),
 			),
 		)
 
 		return nil, 0, err
 	}
 
-	// Terapkan paginasi
-	offset := (params.Page - 1) * params.Limit
-	query = query.Limit(params.Limit).Offset(offset).Order("transaction_date DESC")
+	t.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
 
-	if err := query.Find(&transactions).Error; err != nil {
-		span.SetStatus(codes.Error, "Error finding transactions paginated")
-		span.RecordError(err)
+	t.log.Info("Transactions found paginated",
+		zap.Uint64("customer_id", customerID),
+		zap.Int64("total", total),
+		zap.Int("retrieved", len(transactions)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
 
-		t.log.Error("Error finding transactions paginated",
-			zap.Uint64("customer_id", customerID),
+	return model.TransactionsToEntity(transactions), total, nil
+}
+
+// FindPaginatedByPartnerID implements TransactionRepository.
+func (t *transactionRepository) FindPaginatedByPartnerID(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) ([]domain.Transaction, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Find transactions paginated by partner ID",
+		zap.Uint64("partner_id", partnerID),
+		zap.Bool("sandbox", sandbox),
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_paginated_by_partner_id"),
+			attribute.String("table", "transactions"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_paginated_by_partner_id"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 2, // Count query + Select query
+		metric.WithAttributes(
+			attribute.String("operation", "select_paginated"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	var transactions []model.Transaction
+	var total int64
+
+	// Key sandbox hanya melihat transaksi sandbox, begitu pula sebaliknya
+	query := t.db.WithContext(ctx).Model(&model.Transaction{}).Where("partner_id = ? AND is_sandbox = ?", partnerID, sandbox)
+	countQuery := t.db.WithContext(ctx).Model(&model.Transaction{}).Where("partner_id = ? AND is_sandbox = ?", partnerID, sandbox)
+
+	query = params.Filter(query)
+	countQuery = params.Filter(countQuery)
+
+	if err := countQuery.Count(&total).Error; err != nil {
+		t.log.Error("Error counting transactions",
+			zap.Uint64("partner_id", partnerID),
+			zap.String("status", params.Value("status")),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 			zap.Error(err),
 		)
 
 		t.errorCount.Add(ctx, 1,
 			metric.WithAttributes(
-				attribute.String("operation", "select_paginated"),
+				attribute.String("operation", "count"),
 				attribute.String("table", "transactions"),
 				attribute.String("error", err.Error()),
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		t.queryDuration.Record(ctx, duration,
+		return nil, 0, err
+	}
+
+	query = params.Paginate(query).Order("transaction_date DESC").Order("id DESC")
+
+	if err := query.Find(&transactions).Error; err != nil {
+		t.log.Error("Error finding transactions paginated",
+			zap.Uint64("partner_id", partnerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
 			metric.WithAttributes(
 				attribute.String("operation", "select_paginated"),
 				attribute.String("table", "transactions"),
-				attribute.String("status", "error"),
+				attribute.String("error", err.Error()),
 			),
 		)
 
@@ -158,38 +221,19 @@ func (t *transactionRepository) FindPaginatedByCustomerID(ctx context.Context, c
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	t.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "select_paginated"),
-			attribute.String("table", "transactions"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	t.log.Info("Transactions found paginated",
-		zap.Uint64("customer_id", customerID),
+		zap.Uint64("partner_id", partnerID),
 		zap.Int64("total", total),
 		zap.Int("retrieved", len(transactions)),
-		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Transactions found paginated")
-	span.SetAttributes(
-		attribute.Int64("result.total", total),
-		attribute.Int("result.retrieved", len(transactions)),
-	)
-
 	return model.TransactionsToEntity(transactions), total, nil
 }
 
 // CreateTransaction implements TransactionRepository.
 func (t *transactionRepository) CreateTransaction(ctx context.Context, transaction *domain.Transaction) error {
-	ctx, span := t.tracer.Start(ctx, "repository.CreateTransaction")
-	defer span.End()
-
-	start := time.Now()
+	span := trace.SpanFromContext(ctx)
 
 	t.log.Debug("Create transaction",
 		zap.String("contract_number", transaction.ContractNumber),
@@ -217,17 +261,17 @@ func (t *transactionRepository) CreateTransaction(ctx context.Context, transacti
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "insert"),
-		attribute.String("db.table", "transactions"),
-		attribute.String("transaction.contract_number", transaction.ContractNumber),
-	)
-
 	data := model.TransactionFromEntity(transaction)
-	err := t.db.WithContext(ctx).Create(&data).Error
+	// Nomor kontrak dan virtual account dijaga unik lewat transaction_keys
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&data).Error; err != nil {
+			return err
+		}
+		keys := model.TransactionKeys(data)
+		return tx.Create(&keys).Error
+	})
 	if err != nil {
-		span.SetStatus(codes.Error, "Error creating transaction")
-		span.RecordError(err)
+		err = duplicateTransactionError(err)
 
 		t.log.Error("Error creating transaction",
 			zap.String("contract_number", transaction.ContractNumber),
@@ -243,15 +287,6 @@ func (t *transactionRepository) CreateTransaction(ctx context.Context, transacti
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		t.queryDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("operation", "insert"),
-				attribute.String("table", "transactions"),
-				attribute.String("status", "error"),
-			),
-		)
-
 		return err
 	}
 
@@ -261,37 +296,107 @@ func (t *transactionRepository) CreateTransaction(ctx context.Context, transacti
 		),
 	)
 
-	duration := float64(time.Since(start).Milliseconds())
-	t.queryDuration.Record(ctx, duration,
-		metric.WithAttributes(
-			attribute.String("operation", "insert"),
-			attribute.String("table", "transactions"),
-			attribute.String("status", "success"),
-		),
-	)
-
 	t.log.Info("Transaction created successfully",
 		zap.Uint64("transaction_id", data.ID),
 		zap.String("contract_number", data.ContractNumber),
-		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Transaction created successfully")
-	span.SetAttributes(attribute.Int64("transaction.id", int64(data.ID)))
-
 	// Update the original domain object with the generated ID
 	transaction.ID = data.ID
 
 	return nil
 }
 
-// SumActivePrincipalByCustomerIDAndTenorID implements TransactionRepository.
-func (t *transactionRepository) SumActivePrincipalByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint) (float64, error) {
-	ctx, span := t.tracer.Start(ctx, "repository.SumActivePrincipalByCustomerIDAndTenorID")
-	defer span.End()
+// CreateMany implements TransactionRepository.
+func (t *transactionRepository) CreateMany(ctx context.Context, transactions []domain.Transaction, batchSize int) error {
+	span := trace.SpanFromContext(ctx)
+
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	t.log.Debug("Create transactions in batches",
+		zap.Int("count", len(transactions)),
+		zap.Int("batch_size", batchSize),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "create_many_transactions"),
+			attribute.String("table", "transactions"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "create_many_transactions"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "insert"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	data := make([]model.Transaction, len(transactions))
+	for i := range transactions {
+		data[i] = model.TransactionFromEntity(&transactions[i])
+	}
+
+	// Semua batch dalam satu transaksi, pelanggaran constraint di batch mana
+	// pun membatalkan baris dari batch sebelumnya juga
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(&data, batchSize).Error; err != nil {
+			return err
+		}
+		keys := model.TransactionKeys(data...)
+		return tx.CreateInBatches(&keys, batchSize).Error
+	})
+	if err != nil {
+		err = duplicateTransactionError(err)
+
+		t.log.Error("Error creating transactions",
+			zap.Int("count", len(transactions)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "insert"),
+				attribute.String("table", "transactions"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return err
+	}
 
-	start := time.Now()
+	for i := range data {
+		transactions[i].ID = data[i].ID
+	}
+
+	t.documentsInserted.Add(ctx, int64(len(data)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	t.log.Info("Transactions created successfully",
+		zap.Int("count", len(data)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// SumActivePrincipalByCustomerIDAndTenorID implements TransactionRepository.
+func (t *transactionRepository) SumActivePrincipalByCustomerIDAndTenorID(ctx context.Context, customerID uint64, tenorID uint) (decimal.Decimal, error) {
+	span := trace.SpanFromContext(ctx)
 
 	t.log.Debug("Summing active principal for customer and tenor",
 		zap.Uint64("customer_id", customerID),
@@ -319,23 +424,14 @@ func (t *transactionRepository) SumActivePrincipalByCustomerIDAndTenorID(ctx con
 		),
 	)
 
-	span.SetAttributes(
-		attribute.String("db.operation", "select_sum"),
-		attribute.String("db.table", "transactions"),
-		attribute.Int64("customer.id", int64(customerID)),
-		attribute.Int("tenor.id", int(tenorID)),
-	)
-
-	var totalUsed float64
+	var totalUsed decimal.Decimal
 	err := t.db.WithContext(ctx).Model(&model.Transaction{}).
-		Where("customer_id = ? AND tenor_id = ? AND status = ?", customerID, tenorID, model.TransactionActive).
-		Select("COALESCE(SUM(otr_amount + admin_fee), 0)").
+		Where("customer_id = ? AND tenor_id = ? AND status = ? AND is_sandbox = ?", customerID, tenorID, model.TransactionActive, false).
+		// Dikonversi ke IDR dengan kurs yang dipakai saat transaksi dibuat
+		Select("COALESCE(ROUND(SUM((otr_amount + admin_fee) * fx_rate), 2), 0)").
 		Row().
 		Scan(&totalUsed)
 	if err != nil {
-		span.SetStatus(codes.Error, "Error summing active principal")
-		span.RecordError(err)
-
 		t.log.Error("Error summing active principal",
 			zap.Uint64("customer_id", customerID),
 			zap.Uint("tenor_id", tenorID),
@@ -351,53 +447,412 @@ func (t *transactionRepository) SumActivePrincipalByCustomerIDAndTenorID(ctx con
 			),
 		)
 
-		duration := float64(time.Since(start).Milliseconds())
-		t.queryDuration.Record(ctx, duration,
+		return decimal.Zero, err
+	}
+
+	t.log.Debug("Sum of active principal retrieved successfully",
+		zap.Uint64("customer_id", customerID),
+		zap.Uint("tenor_id", tenorID),
+		zap.Stringer("total_used", totalUsed),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return totalUsed, nil
+}
+
+// SumActiveMonthlyInstallmentByCustomerID implements TransactionRepository.
+func (t *transactionRepository) SumActiveMonthlyInstallmentByCustomerID(ctx context.Context, customerID uint64) (decimal.Decimal, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Summing active monthly installments for customer",
+		zap.Uint64("customer_id", customerID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "sum_active_installment"),
+			attribute.String("table", "transactions"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "sum_active_installment"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select_sum"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	var total decimal.Decimal
+	err := t.db.WithContext(ctx).Model(&model.Transaction{}).
+		Joins("JOIN tenors ON tenors.id = transactions.tenor_id").
+		Where("transactions.customer_id = ? AND transactions.status = ? AND transactions.is_sandbox = ? AND tenors.duration_months > 0",
+			customerID, model.TransactionActive, false).
+		// Cicilan dibagi rata per bulan, lalu dikonversi ke IDR dengan kurs saat transaksi dibuat
+		Select("COALESCE(ROUND(SUM(transactions.total_installment_amount / tenors.duration_months * transactions.fx_rate), 2), 0)").
+		Row().
+		Scan(&total)
+	if err != nil {
+		t.log.Error("Error summing active monthly installments",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
 			metric.WithAttributes(
 				attribute.String("operation", "select_sum"),
 				attribute.String("table", "transactions"),
-				attribute.String("status", "error"),
+				attribute.String("error", err.Error()),
 			),
 		)
 
-		return 0, err
+		return decimal.Zero, err
 	}
 
-	duration := float64(time.Since(start).Milliseconds())
-	t.queryDuration.Record(ctx, duration,
+	t.log.Debug("Sum of active monthly installments retrieved successfully",
+		zap.Uint64("customer_id", customerID),
+		zap.Stringer("total_monthly_installment", total),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return total, nil
+}
+
+// FindActiveByCustomerID implements TransactionRepository.
+func (t *transactionRepository) FindActiveByCustomerID(ctx context.Context, customerID uint64) ([]domain.Transaction, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Find active transactions by customer ID",
+		zap.Uint64("customer_id", customerID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
 		metric.WithAttributes(
-			attribute.String("operation", "select_sum"),
+			attribute.String("operation", "find_active_by_customer_id"),
+			attribute.String("table", "transactions"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_active_by_customer_id"),
 			attribute.String("table", "transactions"),
-			attribute.String("status", "success"),
 		),
 	)
 
-	t.log.Debug("Sum of active principal retrieved successfully",
-		zap.Uint64("customer_id", customerID),
-		zap.Uint("tenor_id", tenorID),
-		zap.Float64("total_used", totalUsed),
-		zap.Float64("duration_ms", duration),
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	var transactions []model.Transaction
+	err := t.db.WithContext(ctx).
+		Where("customer_id = ? AND status = ? AND is_sandbox = ?", customerID, model.TransactionActive, false).
+		Order("transaction_date ASC").
+		Find(&transactions).Error
+	if err != nil {
+		t.log.Error("Error finding active transactions",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select"),
+				attribute.String("table", "transactions"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return nil, err
+	}
+
+	t.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	return model.TransactionsToEntity(transactions), nil
+}
+
+// FindByContractNumber implements TransactionRepository.
+func (t *transactionRepository) FindByContractNumber(ctx context.Context, contractNumber string) (*domain.Transaction, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Find transaction by contract number",
+		zap.String("contract_number", contractNumber),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
-	span.SetStatus(codes.Ok, "Sum of active principal retrieved")
-	span.SetAttributes(attribute.Float64("result.sum", totalUsed))
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_by_contract_number"),
+			attribute.String("table", "transactions"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_by_contract_number"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select"),
+			attribute.String("table", "transactions"),
+		),
+	)
 
-	return totalUsed, nil
+	// Tanggal di nomor kontrak membatasi transaction_date agar MySQL hanya
+	// membaca partisi hari itu, zona waktu bisa menggesernya sehari
+	var transaction model.Transaction
+	err := gorm.ErrRecordNotFound
+	if day, ok := contractDay(contractNumber); ok {
+		err = t.db.WithContext(ctx).
+			Where("contract_number = ? AND transaction_date >= ? AND transaction_date < ?", contractNumber, day.AddDate(0, 0, -1), day.AddDate(0, 0, 2)).
+			First(&transaction).Error
+	}
+	// Nomor tanpa tanggal atau yang tanggalnya tidak cocok dicari di semua partisi
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = t.db.WithContext(ctx).Where("contract_number = ?", contractNumber).First(&transaction).Error
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		t.log.Error("Error finding transaction by contract number",
+			zap.String("contract_number", contractNumber),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select"),
+				attribute.String("table", "transactions"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return nil, err
+	}
+
+	t.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	return model.TransactionToEntity(transaction), nil
+}
+
+// StreamForExport implements TransactionRepository.
+func (t *transactionRepository) StreamForExport(ctx context.Context, from, to time.Time, fn func(domain.Transaction) error) error {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Stream transactions for export",
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "stream_transactions"),
+			attribute.String("table", "transactions"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "stream_transactions"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "select_stream"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	// Cursor dibaca selama ekspor berlangsung, jauh melewati batas waktu
+	// satu query; berhenti saat fn gagal atau context dibatalkan
+	query := t.db.WithContext(mysqldb.WithoutQueryTimeout(ctx)).Model(&model.Transaction{})
+	query = query.Where("transaction_date >= ? AND transaction_date < ? AND is_sandbox = ?", from, to, false)
+	rows, err := query.Order("transaction_date, id").Rows()
+	if err == nil {
+		defer rows.Close()
+
+		var streamed int64
+		for err == nil && rows.Next() {
+			var data model.Transaction
+			if err = t.db.ScanRows(rows, &data); err == nil {
+				err = fn(*model.TransactionToEntity(data))
+				streamed++
+			}
+		}
+		if err == nil {
+			err = rows.Err()
+		}
+
+		t.documentsRetrieved.Add(ctx, streamed,
+			metric.WithAttributes(
+				attribute.String("table", "transactions"),
+			),
+		)
+	}
+	if err != nil {
+		t.log.Error("Error streaming transactions",
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select_stream"),
+				attribute.String("table", "transactions"),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// contractDay returns the day a contract number such as
+// KTR-JKT-20250101-000123 was issued on.
+func contractDay(contractNumber string) (time.Time, bool) {
+	for _, part := range strings.Split(contractNumber, "-") {
+		if len(part) != 8 {
+			continue
+		}
+		if day, err := time.Parse("20060102", part); err == nil {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// likeEscaper escapes the LIKE wildcards of user input, MySQL uses the
+// backslash as escape character by default.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// FindPaginatedByContractNumberPrefix implements TransactionRepository.
+func (t *transactionRepository) FindPaginatedByContractNumberPrefix(ctx context.Context, prefix string, params domain.Params) ([]domain.Transaction, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	t.log.Debug("Find transactions paginated by contract number prefix",
+		zap.String("prefix", prefix),
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	t.connectionGauge.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_by_contract_number_prefix"),
+			attribute.String("table", "transactions"),
+		),
+	)
+	defer t.connectionGauge.Add(ctx, -1,
+		metric.WithAttributes(
+			attribute.String("operation", "find_by_contract_number_prefix"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	t.queryCount.Add(ctx, 2, // Count query + Select query
+		metric.WithAttributes(
+			attribute.String("operation", "select_paginated"),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	recordError := func(message string, err error) {
+		t.log.Error(message,
+			zap.String("prefix", prefix),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		t.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "select_paginated"),
+				attribute.String("table", "transactions"),
+				attribute.String("error", err.Error()),
+			),
+		)
+	}
+
+	// Pola LIKE dengan awalan tetap tetap bisa memakai unique index contract_number
+	pattern := likeEscaper.Replace(prefix) + "%"
+
+	var total int64
+	if err := t.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("contract_number LIKE ?", pattern).
+		Count(&total).Error; err != nil {
+		recordError("Error counting transactions by contract number prefix", err)
+		return nil, 0, err
+	}
+
+	var transactions []model.Transaction
+	query := t.db.WithContext(ctx).Preload("Customer").Where("contract_number LIKE ?", pattern)
+	if err := params.Paginate(query).Order("contract_number ASC").Find(&transactions).Error; err != nil {
+		recordError("Error finding transactions by contract number prefix", err)
+		return nil, 0, err
+	}
+
+	t.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	result := make([]domain.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		result[i] = *model.TransactionToEntity(transaction)
+		result[i].Customer = *model.CustomerToEntity(transaction.Customer)
+	}
+
+	return result, total, nil
+}
+
+// duplicateTransactionError maps a duplicate key error to
+// common.ErrDuplicateContract when the contract number index was hit, and
+// to gorm.ErrDuplicatedKey for any other unique index. Other errors are
+// returned as they are.
+func duplicateTransactionError(err error) error {
+	key, ok := mysqldb.DuplicateKey(err)
+	if !ok {
+		return err
+	}
+	if strings.HasSuffix(key, "contract_number") {
+		return fmt.Errorf("%w: %v", common.ErrDuplicateContract, err)
+	}
+	return fmt.Errorf("%w: %v", gorm.ErrDuplicatedKey, err)
 }
 
 func NewTransactionRepository(
 	db *gorm.DB,
 	meter metric.Meter,
-	tracer trace.Tracer,
 	log *zap.Logger,
 ) repository.TransactionRepository {
-	queryDuration, _ := meter.Float64Histogram(
-		"db.query.duration",
-		metric.WithDescription("Duration of database queries"),
-		metric.WithUnit("ms"),
-	)
-
 	queryCount, _ := meter.Int64Counter(
 		"db.query.count",
 		metric.WithDescription("Number of database queries"),
@@ -431,9 +886,7 @@ func NewTransactionRepository(
 	return &transactionRepository{
 		db:                 db,
 		meter:              meter,
-		tracer:             tracer,
 		log:                log,
-		queryDuration:      queryDuration,
 		queryCount:         queryCount,
 		errorCount:         errorCount,
 		connectionGauge:    connectionGauge,
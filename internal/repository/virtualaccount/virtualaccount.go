@@ -0,0 +1,188 @@
+package virtualaccountrepo
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type virtualAccountRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// FindUnprovisioned implements VirtualAccountRepository.
+func (r *virtualAccountRepository) FindUnprovisioned(ctx context.Context, limit int) ([]domain.Transaction, error) {
+	done := r.begin(ctx, "find_unprovisioned_virtual_accounts", "select")
+	defer done()
+
+	// Kontrak sandbox tidak pernah ditagih sehingga tidak perlu virtual account
+	var transactions []model.Transaction
+	err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Where("virtual_account_number IS NULL AND is_sandbox = ? AND status IN ?", false,
+			[]model.TransactionStatus{model.TransactionApproved, model.TransactionActive}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		r.recordError(ctx, "select", "Error finding contracts without virtual account", err)
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(transactions)),
+		metric.WithAttributes(
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	result := make([]domain.Transaction, len(transactions))
+	for i, t := range transactions {
+		result[i] = *model.TransactionToEntity(t)
+		result[i].Customer = *model.CustomerToEntity(t.Customer)
+	}
+
+	return result, nil
+}
+
+// Assign implements VirtualAccountRepository.
+func (r *virtualAccountRepository) Assign(ctx context.Context, transactionID uint64, bank, number string) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "assign_virtual_account", "update")
+	defer done()
+
+	// Nomor yang sudah terpasang tidak pernah ditimpa, nasabah mungkin sudah membayar ke sana
+	var assigned int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Transaction{}).
+			Where("id = ? AND virtual_account_number IS NULL", transactionID).
+			Updates(map[string]any{
+				"virtual_account_bank":   bank,
+				"virtual_account_number": number,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		assigned = result.RowsAffected
+
+		// Unique index di transaction_keys tetap berlaku saat transactions dipartisi
+		return tx.Model(&model.TransactionKey{}).
+			Where("transaction_id = ?", transactionID).
+			Update("virtual_account_number", number).Error
+	})
+	if err != nil {
+		r.recordError(ctx, "update", "Error assigning virtual account", err, zap.Uint64("transaction_id", transactionID))
+		return false, err
+	}
+
+	if assigned == 0 {
+		return false, nil
+	}
+
+	r.log.Info("Virtual account assigned",
+		zap.Uint64("transaction_id", transactionID),
+		zap.String("bank", bank),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return true, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *virtualAccountRepository) begin(ctx context.Context, operation, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", "transactions"),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "transactions"),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *virtualAccountRepository) recordError(
+	ctx context.Context, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", "transactions"),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewVirtualAccountRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.VirtualAccountRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &virtualAccountRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
@@ -0,0 +1,371 @@
+package writeoffrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	writeOffsTable  = "write_offs"
+	recoveriesTable = "write_off_recoveries"
+)
+
+type writeOffRepository struct {
+	db                 *gorm.DB
+	meter              metric.Meter
+	log                *zap.Logger
+	queryCount         metric.Int64Counter
+	errorCount         metric.Int64Counter
+	connectionGauge    metric.Int64UpDownCounter
+	documentsRetrieved metric.Int64Counter
+	documentsInserted  metric.Int64Counter
+}
+
+// Create implements WriteOffRepository.
+func (r *writeOffRepository) Create(ctx context.Context, writeOff *domain.WriteOff) error {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Create write-off",
+		zap.Uint64("transaction_id", writeOff.TransactionID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "create_write_off", writeOffsTable, "insert")
+	defer done()
+
+	data := model.WriteOffFromEntity(writeOff)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, writeOffsTable, "insert", "Error creating write-off", err, zap.Uint64("transaction_id", writeOff.TransactionID))
+		return err
+	}
+
+	writeOff.ID = data.ID
+	writeOff.CreatedAt = data.CreatedAt
+	writeOff.UpdatedAt = data.UpdatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", writeOffsTable),
+		),
+	)
+
+	r.log.Info("Write-off created",
+		zap.Uint64("write_off_id", writeOff.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// Update implements WriteOffRepository.
+func (r *writeOffRepository) Update(ctx context.Context, writeOff *domain.WriteOff) error {
+	span := trace.SpanFromContext(ctx)
+
+	done := r.begin(ctx, "update_write_off", writeOffsTable, "update")
+	defer done()
+
+	data := model.WriteOffFromEntity(writeOff)
+	err := r.db.WithContext(ctx).Model(&model.WriteOff{ID: writeOff.ID}).
+		Select("status", "recovered_amount", "reviewer_id", "review_note", "reviewed_at").
+		Updates(&data).Error
+	if err != nil {
+		r.recordError(ctx, writeOffsTable, "update", "Error updating write-off", err, zap.Uint64("write_off_id", writeOff.ID))
+		return err
+	}
+
+	r.log.Info("Write-off updated",
+		zap.Uint64("write_off_id", writeOff.ID),
+		zap.String("status", string(writeOff.Status)),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	return nil
+}
+
+// FindByID implements WriteOffRepository.
+func (r *writeOffRepository) FindByID(ctx context.Context, id uint64) (*domain.WriteOff, error) {
+	return r.findOne(ctx, "find_write_off_by_id", r.db.Preload("Transaction").Where("id = ?", id))
+}
+
+// FindByIDWithLock implements WriteOffRepository.
+func (r *writeOffRepository) FindByIDWithLock(ctx context.Context, id uint64) (*domain.WriteOff, error) {
+	// SELECT ... FOR UPDATE supaya review dan pencatatan recovery berjalan bergantian
+	return r.findOne(ctx, "find_write_off_by_id_with_lock",
+		r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Transaction").Where("id = ?", id))
+}
+
+// FindPendingByTransactionID implements WriteOffRepository.
+func (r *writeOffRepository) FindPendingByTransactionID(ctx context.Context, transactionID uint64) (*domain.WriteOff, error) {
+	return r.findOne(ctx, "find_pending_write_off",
+		r.db.Where("transaction_id = ? AND status = ?", transactionID, domain.WriteOffPending))
+}
+
+func (r *writeOffRepository) findOne(ctx context.Context, operation string, query *gorm.DB) (*domain.WriteOff, error) {
+	done := r.begin(ctx, operation, writeOffsTable, "select")
+	defer done()
+
+	var writeOff model.WriteOff
+	if err := query.WithContext(ctx).First(&writeOff).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.recordError(ctx, writeOffsTable, "select", "Error finding write-off", err, zap.String("operation", operation))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", writeOffsTable),
+		),
+	)
+
+	return model.WriteOffToEntity(writeOff), nil
+}
+
+// FindPaginated implements WriteOffRepository.
+func (r *writeOffRepository) FindPaginated(ctx context.Context, params domain.Params) ([]domain.WriteOff, int64, error) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Debug("Find write-offs paginated",
+		zap.Int("page", params.Page),
+		zap.Int("limit", params.Limit),
+		zap.String("status", params.Value("status")),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	done := r.begin(ctx, "find_paginated_write_offs", writeOffsTable, "select_paginated")
+	defer done()
+
+	countQuery := params.Filter(r.db.WithContext(ctx).Model(&model.WriteOff{}))
+
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.recordError(ctx, writeOffsTable, "select_paginated", "Error counting write-offs", err)
+		return nil, 0, err
+	}
+
+	var writeOffs []model.WriteOff
+	query := params.Filter(r.db.WithContext(ctx).Model(&model.WriteOff{}).Preload("Transaction"))
+	if err := params.Paginate(query).Order("id DESC").Find(&writeOffs).Error; err != nil {
+		r.recordError(ctx, writeOffsTable, "select_paginated", "Error finding write-offs", err)
+		return nil, 0, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(writeOffs)),
+		metric.WithAttributes(
+			attribute.String("table", writeOffsTable),
+		),
+	)
+
+	return model.WriteOffsToEntity(writeOffs), total, nil
+}
+
+// AddRecovery implements WriteOffRepository.
+func (r *writeOffRepository) AddRecovery(ctx context.Context, recovery *domain.WriteOffRecovery) error {
+	done := r.begin(ctx, "add_write_off_recovery", recoveriesTable, "insert")
+	defer done()
+
+	data := model.WriteOffRecoveryFromEntity(recovery)
+	if err := r.db.WithContext(ctx).Create(&data).Error; err != nil {
+		r.recordError(ctx, recoveriesTable, "insert", "Error adding write-off recovery", err, zap.Uint64("write_off_id", recovery.WriteOffID))
+		return err
+	}
+
+	recovery.ID = data.ID
+	recovery.CreatedAt = data.CreatedAt
+
+	r.documentsInserted.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("table", recoveriesTable),
+		),
+	)
+
+	return nil
+}
+
+// FindRecoveries implements WriteOffRepository.
+func (r *writeOffRepository) FindRecoveries(ctx context.Context, writeOffID uint64) ([]domain.WriteOffRecovery, error) {
+	done := r.begin(ctx, "find_write_off_recoveries", recoveriesTable, "select")
+	defer done()
+
+	var recoveries []model.WriteOffRecovery
+	err := r.db.WithContext(ctx).
+		Where("write_off_id = ?", writeOffID).
+		Order("received_on DESC, id DESC").
+		Find(&recoveries).Error
+	if err != nil {
+		r.recordError(ctx, recoveriesTable, "select", "Error finding write-off recoveries", err, zap.Uint64("write_off_id", writeOffID))
+		return nil, err
+	}
+
+	r.documentsRetrieved.Add(ctx, int64(len(recoveries)),
+		metric.WithAttributes(
+			attribute.String("table", recoveriesTable),
+		),
+	)
+
+	return model.WriteOffRecoveriesToEntity(recoveries), nil
+}
+
+// Report implements WriteOffRepository.
+func (r *writeOffRepository) Report(ctx context.Context, from, to time.Time) ([]domain.WriteOffPeriod, error) {
+	end := to.AddDate(0, 1, 0)
+
+	done := r.begin(ctx, "write_off_report", writeOffsTable, "aggregate")
+	defer done()
+
+	var writtenOff []struct {
+		Period    string
+		Count     int64
+		Amount    decimal.Decimal
+		Recovered decimal.Decimal
+	}
+	err := r.db.WithContext(ctx).Model(&model.WriteOff{}).
+		Select("DATE_FORMAT(reviewed_at, '%Y-%m') AS period, COUNT(*) AS count, SUM(amount) AS amount, SUM(recovered_amount) AS recovered").
+		Where("status = ? AND reviewed_at >= ? AND reviewed_at < ?", domain.WriteOffApproved, from, end).
+		Group("period").
+		Scan(&writtenOff).Error
+	if err != nil {
+		r.recordError(ctx, writeOffsTable, "aggregate", "Error summarising write-offs", err)
+		return nil, err
+	}
+
+	var collected []struct {
+		Period string
+		Amount decimal.Decimal
+	}
+	err = r.db.WithContext(ctx).Model(&model.WriteOffRecovery{}).
+		Select("DATE_FORMAT(received_on, '%Y-%m') AS period, SUM(amount) AS amount").
+		Where("received_on >= ? AND received_on < ?", from, end).
+		Group("period").
+		Scan(&collected).Error
+	if err != nil {
+		r.recordError(ctx, recoveriesTable, "aggregate", "Error summarising write-off recoveries", err)
+		return nil, err
+	}
+
+	// Setiap bulan dalam rentang tetap muncul walaupun tanpa write-off
+	var periods []domain.WriteOffPeriod
+	index := make(map[string]int)
+	for month := from; month.Before(end); month = month.AddDate(0, 1, 0) {
+		index[month.Format("2006-01")] = len(periods)
+		periods = append(periods, domain.WriteOffPeriod{
+			Period:    month,
+			Amount:    decimal.Zero,
+			Recovered: decimal.Zero,
+			Collected: decimal.Zero,
+		})
+	}
+	for _, row := range writtenOff {
+		if i, ok := index[row.Period]; ok {
+			periods[i].Count = row.Count
+			periods[i].Amount = row.Amount
+			periods[i].Recovered = row.Recovered
+		}
+	}
+	for _, row := range collected {
+		if i, ok := index[row.Period]; ok {
+			periods[i].Collected = row.Amount
+		}
+	}
+
+	return periods, nil
+}
+
+// begin records the connection and query metrics shared by every method and
+// returns the deferred connection release.
+func (r *writeOffRepository) begin(ctx context.Context, operation, table, dbOperation string) func() {
+	attrs := metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	r.connectionGauge.Add(ctx, 1, attrs)
+
+	r.queryCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+		),
+	)
+
+	return func() { r.connectionGauge.Add(ctx, -1, attrs) }
+}
+
+func (r *writeOffRepository) recordError(
+	ctx context.Context, table, dbOperation, message string, err error, fields ...zap.Field) {
+	span := trace.SpanFromContext(ctx)
+
+	r.log.Error(message, append(fields,
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)...)
+
+	r.errorCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", dbOperation),
+			attribute.String("table", table),
+			attribute.String("error", err.Error()),
+		),
+	)
+}
+
+func NewWriteOffRepository(
+	db *gorm.DB,
+	meter metric.Meter,
+	log *zap.Logger,
+) repository.WriteOffRepository {
+	queryCount, _ := meter.Int64Counter(
+		"db.query.count",
+		metric.WithDescription("Number of database queries"),
+		metric.WithUnit("{query}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"db.error.count",
+		metric.WithDescription("Number of database errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	connectionGauge, _ := meter.Int64UpDownCounter(
+		"db.connections",
+		metric.WithDescription("Number of active database connections"),
+		metric.WithUnit("{connection}"),
+	)
+
+	documentsRetrieved, _ := meter.Int64Counter(
+		"db.documents.retrieved",
+		metric.WithDescription("Number of documents retrieved from the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	documentsInserted, _ := meter.Int64Counter(
+		"db.documents.inserted",
+		metric.WithDescription("Number of documents inserted into the database"),
+		metric.WithUnit("{document}"),
+	)
+
+	return &writeOffRepository{
+		db:                 db,
+		meter:              meter,
+		log:                log,
+		queryCount:         queryCount,
+		errorCount:         errorCount,
+		connectionGauge:    connectionGauge,
+		documentsRetrieved: documentsRetrieved,
+		documentsInserted:  documentsInserted,
+	}
+}
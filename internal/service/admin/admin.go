@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
@@ -12,10 +11,14 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
 	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
 	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
 	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
+	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
 	"github.com/fazamuttaqien/multifinance/internal/service"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	"go.opentelemetry.io/otel"
@@ -29,6 +32,9 @@ import (
 type adminService struct {
 	db                 *gorm.DB
 	customerRepository repository.CustomerRepository
+	limitRepository    repository.LimitRepository
+	notifier           service.CustomerNotifier
+	currencyConverter  service.CurrencyConverter
 	meter              metric.Meter
 	tracer             trace.Tracer
 	log                *zap.Logger
@@ -41,7 +47,7 @@ type adminService struct {
 }
 
 // SetLimits implements AdminUsecases.
-func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto.SetLimits) error {
+func (a *adminService) SetLimits(ctx context.Context, customerID, changedBy uint64, req dto.SetLimits) error {
 	ctx, span := a.tracer.Start(ctx, "service.SetLimits")
 	defer span.End()
 
@@ -63,6 +69,7 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 	span.SetAttributes(
 		attribute.Int64("customer.id", int64(customerID)),
 		attribute.Int("limits.count", len(req.Limits)),
+		attribute.Int64("admin.id", int64(changedBy)),
 		attribute.String("service", "admin"),
 	)
 
@@ -100,7 +107,7 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 	defer tx.Rollback()
 
 	// 1. Validasi customer
-	customerTx := customerrepo.NewCustomerRepository(tx, a.meter, a.tracer, a.log)
+	customerTx := customerrepo.NewCustomerRepository(tx, a.meter, a.log)
 	customer, err := customerTx.FindByID(ctx, customerID)
 	if err != nil {
 		span.SetStatus(codes.Error, "Failed to find customer")
@@ -162,17 +169,56 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 		return err
 	}
 
+	// Baris customer dikunci seperti saat transaksi dibuat, agar pokok yang
+	// dibandingkan dengan limit baru tidak bertambah sebelum commit
+	if _, err := customerTx.FindByNIKWithLock(ctx, customer.NIK); err != nil {
+		span.SetStatus(codes.Error, "Failed to lock customer")
+		span.RecordError(err)
+
+		a.log.Error("Failed to lock customer",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "set_limits"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "customer_lock_error"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "set_limits"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return fmt.Errorf("error locking customer: %w", err)
+	}
+
 	limitsToUpsert := make([]domain.CustomerLimit, 0, len(req.Limits))
 	tenorTx := tenorrepo.NewTenorRepository(
 		tx,
 		otel.GetMeterProvider().Meter(""),
-		otel.GetTracerProvider().Tracer(""),
 		zap.L(),
 	)
+	transactionTx := transactionrepo.NewTransactionRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	// Pokok berjalan per tenor yang limitnya dipaksa di bawah pemakaian
+	forced := make(map[uint8]decimal.Decimal)
 
 	// 2. Loop dan validasi setiap item limit dalam request
+	now := time.Now()
 	for _, item := range req.Limits {
-		if item.LimitAmount < 0 {
+		if item.LimitAmount.IsNegative() {
 			err := common.ErrInvalidLimitAmount
 			span.SetStatus(codes.Error, "Invalid limit amount")
 			span.RecordError(err)
@@ -180,7 +226,7 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 			a.log.Error("Invalid limit amount",
 				zap.Uint64("customer_id", customerID),
 				zap.Uint8("tenor_months", item.TenorMonths),
-				zap.Float64("limit_amount", item.LimitAmount),
+				zap.Stringer("limit_amount", item.LimitAmount),
 				zap.String("trace_id", span.SpanContext().TraceID().String()),
 			)
 
@@ -204,6 +250,39 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 			return err
 		}
 
+		// Perubahan hanya bisa dijadwalkan ke depan, limit yang sudah lewat tidak ditulis ulang
+		if item.EffectiveFrom != nil && item.EffectiveFrom.Before(now) {
+			err := common.ErrInvalidEffectiveDate
+			span.SetStatus(codes.Error, "Invalid limit effective date")
+			span.RecordError(err)
+
+			a.log.Warn("Limit effective date is in the past",
+				zap.Uint64("customer_id", customerID),
+				zap.Uint8("tenor_months", item.TenorMonths),
+				zap.Time("effective_from", *item.EffectiveFrom),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+			)
+
+			a.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("error_type", "invalid_effective_date"),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			a.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return err
+		}
+
 		// Cari tenor ID berdasarkan durasi bulan
 		tenor, err := tenorTx.FindByDuration(ctx, item.TenorMonths)
 		if err != nil {
@@ -268,18 +347,138 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 			return err
 		}
 
+		// Limit di bawah pokok yang masih berjalan membuat sisa limit negatif
+		usedAmount, err := transactionTx.SumActivePrincipalByCustomerIDAndTenorID(ctx, customerID, tenor.ID)
+		if err != nil {
+			span.SetStatus(codes.Error, "Error calculating used amount")
+			span.RecordError(err)
+
+			a.log.Error("Error summing active principal",
+				zap.Uint64("customer_id", customerID),
+				zap.Uint("tenor_id", tenor.ID),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+				zap.Error(err),
+			)
+
+			a.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("error_type", "sum_principal_error"),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			a.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return fmt.Errorf("error summing used amount for %d months: %w", item.TenorMonths, err)
+		}
+
+		limitRate, err := a.rateToIDR(ctx, item.Currency)
+		if err != nil {
+			span.SetStatus(codes.Error, "Failed to convert limit currency")
+			span.RecordError(err)
+
+			a.log.Error("Failed to get rate for limit currency",
+				zap.Uint64("customer_id", customerID),
+				zap.String("currency", item.Currency),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+				zap.Error(err),
+			)
+
+			a.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("error_type", "fx_rate_error"),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			a.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return err
+		}
+
+		if currency.ToIDR(item.LimitAmount, limitRate).LessThan(usedAmount) {
+			if !req.Force {
+				err := fmt.Errorf("%w: %d months limit %s %s, used %s IDR", common.ErrLimitBelowUsage, item.TenorMonths, item.LimitAmount, currency.Normalize(item.Currency), usedAmount)
+				span.SetStatus(codes.Error, "Limit below usage")
+				span.RecordError(err)
+
+				a.log.Warn("Limit is below the customer's active principal",
+					zap.Uint64("customer_id", customerID),
+					zap.Uint8("tenor_months", item.TenorMonths),
+					zap.Stringer("limit_amount", item.LimitAmount),
+					zap.Stringer("used_amount", usedAmount),
+					zap.String("trace_id", span.SpanContext().TraceID().String()),
+				)
+
+				a.errorCount.Add(ctx, 1,
+					metric.WithAttributes(
+						attribute.String("operation", "set_limits"),
+						attribute.String("service", "admin"),
+						attribute.String("error_type", "limit_below_usage"),
+					),
+				)
+
+				duration := float64(time.Since(start).Milliseconds())
+				a.operationDuration.Record(ctx, duration,
+					metric.WithAttributes(
+						attribute.String("operation", "set_limits"),
+						attribute.String("service", "admin"),
+						attribute.String("status", "error"),
+					),
+				)
+
+				return err
+			}
+
+			a.log.Warn("Limit forced below the customer's active principal",
+				zap.Uint64("customer_id", customerID),
+				zap.Uint64("admin_id", changedBy),
+				zap.Uint8("tenor_months", item.TenorMonths),
+				zap.Stringer("limit_amount", item.LimitAmount),
+				zap.Stringer("used_amount", usedAmount),
+				zap.String("reason", req.Reason),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+			)
+			forced[item.TenorMonths] = usedAmount
+		}
+
 		// Menyiapkan data untuk di upsert
-		limitsToUpsert = append(limitsToUpsert, domain.CustomerLimit{
-			CustomerID:  customerID,
-			TenorID:     tenor.ID,
-			LimitAmount: item.LimitAmount,
-		})
+		limit := domain.CustomerLimit{
+			CustomerID:    customerID,
+			TenorID:       tenor.ID,
+			LimitAmount:   item.LimitAmount,
+			Currency:      currency.Normalize(item.Currency),
+			EffectiveFrom: now,
+		}
+		if item.Version != nil {
+			limit.Version = *item.Version
+		}
+		if item.EffectiveFrom != nil {
+			limit.EffectiveFrom = *item.EffectiveFrom
+		}
+		limitsToUpsert = append(limitsToUpsert, limit)
 
 		a.log.Debug("Prepared limit for upsert",
 			zap.Uint64("customer_id", customerID),
 			zap.Uint("tenor_id", tenor.ID),
 			zap.Uint8("tenor_months", item.TenorMonths),
-			zap.Float64("limit_amount", item.LimitAmount),
+			zap.Stringer("limit_amount", item.LimitAmount),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 		)
 	}
@@ -289,10 +488,10 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 		limitTx := limitrepo.NewLimitRepository(
 			tx,
 			otel.GetMeterProvider().Meter(""),
-			otel.GetTracerProvider().Tracer(""),
 			zap.L(),
 		)
-		if err := limitTx.UpsertMany(ctx, limitsToUpsert); err != nil {
+		applied, err := limitTx.UpsertMany(ctx, limitsToUpsert, changedBy)
+		if err != nil {
 			span.SetStatus(codes.Error, "Failed to upsert limits")
 			span.RecordError(err)
 
@@ -322,6 +521,86 @@ func (a *adminService) SetLimits(ctx context.Context, customerID uint64, req dto
 
 			return fmt.Errorf("failed to upsert limits: %w", err)
 		}
+		if !applied {
+			err := common.ErrVersionConflict
+			span.SetStatus(codes.Error, "Limit version conflict")
+			span.RecordError(err)
+
+			a.log.Warn("Limits changed since they were read",
+				zap.Uint64("customer_id", customerID),
+				zap.Int("limits_count", len(limitsToUpsert)),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+			)
+
+			a.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("error_type", "version_conflict"),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			a.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return err
+		}
+
+		// Event timeline ikut transaksi yang sama, tidak tercatat bila upsert batal
+		event := &domain.CustomerEvent{
+			CustomerID: customerID,
+			Type:       domain.CustomerLimitSet,
+			ActorID:    &changedBy,
+			Data:       make(map[string]string, len(req.Limits)),
+		}
+		for _, item := range req.Limits {
+			value := item.LimitAmount.String() + " " + currency.Normalize(item.Currency)
+			if item.EffectiveFrom != nil {
+				value += " from " + item.EffectiveFrom.UTC().Format(time.RFC3339)
+			}
+			event.Data[fmt.Sprintf("tenor_%d_months", item.TenorMonths)] = value
+			if used, ok := forced[item.TenorMonths]; ok {
+				event.Data[fmt.Sprintf("tenor_%d_months_used", item.TenorMonths)] = used.String() + " IDR"
+			}
+		}
+		if len(forced) > 0 {
+			event.Data["forced_below_usage"] = req.Reason
+		}
+		if err := appendCustomerEvent(ctx, tx, event); err != nil {
+			span.SetStatus(codes.Error, "Failed to record limit event")
+			span.RecordError(err)
+
+			a.log.Error("Failed to record limit event",
+				zap.Uint64("customer_id", customerID),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+				zap.Error(err),
+			)
+
+			a.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("error_type", "event_failed"),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			a.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "set_limits"),
+					attribute.String("service", "admin"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return fmt.Errorf("failed to record limit event: %w", err)
+		}
 	}
 
 	// 4. Jika semua berhasil, commit transaksi
@@ -562,18 +841,7 @@ func (a *adminService) ListCustomers(ctx context.Context, params domain.Params)
 		return nil, err
 	}
 
-	totalPages := 0
-	if params.Limit > 0 {
-		totalPages = int(math.Ceil(float64(total) / float64(params.Limit)))
-	}
-
-	result := &domain.Paginated{
-		Data:       customers,
-		Total:      total,
-		Page:       params.Page,
-		Limit:      params.Limit,
-		TotalPages: totalPages,
-	}
+	result := params.Paginated(customers, total)
 
 	duration := float64(time.Since(start).Milliseconds())
 	a.operationDuration.Record(ctx, duration,
@@ -587,7 +855,7 @@ func (a *adminService) ListCustomers(ctx context.Context, params domain.Params)
 	a.log.Info("Customers listed successfully",
 		zap.Int64("total_customers", total),
 		zap.Int("current_page", params.Page),
-		zap.Int("total_pages", totalPages),
+		zap.Int("total_pages", result.TotalPages),
 		zap.Int("returned_count", len(customers)),
 		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
@@ -597,7 +865,7 @@ func (a *adminService) ListCustomers(ctx context.Context, params domain.Params)
 	span.SetStatus(codes.Ok, "Customers listed successfully")
 	span.SetAttributes(
 		attribute.Int64("customers.total", total),
-		attribute.Int("pagination.total_pages", totalPages),
+		attribute.Int("pagination.total_pages", result.TotalPages),
 		attribute.Int("customers.returned", len(customers)),
 	)
 
@@ -735,10 +1003,80 @@ func (a *adminService) VerifyCustomer(ctx context.Context, customerID uint64, re
 		return err
 	}
 
+	// Mutasi rekening yang jauh dari gaji yang diakui harus diputuskan dulu sebelum customer disetujui
+	if req.Status == domain.VerificationVerified {
+		var flagged int64
+		if err := tx.Model(&model.IncomeVerification{}).
+			Where("customer_id = ? AND status = ?", customerID, model.IncomeFlagged).
+			Count(&flagged).Error; err != nil {
+			span.SetStatus(codes.Error, "Failed to check income verifications")
+			span.RecordError(err)
+
+			a.log.Error("Failed to check income verifications",
+				zap.Uint64("customer_id", customerID),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+				zap.Error(err),
+			)
+
+			a.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "verify_customer"),
+					attribute.String("service", "admin"),
+					attribute.String("error_type", "repository_error"),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			a.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "verify_customer"),
+					attribute.String("service", "admin"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return err
+		}
+
+		if flagged > 0 {
+			err := common.ErrIncomeReviewPending
+			span.SetStatus(codes.Error, "Flagged income verification awaits review")
+			span.RecordError(err)
+
+			a.log.Warn("Customer verification blocked by flagged income verification",
+				zap.Uint64("customer_id", customerID),
+				zap.Int64("flagged", flagged),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+			)
+
+			a.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "verify_customer"),
+					attribute.String("service", "admin"),
+					attribute.String("error_type", "income_review_pending"),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			a.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "verify_customer"),
+					attribute.String("service", "admin"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return err
+		}
+	}
+
 	oldStatus := customer.VerificationStatus
 	customer.VerificationStatus = model.VerificationStatus(req.Status)
 
-	if err := tx.Model(&customer).Update("verification_status", req.Status).Error; err != nil {
+	if err := tx.Model(&customer).Updates(map[string]any{
+		"verification_status": req.Status,
+		"version":             gorm.Expr("version + 1"),
+	}).Error; err != nil {
 		span.SetStatus(codes.Error, "Failed to update verification status")
 		span.RecordError(err)
 
@@ -770,6 +1108,46 @@ func (a *adminService) VerifyCustomer(ctx context.Context, customerID uint64, re
 		return err
 	}
 
+	event := &domain.CustomerEvent{
+		CustomerID: customerID,
+		Type:       domain.CustomerVerified,
+	}
+	if req.Status == domain.VerificationRejected {
+		event.Type = domain.CustomerRejected
+		if req.Reason != "" {
+			event.Data = map[string]string{"reason": req.Reason}
+		}
+	}
+	if err := appendCustomerEvent(ctx, tx, event); err != nil {
+		span.SetStatus(codes.Error, "Failed to record verification event")
+		span.RecordError(err)
+
+		a.log.Error("Failed to record verification event",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "verify_customer"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "event_failed"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "verify_customer"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		span.SetStatus(codes.Error, "Failed to commit transaction")
 		span.RecordError(err)
@@ -800,6 +1178,20 @@ func (a *adminService) VerifyCustomer(ctx context.Context, customerID uint64, re
 		return err
 	}
 
+	// Notifikasi dikirim setelah commit dan kegagalannya tidak membatalkan verifikasi
+	template, data := domain.TemplateVerificationApproved, map[string]string{"full_name": customer.FullName}
+	if req.Status == domain.VerificationRejected {
+		template = domain.TemplateVerificationRejected
+		data["reason"] = req.Reason
+	}
+	if err := a.notifier.Notify(ctx, customerID, template, data); err != nil {
+		a.log.Warn("Failed to send verification result",
+			zap.Uint64("customer_id", customerID),
+			zap.String("template", string(template)),
+			zap.Error(err),
+		)
+	}
+
 	a.customersVerified.Add(ctx, 1,
 		metric.WithAttributes(
 			attribute.String("service", "admin"),
@@ -834,9 +1226,299 @@ func (a *adminService) VerifyCustomer(ctx context.Context, customerID uint64, re
 	return nil
 }
 
+// GetLimitHistory implements AdminServices.
+func (a *adminService) GetLimitHistory(ctx context.Context, customerID uint64) ([]domain.LimitChange, error) {
+	ctx, span := a.tracer.Start(ctx, "service.GetLimitHistory")
+	defer span.End()
+
+	start := time.Now()
+
+	a.operationCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "get_limit_history"),
+			attribute.String("service", "admin"),
+		),
+	)
+
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("service", "admin"),
+	)
+
+	customer, err := a.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to find customer")
+		span.RecordError(err)
+
+		a.log.Error("Failed to find customer",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limit_history"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "customer_lookup_error"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limit_history"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, fmt.Errorf("error finding customer: %w", err)
+	}
+
+	if customer == nil {
+		err := common.ErrCustomerNotFound
+		span.SetStatus(codes.Error, "Customer not found")
+		span.RecordError(err)
+
+		a.log.Warn("Customer not found for limit history",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limit_history"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "customer_not_found"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limit_history"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, err
+	}
+
+	history, err := a.limitRepository.FindHistoryByCustomerID(ctx, customerID)
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to find limit history")
+		span.RecordError(err)
+
+		a.log.Error("Failed to find limit history",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limit_history"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "repository_error"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limit_history"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, fmt.Errorf("failed to find limit history: %w", err)
+	}
+
+	duration := float64(time.Since(start).Milliseconds())
+	a.operationDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("operation", "get_limit_history"),
+			attribute.String("service", "admin"),
+			attribute.String("status", "success"),
+		),
+	)
+
+	a.log.Info("Limit history retrieved successfully",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("changes", len(history)),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	span.SetStatus(codes.Ok, "Limit history retrieved successfully")
+	span.SetAttributes(attribute.Int("result.retrieved", len(history)))
+
+	return history, nil
+}
+
+// GetLimitsAt implements AdminServices.
+func (a *adminService) GetLimitsAt(ctx context.Context, customerID uint64, at time.Time) ([]domain.CustomerLimit, error) {
+	ctx, span := a.tracer.Start(ctx, "service.GetLimitsAt")
+	defer span.End()
+
+	start := time.Now()
+
+	a.operationCount.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("operation", "get_limits_at"),
+			attribute.String("service", "admin"),
+		),
+	)
+
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("limits.at", at.UTC().Format(time.RFC3339)),
+		attribute.String("service", "admin"),
+	)
+
+	customer, err := a.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to find customer")
+		span.RecordError(err)
+
+		a.log.Error("Failed to find customer",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limits_at"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "customer_lookup_error"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limits_at"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, fmt.Errorf("error finding customer: %w", err)
+	}
+
+	if customer == nil {
+		err := common.ErrCustomerNotFound
+		span.SetStatus(codes.Error, "Customer not found")
+		span.RecordError(err)
+
+		a.log.Warn("Customer not found for limits in force",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limits_at"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "customer_not_found"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limits_at"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, err
+	}
+
+	limits, err := a.limitRepository.FindAllByCustomerID(ctx, customerID, at)
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to find limits")
+		span.RecordError(err)
+
+		a.log.Error("Failed to find limits",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		a.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limits_at"),
+				attribute.String("service", "admin"),
+				attribute.String("error_type", "repository_error"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		a.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "get_limits_at"),
+				attribute.String("service", "admin"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, fmt.Errorf("failed to find limits: %w", err)
+	}
+
+	duration := float64(time.Since(start).Milliseconds())
+	a.operationDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("operation", "get_limits_at"),
+			attribute.String("service", "admin"),
+			attribute.String("status", "success"),
+		),
+	)
+
+	a.log.Info("Limits in force retrieved successfully",
+		zap.Uint64("customer_id", customerID),
+		zap.Time("at", at),
+		zap.Int("limits", len(limits)),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	span.SetStatus(codes.Ok, "Limits in force retrieved successfully")
+	span.SetAttributes(attribute.Int("result.retrieved", len(limits)))
+
+	return limits, nil
+}
+
+// rateToIDR returns 1 for IDR so limits in the book currency never need an
+// uploaded rate.
+func (a *adminService) rateToIDR(ctx context.Context, code string) (decimal.Decimal, error) {
+	if currency.IsBase(code) {
+		return decimal.NewFromInt(1), nil
+	}
+	return a.currencyConverter.RateToIDR(ctx, code, time.Now())
+}
+
+// appendCustomerEvent writes a timeline event on tx so it commits, or rolls
+// back, together with the change it describes.
+func appendCustomerEvent(ctx context.Context, tx *gorm.DB, event *domain.CustomerEvent) error {
+	return customereventrepo.NewCustomerEventRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	).Append(ctx, event)
+}
+
 func NewAdminService(
 	db *gorm.DB,
 	customerRepository repository.CustomerRepository,
+	limitRepository repository.LimitRepository,
+	notifier service.CustomerNotifier,
+	currencyConverter service.CurrencyConverter,
 	meter metric.Meter,
 	tracer trace.Tracer,
 	log *zap.Logger,
@@ -880,6 +1562,9 @@ func NewAdminService(
 	return &adminService{
 		db:                 db,
 		customerRepository: customerRepository,
+		limitRepository:    limitRepository,
+		notifier:           notifier,
+		currencyConverter:  currencyConverter,
 		meter:              meter,
 		tracer:             tracer,
 		log:                log,
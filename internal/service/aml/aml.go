@@ -0,0 +1,397 @@
+package amlsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	amlrepo "github.com/fazamuttaqien/multifinance/internal/repository/aml"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MaxExportDays is the longest range Export covers.
+const MaxExportDays = 366
+
+// Config holds the screening rules. A transaction worth at least
+// LargeAmount in IDR opens a LARGE_TRANSACTION case; RapidCount or more
+// transactions of one customer within RapidWindow open a RAPID_SEQUENCE
+// case. A zero LargeAmount or RapidCount disables that rule.
+type Config struct {
+	LargeAmount decimal.Decimal
+	RapidCount  int
+	RapidWindow time.Duration
+	BatchSize   int
+}
+
+type amlService struct {
+	db            *gorm.DB
+	amlRepository repository.AMLRepository
+	cfg           Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	screenedCount     metric.Int64Counter
+	casesOpened       metric.Int64Counter
+}
+
+// Screen implements AMLServices.
+func (s *amlService) Screen(ctx context.Context, now time.Time) (*domain.AMLScreeningRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ScreenTransactions")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "screen_transactions"), attribute.String("service", "aml")))
+
+	transactions, err := s.amlRepository.FindUnscreened(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "screen_transactions", "repository_error", fmt.Errorf("failed to find unscreened transactions: %w", err))
+	}
+
+	run := &domain.AMLScreeningRun{}
+	for _, transaction := range transactions {
+		cases, err := s.evaluate(ctx, transaction)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "screen_transactions", "repository_error", fmt.Errorf("failed to screen transaction %d: %w", transaction.ID, err))
+		}
+
+		// Kasus dan penanda screening disimpan bersama supaya transaksi
+		// tidak discreening ulang atau terlewat kalau job berhenti di tengah
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			amlTx := amlrepo.NewAMLRepository(tx, s.meter, s.log)
+			for i := range cases {
+				if err := amlTx.CreateCase(ctx, &cases[i]); err != nil {
+					return err
+				}
+			}
+			return amlTx.MarkScreened(ctx, transaction.ID, now)
+		})
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "screen_transactions", "repository_error", fmt.Errorf("failed to record screening of transaction %d: %w", transaction.ID, err))
+		}
+
+		run.Screened++
+		run.Flagged += len(cases)
+		for _, amlCase := range cases {
+			s.casesOpened.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "aml"), attribute.String("rule", string(amlCase.Rule))))
+		}
+	}
+
+	s.screenedCount.Add(ctx, int64(run.Screened), metric.WithAttributes(attribute.String("service", "aml")))
+	span.SetAttributes(
+		attribute.Int("aml.screened", run.Screened),
+		attribute.Int("aml.flagged", run.Flagged),
+	)
+	s.recordSuccess(ctx, span, start, "screen_transactions",
+		zap.Int("screened", run.Screened),
+		zap.Int("flagged", run.Flagged),
+	)
+
+	return run, nil
+}
+
+// evaluate runs every rule against transaction and returns the cases to
+// open for it.
+func (s *amlService) evaluate(ctx context.Context, transaction domain.Transaction) ([]domain.AMLCase, error) {
+	amount := amountIDR(transaction)
+
+	var cases []domain.AMLCase
+	if s.cfg.LargeAmount.IsPositive() && amount.GreaterThanOrEqual(s.cfg.LargeAmount) {
+		cases = append(cases, domain.AMLCase{
+			CustomerID:    transaction.CustomerID,
+			TransactionID: transaction.ID,
+			Rule:          domain.AMLLargeTransaction,
+			AmountIDR:     amount,
+			Detail:        fmt.Sprintf("amount of IDR %s reaches the IDR %s threshold", amount.StringFixed(2), s.cfg.LargeAmount.StringFixed(2)),
+		})
+	}
+
+	if s.cfg.RapidCount > 0 {
+		from := transaction.TransactionDate.Add(-s.cfg.RapidWindow)
+		count, err := s.amlRepository.CountRecent(ctx, transaction.CustomerID, from, transaction.TransactionDate)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(s.cfg.RapidCount) {
+			// Satu rangkaian cukup satu kasus, transaksi berikutnya di
+			// jendela yang sama tidak membuka kasus baru
+			exists, err := s.amlRepository.HasRecentCase(ctx, transaction.CustomerID, domain.AMLRapidSequence, from)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				cases = append(cases, domain.AMLCase{
+					CustomerID:    transaction.CustomerID,
+					TransactionID: transaction.ID,
+					Rule:          domain.AMLRapidSequence,
+					AmountIDR:     amount,
+					Detail:        fmt.Sprintf("%d transactions within %s", count, s.cfg.RapidWindow),
+				})
+			}
+		}
+	}
+
+	return cases, nil
+}
+
+// ListCases implements AMLServices.
+func (s *amlService) ListCases(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListAMLCases")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.status", params.Value("status")),
+		attribute.String("filter.rule", params.Value("rule")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_aml_cases"), attribute.String("service", "aml")))
+
+	cases, total, err := s.amlRepository.FindCasesPaginated(ctx, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_aml_cases", "repository_error", fmt.Errorf("failed to list AML cases: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_aml_cases", zap.Int64("total", total))
+
+	return params.Paginated(cases, total), nil
+}
+
+// GetCase implements AMLServices.
+func (s *amlService) GetCase(ctx context.Context, id uint64) (*domain.AMLCase, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetAMLCase")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("aml_case.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_aml_case"), attribute.String("service", "aml")))
+
+	amlCase, err := s.amlRepository.FindCaseByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_aml_case", "repository_error", fmt.Errorf("failed to get AML case: %w", err))
+	}
+	if amlCase == nil {
+		return nil, s.recordError(ctx, span, start, "get_aml_case", "not_found", common.ErrAMLCaseNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_aml_case", zap.Uint64("aml_case_id", id))
+
+	return amlCase, nil
+}
+
+// UpdateStatus implements AMLServices.
+func (s *amlService) UpdateStatus(ctx context.Context, id, adminID uint64, req dto.AMLCaseStatusRequest) (*domain.AMLCase, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UpdateAMLCaseStatus")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("aml_case.id", int64(id)),
+		attribute.Int64("admin.id", int64(adminID)),
+		attribute.String("aml_case.status", string(req.Status)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_aml_case_status"), attribute.String("service", "aml")))
+
+	amlCase, err := s.amlRepository.FindCaseByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_aml_case_status", "repository_error", fmt.Errorf("failed to get AML case: %w", err))
+	}
+	if amlCase == nil {
+		return nil, s.recordError(ctx, span, start, "update_aml_case_status", "not_found", common.ErrAMLCaseNotFound)
+	}
+
+	from := amlCase.Status
+	if !from.CanMoveTo(req.Status) {
+		return nil, s.recordError(ctx, span, start, "update_aml_case_status", "invalid_transition", common.ErrInvalidAMLTransition)
+	}
+
+	now := time.Now()
+	amlCase.Status = req.Status
+	if req.Note != "" {
+		amlCase.ReviewNote = req.Note
+	}
+	// Kasus yang langsung dilaporkan tetap mencatat siapa yang menelaahnya
+	if amlCase.ReviewedBy == nil {
+		amlCase.ReviewedBy = &adminID
+		amlCase.ReviewedAt = &now
+	}
+	if req.Status == domain.AMLCaseReported {
+		amlCase.ReportReference = req.ReportReference
+		amlCase.ReportedAt = &now
+	}
+
+	updated, err := s.amlRepository.UpdateCase(ctx, amlCase, from)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_aml_case_status", "repository_error", fmt.Errorf("failed to update AML case: %w", err))
+	}
+	// Admin lain sudah lebih dulu mengubah status kasus
+	if !updated {
+		return nil, s.recordError(ctx, span, start, "update_aml_case_status", "invalid_transition", common.ErrInvalidAMLTransition)
+	}
+
+	s.recordSuccess(ctx, span, start, "update_aml_case_status",
+		zap.Uint64("aml_case_id", id),
+		zap.Uint64("admin_id", adminID),
+		zap.String("from", string(from)),
+		zap.String("status", string(req.Status)),
+	)
+
+	return amlCase, nil
+}
+
+// Export implements AMLServices.
+func (s *amlService) Export(ctx context.Context, from, to string, status domain.AMLCaseStatus) ([]domain.AMLCase, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ExportAMLCases")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("aml.from", from),
+		attribute.String("aml.to", to),
+		attribute.String("filter.status", string(status)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "export_aml_cases"), attribute.String("service", "aml")))
+
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "export_aml_cases", "invalid_range", common.ErrInvalidAMLExportRange)
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "export_aml_cases", "invalid_range", common.ErrInvalidAMLExportRange)
+	}
+	// Tanggal akhir ikut dihitung sampai akhir harinya
+	toDate = toDate.AddDate(0, 0, 1)
+	if !toDate.After(fromDate) || toDate.Sub(fromDate) > MaxExportDays*24*time.Hour {
+		return nil, s.recordError(ctx, span, start, "export_aml_cases", "invalid_range", common.ErrInvalidAMLExportRange)
+	}
+
+	cases, err := s.amlRepository.FindForExport(ctx, fromDate, toDate, status)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "export_aml_cases", "repository_error", fmt.Errorf("failed to export AML cases: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "export_aml_cases",
+		zap.String("from", from),
+		zap.String("to", to),
+		zap.Int("cases", len(cases)),
+	)
+
+	return cases, nil
+}
+
+// amountIDR converts the OTR amount of transaction to rupiah. Contracts
+// booked before FX support carry no rate and are already in rupiah.
+func amountIDR(transaction domain.Transaction) decimal.Decimal {
+	if !transaction.FxRate.IsPositive() {
+		return transaction.OTRAmount
+	}
+	return transaction.OTRAmount.Mul(transaction.FxRate).Round(2)
+}
+
+func (s *amlService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("AML operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "aml"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "aml"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *amlService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "aml"), attribute.String("status", "success")))
+
+	s.log.Info("AML operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewAMLService(
+	db *gorm.DB,
+	amlRepository repository.AMLRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.AMLServices {
+	if cfg.RapidWindow <= 0 {
+		cfg.RapidWindow = 24 * time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 200
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	screenedCount, _ := meter.Int64Counter(
+		"service.aml.screened",
+		metric.WithDescription("Number of transactions screened for AML"),
+		metric.WithUnit("{transaction}"),
+	)
+
+	casesOpened, _ := meter.Int64Counter(
+		"service.aml.cases_opened",
+		metric.WithDescription("Number of AML cases opened"),
+		metric.WithUnit("{case}"),
+	)
+
+	return &amlService{
+		db:                db,
+		amlRepository:     amlRepository,
+		cfg:               cfg,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+		screenedCount:     screenedCount,
+		casesOpened:       casesOpened,
+	}
+}
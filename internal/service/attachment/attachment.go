@@ -0,0 +1,308 @@
+package attachmentsrv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// allowedContentTypes lists the sniffed content types a partner may upload.
+// The declared content type of the upload is ignored.
+var allowedContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+type Config struct {
+	Folder  string
+	MaxSize int64
+}
+
+type attachmentService struct {
+	attachmentRepository repository.TransactionAttachmentRepository
+	cloudinaryService    service.CloudinaryService
+	cfg                  Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// UploadAttachment implements TransactionAttachmentServices.
+func (s *attachmentService) UploadAttachment(
+	ctx context.Context, owner domain.BatchOwner, transactionID uint64,
+	req dto.TransactionAttachmentRequest, fileName string, data []byte) (*domain.TransactionAttachment, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UploadTransactionAttachment")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("transaction.id", int64(transactionID)),
+		attribute.String("attachment.type", string(req.Type)),
+		attribute.Int("attachment.size", len(data)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "upload_attachment"), attribute.String("service", "attachment")))
+
+	if int64(len(data)) > s.cfg.MaxSize {
+		return nil, s.recordError(ctx, span, start, "upload_attachment", "too_large", common.ErrAttachmentTooLarge)
+	}
+
+	// Jenis file ditentukan dari isinya, bukan dari nama atau header yang dikirim partner
+	contentType := http.DetectContentType(data)
+	if len(data) == 0 || !allowedContentTypes[contentType] {
+		return nil, s.recordError(ctx, span, start, "upload_attachment", "unsupported_type", common.ErrUnsupportedAttachment)
+	}
+
+	if _, err := s.ownedTransaction(ctx, owner, transactionID); err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_attachment", "transaction_lookup_error", err)
+	}
+
+	publicID := fmt.Sprintf("transaction-%d-%d", transactionID, time.Now().UnixNano())
+	url, err := s.cloudinaryService.UploadFile(ctx, data, s.cfg.Folder, publicID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_attachment", "upload_error", fmt.Errorf("failed to upload attachment: %w", err))
+	}
+
+	attachment := &domain.TransactionAttachment{
+		TransactionID: transactionID,
+		Type:          req.Type,
+		FileName:      sanitizeFileName(fileName),
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		URL:           url,
+		PartnerID:     owner.PartnerID,
+		CustomerID:    owner.CustomerID,
+	}
+	if err := s.attachmentRepository.Create(ctx, attachment); err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_attachment", "repository_error", fmt.Errorf("failed to create attachment: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "upload_attachment",
+		zap.Uint64("attachment_id", attachment.ID),
+		zap.Uint64("transaction_id", transactionID),
+		zap.String("type", string(req.Type)),
+		zap.String("content_type", contentType),
+		zap.Int64("size", attachment.Size),
+	)
+
+	return attachment, nil
+}
+
+// ListAttachments implements TransactionAttachmentServices.
+func (s *attachmentService) ListAttachments(ctx context.Context, owner domain.BatchOwner, transactionID uint64) ([]domain.TransactionAttachment, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListTransactionAttachments")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("transaction.id", int64(transactionID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_attachments"), attribute.String("service", "attachment")))
+
+	if _, err := s.ownedTransaction(ctx, owner, transactionID); err != nil {
+		return nil, s.recordError(ctx, span, start, "list_attachments", "transaction_lookup_error", err)
+	}
+
+	return s.list(ctx, span, start, "list_attachments", transactionID)
+}
+
+// ListAllAttachments implements TransactionAttachmentServices.
+func (s *attachmentService) ListAllAttachments(ctx context.Context, transactionID uint64) ([]domain.TransactionAttachment, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListAllTransactionAttachments")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("transaction.id", int64(transactionID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_all_attachments"), attribute.String("service", "attachment")))
+
+	transaction, err := s.attachmentRepository.FindTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_all_attachments", "repository_error", fmt.Errorf("failed to find transaction: %w", err))
+	}
+	if transaction == nil {
+		return nil, s.recordError(ctx, span, start, "list_all_attachments", "not_found", common.ErrTransactionNotFound)
+	}
+
+	return s.list(ctx, span, start, "list_all_attachments", transactionID)
+}
+
+// DeleteAttachment implements TransactionAttachmentServices.
+func (s *attachmentService) DeleteAttachment(ctx context.Context, transactionID, attachmentID, adminID uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteTransactionAttachment")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("transaction.id", int64(transactionID)),
+		attribute.Int64("attachment.id", int64(attachmentID)),
+		attribute.Int64("admin.id", int64(adminID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_attachment"), attribute.String("service", "attachment")))
+
+	attachment, err := s.attachmentRepository.FindByID(ctx, attachmentID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_attachment", "repository_error", fmt.Errorf("failed to find attachment: %w", err))
+	}
+	// Lampiran transaksi lain atau yang sudah dihapus diperlakukan sama dengan lampiran yang tidak ada
+	if attachment == nil || attachment.TransactionID != transactionID || attachment.DeletedAt != nil {
+		return s.recordError(ctx, span, start, "delete_attachment", "not_found", common.ErrAttachmentNotFound)
+	}
+
+	deleted, err := s.attachmentRepository.Delete(ctx, attachmentID, adminID, time.Now())
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_attachment", "repository_error", fmt.Errorf("failed to delete attachment: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_attachment", "not_found", common.ErrAttachmentNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "delete_attachment",
+		zap.Uint64("attachment_id", attachmentID),
+		zap.Uint64("transaction_id", transactionID),
+		zap.Uint64("admin_id", adminID),
+	)
+
+	return nil
+}
+
+func (s *attachmentService) list(ctx context.Context, span trace.Span, start time.Time, operation string, transactionID uint64) ([]domain.TransactionAttachment, error) {
+	attachments, err := s.attachmentRepository.FindByTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, operation, "repository_error", fmt.Errorf("failed to find attachments: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, operation,
+		zap.Uint64("transaction_id", transactionID),
+		zap.Int("count", len(attachments)),
+	)
+
+	return attachments, nil
+}
+
+// ownedTransaction loads the transaction and checks that it belongs to the
+// caller: the partner that booked it, or the customer it was booked for. A
+// transaction owned by someone else is reported as not found.
+func (s *attachmentService) ownedTransaction(ctx context.Context, owner domain.BatchOwner, transactionID uint64) (*domain.Transaction, error) {
+	transaction, err := s.attachmentRepository.FindTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction: %w", err)
+	}
+	if transaction == nil {
+		return nil, common.ErrTransactionNotFound
+	}
+
+	switch {
+	case owner.PartnerID != nil:
+		if transaction.PartnerID == nil || *transaction.PartnerID != *owner.PartnerID || transaction.IsSandbox != owner.Sandbox {
+			return nil, common.ErrTransactionNotFound
+		}
+	case owner.CustomerID != nil:
+		if transaction.CustomerID != *owner.CustomerID {
+			return nil, common.ErrTransactionNotFound
+		}
+	default:
+		return nil, common.ErrTransactionNotFound
+	}
+
+	return transaction, nil
+}
+
+// sanitizeFileName keeps only the base name of the uploaded file so client
+// paths are never stored.
+func sanitizeFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+func (s *attachmentService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Attachment operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "attachment"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "attachment"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *attachmentService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "attachment"), attribute.String("status", "success")))
+
+	s.log.Info("Attachment operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewTransactionAttachmentService(
+	attachmentRepository repository.TransactionAttachmentRepository,
+	cloudinaryService service.CloudinaryService,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.TransactionAttachmentServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &attachmentService{
+		attachmentRepository: attachmentRepository,
+		cloudinaryService:    cloudinaryService,
+		cfg:                  cfg,
+		meter:                meter,
+		tracer:               tracer,
+		log:                  log,
+		operationDuration:    operationDuration,
+		operationCount:       operationCount,
+		errorCount:           errorCount,
+	}
+}
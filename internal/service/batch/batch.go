@@ -0,0 +1,304 @@
+package batchsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config limits how many items one batch may carry and how many pending
+// batches a single background run picks up.
+type Config struct {
+	MaxItems  int
+	BatchSize int
+}
+
+// itemErrors maps the rejections of CreateTransaction to the codes the
+// single transaction endpoint answers with, so partners handle both alike.
+var itemErrors = []struct {
+	err     error
+	code    string
+	message string
+}{
+	{common.ErrCustomerNotFound, "customer_not_found", "Customer not found"},
+	{common.ErrTenorNotFound, "tenor_not_found", "Tenor not found"},
+	{common.ErrInsufficientLimit, "insufficient_limit", "Insufficient limit"},
+	{common.ErrLimitNotSet, "limit_not_set", "Limit not set"},
+	{common.ErrCustomerSuspended, "customer_suspended", "Customer account is suspended"},
+	{common.ErrLimitFrozen, "limit_frozen", "Limit for this tenor is frozen"},
+	{common.ErrCustomerDormant, "customer_dormant", "Customer account is dormant"},
+	{common.ErrAccountClosed, "account_closed", "Customer account is closed"},
+	{common.ErrBlacklisted, "blacklisted", "Transaction blocked by screening"},
+	{common.ErrFxRateNotFound, "fx_rate_not_found", "No exchange rate available for currency"},
+	{common.ErrAmountOutsideProduct, "amount_outside_product", "Transaction amount is outside the tenor's allowed range"},
+	{common.ErrAgeNotEligible, "age_not_eligible", "Customer age is not eligible for this tenor"},
+	{common.ErrAgeAtTenorEnd, "age_at_tenor_end", "Customer would exceed the maximum age before the contract ends"},
+	{common.ErrDebtServiceRatioExceeded, "debt_service_ratio_exceeded", "Monthly installments would exceed the allowed share of the customer's salary"},
+	{common.ErrAssetNotAllowed, "asset_not_allowed", "Asset category is not financed under this tenor"},
+	{common.ErrAdminFeeRequired, "admin_fee_required", "Admin fee is required"},
+	{common.ErrPromotionNotFound, "invalid_promo_code", "Promo code is not valid for this transaction"},
+	{common.ErrPromotionNotApplicable, "invalid_promo_code", "Promo code is not valid for this transaction"},
+	{common.ErrPromotionExhausted, "promo_code_exhausted", "Promo code has reached its redemption limit"},
+	{common.ErrDailyCountQuotaExceeded, "daily_quota_exceeded", "Partner has reached its daily transaction quota"},
+	{common.ErrDailyVolumeQuotaExceeded, "daily_volume_exceeded", "Transaction would exceed the partner's daily volume quota"},
+	{common.ErrRegionNotFound, "region_not_found", "Region not found or inactive"},
+}
+
+type batchService struct {
+	batchRepository repository.TransactionBatchRepository
+	partnerService  service.PartnerServices
+	cfg             Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	itemsProcessed    metric.Int64Counter
+}
+
+// Submit implements TransactionBatchServices.
+func (s *batchService) Submit(ctx context.Context, owner domain.BatchOwner, req dto.CreateTransactionBatchRequest) (*domain.TransactionBatch, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SubmitTransactionBatch")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("batch.items", len(req.Items)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "submit_transaction_batch"), attribute.String("service", "transaction_batch")))
+
+	if len(req.Items) > s.cfg.MaxItems {
+		return nil, s.recordError(ctx, span, start, "submit_transaction_batch", "batch_too_large", common.ErrBatchTooLarge)
+	}
+
+	batch := &domain.TransactionBatch{
+		Owner:  owner,
+		Status: domain.BatchPending,
+		Items:  make([]domain.TransactionBatchItem, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		batch.Items[i] = domain.TransactionBatchItem{
+			Sequence:    i + 1,
+			CustomerNIK: item.CustomerNIK,
+			TenorMonths: item.TenorMonths,
+			AssetName:   item.AssetName,
+			OTRAmount:   item.OTRAmount,
+			AdminFee:    item.AdminFee,
+			Currency:    item.Currency,
+			PromoCode:   item.PromoCode,
+			Status:      domain.BatchItemPending,
+		}
+	}
+
+	if err := s.batchRepository.Create(ctx, batch); err != nil {
+		return nil, s.recordError(ctx, span, start, "submit_transaction_batch", "repository_error", fmt.Errorf("failed to create transaction batch: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "submit_transaction_batch", zap.Uint64("batch_id", batch.ID), zap.Int("items", len(batch.Items)))
+
+	return batch, nil
+}
+
+// GetBatch implements TransactionBatchServices.
+func (s *batchService) GetBatch(ctx context.Context, id uint64, owner domain.BatchOwner) (*domain.TransactionBatch, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetTransactionBatch")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("batch.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_transaction_batch"), attribute.String("service", "transaction_batch")))
+
+	batch, err := s.batchRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_transaction_batch", "repository_error", fmt.Errorf("failed to find transaction batch: %w", err))
+	}
+	// Batch milik pihak lain dilaporkan tidak ada agar ID tidak bisa ditebak
+	if batch == nil || !batch.OwnedBy(owner) {
+		return nil, s.recordError(ctx, span, start, "get_transaction_batch", "not_found", common.ErrBatchNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_transaction_batch", zap.Uint64("batch_id", id), zap.String("status", string(batch.Status)))
+
+	return batch, nil
+}
+
+// ProcessPending implements TransactionBatchServices.
+func (s *batchService) ProcessPending(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ProcessPendingTransactionBatches")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "process_transaction_batches"), attribute.String("service", "transaction_batch")))
+
+	batches, err := s.batchRepository.FindPending(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "process_transaction_batches", "repository_error", fmt.Errorf("failed to find pending batches: %w", err))
+	}
+
+	processed := 0
+	for _, batch := range batches {
+		for i := range batch.Items {
+			item := &batch.Items[i]
+
+			claimed, err := s.batchRepository.ClaimItem(ctx, item.ID)
+			if err != nil {
+				return processed, s.recordError(ctx, span, start, "process_transaction_batches", "repository_error", fmt.Errorf("failed to claim batch item: %w", err))
+			}
+			if !claimed {
+				continue
+			}
+
+			s.process(ctx, batch.Owner, item)
+			if err := s.batchRepository.CompleteItem(ctx, item); err != nil {
+				return processed, s.recordError(ctx, span, start, "process_transaction_batches", "repository_error", fmt.Errorf("failed to save batch item result: %w", err))
+			}
+			processed++
+
+			s.itemsProcessed.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "transaction_batch"), attribute.String("status", string(item.Status))))
+		}
+
+		if _, err := s.batchRepository.Complete(ctx, batch.ID, time.Now()); err != nil {
+			return processed, s.recordError(ctx, span, start, "process_transaction_batches", "repository_error", fmt.Errorf("failed to complete batch: %w", err))
+		}
+	}
+
+	span.SetAttributes(attribute.Int("batch.items_processed", processed))
+	s.recordSuccess(ctx, span, start, "process_transaction_batches", zap.Int("batches", len(batches)), zap.Int("items_processed", processed))
+
+	return processed, nil
+}
+
+// process creates the transaction behind item and records the outcome on it.
+// Every item runs in its own database transaction inside CreateTransaction,
+// so a rejected or panicking item never affects the rest of the batch.
+func (s *batchService) process(ctx context.Context, owner domain.BatchOwner, item *domain.TransactionBatchItem) {
+	defer func() {
+		now := time.Now()
+		item.ProcessedAt = &now
+
+		if r := recover(); r != nil {
+			s.log.Error("Transaction batch item panicked", zap.Uint64("item_id", item.ID), zap.Any("panic", r))
+			item.Status = domain.BatchItemFailed
+			item.ErrorCode = "service_error"
+			item.ErrorMessage = "An internal server error occurred"
+		}
+	}()
+
+	transaction, err := s.partnerService.CreateTransaction(ctx, dto.CreateTransactionRequest{
+		CustomerNIK: item.CustomerNIK,
+		TenorMonths: item.TenorMonths,
+		AssetName:   item.AssetName,
+		OTRAmount:   item.OTRAmount,
+		AdminFee:    item.AdminFee,
+		Currency:    item.Currency,
+		PromoCode:   item.PromoCode,
+		PartnerID:   owner.PartnerID,
+		Sandbox:     owner.Sandbox,
+	})
+	if err != nil {
+		item.Status = domain.BatchItemFailed
+		item.ErrorCode, item.ErrorMessage = "service_error", "An internal server error occurred"
+		for _, known := range itemErrors {
+			if errors.Is(err, known.err) {
+				item.ErrorCode, item.ErrorMessage = known.code, known.message
+				break
+			}
+		}
+		s.log.Warn("Transaction batch item failed", zap.Uint64("item_id", item.ID), zap.String("error_code", item.ErrorCode), zap.Error(err))
+		return
+	}
+
+	item.Status = domain.BatchItemSucceeded
+	item.TransactionID = &transaction.ID
+	item.ContractNumber = transaction.ContractNumber
+}
+
+func (s *batchService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Transaction batch operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "transaction_batch"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "transaction_batch"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *batchService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "transaction_batch"), attribute.String("status", "success")))
+
+	s.log.Info("Transaction batch operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewBatchService(
+	batchRepository repository.TransactionBatchRepository,
+	partnerService service.PartnerServices,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.TransactionBatchServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	itemsProcessed, _ := meter.Int64Counter(
+		"service.transaction_batch.items_processed",
+		metric.WithDescription("Number of batch items turned into transactions or rejected"),
+		metric.WithUnit("{item}"),
+	)
+
+	return &batchService{
+		batchRepository:   batchRepository,
+		partnerService:    partnerService,
+		cfg:               cfg,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+		itemsProcessed:    itemsProcessed,
+	}
+}
@@ -0,0 +1,316 @@
+package blacklistsrv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type blacklistService struct {
+	blacklistRepository repository.BlacklistRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	screeningHits     metric.Int64Counter
+}
+
+// Screen implements ScreeningServices.
+func (b *blacklistService) Screen(ctx context.Context, action domain.ScreeningAction, customerID *uint64, nik, fullName string) (*domain.ScreeningLog, error) {
+	ctx, span := b.tracer.Start(ctx, "service.Screen")
+	defer span.End()
+
+	start := time.Now()
+	fullName = normalizeName(fullName)
+	span.SetAttributes(attribute.String("screening.action", string(action)))
+	b.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "screen"), attribute.String("service", "blacklist")))
+
+	matches, err := b.blacklistRepository.FindMatches(ctx, nik, fullName)
+	if err != nil {
+		return nil, b.recordError(ctx, span, start, "screen", "repository_error", fmt.Errorf("failed to screen against blacklist: %w", err))
+	}
+	if len(matches) == 0 {
+		b.recordSuccess(ctx, span, start, "screen", zap.String("action", string(action)), zap.Bool("hit", false))
+		return nil, nil
+	}
+
+	// Jika ada beberapa hit, severity BLOCK selalu diutamakan
+	hit := matches[0]
+	for _, m := range matches[1:] {
+		if m.Severity == domain.SeverityBlock {
+			hit = m
+			break
+		}
+	}
+
+	outcome := domain.ScreeningFlagged
+	if hit.Severity == domain.SeverityBlock {
+		outcome = domain.ScreeningBlocked
+	}
+
+	entry := &domain.ScreeningLog{
+		EntryID:    hit.ID,
+		CustomerID: customerID,
+		NIK:        nik,
+		FullName:   fullName,
+		Action:     action,
+		Outcome:    outcome,
+	}
+	if err := b.blacklistRepository.CreateScreeningLog(ctx, entry); err != nil {
+		return nil, b.recordError(ctx, span, start, "screen", "repository_error", fmt.Errorf("failed to record screening log: %w", err))
+	}
+
+	b.screeningHits.Add(ctx, 1, metric.WithAttributes(attribute.String("action", string(action)), attribute.String("outcome", string(outcome))))
+	span.SetAttributes(
+		attribute.Int64("screening.entry_id", int64(hit.ID)),
+		attribute.String("screening.outcome", string(outcome)),
+	)
+
+	if outcome == domain.ScreeningBlocked {
+		return entry, b.recordError(ctx, span, start, "screen", "blacklisted", common.ErrBlacklisted)
+	}
+
+	b.log.Warn("Blacklist screening flagged action",
+		zap.String("action", string(action)),
+		zap.Uint64("entry_id", hit.ID),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+	b.recordSuccess(ctx, span, start, "screen", zap.String("action", string(action)), zap.Bool("hit", true))
+
+	return entry, nil
+}
+
+// CreateEntry implements BlacklistServices.
+func (b *blacklistService) CreateEntry(ctx context.Context, req dto.BlacklistEntryRequest) (*domain.BlacklistEntry, error) {
+	ctx, span := b.tracer.Start(ctx, "service.CreateBlacklistEntry")
+	defer span.End()
+
+	start := time.Now()
+	b.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_blacklist_entry"), attribute.String("service", "blacklist")))
+
+	entry := &domain.BlacklistEntry{
+		NIK:      strings.TrimSpace(req.NIK),
+		FullName: normalizeName(req.FullName),
+		Reason:   strings.TrimSpace(req.Reason),
+		Source:   strings.TrimSpace(req.Source),
+		Severity: req.Severity,
+	}
+	if err := b.blacklistRepository.Create(ctx, entry); err != nil {
+		return nil, b.recordError(ctx, span, start, "create_blacklist_entry", "repository_error", fmt.Errorf("failed to create blacklist entry: %w", err))
+	}
+
+	b.recordSuccess(ctx, span, start, "create_blacklist_entry", zap.Uint64("entry_id", entry.ID))
+
+	return entry, nil
+}
+
+// UpdateEntry implements BlacklistServices.
+func (b *blacklistService) UpdateEntry(ctx context.Context, id uint64, req dto.BlacklistEntryRequest) (*domain.BlacklistEntry, error) {
+	ctx, span := b.tracer.Start(ctx, "service.UpdateBlacklistEntry")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("entry.id", int64(id)))
+	b.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_blacklist_entry"), attribute.String("service", "blacklist")))
+
+	entry, err := b.blacklistRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, b.recordError(ctx, span, start, "update_blacklist_entry", "repository_error", fmt.Errorf("failed to get blacklist entry: %w", err))
+	}
+	if entry == nil {
+		return nil, b.recordError(ctx, span, start, "update_blacklist_entry", "entry_not_found", common.ErrBlacklistNotFound)
+	}
+
+	entry.NIK = strings.TrimSpace(req.NIK)
+	entry.FullName = normalizeName(req.FullName)
+	entry.Reason = strings.TrimSpace(req.Reason)
+	entry.Source = strings.TrimSpace(req.Source)
+	entry.Severity = req.Severity
+
+	if err := b.blacklistRepository.Update(ctx, entry); err != nil {
+		return nil, b.recordError(ctx, span, start, "update_blacklist_entry", "repository_error", fmt.Errorf("failed to update blacklist entry: %w", err))
+	}
+
+	b.recordSuccess(ctx, span, start, "update_blacklist_entry", zap.Uint64("entry_id", id))
+
+	return entry, nil
+}
+
+// DeleteEntry implements BlacklistServices.
+func (b *blacklistService) DeleteEntry(ctx context.Context, id uint64) error {
+	ctx, span := b.tracer.Start(ctx, "service.DeleteBlacklistEntry")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("entry.id", int64(id)))
+	b.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_blacklist_entry"), attribute.String("service", "blacklist")))
+
+	entry, err := b.blacklistRepository.FindByID(ctx, id)
+	if err != nil {
+		return b.recordError(ctx, span, start, "delete_blacklist_entry", "repository_error", fmt.Errorf("failed to get blacklist entry: %w", err))
+	}
+	if entry == nil {
+		return b.recordError(ctx, span, start, "delete_blacklist_entry", "entry_not_found", common.ErrBlacklistNotFound)
+	}
+
+	if err := b.blacklistRepository.Delete(ctx, id); err != nil {
+		return b.recordError(ctx, span, start, "delete_blacklist_entry", "repository_error", fmt.Errorf("failed to delete blacklist entry: %w", err))
+	}
+
+	b.recordSuccess(ctx, span, start, "delete_blacklist_entry", zap.Uint64("entry_id", id))
+
+	return nil
+}
+
+// GetEntry implements BlacklistServices.
+func (b *blacklistService) GetEntry(ctx context.Context, id uint64) (*domain.BlacklistEntry, error) {
+	ctx, span := b.tracer.Start(ctx, "service.GetBlacklistEntry")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("entry.id", int64(id)))
+	b.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_blacklist_entry"), attribute.String("service", "blacklist")))
+
+	entry, err := b.blacklistRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, b.recordError(ctx, span, start, "get_blacklist_entry", "repository_error", fmt.Errorf("failed to get blacklist entry: %w", err))
+	}
+	if entry == nil {
+		return nil, b.recordError(ctx, span, start, "get_blacklist_entry", "entry_not_found", common.ErrBlacklistNotFound)
+	}
+
+	b.recordSuccess(ctx, span, start, "get_blacklist_entry", zap.Uint64("entry_id", id))
+
+	return entry, nil
+}
+
+// ListEntries implements BlacklistServices.
+func (b *blacklistService) ListEntries(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := b.tracer.Start(ctx, "service.ListBlacklistEntries")
+	defer span.End()
+
+	start := time.Now()
+	b.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_blacklist_entries"), attribute.String("service", "blacklist")))
+
+	entries, total, err := b.blacklistRepository.FindPaginated(ctx, params)
+	if err != nil {
+		return nil, b.recordError(ctx, span, start, "list_blacklist_entries", "repository_error", fmt.Errorf("failed to list blacklist entries: %w", err))
+	}
+
+	b.recordSuccess(ctx, span, start, "list_blacklist_entries", zap.Int64("total", total))
+
+	return params.Paginated(entries, total), nil
+}
+
+// ListScreeningLogs implements BlacklistServices.
+func (b *blacklistService) ListScreeningLogs(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := b.tracer.Start(ctx, "service.ListScreeningLogs")
+	defer span.End()
+
+	start := time.Now()
+	b.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_screening_logs"), attribute.String("service", "blacklist")))
+
+	logs, total, err := b.blacklistRepository.FindScreeningLogsPaginated(ctx, params)
+	if err != nil {
+		return nil, b.recordError(ctx, span, start, "list_screening_logs", "repository_error", fmt.Errorf("failed to list screening logs: %w", err))
+	}
+
+	b.recordSuccess(ctx, span, start, "list_screening_logs", zap.Int64("total", total))
+
+	return params.Paginated(logs, total), nil
+}
+
+// normalizeName trims and collapses whitespace so "Budi  Santoso " matches
+// an entry stored as "Budi Santoso".
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func (b *blacklistService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	b.log.Error("Blacklist operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	b.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "blacklist"), attribute.String("error_type", errorType)))
+	b.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "blacklist"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (b *blacklistService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	b.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "blacklist"), attribute.String("status", "success")))
+
+	b.log.Info("Blacklist operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewBlacklistService(
+	blacklistRepository repository.BlacklistRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.BlacklistServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	screeningHits, _ := meter.Int64Counter(
+		"service.screening.hits",
+		metric.WithDescription("Number of blacklist screening hits"),
+		metric.WithUnit("{hit}"),
+	)
+
+	return &blacklistService{
+		blacklistRepository: blacklistRepository,
+		meter:               meter,
+		tracer:              tracer,
+		log:                 log,
+		operationDuration:   operationDuration,
+		operationCount:      operationCount,
+		errorCount:          errorCount,
+		screeningHits:       screeningHits,
+	}
+}
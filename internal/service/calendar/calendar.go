@@ -0,0 +1,328 @@
+package calendarsrv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	calendarrepo "github.com/fazamuttaqien/multifinance/internal/repository/calendar"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/apikey"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/ical"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// TokenPrefix marks calendar tokens so a leaked one is easy to recognise.
+	TokenPrefix = "mf_cal_"
+
+	prodID = "-//Multifinance//Installment Calendar//EN"
+
+	// refreshInterval is how often subscribed calendars are asked to fetch
+	// the feed again, so paid installments drop off within a day.
+	refreshInterval = 12 * time.Hour
+
+	// installmentUpcoming is the status the profile service gives unpaid
+	// installments.
+	installmentUpcoming = "UPCOMING"
+)
+
+// Config sets the zone due dates are shown in and how long before a due
+// date calendars raise a reminder. A zero Reminder adds none.
+type Config struct {
+	Location *time.Location
+	Reminder time.Duration
+}
+
+// feedText holds the wording of the feed in one language.
+type feedText struct {
+	name        string
+	summary     string
+	description string
+	payTo       string
+}
+
+var feedTexts = map[domain.Language]feedText{
+	domain.LanguageIndonesian: {
+		name:        "Cicilan %s",
+		summary:     "Cicilan %d/%d %s",
+		description: "Cicilan kontrak %s sebesar %s %s jatuh tempo hari ini.",
+		payTo:       "Bayar ke virtual account %s %s.",
+	},
+	domain.LanguageEnglish: {
+		name:        "Installments %s",
+		summary:     "Installment %d/%d %s",
+		description: "Installment of %[3]s %[2]s for contract %[1]s is due today.",
+		payTo:       "Pay to virtual account %s %s.",
+	},
+}
+
+type calendarService struct {
+	db                 *gorm.DB
+	profileService     service.ProfileServices
+	customerRepository repository.CustomerRepository
+	tokenRepository    repository.CalendarTokenRepository
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	feedsServed       metric.Int64Counter
+}
+
+// CreateToken implements CalendarServices. The plain token is only returned
+// here; it cannot be read back later.
+func (s *calendarService) CreateToken(ctx context.Context, customerID uint64, contractNumber string) (string, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CreateCalendarToken")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_calendar_token"), attribute.String("service", "calendar")))
+
+	// Kepemilikan kontrak dicek oleh profile service, kontrak orang lain dianggap tidak ada
+	if _, err := s.profileService.GetMyTransactionDetail(ctx, customerID, contractNumber); err != nil {
+		return "", s.recordError(ctx, span, start, "create_calendar_token", "transaction_lookup_error", err)
+	}
+
+	plain, hash, _, err := apikey.Generate(TokenPrefix)
+	if err != nil {
+		return "", s.recordError(ctx, span, start, "create_calendar_token", "token_generation_error", fmt.Errorf("failed to generate calendar token: %w", err))
+	}
+
+	// Link lama dicabut bersamaan agar satu kontrak hanya punya satu link aktif
+	token := &domain.CalendarToken{CustomerID: customerID, ContractNumber: contractNumber, TokenHash: hash}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tokenTx := calendarrepo.NewCalendarTokenRepository(tx, s.meter, s.log)
+		if _, err := tokenTx.Revoke(ctx, customerID, contractNumber, time.Now()); err != nil {
+			return err
+		}
+		return tokenTx.Create(ctx, token)
+	})
+	if err != nil {
+		return "", s.recordError(ctx, span, start, "create_calendar_token", "repository_error", fmt.Errorf("failed to save calendar token: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "create_calendar_token",
+		zap.Uint64("customer_id", customerID),
+		zap.String("contract_number", contractNumber),
+		zap.Uint64("token_id", token.ID),
+	)
+
+	return plain, nil
+}
+
+// RevokeToken implements CalendarServices.
+func (s *calendarService) RevokeToken(ctx context.Context, customerID uint64, contractNumber string) error {
+	ctx, span := s.tracer.Start(ctx, "service.RevokeCalendarToken")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "revoke_calendar_token"), attribute.String("service", "calendar")))
+
+	revoked, err := s.tokenRepository.Revoke(ctx, customerID, contractNumber, time.Now())
+	if err != nil {
+		return s.recordError(ctx, span, start, "revoke_calendar_token", "repository_error", fmt.Errorf("failed to revoke calendar token: %w", err))
+	}
+	if revoked == 0 {
+		return s.recordError(ctx, span, start, "revoke_calendar_token", "not_found", common.ErrCalendarTokenNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "revoke_calendar_token",
+		zap.Uint64("customer_id", customerID),
+		zap.String("contract_number", contractNumber),
+	)
+
+	return nil
+}
+
+// Feed implements CalendarServices. It returns ErrCalendarTokenInvalid for
+// a token that is unknown, revoked, issued for another contract or owned by
+// a closed account.
+func (s *calendarService) Feed(ctx context.Context, contractNumber, plain string) ([]byte, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetCalendarFeed")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("transaction.contract_number", contractNumber))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_calendar_feed"), attribute.String("service", "calendar")))
+
+	if plain == "" {
+		return nil, s.recordError(ctx, span, start, "get_calendar_feed", "invalid_token", common.ErrCalendarTokenInvalid)
+	}
+
+	token, err := s.tokenRepository.FindActiveByHash(ctx, apikey.Hash(plain))
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_calendar_feed", "repository_error", fmt.Errorf("failed to find calendar token: %w", err))
+	}
+	if token == nil || token.ContractNumber != contractNumber {
+		return nil, s.recordError(ctx, span, start, "get_calendar_feed", "invalid_token", common.ErrCalendarTokenInvalid)
+	}
+	span.SetAttributes(attribute.Int64("customer.id", int64(token.CustomerID)))
+
+	customer, err := s.customerRepository.FindByID(ctx, token.CustomerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_calendar_feed", "repository_error", fmt.Errorf("failed to find customer: %w", err))
+	}
+	if customer == nil || customer.ClosedAt != nil {
+		return nil, s.recordError(ctx, span, start, "get_calendar_feed", "invalid_token", common.ErrCalendarTokenInvalid)
+	}
+
+	detail, err := s.profileService.GetMyTransactionDetail(ctx, token.CustomerID, contractNumber)
+	if err != nil {
+		if errors.Is(err, common.ErrTransactionNotFound) {
+			err = common.ErrCalendarTokenInvalid
+		}
+		return nil, s.recordError(ctx, span, start, "get_calendar_feed", "transaction_lookup_error", err)
+	}
+
+	text, ok := feedTexts[customer.Language]
+	if !ok {
+		text = feedTexts[domain.DefaultLanguage]
+	}
+
+	cal := ical.Calendar{
+		ProdID:          prodID,
+		Name:            fmt.Sprintf(text.name, contractNumber),
+		RefreshInterval: refreshInterval,
+	}
+	for _, inst := range detail.Installments {
+		if inst.Status != installmentUpcoming {
+			continue
+		}
+
+		description := fmt.Sprintf(text.description, contractNumber, inst.Amount.StringFixed(2), detail.Currency)
+		if detail.VirtualAccountNumber != "" {
+			description += "\n" + fmt.Sprintf(text.payTo, detail.VirtualAccountBank, detail.VirtualAccountNumber)
+		}
+
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         fmt.Sprintf("%s-%d@multifinance", contractNumber, inst.Sequence),
+			Date:        datetime.Date(inst.DueDate.In(s.cfg.Location)),
+			Summary:     fmt.Sprintf(text.summary, inst.Sequence, len(detail.Installments), detail.AssetName),
+			Description: description,
+			Reminder:    s.cfg.Reminder,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf, time.Now()); err != nil {
+		return nil, s.recordError(ctx, span, start, "get_calendar_feed", "render_error", fmt.Errorf("failed to write calendar: %w", err))
+	}
+
+	s.feedsServed.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "calendar")))
+	s.recordSuccess(ctx, span, start, "get_calendar_feed",
+		zap.Uint64("customer_id", token.CustomerID),
+		zap.String("contract_number", contractNumber),
+		zap.Int("events", len(cal.Events)),
+	)
+
+	return buf.Bytes(), nil
+}
+
+func (s *calendarService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Calendar operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "calendar"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "calendar"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *calendarService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "calendar"), attribute.String("status", "success")))
+
+	s.log.Info("Calendar operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewCalendarService(
+	db *gorm.DB,
+	profileService service.ProfileServices,
+	customerRepository repository.CustomerRepository,
+	tokenRepository repository.CalendarTokenRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.CalendarServices {
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	feedsServed, _ := meter.Int64Counter(
+		"service.calendar.feeds",
+		metric.WithDescription("Number of installment calendar feeds served"),
+		metric.WithUnit("{feed}"),
+	)
+
+	return &calendarService{
+		db:                 db,
+		profileService:     profileService,
+		customerRepository: customerRepository,
+		tokenRepository:    tokenRepository,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		feedsServed:        feedsServed,
+	}
+}
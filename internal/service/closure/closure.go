@@ -0,0 +1,327 @@
+package closuresrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	closurerepo "github.com/fazamuttaqien/multifinance/internal/repository/closure"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/password"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config sets how long a closed account keeps its personal data, and how
+// many accounts one run of the anonymization job handles.
+type Config struct {
+	CoolingOff time.Duration
+	BatchSize  int
+}
+
+type closureService struct {
+	db                 *gorm.DB
+	customerRepository repository.CustomerRepository
+	closureRepository  repository.AccountClosureRepository
+	notifier           service.CustomerNotifier
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	closedCount       metric.Int64Counter
+	anonymizedCount   metric.Int64Counter
+}
+
+// Close implements AccountClosureServices.
+func (s *closureService) Close(ctx context.Context, customerID uint64, req dto.CloseAccountRequest) (*domain.AccountClosure, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CloseAccount")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "close_account"), attribute.String("service", "closure")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "close_account", "repository_error", fmt.Errorf("failed to find customer: %w", err))
+	}
+	if customer == nil {
+		return nil, s.recordError(ctx, span, start, "close_account", "not_found", common.ErrCustomerNotFound)
+	}
+	if customer.ClosedAt != nil {
+		return nil, s.recordError(ctx, span, start, "close_account", "already_closed", common.ErrAccountClosed)
+	}
+	if !password.CheckPasswordHash(req.Password, customer.Password) {
+		return nil, s.recordError(ctx, span, start, "close_account", "invalid_credentials", common.ErrInvalidCredentials)
+	}
+
+	blockers, err := s.closureRepository.Blockers(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "close_account", "repository_error", fmt.Errorf("failed to check closure blockers: %w", err))
+	}
+	if err := checkBlockers(blockers); err != nil {
+		return nil, s.recordError(ctx, span, start, "close_account", blockerErrorType(err), err)
+	}
+
+	now := time.Now()
+	closure := &domain.AccountClosure{
+		CustomerID:     customerID,
+		Reason:         strings.TrimSpace(req.Reason),
+		ClosedAt:       now,
+		AnonymizeAfter: now.Add(s.cfg.CoolingOff),
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		closureTx := closurerepo.NewAccountClosureRepository(tx, s.meter, s.log)
+		closed, err := closureTx.Close(ctx, closure)
+		if err != nil {
+			return err
+		}
+		// Permintaan lain menutup akun lebih dulu
+		if !closed {
+			return common.ErrAccountClosed
+		}
+
+		// Dicek ulang setelah baris customer terkunci, kontrak partner yang
+		// masuk sejak pengecekan pertama ikut menggagalkan penutupan
+		blockers, err := closureTx.Blockers(ctx, customerID)
+		if err != nil {
+			return err
+		}
+		if err := checkBlockers(blockers); err != nil {
+			return err
+		}
+
+		eventTx := customereventrepo.NewCustomerEventRepository(tx, s.meter, s.log)
+		return eventTx.Append(ctx, &domain.CustomerEvent{
+			CustomerID:  customerID,
+			Type:        domain.CustomerClosed,
+			ReferenceID: strconv.FormatUint(closure.ID, 10),
+			Data: map[string]string{
+				"reason":          closure.Reason,
+				"anonymize_after": closure.AnonymizeAfter.UTC().Format(time.RFC3339),
+			},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, common.ErrAccountClosed) || errors.Is(err, common.ErrActiveContracts) || errors.Is(err, common.ErrOutstandingBalance) {
+			return nil, s.recordError(ctx, span, start, "close_account", blockerErrorType(err), err)
+		}
+		return nil, s.recordError(ctx, span, start, "close_account", "repository_error", fmt.Errorf("failed to close account: %w", err))
+	}
+
+	if err := s.notifier.Notify(ctx, customerID, domain.TemplateAccountClosed, map[string]string{
+		"full_name":       customer.FullName,
+		"anonymize_after": closure.AnonymizeAfter.Format(time.DateOnly),
+	}); err != nil {
+		s.log.Warn("Failed to send account closure notice",
+			zap.Uint64("customer_id", customerID),
+			zap.Error(err),
+		)
+	}
+
+	s.closedCount.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "closure")))
+	s.recordSuccess(ctx, span, start, "close_account",
+		zap.Uint64("customer_id", customerID),
+		zap.Time("anonymize_after", closure.AnonymizeAfter),
+	)
+
+	return closure, nil
+}
+
+// Authorize implements AccountClosureServices. It returns ErrAccountClosed
+// for a closed account.
+func (s *closureService) Authorize(ctx context.Context, customerID uint64) error {
+	closed, err := s.closureRepository.IsClosed(ctx, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to check account closure: %w", err)
+	}
+	if closed {
+		return common.ErrAccountClosed
+	}
+	return nil
+}
+
+// AnonymizeDue implements AccountClosureServices for at most BatchSize
+// closures whose cooling-off period ended before now.
+func (s *closureService) AnonymizeDue(ctx context.Context, now time.Time) (*domain.AccountClosureRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.AnonymizeClosedAccounts")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "anonymize_closed_accounts"), attribute.String("service", "closure")))
+
+	due, err := s.closureRepository.FindDue(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "anonymize_closed_accounts", "repository_error", fmt.Errorf("failed to find due closures: %w", err))
+	}
+
+	run := &domain.AccountClosureRun{}
+	for _, closure := range due {
+		anonymized := false
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			closureTx := closurerepo.NewAccountClosureRepository(tx, s.meter, s.log)
+			done, err := closureTx.Anonymize(ctx, closure, now)
+			if err != nil || !done {
+				return err
+			}
+			anonymized = true
+
+			eventTx := customereventrepo.NewCustomerEventRepository(tx, s.meter, s.log)
+			return eventTx.Append(ctx, &domain.CustomerEvent{
+				CustomerID:  closure.CustomerID,
+				Type:        domain.CustomerAnonymized,
+				ReferenceID: strconv.FormatUint(closure.ID, 10),
+			})
+		})
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "anonymize_closed_accounts", "repository_error", fmt.Errorf("failed to anonymize customer %d: %w", closure.CustomerID, err))
+		}
+		if anonymized {
+			run.Anonymized++
+		}
+	}
+
+	s.anonymizedCount.Add(ctx, int64(run.Anonymized), metric.WithAttributes(attribute.String("service", "closure")))
+	span.SetAttributes(attribute.Int("closure.anonymized", run.Anonymized))
+	s.recordSuccess(ctx, span, start, "anonymize_closed_accounts",
+		zap.Int("due", len(due)),
+		zap.Int("anonymized", run.Anonymized),
+	)
+
+	return run, nil
+}
+
+// checkBlockers returns why an account with these blockers cannot be closed
+// yet, nil when it can.
+func checkBlockers(blockers *domain.ClosureBlockers) error {
+	if blockers.ActiveContracts > 0 {
+		return fmt.Errorf("%d running contracts: %w", blockers.ActiveContracts, common.ErrActiveContracts)
+	}
+	if blockers.OutstandingPrincipal.IsPositive() {
+		return fmt.Errorf("%s principal outstanding: %w", blockers.OutstandingPrincipal.StringFixed(2), common.ErrOutstandingBalance)
+	}
+	return nil
+}
+
+func blockerErrorType(err error) string {
+	switch {
+	case errors.Is(err, common.ErrAccountClosed):
+		return "already_closed"
+	case errors.Is(err, common.ErrActiveContracts):
+		return "active_contracts"
+	default:
+		return "outstanding_balance"
+	}
+}
+
+func (s *closureService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Account closure operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "closure"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "closure"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *closureService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "closure"), attribute.String("status", "success")))
+
+	s.log.Info("Account closure operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewAccountClosureService(
+	db *gorm.DB,
+	customerRepository repository.CustomerRepository,
+	closureRepository repository.AccountClosureRepository,
+	notifier service.CustomerNotifier,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.AccountClosureServices {
+	if cfg.CoolingOff <= 0 {
+		cfg.CoolingOff = 30 * 24 * time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	closedCount, _ := meter.Int64Counter(
+		"service.closure.closed",
+		metric.WithDescription("Number of accounts closed by their customer"),
+		metric.WithUnit("{customer}"),
+	)
+
+	anonymizedCount, _ := meter.Int64Counter(
+		"service.closure.anonymized",
+		metric.WithDescription("Number of closed accounts anonymized"),
+		metric.WithUnit("{customer}"),
+	)
+
+	return &closureService{
+		db:                 db,
+		customerRepository: customerRepository,
+		closureRepository:  closureRepository,
+		notifier:           notifier,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		closedCount:        closedCount,
+		anonymizedCount:    anonymizedCount,
+	}
+}
@@ -1,22 +1,30 @@
 package cloudinarysrv
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/service"
+	cloudinarypkg "github.com/fazamuttaqien/multifinance/pkg/cloudinary"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
 )
 
 type cloudinaryService struct {
 	client *cloudinary.Cloudinary
 }
 
-// UploadImage implements CloudinaryService.
+// UploadImage implements CloudinaryService. Like UploadFile it stores the
+// image under the folder of the tenant in ctx.
 func (c *cloudinaryService) UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (string, error) {
 	// Open the uploaded file
 	src, err := file.Open()
@@ -27,7 +35,7 @@ func (c *cloudinaryService) UploadImage(ctx context.Context, file *multipart.Fil
 
 	// Upload to Cloudinary
 	uploadResult, err := c.client.Upload.Upload(ctx, src, uploader.UploadParams{
-		Folder:    folder,
+		Folder:    tenancy.Folder(ctx, folder),
 		PublicID:  generatePublicID(file.Filename),
 		Overwrite: func(b bool) *bool { return &b }(true),
 	})
@@ -38,6 +46,86 @@ func (c *cloudinaryService) UploadImage(ctx context.Context, file *multipart.Fil
 	return uploadResult.SecureURL, nil
 }
 
+// UploadFile implements CloudinaryService. Files are stored as authenticated
+// raw assets, so the returned URL carries a signature and the file cannot be
+// listed or fetched by guessing its public ID.
+func (c *cloudinaryService) UploadFile(ctx context.Context, data []byte, folder, publicID string) (string, error) {
+	uploadResult, err := c.client.Upload.Upload(ctx, bytes.NewReader(data), uploader.UploadParams{
+		Folder:       tenancy.Folder(ctx, folder),
+		PublicID:     publicID,
+		ResourceType: "raw",
+		Type:         api.Authenticated,
+		Overwrite:    func(b bool) *bool { return &b }(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload to Cloudinary: %w", err)
+	}
+
+	return uploadResult.SecureURL, nil
+}
+
+// DeleteImage implements CloudinaryService. The CDN copy is invalidated too,
+// so a deleted KTP photo stops being served straight away.
+func (c *cloudinaryService) DeleteImage(ctx context.Context, url string) error {
+	asset, err := cloudinarypkg.ParseAssetURL(url)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.client.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID:     asset.PublicID,
+		Type:         asset.Type,
+		ResourceType: asset.ResourceType,
+		Invalidate:   func(b bool) *bool { return &b }(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete from Cloudinary: %w", err)
+	}
+	if result.Error.Message != "" {
+		return fmt.Errorf("failed to delete from Cloudinary: %s", result.Error.Message)
+	}
+	// "not found" berarti aset sudah terhapus sebelumnya
+	if result.Result != "ok" && result.Result != "not found" {
+		return fmt.Errorf("failed to delete from Cloudinary: %s", result.Result)
+	}
+
+	return nil
+}
+
+// ListImages implements CloudinaryService. Images are matched on the public
+// ID prefix of the tenant folder, which UploadImage sets under both fixed
+// and dynamic folder modes. Only images directly in the folder are
+// returned; subfolders may belong to another tenant.
+func (c *cloudinaryService) ListImages(ctx context.Context, folder, cursor string, limit int) (*domain.StoredImagePage, error) {
+	prefix := tenancy.Folder(ctx, folder) + "/"
+	result, err := c.client.Admin.Assets(ctx, admin.AssetsParams{
+		AssetType:    api.Image,
+		DeliveryType: string(api.Upload),
+		Prefix:       prefix,
+		NextCursor:   cursor,
+		MaxResults:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Cloudinary assets: %w", err)
+	}
+	if result.Error.Message != "" {
+		return nil, fmt.Errorf("failed to list Cloudinary assets: %s", result.Error.Message)
+	}
+
+	page := &domain.StoredImagePage{
+		Images:     make([]domain.StoredImage, 0, len(result.Assets)),
+		NextCursor: result.NextCursor,
+	}
+	for _, asset := range result.Assets {
+		if strings.Contains(strings.TrimPrefix(asset.PublicID, prefix), "/") {
+			continue
+		}
+		page.Images = append(page.Images, domain.StoredImage{URL: asset.SecureURL, CreatedAt: asset.CreatedAt})
+	}
+
+	return page, nil
+}
+
 func NewCloudinaryService(client *cloudinary.Cloudinary) service.CloudinaryService {
 	return &cloudinaryService{
 		client: client,
@@ -0,0 +1,491 @@
+package collectionsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config sets when a contract goes to collections and how many contracts
+// the job checks per batch.
+type Config struct {
+	MinDaysPastDue int
+	BatchSize      int
+}
+
+type collectionService struct {
+	collectionRepository repository.CollectionRepository
+	customerRepository   repository.CustomerRepository
+	dueDateRules         service.DueDateRules
+	cfg                  Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	casesOpened       metric.Int64Counter
+	activitiesLogged  metric.Int64Counter
+}
+
+// OpenCases implements CollectionServices.
+func (s *collectionService) OpenCases(ctx context.Context, now time.Time) (*domain.CollectionRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.OpenCollectionCases")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "open_collection_cases"), attribute.String("service", "collection")))
+
+	rules := s.dueDateRules.Rules(ctx)
+
+	run := &domain.CollectionRun{}
+	var afterID uint64
+	for {
+		exposures, err := s.collectionRepository.FindExposures(ctx, afterID, s.cfg.BatchSize)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "open_collection_cases", "repository_error", fmt.Errorf("failed to find active contracts: %w", err))
+		}
+		if len(exposures) == 0 {
+			break
+		}
+
+		for _, exposure := range exposures {
+			run.Scanned++
+
+			days, overdue := delinquency(exposure, rules, now)
+			if days < s.cfg.MinDaysPastDue {
+				continue
+			}
+
+			collectionCase := &domain.CollectionCase{
+				TransactionID: exposure.TransactionID,
+				CustomerID:    exposure.CustomerID,
+				Status:        domain.CollectionOpen,
+				DaysPastDue:   days,
+				OverdueAmount: overdue,
+			}
+			if err := s.collectionRepository.CreateCase(ctx, collectionCase); err != nil {
+				return nil, s.recordError(ctx, span, start, "open_collection_cases", "repository_error", fmt.Errorf("failed to open collection case for transaction %d: %w", exposure.TransactionID, err))
+			}
+			run.Opened++
+		}
+
+		afterID = exposures[len(exposures)-1].TransactionID
+		if len(exposures) < s.cfg.BatchSize {
+			break
+		}
+	}
+
+	s.casesOpened.Add(ctx, int64(run.Opened), metric.WithAttributes(attribute.String("service", "collection")))
+	span.SetAttributes(
+		attribute.Int("collection.scanned", run.Scanned),
+		attribute.Int("collection.opened", run.Opened),
+	)
+	s.recordSuccess(ctx, span, start, "open_collection_cases",
+		zap.Int("scanned", run.Scanned),
+		zap.Int("opened", run.Opened),
+	)
+
+	return run, nil
+}
+
+// ListCases implements CollectionServices.
+func (s *collectionService) ListCases(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListCollectionCases")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.status", params.Value("status")),
+		attribute.String("filter.collector_id", params.Value("collector_id")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_collection_cases"), attribute.String("service", "collection")))
+
+	cases, total, err := s.collectionRepository.FindCasesPaginated(ctx, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_collection_cases", "repository_error", fmt.Errorf("failed to list collection cases: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_collection_cases", zap.Int64("total", total))
+
+	return params.Paginated(cases, total), nil
+}
+
+// GetCase implements CollectionServices.
+func (s *collectionService) GetCase(ctx context.Context, id uint64) (*domain.CollectionCase, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetCollectionCase")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("collection_case.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_collection_case"), attribute.String("service", "collection")))
+
+	collectionCase, err := s.collectionRepository.FindCaseByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_collection_case", "repository_error", fmt.Errorf("failed to get collection case: %w", err))
+	}
+	if collectionCase == nil {
+		return nil, s.recordError(ctx, span, start, "get_collection_case", "not_found", common.ErrCollectionCaseNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_collection_case", zap.Uint64("collection_case_id", id))
+
+	return collectionCase, nil
+}
+
+// Assign implements CollectionServices.
+func (s *collectionService) Assign(ctx context.Context, id uint64, req dto.CollectionAssignRequest) (*domain.CollectionCase, error) {
+	ctx, span := s.tracer.Start(ctx, "service.AssignCollectionCase")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("collection_case.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "assign_collection_case"), attribute.String("service", "collection")))
+
+	collectionCase, err := s.collectionRepository.FindCaseByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "assign_collection_case", "repository_error", fmt.Errorf("failed to get collection case: %w", err))
+	}
+	if collectionCase == nil {
+		return nil, s.recordError(ctx, span, start, "assign_collection_case", "not_found", common.ErrCollectionCaseNotFound)
+	}
+	if collectionCase.Status.Closed() {
+		return nil, s.recordError(ctx, span, start, "assign_collection_case", "case_closed", common.ErrCollectionCaseClosed)
+	}
+
+	if req.CollectorID != nil {
+		span.SetAttributes(attribute.Int64("collector.id", int64(*req.CollectorID)))
+		collector, err := s.customerRepository.FindByID(ctx, *req.CollectorID)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "assign_collection_case", "repository_error", fmt.Errorf("failed to get collector: %w", err))
+		}
+		if collector == nil || collector.Role != domain.AdminRole {
+			return nil, s.recordError(ctx, span, start, "assign_collection_case", "invalid_collector", common.ErrCollectorNotFound)
+		}
+	}
+
+	collectionCase.CollectorID = req.CollectorID
+	updated, err := s.collectionRepository.UpdateCase(ctx, collectionCase, collectionCase.Status)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "assign_collection_case", "repository_error", fmt.Errorf("failed to assign collection case: %w", err))
+	}
+	// Status kasus sudah diubah collector lain sejak dibaca
+	if !updated {
+		return nil, s.recordError(ctx, span, start, "assign_collection_case", "conflict", common.ErrVersionConflict)
+	}
+
+	s.recordSuccess(ctx, span, start, "assign_collection_case",
+		zap.Uint64("collection_case_id", id),
+		zap.Uint64p("collector_id", req.CollectorID),
+	)
+
+	return collectionCase, nil
+}
+
+// UpdateStatus implements CollectionServices.
+func (s *collectionService) UpdateStatus(ctx context.Context, id uint64, req dto.CollectionCaseStatusRequest) (*domain.CollectionCase, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UpdateCollectionCaseStatus")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("collection_case.id", int64(id)),
+		attribute.String("collection_case.status", string(req.Status)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_collection_case_status"), attribute.String("service", "collection")))
+
+	collectionCase, err := s.collectionRepository.FindCaseByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_collection_case_status", "repository_error", fmt.Errorf("failed to get collection case: %w", err))
+	}
+	if collectionCase == nil {
+		return nil, s.recordError(ctx, span, start, "update_collection_case_status", "not_found", common.ErrCollectionCaseNotFound)
+	}
+
+	from := collectionCase.Status
+	if !from.CanMoveTo(req.Status) {
+		return nil, s.recordError(ctx, span, start, "update_collection_case_status", "invalid_transition", common.ErrInvalidCollectionMove)
+	}
+
+	collectionCase.Status = req.Status
+	if req.Status.Closed() {
+		now := time.Now()
+		collectionCase.ClosedAt = &now
+	}
+
+	updated, err := s.collectionRepository.UpdateCase(ctx, collectionCase, from)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_collection_case_status", "repository_error", fmt.Errorf("failed to update collection case: %w", err))
+	}
+	// Collector lain sudah lebih dulu mengubah status kasus
+	if !updated {
+		return nil, s.recordError(ctx, span, start, "update_collection_case_status", "invalid_transition", common.ErrInvalidCollectionMove)
+	}
+
+	s.recordSuccess(ctx, span, start, "update_collection_case_status",
+		zap.Uint64("collection_case_id", id),
+		zap.String("from", string(from)),
+		zap.String("status", string(req.Status)),
+	)
+
+	return collectionCase, nil
+}
+
+// AddActivity implements CollectionServices.
+func (s *collectionService) AddActivity(ctx context.Context, id, adminID uint64, req dto.CollectionActivityRequest) (*domain.CollectionActivity, error) {
+	ctx, span := s.tracer.Start(ctx, "service.AddCollectionActivity")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("collection_case.id", int64(id)),
+		attribute.Int64("admin.id", int64(adminID)),
+		attribute.String("collection_activity.type", string(req.Type)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "add_collection_activity"), attribute.String("service", "collection")))
+
+	activity := &domain.CollectionActivity{
+		CaseID:    id,
+		Type:      req.Type,
+		Note:      req.Note,
+		CreatedBy: adminID,
+	}
+	if req.Type == domain.CollectionPromiseToPay {
+		promiseDate, err := time.Parse("2006-01-02", req.PromiseDate)
+		if err != nil || req.PromiseAmount == nil || !req.PromiseAmount.IsPositive() {
+			return nil, s.recordError(ctx, span, start, "add_collection_activity", "validation_error", common.ErrInvalidPromiseToPay)
+		}
+		// Janji untuk hari ini masih diterima
+		if promiseDate.Before(day(time.Now())) {
+			return nil, s.recordError(ctx, span, start, "add_collection_activity", "validation_error", common.ErrInvalidPromiseToPay)
+		}
+		activity.PromiseAmount = req.PromiseAmount
+		activity.PromiseDate = &promiseDate
+	}
+
+	collectionCase, err := s.collectionRepository.FindCaseByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "add_collection_activity", "repository_error", fmt.Errorf("failed to get collection case: %w", err))
+	}
+	if collectionCase == nil {
+		return nil, s.recordError(ctx, span, start, "add_collection_activity", "not_found", common.ErrCollectionCaseNotFound)
+	}
+	if collectionCase.Status.Closed() {
+		return nil, s.recordError(ctx, span, start, "add_collection_activity", "case_closed", common.ErrCollectionCaseClosed)
+	}
+
+	added, err := s.collectionRepository.AddActivity(ctx, activity)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "add_collection_activity", "repository_error", fmt.Errorf("failed to add collection activity: %w", err))
+	}
+	// Kasus ditutup di antara pengecekan di atas dan penyimpanan aktivitas
+	if !added {
+		return nil, s.recordError(ctx, span, start, "add_collection_activity", "case_closed", common.ErrCollectionCaseClosed)
+	}
+
+	s.activitiesLogged.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "collection"), attribute.String("type", string(req.Type))))
+	s.recordSuccess(ctx, span, start, "add_collection_activity",
+		zap.Uint64("collection_case_id", id),
+		zap.Uint64("activity_id", activity.ID),
+		zap.String("type", string(req.Type)),
+	)
+
+	return activity, nil
+}
+
+// ListActivities implements CollectionServices.
+func (s *collectionService) ListActivities(ctx context.Context, id uint64) ([]domain.CollectionActivity, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListCollectionActivities")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("collection_case.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_collection_activities"), attribute.String("service", "collection")))
+
+	collectionCase, err := s.collectionRepository.FindCaseByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_collection_activities", "repository_error", fmt.Errorf("failed to get collection case: %w", err))
+	}
+	if collectionCase == nil {
+		return nil, s.recordError(ctx, span, start, "list_collection_activities", "not_found", common.ErrCollectionCaseNotFound)
+	}
+
+	activities, err := s.collectionRepository.FindActivities(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_collection_activities", "repository_error", fmt.Errorf("failed to list collection activities: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_collection_activities",
+		zap.Uint64("collection_case_id", id),
+		zap.Int("activities", len(activities)),
+	)
+
+	return activities, nil
+}
+
+// Dashboard implements CollectionServices.
+func (s *collectionService) Dashboard(ctx context.Context, now time.Time) (*domain.CollectionDashboard, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CollectionDashboard")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "collection_dashboard"), attribute.String("service", "collection")))
+
+	dashboard, err := s.collectionRepository.Dashboard(ctx, day(now))
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "collection_dashboard", "repository_error", fmt.Errorf("failed to build collection dashboard: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "collection_dashboard",
+		zap.Int64("unassigned", dashboard.Unassigned),
+		zap.Int("collectors", len(dashboard.Collectors)),
+	)
+
+	return dashboard, nil
+}
+
+// delinquency returns how many days the contract is past due at asOf and
+// the installments already due but unpaid, in IDR. It counts paid
+// installments the same way as the aging report.
+func delinquency(exposure domain.AgingExposure, rules installment.Rules, asOf time.Time) (int, decimal.Decimal) {
+	months := int(exposure.TenorMonths)
+	if months == 0 {
+		return 0, decimal.Zero
+	}
+
+	elapsed := min(rules.Elapsed(exposure.TransactionDate, asOf), months)
+	paid := elapsed
+	if exposure.PaidInstallments != nil {
+		paid = min(max(*exposure.PaidInstallments, 0), months)
+	}
+
+	// Jadwal dibuat untuk seluruh tenor supaya pembulatannya sama dengan tagihan
+	overdue := decimal.Zero
+	for _, inst := range rules.Schedule(exposure.TransactionDate, 1, months, exposure.TotalInstallmentAmount) {
+		if inst.Sequence > paid && inst.Sequence <= elapsed {
+			overdue = overdue.Add(inst.Amount)
+		}
+	}
+	// Kontrak lama tanpa kurs sudah dalam rupiah
+	if exposure.FxRate.IsPositive() {
+		overdue = overdue.Mul(exposure.FxRate).Round(2)
+	}
+
+	return rules.DaysPastDue(exposure.TransactionDate, paid, months, asOf), overdue
+}
+
+// day truncates t to midnight UTC of its calendar day, matching how DATE
+// columns are read back.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *collectionService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Collection operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "collection"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "collection"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *collectionService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "collection"), attribute.String("status", "success")))
+
+	s.log.Info("Collection operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewCollectionService(
+	collectionRepository repository.CollectionRepository,
+	customerRepository repository.CustomerRepository,
+	dueDateRules service.DueDateRules,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.CollectionServices {
+	if cfg.MinDaysPastDue <= 0 {
+		cfg.MinDaysPastDue = 90
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	casesOpened, _ := meter.Int64Counter(
+		"service.collections.cases_opened",
+		metric.WithDescription("Number of collection cases opened"),
+		metric.WithUnit("{case}"),
+	)
+
+	activitiesLogged, _ := meter.Int64Counter(
+		"service.collections.activities",
+		metric.WithDescription("Number of collection activities logged"),
+		metric.WithUnit("{activity}"),
+	)
+
+	return &collectionService{
+		collectionRepository: collectionRepository,
+		customerRepository:   customerRepository,
+		dueDateRules:         dueDateRules,
+		cfg:                  cfg,
+		meter:                meter,
+		tracer:               tracer,
+		log:                  log,
+		operationDuration:    operationDuration,
+		operationCount:       operationCount,
+		errorCount:           errorCount,
+		casesOpened:          casesOpened,
+		activitiesLogged:     activitiesLogged,
+	}
+}
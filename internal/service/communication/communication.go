@@ -0,0 +1,134 @@
+package communicationsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type communicationService struct {
+	communicationRepository repository.CommunicationRepository
+	customerRepository      repository.CustomerRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// ListByCustomer implements CommunicationServices.
+func (s *communicationService) ListByCustomer(ctx context.Context, customerID uint64, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListCommunications")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.channel", params.Value("channel")),
+		attribute.String("filter.template", params.Value("template")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_communications"), attribute.String("service", "communication")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_communications", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return nil, s.recordError(ctx, span, start, "list_communications", "customer_not_found", common.ErrCustomerNotFound)
+	}
+
+	communications, total, err := s.communicationRepository.FindByCustomer(ctx, customerID, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_communications", "repository_error", fmt.Errorf("failed to list communications: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_communications",
+		zap.Uint64("customer_id", customerID),
+		zap.Int64("total", total),
+	)
+
+	return params.Paginated(communications, total), nil
+}
+
+func (s *communicationService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Communication operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "communication"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "communication"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *communicationService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "communication"), attribute.String("status", "success")))
+
+	s.log.Info("Communication operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewCommunicationService(
+	communicationRepository repository.CommunicationRepository,
+	customerRepository repository.CustomerRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.CommunicationServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &communicationService{
+		communicationRepository: communicationRepository,
+		customerRepository:      customerRepository,
+		meter:                   meter,
+		tracer:                  tracer,
+		log:                     log,
+		operationDuration:       operationDuration,
+		operationCount:          operationCount,
+		errorCount:              errorCount,
+	}
+}
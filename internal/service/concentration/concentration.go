@@ -0,0 +1,501 @@
+package concentrationsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config sets the active principal, in IDR, the portfolio must reach before
+// concentration limits are evaluated or enforced.
+type Config struct {
+	MinPortfolio decimal.Decimal
+}
+
+var dimensions = []domain.ConcentrationDimension{domain.ConcentrationAssetCategory, domain.ConcentrationRegion}
+
+type concentrationService struct {
+	concentrationRepository repository.ConcentrationRepository
+	regionRepository        repository.RegionRepository
+	cfg                     Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	breachAlerts      metric.Int64Counter
+}
+
+// ListLimits implements ConcentrationServices.
+func (s *concentrationService) ListLimits(ctx context.Context) ([]domain.ConcentrationLimit, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListConcentrationLimits")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_concentration_limits"), attribute.String("service", "concentration")))
+
+	limits, err := s.concentrationRepository.FindLimits(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_concentration_limits", "repository_error", fmt.Errorf("failed to get concentration limits: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_concentration_limits", zap.Int("limits", len(limits)))
+
+	return limits, nil
+}
+
+// CreateLimit implements ConcentrationServices.
+func (s *concentrationService) CreateLimit(ctx context.Context, updatedBy uint64, req dto.ConcentrationLimitRequest) (*domain.ConcentrationLimit, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CreateConcentrationLimit")
+	defer span.End()
+
+	start := time.Now()
+	value := req.Dimension.Normalize(req.Value)
+	span.SetAttributes(
+		attribute.String("concentration_limit.dimension", string(req.Dimension)),
+		attribute.String("concentration_limit.value", value),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_concentration_limit"), attribute.String("service", "concentration")))
+
+	// Limit region hanya masuk akal untuk kode yang ada di tabel referensi
+	if req.Dimension == domain.ConcentrationRegion {
+		region, err := s.regionRepository.FindByCode(ctx, value)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "create_concentration_limit", "repository_error", fmt.Errorf("failed to find region: %w", err))
+		}
+		if region == nil {
+			return nil, s.recordError(ctx, span, start, "create_concentration_limit", "region_not_found", common.ErrRegionNotFound)
+		}
+	}
+
+	existing, err := s.concentrationRepository.FindLimit(ctx, req.Dimension, value)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "create_concentration_limit", "repository_error", fmt.Errorf("failed to find concentration limit: %w", err))
+	}
+	if existing != nil {
+		return nil, s.recordError(ctx, span, start, "create_concentration_limit", "limit_exists", common.ErrConcentrationExists)
+	}
+
+	limit := &domain.ConcentrationLimit{
+		Dimension:  req.Dimension,
+		Value:      value,
+		MaxPercent: req.MaxPercent.Round(2),
+		Enforce:    req.Enforce,
+		UpdatedBy:  updatedBy,
+	}
+	if err := s.concentrationRepository.CreateLimit(ctx, limit); err != nil {
+		// Dua admin yang membuat limit yang sama bersamaan ditahan oleh unique index
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, s.recordError(ctx, span, start, "create_concentration_limit", "limit_exists", common.ErrConcentrationExists)
+		}
+		return nil, s.recordError(ctx, span, start, "create_concentration_limit", "repository_error", fmt.Errorf("failed to create concentration limit: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "create_concentration_limit",
+		zap.Uint64("limit_id", limit.ID),
+		zap.String("dimension", string(limit.Dimension)),
+		zap.String("value", limit.Value),
+		zap.Stringer("max_percent", limit.MaxPercent),
+		zap.Bool("enforce", limit.Enforce),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return limit, nil
+}
+
+// UpdateLimit implements ConcentrationServices.
+func (s *concentrationService) UpdateLimit(ctx context.Context, id, updatedBy uint64, req dto.ConcentrationLimitUpdateRequest) (*domain.ConcentrationLimit, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UpdateConcentrationLimit")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("concentration_limit.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_concentration_limit"), attribute.String("service", "concentration")))
+
+	limit, err := s.concentrationRepository.FindLimitByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_concentration_limit", "repository_error", fmt.Errorf("failed to find concentration limit: %w", err))
+	}
+	if limit == nil {
+		return nil, s.recordError(ctx, span, start, "update_concentration_limit", "not_found", common.ErrConcentrationNotFound)
+	}
+
+	limit.MaxPercent = req.MaxPercent.Round(2)
+	limit.Enforce = req.Enforce
+	limit.UpdatedBy = updatedBy
+	if err := s.concentrationRepository.UpdateLimit(ctx, limit); err != nil {
+		return nil, s.recordError(ctx, span, start, "update_concentration_limit", "repository_error", fmt.Errorf("failed to update concentration limit: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "update_concentration_limit",
+		zap.Uint64("limit_id", limit.ID),
+		zap.Stringer("max_percent", limit.MaxPercent),
+		zap.Bool("enforce", limit.Enforce),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return limit, nil
+}
+
+// DeleteLimit implements ConcentrationServices.
+func (s *concentrationService) DeleteLimit(ctx context.Context, id uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteConcentrationLimit")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("concentration_limit.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_concentration_limit"), attribute.String("service", "concentration")))
+
+	deleted, err := s.concentrationRepository.DeleteLimit(ctx, id)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_concentration_limit", "repository_error", fmt.Errorf("failed to delete concentration limit: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_concentration_limit", "not_found", common.ErrConcentrationNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "delete_concentration_limit", zap.Uint64("limit_id", id))
+
+	return nil
+}
+
+// Report implements ConcentrationServices.
+func (s *concentrationService) Report(ctx context.Context, now time.Time) (*domain.ConcentrationReport, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ConcentrationReport")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "concentration_report"), attribute.String("service", "concentration")))
+
+	limits, err := s.concentrationRepository.FindLimits(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "concentration_report", "repository_error", fmt.Errorf("failed to get concentration limits: %w", err))
+	}
+	report, err := s.report(ctx, limits, now)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "concentration_report", "repository_error", err)
+	}
+
+	s.recordSuccess(ctx, span, start, "concentration_report",
+		zap.Stringer("total_principal", report.TotalPrincipal),
+		zap.Int("concentrations", len(report.Concentrations)),
+	)
+
+	return report, nil
+}
+
+// Evaluate implements ConcentrationServices.
+func (s *concentrationService) Evaluate(ctx context.Context, now time.Time) (*domain.ConcentrationRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.EvaluateConcentrationLimits")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "evaluate_concentration_limits"), attribute.String("service", "concentration")))
+
+	limits, err := s.concentrationRepository.FindLimits(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "evaluate_concentration_limits", "repository_error", fmt.Errorf("failed to get concentration limits: %w", err))
+	}
+
+	run := &domain.ConcentrationRun{Limits: len(limits)}
+	if len(limits) == 0 {
+		s.recordSuccess(ctx, span, start, "evaluate_concentration_limits", zap.Int("limits", 0))
+		return run, nil
+	}
+
+	report, err := s.report(ctx, limits, now)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "evaluate_concentration_limits", "repository_error", err)
+	}
+
+	for _, concentration := range report.Concentrations {
+		limit := concentration.Limit
+		if limit == nil {
+			continue
+		}
+
+		breached := report.Evaluated() && concentration.Breached()
+		switch {
+		case breached && limit.BreachedAt == nil:
+			if err := s.concentrationRepository.SetBreachedAt(ctx, limit.ID, &now); err != nil {
+				return nil, s.recordError(ctx, span, start, "evaluate_concentration_limits", "repository_error", fmt.Errorf("failed to record concentration breach: %w", err))
+			}
+			run.Opened++
+			s.alertBreach(ctx, span, concentration, report.TotalPrincipal)
+		case !breached && limit.BreachedAt != nil:
+			if err := s.concentrationRepository.SetBreachedAt(ctx, limit.ID, nil); err != nil {
+				return nil, s.recordError(ctx, span, start, "evaluate_concentration_limits", "repository_error", fmt.Errorf("failed to clear concentration breach: %w", err))
+			}
+			run.Cleared++
+			s.log.Info("Portfolio concentration back within limit",
+				zap.Uint64("limit_id", limit.ID),
+				zap.String("dimension", string(limit.Dimension)),
+				zap.String("value", limit.Value),
+				zap.Stringer("percent", concentration.Percent),
+				zap.Stringer("max_percent", limit.MaxPercent),
+				zap.Time("breached_at", *limit.BreachedAt),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+			)
+		}
+		if breached {
+			run.Breached++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("concentration.limits", run.Limits),
+		attribute.Int("concentration.breached", run.Breached),
+	)
+	s.recordSuccess(ctx, span, start, "evaluate_concentration_limits",
+		zap.Int("limits", run.Limits),
+		zap.Int("breached", run.Breached),
+		zap.Int("opened", run.Opened),
+		zap.Int("cleared", run.Cleared),
+		zap.Stringer("total_principal", report.TotalPrincipal),
+	)
+
+	return run, nil
+}
+
+// Check implements ConcentrationServices.
+func (s *concentrationService) Check(ctx context.Context, assetCategory, regionCode string, principal decimal.Decimal) error {
+	ctx, span := s.tracer.Start(ctx, "service.CheckConcentration")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("transaction.asset_category", assetCategory),
+		attribute.String("transaction.region_code", regionCode),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_concentration"), attribute.String("service", "concentration")))
+
+	limits, err := s.concentrationRepository.FindLimits(ctx)
+	if err != nil {
+		return s.recordError(ctx, span, start, "check_concentration", "repository_error", fmt.Errorf("failed to get concentration limits: %w", err))
+	}
+
+	values := map[domain.ConcentrationDimension]string{
+		domain.ConcentrationAssetCategory: domain.ConcentrationAssetCategory.Normalize(assetCategory),
+		domain.ConcentrationRegion:        domain.ConcentrationRegion.Normalize(regionCode),
+	}
+	var enforced []domain.ConcentrationLimit
+	for _, limit := range limits {
+		if limit.Enforce && values[limit.Dimension] != "" && limit.Value == values[limit.Dimension] {
+			enforced = append(enforced, limit)
+		}
+	}
+	// Sebagian besar transaksi tidak tersentuh limit yang ditegakkan, jadi
+	// portofolio hanya dijumlahkan bila memang perlu
+	if len(enforced) == 0 {
+		span.SetStatus(codes.Ok, "No enforced concentration limit applies")
+		s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", "check_concentration"), attribute.String("service", "concentration"), attribute.String("status", "success")))
+		return nil
+	}
+
+	total, err := s.concentrationRepository.TotalPrincipal(ctx)
+	if err != nil {
+		return s.recordError(ctx, span, start, "check_concentration", "repository_error", fmt.Errorf("failed to sum portfolio principal: %w", err))
+	}
+	if !(domain.ConcentrationReport{TotalPrincipal: total, MinPortfolio: s.cfg.MinPortfolio}).Evaluated() {
+		span.SetStatus(codes.Ok, "Portfolio below minimum for concentration limits")
+		s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", "check_concentration"), attribute.String("service", "concentration"), attribute.String("status", "success")))
+		return nil
+	}
+
+	for _, limit := range enforced {
+		held, err := s.concentrationRepository.SumPrincipalFor(ctx, limit.Dimension, limit.Value)
+		if err != nil {
+			return s.recordError(ctx, span, start, "check_concentration", "repository_error", fmt.Errorf("failed to sum concentration principal: %w", err))
+		}
+
+		after := domain.SharePercent(held.Add(principal), total.Add(principal))
+		if after.GreaterThan(limit.MaxPercent) {
+			s.log.Warn("Transaction would exceed concentration limit",
+				zap.Uint64("limit_id", limit.ID),
+				zap.String("dimension", string(limit.Dimension)),
+				zap.String("value", limit.Value),
+				zap.Stringer("percent_after", after),
+				zap.Stringer("max_percent", limit.MaxPercent),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+			)
+			return s.recordError(ctx, span, start, "check_concentration", "concentration_exceeded", common.ErrConcentrationExceeded)
+		}
+	}
+
+	s.recordSuccess(ctx, span, start, "check_concentration",
+		zap.String("asset_category", values[domain.ConcentrationAssetCategory]),
+		zap.String("region_code", values[domain.ConcentrationRegion]),
+		zap.Int("limits", len(enforced)),
+	)
+
+	return nil
+}
+
+// report sums the portfolio by every dimension and pairs each value with
+// its limit. Limits on values without active principal are listed with a
+// zero share so every limit shows up.
+func (s *concentrationService) report(ctx context.Context, limits []domain.ConcentrationLimit, now time.Time) (*domain.ConcentrationReport, error) {
+	total, err := s.concentrationRepository.TotalPrincipal(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum portfolio principal: %w", err)
+	}
+
+	report := &domain.ConcentrationReport{
+		TotalPrincipal: total,
+		MinPortfolio:   s.cfg.MinPortfolio,
+		GeneratedAt:    now,
+	}
+	for _, dimension := range dimensions {
+		byValue := make(map[string]*domain.ConcentrationLimit)
+		for i := range limits {
+			if limits[i].Dimension == dimension {
+				byValue[limits[i].Value] = &limits[i]
+			}
+		}
+
+		rows, err := s.concentrationRepository.SumPrincipal(ctx, dimension)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sum concentration principal: %w", err)
+		}
+		for _, row := range rows {
+			row.Percent = domain.SharePercent(row.Principal, total)
+			row.Limit = byValue[row.Value]
+			delete(byValue, row.Value)
+			report.Concentrations = append(report.Concentrations, row)
+		}
+		for i := range limits {
+			if limit, ok := byValue[limits[i].Value]; ok && limits[i].Dimension == dimension {
+				report.Concentrations = append(report.Concentrations, domain.Concentration{
+					Dimension: dimension,
+					Value:     limit.Value,
+					Principal: decimal.Zero,
+					Percent:   decimal.Zero,
+					Limit:     limit,
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// alertBreach reports a limit the first time the evaluation job finds it
+// breached, it is not repeated until the share has been back within the
+// limit.
+func (s *concentrationService) alertBreach(ctx context.Context, span trace.Span, concentration domain.Concentration, total decimal.Decimal) {
+	limit := concentration.Limit
+	s.breachAlerts.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("service", "concentration"),
+		attribute.String("dimension", string(limit.Dimension)),
+		attribute.String("value", limit.Value),
+	))
+	s.log.Warn("Portfolio concentration limit breached",
+		zap.Uint64("limit_id", limit.ID),
+		zap.String("dimension", string(limit.Dimension)),
+		zap.String("value", limit.Value),
+		zap.Stringer("principal", concentration.Principal),
+		zap.Stringer("total_principal", total),
+		zap.Stringer("percent", concentration.Percent),
+		zap.Stringer("max_percent", limit.MaxPercent),
+		zap.Bool("enforce", limit.Enforce),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+}
+
+func (s *concentrationService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Concentration operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "concentration"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "concentration"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *concentrationService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "concentration"), attribute.String("status", "success")))
+
+	s.log.Info("Concentration operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewConcentrationService(
+	concentrationRepository repository.ConcentrationRepository,
+	regionRepository repository.RegionRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ConcentrationServices {
+	if cfg.MinPortfolio.IsNegative() {
+		cfg.MinPortfolio = decimal.Zero
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	breachAlerts, _ := meter.Int64Counter(
+		"service.concentration.breaches",
+		metric.WithDescription("Number of times a portfolio concentration limit started being breached"),
+		metric.WithUnit("{alert}"),
+	)
+
+	return &concentrationService{
+		concentrationRepository: concentrationRepository,
+		regionRepository:        regionRepository,
+		cfg:                     cfg,
+		meter:                   meter,
+		tracer:                  tracer,
+		log:                     log,
+		operationDuration:       operationDuration,
+		operationCount:          operationCount,
+		errorCount:              errorCount,
+		breachAlerts:            breachAlerts,
+	}
+}
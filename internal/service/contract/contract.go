@@ -0,0 +1,254 @@
+package contractsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type contractLookupService struct {
+	transactionRepository repository.TransactionRepository
+	customerRepository    repository.CustomerRepository
+	tenorRepository       repository.TenorRepository
+	dueDateRules          service.DueDateRules
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// FindByContractNumber implements ContractLookupServices.
+func (s *contractLookupService) FindByContractNumber(ctx context.Context, contractNumber string) (*dto.ContractLookupResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.FindContractByNumber")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("transaction.contract_number", contractNumber))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "find_by_contract_number"), attribute.String("service", "contract")))
+
+	transaction, err := s.transactionRepository.FindByContractNumber(ctx, contractNumber)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "find_by_contract_number", "repository_error", fmt.Errorf("failed to get transaction: %w", err))
+	}
+	if transaction == nil {
+		return nil, s.recordError(ctx, span, start, "find_by_contract_number", "transaction_not_found", common.ErrTransactionNotFound)
+	}
+
+	customer, err := s.customerRepository.FindByID(ctx, transaction.CustomerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "find_by_contract_number", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer != nil {
+		transaction.Customer = *customer
+	}
+
+	tenors, err := s.tenorMonths(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "find_by_contract_number", "repository_error", err)
+	}
+
+	response := contractLookup(*transaction, tenors[transaction.TenorID], s.dueDateRules.Rules(ctx), time.Now())
+
+	s.recordSuccess(ctx, span, start, "find_by_contract_number",
+		zap.String("contract_number", contractNumber),
+		zap.Uint64("customer_id", transaction.CustomerID),
+	)
+
+	return &response, nil
+}
+
+// Search implements ContractLookupServices.
+func (s *contractLookupService) Search(ctx context.Context, prefix string, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SearchContracts")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("transaction.contract_number_prefix", prefix),
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "search_contracts"), attribute.String("service", "contract")))
+
+	transactions, total, err := s.transactionRepository.FindPaginatedByContractNumberPrefix(ctx, prefix, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "search_contracts", "repository_error", fmt.Errorf("failed to search transactions: %w", err))
+	}
+
+	tenors, err := s.tenorMonths(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "search_contracts", "repository_error", err)
+	}
+
+	now := time.Now()
+	rules := s.dueDateRules.Rules(ctx)
+	responses := make([]dto.ContractLookupResponse, len(transactions))
+	for i, transaction := range transactions {
+		responses[i] = contractLookup(transaction, tenors[transaction.TenorID], rules, now)
+	}
+
+	s.recordSuccess(ctx, span, start, "search_contracts",
+		zap.String("prefix", prefix),
+		zap.Int64("total", total),
+	)
+
+	return params.Paginated(responses, total), nil
+}
+
+// tenorMonths maps every tenor ID to its duration in months.
+func (s *contractLookupService) tenorMonths(ctx context.Context) (map[uint]uint8, error) {
+	tenors, err := s.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenors: %w", err)
+	}
+
+	months := make(map[uint]uint8, len(tenors))
+	for _, tenor := range tenors {
+		months[tenor.ID] = tenor.DurationMonths
+	}
+	return months, nil
+}
+
+// contractLookup summarizes a contract for support. Like the customer's own
+// transaction detail, due installments of an ACTIVE contract count as paid
+// until repayments are recorded.
+func contractLookup(tx domain.Transaction, months uint8, rules installment.Rules, now time.Time) dto.ContractLookupResponse {
+	response := dto.ContractLookupResponse{
+		ID:                     tx.ID,
+		ContractNumber:         tx.ContractNumber,
+		Status:                 string(tx.Status),
+		AssetName:              tx.AssetName,
+		Currency:               currency.Normalize(tx.Currency),
+		TransactionDate:        tx.TransactionDate,
+		TotalInstallmentAmount: tx.TotalInstallmentAmount,
+		PartnerID:              tx.PartnerID,
+		IsSandbox:              tx.IsSandbox,
+		Customer: dto.ContractCustomerResponse{
+			ID:                 tx.CustomerID,
+			NIK:                tx.Customer.NIK,
+			FullName:           tx.Customer.FullName,
+			VerificationStatus: string(tx.Customer.VerificationStatus),
+		},
+		Installments: dto.InstallmentSummaryResponse{TenorMonths: months},
+	}
+	if months == 0 || tx.Status == domain.TransactionCancelled {
+		return response
+	}
+
+	paid := 0
+	switch tx.Status {
+	case domain.TransactionPaidOff:
+		paid = int(months)
+	case domain.TransactionActive, domain.TransactionWrittenOff:
+		paid = min(rules.Elapsed(tx.TransactionDate, now), int(months))
+		if tx.PaidInstallments != nil {
+			paid = min(max(*tx.PaidInstallments, 0), int(months))
+		}
+	}
+
+	summary := &response.Installments
+	summary.PaidInstallments = paid
+	for _, inst := range rules.Schedule(tx.TransactionDate, 1, int(months), tx.TotalInstallmentAmount) {
+		if inst.Sequence == 1 {
+			summary.MonthlyInstallment = inst.Amount
+		}
+		if inst.Sequence <= paid {
+			summary.TotalPaid = summary.TotalPaid.Add(inst.Amount)
+		} else if summary.NextDueDate == nil {
+			dueDate := inst.DueDate
+			summary.NextDueDate = &dueDate
+		}
+	}
+	summary.OutstandingBalance = tx.TotalInstallmentAmount.Sub(summary.TotalPaid)
+
+	return response
+}
+
+func (s *contractLookupService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Contract lookup operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "contract"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "contract"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *contractLookupService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "contract"), attribute.String("status", "success")))
+
+	s.log.Info("Contract lookup operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewContractLookupService(
+	transactionRepository repository.TransactionRepository,
+	customerRepository repository.CustomerRepository,
+	tenorRepository repository.TenorRepository,
+	dueDateRules service.DueDateRules,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ContractLookupServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &contractLookupService{
+		transactionRepository: transactionRepository,
+		customerRepository:    customerRepository,
+		tenorRepository:       tenorRepository,
+		dueDateRules:          dueDateRules,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+	}
+}
@@ -0,0 +1,241 @@
+package contractsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ImportConfig limits how many contracts one import may carry and how many
+// rows go into each INSERT.
+type ImportConfig struct {
+	MaxItems  int
+	BatchSize int
+}
+
+type contractImportService struct {
+	transactionRepository repository.TransactionRepository
+	customerRepository    repository.CustomerRepository
+	tenorRepository       repository.TenorRepository
+	cfg                   ImportConfig
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	contractsImported metric.Int64Counter
+}
+
+// Import implements ContractImportServices. Every contract is checked before
+// the first row is written; the customer's exposure catches up on the next
+// exposure-reconcile run.
+func (s *contractImportService) Import(ctx context.Context, req dto.ContractImportRequest) (*dto.ContractImportResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ImportContracts")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("import.contracts", len(req.Contracts)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "import_contracts"), attribute.String("service", "contract")))
+
+	if len(req.Contracts) > s.cfg.MaxItems {
+		return nil, s.recordError(ctx, span, start, "import_contracts", "batch_too_large", common.ErrBatchTooLarge)
+	}
+
+	tenors, err := s.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "import_contracts", "repository_error", fmt.Errorf("failed to get tenors: %w", err))
+	}
+	tenorByMonths := make(map[uint8]domain.Tenor, len(tenors))
+	for _, tenor := range tenors {
+		tenorByMonths[tenor.DurationMonths] = tenor
+	}
+
+	now := time.Now()
+	customers := make(map[string]*domain.Customer)
+	seen := make(map[string]bool, len(req.Contracts))
+	transactions := make([]domain.Transaction, len(req.Contracts))
+	for i, item := range req.Contracts {
+		number := strings.ToUpper(strings.TrimSpace(item.ContractNumber))
+		if seen[number] {
+			return nil, s.recordError(ctx, span, start, "import_contracts", "duplicate_contract", fmt.Errorf("contract %s is listed twice: %w", number, common.ErrDuplicateContract))
+		}
+		seen[number] = true
+
+		tenor, ok := tenorByMonths[item.TenorMonths]
+		if !ok {
+			return nil, s.recordError(ctx, span, start, "import_contracts", "tenor_not_found", fmt.Errorf("contract %s: %w", number, common.ErrTenorNotFound))
+		}
+
+		customer, ok := customers[item.CustomerNIK]
+		if !ok {
+			customer, err = s.customerRepository.FindByNIK(ctx, item.CustomerNIK)
+			if err != nil {
+				return nil, s.recordError(ctx, span, start, "import_contracts", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+			}
+			customers[item.CustomerNIK] = customer
+		}
+		if customer == nil {
+			return nil, s.recordError(ctx, span, start, "import_contracts", "customer_not_found", fmt.Errorf("contract %s: %w", number, common.ErrCustomerNotFound))
+		}
+
+		transaction, err := importedContract(number, item, customer.ID, tenor, now)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "import_contracts", "invalid_contract", err)
+		}
+		transactions[i] = transaction
+	}
+
+	if err := s.transactionRepository.CreateMany(ctx, transactions, s.cfg.BatchSize); err != nil {
+		// Nomor kontrak yang sudah ada di database baru ketahuan saat insert
+		if errors.Is(err, common.ErrDuplicateContract) {
+			return nil, s.recordError(ctx, span, start, "import_contracts", "duplicate_contract", err)
+		}
+		return nil, s.recordError(ctx, span, start, "import_contracts", "repository_error", fmt.Errorf("failed to create transactions: %w", err))
+	}
+
+	response := &dto.ContractImportResponse{
+		Imported:  len(transactions),
+		Contracts: make([]dto.ImportedContractResponse, len(transactions)),
+	}
+	for i, transaction := range transactions {
+		response.Contracts[i] = dto.ImportedContractResponse{
+			ID:             transaction.ID,
+			ContractNumber: transaction.ContractNumber,
+			CustomerID:     transaction.CustomerID,
+		}
+	}
+
+	s.contractsImported.Add(ctx, int64(len(transactions)), metric.WithAttributes(attribute.String("service", "contract")))
+	s.recordSuccess(ctx, span, start, "import_contracts", zap.Int("imported", len(transactions)))
+
+	return response, nil
+}
+
+// importedContract builds the transaction stored for item. Imported contracts
+// are booked in IDR on the date they were originally signed.
+func importedContract(number string, item dto.ContractImportItemRequest, customerID uint64, tenor domain.Tenor, now time.Time) (domain.Transaction, error) {
+	transactionDate, err := datetime.ParseDate(item.TransactionDate)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("contract %s has an invalid transaction date: %w", number, common.ErrInvalidContractImport)
+	}
+	if transactionDate.After(now) {
+		return domain.Transaction{}, fmt.Errorf("contract %s is dated in the future: %w", number, common.ErrInvalidContractImport)
+	}
+	if item.PaidInstallments != nil && *item.PaidInstallments > int(tenor.DurationMonths) {
+		return domain.Transaction{}, fmt.Errorf("contract %s has more paid installments than its tenor: %w", number, common.ErrInvalidContractImport)
+	}
+
+	return domain.Transaction{
+		ContractNumber:         number,
+		CustomerID:             customerID,
+		TenorID:                tenor.ID,
+		AssetName:              item.AssetName,
+		OTRAmount:              item.OTRAmount,
+		AdminFee:               item.AdminFee,
+		TotalInterest:          item.TotalInterest,
+		TotalInstallmentAmount: item.TotalInstallmentAmount,
+		Status:                 domain.TransactionStatus(item.Status),
+		TransactionDate:        transactionDate,
+		Currency:               currency.IDR,
+		FxRate:                 decimal.NewFromInt(1),
+		PaidInstallments:       item.PaidInstallments,
+	}, nil
+}
+
+func (s *contractImportService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Contract import operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "contract"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "contract"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *contractImportService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "contract"), attribute.String("status", "success")))
+
+	s.log.Info("Contract import operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewContractImportService(
+	transactionRepository repository.TransactionRepository,
+	customerRepository repository.CustomerRepository,
+	tenorRepository repository.TenorRepository,
+	cfg ImportConfig,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ContractImportServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	contractsImported, _ := meter.Int64Counter(
+		"service.contract.imported",
+		metric.WithDescription("Number of contracts imported in bulk"),
+		metric.WithUnit("{contract}"),
+	)
+
+	return &contractImportService{
+		transactionRepository: transactionRepository,
+		customerRepository:    customerRepository,
+		tenorRepository:       tenorRepository,
+		cfg:                   cfg,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+		contractsImported:     contractsImported,
+	}
+}
@@ -0,0 +1,239 @@
+package customernotesrv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type customerNoteService struct {
+	customerRepository repository.CustomerRepository
+	noteRepository     repository.CustomerNoteRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// ListNotes implements CustomerNoteServices.
+func (s *customerNoteService) ListNotes(ctx context.Context, customerID uint64) ([]domain.CustomerNote, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListCustomerNotes")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_customer_notes"), attribute.String("service", "customer_note")))
+
+	if err := s.ensureCustomer(ctx, customerID); err != nil {
+		return nil, s.recordError(ctx, span, start, "list_customer_notes", "customer_lookup_error", err)
+	}
+
+	notes, err := s.noteRepository.FindByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_customer_notes", "repository_error", fmt.Errorf("failed to find customer notes: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_customer_notes",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("count", len(notes)),
+	)
+
+	return notes, nil
+}
+
+// CreateNote implements CustomerNoteServices.
+func (s *customerNoteService) CreateNote(ctx context.Context, customerID, authorID uint64, req dto.CustomerNoteRequest) (*domain.CustomerNote, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CreateCustomerNote")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int64("admin.id", int64(authorID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_customer_note"), attribute.String("service", "customer_note")))
+
+	if err := s.ensureCustomer(ctx, customerID); err != nil {
+		return nil, s.recordError(ctx, span, start, "create_customer_note", "customer_lookup_error", err)
+	}
+
+	note := &domain.CustomerNote{
+		CustomerID: customerID,
+		AuthorID:   authorID,
+		Body:       strings.TrimSpace(req.Body),
+		Pinned:     req.Pinned,
+		UpdatedBy:  authorID,
+	}
+	if err := s.noteRepository.Create(ctx, note); err != nil {
+		return nil, s.recordError(ctx, span, start, "create_customer_note", "repository_error", fmt.Errorf("failed to create customer note: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "create_customer_note",
+		zap.Uint64("note_id", note.ID),
+		zap.Uint64("customer_id", customerID),
+		zap.Uint64("author_id", authorID),
+	)
+
+	return note, nil
+}
+
+// UpdateNote implements CustomerNoteServices.
+func (s *customerNoteService) UpdateNote(ctx context.Context, customerID, noteID, updatedBy uint64, req dto.CustomerNoteRequest) (*domain.CustomerNote, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UpdateCustomerNote")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int64("note.id", int64(noteID)),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_customer_note"), attribute.String("service", "customer_note")))
+
+	current, err := s.noteRepository.FindByID(ctx, noteID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_customer_note", "repository_error", fmt.Errorf("failed to find customer note: %w", err))
+	}
+	// Catatan milik customer lain diperlakukan sama dengan catatan yang tidak ada
+	if current == nil || current.CustomerID != customerID {
+		return nil, s.recordError(ctx, span, start, "update_customer_note", "not_found", common.ErrCustomerNoteNotFound)
+	}
+
+	body := strings.TrimSpace(req.Body)
+	// Menyimpan isi yang sama tidak menambah revisi
+	if current.Body == body && current.Pinned == req.Pinned {
+		s.recordSuccess(ctx, span, start, "update_customer_note",
+			zap.Uint64("note_id", noteID),
+			zap.Bool("changed", false),
+		)
+		return current, nil
+	}
+
+	note := &domain.CustomerNote{
+		ID:         noteID,
+		CustomerID: customerID,
+		Body:       body,
+		Pinned:     req.Pinned,
+		UpdatedBy:  updatedBy,
+	}
+	updated, err := s.noteRepository.Update(ctx, note)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_customer_note", "repository_error", fmt.Errorf("failed to update customer note: %w", err))
+	}
+	if !updated {
+		return nil, s.recordError(ctx, span, start, "update_customer_note", "not_found", common.ErrCustomerNoteNotFound)
+	}
+
+	// Dibaca ulang agar revisi yang baru tertulis ikut dikembalikan
+	note, err = s.noteRepository.FindByID(ctx, noteID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_customer_note", "repository_error", fmt.Errorf("failed to find customer note: %w", err))
+	}
+	if note == nil {
+		return nil, s.recordError(ctx, span, start, "update_customer_note", "not_found", common.ErrCustomerNoteNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "update_customer_note",
+		zap.Uint64("note_id", noteID),
+		zap.Uint64("customer_id", customerID),
+		zap.Uint64("updated_by", updatedBy),
+		zap.Bool("changed", true),
+	)
+
+	return note, nil
+}
+
+func (s *customerNoteService) ensureCustomer(ctx context.Context, customerID uint64) error {
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to find customer: %w", err)
+	}
+	if customer == nil {
+		return common.ErrCustomerNotFound
+	}
+	return nil
+}
+
+func (s *customerNoteService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Customer note operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "customer_note"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "customer_note"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *customerNoteService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "customer_note"), attribute.String("status", "success")))
+
+	s.log.Info("Customer note operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewCustomerNoteService(
+	customerRepository repository.CustomerRepository,
+	noteRepository repository.CustomerNoteRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.CustomerNoteServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &customerNoteService{
+		customerRepository: customerRepository,
+		noteRepository:     noteRepository,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+	}
+}
@@ -0,0 +1,296 @@
+package dashboardsrv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// registrationBatch caps the registrations read per refresh, the rest
+	// follow on the next one.
+	registrationBatch = 100
+	// subscriberBuffer is how many snapshots a subscriber may fall behind
+	// before it is dropped.
+	subscriberBuffer = 16
+)
+
+type dashboardService struct {
+	customerRepository      repository.CustomerRepository
+	customerEventRepository repository.CustomerEventRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	subscriberGauge   metric.Int64UpDownCounter
+
+	shutdown sync.Once
+
+	mu     sync.Mutex
+	hubs   map[string]*hub
+	closed bool
+}
+
+// hub is the dashboard of one tenant: its subscribers and what was last sent
+// to them.
+type hub struct {
+	subscribers map[chan domain.DashboardSnapshot]struct{}
+	latest      *domain.DashboardSnapshot
+	// cursor is the last registration event delivered, valid while following
+	cursor    uint64
+	following bool
+}
+
+// hub returns the hub of tenantID, creating it on first use. s.mu must be
+// held.
+func (s *dashboardService) hub(tenantID string) *hub {
+	h, ok := s.hubs[tenantID]
+	if !ok {
+		h = &hub{subscribers: make(map[chan domain.DashboardSnapshot]struct{})}
+		s.hubs[tenantID] = h
+	}
+	return h
+}
+
+// Refresh implements DashboardServices. It refreshes the dashboard of the
+// tenant in ctx. The ctx of the first call is watched for the shutdown: once
+// it is cancelled every subscription is closed, so open streams end and the
+// server can stop.
+func (s *dashboardService) Refresh(ctx context.Context, now time.Time) (*domain.DashboardSnapshot, error) {
+	s.shutdown.Do(func() { context.AfterFunc(ctx, s.close) })
+
+	// Tanpa pelanggan tidak ada yang perlu dibaca; cursor diambil ulang dari
+	// event terbaru saat ada yang berlangganan lagi
+	s.mu.Lock()
+	h := s.hub(tenancy.ID(ctx))
+	if len(h.subscribers) == 0 {
+		h.following = false
+		h.latest = nil
+		s.mu.Unlock()
+		return nil, nil
+	}
+	cursor, following := h.cursor, h.following
+	s.mu.Unlock()
+
+	ctx, span := s.tracer.Start(ctx, "service.DashboardRefresh")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "dashboard_refresh"), attribute.String("service", "dashboard")))
+
+	// Pendaftaran sebelum pelanggan pertama tidak diputar ulang
+	if !following {
+		latestID, err := s.customerEventRepository.LatestID(ctx)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "dashboard_refresh", "repository_error", fmt.Errorf("failed to get latest event: %w", err))
+		}
+		cursor = latestID
+	}
+
+	registrations, err := s.customerEventRepository.FindAfter(ctx, cursor, []domain.CustomerEventType{domain.CustomerRegistered}, registrationBatch)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "dashboard_refresh", "repository_error", fmt.Errorf("failed to get registrations: %w", err))
+	}
+	if len(registrations) > 0 {
+		cursor = registrations[len(registrations)-1].ID
+	}
+
+	queue, err := s.customerRepository.CountByStatus(ctx, domain.VerificationPending)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "dashboard_refresh", "repository_error", fmt.Errorf("failed to count verification queue: %w", err))
+	}
+
+	perMinute, err := s.customerEventRepository.CountSince(ctx, domain.CustomerTransactionCreated, now.Add(-time.Minute))
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "dashboard_refresh", "repository_error", fmt.Errorf("failed to count transactions: %w", err))
+	}
+
+	snapshot := &domain.DashboardSnapshot{
+		Registrations:         registrations,
+		VerificationQueue:     queue,
+		TransactionsPerMinute: perMinute,
+		GeneratedAt:           now,
+	}
+
+	s.mu.Lock()
+	h.cursor, h.following, h.latest = cursor, true, snapshot
+	delivered, dropped := h.broadcast(*snapshot)
+	s.mu.Unlock()
+
+	if dropped > 0 {
+		s.subscriberGauge.Add(ctx, -int64(dropped))
+		s.log.Warn("Dropped slow dashboard subscribers", zap.Int("dropped", dropped))
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant.id", tenancy.ID(ctx)),
+		attribute.Int("dashboard.registrations", len(registrations)),
+		attribute.Int("dashboard.subscribers", delivered),
+	)
+	s.recordSuccess(ctx, span, start, "dashboard_refresh",
+		zap.Int("registrations", len(registrations)),
+		zap.Int64("verification_queue", queue),
+		zap.Int64("transactions_per_minute", perMinute),
+		zap.Int("subscribers", delivered),
+	)
+
+	return snapshot, nil
+}
+
+// broadcast delivers snapshot without blocking. A subscriber whose buffer is
+// full is closed, its client reconnects and starts again from the latest
+// counters. s.mu must be held.
+func (h *hub) broadcast(snapshot domain.DashboardSnapshot) (delivered, dropped int) {
+	for updates := range h.subscribers {
+		select {
+		case updates <- snapshot:
+			delivered++
+		default:
+			delete(h.subscribers, updates)
+			close(updates)
+			dropped++
+		}
+	}
+	return delivered, dropped
+}
+
+// Subscribe implements DashboardServices.
+func (s *dashboardService) Subscribe(ctx context.Context) (<-chan domain.DashboardSnapshot, func()) {
+	updates := make(chan domain.DashboardSnapshot, subscriberBuffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		close(updates)
+		return updates, func() {}
+	}
+
+	// Pendaftaran pada snapshot terakhir sudah terkirim ke pelanggan lain,
+	// pelanggan baru hanya menerima angkanya
+	h := s.hub(tenancy.ID(ctx))
+	if h.latest != nil {
+		current := *h.latest
+		current.Registrations = nil
+		updates <- current
+	}
+	h.subscribers[updates] = struct{}{}
+	s.subscriberGauge.Add(context.Background(), 1)
+
+	return updates, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := h.subscribers[updates]; ok {
+			delete(h.subscribers, updates)
+			close(updates)
+			s.subscriberGauge.Add(context.Background(), -1)
+		}
+	}
+}
+
+// close ends every subscription and refuses new ones.
+func (s *dashboardService) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for _, h := range s.hubs {
+		for updates := range h.subscribers {
+			delete(h.subscribers, updates)
+			close(updates)
+			s.subscriberGauge.Add(context.Background(), -1)
+		}
+	}
+}
+
+func (s *dashboardService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Dashboard operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "dashboard"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "dashboard"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *dashboardService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "dashboard"), attribute.String("status", "success")))
+
+	s.log.Debug("Dashboard operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewDashboardService(
+	customerRepository repository.CustomerRepository,
+	customerEventRepository repository.CustomerEventRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.DashboardServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	subscriberGauge, _ := meter.Int64UpDownCounter(
+		"service.dashboard.subscribers",
+		metric.WithDescription("Number of open realtime dashboard subscriptions"),
+		metric.WithUnit("{subscriber}"),
+	)
+
+	return &dashboardService{
+		customerRepository:      customerRepository,
+		customerEventRepository: customerEventRepository,
+		meter:                   meter,
+		tracer:                  tracer,
+		log:                     log,
+		operationDuration:       operationDuration,
+		operationCount:          operationCount,
+		errorCount:              errorCount,
+		subscriberGauge:         subscriberGauge,
+		hubs:                    make(map[string]*hub),
+	}
+}
@@ -0,0 +1,343 @@
+package deliverysrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/httpclient"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config controls how hard a delivery is retried before it lands in the
+// dead-letter queue, and how many manual replays it gets before it is
+// considered poisoned.
+type Config struct {
+	MaxAttempts int
+	MaxReplays  int
+	Backoff     time.Duration
+}
+
+// permanentError marks a response that will not succeed on retry, such as a
+// 4xx from the partner endpoint.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+type deliveryService struct {
+	deliveryRepository repository.DeliveryRepository
+	client             *http.Client
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	deliveryAttempts  metric.Int64Counter
+}
+
+// Dispatch implements DeliveryServices.
+func (d *deliveryService) Dispatch(ctx context.Context, partnerID uint64, url, eventType string, payload any) (*domain.WebhookDelivery, error) {
+	ctx, span := d.tracer.Start(ctx, "service.DispatchWebhook")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.String("webhook.event_type", eventType),
+	)
+	d.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "dispatch_webhook"), attribute.String("service", "delivery")))
+
+	delivery := &domain.WebhookDelivery{
+		PartnerID: partnerID,
+		EventType: eventType,
+		URL:       url,
+		Status:    domain.DeliveryPending,
+	}
+
+	// Payload yang tidak bisa di-encode tidak akan pernah sukses, langsung poisoned
+	body, err := json.Marshal(map[string]any{"event": eventType, "data": payload})
+	if err != nil {
+		delivery.Payload = "{}"
+		delivery.Status = domain.DeliveryPoisoned
+		delivery.LastError = err.Error()
+		if createErr := d.deliveryRepository.Create(ctx, delivery); createErr != nil {
+			return nil, d.recordError(ctx, span, start, "dispatch_webhook", "repository_error", fmt.Errorf("failed to store webhook delivery: %w", createErr))
+		}
+		return delivery, d.recordError(ctx, span, start, "dispatch_webhook", "poisoned_payload", fmt.Errorf("%w: %v", common.ErrDeliveryPoisoned, err))
+	}
+	delivery.Payload = string(body)
+
+	if err := d.deliveryRepository.Create(ctx, delivery); err != nil {
+		return nil, d.recordError(ctx, span, start, "dispatch_webhook", "repository_error", fmt.Errorf("failed to store webhook delivery: %w", err))
+	}
+
+	if err := d.attempt(ctx, delivery); err != nil {
+		return nil, d.recordError(ctx, span, start, "dispatch_webhook", "repository_error", err)
+	}
+
+	d.recordSuccess(ctx, span, start, "dispatch_webhook",
+		zap.Uint64("delivery_id", delivery.ID),
+		zap.String("status", string(delivery.Status)),
+	)
+
+	return delivery, nil
+}
+
+// ListDeliveries implements DeliveryServices.
+func (d *deliveryService) ListDeliveries(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := d.tracer.Start(ctx, "service.ListDeliveries")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.status", params.Value("status")),
+	)
+	d.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_deliveries"), attribute.String("service", "delivery")))
+
+	deliveries, total, err := d.deliveryRepository.FindPaginated(ctx, params)
+	if err != nil {
+		return nil, d.recordError(ctx, span, start, "list_deliveries", "repository_error", fmt.Errorf("failed to list webhook deliveries: %w", err))
+	}
+
+	d.recordSuccess(ctx, span, start, "list_deliveries", zap.Int64("total", total))
+
+	return params.Paginated(deliveries, total), nil
+}
+
+// Replay implements DeliveryServices.
+func (d *deliveryService) Replay(ctx context.Context, deliveryID uint64) (*domain.WebhookDelivery, error) {
+	ctx, span := d.tracer.Start(ctx, "service.ReplayDelivery")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("delivery.id", int64(deliveryID)))
+	d.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "replay_delivery"), attribute.String("service", "delivery")))
+
+	delivery, err := d.deliveryRepository.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, d.recordError(ctx, span, start, "replay_delivery", "repository_error", fmt.Errorf("failed to get webhook delivery: %w", err))
+	}
+	if delivery == nil {
+		return nil, d.recordError(ctx, span, start, "replay_delivery", "delivery_not_found", common.ErrDeliveryNotFound)
+	}
+
+	switch delivery.Status {
+	case domain.DeliveryDelivered:
+		return nil, d.recordError(ctx, span, start, "replay_delivery", "already_delivered", common.ErrDeliveryDelivered)
+	case domain.DeliveryPoisoned:
+		return nil, d.recordError(ctx, span, start, "replay_delivery", "poisoned", common.ErrDeliveryPoisoned)
+	}
+
+	// Proteksi poison message: replay berulang yang terus gagal dihentikan permanen
+	if delivery.Replays >= d.cfg.MaxReplays {
+		delivery.Status = domain.DeliveryPoisoned
+		if err := d.deliveryRepository.Update(ctx, delivery); err != nil {
+			return nil, d.recordError(ctx, span, start, "replay_delivery", "repository_error", fmt.Errorf("failed to mark delivery as poisoned: %w", err))
+		}
+		return nil, d.recordError(ctx, span, start, "replay_delivery", "poisoned", common.ErrDeliveryPoisoned)
+	}
+
+	delivery.Replays++
+	if err := d.attempt(ctx, delivery); err != nil {
+		return nil, d.recordError(ctx, span, start, "replay_delivery", "repository_error", err)
+	}
+
+	d.recordSuccess(ctx, span, start, "replay_delivery",
+		zap.Uint64("delivery_id", delivery.ID),
+		zap.String("status", string(delivery.Status)),
+		zap.Int("replays", delivery.Replays),
+	)
+
+	return delivery, nil
+}
+
+// attempt sends the delivery up to MaxAttempts times and persists the outcome.
+// A delivery that still fails is left as FAILED for inspection and replay.
+func (d *deliveryService) attempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	var lastErr error
+	for i := 0; i < d.cfg.MaxAttempts; i++ {
+		if i > 0 && d.cfg.Backoff > 0 {
+			select {
+			case <-ctx.Done():
+				lastErr = ctx.Err()
+			case <-time.After(d.cfg.Backoff * time.Duration(1<<(i-1))):
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		now := time.Now()
+		delivery.Attempts++
+		delivery.LastAttemptAt = &now
+
+		lastErr = d.send(ctx, delivery)
+		d.deliveryAttempts.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("event_type", delivery.EventType),
+			attribute.Bool("success", lastErr == nil),
+		))
+		if lastErr == nil {
+			break
+		}
+
+		var permanent permanentError
+		if errors.As(lastErr, &permanent) {
+			break
+		}
+	}
+
+	if lastErr == nil {
+		now := time.Now()
+		delivery.Status = domain.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	} else {
+		delivery.Status = domain.DeliveryFailed
+		delivery.LastError = lastErr.Error()
+
+		d.log.Warn("Webhook delivery moved to dead-letter queue",
+			zap.Uint64("delivery_id", delivery.ID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Error(lastErr),
+		)
+	}
+
+	if err := d.deliveryRepository.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (d *deliveryService) send(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery-Id", strconv.FormatUint(delivery.ID, 10))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("partner endpoint responded with status %d", resp.StatusCode)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return permanentError{err}
+	}
+
+	return err
+}
+
+func (d *deliveryService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	d.log.Error("Webhook delivery operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	d.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "delivery"), attribute.String("error_type", errorType)))
+	d.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "delivery"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (d *deliveryService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	d.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "delivery"), attribute.String("status", "success")))
+
+	d.log.Info("Webhook delivery operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewDeliveryService(
+	deliveryRepository repository.DeliveryRepository,
+	client *http.Client,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.DeliveryServices {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if client == nil {
+		client = httpclient.New(httpclient.Config{Name: "webhook", Timeout: 10 * time.Second}, meter, tracer, log)
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	deliveryAttempts, _ := meter.Int64Counter(
+		"service.webhook.attempts",
+		metric.WithDescription("Number of webhook delivery attempts"),
+		metric.WithUnit("{attempt}"),
+	)
+
+	return &deliveryService{
+		deliveryRepository: deliveryRepository,
+		client:             client,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		deliveryAttempts:   deliveryAttempts,
+	}
+}
@@ -0,0 +1,398 @@
+package directdebitsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/paymentgateway"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config is the retry policy of the collection job. An installment is
+// charged at most MaxAttempts times, waiting RetryAfter after a failed
+// attempt. BatchSize bounds how many contracts are loaded per query.
+type Config struct {
+	MaxAttempts int
+	RetryAfter  time.Duration
+	BatchSize   int
+}
+
+type directDebitService struct {
+	directDebitRepository repository.DirectDebitRepository
+	paymentService        service.PaymentServices
+	chargers              map[string]paymentgateway.Charger
+	dueDateRules          service.DueDateRules
+	cfg                   Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	chargeCount       metric.Int64Counter
+	dueGauge          metric.Int64Gauge
+}
+
+// GetMandate implements DirectDebitServices.
+func (s *directDebitService) GetMandate(ctx context.Context, customerID uint64) (*domain.DirectDebitMandate, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetDirectDebitMandate")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_direct_debit_mandate"), attribute.String("service", "direct_debit")))
+
+	mandate, err := s.directDebitRepository.FindMandate(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_direct_debit_mandate", "repository_error", fmt.Errorf("failed to find mandate: %w", err))
+	}
+	if mandate == nil {
+		return nil, s.recordError(ctx, span, start, "get_direct_debit_mandate", "not_found", common.ErrDirectDebitNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_direct_debit_mandate", zap.Uint64("customer_id", customerID))
+
+	return mandate, nil
+}
+
+// SetMandate implements DirectDebitServices.
+func (s *directDebitService) SetMandate(ctx context.Context, customerID uint64, req dto.DirectDebitMandateRequest) (*domain.DirectDebitMandate, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetDirectDebitMandate")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("payment.provider", req.Provider),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_direct_debit_mandate"), attribute.String("service", "direct_debit")))
+
+	if _, ok := s.chargers[req.Provider]; !ok {
+		return nil, s.recordError(ctx, span, start, "set_direct_debit_mandate", "unknown_provider", common.ErrUnknownPaymentProvider)
+	}
+
+	mandate := &domain.DirectDebitMandate{
+		CustomerID: customerID,
+		Provider:   req.Provider,
+		Token:      req.Token,
+	}
+	if err := s.directDebitRepository.UpsertMandate(ctx, mandate); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_direct_debit_mandate", "repository_error", fmt.Errorf("failed to save mandate: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "set_direct_debit_mandate", zap.Uint64("customer_id", customerID), zap.String("provider", req.Provider))
+
+	return mandate, nil
+}
+
+// DeleteMandate implements DirectDebitServices.
+func (s *directDebitService) DeleteMandate(ctx context.Context, customerID uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteDirectDebitMandate")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_direct_debit_mandate"), attribute.String("service", "direct_debit")))
+
+	deleted, err := s.directDebitRepository.DeleteMandate(ctx, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_direct_debit_mandate", "repository_error", fmt.Errorf("failed to delete mandate: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_direct_debit_mandate", "not_found", common.ErrDirectDebitNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "delete_direct_debit_mandate", zap.Uint64("customer_id", customerID))
+
+	return nil
+}
+
+// Run implements DirectDebitServices.
+func (s *directDebitService) Run(ctx context.Context, now time.Time) (*domain.DirectDebitRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RunDirectDebit")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("direct_debit.max_attempts", s.cfg.MaxAttempts),
+		attribute.String("direct_debit.retry_after", s.cfg.RetryAfter.String()),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "run_direct_debit"), attribute.String("service", "direct_debit")))
+
+	run := &domain.DirectDebitRun{}
+	rules := s.dueDateRules.Rules(ctx)
+
+	var afterID uint64
+	for {
+		contracts, err := s.directDebitRepository.FindCollectible(ctx, afterID, s.cfg.BatchSize)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "run_direct_debit", "repository_error", fmt.Errorf("failed to find collectible contracts: %w", err))
+		}
+
+		for i := range contracts {
+			if err := s.collect(ctx, &contracts[i], rules, now, run); err != nil {
+				return nil, s.recordError(ctx, span, start, "run_direct_debit", "repository_error", err)
+			}
+		}
+
+		if len(contracts) == 0 || len(contracts) < s.cfg.BatchSize {
+			break
+		}
+		afterID = contracts[len(contracts)-1].ID
+	}
+
+	for outcome, count := range map[string]int{
+		"charged":   run.Charged,
+		"pending":   run.Pending,
+		"failed":    run.Failed,
+		"deferred":  run.Deferred,
+		"exhausted": run.Exhausted,
+	} {
+		s.chargeCount.Add(ctx, int64(count), metric.WithAttributes(attribute.String("service", "direct_debit"), attribute.String("outcome", outcome)))
+	}
+	s.dueGauge.Record(ctx, int64(run.Due), metric.WithAttributes(attribute.String("service", "direct_debit")))
+
+	span.SetAttributes(
+		attribute.Int("direct_debit.due", run.Due),
+		attribute.Int("direct_debit.charged", run.Charged),
+		attribute.Int("direct_debit.failed", run.Failed),
+	)
+	s.recordSuccess(ctx, span, start, "run_direct_debit",
+		zap.Int("due", run.Due),
+		zap.Int("charged", run.Charged),
+		zap.Int("pending", run.Pending),
+		zap.Int("failed", run.Failed),
+		zap.Int("deferred", run.Deferred),
+		zap.Int("exhausted", run.Exhausted),
+	)
+
+	return run, nil
+}
+
+// collect charges the first unpaid installment of contract when its due
+// date adjusted by rules is reached and the retry policy allows another
+// attempt. Only one installment is
+// charged per run, so a contract that fell behind catches up one run at a time.
+func (s *directDebitService) collect(ctx context.Context, contract *domain.Transaction, rules installment.Rules, now time.Time, run *domain.DirectDebitRun) error {
+	months := int(contract.Tenor.DurationMonths)
+	paid := 0
+	if contract.PaidInstallments != nil {
+		paid = *contract.PaidInstallments
+	}
+	if months == 0 || paid >= months {
+		return nil
+	}
+
+	sequence := paid + 1
+	if rules.DueDate(contract.TransactionDate, sequence).After(now) {
+		return nil
+	}
+	run.Due++
+
+	attempts, err := s.directDebitRepository.ListAttempts(ctx, contract.ID, sequence)
+	if err != nil {
+		return fmt.Errorf("failed to list attempts: %w", err)
+	}
+	if n := len(attempts); n > 0 {
+		last := attempts[n-1]
+		switch {
+		// Charge PENDING diselesaikan lewat callback gateway, jangan menagih dua kali
+		case last.Status == domain.PaymentPending, last.Status == domain.PaymentPaid:
+			run.Deferred++
+			return nil
+		case n >= s.cfg.MaxAttempts:
+			run.Exhausted++
+			return nil
+		case now.Before(last.AttemptedAt.Add(s.cfg.RetryAfter)):
+			run.Deferred++
+			return nil
+		}
+	}
+
+	mandate, err := s.directDebitRepository.FindMandate(ctx, contract.CustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to find mandate: %w", err)
+	}
+	if mandate == nil {
+		return nil
+	}
+	charger, ok := s.chargers[mandate.Provider]
+	if !ok {
+		s.log.Warn("Direct debit provider is not configured",
+			zap.Uint64("customer_id", contract.CustomerID),
+			zap.String("provider", mandate.Provider),
+		)
+		return nil
+	}
+
+	amount := installment.Schedule(contract.TransactionDate, 1, months, contract.TotalInstallmentAmount)[sequence-1].Amount
+	attempt := &domain.DirectDebitAttempt{
+		TransactionID: contract.ID,
+		Installment:   sequence,
+		Attempt:       len(attempts) + 1,
+		Provider:      charger.Provider(),
+		Amount:        amount,
+		Currency:      contract.Currency,
+		AttemptedAt:   now,
+	}
+
+	charge, err := charger.Charge(ctx, paymentgateway.ChargeRequest{
+		IdempotencyKey: fmt.Sprintf("dd-%d-%d-%d", contract.ID, sequence, attempt.Attempt),
+		Token:          mandate.Token,
+		Reference:      contract.ContractNumber,
+		Amount:         amount,
+		Currency:       contract.Currency,
+	})
+	if err != nil {
+		attempt.Status = domain.PaymentFailed
+		attempt.FailureReason = err.Error()
+	} else {
+		attempt.ExternalID = charge.ExternalID
+		attempt.Status = domain.PaymentStatus(charge.Status)
+		attempt.FailureReason = charge.FailureReason
+	}
+
+	// Pembayaran dicatat sebelum attempt: bila pencatatan gagal, putaran berikutnya
+	// memakai idempotency key yang sama sehingga gateway tidak menagih dua kali
+	if attempt.Status == domain.PaymentPaid {
+		paidAt := now
+		err := s.paymentService.RecordPayment(ctx, contract.ContractNumber, &domain.Payment{
+			Provider:   attempt.Provider,
+			ExternalID: attempt.ExternalID,
+			Amount:     amount,
+			Currency:   contract.Currency,
+			Status:     domain.PaymentPaid,
+			PaidAt:     &paidAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record payment: %w", err)
+		}
+	}
+
+	if err := s.directDebitRepository.CreateAttempt(ctx, attempt); err != nil {
+		return fmt.Errorf("failed to record attempt: %w", err)
+	}
+
+	switch attempt.Status {
+	case domain.PaymentPaid:
+		run.Charged++
+	case domain.PaymentPending:
+		run.Pending++
+	default:
+		s.log.Warn("Direct debit charge failed",
+			zap.Uint64("transaction_id", contract.ID),
+			zap.Int("installment", sequence),
+			zap.Int("attempt", attempt.Attempt),
+			zap.String("reason", attempt.FailureReason),
+		)
+		run.Failed++
+	}
+
+	return nil
+}
+
+func (s *directDebitService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Direct debit operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "direct_debit"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "direct_debit"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *directDebitService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "direct_debit"), attribute.String("status", "success")))
+
+	s.log.Info("Direct debit operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewDirectDebitService(
+	directDebitRepository repository.DirectDebitRepository,
+	paymentService service.PaymentServices,
+	chargers []paymentgateway.Charger,
+	dueDateRules service.DueDateRules,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.DirectDebitServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	chargeCount, _ := meter.Int64Counter(
+		"service.direct_debit.installments",
+		metric.WithDescription("Number of due installments handled by the collection job, by outcome"),
+		metric.WithUnit("{installment}"),
+	)
+
+	dueGauge, _ := meter.Int64Gauge(
+		"service.direct_debit.due",
+		metric.WithDescription("Number of installments due for direct debit in the last run"),
+		metric.WithUnit("{installment}"),
+	)
+
+	byProvider := make(map[string]paymentgateway.Charger, len(chargers))
+	for _, c := range chargers {
+		byProvider[c.Provider()] = c
+	}
+
+	return &directDebitService{
+		directDebitRepository: directDebitRepository,
+		paymentService:        paymentService,
+		chargers:              byProvider,
+		dueDateRules:          dueDateRules,
+		cfg:                   cfg,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+		chargeCount:           chargeCount,
+		dueGauge:              dueGauge,
+	}
+}
@@ -0,0 +1,340 @@
+package dormancysrv
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
+	dormancyrepo "github.com/fazamuttaqien/multifinance/internal/repository/dormancy"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/otp"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MaxStatsDays is the longest window Stats reports on.
+const MaxStatsDays = 365
+
+// Config decides when a customer becomes dormant. Customers with no activity
+// for InactiveMonths are flagged, at most BatchSize per run.
+type Config struct {
+	InactiveMonths int
+	BatchSize      int
+}
+
+type dormancyService struct {
+	db                 *gorm.DB
+	customerRepository repository.CustomerRepository
+	dormancyRepository repository.DormancyRepository
+	otpService         service.OTPServices
+	notifier           service.CustomerNotifier
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	flaggedCount      metric.Int64Counter
+	reactivatedCount  metric.Int64Counter
+	dormantGauge      metric.Int64Gauge
+}
+
+// Run implements DormancyServices.
+func (s *dormancyService) Run(ctx context.Context, now time.Time) (*domain.DormancyRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RunDormancy")
+	defer span.End()
+
+	start := time.Now()
+	cutoff := now.AddDate(0, -s.cfg.InactiveMonths, 0)
+	span.SetAttributes(
+		attribute.Int("dormancy.inactive_months", s.cfg.InactiveMonths),
+		attribute.String("dormancy.cutoff", cutoff.Format(time.RFC3339)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "run_dormancy"), attribute.String("service", "dormancy")))
+
+	inactive, err := s.dormancyRepository.FindInactive(ctx, cutoff, s.cfg.BatchSize)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "run_dormancy", "repository_error", fmt.Errorf("failed to find inactive customers: %w", err))
+	}
+
+	run := &domain.DormancyRun{}
+	for _, customer := range inactive {
+		flagged := false
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			dormancyTx := dormancyrepo.NewDormancyRepository(tx, s.meter, s.log)
+			marked, err := dormancyTx.MarkDormant(ctx, customer.ID, cutoff, now)
+			if err != nil || !marked {
+				return err
+			}
+			flagged = true
+
+			eventTx := customereventrepo.NewCustomerEventRepository(tx, s.meter, s.log)
+			return eventTx.Append(ctx, &domain.CustomerEvent{
+				CustomerID: customer.ID,
+				Type:       domain.CustomerDormant,
+				Data:       map[string]string{"inactive_months": strconv.Itoa(s.cfg.InactiveMonths)},
+			})
+		})
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "run_dormancy", "repository_error", fmt.Errorf("failed to mark customer %d dormant: %w", customer.ID, err))
+		}
+		// Ada aktivitas baru di antara pencarian dan update
+		if !flagged {
+			continue
+		}
+		run.Flagged++
+
+		if err := s.notifier.Notify(ctx, customer.ID, domain.TemplateAccountDormant, map[string]string{
+			"full_name":       customer.FullName,
+			"inactive_months": strconv.Itoa(s.cfg.InactiveMonths),
+		}); err != nil {
+			s.log.Warn("Failed to send dormancy notice",
+				zap.Uint64("customer_id", customer.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.flaggedCount.Add(ctx, int64(run.Flagged), metric.WithAttributes(attribute.String("service", "dormancy")))
+
+	stats, err := s.dormancyRepository.Stats(ctx, now)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "run_dormancy", "repository_error", fmt.Errorf("failed to compute dormancy stats: %w", err))
+	}
+	run.Dormant = stats.Dormant
+	s.dormantGauge.Record(ctx, run.Dormant, metric.WithAttributes(attribute.String("service", "dormancy")))
+
+	span.SetAttributes(
+		attribute.Int("dormancy.flagged", run.Flagged),
+		attribute.Int64("dormancy.count", run.Dormant),
+	)
+	s.recordSuccess(ctx, span, start, "run_dormancy",
+		zap.Int("found", len(inactive)),
+		zap.Int("flagged", run.Flagged),
+		zap.Int64("dormant", run.Dormant),
+	)
+
+	return run, nil
+}
+
+// Reactivate implements DormancyServices.
+func (s *dormancyService) Reactivate(ctx context.Context, customerID uint64, req dto.ReactivationRequest) error {
+	ctx, span := s.tracer.Start(ctx, "service.ReactivateCustomer")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "reactivate_customer"), attribute.String("service", "dormancy")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "reactivate_customer", "repository_error", fmt.Errorf("failed to find customer: %w", err))
+	}
+	if customer == nil {
+		return s.recordError(ctx, span, start, "reactivate_customer", "not_found", common.ErrCustomerNotFound)
+	}
+	if customer.DormantAt == nil {
+		return s.recordError(ctx, span, start, "reactivate_customer", "not_dormant", common.ErrCustomerNotDormant)
+	}
+
+	// Tanggal lahir dicek dulu supaya salah ketik tidak menghanguskan token OTP
+	if customer.BirthDate.Format(time.DateOnly) != req.BirthDate {
+		return s.recordError(ctx, span, start, "reactivate_customer", "identity_mismatch", common.ErrIdentityMismatch)
+	}
+
+	verification, err := s.otpService.Consume(ctx, req.OTPToken, domain.OTPReactivation, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "reactivate_customer", "otp_required", err)
+	}
+	if !onFile(customer, verification) {
+		return s.recordError(ctx, span, start, "reactivate_customer", "contact_not_on_file", common.ErrContactNotVerified)
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dormancyTx := dormancyrepo.NewDormancyRepository(tx, s.meter, s.log)
+		reactivated, err := dormancyTx.Reactivate(ctx, customerID)
+		if err != nil {
+			return err
+		}
+		if !reactivated {
+			return common.ErrCustomerNotDormant
+		}
+
+		eventTx := customereventrepo.NewCustomerEventRepository(tx, s.meter, s.log)
+		return eventTx.Append(ctx, &domain.CustomerEvent{
+			CustomerID: customerID,
+			Type:       domain.CustomerReactivated,
+			Data: map[string]string{
+				"dormant_at": customer.DormantAt.UTC().Format(time.RFC3339),
+				"channel":    verification.Channel,
+			},
+		})
+	})
+	if err != nil {
+		return s.recordError(ctx, span, start, "reactivate_customer", "repository_error", fmt.Errorf("failed to reactivate customer: %w", err))
+	}
+
+	s.reactivatedCount.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "dormancy"), attribute.String("channel", verification.Channel)))
+	s.recordSuccess(ctx, span, start, "reactivate_customer",
+		zap.Uint64("customer_id", customerID),
+		zap.String("channel", verification.Channel),
+		zap.Duration("dormant_for", time.Since(*customer.DormantAt)),
+	)
+
+	return nil
+}
+
+// Stats implements DormancyServices.
+func (s *dormancyService) Stats(ctx context.Context, days int, now time.Time) (*domain.DormancyStats, error) {
+	ctx, span := s.tracer.Start(ctx, "service.DormancyStats")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("dormancy.days", days))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "dormancy_stats"), attribute.String("service", "dormancy")))
+
+	if days < 1 || days > MaxStatsDays {
+		return nil, s.recordError(ctx, span, start, "dormancy_stats", "invalid_days", common.ErrInvalidDormancyDays)
+	}
+
+	stats, err := s.dormancyRepository.Stats(ctx, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "dormancy_stats", "repository_error", fmt.Errorf("failed to compute dormancy stats: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "dormancy_stats",
+		zap.Int("days", days),
+		zap.Int64("dormant", stats.Dormant),
+		zap.Int64("flagged", stats.Flagged),
+		zap.Int64("reactivated", stats.Reactivated),
+	)
+
+	return stats, nil
+}
+
+// onFile reports whether the OTP was sent to the email or phone registered
+// on the customer, a new contact cannot be used to reactivate.
+func onFile(customer *domain.Customer, verification *domain.OTPVerification) bool {
+	contact := customer.Email
+	if verification.Channel == otp.ChannelSMS {
+		contact = customer.Phone
+	}
+	return contact != "" && otp.NormalizeDestination(verification.Channel, contact) == verification.Destination
+}
+
+func (s *dormancyService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Dormancy operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "dormancy"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "dormancy"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *dormancyService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "dormancy"), attribute.String("status", "success")))
+
+	s.log.Info("Dormancy operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewDormancyService(
+	db *gorm.DB,
+	customerRepository repository.CustomerRepository,
+	dormancyRepository repository.DormancyRepository,
+	otpService service.OTPServices,
+	notifier service.CustomerNotifier,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.DormancyServices {
+	if cfg.InactiveMonths <= 0 {
+		cfg.InactiveMonths = 12
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	flaggedCount, _ := meter.Int64Counter(
+		"service.dormancy.flagged",
+		metric.WithDescription("Number of customers flagged as dormant"),
+		metric.WithUnit("{customer}"),
+	)
+
+	reactivatedCount, _ := meter.Int64Counter(
+		"service.dormancy.reactivated",
+		metric.WithDescription("Number of dormant customers reactivated"),
+		metric.WithUnit("{customer}"),
+	)
+
+	dormantGauge, _ := meter.Int64Gauge(
+		"service.dormancy.count",
+		metric.WithDescription("Number of dormant customers"),
+		metric.WithUnit("{customer}"),
+	)
+
+	return &dormancyService{
+		db:                 db,
+		customerRepository: customerRepository,
+		dormancyRepository: dormancyRepository,
+		otpService:         otpService,
+		notifier:           notifier,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		flaggedCount:       flaggedCount,
+		reactivatedCount:   reactivatedCount,
+		dormantGauge:       dormantGauge,
+	}
+}
@@ -0,0 +1,319 @@
+package duedatesrv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/cache"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// fullCalendar is the only cache key; the policy and holidays are always
+// loaded together.
+const fullCalendar = "all"
+
+type dueDateService struct {
+	dueDateRepository repository.DueDateRepository
+	calendars         *cache.Cache[domain.DueDateCalendar]
+	location          *time.Location
+
+	// lastKnown is served per tenant while the calendar cannot be loaded at all
+	mu        sync.RWMutex
+	lastKnown map[string]domain.DueDateCalendar
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Rules implements DueDateRules.
+func (s *dueDateService) Rules(ctx context.Context) installment.Rules {
+	calendar, err := s.calendars.Get(ctx, fullCalendar, s.load)
+	if err != nil {
+		s.mu.RLock()
+		calendar = s.lastKnown[tenancy.ID(ctx)]
+		s.mu.RUnlock()
+
+		s.log.Warn("Failed to reload due date calendar, serving cached values",
+			zap.String("shift", string(calendar.Policy.Shift)),
+			zap.Int("holidays", len(calendar.Holidays)),
+			zap.Error(err),
+		)
+	} else {
+		s.mu.Lock()
+		s.lastKnown[tenancy.ID(ctx)] = calendar
+		s.mu.Unlock()
+	}
+
+	holidays := make([]time.Time, len(calendar.Holidays))
+	for i, holiday := range calendar.Holidays {
+		holidays[i] = holiday.Date
+	}
+	return installment.NewRules(calendar.Policy.Shift, calendar.Policy.GraceDays, s.location, holidays)
+}
+
+// ListHolidays implements DueDateServices.
+func (s *dueDateService) ListHolidays(ctx context.Context, year int) ([]domain.Holiday, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListHolidays")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("holiday.year", year))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_holidays"), attribute.String("service", "due_date")))
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	holidays, err := s.dueDateRepository.FindHolidays(ctx, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_holidays", "repository_error", fmt.Errorf("failed to list holidays: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_holidays", zap.Int("year", year), zap.Int("count", len(holidays)))
+
+	return holidays, nil
+}
+
+// SetHoliday implements DueDateServices.
+func (s *dueDateService) SetHoliday(ctx context.Context, date string, updatedBy uint64, req dto.HolidayRequest) (*domain.Holiday, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetHoliday")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("holiday.date", date),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_holiday"), attribute.String("service", "due_date")))
+
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_holiday", "invalid_date", fmt.Errorf("%w: %s", common.ErrInvalidHolidayDate, date))
+	}
+
+	holiday := &domain.Holiday{
+		Date:      day,
+		Name:      req.Name,
+		UpdatedBy: updatedBy,
+	}
+	if err := s.dueDateRepository.UpsertHoliday(ctx, holiday); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_holiday", "repository_error", fmt.Errorf("failed to save holiday: %w", err))
+	}
+	s.invalidate(ctx)
+
+	s.recordSuccess(ctx, span, start, "set_holiday",
+		zap.String("date", date),
+		zap.String("name", holiday.Name),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return holiday, nil
+}
+
+// DeleteHoliday implements DueDateServices.
+func (s *dueDateService) DeleteHoliday(ctx context.Context, date string) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteHoliday")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("holiday.date", date))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_holiday"), attribute.String("service", "due_date")))
+
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_holiday", "invalid_date", fmt.Errorf("%w: %s", common.ErrInvalidHolidayDate, date))
+	}
+
+	deleted, err := s.dueDateRepository.DeleteHoliday(ctx, day)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_holiday", "repository_error", fmt.Errorf("failed to delete holiday: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_holiday", "not_found", common.ErrHolidayNotFound)
+	}
+	s.invalidate(ctx)
+
+	s.recordSuccess(ctx, span, start, "delete_holiday", zap.String("date", date))
+
+	return nil
+}
+
+// GetPolicy implements DueDateServices.
+func (s *dueDateService) GetPolicy(ctx context.Context) (*domain.DueDatePolicy, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetDueDatePolicy")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_due_date_policy"), attribute.String("service", "due_date")))
+
+	// Admin selalu melihat data terbaru, bukan isi cache
+	policy, err := s.policy(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_due_date_policy", "repository_error", fmt.Errorf("failed to load due date policy: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "get_due_date_policy",
+		zap.String("shift", string(policy.Shift)),
+		zap.Int("grace_days", policy.GraceDays),
+	)
+
+	return policy, nil
+}
+
+// SetPolicy implements DueDateServices.
+func (s *dueDateService) SetPolicy(ctx context.Context, updatedBy uint64, req dto.DueDatePolicyRequest) (*domain.DueDatePolicy, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetDueDatePolicy")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("due_date_policy.shift", string(req.Shift)),
+		attribute.Int("due_date_policy.grace_days", req.GraceDays),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_due_date_policy"), attribute.String("service", "due_date")))
+
+	if !req.Shift.Valid() || req.GraceDays < 0 {
+		return nil, s.recordError(ctx, span, start, "set_due_date_policy", "invalid_policy", common.ErrInvalidDueDatePolicy)
+	}
+
+	policy := &domain.DueDatePolicy{
+		Shift:     req.Shift,
+		GraceDays: req.GraceDays,
+		UpdatedBy: updatedBy,
+	}
+	if err := s.dueDateRepository.SavePolicy(ctx, policy); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_due_date_policy", "repository_error", fmt.Errorf("failed to save due date policy: %w", err))
+	}
+	s.invalidate(ctx)
+
+	s.recordSuccess(ctx, span, start, "set_due_date_policy",
+		zap.String("shift", string(policy.Shift)),
+		zap.Int("grace_days", policy.GraceDays),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return policy, nil
+}
+
+// policy returns the saved policy, or the default one keeping nominal due
+// dates when none has been saved.
+func (s *dueDateService) policy(ctx context.Context) (*domain.DueDatePolicy, error) {
+	policy, err := s.dueDateRepository.FindPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &domain.DueDatePolicy{Shift: installment.ShiftNone}
+	}
+	return policy, nil
+}
+
+func (s *dueDateService) load(ctx context.Context) (domain.DueDateCalendar, error) {
+	policy, err := s.policy(ctx)
+	if err != nil {
+		return domain.DueDateCalendar{}, err
+	}
+
+	holidays, err := s.dueDateRepository.FindAllHolidays(ctx)
+	if err != nil {
+		return domain.DueDateCalendar{}, err
+	}
+
+	return domain.DueDateCalendar{Policy: *policy, Holidays: holidays}, nil
+}
+
+// invalidate makes every instance reload the calendar on their next lookup.
+func (s *dueDateService) invalidate(ctx context.Context) {
+	if err := s.calendars.Invalidate(ctx, fullCalendar); err != nil {
+		s.log.Warn("Failed to invalidate due date calendar cache", zap.Error(err))
+	}
+}
+
+func (s *dueDateService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Due date operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "due_date"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "due_date"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *dueDateService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "due_date"), attribute.String("status", "success")))
+
+	s.log.Info("Due date operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+// NewDueDateService reads the calendar days of due dates and holidays in
+// location.
+func NewDueDateService(
+	dueDateRepository repository.DueDateRepository,
+	calendars *cache.Cache[domain.DueDateCalendar],
+	location *time.Location,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.DueDateServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &dueDateService{
+		dueDateRepository: dueDateRepository,
+		calendars:         calendars,
+		location:          location,
+		lastKnown:         make(map[string]domain.DueDateCalendar),
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+	}
+}
@@ -0,0 +1,316 @@
+package duplicatesrv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	duplicaterepo "github.com/fazamuttaqien/multifinance/internal/repository/duplicate"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/fuzzy"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// Nama lebih menentukan daripada tempat lahir yang sering ditulis berbeda
+	nameWeight  = 0.7
+	placeWeight = 0.3
+
+	// MatchThreshold is the minimum combined score for a customer to be
+	// reported as a possible duplicate.
+	MatchThreshold = 0.75
+)
+
+type duplicateService struct {
+	db                  *gorm.DB
+	customerRepository  repository.CustomerRepository
+	duplicateRepository repository.DuplicateRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	duplicatesFound   metric.Int64Counter
+}
+
+// FindPossibleDuplicates implements DuplicateServices.
+func (s *duplicateService) FindPossibleDuplicates(ctx context.Context, customerID uint64) ([]dto.DuplicateCandidateResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.FindPossibleDuplicates")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "find_possible_duplicates"), attribute.String("service", "duplicate")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "find_possible_duplicates", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return nil, s.recordError(ctx, span, start, "find_possible_duplicates", "customer_not_found", common.ErrCustomerNotFound)
+	}
+
+	// Kandidat dipersempit lewat tanggal lahir yang harus sama persis
+	others, err := s.duplicateRepository.FindByBirthDate(ctx, customer.BirthDate, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "find_possible_duplicates", "repository_error", fmt.Errorf("failed to find candidates: %w", err))
+	}
+
+	resolvedIDs, err := s.duplicateRepository.FindResolvedIDs(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "find_possible_duplicates", "repository_error", fmt.Errorf("failed to find resolved duplicates: %w", err))
+	}
+	resolved := make(map[uint64]struct{}, len(resolvedIDs))
+	for _, id := range resolvedIDs {
+		resolved[id] = struct{}{}
+	}
+
+	candidates := make([]dto.DuplicateCandidateResponse, 0)
+	for _, other := range others {
+		if _, ok := resolved[other.ID]; ok {
+			continue
+		}
+
+		nameScore := fuzzy.NameSimilarity(customer.LegalName, other.LegalName)
+		placeScore := fuzzy.Similarity(customer.BirthPlace, other.BirthPlace)
+		score := nameWeight*nameScore + placeWeight*placeScore
+		if score < MatchThreshold {
+			continue
+		}
+
+		candidates = append(candidates, dto.DuplicateCandidateResponse{
+			CustomerID:         other.ID,
+			NIK:                other.NIK,
+			LegalName:          other.LegalName,
+			BirthPlace:         other.BirthPlace,
+			BirthDate:          other.BirthDate,
+			VerificationStatus: string(other.VerificationStatus),
+			NameScore:          round(nameScore),
+			PlaceScore:         round(placeScore),
+			Score:              round(score),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	s.duplicatesFound.Add(ctx, int64(len(candidates)))
+	s.recordSuccess(ctx, span, start, "find_possible_duplicates",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("candidates", len(candidates)),
+	)
+
+	return candidates, nil
+}
+
+// Resolve implements DuplicateServices.
+func (s *duplicateService) Resolve(ctx context.Context, customerID, duplicateID, reviewerID uint64, req dto.DuplicateResolutionRequest) (*domain.DuplicateResolution, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ResolveDuplicate")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int64("duplicate.id", int64(duplicateID)),
+		attribute.Int64("reviewer.id", int64(reviewerID)),
+		attribute.String("duplicate.action", string(req.Action)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "resolve_duplicate"), attribute.String("service", "duplicate")))
+
+	if customerID == duplicateID {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "same_customer", common.ErrDuplicateSelf)
+	}
+
+	for _, id := range []uint64{customerID, duplicateID} {
+		customer, err := s.customerRepository.FindByID(ctx, id)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "resolve_duplicate", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+		}
+		if customer == nil {
+			return nil, s.recordError(ctx, span, start, "resolve_duplicate", "customer_not_found", common.ErrCustomerNotFound)
+		}
+	}
+
+	existing, err := s.duplicateRepository.FindResolution(ctx, customerID, duplicateID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "repository_error", fmt.Errorf("failed to check resolution: %w", err))
+	}
+	if existing != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "already_resolved", common.ErrDuplicateResolved)
+	}
+
+	resolution := &domain.DuplicateResolution{
+		CustomerID:  customerID,
+		DuplicateID: duplicateID,
+		Action:      req.Action,
+		ResolvedBy:  reviewerID,
+		Note:        req.Note,
+	}
+
+	if req.Action == domain.DuplicateIgnored {
+		if err := s.duplicateRepository.CreateResolution(ctx, resolution); err != nil {
+			return nil, s.recordError(ctx, span, start, "resolve_duplicate", "repository_error", fmt.Errorf("failed to create resolution: %w", err))
+		}
+
+		s.recordSuccess(ctx, span, start, "resolve_duplicate",
+			zap.Uint64("resolution_id", resolution.ID),
+			zap.String("action", string(req.Action)),
+		)
+		return resolution, nil
+	}
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "transaction_begin_error", tx.Error)
+	}
+	defer tx.Rollback()
+
+	// 1. Transaksi milik akun duplikat dipindahkan ke akun utama
+	if err := tx.Model(&model.Transaction{}).Where("customer_id = ?", duplicateID).Update("customer_id", customerID).Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "merge_transactions_error", fmt.Errorf("failed to move transactions: %w", err))
+	}
+
+	// Exposure kedua akun dihitung ulang karena kontraknya sudah berpindah
+	exposureTx := exposurerepo.NewCustomerExposureRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	for _, id := range []uint64{customerID, duplicateID} {
+		if err := exposureTx.Recompute(ctx, id); err != nil {
+			return nil, s.recordError(ctx, span, start, "resolve_duplicate", "merge_exposure_error", fmt.Errorf("failed to recompute customer exposure: %w", err))
+		}
+	}
+
+	// 2. Limit akun duplikat dihapus, limit akun utama tetap berlaku
+	if err := tx.Where("customer_id = ?", duplicateID).Delete(&model.CustomerLimit{}).Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "merge_limits_error", fmt.Errorf("failed to remove duplicate limits: %w", err))
+	}
+
+	// 3. Akun duplikat ditolak agar tidak bisa bertransaksi lagi
+	if err := tx.Model(&model.Customer{}).Where("id = ?", duplicateID).Updates(map[string]any{
+		"verification_status": model.VerificationRejected,
+		"version":             gorm.Expr("version + 1"),
+	}).Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "merge_customer_error", fmt.Errorf("failed to reject duplicate customer: %w", err))
+	}
+
+	duplicateTx := duplicaterepo.NewDuplicateRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	if err := duplicateTx.CreateResolution(ctx, resolution); err != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "repository_error", fmt.Errorf("failed to create resolution: %w", err))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_duplicate", "transaction_commit_error", err)
+	}
+
+	s.recordSuccess(ctx, span, start, "resolve_duplicate",
+		zap.Uint64("resolution_id", resolution.ID),
+		zap.String("action", string(req.Action)),
+	)
+
+	return resolution, nil
+}
+
+func round(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
+
+func (s *duplicateService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Duplicate operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "duplicate"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "duplicate"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *duplicateService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "duplicate"), attribute.String("status", "success")))
+
+	s.log.Info("Duplicate operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewDuplicateService(
+	db *gorm.DB,
+	customerRepository repository.CustomerRepository,
+	duplicateRepository repository.DuplicateRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.DuplicateServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	duplicatesFound, _ := meter.Int64Counter(
+		"service.duplicates.found",
+		metric.WithDescription("Number of possible duplicate customers reported"),
+		metric.WithUnit("{customer}"),
+	)
+
+	return &duplicateService{
+		db:                  db,
+		customerRepository:  customerRepository,
+		duplicateRepository: duplicateRepository,
+		meter:               meter,
+		tracer:              tracer,
+		log:                 log,
+		operationDuration:   operationDuration,
+		operationCount:      operationCount,
+		errorCount:          errorCount,
+		duplicatesFound:     duplicatesFound,
+	}
+}
@@ -0,0 +1,381 @@
+package emailsrv
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/mailer"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config controls the outbound queue. A failed message waits Backoff,
+// doubled on every further attempt, until it has been tried MaxAttempts
+// times. Lease is how long a claimed message is hidden from other workers,
+// so it must comfortably exceed the provider timeout.
+type Config struct {
+	From        string
+	MaxAttempts int
+	Backoff     time.Duration
+	Lease       time.Duration
+	BatchSize   int
+}
+
+// sampleData fills every variable used by the notification templates, so a
+// preview reads like a real email without the admin supplying any data.
+var sampleData = map[string]string{
+	"full_name":        "Budi Santoso",
+	"deadline":         "2025-01-31",
+	"inactive_months":  "12",
+	"anonymize_after":  "2025-04-30",
+	"period":           "2025-01",
+	"new_transactions": "2",
+	"payments":         "Rp1.500.000",
+	"outstanding":      "Rp4.500.000",
+	"statement_url":    "https://example.com/statements/2025-01.pdf",
+	"contract_number":  "KTR-202501-000123",
+	"installment":      "3",
+	"due_date":         "2025-01-25",
+	"amount":           "IDR 1.500.000,00",
+	"reason":           "Foto KTP tidak terbaca",
+}
+
+type emailService struct {
+	emailRepository    repository.EmailRepository
+	customerRepository repository.CustomerRepository
+	templates          service.TemplateRenderer
+	sender             mailer.Sender
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	deliveryCount     metric.Int64Counter
+	deliveryLatency   metric.Float64Histogram
+}
+
+// Notify implements EmailServices.
+func (s *emailService) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	ctx, span := s.tracer.Start(ctx, "service.QueueEmail")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("email.template", string(template)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "queue_email"), attribute.String("service", "email")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "queue_email", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return s.recordError(ctx, span, start, "queue_email", "customer_not_found", common.ErrCustomerNotFound)
+	}
+	if customer.Email == "" {
+		return s.recordError(ctx, span, start, "queue_email", "no_email", common.ErrCustomerHasNoEmail)
+	}
+
+	language := customer.Language
+	if language == "" {
+		language = domain.DefaultLanguage
+	}
+
+	// Konten dirender saat antre agar pengiriman ulang memakai isi yang sama
+	subject, body, err := s.templates.Render(template, language, data)
+	if err != nil {
+		return s.recordError(ctx, span, start, "queue_email", "render_error", err)
+	}
+
+	message := &domain.EmailMessage{
+		CustomerID:    customerID,
+		Recipient:     customer.Email,
+		Template:      template,
+		Language:      language,
+		Subject:       subject,
+		Body:          body,
+		Status:        domain.EmailPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := s.emailRepository.Create(ctx, message); err != nil {
+		return s.recordError(ctx, span, start, "queue_email", "repository_error", fmt.Errorf("failed to queue email: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "queue_email",
+		zap.Uint64("email_id", message.ID),
+		zap.Uint64("customer_id", customerID),
+		zap.String("template", string(template)),
+	)
+
+	return nil
+}
+
+// ProcessPending implements EmailServices.
+func (s *emailService) ProcessPending(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ProcessPendingEmails")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("email.provider", s.sender.Provider()))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "process_pending_emails"), attribute.String("service", "email")))
+
+	messages, err := s.emailRepository.FindDue(ctx, start, s.cfg.BatchSize)
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "process_pending_emails", "repository_error", fmt.Errorf("failed to find due emails: %w", err))
+	}
+
+	sent, retried, failed := 0, 0, 0
+	for i := range messages {
+		message := &messages[i]
+
+		// Pesan yang sudah diklaim worker lain dilewati
+		claimed, err := s.emailRepository.Claim(ctx, message, time.Now().Add(s.cfg.Lease))
+		if err != nil {
+			return sent, s.recordError(ctx, span, start, "process_pending_emails", "repository_error", fmt.Errorf("failed to claim email: %w", err))
+		}
+		if !claimed {
+			continue
+		}
+
+		outcome := s.send(ctx, message)
+		switch outcome {
+		case "sent":
+			sent++
+		case "retry":
+			retried++
+		default:
+			failed++
+		}
+
+		if err := s.emailRepository.Update(ctx, message); err != nil {
+			return sent, s.recordError(ctx, span, start, "process_pending_emails", "repository_error", fmt.Errorf("failed to update email: %w", err))
+		}
+
+		s.deliveryCount.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("provider", s.sender.Provider()),
+			attribute.String("template", string(message.Template)),
+			attribute.String("outcome", outcome),
+		))
+		if message.SentAt != nil {
+			s.deliveryLatency.Record(ctx, float64(message.SentAt.Sub(message.CreatedAt).Milliseconds()),
+				metric.WithAttributes(attribute.String("provider", s.sender.Provider())))
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("email.sent", sent),
+		attribute.Int("email.retried", retried),
+		attribute.Int("email.failed", failed),
+	)
+	s.recordSuccess(ctx, span, start, "process_pending_emails",
+		zap.Int("sent", sent),
+		zap.Int("retried", retried),
+		zap.Int("failed", failed),
+	)
+
+	return sent, nil
+}
+
+// send hands a claimed message to the provider and records the outcome on
+// it: "sent", "retry" when it is due again after backoff, or "failed" when
+// it will not be tried again.
+func (s *emailService) send(ctx context.Context, message *domain.EmailMessage) string {
+	message.Provider = s.sender.Provider()
+
+	providerMessageID, err := s.sender.Send(ctx, mailer.Message{
+		ID:      "email-" + strconv.FormatUint(message.ID, 10),
+		From:    s.cfg.From,
+		To:      message.Recipient,
+		Subject: message.Subject,
+		Body:    message.Body,
+	})
+	if err == nil {
+		now := time.Now()
+		message.Status = domain.EmailSent
+		message.SentAt = &now
+		message.ProviderMessageID = providerMessageID
+		message.LastError = ""
+		return "sent"
+	}
+
+	message.LastError = err.Error()
+	if mailer.IsPermanent(err) || message.Attempts >= s.cfg.MaxAttempts {
+		message.Status = domain.EmailFailed
+
+		s.log.Warn("Email delivery gave up",
+			zap.Uint64("email_id", message.ID),
+			zap.String("template", string(message.Template)),
+			zap.Int("attempts", message.Attempts),
+			zap.Error(err),
+		)
+		return "failed"
+	}
+
+	// Jeda berlipat dua setiap percobaan, dibatasi agar tidak overflow
+	message.NextAttemptAt = time.Now().Add(s.cfg.Backoff << min(message.Attempts-1, 16))
+
+	s.log.Warn("Email delivery failed, will retry",
+		zap.Uint64("email_id", message.ID),
+		zap.Int("attempts", message.Attempts),
+		zap.Time("next_attempt_at", message.NextAttemptAt),
+		zap.Error(err),
+	)
+	return "retry"
+}
+
+// PreviewTemplate implements EmailServices.
+func (s *emailService) PreviewTemplate(ctx context.Context, template domain.NotificationTemplate, req dto.EmailPreviewRequest) (*domain.RenderedEmail, error) {
+	ctx, span := s.tracer.Start(ctx, "service.PreviewEmailTemplate")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("email.template", string(template)),
+		attribute.String("email.language", req.Language),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "preview_email_template"), attribute.String("service", "email")))
+
+	if !slices.Contains(domain.NotificationTemplates, template) {
+		return nil, s.recordError(ctx, span, start, "preview_email_template", "template_not_found", common.ErrEmailTemplateNotFound)
+	}
+
+	language := domain.Language(req.Language)
+	if language == "" {
+		language = domain.DefaultLanguage
+	}
+
+	data := maps.Clone(sampleData)
+	maps.Copy(data, req.Data)
+
+	subject, body, err := s.templates.Render(template, language, data)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "preview_email_template", "render_error", err)
+	}
+
+	s.recordSuccess(ctx, span, start, "preview_email_template",
+		zap.String("template", string(template)),
+		zap.String("language", string(language)),
+	)
+
+	return &domain.RenderedEmail{
+		Template: template,
+		Language: language,
+		Subject:  subject,
+		Body:     body,
+	}, nil
+}
+
+func (s *emailService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Email operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "email"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "email"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *emailService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "email"), attribute.String("status", "success")))
+
+	s.log.Info("Email operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewEmailService(
+	emailRepository repository.EmailRepository,
+	customerRepository repository.CustomerRepository,
+	templates service.TemplateRenderer,
+	sender mailer.Sender,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.EmailServices {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 5 * time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	deliveryCount, _ := meter.Int64Counter(
+		"service.email.deliveries",
+		metric.WithDescription("Number of email delivery attempts by outcome"),
+		metric.WithUnit("{attempt}"),
+	)
+
+	deliveryLatency, _ := meter.Float64Histogram(
+		"service.email.delivery.latency",
+		metric.WithDescription("Time from queueing an email to the provider accepting it"),
+		metric.WithUnit("ms"),
+	)
+
+	return &emailService{
+		emailRepository:    emailRepository,
+		customerRepository: customerRepository,
+		templates:          templates,
+		sender:             sender,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		deliveryCount:      deliveryCount,
+		deliveryLatency:    deliveryLatency,
+	}
+}
@@ -0,0 +1,279 @@
+package exportsrv
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// FlushRows is how many rows are buffered before they are pushed to the
+// writer.
+const FlushRows = 500
+
+// flusher is implemented by buffered writers such as the HTTP response
+// stream, whose Flush blocks until the client has taken the bytes.
+type flusher interface {
+	Flush() error
+}
+
+type exportService struct {
+	customerRepository    repository.CustomerRepository
+	transactionRepository repository.TransactionRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	rowsExported      metric.Int64Counter
+}
+
+// csvStream writes records to w, pushing them through every FlushRows rows.
+type csvStream struct {
+	csv  *csv.Writer
+	w    io.Writer
+	rows int
+}
+
+func newCSVStream(w io.Writer, header []string) (*csvStream, error) {
+	stream := &csvStream{csv: csv.NewWriter(w), w: w}
+	if err := stream.csv.Write(header); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (s *csvStream) write(record []string) error {
+	if err := s.csv.Write(record); err != nil {
+		return err
+	}
+	s.rows++
+	if s.rows%FlushRows == 0 {
+		return s.flush()
+	}
+	return nil
+}
+
+func (s *csvStream) flush() error {
+	s.csv.Flush()
+	if err := s.csv.Error(); err != nil {
+		return err
+	}
+	if f, ok := s.w.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// ExportCustomers implements ExportServices. Passwords and document photos
+// are never exported.
+func (s *exportService) ExportCustomers(ctx context.Context, w io.Writer, status domain.VerificationStatus) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ExportCustomers")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("filter.status", string(status)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "export_customers"), attribute.String("service", "export")))
+
+	stream, err := newCSVStream(w, []string{
+		"id", "nik", "full_name", "legal_name", "birth_place", "birth_date", "salary",
+		"verification_status", "email", "phone", "language", "created_at", "dormant_at",
+	})
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "export_customers", "write_error", fmt.Errorf("failed to write header: %w", err))
+	}
+
+	err = s.customerRepository.StreamForExport(ctx, status, func(customer domain.Customer) error {
+		return stream.write([]string{
+			strconv.FormatUint(customer.ID, 10),
+			customer.NIK,
+			customer.FullName,
+			customer.LegalName,
+			customer.BirthPlace,
+			customer.BirthDate.Format(time.DateOnly),
+			customer.Salary.StringFixed(2),
+			string(customer.VerificationStatus),
+			customer.Email,
+			customer.Phone,
+			string(customer.Language),
+			customer.CreatedAt.UTC().Format(time.RFC3339),
+			formatTime(customer.DormantAt),
+		})
+	})
+	if err == nil {
+		err = stream.flush()
+	}
+	if err != nil {
+		return stream.rows, s.recordError(ctx, span, start, "export_customers", "stream_error", fmt.Errorf("failed to export customers: %w", err))
+	}
+
+	s.rowsExported.Add(ctx, int64(stream.rows), metric.WithAttributes(attribute.String("export", "customers")))
+	s.recordSuccess(ctx, span, start, "export_customers", zap.String("status", string(status)), zap.Int("rows", stream.rows))
+
+	return stream.rows, nil
+}
+
+// ExportTransactions implements ExportServices for the contracts dated in
+// [from, to). Amounts are in the contract's own currency, next to its rate.
+func (s *exportService) ExportTransactions(ctx context.Context, w io.Writer, from, to time.Time) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ExportTransactions")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("export.from", from.Format(time.RFC3339)),
+		attribute.String("export.to", to.Format(time.RFC3339)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "export_transactions"), attribute.String("service", "export")))
+
+	if !to.After(from) {
+		return 0, s.recordError(ctx, span, start, "export_transactions", "invalid_range", common.ErrInvalidExportRange)
+	}
+
+	stream, err := newCSVStream(w, []string{
+		"id", "contract_number", "customer_id", "tenor_id", "asset_name", "otr_amount", "admin_fee",
+		"total_interest", "total_installment_amount", "discount_amount", "status", "transaction_date",
+		"currency", "fx_rate", "partner_id", "region_code", "branch_code",
+	})
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "export_transactions", "write_error", fmt.Errorf("failed to write header: %w", err))
+	}
+
+	err = s.transactionRepository.StreamForExport(ctx, from, to, func(transaction domain.Transaction) error {
+		partnerID := ""
+		if transaction.PartnerID != nil {
+			partnerID = strconv.FormatUint(*transaction.PartnerID, 10)
+		}
+		return stream.write([]string{
+			strconv.FormatUint(transaction.ID, 10),
+			transaction.ContractNumber,
+			strconv.FormatUint(transaction.CustomerID, 10),
+			strconv.FormatUint(uint64(transaction.TenorID), 10),
+			transaction.AssetName,
+			transaction.OTRAmount.StringFixed(2),
+			transaction.AdminFee.StringFixed(2),
+			transaction.TotalInterest.StringFixed(2),
+			transaction.TotalInstallmentAmount.StringFixed(2),
+			transaction.DiscountAmount.StringFixed(2),
+			string(transaction.Status),
+			transaction.TransactionDate.UTC().Format(time.RFC3339),
+			transaction.Currency,
+			transaction.FxRate.String(),
+			partnerID,
+			transaction.RegionCode,
+			transaction.BranchCode,
+		})
+	})
+	if err == nil {
+		err = stream.flush()
+	}
+	if err != nil {
+		return stream.rows, s.recordError(ctx, span, start, "export_transactions", "stream_error", fmt.Errorf("failed to export transactions: %w", err))
+	}
+
+	s.rowsExported.Add(ctx, int64(stream.rows), metric.WithAttributes(attribute.String("export", "transactions")))
+	s.recordSuccess(ctx, span, start, "export_transactions",
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Int("rows", stream.rows),
+	)
+
+	return stream.rows, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (s *exportService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Export operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "export"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "export"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *exportService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "export"), attribute.String("status", "success")))
+
+	s.log.Info("Export operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewExportService(
+	customerRepository repository.CustomerRepository,
+	transactionRepository repository.TransactionRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ExportServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	rowsExported, _ := meter.Int64Counter(
+		"service.export.rows",
+		metric.WithDescription("Number of rows written to admin exports"),
+		metric.WithUnit("{row}"),
+	)
+
+	return &exportService{
+		customerRepository:    customerRepository,
+		transactionRepository: transactionRepository,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+		rowsExported:          rowsExported,
+	}
+}
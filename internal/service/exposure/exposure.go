@@ -0,0 +1,185 @@
+package exposuresrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type exposureKey struct {
+	customerID uint64
+	tenorID    uint
+}
+
+type exposureService struct {
+	exposureRepository repository.CustomerExposureRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	mismatchCount     metric.Int64Counter
+}
+
+// Reconcile implements ExposureServices. Pairs whose summary differs from
+// the raw transaction sums are re-checked and corrected one by one; a pair
+// that only differed because a contract landed between the two reads is
+// left alone.
+func (s *exposureService) Reconcile(ctx context.Context) (*domain.ExposureReconciliation, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ReconcileExposure")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "reconcile_exposure"), attribute.String("service", "exposure")))
+
+	summary, err := s.exposureRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "reconcile_exposure", "repository_error", fmt.Errorf("failed to load exposure summary: %w", err))
+	}
+
+	raw, err := s.exposureRepository.SumTransactions(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "reconcile_exposure", "repository_error", fmt.Errorf("failed to sum transactions: %w", err))
+	}
+
+	expected := make(map[exposureKey]decimal.Decimal, len(raw))
+	for _, e := range raw {
+		expected[exposureKey{e.CustomerID, e.TenorID}] = e.ActivePrincipal
+	}
+
+	var mismatched []exposureKey
+	for _, e := range summary {
+		key := exposureKey{e.CustomerID, e.TenorID}
+		want, ok := expected[key]
+		delete(expected, key)
+		if !ok {
+			want = decimal.Zero
+		}
+		if !e.ActivePrincipal.Equal(want) {
+			mismatched = append(mismatched, key)
+		}
+	}
+	// Sisa di expected belum punya baris ringkasan sama sekali
+	for _, e := range raw {
+		key := exposureKey{e.CustomerID, e.TenorID}
+		if _, ok := expected[key]; ok && !e.ActivePrincipal.IsZero() {
+			mismatched = append(mismatched, key)
+		}
+	}
+
+	run := &domain.ExposureReconciliation{Checked: len(summary) + len(expected)}
+	for _, key := range mismatched {
+		repaired, err := s.exposureRepository.Repair(ctx, key.customerID, key.tenorID)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "reconcile_exposure", "repository_error", fmt.Errorf("failed to repair exposure: %w", err))
+		}
+		if !repaired {
+			continue
+		}
+
+		run.Repaired++
+		s.log.Warn("Customer exposure summary drifted from transactions",
+			zap.Uint64("customer_id", key.customerID),
+			zap.Uint("tenor_id", key.tenorID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+	}
+
+	s.mismatchCount.Add(ctx, int64(run.Repaired), metric.WithAttributes(attribute.String("service", "exposure")))
+	span.SetAttributes(
+		attribute.Int("exposure.checked", run.Checked),
+		attribute.Int("exposure.repaired", run.Repaired),
+	)
+	s.recordSuccess(ctx, span, start, "reconcile_exposure",
+		zap.Int("checked", run.Checked),
+		zap.Int("repaired", run.Repaired),
+	)
+
+	return run, nil
+}
+
+func (s *exposureService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Exposure operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "exposure"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "exposure"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *exposureService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "exposure"), attribute.String("status", "success")))
+
+	s.log.Info("Exposure operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewExposureService(
+	exposureRepository repository.CustomerExposureRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ExposureServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	mismatchCount, _ := meter.Int64Counter(
+		"service.exposure.mismatches",
+		metric.WithDescription("Number of customer exposure rows corrected by reconciliation"),
+		metric.WithUnit("{row}"),
+	)
+
+	return &exposureService{
+		exposureRepository: exposureRepository,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		mismatchCount:      mismatchCount,
+	}
+}
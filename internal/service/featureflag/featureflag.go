@@ -0,0 +1,273 @@
+package featureflagsrv
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/cache"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/flags"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// allFlags is the only cache key; flags are few and always loaded together.
+const allFlags = "all"
+
+type featureFlagService struct {
+	featureFlagRepository repository.FeatureFlagRepository
+	flags                 *cache.Cache[map[string]domain.FeatureFlag]
+
+	// lastKnown is served per tenant while the flags cannot be loaded at all
+	mu        sync.RWMutex
+	lastKnown map[string]map[string]domain.FeatureFlag
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	evaluations       metric.Int64Counter
+}
+
+// Enabled implements FeatureFlags.
+func (s *featureFlagService) Enabled(ctx context.Context, key string) bool {
+	flag, ok := s.lookup(ctx, key)
+
+	enabled := ok && flag.Enabled
+	if ok && !enabled {
+		if partnerID, found := flags.PartnerFrom(ctx); found {
+			enabled = slices.Contains(flag.PartnerIDs, partnerID)
+		}
+	}
+
+	s.evaluations.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("flag", key),
+		attribute.Bool("enabled", enabled),
+	))
+
+	return enabled
+}
+
+// ListFlags implements FeatureFlagServices.
+func (s *featureFlagService) ListFlags(ctx context.Context) ([]domain.FeatureFlag, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListFeatureFlags")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_feature_flags"), attribute.String("service", "feature_flag")))
+
+	// Admin selalu melihat data terbaru, bukan isi cache
+	featureFlags, err := s.featureFlagRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_feature_flags", "repository_error", fmt.Errorf("failed to list feature flags: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_feature_flags", zap.Int("count", len(featureFlags)))
+
+	return featureFlags, nil
+}
+
+// SetFlag implements FeatureFlagServices.
+func (s *featureFlagService) SetFlag(ctx context.Context, key string, updatedBy uint64, req dto.FeatureFlagRequest) (*domain.FeatureFlag, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetFeatureFlag")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("feature_flag.key", key),
+		attribute.Bool("feature_flag.enabled", req.Enabled),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_feature_flag"), attribute.String("service", "feature_flag")))
+
+	if !keyPattern.MatchString(key) {
+		return nil, s.recordError(ctx, span, start, "set_feature_flag", "invalid_key", common.ErrInvalidFeatureFlag)
+	}
+
+	flag := &domain.FeatureFlag{
+		Key:         key,
+		Description: req.Description,
+		Enabled:     req.Enabled,
+		PartnerIDs:  req.PartnerIDs,
+		UpdatedBy:   updatedBy,
+	}
+	if flag.PartnerIDs == nil {
+		flag.PartnerIDs = []uint64{}
+	}
+
+	if err := s.featureFlagRepository.Upsert(ctx, flag); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_feature_flag", "repository_error", fmt.Errorf("failed to save feature flag: %w", err))
+	}
+	s.invalidate(ctx)
+
+	s.recordSuccess(ctx, span, start, "set_feature_flag",
+		zap.String("key", key),
+		zap.Bool("enabled", flag.Enabled),
+		zap.Uint64s("partner_ids", flag.PartnerIDs),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return flag, nil
+}
+
+// DeleteFlag implements FeatureFlagServices.
+func (s *featureFlagService) DeleteFlag(ctx context.Context, key string) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteFeatureFlag")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("feature_flag.key", key))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_feature_flag"), attribute.String("service", "feature_flag")))
+
+	deleted, err := s.featureFlagRepository.Delete(ctx, key)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_feature_flag", "repository_error", fmt.Errorf("failed to delete feature flag: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_feature_flag", "not_found", common.ErrFeatureFlagNotFound)
+	}
+	s.invalidate(ctx)
+
+	s.recordSuccess(ctx, span, start, "delete_feature_flag", zap.String("key", key))
+
+	return nil
+}
+
+// lookup serves key from the shared cache, which reloads every flag at
+// once when it expires. When the reload fails the last flags loaded are kept
+// until the next attempt so a database hiccup does not switch features off.
+func (s *featureFlagService) lookup(ctx context.Context, key string) (domain.FeatureFlag, bool) {
+	featureFlags, err := s.flags.Get(ctx, allFlags, s.load)
+	if err != nil {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		lastKnown := s.lastKnown[tenancy.ID(ctx)]
+		s.log.Warn("Failed to reload feature flags, serving cached values",
+			zap.Int("cached", len(lastKnown)),
+			zap.Error(err),
+		)
+		flag, ok := lastKnown[key]
+		return flag, ok
+	}
+
+	s.mu.Lock()
+	s.lastKnown[tenancy.ID(ctx)] = featureFlags
+	s.mu.Unlock()
+
+	flag, ok := featureFlags[key]
+	return flag, ok
+}
+
+func (s *featureFlagService) load(ctx context.Context) (map[string]domain.FeatureFlag, error) {
+	featureFlags, err := s.featureFlagRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]domain.FeatureFlag, len(featureFlags))
+	for _, flag := range featureFlags {
+		byKey[flag.Key] = flag
+	}
+	return byKey, nil
+}
+
+// invalidate makes every instance reload the flags on their next lookup.
+func (s *featureFlagService) invalidate(ctx context.Context) {
+	if err := s.flags.Invalidate(ctx, allFlags); err != nil {
+		s.log.Warn("Failed to invalidate feature flag cache", zap.Error(err))
+	}
+}
+
+func (s *featureFlagService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Feature flag operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "feature_flag"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "feature_flag"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *featureFlagService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "feature_flag"), attribute.String("status", "success")))
+
+	s.log.Info("Feature flag operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewFeatureFlagService(
+	featureFlagRepository repository.FeatureFlagRepository,
+	flags *cache.Cache[map[string]domain.FeatureFlag],
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.FeatureFlagServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	evaluations, _ := meter.Int64Counter(
+		"service.feature_flag.evaluations",
+		metric.WithDescription("Number of feature flag evaluations"),
+		metric.WithUnit("{evaluation}"),
+	)
+
+	return &featureFlagService{
+		featureFlagRepository: featureFlagRepository,
+		flags:                 flags,
+		lastKnown:             make(map[string]map[string]domain.FeatureFlag),
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+		evaluations:           evaluations,
+	}
+}
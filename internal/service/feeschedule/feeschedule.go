@@ -0,0 +1,200 @@
+package feeschedulesrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type feeScheduleService struct {
+	partnerRepository     repository.PartnerRepository
+	feeScheduleRepository repository.FeeScheduleRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// ListSchedules implements FeeScheduleServices.
+func (s *feeScheduleService) ListSchedules(ctx context.Context, partnerID uint64) ([]domain.PartnerFeeSchedule, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListFeeSchedules")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(partnerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_fee_schedules"), attribute.String("service", "fee_schedule")))
+
+	if err := s.ensurePartner(ctx, partnerID); err != nil {
+		return nil, s.recordError(ctx, span, start, "list_fee_schedules", "partner_lookup_error", err)
+	}
+
+	schedules, err := s.feeScheduleRepository.FindByPartner(ctx, partnerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_fee_schedules", "repository_error", fmt.Errorf("failed to list fee schedules: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_fee_schedules", zap.Uint64("partner_id", partnerID), zap.Int("count", len(schedules)))
+
+	return schedules, nil
+}
+
+// SetSchedule implements FeeScheduleServices.
+func (s *feeScheduleService) SetSchedule(ctx context.Context, partnerID, updatedBy uint64, req dto.FeeScheduleRequest) (*domain.PartnerFeeSchedule, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetFeeSchedule")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Int("tenor.duration_months", int(req.TenorMonths)),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_fee_schedule"), attribute.String("service", "fee_schedule")))
+
+	if err := s.ensurePartner(ctx, partnerID); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_fee_schedule", "partner_lookup_error", err)
+	}
+
+	schedule := &domain.PartnerFeeSchedule{
+		PartnerID:      partnerID,
+		TenorMonths:    req.TenorMonths,
+		AdminFeeFlat:   req.AdminFeeFlat,
+		AdminFeeRate:   req.AdminFeeRate,
+		CommissionRate: req.CommissionRate,
+		UpdatedBy:      updatedBy,
+	}
+
+	if err := s.feeScheduleRepository.Upsert(ctx, schedule); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_fee_schedule", "repository_error", fmt.Errorf("failed to save fee schedule: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "set_fee_schedule",
+		zap.Uint64("partner_id", partnerID),
+		zap.Uint8("tenor_months", req.TenorMonths),
+		zap.Stringer("admin_fee_flat", req.AdminFeeFlat),
+		zap.Stringer("admin_fee_rate", req.AdminFeeRate),
+		zap.Stringer("commission_rate", req.CommissionRate),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return schedule, nil
+}
+
+// DeleteSchedule implements FeeScheduleServices.
+func (s *feeScheduleService) DeleteSchedule(ctx context.Context, partnerID uint64, tenorMonths uint8) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteFeeSchedule")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Int("tenor.duration_months", int(tenorMonths)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_fee_schedule"), attribute.String("service", "fee_schedule")))
+
+	deleted, err := s.feeScheduleRepository.Delete(ctx, partnerID, tenorMonths)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_fee_schedule", "repository_error", fmt.Errorf("failed to delete fee schedule: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_fee_schedule", "not_found", common.ErrFeeScheduleNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "delete_fee_schedule", zap.Uint64("partner_id", partnerID), zap.Uint8("tenor_months", tenorMonths))
+
+	return nil
+}
+
+func (s *feeScheduleService) ensurePartner(ctx context.Context, partnerID uint64) error {
+	partner, err := s.partnerRepository.FindByID(ctx, partnerID)
+	if err != nil {
+		return fmt.Errorf("failed to find partner: %w", err)
+	}
+	if partner == nil {
+		return common.ErrPartnerNotFound
+	}
+	return nil
+}
+
+func (s *feeScheduleService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Fee schedule operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "fee_schedule"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "fee_schedule"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *feeScheduleService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "fee_schedule"), attribute.String("status", "success")))
+
+	s.log.Info("Fee schedule operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewFeeScheduleService(
+	partnerRepository repository.PartnerRepository,
+	feeScheduleRepository repository.FeeScheduleRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.FeeScheduleServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &feeScheduleService{
+		partnerRepository:     partnerRepository,
+		feeScheduleRepository: feeScheduleRepository,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+	}
+}
@@ -0,0 +1,218 @@
+package fxratesrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type fxRateService struct {
+	fxRateRepository repository.FxRateRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	ratesUploaded     metric.Int64Counter
+}
+
+// UploadRates implements FxRateServices.
+func (s *fxRateService) UploadRates(ctx context.Context, uploadedBy uint64, req dto.FxRateUploadRequest) ([]domain.FxRate, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UploadFxRates")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("admin.id", int64(uploadedBy)),
+		attribute.String("fx_rate.date", req.RateDate),
+		attribute.Int("fx_rate.count", len(req.Rates)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "upload_fx_rates"), attribute.String("service", "fx_rate")))
+
+	rateDate, err := time.Parse("2006-01-02", req.RateDate)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_fx_rates", "invalid_date", fmt.Errorf("invalid rate date: %w", err))
+	}
+
+	// Satu kurs per mata uang, baris terakhir yang menang bila ada duplikat
+	byCurrency := make(map[string]int, len(req.Rates))
+	rates := make([]domain.FxRate, 0, len(req.Rates))
+	for _, item := range req.Rates {
+		code := currency.Normalize(item.Currency)
+		if !currency.Valid(code) {
+			return nil, s.recordError(ctx, span, start, "upload_fx_rates", "invalid_currency", common.ErrInvalidCurrency)
+		}
+		if currency.IsBase(code) {
+			return nil, s.recordError(ctx, span, start, "upload_fx_rates", "base_currency_rate", common.ErrBaseCurrencyRate)
+		}
+
+		rate := domain.FxRate{
+			Currency:   code,
+			RateDate:   rateDate,
+			RateToIDR:  item.RateToIDR,
+			UploadedBy: uploadedBy,
+		}
+		if i, ok := byCurrency[code]; ok {
+			rates[i] = rate
+			continue
+		}
+		byCurrency[code] = len(rates)
+		rates = append(rates, rate)
+	}
+
+	if err := s.fxRateRepository.UpsertMany(ctx, rates); err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_fx_rates", "repository_error", fmt.Errorf("failed to save fx rates: %w", err))
+	}
+
+	s.ratesUploaded.Add(ctx, int64(len(rates)), metric.WithAttributes(attribute.String("service", "fx_rate")))
+	s.recordSuccess(ctx, span, start, "upload_fx_rates", zap.String("rate_date", req.RateDate), zap.Int("count", len(rates)))
+
+	return rates, nil
+}
+
+// ListRates implements FxRateServices.
+func (s *fxRateService) ListRates(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListFxRates")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.currency", params.Value("currency")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_fx_rates"), attribute.String("service", "fx_rate")))
+
+	rates, total, err := s.fxRateRepository.FindPaginated(ctx, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_fx_rates", "repository_error", fmt.Errorf("failed to list fx rates: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_fx_rates", zap.Int64("total", total))
+
+	return params.Paginated(dto.FxRatesToResponse(rates), total), nil
+}
+
+// RateToIDR implements CurrencyConverter.
+func (s *fxRateService) RateToIDR(ctx context.Context, code string, asOf time.Time) (decimal.Decimal, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RateToIDR")
+	defer span.End()
+
+	start := time.Now()
+	code = currency.Normalize(code)
+	span.SetAttributes(
+		attribute.String("fx_rate.currency", code),
+		attribute.String("fx_rate.as_of", asOf.Format("2006-01-02")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "rate_to_idr"), attribute.String("service", "fx_rate")))
+
+	if currency.IsBase(code) {
+		s.recordSuccess(ctx, span, start, "rate_to_idr", zap.String("currency", code))
+		return decimal.NewFromInt(1), nil
+	}
+
+	rate, err := s.fxRateRepository.FindLatest(ctx, code, asOf)
+	if err != nil {
+		return decimal.Zero, s.recordError(ctx, span, start, "rate_to_idr", "repository_error", fmt.Errorf("failed to get fx rate: %w", err))
+	}
+	if rate == nil {
+		return decimal.Zero, s.recordError(ctx, span, start, "rate_to_idr", "rate_not_found", fmt.Errorf("%w: %s", common.ErrFxRateNotFound, code))
+	}
+
+	s.recordSuccess(ctx, span, start, "rate_to_idr",
+		zap.String("currency", code),
+		zap.Time("rate_date", rate.RateDate),
+		zap.Stringer("rate_to_idr", rate.RateToIDR),
+	)
+
+	return rate.RateToIDR, nil
+}
+
+func (s *fxRateService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Fx rate operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "fx_rate"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "fx_rate"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *fxRateService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "fx_rate"), attribute.String("status", "success")))
+
+	s.log.Info("Fx rate operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewFxRateService(
+	fxRateRepository repository.FxRateRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.FxRateServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	ratesUploaded, _ := meter.Int64Counter(
+		"service.fx_rates.uploaded",
+		metric.WithDescription("Number of fx rates uploaded"),
+		metric.WithUnit("{rate}"),
+	)
+
+	return &fxRateService{
+		fxRateRepository:  fxRateRepository,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+		ratesUploaded:     ratesUploaded,
+	}
+}
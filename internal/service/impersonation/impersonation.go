@@ -0,0 +1,277 @@
+package impersonationsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/golang-jwt/jwt/v5"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config sets how long an impersonation token lives. It is signed with the
+// same secret as login tokens so the regular auth middleware accepts it.
+type Config struct {
+	JWTSecret string
+	TTL       time.Duration
+}
+
+type impersonationService struct {
+	customerRepository      repository.CustomerRepository
+	impersonationRepository repository.ImpersonationRepository
+	cfg                     Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Start implements ImpersonationServices.
+func (s *impersonationService) Start(ctx context.Context, adminID, customerID uint64, req dto.ImpersonationRequest) (*dto.ImpersonationResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.StartImpersonation")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("admin.id", int64(adminID)),
+		attribute.Int64("customer.id", int64(customerID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "start_impersonation"), attribute.String("service", "impersonation")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "start_impersonation", "repository_error", fmt.Errorf("failed to find customer: %w", err))
+	}
+	if customer == nil {
+		return nil, s.recordError(ctx, span, start, "start_impersonation", "not_found", common.ErrCustomerNotFound)
+	}
+	// Akun admin tidak boleh diimpersonasi, supaya token ini tidak bisa dipakai naik hak akses
+	if customer.Role != domain.CustomerRole {
+		return nil, s.recordError(ctx, span, start, "start_impersonation", "not_allowed", common.ErrImpersonationNotAllowed)
+	}
+
+	session := &domain.ImpersonationSession{
+		AdminID:    adminID,
+		CustomerID: customerID,
+		Reason:     req.Reason,
+		ExpiresAt:  time.Now().Add(s.cfg.TTL),
+	}
+	if err := s.impersonationRepository.Create(ctx, session); err != nil {
+		return nil, s.recordError(ctx, span, start, "start_impersonation", "repository_error", fmt.Errorf("failed to create impersonation session: %w", err))
+	}
+
+	claims := &domain.JwtCustomClaims{
+		UserID:          customerID,
+		Role:            domain.CustomerRole,
+		ImpersonatorID:  adminID,
+		ImpersonationID: session.ID,
+		Tenant:          tenancy.ID(ctx),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+			Issuer:    "multifinance",
+		},
+	}
+
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWTSecret))
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "start_impersonation", "token_error", fmt.Errorf("failed to sign impersonation token: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "start_impersonation",
+		zap.Uint64("impersonation_id", session.ID),
+		zap.Uint64("admin_id", adminID),
+		zap.Uint64("customer_id", customerID),
+		zap.String("reason", req.Reason),
+		zap.Time("expires_at", session.ExpiresAt),
+	)
+
+	return &dto.ImpersonationResponse{
+		SessionID:  session.ID,
+		CustomerID: customerID,
+		Token:      signedToken,
+		ExpiresAt:  session.ExpiresAt,
+	}, nil
+}
+
+// End implements ImpersonationServices.
+func (s *impersonationService) End(ctx context.Context, sessionID uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.EndImpersonation")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("impersonation.id", int64(sessionID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "end_impersonation"), attribute.String("service", "impersonation")))
+
+	ended, err := s.impersonationRepository.End(ctx, sessionID, time.Now())
+	if err != nil {
+		return s.recordError(ctx, span, start, "end_impersonation", "repository_error", fmt.Errorf("failed to end impersonation session: %w", err))
+	}
+	if !ended {
+		return s.recordError(ctx, span, start, "end_impersonation", "not_found", common.ErrImpersonationNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "end_impersonation", zap.Uint64("impersonation_id", sessionID))
+
+	return nil
+}
+
+// Authorize implements ImpersonationServices.
+func (s *impersonationService) Authorize(ctx context.Context, sessionID uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.AuthorizeImpersonation")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("impersonation.id", int64(sessionID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "authorize_impersonation"), attribute.String("service", "impersonation")))
+
+	session, err := s.impersonationRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "authorize_impersonation", "repository_error", fmt.Errorf("failed to find impersonation session: %w", err))
+	}
+	// Token tetap valid sampai exp-nya, jadi sesi yang sudah diakhiri admin harus ditolak di sini
+	if session == nil || !session.Active(time.Now()) {
+		return s.recordError(ctx, span, start, "authorize_impersonation", "ended", common.ErrImpersonationEnded)
+	}
+
+	s.recordSuccess(ctx, span, start, "authorize_impersonation", zap.Uint64("impersonation_id", sessionID))
+
+	return nil
+}
+
+// RecordRequest implements ImpersonationServices.
+func (s *impersonationService) RecordRequest(ctx context.Context, request domain.ImpersonationRequest) error {
+	ctx, span := s.tracer.Start(ctx, "service.RecordImpersonationRequest")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("impersonation.id", int64(request.SessionID)),
+		attribute.String("http.method", request.Method),
+		attribute.String("http.path", request.Path),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "record_impersonation_request"), attribute.String("service", "impersonation")))
+
+	if err := s.impersonationRepository.CreateRequest(ctx, &request); err != nil {
+		return s.recordError(ctx, span, start, "record_impersonation_request", "repository_error", fmt.Errorf("failed to record impersonation request: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "record_impersonation_request",
+		zap.Uint64("impersonation_id", request.SessionID),
+		zap.String("method", request.Method),
+		zap.String("path", request.Path),
+		zap.Int("status", request.Status),
+	)
+
+	return nil
+}
+
+// ListRequests implements ImpersonationServices.
+func (s *impersonationService) ListRequests(ctx context.Context, sessionID uint64) ([]domain.ImpersonationRequest, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListImpersonationRequests")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("impersonation.id", int64(sessionID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_impersonation_requests"), attribute.String("service", "impersonation")))
+
+	session, err := s.impersonationRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_impersonation_requests", "repository_error", fmt.Errorf("failed to find impersonation session: %w", err))
+	}
+	if session == nil {
+		return nil, s.recordError(ctx, span, start, "list_impersonation_requests", "not_found", common.ErrImpersonationNotFound)
+	}
+
+	requests, err := s.impersonationRepository.FindRequests(ctx, sessionID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_impersonation_requests", "repository_error", fmt.Errorf("failed to list impersonation requests: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_impersonation_requests", zap.Uint64("impersonation_id", sessionID), zap.Int("count", len(requests)))
+
+	return requests, nil
+}
+
+func (s *impersonationService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Impersonation operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "impersonation"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "impersonation"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *impersonationService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "impersonation"), attribute.String("status", "success")))
+
+	s.log.Info("Impersonation operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewImpersonationService(
+	customerRepository repository.CustomerRepository,
+	impersonationRepository repository.ImpersonationRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ImpersonationServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &impersonationService{
+		customerRepository:      customerRepository,
+		impersonationRepository: impersonationRepository,
+		cfg:                     cfg,
+		meter:                   meter,
+		tracer:                  tracer,
+		log:                     log,
+		operationDuration:       operationDuration,
+		operationCount:          operationCount,
+		errorCount:              errorCount,
+	}
+}
@@ -0,0 +1,315 @@
+package incomesrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/bankstatement"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config controls where statements are stored and when an inflow is too far
+// from the declared salary. DiscrepancyThreshold is a fraction of the
+// declared salary, 0.3 flags inflows more than 30% above or below it.
+type Config struct {
+	Folder               string
+	MaxSize              int64
+	DiscrepancyThreshold decimal.Decimal
+}
+
+type incomeService struct {
+	incomeRepository   repository.IncomeVerificationRepository
+	customerRepository repository.CustomerRepository
+	cloudinaryService  service.CloudinaryService
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	statementsChecked metric.Int64Counter
+}
+
+// Upload implements IncomeVerificationServices.
+func (s *incomeService) Upload(ctx context.Context, customerID uint64, fileName string, data []byte) (*domain.IncomeVerification, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UploadBankStatement")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int("statement.size", len(data)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "upload_statement"), attribute.String("service", "income")))
+
+	if int64(len(data)) > s.cfg.MaxSize {
+		return nil, s.recordError(ctx, span, start, "upload_statement", "too_large", common.ErrStatementTooLarge)
+	}
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_statement", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return nil, s.recordError(ctx, span, start, "upload_statement", "customer_not_found", common.ErrCustomerNotFound)
+	}
+
+	transactions, err := bankstatement.Parse(data)
+	switch {
+	case errors.Is(err, bankstatement.ErrNoTransactions):
+		return nil, s.recordError(ctx, span, start, "upload_statement", "statement_empty", common.ErrStatementEmpty)
+	case err != nil:
+		return nil, s.recordError(ctx, span, start, "upload_statement", "statement_unreadable", common.ErrStatementUnreadable)
+	}
+	summary := bankstatement.Summarize(transactions)
+
+	discrepancy := s.discrepancy(summary.AverageMonthlyInflow, customer.Salary)
+	status := domain.IncomeMatched
+	if discrepancy.GreaterThan(s.cfg.DiscrepancyThreshold) {
+		status = domain.IncomeFlagged
+	}
+
+	publicID := fmt.Sprintf("statement-%d-%d", customerID, time.Now().UnixNano())
+	url, err := s.cloudinaryService.UploadFile(ctx, data, s.cfg.Folder, publicID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_statement", "upload_error", fmt.Errorf("failed to upload bank statement: %w", err))
+	}
+
+	verification := &domain.IncomeVerification{
+		CustomerID:           customerID,
+		FileName:             sanitizeFileName(fileName),
+		StatementUrl:         url,
+		DeclaredSalary:       customer.Salary,
+		AverageMonthlyInflow: summary.AverageMonthlyInflow,
+		TotalInflow:          summary.TotalInflow,
+		PeriodStart:          summary.From,
+		PeriodEnd:            summary.To,
+		MonthsCovered:        summary.Months,
+		TransactionCount:     summary.Transactions,
+		Discrepancy:          discrepancy,
+		Status:               status,
+	}
+	if err := s.incomeRepository.Create(ctx, verification); err != nil {
+		return nil, s.recordError(ctx, span, start, "upload_statement", "repository_error", fmt.Errorf("failed to create income verification: %w", err))
+	}
+
+	s.statementsChecked.Add(ctx, 1, metric.WithAttributes(attribute.String("status", string(status))))
+	s.recordSuccess(ctx, span, start, "upload_statement",
+		zap.Uint64("income_verification_id", verification.ID),
+		zap.Uint64("customer_id", customerID),
+		zap.Stringer("average_monthly_inflow", summary.AverageMonthlyInflow),
+		zap.Stringer("discrepancy", discrepancy),
+		zap.String("status", string(status)),
+	)
+
+	return verification, nil
+}
+
+// ListByCustomer implements IncomeVerificationServices.
+func (s *incomeService) ListByCustomer(ctx context.Context, customerID uint64) ([]domain.IncomeVerification, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListIncomeVerificationsByCustomer")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_customer_verifications"), attribute.String("service", "income")))
+
+	verifications, err := s.incomeRepository.FindByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_customer_verifications", "repository_error", fmt.Errorf("failed to list income verifications: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_customer_verifications",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("count", len(verifications)),
+	)
+
+	return verifications, nil
+}
+
+// ListVerifications implements IncomeVerificationServices.
+func (s *incomeService) ListVerifications(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListIncomeVerifications")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.status", params.Value("status")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_verifications"), attribute.String("service", "income")))
+
+	verifications, total, err := s.incomeRepository.FindPaginated(ctx, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_verifications", "repository_error", fmt.Errorf("failed to list income verifications: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_verifications", zap.Int64("total", total))
+
+	return params.Paginated(dto.IncomeVerificationsToResponse(verifications), total), nil
+}
+
+// Review implements IncomeVerificationServices.
+func (s *incomeService) Review(ctx context.Context, verificationID, reviewerID uint64, req dto.IncomeVerificationReviewRequest) (*domain.IncomeVerification, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ReviewIncomeVerification")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("income_verification.id", int64(verificationID)),
+		attribute.Int64("reviewer.id", int64(reviewerID)),
+		attribute.String("income_verification.decision", string(req.Status)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "review_verification"), attribute.String("service", "income")))
+
+	verification, err := s.incomeRepository.FindByID(ctx, verificationID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "review_verification", "repository_error", fmt.Errorf("failed to get income verification: %w", err))
+	}
+	if verification == nil {
+		return nil, s.recordError(ctx, span, start, "review_verification", "not_found", common.ErrIncomeCheckNotFound)
+	}
+	// Hasil yang cocok dengan gaji tidak perlu diputuskan admin
+	if verification.Status != domain.IncomeFlagged {
+		return nil, s.recordError(ctx, span, start, "review_verification", "not_flagged", common.ErrIncomeCheckReviewed)
+	}
+
+	now := time.Now()
+	verification.Status = req.Status
+	verification.ReviewerID = &reviewerID
+	verification.ReviewNote = req.Note
+	verification.ReviewedAt = &now
+
+	reviewed, err := s.incomeRepository.Review(ctx, verification)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "review_verification", "repository_error", fmt.Errorf("failed to review income verification: %w", err))
+	}
+	if !reviewed {
+		return nil, s.recordError(ctx, span, start, "review_verification", "not_flagged", common.ErrIncomeCheckReviewed)
+	}
+
+	s.recordSuccess(ctx, span, start, "review_verification",
+		zap.Uint64("income_verification_id", verificationID),
+		zap.Uint64("reviewer_id", reviewerID),
+		zap.String("decision", string(req.Status)),
+	)
+
+	return verification, nil
+}
+
+// discrepancy returns |inflow - salary| / salary. A customer without a
+// declared salary always counts as a full discrepancy.
+func (s *incomeService) discrepancy(inflow, salary decimal.Decimal) decimal.Decimal {
+	if !salary.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+	return inflow.Sub(salary).Abs().Div(salary).Round(4)
+}
+
+func sanitizeFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "statement"
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+func (s *incomeService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Income verification operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "income"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "income"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *incomeService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "income"), attribute.String("status", "success")))
+
+	s.log.Info("Income verification operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewIncomeVerificationService(
+	incomeRepository repository.IncomeVerificationRepository,
+	customerRepository repository.CustomerRepository,
+	cloudinaryService service.CloudinaryService,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.IncomeVerificationServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	statementsChecked, _ := meter.Int64Counter(
+		"service.income_verifications.checked",
+		metric.WithDescription("Number of bank statements checked against the declared salary"),
+		metric.WithUnit("{statement}"),
+	)
+
+	return &incomeService{
+		incomeRepository:   incomeRepository,
+		customerRepository: customerRepository,
+		cloudinaryService:  cloudinaryService,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		statementsChecked:  statementsChecked,
+	}
+}
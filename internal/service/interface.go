@@ -1,11 +1,19 @@
 package service
 
+//go:generate mockgen -source=interface.go -destination=mocks/mock_service.go -package=mocks
+
 import (
 	"context"
+	"io"
 	"mime/multipart"
+	"net/http"
+	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/refdata"
+	"github.com/shopspring/decimal"
 )
 
 type Media interface {
@@ -13,11 +21,12 @@ type Media interface {
 }
 
 type ProfileServices interface {
-	Create(ctx context.Context, req *domain.Customer) (*domain.Customer, error)
-	Update(ctx context.Context, customerID uint64, req domain.Customer) error
+	Create(ctx context.Context, req *domain.Customer, checks dto.RegistrationChecks) (*domain.Customer, error)
+	Update(ctx context.Context, customerID uint64, req domain.Customer, checks dto.ContactChecks) error
 	GetMyProfile(ctx context.Context, customerID uint64) (*domain.Customer, error)
 	GetMyLimits(ctx context.Context, customerID uint64) ([]dto.LimitDetailResponse, error)
 	GetMyTransactions(ctx context.Context, customerID uint64, params domain.Params) (*domain.Paginated, error)
+	GetMyTransactionDetail(ctx context.Context, customerID uint64, contractNumber string) (*dto.TransactionDetailResponse, error)
 }
 
 type PartnerServices interface {
@@ -26,7 +35,11 @@ type PartnerServices interface {
 }
 
 type AdminServices interface {
-	SetLimits(ctx context.Context, customerID uint64, req dto.SetLimits) error
+	SetLimits(ctx context.Context, customerID, changedBy uint64, req dto.SetLimits) error
+	GetLimitHistory(ctx context.Context, customerID uint64) ([]domain.LimitChange, error)
+	// GetLimitsAt returns the customer's limits in force at at, past or
+	// scheduled.
+	GetLimitsAt(ctx context.Context, customerID uint64, at time.Time) ([]domain.CustomerLimit, error)
 	GetCustomerByID(ctx context.Context, customerID uint64) (*domain.Customer, error)
 	ListCustomers(ctx context.Context, params domain.Params) (*domain.Paginated, error)
 	VerifyCustomer(ctx context.Context, customerID uint64, req dto.VerificationRequest) error
@@ -34,8 +47,472 @@ type AdminServices interface {
 
 type CloudinaryService interface {
 	UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (string, error)
+	UploadFile(ctx context.Context, data []byte, folder, publicID string) (string, error)
+	// DeleteImage removes the image behind a URL returned by UploadImage.
+	// An image that is already gone is not an error.
+	DeleteImage(ctx context.Context, url string) error
+	// ListImages returns a page of the images in the tenant's folder, in
+	// the order the provider lists them; cursor is empty for the first page.
+	ListImages(ctx context.Context, folder, cursor string, limit int) (*domain.StoredImagePage, error)
 }
 
 type PrivateService interface {
 	Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error)
+	ChangePassword(ctx context.Context, customerID uint64, req dto.ChangePasswordRequest) error
+}
+
+type PartnerOnboardingServices interface {
+	Register(ctx context.Context, req dto.RegisterPartnerRequest) (*dto.PartnerCredentialsResponse, error)
+	Promote(ctx context.Context, partnerID uint64) (*dto.PartnerCredentialsResponse, error)
+	Authenticate(ctx context.Context, apiKey string) (partner *domain.Partner, sandbox bool, err error)
+	GetSecurity(ctx context.Context, partnerID uint64) (*dto.PartnerSecurityResponse, error)
+	UpdateSecurity(ctx context.Context, partnerID uint64, req dto.PartnerSecurityRequest) (*dto.PartnerSecurityResponse, error)
+}
+
+type DeliveryServices interface {
+	Dispatch(ctx context.Context, partnerID uint64, url, eventType string, payload any) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+	Replay(ctx context.Context, deliveryID uint64) (*domain.WebhookDelivery, error)
+}
+
+type PartnerPortalServices interface {
+	ListTransactions(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) (*domain.Paginated, error)
+	ListDeliveries(ctx context.Context, partnerID uint64, params domain.Params) (*domain.Paginated, error)
+	GetUsage(ctx context.Context, partnerID uint64, days int, now time.Time) ([]domain.APIUsage, error)
+	RegenerateAPIKey(ctx context.Context, partnerID uint64, sandbox bool) (*dto.PartnerCredentialsResponse, error)
+}
+
+type SalaryChangeServices interface {
+	RequestChange(ctx context.Context, customerID uint64, requestedSalary decimal.Decimal, payslipURL string) (*domain.SalaryChange, error)
+	ListChanges(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+	Review(ctx context.Context, changeID, reviewerID uint64, req dto.SalaryChangeReviewRequest) (*domain.SalaryChange, error)
+}
+
+type IncomeVerificationServices interface {
+	Upload(ctx context.Context, customerID uint64, fileName string, data []byte) (*domain.IncomeVerification, error)
+	ListByCustomer(ctx context.Context, customerID uint64) ([]domain.IncomeVerification, error)
+	ListVerifications(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+	Review(ctx context.Context, verificationID, reviewerID uint64, req dto.IncomeVerificationReviewRequest) (*domain.IncomeVerification, error)
+}
+
+// RiskTierServices grades customers LOW, MEDIUM or HIGH with admin-managed
+// rules on salary, credit score and delinquency. Recalculate regrades every
+// open account; rule changes take effect on its next run. SetCreditScore
+// regrades the one customer at once.
+type RiskTierServices interface {
+	Recalculate(ctx context.Context, now time.Time) (*domain.RiskTierRun, error)
+	ListRules(ctx context.Context) ([]domain.RiskTierRule, error)
+	CreateRule(ctx context.Context, updatedBy uint64, req dto.RiskTierRuleRequest) (*domain.RiskTierRule, error)
+	UpdateRule(ctx context.Context, id, updatedBy uint64, req dto.RiskTierRuleRequest) (*domain.RiskTierRule, error)
+	DeleteRule(ctx context.Context, id uint64) error
+	GetCustomerTier(ctx context.Context, customerID uint64) (*domain.CustomerRiskTier, error)
+	SetCreditScore(ctx context.Context, customerID uint64, req dto.CreditScoreRequest) (*domain.CustomerRiskTier, error)
+}
+
+type LimitRecommendationServices interface {
+	Recommend(ctx context.Context, customerID uint64) (*dto.LimitRecommendationResponse, error)
+}
+
+type ScreeningServices interface {
+	Screen(ctx context.Context, action domain.ScreeningAction, customerID *uint64, nik, fullName string) (*domain.ScreeningLog, error)
+}
+
+type BlacklistServices interface {
+	ScreeningServices
+	CreateEntry(ctx context.Context, req dto.BlacklistEntryRequest) (*domain.BlacklistEntry, error)
+	UpdateEntry(ctx context.Context, id uint64, req dto.BlacklistEntryRequest) (*domain.BlacklistEntry, error)
+	DeleteEntry(ctx context.Context, id uint64) error
+	GetEntry(ctx context.Context, id uint64) (*domain.BlacklistEntry, error)
+	ListEntries(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+	ListScreeningLogs(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+}
+
+type DuplicateServices interface {
+	FindPossibleDuplicates(ctx context.Context, customerID uint64) ([]dto.DuplicateCandidateResponse, error)
+	Resolve(ctx context.Context, customerID, duplicateID, reviewerID uint64, req dto.DuplicateResolutionRequest) (*domain.DuplicateResolution, error)
+}
+
+type RestructuringServices interface {
+	Propose(ctx context.Context, makerID uint64, req dto.RestructuringRequest) (*dto.RestructuringResponse, error)
+	Review(ctx context.Context, id, checkerID uint64, req dto.RestructuringReviewRequest) (*dto.RestructuringResponse, error)
+	GetRestructuring(ctx context.Context, id uint64) (*dto.RestructuringResponse, error)
+	ListRestructurings(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+}
+
+type CurrencyConverter interface {
+	RateToIDR(ctx context.Context, currency string, asOf time.Time) (decimal.Decimal, error)
+}
+
+type FxRateServices interface {
+	CurrencyConverter
+	UploadRates(ctx context.Context, uploadedBy uint64, req dto.FxRateUploadRequest) ([]domain.FxRate, error)
+	ListRates(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+}
+
+type ReportServices interface {
+	InterestAccrual(ctx context.Context, month string) (*dto.InterestAccrualReportResponse, error)
+	Aging(ctx context.Context, date string) (*dto.AgingReportResponse, error)
+	PartnerCommission(ctx context.Context, month string) (*dto.PartnerCommissionReportResponse, error)
+	ReferralConversion(ctx context.Context, month string) (*dto.ReferralConversionReportResponse, error)
+	RefreshAgingSnapshot(ctx context.Context, now time.Time) error
+	RegionPerformance(ctx context.Context, month string) (*dto.RegionReportResponse, error)
+}
+
+type CustomerNotifier interface {
+	Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error
+}
+
+type TemplateRenderer interface {
+	Render(name domain.NotificationTemplate, language domain.Language, data map[string]string) (subject, body string, err error)
+}
+
+type EmailServices interface {
+	CustomerNotifier
+	ProcessPending(ctx context.Context) (int, error)
+	PreviewTemplate(ctx context.Context, template domain.NotificationTemplate, req dto.EmailPreviewRequest) (*domain.RenderedEmail, error)
+}
+
+type PushServices interface {
+	CustomerNotifier
+	RegisterDevice(ctx context.Context, customerID uint64, req dto.DeviceRequest) (*domain.Device, error)
+	UnregisterDevice(ctx context.Context, customerID uint64, token string) error
+}
+
+type NotificationPreferenceServices interface {
+	GetPreferences(ctx context.Context, customerID uint64) (*domain.NotificationPreferences, error)
+	SetPreferences(ctx context.Context, customerID uint64, req dto.NotificationPreferencesRequest) (*domain.NotificationPreferences, error)
+}
+
+type PaymentReminderServices interface {
+	Run(ctx context.Context, now time.Time) (*domain.PaymentReminderRun, error)
+}
+
+type PendingExpiryServices interface {
+	Run(ctx context.Context, now time.Time) (*domain.PendingExpiryRun, error)
+}
+
+type CommunicationServices interface {
+	ListByCustomer(ctx context.Context, customerID uint64, params domain.Params) (*domain.Paginated, error)
+}
+
+type FeatureFlags interface {
+	Enabled(ctx context.Context, key string) bool
+}
+
+type FeatureFlagServices interface {
+	FeatureFlags
+	ListFlags(ctx context.Context) ([]domain.FeatureFlag, error)
+	SetFlag(ctx context.Context, key string, updatedBy uint64, req dto.FeatureFlagRequest) (*domain.FeatureFlag, error)
+	DeleteFlag(ctx context.Context, key string) error
+}
+
+type FeeScheduleServices interface {
+	ListSchedules(ctx context.Context, partnerID uint64) ([]domain.PartnerFeeSchedule, error)
+	SetSchedule(ctx context.Context, partnerID, updatedBy uint64, req dto.FeeScheduleRequest) (*domain.PartnerFeeSchedule, error)
+	DeleteSchedule(ctx context.Context, partnerID uint64, tenorMonths uint8) error
+}
+
+type PaymentServices interface {
+	HandleCallback(ctx context.Context, provider string, header http.Header, body []byte) (*domain.PaymentCallback, error)
+	RecordPayment(ctx context.Context, contractNumber string, payment *domain.Payment) error
+}
+
+type VirtualAccountServices interface {
+	ProvisionPending(ctx context.Context) (int, error)
+}
+
+type DirectDebitServices interface {
+	GetMandate(ctx context.Context, customerID uint64) (*domain.DirectDebitMandate, error)
+	SetMandate(ctx context.Context, customerID uint64, req dto.DirectDebitMandateRequest) (*domain.DirectDebitMandate, error)
+	DeleteMandate(ctx context.Context, customerID uint64) error
+	Run(ctx context.Context, now time.Time) (*domain.DirectDebitRun, error)
+}
+
+type PromotionServices interface {
+	ListPromotions(ctx context.Context) ([]domain.Promotion, error)
+	SetPromotion(ctx context.Context, code string, updatedBy uint64, req dto.PromotionRequest) (*domain.Promotion, error)
+	DeactivatePromotion(ctx context.Context, code string) error
+}
+
+type ReferralServices interface {
+	GetMyCode(ctx context.Context, customerID uint64) (*domain.ReferralCode, error)
+	Resolve(ctx context.Context, code string) (*domain.ReferralCode, error)
+	Record(ctx context.Context, code *domain.ReferralCode, referee *domain.Customer) (*domain.Referral, error)
+}
+
+type OTPServices interface {
+	Request(ctx context.Context, customerID uint64, req dto.OTPRequest) (*domain.OTPChallenge, error)
+	Verify(ctx context.Context, req dto.OTPVerifyRequest) (*domain.OTPVerification, error)
+	Consume(ctx context.Context, token string, purpose domain.OTPPurpose, customerID uint64) (*domain.OTPVerification, error)
+}
+
+type ImpersonationServices interface {
+	Start(ctx context.Context, adminID, customerID uint64, req dto.ImpersonationRequest) (*dto.ImpersonationResponse, error)
+	End(ctx context.Context, sessionID uint64) error
+	Authorize(ctx context.Context, sessionID uint64) error
+	RecordRequest(ctx context.Context, request domain.ImpersonationRequest) error
+	ListRequests(ctx context.Context, sessionID uint64) ([]domain.ImpersonationRequest, error)
+}
+
+type RequestSignatureServices interface {
+	Verify(ctx context.Context, partner *domain.Partner, req domain.SignedRequest) error
+}
+
+type MaintenanceServices interface {
+	Check(ctx context.Context, scope domain.GateScope, now time.Time) (*domain.GateClosure, error)
+	ListModes(ctx context.Context) ([]domain.MaintenanceMode, error)
+	SetMode(ctx context.Context, scope domain.GateScope, updatedBy uint64, req dto.MaintenanceRequest) (*domain.MaintenanceMode, error)
+	ClearMode(ctx context.Context, scope domain.GateScope) error
+}
+
+type PartnerQuotaServices interface {
+	GetQuota(ctx context.Context, partnerID uint64, now time.Time) (*domain.PartnerQuota, *domain.QuotaUsage, error)
+	SetQuota(ctx context.Context, partnerID, updatedBy uint64, req dto.PartnerQuotaRequest) (*domain.PartnerQuota, error)
+	DeleteQuota(ctx context.Context, partnerID uint64) error
+	Reserve(ctx context.Context, partnerID uint64, volume decimal.Decimal, now time.Time) (*domain.QuotaReservation, error)
+	Release(ctx context.Context, reservation *domain.QuotaReservation) error
+}
+
+type TransactionBatchServices interface {
+	Submit(ctx context.Context, owner domain.BatchOwner, req dto.CreateTransactionBatchRequest) (*domain.TransactionBatch, error)
+	GetBatch(ctx context.Context, id uint64, owner domain.BatchOwner) (*domain.TransactionBatch, error)
+	ProcessPending(ctx context.Context) (int, error)
+}
+
+type TenorServices interface {
+	ListTenors(ctx context.Context) ([]domain.Tenor, error)
+	CreateTenor(ctx context.Context, req dto.CreateTenorRequest) (*domain.Tenor, error)
+	UpdateTenor(ctx context.Context, id uint, req dto.UpdateTenorRequest) (*domain.Tenor, error)
+	DeactivateTenor(ctx context.Context, id uint) (*domain.Tenor, error)
+	DeleteTenor(ctx context.Context, id uint) error
+	SeedTenors(ctx context.Context, tenors []dto.CreateTenorRequest) error
+}
+
+// ReferenceDataServices writes the reference data read by refdata.Load.
+// Apply is idempotent: rows are matched on their natural key, created when
+// missing and updated otherwise. Rows the data does not mention are left
+// alone.
+type ReferenceDataServices interface {
+	Apply(ctx context.Context, data *refdata.Set) (*domain.ReferenceDataResult, error)
+}
+
+type StatementServices interface {
+	GetStatement(ctx context.Context, customerID uint64, contractNumber string) (*domain.TransactionStatement, []byte, error)
+	ProcessPending(ctx context.Context) (int, error)
+	SendMonthlyStatements(ctx context.Context, now time.Time) (*domain.MonthlyStatementRun, error)
+	SetMonthlyStatementOptOut(ctx context.Context, customerID uint64, optOut bool) error
+}
+
+type CustomerNoteServices interface {
+	ListNotes(ctx context.Context, customerID uint64) ([]domain.CustomerNote, error)
+	CreateNote(ctx context.Context, customerID, authorID uint64, req dto.CustomerNoteRequest) (*domain.CustomerNote, error)
+	UpdateNote(ctx context.Context, customerID, noteID, updatedBy uint64, req dto.CustomerNoteRequest) (*domain.CustomerNote, error)
+}
+
+type TransactionAttachmentServices interface {
+	UploadAttachment(ctx context.Context, owner domain.BatchOwner, transactionID uint64, req dto.TransactionAttachmentRequest, fileName string, data []byte) (*domain.TransactionAttachment, error)
+	ListAttachments(ctx context.Context, owner domain.BatchOwner, transactionID uint64) ([]domain.TransactionAttachment, error)
+	ListAllAttachments(ctx context.Context, transactionID uint64) ([]domain.TransactionAttachment, error)
+	DeleteAttachment(ctx context.Context, transactionID, attachmentID, adminID uint64) error
+}
+
+type RegionServices interface {
+	ListRegions(ctx context.Context) ([]domain.Region, error)
+	SetRegion(ctx context.Context, code string, req dto.RegionRequest) (*domain.Region, error)
+	SetPartnerRegion(ctx context.Context, partnerID uint64, req dto.PartnerRegionRequest) (*domain.Partner, error)
+}
+
+type ExposureServices interface {
+	Reconcile(ctx context.Context) (*domain.ExposureReconciliation, error)
+}
+
+type CustomerTimelineServices interface {
+	GetTimeline(ctx context.Context, customerID uint64, params domain.Params) (*domain.Paginated, error)
+}
+
+// ContractLookupServices finds contracts by their number for support staff.
+// Search pages through the contracts whose number starts with prefix, with
+// Data holding []dto.ContractLookupResponse.
+type ContractLookupServices interface {
+	FindByContractNumber(ctx context.Context, contractNumber string) (*dto.ContractLookupResponse, error)
+	Search(ctx context.Context, prefix string, params domain.Params) (*domain.Paginated, error)
+}
+
+// ContractImportServices backfills contracts in bulk. A batch is stored
+// entirely or not at all.
+type ContractImportServices interface {
+	Import(ctx context.Context, req dto.ContractImportRequest) (*dto.ContractImportResponse, error)
+}
+
+// ExportServices writes admin exports as CSV straight to w while the rows are
+// read from the database, and returns how many rows were written. When w has
+// a Flush method it is flushed along the way, so a slow reader slows the
+// database cursor down instead of the export piling up in memory.
+type ExportServices interface {
+	ExportCustomers(ctx context.Context, w io.Writer, status domain.VerificationStatus) (int, error)
+	ExportTransactions(ctx context.Context, w io.Writer, from, to time.Time) (int, error)
+}
+
+// CustomerRestrictionServices suspends customers and freezes their limits.
+// ExpireDue lifts restrictions whose expiry has passed and returns how many
+// it lifted.
+type CustomerRestrictionServices interface {
+	Suspend(ctx context.Context, customerID, adminID uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error)
+	Unsuspend(ctx context.Context, customerID, adminID uint64) error
+	FreezeLimit(ctx context.Context, customerID uint64, tenorMonths uint8, adminID uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error)
+	UnfreezeLimit(ctx context.Context, customerID uint64, tenorMonths uint8, adminID uint64) error
+	ListRestrictions(ctx context.Context, customerID uint64) ([]domain.CustomerRestriction, error)
+	ExpireDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// DormancyServices flags customers inactive for too long as dormant and lets
+// them reactivate with a lightweight re-KYC: their birth date and an OTP sent
+// to a contact on file.
+type DormancyServices interface {
+	Run(ctx context.Context, now time.Time) (*domain.DormancyRun, error)
+	Reactivate(ctx context.Context, customerID uint64, req dto.ReactivationRequest) error
+	Stats(ctx context.Context, days int, now time.Time) (*domain.DormancyStats, error)
+}
+
+// AccountClosureServices lets customers close their own account once nothing
+// is owed on it. The account is locked right away, tokens already issued
+// stop working, and its personal data is anonymized after a cooling-off
+// period.
+type AccountClosureServices interface {
+	Close(ctx context.Context, customerID uint64, req dto.CloseAccountRequest) (*domain.AccountClosure, error)
+	Authorize(ctx context.Context, customerID uint64) error
+	AnonymizeDue(ctx context.Context, now time.Time) (*domain.AccountClosureRun, error)
+}
+
+// CalendarServices publishes the upcoming installments of a contract as an
+// iCalendar feed. Calendar apps fetch the feed without a login, using a
+// token the customer creates; creating a new one revokes the old link.
+type CalendarServices interface {
+	CreateToken(ctx context.Context, customerID uint64, contractNumber string) (string, error)
+	RevokeToken(ctx context.Context, customerID uint64, contractNumber string) error
+	Feed(ctx context.Context, contractNumber, token string) ([]byte, error)
+}
+
+// DueDateRules hands out the rules that move installment due dates off
+// weekends and holidays. When the calendar cannot be loaded the rules last
+// loaded are kept, so a database hiccup does not move due dates.
+type DueDateRules interface {
+	Rules(ctx context.Context) installment.Rules
+}
+
+// DueDateServices manages the holiday calendar and the due date policy.
+// Dates are YYYY-MM-DD calendar days.
+type DueDateServices interface {
+	DueDateRules
+	ListHolidays(ctx context.Context, year int) ([]domain.Holiday, error)
+	SetHoliday(ctx context.Context, date string, updatedBy uint64, req dto.HolidayRequest) (*domain.Holiday, error)
+	DeleteHoliday(ctx context.Context, date string) error
+	GetPolicy(ctx context.Context) (*domain.DueDatePolicy, error)
+	SetPolicy(ctx context.Context, updatedBy uint64, req dto.DueDatePolicyRequest) (*domain.DueDatePolicy, error)
+}
+
+// AMLServices screens new transactions against the large-amount and
+// rapid-sequence rules and manages the cases they open. Export returns the
+// cases opened between two YYYY-MM-DD dates, both inclusive, for filing
+// with the FIU.
+type AMLServices interface {
+	Screen(ctx context.Context, now time.Time) (*domain.AMLScreeningRun, error)
+	ListCases(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+	GetCase(ctx context.Context, id uint64) (*domain.AMLCase, error)
+	UpdateStatus(ctx context.Context, id, adminID uint64, req dto.AMLCaseStatusRequest) (*domain.AMLCase, error)
+	Export(ctx context.Context, from, to string, status domain.AMLCaseStatus) ([]domain.AMLCase, error)
+}
+
+// CollectionServices opens a collection case for every contract that falls
+// MinDaysPastDue days or more behind and tracks the work on it. Collectors
+// are admins; logging the first activity on an open case moves it to
+// IN_PROGRESS.
+type CollectionServices interface {
+	OpenCases(ctx context.Context, now time.Time) (*domain.CollectionRun, error)
+	ListCases(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+	GetCase(ctx context.Context, id uint64) (*domain.CollectionCase, error)
+	Assign(ctx context.Context, id uint64, req dto.CollectionAssignRequest) (*domain.CollectionCase, error)
+	UpdateStatus(ctx context.Context, id uint64, req dto.CollectionCaseStatusRequest) (*domain.CollectionCase, error)
+	AddActivity(ctx context.Context, id, adminID uint64, req dto.CollectionActivityRequest) (*domain.CollectionActivity, error)
+	ListActivities(ctx context.Context, id uint64) ([]domain.CollectionActivity, error)
+	Dashboard(ctx context.Context, now time.Time) (*domain.CollectionDashboard, error)
+}
+
+// WriteOffServices takes contracts that are MinDaysPastDue days or more
+// behind off the books, maker-checker. Approval marks the contract
+// WRITTEN_OFF, suspends the customer and posts the unpaid balance from loan
+// receivable to bad debt expense. Every recovery is posted from cash to bad
+// debt recovery. Report lists every month from from to to, both being the
+// first day of the month.
+type WriteOffServices interface {
+	Propose(ctx context.Context, makerID uint64, req dto.WriteOffRequest) (*domain.WriteOff, error)
+	Review(ctx context.Context, id, checkerID uint64, req dto.WriteOffReviewRequest) (*domain.WriteOff, error)
+	GetWriteOff(ctx context.Context, id uint64) (*domain.WriteOff, error)
+	ListWriteOffs(ctx context.Context, params domain.Params) (*domain.Paginated, error)
+	RecordRecovery(ctx context.Context, id, adminID uint64, req dto.WriteOffRecoveryRequest) (*domain.WriteOffRecovery, error)
+	ListRecoveries(ctx context.Context, id uint64) ([]domain.WriteOffRecovery, error)
+	Ledger(ctx context.Context, id uint64) ([]domain.LedgerEntry, error)
+	Report(ctx context.Context, from, to time.Time) ([]domain.WriteOffPeriod, error)
+}
+
+// ConcentrationServices caps the share of active principal one asset
+// category or region may hold. Evaluate is the periodic job that records
+// and alerts on breaches, Check is called when a transaction is created
+// and rejects it only for enforced limits. Neither applies until the
+// portfolio reaches the configured minimum.
+type ConcentrationServices interface {
+	ListLimits(ctx context.Context) ([]domain.ConcentrationLimit, error)
+	CreateLimit(ctx context.Context, updatedBy uint64, req dto.ConcentrationLimitRequest) (*domain.ConcentrationLimit, error)
+	UpdateLimit(ctx context.Context, id, updatedBy uint64, req dto.ConcentrationLimitUpdateRequest) (*domain.ConcentrationLimit, error)
+	DeleteLimit(ctx context.Context, id uint64) error
+	Report(ctx context.Context, now time.Time) (*domain.ConcentrationReport, error)
+	Evaluate(ctx context.Context, now time.Time) (*domain.ConcentrationRun, error)
+	Check(ctx context.Context, assetCategory, regionCode string, principal decimal.Decimal) error
+}
+
+// LogLevelServices reads and changes the log level of each module at
+// runtime. SetLevel returns the level it replaced.
+type LogLevelServices interface {
+	Levels() map[string]string
+	SetLevel(module, level string) (string, error)
+}
+
+// PartnerDebugServices stores the partner API traffic of a key while its
+// debug mode is on. SetMode returns when recording ends, or nil once it is
+// turned off. Records are sanitized before they are stored.
+type PartnerDebugServices interface {
+	SetMode(ctx context.Context, partnerID uint64, sandbox, enabled bool, now time.Time) (*time.Time, error)
+	Record(ctx context.Context, record *domain.PartnerDebugRecord) error
+	ListRecords(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) (*domain.Paginated, error)
+	GetRecord(ctx context.Context, partnerID uint64, sandbox bool, id uint64) (*domain.PartnerDebugRecord, error)
+	Purge(ctx context.Context, now time.Time) (int64, error)
+}
+
+// MediaServices deletes uploaded images no record refers to any more, such
+// as those of a registration that failed after its uploads succeeded.
+type MediaServices interface {
+	CleanupOrphans(ctx context.Context, now time.Time) (*domain.MediaCleanupRun, error)
+}
+
+// MobileServices backs the mobile app's own endpoints, which combine what
+// the /me endpoints return separately into one compact payload per screen.
+type MobileServices interface {
+	Home(ctx context.Context, customerID uint64, now time.Time) (*dto.MobileHomeResponse, error)
+}
+
+// DashboardServices feeds the realtime admin dashboard. Every instance runs
+// Refresh on a timer and fans the snapshot out to its own subscribers; the
+// snapshot is read from the customer events table, so instances stay in step
+// without a message broker.
+type DashboardServices interface {
+	// Refresh reads the registrations since the previous refresh and the
+	// current counters of the tenant in ctx and delivers them to every
+	// subscriber of that tenant. It does nothing and returns nil while nobody
+	// is subscribed.
+	Refresh(ctx context.Context, now time.Time) (*domain.DashboardSnapshot, error)
+	// Subscribe returns the channel snapshots of the tenant in ctx are
+	// delivered on, starting with the latest counters when there are any, and
+	// the function that ends the subscription. The channel is closed when the
+	// subscriber falls too far behind or the service shuts down.
+	Subscribe(ctx context.Context) (<-chan domain.DashboardSnapshot, func())
 }
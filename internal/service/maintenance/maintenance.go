@@ -0,0 +1,238 @@
+package maintenancesrv
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/businesshours"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config holds the gates set at deploy time. Enabled closes every scope and
+// can only be lifted by redeploying; Hours limits a scope to its daily window.
+type Config struct {
+	Enabled bool
+	Hours   map[domain.GateScope]*businesshours.Hours
+}
+
+const configMaintenanceMessage = "The service is under maintenance"
+
+type maintenanceService struct {
+	maintenanceRepository repository.MaintenanceRepository
+	cfg                   Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Check implements MaintenanceServices.
+func (s *maintenanceService) Check(ctx context.Context, scope domain.GateScope, now time.Time) (*domain.GateClosure, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CheckGate")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("maintenance.scope", string(scope)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_gate"), attribute.String("service", "maintenance")))
+
+	if s.cfg.Enabled {
+		s.recordSuccess(ctx, span, start, "check_gate", zap.String("scope", string(scope)), zap.String("reason", domain.GateReasonMaintenance))
+		return &domain.GateClosure{Scope: scope, Reason: domain.GateReasonMaintenance, Message: configMaintenanceMessage}, nil
+	}
+
+	mode, err := s.maintenanceRepository.Find(ctx, scope)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "check_gate", "repository_error", fmt.Errorf("failed to find maintenance mode: %w", err))
+	}
+	if mode != nil && mode.Active(now) {
+		closure := &domain.GateClosure{Scope: scope, Reason: domain.GateReasonMaintenance, Message: mode.Message}
+		if mode.Until != nil {
+			closure.RetryAt = *mode.Until
+		}
+		s.recordSuccess(ctx, span, start, "check_gate", zap.String("scope", string(scope)), zap.String("reason", closure.Reason))
+		return closure, nil
+	}
+
+	if hours := s.cfg.Hours[scope]; !hours.Open(now) {
+		s.recordSuccess(ctx, span, start, "check_gate", zap.String("scope", string(scope)), zap.String("reason", domain.GateReasonBusinessHours))
+		return &domain.GateClosure{
+			Scope:   scope,
+			Reason:  domain.GateReasonBusinessHours,
+			Message: "This operation is only available during business hours",
+			RetryAt: hours.NextOpen(now),
+		}, nil
+	}
+
+	span.SetStatus(codes.Ok, "Gate open")
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", "check_gate"), attribute.String("service", "maintenance"), attribute.String("status", "success")))
+
+	return nil, nil
+}
+
+// ListModes implements MaintenanceServices.
+func (s *maintenanceService) ListModes(ctx context.Context) ([]domain.MaintenanceMode, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListMaintenanceModes")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_maintenance_modes"), attribute.String("service", "maintenance")))
+
+	modes, err := s.maintenanceRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_maintenance_modes", "repository_error", fmt.Errorf("failed to list maintenance modes: %w", err))
+	}
+
+	// Mode yang sudah lewat Until tidak lagi menutup scope, jadi tidak ditampilkan
+	now := time.Now()
+	modes = slices.DeleteFunc(modes, func(mode domain.MaintenanceMode) bool { return !mode.Active(now) })
+
+	s.recordSuccess(ctx, span, start, "list_maintenance_modes", zap.Int("count", len(modes)))
+
+	return modes, nil
+}
+
+// SetMode implements MaintenanceServices.
+func (s *maintenanceService) SetMode(ctx context.Context, scope domain.GateScope, updatedBy uint64, req dto.MaintenanceRequest) (*domain.MaintenanceMode, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetMaintenanceMode")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("maintenance.scope", string(scope)),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_maintenance_mode"), attribute.String("service", "maintenance")))
+
+	if !slices.Contains(domain.GateScopes, scope) {
+		return nil, s.recordError(ctx, span, start, "set_maintenance_mode", "unknown_scope", common.ErrUnknownGateScope)
+	}
+
+	now := time.Now()
+	if req.Until != nil && !req.Until.After(now) {
+		return nil, s.recordError(ctx, span, start, "set_maintenance_mode", "invalid_until", common.ErrInvalidMaintenanceUntil)
+	}
+
+	mode := &domain.MaintenanceMode{
+		Scope:     scope,
+		Message:   req.Message,
+		Until:     req.Until,
+		UpdatedBy: updatedBy,
+		UpdatedAt: now,
+	}
+	if err := s.maintenanceRepository.Save(ctx, mode); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_maintenance_mode", "repository_error", fmt.Errorf("failed to save maintenance mode: %w", err))
+	}
+
+	fields := []zap.Field{zap.String("scope", string(scope)), zap.Uint64("updated_by", updatedBy)}
+	if req.Until != nil {
+		fields = append(fields, zap.Time("until", *req.Until))
+	}
+	s.recordSuccess(ctx, span, start, "set_maintenance_mode", fields...)
+
+	return mode, nil
+}
+
+// ClearMode implements MaintenanceServices.
+func (s *maintenanceService) ClearMode(ctx context.Context, scope domain.GateScope) error {
+	ctx, span := s.tracer.Start(ctx, "service.ClearMaintenanceMode")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("maintenance.scope", string(scope)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "clear_maintenance_mode"), attribute.String("service", "maintenance")))
+
+	deleted, err := s.maintenanceRepository.Delete(ctx, scope)
+	if err != nil {
+		return s.recordError(ctx, span, start, "clear_maintenance_mode", "repository_error", fmt.Errorf("failed to clear maintenance mode: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "clear_maintenance_mode", "not_found", common.ErrMaintenanceNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "clear_maintenance_mode", zap.String("scope", string(scope)))
+
+	return nil
+}
+
+func (s *maintenanceService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Maintenance operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "maintenance"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "maintenance"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *maintenanceService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "maintenance"), attribute.String("status", "success")))
+
+	s.log.Info("Maintenance operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewMaintenanceService(
+	maintenanceRepository repository.MaintenanceRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.MaintenanceServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &maintenanceService{
+		maintenanceRepository: maintenanceRepository,
+		cfg:                   cfg,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+	}
+}
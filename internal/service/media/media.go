@@ -0,0 +1,208 @@
+package mediasrv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config decides which images the cleanup job may delete. Images in Folder
+// older than GracePeriod that no record refers to are deleted, BatchSize
+// images are checked per run.
+type Config struct {
+	Folder      string
+	GracePeriod time.Duration
+	BatchSize   int
+}
+
+type mediaService struct {
+	cloudinaryService service.CloudinaryService
+	mediaRepository   repository.MediaRepository
+	cfg               Config
+
+	// cursors remembers per tenant where the listing stopped, so each run
+	// checks the next batch instead of the same first page.
+	mu      sync.Mutex
+	cursors map[string]string
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	deletedCount      metric.Int64Counter
+}
+
+// CleanupOrphans implements MediaServices.
+func (s *mediaService) CleanupOrphans(ctx context.Context, now time.Time) (*domain.MediaCleanupRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CleanupOrphanedMedia")
+	defer span.End()
+
+	start := time.Now()
+	cutoff := now.Add(-s.cfg.GracePeriod)
+	span.SetAttributes(
+		attribute.String("media.folder", s.cfg.Folder),
+		attribute.String("media.cutoff", cutoff.Format(time.RFC3339)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "cleanup_orphans"), attribute.String("service", "media")))
+
+	tenant := tenancy.ID(ctx)
+	s.mu.Lock()
+	cursor := s.cursors[tenant]
+	s.mu.Unlock()
+
+	page, err := s.cloudinaryService.ListImages(ctx, s.cfg.Folder, cursor, s.cfg.BatchSize)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "cleanup_orphans", "storage_error", fmt.Errorf("failed to list images: %w", err))
+	}
+
+	// Gambar yang baru diunggah bisa jadi milik registrasi yang masih berjalan
+	var candidates []string
+	for _, image := range page.Images {
+		if image.CreatedAt.Before(cutoff) {
+			candidates = append(candidates, image.URL)
+		}
+	}
+
+	referenced, err := s.mediaRepository.FindReferenced(ctx, candidates)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "cleanup_orphans", "repository_error", fmt.Errorf("failed to find referenced images: %w", err))
+	}
+
+	run := &domain.MediaCleanupRun{Scanned: len(page.Images)}
+	for _, url := range candidates {
+		if referenced[url] {
+			continue
+		}
+		if err := s.cloudinaryService.DeleteImage(ctx, url); err != nil {
+			run.Failed++
+			s.log.Warn("Failed to delete orphaned image",
+				zap.String("url", url),
+				zap.Error(err),
+			)
+			continue
+		}
+		run.Deleted++
+	}
+
+	// Cursor kosong berarti listing selesai, run berikutnya mulai dari awal
+	s.mu.Lock()
+	s.cursors[tenant] = page.NextCursor
+	s.mu.Unlock()
+
+	s.deletedCount.Add(ctx, int64(run.Deleted), metric.WithAttributes(attribute.String("service", "media")))
+	span.SetAttributes(
+		attribute.Int("media.scanned", run.Scanned),
+		attribute.Int("media.deleted", run.Deleted),
+		attribute.Int("media.failed", run.Failed),
+	)
+	s.recordSuccess(ctx, span, start, "cleanup_orphans",
+		zap.Int("scanned", run.Scanned),
+		zap.Int("deleted", run.Deleted),
+		zap.Int("failed", run.Failed),
+	)
+
+	return run, nil
+}
+
+func (s *mediaService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Media operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "media"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "media"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *mediaService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "media"), attribute.String("status", "success")))
+
+	s.log.Info("Media operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewMediaService(
+	cloudinaryService service.CloudinaryService,
+	mediaRepository repository.MediaRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.MediaServices {
+	if cfg.Folder == "" {
+		cfg.Folder = "multifinance"
+	}
+	if cfg.GracePeriod <= 0 {
+		cfg.GracePeriod = 24 * time.Hour
+	}
+	// Admin API Cloudinary membatasi satu halaman maksimal 500 aset
+	if cfg.BatchSize <= 0 || cfg.BatchSize > 500 {
+		cfg.BatchSize = 500
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	deletedCount, _ := meter.Int64Counter(
+		"service.media.orphans_deleted",
+		metric.WithDescription("Number of orphaned images deleted"),
+		metric.WithUnit("{image}"),
+	)
+
+	return &mediaService{
+		cloudinaryService: cloudinaryService,
+		mediaRepository:   mediaRepository,
+		cfg:               cfg,
+		cursors:           make(map[string]string),
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+		deletedCount:      deletedCount,
+	}
+}
@@ -0,0 +1,215 @@
+package mobilesrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"golang.org/x/sync/errgroup"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type mobileService struct {
+	profileService        service.ProfileServices
+	transactionRepository repository.TransactionRepository
+	tenorRepository       repository.TenorRepository
+	dueDateRules          service.DueDateRules
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Home implements MobileServices. The profile, the limits and the active
+// contracts are read concurrently so the screen costs one round trip for the
+// app and roughly the slowest lookup for the server.
+func (s *mobileService) Home(ctx context.Context, customerID uint64, now time.Time) (*dto.MobileHomeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.MobileHome")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "mobile_home"), attribute.String("service", "mobile")))
+
+	var (
+		customer  *domain.Customer
+		limits    []dto.LimitDetailResponse
+		contracts []domain.Transaction
+	)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() (err error) {
+		customer, err = s.profileService.GetMyProfile(groupCtx, customerID)
+		return err
+	})
+	group.Go(func() (err error) {
+		limits, err = s.profileService.GetMyLimits(groupCtx, customerID)
+		return err
+	})
+	group.Go(func() (err error) {
+		contracts, err = s.transactionRepository.FindActiveByCustomerID(groupCtx, customerID)
+		if err != nil {
+			return fmt.Errorf("failed to get active contracts: %w", err)
+		}
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		return nil, s.recordError(ctx, span, start, "mobile_home", "lookup_error", err)
+	}
+
+	home := &dto.MobileHomeResponse{
+		FullName:           customer.FullName,
+		VerificationStatus: string(customer.VerificationStatus),
+		Limits:             make([]dto.MobileLimitResponse, len(limits)),
+		ActiveContracts:    len(contracts),
+	}
+	for i, limit := range limits {
+		home.Limits[i] = dto.MobileLimitResponse{
+			TenorMonths:    limit.TenorMonths,
+			Currency:       limit.Currency,
+			LimitAmount:    limit.LimitAmount,
+			RemainingLimit: limit.RemainingLimit,
+		}
+	}
+
+	if len(contracts) > 0 {
+		tenors, err := s.tenorRepository.FindAll(ctx)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "mobile_home", "repository_error", fmt.Errorf("failed to get tenors: %w", err))
+		}
+		months := make(map[uint]int, len(tenors))
+		for _, tenor := range tenors {
+			months[tenor.ID] = int(tenor.DurationMonths)
+		}
+
+		rules := s.dueDateRules.Rules(ctx)
+		for i := range contracts {
+			next := nextInstallment(&contracts[i], months[contracts[i].TenorID], rules, now)
+			if next != nil && (home.NextInstallment == nil || next.DueDate.Before(home.NextInstallment.DueDate)) {
+				home.NextInstallment = next
+			}
+		}
+	}
+
+	s.recordSuccess(ctx, span, start, "mobile_home",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("limits", len(home.Limits)),
+		zap.Int("active_contracts", home.ActiveContracts),
+	)
+
+	return home, nil
+}
+
+// nextInstallment returns the first unpaid installment of the contract, or
+// nil when every installment is paid. Installments are counted paid the same
+// way as in the transaction detail.
+func nextInstallment(transaction *domain.Transaction, months int, rules installment.Rules, now time.Time) *dto.MobileInstallmentResponse {
+	if months == 0 {
+		return nil
+	}
+
+	elapsed := min(rules.Elapsed(transaction.TransactionDate, now), months)
+	paid := elapsed
+	if transaction.PaidInstallments != nil {
+		paid = min(max(*transaction.PaidInstallments, 0), months)
+	}
+	if paid >= months {
+		return nil
+	}
+
+	// Jadwal dibuat untuk seluruh tenor supaya pembulatannya sama dengan tagihan
+	inst := rules.Schedule(transaction.TransactionDate, 1, months, transaction.TotalInstallmentAmount)[paid]
+	return &dto.MobileInstallmentResponse{
+		ContractNumber: transaction.ContractNumber,
+		Sequence:       inst.Sequence,
+		DueDate:        inst.DueDate,
+		Amount:         inst.Amount,
+		Currency:       currency.Normalize(transaction.Currency),
+		Overdue:        inst.Sequence <= elapsed,
+	}
+}
+
+func (s *mobileService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Mobile operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "mobile"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "mobile"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *mobileService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "mobile"), attribute.String("status", "success")))
+
+	s.log.Info("Mobile operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewMobileService(
+	profileService service.ProfileServices,
+	transactionRepository repository.TransactionRepository,
+	tenorRepository repository.TenorRepository,
+	dueDateRules service.DueDateRules,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.MobileServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &mobileService{
+		profileService:        profileService,
+		transactionRepository: transactionRepository,
+		tenorRepository:       tenorRepository,
+		dueDateRules:          dueDateRules,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+	}
+}
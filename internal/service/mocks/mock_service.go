@@ -0,0 +1,4860 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	multipart "mime/multipart"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/fazamuttaqien/multifinance/internal/domain"
+	dto "github.com/fazamuttaqien/multifinance/internal/dto"
+	installment "github.com/fazamuttaqien/multifinance/pkg/installment"
+	refdata "github.com/fazamuttaqien/multifinance/pkg/refdata"
+	decimal "github.com/shopspring/decimal"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMedia is a mock of Media interface.
+type MockMedia struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaMockRecorder is the mock recorder for MockMedia.
+type MockMediaMockRecorder struct {
+	mock *MockMedia
+}
+
+// NewMockMedia creates a new mock instance.
+func NewMockMedia(ctrl *gomock.Controller) *MockMedia {
+	mock := &MockMedia{ctrl: ctrl}
+	mock.recorder = &MockMediaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedia) EXPECT() *MockMediaMockRecorder {
+	return m.recorder
+}
+
+// Upload mocks base method.
+func (m *MockMedia) Upload(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, file)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockMediaMockRecorder) Upload(ctx, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockMedia)(nil).Upload), ctx, file)
+}
+
+// MockProfileServices is a mock of ProfileServices interface.
+type MockProfileServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileServicesMockRecorder is the mock recorder for MockProfileServices.
+type MockProfileServicesMockRecorder struct {
+	mock *MockProfileServices
+}
+
+// NewMockProfileServices creates a new mock instance.
+func NewMockProfileServices(ctrl *gomock.Controller) *MockProfileServices {
+	mock := &MockProfileServices{ctrl: ctrl}
+	mock.recorder = &MockProfileServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileServices) EXPECT() *MockProfileServicesMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProfileServices) Create(ctx context.Context, req *domain.Customer, checks dto.RegistrationChecks) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req, checks)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProfileServicesMockRecorder) Create(ctx, req, checks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProfileServices)(nil).Create), ctx, req, checks)
+}
+
+// GetMyLimits mocks base method.
+func (m *MockProfileServices) GetMyLimits(ctx context.Context, customerID uint64) ([]dto.LimitDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyLimits", ctx, customerID)
+	ret0, _ := ret[0].([]dto.LimitDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyLimits indicates an expected call of GetMyLimits.
+func (mr *MockProfileServicesMockRecorder) GetMyLimits(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyLimits", reflect.TypeOf((*MockProfileServices)(nil).GetMyLimits), ctx, customerID)
+}
+
+// GetMyProfile mocks base method.
+func (m *MockProfileServices) GetMyProfile(ctx context.Context, customerID uint64) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyProfile", ctx, customerID)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyProfile indicates an expected call of GetMyProfile.
+func (mr *MockProfileServicesMockRecorder) GetMyProfile(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyProfile", reflect.TypeOf((*MockProfileServices)(nil).GetMyProfile), ctx, customerID)
+}
+
+// GetMyTransactionDetail mocks base method.
+func (m *MockProfileServices) GetMyTransactionDetail(ctx context.Context, customerID uint64, contractNumber string) (*dto.TransactionDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyTransactionDetail", ctx, customerID, contractNumber)
+	ret0, _ := ret[0].(*dto.TransactionDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyTransactionDetail indicates an expected call of GetMyTransactionDetail.
+func (mr *MockProfileServicesMockRecorder) GetMyTransactionDetail(ctx, customerID, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyTransactionDetail", reflect.TypeOf((*MockProfileServices)(nil).GetMyTransactionDetail), ctx, customerID, contractNumber)
+}
+
+// GetMyTransactions mocks base method.
+func (m *MockProfileServices) GetMyTransactions(ctx context.Context, customerID uint64, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyTransactions", ctx, customerID, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyTransactions indicates an expected call of GetMyTransactions.
+func (mr *MockProfileServicesMockRecorder) GetMyTransactions(ctx, customerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyTransactions", reflect.TypeOf((*MockProfileServices)(nil).GetMyTransactions), ctx, customerID, params)
+}
+
+// Update mocks base method.
+func (m *MockProfileServices) Update(ctx context.Context, customerID uint64, req domain.Customer, checks dto.ContactChecks) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, customerID, req, checks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProfileServicesMockRecorder) Update(ctx, customerID, req, checks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProfileServices)(nil).Update), ctx, customerID, req, checks)
+}
+
+// MockPartnerServices is a mock of PartnerServices interface.
+type MockPartnerServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerServicesMockRecorder is the mock recorder for MockPartnerServices.
+type MockPartnerServicesMockRecorder struct {
+	mock *MockPartnerServices
+}
+
+// NewMockPartnerServices creates a new mock instance.
+func NewMockPartnerServices(ctrl *gomock.Controller) *MockPartnerServices {
+	mock := &MockPartnerServices{ctrl: ctrl}
+	mock.recorder = &MockPartnerServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerServices) EXPECT() *MockPartnerServicesMockRecorder {
+	return m.recorder
+}
+
+// CheckLimit mocks base method.
+func (m *MockPartnerServices) CheckLimit(ctx context.Context, req dto.CheckLimitRequest) (*dto.CheckLimitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLimit", ctx, req)
+	ret0, _ := ret[0].(*dto.CheckLimitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLimit indicates an expected call of CheckLimit.
+func (mr *MockPartnerServicesMockRecorder) CheckLimit(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLimit", reflect.TypeOf((*MockPartnerServices)(nil).CheckLimit), ctx, req)
+}
+
+// CreateTransaction mocks base method.
+func (m *MockPartnerServices) CreateTransaction(ctx context.Context, req dto.CreateTransactionRequest) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransaction", ctx, req)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransaction indicates an expected call of CreateTransaction.
+func (mr *MockPartnerServicesMockRecorder) CreateTransaction(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockPartnerServices)(nil).CreateTransaction), ctx, req)
+}
+
+// MockAdminServices is a mock of AdminServices interface.
+type MockAdminServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminServicesMockRecorder is the mock recorder for MockAdminServices.
+type MockAdminServicesMockRecorder struct {
+	mock *MockAdminServices
+}
+
+// NewMockAdminServices creates a new mock instance.
+func NewMockAdminServices(ctrl *gomock.Controller) *MockAdminServices {
+	mock := &MockAdminServices{ctrl: ctrl}
+	mock.recorder = &MockAdminServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminServices) EXPECT() *MockAdminServicesMockRecorder {
+	return m.recorder
+}
+
+// GetCustomerByID mocks base method.
+func (m *MockAdminServices) GetCustomerByID(ctx context.Context, customerID uint64) (*domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerByID", ctx, customerID)
+	ret0, _ := ret[0].(*domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerByID indicates an expected call of GetCustomerByID.
+func (mr *MockAdminServicesMockRecorder) GetCustomerByID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockAdminServices)(nil).GetCustomerByID), ctx, customerID)
+}
+
+// GetLimitHistory mocks base method.
+func (m *MockAdminServices) GetLimitHistory(ctx context.Context, customerID uint64) ([]domain.LimitChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitHistory", ctx, customerID)
+	ret0, _ := ret[0].([]domain.LimitChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimitHistory indicates an expected call of GetLimitHistory.
+func (mr *MockAdminServicesMockRecorder) GetLimitHistory(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitHistory", reflect.TypeOf((*MockAdminServices)(nil).GetLimitHistory), ctx, customerID)
+}
+
+// GetLimitsAt mocks base method.
+func (m *MockAdminServices) GetLimitsAt(ctx context.Context, customerID uint64, at time.Time) ([]domain.CustomerLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitsAt", ctx, customerID, at)
+	ret0, _ := ret[0].([]domain.CustomerLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimitsAt indicates an expected call of GetLimitsAt.
+func (mr *MockAdminServicesMockRecorder) GetLimitsAt(ctx, customerID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitsAt", reflect.TypeOf((*MockAdminServices)(nil).GetLimitsAt), ctx, customerID, at)
+}
+
+// ListCustomers mocks base method.
+func (m *MockAdminServices) ListCustomers(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomers", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomers indicates an expected call of ListCustomers.
+func (mr *MockAdminServicesMockRecorder) ListCustomers(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomers", reflect.TypeOf((*MockAdminServices)(nil).ListCustomers), ctx, params)
+}
+
+// SetLimits mocks base method.
+func (m *MockAdminServices) SetLimits(ctx context.Context, customerID, changedBy uint64, req dto.SetLimits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimits", ctx, customerID, changedBy, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLimits indicates an expected call of SetLimits.
+func (mr *MockAdminServicesMockRecorder) SetLimits(ctx, customerID, changedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimits", reflect.TypeOf((*MockAdminServices)(nil).SetLimits), ctx, customerID, changedBy, req)
+}
+
+// VerifyCustomer mocks base method.
+func (m *MockAdminServices) VerifyCustomer(ctx context.Context, customerID uint64, req dto.VerificationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCustomer", ctx, customerID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCustomer indicates an expected call of VerifyCustomer.
+func (mr *MockAdminServicesMockRecorder) VerifyCustomer(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCustomer", reflect.TypeOf((*MockAdminServices)(nil).VerifyCustomer), ctx, customerID, req)
+}
+
+// MockCloudinaryService is a mock of CloudinaryService interface.
+type MockCloudinaryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudinaryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCloudinaryServiceMockRecorder is the mock recorder for MockCloudinaryService.
+type MockCloudinaryServiceMockRecorder struct {
+	mock *MockCloudinaryService
+}
+
+// NewMockCloudinaryService creates a new mock instance.
+func NewMockCloudinaryService(ctrl *gomock.Controller) *MockCloudinaryService {
+	mock := &MockCloudinaryService{ctrl: ctrl}
+	mock.recorder = &MockCloudinaryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudinaryService) EXPECT() *MockCloudinaryServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteImage mocks base method.
+func (m *MockCloudinaryService) DeleteImage(ctx context.Context, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", ctx, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockCloudinaryServiceMockRecorder) DeleteImage(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockCloudinaryService)(nil).DeleteImage), ctx, url)
+}
+
+// ListImages mocks base method.
+func (m *MockCloudinaryService) ListImages(ctx context.Context, folder, cursor string, limit int) (*domain.StoredImagePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", ctx, folder, cursor, limit)
+	ret0, _ := ret[0].(*domain.StoredImagePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages.
+func (mr *MockCloudinaryServiceMockRecorder) ListImages(ctx, folder, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockCloudinaryService)(nil).ListImages), ctx, folder, cursor, limit)
+}
+
+// UploadFile mocks base method.
+func (m *MockCloudinaryService) UploadFile(ctx context.Context, data []byte, folder, publicID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", ctx, data, folder, publicID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockCloudinaryServiceMockRecorder) UploadFile(ctx, data, folder, publicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockCloudinaryService)(nil).UploadFile), ctx, data, folder, publicID)
+}
+
+// UploadImage mocks base method.
+func (m *MockCloudinaryService) UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, file, folder)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockCloudinaryServiceMockRecorder) UploadImage(ctx, file, folder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockCloudinaryService)(nil).UploadImage), ctx, file, folder)
+}
+
+// MockPrivateService is a mock of PrivateService interface.
+type MockPrivateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPrivateServiceMockRecorder is the mock recorder for MockPrivateService.
+type MockPrivateServiceMockRecorder struct {
+	mock *MockPrivateService
+}
+
+// NewMockPrivateService creates a new mock instance.
+func NewMockPrivateService(ctrl *gomock.Controller) *MockPrivateService {
+	mock := &MockPrivateService{ctrl: ctrl}
+	mock.recorder = &MockPrivateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivateService) EXPECT() *MockPrivateServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockPrivateService) ChangePassword(ctx context.Context, customerID uint64, req dto.ChangePasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, customerID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockPrivateServiceMockRecorder) ChangePassword(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockPrivateService)(nil).ChangePassword), ctx, customerID, req)
+}
+
+// Login mocks base method.
+func (m *MockPrivateService) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, req)
+	ret0, _ := ret[0].(*dto.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockPrivateServiceMockRecorder) Login(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockPrivateService)(nil).Login), ctx, req)
+}
+
+// MockPartnerOnboardingServices is a mock of PartnerOnboardingServices interface.
+type MockPartnerOnboardingServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerOnboardingServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerOnboardingServicesMockRecorder is the mock recorder for MockPartnerOnboardingServices.
+type MockPartnerOnboardingServicesMockRecorder struct {
+	mock *MockPartnerOnboardingServices
+}
+
+// NewMockPartnerOnboardingServices creates a new mock instance.
+func NewMockPartnerOnboardingServices(ctrl *gomock.Controller) *MockPartnerOnboardingServices {
+	mock := &MockPartnerOnboardingServices{ctrl: ctrl}
+	mock.recorder = &MockPartnerOnboardingServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerOnboardingServices) EXPECT() *MockPartnerOnboardingServicesMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockPartnerOnboardingServices) Authenticate(ctx context.Context, apiKey string) (*domain.Partner, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, apiKey)
+	ret0, _ := ret[0].(*domain.Partner)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockPartnerOnboardingServicesMockRecorder) Authenticate(ctx, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockPartnerOnboardingServices)(nil).Authenticate), ctx, apiKey)
+}
+
+// GetSecurity mocks base method.
+func (m *MockPartnerOnboardingServices) GetSecurity(ctx context.Context, partnerID uint64) (*dto.PartnerSecurityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurity", ctx, partnerID)
+	ret0, _ := ret[0].(*dto.PartnerSecurityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurity indicates an expected call of GetSecurity.
+func (mr *MockPartnerOnboardingServicesMockRecorder) GetSecurity(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurity", reflect.TypeOf((*MockPartnerOnboardingServices)(nil).GetSecurity), ctx, partnerID)
+}
+
+// Promote mocks base method.
+func (m *MockPartnerOnboardingServices) Promote(ctx context.Context, partnerID uint64) (*dto.PartnerCredentialsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Promote", ctx, partnerID)
+	ret0, _ := ret[0].(*dto.PartnerCredentialsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Promote indicates an expected call of Promote.
+func (mr *MockPartnerOnboardingServicesMockRecorder) Promote(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promote", reflect.TypeOf((*MockPartnerOnboardingServices)(nil).Promote), ctx, partnerID)
+}
+
+// Register mocks base method.
+func (m *MockPartnerOnboardingServices) Register(ctx context.Context, req dto.RegisterPartnerRequest) (*dto.PartnerCredentialsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, req)
+	ret0, _ := ret[0].(*dto.PartnerCredentialsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockPartnerOnboardingServicesMockRecorder) Register(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockPartnerOnboardingServices)(nil).Register), ctx, req)
+}
+
+// UpdateSecurity mocks base method.
+func (m *MockPartnerOnboardingServices) UpdateSecurity(ctx context.Context, partnerID uint64, req dto.PartnerSecurityRequest) (*dto.PartnerSecurityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurity", ctx, partnerID, req)
+	ret0, _ := ret[0].(*dto.PartnerSecurityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurity indicates an expected call of UpdateSecurity.
+func (mr *MockPartnerOnboardingServicesMockRecorder) UpdateSecurity(ctx, partnerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurity", reflect.TypeOf((*MockPartnerOnboardingServices)(nil).UpdateSecurity), ctx, partnerID, req)
+}
+
+// MockDeliveryServices is a mock of DeliveryServices interface.
+type MockDeliveryServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockDeliveryServicesMockRecorder is the mock recorder for MockDeliveryServices.
+type MockDeliveryServicesMockRecorder struct {
+	mock *MockDeliveryServices
+}
+
+// NewMockDeliveryServices creates a new mock instance.
+func NewMockDeliveryServices(ctrl *gomock.Controller) *MockDeliveryServices {
+	mock := &MockDeliveryServices{ctrl: ctrl}
+	mock.recorder = &MockDeliveryServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryServices) EXPECT() *MockDeliveryServicesMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *MockDeliveryServices) Dispatch(ctx context.Context, partnerID uint64, url, eventType string, payload any) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx, partnerID, url, eventType, payload)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockDeliveryServicesMockRecorder) Dispatch(ctx, partnerID, url, eventType, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockDeliveryServices)(nil).Dispatch), ctx, partnerID, url, eventType, payload)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockDeliveryServices) ListDeliveries(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockDeliveryServicesMockRecorder) ListDeliveries(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockDeliveryServices)(nil).ListDeliveries), ctx, params)
+}
+
+// Replay mocks base method.
+func (m *MockDeliveryServices) Replay(ctx context.Context, deliveryID uint64) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, deliveryID)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockDeliveryServicesMockRecorder) Replay(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockDeliveryServices)(nil).Replay), ctx, deliveryID)
+}
+
+// MockPartnerPortalServices is a mock of PartnerPortalServices interface.
+type MockPartnerPortalServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerPortalServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerPortalServicesMockRecorder is the mock recorder for MockPartnerPortalServices.
+type MockPartnerPortalServicesMockRecorder struct {
+	mock *MockPartnerPortalServices
+}
+
+// NewMockPartnerPortalServices creates a new mock instance.
+func NewMockPartnerPortalServices(ctrl *gomock.Controller) *MockPartnerPortalServices {
+	mock := &MockPartnerPortalServices{ctrl: ctrl}
+	mock.recorder = &MockPartnerPortalServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerPortalServices) EXPECT() *MockPartnerPortalServicesMockRecorder {
+	return m.recorder
+}
+
+// GetUsage mocks base method.
+func (m *MockPartnerPortalServices) GetUsage(ctx context.Context, partnerID uint64, days int, now time.Time) ([]domain.APIUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, partnerID, days, now)
+	ret0, _ := ret[0].([]domain.APIUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockPartnerPortalServicesMockRecorder) GetUsage(ctx, partnerID, days, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockPartnerPortalServices)(nil).GetUsage), ctx, partnerID, days, now)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockPartnerPortalServices) ListDeliveries(ctx context.Context, partnerID uint64, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, partnerID, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockPartnerPortalServicesMockRecorder) ListDeliveries(ctx, partnerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockPartnerPortalServices)(nil).ListDeliveries), ctx, partnerID, params)
+}
+
+// ListTransactions mocks base method.
+func (m *MockPartnerPortalServices) ListTransactions(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, partnerID, sandbox, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockPartnerPortalServicesMockRecorder) ListTransactions(ctx, partnerID, sandbox, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockPartnerPortalServices)(nil).ListTransactions), ctx, partnerID, sandbox, params)
+}
+
+// RegenerateAPIKey mocks base method.
+func (m *MockPartnerPortalServices) RegenerateAPIKey(ctx context.Context, partnerID uint64, sandbox bool) (*dto.PartnerCredentialsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateAPIKey", ctx, partnerID, sandbox)
+	ret0, _ := ret[0].(*dto.PartnerCredentialsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateAPIKey indicates an expected call of RegenerateAPIKey.
+func (mr *MockPartnerPortalServicesMockRecorder) RegenerateAPIKey(ctx, partnerID, sandbox any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateAPIKey", reflect.TypeOf((*MockPartnerPortalServices)(nil).RegenerateAPIKey), ctx, partnerID, sandbox)
+}
+
+// MockSalaryChangeServices is a mock of SalaryChangeServices interface.
+type MockSalaryChangeServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockSalaryChangeServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockSalaryChangeServicesMockRecorder is the mock recorder for MockSalaryChangeServices.
+type MockSalaryChangeServicesMockRecorder struct {
+	mock *MockSalaryChangeServices
+}
+
+// NewMockSalaryChangeServices creates a new mock instance.
+func NewMockSalaryChangeServices(ctrl *gomock.Controller) *MockSalaryChangeServices {
+	mock := &MockSalaryChangeServices{ctrl: ctrl}
+	mock.recorder = &MockSalaryChangeServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSalaryChangeServices) EXPECT() *MockSalaryChangeServicesMockRecorder {
+	return m.recorder
+}
+
+// ListChanges mocks base method.
+func (m *MockSalaryChangeServices) ListChanges(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChanges", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChanges indicates an expected call of ListChanges.
+func (mr *MockSalaryChangeServicesMockRecorder) ListChanges(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChanges", reflect.TypeOf((*MockSalaryChangeServices)(nil).ListChanges), ctx, params)
+}
+
+// RequestChange mocks base method.
+func (m *MockSalaryChangeServices) RequestChange(ctx context.Context, customerID uint64, requestedSalary decimal.Decimal, payslipURL string) (*domain.SalaryChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestChange", ctx, customerID, requestedSalary, payslipURL)
+	ret0, _ := ret[0].(*domain.SalaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestChange indicates an expected call of RequestChange.
+func (mr *MockSalaryChangeServicesMockRecorder) RequestChange(ctx, customerID, requestedSalary, payslipURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestChange", reflect.TypeOf((*MockSalaryChangeServices)(nil).RequestChange), ctx, customerID, requestedSalary, payslipURL)
+}
+
+// Review mocks base method.
+func (m *MockSalaryChangeServices) Review(ctx context.Context, changeID, reviewerID uint64, req dto.SalaryChangeReviewRequest) (*domain.SalaryChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, changeID, reviewerID, req)
+	ret0, _ := ret[0].(*domain.SalaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockSalaryChangeServicesMockRecorder) Review(ctx, changeID, reviewerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockSalaryChangeServices)(nil).Review), ctx, changeID, reviewerID, req)
+}
+
+// MockIncomeVerificationServices is a mock of IncomeVerificationServices interface.
+type MockIncomeVerificationServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncomeVerificationServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockIncomeVerificationServicesMockRecorder is the mock recorder for MockIncomeVerificationServices.
+type MockIncomeVerificationServicesMockRecorder struct {
+	mock *MockIncomeVerificationServices
+}
+
+// NewMockIncomeVerificationServices creates a new mock instance.
+func NewMockIncomeVerificationServices(ctrl *gomock.Controller) *MockIncomeVerificationServices {
+	mock := &MockIncomeVerificationServices{ctrl: ctrl}
+	mock.recorder = &MockIncomeVerificationServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncomeVerificationServices) EXPECT() *MockIncomeVerificationServicesMockRecorder {
+	return m.recorder
+}
+
+// ListByCustomer mocks base method.
+func (m *MockIncomeVerificationServices) ListByCustomer(ctx context.Context, customerID uint64) ([]domain.IncomeVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCustomer", ctx, customerID)
+	ret0, _ := ret[0].([]domain.IncomeVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCustomer indicates an expected call of ListByCustomer.
+func (mr *MockIncomeVerificationServicesMockRecorder) ListByCustomer(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCustomer", reflect.TypeOf((*MockIncomeVerificationServices)(nil).ListByCustomer), ctx, customerID)
+}
+
+// ListVerifications mocks base method.
+func (m *MockIncomeVerificationServices) ListVerifications(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVerifications", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVerifications indicates an expected call of ListVerifications.
+func (mr *MockIncomeVerificationServicesMockRecorder) ListVerifications(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerifications", reflect.TypeOf((*MockIncomeVerificationServices)(nil).ListVerifications), ctx, params)
+}
+
+// Review mocks base method.
+func (m *MockIncomeVerificationServices) Review(ctx context.Context, verificationID, reviewerID uint64, req dto.IncomeVerificationReviewRequest) (*domain.IncomeVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, verificationID, reviewerID, req)
+	ret0, _ := ret[0].(*domain.IncomeVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockIncomeVerificationServicesMockRecorder) Review(ctx, verificationID, reviewerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockIncomeVerificationServices)(nil).Review), ctx, verificationID, reviewerID, req)
+}
+
+// Upload mocks base method.
+func (m *MockIncomeVerificationServices) Upload(ctx context.Context, customerID uint64, fileName string, data []byte) (*domain.IncomeVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, customerID, fileName, data)
+	ret0, _ := ret[0].(*domain.IncomeVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockIncomeVerificationServicesMockRecorder) Upload(ctx, customerID, fileName, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockIncomeVerificationServices)(nil).Upload), ctx, customerID, fileName, data)
+}
+
+// MockRiskTierServices is a mock of RiskTierServices interface.
+type MockRiskTierServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskTierServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockRiskTierServicesMockRecorder is the mock recorder for MockRiskTierServices.
+type MockRiskTierServicesMockRecorder struct {
+	mock *MockRiskTierServices
+}
+
+// NewMockRiskTierServices creates a new mock instance.
+func NewMockRiskTierServices(ctrl *gomock.Controller) *MockRiskTierServices {
+	mock := &MockRiskTierServices{ctrl: ctrl}
+	mock.recorder = &MockRiskTierServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskTierServices) EXPECT() *MockRiskTierServicesMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockRiskTierServices) CreateRule(ctx context.Context, updatedBy uint64, req dto.RiskTierRuleRequest) (*domain.RiskTierRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, updatedBy, req)
+	ret0, _ := ret[0].(*domain.RiskTierRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockRiskTierServicesMockRecorder) CreateRule(ctx, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockRiskTierServices)(nil).CreateRule), ctx, updatedBy, req)
+}
+
+// DeleteRule mocks base method.
+func (m *MockRiskTierServices) DeleteRule(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRiskTierServicesMockRecorder) DeleteRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRiskTierServices)(nil).DeleteRule), ctx, id)
+}
+
+// GetCustomerTier mocks base method.
+func (m *MockRiskTierServices) GetCustomerTier(ctx context.Context, customerID uint64) (*domain.CustomerRiskTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerTier", ctx, customerID)
+	ret0, _ := ret[0].(*domain.CustomerRiskTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerTier indicates an expected call of GetCustomerTier.
+func (mr *MockRiskTierServicesMockRecorder) GetCustomerTier(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerTier", reflect.TypeOf((*MockRiskTierServices)(nil).GetCustomerTier), ctx, customerID)
+}
+
+// ListRules mocks base method.
+func (m *MockRiskTierServices) ListRules(ctx context.Context) ([]domain.RiskTierRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx)
+	ret0, _ := ret[0].([]domain.RiskTierRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockRiskTierServicesMockRecorder) ListRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockRiskTierServices)(nil).ListRules), ctx)
+}
+
+// Recalculate mocks base method.
+func (m *MockRiskTierServices) Recalculate(ctx context.Context, now time.Time) (*domain.RiskTierRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recalculate", ctx, now)
+	ret0, _ := ret[0].(*domain.RiskTierRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recalculate indicates an expected call of Recalculate.
+func (mr *MockRiskTierServicesMockRecorder) Recalculate(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recalculate", reflect.TypeOf((*MockRiskTierServices)(nil).Recalculate), ctx, now)
+}
+
+// SetCreditScore mocks base method.
+func (m *MockRiskTierServices) SetCreditScore(ctx context.Context, customerID uint64, req dto.CreditScoreRequest) (*domain.CustomerRiskTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCreditScore", ctx, customerID, req)
+	ret0, _ := ret[0].(*domain.CustomerRiskTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCreditScore indicates an expected call of SetCreditScore.
+func (mr *MockRiskTierServicesMockRecorder) SetCreditScore(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCreditScore", reflect.TypeOf((*MockRiskTierServices)(nil).SetCreditScore), ctx, customerID, req)
+}
+
+// UpdateRule mocks base method.
+func (m *MockRiskTierServices) UpdateRule(ctx context.Context, id, updatedBy uint64, req dto.RiskTierRuleRequest) (*domain.RiskTierRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", ctx, id, updatedBy, req)
+	ret0, _ := ret[0].(*domain.RiskTierRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockRiskTierServicesMockRecorder) UpdateRule(ctx, id, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockRiskTierServices)(nil).UpdateRule), ctx, id, updatedBy, req)
+}
+
+// MockLimitRecommendationServices is a mock of LimitRecommendationServices interface.
+type MockLimitRecommendationServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitRecommendationServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockLimitRecommendationServicesMockRecorder is the mock recorder for MockLimitRecommendationServices.
+type MockLimitRecommendationServicesMockRecorder struct {
+	mock *MockLimitRecommendationServices
+}
+
+// NewMockLimitRecommendationServices creates a new mock instance.
+func NewMockLimitRecommendationServices(ctrl *gomock.Controller) *MockLimitRecommendationServices {
+	mock := &MockLimitRecommendationServices{ctrl: ctrl}
+	mock.recorder = &MockLimitRecommendationServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitRecommendationServices) EXPECT() *MockLimitRecommendationServicesMockRecorder {
+	return m.recorder
+}
+
+// Recommend mocks base method.
+func (m *MockLimitRecommendationServices) Recommend(ctx context.Context, customerID uint64) (*dto.LimitRecommendationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recommend", ctx, customerID)
+	ret0, _ := ret[0].(*dto.LimitRecommendationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recommend indicates an expected call of Recommend.
+func (mr *MockLimitRecommendationServicesMockRecorder) Recommend(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recommend", reflect.TypeOf((*MockLimitRecommendationServices)(nil).Recommend), ctx, customerID)
+}
+
+// MockScreeningServices is a mock of ScreeningServices interface.
+type MockScreeningServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreeningServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockScreeningServicesMockRecorder is the mock recorder for MockScreeningServices.
+type MockScreeningServicesMockRecorder struct {
+	mock *MockScreeningServices
+}
+
+// NewMockScreeningServices creates a new mock instance.
+func NewMockScreeningServices(ctrl *gomock.Controller) *MockScreeningServices {
+	mock := &MockScreeningServices{ctrl: ctrl}
+	mock.recorder = &MockScreeningServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreeningServices) EXPECT() *MockScreeningServicesMockRecorder {
+	return m.recorder
+}
+
+// Screen mocks base method.
+func (m *MockScreeningServices) Screen(ctx context.Context, action domain.ScreeningAction, customerID *uint64, nik, fullName string) (*domain.ScreeningLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", ctx, action, customerID, nik, fullName)
+	ret0, _ := ret[0].(*domain.ScreeningLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockScreeningServicesMockRecorder) Screen(ctx, action, customerID, nik, fullName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockScreeningServices)(nil).Screen), ctx, action, customerID, nik, fullName)
+}
+
+// MockBlacklistServices is a mock of BlacklistServices interface.
+type MockBlacklistServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlacklistServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockBlacklistServicesMockRecorder is the mock recorder for MockBlacklistServices.
+type MockBlacklistServicesMockRecorder struct {
+	mock *MockBlacklistServices
+}
+
+// NewMockBlacklistServices creates a new mock instance.
+func NewMockBlacklistServices(ctrl *gomock.Controller) *MockBlacklistServices {
+	mock := &MockBlacklistServices{ctrl: ctrl}
+	mock.recorder = &MockBlacklistServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlacklistServices) EXPECT() *MockBlacklistServicesMockRecorder {
+	return m.recorder
+}
+
+// CreateEntry mocks base method.
+func (m *MockBlacklistServices) CreateEntry(ctx context.Context, req dto.BlacklistEntryRequest) (*domain.BlacklistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, req)
+	ret0, _ := ret[0].(*domain.BlacklistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockBlacklistServicesMockRecorder) CreateEntry(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockBlacklistServices)(nil).CreateEntry), ctx, req)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockBlacklistServices) DeleteEntry(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockBlacklistServicesMockRecorder) DeleteEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockBlacklistServices)(nil).DeleteEntry), ctx, id)
+}
+
+// GetEntry mocks base method.
+func (m *MockBlacklistServices) GetEntry(ctx context.Context, id uint64) (*domain.BlacklistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
+	ret0, _ := ret[0].(*domain.BlacklistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockBlacklistServicesMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockBlacklistServices)(nil).GetEntry), ctx, id)
+}
+
+// ListEntries mocks base method.
+func (m *MockBlacklistServices) ListEntries(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockBlacklistServicesMockRecorder) ListEntries(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockBlacklistServices)(nil).ListEntries), ctx, params)
+}
+
+// ListScreeningLogs mocks base method.
+func (m *MockBlacklistServices) ListScreeningLogs(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScreeningLogs", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScreeningLogs indicates an expected call of ListScreeningLogs.
+func (mr *MockBlacklistServicesMockRecorder) ListScreeningLogs(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScreeningLogs", reflect.TypeOf((*MockBlacklistServices)(nil).ListScreeningLogs), ctx, params)
+}
+
+// Screen mocks base method.
+func (m *MockBlacklistServices) Screen(ctx context.Context, action domain.ScreeningAction, customerID *uint64, nik, fullName string) (*domain.ScreeningLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", ctx, action, customerID, nik, fullName)
+	ret0, _ := ret[0].(*domain.ScreeningLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockBlacklistServicesMockRecorder) Screen(ctx, action, customerID, nik, fullName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockBlacklistServices)(nil).Screen), ctx, action, customerID, nik, fullName)
+}
+
+// UpdateEntry mocks base method.
+func (m *MockBlacklistServices) UpdateEntry(ctx context.Context, id uint64, req dto.BlacklistEntryRequest) (*domain.BlacklistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntry", ctx, id, req)
+	ret0, _ := ret[0].(*domain.BlacklistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEntry indicates an expected call of UpdateEntry.
+func (mr *MockBlacklistServicesMockRecorder) UpdateEntry(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntry", reflect.TypeOf((*MockBlacklistServices)(nil).UpdateEntry), ctx, id, req)
+}
+
+// MockDuplicateServices is a mock of DuplicateServices interface.
+type MockDuplicateServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockDuplicateServicesMockRecorder is the mock recorder for MockDuplicateServices.
+type MockDuplicateServicesMockRecorder struct {
+	mock *MockDuplicateServices
+}
+
+// NewMockDuplicateServices creates a new mock instance.
+func NewMockDuplicateServices(ctrl *gomock.Controller) *MockDuplicateServices {
+	mock := &MockDuplicateServices{ctrl: ctrl}
+	mock.recorder = &MockDuplicateServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateServices) EXPECT() *MockDuplicateServicesMockRecorder {
+	return m.recorder
+}
+
+// FindPossibleDuplicates mocks base method.
+func (m *MockDuplicateServices) FindPossibleDuplicates(ctx context.Context, customerID uint64) ([]dto.DuplicateCandidateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPossibleDuplicates", ctx, customerID)
+	ret0, _ := ret[0].([]dto.DuplicateCandidateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPossibleDuplicates indicates an expected call of FindPossibleDuplicates.
+func (mr *MockDuplicateServicesMockRecorder) FindPossibleDuplicates(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPossibleDuplicates", reflect.TypeOf((*MockDuplicateServices)(nil).FindPossibleDuplicates), ctx, customerID)
+}
+
+// Resolve mocks base method.
+func (m *MockDuplicateServices) Resolve(ctx context.Context, customerID, duplicateID, reviewerID uint64, req dto.DuplicateResolutionRequest) (*domain.DuplicateResolution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, customerID, duplicateID, reviewerID, req)
+	ret0, _ := ret[0].(*domain.DuplicateResolution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockDuplicateServicesMockRecorder) Resolve(ctx, customerID, duplicateID, reviewerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockDuplicateServices)(nil).Resolve), ctx, customerID, duplicateID, reviewerID, req)
+}
+
+// MockRestructuringServices is a mock of RestructuringServices interface.
+type MockRestructuringServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockRestructuringServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockRestructuringServicesMockRecorder is the mock recorder for MockRestructuringServices.
+type MockRestructuringServicesMockRecorder struct {
+	mock *MockRestructuringServices
+}
+
+// NewMockRestructuringServices creates a new mock instance.
+func NewMockRestructuringServices(ctrl *gomock.Controller) *MockRestructuringServices {
+	mock := &MockRestructuringServices{ctrl: ctrl}
+	mock.recorder = &MockRestructuringServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRestructuringServices) EXPECT() *MockRestructuringServicesMockRecorder {
+	return m.recorder
+}
+
+// GetRestructuring mocks base method.
+func (m *MockRestructuringServices) GetRestructuring(ctx context.Context, id uint64) (*dto.RestructuringResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestructuring", ctx, id)
+	ret0, _ := ret[0].(*dto.RestructuringResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestructuring indicates an expected call of GetRestructuring.
+func (mr *MockRestructuringServicesMockRecorder) GetRestructuring(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestructuring", reflect.TypeOf((*MockRestructuringServices)(nil).GetRestructuring), ctx, id)
+}
+
+// ListRestructurings mocks base method.
+func (m *MockRestructuringServices) ListRestructurings(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRestructurings", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRestructurings indicates an expected call of ListRestructurings.
+func (mr *MockRestructuringServicesMockRecorder) ListRestructurings(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRestructurings", reflect.TypeOf((*MockRestructuringServices)(nil).ListRestructurings), ctx, params)
+}
+
+// Propose mocks base method.
+func (m *MockRestructuringServices) Propose(ctx context.Context, makerID uint64, req dto.RestructuringRequest) (*dto.RestructuringResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Propose", ctx, makerID, req)
+	ret0, _ := ret[0].(*dto.RestructuringResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Propose indicates an expected call of Propose.
+func (mr *MockRestructuringServicesMockRecorder) Propose(ctx, makerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Propose", reflect.TypeOf((*MockRestructuringServices)(nil).Propose), ctx, makerID, req)
+}
+
+// Review mocks base method.
+func (m *MockRestructuringServices) Review(ctx context.Context, id, checkerID uint64, req dto.RestructuringReviewRequest) (*dto.RestructuringResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, id, checkerID, req)
+	ret0, _ := ret[0].(*dto.RestructuringResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockRestructuringServicesMockRecorder) Review(ctx, id, checkerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockRestructuringServices)(nil).Review), ctx, id, checkerID, req)
+}
+
+// MockCurrencyConverter is a mock of CurrencyConverter interface.
+type MockCurrencyConverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyConverterMockRecorder
+	isgomock struct{}
+}
+
+// MockCurrencyConverterMockRecorder is the mock recorder for MockCurrencyConverter.
+type MockCurrencyConverterMockRecorder struct {
+	mock *MockCurrencyConverter
+}
+
+// NewMockCurrencyConverter creates a new mock instance.
+func NewMockCurrencyConverter(ctrl *gomock.Controller) *MockCurrencyConverter {
+	mock := &MockCurrencyConverter{ctrl: ctrl}
+	mock.recorder = &MockCurrencyConverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyConverter) EXPECT() *MockCurrencyConverterMockRecorder {
+	return m.recorder
+}
+
+// RateToIDR mocks base method.
+func (m *MockCurrencyConverter) RateToIDR(ctx context.Context, currency string, asOf time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateToIDR", ctx, currency, asOf)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateToIDR indicates an expected call of RateToIDR.
+func (mr *MockCurrencyConverterMockRecorder) RateToIDR(ctx, currency, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateToIDR", reflect.TypeOf((*MockCurrencyConverter)(nil).RateToIDR), ctx, currency, asOf)
+}
+
+// MockFxRateServices is a mock of FxRateServices interface.
+type MockFxRateServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRateServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockFxRateServicesMockRecorder is the mock recorder for MockFxRateServices.
+type MockFxRateServicesMockRecorder struct {
+	mock *MockFxRateServices
+}
+
+// NewMockFxRateServices creates a new mock instance.
+func NewMockFxRateServices(ctrl *gomock.Controller) *MockFxRateServices {
+	mock := &MockFxRateServices{ctrl: ctrl}
+	mock.recorder = &MockFxRateServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFxRateServices) EXPECT() *MockFxRateServicesMockRecorder {
+	return m.recorder
+}
+
+// ListRates mocks base method.
+func (m *MockFxRateServices) ListRates(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRates", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRates indicates an expected call of ListRates.
+func (mr *MockFxRateServicesMockRecorder) ListRates(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRates", reflect.TypeOf((*MockFxRateServices)(nil).ListRates), ctx, params)
+}
+
+// RateToIDR mocks base method.
+func (m *MockFxRateServices) RateToIDR(ctx context.Context, currency string, asOf time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateToIDR", ctx, currency, asOf)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateToIDR indicates an expected call of RateToIDR.
+func (mr *MockFxRateServicesMockRecorder) RateToIDR(ctx, currency, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateToIDR", reflect.TypeOf((*MockFxRateServices)(nil).RateToIDR), ctx, currency, asOf)
+}
+
+// UploadRates mocks base method.
+func (m *MockFxRateServices) UploadRates(ctx context.Context, uploadedBy uint64, req dto.FxRateUploadRequest) ([]domain.FxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadRates", ctx, uploadedBy, req)
+	ret0, _ := ret[0].([]domain.FxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadRates indicates an expected call of UploadRates.
+func (mr *MockFxRateServicesMockRecorder) UploadRates(ctx, uploadedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadRates", reflect.TypeOf((*MockFxRateServices)(nil).UploadRates), ctx, uploadedBy, req)
+}
+
+// MockReportServices is a mock of ReportServices interface.
+type MockReportServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockReportServicesMockRecorder is the mock recorder for MockReportServices.
+type MockReportServicesMockRecorder struct {
+	mock *MockReportServices
+}
+
+// NewMockReportServices creates a new mock instance.
+func NewMockReportServices(ctrl *gomock.Controller) *MockReportServices {
+	mock := &MockReportServices{ctrl: ctrl}
+	mock.recorder = &MockReportServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportServices) EXPECT() *MockReportServicesMockRecorder {
+	return m.recorder
+}
+
+// Aging mocks base method.
+func (m *MockReportServices) Aging(ctx context.Context, date string) (*dto.AgingReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aging", ctx, date)
+	ret0, _ := ret[0].(*dto.AgingReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aging indicates an expected call of Aging.
+func (mr *MockReportServicesMockRecorder) Aging(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aging", reflect.TypeOf((*MockReportServices)(nil).Aging), ctx, date)
+}
+
+// InterestAccrual mocks base method.
+func (m *MockReportServices) InterestAccrual(ctx context.Context, month string) (*dto.InterestAccrualReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InterestAccrual", ctx, month)
+	ret0, _ := ret[0].(*dto.InterestAccrualReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InterestAccrual indicates an expected call of InterestAccrual.
+func (mr *MockReportServicesMockRecorder) InterestAccrual(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InterestAccrual", reflect.TypeOf((*MockReportServices)(nil).InterestAccrual), ctx, month)
+}
+
+// PartnerCommission mocks base method.
+func (m *MockReportServices) PartnerCommission(ctx context.Context, month string) (*dto.PartnerCommissionReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartnerCommission", ctx, month)
+	ret0, _ := ret[0].(*dto.PartnerCommissionReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PartnerCommission indicates an expected call of PartnerCommission.
+func (mr *MockReportServicesMockRecorder) PartnerCommission(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartnerCommission", reflect.TypeOf((*MockReportServices)(nil).PartnerCommission), ctx, month)
+}
+
+// ReferralConversion mocks base method.
+func (m *MockReportServices) ReferralConversion(ctx context.Context, month string) (*dto.ReferralConversionReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReferralConversion", ctx, month)
+	ret0, _ := ret[0].(*dto.ReferralConversionReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReferralConversion indicates an expected call of ReferralConversion.
+func (mr *MockReportServicesMockRecorder) ReferralConversion(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReferralConversion", reflect.TypeOf((*MockReportServices)(nil).ReferralConversion), ctx, month)
+}
+
+// RefreshAgingSnapshot mocks base method.
+func (m *MockReportServices) RefreshAgingSnapshot(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshAgingSnapshot", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshAgingSnapshot indicates an expected call of RefreshAgingSnapshot.
+func (mr *MockReportServicesMockRecorder) RefreshAgingSnapshot(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAgingSnapshot", reflect.TypeOf((*MockReportServices)(nil).RefreshAgingSnapshot), ctx, now)
+}
+
+// RegionPerformance mocks base method.
+func (m *MockReportServices) RegionPerformance(ctx context.Context, month string) (*dto.RegionReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegionPerformance", ctx, month)
+	ret0, _ := ret[0].(*dto.RegionReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegionPerformance indicates an expected call of RegionPerformance.
+func (mr *MockReportServicesMockRecorder) RegionPerformance(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegionPerformance", reflect.TypeOf((*MockReportServices)(nil).RegionPerformance), ctx, month)
+}
+
+// MockCustomerNotifier is a mock of CustomerNotifier interface.
+type MockCustomerNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerNotifierMockRecorder is the mock recorder for MockCustomerNotifier.
+type MockCustomerNotifierMockRecorder struct {
+	mock *MockCustomerNotifier
+}
+
+// NewMockCustomerNotifier creates a new mock instance.
+func NewMockCustomerNotifier(ctrl *gomock.Controller) *MockCustomerNotifier {
+	mock := &MockCustomerNotifier{ctrl: ctrl}
+	mock.recorder = &MockCustomerNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerNotifier) EXPECT() *MockCustomerNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockCustomerNotifier) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, customerID, template, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockCustomerNotifierMockRecorder) Notify(ctx, customerID, template, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockCustomerNotifier)(nil).Notify), ctx, customerID, template, data)
+}
+
+// MockTemplateRenderer is a mock of TemplateRenderer interface.
+type MockTemplateRenderer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateRendererMockRecorder
+	isgomock struct{}
+}
+
+// MockTemplateRendererMockRecorder is the mock recorder for MockTemplateRenderer.
+type MockTemplateRendererMockRecorder struct {
+	mock *MockTemplateRenderer
+}
+
+// NewMockTemplateRenderer creates a new mock instance.
+func NewMockTemplateRenderer(ctrl *gomock.Controller) *MockTemplateRenderer {
+	mock := &MockTemplateRenderer{ctrl: ctrl}
+	mock.recorder = &MockTemplateRendererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateRenderer) EXPECT() *MockTemplateRendererMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockTemplateRenderer) Render(name domain.NotificationTemplate, language domain.Language, data map[string]string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", name, language, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockTemplateRendererMockRecorder) Render(name, language, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockTemplateRenderer)(nil).Render), name, language, data)
+}
+
+// MockEmailServices is a mock of EmailServices interface.
+type MockEmailServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailServicesMockRecorder is the mock recorder for MockEmailServices.
+type MockEmailServicesMockRecorder struct {
+	mock *MockEmailServices
+}
+
+// NewMockEmailServices creates a new mock instance.
+func NewMockEmailServices(ctrl *gomock.Controller) *MockEmailServices {
+	mock := &MockEmailServices{ctrl: ctrl}
+	mock.recorder = &MockEmailServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailServices) EXPECT() *MockEmailServicesMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockEmailServices) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, customerID, template, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockEmailServicesMockRecorder) Notify(ctx, customerID, template, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockEmailServices)(nil).Notify), ctx, customerID, template, data)
+}
+
+// PreviewTemplate mocks base method.
+func (m *MockEmailServices) PreviewTemplate(ctx context.Context, template domain.NotificationTemplate, req dto.EmailPreviewRequest) (*domain.RenderedEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewTemplate", ctx, template, req)
+	ret0, _ := ret[0].(*domain.RenderedEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewTemplate indicates an expected call of PreviewTemplate.
+func (mr *MockEmailServicesMockRecorder) PreviewTemplate(ctx, template, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTemplate", reflect.TypeOf((*MockEmailServices)(nil).PreviewTemplate), ctx, template, req)
+}
+
+// ProcessPending mocks base method.
+func (m *MockEmailServices) ProcessPending(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPending", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessPending indicates an expected call of ProcessPending.
+func (mr *MockEmailServicesMockRecorder) ProcessPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPending", reflect.TypeOf((*MockEmailServices)(nil).ProcessPending), ctx)
+}
+
+// MockPushServices is a mock of PushServices interface.
+type MockPushServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPushServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPushServicesMockRecorder is the mock recorder for MockPushServices.
+type MockPushServicesMockRecorder struct {
+	mock *MockPushServices
+}
+
+// NewMockPushServices creates a new mock instance.
+func NewMockPushServices(ctrl *gomock.Controller) *MockPushServices {
+	mock := &MockPushServices{ctrl: ctrl}
+	mock.recorder = &MockPushServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPushServices) EXPECT() *MockPushServicesMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockPushServices) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, customerID, template, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockPushServicesMockRecorder) Notify(ctx, customerID, template, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockPushServices)(nil).Notify), ctx, customerID, template, data)
+}
+
+// RegisterDevice mocks base method.
+func (m *MockPushServices) RegisterDevice(ctx context.Context, customerID uint64, req dto.DeviceRequest) (*domain.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterDevice", ctx, customerID, req)
+	ret0, _ := ret[0].(*domain.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterDevice indicates an expected call of RegisterDevice.
+func (mr *MockPushServicesMockRecorder) RegisterDevice(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterDevice", reflect.TypeOf((*MockPushServices)(nil).RegisterDevice), ctx, customerID, req)
+}
+
+// UnregisterDevice mocks base method.
+func (m *MockPushServices) UnregisterDevice(ctx context.Context, customerID uint64, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnregisterDevice", ctx, customerID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnregisterDevice indicates an expected call of UnregisterDevice.
+func (mr *MockPushServicesMockRecorder) UnregisterDevice(ctx, customerID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterDevice", reflect.TypeOf((*MockPushServices)(nil).UnregisterDevice), ctx, customerID, token)
+}
+
+// MockNotificationPreferenceServices is a mock of NotificationPreferenceServices interface.
+type MockNotificationPreferenceServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPreferenceServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationPreferenceServicesMockRecorder is the mock recorder for MockNotificationPreferenceServices.
+type MockNotificationPreferenceServicesMockRecorder struct {
+	mock *MockNotificationPreferenceServices
+}
+
+// NewMockNotificationPreferenceServices creates a new mock instance.
+func NewMockNotificationPreferenceServices(ctrl *gomock.Controller) *MockNotificationPreferenceServices {
+	mock := &MockNotificationPreferenceServices{ctrl: ctrl}
+	mock.recorder = &MockNotificationPreferenceServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPreferenceServices) EXPECT() *MockNotificationPreferenceServicesMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationPreferenceServices) GetPreferences(ctx context.Context, customerID uint64) (*domain.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, customerID)
+	ret0, _ := ret[0].(*domain.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationPreferenceServicesMockRecorder) GetPreferences(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationPreferenceServices)(nil).GetPreferences), ctx, customerID)
+}
+
+// SetPreferences mocks base method.
+func (m *MockNotificationPreferenceServices) SetPreferences(ctx context.Context, customerID uint64, req dto.NotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreferences", ctx, customerID, req)
+	ret0, _ := ret[0].(*domain.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPreferences indicates an expected call of SetPreferences.
+func (mr *MockNotificationPreferenceServicesMockRecorder) SetPreferences(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreferences", reflect.TypeOf((*MockNotificationPreferenceServices)(nil).SetPreferences), ctx, customerID, req)
+}
+
+// MockPaymentReminderServices is a mock of PaymentReminderServices interface.
+type MockPaymentReminderServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentReminderServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentReminderServicesMockRecorder is the mock recorder for MockPaymentReminderServices.
+type MockPaymentReminderServicesMockRecorder struct {
+	mock *MockPaymentReminderServices
+}
+
+// NewMockPaymentReminderServices creates a new mock instance.
+func NewMockPaymentReminderServices(ctrl *gomock.Controller) *MockPaymentReminderServices {
+	mock := &MockPaymentReminderServices{ctrl: ctrl}
+	mock.recorder = &MockPaymentReminderServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentReminderServices) EXPECT() *MockPaymentReminderServicesMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockPaymentReminderServices) Run(ctx context.Context, now time.Time) (*domain.PaymentReminderRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, now)
+	ret0, _ := ret[0].(*domain.PaymentReminderRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockPaymentReminderServicesMockRecorder) Run(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockPaymentReminderServices)(nil).Run), ctx, now)
+}
+
+// MockPendingExpiryServices is a mock of PendingExpiryServices interface.
+type MockPendingExpiryServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingExpiryServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPendingExpiryServicesMockRecorder is the mock recorder for MockPendingExpiryServices.
+type MockPendingExpiryServicesMockRecorder struct {
+	mock *MockPendingExpiryServices
+}
+
+// NewMockPendingExpiryServices creates a new mock instance.
+func NewMockPendingExpiryServices(ctrl *gomock.Controller) *MockPendingExpiryServices {
+	mock := &MockPendingExpiryServices{ctrl: ctrl}
+	mock.recorder = &MockPendingExpiryServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingExpiryServices) EXPECT() *MockPendingExpiryServicesMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockPendingExpiryServices) Run(ctx context.Context, now time.Time) (*domain.PendingExpiryRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, now)
+	ret0, _ := ret[0].(*domain.PendingExpiryRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockPendingExpiryServicesMockRecorder) Run(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockPendingExpiryServices)(nil).Run), ctx, now)
+}
+
+// MockCommunicationServices is a mock of CommunicationServices interface.
+type MockCommunicationServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommunicationServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockCommunicationServicesMockRecorder is the mock recorder for MockCommunicationServices.
+type MockCommunicationServicesMockRecorder struct {
+	mock *MockCommunicationServices
+}
+
+// NewMockCommunicationServices creates a new mock instance.
+func NewMockCommunicationServices(ctrl *gomock.Controller) *MockCommunicationServices {
+	mock := &MockCommunicationServices{ctrl: ctrl}
+	mock.recorder = &MockCommunicationServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommunicationServices) EXPECT() *MockCommunicationServicesMockRecorder {
+	return m.recorder
+}
+
+// ListByCustomer mocks base method.
+func (m *MockCommunicationServices) ListByCustomer(ctx context.Context, customerID uint64, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCustomer", ctx, customerID, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCustomer indicates an expected call of ListByCustomer.
+func (mr *MockCommunicationServicesMockRecorder) ListByCustomer(ctx, customerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCustomer", reflect.TypeOf((*MockCommunicationServices)(nil).ListByCustomer), ctx, customerID, params)
+}
+
+// MockFeatureFlags is a mock of FeatureFlags interface.
+type MockFeatureFlags struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagsMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagsMockRecorder is the mock recorder for MockFeatureFlags.
+type MockFeatureFlagsMockRecorder struct {
+	mock *MockFeatureFlags
+}
+
+// NewMockFeatureFlags creates a new mock instance.
+func NewMockFeatureFlags(ctrl *gomock.Controller) *MockFeatureFlags {
+	mock := &MockFeatureFlags{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlags) EXPECT() *MockFeatureFlagsMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockFeatureFlags) Enabled(ctx context.Context, key string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", ctx, key)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockFeatureFlagsMockRecorder) Enabled(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockFeatureFlags)(nil).Enabled), ctx, key)
+}
+
+// MockFeatureFlagServices is a mock of FeatureFlagServices interface.
+type MockFeatureFlagServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagServicesMockRecorder is the mock recorder for MockFeatureFlagServices.
+type MockFeatureFlagServicesMockRecorder struct {
+	mock *MockFeatureFlagServices
+}
+
+// NewMockFeatureFlagServices creates a new mock instance.
+func NewMockFeatureFlagServices(ctrl *gomock.Controller) *MockFeatureFlagServices {
+	mock := &MockFeatureFlagServices{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlagServices) EXPECT() *MockFeatureFlagServicesMockRecorder {
+	return m.recorder
+}
+
+// DeleteFlag mocks base method.
+func (m *MockFeatureFlagServices) DeleteFlag(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlag", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFlag indicates an expected call of DeleteFlag.
+func (mr *MockFeatureFlagServicesMockRecorder) DeleteFlag(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlag", reflect.TypeOf((*MockFeatureFlagServices)(nil).DeleteFlag), ctx, key)
+}
+
+// Enabled mocks base method.
+func (m *MockFeatureFlagServices) Enabled(ctx context.Context, key string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", ctx, key)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockFeatureFlagServicesMockRecorder) Enabled(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockFeatureFlagServices)(nil).Enabled), ctx, key)
+}
+
+// ListFlags mocks base method.
+func (m *MockFeatureFlagServices) ListFlags(ctx context.Context) ([]domain.FeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlags", ctx)
+	ret0, _ := ret[0].([]domain.FeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlags indicates an expected call of ListFlags.
+func (mr *MockFeatureFlagServicesMockRecorder) ListFlags(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlags", reflect.TypeOf((*MockFeatureFlagServices)(nil).ListFlags), ctx)
+}
+
+// SetFlag mocks base method.
+func (m *MockFeatureFlagServices) SetFlag(ctx context.Context, key string, updatedBy uint64, req dto.FeatureFlagRequest) (*domain.FeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFlag", ctx, key, updatedBy, req)
+	ret0, _ := ret[0].(*domain.FeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFlag indicates an expected call of SetFlag.
+func (mr *MockFeatureFlagServicesMockRecorder) SetFlag(ctx, key, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFlag", reflect.TypeOf((*MockFeatureFlagServices)(nil).SetFlag), ctx, key, updatedBy, req)
+}
+
+// MockFeeScheduleServices is a mock of FeeScheduleServices interface.
+type MockFeeScheduleServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeScheduleServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockFeeScheduleServicesMockRecorder is the mock recorder for MockFeeScheduleServices.
+type MockFeeScheduleServicesMockRecorder struct {
+	mock *MockFeeScheduleServices
+}
+
+// NewMockFeeScheduleServices creates a new mock instance.
+func NewMockFeeScheduleServices(ctrl *gomock.Controller) *MockFeeScheduleServices {
+	mock := &MockFeeScheduleServices{ctrl: ctrl}
+	mock.recorder = &MockFeeScheduleServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeScheduleServices) EXPECT() *MockFeeScheduleServicesMockRecorder {
+	return m.recorder
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockFeeScheduleServices) DeleteSchedule(ctx context.Context, partnerID uint64, tenorMonths uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, partnerID, tenorMonths)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockFeeScheduleServicesMockRecorder) DeleteSchedule(ctx, partnerID, tenorMonths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockFeeScheduleServices)(nil).DeleteSchedule), ctx, partnerID, tenorMonths)
+}
+
+// ListSchedules mocks base method.
+func (m *MockFeeScheduleServices) ListSchedules(ctx context.Context, partnerID uint64) ([]domain.PartnerFeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", ctx, partnerID)
+	ret0, _ := ret[0].([]domain.PartnerFeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockFeeScheduleServicesMockRecorder) ListSchedules(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockFeeScheduleServices)(nil).ListSchedules), ctx, partnerID)
+}
+
+// SetSchedule mocks base method.
+func (m *MockFeeScheduleServices) SetSchedule(ctx context.Context, partnerID, updatedBy uint64, req dto.FeeScheduleRequest) (*domain.PartnerFeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", ctx, partnerID, updatedBy, req)
+	ret0, _ := ret[0].(*domain.PartnerFeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockFeeScheduleServicesMockRecorder) SetSchedule(ctx, partnerID, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockFeeScheduleServices)(nil).SetSchedule), ctx, partnerID, updatedBy, req)
+}
+
+// MockPaymentServices is a mock of PaymentServices interface.
+type MockPaymentServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentServicesMockRecorder is the mock recorder for MockPaymentServices.
+type MockPaymentServicesMockRecorder struct {
+	mock *MockPaymentServices
+}
+
+// NewMockPaymentServices creates a new mock instance.
+func NewMockPaymentServices(ctrl *gomock.Controller) *MockPaymentServices {
+	mock := &MockPaymentServices{ctrl: ctrl}
+	mock.recorder = &MockPaymentServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentServices) EXPECT() *MockPaymentServicesMockRecorder {
+	return m.recorder
+}
+
+// HandleCallback mocks base method.
+func (m *MockPaymentServices) HandleCallback(ctx context.Context, provider string, header http.Header, body []byte) (*domain.PaymentCallback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCallback", ctx, provider, header, body)
+	ret0, _ := ret[0].(*domain.PaymentCallback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCallback indicates an expected call of HandleCallback.
+func (mr *MockPaymentServicesMockRecorder) HandleCallback(ctx, provider, header, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCallback", reflect.TypeOf((*MockPaymentServices)(nil).HandleCallback), ctx, provider, header, body)
+}
+
+// RecordPayment mocks base method.
+func (m *MockPaymentServices) RecordPayment(ctx context.Context, contractNumber string, payment *domain.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayment", ctx, contractNumber, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordPayment indicates an expected call of RecordPayment.
+func (mr *MockPaymentServicesMockRecorder) RecordPayment(ctx, contractNumber, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayment", reflect.TypeOf((*MockPaymentServices)(nil).RecordPayment), ctx, contractNumber, payment)
+}
+
+// MockVirtualAccountServices is a mock of VirtualAccountServices interface.
+type MockVirtualAccountServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualAccountServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockVirtualAccountServicesMockRecorder is the mock recorder for MockVirtualAccountServices.
+type MockVirtualAccountServicesMockRecorder struct {
+	mock *MockVirtualAccountServices
+}
+
+// NewMockVirtualAccountServices creates a new mock instance.
+func NewMockVirtualAccountServices(ctrl *gomock.Controller) *MockVirtualAccountServices {
+	mock := &MockVirtualAccountServices{ctrl: ctrl}
+	mock.recorder = &MockVirtualAccountServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualAccountServices) EXPECT() *MockVirtualAccountServicesMockRecorder {
+	return m.recorder
+}
+
+// ProvisionPending mocks base method.
+func (m *MockVirtualAccountServices) ProvisionPending(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProvisionPending", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProvisionPending indicates an expected call of ProvisionPending.
+func (mr *MockVirtualAccountServicesMockRecorder) ProvisionPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisionPending", reflect.TypeOf((*MockVirtualAccountServices)(nil).ProvisionPending), ctx)
+}
+
+// MockDirectDebitServices is a mock of DirectDebitServices interface.
+type MockDirectDebitServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockDirectDebitServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockDirectDebitServicesMockRecorder is the mock recorder for MockDirectDebitServices.
+type MockDirectDebitServicesMockRecorder struct {
+	mock *MockDirectDebitServices
+}
+
+// NewMockDirectDebitServices creates a new mock instance.
+func NewMockDirectDebitServices(ctrl *gomock.Controller) *MockDirectDebitServices {
+	mock := &MockDirectDebitServices{ctrl: ctrl}
+	mock.recorder = &MockDirectDebitServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDirectDebitServices) EXPECT() *MockDirectDebitServicesMockRecorder {
+	return m.recorder
+}
+
+// DeleteMandate mocks base method.
+func (m *MockDirectDebitServices) DeleteMandate(ctx context.Context, customerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMandate", ctx, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMandate indicates an expected call of DeleteMandate.
+func (mr *MockDirectDebitServicesMockRecorder) DeleteMandate(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMandate", reflect.TypeOf((*MockDirectDebitServices)(nil).DeleteMandate), ctx, customerID)
+}
+
+// GetMandate mocks base method.
+func (m *MockDirectDebitServices) GetMandate(ctx context.Context, customerID uint64) (*domain.DirectDebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMandate", ctx, customerID)
+	ret0, _ := ret[0].(*domain.DirectDebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMandate indicates an expected call of GetMandate.
+func (mr *MockDirectDebitServicesMockRecorder) GetMandate(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMandate", reflect.TypeOf((*MockDirectDebitServices)(nil).GetMandate), ctx, customerID)
+}
+
+// Run mocks base method.
+func (m *MockDirectDebitServices) Run(ctx context.Context, now time.Time) (*domain.DirectDebitRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, now)
+	ret0, _ := ret[0].(*domain.DirectDebitRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockDirectDebitServicesMockRecorder) Run(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDirectDebitServices)(nil).Run), ctx, now)
+}
+
+// SetMandate mocks base method.
+func (m *MockDirectDebitServices) SetMandate(ctx context.Context, customerID uint64, req dto.DirectDebitMandateRequest) (*domain.DirectDebitMandate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMandate", ctx, customerID, req)
+	ret0, _ := ret[0].(*domain.DirectDebitMandate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMandate indicates an expected call of SetMandate.
+func (mr *MockDirectDebitServicesMockRecorder) SetMandate(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMandate", reflect.TypeOf((*MockDirectDebitServices)(nil).SetMandate), ctx, customerID, req)
+}
+
+// MockPromotionServices is a mock of PromotionServices interface.
+type MockPromotionServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionServicesMockRecorder is the mock recorder for MockPromotionServices.
+type MockPromotionServicesMockRecorder struct {
+	mock *MockPromotionServices
+}
+
+// NewMockPromotionServices creates a new mock instance.
+func NewMockPromotionServices(ctrl *gomock.Controller) *MockPromotionServices {
+	mock := &MockPromotionServices{ctrl: ctrl}
+	mock.recorder = &MockPromotionServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionServices) EXPECT() *MockPromotionServicesMockRecorder {
+	return m.recorder
+}
+
+// DeactivatePromotion mocks base method.
+func (m *MockPromotionServices) DeactivatePromotion(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivatePromotion", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivatePromotion indicates an expected call of DeactivatePromotion.
+func (mr *MockPromotionServicesMockRecorder) DeactivatePromotion(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivatePromotion", reflect.TypeOf((*MockPromotionServices)(nil).DeactivatePromotion), ctx, code)
+}
+
+// ListPromotions mocks base method.
+func (m *MockPromotionServices) ListPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotions", ctx)
+	ret0, _ := ret[0].([]domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotions indicates an expected call of ListPromotions.
+func (mr *MockPromotionServicesMockRecorder) ListPromotions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotions", reflect.TypeOf((*MockPromotionServices)(nil).ListPromotions), ctx)
+}
+
+// SetPromotion mocks base method.
+func (m *MockPromotionServices) SetPromotion(ctx context.Context, code string, updatedBy uint64, req dto.PromotionRequest) (*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPromotion", ctx, code, updatedBy, req)
+	ret0, _ := ret[0].(*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPromotion indicates an expected call of SetPromotion.
+func (mr *MockPromotionServicesMockRecorder) SetPromotion(ctx, code, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPromotion", reflect.TypeOf((*MockPromotionServices)(nil).SetPromotion), ctx, code, updatedBy, req)
+}
+
+// MockReferralServices is a mock of ReferralServices interface.
+type MockReferralServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferralServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockReferralServicesMockRecorder is the mock recorder for MockReferralServices.
+type MockReferralServicesMockRecorder struct {
+	mock *MockReferralServices
+}
+
+// NewMockReferralServices creates a new mock instance.
+func NewMockReferralServices(ctrl *gomock.Controller) *MockReferralServices {
+	mock := &MockReferralServices{ctrl: ctrl}
+	mock.recorder = &MockReferralServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferralServices) EXPECT() *MockReferralServicesMockRecorder {
+	return m.recorder
+}
+
+// GetMyCode mocks base method.
+func (m *MockReferralServices) GetMyCode(ctx context.Context, customerID uint64) (*domain.ReferralCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyCode", ctx, customerID)
+	ret0, _ := ret[0].(*domain.ReferralCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyCode indicates an expected call of GetMyCode.
+func (mr *MockReferralServicesMockRecorder) GetMyCode(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyCode", reflect.TypeOf((*MockReferralServices)(nil).GetMyCode), ctx, customerID)
+}
+
+// Record mocks base method.
+func (m *MockReferralServices) Record(ctx context.Context, code *domain.ReferralCode, referee *domain.Customer) (*domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, code, referee)
+	ret0, _ := ret[0].(*domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockReferralServicesMockRecorder) Record(ctx, code, referee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockReferralServices)(nil).Record), ctx, code, referee)
+}
+
+// Resolve mocks base method.
+func (m *MockReferralServices) Resolve(ctx context.Context, code string) (*domain.ReferralCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, code)
+	ret0, _ := ret[0].(*domain.ReferralCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockReferralServicesMockRecorder) Resolve(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockReferralServices)(nil).Resolve), ctx, code)
+}
+
+// MockOTPServices is a mock of OTPServices interface.
+type MockOTPServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockOTPServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockOTPServicesMockRecorder is the mock recorder for MockOTPServices.
+type MockOTPServicesMockRecorder struct {
+	mock *MockOTPServices
+}
+
+// NewMockOTPServices creates a new mock instance.
+func NewMockOTPServices(ctrl *gomock.Controller) *MockOTPServices {
+	mock := &MockOTPServices{ctrl: ctrl}
+	mock.recorder = &MockOTPServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOTPServices) EXPECT() *MockOTPServicesMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockOTPServices) Consume(ctx context.Context, token string, purpose domain.OTPPurpose, customerID uint64) (*domain.OTPVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, token, purpose, customerID)
+	ret0, _ := ret[0].(*domain.OTPVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockOTPServicesMockRecorder) Consume(ctx, token, purpose, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockOTPServices)(nil).Consume), ctx, token, purpose, customerID)
+}
+
+// Request mocks base method.
+func (m *MockOTPServices) Request(ctx context.Context, customerID uint64, req dto.OTPRequest) (*domain.OTPChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", ctx, customerID, req)
+	ret0, _ := ret[0].(*domain.OTPChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockOTPServicesMockRecorder) Request(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockOTPServices)(nil).Request), ctx, customerID, req)
+}
+
+// Verify mocks base method.
+func (m *MockOTPServices) Verify(ctx context.Context, req dto.OTPVerifyRequest) (*domain.OTPVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, req)
+	ret0, _ := ret[0].(*domain.OTPVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockOTPServicesMockRecorder) Verify(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockOTPServices)(nil).Verify), ctx, req)
+}
+
+// MockImpersonationServices is a mock of ImpersonationServices interface.
+type MockImpersonationServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockImpersonationServicesMockRecorder is the mock recorder for MockImpersonationServices.
+type MockImpersonationServicesMockRecorder struct {
+	mock *MockImpersonationServices
+}
+
+// NewMockImpersonationServices creates a new mock instance.
+func NewMockImpersonationServices(ctrl *gomock.Controller) *MockImpersonationServices {
+	mock := &MockImpersonationServices{ctrl: ctrl}
+	mock.recorder = &MockImpersonationServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonationServices) EXPECT() *MockImpersonationServicesMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockImpersonationServices) Authorize(ctx context.Context, sessionID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockImpersonationServicesMockRecorder) Authorize(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockImpersonationServices)(nil).Authorize), ctx, sessionID)
+}
+
+// End mocks base method.
+func (m *MockImpersonationServices) End(ctx context.Context, sessionID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// End indicates an expected call of End.
+func (mr *MockImpersonationServicesMockRecorder) End(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockImpersonationServices)(nil).End), ctx, sessionID)
+}
+
+// ListRequests mocks base method.
+func (m *MockImpersonationServices) ListRequests(ctx context.Context, sessionID uint64) ([]domain.ImpersonationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRequests", ctx, sessionID)
+	ret0, _ := ret[0].([]domain.ImpersonationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRequests indicates an expected call of ListRequests.
+func (mr *MockImpersonationServicesMockRecorder) ListRequests(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRequests", reflect.TypeOf((*MockImpersonationServices)(nil).ListRequests), ctx, sessionID)
+}
+
+// RecordRequest mocks base method.
+func (m *MockImpersonationServices) RecordRequest(ctx context.Context, request domain.ImpersonationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRequest indicates an expected call of RecordRequest.
+func (mr *MockImpersonationServicesMockRecorder) RecordRequest(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRequest", reflect.TypeOf((*MockImpersonationServices)(nil).RecordRequest), ctx, request)
+}
+
+// Start mocks base method.
+func (m *MockImpersonationServices) Start(ctx context.Context, adminID, customerID uint64, req dto.ImpersonationRequest) (*dto.ImpersonationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, adminID, customerID, req)
+	ret0, _ := ret[0].(*dto.ImpersonationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockImpersonationServicesMockRecorder) Start(ctx, adminID, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockImpersonationServices)(nil).Start), ctx, adminID, customerID, req)
+}
+
+// MockRequestSignatureServices is a mock of RequestSignatureServices interface.
+type MockRequestSignatureServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestSignatureServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockRequestSignatureServicesMockRecorder is the mock recorder for MockRequestSignatureServices.
+type MockRequestSignatureServicesMockRecorder struct {
+	mock *MockRequestSignatureServices
+}
+
+// NewMockRequestSignatureServices creates a new mock instance.
+func NewMockRequestSignatureServices(ctrl *gomock.Controller) *MockRequestSignatureServices {
+	mock := &MockRequestSignatureServices{ctrl: ctrl}
+	mock.recorder = &MockRequestSignatureServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestSignatureServices) EXPECT() *MockRequestSignatureServicesMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockRequestSignatureServices) Verify(ctx context.Context, partner *domain.Partner, req domain.SignedRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, partner, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockRequestSignatureServicesMockRecorder) Verify(ctx, partner, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockRequestSignatureServices)(nil).Verify), ctx, partner, req)
+}
+
+// MockMaintenanceServices is a mock of MaintenanceServices interface.
+type MockMaintenanceServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockMaintenanceServicesMockRecorder is the mock recorder for MockMaintenanceServices.
+type MockMaintenanceServicesMockRecorder struct {
+	mock *MockMaintenanceServices
+}
+
+// NewMockMaintenanceServices creates a new mock instance.
+func NewMockMaintenanceServices(ctrl *gomock.Controller) *MockMaintenanceServices {
+	mock := &MockMaintenanceServices{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceServices) EXPECT() *MockMaintenanceServicesMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockMaintenanceServices) Check(ctx context.Context, scope domain.GateScope, now time.Time) (*domain.GateClosure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, scope, now)
+	ret0, _ := ret[0].(*domain.GateClosure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockMaintenanceServicesMockRecorder) Check(ctx, scope, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockMaintenanceServices)(nil).Check), ctx, scope, now)
+}
+
+// ClearMode mocks base method.
+func (m *MockMaintenanceServices) ClearMode(ctx context.Context, scope domain.GateScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearMode", ctx, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearMode indicates an expected call of ClearMode.
+func (mr *MockMaintenanceServicesMockRecorder) ClearMode(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearMode", reflect.TypeOf((*MockMaintenanceServices)(nil).ClearMode), ctx, scope)
+}
+
+// ListModes mocks base method.
+func (m *MockMaintenanceServices) ListModes(ctx context.Context) ([]domain.MaintenanceMode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModes", ctx)
+	ret0, _ := ret[0].([]domain.MaintenanceMode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModes indicates an expected call of ListModes.
+func (mr *MockMaintenanceServicesMockRecorder) ListModes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModes", reflect.TypeOf((*MockMaintenanceServices)(nil).ListModes), ctx)
+}
+
+// SetMode mocks base method.
+func (m *MockMaintenanceServices) SetMode(ctx context.Context, scope domain.GateScope, updatedBy uint64, req dto.MaintenanceRequest) (*domain.MaintenanceMode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMode", ctx, scope, updatedBy, req)
+	ret0, _ := ret[0].(*domain.MaintenanceMode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMode indicates an expected call of SetMode.
+func (mr *MockMaintenanceServicesMockRecorder) SetMode(ctx, scope, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMode", reflect.TypeOf((*MockMaintenanceServices)(nil).SetMode), ctx, scope, updatedBy, req)
+}
+
+// MockPartnerQuotaServices is a mock of PartnerQuotaServices interface.
+type MockPartnerQuotaServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerQuotaServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerQuotaServicesMockRecorder is the mock recorder for MockPartnerQuotaServices.
+type MockPartnerQuotaServicesMockRecorder struct {
+	mock *MockPartnerQuotaServices
+}
+
+// NewMockPartnerQuotaServices creates a new mock instance.
+func NewMockPartnerQuotaServices(ctrl *gomock.Controller) *MockPartnerQuotaServices {
+	mock := &MockPartnerQuotaServices{ctrl: ctrl}
+	mock.recorder = &MockPartnerQuotaServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerQuotaServices) EXPECT() *MockPartnerQuotaServicesMockRecorder {
+	return m.recorder
+}
+
+// DeleteQuota mocks base method.
+func (m *MockPartnerQuotaServices) DeleteQuota(ctx context.Context, partnerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuota", ctx, partnerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuota indicates an expected call of DeleteQuota.
+func (mr *MockPartnerQuotaServicesMockRecorder) DeleteQuota(ctx, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuota", reflect.TypeOf((*MockPartnerQuotaServices)(nil).DeleteQuota), ctx, partnerID)
+}
+
+// GetQuota mocks base method.
+func (m *MockPartnerQuotaServices) GetQuota(ctx context.Context, partnerID uint64, now time.Time) (*domain.PartnerQuota, *domain.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuota", ctx, partnerID, now)
+	ret0, _ := ret[0].(*domain.PartnerQuota)
+	ret1, _ := ret[1].(*domain.QuotaUsage)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetQuota indicates an expected call of GetQuota.
+func (mr *MockPartnerQuotaServicesMockRecorder) GetQuota(ctx, partnerID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuota", reflect.TypeOf((*MockPartnerQuotaServices)(nil).GetQuota), ctx, partnerID, now)
+}
+
+// Release mocks base method.
+func (m *MockPartnerQuotaServices) Release(ctx context.Context, reservation *domain.QuotaReservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, reservation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockPartnerQuotaServicesMockRecorder) Release(ctx, reservation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockPartnerQuotaServices)(nil).Release), ctx, reservation)
+}
+
+// Reserve mocks base method.
+func (m *MockPartnerQuotaServices) Reserve(ctx context.Context, partnerID uint64, volume decimal.Decimal, now time.Time) (*domain.QuotaReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, partnerID, volume, now)
+	ret0, _ := ret[0].(*domain.QuotaReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockPartnerQuotaServicesMockRecorder) Reserve(ctx, partnerID, volume, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockPartnerQuotaServices)(nil).Reserve), ctx, partnerID, volume, now)
+}
+
+// SetQuota mocks base method.
+func (m *MockPartnerQuotaServices) SetQuota(ctx context.Context, partnerID, updatedBy uint64, req dto.PartnerQuotaRequest) (*domain.PartnerQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetQuota", ctx, partnerID, updatedBy, req)
+	ret0, _ := ret[0].(*domain.PartnerQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetQuota indicates an expected call of SetQuota.
+func (mr *MockPartnerQuotaServicesMockRecorder) SetQuota(ctx, partnerID, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQuota", reflect.TypeOf((*MockPartnerQuotaServices)(nil).SetQuota), ctx, partnerID, updatedBy, req)
+}
+
+// MockTransactionBatchServices is a mock of TransactionBatchServices interface.
+type MockTransactionBatchServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionBatchServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionBatchServicesMockRecorder is the mock recorder for MockTransactionBatchServices.
+type MockTransactionBatchServicesMockRecorder struct {
+	mock *MockTransactionBatchServices
+}
+
+// NewMockTransactionBatchServices creates a new mock instance.
+func NewMockTransactionBatchServices(ctrl *gomock.Controller) *MockTransactionBatchServices {
+	mock := &MockTransactionBatchServices{ctrl: ctrl}
+	mock.recorder = &MockTransactionBatchServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionBatchServices) EXPECT() *MockTransactionBatchServicesMockRecorder {
+	return m.recorder
+}
+
+// GetBatch mocks base method.
+func (m *MockTransactionBatchServices) GetBatch(ctx context.Context, id uint64, owner domain.BatchOwner) (*domain.TransactionBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatch", ctx, id, owner)
+	ret0, _ := ret[0].(*domain.TransactionBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockTransactionBatchServicesMockRecorder) GetBatch(ctx, id, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockTransactionBatchServices)(nil).GetBatch), ctx, id, owner)
+}
+
+// ProcessPending mocks base method.
+func (m *MockTransactionBatchServices) ProcessPending(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPending", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessPending indicates an expected call of ProcessPending.
+func (mr *MockTransactionBatchServicesMockRecorder) ProcessPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPending", reflect.TypeOf((*MockTransactionBatchServices)(nil).ProcessPending), ctx)
+}
+
+// Submit mocks base method.
+func (m *MockTransactionBatchServices) Submit(ctx context.Context, owner domain.BatchOwner, req dto.CreateTransactionBatchRequest) (*domain.TransactionBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, owner, req)
+	ret0, _ := ret[0].(*domain.TransactionBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockTransactionBatchServicesMockRecorder) Submit(ctx, owner, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockTransactionBatchServices)(nil).Submit), ctx, owner, req)
+}
+
+// MockTenorServices is a mock of TenorServices interface.
+type MockTenorServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenorServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockTenorServicesMockRecorder is the mock recorder for MockTenorServices.
+type MockTenorServicesMockRecorder struct {
+	mock *MockTenorServices
+}
+
+// NewMockTenorServices creates a new mock instance.
+func NewMockTenorServices(ctrl *gomock.Controller) *MockTenorServices {
+	mock := &MockTenorServices{ctrl: ctrl}
+	mock.recorder = &MockTenorServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenorServices) EXPECT() *MockTenorServicesMockRecorder {
+	return m.recorder
+}
+
+// CreateTenor mocks base method.
+func (m *MockTenorServices) CreateTenor(ctx context.Context, req dto.CreateTenorRequest) (*domain.Tenor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTenor", ctx, req)
+	ret0, _ := ret[0].(*domain.Tenor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTenor indicates an expected call of CreateTenor.
+func (mr *MockTenorServicesMockRecorder) CreateTenor(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenor", reflect.TypeOf((*MockTenorServices)(nil).CreateTenor), ctx, req)
+}
+
+// DeactivateTenor mocks base method.
+func (m *MockTenorServices) DeactivateTenor(ctx context.Context, id uint) (*domain.Tenor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTenor", ctx, id)
+	ret0, _ := ret[0].(*domain.Tenor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTenor indicates an expected call of DeactivateTenor.
+func (mr *MockTenorServicesMockRecorder) DeactivateTenor(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTenor", reflect.TypeOf((*MockTenorServices)(nil).DeactivateTenor), ctx, id)
+}
+
+// DeleteTenor mocks base method.
+func (m *MockTenorServices) DeleteTenor(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTenor", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTenor indicates an expected call of DeleteTenor.
+func (mr *MockTenorServicesMockRecorder) DeleteTenor(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenor", reflect.TypeOf((*MockTenorServices)(nil).DeleteTenor), ctx, id)
+}
+
+// ListTenors mocks base method.
+func (m *MockTenorServices) ListTenors(ctx context.Context) ([]domain.Tenor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenors", ctx)
+	ret0, _ := ret[0].([]domain.Tenor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTenors indicates an expected call of ListTenors.
+func (mr *MockTenorServicesMockRecorder) ListTenors(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenors", reflect.TypeOf((*MockTenorServices)(nil).ListTenors), ctx)
+}
+
+// SeedTenors mocks base method.
+func (m *MockTenorServices) SeedTenors(ctx context.Context, tenors []dto.CreateTenorRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedTenors", ctx, tenors)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SeedTenors indicates an expected call of SeedTenors.
+func (mr *MockTenorServicesMockRecorder) SeedTenors(ctx, tenors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedTenors", reflect.TypeOf((*MockTenorServices)(nil).SeedTenors), ctx, tenors)
+}
+
+// UpdateTenor mocks base method.
+func (m *MockTenorServices) UpdateTenor(ctx context.Context, id uint, req dto.UpdateTenorRequest) (*domain.Tenor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenor", ctx, id, req)
+	ret0, _ := ret[0].(*domain.Tenor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTenor indicates an expected call of UpdateTenor.
+func (mr *MockTenorServicesMockRecorder) UpdateTenor(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenor", reflect.TypeOf((*MockTenorServices)(nil).UpdateTenor), ctx, id, req)
+}
+
+// MockReferenceDataServices is a mock of ReferenceDataServices interface.
+type MockReferenceDataServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferenceDataServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockReferenceDataServicesMockRecorder is the mock recorder for MockReferenceDataServices.
+type MockReferenceDataServicesMockRecorder struct {
+	mock *MockReferenceDataServices
+}
+
+// NewMockReferenceDataServices creates a new mock instance.
+func NewMockReferenceDataServices(ctrl *gomock.Controller) *MockReferenceDataServices {
+	mock := &MockReferenceDataServices{ctrl: ctrl}
+	mock.recorder = &MockReferenceDataServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferenceDataServices) EXPECT() *MockReferenceDataServicesMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockReferenceDataServices) Apply(ctx context.Context, data *refdata.Set) (*domain.ReferenceDataResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, data)
+	ret0, _ := ret[0].(*domain.ReferenceDataResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockReferenceDataServicesMockRecorder) Apply(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockReferenceDataServices)(nil).Apply), ctx, data)
+}
+
+// MockStatementServices is a mock of StatementServices interface.
+type MockStatementServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementServicesMockRecorder is the mock recorder for MockStatementServices.
+type MockStatementServicesMockRecorder struct {
+	mock *MockStatementServices
+}
+
+// NewMockStatementServices creates a new mock instance.
+func NewMockStatementServices(ctrl *gomock.Controller) *MockStatementServices {
+	mock := &MockStatementServices{ctrl: ctrl}
+	mock.recorder = &MockStatementServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementServices) EXPECT() *MockStatementServicesMockRecorder {
+	return m.recorder
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementServices) GetStatement(ctx context.Context, customerID uint64, contractNumber string) (*domain.TransactionStatement, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, customerID, contractNumber)
+	ret0, _ := ret[0].(*domain.TransactionStatement)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementServicesMockRecorder) GetStatement(ctx, customerID, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementServices)(nil).GetStatement), ctx, customerID, contractNumber)
+}
+
+// ProcessPending mocks base method.
+func (m *MockStatementServices) ProcessPending(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPending", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessPending indicates an expected call of ProcessPending.
+func (mr *MockStatementServicesMockRecorder) ProcessPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPending", reflect.TypeOf((*MockStatementServices)(nil).ProcessPending), ctx)
+}
+
+// SendMonthlyStatements mocks base method.
+func (m *MockStatementServices) SendMonthlyStatements(ctx context.Context, now time.Time) (*domain.MonthlyStatementRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMonthlyStatements", ctx, now)
+	ret0, _ := ret[0].(*domain.MonthlyStatementRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMonthlyStatements indicates an expected call of SendMonthlyStatements.
+func (mr *MockStatementServicesMockRecorder) SendMonthlyStatements(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMonthlyStatements", reflect.TypeOf((*MockStatementServices)(nil).SendMonthlyStatements), ctx, now)
+}
+
+// SetMonthlyStatementOptOut mocks base method.
+func (m *MockStatementServices) SetMonthlyStatementOptOut(ctx context.Context, customerID uint64, optOut bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMonthlyStatementOptOut", ctx, customerID, optOut)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMonthlyStatementOptOut indicates an expected call of SetMonthlyStatementOptOut.
+func (mr *MockStatementServicesMockRecorder) SetMonthlyStatementOptOut(ctx, customerID, optOut any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMonthlyStatementOptOut", reflect.TypeOf((*MockStatementServices)(nil).SetMonthlyStatementOptOut), ctx, customerID, optOut)
+}
+
+// MockCustomerNoteServices is a mock of CustomerNoteServices interface.
+type MockCustomerNoteServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerNoteServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerNoteServicesMockRecorder is the mock recorder for MockCustomerNoteServices.
+type MockCustomerNoteServicesMockRecorder struct {
+	mock *MockCustomerNoteServices
+}
+
+// NewMockCustomerNoteServices creates a new mock instance.
+func NewMockCustomerNoteServices(ctrl *gomock.Controller) *MockCustomerNoteServices {
+	mock := &MockCustomerNoteServices{ctrl: ctrl}
+	mock.recorder = &MockCustomerNoteServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerNoteServices) EXPECT() *MockCustomerNoteServicesMockRecorder {
+	return m.recorder
+}
+
+// CreateNote mocks base method.
+func (m *MockCustomerNoteServices) CreateNote(ctx context.Context, customerID, authorID uint64, req dto.CustomerNoteRequest) (*domain.CustomerNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNote", ctx, customerID, authorID, req)
+	ret0, _ := ret[0].(*domain.CustomerNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNote indicates an expected call of CreateNote.
+func (mr *MockCustomerNoteServicesMockRecorder) CreateNote(ctx, customerID, authorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockCustomerNoteServices)(nil).CreateNote), ctx, customerID, authorID, req)
+}
+
+// ListNotes mocks base method.
+func (m *MockCustomerNoteServices) ListNotes(ctx context.Context, customerID uint64) ([]domain.CustomerNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotes", ctx, customerID)
+	ret0, _ := ret[0].([]domain.CustomerNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotes indicates an expected call of ListNotes.
+func (mr *MockCustomerNoteServicesMockRecorder) ListNotes(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotes", reflect.TypeOf((*MockCustomerNoteServices)(nil).ListNotes), ctx, customerID)
+}
+
+// UpdateNote mocks base method.
+func (m *MockCustomerNoteServices) UpdateNote(ctx context.Context, customerID, noteID, updatedBy uint64, req dto.CustomerNoteRequest) (*domain.CustomerNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, customerID, noteID, updatedBy, req)
+	ret0, _ := ret[0].(*domain.CustomerNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MockCustomerNoteServicesMockRecorder) UpdateNote(ctx, customerID, noteID, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockCustomerNoteServices)(nil).UpdateNote), ctx, customerID, noteID, updatedBy, req)
+}
+
+// MockTransactionAttachmentServices is a mock of TransactionAttachmentServices interface.
+type MockTransactionAttachmentServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionAttachmentServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionAttachmentServicesMockRecorder is the mock recorder for MockTransactionAttachmentServices.
+type MockTransactionAttachmentServicesMockRecorder struct {
+	mock *MockTransactionAttachmentServices
+}
+
+// NewMockTransactionAttachmentServices creates a new mock instance.
+func NewMockTransactionAttachmentServices(ctrl *gomock.Controller) *MockTransactionAttachmentServices {
+	mock := &MockTransactionAttachmentServices{ctrl: ctrl}
+	mock.recorder = &MockTransactionAttachmentServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionAttachmentServices) EXPECT() *MockTransactionAttachmentServicesMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockTransactionAttachmentServices) DeleteAttachment(ctx context.Context, transactionID, attachmentID, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, transactionID, attachmentID, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockTransactionAttachmentServicesMockRecorder) DeleteAttachment(ctx, transactionID, attachmentID, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockTransactionAttachmentServices)(nil).DeleteAttachment), ctx, transactionID, attachmentID, adminID)
+}
+
+// ListAllAttachments mocks base method.
+func (m *MockTransactionAttachmentServices) ListAllAttachments(ctx context.Context, transactionID uint64) ([]domain.TransactionAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllAttachments", ctx, transactionID)
+	ret0, _ := ret[0].([]domain.TransactionAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllAttachments indicates an expected call of ListAllAttachments.
+func (mr *MockTransactionAttachmentServicesMockRecorder) ListAllAttachments(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllAttachments", reflect.TypeOf((*MockTransactionAttachmentServices)(nil).ListAllAttachments), ctx, transactionID)
+}
+
+// ListAttachments mocks base method.
+func (m *MockTransactionAttachmentServices) ListAttachments(ctx context.Context, owner domain.BatchOwner, transactionID uint64) ([]domain.TransactionAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, owner, transactionID)
+	ret0, _ := ret[0].([]domain.TransactionAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockTransactionAttachmentServicesMockRecorder) ListAttachments(ctx, owner, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockTransactionAttachmentServices)(nil).ListAttachments), ctx, owner, transactionID)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockTransactionAttachmentServices) UploadAttachment(ctx context.Context, owner domain.BatchOwner, transactionID uint64, req dto.TransactionAttachmentRequest, fileName string, data []byte) (*domain.TransactionAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, owner, transactionID, req, fileName, data)
+	ret0, _ := ret[0].(*domain.TransactionAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockTransactionAttachmentServicesMockRecorder) UploadAttachment(ctx, owner, transactionID, req, fileName, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockTransactionAttachmentServices)(nil).UploadAttachment), ctx, owner, transactionID, req, fileName, data)
+}
+
+// MockRegionServices is a mock of RegionServices interface.
+type MockRegionServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegionServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockRegionServicesMockRecorder is the mock recorder for MockRegionServices.
+type MockRegionServicesMockRecorder struct {
+	mock *MockRegionServices
+}
+
+// NewMockRegionServices creates a new mock instance.
+func NewMockRegionServices(ctrl *gomock.Controller) *MockRegionServices {
+	mock := &MockRegionServices{ctrl: ctrl}
+	mock.recorder = &MockRegionServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegionServices) EXPECT() *MockRegionServicesMockRecorder {
+	return m.recorder
+}
+
+// ListRegions mocks base method.
+func (m *MockRegionServices) ListRegions(ctx context.Context) ([]domain.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegions", ctx)
+	ret0, _ := ret[0].([]domain.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegions indicates an expected call of ListRegions.
+func (mr *MockRegionServicesMockRecorder) ListRegions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegions", reflect.TypeOf((*MockRegionServices)(nil).ListRegions), ctx)
+}
+
+// SetPartnerRegion mocks base method.
+func (m *MockRegionServices) SetPartnerRegion(ctx context.Context, partnerID uint64, req dto.PartnerRegionRequest) (*domain.Partner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPartnerRegion", ctx, partnerID, req)
+	ret0, _ := ret[0].(*domain.Partner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPartnerRegion indicates an expected call of SetPartnerRegion.
+func (mr *MockRegionServicesMockRecorder) SetPartnerRegion(ctx, partnerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPartnerRegion", reflect.TypeOf((*MockRegionServices)(nil).SetPartnerRegion), ctx, partnerID, req)
+}
+
+// SetRegion mocks base method.
+func (m *MockRegionServices) SetRegion(ctx context.Context, code string, req dto.RegionRequest) (*domain.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRegion", ctx, code, req)
+	ret0, _ := ret[0].(*domain.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRegion indicates an expected call of SetRegion.
+func (mr *MockRegionServicesMockRecorder) SetRegion(ctx, code, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRegion", reflect.TypeOf((*MockRegionServices)(nil).SetRegion), ctx, code, req)
+}
+
+// MockExposureServices is a mock of ExposureServices interface.
+type MockExposureServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockExposureServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockExposureServicesMockRecorder is the mock recorder for MockExposureServices.
+type MockExposureServicesMockRecorder struct {
+	mock *MockExposureServices
+}
+
+// NewMockExposureServices creates a new mock instance.
+func NewMockExposureServices(ctrl *gomock.Controller) *MockExposureServices {
+	mock := &MockExposureServices{ctrl: ctrl}
+	mock.recorder = &MockExposureServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExposureServices) EXPECT() *MockExposureServicesMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockExposureServices) Reconcile(ctx context.Context) (*domain.ExposureReconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].(*domain.ExposureReconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockExposureServicesMockRecorder) Reconcile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockExposureServices)(nil).Reconcile), ctx)
+}
+
+// MockCustomerTimelineServices is a mock of CustomerTimelineServices interface.
+type MockCustomerTimelineServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerTimelineServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerTimelineServicesMockRecorder is the mock recorder for MockCustomerTimelineServices.
+type MockCustomerTimelineServicesMockRecorder struct {
+	mock *MockCustomerTimelineServices
+}
+
+// NewMockCustomerTimelineServices creates a new mock instance.
+func NewMockCustomerTimelineServices(ctrl *gomock.Controller) *MockCustomerTimelineServices {
+	mock := &MockCustomerTimelineServices{ctrl: ctrl}
+	mock.recorder = &MockCustomerTimelineServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerTimelineServices) EXPECT() *MockCustomerTimelineServicesMockRecorder {
+	return m.recorder
+}
+
+// GetTimeline mocks base method.
+func (m *MockCustomerTimelineServices) GetTimeline(ctx context.Context, customerID uint64, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeline", ctx, customerID, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeline indicates an expected call of GetTimeline.
+func (mr *MockCustomerTimelineServicesMockRecorder) GetTimeline(ctx, customerID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeline", reflect.TypeOf((*MockCustomerTimelineServices)(nil).GetTimeline), ctx, customerID, params)
+}
+
+// MockContractLookupServices is a mock of ContractLookupServices interface.
+type MockContractLookupServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockContractLookupServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockContractLookupServicesMockRecorder is the mock recorder for MockContractLookupServices.
+type MockContractLookupServicesMockRecorder struct {
+	mock *MockContractLookupServices
+}
+
+// NewMockContractLookupServices creates a new mock instance.
+func NewMockContractLookupServices(ctrl *gomock.Controller) *MockContractLookupServices {
+	mock := &MockContractLookupServices{ctrl: ctrl}
+	mock.recorder = &MockContractLookupServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContractLookupServices) EXPECT() *MockContractLookupServicesMockRecorder {
+	return m.recorder
+}
+
+// FindByContractNumber mocks base method.
+func (m *MockContractLookupServices) FindByContractNumber(ctx context.Context, contractNumber string) (*dto.ContractLookupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByContractNumber", ctx, contractNumber)
+	ret0, _ := ret[0].(*dto.ContractLookupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByContractNumber indicates an expected call of FindByContractNumber.
+func (mr *MockContractLookupServicesMockRecorder) FindByContractNumber(ctx, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByContractNumber", reflect.TypeOf((*MockContractLookupServices)(nil).FindByContractNumber), ctx, contractNumber)
+}
+
+// Search mocks base method.
+func (m *MockContractLookupServices) Search(ctx context.Context, prefix string, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, prefix, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockContractLookupServicesMockRecorder) Search(ctx, prefix, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockContractLookupServices)(nil).Search), ctx, prefix, params)
+}
+
+// MockContractImportServices is a mock of ContractImportServices interface.
+type MockContractImportServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockContractImportServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockContractImportServicesMockRecorder is the mock recorder for MockContractImportServices.
+type MockContractImportServicesMockRecorder struct {
+	mock *MockContractImportServices
+}
+
+// NewMockContractImportServices creates a new mock instance.
+func NewMockContractImportServices(ctrl *gomock.Controller) *MockContractImportServices {
+	mock := &MockContractImportServices{ctrl: ctrl}
+	mock.recorder = &MockContractImportServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContractImportServices) EXPECT() *MockContractImportServicesMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockContractImportServices) Import(ctx context.Context, req dto.ContractImportRequest) (*dto.ContractImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, req)
+	ret0, _ := ret[0].(*dto.ContractImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockContractImportServicesMockRecorder) Import(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockContractImportServices)(nil).Import), ctx, req)
+}
+
+// MockExportServices is a mock of ExportServices interface.
+type MockExportServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockExportServicesMockRecorder is the mock recorder for MockExportServices.
+type MockExportServicesMockRecorder struct {
+	mock *MockExportServices
+}
+
+// NewMockExportServices creates a new mock instance.
+func NewMockExportServices(ctrl *gomock.Controller) *MockExportServices {
+	mock := &MockExportServices{ctrl: ctrl}
+	mock.recorder = &MockExportServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportServices) EXPECT() *MockExportServicesMockRecorder {
+	return m.recorder
+}
+
+// ExportCustomers mocks base method.
+func (m *MockExportServices) ExportCustomers(ctx context.Context, w io.Writer, status domain.VerificationStatus) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCustomers", ctx, w, status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCustomers indicates an expected call of ExportCustomers.
+func (mr *MockExportServicesMockRecorder) ExportCustomers(ctx, w, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCustomers", reflect.TypeOf((*MockExportServices)(nil).ExportCustomers), ctx, w, status)
+}
+
+// ExportTransactions mocks base method.
+func (m *MockExportServices) ExportTransactions(ctx context.Context, w io.Writer, from, to time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransactions", ctx, w, from, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTransactions indicates an expected call of ExportTransactions.
+func (mr *MockExportServicesMockRecorder) ExportTransactions(ctx, w, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransactions", reflect.TypeOf((*MockExportServices)(nil).ExportTransactions), ctx, w, from, to)
+}
+
+// MockCustomerRestrictionServices is a mock of CustomerRestrictionServices interface.
+type MockCustomerRestrictionServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRestrictionServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerRestrictionServicesMockRecorder is the mock recorder for MockCustomerRestrictionServices.
+type MockCustomerRestrictionServicesMockRecorder struct {
+	mock *MockCustomerRestrictionServices
+}
+
+// NewMockCustomerRestrictionServices creates a new mock instance.
+func NewMockCustomerRestrictionServices(ctrl *gomock.Controller) *MockCustomerRestrictionServices {
+	mock := &MockCustomerRestrictionServices{ctrl: ctrl}
+	mock.recorder = &MockCustomerRestrictionServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRestrictionServices) EXPECT() *MockCustomerRestrictionServicesMockRecorder {
+	return m.recorder
+}
+
+// ExpireDue mocks base method.
+func (m *MockCustomerRestrictionServices) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireDue", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireDue indicates an expected call of ExpireDue.
+func (mr *MockCustomerRestrictionServicesMockRecorder) ExpireDue(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireDue", reflect.TypeOf((*MockCustomerRestrictionServices)(nil).ExpireDue), ctx, now)
+}
+
+// FreezeLimit mocks base method.
+func (m *MockCustomerRestrictionServices) FreezeLimit(ctx context.Context, customerID uint64, tenorMonths uint8, adminID uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeLimit", ctx, customerID, tenorMonths, adminID, req)
+	ret0, _ := ret[0].(*domain.CustomerRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeLimit indicates an expected call of FreezeLimit.
+func (mr *MockCustomerRestrictionServicesMockRecorder) FreezeLimit(ctx, customerID, tenorMonths, adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeLimit", reflect.TypeOf((*MockCustomerRestrictionServices)(nil).FreezeLimit), ctx, customerID, tenorMonths, adminID, req)
+}
+
+// ListRestrictions mocks base method.
+func (m *MockCustomerRestrictionServices) ListRestrictions(ctx context.Context, customerID uint64) ([]domain.CustomerRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRestrictions", ctx, customerID)
+	ret0, _ := ret[0].([]domain.CustomerRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRestrictions indicates an expected call of ListRestrictions.
+func (mr *MockCustomerRestrictionServicesMockRecorder) ListRestrictions(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRestrictions", reflect.TypeOf((*MockCustomerRestrictionServices)(nil).ListRestrictions), ctx, customerID)
+}
+
+// Suspend mocks base method.
+func (m *MockCustomerRestrictionServices) Suspend(ctx context.Context, customerID, adminID uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, customerID, adminID, req)
+	ret0, _ := ret[0].(*domain.CustomerRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockCustomerRestrictionServicesMockRecorder) Suspend(ctx, customerID, adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockCustomerRestrictionServices)(nil).Suspend), ctx, customerID, adminID, req)
+}
+
+// UnfreezeLimit mocks base method.
+func (m *MockCustomerRestrictionServices) UnfreezeLimit(ctx context.Context, customerID uint64, tenorMonths uint8, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfreezeLimit", ctx, customerID, tenorMonths, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfreezeLimit indicates an expected call of UnfreezeLimit.
+func (mr *MockCustomerRestrictionServicesMockRecorder) UnfreezeLimit(ctx, customerID, tenorMonths, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfreezeLimit", reflect.TypeOf((*MockCustomerRestrictionServices)(nil).UnfreezeLimit), ctx, customerID, tenorMonths, adminID)
+}
+
+// Unsuspend mocks base method.
+func (m *MockCustomerRestrictionServices) Unsuspend(ctx context.Context, customerID, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, customerID, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockCustomerRestrictionServicesMockRecorder) Unsuspend(ctx, customerID, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockCustomerRestrictionServices)(nil).Unsuspend), ctx, customerID, adminID)
+}
+
+// MockDormancyServices is a mock of DormancyServices interface.
+type MockDormancyServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockDormancyServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockDormancyServicesMockRecorder is the mock recorder for MockDormancyServices.
+type MockDormancyServicesMockRecorder struct {
+	mock *MockDormancyServices
+}
+
+// NewMockDormancyServices creates a new mock instance.
+func NewMockDormancyServices(ctrl *gomock.Controller) *MockDormancyServices {
+	mock := &MockDormancyServices{ctrl: ctrl}
+	mock.recorder = &MockDormancyServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDormancyServices) EXPECT() *MockDormancyServicesMockRecorder {
+	return m.recorder
+}
+
+// Reactivate mocks base method.
+func (m *MockDormancyServices) Reactivate(ctx context.Context, customerID uint64, req dto.ReactivationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactivate", ctx, customerID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reactivate indicates an expected call of Reactivate.
+func (mr *MockDormancyServicesMockRecorder) Reactivate(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactivate", reflect.TypeOf((*MockDormancyServices)(nil).Reactivate), ctx, customerID, req)
+}
+
+// Run mocks base method.
+func (m *MockDormancyServices) Run(ctx context.Context, now time.Time) (*domain.DormancyRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, now)
+	ret0, _ := ret[0].(*domain.DormancyRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockDormancyServicesMockRecorder) Run(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDormancyServices)(nil).Run), ctx, now)
+}
+
+// Stats mocks base method.
+func (m *MockDormancyServices) Stats(ctx context.Context, days int, now time.Time) (*domain.DormancyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, days, now)
+	ret0, _ := ret[0].(*domain.DormancyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockDormancyServicesMockRecorder) Stats(ctx, days, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockDormancyServices)(nil).Stats), ctx, days, now)
+}
+
+// MockAccountClosureServices is a mock of AccountClosureServices interface.
+type MockAccountClosureServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountClosureServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountClosureServicesMockRecorder is the mock recorder for MockAccountClosureServices.
+type MockAccountClosureServicesMockRecorder struct {
+	mock *MockAccountClosureServices
+}
+
+// NewMockAccountClosureServices creates a new mock instance.
+func NewMockAccountClosureServices(ctrl *gomock.Controller) *MockAccountClosureServices {
+	mock := &MockAccountClosureServices{ctrl: ctrl}
+	mock.recorder = &MockAccountClosureServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountClosureServices) EXPECT() *MockAccountClosureServicesMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeDue mocks base method.
+func (m *MockAccountClosureServices) AnonymizeDue(ctx context.Context, now time.Time) (*domain.AccountClosureRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeDue", ctx, now)
+	ret0, _ := ret[0].(*domain.AccountClosureRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeDue indicates an expected call of AnonymizeDue.
+func (mr *MockAccountClosureServicesMockRecorder) AnonymizeDue(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeDue", reflect.TypeOf((*MockAccountClosureServices)(nil).AnonymizeDue), ctx, now)
+}
+
+// Authorize mocks base method.
+func (m *MockAccountClosureServices) Authorize(ctx context.Context, customerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockAccountClosureServicesMockRecorder) Authorize(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAccountClosureServices)(nil).Authorize), ctx, customerID)
+}
+
+// Close mocks base method.
+func (m *MockAccountClosureServices) Close(ctx context.Context, customerID uint64, req dto.CloseAccountRequest) (*domain.AccountClosure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, customerID, req)
+	ret0, _ := ret[0].(*domain.AccountClosure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockAccountClosureServicesMockRecorder) Close(ctx, customerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockAccountClosureServices)(nil).Close), ctx, customerID, req)
+}
+
+// MockCalendarServices is a mock of CalendarServices interface.
+type MockCalendarServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarServicesMockRecorder is the mock recorder for MockCalendarServices.
+type MockCalendarServicesMockRecorder struct {
+	mock *MockCalendarServices
+}
+
+// NewMockCalendarServices creates a new mock instance.
+func NewMockCalendarServices(ctrl *gomock.Controller) *MockCalendarServices {
+	mock := &MockCalendarServices{ctrl: ctrl}
+	mock.recorder = &MockCalendarServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarServices) EXPECT() *MockCalendarServicesMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method.
+func (m *MockCalendarServices) CreateToken(ctx context.Context, customerID uint64, contractNumber string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, customerID, contractNumber)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockCalendarServicesMockRecorder) CreateToken(ctx, customerID, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockCalendarServices)(nil).CreateToken), ctx, customerID, contractNumber)
+}
+
+// Feed mocks base method.
+func (m *MockCalendarServices) Feed(ctx context.Context, contractNumber, token string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Feed", ctx, contractNumber, token)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *MockCalendarServicesMockRecorder) Feed(ctx, contractNumber, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*MockCalendarServices)(nil).Feed), ctx, contractNumber, token)
+}
+
+// RevokeToken mocks base method.
+func (m *MockCalendarServices) RevokeToken(ctx context.Context, customerID uint64, contractNumber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, customerID, contractNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockCalendarServicesMockRecorder) RevokeToken(ctx, customerID, contractNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockCalendarServices)(nil).RevokeToken), ctx, customerID, contractNumber)
+}
+
+// MockDueDateRules is a mock of DueDateRules interface.
+type MockDueDateRules struct {
+	ctrl     *gomock.Controller
+	recorder *MockDueDateRulesMockRecorder
+	isgomock struct{}
+}
+
+// MockDueDateRulesMockRecorder is the mock recorder for MockDueDateRules.
+type MockDueDateRulesMockRecorder struct {
+	mock *MockDueDateRules
+}
+
+// NewMockDueDateRules creates a new mock instance.
+func NewMockDueDateRules(ctrl *gomock.Controller) *MockDueDateRules {
+	mock := &MockDueDateRules{ctrl: ctrl}
+	mock.recorder = &MockDueDateRulesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDueDateRules) EXPECT() *MockDueDateRulesMockRecorder {
+	return m.recorder
+}
+
+// Rules mocks base method.
+func (m *MockDueDateRules) Rules(ctx context.Context) installment.Rules {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rules", ctx)
+	ret0, _ := ret[0].(installment.Rules)
+	return ret0
+}
+
+// Rules indicates an expected call of Rules.
+func (mr *MockDueDateRulesMockRecorder) Rules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rules", reflect.TypeOf((*MockDueDateRules)(nil).Rules), ctx)
+}
+
+// MockDueDateServices is a mock of DueDateServices interface.
+type MockDueDateServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockDueDateServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockDueDateServicesMockRecorder is the mock recorder for MockDueDateServices.
+type MockDueDateServicesMockRecorder struct {
+	mock *MockDueDateServices
+}
+
+// NewMockDueDateServices creates a new mock instance.
+func NewMockDueDateServices(ctrl *gomock.Controller) *MockDueDateServices {
+	mock := &MockDueDateServices{ctrl: ctrl}
+	mock.recorder = &MockDueDateServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDueDateServices) EXPECT() *MockDueDateServicesMockRecorder {
+	return m.recorder
+}
+
+// DeleteHoliday mocks base method.
+func (m *MockDueDateServices) DeleteHoliday(ctx context.Context, date string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHoliday", ctx, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHoliday indicates an expected call of DeleteHoliday.
+func (mr *MockDueDateServicesMockRecorder) DeleteHoliday(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHoliday", reflect.TypeOf((*MockDueDateServices)(nil).DeleteHoliday), ctx, date)
+}
+
+// GetPolicy mocks base method.
+func (m *MockDueDateServices) GetPolicy(ctx context.Context) (*domain.DueDatePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx)
+	ret0, _ := ret[0].(*domain.DueDatePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockDueDateServicesMockRecorder) GetPolicy(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockDueDateServices)(nil).GetPolicy), ctx)
+}
+
+// ListHolidays mocks base method.
+func (m *MockDueDateServices) ListHolidays(ctx context.Context, year int) ([]domain.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolidays", ctx, year)
+	ret0, _ := ret[0].([]domain.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolidays indicates an expected call of ListHolidays.
+func (mr *MockDueDateServicesMockRecorder) ListHolidays(ctx, year any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolidays", reflect.TypeOf((*MockDueDateServices)(nil).ListHolidays), ctx, year)
+}
+
+// Rules mocks base method.
+func (m *MockDueDateServices) Rules(ctx context.Context) installment.Rules {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rules", ctx)
+	ret0, _ := ret[0].(installment.Rules)
+	return ret0
+}
+
+// Rules indicates an expected call of Rules.
+func (mr *MockDueDateServicesMockRecorder) Rules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rules", reflect.TypeOf((*MockDueDateServices)(nil).Rules), ctx)
+}
+
+// SetHoliday mocks base method.
+func (m *MockDueDateServices) SetHoliday(ctx context.Context, date string, updatedBy uint64, req dto.HolidayRequest) (*domain.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHoliday", ctx, date, updatedBy, req)
+	ret0, _ := ret[0].(*domain.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHoliday indicates an expected call of SetHoliday.
+func (mr *MockDueDateServicesMockRecorder) SetHoliday(ctx, date, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHoliday", reflect.TypeOf((*MockDueDateServices)(nil).SetHoliday), ctx, date, updatedBy, req)
+}
+
+// SetPolicy mocks base method.
+func (m *MockDueDateServices) SetPolicy(ctx context.Context, updatedBy uint64, req dto.DueDatePolicyRequest) (*domain.DueDatePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPolicy", ctx, updatedBy, req)
+	ret0, _ := ret[0].(*domain.DueDatePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPolicy indicates an expected call of SetPolicy.
+func (mr *MockDueDateServicesMockRecorder) SetPolicy(ctx, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPolicy", reflect.TypeOf((*MockDueDateServices)(nil).SetPolicy), ctx, updatedBy, req)
+}
+
+// MockAMLServices is a mock of AMLServices interface.
+type MockAMLServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockAMLServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockAMLServicesMockRecorder is the mock recorder for MockAMLServices.
+type MockAMLServicesMockRecorder struct {
+	mock *MockAMLServices
+}
+
+// NewMockAMLServices creates a new mock instance.
+func NewMockAMLServices(ctrl *gomock.Controller) *MockAMLServices {
+	mock := &MockAMLServices{ctrl: ctrl}
+	mock.recorder = &MockAMLServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAMLServices) EXPECT() *MockAMLServicesMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockAMLServices) Export(ctx context.Context, from, to string, status domain.AMLCaseStatus) ([]domain.AMLCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, from, to, status)
+	ret0, _ := ret[0].([]domain.AMLCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockAMLServicesMockRecorder) Export(ctx, from, to, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockAMLServices)(nil).Export), ctx, from, to, status)
+}
+
+// GetCase mocks base method.
+func (m *MockAMLServices) GetCase(ctx context.Context, id uint64) (*domain.AMLCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCase", ctx, id)
+	ret0, _ := ret[0].(*domain.AMLCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCase indicates an expected call of GetCase.
+func (mr *MockAMLServicesMockRecorder) GetCase(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCase", reflect.TypeOf((*MockAMLServices)(nil).GetCase), ctx, id)
+}
+
+// ListCases mocks base method.
+func (m *MockAMLServices) ListCases(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCases", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCases indicates an expected call of ListCases.
+func (mr *MockAMLServicesMockRecorder) ListCases(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCases", reflect.TypeOf((*MockAMLServices)(nil).ListCases), ctx, params)
+}
+
+// Screen mocks base method.
+func (m *MockAMLServices) Screen(ctx context.Context, now time.Time) (*domain.AMLScreeningRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", ctx, now)
+	ret0, _ := ret[0].(*domain.AMLScreeningRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockAMLServicesMockRecorder) Screen(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockAMLServices)(nil).Screen), ctx, now)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAMLServices) UpdateStatus(ctx context.Context, id, adminID uint64, req dto.AMLCaseStatusRequest) (*domain.AMLCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, adminID, req)
+	ret0, _ := ret[0].(*domain.AMLCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAMLServicesMockRecorder) UpdateStatus(ctx, id, adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAMLServices)(nil).UpdateStatus), ctx, id, adminID, req)
+}
+
+// MockCollectionServices is a mock of CollectionServices interface.
+type MockCollectionServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockCollectionServicesMockRecorder is the mock recorder for MockCollectionServices.
+type MockCollectionServicesMockRecorder struct {
+	mock *MockCollectionServices
+}
+
+// NewMockCollectionServices creates a new mock instance.
+func NewMockCollectionServices(ctrl *gomock.Controller) *MockCollectionServices {
+	mock := &MockCollectionServices{ctrl: ctrl}
+	mock.recorder = &MockCollectionServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionServices) EXPECT() *MockCollectionServicesMockRecorder {
+	return m.recorder
+}
+
+// AddActivity mocks base method.
+func (m *MockCollectionServices) AddActivity(ctx context.Context, id, adminID uint64, req dto.CollectionActivityRequest) (*domain.CollectionActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActivity", ctx, id, adminID, req)
+	ret0, _ := ret[0].(*domain.CollectionActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddActivity indicates an expected call of AddActivity.
+func (mr *MockCollectionServicesMockRecorder) AddActivity(ctx, id, adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivity", reflect.TypeOf((*MockCollectionServices)(nil).AddActivity), ctx, id, adminID, req)
+}
+
+// Assign mocks base method.
+func (m *MockCollectionServices) Assign(ctx context.Context, id uint64, req dto.CollectionAssignRequest) (*domain.CollectionCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, id, req)
+	ret0, _ := ret[0].(*domain.CollectionCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockCollectionServicesMockRecorder) Assign(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockCollectionServices)(nil).Assign), ctx, id, req)
+}
+
+// Dashboard mocks base method.
+func (m *MockCollectionServices) Dashboard(ctx context.Context, now time.Time) (*domain.CollectionDashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dashboard", ctx, now)
+	ret0, _ := ret[0].(*domain.CollectionDashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dashboard indicates an expected call of Dashboard.
+func (mr *MockCollectionServicesMockRecorder) Dashboard(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dashboard", reflect.TypeOf((*MockCollectionServices)(nil).Dashboard), ctx, now)
+}
+
+// GetCase mocks base method.
+func (m *MockCollectionServices) GetCase(ctx context.Context, id uint64) (*domain.CollectionCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCase", ctx, id)
+	ret0, _ := ret[0].(*domain.CollectionCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCase indicates an expected call of GetCase.
+func (mr *MockCollectionServicesMockRecorder) GetCase(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCase", reflect.TypeOf((*MockCollectionServices)(nil).GetCase), ctx, id)
+}
+
+// ListActivities mocks base method.
+func (m *MockCollectionServices) ListActivities(ctx context.Context, id uint64) ([]domain.CollectionActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivities", ctx, id)
+	ret0, _ := ret[0].([]domain.CollectionActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivities indicates an expected call of ListActivities.
+func (mr *MockCollectionServicesMockRecorder) ListActivities(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivities", reflect.TypeOf((*MockCollectionServices)(nil).ListActivities), ctx, id)
+}
+
+// ListCases mocks base method.
+func (m *MockCollectionServices) ListCases(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCases", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCases indicates an expected call of ListCases.
+func (mr *MockCollectionServicesMockRecorder) ListCases(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCases", reflect.TypeOf((*MockCollectionServices)(nil).ListCases), ctx, params)
+}
+
+// OpenCases mocks base method.
+func (m *MockCollectionServices) OpenCases(ctx context.Context, now time.Time) (*domain.CollectionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCases", ctx, now)
+	ret0, _ := ret[0].(*domain.CollectionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenCases indicates an expected call of OpenCases.
+func (mr *MockCollectionServicesMockRecorder) OpenCases(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCases", reflect.TypeOf((*MockCollectionServices)(nil).OpenCases), ctx, now)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockCollectionServices) UpdateStatus(ctx context.Context, id uint64, req dto.CollectionCaseStatusRequest) (*domain.CollectionCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, req)
+	ret0, _ := ret[0].(*domain.CollectionCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCollectionServicesMockRecorder) UpdateStatus(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCollectionServices)(nil).UpdateStatus), ctx, id, req)
+}
+
+// MockWriteOffServices is a mock of WriteOffServices interface.
+type MockWriteOffServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriteOffServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockWriteOffServicesMockRecorder is the mock recorder for MockWriteOffServices.
+type MockWriteOffServicesMockRecorder struct {
+	mock *MockWriteOffServices
+}
+
+// NewMockWriteOffServices creates a new mock instance.
+func NewMockWriteOffServices(ctrl *gomock.Controller) *MockWriteOffServices {
+	mock := &MockWriteOffServices{ctrl: ctrl}
+	mock.recorder = &MockWriteOffServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriteOffServices) EXPECT() *MockWriteOffServicesMockRecorder {
+	return m.recorder
+}
+
+// GetWriteOff mocks base method.
+func (m *MockWriteOffServices) GetWriteOff(ctx context.Context, id uint64) (*domain.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWriteOff", ctx, id)
+	ret0, _ := ret[0].(*domain.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWriteOff indicates an expected call of GetWriteOff.
+func (mr *MockWriteOffServicesMockRecorder) GetWriteOff(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWriteOff", reflect.TypeOf((*MockWriteOffServices)(nil).GetWriteOff), ctx, id)
+}
+
+// Ledger mocks base method.
+func (m *MockWriteOffServices) Ledger(ctx context.Context, id uint64) ([]domain.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ledger", ctx, id)
+	ret0, _ := ret[0].([]domain.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ledger indicates an expected call of Ledger.
+func (mr *MockWriteOffServicesMockRecorder) Ledger(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ledger", reflect.TypeOf((*MockWriteOffServices)(nil).Ledger), ctx, id)
+}
+
+// ListRecoveries mocks base method.
+func (m *MockWriteOffServices) ListRecoveries(ctx context.Context, id uint64) ([]domain.WriteOffRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecoveries", ctx, id)
+	ret0, _ := ret[0].([]domain.WriteOffRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecoveries indicates an expected call of ListRecoveries.
+func (mr *MockWriteOffServicesMockRecorder) ListRecoveries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecoveries", reflect.TypeOf((*MockWriteOffServices)(nil).ListRecoveries), ctx, id)
+}
+
+// ListWriteOffs mocks base method.
+func (m *MockWriteOffServices) ListWriteOffs(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWriteOffs", ctx, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWriteOffs indicates an expected call of ListWriteOffs.
+func (mr *MockWriteOffServicesMockRecorder) ListWriteOffs(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWriteOffs", reflect.TypeOf((*MockWriteOffServices)(nil).ListWriteOffs), ctx, params)
+}
+
+// Propose mocks base method.
+func (m *MockWriteOffServices) Propose(ctx context.Context, makerID uint64, req dto.WriteOffRequest) (*domain.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Propose", ctx, makerID, req)
+	ret0, _ := ret[0].(*domain.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Propose indicates an expected call of Propose.
+func (mr *MockWriteOffServicesMockRecorder) Propose(ctx, makerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Propose", reflect.TypeOf((*MockWriteOffServices)(nil).Propose), ctx, makerID, req)
+}
+
+// RecordRecovery mocks base method.
+func (m *MockWriteOffServices) RecordRecovery(ctx context.Context, id, adminID uint64, req dto.WriteOffRecoveryRequest) (*domain.WriteOffRecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRecovery", ctx, id, adminID, req)
+	ret0, _ := ret[0].(*domain.WriteOffRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordRecovery indicates an expected call of RecordRecovery.
+func (mr *MockWriteOffServicesMockRecorder) RecordRecovery(ctx, id, adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRecovery", reflect.TypeOf((*MockWriteOffServices)(nil).RecordRecovery), ctx, id, adminID, req)
+}
+
+// Report mocks base method.
+func (m *MockWriteOffServices) Report(ctx context.Context, from, to time.Time) ([]domain.WriteOffPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, from, to)
+	ret0, _ := ret[0].([]domain.WriteOffPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockWriteOffServicesMockRecorder) Report(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockWriteOffServices)(nil).Report), ctx, from, to)
+}
+
+// Review mocks base method.
+func (m *MockWriteOffServices) Review(ctx context.Context, id, checkerID uint64, req dto.WriteOffReviewRequest) (*domain.WriteOff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, id, checkerID, req)
+	ret0, _ := ret[0].(*domain.WriteOff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockWriteOffServicesMockRecorder) Review(ctx, id, checkerID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockWriteOffServices)(nil).Review), ctx, id, checkerID, req)
+}
+
+// MockConcentrationServices is a mock of ConcentrationServices interface.
+type MockConcentrationServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockConcentrationServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockConcentrationServicesMockRecorder is the mock recorder for MockConcentrationServices.
+type MockConcentrationServicesMockRecorder struct {
+	mock *MockConcentrationServices
+}
+
+// NewMockConcentrationServices creates a new mock instance.
+func NewMockConcentrationServices(ctrl *gomock.Controller) *MockConcentrationServices {
+	mock := &MockConcentrationServices{ctrl: ctrl}
+	mock.recorder = &MockConcentrationServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConcentrationServices) EXPECT() *MockConcentrationServicesMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockConcentrationServices) Check(ctx context.Context, assetCategory, regionCode string, principal decimal.Decimal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, assetCategory, regionCode, principal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockConcentrationServicesMockRecorder) Check(ctx, assetCategory, regionCode, principal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockConcentrationServices)(nil).Check), ctx, assetCategory, regionCode, principal)
+}
+
+// CreateLimit mocks base method.
+func (m *MockConcentrationServices) CreateLimit(ctx context.Context, updatedBy uint64, req dto.ConcentrationLimitRequest) (*domain.ConcentrationLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLimit", ctx, updatedBy, req)
+	ret0, _ := ret[0].(*domain.ConcentrationLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLimit indicates an expected call of CreateLimit.
+func (mr *MockConcentrationServicesMockRecorder) CreateLimit(ctx, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLimit", reflect.TypeOf((*MockConcentrationServices)(nil).CreateLimit), ctx, updatedBy, req)
+}
+
+// DeleteLimit mocks base method.
+func (m *MockConcentrationServices) DeleteLimit(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLimit", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLimit indicates an expected call of DeleteLimit.
+func (mr *MockConcentrationServicesMockRecorder) DeleteLimit(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLimit", reflect.TypeOf((*MockConcentrationServices)(nil).DeleteLimit), ctx, id)
+}
+
+// Evaluate mocks base method.
+func (m *MockConcentrationServices) Evaluate(ctx context.Context, now time.Time) (*domain.ConcentrationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, now)
+	ret0, _ := ret[0].(*domain.ConcentrationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockConcentrationServicesMockRecorder) Evaluate(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockConcentrationServices)(nil).Evaluate), ctx, now)
+}
+
+// ListLimits mocks base method.
+func (m *MockConcentrationServices) ListLimits(ctx context.Context) ([]domain.ConcentrationLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLimits", ctx)
+	ret0, _ := ret[0].([]domain.ConcentrationLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLimits indicates an expected call of ListLimits.
+func (mr *MockConcentrationServicesMockRecorder) ListLimits(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLimits", reflect.TypeOf((*MockConcentrationServices)(nil).ListLimits), ctx)
+}
+
+// Report mocks base method.
+func (m *MockConcentrationServices) Report(ctx context.Context, now time.Time) (*domain.ConcentrationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, now)
+	ret0, _ := ret[0].(*domain.ConcentrationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockConcentrationServicesMockRecorder) Report(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockConcentrationServices)(nil).Report), ctx, now)
+}
+
+// UpdateLimit mocks base method.
+func (m *MockConcentrationServices) UpdateLimit(ctx context.Context, id, updatedBy uint64, req dto.ConcentrationLimitUpdateRequest) (*domain.ConcentrationLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLimit", ctx, id, updatedBy, req)
+	ret0, _ := ret[0].(*domain.ConcentrationLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLimit indicates an expected call of UpdateLimit.
+func (mr *MockConcentrationServicesMockRecorder) UpdateLimit(ctx, id, updatedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLimit", reflect.TypeOf((*MockConcentrationServices)(nil).UpdateLimit), ctx, id, updatedBy, req)
+}
+
+// MockLogLevelServices is a mock of LogLevelServices interface.
+type MockLogLevelServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogLevelServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockLogLevelServicesMockRecorder is the mock recorder for MockLogLevelServices.
+type MockLogLevelServicesMockRecorder struct {
+	mock *MockLogLevelServices
+}
+
+// NewMockLogLevelServices creates a new mock instance.
+func NewMockLogLevelServices(ctrl *gomock.Controller) *MockLogLevelServices {
+	mock := &MockLogLevelServices{ctrl: ctrl}
+	mock.recorder = &MockLogLevelServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogLevelServices) EXPECT() *MockLogLevelServicesMockRecorder {
+	return m.recorder
+}
+
+// Levels mocks base method.
+func (m *MockLogLevelServices) Levels() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Levels")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Levels indicates an expected call of Levels.
+func (mr *MockLogLevelServicesMockRecorder) Levels() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Levels", reflect.TypeOf((*MockLogLevelServices)(nil).Levels))
+}
+
+// SetLevel mocks base method.
+func (m *MockLogLevelServices) SetLevel(module, level string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevel", module, level)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLevel indicates an expected call of SetLevel.
+func (mr *MockLogLevelServicesMockRecorder) SetLevel(module, level any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevel", reflect.TypeOf((*MockLogLevelServices)(nil).SetLevel), module, level)
+}
+
+// MockPartnerDebugServices is a mock of PartnerDebugServices interface.
+type MockPartnerDebugServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartnerDebugServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockPartnerDebugServicesMockRecorder is the mock recorder for MockPartnerDebugServices.
+type MockPartnerDebugServicesMockRecorder struct {
+	mock *MockPartnerDebugServices
+}
+
+// NewMockPartnerDebugServices creates a new mock instance.
+func NewMockPartnerDebugServices(ctrl *gomock.Controller) *MockPartnerDebugServices {
+	mock := &MockPartnerDebugServices{ctrl: ctrl}
+	mock.recorder = &MockPartnerDebugServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartnerDebugServices) EXPECT() *MockPartnerDebugServicesMockRecorder {
+	return m.recorder
+}
+
+// GetRecord mocks base method.
+func (m *MockPartnerDebugServices) GetRecord(ctx context.Context, partnerID uint64, sandbox bool, id uint64) (*domain.PartnerDebugRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecord", ctx, partnerID, sandbox, id)
+	ret0, _ := ret[0].(*domain.PartnerDebugRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecord indicates an expected call of GetRecord.
+func (mr *MockPartnerDebugServicesMockRecorder) GetRecord(ctx, partnerID, sandbox, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecord", reflect.TypeOf((*MockPartnerDebugServices)(nil).GetRecord), ctx, partnerID, sandbox, id)
+}
+
+// ListRecords mocks base method.
+func (m *MockPartnerDebugServices) ListRecords(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) (*domain.Paginated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecords", ctx, partnerID, sandbox, params)
+	ret0, _ := ret[0].(*domain.Paginated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecords indicates an expected call of ListRecords.
+func (mr *MockPartnerDebugServicesMockRecorder) ListRecords(ctx, partnerID, sandbox, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecords", reflect.TypeOf((*MockPartnerDebugServices)(nil).ListRecords), ctx, partnerID, sandbox, params)
+}
+
+// Purge mocks base method.
+func (m *MockPartnerDebugServices) Purge(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockPartnerDebugServicesMockRecorder) Purge(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockPartnerDebugServices)(nil).Purge), ctx, now)
+}
+
+// Record mocks base method.
+func (m *MockPartnerDebugServices) Record(ctx context.Context, record *domain.PartnerDebugRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockPartnerDebugServicesMockRecorder) Record(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockPartnerDebugServices)(nil).Record), ctx, record)
+}
+
+// SetMode mocks base method.
+func (m *MockPartnerDebugServices) SetMode(ctx context.Context, partnerID uint64, sandbox, enabled bool, now time.Time) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMode", ctx, partnerID, sandbox, enabled, now)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMode indicates an expected call of SetMode.
+func (mr *MockPartnerDebugServicesMockRecorder) SetMode(ctx, partnerID, sandbox, enabled, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMode", reflect.TypeOf((*MockPartnerDebugServices)(nil).SetMode), ctx, partnerID, sandbox, enabled, now)
+}
+
+// MockMediaServices is a mock of MediaServices interface.
+type MockMediaServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaServicesMockRecorder is the mock recorder for MockMediaServices.
+type MockMediaServicesMockRecorder struct {
+	mock *MockMediaServices
+}
+
+// NewMockMediaServices creates a new mock instance.
+func NewMockMediaServices(ctrl *gomock.Controller) *MockMediaServices {
+	mock := &MockMediaServices{ctrl: ctrl}
+	mock.recorder = &MockMediaServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaServices) EXPECT() *MockMediaServicesMockRecorder {
+	return m.recorder
+}
+
+// CleanupOrphans mocks base method.
+func (m *MockMediaServices) CleanupOrphans(ctx context.Context, now time.Time) (*domain.MediaCleanupRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOrphans", ctx, now)
+	ret0, _ := ret[0].(*domain.MediaCleanupRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupOrphans indicates an expected call of CleanupOrphans.
+func (mr *MockMediaServicesMockRecorder) CleanupOrphans(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOrphans", reflect.TypeOf((*MockMediaServices)(nil).CleanupOrphans), ctx, now)
+}
+
+// MockMobileServices is a mock of MobileServices interface.
+type MockMobileServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockMobileServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockMobileServicesMockRecorder is the mock recorder for MockMobileServices.
+type MockMobileServicesMockRecorder struct {
+	mock *MockMobileServices
+}
+
+// NewMockMobileServices creates a new mock instance.
+func NewMockMobileServices(ctrl *gomock.Controller) *MockMobileServices {
+	mock := &MockMobileServices{ctrl: ctrl}
+	mock.recorder = &MockMobileServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMobileServices) EXPECT() *MockMobileServicesMockRecorder {
+	return m.recorder
+}
+
+// Home mocks base method.
+func (m *MockMobileServices) Home(ctx context.Context, customerID uint64, now time.Time) (*dto.MobileHomeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Home", ctx, customerID, now)
+	ret0, _ := ret[0].(*dto.MobileHomeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Home indicates an expected call of Home.
+func (mr *MockMobileServicesMockRecorder) Home(ctx, customerID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Home", reflect.TypeOf((*MockMobileServices)(nil).Home), ctx, customerID, now)
+}
+
+// MockDashboardServices is a mock of DashboardServices interface.
+type MockDashboardServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockDashboardServicesMockRecorder
+	isgomock struct{}
+}
+
+// MockDashboardServicesMockRecorder is the mock recorder for MockDashboardServices.
+type MockDashboardServicesMockRecorder struct {
+	mock *MockDashboardServices
+}
+
+// NewMockDashboardServices creates a new mock instance.
+func NewMockDashboardServices(ctrl *gomock.Controller) *MockDashboardServices {
+	mock := &MockDashboardServices{ctrl: ctrl}
+	mock.recorder = &MockDashboardServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDashboardServices) EXPECT() *MockDashboardServicesMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockDashboardServices) Refresh(ctx context.Context, now time.Time) (*domain.DashboardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, now)
+	ret0, _ := ret[0].(*domain.DashboardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockDashboardServicesMockRecorder) Refresh(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockDashboardServices)(nil).Refresh), ctx, now)
+}
+
+// Subscribe mocks base method.
+func (m *MockDashboardServices) Subscribe(ctx context.Context) (<-chan domain.DashboardSnapshot, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx)
+	ret0, _ := ret[0].(<-chan domain.DashboardSnapshot)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockDashboardServicesMockRecorder) Subscribe(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockDashboardServices)(nil).Subscribe), ctx)
+}
@@ -0,0 +1,84 @@
+package notifiersrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.uber.org/zap"
+)
+
+// channelOrder menentukan urutan pengiriman agar log komunikasi konsisten
+var channelOrder = []domain.NotificationChannel{domain.ChannelEmail, domain.ChannelSMS, domain.ChannelPush}
+
+// channelNotifier mengirim notifikasi ke setiap kanal yang diizinkan
+// preferensi customer
+type channelNotifier struct {
+	channels               map[domain.NotificationChannel]service.CustomerNotifier
+	notificationRepository repository.NotificationRepository
+	log                    *zap.Logger
+}
+
+// Notify implements CustomerNotifier. It fails only when every channel that
+// was tried failed, so a push outage does not fail a notification that was
+// emailed.
+func (n *channelNotifier) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	preferences, err := n.notificationRepository.FindPreferences(ctx, customerID)
+	if err != nil {
+		// Lebih baik terkirim ke kanal yang dimatikan daripada tidak terkirim sama sekali
+		n.log.Warn("Failed to load notification preferences, sending on every channel",
+			zap.Uint64("customer_id", customerID),
+			zap.Error(err),
+		)
+	}
+	if preferences == nil {
+		preferences = domain.DefaultNotificationPreferences(customerID)
+	}
+
+	delivered := false
+	var errs []error
+	for _, channel := range channelOrder {
+		notifier, ok := n.channels[channel]
+		if !ok || !preferences.Allows(channel) {
+			continue
+		}
+
+		err := notifier.Notify(ctx, customerID, template, data)
+		switch {
+		case err == nil:
+			delivered = true
+		case errors.Is(err, common.ErrNotificationSkipped):
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+		}
+	}
+
+	if delivered {
+		if len(errs) > 0 {
+			n.log.Warn("Customer notification failed on some channels",
+				zap.Uint64("customer_id", customerID),
+				zap.String("template", string(template)),
+				zap.Error(errors.Join(errs...)),
+			)
+		}
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+func NewChannelNotifier(
+	channels map[domain.NotificationChannel]service.CustomerNotifier,
+	notificationRepository repository.NotificationRepository,
+	log *zap.Logger,
+) service.CustomerNotifier {
+	return &channelNotifier{
+		channels:               channels,
+		notificationRepository: notificationRepository,
+		log:                    log,
+	}
+}
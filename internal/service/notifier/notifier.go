@@ -0,0 +1,62 @@
+package notifiersrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.uber.org/zap"
+)
+
+// logNotifier hanya mencatat notifikasi ke log, dipakai untuk kanal yang belum punya provider seperti SMS
+type logNotifier struct {
+	customerRepository repository.CustomerRepository
+	templates          *Templates
+	log                *zap.Logger
+}
+
+// Notify implements CustomerNotifier.
+func (n *logNotifier) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	customer, err := n.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to get customer: %w", err)
+	}
+	if customer == nil {
+		return common.ErrCustomerNotFound
+	}
+
+	language := customer.Language
+	if language == "" {
+		language = domain.DefaultLanguage
+	}
+
+	subject, body, err := n.templates.Render(template, language, data)
+	if err != nil {
+		return err
+	}
+
+	n.log.Info("Customer notification queued",
+		zap.Uint64("customer_id", customerID),
+		zap.String("template", string(template)),
+		zap.String("language", string(language)),
+		zap.String("subject", subject),
+		zap.String("body", body),
+	)
+	return nil
+}
+
+func NewLogNotifier(
+	customerRepository repository.CustomerRepository,
+	templates *Templates,
+	log *zap.Logger,
+) service.CustomerNotifier {
+	return &logNotifier{
+		customerRepository: customerRepository,
+		templates:          templates,
+		log:                log,
+	}
+}
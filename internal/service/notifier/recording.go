@@ -0,0 +1,68 @@
+package notifiersrv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.uber.org/zap"
+)
+
+// recordingNotifier mencatat setiap notifikasi ke log komunikasi customer,
+// termasuk yang gagal dikirim, supaya tim support bisa melihat riwayatnya
+type recordingNotifier struct {
+	next                    service.CustomerNotifier
+	channel                 domain.NotificationChannel
+	communicationRepository repository.CommunicationRepository
+	log                     *zap.Logger
+}
+
+// Notify implements CustomerNotifier.
+func (n *recordingNotifier) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	err := n.next.Notify(ctx, customerID, template, data)
+	// Kanal yang memang tidak dipakai untuk notifikasi ini tidak dicatat
+	if errors.Is(err, common.ErrNotificationSkipped) {
+		return err
+	}
+
+	communication := &domain.Communication{
+		CustomerID: customerID,
+		Channel:    n.channel,
+		Template:   template,
+		Data:       data,
+		Status:     domain.CommunicationSent,
+	}
+	if err != nil {
+		communication.Status = domain.CommunicationFailed
+		communication.Error = err.Error()
+	}
+
+	// Gagal mencatat tidak mengubah hasil pengiriman
+	if recordErr := n.communicationRepository.Create(ctx, communication); recordErr != nil {
+		n.log.Warn("Failed to record customer communication",
+			zap.Uint64("customer_id", customerID),
+			zap.String("template", string(template)),
+			zap.Error(recordErr),
+		)
+	}
+
+	return err
+}
+
+func NewRecordingNotifier(
+	next service.CustomerNotifier,
+	channel domain.NotificationChannel,
+	communicationRepository repository.CommunicationRepository,
+	log *zap.Logger,
+) service.CustomerNotifier {
+	return &recordingNotifier{
+		next:                    next,
+		channel:                 channel,
+		communicationRepository: communicationRepository,
+		log:                     log,
+	}
+}
@@ -0,0 +1,107 @@
+package notifiersrv
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+//go:embed push
+var pushFS embed.FS
+
+// Templates renders notification content from the Go templates embedded under
+// templates/<language>/<name>.tmpl. Each file defines a "subject" and a "body"
+// block and reads its variables from the notification data.
+type Templates struct {
+	byLanguage map[domain.Language]map[domain.NotificationTemplate]*template.Template
+}
+
+// LoadTemplates parses the embedded templates. Every notification template
+// must exist in the default language; other languages may be partial and
+// fall back to it.
+func LoadTemplates() (*Templates, error) {
+	return loadTemplates(templateFS, "templates", domain.NotificationTemplates)
+}
+
+// LoadPushTemplates parses the shorter push variants embedded under
+// push/<language>/<name>.tmpl, where the "subject" block is the title. Only
+// the templates in domain.PushTemplates are required.
+func LoadPushTemplates() (*Templates, error) {
+	return loadTemplates(pushFS, "push", domain.PushTemplates)
+}
+
+func loadTemplates(fsys embed.FS, root string, required []domain.NotificationTemplate) (*Templates, error) {
+	t := &Templates{byLanguage: make(map[domain.Language]map[domain.NotificationTemplate]*template.Template)}
+
+	err := fs.WalkDir(fsys, root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(file) != ".tmpl" {
+			return err
+		}
+
+		language := domain.Language(path.Base(path.Dir(file)))
+		name := domain.NotificationTemplate(strings.TrimSuffix(path.Base(file), ".tmpl"))
+
+		// Variabel yang tidak dikirim dirender kosong, bukan "<no value>"
+		tmpl, err := template.New(string(name)).Option("missingkey=zero").ParseFS(fsys, file)
+		if err != nil {
+			return fmt.Errorf("parse notification template %s: %w", file, err)
+		}
+		for _, block := range []string{"subject", "body"} {
+			if tmpl.Lookup(block) == nil {
+				return fmt.Errorf("notification template %s has no %q block", file, block)
+			}
+		}
+
+		if t.byLanguage[language] == nil {
+			t.byLanguage[language] = make(map[domain.NotificationTemplate]*template.Template)
+		}
+		t.byLanguage[language][name] = tmpl
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range required {
+		if t.byLanguage[domain.DefaultLanguage][name] == nil {
+			return nil, fmt.Errorf("notification template %q is missing for language %q", name, domain.DefaultLanguage)
+		}
+	}
+
+	return t, nil
+}
+
+// Render returns the subject and body of name in language, using the default
+// language when the template has no translation.
+func (t *Templates) Render(name domain.NotificationTemplate, language domain.Language, data map[string]string) (subject, body string, err error) {
+	tmpl := t.byLanguage[language][name]
+	if tmpl == nil {
+		tmpl = t.byLanguage[domain.DefaultLanguage][name]
+	}
+	if tmpl == nil {
+		return "", "", fmt.Errorf("notification template %q not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", fmt.Errorf("render subject of %q: %w", name, err)
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := tmpl.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", fmt.Errorf("render body of %q: %w", name, err)
+	}
+	body = strings.TrimSpace(buf.String())
+
+	return subject, body, nil
+}
@@ -0,0 +1,383 @@
+package onboardingsrv
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/apikey"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/requestsig"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const keyNotice = "Store this API key securely, it will not be shown again"
+
+type onboardingService struct {
+	partnerRepository repository.PartnerRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration  metric.Float64Histogram
+	operationCount     metric.Int64Counter
+	errorCount         metric.Int64Counter
+	partnersRegistered metric.Int64Counter
+	partnersPromoted   metric.Int64Counter
+}
+
+// Register implements PartnerOnboardingServices.
+func (o *onboardingService) Register(ctx context.Context, req dto.RegisterPartnerRequest) (*dto.PartnerCredentialsResponse, error) {
+	ctx, span := o.tracer.Start(ctx, "service.RegisterPartner")
+	defer span.End()
+
+	start := time.Now()
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	o.log.Debug("Registering partner",
+		zap.String("email", email),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+	o.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "register_partner"), attribute.String("service", "onboarding")))
+
+	// 1. Email partner harus unik
+	existing, err := o.partnerRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, o.recordError(ctx, span, start, "register_partner", "repository_error", fmt.Errorf("failed to check partner email: %w", err))
+	}
+	if existing != nil {
+		return nil, o.recordError(ctx, span, start, "register_partner", "partner_exists", common.ErrPartnerExists)
+	}
+
+	// 2. Partner baru selalu mulai di sandbox
+	plain, hash, display, err := apikey.Generate(apikey.SandboxPrefix)
+	if err != nil {
+		return nil, o.recordError(ctx, span, start, "register_partner", "key_generation_error", fmt.Errorf("failed to generate api key: %w", err))
+	}
+
+	partner := &domain.Partner{
+		Name:             strings.TrimSpace(req.Name),
+		Email:            email,
+		Status:           domain.PartnerSandbox,
+		SandboxKeyHash:   hash,
+		SandboxKeyPrefix: display,
+		WebhookURL:       strings.TrimSpace(req.WebhookURL),
+	}
+	if err := o.partnerRepository.Create(ctx, partner); err != nil {
+		return nil, o.recordError(ctx, span, start, "register_partner", "repository_error", fmt.Errorf("failed to create partner: %w", err))
+	}
+
+	o.partnersRegistered.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "onboarding")))
+	o.recordSuccess(ctx, span, start, "register_partner", zap.Uint64("partner_id", partner.ID))
+
+	return &dto.PartnerCredentialsResponse{
+		PartnerID: partner.ID,
+		Name:      partner.Name,
+		Status:    string(partner.Status),
+		APIKey:    plain,
+		Sandbox:   true,
+		Notice:    keyNotice,
+	}, nil
+}
+
+// Promote implements PartnerOnboardingServices.
+func (o *onboardingService) Promote(ctx context.Context, partnerID uint64) (*dto.PartnerCredentialsResponse, error) {
+	ctx, span := o.tracer.Start(ctx, "service.PromotePartner")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(partnerID)))
+	o.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "promote_partner"), attribute.String("service", "onboarding")))
+
+	partner, err := o.partnerRepository.FindByID(ctx, partnerID)
+	if err != nil {
+		return nil, o.recordError(ctx, span, start, "promote_partner", "repository_error", fmt.Errorf("failed to get partner: %w", err))
+	}
+	if partner == nil {
+		return nil, o.recordError(ctx, span, start, "promote_partner", "partner_not_found", common.ErrPartnerNotFound)
+	}
+	if partner.Status == domain.PartnerProduction {
+		return nil, o.recordError(ctx, span, start, "promote_partner", "already_promoted", common.ErrPartnerPromoted)
+	}
+
+	plain, hash, display, err := apikey.Generate(apikey.LivePrefix)
+	if err != nil {
+		return nil, o.recordError(ctx, span, start, "promote_partner", "key_generation_error", fmt.Errorf("failed to generate api key: %w", err))
+	}
+
+	// Sandbox key tetap aktif supaya partner masih bisa menguji integrasi
+	now := time.Now()
+	partner.Status = domain.PartnerProduction
+	partner.LiveKeyHash = hash
+	partner.LiveKeyPrefix = display
+	partner.PromotedAt = &now
+
+	if err := o.partnerRepository.Update(ctx, partner); err != nil {
+		return nil, o.recordError(ctx, span, start, "promote_partner", "repository_error", fmt.Errorf("failed to promote partner: %w", err))
+	}
+
+	o.partnersPromoted.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "onboarding")))
+	o.recordSuccess(ctx, span, start, "promote_partner", zap.Uint64("partner_id", partner.ID))
+
+	return &dto.PartnerCredentialsResponse{
+		PartnerID: partner.ID,
+		Name:      partner.Name,
+		Status:    string(partner.Status),
+		APIKey:    plain,
+		Sandbox:   false,
+		Notice:    keyNotice,
+	}, nil
+}
+
+// Authenticate implements PartnerOnboardingServices.
+func (o *onboardingService) Authenticate(ctx context.Context, key string) (*domain.Partner, bool, error) {
+	ctx, span := o.tracer.Start(ctx, "service.AuthenticatePartner")
+	defer span.End()
+
+	start := time.Now()
+	o.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "authenticate_partner"), attribute.String("service", "onboarding")))
+
+	if !strings.HasPrefix(key, apikey.SandboxPrefix) && !strings.HasPrefix(key, apikey.LivePrefix) {
+		return nil, false, o.recordError(ctx, span, start, "authenticate_partner", "invalid_api_key", common.ErrInvalidAPIKey)
+	}
+
+	hash := apikey.Hash(key)
+	partner, err := o.partnerRepository.FindByAPIKeyHash(ctx, hash)
+	if err != nil {
+		return nil, false, o.recordError(ctx, span, start, "authenticate_partner", "repository_error", fmt.Errorf("failed to find partner by api key: %w", err))
+	}
+	if partner == nil {
+		return nil, false, o.recordError(ctx, span, start, "authenticate_partner", "invalid_api_key", common.ErrInvalidAPIKey)
+	}
+
+	sandbox := partner.LiveKeyHash != hash
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partner.ID)),
+		attribute.Bool("partner.sandbox", sandbox),
+	)
+	o.recordSuccess(ctx, span, start, "authenticate_partner",
+		zap.Uint64("partner_id", partner.ID),
+		zap.Bool("sandbox", sandbox),
+	)
+
+	return partner, sandbox, nil
+}
+
+// GetSecurity implements PartnerOnboardingServices.
+func (o *onboardingService) GetSecurity(ctx context.Context, partnerID uint64) (*dto.PartnerSecurityResponse, error) {
+	ctx, span := o.tracer.Start(ctx, "service.GetPartnerSecurity")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(partnerID)))
+	o.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_partner_security"), attribute.String("service", "onboarding")))
+
+	partner, err := o.partnerRepository.FindByID(ctx, partnerID)
+	if err != nil {
+		return nil, o.recordError(ctx, span, start, "get_partner_security", "repository_error", fmt.Errorf("failed to get partner: %w", err))
+	}
+	if partner == nil {
+		return nil, o.recordError(ctx, span, start, "get_partner_security", "partner_not_found", common.ErrPartnerNotFound)
+	}
+
+	o.recordSuccess(ctx, span, start, "get_partner_security", zap.Uint64("partner_id", partnerID))
+
+	res := dto.PartnerSecurityToResponse(*partner)
+	return &res, nil
+}
+
+// UpdateSecurity implements PartnerOnboardingServices.
+func (o *onboardingService) UpdateSecurity(ctx context.Context, partnerID uint64, req dto.PartnerSecurityRequest) (*dto.PartnerSecurityResponse, error) {
+	ctx, span := o.tracer.Start(ctx, "service.UpdatePartnerSecurity")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Int("partner.allowed_cidrs", len(req.AllowedCIDRs)),
+		attribute.Bool("partner.require_client_cert", req.RequireClientCert),
+		attribute.Bool("partner.require_signature", req.RequireSignature),
+	)
+	o.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_partner_security"), attribute.String("service", "onboarding")))
+
+	cidrs, err := normalizeCIDRs(req.AllowedCIDRs)
+	if err != nil {
+		return nil, o.recordError(ctx, span, start, "update_partner_security", "invalid_cidr", err)
+	}
+
+	fingerprint := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(req.ClientCertFingerprint), ":", ""))
+	// Input seperti "  :: " lolos validasi DTO tetapi menjadi kosong di sini
+	if (fingerprint != "" || req.RequireClientCert) && !isSHA256Hex(fingerprint) {
+		return nil, o.recordError(ctx, span, start, "update_partner_security", "invalid_fingerprint", common.ErrInvalidFingerprint)
+	}
+
+	partner, err := o.partnerRepository.FindByID(ctx, partnerID)
+	if err != nil {
+		return nil, o.recordError(ctx, span, start, "update_partner_security", "repository_error", fmt.Errorf("failed to get partner: %w", err))
+	}
+	if partner == nil {
+		return nil, o.recordError(ctx, span, start, "update_partner_security", "partner_not_found", common.ErrPartnerNotFound)
+	}
+
+	// Secret lama dipertahankan kecuali diminta rotasi, supaya partner yang
+	// sudah menandatangani request tidak putus saat setting lain diubah
+	signingSecret := partner.Security.SigningSecret
+	generated := false
+	if req.RotateSigningSecret || (req.RequireSignature && signingSecret == "") {
+		signingSecret, err = requestsig.GenerateSecret()
+		if err != nil {
+			return nil, o.recordError(ctx, span, start, "update_partner_security", "secret_generation_error", fmt.Errorf("failed to generate signing secret: %w", err))
+		}
+		generated = true
+	}
+
+	partner.Security = domain.PartnerSecurity{
+		AllowedCIDRs:          cidrs,
+		RequireClientCert:     req.RequireClientCert,
+		ClientCertFingerprint: fingerprint,
+		RequireSignature:      req.RequireSignature,
+		SigningSecret:         signingSecret,
+	}
+	if err := o.partnerRepository.Update(ctx, partner); err != nil {
+		return nil, o.recordError(ctx, span, start, "update_partner_security", "repository_error", fmt.Errorf("failed to update partner security: %w", err))
+	}
+
+	o.recordSuccess(ctx, span, start, "update_partner_security",
+		zap.Uint64("partner_id", partnerID),
+		zap.Strings("allowed_cidrs", cidrs),
+		zap.Bool("require_client_cert", req.RequireClientCert),
+		zap.Bool("require_signature", req.RequireSignature),
+		zap.Bool("signing_secret_generated", generated),
+	)
+
+	res := dto.PartnerSecurityToResponse(*partner)
+	if generated {
+		res.SigningSecret = signingSecret
+	}
+	return &res, nil
+}
+
+// normalizeCIDRs accepts bare addresses as single-host ranges and returns the
+// canonical form of every entry, dropping duplicates.
+func normalizeCIDRs(entries []string) ([]string, error) {
+	seen := make(map[string]bool, len(entries))
+	cidrs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+
+		var prefix netip.Prefix
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		} else if prefix, err = netip.ParsePrefix(entry); err != nil {
+			return nil, fmt.Errorf("%w: %q", common.ErrInvalidCIDR, entry)
+		}
+
+		cidr := prefix.Masked().String()
+		if !seen[cidr] {
+			seen[cidr] = true
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs, nil
+}
+
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func (o *onboardingService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	o.log.Error("Partner onboarding operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	o.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "onboarding"), attribute.String("error_type", errorType)))
+	o.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "onboarding"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (o *onboardingService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	o.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "onboarding"), attribute.String("status", "success")))
+
+	o.log.Info("Partner onboarding operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewOnboardingService(
+	partnerRepository repository.PartnerRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PartnerOnboardingServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	partnersRegistered, _ := meter.Int64Counter(
+		"service.partners.registered",
+		metric.WithDescription("Number of partners registered"),
+		metric.WithUnit("{partner}"),
+	)
+
+	partnersPromoted, _ := meter.Int64Counter(
+		"service.partners.promoted",
+		metric.WithDescription("Number of partners promoted to production"),
+		metric.WithUnit("{partner}"),
+	)
+
+	return &onboardingService{
+		partnerRepository:  partnerRepository,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		partnersRegistered: partnersRegistered,
+		partnersPromoted:   partnersPromoted,
+	}
+}
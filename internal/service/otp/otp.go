@@ -0,0 +1,321 @@
+package otpsrv
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/otp"
+	"github.com/go-playground/validator/v10"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config bounds how OTPs are issued. MaxRequests codes may be sent to one
+// destination per RequestWindow, and a challenge is dropped after
+// MaxAttempts wrong codes.
+type Config struct {
+	TTL             time.Duration
+	VerificationTTL time.Duration
+	MaxAttempts     int
+	MaxRequests     int
+	RequestWindow   time.Duration
+}
+
+type otpService struct {
+	otpRepository repository.OTPRepository
+	senders       map[string]otp.Sender
+	validate      *validator.Validate
+	cfg           Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Request implements OTPServices.
+func (s *otpService) Request(ctx context.Context, customerID uint64, req dto.OTPRequest) (*domain.OTPChallenge, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RequestOTP")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("otp.purpose", req.Purpose),
+		attribute.String("otp.channel", req.Channel),
+		attribute.Int64("customer.id", int64(customerID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "request_otp"), attribute.String("service", "otp")))
+
+	purpose := domain.OTPPurpose(req.Purpose)
+	if purpose != domain.OTPRegistration && customerID == 0 {
+		return nil, s.recordError(ctx, span, start, "request_otp", "login_required", common.ErrOTPLoginRequired)
+	}
+
+	sender, ok := s.senders[req.Channel]
+	if !ok {
+		return nil, s.recordError(ctx, span, start, "request_otp", "unknown_channel", fmt.Errorf("%w: %s", common.ErrUnknownOTPChannel, req.Channel))
+	}
+
+	destination, err := s.normalizeDestination(req.Channel, req.Destination)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "request_otp", "invalid_destination", err)
+	}
+
+	count, err := s.otpRepository.CountRequest(ctx, req.Channel+":"+destination, s.cfg.RequestWindow)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "request_otp", "repository_error", fmt.Errorf("failed to count OTP requests: %w", err))
+	}
+	if count > int64(s.cfg.MaxRequests) {
+		return nil, s.recordError(ctx, span, start, "request_otp", "rate_limited", common.ErrOTPRateLimited)
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "request_otp", "generate_error", fmt.Errorf("failed to generate challenge id: %w", err))
+	}
+	code, err := otp.Generate()
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "request_otp", "generate_error", fmt.Errorf("failed to generate OTP: %w", err))
+	}
+
+	challenge := &domain.OTPChallenge{
+		ID:          id,
+		Purpose:     purpose,
+		Channel:     req.Channel,
+		Destination: destination,
+		CustomerID:  customerID,
+		CodeHash:    otp.Hash(id, code),
+		ExpiresAt:   time.Now().Add(s.cfg.TTL),
+	}
+	if err := s.otpRepository.SaveChallenge(ctx, challenge); err != nil {
+		return nil, s.recordError(ctx, span, start, "request_otp", "repository_error", fmt.Errorf("failed to save OTP challenge: %w", err))
+	}
+
+	if err := sender.Send(ctx, destination, code); err != nil {
+		// Challenge yang kodenya tidak terkirim tidak boleh bisa diverifikasi
+		if _, delErr := s.otpRepository.DeleteChallenge(ctx, id); delErr != nil {
+			s.log.Warn("Failed to drop undelivered OTP challenge", zap.Error(delErr))
+		}
+		return nil, s.recordError(ctx, span, start, "request_otp", "delivery_error", fmt.Errorf("failed to send OTP: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "request_otp",
+		zap.String("purpose", req.Purpose),
+		zap.String("channel", req.Channel),
+		zap.Uint64("customer_id", customerID),
+	)
+
+	return challenge, nil
+}
+
+// Verify implements OTPServices.
+func (s *otpService) Verify(ctx context.Context, req dto.OTPVerifyRequest) (*domain.OTPVerification, error) {
+	ctx, span := s.tracer.Start(ctx, "service.VerifyOTP")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "verify_otp"), attribute.String("service", "otp")))
+
+	challenge, err := s.otpRepository.FindChallenge(ctx, req.ChallengeID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "verify_otp", "repository_error", fmt.Errorf("failed to find OTP challenge: %w", err))
+	}
+	if challenge == nil {
+		return nil, s.recordError(ctx, span, start, "verify_otp", "invalid_code", common.ErrOTPInvalid)
+	}
+	span.SetAttributes(attribute.String("otp.purpose", string(challenge.Purpose)))
+
+	if subtle.ConstantTimeCompare([]byte(otp.Hash(challenge.ID, req.Code)), []byte(challenge.CodeHash)) != 1 {
+		attempts, err := s.otpRepository.IncrementAttempts(ctx, challenge.ID)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "verify_otp", "repository_error", fmt.Errorf("failed to count OTP attempt: %w", err))
+		}
+		if attempts >= s.cfg.MaxAttempts {
+			if _, err := s.otpRepository.DeleteChallenge(ctx, challenge.ID); err != nil {
+				return nil, s.recordError(ctx, span, start, "verify_otp", "repository_error", fmt.Errorf("failed to drop OTP challenge: %w", err))
+			}
+			return nil, s.recordError(ctx, span, start, "verify_otp", "attempts_exceeded", common.ErrOTPAttemptsExceeded)
+		}
+		return nil, s.recordError(ctx, span, start, "verify_otp", "invalid_code", common.ErrOTPInvalid)
+	}
+
+	// Hanya request yang berhasil menghapus challenge yang mendapat token,
+	// verifikasi paralel dengan kode yang sama tidak menghasilkan dua token
+	deleted, err := s.otpRepository.DeleteChallenge(ctx, challenge.ID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "verify_otp", "repository_error", fmt.Errorf("failed to drop OTP challenge: %w", err))
+	}
+	if !deleted {
+		return nil, s.recordError(ctx, span, start, "verify_otp", "invalid_code", common.ErrOTPInvalid)
+	}
+
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "verify_otp", "generate_error", fmt.Errorf("failed to generate OTP token: %w", err))
+	}
+
+	now := time.Now()
+	verification := &domain.OTPVerification{
+		Token:       token,
+		Purpose:     challenge.Purpose,
+		Channel:     challenge.Channel,
+		Destination: challenge.Destination,
+		CustomerID:  challenge.CustomerID,
+		VerifiedAt:  now,
+		ExpiresAt:   now.Add(s.cfg.VerificationTTL),
+	}
+	if err := s.otpRepository.SaveVerification(ctx, verification); err != nil {
+		return nil, s.recordError(ctx, span, start, "verify_otp", "repository_error", fmt.Errorf("failed to save OTP verification: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "verify_otp",
+		zap.String("purpose", string(challenge.Purpose)),
+		zap.Uint64("customer_id", challenge.CustomerID),
+	)
+
+	return verification, nil
+}
+
+// Consume implements OTPServices.
+func (s *otpService) Consume(ctx context.Context, token string, purpose domain.OTPPurpose, customerID uint64) (*domain.OTPVerification, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ConsumeOTP")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("otp.purpose", string(purpose)),
+		attribute.Int64("customer.id", int64(customerID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "consume_otp"), attribute.String("service", "otp")))
+
+	if token == "" {
+		return nil, s.recordError(ctx, span, start, "consume_otp", "missing_token", common.ErrOTPRequired)
+	}
+
+	verification, err := s.otpRepository.TakeVerification(ctx, token)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "consume_otp", "repository_error", fmt.Errorf("failed to take OTP verification: %w", err))
+	}
+	if verification == nil || verification.Purpose != purpose || verification.CustomerID != customerID {
+		return nil, s.recordError(ctx, span, start, "consume_otp", "invalid_token", common.ErrOTPRequired)
+	}
+
+	s.recordSuccess(ctx, span, start, "consume_otp", zap.String("purpose", string(purpose)), zap.Uint64("customer_id", customerID))
+
+	return verification, nil
+}
+
+// normalizeDestination validates the destination after normalizing it, so
+// rate limits apply however it is typed.
+func (s *otpService) normalizeDestination(channel, destination string) (string, error) {
+	destination = otp.NormalizeDestination(channel, destination)
+
+	tag := "email"
+	if channel == otp.ChannelSMS {
+		tag = "e164"
+	}
+
+	if err := s.validate.Var(destination, tag); err != nil {
+		return "", fmt.Errorf("%w: %s", common.ErrInvalidOTPDestination, channel)
+	}
+	return destination, nil
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func (s *otpService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("OTP operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "otp"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "otp"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *otpService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "otp"), attribute.String("status", "success")))
+
+	s.log.Info("OTP operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewOTPService(
+	otpRepository repository.OTPRepository,
+	senders []otp.Sender,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.OTPServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	byChannel := make(map[string]otp.Sender, len(senders))
+	for _, sender := range senders {
+		byChannel[sender.Channel()] = sender
+	}
+
+	return &otpService{
+		otpRepository:     otpRepository,
+		senders:           byChannel,
+		validate:          validator.New(),
+		cfg:               cfg,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+	}
+}
@@ -0,0 +1,79 @@
+package partnersrv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// Affordability caps the debt-service ratio of a customer: the monthly
+// installments of all active contracts plus the new one must not exceed
+// MaxRatios of the declared salary. The ratio is looked up by the tier
+// RiskTiers assigned to the customer, or when there is none by the tier
+// TierFor assigns to the salary; tiers without a ratio are not checked, and
+// a zero Affordability disables the check.
+type Affordability struct {
+	TierFor   func(salary decimal.Decimal) string
+	MaxRatios map[string]decimal.Decimal
+	RiskTiers repository.RiskTierRepository
+}
+
+// ParseMaxRatios reads ratios from "TIER:ratio" entries separated by commas,
+// e.g. "LOW:0.3,MEDIUM:0.35,HIGH:0.4". An empty spec disables the check.
+func ParseMaxRatios(spec string) (map[string]decimal.Decimal, error) {
+	var ratios map[string]decimal.Decimal
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid debt-service ratio %q, expected TIER:ratio", entry)
+		}
+		ratio, err := decimal.NewFromString(parts[1])
+		if err != nil || !ratio.IsPositive() || ratio.GreaterThan(decimal.NewFromInt(1)) {
+			return nil, fmt.Errorf("invalid ratio in %q, must be above 0 and at most 1", entry)
+		}
+
+		if ratios == nil {
+			ratios = make(map[string]decimal.Decimal)
+		}
+		ratios[strings.ToUpper(parts[0])] = ratio
+	}
+	return ratios, nil
+}
+
+// maxInstallment returns the highest total monthly installment allowed for
+// customer, and false when no ratio applies.
+func (a Affordability) maxInstallment(ctx context.Context, customer *domain.Customer) (decimal.Decimal, bool, error) {
+	if len(a.MaxRatios) == 0 || a.TierFor == nil {
+		return decimal.Zero, false, nil
+	}
+
+	tier := ""
+	if a.RiskTiers != nil {
+		assigned, err := a.RiskTiers.FindByCustomerID(ctx, customer.ID)
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		if assigned != nil {
+			tier = string(assigned.Tier)
+		}
+	}
+	// Customer yang belum dinilai job risk tier memakai pita gaji
+	if tier == "" {
+		tier = a.TierFor(customer.Salary)
+	}
+
+	ratio, ok := a.MaxRatios[strings.ToUpper(tier)]
+	if !ok {
+		return decimal.Zero, false, nil
+	}
+	return customer.Salary.Mul(ratio), true, nil
+}
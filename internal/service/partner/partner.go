@@ -2,18 +2,29 @@ package partnersrv
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/dto"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
+	contractsequencerepo "github.com/fazamuttaqien/multifinance/internal/repository/contractsequence"
 	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
 	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
+	promotionrepo "github.com/fazamuttaqien/multifinance/internal/repository/promotion"
+	restrictionrepo "github.com/fazamuttaqien/multifinance/internal/repository/restriction"
 	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
 	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
 	"github.com/fazamuttaqien/multifinance/internal/service"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/shopspring/decimal"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -30,6 +41,18 @@ type partnerService struct {
 	tenorRepository       repository.TenorRepository
 	limitRepository       repository.LimitRepository
 	transactionRepository repository.TransactionRepository
+	feeScheduleRepository repository.FeeScheduleRepository
+	screeningService      service.ScreeningServices
+	currencyConverter     service.CurrencyConverter
+	quotaService          service.PartnerQuotaServices
+	concentrationService  service.ConcentrationServices
+	partnerRepository     repository.PartnerRepository
+	regionRepository      repository.RegionRepository
+	exposureRepository    repository.CustomerExposureRepository
+	restrictionRepository repository.CustomerRestrictionRepository
+
+	maxAgeAtEnd   uint8
+	affordability Affordability
 
 	meter  metric.Meter
 	tracer trace.Tracer
@@ -51,7 +74,7 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 
 	p.log.Debug("Creating new transaction",
 		zap.String("customer_nik", req.CustomerNIK),
-		zap.Float64("otr_amount", req.OTRAmount),
+		zap.Stringer("otr_amount", req.OTRAmount),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 	)
 
@@ -64,11 +87,22 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 
 	span.SetAttributes(
 		attribute.String("customer.nik", req.CustomerNIK),
-		attribute.Float64("transaction.otr_amount", req.OTRAmount),
+		attribute.Float64("transaction.otr_amount", req.OTRAmount.InexactFloat64()),
 		attribute.Int("transaction.tenor_months", int(req.TenorMonths)),
 		attribute.String("service", "partner"),
 	)
 
+	// Nilai dicek ulang di sini karena tidak semua pemanggil melewati validator handler
+	if err := checkAmounts(req.OTRAmount, req.AdminFee); err != nil {
+		span.SetStatus(codes.Error, "Invalid transaction amount")
+		span.RecordError(err)
+		p.log.Warn("Invalid transaction amount", zap.Stringer("otr_amount", req.OTRAmount), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "invalid_amount")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
 	tx := p.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		span.SetStatus(codes.Error, "Failed to begin transaction")
@@ -97,7 +131,7 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 	defer tx.Rollback()
 
 	// 1. Mendapatkan Customer berdasarkan NIK dan KUNCI barisnya untuk mencegah race condition
-	customerTx := customerrepo.NewCustomerRepository(tx, p.meter, p.tracer, p.log)
+	customerTx := customerrepo.NewCustomerRepository(tx, p.meter, p.log)
 	lockedCustomer, err := customerTx.FindByNIKWithLock(ctx, req.CustomerNIK)
 	if err != nil {
 		span.SetStatus(codes.Error, "Error finding customer")
@@ -141,11 +175,22 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 		return nil, err
 	}
 
+	// Screening blacklist sebelum transaksi dibuat
+	customerID := lockedCustomer.ID
+	if _, err := p.screeningService.Screen(ctx, domain.ScreeningTransaction, &customerID, lockedCustomer.NIK, lockedCustomer.LegalName); err != nil {
+		span.SetStatus(codes.Error, "Blacklist screening failed")
+		span.RecordError(err)
+		p.log.Warn("Transaction stopped by blacklist screening", zap.String("customer_nik", req.CustomerNIK), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "screening_failed")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
 	// 2. Mendapatkan Tenor
 	tenorTx := tenorrepo.NewTenorRepository(
 		tx,
 		otel.GetMeterProvider().Meter(""),
-		otel.GetTracerProvider().Tracer(""),
 		zap.L(),
 	)
 	tenor, err := tenorTx.FindByDuration(ctx, req.TenorMonths)
@@ -169,14 +214,64 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 		return nil, err
 	}
 
+	// Suspensi akun dan limit yang dibekukan menolak transaksi berapa pun sisa limitnya
+	restrictionTx := restrictionrepo.NewCustomerRestrictionRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	restrictions, err := restrictionTx.FindActive(ctx, lockedCustomer.ID, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "Error finding customer restrictions")
+		span.RecordError(err)
+		p.log.Error("Error finding customer restrictions", zap.Uint64("customer_id", lockedCustomer.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "restriction_lookup_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+	if err := restrictionError(domain.BlockingRestriction(restrictions, tenor.ID, time.Now())); err != nil {
+		span.SetStatus(codes.Error, "Customer is restricted")
+		span.RecordError(err)
+		p.log.Warn("Transaction blocked by customer restriction", zap.Uint64("customer_id", lockedCustomer.ID), zap.Uint("tenor_id", tenor.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "customer_restricted")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	// Akun yang sudah ditutup customer tidak bisa dipakai lagi
+	if lockedCustomer.ClosedAt != nil {
+		err = common.ErrAccountClosed
+		span.SetStatus(codes.Error, "Customer account is closed")
+		span.RecordError(err)
+		p.log.Warn("Transaction blocked for closed account", zap.Uint64("customer_id", lockedCustomer.ID), zap.Time("closed_at", *lockedCustomer.ClosedAt), zap.String("trace_id", span.SpanContext().TraceID().String()))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "account_closed")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	// Akun dormant harus diaktifkan kembali oleh customer lewat re-KYC
+	if lockedCustomer.DormantAt != nil {
+		err = common.ErrCustomerDormant
+		span.SetStatus(codes.Error, "Customer is dormant")
+		span.RecordError(err)
+		p.log.Warn("Transaction blocked for dormant customer", zap.Uint64("customer_id", lockedCustomer.ID), zap.Time("dormant_at", *lockedCustomer.DormantAt), zap.String("trace_id", span.SpanContext().TraceID().String()))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "customer_dormant")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
 	// 3. Validasi ulang limit di dalam transanksi yang terkunci
 	limitTx := limitrepo.NewLimitRepository(
 		tx,
 		otel.GetMeterProvider().Meter(""),
-		otel.GetTracerProvider().Tracer(""),
 		zap.L(),
 	)
-	limit, err := limitTx.FindByCustomerIDAndTenorID(ctx, lockedCustomer.ID, tenor.ID)
+	// Limit yang dipakai adalah yang berlaku saat transaksi dibuat, perubahan terjadwal belum ikut
+	limit, err := limitTx.FindByCustomerIDAndTenorID(ctx, lockedCustomer.ID, tenor.ID, time.Now())
 	if err != nil {
 		span.SetStatus(codes.Error, "Error finding limit")
 		span.RecordError(err)
@@ -196,12 +291,92 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
 		return nil, err
 	}
-	totalLimit := limit.LimitAmount
+
+	// Limit dan pemakaian dibandingkan dalam IDR, kurs transaksi disimpan bersama kontrak
+	transactionCurrency := currency.Normalize(req.Currency)
+	fxRate, err := p.rateToIDR(ctx, transactionCurrency)
+	if err != nil {
+		span.SetStatus(codes.Error, "Error resolving transaction fx rate")
+		span.RecordError(err)
+		p.log.Warn("Error resolving transaction fx rate", zap.String("currency", transactionCurrency), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "fx_rate_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+	limitRate, err := p.rateToIDR(ctx, limit.Currency)
+	if err != nil {
+		span.SetStatus(codes.Error, "Error resolving limit fx rate")
+		span.RecordError(err)
+		p.log.Warn("Error resolving limit fx rate", zap.String("currency", limit.Currency), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "fx_rate_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+	totalLimit := currency.ToIDR(limit.LimitAmount, limitRate)
+
+	// Aturan produk tenor dicek terhadap OTR dalam IDR sebelum biaya dihitung
+	if err := checkProduct(tenor, currency.ToIDR(req.OTRAmount, fxRate), lockedCustomer.BirthDate, req.AssetCategory, p.maxAgeAtEnd, time.Now()); err != nil {
+		err = amountField(err, "otr_amount")
+		span.SetStatus(codes.Error, "Transaction rejected by tenor product rules")
+		span.RecordError(err)
+		p.log.Warn("Transaction rejected by tenor product rules", zap.Uint8("tenor_months", req.TenorMonths), zap.String("asset_category", req.AssetCategory), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "product_rule_violation")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	adminFee, commission, err := p.resolveFees(ctx, req, fxRate)
+	if err != nil {
+		span.SetStatus(codes.Error, "Error resolving partner fees")
+		span.RecordError(err)
+		p.log.Warn("Error resolving partner fees", zap.Uint8("tenor_months", req.TenorMonths), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "fee_schedule_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	regionCode, branchCode, err := p.resolveRegion(ctx, req)
+	if err != nil {
+		span.SetStatus(codes.Error, "Error resolving transaction region")
+		span.RecordError(err)
+		p.log.Warn("Error resolving transaction region", zap.String("region_code", req.RegionCode), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "region_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	// Diskon biaya admin mengurangi pokok, jadi promo diterapkan sebelum pengecekan limit
+	promotionTx := promotionrepo.NewPromotionRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	var promotion *domain.Promotion
+	discount := decimal.Zero
+	if req.PromoCode != "" {
+		promotion, discount, err = p.applyPromotion(ctx, promotionTx, req, lockedCustomer.ID, fxRate, adminFee)
+		if err != nil {
+			span.SetStatus(codes.Error, "Promo code rejected")
+			span.RecordError(err)
+			p.log.Warn("Promo code rejected", zap.String("promo_code", req.PromoCode), zap.Uint64("customer_id", lockedCustomer.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+			p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "promotion_error")))
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+			return nil, err
+		}
+		if promotion.Target == domain.PromotionAdminFee {
+			adminFee = adminFee.Sub(discount)
+		}
+	}
 
 	transactionTx := transactionrepo.NewTransactionRepository(
 		tx,
 		otel.GetMeterProvider().Meter(""),
-		otel.GetTracerProvider().Tracer(""),
 		zap.L(),
 	)
 	usedAmount, err := transactionTx.SumActivePrincipalByCustomerIDAndTenorID(ctx, lockedCustomer.ID, tenor.ID)
@@ -215,17 +390,18 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 		return nil, err
 	}
 
-	remainingLimit := totalLimit - usedAmount
-	transactionPrincipal := req.OTRAmount + req.AdminFee
+	remainingLimit := totalLimit.Sub(usedAmount)
+	transactionPrincipal := req.OTRAmount.Add(adminFee)
+	principalIDR := currency.ToIDR(transactionPrincipal, fxRate)
 
-	if remainingLimit < transactionPrincipal {
+	if remainingLimit.LessThan(principalIDR) {
 		err = common.ErrInsufficientLimit
 		span.SetStatus(codes.Error, "Insufficient limit")
 		span.RecordError(err)
 		p.log.Warn("Insufficient limit for transaction",
 			zap.String("customer_nik", req.CustomerNIK),
-			zap.Float64("remaining_limit", remainingLimit),
-			zap.Float64("required_principal", transactionPrincipal),
+			zap.Stringer("remaining_limit", remainingLimit),
+			zap.Stringer("required_principal", principalIDR),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 		)
 		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "insufficient_limit")))
@@ -235,35 +411,200 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 	}
 
 	// 4. Hitung komponen finansial lainnya (business logic)
-	totalInterest := req.OTRAmount * 0.02 * float64(req.TenorMonths)
-	totalInstallment := transactionPrincipal + totalInterest
+	totalInterest := money.FlatInterest(req.OTRAmount, req.TenorMonths)
+	if promotion != nil && promotion.Target == domain.PromotionInterest {
+		totalInterest = totalInterest.Sub(discount)
+	}
+	totalInstallment := transactionPrincipal.Add(totalInterest)
 
-	// 5. Generate contract number
-	contractNumber := fmt.Sprintf("KTR-%s-%d", time.Now().Format("20060102"), time.Now().UnixNano()%100000)
+	if err := p.checkAffordability(ctx, transactionTx, lockedCustomer, currency.ToIDR(totalInstallment, fxRate), req.TenorMonths); err != nil {
+		span.SetStatus(codes.Error, "Debt-service ratio check failed")
+		span.RecordError(err)
+		p.log.Warn("Transaction rejected by debt-service ratio check", zap.Uint64("customer_id", lockedCustomer.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "debt_service_ratio")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	// Batas konsentrasi portofolio dinilai dari pokok dalam IDR, kontrak sandbox tidak ikut dihitung
+	assetCategory := strings.ToLower(strings.TrimSpace(req.AssetCategory))
+	if !req.Sandbox {
+		if err := p.concentrationService.Check(ctx, assetCategory, regionCode, principalIDR); err != nil {
+			span.SetStatus(codes.Error, "Portfolio concentration check failed")
+			span.RecordError(err)
+			p.log.Warn("Transaction rejected by portfolio concentration check", zap.String("asset_category", assetCategory), zap.String("region_code", regionCode), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+			p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "concentration_error")))
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+			return nil, err
+		}
+	}
 
-	// 6. Buat entitas Transaction baru
+	// Kuota harian partner dipesan setelah semua validasi lain lolos, dan
+	// dikembalikan bila transaksi akhirnya tidak tersimpan
+	var reservation *domain.QuotaReservation
+	if req.PartnerID != nil && !req.Sandbox {
+		reservation, err = p.quotaService.Reserve(ctx, *req.PartnerID, currency.ToIDR(req.OTRAmount, fxRate), time.Now())
+		if err != nil {
+			span.SetStatus(codes.Error, "Partner quota rejected transaction")
+			span.RecordError(err)
+			p.log.Warn("Partner quota rejected transaction", zap.Uint64("partner_id", *req.PartnerID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+			p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "partner_quota_error")))
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+			return nil, err
+		}
+	}
+	committed := false
+	defer func() {
+		if committed || reservation == nil {
+			return
+		}
+		if err := p.quotaService.Release(context.WithoutCancel(ctx), reservation); err != nil {
+			p.log.Error("Failed to release partner quota", zap.Uint64("partner_id", reservation.PartnerID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		}
+	}()
+
+	// 5. Generate contract number dari sequence harian per region. Baris
+	// sequence ikut terkunci sampai commit, jadi nomor yang gagal dipakai
+	// dikembalikan bersama rollback dan tidak meninggalkan celah
+	sequenceTx := contractsequencerepo.NewContractSequenceRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	day := time.Now().Format("20060102")
+
+	// 6. Buat entitas Transaction baru, nomor kontrak diisi per percobaan
 	newTransaction := domain.Transaction{
-		ContractNumber:         contractNumber,
 		CustomerID:             lockedCustomer.ID,
 		TenorID:                tenor.ID,
 		AssetName:              req.AssetName,
+		AssetCategory:          assetCategory,
 		OTRAmount:              req.OTRAmount,
-		AdminFee:               req.AdminFee,
+		AdminFee:               adminFee,
 		TotalInterest:          totalInterest,
 		TotalInstallmentAmount: totalInstallment,
+		CommissionAmount:       commission,
+		DiscountAmount:         discount,
 		Status:                 domain.TransactionActive,
+		PartnerID:              req.PartnerID,
+		IsSandbox:              req.Sandbox,
+		Currency:               transactionCurrency,
+		FxRate:                 fxRate,
+		RegionCode:             regionCode,
+		BranchCode:             branchCode,
+		Latitude:               req.Latitude,
+		Longitude:              req.Longitude,
 	}
 
-	// 7. Simpan transaksi baru ke DB
-	if err := transactionTx.CreateTransaction(ctx, &newTransaction); err != nil {
+	// 7. Simpan transaksi baru ke DB. Nomor yang ternyata sudah terpakai,
+	// misalnya kontrak lama yang tidak tercatat di sequence, diganti nomor
+	// berikutnya sampai maxContractNumberAttempts kali
+	for attempt := 1; ; attempt++ {
+		sequence, err := sequenceTx.Next(ctx, req.Sandbox, regionCode, day)
+		if err != nil {
+			span.SetStatus(codes.Error, "Failed to allocate contract number")
+			span.RecordError(err)
+			p.log.Error("Failed to allocate contract number", zap.String("region_code", regionCode), zap.String("day", day), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+			p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "contract_sequence_error")))
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+			return nil, fmt.Errorf("failed to allocate contract number: %w", err)
+		}
+		newTransaction.ContractNumber = formatContractNumber(req.Sandbox, regionCode, day, sequence)
+
+		err = transactionTx.CreateTransaction(ctx, &newTransaction)
+		if err == nil {
+			break
+		}
+		duplicate := errors.Is(err, common.ErrDuplicateContract)
+		if duplicate && attempt < maxContractNumberAttempts {
+			p.log.Warn("Contract number already taken, retrying with the next one", zap.String("contract_number", newTransaction.ContractNumber), zap.Int("attempt", attempt), zap.String("trace_id", span.SpanContext().TraceID().String()))
+			continue
+		}
+
+		errorType := "create_record_failed"
+		if duplicate {
+			errorType = "duplicate_contract"
+		}
 		span.SetStatus(codes.Error, "Failed to create transaction record")
 		span.RecordError(err)
-		p.log.Error("Failed to create transaction record", zap.String("contract_number", contractNumber), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
-		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "create_record_failed")))
+		p.log.Error("Failed to create transaction record", zap.String("contract_number", newTransaction.ContractNumber), zap.Int("attempt", attempt), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", errorType)))
 		duration := float64(time.Since(start).Milliseconds())
 		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
 		return nil, fmt.Errorf("failed to create transaction record: %w", err)
 	}
+	contractNumber := newTransaction.ContractNumber
+
+	// Ringkasan exposure ikut transaksi DB yang sama agar tidak pernah
+	// menyimpang dari kontrak yang tersimpan
+	if !req.Sandbox {
+		exposureTx := exposurerepo.NewCustomerExposureRepository(
+			tx,
+			otel.GetMeterProvider().Meter(""),
+			zap.L(),
+		)
+		if err := exposureTx.Add(ctx, lockedCustomer.ID, tenor.ID, principalIDR); err != nil {
+			span.SetStatus(codes.Error, "Failed to update customer exposure")
+			span.RecordError(err)
+			p.log.Error("Failed to update customer exposure", zap.String("contract_number", contractNumber), zap.Uint64("customer_id", lockedCustomer.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+			p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "exposure_update_failed")))
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+			return nil, fmt.Errorf("failed to update customer exposure: %w", err)
+		}
+
+		eventData := map[string]string{
+			"otr_amount":   req.OTRAmount.String(),
+			"currency":     transactionCurrency,
+			"tenor_months": strconv.Itoa(int(tenor.DurationMonths)),
+		}
+		if req.PartnerID != nil {
+			eventData["partner_id"] = strconv.FormatUint(*req.PartnerID, 10)
+		}
+		eventTx := customereventrepo.NewCustomerEventRepository(
+			tx,
+			otel.GetMeterProvider().Meter(""),
+			zap.L(),
+		)
+		if err := eventTx.Append(ctx, &domain.CustomerEvent{
+			CustomerID:  lockedCustomer.ID,
+			Type:        domain.CustomerTransactionCreated,
+			ReferenceID: contractNumber,
+			Data:        eventData,
+		}); err != nil {
+			span.SetStatus(codes.Error, "Failed to record transaction event")
+			span.RecordError(err)
+			p.log.Error("Failed to record transaction event", zap.String("contract_number", contractNumber), zap.Uint64("customer_id", lockedCustomer.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+			p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "event_failed")))
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+			return nil, fmt.Errorf("failed to record transaction event: %w", err)
+		}
+	}
+
+	// Transaksi sandbox tidak memakai kuota promo
+	if promotion != nil && !req.Sandbox {
+		redemption := &domain.PromotionRedemption{
+			PromotionID:    promotion.ID,
+			CustomerID:     lockedCustomer.ID,
+			TransactionID:  newTransaction.ID,
+			DiscountAmount: discount,
+			Currency:       transactionCurrency,
+		}
+		if err := promotionTx.CreateRedemption(ctx, redemption); err != nil {
+			span.SetStatus(codes.Error, "Failed to record promotion redemption")
+			span.RecordError(err)
+			p.log.Error("Failed to record promotion redemption", zap.String("contract_number", contractNumber), zap.String("promo_code", promotion.Code), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+			p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("error_type", "create_redemption_failed")))
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
+			return nil, fmt.Errorf("failed to record promotion redemption: %w", err)
+		}
+	}
 
 	// 8. Jika semua berhasil, commit transaksi
 	if err := tx.Commit().Error; err != nil {
@@ -275,6 +616,7 @@ func (p *partnerService) CreateTransaction(ctx context.Context, req dto.CreateTr
 		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "create_transaction"), attribute.String("service", "partner"), attribute.String("status", "error")))
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	committed = true
 
 	p.transactionsCreated.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "partner")))
 	duration := float64(time.Since(start).Milliseconds())
@@ -315,10 +657,21 @@ func (p *partnerService) CheckLimit(ctx context.Context, req dto.CheckLimitReque
 	span.SetAttributes(
 		attribute.String("customer.nik", req.CustomerNIK),
 		attribute.Int("transaction.tenor_months", int(req.TenorMonths)),
-		attribute.Float64("transaction.amount", req.TransactionAmount),
+		attribute.Float64("transaction.amount", req.TransactionAmount.InexactFloat64()),
 		attribute.String("service", "partner"),
 	)
 
+	if !req.TransactionAmount.IsPositive() {
+		err := &common.FieldError{Field: "transaction_amount", Err: common.ErrAmountNotPositive}
+		span.SetStatus(codes.Error, "Invalid transaction amount")
+		span.RecordError(err)
+		p.log.Warn("Invalid transaction amount", zap.Stringer("transaction_amount", req.TransactionAmount), zap.String("trace_id", span.SpanContext().TraceID().String()))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("error_type", "invalid_amount")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
 	// 1. Validasi Customer & Tenor
 	cust, err := p.customerRepository.FindByNIK(ctx, req.CustomerNIK)
 	if err != nil {
@@ -372,8 +725,41 @@ func (p *partnerService) CheckLimit(ctx context.Context, req dto.CheckLimitReque
 		return nil, err
 	}
 
+	restrictions, err := p.restrictionRepository.FindActive(ctx, cust.ID, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "Error finding customer restrictions")
+		span.RecordError(err)
+		p.log.Error("Error finding customer restrictions", zap.Uint64("customer_id", cust.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("error_type", "restriction_lookup_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+	// Kode alasan tidak dibagikan ke partner, cukup status dan pesan penolakan
+	if message := blockedMessage(cust, domain.BlockingRestriction(restrictions, tenor.ID, time.Now())); message != "" {
+		response := &dto.CheckLimitResponse{
+			Status:  "rejected",
+			Message: message,
+		}
+
+		p.limitsChecked.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "partner"), attribute.String("status", response.Status)))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("status", "success")))
+		p.log.Info("Limit check rejected for blocked customer",
+			zap.String("customer_nik", req.CustomerNIK),
+			zap.Uint("tenor_id", tenor.ID),
+			zap.String("message", message),
+			zap.Float64("duration_ms", duration),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+		span.SetStatus(codes.Ok, "Limit check completed")
+		span.SetAttributes(attribute.String("limit_check.status", response.Status))
+
+		return response, nil
+	}
+
 	// 2. Hitung Sisa Limit
-	limit, err := p.limitRepository.FindByCustomerIDAndTenorID(ctx, cust.ID, tenor.ID)
+	limit, err := p.limitRepository.FindByCustomerIDAndTenorID(ctx, cust.ID, tenor.ID, time.Now())
 	if err != nil {
 		span.SetStatus(codes.Error, "Error finding limit")
 		span.RecordError(err)
@@ -394,8 +780,7 @@ func (p *partnerService) CheckLimit(ctx context.Context, req dto.CheckLimitReque
 		return nil, err
 	}
 
-	usedAmount, err := p.transactionRepository.SumActivePrincipalByCustomerIDAndTenorID(
-		ctx, cust.ID, tenor.ID)
+	usedAmount, err := p.usedPrincipal(ctx, cust.ID, tenor.ID)
 	if err != nil {
 		span.SetStatus(codes.Error, "Error calculating used amount")
 		span.RecordError(err)
@@ -406,20 +791,81 @@ func (p *partnerService) CheckLimit(ctx context.Context, req dto.CheckLimitReque
 		return nil, err
 	}
 
-	remainingLimit := limit.LimitAmount - usedAmount
+	requestCurrency := currency.Normalize(req.Currency)
+	requestRate, err := p.rateToIDR(ctx, requestCurrency)
+	if err != nil {
+		span.SetStatus(codes.Error, "Error resolving request fx rate")
+		span.RecordError(err)
+		p.log.Warn("Error resolving request fx rate", zap.String("currency", requestCurrency), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("error_type", "fx_rate_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+	limitRate, err := p.rateToIDR(ctx, limit.Currency)
+	if err != nil {
+		span.SetStatus(codes.Error, "Error resolving limit fx rate")
+		span.RecordError(err)
+		p.log.Warn("Error resolving limit fx rate", zap.String("currency", limit.Currency), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("error_type", "fx_rate_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	if err := checkProduct(tenor, currency.ToIDR(req.TransactionAmount, requestRate), cust.BirthDate, req.AssetCategory, p.maxAgeAtEnd, time.Now()); err != nil {
+		err = amountField(err, "transaction_amount")
+		span.SetStatus(codes.Error, "Limit check rejected by tenor product rules")
+		span.RecordError(err)
+		p.log.Warn("Limit check rejected by tenor product rules", zap.Uint8("tenor_months", req.TenorMonths), zap.String("asset_category", req.AssetCategory), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("error_type", "product_rule_violation")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
+
+	// Sisa limit dihitung dalam IDR lalu dilaporkan dalam mata uang request
+	remainingIDR := currency.ToIDR(limit.LimitAmount, limitRate).Sub(usedAmount)
+	remainingLimit := currency.FromIDR(remainingIDR, requestRate)
+
+	// Cicilan baru diperkirakan dari bunga flat tanpa biaya admin dan promo,
+	// yang baru diketahui saat transaksi dibuat
+	amountIDR := currency.ToIDR(req.TransactionAmount, requestRate)
+	installmentIDR := currency.ToIDR(req.TransactionAmount.Add(money.FlatInterest(req.TransactionAmount, req.TenorMonths)), requestRate)
+	affordabilityErr := p.checkAffordability(ctx, p.transactionRepository, cust, installmentIDR, req.TenorMonths)
+	if affordabilityErr != nil && !errors.Is(affordabilityErr, common.ErrDebtServiceRatioExceeded) {
+		err = affordabilityErr
+		span.SetStatus(codes.Error, "Error checking debt-service ratio")
+		span.RecordError(err)
+		p.log.Error("Error checking debt-service ratio", zap.Uint64("customer_id", cust.ID), zap.String("trace_id", span.SpanContext().TraceID().String()), zap.Error(err))
+		p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("error_type", "sum_installment_error")))
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "check_limit"), attribute.String("service", "partner"), attribute.String("status", "error")))
+		return nil, err
+	}
 
 	// 3. Buat Response
 	var response *dto.CheckLimitResponse
-	if remainingLimit >= req.TransactionAmount {
+	switch {
+	case remainingIDR.LessThan(amountIDR):
 		response = &dto.CheckLimitResponse{
-			Status:         "approved",
-			Message:        "Limit is sufficient.",
+			Status:         "rejected",
+			Message:        "Insufficient limit for this transaction.",
+			Currency:       requestCurrency,
 			RemainingLimit: remainingLimit,
 		}
-	} else {
+	case affordabilityErr != nil:
 		response = &dto.CheckLimitResponse{
 			Status:         "rejected",
-			Message:        "Insufficient limit for this transaction.",
+			Message:        "Monthly installments would exceed the allowed share of the customer's salary.",
+			Currency:       requestCurrency,
+			RemainingLimit: remainingLimit,
+		}
+	default:
+		response = &dto.CheckLimitResponse{
+			Status:         "approved",
+			Message:        "Limit is sufficient.",
+			Currency:       requestCurrency,
 			RemainingLimit: remainingLimit,
 		}
 	}
@@ -430,7 +876,7 @@ func (p *partnerService) CheckLimit(ctx context.Context, req dto.CheckLimitReque
 	p.log.Info("Limit check completed successfully",
 		zap.String("customer_nik", req.CustomerNIK),
 		zap.String("check_status", response.Status),
-		zap.Float64("remaining_limit", remainingLimit),
+		zap.Stringer("remaining_limit", remainingLimit),
 		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 		zap.String("span_id", span.SpanContext().SpanID().String()),
@@ -438,18 +884,300 @@ func (p *partnerService) CheckLimit(ctx context.Context, req dto.CheckLimitReque
 	span.SetStatus(codes.Ok, "Limit check completed")
 	span.SetAttributes(
 		attribute.String("limit_check.status", response.Status),
-		attribute.Float64("limit_check.remaining", remainingLimit),
+		attribute.Float64("limit_check.remaining", remainingLimit.InexactFloat64()),
 	)
 
 	return response, nil
 }
 
+// restrictionError maps the restriction blocking a transaction to the error
+// returned to the partner, nil when nothing blocks it.
+func restrictionError(restriction *domain.CustomerRestriction) error {
+	switch {
+	case restriction == nil:
+		return nil
+	case restriction.IsSuspension():
+		return common.ErrCustomerSuspended
+	default:
+		return common.ErrLimitFrozen
+	}
+}
+
+// blockedMessage is the reason given to the partner when a limit check is
+// rejected before the limit is looked at, empty when nothing blocks it.
+func blockedMessage(customer *domain.Customer, restriction *domain.CustomerRestriction) string {
+	switch {
+	case customer.ClosedAt != nil:
+		return "Customer account is closed."
+	case restriction != nil && restriction.IsSuspension():
+		return "Customer account is suspended."
+	case customer.DormantAt != nil:
+		return "Customer account is dormant and must be reactivated by the customer."
+	case restriction != nil:
+		return "Limit for this tenor is frozen."
+	default:
+		return ""
+	}
+}
+
+// checkAffordability returns ErrDebtServiceRatioExceeded when the monthly
+// installments of the customer's active contracts plus one of
+// installmentIDR spread over months would exceed the ratio allowed for the
+// customer's risk tier.
+func (p *partnerService) checkAffordability(ctx context.Context, transactions repository.TransactionRepository, customer *domain.Customer, installmentIDR decimal.Decimal, months uint8) error {
+	maxInstallment, ok, err := p.affordability.maxInstallment(ctx, customer)
+	if err != nil || !ok || months == 0 {
+		return err
+	}
+
+	existing, err := transactions.SumActiveMonthlyInstallmentByCustomerID(ctx, customer.ID)
+	if err != nil {
+		return err
+	}
+	monthly := existing.Add(installmentIDR.Div(decimal.NewFromInt(int64(months))))
+	if monthly.GreaterThan(maxInstallment) {
+		return common.ErrDebtServiceRatioExceeded
+	}
+	return nil
+}
+
+// checkProduct applies the financing rules of tenor to a transaction of
+// amountIDR for a customer born on birthDate.
+func checkProduct(tenor *domain.Tenor, amountIDR decimal.Decimal, birthDate time.Time, assetCategory string, defaultMaxAgeAtEnd uint8, now time.Time) error {
+	if tenor.MinAmount.IsPositive() && amountIDR.LessThan(tenor.MinAmount) {
+		return fmt.Errorf("%w: minimum is %s IDR", common.ErrAmountOutsideProduct, tenor.MinAmount)
+	}
+	if tenor.MaxAmount.IsPositive() && amountIDR.GreaterThan(tenor.MaxAmount) {
+		return fmt.Errorf("%w: maximum is %s IDR", common.ErrAmountOutsideProduct, tenor.MaxAmount)
+	}
+
+	age := domain.AgeAt(birthDate, now)
+	if tenor.MinAge > 0 && age < int(tenor.MinAge) {
+		return common.ErrAgeNotEligible
+	}
+	if tenor.MaxAge > 0 && age > int(tenor.MaxAge) {
+		return common.ErrAgeNotEligible
+	}
+
+	// Usia dihitung pada tanggal jatuh tempo cicilan terakhir
+	maxAgeAtEnd := tenor.MaxAgeAtEnd
+	if maxAgeAtEnd == 0 {
+		maxAgeAtEnd = defaultMaxAgeAtEnd
+	}
+	if maxAgeAtEnd > 0 && domain.AgeAt(birthDate, now.AddDate(0, int(tenor.DurationMonths), 0)) > int(maxAgeAtEnd) {
+		return common.ErrAgeAtTenorEnd
+	}
+
+	if !tenor.AllowsAsset(assetCategory) {
+		return common.ErrAssetNotAllowed
+	}
+	return nil
+}
+
+// checkAmounts rejects a non-positive OTR amount and a negative admin fee
+// sent by the partner.
+func checkAmounts(otrAmount decimal.Decimal, adminFee *decimal.Decimal) error {
+	if !otrAmount.IsPositive() {
+		return &common.FieldError{Field: "otr_amount", Err: common.ErrAmountNotPositive}
+	}
+	if adminFee != nil && adminFee.IsNegative() {
+		return &common.FieldError{Field: "admin_fee", Err: common.ErrNegativeAdminFee}
+	}
+	return nil
+}
+
+// amountField attaches field to an amount error of checkProduct, leaving
+// the age and asset errors as they are.
+func amountField(err error, field string) error {
+	if errors.Is(err, common.ErrAmountOutsideProduct) {
+		return &common.FieldError{Field: field, Err: err}
+	}
+	return err
+}
+
+// resolveFees returns the admin fee and partner commission for req, both in
+// the transaction currency. An admin fee sent by the partner wins over the
+// schedule; the flat part of a schedule is quoted in IDR.
+func (p *partnerService) resolveFees(ctx context.Context, req dto.CreateTransactionRequest, fxRate decimal.Decimal) (adminFee, commission decimal.Decimal, err error) {
+	var schedule *domain.PartnerFeeSchedule
+	if req.PartnerID != nil {
+		schedule, err = p.feeScheduleRepository.FindForTenor(ctx, *req.PartnerID, req.TenorMonths)
+		if err != nil {
+			return decimal.Zero, decimal.Zero, fmt.Errorf("failed to find fee schedule: %w", err)
+		}
+	}
+
+	switch {
+	case req.AdminFee != nil:
+		adminFee = money.Round(*req.AdminFee)
+	case schedule != nil:
+		flat := currency.FromIDR(schedule.AdminFeeFlat, fxRate)
+		adminFee = money.Round(flat.Add(req.OTRAmount.Mul(schedule.AdminFeeRate)))
+	default:
+		return decimal.Zero, decimal.Zero, common.ErrAdminFeeRequired
+	}
+
+	commission = decimal.Zero
+	if schedule != nil {
+		commission = money.Round(req.OTRAmount.Mul(schedule.CommissionRate))
+	}
+
+	return adminFee, commission, nil
+}
+
+// usedPrincipal reads the customer's active principal on a tenor from the
+// exposure summary. Pairs without a summary row yet, e.g. contracts booked
+// before the table existed, fall back to summing the transactions.
+func (p *partnerService) usedPrincipal(ctx context.Context, customerID uint64, tenorID uint) (decimal.Decimal, error) {
+	exposure, err := p.exposureRepository.Find(ctx, customerID, tenorID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if exposure != nil {
+		return exposure.ActivePrincipal, nil
+	}
+	return p.transactionRepository.SumActivePrincipalByCustomerIDAndTenorID(ctx, customerID, tenorID)
+}
+
+// maxContractNumberAttempts bounds how many contract numbers
+// CreateTransaction tries before giving up with common.ErrDuplicateContract.
+const maxContractNumberAttempts = 3
+
+// formatContractNumber builds numbers such as KTR-JKT-20250101-000123.
+// Transactions without a region leave out the region part, and sandbox
+// numbers come from their own sequence with an SBX- prefix.
+func formatContractNumber(sandbox bool, regionCode, day string, sequence uint64) string {
+	parts := []string{"KTR"}
+	if sandbox {
+		parts = []string{"SBX", "KTR"}
+	}
+	if regionCode != "" {
+		parts = append(parts, regionCode)
+	}
+	parts = append(parts, day, fmt.Sprintf("%06d", sequence))
+	return strings.Join(parts, "-")
+}
+
+// resolveRegion returns the region and branch recorded on the transaction.
+// Codes sent by the partner win; missing ones fall back to the partner
+// profile. A named region must exist and be active, while a transaction
+// without any region is stored as unassigned.
+func (p *partnerService) resolveRegion(ctx context.Context, req dto.CreateTransactionRequest) (regionCode, branchCode string, err error) {
+	regionCode = strings.ToUpper(strings.TrimSpace(req.RegionCode))
+	branchCode = strings.TrimSpace(req.BranchCode)
+
+	if (regionCode == "" || branchCode == "") && req.PartnerID != nil {
+		partner, err := p.partnerRepository.FindByID(ctx, *req.PartnerID)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to find partner: %w", err)
+		}
+		if partner != nil {
+			if regionCode == "" {
+				regionCode = partner.RegionCode
+			}
+			if branchCode == "" {
+				branchCode = partner.BranchCode
+			}
+		}
+	}
+
+	if regionCode == "" {
+		return "", branchCode, nil
+	}
+
+	region, err := p.regionRepository.FindByCode(ctx, regionCode)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find region: %w", err)
+	}
+	if region == nil || !region.Active {
+		return "", "", common.ErrRegionNotFound
+	}
+
+	return regionCode, branchCode, nil
+}
+
+// applyPromotion locks the promotion behind req.PromoCode and returns it with
+// the discount it grants, in the transaction currency. The caller holds the
+// customer row lock, so the per-customer count cannot change underneath it;
+// the promotion lock does the same for the campaign count.
+func (p *partnerService) applyPromotion(
+	ctx context.Context, promotionTx repository.PromotionRepository,
+	req dto.CreateTransactionRequest, customerID uint64, fxRate, adminFee decimal.Decimal,
+) (*domain.Promotion, decimal.Decimal, error) {
+	promotion, err := promotionTx.LockByCode(ctx, strings.ToUpper(req.PromoCode))
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to find promotion: %w", err)
+	}
+	if promotion == nil || !promotion.Active {
+		return nil, decimal.Zero, common.ErrPromotionNotFound
+	}
+
+	now := time.Now()
+	switch {
+	case now.Before(promotion.ValidFrom), !now.Before(promotion.ValidUntil):
+		return nil, decimal.Zero, common.ErrPromotionNotApplicable
+	case promotion.PartnerID != nil && (req.PartnerID == nil || *promotion.PartnerID != *req.PartnerID):
+		return nil, decimal.Zero, common.ErrPromotionNotApplicable
+	case promotion.TenorMonths != 0 && promotion.TenorMonths != req.TenorMonths:
+		return nil, decimal.Zero, common.ErrPromotionNotApplicable
+	case currency.ToIDR(req.OTRAmount, fxRate).LessThan(promotion.MinOTRAmount):
+		return nil, decimal.Zero, common.ErrPromotionNotApplicable
+	}
+
+	total, byCustomer, err := promotionTx.CountRedemptions(ctx, promotion.ID, customerID)
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to count promotion redemptions: %w", err)
+	}
+	if (promotion.MaxRedemptions > 0 && total >= int64(promotion.MaxRedemptions)) ||
+		(promotion.MaxPerCustomer > 0 && byCustomer >= int64(promotion.MaxPerCustomer)) {
+		return nil, decimal.Zero, common.ErrPromotionExhausted
+	}
+
+	charge := adminFee
+	if promotion.Target == domain.PromotionInterest {
+		charge = money.FlatInterest(req.OTRAmount, req.TenorMonths)
+	}
+
+	var discount decimal.Decimal
+	if promotion.DiscountType == domain.DiscountFlat {
+		discount = currency.FromIDR(promotion.DiscountValue, fxRate)
+	} else {
+		discount = charge.Mul(promotion.DiscountValue)
+	}
+	if promotion.MaxDiscount.IsPositive() {
+		discount = decimal.Min(discount, currency.FromIDR(promotion.MaxDiscount, fxRate))
+	}
+
+	// Diskon tidak boleh membuat biaya menjadi negatif
+	return promotion, money.Round(decimal.Min(discount, charge)), nil
+}
+
+// rateToIDR returns 1 for IDR so contracts in the book currency never need
+// an uploaded rate.
+func (p *partnerService) rateToIDR(ctx context.Context, code string) (decimal.Decimal, error) {
+	if currency.IsBase(code) {
+		return decimal.NewFromInt(1), nil
+	}
+	return p.currencyConverter.RateToIDR(ctx, code, time.Now())
+}
+
 func NewPartnerService(
 	db *gorm.DB,
 	customerRepository repository.CustomerRepository,
 	tenorRepository repository.TenorRepository,
 	limitRepository repository.LimitRepository,
 	transactionRepository repository.TransactionRepository,
+	feeScheduleRepository repository.FeeScheduleRepository,
+	screeningService service.ScreeningServices,
+	currencyConverter service.CurrencyConverter,
+	quotaService service.PartnerQuotaServices,
+	concentrationService service.ConcentrationServices,
+	partnerRepository repository.PartnerRepository,
+	regionRepository repository.RegionRepository,
+	exposureRepository repository.CustomerExposureRepository,
+	restrictionRepository repository.CustomerRestrictionRepository,
+	maxAgeAtEnd uint8,
+	affordability Affordability,
 
 	meter metric.Meter,
 	tracer trace.Tracer,
@@ -487,6 +1215,17 @@ func NewPartnerService(
 		tenorRepository:       tenorRepository,
 		limitRepository:       limitRepository,
 		transactionRepository: transactionRepository,
+		feeScheduleRepository: feeScheduleRepository,
+		screeningService:      screeningService,
+		currencyConverter:     currencyConverter,
+		quotaService:          quotaService,
+		concentrationService:  concentrationService,
+		partnerRepository:     partnerRepository,
+		regionRepository:      regionRepository,
+		exposureRepository:    exposureRepository,
+		restrictionRepository: restrictionRepository,
+		maxAgeAtEnd:           maxAgeAtEnd,
+		affordability:         affordability,
 		meter:                 meter,
 		tracer:                tracer,
 		log:                   log,
@@ -0,0 +1,368 @@
+package partnerdebugsrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// alwaysRedacted are the body keys masked on top of Config.RedactFields,
+// since credentials have no debugging value.
+var alwaysRedacted = []string{"password", "api_key", "secret", "signing_secret", "token", "otp_token", "access_token", "refresh_token"}
+
+// sensitiveHeaders are matched as substrings of the lowercased header name.
+var sensitiveHeaders = []string{"key", "authorization", "cookie", "signature", "secret", "token"}
+
+// Config controls how long and how much is recorded. Debug mode lasts
+// Retention once turned on and records are purged Retention after they were
+// made. Cap is the number of records kept per key, MaxBodyBytes bounds each
+// stored body. RedactFields lists the body keys masked in every record.
+type Config struct {
+	Retention    time.Duration
+	Cap          int
+	MaxBodyBytes int
+	RedactFields []string
+}
+
+type partnerDebugService struct {
+	partnerDebugRepository repository.PartnerDebugRepository
+	cfg                    Config
+	redact                 map[string]bool
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	recordedCount     metric.Int64Counter
+}
+
+// SetMode implements PartnerDebugServices.
+func (s *partnerDebugService) SetMode(ctx context.Context, partnerID uint64, sandbox, enabled bool, now time.Time) (*time.Time, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetPartnerDebugMode")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Bool("partner.sandbox", sandbox),
+		attribute.Bool("debug.enabled", enabled),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_debug_mode"), attribute.String("service", "partner_debug")))
+
+	var until *time.Time
+	if enabled {
+		end := now.Add(s.cfg.Retention)
+		until = &end
+	}
+
+	updated, err := s.partnerDebugRepository.SetDebugUntil(ctx, partnerID, sandbox, until)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_debug_mode", "repository_error", fmt.Errorf("failed to set debug mode: %w", err))
+	}
+	if !updated {
+		return nil, s.recordError(ctx, span, start, "set_debug_mode", "partner_not_found", common.ErrPartnerNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "set_debug_mode",
+		zap.Uint64("partner_id", partnerID),
+		zap.Bool("sandbox", sandbox),
+		zap.Bool("enabled", enabled),
+	)
+
+	return until, nil
+}
+
+// Record implements PartnerDebugServices. The oldest records of the key are
+// dropped once it holds more than Config.Cap.
+func (s *partnerDebugService) Record(ctx context.Context, record *domain.PartnerDebugRecord) error {
+	ctx, span := s.tracer.Start(ctx, "service.RecordPartnerDebug")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(record.PartnerID)),
+		attribute.Bool("partner.sandbox", record.Sandbox),
+		attribute.String("http.route", record.Path),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "record_debug"), attribute.String("service", "partner_debug")))
+
+	record.RequestHeaders = s.sanitizeHeaders(record.RequestHeaders)
+	record.RequestBody = s.sanitizeBody(record.RequestBody)
+	record.ResponseBody = s.sanitizeBody(record.ResponseBody)
+
+	if err := s.partnerDebugRepository.Create(ctx, record); err != nil {
+		return s.recordError(ctx, span, start, "record_debug", "repository_error", fmt.Errorf("failed to store debug record: %w", err))
+	}
+
+	trimmed, err := s.partnerDebugRepository.Trim(ctx, record.PartnerID, record.Sandbox, s.cfg.Cap)
+	if err != nil {
+		return s.recordError(ctx, span, start, "record_debug", "repository_error", fmt.Errorf("failed to trim debug records: %w", err))
+	}
+
+	s.recordedCount.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "partner_debug"), attribute.Bool("sandbox", record.Sandbox)))
+	s.recordSuccess(ctx, span, start, "record_debug",
+		zap.Uint64("partner_id", record.PartnerID),
+		zap.Uint64("debug_record_id", record.ID),
+		zap.Int64("trimmed", trimmed),
+	)
+
+	return nil
+}
+
+// ListRecords implements PartnerDebugServices.
+func (s *partnerDebugService) ListRecords(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListPartnerDebugRecords")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Bool("partner.sandbox", sandbox),
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_debug_records"), attribute.String("service", "partner_debug")))
+
+	records, total, err := s.partnerDebugRepository.FindPaginated(ctx, partnerID, sandbox, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_debug_records", "repository_error", fmt.Errorf("failed to list debug records: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_debug_records",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int64("total", total),
+	)
+
+	return params.Paginated(records, total), nil
+}
+
+// GetRecord implements PartnerDebugServices.
+func (s *partnerDebugService) GetRecord(ctx context.Context, partnerID uint64, sandbox bool, id uint64) (*domain.PartnerDebugRecord, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetPartnerDebugRecord")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Bool("partner.sandbox", sandbox),
+		attribute.Int64("debug_record.id", int64(id)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_debug_record"), attribute.String("service", "partner_debug")))
+
+	record, err := s.partnerDebugRepository.FindByID(ctx, partnerID, sandbox, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_debug_record", "repository_error", fmt.Errorf("failed to get debug record: %w", err))
+	}
+	if record == nil {
+		return nil, s.recordError(ctx, span, start, "get_debug_record", "not_found", common.ErrDebugRecordNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_debug_record",
+		zap.Uint64("partner_id", partnerID),
+		zap.Uint64("debug_record_id", id),
+	)
+
+	return record, nil
+}
+
+// Purge implements PartnerDebugServices.
+func (s *partnerDebugService) Purge(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "service.PurgePartnerDebugRecords")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "purge_debug_records"), attribute.String("service", "partner_debug")))
+
+	deleted, err := s.partnerDebugRepository.DeleteBefore(ctx, now.Add(-s.cfg.Retention))
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "purge_debug_records", "repository_error", fmt.Errorf("failed to purge debug records: %w", err))
+	}
+
+	span.SetAttributes(attribute.Int64("debug_record.deleted", deleted))
+	s.recordSuccess(ctx, span, start, "purge_debug_records", zap.Int64("deleted", deleted))
+
+	return deleted, nil
+}
+
+// sanitizeHeaders masks credentials and signatures, header names are kept
+// so partners can still see what they sent.
+func (s *partnerDebugService) sanitizeHeaders(headers map[string]string) map[string]string {
+	sanitized := make(map[string]string, len(headers))
+	for name, value := range headers {
+		lower := strings.ToLower(name)
+		for _, sensitive := range sensitiveHeaders {
+			if strings.Contains(lower, sensitive) {
+				value = telemetry.Redacted
+				break
+			}
+		}
+		sanitized[name] = value
+	}
+	return sanitized
+}
+
+// sanitizeBody masks the redacted keys of a JSON body at any depth. Other
+// bodies, such as multipart uploads, are replaced by their size because
+// their content cannot be masked reliably.
+func (s *partnerDebugService) sanitizeBody(body string) string {
+	if body == "" {
+		return ""
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Sprintf("[non-JSON body of %d bytes omitted]", len(body))
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s.redactValue(value)); err != nil {
+		return fmt.Sprintf("[body of %d bytes omitted]", len(body))
+	}
+
+	sanitized := strings.TrimSuffix(buf.String(), "\n")
+	if s.cfg.MaxBodyBytes > 0 && len(sanitized) > s.cfg.MaxBodyBytes {
+		// Potongan bisa jatuh di tengah karakter multibyte
+		sanitized = strings.ToValidUTF8(sanitized[:s.cfg.MaxBodyBytes], "") + "...[truncated]"
+	}
+	return sanitized
+}
+
+func (s *partnerDebugService) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, inner := range v {
+			if s.redact[normalizeKey(key)] {
+				v[key] = telemetry.Redacted
+				continue
+			}
+			v[key] = s.redactValue(inner)
+		}
+	case []any:
+		for i, inner := range v {
+			v[i] = s.redactValue(inner)
+		}
+	}
+	return value
+}
+
+// normalizeKey matches keys regardless of case and separators, so "NIK",
+// "birthDate" and "birth_date" are the same key.
+func normalizeKey(key string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(key) {
+		if r == '_' || r == '-' || r == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (s *partnerDebugService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Partner debug operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "partner_debug"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "partner_debug"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *partnerDebugService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "partner_debug"), attribute.String("status", "success")))
+
+	s.log.Info("Partner debug operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewPartnerDebugService(
+	partnerDebugRepository repository.PartnerDebugRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PartnerDebugServices {
+	if cfg.Retention <= 0 {
+		cfg.Retention = 24 * time.Hour
+	}
+	if cfg.Cap <= 0 {
+		cfg.Cap = 500
+	}
+
+	redact := make(map[string]bool)
+	for _, field := range append(cfg.RedactFields, alwaysRedacted...) {
+		redact[normalizeKey(field)] = true
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	recordedCount, _ := meter.Int64Counter(
+		"service.partner_debug.recorded",
+		metric.WithDescription("Number of partner requests recorded in debug mode"),
+		metric.WithUnit("{request}"),
+	)
+
+	return &partnerDebugService{
+		partnerDebugRepository: partnerDebugRepository,
+		cfg:                    cfg,
+		redact:                 redact,
+		meter:                  meter,
+		tracer:                 tracer,
+		log:                    log,
+		operationDuration:      operationDuration,
+		operationCount:         operationCount,
+		errorCount:             errorCount,
+		recordedCount:          recordedCount,
+	}
+}
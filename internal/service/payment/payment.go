@@ -0,0 +1,422 @@
+package paymentsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
+	paymentrepo "github.com/fazamuttaqien/multifinance/internal/repository/payment"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/paymentgateway"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type paymentService struct {
+	db                *gorm.DB
+	paymentRepository repository.PaymentRepository
+	verifiers         map[string]paymentgateway.Verifier
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	callbacksHandled  metric.Int64Counter
+}
+
+// HandleCallback implements PaymentServices.
+func (s *paymentService) HandleCallback(ctx context.Context, provider string, header http.Header, body []byte) (*domain.PaymentCallback, error) {
+	ctx, span := s.tracer.Start(ctx, "service.HandlePaymentCallback")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("payment.provider", provider))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "handle_payment_callback"), attribute.String("service", "payment")))
+
+	verifier, ok := s.verifiers[provider]
+	if !ok {
+		return nil, s.recordError(ctx, span, start, "handle_payment_callback", "unknown_provider", common.ErrUnknownPaymentProvider)
+	}
+
+	// 1. Verifikasi tanda tangan, callback yang ditolak tetap diarsipkan untuk investigasi
+	notification, err := verifier.Verify(header, body)
+	if err != nil {
+		rejected := common.ErrInvalidCallbackPayload
+		if errors.Is(err, paymentgateway.ErrInvalidSignature) {
+			rejected = common.ErrInvalidCallbackSignature
+		}
+
+		callback := &domain.PaymentCallback{
+			Provider: provider,
+			Outcome:  domain.CallbackRejected,
+			Error:    err.Error(),
+			Payload:  string(body),
+		}
+		if _, archiveErr := s.paymentRepository.ArchiveCallback(ctx, callback); archiveErr != nil {
+			s.log.Error("Failed to archive rejected payment callback", zap.String("provider", provider), zap.Error(archiveErr))
+		}
+		s.callbacksHandled.Add(ctx, 1, metric.WithAttributes(attribute.String("provider", provider), attribute.String("outcome", string(domain.CallbackRejected))))
+
+		return nil, s.recordError(ctx, span, start, "handle_payment_callback", "verification_error", fmt.Errorf("%w: %v", rejected, err))
+	}
+
+	span.SetAttributes(
+		attribute.String("payment.event_id", notification.EventID),
+		attribute.String("payment.status", string(notification.Status)),
+		attribute.String("transaction.contract_number", notification.Reference),
+	)
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, s.recordError(ctx, span, start, "handle_payment_callback", "transaction_begin_error", tx.Error)
+	}
+	defer tx.Rollback()
+
+	paymentTx := paymentrepo.NewPaymentRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	exposureTx := exposurerepo.NewCustomerExposureRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	eventTx := customereventrepo.NewCustomerEventRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+
+	// 2. Kunci kontrak agar callback paralel tidak menghitung ulang cicilan bersamaan.
+	// Nomor virtual account lebih dipercaya karena reference transfer bank diisi bebas
+	// oleh gateway
+	var contract *domain.Transaction
+	if notification.VirtualAccount != "" {
+		contract, err = paymentTx.LockContractByVirtualAccount(ctx, notification.VirtualAccount)
+	}
+	if contract == nil && err == nil {
+		contract, err = paymentTx.LockContract(ctx, notification.Reference)
+	}
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "handle_payment_callback", "repository_error", fmt.Errorf("failed to lock contract: %w", err))
+	}
+
+	eventID := notification.EventID
+	callback := &domain.PaymentCallback{
+		Provider: provider,
+		EventID:  &eventID,
+		Outcome:  domain.CallbackProcessed,
+		Payload:  string(body),
+	}
+	switch {
+	case contract == nil:
+		callback.Outcome = domain.CallbackUnmatched
+		callback.Error = "no contract matches the payment reference"
+	case contract.Currency != notification.Currency:
+		callback.Outcome = domain.CallbackUnmatched
+		callback.Error = fmt.Sprintf("payment currency %s does not match contract currency %s", notification.Currency, contract.Currency)
+	}
+
+	// 3. Replay protection: event yang sudah diarsipkan tidak diproses ulang
+	inserted, err := paymentTx.ArchiveCallback(ctx, callback)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "handle_payment_callback", "repository_error", fmt.Errorf("failed to archive callback: %w", err))
+	}
+	if !inserted {
+		s.callbacksHandled.Add(ctx, 1, metric.WithAttributes(attribute.String("provider", provider), attribute.String("outcome", string(domain.CallbackDuplicate))))
+		s.recordSuccess(ctx, span, start, "handle_payment_callback",
+			zap.String("provider", provider),
+			zap.String("event_id", eventID),
+			zap.String("outcome", string(domain.CallbackDuplicate)),
+		)
+		return &domain.PaymentCallback{Provider: provider, EventID: &eventID, Outcome: domain.CallbackDuplicate}, nil
+	}
+
+	if callback.Outcome == domain.CallbackProcessed {
+		occurredAt := notification.OccurredAt
+		incoming := &domain.Payment{
+			Provider:   provider,
+			ExternalID: notification.ExternalID,
+			Amount:     notification.Amount,
+			Currency:   notification.Currency,
+			Status:     domain.PaymentStatus(notification.Status),
+			PaidAt:     &occurredAt,
+		}
+		if err := s.applyPayment(ctx, paymentTx, exposureTx, eventTx, contract, incoming); err != nil {
+			return nil, s.recordError(ctx, span, start, "handle_payment_callback", "repository_error", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "handle_payment_callback", "transaction_commit_error", err)
+	}
+
+	s.callbacksHandled.Add(ctx, 1, metric.WithAttributes(attribute.String("provider", provider), attribute.String("outcome", string(callback.Outcome))))
+	s.recordSuccess(ctx, span, start, "handle_payment_callback",
+		zap.String("provider", provider),
+		zap.String("event_id", eventID),
+		zap.String("contract_number", notification.Reference),
+		zap.String("payment_status", string(notification.Status)),
+		zap.String("outcome", string(callback.Outcome)),
+	)
+
+	return callback, nil
+}
+
+// RecordPayment implements PaymentServices.
+func (s *paymentService) RecordPayment(ctx context.Context, contractNumber string, payment *domain.Payment) error {
+	ctx, span := s.tracer.Start(ctx, "service.RecordPayment")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("payment.provider", payment.Provider),
+		attribute.String("payment.status", string(payment.Status)),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "record_payment"), attribute.String("service", "payment")))
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return s.recordError(ctx, span, start, "record_payment", "transaction_begin_error", tx.Error)
+	}
+	defer tx.Rollback()
+
+	paymentTx := paymentrepo.NewPaymentRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	exposureTx := exposurerepo.NewCustomerExposureRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+	eventTx := customereventrepo.NewCustomerEventRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+
+	contract, err := paymentTx.LockContract(ctx, contractNumber)
+	if err != nil {
+		return s.recordError(ctx, span, start, "record_payment", "repository_error", fmt.Errorf("failed to lock contract: %w", err))
+	}
+	if contract == nil {
+		return s.recordError(ctx, span, start, "record_payment", "transaction_not_found", common.ErrTransactionNotFound)
+	}
+
+	if err := s.applyPayment(ctx, paymentTx, exposureTx, eventTx, contract, payment); err != nil {
+		return s.recordError(ctx, span, start, "record_payment", "repository_error", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return s.recordError(ctx, span, start, "record_payment", "transaction_commit_error", err)
+	}
+
+	s.recordSuccess(ctx, span, start, "record_payment",
+		zap.String("provider", payment.Provider),
+		zap.String("external_id", payment.ExternalID),
+		zap.String("contract_number", contractNumber),
+		zap.String("status", string(payment.Status)),
+	)
+
+	return nil
+}
+
+// applyPayment records the gateway's view of a payment and, once it is paid,
+// recomputes how many installments the contract has covered. A contract that
+// becomes paid off no longer counts towards the customer's exposure. A payment
+// reaching PAID is added to the customer's timeline once, since PAID is final.
+func (s *paymentService) applyPayment(
+	ctx context.Context, paymentTx repository.PaymentRepository, exposureTx repository.CustomerExposureRepository,
+	eventTx repository.CustomerEventRepository, contract *domain.Transaction, incoming *domain.Payment,
+) error {
+	payment, err := paymentTx.FindByExternalID(ctx, incoming.Provider, incoming.ExternalID)
+	if err != nil {
+		return fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	// PAID bersifat final, notifikasi yang datang terlambat tidak boleh menurunkannya
+	if payment != nil && payment.Status == domain.PaymentPaid {
+		return nil
+	}
+	if payment == nil {
+		payment = &domain.Payment{
+			TransactionID: contract.ID,
+			Provider:      incoming.Provider,
+			ExternalID:    incoming.ExternalID,
+		}
+	}
+
+	payment.Amount = incoming.Amount
+	payment.Currency = incoming.Currency
+	payment.Status = incoming.Status
+	payment.PaidAt = nil
+	if payment.Status == domain.PaymentPaid {
+		payment.PaidAt = incoming.PaidAt
+	}
+
+	if err := paymentTx.Save(ctx, payment); err != nil {
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+
+	if payment.Status == domain.PaymentPaid && !contract.IsSandbox {
+		event := &domain.CustomerEvent{
+			CustomerID:  contract.CustomerID,
+			Type:        domain.CustomerPaymentReceived,
+			ReferenceID: contract.ContractNumber,
+			Data: map[string]string{
+				"amount":      payment.Amount.String(),
+				"currency":    payment.Currency,
+				"provider":    payment.Provider,
+				"external_id": payment.ExternalID,
+			},
+		}
+		if err := eventTx.Append(ctx, event); err != nil {
+			return fmt.Errorf("failed to record payment event: %w", err)
+		}
+	}
+
+	if payment.Status != domain.PaymentPaid || contract.Status != domain.TransactionActive {
+		return nil
+	}
+
+	totalPaid, err := paymentTx.SumPaid(ctx, contract.ID)
+	if err != nil {
+		return fmt.Errorf("failed to sum payments: %w", err)
+	}
+
+	paid, status := coveredInstallments(contract, totalPaid)
+	if err := paymentTx.ApplyRepayment(ctx, contract.ID, paid, status); err != nil {
+		return fmt.Errorf("failed to apply repayment: %w", err)
+	}
+
+	if status == domain.TransactionPaidOff && !contract.IsSandbox {
+		principal := currency.ToIDR(contract.OTRAmount.Add(contract.AdminFee), contract.FxRate)
+		if err := exposureTx.Add(ctx, contract.CustomerID, contract.TenorID, principal.Neg()); err != nil {
+			return fmt.Errorf("failed to update customer exposure: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// coveredInstallments converts the total collected on a contract into whole
+// installments paid, closing the contract once every installment is covered.
+func coveredInstallments(contract *domain.Transaction, totalPaid decimal.Decimal) (int, domain.TransactionStatus) {
+	months := int(contract.Tenor.DurationMonths)
+	if months == 0 || !contract.TotalInstallmentAmount.IsPositive() {
+		return 0, contract.Status
+	}
+
+	paid := int(totalPaid.Mul(decimal.NewFromInt(int64(months))).Div(contract.TotalInstallmentAmount).Floor().IntPart())
+	paid = min(max(paid, 0), months)
+
+	if paid == months {
+		return paid, domain.TransactionPaidOff
+	}
+	return paid, contract.Status
+}
+
+func (s *paymentService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Payment operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "payment"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "payment"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *paymentService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "payment"), attribute.String("status", "success")))
+
+	s.log.Info("Payment operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+// NewPaymentService accepts callbacks only from the providers in verifiers,
+// keyed by Verifier.Provider.
+func NewPaymentService(
+	db *gorm.DB,
+	paymentRepository repository.PaymentRepository,
+	verifiers []paymentgateway.Verifier,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PaymentServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	callbacksHandled, _ := meter.Int64Counter(
+		"service.payment.callbacks",
+		metric.WithDescription("Number of payment gateway callbacks by outcome"),
+		metric.WithUnit("{callback}"),
+	)
+
+	byProvider := make(map[string]paymentgateway.Verifier, len(verifiers))
+	for _, v := range verifiers {
+		byProvider[v.Provider()] = v
+	}
+
+	return &paymentService{
+		db:                db,
+		paymentRepository: paymentRepository,
+		verifiers:         byProvider,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+		callbacksHandled:  callbacksHandled,
+	}
+}
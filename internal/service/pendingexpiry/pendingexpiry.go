@@ -0,0 +1,253 @@
+package pendingexpirysrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config decides when a PENDING registration is considered stale. Customers
+// are reminded once RemindAfter has passed since their last document
+// submission and rejected once RejectAfter has passed; a zero RejectAfter
+// keeps them pending forever.
+type Config struct {
+	RemindAfter time.Duration
+	RejectAfter time.Duration
+	BatchSize   int
+}
+
+type pendingExpiryService struct {
+	pendingExpiryRepository repository.PendingExpiryRepository
+	notifier                service.CustomerNotifier
+	cfg                     Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	remindedCount     metric.Int64Counter
+	rejectedCount     metric.Int64Counter
+	pendingGauge      metric.Int64Gauge
+	oldestAgeGauge    metric.Float64Gauge
+}
+
+// Run implements PendingExpiryServices.
+func (s *pendingExpiryService) Run(ctx context.Context, now time.Time) (*domain.PendingExpiryRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RunPendingExpiry")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("pending.remind_after", s.cfg.RemindAfter.String()),
+		attribute.String("pending.reject_after", s.cfg.RejectAfter.String()),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "run_pending_expiry"), attribute.String("service", "pending_expiry")))
+
+	run := &domain.PendingExpiryRun{}
+
+	stale, err := s.pendingExpiryRepository.FindUnreminded(ctx, now.Add(-s.cfg.RemindAfter), s.cfg.BatchSize)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "run_pending_expiry", "repository_error", fmt.Errorf("failed to find stale registrations: %w", err))
+	}
+
+	for _, customer := range stale {
+		data := map[string]string{"full_name": customer.FullName}
+		if s.cfg.RejectAfter > 0 {
+			data["deadline"] = submittedAt(customer).Add(s.cfg.RejectAfter).Format("2006-01-02")
+		}
+
+		// Gagal kirim tidak ditandai, pengingat dicoba lagi di putaran berikutnya
+		if err := s.notifier.Notify(ctx, customer.ID, domain.TemplateVerificationReminder, data); err != nil {
+			s.log.Warn("Failed to send verification reminder",
+				zap.Uint64("customer_id", customer.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := s.pendingExpiryRepository.MarkReminded(ctx, customer.ID, now); err != nil {
+			return nil, s.recordError(ctx, span, start, "run_pending_expiry", "repository_error", fmt.Errorf("failed to mark reminder: %w", err))
+		}
+		run.Reminded++
+	}
+
+	if s.cfg.RejectAfter > 0 {
+		// Customer yang baru diingatkan tetap mendapat jeda sebelum ditolak
+		grace := max(s.cfg.RejectAfter-s.cfg.RemindAfter, 0)
+
+		expired, err := s.pendingExpiryRepository.FindExpired(ctx, now.Add(-s.cfg.RejectAfter), now.Add(-grace), s.cfg.BatchSize)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "run_pending_expiry", "repository_error", fmt.Errorf("failed to find expired registrations: %w", err))
+		}
+
+		for _, customer := range expired {
+			rejected, err := s.pendingExpiryRepository.Reject(ctx, customer.ID)
+			if err != nil {
+				return nil, s.recordError(ctx, span, start, "run_pending_expiry", "repository_error", fmt.Errorf("failed to reject registration: %w", err))
+			}
+			if !rejected {
+				continue
+			}
+			run.Rejected++
+
+			if err := s.notifier.Notify(ctx, customer.ID, domain.TemplateVerificationExpired, map[string]string{"full_name": customer.FullName}); err != nil {
+				s.log.Warn("Failed to send verification expiry notice",
+					zap.Uint64("customer_id", customer.ID),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	s.remindedCount.Add(ctx, int64(run.Reminded), metric.WithAttributes(attribute.String("service", "pending_expiry")))
+	s.rejectedCount.Add(ctx, int64(run.Rejected), metric.WithAttributes(attribute.String("service", "pending_expiry")))
+
+	run.Pending, run.OldestSubmittedAt, err = s.pendingExpiryRepository.Stats(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "run_pending_expiry", "repository_error", fmt.Errorf("failed to compute pending stats: %w", err))
+	}
+
+	oldestAge := 0.0
+	if run.OldestSubmittedAt != nil {
+		oldestAge = now.Sub(*run.OldestSubmittedAt).Seconds()
+	}
+	s.pendingGauge.Record(ctx, run.Pending, metric.WithAttributes(attribute.String("service", "pending_expiry")))
+	s.oldestAgeGauge.Record(ctx, oldestAge, metric.WithAttributes(attribute.String("service", "pending_expiry")))
+
+	span.SetAttributes(
+		attribute.Int("pending.reminded", run.Reminded),
+		attribute.Int("pending.rejected", run.Rejected),
+		attribute.Int64("pending.count", run.Pending),
+	)
+	s.recordSuccess(ctx, span, start, "run_pending_expiry",
+		zap.Int("reminded", run.Reminded),
+		zap.Int("rejected", run.Rejected),
+		zap.Int64("pending", run.Pending),
+		zap.Float64("oldest_age_seconds", oldestAge),
+	)
+
+	return run, nil
+}
+
+// submittedAt falls back to the registration time for customers created
+// before document submissions were tracked.
+func submittedAt(customer domain.Customer) time.Time {
+	if customer.DocumentsSubmittedAt != nil {
+		return *customer.DocumentsSubmittedAt
+	}
+	return customer.CreatedAt
+}
+
+func (s *pendingExpiryService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Pending expiry operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "pending_expiry"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "pending_expiry"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *pendingExpiryService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "pending_expiry"), attribute.String("status", "success")))
+
+	s.log.Info("Pending expiry operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewPendingExpiryService(
+	pendingExpiryRepository repository.PendingExpiryRepository,
+	notifier service.CustomerNotifier,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PendingExpiryServices {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	remindedCount, _ := meter.Int64Counter(
+		"service.pending_registrations.reminded",
+		metric.WithDescription("Number of stale pending registrations reminded"),
+		metric.WithUnit("{customer}"),
+	)
+
+	rejectedCount, _ := meter.Int64Counter(
+		"service.pending_registrations.rejected",
+		metric.WithDescription("Number of stale pending registrations auto-rejected"),
+		metric.WithUnit("{customer}"),
+	)
+
+	pendingGauge, _ := meter.Int64Gauge(
+		"service.pending_registrations.count",
+		metric.WithDescription("Number of registrations waiting for verification"),
+		metric.WithUnit("{customer}"),
+	)
+
+	oldestAgeGauge, _ := meter.Float64Gauge(
+		"service.pending_registrations.oldest_age",
+		metric.WithDescription("Age of the oldest registration waiting for verification"),
+		metric.WithUnit("s"),
+	)
+
+	return &pendingExpiryService{
+		pendingExpiryRepository: pendingExpiryRepository,
+		notifier:                notifier,
+		cfg:                     cfg,
+		meter:                   meter,
+		tracer:                  tracer,
+		log:                     log,
+		operationDuration:       operationDuration,
+		operationCount:          operationCount,
+		errorCount:              errorCount,
+		remindedCount:           remindedCount,
+		rejectedCount:           rejectedCount,
+		pendingGauge:            pendingGauge,
+		oldestAgeGauge:          oldestAgeGauge,
+	}
+}
@@ -0,0 +1,264 @@
+package portalsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/apikey"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	ratelimiter "github.com/fazamuttaqien/multifinance/pkg/rate-limiter"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MaxUsageDays is the longest range GetUsage reports, bounded by how long the
+// rate limiter keeps its counters.
+const MaxUsageDays = int(ratelimiter.UsageRetention / (24 * time.Hour))
+
+const regeneratedNotice = "Store this API key securely, the previous key no longer works"
+
+type portalService struct {
+	partnerRepository     repository.PartnerRepository
+	transactionRepository repository.TransactionRepository
+	deliveryRepository    repository.DeliveryRepository
+	apiUsageRepository    repository.APIUsageRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	keysRegenerated   metric.Int64Counter
+}
+
+// ListTransactions implements PartnerPortalServices.
+func (p *portalService) ListTransactions(ctx context.Context, partnerID uint64, sandbox bool, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := p.tracer.Start(ctx, "service.ListPartnerTransactions")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Bool("partner.sandbox", sandbox),
+	)
+	p.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_partner_transactions"), attribute.String("service", "portal")))
+
+	transactions, total, err := p.transactionRepository.FindPaginatedByPartnerID(ctx, partnerID, sandbox, params)
+	if err != nil {
+		return nil, p.recordError(ctx, span, start, "list_partner_transactions", "repository_error", fmt.Errorf("failed to list partner transactions: %w", err))
+	}
+
+	p.recordSuccess(ctx, span, start, "list_partner_transactions",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int64("total", total),
+	)
+
+	return params.Paginated(transactions, total), nil
+}
+
+// ListDeliveries implements PartnerPortalServices.
+func (p *portalService) ListDeliveries(ctx context.Context, partnerID uint64, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := p.tracer.Start(ctx, "service.ListPartnerDeliveries")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(partnerID)))
+	p.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_partner_deliveries"), attribute.String("service", "portal")))
+
+	deliveries, total, err := p.deliveryRepository.FindPaginatedByPartnerID(ctx, partnerID, params)
+	if err != nil {
+		return nil, p.recordError(ctx, span, start, "list_partner_deliveries", "repository_error", fmt.Errorf("failed to list partner deliveries: %w", err))
+	}
+
+	p.recordSuccess(ctx, span, start, "list_partner_deliveries",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int64("total", total),
+	)
+
+	return params.Paginated(deliveries, total), nil
+}
+
+// GetUsage implements PartnerPortalServices.
+func (p *portalService) GetUsage(ctx context.Context, partnerID uint64, days int, now time.Time) ([]domain.APIUsage, error) {
+	ctx, span := p.tracer.Start(ctx, "service.GetPartnerUsage")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Int("usage.days", days),
+	)
+	p.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_partner_usage"), attribute.String("service", "portal")))
+
+	if days < 1 || days > MaxUsageDays {
+		return nil, p.recordError(ctx, span, start, "get_partner_usage", "invalid_days", common.ErrInvalidUsageDays)
+	}
+
+	// Hari dihitung dalam UTC mengikuti kunci counter rate limiter, urut dari
+	// yang paling lama
+	today := now.UTC()
+	window := make([]time.Time, days)
+	for i := range window {
+		window[i] = today.AddDate(0, 0, i-days+1)
+	}
+
+	usage, err := p.apiUsageRepository.FindByPartner(ctx, partnerID, window)
+	if err != nil {
+		return nil, p.recordError(ctx, span, start, "get_partner_usage", "repository_error", fmt.Errorf("failed to get partner usage: %w", err))
+	}
+
+	p.recordSuccess(ctx, span, start, "get_partner_usage",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int("days", days),
+	)
+
+	return usage, nil
+}
+
+// RegenerateAPIKey implements PartnerPortalServices. Only the key of the
+// environment the request was authenticated with is replaced, and it stops
+// working as soon as the new hash is saved.
+func (p *portalService) RegenerateAPIKey(ctx context.Context, partnerID uint64, sandbox bool) (*dto.PartnerCredentialsResponse, error) {
+	ctx, span := p.tracer.Start(ctx, "service.RegeneratePartnerAPIKey")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Bool("partner.sandbox", sandbox),
+	)
+	p.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "regenerate_api_key"), attribute.String("service", "portal")))
+
+	partner, err := p.partnerRepository.FindByID(ctx, partnerID)
+	if err != nil {
+		return nil, p.recordError(ctx, span, start, "regenerate_api_key", "repository_error", fmt.Errorf("failed to get partner: %w", err))
+	}
+	if partner == nil {
+		return nil, p.recordError(ctx, span, start, "regenerate_api_key", "partner_not_found", common.ErrPartnerNotFound)
+	}
+
+	prefix := apikey.LivePrefix
+	if sandbox {
+		prefix = apikey.SandboxPrefix
+	}
+	plain, hash, display, err := apikey.Generate(prefix)
+	if err != nil {
+		return nil, p.recordError(ctx, span, start, "regenerate_api_key", "key_generation_error", fmt.Errorf("failed to generate api key: %w", err))
+	}
+
+	if sandbox {
+		partner.SandboxKeyHash = hash
+		partner.SandboxKeyPrefix = display
+	} else {
+		partner.LiveKeyHash = hash
+		partner.LiveKeyPrefix = display
+	}
+	if err := p.partnerRepository.Update(ctx, partner); err != nil {
+		return nil, p.recordError(ctx, span, start, "regenerate_api_key", "repository_error", fmt.Errorf("failed to save api key: %w", err))
+	}
+
+	p.keysRegenerated.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "portal"), attribute.Bool("sandbox", sandbox)))
+	p.recordSuccess(ctx, span, start, "regenerate_api_key",
+		zap.Uint64("partner_id", partnerID),
+		zap.Bool("sandbox", sandbox),
+		zap.String("key_prefix", display),
+	)
+
+	return &dto.PartnerCredentialsResponse{
+		PartnerID: partner.ID,
+		Name:      partner.Name,
+		Status:    string(partner.Status),
+		APIKey:    plain,
+		Sandbox:   sandbox,
+		Notice:    regeneratedNotice,
+	}, nil
+}
+
+func (p *portalService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	p.log.Error("Partner portal operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "portal"), attribute.String("error_type", errorType)))
+	p.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "portal"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (p *portalService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "portal"), attribute.String("status", "success")))
+
+	p.log.Info("Partner portal operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewPartnerPortalService(
+	partnerRepository repository.PartnerRepository,
+	transactionRepository repository.TransactionRepository,
+	deliveryRepository repository.DeliveryRepository,
+	apiUsageRepository repository.APIUsageRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PartnerPortalServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	keysRegenerated, _ := meter.Int64Counter(
+		"service.partners.keys_regenerated",
+		metric.WithDescription("Number of partner API keys regenerated by the partner"),
+		metric.WithUnit("{key}"),
+	)
+
+	return &portalService{
+		partnerRepository:     partnerRepository,
+		transactionRepository: transactionRepository,
+		deliveryRepository:    deliveryRepository,
+		apiUsageRepository:    apiUsageRepository,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+		keysRegenerated:       keysRegenerated,
+	}
+}
@@ -0,0 +1,161 @@
+package preferencesrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type preferenceService struct {
+	notificationRepository repository.NotificationRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// GetPreferences implements NotificationPreferenceServices.
+func (s *preferenceService) GetPreferences(ctx context.Context, customerID uint64) (*domain.NotificationPreferences, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetNotificationPreferences")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_notification_preferences"), attribute.String("service", "preference")))
+
+	preferences, err := s.find(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_notification_preferences", "repository_error", err)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_notification_preferences", zap.Uint64("customer_id", customerID))
+
+	return preferences, nil
+}
+
+// SetPreferences implements NotificationPreferenceServices.
+func (s *preferenceService) SetPreferences(ctx context.Context, customerID uint64, req dto.NotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetNotificationPreferences")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_notification_preferences"), attribute.String("service", "preference")))
+
+	preferences, err := s.find(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_notification_preferences", "repository_error", err)
+	}
+
+	if req.Email != nil {
+		preferences.Email = *req.Email
+	}
+	if req.Push != nil {
+		preferences.Push = *req.Push
+	}
+
+	if err := s.notificationRepository.SavePreferences(ctx, preferences); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_notification_preferences", "repository_error", fmt.Errorf("failed to save notification preferences: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "set_notification_preferences",
+		zap.Uint64("customer_id", customerID),
+		zap.Bool("email", preferences.Email),
+		zap.Bool("push", preferences.Push),
+	)
+
+	return preferences, nil
+}
+
+// find returns the saved preferences, or every channel enabled when the
+// customer never changed them.
+func (s *preferenceService) find(ctx context.Context, customerID uint64) (*domain.NotificationPreferences, error) {
+	preferences, err := s.notificationRepository.FindPreferences(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	if preferences == nil {
+		return domain.DefaultNotificationPreferences(customerID), nil
+	}
+	return preferences, nil
+}
+
+func (s *preferenceService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Notification preference operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "preference"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "preference"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *preferenceService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "preference"), attribute.String("status", "success")))
+
+	s.log.Info("Notification preference operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewPreferenceService(
+	notificationRepository repository.NotificationRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.NotificationPreferenceServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &preferenceService{
+		notificationRepository: notificationRepository,
+		meter:                  meter,
+		tracer:                 tracer,
+		log:                    log,
+		operationDuration:      operationDuration,
+		operationCount:         operationCount,
+		errorCount:             errorCount,
+	}
+}
@@ -2,17 +2,21 @@ package privatesrv
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
 	"github.com/fazamuttaqien/multifinance/internal/service"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
 	"github.com/fazamuttaqien/multifinance/pkg/password"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
 	"github.com/golang-jwt/jwt/v5"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
@@ -23,6 +27,7 @@ import (
 type privateService struct {
 	db                 *gorm.DB
 	customerRepository repository.CustomerRepository
+	otpService         service.OTPServices
 
 	jwtSecret string
 
@@ -43,14 +48,18 @@ func (p *privateService) Login(ctx context.Context, data dto.LoginRequest) (*dto
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Hello")
 	if cust == nil || !password.CheckPasswordHash(data.Password, cust.Password) {
 		return nil, common.ErrInvalidCredentials
 	}
+	if cust.ClosedAt != nil {
+		return nil, common.ErrAccountClosed
+	}
 
 	claims := &domain.JwtCustomClaims{
-		UserID: cust.ID,
-		Role:   cust.Role,
+		UserID:             cust.ID,
+		Role:               cust.Role,
+		MustChangePassword: cust.MustChangePassword,
+		Tenant:             tenancy.ID(ctx),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 72)),
 			Issuer:    "multifinance",
@@ -63,13 +72,86 @@ func (p *privateService) Login(ctx context.Context, data dto.LoginRequest) (*dto
 		return nil, err
 	}
 
-	return &dto.LoginResponse{Token: signedToken}, nil
+	return &dto.LoginResponse{Token: signedToken, MustChangePassword: cust.MustChangePassword}, nil
+}
+
+// ChangePassword implements service.PrivateService.
+func (p *privateService) ChangePassword(ctx context.Context, customerID uint64, req dto.ChangePasswordRequest) error {
+	ctx, span := p.tracer.Start(ctx, "service.ChangePassword")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	p.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "change_password"), attribute.String("service", "private")))
+
+	cust, err := p.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return p.recordError(ctx, span, start, "change_password", "repository_error", fmt.Errorf("failed to find customer: %w", err))
+	}
+	if cust == nil {
+		return p.recordError(ctx, span, start, "change_password", "not_found", common.ErrCustomerNotFound)
+	}
+
+	// Password lama dicek dulu supaya salah ketik tidak menghanguskan token OTP
+	if !password.CheckPasswordHash(req.CurrentPassword, cust.Password) {
+		return p.recordError(ctx, span, start, "change_password", "invalid_credentials", common.ErrInvalidCredentials)
+	}
+
+	// Penggantian wajib pertama cukup dengan password seed, akun seed belum punya kontak untuk OTP
+	if !cust.MustChangePassword {
+		if _, err := p.otpService.Consume(ctx, req.OTPToken, domain.OTPChangePassword, customerID); err != nil {
+			return p.recordError(ctx, span, start, "change_password", "otp_required", err)
+		}
+	}
+
+	hashed, err := password.HashPassword(req.NewPassword)
+	if err != nil {
+		return p.recordError(ctx, span, start, "change_password", "hash_error", fmt.Errorf("failed to hash password: %w", err))
+	}
+
+	if err := p.db.WithContext(ctx).Model(&model.Customer{}).Where("id = ?", customerID).Updates(map[string]any{
+		"password":             hashed,
+		"must_change_password": false,
+	}).Error; err != nil {
+		return p.recordError(ctx, span, start, "change_password", "repository_error", fmt.Errorf("failed to update password: %w", err))
+	}
+
+	p.profilesUpdated.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "change_password")))
+
+	duration := float64(time.Since(start).Milliseconds())
+	p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", "change_password"), attribute.String("service", "private"), attribute.String("status", "success")))
+	p.log.Info("Customer password changed",
+		zap.Uint64("customer_id", customerID),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+	span.SetStatus(codes.Ok, "Password changed successfully")
+
+	return nil
+}
+
+func (p *privateService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	p.log.Error("Private operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "private"), attribute.String("error_type", errorType)))
+	p.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "private"), attribute.String("status", "error")))
+
+	return err
 }
 
 func NewPrivateService(
 	db *gorm.DB,
 	jwtSecret string,
 	customerRepository repository.CustomerRepository,
+	otpService service.OTPServices,
 	meter metric.Meter,
 	tracer trace.Tracer,
 	log *zap.Logger,
@@ -113,6 +195,7 @@ func NewPrivateService(
 	return &privateService{
 		db:                 db,
 		customerRepository: customerRepository,
+		otpService:         otpService,
 
 		jwtSecret: jwtSecret,
 
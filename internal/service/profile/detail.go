@@ -0,0 +1,80 @@
+package profilesrv
+
+import (
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+)
+
+const (
+	installmentPaid     = "PAID"
+	installmentUpcoming = "UPCOMING"
+)
+
+// transactionDetail composes the customer-facing view of a transaction,
+// with due dates adjusted by rules.
+//
+// Selama belum ada pembayaran yang tercatat, cicilan transaksi ACTIVE yang
+// sudah jatuh tempo dianggap lunas. Karena itu belum ada cicilan terlambat dan
+// denda selalu nol sampai pembayaran tercatat.
+func transactionDetail(tx *domain.Transaction, tenor domain.Tenor, rules installment.Rules, now time.Time) *dto.TransactionDetailResponse {
+	months := int(tenor.DurationMonths)
+	detail := &dto.TransactionDetailResponse{
+		ContractNumber:         tx.ContractNumber,
+		AssetName:              tx.AssetName,
+		Status:                 string(tx.Status),
+		Currency:               currency.Normalize(tx.Currency),
+		TransactionDate:        tx.TransactionDate,
+		TenorMonths:            tenor.DurationMonths,
+		TenorDescription:       tenor.Description,
+		OTRAmount:              tx.OTRAmount,
+		AdminFee:               tx.AdminFee,
+		TotalInterest:          tx.TotalInterest,
+		TotalInstallmentAmount: tx.TotalInstallmentAmount,
+		Installments:           []dto.InstallmentDetailResponse{},
+	}
+	if tx.VirtualAccountNumber != nil {
+		detail.VirtualAccountBank = tx.VirtualAccountBank
+		detail.VirtualAccountNumber = *tx.VirtualAccountNumber
+	}
+	if months == 0 || tx.Status == domain.TransactionCancelled {
+		return detail
+	}
+
+	paid := 0
+	switch tx.Status {
+	case domain.TransactionPaidOff:
+		paid = months
+	case domain.TransactionActive, domain.TransactionWrittenOff:
+		paid = min(rules.Elapsed(tx.TransactionDate, now), months)
+		if tx.PaidInstallments != nil {
+			paid = min(*tx.PaidInstallments, months)
+		}
+	}
+
+	for _, inst := range rules.Schedule(tx.TransactionDate, 1, months, tx.TotalInstallmentAmount) {
+		status := installmentUpcoming
+		if inst.Sequence <= paid {
+			status = installmentPaid
+			detail.TotalPaid = detail.TotalPaid.Add(inst.Amount)
+		} else if detail.NextDueDate == nil {
+			dueDate := inst.DueDate
+			detail.NextDueDate = &dueDate
+		}
+
+		detail.Installments = append(detail.Installments, dto.InstallmentDetailResponse{
+			Sequence: inst.Sequence,
+			DueDate:  inst.DueDate,
+			Amount:   inst.Amount,
+			Status:   status,
+		})
+	}
+
+	detail.MonthlyInstallment = detail.Installments[0].Amount
+	detail.OutstandingBalance = tx.TotalInstallmentAmount.Sub(detail.TotalPaid)
+
+	return detail
+}
@@ -4,16 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
-	"math"
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/internal/dto"
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
+	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
 	"github.com/fazamuttaqien/multifinance/internal/service"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/otp"
 	"github.com/fazamuttaqien/multifinance/pkg/password"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -30,6 +32,11 @@ type profileService struct {
 	limitRepository       repository.LimitRepository
 	tenorRepository       repository.TenorRepository
 	transactionRepository repository.TransactionRepository
+	screeningService      service.ScreeningServices
+	currencyConverter     service.CurrencyConverter
+	referralService       service.ReferralServices
+	otpService            service.OTPServices
+	dueDateRules          service.DueDateRules
 
 	meter             metric.Meter
 	tracer            trace.Tracer
@@ -43,7 +50,7 @@ type profileService struct {
 }
 
 // Create implements ProfileUsecases
-func (p *profileService) Create(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
+func (p *profileService) Create(ctx context.Context, customer *domain.Customer, checks dto.RegistrationChecks) (*domain.Customer, error) {
 	ctx, span := p.tracer.Start(ctx, "service.CreateProfile")
 	defer span.End()
 
@@ -130,25 +137,214 @@ func (p *profileService) Create(ctx context.Context, customer *domain.Customer)
 		return nil, err
 	}
 
+	if err := p.ensureContactAvailable(ctx, 0, customer.Email, customer.Phone); err != nil {
+		span.SetStatus(codes.Error, "Contact already registered")
+		span.RecordError(err)
+
+		errorType := "repository_error"
+		if errors.Is(err, common.ErrEmailExists) || errors.Is(err, common.ErrPhoneExists) {
+			errorType = "duplicate_contact"
+		}
+
+		p.log.Warn("Customer registration stopped by contact check",
+			zap.String("nik", customer.NIK),
+			zap.String("error_type", errorType),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		p.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "create_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("error_type", errorType),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "create_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, err
+	}
+
+	// Screening blacklist, severity FLAG tetap lanjut tapi tercatat di screening log
+	if _, err := p.screeningService.Screen(ctx, domain.ScreeningRegistration, nil, customer.NIK, customer.LegalName); err != nil {
+		span.SetStatus(codes.Error, "Blacklist screening failed")
+		span.RecordError(err)
+
+		errorType := "screening_error"
+		if errors.Is(err, common.ErrBlacklisted) {
+			errorType = "blacklisted"
+		}
+
+		p.log.Warn("Customer registration stopped by blacklist screening",
+			zap.String("nik", customer.NIK),
+			zap.String("error_type", errorType),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		p.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "create_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("error_type", errorType),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "create_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, err
+	}
+
+	// Kode referral yang salah menolak registrasi, hasil fraud check tidak
+	var referralCode *domain.ReferralCode
+	if referral := checks.ReferralCode; referral != "" {
+		referralCode, err = p.referralService.Resolve(ctx, referral)
+		if err != nil {
+			span.SetStatus(codes.Error, "Failed to resolve referral code")
+			span.RecordError(err)
+
+			errorType := "referral_error"
+			if errors.Is(err, common.ErrReferralCodeNotFound) {
+				errorType = "invalid_referral_code"
+			}
+
+			p.log.Warn("Customer registration stopped by referral code",
+				zap.String("nik", customer.NIK),
+				zap.String("referral_code", referral),
+				zap.String("error_type", errorType),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+				zap.Error(err),
+			)
+
+			p.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "create_profile"),
+					attribute.String("service", "profile"),
+					attribute.String("error_type", errorType),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "create_profile"),
+					attribute.String("service", "profile"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return nil, err
+		}
+	}
+
+	// Token OTP dipakai paling akhir agar tidak hangus saat registrasi gagal validasi lain
+	verification, err := p.otpService.Consume(ctx, checks.OTPToken, domain.OTPRegistration, 0)
+	if err == nil && !verifiesContact(verification, customer.Email, customer.Phone) {
+		err = common.ErrContactNotVerified
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "OTP verification required")
+		span.RecordError(err)
+
+		errorType := "otp_error"
+		switch {
+		case errors.Is(err, common.ErrOTPRequired):
+			errorType = "otp_required"
+		case errors.Is(err, common.ErrContactNotVerified):
+			errorType = "contact_not_verified"
+		}
+
+		p.log.Warn("Customer registration stopped by OTP verification",
+			zap.String("nik", customer.NIK),
+			zap.String("error_type", errorType),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.Error(err),
+		)
+
+		p.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "create_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("error_type", errorType),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "create_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("otp.channel", verification.Channel))
+
 	customer.VerificationStatus = domain.VerificationPending
+	submittedAt := time.Now()
+	customer.DocumentsSubmittedAt = &submittedAt
 
 	hashPassword, err := password.HashPassword(customer.Password)
-	log.Println("hashPassword:", hashPassword)
-	log.Println("customer:", customer)
 	if err != nil {
 		return nil, err
 	}
 
 	customer.Password = hashPassword
 
-	// 5. Simpan ke database
-	data, err := p.customerRepository.CreateCustomer(ctx, customer)
+	// 5. Simpan ke database, event REGISTERED ikut transaksi yang sama
+	var data *domain.Customer
+	err = p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		customerTx := customerrepo.NewCustomerRepository(tx, p.meter, p.log)
+		created, err := customerTx.CreateCustomer(ctx, customer)
+		if err != nil {
+			return err
+		}
+
+		eventTx := customereventrepo.NewCustomerEventRepository(tx, p.meter, p.log)
+		if err := eventTx.Append(ctx, &domain.CustomerEvent{
+			CustomerID: created.ID,
+			Type:       domain.CustomerRegistered,
+		}); err != nil {
+			return err
+		}
+
+		data = created
+		return nil
+	})
 	if err != nil {
 		span.SetStatus(codes.Error, "Failed to create customer")
 		span.RecordError(err)
 
+		// Registrasi paralel dengan NIK atau kontak yang sama lolos pengecekan awal,
+		// unique index yang menolaknya di sini
+		errorType := "create_failed"
+		switch {
+		case errors.Is(err, common.ErrNIKExists):
+			errorType = "duplicate_customer"
+		case errors.Is(err, common.ErrEmailExists) || errors.Is(err, common.ErrPhoneExists):
+			errorType = "duplicate_contact"
+		}
+
 		p.log.Error("Failed to create customer",
 			zap.String("nik", customer.NIK),
+			zap.String("error_type", errorType),
 			zap.String("trace_id", span.SpanContext().TraceID().String()),
 			zap.Error(err),
 		)
@@ -157,7 +353,7 @@ func (p *profileService) Create(ctx context.Context, customer *domain.Customer)
 			metric.WithAttributes(
 				attribute.String("operation", "create_profile"),
 				attribute.String("service", "profile"),
-				attribute.String("error_type", "create_failed"),
+				attribute.String("error_type", errorType),
 			),
 		)
 
@@ -179,6 +375,17 @@ func (p *profileService) Create(ctx context.Context, customer *domain.Customer)
 		),
 	)
 
+	if referralCode != nil {
+		if _, err := p.referralService.Record(ctx, referralCode, data); err != nil {
+			p.log.Warn("Failed to record referral, registration kept",
+				zap.Uint64("customer_id", data.ID),
+				zap.Uint64("referrer_id", referralCode.CustomerID),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+				zap.Error(err),
+			)
+		}
+	}
+
 	duration := float64(time.Since(start).Milliseconds())
 	p.operationDuration.Record(ctx, duration,
 		metric.WithAttributes(
@@ -231,7 +438,7 @@ func (p *profileService) GetMyLimits(ctx context.Context, customerID uint64) ([]
 	)
 
 	// 1. Ambil semua limit yang ditetapkan untuk customer
-	customerLimits, err := p.limitRepository.FindAllByCustomerID(ctx, customerID)
+	customerLimits, err := p.limitRepository.FindAllByCustomerID(ctx, customerID, time.Now())
 	if err != nil {
 		span.SetStatus(codes.Error, "Failed to fetch customer limits")
 		span.RecordError(err)
@@ -336,11 +543,23 @@ func (p *profileService) GetMyLimits(ctx context.Context, customerID uint64) ([]
 			return nil, fmt.Errorf("failed to calculate used amount for tenor %d: %w", limit.TenorID, err)
 		}
 
+		// Pemakaian tersimpan dalam IDR, ditampilkan dalam mata uang limit
+		limitCurrency := currency.Normalize(limit.Currency)
+		if !currency.IsBase(limitCurrency) {
+			rate, err := p.currencyConverter.RateToIDR(ctx, limitCurrency, time.Now())
+			if err != nil {
+				return nil, p.recordError(ctx, span, start, "get_limits", "fx_rate_error", fmt.Errorf("failed to convert used amount for tenor %d: %w", limit.TenorID, err))
+			}
+			usedAmount = currency.FromIDR(usedAmount, rate)
+		}
+
 		detail := dto.LimitDetailResponse{
 			TenorMonths:    tenorMap[limit.TenorID],
+			Currency:       limitCurrency,
 			LimitAmount:    limit.LimitAmount,
 			UsedAmount:     usedAmount,
-			RemainingLimit: limit.LimitAmount - usedAmount,
+			RemainingLimit: limit.LimitAmount.Sub(usedAmount),
+			Version:        limit.Version,
 		}
 		response = append(response, detail)
 	}
@@ -429,18 +648,7 @@ func (p *profileService) GetMyTransactions(ctx context.Context, customerID uint6
 		return nil, err
 	}
 
-	totalPages := 0
-	if params.Limit > 0 {
-		totalPages = int(math.Ceil(float64(total) / float64(params.Limit)))
-	}
-
-	result := &domain.Paginated{
-		Data:       transactions,
-		Total:      total,
-		Page:       params.Page,
-		Limit:      params.Limit,
-		TotalPages: totalPages,
-	}
+	result := params.Paginated(transactions, total)
 
 	duration := float64(time.Since(start).Milliseconds())
 	p.operationDuration.Record(ctx, duration,
@@ -455,7 +663,7 @@ func (p *profileService) GetMyTransactions(ctx context.Context, customerID uint6
 		zap.Uint64("customer_id", customerID),
 		zap.Int64("total_transactions", total),
 		zap.Int("current_page", params.Page),
-		zap.Int("total_pages", totalPages),
+		zap.Int("total_pages", result.TotalPages),
 		zap.Float64("duration_ms", duration),
 		zap.String("trace_id", span.SpanContext().TraceID().String()),
 		zap.String("span_id", span.SpanContext().SpanID().String()),
@@ -464,12 +672,60 @@ func (p *profileService) GetMyTransactions(ctx context.Context, customerID uint6
 	span.SetStatus(codes.Ok, "Customer transactions retrieved successfully")
 	span.SetAttributes(
 		attribute.Int64("transactions.total", total),
-		attribute.Int("pagination.total_pages", totalPages),
+		attribute.Int("pagination.total_pages", result.TotalPages),
 	)
 
 	return result, nil
 }
 
+// GetMyTransactionDetail implements ProfileUsecases
+func (p *profileService) GetMyTransactionDetail(ctx context.Context, customerID uint64, contractNumber string) (*dto.TransactionDetailResponse, error) {
+	ctx, span := p.tracer.Start(ctx, "service.GetMyTransactionDetail")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("transaction.contract_number", contractNumber),
+		attribute.String("service", "profile"),
+	)
+	p.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_transaction_detail"), attribute.String("service", "profile")))
+
+	transaction, err := p.transactionRepository.FindByContractNumber(ctx, contractNumber)
+	if err != nil {
+		return nil, p.recordError(ctx, span, start, "get_transaction_detail", "repository_error", fmt.Errorf("failed to get transaction: %w", err))
+	}
+	// Kontrak milik customer lain diperlakukan sama dengan kontrak yang tidak ada
+	if transaction == nil || transaction.CustomerID != customerID || transaction.IsSandbox {
+		return nil, p.recordError(ctx, span, start, "get_transaction_detail", "transaction_not_found", common.ErrTransactionNotFound)
+	}
+
+	tenors, err := p.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, p.recordError(ctx, span, start, "get_transaction_detail", "repository_error", fmt.Errorf("failed to get tenors: %w", err))
+	}
+
+	var tenor *domain.Tenor
+	for i := range tenors {
+		if tenors[i].ID == transaction.TenorID {
+			tenor = &tenors[i]
+			break
+		}
+	}
+	if tenor == nil {
+		return nil, p.recordError(ctx, span, start, "get_transaction_detail", "tenor_not_found", common.ErrTenorNotFound)
+	}
+
+	detail := transactionDetail(transaction, *tenor, p.dueDateRules.Rules(ctx), time.Now())
+
+	p.recordSuccess(ctx, span, start, "get_transaction_detail",
+		zap.Uint64("customer_id", customerID),
+		zap.String("contract_number", contractNumber),
+	)
+
+	return detail, nil
+}
+
 // GetMyProfile implements ProfileUsecases
 func (p *profileService) GetMyProfile(ctx context.Context, customerID uint64) (*domain.Customer, error) {
 	ctx, span := p.tracer.Start(ctx, "service.GetMyProfile")
@@ -589,7 +845,7 @@ func (p *profileService) GetMyProfile(ctx context.Context, customerID uint64) (*
 }
 
 // Update implements ProfileUsecases
-func (p *profileService) Update(ctx context.Context, customerID uint64, req domain.Customer) error {
+func (p *profileService) Update(ctx context.Context, customerID uint64, req domain.Customer, checks dto.ContactChecks) error {
 	ctx, span := p.tracer.Start(ctx, "service.UpdateProfile")
 	defer span.End()
 
@@ -686,15 +942,121 @@ func (p *profileService) Update(ctx context.Context, customerID uint64, req doma
 		return err
 	}
 
+	// Edit berbasis versi lama ditolak agar tidak menimpa perubahan lain
+	if req.Version != customer.Version {
+		return p.recordError(ctx, span, start, "update_profile", "version_conflict",
+			fmt.Errorf("%w: customer %d is at version %d, not %d", common.ErrVersionConflict, customerID, customer.Version, req.Version))
+	}
+
+	// Perubahan gaji harus lewat pengajuan dengan slip gaji dan review admin
+	if !req.Salary.IsZero() && !req.Salary.Equal(customer.Salary) {
+		err := common.ErrSalaryNeedsProof
+		span.SetStatus(codes.Error, "Salary change requires proof")
+		span.RecordError(err)
+
+		p.log.Warn("Salary change attempted through profile update",
+			zap.Uint64("customer_id", customerID),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+
+		p.errorCount.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("operation", "update_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("error_type", "salary_needs_proof"),
+			),
+		)
+
+		duration := float64(time.Since(start).Milliseconds())
+		p.operationDuration.Record(ctx, duration,
+			metric.WithAttributes(
+				attribute.String("operation", "update_profile"),
+				attribute.String("service", "profile"),
+				attribute.String("status", "error"),
+			),
+		)
+
+		return err
+	}
+
 	updates := map[string]any{
 		"full_name": req.FullName,
-		"salary":    req.Salary,
+	}
+	if req.Language != "" {
+		updates["language"] = string(req.Language)
+	}
+
+	// Kontak yang berubah wajib dikonfirmasi OTP ke alamat barunya
+	current := model.CustomerToEntity(customer)
+	var newEmail, newPhone string
+	if req.Email != "" && req.Email != current.Email {
+		newEmail = req.Email
+	}
+	if req.Phone != "" && req.Phone != current.Phone {
+		newPhone = req.Phone
+	}
+
+	if newEmail != "" || newPhone != "" {
+		err := p.ensureContactAvailable(ctx, customerID, newEmail, newPhone)
+		if err == nil && newEmail != "" {
+			err = p.confirmContact(ctx, customerID, checks.EmailOTPToken, otp.ChannelEmail, newEmail)
+		}
+		if err == nil && newPhone != "" {
+			err = p.confirmContact(ctx, customerID, checks.PhoneOTPToken, otp.ChannelSMS, newPhone)
+		}
+		if err != nil {
+			span.SetStatus(codes.Error, "Contact change rejected")
+			span.RecordError(err)
+
+			errorType := "contact_error"
+			switch {
+			case errors.Is(err, common.ErrEmailExists), errors.Is(err, common.ErrPhoneExists):
+				errorType = "duplicate_contact"
+			case errors.Is(err, common.ErrOTPRequired), errors.Is(err, common.ErrContactNotVerified):
+				errorType = "contact_not_verified"
+			}
+
+			p.log.Warn("Contact change rejected",
+				zap.Uint64("customer_id", customerID),
+				zap.String("error_type", errorType),
+				zap.String("trace_id", span.SpanContext().TraceID().String()),
+				zap.Error(err),
+			)
+
+			p.errorCount.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("operation", "update_profile"),
+					attribute.String("service", "profile"),
+					attribute.String("error_type", errorType),
+				),
+			)
+
+			duration := float64(time.Since(start).Milliseconds())
+			p.operationDuration.Record(ctx, duration,
+				metric.WithAttributes(
+					attribute.String("operation", "update_profile"),
+					attribute.String("service", "profile"),
+					attribute.String("status", "error"),
+				),
+			)
+
+			return err
+		}
+
+		if newEmail != "" {
+			updates["email"] = newEmail
+		}
+		if newPhone != "" {
+			updates["phone"] = newPhone
+		}
 	}
 
 	customer.FullName = req.FullName
-	customer.Salary = req.Salary
+	updates["version"] = gorm.Expr("version + 1")
 
-	if err := tx.Model(&customer).Updates(updates).Error; err != nil {
+	// Kondisi versi di WHERE menangkap edit lain yang commit setelah SELECT di atas
+	result := tx.Model(&customer).Where("version = ?", req.Version).Updates(updates)
+	if err := result.Error; err != nil {
 		span.SetStatus(codes.Error, "Failed to update customer")
 		span.RecordError(err)
 
@@ -723,6 +1085,10 @@ func (p *profileService) Update(ctx context.Context, customerID uint64, req doma
 
 		return err
 	}
+	if result.RowsAffected == 0 {
+		return p.recordError(ctx, span, start, "update_profile", "version_conflict",
+			fmt.Errorf("%w: customer %d changed after version %d was read", common.ErrVersionConflict, customerID, req.Version))
+	}
 
 	if err := tx.Commit().Error; err != nil {
 		span.SetStatus(codes.Error, "Failed to commit transaction")
@@ -782,12 +1148,92 @@ func (p *profileService) Update(ctx context.Context, customerID uint64, req doma
 	return nil
 }
 
+func (p *profileService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	p.log.Error("Profile operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	p.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "profile"), attribute.String("error_type", errorType)))
+	p.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "profile"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (p *profileService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	p.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "profile"), attribute.String("status", "success")))
+
+	p.log.Info("Profile operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+// ensureContactAvailable returns ErrEmailExists or ErrPhoneExists when
+// another customer already uses the email or phone number.
+func (p *profileService) ensureContactAvailable(ctx context.Context, customerID uint64, email, phone string) error {
+	if email == "" && phone == "" {
+		return nil
+	}
+
+	existing, err := p.customerRepository.FindByContact(ctx, email, phone)
+	if err != nil {
+		return fmt.Errorf("failed to check contact: %w", err)
+	}
+	if existing == nil || existing.ID == customerID {
+		return nil
+	}
+	if email != "" && existing.Email == email {
+		return common.ErrEmailExists
+	}
+	return common.ErrPhoneExists
+}
+
+// confirmContact consumes a CHANGE_CONTACT token and checks it was verified
+// on the new destination.
+func (p *profileService) confirmContact(ctx context.Context, customerID uint64, token, channel, destination string) error {
+	verification, err := p.otpService.Consume(ctx, token, domain.OTPChangeContact, customerID)
+	if err != nil {
+		return err
+	}
+	if verification.Channel != channel || verification.Destination != destination {
+		return common.ErrContactNotVerified
+	}
+	return nil
+}
+
+// verifiesContact reports whether a verification was delivered to the given
+// email or phone number.
+func verifiesContact(verification *domain.OTPVerification, email, phone string) bool {
+	switch verification.Channel {
+	case otp.ChannelEmail:
+		return email != "" && verification.Destination == email
+	case otp.ChannelSMS:
+		return phone != "" && verification.Destination == phone
+	}
+	return false
+}
+
 func NewProfileService(
 	db *gorm.DB,
 	customerRepository repository.CustomerRepository,
 	limitRepository repository.LimitRepository,
 	tenorRepository repository.TenorRepository,
 	transactionRepository repository.TransactionRepository,
+	screeningService service.ScreeningServices,
+	currencyConverter service.CurrencyConverter,
+	referralService service.ReferralServices,
+	otpService service.OTPServices,
+	dueDateRules service.DueDateRules,
 	meter metric.Meter,
 	tracer trace.Tracer,
 	log *zap.Logger,
@@ -834,6 +1280,11 @@ func NewProfileService(
 		limitRepository:       limitRepository,
 		tenorRepository:       tenorRepository,
 		transactionRepository: transactionRepository,
+		screeningService:      screeningService,
+		currencyConverter:     currencyConverter,
+		referralService:       referralService,
+		otpService:            otpService,
+		dueDateRules:          dueDateRules,
 
 		meter:             meter,
 		tracer:            tracer,
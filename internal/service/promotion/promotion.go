@@ -0,0 +1,210 @@
+package promotionsrv
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var codePattern = regexp.MustCompile(`^[A-Z0-9]{3,32}$`)
+
+type promotionService struct {
+	partnerRepository   repository.PartnerRepository
+	promotionRepository repository.PromotionRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// ListPromotions implements PromotionServices.
+func (s *promotionService) ListPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListPromotions")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_promotions"), attribute.String("service", "promotion")))
+
+	promotions, err := s.promotionRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_promotions", "repository_error", fmt.Errorf("failed to list promotions: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_promotions", zap.Int("count", len(promotions)))
+
+	return promotions, nil
+}
+
+// SetPromotion implements PromotionServices.
+func (s *promotionService) SetPromotion(ctx context.Context, code string, updatedBy uint64, req dto.PromotionRequest) (*domain.Promotion, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetPromotion")
+	defer span.End()
+
+	start := time.Now()
+	code = strings.ToUpper(code)
+	span.SetAttributes(
+		attribute.String("promotion.code", code),
+		attribute.Bool("promotion.active", req.Active),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_promotion"), attribute.String("service", "promotion")))
+
+	if !codePattern.MatchString(code) {
+		return nil, s.recordError(ctx, span, start, "set_promotion", "invalid_code", common.ErrInvalidPromoCode)
+	}
+	if domain.DiscountType(req.DiscountType) == domain.DiscountPercent && req.DiscountValue.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, s.recordError(ctx, span, start, "set_promotion", "invalid_discount", common.ErrInvalidPromotion)
+	}
+
+	if req.PartnerID != nil {
+		partner, err := s.partnerRepository.FindByID(ctx, *req.PartnerID)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "set_promotion", "partner_lookup_error", fmt.Errorf("failed to find partner: %w", err))
+		}
+		if partner == nil {
+			return nil, s.recordError(ctx, span, start, "set_promotion", "partner_not_found", common.ErrPartnerNotFound)
+		}
+	}
+
+	promotion := &domain.Promotion{
+		Code:           code,
+		Description:    req.Description,
+		Target:         domain.PromotionTarget(req.Target),
+		DiscountType:   domain.DiscountType(req.DiscountType),
+		DiscountValue:  req.DiscountValue,
+		MaxDiscount:    req.MaxDiscount,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		PartnerID:      req.PartnerID,
+		TenorMonths:    req.TenorMonths,
+		MinOTRAmount:   req.MinOTRAmount,
+		MaxRedemptions: req.MaxRedemptions,
+		MaxPerCustomer: req.MaxPerCustomer,
+		Active:         req.Active,
+		UpdatedBy:      updatedBy,
+	}
+
+	if err := s.promotionRepository.Upsert(ctx, promotion); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_promotion", "repository_error", fmt.Errorf("failed to save promotion: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "set_promotion",
+		zap.String("code", code),
+		zap.String("target", req.Target),
+		zap.String("discount_type", req.DiscountType),
+		zap.Stringer("discount_value", req.DiscountValue),
+		zap.Bool("active", req.Active),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return promotion, nil
+}
+
+// DeactivatePromotion implements PromotionServices.
+func (s *promotionService) DeactivatePromotion(ctx context.Context, code string) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeactivatePromotion")
+	defer span.End()
+
+	start := time.Now()
+	code = strings.ToUpper(code)
+	span.SetAttributes(attribute.String("promotion.code", code))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "deactivate_promotion"), attribute.String("service", "promotion")))
+
+	deactivated, err := s.promotionRepository.Deactivate(ctx, code)
+	if err != nil {
+		return s.recordError(ctx, span, start, "deactivate_promotion", "repository_error", fmt.Errorf("failed to deactivate promotion: %w", err))
+	}
+	if !deactivated {
+		return s.recordError(ctx, span, start, "deactivate_promotion", "not_found", common.ErrPromotionNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "deactivate_promotion", zap.String("code", code))
+
+	return nil
+}
+
+func (s *promotionService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Promotion operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "promotion"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "promotion"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *promotionService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "promotion"), attribute.String("status", "success")))
+
+	s.log.Info("Promotion operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewPromotionService(
+	partnerRepository repository.PartnerRepository,
+	promotionRepository repository.PromotionRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PromotionServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &promotionService{
+		partnerRepository:   partnerRepository,
+		promotionRepository: promotionRepository,
+		meter:               meter,
+		tracer:              tracer,
+		log:                 log,
+		operationDuration:   operationDuration,
+		operationCount:      operationCount,
+		errorCount:          errorCount,
+	}
+}
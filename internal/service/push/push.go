@@ -0,0 +1,273 @@
+package pushsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/push"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type pushService struct {
+	notificationRepository repository.NotificationRepository
+	customerRepository     repository.CustomerRepository
+	templates              service.TemplateRenderer
+	sender                 push.Sender
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	deliveryCount     metric.Int64Counter
+}
+
+// RegisterDevice implements PushServices.
+func (s *pushService) RegisterDevice(ctx context.Context, customerID uint64, req dto.DeviceRequest) (*domain.Device, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RegisterDevice")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("device.platform", req.Platform),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "register_device"), attribute.String("service", "push")))
+
+	device := &domain.Device{
+		CustomerID: customerID,
+		Token:      req.Token,
+		Platform:   domain.DevicePlatform(req.Platform),
+		LastSeenAt: start,
+	}
+	if err := s.notificationRepository.UpsertDevice(ctx, device); err != nil {
+		return nil, s.recordError(ctx, span, start, "register_device", "repository_error", fmt.Errorf("failed to save device: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "register_device", zap.Uint64("customer_id", customerID), zap.String("platform", req.Platform))
+
+	return device, nil
+}
+
+// UnregisterDevice implements PushServices.
+func (s *pushService) UnregisterDevice(ctx context.Context, customerID uint64, token string) error {
+	ctx, span := s.tracer.Start(ctx, "service.UnregisterDevice")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "unregister_device"), attribute.String("service", "push")))
+
+	deleted, err := s.notificationRepository.DeleteDevice(ctx, customerID, token)
+	if err != nil {
+		return s.recordError(ctx, span, start, "unregister_device", "repository_error", fmt.Errorf("failed to delete device: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "unregister_device", "not_found", common.ErrDeviceNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "unregister_device", zap.Uint64("customer_id", customerID))
+
+	return nil
+}
+
+// Notify implements CustomerNotifier. Templates outside domain.PushTemplates
+// and customers without a registered device are skipped with
+// common.ErrNotificationSkipped.
+func (s *pushService) Notify(ctx context.Context, customerID uint64, template domain.NotificationTemplate, data map[string]string) error {
+	if !slices.Contains(domain.PushTemplates, template) {
+		return common.ErrNotificationSkipped
+	}
+
+	ctx, span := s.tracer.Start(ctx, "service.SendPushNotification")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("push.template", string(template)),
+		attribute.String("push.provider", s.sender.Provider()),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "send_push"), attribute.String("service", "push")))
+
+	devices, err := s.notificationRepository.FindDevices(ctx, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "send_push", "repository_error", fmt.Errorf("failed to find devices: %w", err))
+	}
+	if len(devices) == 0 {
+		span.SetStatus(codes.Ok, "Customer has no registered device")
+		return common.ErrNotificationSkipped
+	}
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "send_push", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return s.recordError(ctx, span, start, "send_push", "customer_not_found", common.ErrCustomerNotFound)
+	}
+
+	language := customer.Language
+	if language == "" {
+		language = domain.DefaultLanguage
+	}
+
+	title, body, err := s.templates.Render(template, language, data)
+	if err != nil {
+		return s.recordError(ctx, span, start, "send_push", "render_error", err)
+	}
+
+	// Hanya nama template dan nomor kontrak yang ikut di payload, data pribadi tetap di server
+	payload := map[string]string{"template": string(template)}
+	if contractNumber, ok := data["contract_number"]; ok {
+		payload["contract_number"] = contractNumber
+	}
+
+	sent, invalid := 0, 0
+	var errs []error
+	for _, device := range devices {
+		_, err := s.sender.Send(ctx, push.Message{Token: device.Token, Title: title, Body: body, Data: payload})
+
+		outcome := "sent"
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, push.ErrInvalidToken):
+			// Token yang sudah tidak berlaku dihapus agar tidak dikirimi lagi
+			outcome = "invalid_token"
+			invalid++
+			if deleteErr := s.notificationRepository.DeleteDeviceByToken(ctx, device.Token); deleteErr != nil {
+				s.log.Warn("Failed to remove invalid push token",
+					zap.Uint64("device_id", device.ID),
+					zap.Error(deleteErr),
+				)
+			}
+		default:
+			outcome = "failed"
+			errs = append(errs, err)
+		}
+
+		s.deliveryCount.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("provider", s.sender.Provider()),
+			attribute.String("template", string(template)),
+			attribute.String("outcome", outcome),
+		))
+	}
+
+	span.SetAttributes(
+		attribute.Int("push.sent", sent),
+		attribute.Int("push.invalid", invalid),
+		attribute.Int("push.failed", len(errs)),
+	)
+
+	switch {
+	case sent == 0 && len(errs) > 0:
+		return s.recordError(ctx, span, start, "send_push", "provider_error", errors.Join(errs...))
+	case sent == 0:
+		span.SetStatus(codes.Ok, "Every registered device was unregistered")
+		return common.ErrNotificationSkipped
+	}
+
+	s.recordSuccess(ctx, span, start, "send_push",
+		zap.Uint64("customer_id", customerID),
+		zap.String("template", string(template)),
+		zap.Int("sent", sent),
+		zap.Int("invalid", invalid),
+		zap.Int("failed", len(errs)),
+	)
+
+	return nil
+}
+
+func (s *pushService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Push operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "push"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "push"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *pushService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "push"), attribute.String("status", "success")))
+
+	s.log.Info("Push operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewPushService(
+	notificationRepository repository.NotificationRepository,
+	customerRepository repository.CustomerRepository,
+	templates service.TemplateRenderer,
+	sender push.Sender,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PushServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	deliveryCount, _ := meter.Int64Counter(
+		"service.push.deliveries",
+		metric.WithDescription("Number of push deliveries per device by outcome"),
+		metric.WithUnit("{delivery}"),
+	)
+
+	return &pushService{
+		notificationRepository: notificationRepository,
+		customerRepository:     customerRepository,
+		templates:              templates,
+		sender:                 sender,
+		meter:                  meter,
+		tracer:                 tracer,
+		log:                    log,
+		operationDuration:      operationDuration,
+		operationCount:         operationCount,
+		errorCount:             errorCount,
+		deliveryCount:          deliveryCount,
+	}
+}
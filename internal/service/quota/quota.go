@@ -0,0 +1,347 @@
+package quotasrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config sets where the business day rolls over, and with it the daily
+// counters, and the share of a cap in percent at which a partner is reported
+// as approaching it. AlertPercent zero turns the report off.
+type Config struct {
+	Location     *time.Location
+	AlertPercent int
+}
+
+type quotaService struct {
+	partnerRepository repository.PartnerRepository
+	quotaRepository   repository.PartnerQuotaRepository
+	counterRepository repository.QuotaCounterRepository
+	cfg               Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	quotaAlerts       metric.Int64Counter
+}
+
+// GetQuota implements PartnerQuotaServices.
+func (s *quotaService) GetQuota(ctx context.Context, partnerID uint64, now time.Time) (*domain.PartnerQuota, *domain.QuotaUsage, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetPartnerQuota")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(partnerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_partner_quota"), attribute.String("service", "partner_quota")))
+
+	if err := s.ensurePartner(ctx, partnerID); err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_partner_quota", "partner_lookup_error", err)
+	}
+
+	quota, err := s.quotaRepository.FindByPartner(ctx, partnerID)
+	if err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_partner_quota", "repository_error", fmt.Errorf("failed to find partner quota: %w", err))
+	}
+	// Partner tanpa kuota tetap ditampilkan pemakaiannya, dengan cap nol
+	if quota == nil {
+		quota = &domain.PartnerQuota{PartnerID: partnerID, DailyVolume: decimal.Zero}
+	}
+
+	day, resetsAt := s.businessDay(now)
+	usage, err := s.counterRepository.Find(ctx, partnerID, day)
+	if err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_partner_quota", "counter_error", fmt.Errorf("failed to find quota usage: %w", err))
+	}
+	usage.ResetsAt = resetsAt
+
+	s.recordSuccess(ctx, span, start, "get_partner_quota",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int64("used_count", usage.Count),
+		zap.Stringer("used_volume", usage.Volume),
+	)
+
+	return quota, usage, nil
+}
+
+// SetQuota implements PartnerQuotaServices.
+func (s *quotaService) SetQuota(ctx context.Context, partnerID, updatedBy uint64, req dto.PartnerQuotaRequest) (*domain.PartnerQuota, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetPartnerQuota")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Int64("admin.id", int64(updatedBy)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_partner_quota"), attribute.String("service", "partner_quota")))
+
+	if err := s.ensurePartner(ctx, partnerID); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_partner_quota", "partner_lookup_error", err)
+	}
+
+	quota := &domain.PartnerQuota{
+		PartnerID:   partnerID,
+		DailyCount:  req.DailyCount,
+		DailyVolume: req.DailyVolume.Round(2),
+		UpdatedBy:   updatedBy,
+	}
+
+	if err := s.quotaRepository.Upsert(ctx, quota); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_partner_quota", "repository_error", fmt.Errorf("failed to save partner quota: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "set_partner_quota",
+		zap.Uint64("partner_id", partnerID),
+		zap.Int64("daily_count", quota.DailyCount),
+		zap.Stringer("daily_volume", quota.DailyVolume),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return quota, nil
+}
+
+// DeleteQuota implements PartnerQuotaServices.
+func (s *quotaService) DeleteQuota(ctx context.Context, partnerID uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeletePartnerQuota")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(partnerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_partner_quota"), attribute.String("service", "partner_quota")))
+
+	deleted, err := s.quotaRepository.Delete(ctx, partnerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_partner_quota", "repository_error", fmt.Errorf("failed to delete partner quota: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_partner_quota", "not_found", common.ErrPartnerQuotaNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "delete_partner_quota", zap.Uint64("partner_id", partnerID))
+
+	return nil
+}
+
+// Reserve implements PartnerQuotaServices.
+func (s *quotaService) Reserve(ctx context.Context, partnerID uint64, volume decimal.Decimal, now time.Time) (*domain.QuotaReservation, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ReservePartnerQuota")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.Float64("quota.volume", volume.InexactFloat64()),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "reserve_partner_quota"), attribute.String("service", "partner_quota")))
+
+	quota, err := s.quotaRepository.FindByPartner(ctx, partnerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "reserve_partner_quota", "repository_error", fmt.Errorf("failed to find partner quota: %w", err))
+	}
+	if quota == nil || (quota.DailyCount == 0 && !quota.DailyVolume.IsPositive()) {
+		span.SetStatus(codes.Ok, "Partner has no quota")
+		s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", "reserve_partner_quota"), attribute.String("service", "partner_quota"), attribute.String("status", "success")))
+		return nil, nil
+	}
+
+	volume = volume.Round(2)
+	day, resetsAt := s.businessDay(now)
+	usage, reserved, err := s.counterRepository.Reserve(ctx, *quota, day, volume, resetsAt)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "reserve_partner_quota", "counter_error", fmt.Errorf("failed to reserve partner quota: %w", err))
+	}
+	if !reserved {
+		if quota.DailyCount > 0 && usage.Count >= quota.DailyCount {
+			return nil, s.recordError(ctx, span, start, "reserve_partner_quota", "count_quota_exceeded", common.ErrDailyCountQuotaExceeded)
+		}
+		return nil, s.recordError(ctx, span, start, "reserve_partner_quota", "volume_quota_exceeded", common.ErrDailyVolumeQuotaExceeded)
+	}
+
+	s.alertApproaching(ctx, span, quota, usage, volume)
+
+	s.recordSuccess(ctx, span, start, "reserve_partner_quota",
+		zap.Uint64("partner_id", partnerID),
+		zap.String("day", day),
+		zap.Int64("used_count", usage.Count),
+		zap.Stringer("used_volume", usage.Volume),
+	)
+
+	return &domain.QuotaReservation{PartnerID: partnerID, Day: day, Volume: volume}, nil
+}
+
+// Release implements PartnerQuotaServices.
+func (s *quotaService) Release(ctx context.Context, reservation *domain.QuotaReservation) error {
+	if reservation == nil {
+		return nil
+	}
+
+	ctx, span := s.tracer.Start(ctx, "service.ReleasePartnerQuota")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("partner.id", int64(reservation.PartnerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "release_partner_quota"), attribute.String("service", "partner_quota")))
+
+	if err := s.counterRepository.Release(ctx, reservation.PartnerID, reservation.Day, reservation.Volume); err != nil {
+		return s.recordError(ctx, span, start, "release_partner_quota", "counter_error", fmt.Errorf("failed to release partner quota: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "release_partner_quota",
+		zap.Uint64("partner_id", reservation.PartnerID),
+		zap.String("day", reservation.Day),
+		zap.Stringer("volume", reservation.Volume),
+	)
+
+	return nil
+}
+
+// businessDay returns the counter key for the business day containing now
+// and the midnight that ends it.
+func (s *quotaService) businessDay(now time.Time) (string, time.Time) {
+	local := now.In(s.cfg.Location)
+	next := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, s.cfg.Location)
+	return local.Format("20060102"), next
+}
+
+// alertApproaching reports each cap the reservation carried over AlertPercent.
+// Only the reservation that crosses the threshold sees it between its usage
+// before and after, so a partner is reported once per cap per day.
+func (s *quotaService) alertApproaching(ctx context.Context, span trace.Span, quota *domain.PartnerQuota, usage *domain.QuotaUsage, volume decimal.Decimal) {
+	if s.cfg.AlertPercent <= 0 {
+		return
+	}
+
+	check := func(dimension string, before, after, limit decimal.Decimal) {
+		if !limit.IsPositive() {
+			return
+		}
+		threshold := limit.Mul(decimal.NewFromInt(int64(s.cfg.AlertPercent))).Div(decimal.NewFromInt(100))
+		if !before.LessThan(threshold) || after.LessThan(threshold) {
+			return
+		}
+
+		s.quotaAlerts.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("service", "partner_quota"),
+			attribute.String("dimension", dimension),
+			attribute.Int64("partner_id", int64(quota.PartnerID)),
+		))
+		s.log.Warn("Partner approaching daily quota",
+			zap.Uint64("partner_id", quota.PartnerID),
+			zap.String("dimension", dimension),
+			zap.Stringer("used", after),
+			zap.Stringer("limit", limit),
+			zap.Int("alert_percent", s.cfg.AlertPercent),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+	}
+
+	check("count", decimal.NewFromInt(usage.Count-1), decimal.NewFromInt(usage.Count), decimal.NewFromInt(quota.DailyCount))
+	check("volume", usage.Volume.Sub(volume), usage.Volume, quota.DailyVolume)
+}
+
+func (s *quotaService) ensurePartner(ctx context.Context, partnerID uint64) error {
+	partner, err := s.partnerRepository.FindByID(ctx, partnerID)
+	if err != nil {
+		return fmt.Errorf("failed to find partner: %w", err)
+	}
+	if partner == nil {
+		return common.ErrPartnerNotFound
+	}
+	return nil
+}
+
+func (s *quotaService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Partner quota operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "partner_quota"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "partner_quota"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *quotaService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "partner_quota"), attribute.String("status", "success")))
+
+	s.log.Info("Partner quota operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewQuotaService(
+	partnerRepository repository.PartnerRepository,
+	quotaRepository repository.PartnerQuotaRepository,
+	counterRepository repository.QuotaCounterRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PartnerQuotaServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	quotaAlerts, _ := meter.Int64Counter(
+		"service.partner_quota.alerts",
+		metric.WithDescription("Number of times a partner crossed the alert share of a daily quota"),
+		metric.WithUnit("{alert}"),
+	)
+
+	return &quotaService{
+		partnerRepository: partnerRepository,
+		quotaRepository:   quotaRepository,
+		counterRepository: counterRepository,
+		cfg:               cfg,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+		quotaAlerts:       quotaAlerts,
+	}
+}
@@ -0,0 +1,183 @@
+package recommendationsrv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type recommendationService struct {
+	customerRepository repository.CustomerRepository
+	tenorRepository    repository.TenorRepository
+	limitRepository    repository.LimitRepository
+	riskTierRepository repository.RiskTierRepository
+	rules              Rules
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Recommend implements LimitRecommendationServices.
+func (r *recommendationService) Recommend(ctx context.Context, customerID uint64) (*dto.LimitRecommendationResponse, error) {
+	ctx, span := r.tracer.Start(ctx, "service.RecommendLimits")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	r.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "recommend_limits"), attribute.String("service", "recommendation")))
+
+	customer, err := r.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, r.recordError(ctx, span, start, "recommend_limits", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return nil, r.recordError(ctx, span, start, "recommend_limits", "customer_not_found", common.ErrCustomerNotFound)
+	}
+
+	tenors, err := r.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, r.recordError(ctx, span, start, "recommend_limits", "repository_error", fmt.Errorf("failed to get tenors: %w", err))
+	}
+	sort.Slice(tenors, func(i, j int) bool { return tenors[i].DurationMonths < tenors[j].DurationMonths })
+
+	// Versi limit saat ini ikut dikirim agar rekomendasi bisa langsung di-submit ke SetLimits
+	limits, err := r.limitRepository.FindAllByCustomerID(ctx, customerID, time.Now())
+	if err != nil {
+		return nil, r.recordError(ctx, span, start, "recommend_limits", "repository_error", fmt.Errorf("failed to get current limits: %w", err))
+	}
+	versions := make(map[uint]uint64, len(limits))
+	for _, limit := range limits {
+		versions[limit.TenorID] = limit.Version
+	}
+
+	// Tier dari job risk tier didahulukan, pita gaji hanya cadangan bila customer belum dinilai
+	assigned, err := r.riskTierRepository.FindByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, r.recordError(ctx, span, start, "recommend_limits", "repository_error", fmt.Errorf("failed to get risk tier: %w", err))
+	}
+	var tier *Tier
+	if assigned != nil && assigned.Tier != "" {
+		tier = r.rules.tierNamed(string(assigned.Tier))
+	}
+	if tier == nil {
+		tier = r.rules.tierFor(customer.Salary)
+	}
+	res := &dto.LimitRecommendationResponse{
+		CustomerID:  customerID,
+		Salary:      customer.Salary,
+		SalaryRatio: r.rules.SalaryRatio,
+		Limits:      make([]dto.LimitRecommendationItem, 0, len(tenors)),
+	}
+	if tier != nil {
+		res.RiskTier = tier.Name
+	}
+
+	for _, tenor := range tenors {
+		amount, capped := r.rules.limitFor(customer.Salary, tenor.DurationMonths, tier)
+		res.Limits = append(res.Limits, dto.LimitRecommendationItem{
+			TenorMonths: tenor.DurationMonths,
+			LimitAmount: amount,
+			Capped:      capped,
+			Version:     versions[tenor.ID],
+		})
+	}
+
+	span.SetAttributes(attribute.String("recommendation.risk_tier", res.RiskTier))
+	r.recordSuccess(ctx, span, start, "recommend_limits",
+		zap.Uint64("customer_id", customerID),
+		zap.String("risk_tier", res.RiskTier),
+		zap.Int("tenors", len(res.Limits)),
+	)
+
+	return res, nil
+}
+
+func (r *recommendationService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	r.log.Error("Limit recommendation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	r.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "recommendation"), attribute.String("error_type", errorType)))
+	r.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "recommendation"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (r *recommendationService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	r.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "recommendation"), attribute.String("status", "success")))
+
+	r.log.Info("Limit recommendation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewRecommendationService(
+	customerRepository repository.CustomerRepository,
+	tenorRepository repository.TenorRepository,
+	limitRepository repository.LimitRepository,
+	riskTierRepository repository.RiskTierRepository,
+	rules Rules,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.LimitRecommendationServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &recommendationService{
+		customerRepository: customerRepository,
+		tenorRepository:    tenorRepository,
+		limitRepository:    limitRepository,
+		riskTierRepository: riskTierRepository,
+		rules:              rules,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+	}
+}
@@ -0,0 +1,113 @@
+package recommendationsrv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Tier caps the recommended limit per tenor for customers whose monthly
+// salary is at least MinSalary.
+type Tier struct {
+	Name      string
+	MinSalary decimal.Decimal
+	MaxLimit  decimal.Decimal
+}
+
+// Rules drive the recommendation: limit = salary * SalaryRatio * tenor months,
+// rounded down to Rounding and capped by the customer's tier.
+type Rules struct {
+	SalaryRatio float64
+	Rounding    decimal.Decimal
+	Tiers       []Tier
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		SalaryRatio: 0.3,
+		Rounding:    decimal.NewFromInt(100_000),
+		Tiers: []Tier{
+			{Name: "LOW", MinSalary: decimal.Zero, MaxLimit: decimal.NewFromInt(10_000_000)},
+			{Name: "MEDIUM", MinSalary: decimal.NewFromInt(8_000_000), MaxLimit: decimal.NewFromInt(50_000_000)},
+			{Name: "HIGH", MinSalary: decimal.NewFromInt(20_000_000), MaxLimit: decimal.NewFromInt(150_000_000)},
+		},
+	}
+}
+
+// ParseTiers reads tiers from "NAME:min_salary:max_limit" entries separated
+// by commas, e.g. "LOW:0:10000000,HIGH:20000000:150000000".
+func ParseTiers(spec string) ([]Tier, error) {
+	var tiers []Tier
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid tier %q, expected NAME:min_salary:max_limit", entry)
+		}
+		minSalary, err := decimal.NewFromString(parts[1])
+		if err != nil || minSalary.IsNegative() {
+			return nil, fmt.Errorf("invalid min salary in tier %q", entry)
+		}
+		maxLimit, err := decimal.NewFromString(parts[2])
+		if err != nil || !maxLimit.IsPositive() {
+			return nil, fmt.Errorf("invalid max limit in tier %q", entry)
+		}
+
+		tiers = append(tiers, Tier{Name: strings.ToUpper(parts[0]), MinSalary: minSalary, MaxLimit: maxLimit})
+	}
+
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("no tiers defined")
+	}
+	return tiers, nil
+}
+
+// tierFor returns the tier with the highest MinSalary not above salary.
+func (r Rules) tierFor(salary decimal.Decimal) *Tier {
+	tiers := append([]Tier(nil), r.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinSalary.LessThan(tiers[j].MinSalary) })
+
+	var matched *Tier
+	for i := range tiers {
+		if salary.GreaterThanOrEqual(tiers[i].MinSalary) {
+			matched = &tiers[i]
+		}
+	}
+	return matched
+}
+
+// tierNamed returns the tier called name, nil when there is none.
+func (r Rules) tierNamed(name string) *Tier {
+	for i := range r.Tiers {
+		if strings.EqualFold(r.Tiers[i].Name, name) {
+			return &r.Tiers[i]
+		}
+	}
+	return nil
+}
+
+// TierName returns the name of the tier salary falls in, empty when no tier
+// matches.
+func (r Rules) TierName(salary decimal.Decimal) string {
+	if tier := r.tierFor(salary); tier != nil {
+		return tier.Name
+	}
+	return ""
+}
+
+func (r Rules) limitFor(salary decimal.Decimal, months uint8, tier *Tier) (decimal.Decimal, bool) {
+	amount := salary.Mul(decimal.NewFromFloat(r.SalaryRatio)).Mul(decimal.NewFromInt(int64(months)))
+	if r.Rounding.IsPositive() {
+		amount = amount.Div(r.Rounding).Floor().Mul(r.Rounding)
+	}
+	if tier != nil && amount.GreaterThan(tier.MaxLimit) {
+		return tier.MaxLimit, true
+	}
+	return amount, false
+}
@@ -0,0 +1,176 @@
+package referencedatasrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/refdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type referenceDataService struct {
+	assetCategoryRepository repository.AssetCategoryRepository
+	regionRepository        repository.RegionRepository
+	dueDateRepository       repository.DueDateRepository
+	tenorService            service.TenorServices
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Apply implements ReferenceDataServices.
+func (s *referenceDataService) Apply(ctx context.Context, data *refdata.Set) (*domain.ReferenceDataResult, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ApplyReferenceData")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "apply_reference_data"), attribute.String("service", "reference_data")))
+
+	// Data dari Load sudah divalidasi, tetapi Set juga bisa dibuat langsung oleh pemanggil
+	if err := data.Validate(); err != nil {
+		return nil, s.recordError(ctx, span, start, "apply_reference_data", "invalid_data", err)
+	}
+
+	// Kategori lebih dulu karena tenor merujuk ke kategori
+	result := &domain.ReferenceDataResult{}
+	for _, c := range data.AssetCategories {
+		if err := s.assetCategoryRepository.Upsert(ctx, &domain.AssetCategory{Code: c.Code, Name: c.Name}); err != nil {
+			return nil, s.recordError(ctx, span, start, "apply_reference_data", "repository_error", fmt.Errorf("failed to save asset category %s: %w", c.Code, err))
+		}
+		result.AssetCategories++
+	}
+
+	for _, r := range data.Regions {
+		region := &domain.Region{Code: r.Code, Name: r.Name, Active: r.Active == nil || *r.Active}
+		if err := s.regionRepository.Upsert(ctx, region); err != nil {
+			return nil, s.recordError(ctx, span, start, "apply_reference_data", "repository_error", fmt.Errorf("failed to save region %s: %w", r.Code, err))
+		}
+		result.Regions++
+	}
+
+	if len(data.Tenors) > 0 {
+		tenors := make([]dto.CreateTenorRequest, len(data.Tenors))
+		for i, t := range data.Tenors {
+			tenors[i] = dto.CreateTenorRequest{
+				DurationMonths: t.DurationMonths,
+				Description:    t.Description,
+				TenorProductRequest: dto.TenorProductRequest{
+					MinAmount:       t.MinAmount,
+					MaxAmount:       t.MaxAmount,
+					MinAge:          t.MinAge,
+					MaxAge:          t.MaxAge,
+					MaxAgeAtEnd:     t.MaxAgeAtEnd,
+					AssetCategories: t.AssetCategories,
+				},
+			}
+		}
+		if err := s.tenorService.SeedTenors(ctx, tenors); err != nil {
+			return nil, s.recordError(ctx, span, start, "apply_reference_data", "tenor_error", err)
+		}
+		result.Tenors = len(tenors)
+	}
+
+	for _, h := range data.Holidays {
+		// Validate sudah memastikan formatnya benar
+		day, _ := time.Parse(time.DateOnly, h.Date)
+		if err := s.dueDateRepository.UpsertHoliday(ctx, &domain.Holiday{Date: day, Name: h.Name}); err != nil {
+			return nil, s.recordError(ctx, span, start, "apply_reference_data", "repository_error", fmt.Errorf("failed to save holiday %s: %w", h.Date, err))
+		}
+		result.Holidays++
+	}
+
+	s.recordSuccess(ctx, span, start, "apply_reference_data",
+		zap.Int("asset_categories", result.AssetCategories),
+		zap.Int("regions", result.Regions),
+		zap.Int("tenors", result.Tenors),
+		zap.Int("holidays", result.Holidays),
+	)
+
+	return result, nil
+}
+
+func (s *referenceDataService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Reference data operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "reference_data"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "reference_data"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *referenceDataService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "reference_data"), attribute.String("status", "success")))
+
+	s.log.Info("Reference data operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewReferenceDataService(
+	assetCategoryRepository repository.AssetCategoryRepository,
+	regionRepository repository.RegionRepository,
+	dueDateRepository repository.DueDateRepository,
+	tenorService service.TenorServices,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ReferenceDataServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &referenceDataService{
+		assetCategoryRepository: assetCategoryRepository,
+		regionRepository:        regionRepository,
+		dueDateRepository:       dueDateRepository,
+		tenorService:            tenorService,
+		meter:                   meter,
+		tracer:                  tracer,
+		log:                     log,
+		operationDuration:       operationDuration,
+		operationCount:          operationCount,
+		errorCount:              errorCount,
+	}
+}
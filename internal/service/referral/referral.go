@@ -0,0 +1,291 @@
+package referralsrv
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// Tanpa 0/O dan 1/I supaya kode mudah didiktekan
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength   = 8
+	codeAttempts = 5
+)
+
+// Reasons stored on rejected referrals.
+const (
+	RejectSelfReferral    = "self_referral"
+	RejectDuplicateDevice = "duplicate_device"
+	RejectDuplicateIP     = "duplicate_ip"
+)
+
+// Config tunes the fraud checks. A referee is rejected when MaxPerIP
+// referrals already came from its IP address within IPWindow. Zero MaxPerIP
+// disables the IP check.
+type Config struct {
+	IPWindow time.Duration
+	MaxPerIP int
+}
+
+type referralService struct {
+	customerRepository repository.CustomerRepository
+	referralRepository repository.ReferralRepository
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// GetMyCode implements ReferralServices.
+func (s *referralService) GetMyCode(ctx context.Context, customerID uint64) (*domain.ReferralCode, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetMyReferralCode")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_referral_code"), attribute.String("service", "referral")))
+
+	code, err := s.referralRepository.FindCode(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_referral_code", "repository_error", fmt.Errorf("failed to find referral code: %w", err))
+	}
+
+	// Kode dibuat saat pertama diminta, bentrok kode dicoba ulang dengan kode
+	// baru sedangkan bentrok customer berarti request lain sudah membuatnya
+	for attempt := 0; code == nil && attempt < codeAttempts; attempt++ {
+		candidate, err := generateCode()
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "get_referral_code", "generate_error", fmt.Errorf("failed to generate referral code: %w", err))
+		}
+
+		created := &domain.ReferralCode{CustomerID: customerID, Code: candidate}
+		ok, err := s.referralRepository.CreateCode(ctx, created)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "get_referral_code", "repository_error", fmt.Errorf("failed to create referral code: %w", err))
+		}
+		if ok {
+			code = created
+			break
+		}
+
+		if code, err = s.referralRepository.FindCode(ctx, customerID); err != nil {
+			return nil, s.recordError(ctx, span, start, "get_referral_code", "repository_error", fmt.Errorf("failed to find referral code: %w", err))
+		}
+	}
+	if code == nil {
+		return nil, s.recordError(ctx, span, start, "get_referral_code", "code_exhausted", errors.New("failed to allocate a unique referral code"))
+	}
+
+	s.recordSuccess(ctx, span, start, "get_referral_code", zap.Uint64("customer_id", customerID))
+
+	return code, nil
+}
+
+// Resolve implements ReferralServices.
+func (s *referralService) Resolve(ctx context.Context, code string) (*domain.ReferralCode, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ResolveReferralCode")
+	defer span.End()
+
+	start := time.Now()
+	code = strings.ToUpper(strings.TrimSpace(code))
+	span.SetAttributes(attribute.String("referral.code", code))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "resolve_referral_code"), attribute.String("service", "referral")))
+
+	referralCode, err := s.referralRepository.FindByCode(ctx, code)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "resolve_referral_code", "repository_error", fmt.Errorf("failed to find referral code: %w", err))
+	}
+	if referralCode == nil {
+		return nil, s.recordError(ctx, span, start, "resolve_referral_code", "not_found", common.ErrReferralCodeNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "resolve_referral_code", zap.Uint64("referrer_id", referralCode.CustomerID))
+
+	return referralCode, nil
+}
+
+// Record implements ReferralServices.
+func (s *referralService) Record(ctx context.Context, code *domain.ReferralCode, referee *domain.Customer) (*domain.Referral, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RecordReferral")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("referral.referrer_id", int64(code.CustomerID)),
+		attribute.Int64("referral.referee_id", int64(referee.ID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "record_referral"), attribute.String("service", "referral")))
+
+	referrer, err := s.customerRepository.FindByID(ctx, code.CustomerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "record_referral", "repository_error", fmt.Errorf("failed to find referrer: %w", err))
+	}
+	if referrer == nil {
+		return nil, s.recordError(ctx, span, start, "record_referral", "referrer_not_found", common.ErrCustomerNotFound)
+	}
+
+	reason, err := s.fraudCheck(ctx, referrer, referee)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "record_referral", "repository_error", err)
+	}
+
+	referral := &domain.Referral{
+		ReferrerID: referrer.ID,
+		RefereeID:  referee.ID,
+		Code:       code.Code,
+		Status:     domain.ReferralAccepted,
+		DeviceID:   referee.RegistrationDeviceID,
+		IPAddress:  referee.RegistrationIP,
+	}
+	if reason != "" {
+		referral.Status = domain.ReferralRejected
+		referral.RejectReason = reason
+	}
+
+	if err := s.referralRepository.Create(ctx, referral); err != nil {
+		return nil, s.recordError(ctx, span, start, "record_referral", "repository_error", fmt.Errorf("failed to save referral: %w", err))
+	}
+
+	if reason != "" {
+		s.log.Warn("Referral rejected by fraud check",
+			zap.Uint64("referrer_id", referrer.ID),
+			zap.Uint64("referee_id", referee.ID),
+			zap.String("reason", reason),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+		)
+	}
+
+	s.recordSuccess(ctx, span, start, "record_referral",
+		zap.Uint64("referrer_id", referrer.ID),
+		zap.Uint64("referee_id", referee.ID),
+		zap.String("status", string(referral.Status)),
+	)
+
+	return referral, nil
+}
+
+// fraudCheck returns the reason the referral must be rejected, or an empty
+// string when it passes.
+func (s *referralService) fraudCheck(ctx context.Context, referrer, referee *domain.Customer) (string, error) {
+	if referrer.ID == referee.ID ||
+		(strings.EqualFold(strings.TrimSpace(referrer.LegalName), strings.TrimSpace(referee.LegalName)) && referrer.BirthDate.Equal(referee.BirthDate)) ||
+		(referee.RegistrationDeviceID != "" && referee.RegistrationDeviceID == referrer.RegistrationDeviceID) {
+		return RejectSelfReferral, nil
+	}
+
+	byDevice, byIP, err := s.referralRepository.CountByFingerprint(ctx, referee.RegistrationDeviceID, referee.RegistrationIP, time.Now().Add(-s.cfg.IPWindow))
+	if err != nil {
+		return "", fmt.Errorf("failed to count referrals by fingerprint: %w", err)
+	}
+
+	switch {
+	case byDevice > 0:
+		return RejectDuplicateDevice, nil
+	case s.cfg.MaxPerIP > 0 && byIP >= int64(s.cfg.MaxPerIP):
+		return RejectDuplicateIP, nil
+	}
+
+	return "", nil
+}
+
+func generateCode() (string, error) {
+	buf := make([]byte, codeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	// 256 habis dibagi 32 sehingga tiap karakter berpeluang sama
+	for i, b := range buf {
+		buf[i] = codeAlphabet[int(b)%len(codeAlphabet)]
+	}
+	return string(buf), nil
+}
+
+func (s *referralService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Referral operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "referral"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "referral"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *referralService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "referral"), attribute.String("status", "success")))
+
+	s.log.Info("Referral operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewReferralService(
+	customerRepository repository.CustomerRepository,
+	referralRepository repository.ReferralRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ReferralServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &referralService{
+		customerRepository: customerRepository,
+		referralRepository: referralRepository,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+	}
+}
@@ -0,0 +1,201 @@
+package regionsrv
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var codePattern = regexp.MustCompile(`^[A-Z]{2,8}$`)
+
+type regionService struct {
+	partnerRepository repository.PartnerRepository
+	regionRepository  repository.RegionRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// ListRegions implements RegionServices.
+func (s *regionService) ListRegions(ctx context.Context) ([]domain.Region, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListRegions")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_regions"), attribute.String("service", "region")))
+
+	regions, err := s.regionRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_regions", "repository_error", fmt.Errorf("failed to list regions: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_regions", zap.Int("count", len(regions)))
+
+	return regions, nil
+}
+
+// SetRegion implements RegionServices.
+func (s *regionService) SetRegion(ctx context.Context, code string, req dto.RegionRequest) (*domain.Region, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetRegion")
+	defer span.End()
+
+	start := time.Now()
+	code = strings.ToUpper(code)
+	active := req.Active != nil && *req.Active
+	span.SetAttributes(
+		attribute.String("region.code", code),
+		attribute.Bool("region.active", active),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_region"), attribute.String("service", "region")))
+
+	if !codePattern.MatchString(code) {
+		return nil, s.recordError(ctx, span, start, "set_region", "invalid_code", common.ErrInvalidRegionCode)
+	}
+
+	region := &domain.Region{
+		Code:   code,
+		Name:   req.Name,
+		Active: active,
+	}
+
+	if err := s.regionRepository.Upsert(ctx, region); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_region", "repository_error", fmt.Errorf("failed to save region: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "set_region",
+		zap.String("code", code),
+		zap.Bool("active", active),
+	)
+
+	return region, nil
+}
+
+// SetPartnerRegion implements RegionServices.
+func (s *regionService) SetPartnerRegion(ctx context.Context, partnerID uint64, req dto.PartnerRegionRequest) (*domain.Partner, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetPartnerRegion")
+	defer span.End()
+
+	start := time.Now()
+	code := strings.ToUpper(req.RegionCode)
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partnerID)),
+		attribute.String("region.code", code),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_partner_region"), attribute.String("service", "region")))
+
+	partner, err := s.partnerRepository.FindByID(ctx, partnerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_partner_region", "partner_lookup_error", fmt.Errorf("failed to find partner: %w", err))
+	}
+	if partner == nil {
+		return nil, s.recordError(ctx, span, start, "set_partner_region", "partner_not_found", common.ErrPartnerNotFound)
+	}
+
+	region, err := s.regionRepository.FindByCode(ctx, code)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_partner_region", "region_lookup_error", fmt.Errorf("failed to find region: %w", err))
+	}
+	if region == nil || !region.Active {
+		return nil, s.recordError(ctx, span, start, "set_partner_region", "region_not_found", common.ErrRegionNotFound)
+	}
+
+	partner.RegionCode = region.Code
+	partner.BranchCode = strings.TrimSpace(req.BranchCode)
+	if err := s.partnerRepository.Update(ctx, partner); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_partner_region", "repository_error", fmt.Errorf("failed to update partner: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "set_partner_region",
+		zap.Uint64("partner_id", partnerID),
+		zap.String("region_code", partner.RegionCode),
+		zap.String("branch_code", partner.BranchCode),
+	)
+
+	return partner, nil
+}
+
+func (s *regionService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Region operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "region"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "region"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *regionService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "region"), attribute.String("status", "success")))
+
+	s.log.Info("Region operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewRegionService(
+	partnerRepository repository.PartnerRepository,
+	regionRepository repository.RegionRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.RegionServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &regionService{
+		partnerRepository: partnerRepository,
+		regionRepository:  regionRepository,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+	}
+}
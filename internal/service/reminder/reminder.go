@@ -0,0 +1,258 @@
+package remindersrv
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config controls the reminder job. An installment is reminded once, on the
+// first run within DaysBefore days of its adjusted due date.
+type Config struct {
+	DaysBefore int
+	BatchSize  int
+}
+
+// separators are the thousands and decimal separators of a language.
+var separators = map[domain.Language][2]string{
+	domain.LanguageIndonesian: {".", ","},
+	domain.LanguageEnglish:    {",", "."},
+}
+
+type paymentReminderService struct {
+	paymentReminderRepository repository.PaymentReminderRepository
+	notifier                  service.CustomerNotifier
+	dueDateRules              service.DueDateRules
+	cfg                       Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	reminderCount     metric.Int64Counter
+}
+
+// Run implements PaymentReminderServices.
+func (s *paymentReminderService) Run(ctx context.Context, now time.Time) (*domain.PaymentReminderRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RunPaymentReminders")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("payment_reminder.days_before", s.cfg.DaysBefore))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "run_payment_reminders"), attribute.String("service", "payment_reminder")))
+
+	run := &domain.PaymentReminderRun{}
+	rules := s.dueDateRules.Rules(ctx)
+
+	var afterID uint64
+	for {
+		contracts, err := s.paymentReminderRepository.FindUpcoming(ctx, afterID, s.cfg.BatchSize)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "run_payment_reminders", "repository_error", fmt.Errorf("failed to find contracts: %w", err))
+		}
+
+		for i := range contracts {
+			if err := s.remind(ctx, &contracts[i], rules, now, run); err != nil {
+				return nil, s.recordError(ctx, span, start, "run_payment_reminders", "repository_error", err)
+			}
+		}
+
+		if len(contracts) == 0 || len(contracts) < s.cfg.BatchSize {
+			break
+		}
+		afterID = contracts[len(contracts)-1].ID
+	}
+
+	for outcome, count := range map[string]int{
+		"sent":   run.Sent,
+		"failed": run.Failed,
+	} {
+		s.reminderCount.Add(ctx, int64(count), metric.WithAttributes(attribute.String("service", "payment_reminder"), attribute.String("outcome", outcome)))
+	}
+
+	span.SetAttributes(
+		attribute.Int("payment_reminder.due", run.Due),
+		attribute.Int("payment_reminder.sent", run.Sent),
+		attribute.Int("payment_reminder.failed", run.Failed),
+	)
+	s.recordSuccess(ctx, span, start, "run_payment_reminders",
+		zap.Int("due", run.Due),
+		zap.Int("sent", run.Sent),
+		zap.Int("failed", run.Failed),
+	)
+
+	return run, nil
+}
+
+// remind notifies the customer about the next unpaid installment of contract
+// when its due date falls within the reminder window. The reminder is
+// claimed first so two instances never send it twice, and the claim is
+// released when the notification fails so the next run tries again.
+func (s *paymentReminderService) remind(ctx context.Context, contract *domain.Transaction, rules installment.Rules, now time.Time, run *domain.PaymentReminderRun) error {
+	months := int(contract.Tenor.DurationMonths)
+	paid := 0
+	if contract.PaidInstallments != nil {
+		paid = *contract.PaidInstallments
+	}
+	if months == 0 || paid >= months {
+		return nil
+	}
+
+	sequence := paid + 1
+	today := datetime.Date(now)
+	dueDate := datetime.Date(rules.DueDate(contract.TransactionDate, sequence))
+	// Angsuran yang sudah lewat jatuh tempo ditangani penagihan, bukan pengingat
+	if dueDate.Before(today) || dueDate.After(today.AddDate(0, 0, s.cfg.DaysBefore)) {
+		return nil
+	}
+	run.Due++
+
+	reminder := &domain.PaymentReminder{
+		TransactionID: contract.ID,
+		Installment:   sequence,
+		DueDate:       dueDate,
+	}
+	claimed, err := s.paymentReminderRepository.Claim(ctx, reminder)
+	if err != nil {
+		return fmt.Errorf("failed to claim payment reminder: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	language := contract.Customer.Language
+	if language == "" {
+		language = domain.DefaultLanguage
+	}
+	sep, ok := separators[language]
+	if !ok {
+		sep = separators[domain.DefaultLanguage]
+	}
+	amount := installment.Schedule(contract.TransactionDate, 1, months, contract.TotalInstallmentAmount)[sequence-1].Amount
+
+	err = s.notifier.Notify(ctx, contract.CustomerID, domain.TemplatePaymentReminder, map[string]string{
+		"full_name":       contract.Customer.FullName,
+		"contract_number": contract.ContractNumber,
+		"installment":     strconv.Itoa(sequence),
+		"due_date":        dueDate.Format(time.DateOnly),
+		"amount":          contract.Currency + " " + money.Format(amount, sep[0], sep[1]),
+	})
+	if err != nil {
+		run.Failed++
+		s.log.Warn("Failed to send payment reminder",
+			zap.Uint64("transaction_id", contract.ID),
+			zap.Int("installment", sequence),
+			zap.Error(err),
+		)
+
+		if err := s.paymentReminderRepository.Release(ctx, reminder.ID); err != nil {
+			return fmt.Errorf("failed to release payment reminder: %w", err)
+		}
+		return nil
+	}
+
+	run.Sent++
+	return nil
+}
+
+func (s *paymentReminderService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Payment reminder operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "payment_reminder"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "payment_reminder"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *paymentReminderService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "payment_reminder"), attribute.String("status", "success")))
+
+	s.log.Info("Payment reminder operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewPaymentReminderService(
+	paymentReminderRepository repository.PaymentReminderRepository,
+	notifier service.CustomerNotifier,
+	dueDateRules service.DueDateRules,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.PaymentReminderServices {
+	if cfg.DaysBefore < 0 {
+		cfg.DaysBefore = 0
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	reminderCount, _ := meter.Int64Counter(
+		"service.payment_reminder.reminders",
+		metric.WithDescription("Number of payment reminders by outcome"),
+		metric.WithUnit("{reminder}"),
+	)
+
+	return &paymentReminderService{
+		paymentReminderRepository: paymentReminderRepository,
+		notifier:                  notifier,
+		dueDateRules:              dueDateRules,
+		cfg:                       cfg,
+		meter:                     meter,
+		tracer:                    tracer,
+		log:                       log,
+		operationDuration:         operationDuration,
+		operationCount:            operationCount,
+		errorCount:                errorCount,
+		reminderCount:             reminderCount,
+	}
+}
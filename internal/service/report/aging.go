@@ -0,0 +1,232 @@
+package reportsrv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+const (
+	agingSourceSnapshot = "snapshot"
+	agingSourceLive     = "live"
+)
+
+// Aging implements ReportServices.
+func (s *reportService) Aging(ctx context.Context, date string) (*dto.AgingReportResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.Aging")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("report.date", date))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "aging"), attribute.String("service", "report")))
+
+	asOf := time.Now()
+	if date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "aging", "invalid_date", fmt.Errorf("%w: %s", common.ErrInvalidReportDate, date))
+		}
+		asOf = parsed
+	}
+
+	rows, err := s.reportRepository.LatestAgingSnapshot(ctx, asOf)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "aging", "repository_error", fmt.Errorf("failed to load aging snapshot: %w", err))
+	}
+
+	source := agingSourceSnapshot
+	if len(rows) > 0 {
+		asOf = rows[0].SnapshotDate
+	} else {
+		// Belum ada snapshot sampai tanggal itu, hitung langsung dari kontrak aktif
+		source = agingSourceLive
+		exposures, err := s.reportRepository.AgingExposures(ctx)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "aging", "repository_error", fmt.Errorf("failed to load aging exposures: %w", err))
+		}
+		rows = agingRows(exposures, s.dueDateRules.Rules(ctx), asOf)
+	}
+
+	report := agingReport(asOf, source, rows)
+
+	span.SetAttributes(attribute.String("report.source", source))
+	s.recordSuccess(ctx, span, start, "aging",
+		zap.String("as_of", report.AsOf),
+		zap.String("source", source),
+		zap.Int64("contract_count", report.ContractCount),
+		zap.Stringer("total_outstanding", report.TotalOutstanding),
+	)
+
+	return report, nil
+}
+
+// RefreshAgingSnapshot implements ReportServices.
+func (s *reportService) RefreshAgingSnapshot(ctx context.Context, now time.Time) error {
+	ctx, span := s.tracer.Start(ctx, "service.RefreshAgingSnapshot")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "refresh_aging_snapshot"), attribute.String("service", "report")))
+
+	exposures, err := s.reportRepository.AgingExposures(ctx)
+	if err != nil {
+		return s.recordError(ctx, span, start, "refresh_aging_snapshot", "repository_error", fmt.Errorf("failed to load aging exposures: %w", err))
+	}
+
+	snapshotDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	rows := agingRows(exposures, s.dueDateRules.Rules(ctx), now)
+	for i := range rows {
+		rows[i].SnapshotDate = snapshotDate
+	}
+
+	if err := s.reportRepository.SaveAgingSnapshot(ctx, snapshotDate, rows); err != nil {
+		return s.recordError(ctx, span, start, "refresh_aging_snapshot", "repository_error", fmt.Errorf("failed to save aging snapshot: %w", err))
+	}
+
+	span.SetAttributes(attribute.Int("report.contracts", len(exposures)))
+	s.recordSuccess(ctx, span, start, "refresh_aging_snapshot",
+		zap.String("snapshot_date", snapshotDate.Format("2006-01-02")),
+		zap.Int("contracts", len(exposures)),
+		zap.Int("rows", len(rows)),
+	)
+
+	return nil
+}
+
+// agingRows buckets every exposure by the days its oldest unpaid installment
+// is overdue at asOf and sums the unpaid balance per tenor and bucket. Rows
+// are ordered by tenor, then bucket.
+func agingRows(exposures []domain.AgingExposure, rules installment.Rules, asOf time.Time) []domain.AgingSnapshotRow {
+	type key struct {
+		tenor  uint8
+		bucket domain.AgingBucket
+	}
+	index := make(map[key]int)
+	rows := []domain.AgingSnapshotRow{}
+
+	for _, exposure := range exposures {
+		outstanding, bucket, ok := exposureBalance(exposure, rules, asOf)
+		if !ok {
+			continue
+		}
+
+		k := key{exposure.TenorMonths, bucket}
+		i, ok := index[k]
+		if !ok {
+			i = len(rows)
+			index[k] = i
+			rows = append(rows, domain.AgingSnapshotRow{TenorMonths: k.tenor, Bucket: k.bucket, Outstanding: decimal.Zero})
+		}
+		rows[i].ContractCount++
+		rows[i].Outstanding = rows[i].Outstanding.Add(outstanding)
+	}
+
+	for i := range rows {
+		rows[i].Outstanding = money.Round(rows[i].Outstanding)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].TenorMonths != rows[j].TenorMonths {
+			return rows[i].TenorMonths < rows[j].TenorMonths
+		}
+		return agingBucketRank(rows[i].Bucket) < agingBucketRank(rows[j].Bucket)
+	})
+
+	return rows
+}
+
+// exposureBalance returns the unpaid installment balance of exposure in IDR
+// and the band it falls into at asOf under rules. It reports false for an
+// exposure without a tenor.
+func exposureBalance(exposure domain.AgingExposure, rules installment.Rules, asOf time.Time) (decimal.Decimal, domain.AgingBucket, bool) {
+	months := int(exposure.TenorMonths)
+	if months == 0 {
+		return decimal.Zero, "", false
+	}
+
+	paid := min(rules.Elapsed(exposure.TransactionDate, asOf), months)
+	if exposure.PaidInstallments != nil {
+		paid = min(max(*exposure.PaidInstallments, 0), months)
+	}
+
+	outstanding := decimal.Zero
+	for _, inst := range rules.Schedule(exposure.TransactionDate, 1, months, exposure.TotalInstallmentAmount) {
+		if inst.Sequence > paid {
+			outstanding = outstanding.Add(inst.Amount)
+		}
+	}
+
+	bucket := domain.AgingBucketOf(rules.DaysPastDue(exposure.TransactionDate, paid, months, asOf))
+	return outstanding.Mul(exposure.FxRate), bucket, true
+}
+
+// agingReport lays the rows out with every bucket present, including empty
+// ones, so consumers can chart the report without filling gaps.
+func agingReport(asOf time.Time, source string, rows []domain.AgingSnapshotRow) *dto.AgingReportResponse {
+	report := &dto.AgingReportResponse{
+		AsOf:             asOf.Format("2006-01-02"),
+		Source:           source,
+		Currency:         currency.IDR,
+		Portfolio:        emptyAgingBuckets(),
+		ByTenor:          []dto.AgingTenorResponse{},
+		TotalOutstanding: decimal.Zero,
+	}
+
+	tenorIndex := make(map[uint8]int)
+	for _, row := range rows {
+		i, ok := tenorIndex[row.TenorMonths]
+		if !ok {
+			i = len(report.ByTenor)
+			tenorIndex[row.TenorMonths] = i
+			report.ByTenor = append(report.ByTenor, dto.AgingTenorResponse{TenorMonths: row.TenorMonths, Buckets: emptyAgingBuckets()})
+		}
+
+		rank := agingBucketRank(row.Bucket)
+		if rank < 0 {
+			continue
+		}
+
+		tenorBucket := &report.ByTenor[i].Buckets[rank]
+		tenorBucket.ContractCount += row.ContractCount
+		tenorBucket.Outstanding = tenorBucket.Outstanding.Add(row.Outstanding)
+
+		portfolioBucket := &report.Portfolio[rank]
+		portfolioBucket.ContractCount += row.ContractCount
+		portfolioBucket.Outstanding = portfolioBucket.Outstanding.Add(row.Outstanding)
+
+		report.ContractCount += row.ContractCount
+		report.TotalOutstanding = report.TotalOutstanding.Add(row.Outstanding)
+	}
+
+	return report
+}
+
+func emptyAgingBuckets() []dto.AgingBucketResponse {
+	buckets := make([]dto.AgingBucketResponse, len(domain.AgingBuckets))
+	for i, bucket := range domain.AgingBuckets {
+		buckets[i] = dto.AgingBucketResponse{Bucket: string(bucket), Outstanding: decimal.Zero}
+	}
+	return buckets
+}
+
+func agingBucketRank(bucket domain.AgingBucket) int {
+	for i, b := range domain.AgingBuckets {
+		if b == bucket {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,171 @@
+package reportsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// unassignedRegion labels contracts booked without a region.
+const unassignedRegion = "Unassigned"
+
+// RegionPerformance implements ReportServices.
+func (s *reportService) RegionPerformance(ctx context.Context, month string) (*dto.RegionReportResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RegionPerformance")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("report.month", month))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "region_performance"), attribute.String("service", "report")))
+
+	period, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "region_performance", "invalid_month", fmt.Errorf("%w: %s", common.ErrInvalidReportMonth, month))
+	}
+
+	regions, err := s.regionRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "region_performance", "repository_error", fmt.Errorf("failed to find regions: %w", err))
+	}
+
+	volumes, err := s.reportRepository.RegionVolumes(ctx, period)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "region_performance", "repository_error", fmt.Errorf("failed to compute region volumes: %w", err))
+	}
+
+	exposures, err := s.reportRepository.AgingExposures(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "region_performance", "repository_error", fmt.Errorf("failed to load aging exposures: %w", err))
+	}
+
+	asOf := time.Now()
+	report := regionReport(period, asOf, s.dueDateRules.Rules(ctx), regions, volumes, exposures)
+
+	s.recordSuccess(ctx, span, start, "region_performance",
+		zap.String("month", report.Month),
+		zap.Int("regions", len(report.Rows)),
+		zap.Stringer("delinquency_rate", report.DelinquencyRate),
+	)
+
+	return report, nil
+}
+
+// regionReport lists every region of the reference table, including inactive
+// and empty ones, followed by codes no longer in the table and finally the
+// contracts without a region, so dashboards keep a stable layout.
+func regionReport(period, asOf time.Time, rules installment.Rules, regions []domain.Region, volumes []domain.RegionVolume, exposures []domain.AgingExposure) *dto.RegionReportResponse {
+	report := &dto.RegionReportResponse{
+		Month:                 period.Format("2006-01"),
+		AsOf:                  asOf.Format("2006-01-02"),
+		Currency:              currency.IDR,
+		Rows:                  make([]dto.RegionReportRowResponse, 0, len(regions)+1),
+		TotalOTRAmount:        decimal.Zero,
+		TotalOutstanding:      decimal.Zero,
+		DelinquentOutstanding: decimal.Zero,
+		DelinquencyRate:       decimal.Zero,
+	}
+
+	index := make(map[string]int)
+	row := func(code string) *dto.RegionReportRowResponse {
+		i, ok := index[code]
+		if !ok {
+			i = len(report.Rows)
+			index[code] = i
+			report.Rows = append(report.Rows, dto.RegionReportRowResponse{
+				RegionCode:            code,
+				OTRAmount:             decimal.Zero,
+				Outstanding:           decimal.Zero,
+				DelinquentOutstanding: decimal.Zero,
+				DelinquencyRate:       decimal.Zero,
+			})
+		}
+		return &report.Rows[i]
+	}
+
+	for _, region := range regions {
+		row(region.Code).RegionName = region.Name
+	}
+
+	// Kontrak tanpa region diproses paling akhir agar barisnya selalu di bawah
+	var unassignedVolume *domain.RegionVolume
+	for i, volume := range volumes {
+		if volume.RegionCode == "" {
+			unassignedVolume = &volumes[i]
+			continue
+		}
+		r := row(volume.RegionCode)
+		r.ContractCount += volume.ContractCount
+		r.OTRAmount = r.OTRAmount.Add(volume.OTRAmount)
+	}
+
+	var unassigned []domain.AgingExposure
+	for _, exposure := range exposures {
+		if exposure.RegionCode == "" {
+			unassigned = append(unassigned, exposure)
+			continue
+		}
+		addExposure(row(exposure.RegionCode), exposure, rules, asOf)
+	}
+
+	if unassignedVolume != nil || len(unassigned) > 0 {
+		r := row("")
+		r.RegionName = unassignedRegion
+		if unassignedVolume != nil {
+			r.ContractCount += unassignedVolume.ContractCount
+			r.OTRAmount = r.OTRAmount.Add(unassignedVolume.OTRAmount)
+		}
+		for _, exposure := range unassigned {
+			addExposure(r, exposure, rules, asOf)
+		}
+	}
+
+	for i := range report.Rows {
+		r := &report.Rows[i]
+		r.Outstanding = money.Round(r.Outstanding)
+		r.DelinquentOutstanding = money.Round(r.DelinquentOutstanding)
+		r.DelinquencyRate = delinquencyRate(r.DelinquentOutstanding, r.Outstanding)
+
+		report.ContractCount += r.ContractCount
+		report.TotalOTRAmount = report.TotalOTRAmount.Add(r.OTRAmount)
+		report.TotalOutstanding = report.TotalOutstanding.Add(r.Outstanding)
+		report.DelinquentOutstanding = report.DelinquentOutstanding.Add(r.DelinquentOutstanding)
+	}
+	report.DelinquencyRate = delinquencyRate(report.DelinquentOutstanding, report.TotalOutstanding)
+
+	return report
+}
+
+func addExposure(row *dto.RegionReportRowResponse, exposure domain.AgingExposure, rules installment.Rules, asOf time.Time) {
+	outstanding, bucket, ok := exposureBalance(exposure, rules, asOf)
+	if !ok {
+		return
+	}
+
+	row.ActiveContracts++
+	row.Outstanding = row.Outstanding.Add(outstanding)
+	if bucket != domain.AgingCurrent {
+		row.DelinquentContracts++
+		row.DelinquentOutstanding = row.DelinquentOutstanding.Add(outstanding)
+	}
+}
+
+// delinquencyRate is the share of the outstanding balance that is past due,
+// rounded to four decimals.
+func delinquencyRate(delinquent, outstanding decimal.Decimal) decimal.Decimal {
+	if !outstanding.IsPositive() {
+		return decimal.Zero
+	}
+	return delinquent.DivRound(outstanding, 4)
+}
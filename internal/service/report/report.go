@@ -0,0 +1,279 @@
+package reportsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/currency"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type reportService struct {
+	reportRepository repository.ReportRepository
+	regionRepository repository.RegionRepository
+	dueDateRules     service.DueDateRules
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// InterestAccrual implements ReportServices.
+func (s *reportService) InterestAccrual(ctx context.Context, month string) (*dto.InterestAccrualReportResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.InterestAccrual")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("report.month", month))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "interest_accrual"), attribute.String("service", "report")))
+
+	period, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "interest_accrual", "invalid_month", fmt.Errorf("%w: %s", common.ErrInvalidReportMonth, month))
+	}
+
+	accruals, err := s.reportRepository.InterestAccrual(ctx, period)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "interest_accrual", "repository_error", fmt.Errorf("failed to compute interest accrual: %w", err))
+	}
+
+	report := &dto.InterestAccrualReportResponse{
+		Month:         period.Format("2006-01"),
+		Currency:      currency.IDR,
+		Rows:          make([]dto.InterestAccrualRowResponse, 0, len(accruals)),
+		ByTenor:       []dto.InterestAccrualTenorResponse{},
+		BySegment:     []dto.InterestAccrualSegmentResponse{},
+		TotalInterest: decimal.Zero,
+	}
+
+	// Baris dari repository sudah urut per tenor lalu segmen, rekap cukup dijumlahkan
+	tenorIndex := make(map[uint8]int)
+	segmentIndex := make(map[string]int)
+	for _, accrual := range accruals {
+		segment := string(accrual.Segment)
+		report.Rows = append(report.Rows, dto.InterestAccrualRowResponse{
+			TenorMonths:     accrual.TenorMonths,
+			Segment:         segment,
+			ContractCount:   accrual.ContractCount,
+			AccruedInterest: accrual.AccruedInterest,
+		})
+
+		i, ok := tenorIndex[accrual.TenorMonths]
+		if !ok {
+			i = len(report.ByTenor)
+			tenorIndex[accrual.TenorMonths] = i
+			report.ByTenor = append(report.ByTenor, dto.InterestAccrualTenorResponse{TenorMonths: accrual.TenorMonths, AccruedInterest: decimal.Zero})
+		}
+		report.ByTenor[i].ContractCount += accrual.ContractCount
+		report.ByTenor[i].AccruedInterest = report.ByTenor[i].AccruedInterest.Add(accrual.AccruedInterest)
+
+		j, ok := segmentIndex[segment]
+		if !ok {
+			j = len(report.BySegment)
+			segmentIndex[segment] = j
+			report.BySegment = append(report.BySegment, dto.InterestAccrualSegmentResponse{Segment: segment, AccruedInterest: decimal.Zero})
+		}
+		report.BySegment[j].ContractCount += accrual.ContractCount
+		report.BySegment[j].AccruedInterest = report.BySegment[j].AccruedInterest.Add(accrual.AccruedInterest)
+
+		report.ContractCount += accrual.ContractCount
+		report.TotalInterest = report.TotalInterest.Add(accrual.AccruedInterest)
+	}
+
+	s.recordSuccess(ctx, span, start, "interest_accrual",
+		zap.String("month", report.Month),
+		zap.Int64("contract_count", report.ContractCount),
+		zap.Stringer("total_interest", report.TotalInterest),
+	)
+
+	return report, nil
+}
+
+// PartnerCommission implements ReportServices.
+func (s *reportService) PartnerCommission(ctx context.Context, month string) (*dto.PartnerCommissionReportResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.PartnerCommission")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("report.month", month))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "partner_commission"), attribute.String("service", "report")))
+
+	period, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "partner_commission", "invalid_month", fmt.Errorf("%w: %s", common.ErrInvalidReportMonth, month))
+	}
+
+	commissions, err := s.reportRepository.PartnerCommissions(ctx, period)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "partner_commission", "repository_error", fmt.Errorf("failed to compute partner commissions: %w", err))
+	}
+
+	report := &dto.PartnerCommissionReportResponse{
+		Month:           period.Format("2006-01"),
+		Currency:        currency.IDR,
+		Rows:            make([]dto.PartnerCommissionRowResponse, 0, len(commissions)),
+		TotalOTRAmount:  decimal.Zero,
+		TotalAdminFee:   decimal.Zero,
+		TotalCommission: decimal.Zero,
+	}
+	for _, commission := range commissions {
+		report.Rows = append(report.Rows, dto.PartnerCommissionRowResponse{
+			PartnerID:     commission.PartnerID,
+			PartnerName:   commission.PartnerName,
+			ContractCount: commission.ContractCount,
+			OTRAmount:     commission.OTRAmount,
+			AdminFee:      commission.AdminFee,
+			Commission:    commission.Commission,
+		})
+
+		report.ContractCount += commission.ContractCount
+		report.TotalOTRAmount = report.TotalOTRAmount.Add(commission.OTRAmount)
+		report.TotalAdminFee = report.TotalAdminFee.Add(commission.AdminFee)
+		report.TotalCommission = report.TotalCommission.Add(commission.Commission)
+	}
+
+	s.recordSuccess(ctx, span, start, "partner_commission",
+		zap.String("month", report.Month),
+		zap.Int("partners", len(report.Rows)),
+		zap.Stringer("total_commission", report.TotalCommission),
+	)
+
+	return report, nil
+}
+
+// ReferralConversion implements ReportServices.
+func (s *reportService) ReferralConversion(ctx context.Context, month string) (*dto.ReferralConversionReportResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ReferralConversion")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("report.month", month))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "referral_conversion"), attribute.String("service", "report")))
+
+	period, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "referral_conversion", "invalid_month", fmt.Errorf("%w: %s", common.ErrInvalidReportMonth, month))
+	}
+
+	conversions, err := s.reportRepository.ReferralConversions(ctx, period)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "referral_conversion", "repository_error", fmt.Errorf("failed to compute referral conversions: %w", err))
+	}
+
+	report := &dto.ReferralConversionReportResponse{
+		Month: period.Format("2006-01"),
+		Rows:  make([]dto.ReferralConversionRowResponse, 0, len(conversions)),
+	}
+	for _, conversion := range conversions {
+		rate := decimal.Zero
+		if accepted := conversion.Referred - conversion.Rejected; accepted > 0 {
+			rate = decimal.NewFromInt(conversion.Converted).DivRound(decimal.NewFromInt(accepted), 4)
+		}
+
+		report.Rows = append(report.Rows, dto.ReferralConversionRowResponse{
+			ReferrerID:     conversion.ReferrerID,
+			ReferrerName:   conversion.ReferrerName,
+			Code:           conversion.Code,
+			Referred:       conversion.Referred,
+			Rejected:       conversion.Rejected,
+			Converted:      conversion.Converted,
+			ConversionRate: rate,
+		})
+
+		report.Referred += conversion.Referred
+		report.Rejected += conversion.Rejected
+		report.Converted += conversion.Converted
+	}
+
+	s.recordSuccess(ctx, span, start, "referral_conversion",
+		zap.String("month", report.Month),
+		zap.Int("referrers", len(report.Rows)),
+		zap.Int64("converted", report.Converted),
+	)
+
+	return report, nil
+}
+
+func (s *reportService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Report operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "report"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "report"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *reportService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "report"), attribute.String("status", "success")))
+
+	s.log.Info("Report operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewReportService(
+	reportRepository repository.ReportRepository,
+	regionRepository repository.RegionRepository,
+	dueDateRules service.DueDateRules,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.ReportServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &reportService{
+		reportRepository:  reportRepository,
+		regionRepository:  regionRepository,
+		dueDateRules:      dueDateRules,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+	}
+}
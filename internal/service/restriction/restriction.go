@@ -0,0 +1,532 @@
+package restrictionsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
+	restrictionrepo "github.com/fazamuttaqien/multifinance/internal/repository/restriction"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// expiryBatchSize bounds how many restrictions one ExpireDue run lifts, the
+// rest are picked up by the next run.
+const expiryBatchSize = 100
+
+type restrictionService struct {
+	db                    *gorm.DB
+	customerRepository    repository.CustomerRepository
+	tenorRepository       repository.TenorRepository
+	restrictionRepository repository.CustomerRestrictionRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration   metric.Float64Histogram
+	operationCount      metric.Int64Counter
+	errorCount          metric.Int64Counter
+	restrictionsApplied metric.Int64Counter
+	restrictionsLifted  metric.Int64Counter
+}
+
+// Suspend implements CustomerRestrictionServices.
+func (s *restrictionService) Suspend(ctx context.Context, customerID, adminID uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SuspendCustomer")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int64("admin.id", int64(adminID)),
+		attribute.String("restriction.reason", string(req.Reason)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "suspend_customer"), attribute.String("service", "restriction")))
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, s.recordError(ctx, span, start, "suspend_customer", "invalid_expiry", common.ErrInvalidRestrictionExpiry)
+	}
+
+	customer, err := s.findCustomer(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "suspend_customer", "customer_lookup_error", err)
+	}
+
+	restriction := newRestriction(customerID, adminID, req)
+	if err := s.apply(ctx, customer, restriction, now); err != nil {
+		if errors.Is(err, common.ErrRestrictionExists) {
+			return nil, s.recordError(ctx, span, start, "suspend_customer", "restriction_exists", err)
+		}
+		return nil, s.recordError(ctx, span, start, "suspend_customer", "repository_error", fmt.Errorf("failed to suspend customer: %w", err))
+	}
+
+	s.restrictionsApplied.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "restriction"), attribute.String("scope", "account"), attribute.String("reason", string(req.Reason))))
+	s.recordSuccess(ctx, span, start, "suspend_customer",
+		zap.Uint64("restriction_id", restriction.ID),
+		zap.Uint64("customer_id", customerID),
+		zap.Uint64("admin_id", adminID),
+		zap.String("reason", string(req.Reason)),
+	)
+
+	return restriction, nil
+}
+
+// Unsuspend implements CustomerRestrictionServices.
+func (s *restrictionService) Unsuspend(ctx context.Context, customerID, adminID uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.UnsuspendCustomer")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int64("admin.id", int64(adminID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "unsuspend_customer"), attribute.String("service", "restriction")))
+
+	customer, err := s.findCustomer(ctx, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "unsuspend_customer", "customer_lookup_error", err)
+	}
+
+	if err := s.lift(ctx, customer, nil, adminID, time.Now()); err != nil {
+		if errors.Is(err, common.ErrRestrictionNotFound) {
+			return s.recordError(ctx, span, start, "unsuspend_customer", "not_found", err)
+		}
+		return s.recordError(ctx, span, start, "unsuspend_customer", "repository_error", fmt.Errorf("failed to unsuspend customer: %w", err))
+	}
+
+	s.restrictionsLifted.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "restriction"), attribute.String("scope", "account"), attribute.Bool("expired", false)))
+	s.recordSuccess(ctx, span, start, "unsuspend_customer",
+		zap.Uint64("customer_id", customerID),
+		zap.Uint64("admin_id", adminID),
+	)
+
+	return nil
+}
+
+// FreezeLimit implements CustomerRestrictionServices.
+func (s *restrictionService) FreezeLimit(ctx context.Context, customerID uint64, tenorMonths uint8, adminID uint64, req dto.RestrictionRequest) (*domain.CustomerRestriction, error) {
+	ctx, span := s.tracer.Start(ctx, "service.FreezeCustomerLimit")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int("tenor.months", int(tenorMonths)),
+		attribute.Int64("admin.id", int64(adminID)),
+		attribute.String("restriction.reason", string(req.Reason)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "freeze_limit"), attribute.String("service", "restriction")))
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, s.recordError(ctx, span, start, "freeze_limit", "invalid_expiry", common.ErrInvalidRestrictionExpiry)
+	}
+
+	customer, err := s.findCustomer(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "freeze_limit", "customer_lookup_error", err)
+	}
+
+	tenor, err := s.findTenor(ctx, tenorMonths)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "freeze_limit", "tenor_lookup_error", err)
+	}
+
+	restriction := newRestriction(customerID, adminID, req)
+	restriction.TenorID = &tenor.ID
+	restriction.TenorMonths = tenor.DurationMonths
+	if err := s.apply(ctx, customer, restriction, now); err != nil {
+		if errors.Is(err, common.ErrRestrictionExists) {
+			return nil, s.recordError(ctx, span, start, "freeze_limit", "restriction_exists", err)
+		}
+		return nil, s.recordError(ctx, span, start, "freeze_limit", "repository_error", fmt.Errorf("failed to freeze limit: %w", err))
+	}
+
+	s.restrictionsApplied.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "restriction"), attribute.String("scope", "tenor"), attribute.String("reason", string(req.Reason))))
+	s.recordSuccess(ctx, span, start, "freeze_limit",
+		zap.Uint64("restriction_id", restriction.ID),
+		zap.Uint64("customer_id", customerID),
+		zap.Uint8("tenor_months", tenorMonths),
+		zap.Uint64("admin_id", adminID),
+		zap.String("reason", string(req.Reason)),
+	)
+
+	return restriction, nil
+}
+
+// UnfreezeLimit implements CustomerRestrictionServices.
+func (s *restrictionService) UnfreezeLimit(ctx context.Context, customerID uint64, tenorMonths uint8, adminID uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.UnfreezeCustomerLimit")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Int("tenor.months", int(tenorMonths)),
+		attribute.Int64("admin.id", int64(adminID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "unfreeze_limit"), attribute.String("service", "restriction")))
+
+	customer, err := s.findCustomer(ctx, customerID)
+	if err != nil {
+		return s.recordError(ctx, span, start, "unfreeze_limit", "customer_lookup_error", err)
+	}
+
+	tenor, err := s.findTenor(ctx, tenorMonths)
+	if err != nil {
+		return s.recordError(ctx, span, start, "unfreeze_limit", "tenor_lookup_error", err)
+	}
+
+	if err := s.lift(ctx, customer, &tenor.ID, adminID, time.Now()); err != nil {
+		if errors.Is(err, common.ErrRestrictionNotFound) {
+			return s.recordError(ctx, span, start, "unfreeze_limit", "not_found", err)
+		}
+		return s.recordError(ctx, span, start, "unfreeze_limit", "repository_error", fmt.Errorf("failed to unfreeze limit: %w", err))
+	}
+
+	s.restrictionsLifted.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "restriction"), attribute.String("scope", "tenor"), attribute.Bool("expired", false)))
+	s.recordSuccess(ctx, span, start, "unfreeze_limit",
+		zap.Uint64("customer_id", customerID),
+		zap.Uint8("tenor_months", tenorMonths),
+		zap.Uint64("admin_id", adminID),
+	)
+
+	return nil
+}
+
+// ListRestrictions implements CustomerRestrictionServices.
+func (s *restrictionService) ListRestrictions(ctx context.Context, customerID uint64) ([]domain.CustomerRestriction, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListCustomerRestrictions")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_restrictions"), attribute.String("service", "restriction")))
+
+	if _, err := s.findCustomer(ctx, customerID); err != nil {
+		return nil, s.recordError(ctx, span, start, "list_restrictions", "customer_lookup_error", err)
+	}
+
+	restrictions, err := s.restrictionRepository.FindByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_restrictions", "repository_error", fmt.Errorf("failed to find customer restrictions: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_restrictions",
+		zap.Uint64("customer_id", customerID),
+		zap.Int("count", len(restrictions)),
+	)
+
+	return restrictions, nil
+}
+
+// ExpireDue implements CustomerRestrictionServices. Checks at transaction
+// time already ignore expired restrictions, this only records the lift on
+// the restriction and the customer's timeline.
+func (s *restrictionService) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ExpireDueRestrictions")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "expire_restrictions"), attribute.String("service", "restriction")))
+
+	expired, err := s.restrictionRepository.FindExpired(ctx, now, expiryBatchSize)
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "expire_restrictions", "repository_error", fmt.Errorf("failed to find expired restrictions: %w", err))
+	}
+
+	lifted := 0
+	for _, restriction := range expired {
+		ok := false
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			restrictionTx := restrictionrepo.NewCustomerRestrictionRepository(tx, s.meter, s.log)
+			// Waktu cabut diisi waktu kedaluwarsa, bukan waktu job berjalan
+			updated, err := restrictionTx.Lift(ctx, restriction.ID, nil, *restriction.ExpiresAt)
+			if err != nil || !updated {
+				return err
+			}
+			ok = true
+
+			event := liftEvent(restriction, nil)
+			event.Data["expired"] = "true"
+			return appendEvent(ctx, tx, s, event)
+		})
+		if err != nil {
+			return lifted, s.recordError(ctx, span, start, "expire_restrictions", "repository_error", fmt.Errorf("failed to lift expired restriction %d: %w", restriction.ID, err))
+		}
+		// Sudah dicabut admin di antara pencarian dan update
+		if !ok {
+			continue
+		}
+
+		lifted++
+		s.restrictionsLifted.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "restriction"), attribute.String("scope", scope(restriction)), attribute.Bool("expired", true)))
+	}
+
+	span.SetAttributes(attribute.Int("restrictions.lifted", lifted))
+	s.recordSuccess(ctx, span, start, "expire_restrictions",
+		zap.Int("found", len(expired)),
+		zap.Int("lifted", lifted),
+	)
+
+	return lifted, nil
+}
+
+// apply stores restriction unless one of the same scope is already active.
+// The customer row stays locked until commit so a transaction being created
+// for the customer finishes before, or sees, the new restriction.
+func (s *restrictionService) apply(ctx context.Context, customer *domain.Customer, restriction *domain.CustomerRestriction, now time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		customerTx := customerrepo.NewCustomerRepository(tx, s.meter, s.log)
+		if _, err := customerTx.FindByNIKWithLock(ctx, customer.NIK); err != nil {
+			return fmt.Errorf("failed to lock customer: %w", err)
+		}
+
+		restrictionTx := restrictionrepo.NewCustomerRestrictionRepository(tx, s.meter, s.log)
+		active, err := restrictionTx.FindActive(ctx, customer.ID, now)
+		if err != nil {
+			return err
+		}
+		if sameScope(active, restriction.TenorID) != nil {
+			return common.ErrRestrictionExists
+		}
+
+		if err := restrictionTx.Create(ctx, restriction); err != nil {
+			return err
+		}
+
+		eventType := domain.CustomerSuspended
+		if !restriction.IsSuspension() {
+			eventType = domain.CustomerLimitFrozen
+		}
+		data := map[string]string{"reason": string(restriction.Reason)}
+		if restriction.Note != "" {
+			data["note"] = restriction.Note
+		}
+		if restriction.ExpiresAt != nil {
+			data["expires_at"] = restriction.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		if restriction.TenorMonths > 0 {
+			data["tenor_months"] = strconv.Itoa(int(restriction.TenorMonths))
+		}
+
+		return appendEvent(ctx, tx, s, &domain.CustomerEvent{
+			CustomerID:  customer.ID,
+			Type:        eventType,
+			ActorID:     &restriction.CreatedBy,
+			ReferenceID: strconv.FormatUint(restriction.ID, 10),
+			Data:        data,
+		})
+	})
+}
+
+// lift ends the active restriction of the given scope, a nil tenorID being
+// the account suspension.
+func (s *restrictionService) lift(ctx context.Context, customer *domain.Customer, tenorID *uint, adminID uint64, now time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		customerTx := customerrepo.NewCustomerRepository(tx, s.meter, s.log)
+		if _, err := customerTx.FindByNIKWithLock(ctx, customer.NIK); err != nil {
+			return fmt.Errorf("failed to lock customer: %w", err)
+		}
+
+		restrictionTx := restrictionrepo.NewCustomerRestrictionRepository(tx, s.meter, s.log)
+		active, err := restrictionTx.FindActive(ctx, customer.ID, now)
+		if err != nil {
+			return err
+		}
+		restriction := sameScope(active, tenorID)
+		if restriction == nil {
+			return common.ErrRestrictionNotFound
+		}
+
+		lifted, err := restrictionTx.Lift(ctx, restriction.ID, &adminID, now)
+		if err != nil {
+			return err
+		}
+		if !lifted {
+			return common.ErrRestrictionNotFound
+		}
+
+		return appendEvent(ctx, tx, s, liftEvent(*restriction, &adminID))
+	})
+}
+
+func (s *restrictionService) findCustomer(ctx context.Context, customerID uint64) (*domain.Customer, error) {
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find customer: %w", err)
+	}
+	if customer == nil {
+		return nil, common.ErrCustomerNotFound
+	}
+	return customer, nil
+}
+
+func (s *restrictionService) findTenor(ctx context.Context, tenorMonths uint8) (*domain.Tenor, error) {
+	tenor, err := s.tenorRepository.FindByDuration(ctx, tenorMonths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tenor: %w", err)
+	}
+	if tenor == nil {
+		return nil, common.ErrTenorNotFound
+	}
+	return tenor, nil
+}
+
+func (s *restrictionService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Customer restriction operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "restriction"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "restriction"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *restrictionService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "restriction"), attribute.String("status", "success")))
+
+	s.log.Info("Customer restriction operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func newRestriction(customerID, adminID uint64, req dto.RestrictionRequest) *domain.CustomerRestriction {
+	return &domain.CustomerRestriction{
+		CustomerID: customerID,
+		Reason:     req.Reason,
+		Note:       strings.TrimSpace(req.Note),
+		CreatedBy:  adminID,
+		ExpiresAt:  req.ExpiresAt,
+	}
+}
+
+// sameScope returns the restriction in active that covers exactly tenorID,
+// a nil tenorID matching the account suspension only.
+func sameScope(active []domain.CustomerRestriction, tenorID *uint) *domain.CustomerRestriction {
+	for i := range active {
+		r := &active[i]
+		if tenorID == nil && r.TenorID == nil {
+			return r
+		}
+		if tenorID != nil && r.TenorID != nil && *r.TenorID == *tenorID {
+			return r
+		}
+	}
+	return nil
+}
+
+func scope(restriction domain.CustomerRestriction) string {
+	if restriction.IsSuspension() {
+		return "account"
+	}
+	return "tenor"
+}
+
+func liftEvent(restriction domain.CustomerRestriction, actorID *uint64) *domain.CustomerEvent {
+	eventType := domain.CustomerUnsuspended
+	data := map[string]string{"reason": string(restriction.Reason)}
+	if !restriction.IsSuspension() {
+		eventType = domain.CustomerLimitUnfrozen
+		data["tenor_months"] = strconv.Itoa(int(restriction.TenorMonths))
+	}
+
+	return &domain.CustomerEvent{
+		CustomerID:  restriction.CustomerID,
+		Type:        eventType,
+		ActorID:     actorID,
+		ReferenceID: strconv.FormatUint(restriction.ID, 10),
+		Data:        data,
+	}
+}
+
+// appendEvent writes a timeline event on tx so it commits, or rolls back,
+// together with the restriction change it describes.
+func appendEvent(ctx context.Context, tx *gorm.DB, s *restrictionService, event *domain.CustomerEvent) error {
+	return customereventrepo.NewCustomerEventRepository(tx, s.meter, s.log).Append(ctx, event)
+}
+
+func NewCustomerRestrictionService(
+	db *gorm.DB,
+	customerRepository repository.CustomerRepository,
+	tenorRepository repository.TenorRepository,
+	restrictionRepository repository.CustomerRestrictionRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.CustomerRestrictionServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	restrictionsApplied, _ := meter.Int64Counter(
+		"service.restrictions.applied",
+		metric.WithDescription("Number of customer suspensions and limit freezes applied"),
+		metric.WithUnit("{restriction}"),
+	)
+
+	restrictionsLifted, _ := meter.Int64Counter(
+		"service.restrictions.lifted",
+		metric.WithDescription("Number of customer suspensions and limit freezes lifted"),
+		metric.WithUnit("{restriction}"),
+	)
+
+	return &restrictionService{
+		db:                    db,
+		customerRepository:    customerRepository,
+		tenorRepository:       tenorRepository,
+		restrictionRepository: restrictionRepository,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+		restrictionsApplied:   restrictionsApplied,
+		restrictionsLifted:    restrictionsLifted,
+	}
+}
@@ -0,0 +1,349 @@
+package restructuringsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
+	restructuringrepo "github.com/fazamuttaqien/multifinance/internal/repository/restructuring"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type restructuringService struct {
+	db                      *gorm.DB
+	transactionRepository   repository.TransactionRepository
+	tenorRepository         repository.TenorRepository
+	restructuringRepository repository.RestructuringRepository
+	dueDateRules            service.DueDateRules
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration      metric.Float64Histogram
+	operationCount         metric.Int64Counter
+	errorCount             metric.Int64Counter
+	restructuringsReviewed metric.Int64Counter
+}
+
+// Propose implements RestructuringServices.
+func (s *restructuringService) Propose(ctx context.Context, makerID uint64, req dto.RestructuringRequest) (*dto.RestructuringResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ProposeRestructuring")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("transaction.contract_number", req.ContractNumber),
+		attribute.String("restructuring.type", string(req.Type)),
+		attribute.Int64("maker.id", int64(makerID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "propose_restructuring"), attribute.String("service", "restructuring")))
+
+	transaction, err := s.transactionRepository.FindByContractNumber(ctx, req.ContractNumber)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "repository_error", fmt.Errorf("failed to get transaction: %w", err))
+	}
+	if transaction == nil || transaction.IsSandbox {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "transaction_not_found", common.ErrTransactionNotFound)
+	}
+	if transaction.Status != domain.TransactionActive {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "transaction_not_active", common.ErrTransactionNotActive)
+	}
+
+	// Satu pengajuan restrukturisasi per transaksi sampai direview
+	pending, err := s.restructuringRepository.FindPendingByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "repository_error", fmt.Errorf("failed to check pending restructuring: %w", err))
+	}
+	if pending != nil {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "restructuring_pending", common.ErrRestructureExists)
+	}
+
+	tenors, err := s.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "repository_error", fmt.Errorf("failed to get tenors: %w", err))
+	}
+
+	var original *domain.Tenor
+	for i := range tenors {
+		if tenors[i].ID == transaction.TenorID {
+			original = &tenors[i]
+			break
+		}
+	}
+	if original == nil {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "tenor_not_found", common.ErrTenorNotFound)
+	}
+
+	paid := paidInstallments(transaction, s.dueDateRules.Rules(ctx), time.Now())
+	if paid >= original.DurationMonths {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "transaction_not_active", common.ErrTransactionNotActive)
+	}
+
+	restructuring := &domain.Restructuring{
+		TransactionID:              transaction.ID,
+		CustomerID:                 transaction.CustomerID,
+		Type:                       req.Type,
+		Status:                     domain.RestructuringPending,
+		ContractDate:               transaction.TransactionDate,
+		PaidInstallments:           paid,
+		OriginalTenorID:            original.ID,
+		OriginalTenorMonths:        original.DurationMonths,
+		OriginalTotalInterest:      transaction.TotalInterest,
+		OriginalTotalInstallment:   transaction.TotalInstallmentAmount,
+		OriginalMonthlyInstallment: transaction.TotalInstallmentAmount.DivRound(decimal.NewFromInt(int64(original.DurationMonths)), money.Scale),
+		Reason:                     req.Reason,
+		RequestedBy:                makerID,
+	}
+
+	tenor := pickTenor(restructuring, transaction, tenors, req)
+	if tenor == nil {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "no_feasible_tenor", common.ErrNoFeasibleTenor)
+	}
+	restructure(restructuring, transaction, *tenor)
+
+	if err := s.restructuringRepository.Create(ctx, restructuring); err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_restructuring", "repository_error", fmt.Errorf("failed to create restructuring: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "propose_restructuring",
+		zap.Uint64("restructuring_id", restructuring.ID),
+		zap.Uint64("transaction_id", transaction.ID),
+		zap.Uint8("new_tenor_months", restructuring.NewTenorMonths),
+	)
+
+	return &dto.RestructuringResponse{Restructuring: restructuring, Schedule: schedule(restructuring, s.dueDateRules.Rules(ctx))}, nil
+}
+
+// Review implements RestructuringServices.
+func (s *restructuringService) Review(ctx context.Context, id, checkerID uint64, req dto.RestructuringReviewRequest) (*dto.RestructuringResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ReviewRestructuring")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("restructuring.id", int64(id)),
+		attribute.Int64("checker.id", int64(checkerID)),
+		attribute.String("restructuring.decision", string(req.Status)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "review_restructuring"), attribute.String("service", "restructuring")))
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, s.recordError(ctx, span, start, "review_restructuring", "transaction_begin_error", tx.Error)
+	}
+	defer tx.Rollback()
+
+	restructuringTx := restructuringrepo.NewRestructuringRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+
+	// 1. Kunci pengajuan agar tidak direview dua kali
+	restructuring, err := restructuringTx.FindByIDWithLock(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "review_restructuring", "repository_error", fmt.Errorf("failed to get restructuring: %w", err))
+	}
+	if restructuring == nil {
+		return nil, s.recordError(ctx, span, start, "review_restructuring", "restructuring_not_found", common.ErrRestructureNotFound)
+	}
+	if restructuring.Status != domain.RestructuringPending {
+		return nil, s.recordError(ctx, span, start, "review_restructuring", "already_reviewed", common.ErrRestructureReviewed)
+	}
+
+	// 2. Maker-checker: pengaju tidak boleh menyetujui pengajuannya sendiri
+	if restructuring.RequestedBy == checkerID {
+		return nil, s.recordError(ctx, span, start, "review_restructuring", "self_review", common.ErrRestructureSelf)
+	}
+
+	// 3. Syarat baru baru berlaku di transaksi setelah disetujui
+	if req.Status == domain.RestructuringApproved {
+		result := tx.Model(&model.Transaction{}).
+			Where("id = ? AND status = ?", restructuring.TransactionID, model.TransactionActive).
+			Updates(map[string]any{
+				"tenor_id":                 restructuring.NewTenorID,
+				"total_interest":           restructuring.NewTotalInterest,
+				"total_installment_amount": restructuring.NewTotalInstallment,
+			})
+		if result.Error != nil {
+			return nil, s.recordError(ctx, span, start, "review_restructuring", "update_transaction_error", fmt.Errorf("failed to update transaction terms: %w", result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return nil, s.recordError(ctx, span, start, "review_restructuring", "transaction_not_active", common.ErrTransactionNotActive)
+		}
+
+		// Pokok pindah ke tenor baru, ringkasan exposure nasabah dihitung ulang
+		exposureTx := exposurerepo.NewCustomerExposureRepository(
+			tx,
+			otel.GetMeterProvider().Meter(""),
+			zap.L(),
+		)
+		if err := exposureTx.Recompute(ctx, restructuring.CustomerID); err != nil {
+			return nil, s.recordError(ctx, span, start, "review_restructuring", "exposure_update_error", fmt.Errorf("failed to recompute customer exposure: %w", err))
+		}
+	}
+
+	now := time.Now()
+	restructuring.Status = req.Status
+	restructuring.ReviewerID = &checkerID
+	restructuring.ReviewNote = req.Note
+	restructuring.ReviewedAt = &now
+
+	if err := restructuringTx.Update(ctx, restructuring); err != nil {
+		return nil, s.recordError(ctx, span, start, "review_restructuring", "repository_error", fmt.Errorf("failed to update restructuring: %w", err))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "review_restructuring", "transaction_commit_error", err)
+	}
+
+	s.restructuringsReviewed.Add(ctx, 1, metric.WithAttributes(attribute.String("decision", string(req.Status))))
+	s.recordSuccess(ctx, span, start, "review_restructuring",
+		zap.Uint64("restructuring_id", id),
+		zap.String("decision", string(req.Status)),
+	)
+
+	return &dto.RestructuringResponse{Restructuring: restructuring, Schedule: schedule(restructuring, s.dueDateRules.Rules(ctx))}, nil
+}
+
+// GetRestructuring implements RestructuringServices.
+func (s *restructuringService) GetRestructuring(ctx context.Context, id uint64) (*dto.RestructuringResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetRestructuring")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("restructuring.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_restructuring"), attribute.String("service", "restructuring")))
+
+	restructuring, err := s.restructuringRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_restructuring", "repository_error", fmt.Errorf("failed to get restructuring: %w", err))
+	}
+	if restructuring == nil {
+		return nil, s.recordError(ctx, span, start, "get_restructuring", "restructuring_not_found", common.ErrRestructureNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_restructuring", zap.Uint64("restructuring_id", id))
+
+	return &dto.RestructuringResponse{Restructuring: restructuring, Schedule: schedule(restructuring, s.dueDateRules.Rules(ctx))}, nil
+}
+
+// ListRestructurings implements RestructuringServices.
+func (s *restructuringService) ListRestructurings(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListRestructurings")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.status", params.Value("status")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_restructurings"), attribute.String("service", "restructuring")))
+
+	restructurings, total, err := s.restructuringRepository.FindPaginated(ctx, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_restructurings", "repository_error", fmt.Errorf("failed to list restructurings: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_restructurings", zap.Int64("total", total))
+
+	return params.Paginated(restructurings, total), nil
+}
+
+func (s *restructuringService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Restructuring operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "restructuring"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "restructuring"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *restructuringService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "restructuring"), attribute.String("status", "success")))
+
+	s.log.Info("Restructuring operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewRestructuringService(
+	db *gorm.DB,
+	transactionRepository repository.TransactionRepository,
+	tenorRepository repository.TenorRepository,
+	restructuringRepository repository.RestructuringRepository,
+	dueDateRules service.DueDateRules,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.RestructuringServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	restructuringsReviewed, _ := meter.Int64Counter(
+		"service.restructurings.reviewed",
+		metric.WithDescription("Number of restructurings reviewed"),
+		metric.WithUnit("{restructuring}"),
+	)
+
+	return &restructuringService{
+		db:                      db,
+		transactionRepository:   transactionRepository,
+		tenorRepository:         tenorRepository,
+		restructuringRepository: restructuringRepository,
+		dueDateRules:            dueDateRules,
+		meter:                   meter,
+		tracer:                  tracer,
+		log:                     log,
+		operationDuration:       operationDuration,
+		operationCount:          operationCount,
+		errorCount:              errorCount,
+		restructuringsReviewed:  restructuringsReviewed,
+	}
+}
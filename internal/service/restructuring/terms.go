@@ -0,0 +1,90 @@
+package restructuringsrv
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/shopspring/decimal"
+)
+
+// paidInstallments menghitung cicilan yang sudah dibayar. Selama pembayaran
+// kontrak belum dicatat, cicilan yang jatuh tempo dianggap sudah dibayar.
+func paidInstallments(tx *domain.Transaction, rules installment.Rules, now time.Time) uint8 {
+	if tx.PaidInstallments != nil {
+		return uint8(min(max(*tx.PaidInstallments, 0), math.MaxUint8))
+	}
+	return uint8(min(rules.Elapsed(tx.TransactionDate, now), math.MaxUint8))
+}
+
+// restructure fills the new terms for the given tenor. Installments already
+// paid keep their original amount; the rest of the balance, including the
+// interest for the longer tenor, is spread over the remaining months.
+func restructure(r *domain.Restructuring, tx *domain.Transaction, tenor domain.Tenor) {
+	// Bunga flat dihitung ulang dengan cara yang sama saat transaksi dibuat
+	totalInterest := money.FlatInterest(tx.OTRAmount, tenor.DurationMonths)
+	totalInstallment := money.Sum(tx.OTRAmount, tx.AdminFee, totalInterest)
+
+	r.NewTenorID = tenor.ID
+	r.NewTenorMonths = tenor.DurationMonths
+	r.NewTotalInterest = totalInterest
+	r.NewTotalInstallment = totalInstallment
+	r.NewMonthlyInstallment = remainingBalance(r, totalInstallment).DivRound(decimal.NewFromInt(int64(tenor.DurationMonths-r.PaidInstallments)), money.Scale)
+}
+
+// remainingBalance is what is left of total after the installments already paid.
+func remainingBalance(r *domain.Restructuring, total decimal.Decimal) decimal.Decimal {
+	return total.Sub(r.OriginalMonthlyInstallment.Mul(decimal.NewFromInt(int64(r.PaidInstallments))))
+}
+
+// pickTenor returns the tenor for the request, or nil when no tenor longer
+// than the original one satisfies it.
+func pickTenor(r *domain.Restructuring, tx *domain.Transaction, tenors []domain.Tenor, req dto.RestructuringRequest) *domain.Tenor {
+	sort.Slice(tenors, func(i, j int) bool {
+		return tenors[i].DurationMonths < tenors[j].DurationMonths
+	})
+
+	for i := range tenors {
+		tenor := tenors[i]
+		if tenor.DurationMonths <= r.OriginalTenorMonths {
+			continue
+		}
+
+		switch req.Type {
+		case domain.RestructuringExtendTenor:
+			if tenor.DurationMonths == req.NewTenorMonths {
+				return &tenor
+			}
+		case domain.RestructuringReduceInstallment:
+			// Tenor terpendek yang cicilannya sudah di bawah target
+			candidate := *r
+			restructure(&candidate, tx, tenor)
+			if candidate.NewMonthlyInstallment.LessThanOrEqual(req.TargetInstallment) {
+				return &tenor
+			}
+		}
+	}
+
+	return nil
+}
+
+// schedule regenerates the installments left after PaidInstallments under
+// the new terms, with due dates adjusted by rules.
+func schedule(r *domain.Restructuring, rules installment.Rules) []dto.InstallmentResponse {
+	installments := rules.Schedule(r.ContractDate, int(r.PaidInstallments)+1, int(r.NewTenorMonths), remainingBalance(r, r.NewTotalInstallment))
+
+	responses := make([]dto.InstallmentResponse, len(installments))
+	for i, inst := range installments {
+		responses[i] = dto.InstallmentResponse{
+			Sequence: inst.Sequence,
+			DueDate:  inst.DueDate,
+			Amount:   inst.Amount,
+		}
+	}
+
+	return responses
+}
@@ -0,0 +1,471 @@
+package risktiersrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config sets how many customers the job grades per batch.
+type Config struct {
+	BatchSize int
+}
+
+type riskTierService struct {
+	riskTierRepository repository.RiskTierRepository
+	customerRepository repository.CustomerRepository
+	dueDateRules       service.DueDateRules
+	cfg                Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	gradedCount       metric.Int64Counter
+	changedCount      metric.Int64Counter
+}
+
+// Recalculate implements RiskTierServices.
+func (s *riskTierService) Recalculate(ctx context.Context, now time.Time) (*domain.RiskTierRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RecalculateRiskTiers")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "recalculate_risk_tiers"), attribute.String("service", "risk_tier")))
+
+	rules, err := s.riskTierRepository.FindRules(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "recalculate_risk_tiers", "repository_error", fmt.Errorf("failed to get risk tier rules: %w", err))
+	}
+	dueDates := s.dueDateRules.Rules(ctx)
+
+	run := &domain.RiskTierRun{}
+	var afterID uint64
+	for {
+		customers, err := s.riskTierRepository.FindCustomers(ctx, afterID, s.cfg.BatchSize)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "recalculate_risk_tiers", "repository_error", fmt.Errorf("failed to find customers: %w", err))
+		}
+		if len(customers) == 0 {
+			break
+		}
+
+		changed, err := s.grade(ctx, customers, rules, dueDates, now)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "recalculate_risk_tiers", "repository_error", err)
+		}
+		run.Customers += len(customers)
+		run.Changed += changed
+
+		afterID = customers[len(customers)-1].ID
+		if len(customers) < s.cfg.BatchSize {
+			break
+		}
+	}
+
+	s.gradedCount.Add(ctx, int64(run.Customers), metric.WithAttributes(attribute.String("service", "risk_tier")))
+	s.changedCount.Add(ctx, int64(run.Changed), metric.WithAttributes(attribute.String("service", "risk_tier")))
+
+	span.SetAttributes(
+		attribute.Int("risk_tier.customers", run.Customers),
+		attribute.Int("risk_tier.changed", run.Changed),
+	)
+	s.recordSuccess(ctx, span, start, "recalculate_risk_tiers",
+		zap.Int("rules", len(rules)),
+		zap.Int("customers", run.Customers),
+		zap.Int("changed", run.Changed),
+	)
+
+	return run, nil
+}
+
+// grade gives every customer in a batch its tier and reports how many
+// tiers changed.
+func (s *riskTierService) grade(ctx context.Context, customers []domain.Customer, rules []domain.RiskTierRule, dueDates installment.Rules, now time.Time) (int, error) {
+	ids := make([]uint64, len(customers))
+	for i, customer := range customers {
+		ids[i] = customer.ID
+	}
+
+	exposures, err := s.riskTierRepository.FindActiveExposures(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get active contracts: %w", err)
+	}
+	current, err := s.riskTierRepository.FindByCustomerIDs(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current risk tiers: %w", err)
+	}
+	previous := make(map[uint64]domain.CustomerRiskTier, len(current))
+	for _, tier := range current {
+		previous[tier.CustomerID] = tier
+	}
+	overdue := daysPastDue(exposures, dueDates, now)
+
+	changed := 0
+	for _, customer := range customers {
+		var score *int
+		before, graded := previous[customer.ID]
+		if graded {
+			score = before.CreditScore
+		}
+
+		tier := assign(rules, customer, score, overdue[customer.ID], now)
+		if err := s.riskTierRepository.Save(ctx, tier); err != nil {
+			return 0, fmt.Errorf("failed to save risk tier of customer %d: %w", customer.ID, err)
+		}
+		if !graded || before.Tier != tier.Tier {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// ListRules implements RiskTierServices.
+func (s *riskTierService) ListRules(ctx context.Context) ([]domain.RiskTierRule, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListRiskTierRules")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_risk_tier_rules"), attribute.String("service", "risk_tier")))
+
+	rules, err := s.riskTierRepository.FindRules(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_risk_tier_rules", "repository_error", fmt.Errorf("failed to get risk tier rules: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_risk_tier_rules", zap.Int("rules", len(rules)))
+
+	return rules, nil
+}
+
+// CreateRule implements RiskTierServices.
+func (s *riskTierService) CreateRule(ctx context.Context, updatedBy uint64, req dto.RiskTierRuleRequest) (*domain.RiskTierRule, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CreateRiskTierRule")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("risk_tier_rule.tier", string(req.Tier)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_risk_tier_rule"), attribute.String("service", "risk_tier")))
+
+	if !validRule(req) {
+		return nil, s.recordError(ctx, span, start, "create_risk_tier_rule", "validation_error", common.ErrInvalidRiskTierRule)
+	}
+
+	rule := &domain.RiskTierRule{}
+	applyRule(rule, req, updatedBy)
+	if err := s.riskTierRepository.CreateRule(ctx, rule); err != nil {
+		return nil, s.recordError(ctx, span, start, "create_risk_tier_rule", "repository_error", fmt.Errorf("failed to create risk tier rule: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "create_risk_tier_rule",
+		zap.Uint64("rule_id", rule.ID),
+		zap.String("tier", string(rule.Tier)),
+		zap.Int("priority", rule.Priority),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return rule, nil
+}
+
+// UpdateRule implements RiskTierServices.
+func (s *riskTierService) UpdateRule(ctx context.Context, id, updatedBy uint64, req dto.RiskTierRuleRequest) (*domain.RiskTierRule, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UpdateRiskTierRule")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("risk_tier_rule.id", int64(id)),
+		attribute.String("risk_tier_rule.tier", string(req.Tier)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_risk_tier_rule"), attribute.String("service", "risk_tier")))
+
+	if !validRule(req) {
+		return nil, s.recordError(ctx, span, start, "update_risk_tier_rule", "validation_error", common.ErrInvalidRiskTierRule)
+	}
+
+	rule, err := s.riskTierRepository.FindRuleByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_risk_tier_rule", "repository_error", fmt.Errorf("failed to get risk tier rule: %w", err))
+	}
+	if rule == nil {
+		return nil, s.recordError(ctx, span, start, "update_risk_tier_rule", "not_found", common.ErrRiskTierRuleNotFound)
+	}
+
+	applyRule(rule, req, updatedBy)
+	if err := s.riskTierRepository.UpdateRule(ctx, rule); err != nil {
+		return nil, s.recordError(ctx, span, start, "update_risk_tier_rule", "repository_error", fmt.Errorf("failed to update risk tier rule: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "update_risk_tier_rule",
+		zap.Uint64("rule_id", rule.ID),
+		zap.String("tier", string(rule.Tier)),
+		zap.Int("priority", rule.Priority),
+		zap.Uint64("updated_by", updatedBy),
+	)
+
+	return rule, nil
+}
+
+// DeleteRule implements RiskTierServices.
+func (s *riskTierService) DeleteRule(ctx context.Context, id uint64) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteRiskTierRule")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("risk_tier_rule.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_risk_tier_rule"), attribute.String("service", "risk_tier")))
+
+	deleted, err := s.riskTierRepository.DeleteRule(ctx, id)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_risk_tier_rule", "repository_error", fmt.Errorf("failed to delete risk tier rule: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_risk_tier_rule", "not_found", common.ErrRiskTierRuleNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "delete_risk_tier_rule", zap.Uint64("rule_id", id))
+
+	return nil
+}
+
+// GetCustomerTier implements RiskTierServices.
+func (s *riskTierService) GetCustomerTier(ctx context.Context, customerID uint64) (*domain.CustomerRiskTier, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetCustomerRiskTier")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_customer_risk_tier"), attribute.String("service", "risk_tier")))
+
+	tier, err := s.riskTierRepository.FindByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_customer_risk_tier", "repository_error", fmt.Errorf("failed to get customer risk tier: %w", err))
+	}
+	if tier == nil {
+		return nil, s.recordError(ctx, span, start, "get_customer_risk_tier", "not_found", common.ErrRiskTierNotCalculated)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_customer_risk_tier",
+		zap.Uint64("customer_id", customerID),
+		zap.String("tier", string(tier.Tier)),
+	)
+
+	return tier, nil
+}
+
+// SetCreditScore implements RiskTierServices.
+func (s *riskTierService) SetCreditScore(ctx context.Context, customerID uint64, req dto.CreditScoreRequest) (*domain.CustomerRiskTier, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SetCreditScore")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("customer.id", int64(customerID)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_credit_score"), attribute.String("service", "risk_tier")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_credit_score", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return nil, s.recordError(ctx, span, start, "set_credit_score", "customer_not_found", common.ErrCustomerNotFound)
+	}
+
+	rules, err := s.riskTierRepository.FindRules(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_credit_score", "repository_error", fmt.Errorf("failed to get risk tier rules: %w", err))
+	}
+	exposures, err := s.riskTierRepository.FindActiveExposures(ctx, []uint64{customerID})
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "set_credit_score", "repository_error", fmt.Errorf("failed to get active contracts: %w", err))
+	}
+
+	// Skor baru langsung dipakai, tidak menunggu job malam
+	now := time.Now()
+	overdue := daysPastDue(exposures, s.dueDateRules.Rules(ctx), now)
+	tier := assign(rules, *customer, req.Score, overdue[customerID], now)
+	if err := s.riskTierRepository.Save(ctx, tier); err != nil {
+		return nil, s.recordError(ctx, span, start, "set_credit_score", "repository_error", fmt.Errorf("failed to save risk tier: %w", err))
+	}
+
+	span.SetAttributes(attribute.String("risk_tier.tier", string(tier.Tier)))
+	s.recordSuccess(ctx, span, start, "set_credit_score",
+		zap.Uint64("customer_id", customerID),
+		zap.String("tier", string(tier.Tier)),
+		zap.Int("days_past_due", tier.DaysPastDue),
+	)
+
+	return tier, nil
+}
+
+// assign grades customer with the first rule that matches it.
+func assign(rules []domain.RiskTierRule, customer domain.Customer, score *int, daysPastDue int, now time.Time) *domain.CustomerRiskTier {
+	tier := &domain.CustomerRiskTier{
+		CustomerID:   customer.ID,
+		Salary:       customer.Salary,
+		CreditScore:  score,
+		DaysPastDue:  daysPastDue,
+		CalculatedAt: now,
+	}
+	for _, rule := range rules {
+		if rule.Matches(customer.Salary, score, daysPastDue) {
+			id := rule.ID
+			tier.Tier, tier.RuleID = rule.Tier, &id
+			break
+		}
+	}
+	return tier
+}
+
+// daysPastDue returns the worst delinquency at asOf among each customer's
+// contracts, counted the same way as the aging report.
+func daysPastDue(exposures []domain.AgingExposure, rules installment.Rules, asOf time.Time) map[uint64]int {
+	worst := make(map[uint64]int)
+	for _, exposure := range exposures {
+		months := int(exposure.TenorMonths)
+		if months == 0 {
+			continue
+		}
+
+		paid := min(rules.Elapsed(exposure.TransactionDate, asOf), months)
+		if exposure.PaidInstallments != nil {
+			paid = min(max(*exposure.PaidInstallments, 0), months)
+		}
+		if days := rules.DaysPastDue(exposure.TransactionDate, paid, months, asOf); days > worst[exposure.CustomerID] {
+			worst[exposure.CustomerID] = days
+		}
+	}
+	return worst
+}
+
+func validRule(req dto.RiskTierRuleRequest) bool {
+	for _, salary := range []*decimal.Decimal{req.MinSalary, req.MaxSalary} {
+		if salary != nil && salary.IsNegative() {
+			return false
+		}
+	}
+	if req.MinSalary != nil && req.MaxSalary != nil && req.MinSalary.GreaterThan(*req.MaxSalary) {
+		return false
+	}
+	if req.MinScore != nil && req.MaxScore != nil && *req.MinScore > *req.MaxScore {
+		return false
+	}
+	return req.MinDaysPastDue == nil || req.MaxDaysPastDue == nil || *req.MinDaysPastDue <= *req.MaxDaysPastDue
+}
+
+func applyRule(rule *domain.RiskTierRule, req dto.RiskTierRuleRequest, updatedBy uint64) {
+	rule.Priority = req.Priority
+	rule.Tier = req.Tier
+	rule.MinSalary = req.MinSalary
+	rule.MaxSalary = req.MaxSalary
+	rule.MinScore = req.MinScore
+	rule.MaxScore = req.MaxScore
+	rule.MinDaysPastDue = req.MinDaysPastDue
+	rule.MaxDaysPastDue = req.MaxDaysPastDue
+	rule.UpdatedBy = updatedBy
+}
+
+func (s *riskTierService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Risk tier operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "risk_tier"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "risk_tier"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *riskTierService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "risk_tier"), attribute.String("status", "success")))
+
+	s.log.Info("Risk tier operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewRiskTierService(
+	riskTierRepository repository.RiskTierRepository,
+	customerRepository repository.CustomerRepository,
+	dueDateRules service.DueDateRules,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.RiskTierServices {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	gradedCount, _ := meter.Int64Counter(
+		"service.risk_tiers.graded",
+		metric.WithDescription("Number of customers graded by the risk tier job"),
+		metric.WithUnit("{customer}"),
+	)
+
+	changedCount, _ := meter.Int64Counter(
+		"service.risk_tiers.changed",
+		metric.WithDescription("Number of customers whose risk tier changed"),
+		metric.WithUnit("{customer}"),
+	)
+
+	return &riskTierService{
+		riskTierRepository: riskTierRepository,
+		customerRepository: customerRepository,
+		dueDateRules:       dueDateRules,
+		cfg:                cfg,
+		meter:              meter,
+		tracer:             tracer,
+		log:                log,
+		operationDuration:  operationDuration,
+		operationCount:     operationCount,
+		errorCount:         errorCount,
+		gradedCount:        gradedCount,
+		changedCount:       changedCount,
+	}
+}
@@ -0,0 +1,259 @@
+package salarychangesrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	salarychangerepo "github.com/fazamuttaqien/multifinance/internal/repository/salarychange"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type salaryChangeService struct {
+	db                     *gorm.DB
+	customerRepository     repository.CustomerRepository
+	salaryChangeRepository repository.SalaryChangeRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	changesReviewed   metric.Int64Counter
+}
+
+// RequestChange implements SalaryChangeServices.
+func (s *salaryChangeService) RequestChange(ctx context.Context, customerID uint64, requestedSalary decimal.Decimal, payslipURL string) (*domain.SalaryChange, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RequestSalaryChange")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Float64("salary.requested", requestedSalary.InexactFloat64()),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "request_salary_change"), attribute.String("service", "salary_change")))
+
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "request_salary_change", "repository_error", fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer == nil {
+		return nil, s.recordError(ctx, span, start, "request_salary_change", "customer_not_found", common.ErrCustomerNotFound)
+	}
+	if customer.Salary.Equal(requestedSalary) {
+		return nil, s.recordError(ctx, span, start, "request_salary_change", "salary_unchanged", common.ErrSalaryUnchanged)
+	}
+
+	// Hanya satu pengajuan yang boleh menunggu review per customer
+	pending, err := s.salaryChangeRepository.FindPendingByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "request_salary_change", "repository_error", fmt.Errorf("failed to check pending salary change: %w", err))
+	}
+	if pending != nil {
+		return nil, s.recordError(ctx, span, start, "request_salary_change", "change_pending", common.ErrSalaryChangeExists)
+	}
+
+	change := &domain.SalaryChange{
+		CustomerID:      customerID,
+		CurrentSalary:   customer.Salary,
+		RequestedSalary: requestedSalary,
+		PayslipUrl:      payslipURL,
+		Status:          domain.SalaryChangePending,
+	}
+	if err := s.salaryChangeRepository.Create(ctx, change); err != nil {
+		return nil, s.recordError(ctx, span, start, "request_salary_change", "repository_error", fmt.Errorf("failed to create salary change: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "request_salary_change",
+		zap.Uint64("salary_change_id", change.ID),
+		zap.Uint64("customer_id", customerID),
+	)
+
+	return change, nil
+}
+
+// ListChanges implements SalaryChangeServices.
+func (s *salaryChangeService) ListChanges(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListSalaryChanges")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.status", params.Value("status")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_salary_changes"), attribute.String("service", "salary_change")))
+
+	changes, total, err := s.salaryChangeRepository.FindPaginated(ctx, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_salary_changes", "repository_error", fmt.Errorf("failed to list salary changes: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_salary_changes", zap.Int64("total", total))
+
+	return params.Paginated(changes, total), nil
+}
+
+// Review implements SalaryChangeServices.
+func (s *salaryChangeService) Review(ctx context.Context, changeID, reviewerID uint64, req dto.SalaryChangeReviewRequest) (*domain.SalaryChange, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ReviewSalaryChange")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("salary_change.id", int64(changeID)),
+		attribute.Int64("reviewer.id", int64(reviewerID)),
+		attribute.String("salary_change.decision", string(req.Status)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "review_salary_change"), attribute.String("service", "salary_change")))
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, s.recordError(ctx, span, start, "review_salary_change", "transaction_begin_error", tx.Error)
+	}
+	defer tx.Rollback()
+
+	salaryChangeTx := salarychangerepo.NewSalaryChangeRepository(
+		tx,
+		otel.GetMeterProvider().Meter(""),
+		zap.L(),
+	)
+
+	// 1. Kunci pengajuan agar tidak direview dua kali
+	change, err := salaryChangeTx.FindByIDWithLock(ctx, changeID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "review_salary_change", "repository_error", fmt.Errorf("failed to get salary change: %w", err))
+	}
+	if change == nil {
+		return nil, s.recordError(ctx, span, start, "review_salary_change", "change_not_found", common.ErrSalaryChangeNotFound)
+	}
+	if change.Status != domain.SalaryChangePending {
+		return nil, s.recordError(ctx, span, start, "review_salary_change", "already_reviewed", common.ErrSalaryChangeReviewed)
+	}
+
+	// 2. Gaji customer baru berubah setelah disetujui admin
+	if req.Status == domain.SalaryChangeApproved {
+		if err := tx.Model(&model.Customer{}).Where("id = ?", change.CustomerID).Updates(map[string]any{
+			"salary":  change.RequestedSalary,
+			"version": gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return nil, s.recordError(ctx, span, start, "review_salary_change", "update_salary_error", fmt.Errorf("failed to update customer salary: %w", err))
+		}
+	}
+
+	now := time.Now()
+	change.Status = req.Status
+	change.ReviewerID = &reviewerID
+	change.ReviewNote = req.Note
+	change.ReviewedAt = &now
+
+	if err := salaryChangeTx.Update(ctx, change); err != nil {
+		return nil, s.recordError(ctx, span, start, "review_salary_change", "repository_error", fmt.Errorf("failed to update salary change: %w", err))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "review_salary_change", "transaction_commit_error", err)
+	}
+
+	s.changesReviewed.Add(ctx, 1, metric.WithAttributes(attribute.String("decision", string(req.Status))))
+	s.recordSuccess(ctx, span, start, "review_salary_change",
+		zap.Uint64("salary_change_id", changeID),
+		zap.String("decision", string(req.Status)),
+	)
+
+	return change, nil
+}
+
+func (s *salaryChangeService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Salary change operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "salary_change"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "salary_change"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *salaryChangeService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "salary_change"), attribute.String("status", "success")))
+
+	s.log.Info("Salary change operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewSalaryChangeService(
+	db *gorm.DB,
+	customerRepository repository.CustomerRepository,
+	salaryChangeRepository repository.SalaryChangeRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.SalaryChangeServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	changesReviewed, _ := meter.Int64Counter(
+		"service.salary_changes.reviewed",
+		metric.WithDescription("Number of salary changes reviewed"),
+		metric.WithUnit("{change}"),
+	)
+
+	return &salaryChangeService{
+		db:                     db,
+		customerRepository:     customerRepository,
+		salaryChangeRepository: salaryChangeRepository,
+		meter:                  meter,
+		tracer:                 tracer,
+		log:                    log,
+		operationDuration:      operationDuration,
+		operationCount:         operationCount,
+		errorCount:             errorCount,
+		changesReviewed:        changesReviewed,
+	}
+}
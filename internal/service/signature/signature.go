@@ -0,0 +1,159 @@
+package signaturesrv
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/requestsig"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config sets how far X-Timestamp may drift from the server clock. Nonces
+// are remembered for twice the tolerance, which covers every timestamp that
+// could still be accepted.
+type Config struct {
+	Tolerance time.Duration
+}
+
+type signatureService struct {
+	nonceRepository repository.NonceRepository
+	cfg             Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// Verify implements RequestSignatureServices.
+func (s *signatureService) Verify(ctx context.Context, partner *domain.Partner, req domain.SignedRequest) error {
+	ctx, span := s.tracer.Start(ctx, "service.VerifyRequestSignature")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("partner.id", int64(partner.ID)),
+		attribute.Bool("partner.require_signature", partner.Security.RequireSignature),
+		attribute.Bool("request.signed", req.Signature != ""),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "verify_signature"), attribute.String("service", "signature")))
+
+	if req.Signature == "" {
+		if partner.Security.RequireSignature {
+			return s.recordError(ctx, span, start, "verify_signature", "signature_required", common.ErrSignatureRequired)
+		}
+		return nil
+	}
+
+	// Partner yang mengirim tanda tangan tanpa secret terdaftar dianggap salah konfigurasi
+	if partner.Security.SigningSecret == "" || !requestsig.ValidNonce(req.Nonce) {
+		return s.recordError(ctx, span, start, "verify_signature", "invalid_signature", common.ErrInvalidSignature)
+	}
+
+	unix, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return s.recordError(ctx, span, start, "verify_signature", "invalid_signature", common.ErrInvalidSignature)
+	}
+	drift := time.Since(time.Unix(unix, 0))
+	if drift > s.cfg.Tolerance || drift < -s.cfg.Tolerance {
+		return s.recordError(ctx, span, start, "verify_signature", "signature_expired", common.ErrSignatureExpired)
+	}
+
+	if !requestsig.Verify(partner.Security.SigningSecret, req.Signature, req.Timestamp, req.Nonce, req.Method, req.Path, req.Body) {
+		return s.recordError(ctx, span, start, "verify_signature", "invalid_signature", common.ErrInvalidSignature)
+	}
+
+	// Nonce baru diklaim setelah tanda tangan valid, supaya request palsu tidak bisa menghabiskan nonce partner
+	claimed, err := s.nonceRepository.Claim(ctx, partner.ID, req.Nonce, 2*s.cfg.Tolerance)
+	if err != nil {
+		return s.recordError(ctx, span, start, "verify_signature", "repository_error", fmt.Errorf("failed to claim nonce: %w", err))
+	}
+	if !claimed {
+		return s.recordError(ctx, span, start, "verify_signature", "nonce_reused", common.ErrNonceReused)
+	}
+
+	s.recordSuccess(ctx, span, start, "verify_signature", zap.Uint64("partner_id", partner.ID), zap.String("nonce", req.Nonce))
+
+	return nil
+}
+
+func (s *signatureService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Request signature operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "signature"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "signature"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *signatureService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "signature"), attribute.String("status", "success")))
+
+	s.log.Info("Request signature operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewSignatureService(
+	nonceRepository repository.NonceRepository,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.RequestSignatureServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &signatureService{
+		nonceRepository:   nonceRepository,
+		cfg:               cfg,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+	}
+}
@@ -0,0 +1,283 @@
+package statementsrv
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// monthlyData is what templates/<language>/monthly.tmpl reads.
+type monthlyData struct {
+	Brand           Branding
+	Customer        domain.Customer
+	From            time.Time
+	Through         time.Time
+	GeneratedAt     time.Time
+	NewTransactions []domain.Transaction
+	Payments        []monthlyPayment
+	Balances        []tenorBalance
+}
+
+type monthlyPayment struct {
+	PaidAt         time.Time
+	ContractNumber string
+	Currency       string
+	Amount         decimal.Decimal
+}
+
+// tenorBalance is the outstanding balance of the running contracts sharing a
+// tenor and a currency.
+type tenorBalance struct {
+	TenorMonths uint8
+	Currency    string
+	Contracts   int
+	Outstanding decimal.Decimal
+}
+
+// SendMonthlyStatements implements StatementServices.
+func (s *statementService) SendMonthlyStatements(ctx context.Context, now time.Time) (*domain.MonthlyStatementRun, error) {
+	ctx, span := s.tracer.Start(ctx, "service.SendMonthlyStatements")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "send_monthly_statements"), attribute.String("service", "statement")))
+
+	// Periode yang dikirim selalu bulan kalender sebelumnya di zona waktu bisnis
+	local := now.In(s.cfg.Location)
+	to := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, s.cfg.Location)
+	from := to.AddDate(0, -1, 0)
+
+	run := &domain.MonthlyStatementRun{Period: from.Format("2006-01")}
+	span.SetAttributes(attribute.String("statement.period", run.Period))
+
+	recipients, err := s.monthlyStatementRepository.FindRecipients(ctx, run.Period, from, to, s.cfg.MonthlyBatchSize)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "send_monthly_statements", "repository_error", fmt.Errorf("failed to find monthly statement recipients: %w", err))
+	}
+
+	for i := range recipients {
+		customer := &recipients[i]
+		statement := &domain.MonthlyStatement{
+			CustomerID: customer.ID,
+			Period:     run.Period,
+			Status:     domain.CommunicationSent,
+		}
+
+		// Kegagalan tetap dicatat agar customer yang bermasalah tidak dicoba terus setiap putaran
+		url, err := s.sendMonthly(ctx, customer, run.Period, from, to)
+		if err != nil {
+			s.log.Warn("Failed to send monthly statement",
+				zap.Uint64("customer_id", customer.ID),
+				zap.String("period", run.Period),
+				zap.Error(err),
+			)
+			statement.Status = domain.CommunicationFailed
+			statement.Error = truncate(err.Error(), 255)
+		}
+		statement.URL = url
+
+		created, err := s.monthlyStatementRepository.Create(ctx, statement)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "send_monthly_statements", "repository_error", fmt.Errorf("failed to record monthly statement: %w", err))
+		}
+		if !created {
+			continue
+		}
+
+		if statement.Status == domain.CommunicationSent {
+			run.Sent++
+		} else {
+			run.Failed++
+		}
+	}
+
+	s.renderedCount.Add(ctx, int64(run.Sent), metric.WithAttributes(attribute.String("mode", "monthly")))
+
+	span.SetAttributes(
+		attribute.Int("statement.sent", run.Sent),
+		attribute.Int("statement.failed", run.Failed),
+	)
+	s.recordSuccess(ctx, span, start, "send_monthly_statements",
+		zap.String("period", run.Period),
+		zap.Int("sent", run.Sent),
+		zap.Int("failed", run.Failed),
+	)
+
+	return run, nil
+}
+
+// SetMonthlyStatementOptOut implements StatementServices.
+func (s *statementService) SetMonthlyStatementOptOut(ctx context.Context, customerID uint64, optOut bool) error {
+	ctx, span := s.tracer.Start(ctx, "service.SetMonthlyStatementOptOut")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.Bool("statement.opt_out", optOut),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "set_monthly_statement_opt_out"), attribute.String("service", "statement")))
+
+	updated, err := s.monthlyStatementRepository.SetOptOut(ctx, customerID, optOut)
+	if err != nil {
+		return s.recordError(ctx, span, start, "set_monthly_statement_opt_out", "repository_error", fmt.Errorf("failed to update monthly statement preference: %w", err))
+	}
+	if !updated {
+		// RowsAffected MySQL nol juga bila nilainya tidak berubah, jadi pastikan customer memang ada
+		customer, err := s.customerRepository.FindByID(ctx, customerID)
+		if err != nil {
+			return s.recordError(ctx, span, start, "set_monthly_statement_opt_out", "repository_error", fmt.Errorf("failed to find customer: %w", err))
+		}
+		if customer == nil {
+			return s.recordError(ctx, span, start, "set_monthly_statement_opt_out", "not_found", common.ErrCustomerNotFound)
+		}
+	}
+
+	s.recordSuccess(ctx, span, start, "set_monthly_statement_opt_out",
+		zap.Uint64("customer_id", customerID),
+		zap.Bool("opt_out", optOut),
+	)
+
+	return nil
+}
+
+// sendMonthly renders, stores and emails the summary of one customer and
+// returns the URL of the stored PDF.
+func (s *statementService) sendMonthly(ctx context.Context, customer *domain.Customer, period string, from, to time.Time) (string, error) {
+	contracts, err := s.monthlyStatementRepository.FindContracts(ctx, customer.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find contracts: %w", err)
+	}
+
+	payments, err := s.monthlyStatementRepository.FindPayments(ctx, customer.ID, from, to)
+	if err != nil {
+		return "", fmt.Errorf("failed to find payments: %w", err)
+	}
+
+	brand := s.branding(ctx)
+	data := monthlyData{
+		Brand:       brand,
+		Customer:    *customer,
+		From:        from,
+		Through:     to.AddDate(0, 0, -1),
+		GeneratedAt: time.Now(),
+	}
+
+	contractNumbers := make(map[uint64]string, len(contracts))
+	for _, contract := range contracts {
+		contractNumbers[contract.ID] = contract.ContractNumber
+
+		if !contract.TransactionDate.Before(from) && contract.TransactionDate.Before(to) {
+			data.NewTransactions = append(data.NewTransactions, contract)
+		}
+
+		if contract.Status != domain.TransactionApproved && contract.Status != domain.TransactionActive {
+			continue
+		}
+
+		// Sisa kewajiban dihitung dengan cara yang sama seperti detail kontrak di aplikasi
+		detail, err := s.profileService.GetMyTransactionDetail(ctx, customer.ID, contract.ContractNumber)
+		if err != nil {
+			return "", fmt.Errorf("failed to load contract %s: %w", contract.ContractNumber, err)
+		}
+		data.Balances = addBalance(data.Balances, contract.Tenor.DurationMonths, detail.Currency, detail.OutstandingBalance)
+	}
+	slices.SortFunc(data.Balances, func(a, b tenorBalance) int {
+		if a.TenorMonths != b.TenorMonths {
+			return int(a.TenorMonths) - int(b.TenorMonths)
+		}
+		return strings.Compare(a.Currency, b.Currency)
+	})
+
+	for _, payment := range payments {
+		paidAt := payment.CreatedAt
+		if payment.PaidAt != nil {
+			paidAt = *payment.PaidAt
+		}
+		data.Payments = append(data.Payments, monthlyPayment{
+			PaidAt:         paidAt.In(s.cfg.Location),
+			ContractNumber: contractNumbers[payment.TransactionID],
+			Currency:       payment.Currency,
+			Amount:         payment.Amount,
+		})
+	}
+
+	layout, err := s.templates.render(customer.Language, monthlyLayout, data)
+	if err != nil {
+		return "", err
+	}
+
+	document, err := renderPDF(layout, brand, customer.Language)
+	if err != nil {
+		return "", err
+	}
+
+	url, err := s.cloudinaryService.UploadFile(ctx, document, s.cfg.Folder, fmt.Sprintf("monthly-%d-%s", customer.ID, period))
+	if err != nil {
+		return "", fmt.Errorf("failed to store monthly statement: %w", err)
+	}
+
+	paid := make(map[string]decimal.Decimal)
+	for _, payment := range data.Payments {
+		paid[payment.Currency] = paid[payment.Currency].Add(payment.Amount)
+	}
+	outstanding := make(map[string]decimal.Decimal)
+	for _, balance := range data.Balances {
+		outstanding[balance.Currency] = outstanding[balance.Currency].Add(balance.Outstanding)
+	}
+
+	err = s.notifier.Notify(ctx, customer.ID, domain.TemplateMonthlyStatement, map[string]string{
+		"full_name":        customer.FullName,
+		"period":           period,
+		"statement_url":    url,
+		"new_transactions": strconv.Itoa(len(data.NewTransactions)),
+		"payments":         formatTotals(customer.Language, paid),
+		"outstanding":      formatTotals(customer.Language, outstanding),
+	})
+	if err != nil {
+		return url, fmt.Errorf("failed to notify customer: %w", err)
+	}
+
+	return url, nil
+}
+
+func addBalance(balances []tenorBalance, tenorMonths uint8, currency string, amount decimal.Decimal) []tenorBalance {
+	for i := range balances {
+		if balances[i].TenorMonths == tenorMonths && balances[i].Currency == currency {
+			balances[i].Contracts++
+			balances[i].Outstanding = balances[i].Outstanding.Add(amount)
+			return balances
+		}
+	}
+	return append(balances, tenorBalance{TenorMonths: tenorMonths, Currency: currency, Contracts: 1, Outstanding: amount})
+}
+
+// formatTotals writes one amount per currency, e.g. "IDR 1.500.000,00, USD 20,00".
+func formatTotals(language domain.Language, totals map[string]decimal.Decimal) string {
+	if len(totals) == 0 {
+		return "-"
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	slices.Sort(currencies)
+
+	parts := make([]string, len(currencies))
+	for i, currency := range currencies {
+		parts[i] = formatMoney(language, currency, totals[currency])
+	}
+	return strings.Join(parts, ", ")
+}
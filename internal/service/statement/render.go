@@ -0,0 +1,181 @@
+package statementsrv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/pkg/pdf"
+)
+
+const (
+	marginX      = 18.0
+	headerHeight = 24.0
+	contentTop   = headerHeight + 12
+	contentEnd   = pdf.PageHeight - 22
+	labelWidth   = 55.0
+)
+
+var pageLabels = map[domain.Language]string{
+	domain.LanguageIndonesian: "Halaman %d",
+	domain.LanguageEnglish:    "Page %d",
+}
+
+// renderer lays out the output of a statement template. Every line of the
+// template output is a command, its arguments separated by "|":
+//
+//	title TEXT           large heading
+//	subtitle TEXT        small gray line under the title
+//	section TEXT         section heading in the brand color
+//	field LABEL | VALUE  label and value on one line
+//	table H1 | H2 ...    table header; a leading '>' right aligns the column
+//	row C1 | C2 ...      table row, repeated header after a page break
+//	text TEXT            wrapped paragraph
+//	rule                 thin horizontal line
+//	space                blank gap
+type renderer struct {
+	doc      *pdf.Document
+	brand    Branding
+	language domain.Language
+	y        float64
+	columns  []string
+}
+
+func renderPDF(layout string, brand Branding, language domain.Language) ([]byte, error) {
+	r := &renderer{doc: pdf.New(), brand: brand, language: language}
+	r.newPage()
+
+	for n, line := range strings.Split(layout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		command, rest, _ := strings.Cut(line, " ")
+		args := strings.Split(rest, "|")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+
+		switch command {
+		case "title":
+			r.ensure(9)
+			r.doc.Text(marginX, r.y+6, 16, true, pdf.Black, rest)
+			r.y += 9
+		case "subtitle":
+			r.ensure(6)
+			r.doc.Text(marginX, r.y+4, 9, false, pdf.Gray, rest)
+			r.y += 6
+		case "section":
+			r.ensure(14)
+			r.doc.Text(marginX, r.y+5, 11, true, brand.Color, rest)
+			r.doc.Line(marginX, r.y+7, pdf.PageWidth-marginX, r.y+7, 0.3, brand.Color)
+			r.y += 10
+		case "field":
+			if len(args) != 2 {
+				return nil, fmt.Errorf("statement line %d: field needs a label and a value", n+1)
+			}
+			r.ensure(6)
+			r.doc.Text(marginX, r.y+4, 9, false, pdf.Gray, args[0])
+			r.doc.Text(marginX+labelWidth, r.y+4, 9, false, pdf.Black, args[1])
+			r.y += 6
+		case "table":
+			r.columns = args
+			r.ensure(14)
+			r.tableHeader()
+		case "row":
+			if len(args) != len(r.columns) {
+				return nil, fmt.Errorf("statement line %d: row has %d cells, table has %d", n+1, len(args), len(r.columns))
+			}
+			if r.y+6 > contentEnd {
+				r.newPage()
+				r.tableHeader()
+			}
+			r.cells(args, false, pdf.Black)
+			r.y += 6
+		case "text":
+			for _, wrapped := range wrap(rest, 8, pdf.PageWidth-2*marginX) {
+				r.ensure(4.5)
+				r.doc.Text(marginX, r.y+3, 8, false, pdf.Gray, wrapped)
+				r.y += 4.5
+			}
+		case "rule":
+			r.ensure(4)
+			r.doc.Line(marginX, r.y+2, pdf.PageWidth-marginX, r.y+2, 0.2, pdf.Gray)
+			r.y += 4
+		case "space":
+			r.y += 4
+		default:
+			return nil, fmt.Errorf("statement line %d: unknown command %q", n+1, command)
+		}
+	}
+
+	return r.doc.Bytes(), nil
+}
+
+// ensure starts a new page when height no longer fits on the current one.
+func (r *renderer) ensure(height float64) {
+	if r.y+height > contentEnd {
+		r.newPage()
+	}
+}
+
+func (r *renderer) newPage() {
+	r.doc.AddPage()
+
+	r.doc.Rect(0, 0, pdf.PageWidth, headerHeight, r.brand.Color)
+	r.doc.Text(marginX, 11, 14, true, pdf.White, r.brand.CompanyName)
+	r.doc.Text(marginX, 17, 8, false, pdf.White, r.brand.Address)
+
+	label, ok := pageLabels[r.language]
+	if !ok {
+		label = pageLabels[domain.DefaultLanguage]
+	}
+	r.doc.Line(marginX, pdf.PageHeight-16, pdf.PageWidth-marginX, pdf.PageHeight-16, 0.2, pdf.Gray)
+	r.doc.Text(marginX, pdf.PageHeight-11, 7, false, pdf.Gray, r.brand.Contact)
+	r.doc.TextRight(pdf.PageWidth-marginX, pdf.PageHeight-11, 7, false, pdf.Gray, fmt.Sprintf(label, r.doc.PageCount()))
+
+	r.y = contentTop
+}
+
+func (r *renderer) tableHeader() {
+	r.cells(r.columns, true, pdf.Black)
+	r.doc.Line(marginX, r.y+6, pdf.PageWidth-marginX, r.y+6, 0.2, pdf.Gray)
+	r.y += 7
+}
+
+// cells draws one table line, splitting the content width evenly between
+// the columns. Alignment comes from the header.
+func (r *renderer) cells(values []string, bold bool, color pdf.Color) {
+	width := (pdf.PageWidth - 2*marginX) / float64(len(r.columns))
+	for i, value := range values {
+		value = strings.TrimPrefix(value, ">")
+		left := marginX + float64(i)*width
+		if strings.HasPrefix(r.columns[i], ">") {
+			r.doc.TextRight(left+width-2, r.y+4, 9, bold, color, value)
+		} else {
+			r.doc.Text(left, r.y+4, 9, bold, color, value)
+		}
+	}
+}
+
+// wrap breaks s into lines no wider than width.
+func wrap(s string, size, width float64) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if current != "" && pdf.TextWidth(candidate, size, false) > width {
+			lines = append(lines, current)
+			candidate = word
+		}
+		current = candidate
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,411 @@
+package statementsrv
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/pdf"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// layoutVersion is part of every fingerprint; bump it when the templates or
+// the renderer change so cached statements are generated again.
+const layoutVersion = "1"
+
+// Branding is printed in the header and footer of every page.
+type Branding struct {
+	CompanyName string
+	Address     string
+	Contact     string
+	Color       pdf.Color
+}
+
+// Config controls where statements are stored and which ones are generated
+// in the background. Statements with more than SyncMaxInstallments rows are
+// queued instead of rendered during the request. Monthly summaries cover
+// calendar months in Location, MonthlyBatchSize customers per run.
+type Config struct {
+	Branding            Branding
+	SyncMaxInstallments int
+	Folder              string
+	BatchSize           int
+	Location            *time.Location
+	MonthlyBatchSize    int
+}
+
+type statementService struct {
+	profileService             service.ProfileServices
+	customerRepository         repository.CustomerRepository
+	statementRepository        repository.StatementRepository
+	monthlyStatementRepository repository.MonthlyStatementRepository
+	cloudinaryService          service.CloudinaryService
+	notifier                   service.CustomerNotifier
+	templates                  *Templates
+	cfg                        Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	renderedCount     metric.Int64Counter
+}
+
+// GetStatement implements StatementServices.
+func (s *statementService) GetStatement(ctx context.Context, customerID uint64, contractNumber string) (*domain.TransactionStatement, []byte, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetStatement")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("customer.id", int64(customerID)),
+		attribute.String("transaction.contract_number", contractNumber),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_statement"), attribute.String("service", "statement")))
+
+	// Kepemilikan kontrak dicek oleh profile service, kontrak orang lain dianggap tidak ada
+	detail, err := s.profileService.GetMyTransactionDetail(ctx, customerID, contractNumber)
+	if err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_statement", "transaction_lookup_error", err)
+	}
+
+	customer, err := s.findCustomer(ctx, customerID)
+	if err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_statement", "customer_lookup_error", err)
+	}
+
+	fingerprint, err := s.fingerprint(customer.Language, detail)
+	if err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_statement", "fingerprint_error", err)
+	}
+
+	existing, err := s.statementRepository.FindByContractNumber(ctx, contractNumber)
+	if err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_statement", "repository_error", fmt.Errorf("failed to find statement: %w", err))
+	}
+
+	// Statement yang isinya belum berubah dipakai ulang, yang gagal dibuat ulang
+	if existing != nil && existing.Fingerprint == fingerprint && existing.Status != domain.StatementFailed {
+		s.recordSuccess(ctx, span, start, "get_statement",
+			zap.String("contract_number", contractNumber),
+			zap.String("status", string(existing.Status)),
+			zap.Bool("cached", true),
+		)
+		return existing, nil, nil
+	}
+
+	statement := &domain.TransactionStatement{
+		ContractNumber: contractNumber,
+		CustomerID:     customerID,
+		Fingerprint:    fingerprint,
+		Status:         domain.StatementPending,
+	}
+	if existing != nil {
+		statement.ID = existing.ID
+	}
+
+	if len(detail.Installments) > s.cfg.SyncMaxInstallments {
+		if err := s.statementRepository.Save(ctx, statement); err != nil {
+			return nil, nil, s.recordError(ctx, span, start, "get_statement", "repository_error", fmt.Errorf("failed to queue statement: %w", err))
+		}
+
+		s.recordSuccess(ctx, span, start, "get_statement",
+			zap.String("contract_number", contractNumber),
+			zap.String("status", string(statement.Status)),
+			zap.Int("installments", len(detail.Installments)),
+		)
+		return statement, nil, nil
+	}
+
+	document, err := s.render(ctx, customer, detail)
+	if err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_statement", "render_error", err)
+	}
+	s.renderedCount.Add(ctx, 1, metric.WithAttributes(attribute.String("mode", "sync")))
+
+	// Gagal upload tidak menggagalkan request, PDF tetap dikirim dan dibuat ulang berikutnya
+	url, err := s.cloudinaryService.UploadFile(ctx, document, s.cfg.Folder, publicID(contractNumber, fingerprint))
+	if err != nil {
+		s.log.Warn("Failed to store statement",
+			zap.String("contract_number", contractNumber),
+			zap.Error(err),
+		)
+		statement.Status = domain.StatementFailed
+		statement.Error = truncate(err.Error(), 255)
+	} else {
+		statement.Status = domain.StatementReady
+		statement.URL = url
+	}
+
+	if err := s.statementRepository.Save(ctx, statement); err != nil {
+		return nil, nil, s.recordError(ctx, span, start, "get_statement", "repository_error", fmt.Errorf("failed to save statement: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "get_statement",
+		zap.String("contract_number", contractNumber),
+		zap.String("status", string(statement.Status)),
+		zap.Int("size_bytes", len(document)),
+	)
+
+	return statement, document, nil
+}
+
+// ProcessPending implements StatementServices.
+func (s *statementService) ProcessPending(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ProcessPendingStatements")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "process_pending_statements"), attribute.String("service", "statement")))
+
+	statements, err := s.statementRepository.FindPending(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "process_pending_statements", "repository_error", fmt.Errorf("failed to find pending statements: %w", err))
+	}
+
+	ready, failed := 0, 0
+	for i := range statements {
+		statement := &statements[i]
+
+		if err := s.generate(ctx, statement); err != nil {
+			s.log.Warn("Failed to generate statement",
+				zap.String("contract_number", statement.ContractNumber),
+				zap.Error(err),
+			)
+			statement.Status = domain.StatementFailed
+			statement.Error = truncate(err.Error(), 255)
+			failed++
+		} else {
+			statement.Status = domain.StatementReady
+			statement.Error = ""
+			ready++
+		}
+
+		if err := s.statementRepository.Save(ctx, statement); err != nil {
+			return ready, s.recordError(ctx, span, start, "process_pending_statements", "repository_error", fmt.Errorf("failed to save statement: %w", err))
+		}
+	}
+
+	s.renderedCount.Add(ctx, int64(ready), metric.WithAttributes(attribute.String("mode", "async")))
+
+	span.SetAttributes(
+		attribute.Int("statement.ready", ready),
+		attribute.Int("statement.failed", failed),
+	)
+	s.recordSuccess(ctx, span, start, "process_pending_statements",
+		zap.Int("ready", ready),
+		zap.Int("failed", failed),
+	)
+
+	return ready, nil
+}
+
+// generate renders and uploads a queued statement from the contract as it
+// is now, which may differ from when the statement was requested.
+func (s *statementService) generate(ctx context.Context, statement *domain.TransactionStatement) error {
+	detail, err := s.profileService.GetMyTransactionDetail(ctx, statement.CustomerID, statement.ContractNumber)
+	if err != nil {
+		return err
+	}
+
+	customer, err := s.findCustomer(ctx, statement.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	fingerprint, err := s.fingerprint(customer.Language, detail)
+	if err != nil {
+		return err
+	}
+
+	document, err := s.render(ctx, customer, detail)
+	if err != nil {
+		return err
+	}
+
+	url, err := s.cloudinaryService.UploadFile(ctx, document, s.cfg.Folder, publicID(statement.ContractNumber, fingerprint))
+	if err != nil {
+		return fmt.Errorf("failed to store statement: %w", err)
+	}
+
+	statement.Fingerprint = fingerprint
+	statement.URL = url
+	return nil
+}
+
+func (s *statementService) findCustomer(ctx context.Context, customerID uint64) (*domain.Customer, error) {
+	customer, err := s.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find customer: %w", err)
+	}
+	if customer == nil {
+		return nil, fmt.Errorf("customer %d not found", customerID)
+	}
+	return customer, nil
+}
+
+func (s *statementService) render(ctx context.Context, customer *domain.Customer, detail *dto.TransactionDetailResponse) ([]byte, error) {
+	brand := s.branding(ctx)
+	layout, err := s.templates.render(customer.Language, statementLayout, statementData{
+		Brand:       brand,
+		Customer:    *customer,
+		Detail:      *detail,
+		GeneratedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPDF(layout, brand, customer.Language)
+}
+
+// branding returns the branding of the tenant in ctx. The default tenant
+// uses Config.Branding; a white-label tenant without a color of its own
+// keeps the configured one.
+func (s *statementService) branding(ctx context.Context) Branding {
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok || tenant.ID == tenancy.DefaultID {
+		return s.cfg.Branding
+	}
+
+	brand := Branding{
+		CompanyName: tenant.Branding.CompanyName,
+		Address:     tenant.Branding.Address,
+		Contact:     tenant.Branding.Contact,
+		Color:       s.cfg.Branding.Color,
+	}
+	// Warna tenant sudah divalidasi saat bootstrap
+	if color, err := pdf.ParseHexColor(tenant.Branding.Color); err == nil {
+		brand.Color = color
+	}
+	return brand
+}
+
+// fingerprint identifies the content of a statement, so a cached file is
+// reused until a payment, status change or layout change alters it.
+func (s *statementService) fingerprint(language domain.Language, detail *dto.TransactionDetailResponse) (string, error) {
+	payload, err := json.Marshal(detail)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode statement content: %w", err)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(layoutVersion + "\n" + string(language) + "\n"))
+	hash.Write(payload)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// publicID names the stored file after its content so a new version never
+// overwrites a URL that was already handed out.
+func publicID(contractNumber, fingerprint string) string {
+	return contractNumber + "-" + fingerprint[:16]
+}
+
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit]
+}
+
+func (s *statementService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Statement operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "statement"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "statement"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *statementService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "statement"), attribute.String("status", "success")))
+
+	s.log.Info("Statement operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewStatementService(
+	profileService service.ProfileServices,
+	customerRepository repository.CustomerRepository,
+	statementRepository repository.StatementRepository,
+	monthlyStatementRepository repository.MonthlyStatementRepository,
+	cloudinaryService service.CloudinaryService,
+	notifier service.CustomerNotifier,
+	templates *Templates,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.StatementServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	renderedCount, _ := meter.Int64Counter(
+		"service.statements.rendered",
+		metric.WithDescription("Number of PDF statements rendered, by request, in the background or for the monthly summary"),
+		metric.WithUnit("{statement}"),
+	)
+
+	return &statementService{
+		profileService:             profileService,
+		customerRepository:         customerRepository,
+		statementRepository:        statementRepository,
+		monthlyStatementRepository: monthlyStatementRepository,
+		cloudinaryService:          cloudinaryService,
+		notifier:                   notifier,
+		templates:                  templates,
+		cfg:                        cfg,
+		meter:                      meter,
+		tracer:                     tracer,
+		log:                        log,
+		operationDuration:          operationDuration,
+		operationCount:             operationCount,
+		errorCount:                 errorCount,
+		renderedCount:              renderedCount,
+	}
+}
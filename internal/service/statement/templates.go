@@ -0,0 +1,152 @@
+package statementsrv
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+
+	"github.com/shopspring/decimal"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// statementData is what templates/<language>/statement.tmpl reads.
+type statementData struct {
+	Brand       Branding
+	Customer    domain.Customer
+	Detail      dto.TransactionDetailResponse
+	GeneratedAt time.Time
+}
+
+// Layout names, one file per language under templates/<language>/.
+const (
+	statementLayout = "statement.tmpl"
+	monthlyLayout   = "monthly.tmpl"
+)
+
+// layouts lists the files the default language must provide.
+var layouts = []string{statementLayout, monthlyLayout}
+
+// Templates holds the layouts embedded under templates/<language>/. Each
+// layout produces the line commands understood by renderPDF.
+type Templates struct {
+	byLanguage map[domain.Language]*template.Template
+}
+
+// separators are the thousands and decimal separators of a language.
+var separators = map[domain.Language][2]string{
+	domain.LanguageIndonesian: {".", ","},
+	domain.LanguageEnglish:    {",", "."},
+}
+
+// LoadTemplates parses the embedded templates. The default language must be
+// present; other languages fall back to it.
+func LoadTemplates() (*Templates, error) {
+	t := &Templates{byLanguage: make(map[domain.Language]*template.Template)}
+
+	dirs, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		language := domain.Language(dir.Name())
+		tmpl, err := template.New(string(language)).
+			Option("missingkey=zero").
+			Funcs(templateFuncs(language)).
+			ParseFS(templateFS, path.Join("templates", dir.Name(), "*.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("parse statement templates for %s: %w", language, err)
+		}
+
+		t.byLanguage[language] = tmpl
+	}
+
+	for _, name := range layouts {
+		if t.lookup(domain.DefaultLanguage, name) == nil {
+			return nil, fmt.Errorf("statement template %s is missing for language %q", name, domain.DefaultLanguage)
+		}
+	}
+
+	return t, nil
+}
+
+// render executes the layout name of language, falling back to the default
+// language when there is no translation.
+func (t *Templates) render(language domain.Language, name string, data any) (string, error) {
+	tmpl := t.lookup(language, name)
+	if tmpl == nil {
+		tmpl = t.lookup(domain.DefaultLanguage, name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render statement template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+func (t *Templates) lookup(language domain.Language, name string) *template.Template {
+	tmpl := t.byLanguage[language]
+	if tmpl == nil {
+		return nil
+	}
+	return tmpl.Lookup(name)
+}
+
+func templateFuncs(language domain.Language) template.FuncMap {
+	return template.FuncMap{
+		"money": func(currency string, amount decimal.Decimal) string {
+			return formatMoney(language, currency, amount)
+		},
+		"date": func(layout string, value any) string {
+			switch t := value.(type) {
+			case time.Time:
+				return t.Format(layout)
+			case *time.Time:
+				if t != nil {
+					return t.Format(layout)
+				}
+			}
+			return "-"
+		},
+		"mask": maskNIK,
+	}
+}
+
+// formatMoney writes amount with the separators of language, prefixed by
+// currency when one is given.
+func formatMoney(language domain.Language, currency string, amount decimal.Decimal) string {
+	sep, ok := separators[language]
+	if !ok {
+		sep = separators[domain.DefaultLanguage]
+	}
+
+	formatted := money.Format(amount, sep[0], sep[1])
+	if currency == "" {
+		return formatted
+	}
+	return currency + " " + formatted
+}
+
+// maskNIK keeps the region code and the last four digits of a NIK visible.
+func maskNIK(nik string) string {
+	if len(nik) <= 10 {
+		return nik
+	}
+	return nik[:6] + strings.Repeat("*", len(nik)-10) + nik[len(nik)-4:]
+}
@@ -0,0 +1,327 @@
+package tenorsrv
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type tenorService struct {
+	tenorRepository repository.TenorRepository
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+}
+
+// ListTenors implements TenorServices.
+func (s *tenorService) ListTenors(ctx context.Context) ([]domain.Tenor, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListTenors")
+	defer span.End()
+
+	start := time.Now()
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_tenors"), attribute.String("service", "tenor")))
+
+	tenors, err := s.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_tenors", "repository_error", fmt.Errorf("failed to list tenors: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_tenors", zap.Int("count", len(tenors)))
+
+	return tenors, nil
+}
+
+// CreateTenor implements TenorServices.
+func (s *tenorService) CreateTenor(ctx context.Context, req dto.CreateTenorRequest) (*domain.Tenor, error) {
+	ctx, span := s.tracer.Start(ctx, "service.CreateTenor")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("tenor.duration_months", int(req.DurationMonths)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create_tenor"), attribute.String("service", "tenor")))
+
+	// FindByDuration hanya melihat tenor aktif, sedangkan durasi tetap unik untuk semua tenor
+	tenors, err := s.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "create_tenor", "repository_error", fmt.Errorf("failed to list tenors: %w", err))
+	}
+	for _, existing := range tenors {
+		if existing.DurationMonths == req.DurationMonths {
+			return nil, s.recordError(ctx, span, start, "create_tenor", "duplicate", common.ErrTenorExists)
+		}
+	}
+
+	tenor := &domain.Tenor{
+		DurationMonths: req.DurationMonths,
+		Description:    req.Description,
+		Active:         true,
+	}
+	if tenor.Description == "" {
+		tenor.Description = fmt.Sprintf("%d Months", req.DurationMonths)
+	}
+	if err := applyProduct(tenor, req.TenorProductRequest); err != nil {
+		return nil, s.recordError(ctx, span, start, "create_tenor", "invalid_product", err)
+	}
+
+	if err := s.tenorRepository.Create(ctx, tenor); err != nil {
+		return nil, s.recordError(ctx, span, start, "create_tenor", "repository_error", fmt.Errorf("failed to create tenor: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "create_tenor", zap.Uint("tenor_id", tenor.ID), zap.Uint8("duration_months", tenor.DurationMonths))
+
+	return tenor, nil
+}
+
+// UpdateTenor implements TenorServices.
+func (s *tenorService) UpdateTenor(ctx context.Context, id uint, req dto.UpdateTenorRequest) (*domain.Tenor, error) {
+	ctx, span := s.tracer.Start(ctx, "service.UpdateTenor")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("tenor.id", int(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update_tenor"), attribute.String("service", "tenor")))
+
+	tenor, err := s.findTenor(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "update_tenor", "tenor_lookup_error", err)
+	}
+
+	tenor.Description = req.Description
+	if err := applyProduct(tenor, req.TenorProductRequest); err != nil {
+		return nil, s.recordError(ctx, span, start, "update_tenor", "invalid_product", err)
+	}
+	if err := s.save(ctx, *tenor); err != nil {
+		return nil, s.recordError(ctx, span, start, "update_tenor", "repository_error", err)
+	}
+
+	s.recordSuccess(ctx, span, start, "update_tenor", zap.Uint("tenor_id", id), zap.String("description", tenor.Description))
+
+	return tenor, nil
+}
+
+// DeactivateTenor implements TenorServices.
+func (s *tenorService) DeactivateTenor(ctx context.Context, id uint) (*domain.Tenor, error) {
+	ctx, span := s.tracer.Start(ctx, "service.DeactivateTenor")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("tenor.id", int(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "deactivate_tenor"), attribute.String("service", "tenor")))
+
+	tenor, err := s.findTenor(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "deactivate_tenor", "tenor_lookup_error", err)
+	}
+
+	// Limit dan transaksi yang sudah ada tetap memakai tenor ini, hanya penggunaan baru yang ditutup
+	if tenor.Active {
+		tenor.Active = false
+		if err := s.save(ctx, *tenor); err != nil {
+			return nil, s.recordError(ctx, span, start, "deactivate_tenor", "repository_error", err)
+		}
+	}
+
+	s.recordSuccess(ctx, span, start, "deactivate_tenor", zap.Uint("tenor_id", id), zap.Uint8("duration_months", tenor.DurationMonths))
+
+	return tenor, nil
+}
+
+// DeleteTenor implements TenorServices.
+func (s *tenorService) DeleteTenor(ctx context.Context, id uint) error {
+	ctx, span := s.tracer.Start(ctx, "service.DeleteTenor")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int("tenor.id", int(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete_tenor"), attribute.String("service", "tenor")))
+
+	referenced, err := s.tenorRepository.IsReferenced(ctx, id)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_tenor", "repository_error", fmt.Errorf("failed to check tenor references: %w", err))
+	}
+	if referenced {
+		return s.recordError(ctx, span, start, "delete_tenor", "in_use", common.ErrTenorInUse)
+	}
+
+	deleted, err := s.tenorRepository.Delete(ctx, id)
+	if err != nil {
+		return s.recordError(ctx, span, start, "delete_tenor", "repository_error", fmt.Errorf("failed to delete tenor: %w", err))
+	}
+	if !deleted {
+		return s.recordError(ctx, span, start, "delete_tenor", "not_found", common.ErrTenorNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "delete_tenor", zap.Uint("tenor_id", id))
+
+	return nil
+}
+
+// SeedTenors implements TenorServices. Tenors are matched on their
+// duration: missing ones are created, existing ones get the description
+// and product rules of req. Whether a tenor is active is left to the admin.
+func (s *tenorService) SeedTenors(ctx context.Context, tenors []dto.CreateTenorRequest) error {
+	existing, err := s.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tenors: %w", err)
+	}
+	byDuration := make(map[uint8]domain.Tenor, len(existing))
+	for _, tenor := range existing {
+		byDuration[tenor.DurationMonths] = tenor
+	}
+
+	for _, req := range tenors {
+		tenor, ok := byDuration[req.DurationMonths]
+		if !ok {
+			if _, err := s.CreateTenor(ctx, req); err != nil {
+				return fmt.Errorf("failed to seed %d months tenor: %w", req.DurationMonths, err)
+			}
+			continue
+		}
+
+		tenor.Description = req.Description
+		if tenor.Description == "" {
+			tenor.Description = fmt.Sprintf("%d Months", req.DurationMonths)
+		}
+		if err := applyProduct(&tenor, req.TenorProductRequest); err != nil {
+			return fmt.Errorf("failed to seed %d months tenor: %w", req.DurationMonths, err)
+		}
+		if err := s.save(ctx, tenor); err != nil {
+			return fmt.Errorf("failed to seed %d months tenor: %w", req.DurationMonths, err)
+		}
+	}
+	return nil
+}
+
+func (s *tenorService) findTenor(ctx context.Context, id uint) (*domain.Tenor, error) {
+	tenor, err := s.tenorRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tenor: %w", err)
+	}
+	if tenor == nil {
+		return nil, common.ErrTenorNotFound
+	}
+	return tenor, nil
+}
+
+// applyProduct copies the product rules onto tenor. Categories are stored
+// lowercased without duplicates since they are matched case-insensitively.
+func applyProduct(tenor *domain.Tenor, req dto.TenorProductRequest) error {
+	if req.MaxAmount.IsPositive() && req.MinAmount.GreaterThan(req.MaxAmount) {
+		return common.ErrInvalidTenorProduct
+	}
+	if req.MaxAge > 0 && req.MinAge > req.MaxAge {
+		return common.ErrInvalidTenorProduct
+	}
+	if req.MaxAgeAtEnd > 0 && req.MinAge > req.MaxAgeAtEnd {
+		return common.ErrInvalidTenorProduct
+	}
+
+	categories := make([]string, 0, len(req.AssetCategories))
+	for _, category := range req.AssetCategories {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if category != "" && !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+
+	tenor.MinAmount = req.MinAmount
+	tenor.MaxAmount = req.MaxAmount
+	tenor.MinAge = req.MinAge
+	tenor.MaxAge = req.MaxAge
+	tenor.MaxAgeAtEnd = req.MaxAgeAtEnd
+	tenor.AssetCategories = categories
+	return nil
+}
+
+// save writes tenor after findTenor has loaded it. MySQL reports no affected
+// rows for an update that changes nothing, so that is not read as missing.
+func (s *tenorService) save(ctx context.Context, tenor domain.Tenor) error {
+	if _, err := s.tenorRepository.Update(ctx, tenor); err != nil {
+		return fmt.Errorf("failed to update tenor: %w", err)
+	}
+	return nil
+}
+
+func (s *tenorService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Tenor operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "tenor"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "tenor"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *tenorService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "tenor"), attribute.String("status", "success")))
+
+	s.log.Info("Tenor operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewTenorService(
+	tenorRepository repository.TenorRepository,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.TenorServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	return &tenorService{
+		tenorRepository:   tenorRepository,
+		meter:             meter,
+		tracer:            tracer,
+		log:               log,
+		operationDuration: operationDuration,
+		operationCount:    operationCount,
+		errorCount:        errorCount,
+	}
+}
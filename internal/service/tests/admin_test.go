@@ -2,7 +2,6 @@ package service_test
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -13,10 +12,15 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
 	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
 	"github.com/fazamuttaqien/multifinance/internal/service"
 	adminsrv "github.com/fazamuttaqien/multifinance/internal/service/admin"
+	notifiersrv "github.com/fazamuttaqien/multifinance/internal/service/notifier"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
@@ -26,9 +30,7 @@ import (
 	noop_trace "go.opentelemetry.io/otel/trace/noop"
 
 	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type AdminServiceTestSuite struct {
@@ -43,38 +45,7 @@ type AdminServiceTestSuite struct {
 }
 
 func (suite *AdminServiceTestSuite) SetupSuite() {
-	rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-	sqlDB, err := sql.Open("mysql", dsn)
-	suite.Require().NoError(err)
-	defer sqlDB.Close()
-
-	testDbName := fmt.Sprintf("loan_system_test_%d", rand.Intn(1000))
-
-	_, err = sqlDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDbName))
-	suite.Require().NoError(err)
-	_, err = sqlDB.Exec(fmt.Sprintf("CREATE DATABASE %s", testDbName))
-	suite.Require().NoError(err)
-
-	testDSN := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-		testDbName,
-	)
-	gormDB, err := gorm.Open(mysql.Open(testDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	suite.Require().NoError(err)
-
-	suite.db = gormDB
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_service_admin_test").DB
 	suite.ctx = context.Background()
 
 	suite.log = zap.NewNop()
@@ -83,28 +54,16 @@ func (suite *AdminServiceTestSuite) SetupSuite() {
 	noopMeterProvider := noop_metric.NewMeterProvider()
 	suite.meter = noopMeterProvider.Meter("test-admin-service-meter")
 
-	err = suite.db.AutoMigrate(&model.Customer{}, &model.Tenor{}, &model.CustomerLimit{})
+	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.log)
+	templates, err := notifiersrv.LoadTemplates()
 	suite.Require().NoError(err)
-
-	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.tracer, suite.log)
-	suite.adminService = adminsrv.NewAdminService(suite.db, suite.customerRepository, suite.meter, suite.tracer, suite.log)
-}
-
-func (suite *AdminServiceTestSuite) TearDownSuite() {
-	if suite.db != nil {
-		sqlDB, err := suite.db.DB()
-		suite.Require().NoError(err, "Failed to get underlying sql.DB")
-		suite.Require().NoError(sqlDB.Close(), "Failed to close database connection")
-	}
+	notifier := notifiersrv.NewLogNotifier(suite.customerRepository, templates, suite.log)
+	suite.adminService = adminsrv.NewAdminService(suite.db, suite.customerRepository, limitrepo.NewLimitRepository(suite.db, suite.meter, suite.log), notifier, nil, suite.meter, suite.tracer, suite.log)
 }
 
 func (suite *AdminServiceTestSuite) AfterTest(suiteName, testName string) {
 	// Dijalankan setelah setiap tes untuk membersihkan data
-	suite.db.Exec("SET FOREIGN_KEY_CHECKS = 0")
-	suite.db.Exec("TRUNCATE TABLE customer_limits")
-	suite.db.Exec("TRUNCATE TABLE tenors")
-	suite.db.Exec("TRUNCATE TABLE customers")
-	suite.db.Exec("SET FOREIGN_KEY_CHECKS = 1")
+	testutil.Reset(suite.T(), suite.db)
 }
 
 func (suite *AdminServiceTestSuite) seedCustomer(fullName string, status domain.VerificationStatus) *model.Customer {
@@ -116,7 +75,7 @@ func (suite *AdminServiceTestSuite) seedCustomer(fullName string, status domain.
 		LegalName:          fullName,
 		BirthPlace:         "Jakarta",
 		BirthDate:          time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC),
-		Salary:             10000000,
+		Salary:             decimal.NewFromInt(10000000),
 		KtpPhotoUrl:        "http://example.com/ktp.jpg",
 		SelfiePhotoUrl:     "http://example.com/selfie.jpg",
 		VerificationStatus: model.VerificationStatus(status),
@@ -132,7 +91,7 @@ func (suite *AdminServiceTestSuite) TestListCustomers() {
 		suite.seedCustomer("John Smith", domain.VerificationPending)
 		suite.seedCustomer("Jane Smith", domain.VerificationPending)
 
-		params := domain.Params{Status: "PENDING", Page: 1, Limit: 10}
+		params := domain.Params{Filters: []query.Condition{{Column: "verification_status", Value: "PENDING"}}, Page: 1, Limit: 10}
 
 		// Act
 		result, err := suite.adminService.ListCustomers(suite.ctx, params)
@@ -217,6 +176,11 @@ func (suite *AdminServiceTestSuite) TestVerifyCustomer() {
 		err = suite.db.First(&updatedCustomer, pendingCustomer.ID).Error
 		assert.NoError(t, err)
 		assert.Equal(t, model.VerificationVerified, updatedCustomer.VerificationStatus)
+
+		var events []model.CustomerEvent
+		suite.db.Where("customer_id = ?", pendingCustomer.ID).Find(&events)
+		assert.Len(t, events, 1)
+		assert.Equal(t, string(domain.CustomerVerified), events[0].Type)
 	})
 
 	suite.T().Run("Failure - Verifying Already Verified Customer", func(t *testing.T) {
@@ -232,6 +196,38 @@ func (suite *AdminServiceTestSuite) TestVerifyCustomer() {
 		assert.Contains(t, err.Error(), "customer is not in PENDING state")
 	})
 
+	suite.T().Run("Failure - Flagged Income Verification Awaits Review", func(t *testing.T) {
+		// Arrange
+		pendingCustomer := suite.seedCustomer("Jane Doe", domain.VerificationPending)
+		suite.Require().NoError(suite.db.Create(&model.IncomeVerification{
+			CustomerID:           pendingCustomer.ID,
+			FileName:             "statement.csv",
+			StatementUrl:         "http://example.com/statement.csv",
+			DeclaredSalary:       pendingCustomer.Salary,
+			AverageMonthlyInflow: decimal.NewFromInt(4000000),
+			TotalInflow:          decimal.NewFromInt(12000000),
+			PeriodStart:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:            time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			MonthsCovered:        3,
+			TransactionCount:     12,
+			Discrepancy:          decimal.RequireFromString("0.6"),
+			Status:               model.IncomeFlagged,
+		}).Error)
+
+		// Act
+		err := suite.adminService.VerifyCustomer(suite.ctx, pendingCustomer.ID, dto.VerificationRequest{Status: domain.VerificationVerified})
+
+		// Assert
+		assert.ErrorIs(t, err, common.ErrIncomeReviewPending)
+		var unchanged model.Customer
+		suite.Require().NoError(suite.db.First(&unchanged, pendingCustomer.ID).Error)
+		assert.Equal(t, model.VerificationPending, unchanged.VerificationStatus)
+
+		// Penolakan tetap boleh walau mutasi belum direview
+		err = suite.adminService.VerifyCustomer(suite.ctx, pendingCustomer.ID, dto.VerificationRequest{Status: domain.VerificationRejected})
+		assert.NoError(t, err)
+	})
+
 	suite.T().Run("Failure - Customer Not Found", func(t *testing.T) {
 		// Arrange
 		req := dto.VerificationRequest{Status: domain.VerificationVerified}
@@ -256,13 +252,13 @@ func (suite *AdminServiceTestSuite) TestSetLimits() {
 		// Arrange
 		req := dto.SetLimits{
 			Limits: []dto.LimitItemRequest{
-				{TenorMonths: 3, LimitAmount: 1000},
-				{TenorMonths: 6, LimitAmount: 2000},
+				{TenorMonths: 3, LimitAmount: decimal.NewFromInt(1000)},
+				{TenorMonths: 6, LimitAmount: decimal.NewFromInt(2000)},
 			},
 		}
 
 		// Act
-		err := suite.adminService.SetLimits(suite.ctx, customer.ID, req)
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, req)
 
 		// Assert
 		assert.NoError(t, err)
@@ -270,45 +266,81 @@ func (suite *AdminServiceTestSuite) TestSetLimits() {
 		suite.db.Where("customer_id = ?", customer.ID).Order("tenor_id asc").Find(&limits)
 		assert.Len(t, limits, 2)
 		assert.Equal(t, tenor3.ID, limits[0].TenorID)
-		assert.Equal(t, float64(1000), limits[0].LimitAmount)
+		assert.Equal(t, "1000", limits[0].LimitAmount.String())
 		assert.Equal(t, tenor6.ID, limits[1].TenorID)
-		assert.Equal(t, float64(2000), limits[1].LimitAmount)
+		assert.Equal(t, "2000", limits[1].LimitAmount.String())
+
+		var events []model.CustomerEvent
+		suite.db.Where("customer_id = ? AND type = ?", customer.ID, domain.CustomerLimitSet).Find(&events)
+		assert.Len(t, events, 1)
+		assert.Equal(t, uint64(1), *events[0].ActorID)
+		assert.Equal(t, "1000 IDR", events[0].Data["tenor_3_months"])
 	})
 
 	suite.T().Run("Success - Updating Existing Limits", func(t *testing.T) {
-		// Arrange: Seed initial limits first
-		suite.db.Create(&model.CustomerLimit{CustomerID: customer.ID, TenorID: tenor3.ID, LimitAmount: 500})
-		suite.db.Create(&model.CustomerLimit{CustomerID: customer.ID, TenorID: tenor6.ID, LimitAmount: 1000})
-
+		// Arrange: Limit versi 1 sudah dibuat oleh sub-test sebelumnya
+		current := uint64(1)
 		req := dto.SetLimits{
 			Limits: []dto.LimitItemRequest{
-				{TenorMonths: 3, LimitAmount: 1500}, // Update existing
-				{TenorMonths: 6, LimitAmount: 2500}, // Update existing
+				{TenorMonths: 3, LimitAmount: decimal.NewFromInt(1500), Version: &current}, // Update existing
+				{TenorMonths: 6, LimitAmount: decimal.NewFromInt(2500), Version: &current}, // Update existing
 			},
 		}
 
 		// Act
-		err := suite.adminService.SetLimits(suite.ctx, customer.ID, req)
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, req)
 
 		// Assert
 		assert.NoError(t, err)
 		var updatedLimit3, updatedLimit6 model.CustomerLimit
-		suite.db.Where("customer_id = ? AND tenor_id = ?", customer.ID, tenor3.ID).First(&updatedLimit3)
-		suite.db.Where("customer_id = ? AND tenor_id = ?", customer.ID, tenor6.ID).First(&updatedLimit6)
-		assert.Equal(t, float64(1500), updatedLimit3.LimitAmount)
-		assert.Equal(t, float64(2500), updatedLimit6.LimitAmount)
+		suite.db.Where("customer_id = ? AND tenor_id = ? AND effective_to IS NULL", customer.ID, tenor3.ID).First(&updatedLimit3)
+		suite.db.Where("customer_id = ? AND tenor_id = ? AND effective_to IS NULL", customer.ID, tenor6.ID).First(&updatedLimit6)
+		assert.Equal(t, "1500", updatedLimit3.LimitAmount.String())
+		assert.Equal(t, "2500", updatedLimit6.LimitAmount.String())
+		assert.Equal(t, uint64(2), updatedLimit3.Version)
+	})
+
+	suite.T().Run("Failure - Stale version", func(t *testing.T) {
+		// Admin lain sudah menaikkan limit ke versi 2
+		stale := uint64(1)
+		req := dto.SetLimits{
+			Limits: []dto.LimitItemRequest{
+				{TenorMonths: 3, LimitAmount: decimal.NewFromInt(9000), Version: &stale},
+			},
+		}
+
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, req)
+
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+		var unchanged model.CustomerLimit
+		suite.db.Where("customer_id = ? AND tenor_id = ? AND effective_to IS NULL", customer.ID, tenor3.ID).First(&unchanged)
+		assert.Equal(t, "1500", unchanged.LimitAmount.String())
+	})
+
+	suite.T().Run("Failure - Effective date in the past", func(t *testing.T) {
+		current := uint64(2)
+		yesterday := time.Now().AddDate(0, 0, -1)
+		req := dto.SetLimits{
+			Limits: []dto.LimitItemRequest{
+				{TenorMonths: 3, LimitAmount: decimal.NewFromInt(9000), Version: &current, EffectiveFrom: &yesterday},
+			},
+		}
+
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, req)
+
+		assert.ErrorIs(t, err, common.ErrInvalidEffectiveDate)
 	})
 
 	suite.T().Run("Failure - Tenor not found", func(t *testing.T) {
 		// Arrange
 		req := dto.SetLimits{
 			Limits: []dto.LimitItemRequest{
-				{TenorMonths: 99, LimitAmount: 5000}, // 99 months tenor does not exist
+				{TenorMonths: 99, LimitAmount: decimal.NewFromInt(5000)}, // 99 months tenor does not exist
 			},
 		}
 
 		// Act
-		err := suite.adminService.SetLimits(suite.ctx, customer.ID, req)
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, req)
 
 		// Assert
 		assert.Error(t, err)
@@ -316,6 +348,62 @@ func (suite *AdminServiceTestSuite) TestSetLimits() {
 	})
 }
 
+func (suite *AdminServiceTestSuite) TestSetLimits_BelowUsage() {
+	// Arrange: limit 2000 dengan kontrak aktif berpokok 1500
+	customer := suite.seedCustomer("Jane Doe", domain.VerificationVerified)
+	tenor := &model.Tenor{DurationMonths: 6}
+	suite.Require().NoError(suite.db.Create(tenor).Error)
+	suite.Require().NoError(suite.adminService.SetLimits(suite.ctx, customer.ID, 1, dto.SetLimits{
+		Limits: []dto.LimitItemRequest{{TenorMonths: 6, LimitAmount: decimal.NewFromInt(2000)}},
+	}))
+	suite.Require().NoError(suite.db.Create(&model.Transaction{
+		CustomerID:      customer.ID,
+		TenorID:         tenor.ID,
+		ContractNumber:  "KTR-USED-1",
+		AssetName:       "Used Asset",
+		OTRAmount:       decimal.NewFromInt(1400),
+		AdminFee:        decimal.NewFromInt(100),
+		FxRate:          decimal.NewFromInt(1),
+		TransactionDate: time.Now(),
+		Status:          model.TransactionActive,
+	}).Error)
+	version := uint64(1)
+
+	suite.T().Run("Failure - Rejected Without Force", func(t *testing.T) {
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, dto.SetLimits{
+			Limits: []dto.LimitItemRequest{{TenorMonths: 6, LimitAmount: decimal.NewFromInt(1000), Version: &version}},
+		})
+
+		assert.ErrorIs(t, err, common.ErrLimitBelowUsage)
+		var unchanged model.CustomerLimit
+		suite.db.Where("customer_id = ? AND tenor_id = ? AND effective_to IS NULL", customer.ID, tenor.ID).First(&unchanged)
+		assert.Equal(t, "2000", unchanged.LimitAmount.String())
+	})
+
+	suite.T().Run("Success - Usage Still Covered", func(t *testing.T) {
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, dto.SetLimits{
+			Limits: []dto.LimitItemRequest{{TenorMonths: 6, LimitAmount: decimal.NewFromInt(1500), Version: &version}},
+		})
+
+		assert.NoError(t, err)
+		version++
+	})
+
+	suite.T().Run("Success - Forced With Reason", func(t *testing.T) {
+		err := suite.adminService.SetLimits(suite.ctx, customer.ID, 1, dto.SetLimits{
+			Limits: []dto.LimitItemRequest{{TenorMonths: 6, LimitAmount: decimal.NewFromInt(1000), Version: &version}},
+			Force:  true,
+			Reason: "Penurunan limit karena skor kredit turun",
+		})
+
+		assert.NoError(t, err)
+		var event model.CustomerEvent
+		suite.db.Where("customer_id = ? AND type = ?", customer.ID, domain.CustomerLimitSet).Order("id desc").First(&event)
+		assert.Equal(t, "Penurunan limit karena skor kredit turun", event.Data["forced_below_usage"])
+		assert.Equal(t, "1500 IDR", event.Data["tenor_6_months_used"])
+	})
+}
+
 func TestAdminServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(AdminServiceTestSuite))
 }
@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	adminsrv "github.com/fazamuttaqien/multifinance/internal/service/admin"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminService_ListCustomers_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-admin-service-unit")
+	adminService := adminsrv.NewAdminService(nil, customerRepository, mocks.NewMockLimitRepository(ctrl), nil, nil, meter, tracer, log)
+
+	params := domain.Params{Filters: []query.Condition{{Column: "verification_status", Value: string(domain.VerificationPending)}}, Page: 2, Limit: 2}
+	customerRepository.EXPECT().
+		FindPaginated(gomock.Any(), params).
+		Return([]domain.Customer{{ID: 3}, {ID: 4}}, int64(5), nil)
+
+	result, err := adminService.ListCustomers(context.Background(), params)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.Total)
+	assert.Equal(t, 2, result.Page)
+	assert.Equal(t, 3, result.TotalPages)
+}
+
+func TestAdminService_GetCustomerByID_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-admin-service-unit")
+	adminService := adminsrv.NewAdminService(nil, customerRepository, mocks.NewMockLimitRepository(ctrl), nil, nil, meter, tracer, log)
+
+	t.Run("Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		customer, err := adminService.GetCustomerByID(context.Background(), 99)
+
+		assert.Nil(t, customer)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		repoErr := errors.New("connection reset")
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(nil, repoErr)
+
+		customer, err := adminService.GetCustomerByID(context.Background(), 7)
+
+		assert.Nil(t, customer)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
+
+func TestAdminService_GetLimitHistory_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	limitRepository := mocks.NewMockLimitRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-admin-service-unit")
+	adminService := adminsrv.NewAdminService(nil, customerRepository, limitRepository, nil, nil, meter, tracer, log)
+
+	t.Run("Success", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3}, nil)
+		limitRepository.EXPECT().FindHistoryByCustomerID(gomock.Any(), uint64(3)).
+			Return([]domain.LimitChange{{ID: 8, CustomerID: 3, TenorMonths: 6, ChangedBy: 1}}, nil)
+
+		history, err := adminService.GetLimitHistory(context.Background(), 3)
+
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, uint64(1), history[0].ChangedBy)
+	})
+
+	t.Run("Customer Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		history, err := adminService.GetLimitHistory(context.Background(), 99)
+
+		assert.Nil(t, history)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
+
+func TestAdminService_GetLimitsAt_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	limitRepository := mocks.NewMockLimitRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-admin-service-unit")
+	adminService := adminsrv.NewAdminService(nil, customerRepository, limitRepository, nil, nil, meter, tracer, log)
+
+	at := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3}, nil)
+		limitRepository.EXPECT().FindAllByCustomerID(gomock.Any(), uint64(3), at).
+			Return([]domain.CustomerLimit{{CustomerID: 3, TenorID: 1, Version: 2, EffectiveFrom: at.AddDate(0, 0, -10)}}, nil)
+
+		limits, err := adminService.GetLimitsAt(context.Background(), 3, at)
+
+		require.NoError(t, err)
+		require.Len(t, limits, 1)
+		assert.True(t, limits[0].InForce(at))
+	})
+
+	t.Run("Customer Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		limits, err := adminService.GetLimitsAt(context.Background(), 99, at)
+
+		assert.Nil(t, limits)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
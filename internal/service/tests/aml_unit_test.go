@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	amlsrv "github.com/fazamuttaqien/multifinance/internal/service/aml"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAMLService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-aml-service")
+	now := time.Date(2025, 6, 30, 9, 0, 0, 0, time.UTC)
+	cfg := amlsrv.Config{
+		LargeAmount: decimal.NewFromInt(500_000_000),
+		RapidCount:  3,
+		RapidWindow: 24 * time.Hour,
+		BatchSize:   50,
+	}
+
+	setup := func(t *testing.T) *mocks.MockAMLRepository {
+		return mocks.NewMockAMLRepository(gomock.NewController(t))
+	}
+
+	t.Run("Screen - Nothing To Screen", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		amlRepository.EXPECT().FindUnscreened(gomock.Any(), 50).Return(nil, nil)
+
+		run, err := amlService.Screen(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Screened)
+		assert.Equal(t, 0, run.Flagged)
+	})
+
+	t.Run("Screen - Repository Error", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		amlRepository.EXPECT().FindUnscreened(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		run, err := amlService.Screen(context.Background(), now)
+
+		assert.Nil(t, run)
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("Screen - Rapid Count Error", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		date := now.Add(-time.Hour)
+		dbErr := errors.New("connection refused")
+		amlRepository.EXPECT().FindUnscreened(gomock.Any(), 50).
+			Return([]domain.Transaction{{ID: 11, CustomerID: 7, OTRAmount: decimal.NewFromInt(10_000_000), TransactionDate: date}}, nil)
+		amlRepository.EXPECT().CountRecent(gomock.Any(), uint64(7), date.Add(-24*time.Hour), date).Return(int64(0), dbErr)
+
+		run, err := amlService.Screen(context.Background(), now)
+
+		assert.Nil(t, run)
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("GetCase - Not Found", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		amlRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(3)).Return(nil, nil)
+
+		amlCase, err := amlService.GetCase(context.Background(), 3)
+
+		assert.Nil(t, amlCase)
+		assert.ErrorIs(t, err, common.ErrAMLCaseNotFound)
+	})
+
+	t.Run("UpdateStatus - Review Open Case", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		amlRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(3)).Return(&domain.AMLCase{ID: 3, Status: domain.AMLCaseOpen}, nil)
+		amlRepository.EXPECT().UpdateCase(gomock.Any(), gomock.Any(), domain.AMLCaseOpen).Return(true, nil)
+
+		amlCase, err := amlService.UpdateStatus(context.Background(), 3, 1, dto.AMLCaseStatusRequest{Status: domain.AMLCaseReviewed, Note: "salary slip explains the purchase"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AMLCaseReviewed, amlCase.Status)
+		assert.Equal(t, "salary slip explains the purchase", amlCase.ReviewNote)
+		require.NotNil(t, amlCase.ReviewedBy)
+		assert.Equal(t, uint64(1), *amlCase.ReviewedBy)
+		assert.Nil(t, amlCase.ReportedAt)
+	})
+
+	t.Run("UpdateStatus - Report Keeps Reviewer", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		reviewer := uint64(2)
+		amlRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(3)).
+			Return(&domain.AMLCase{ID: 3, Status: domain.AMLCaseReviewed, ReviewedBy: &reviewer, ReviewNote: "escalate"}, nil)
+		amlRepository.EXPECT().UpdateCase(gomock.Any(), gomock.Any(), domain.AMLCaseReviewed).Return(true, nil)
+
+		amlCase, err := amlService.UpdateStatus(context.Background(), 3, 1, dto.AMLCaseStatusRequest{Status: domain.AMLCaseReported, ReportReference: "LTKM-2025-0042"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AMLCaseReported, amlCase.Status)
+		assert.Equal(t, "LTKM-2025-0042", amlCase.ReportReference)
+		assert.Equal(t, "escalate", amlCase.ReviewNote)
+		assert.Equal(t, uint64(2), *amlCase.ReviewedBy)
+		assert.NotNil(t, amlCase.ReportedAt)
+	})
+
+	t.Run("UpdateStatus - Reported Case Is Final", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		amlRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(3)).Return(&domain.AMLCase{ID: 3, Status: domain.AMLCaseReported}, nil)
+
+		amlCase, err := amlService.UpdateStatus(context.Background(), 3, 1, dto.AMLCaseStatusRequest{Status: domain.AMLCaseReviewed})
+
+		assert.Nil(t, amlCase)
+		assert.ErrorIs(t, err, common.ErrInvalidAMLTransition)
+	})
+
+	t.Run("UpdateStatus - Lost Race", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		amlRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(3)).Return(&domain.AMLCase{ID: 3, Status: domain.AMLCaseOpen}, nil)
+		amlRepository.EXPECT().UpdateCase(gomock.Any(), gomock.Any(), domain.AMLCaseOpen).Return(false, nil)
+
+		amlCase, err := amlService.UpdateStatus(context.Background(), 3, 1, dto.AMLCaseStatusRequest{Status: domain.AMLCaseReviewed})
+
+		assert.Nil(t, amlCase)
+		assert.ErrorIs(t, err, common.ErrInvalidAMLTransition)
+	})
+
+	t.Run("Export - Inclusive Range", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		amlRepository.EXPECT().FindForExport(gomock.Any(),
+			time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			domain.AMLCaseReported,
+		).Return([]domain.AMLCase{{ID: 3}}, nil)
+
+		cases, err := amlService.Export(context.Background(), "2025-06-01", "2025-06-30", domain.AMLCaseReported)
+
+		require.NoError(t, err)
+		assert.Len(t, cases, 1)
+	})
+
+	t.Run("Export - Invalid Ranges", func(t *testing.T) {
+		amlRepository := setup(t)
+		amlService := amlsrv.NewAMLService(nil, amlRepository, cfg, meter, tracer, log)
+
+		for _, r := range [][2]string{{"", "2025-06-30"}, {"2025-06-30", "2025-06-01"}, {"2024-01-01", "2025-06-30"}} {
+			cases, err := amlService.Export(context.Background(), r[0], r[1], "")
+
+			assert.Nil(t, cases)
+			assert.ErrorIs(t, err, common.ErrInvalidAMLExportRange, r)
+		}
+	})
+}
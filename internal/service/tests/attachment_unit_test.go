@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	attachmentsrv "github.com/fazamuttaqien/multifinance/internal/service/attachment"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var pdfAttachment = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+type attachmentMocks struct {
+	attachmentRepository *mocks.MockTransactionAttachmentRepository
+	cloudinaryService    *servicemocks.MockCloudinaryService
+}
+
+func newAttachmentService(t *testing.T) (*attachmentMocks, service.TransactionAttachmentServices) {
+	meter, tracer, log := testutil.Telemetry("test-attachment-service")
+
+	ctrl := gomock.NewController(t)
+	m := &attachmentMocks{
+		attachmentRepository: mocks.NewMockTransactionAttachmentRepository(ctrl),
+		cloudinaryService:    servicemocks.NewMockCloudinaryService(ctrl),
+	}
+	cfg := attachmentsrv.Config{Folder: "attachments", MaxSize: 1024}
+	return m, attachmentsrv.NewTransactionAttachmentService(m.attachmentRepository, m.cloudinaryService, cfg, meter, tracer, log)
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	partnerID := uint64(4)
+	owner := domain.BatchOwner{PartnerID: &partnerID}
+	req := dto.TransactionAttachmentRequest{Type: domain.AttachmentInvoice}
+
+	t.Run("Success - Stored With Sniffed Type", func(t *testing.T) {
+		m, attachmentService := newAttachmentService(t)
+
+		m.attachmentRepository.EXPECT().FindTransaction(gomock.Any(), uint64(9)).
+			Return(&domain.Transaction{ID: 9, CustomerID: 2, PartnerID: &partnerID}, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), pdfAttachment, "attachments", gomock.Any()).
+			Return("https://example.com/invoice.pdf", nil)
+		m.attachmentRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, attachment *domain.TransactionAttachment) error {
+				assert.Equal(t, "application/pdf", attachment.ContentType)
+				assert.Equal(t, "invoice.pdf", attachment.FileName)
+				assert.Equal(t, &partnerID, attachment.PartnerID)
+				attachment.ID = 1
+				return nil
+			})
+
+		attachment, err := attachmentService.UploadAttachment(context.Background(), owner, 9, req, "C:\\scans\\invoice.pdf", pdfAttachment)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), attachment.ID)
+		assert.Equal(t, int64(len(pdfAttachment)), attachment.Size)
+	})
+
+	t.Run("Failure - Another Partner's Transaction", func(t *testing.T) {
+		m, attachmentService := newAttachmentService(t)
+
+		otherPartner := uint64(5)
+		m.attachmentRepository.EXPECT().FindTransaction(gomock.Any(), uint64(9)).
+			Return(&domain.Transaction{ID: 9, PartnerID: &otherPartner}, nil)
+
+		_, err := attachmentService.UploadAttachment(context.Background(), owner, 9, req, "invoice.pdf", pdfAttachment)
+
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+	})
+
+	t.Run("Failure - Unsupported Content", func(t *testing.T) {
+		_, attachmentService := newAttachmentService(t)
+
+		_, err := attachmentService.UploadAttachment(context.Background(), owner, 9, req, "invoice.pdf", []byte("just some text"))
+
+		assert.ErrorIs(t, err, common.ErrUnsupportedAttachment)
+	})
+
+	t.Run("Failure - Too Large", func(t *testing.T) {
+		_, attachmentService := newAttachmentService(t)
+
+		_, err := attachmentService.UploadAttachment(context.Background(), owner, 9, req, "invoice.pdf", make([]byte, 1025))
+
+		assert.ErrorIs(t, err, common.ErrAttachmentTooLarge)
+	})
+}
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	t.Run("Success - Soft Deleted", func(t *testing.T) {
+		m, attachmentService := newAttachmentService(t)
+
+		m.attachmentRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).
+			Return(&domain.TransactionAttachment{ID: 3, TransactionID: 9}, nil)
+		m.attachmentRepository.EXPECT().Delete(gomock.Any(), uint64(3), uint64(1), gomock.Any()).Return(true, nil)
+
+		require.NoError(t, attachmentService.DeleteAttachment(context.Background(), 9, 3, 1))
+	})
+
+	t.Run("Failure - Already Deleted", func(t *testing.T) {
+		m, attachmentService := newAttachmentService(t)
+
+		deletedAt := time.Now()
+		m.attachmentRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).
+			Return(&domain.TransactionAttachment{ID: 3, TransactionID: 9, DeletedAt: &deletedAt}, nil)
+
+		err := attachmentService.DeleteAttachment(context.Background(), 9, 3, 1)
+
+		assert.ErrorIs(t, err, common.ErrAttachmentNotFound)
+	})
+
+	t.Run("Failure - Other Transaction", func(t *testing.T) {
+		m, attachmentService := newAttachmentService(t)
+
+		m.attachmentRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).
+			Return(&domain.TransactionAttachment{ID: 3, TransactionID: 8}, nil)
+
+		err := attachmentService.DeleteAttachment(context.Background(), 9, 3, 1)
+
+		assert.ErrorIs(t, err, common.ErrAttachmentNotFound)
+	})
+}
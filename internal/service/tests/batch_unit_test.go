@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	batchsrv "github.com/fazamuttaqien/multifinance/internal/service/batch"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type batchMocks struct {
+	batchRepository *mocks.MockTransactionBatchRepository
+	partnerService  *servicemocks.MockPartnerServices
+}
+
+func newBatchService(t *testing.T) (*batchMocks, service.TransactionBatchServices) {
+	meter, tracer, log := testutil.Telemetry("test-batch-service")
+
+	ctrl := gomock.NewController(t)
+	m := &batchMocks{
+		batchRepository: mocks.NewMockTransactionBatchRepository(ctrl),
+		partnerService:  servicemocks.NewMockPartnerServices(ctrl),
+	}
+	cfg := batchsrv.Config{MaxItems: 3, BatchSize: 10}
+	return m, batchsrv.NewBatchService(m.batchRepository, m.partnerService, cfg, meter, tracer, log)
+}
+
+func TestBatchService_Submit(t *testing.T) {
+	partnerID := uint64(7)
+	owner := domain.BatchOwner{PartnerID: &partnerID}
+	item := dto.CreateTransactionRequest{CustomerNIK: "1234567890123456", TenorMonths: 6, AssetName: "Motor", OTRAmount: decimal.NewFromInt(15000000)}
+
+	t.Run("Success - Items Enqueued In Order", func(t *testing.T) {
+		m, batchService := newBatchService(t)
+
+		second := item
+		second.CustomerNIK = "6543210987654321"
+		m.batchRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, batch *domain.TransactionBatch) error {
+				batch.ID = 11
+				return nil
+			})
+
+		batch, err := batchService.Submit(context.Background(), owner, dto.CreateTransactionBatchRequest{Items: []dto.CreateTransactionRequest{item, second}})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), batch.ID)
+		assert.Equal(t, domain.BatchPending, batch.Status)
+		require.Len(t, batch.Items, 2)
+		assert.Equal(t, 2, batch.Items[1].Sequence)
+		assert.Equal(t, "6543210987654321", batch.Items[1].CustomerNIK)
+		assert.Equal(t, domain.BatchItemPending, batch.Items[1].Status)
+	})
+
+	t.Run("Failure - Too Many Items", func(t *testing.T) {
+		_, batchService := newBatchService(t)
+
+		items := []dto.CreateTransactionRequest{item, item, item, item}
+		batch, err := batchService.Submit(context.Background(), owner, dto.CreateTransactionBatchRequest{Items: items})
+
+		assert.ErrorIs(t, err, common.ErrBatchTooLarge)
+		assert.Nil(t, batch)
+	})
+}
+
+func TestBatchService_GetBatch(t *testing.T) {
+	partnerID, otherPartnerID := uint64(7), uint64(8)
+	stored := &domain.TransactionBatch{ID: 11, Owner: domain.BatchOwner{PartnerID: &partnerID}, Status: domain.BatchPending}
+
+	t.Run("Success - Sandbox Key Of Same Partner", func(t *testing.T) {
+		m, batchService := newBatchService(t)
+
+		m.batchRepository.EXPECT().FindByID(gomock.Any(), uint64(11)).Return(stored, nil)
+
+		batch, err := batchService.GetBatch(context.Background(), 11, domain.BatchOwner{PartnerID: &partnerID, Sandbox: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), batch.ID)
+	})
+
+	t.Run("Failure - Other Partner", func(t *testing.T) {
+		m, batchService := newBatchService(t)
+
+		m.batchRepository.EXPECT().FindByID(gomock.Any(), uint64(11)).Return(stored, nil)
+
+		_, err := batchService.GetBatch(context.Background(), 11, domain.BatchOwner{PartnerID: &otherPartnerID})
+
+		assert.ErrorIs(t, err, common.ErrBatchNotFound)
+	})
+
+	t.Run("Failure - Not Found", func(t *testing.T) {
+		m, batchService := newBatchService(t)
+
+		m.batchRepository.EXPECT().FindByID(gomock.Any(), uint64(12)).Return(nil, nil)
+
+		_, err := batchService.GetBatch(context.Background(), 12, domain.BatchOwner{PartnerID: &partnerID})
+
+		assert.ErrorIs(t, err, common.ErrBatchNotFound)
+	})
+}
+
+func TestBatchService_ProcessPending(t *testing.T) {
+	partnerID := uint64(7)
+
+	t.Run("Success - Failures Are Isolated Per Item", func(t *testing.T) {
+		m, batchService := newBatchService(t)
+
+		pending := []domain.TransactionBatch{{
+			ID:    11,
+			Owner: domain.BatchOwner{PartnerID: &partnerID, Sandbox: true},
+			Items: []domain.TransactionBatchItem{
+				{ID: 1, BatchID: 11, Sequence: 1, CustomerNIK: "1111111111111111", Status: domain.BatchItemPending},
+				{ID: 2, BatchID: 11, Sequence: 2, CustomerNIK: "2222222222222222", Status: domain.BatchItemPending},
+				{ID: 3, BatchID: 11, Sequence: 3, CustomerNIK: "3333333333333333", Status: domain.BatchItemPending},
+				{ID: 4, BatchID: 11, Sequence: 4, CustomerNIK: "4444444444444444", Status: domain.BatchItemPending},
+			},
+		}}
+		m.batchRepository.EXPECT().FindPending(gomock.Any(), 10).Return(pending, nil)
+		m.batchRepository.EXPECT().ClaimItem(gomock.Any(), uint64(1)).Return(true, nil)
+		m.batchRepository.EXPECT().ClaimItem(gomock.Any(), uint64(2)).Return(true, nil)
+		m.batchRepository.EXPECT().ClaimItem(gomock.Any(), uint64(3)).Return(true, nil)
+		// Item 4 sudah diambil proses lain
+		m.batchRepository.EXPECT().ClaimItem(gomock.Any(), uint64(4)).Return(false, nil)
+
+		m.partnerService.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req dto.CreateTransactionRequest) (*domain.Transaction, error) {
+				assert.Equal(t, &partnerID, req.PartnerID)
+				assert.True(t, req.Sandbox)
+				switch req.CustomerNIK {
+				case "1111111111111111":
+					return &domain.Transaction{ID: 90, ContractNumber: "KTR-90"}, nil
+				case "2222222222222222":
+					return nil, fmt.Errorf("create transaction: %w", common.ErrInsufficientLimit)
+				default:
+					panic("unexpected nil pointer")
+				}
+			}).Times(3)
+
+		results := map[uint64]domain.TransactionBatchItem{}
+		m.batchRepository.EXPECT().CompleteItem(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, item *domain.TransactionBatchItem) error {
+				results[item.ID] = *item
+				return nil
+			}).Times(3)
+		m.batchRepository.EXPECT().Complete(gomock.Any(), uint64(11), gomock.Any()).Return(false, nil)
+
+		processed, err := batchService.ProcessPending(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, processed)
+
+		assert.Equal(t, domain.BatchItemSucceeded, results[1].Status)
+		assert.Equal(t, uint64(90), *results[1].TransactionID)
+		assert.Equal(t, "KTR-90", results[1].ContractNumber)
+
+		assert.Equal(t, domain.BatchItemFailed, results[2].Status)
+		assert.Equal(t, "insufficient_limit", results[2].ErrorCode)
+
+		assert.Equal(t, domain.BatchItemFailed, results[3].Status)
+		assert.Equal(t, "service_error", results[3].ErrorCode)
+		assert.NotNil(t, results[3].ProcessedAt)
+	})
+}
@@ -0,0 +1,79 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	blacklistsrv "github.com/fazamuttaqien/multifinance/internal/service/blacklist"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlacklistService_Screen_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blacklistRepository := mocks.NewMockBlacklistRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-blacklist-service-unit")
+	blacklistService := blacklistsrv.NewBlacklistService(blacklistRepository, meter, tracer, log)
+
+	nik := "3201010101900001"
+
+	t.Run("No Hit", func(t *testing.T) {
+		blacklistRepository.EXPECT().FindMatches(gomock.Any(), nik, "Budi Santoso").Return(nil, nil)
+
+		result, err := blacklistService.Screen(context.Background(), domain.ScreeningRegistration, nil, nik, "  Budi   Santoso ")
+
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Flag Hit Is Logged And Allowed", func(t *testing.T) {
+		blacklistRepository.EXPECT().FindMatches(gomock.Any(), nik, "Budi Santoso").
+			Return([]domain.BlacklistEntry{{ID: 4, Severity: domain.SeverityFlag}}, nil)
+		blacklistRepository.EXPECT().CreateScreeningLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := blacklistService.Screen(context.Background(), domain.ScreeningRegistration, nil, nik, "Budi Santoso")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScreeningFlagged, result.Outcome)
+		assert.Equal(t, uint64(4), result.EntryID)
+	})
+
+	t.Run("Block Hit Wins Over Flag", func(t *testing.T) {
+		customerID := uint64(12)
+		blacklistRepository.EXPECT().FindMatches(gomock.Any(), nik, "Budi Santoso").
+			Return([]domain.BlacklistEntry{
+				{ID: 4, Severity: domain.SeverityFlag},
+				{ID: 7, Severity: domain.SeverityBlock},
+			}, nil)
+		blacklistRepository.EXPECT().CreateScreeningLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *domain.ScreeningLog) error {
+				assert.Equal(t, uint64(7), entry.EntryID)
+				assert.Equal(t, &customerID, entry.CustomerID)
+				return nil
+			})
+
+		result, err := blacklistService.Screen(context.Background(), domain.ScreeningTransaction, &customerID, nik, "Budi Santoso")
+
+		assert.ErrorIs(t, err, common.ErrBlacklisted)
+		assert.Equal(t, domain.ScreeningBlocked, result.Outcome)
+	})
+}
+
+func TestBlacklistService_DeleteEntry_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blacklistRepository := mocks.NewMockBlacklistRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-blacklist-service-unit")
+	blacklistService := blacklistsrv.NewBlacklistService(blacklistRepository, meter, tracer, log)
+
+	blacklistRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+	err := blacklistService.DeleteEntry(context.Background(), 99)
+
+	assert.ErrorIs(t, err, common.ErrBlacklistNotFound)
+}
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	calendarsrv "github.com/fazamuttaqien/multifinance/internal/service/calendar"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/apikey"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalendarService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-calendar-service")
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	cfg := calendarsrv.Config{Location: jakarta, Reminder: 24 * time.Hour}
+
+	const contractNumber = "MF-20250115-000001"
+	plain := calendarsrv.TokenPrefix + "abc123"
+	token := &domain.CalendarToken{ID: 3, CustomerID: 7, ContractNumber: contractNumber, TokenHash: apikey.Hash(plain)}
+
+	// Jatuh tempo 17:30 UTC sudah tanggal berikutnya di Jakarta
+	detail := &dto.TransactionDetailResponse{
+		ContractNumber:       contractNumber,
+		AssetName:            "Honda Beat",
+		Currency:             "IDR",
+		VirtualAccountBank:   "BCA",
+		VirtualAccountNumber: "8808000000000001",
+		Installments: []dto.InstallmentDetailResponse{
+			{Sequence: 1, DueDate: time.Date(2025, 2, 15, 17, 30, 0, 0, time.UTC), Amount: decimal.NewFromInt(1_000_000), Status: "PAID"},
+			{Sequence: 2, DueDate: time.Date(2025, 3, 15, 17, 30, 0, 0, time.UTC), Amount: decimal.NewFromInt(1_000_000), Status: "UPCOMING"},
+			{Sequence: 3, DueDate: time.Date(2025, 4, 15, 17, 30, 0, 0, time.UTC), Amount: decimal.NewFromInt(1_000_000), Status: "UPCOMING"},
+		},
+	}
+
+	setup := func(t *testing.T) (*servicemocks.MockProfileServices, *mocks.MockCustomerRepository, *mocks.MockCalendarTokenRepository) {
+		ctrl := gomock.NewController(t)
+		return servicemocks.NewMockProfileServices(ctrl), mocks.NewMockCustomerRepository(ctrl), mocks.NewMockCalendarTokenRepository(ctrl)
+	}
+
+	t.Run("Feed - Upcoming Installments Only", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		tokenRepository.EXPECT().FindActiveByHash(gomock.Any(), apikey.Hash(plain)).Return(token, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7, Language: domain.LanguageEnglish}, nil)
+		profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(7), contractNumber).Return(detail, nil)
+
+		feed, err := calendarService.Feed(context.Background(), contractNumber, plain)
+
+		require.NoError(t, err)
+		ics := string(feed)
+		assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+		assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+		assert.NotContains(t, ics, "UID:"+contractNumber+"-1@")
+		assert.Contains(t, ics, "UID:"+contractNumber+"-2@multifinance")
+		assert.Contains(t, ics, "DTSTART;VALUE=DATE:20250316")
+		assert.Contains(t, ics, "SUMMARY:Installment 2/3 Honda Beat")
+		assert.Contains(t, ics, "TRIGGER:-P1D")
+		assert.Contains(t, ics, "BCA 8808000000000001")
+	})
+
+	t.Run("Feed - Token For Another Contract", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		tokenRepository.EXPECT().FindActiveByHash(gomock.Any(), apikey.Hash(plain)).Return(token, nil)
+
+		_, err := calendarService.Feed(context.Background(), "MF-20250115-000002", plain)
+
+		assert.ErrorIs(t, err, common.ErrCalendarTokenInvalid)
+	})
+
+	t.Run("Feed - Revoked Token", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		tokenRepository.EXPECT().FindActiveByHash(gomock.Any(), apikey.Hash(plain)).Return(nil, nil)
+
+		_, err := calendarService.Feed(context.Background(), contractNumber, plain)
+
+		assert.ErrorIs(t, err, common.ErrCalendarTokenInvalid)
+	})
+
+	t.Run("Feed - Missing Token", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		_, err := calendarService.Feed(context.Background(), contractNumber, "")
+
+		assert.ErrorIs(t, err, common.ErrCalendarTokenInvalid)
+	})
+
+	t.Run("Feed - Closed Account", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		closedAt := time.Now()
+		tokenRepository.EXPECT().FindActiveByHash(gomock.Any(), gomock.Any()).Return(token, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7, ClosedAt: &closedAt}, nil)
+
+		_, err := calendarService.Feed(context.Background(), contractNumber, plain)
+
+		assert.ErrorIs(t, err, common.ErrCalendarTokenInvalid)
+	})
+
+	t.Run("CreateToken - Contract Of Another Customer", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(8), contractNumber).Return(nil, common.ErrTransactionNotFound)
+
+		_, err := calendarService.CreateToken(context.Background(), 8, contractNumber)
+
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+	})
+
+	t.Run("RevokeToken - No Active Link", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		tokenRepository.EXPECT().Revoke(gomock.Any(), uint64(7), contractNumber, gomock.Any()).Return(int64(0), nil)
+
+		err := calendarService.RevokeToken(context.Background(), 7, contractNumber)
+
+		assert.ErrorIs(t, err, common.ErrCalendarTokenNotFound)
+	})
+
+	t.Run("RevokeToken - Repository Error", func(t *testing.T) {
+		profileService, customerRepository, tokenRepository := setup(t)
+		calendarService := calendarsrv.NewCalendarService(nil, profileService, customerRepository, tokenRepository, cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		tokenRepository.EXPECT().Revoke(gomock.Any(), uint64(7), contractNumber, gomock.Any()).Return(int64(0), dbErr)
+
+		err := calendarService.RevokeToken(context.Background(), 7, contractNumber)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	closuresrv "github.com/fazamuttaqien/multifinance/internal/service/closure"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/password"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountClosureService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-closure-service")
+	now := time.Date(2025, 6, 30, 9, 0, 0, 0, time.UTC)
+	cfg := closuresrv.Config{CoolingOff: 30 * 24 * time.Hour, BatchSize: 50}
+
+	hashed, err := password.HashPassword("secret-pass1")
+	require.NoError(t, err)
+	customer := &domain.Customer{ID: 7, FullName: "Budi Santoso", Password: hashed}
+	req := dto.CloseAccountRequest{Password: "secret-pass1", Reason: "No longer need financing"}
+
+	setup := func(t *testing.T) (*mocks.MockCustomerRepository, *mocks.MockAccountClosureRepository, *servicemocks.MockCustomerNotifier) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockCustomerRepository(ctrl), mocks.NewMockAccountClosureRepository(ctrl), servicemocks.NewMockCustomerNotifier(ctrl)
+	}
+
+	t.Run("Close - Customer Not Found", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(nil, nil)
+
+		closure, err := closureService.Close(context.Background(), 7, req)
+
+		assert.Nil(t, closure)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+
+	t.Run("Close - Already Closed", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		closedAt := now.AddDate(0, 0, -3)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7, Password: hashed, ClosedAt: &closedAt}, nil)
+
+		_, err := closureService.Close(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrAccountClosed)
+	})
+
+	t.Run("Close - Wrong Password", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(customer, nil)
+
+		_, err := closureService.Close(context.Background(), 7, dto.CloseAccountRequest{Password: "wrong-pass1", Reason: req.Reason})
+
+		assert.ErrorIs(t, err, common.ErrInvalidCredentials)
+	})
+
+	t.Run("Close - Running Contracts", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(customer, nil)
+		closureRepository.EXPECT().Blockers(gomock.Any(), uint64(7)).Return(&domain.ClosureBlockers{ActiveContracts: 2}, nil)
+
+		_, err := closureService.Close(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrActiveContracts)
+	})
+
+	t.Run("Close - Outstanding Balance", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(customer, nil)
+		closureRepository.EXPECT().Blockers(gomock.Any(), uint64(7)).Return(&domain.ClosureBlockers{OutstandingPrincipal: decimal.NewFromInt(1_500_000)}, nil)
+
+		_, err := closureService.Close(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrOutstandingBalance)
+	})
+
+	t.Run("Close - Repository Error", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(customer, nil)
+		closureRepository.EXPECT().Blockers(gomock.Any(), uint64(7)).Return(nil, dbErr)
+
+		_, err := closureService.Close(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("Authorize - Closed Account", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		closureRepository.EXPECT().IsClosed(gomock.Any(), uint64(7)).Return(true, nil)
+
+		assert.ErrorIs(t, closureService.Authorize(context.Background(), 7), common.ErrAccountClosed)
+	})
+
+	t.Run("Authorize - Open Account", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		closureRepository.EXPECT().IsClosed(gomock.Any(), uint64(7)).Return(false, nil)
+
+		assert.NoError(t, closureService.Authorize(context.Background(), 7))
+	})
+
+	t.Run("AnonymizeDue - Nothing Due", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		closureRepository.EXPECT().FindDue(gomock.Any(), now, 50).Return(nil, nil)
+
+		run, err := closureService.AnonymizeDue(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Anonymized)
+	})
+
+	t.Run("AnonymizeDue - Repository Error", func(t *testing.T) {
+		customerRepository, closureRepository, notifier := setup(t)
+		closureService := closuresrv.NewAccountClosureService(nil, customerRepository, closureRepository, notifier, cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		closureRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		run, err := closureService.AnonymizeDue(context.Background(), now)
+
+		assert.Nil(t, run)
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
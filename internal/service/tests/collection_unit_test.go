@@ -0,0 +1,330 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	collectionsrv "github.com/fazamuttaqien/multifinance/internal/service/collection"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type collectionMocks struct {
+	collectionRepository *mocks.MockCollectionRepository
+	customerRepository   *mocks.MockCustomerRepository
+}
+
+func newCollectionService(t *testing.T) (*collectionMocks, service.CollectionServices) {
+	meter, tracer, log := testutil.Telemetry("test-collection-service")
+
+	ctrl := gomock.NewController(t)
+	m := &collectionMocks{
+		collectionRepository: mocks.NewMockCollectionRepository(ctrl),
+		customerRepository:   mocks.NewMockCustomerRepository(ctrl),
+	}
+	cfg := collectionsrv.Config{MinDaysPastDue: 90, BatchSize: 2}
+	return m, collectionsrv.NewCollectionService(m.collectionRepository, m.customerRepository, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+}
+
+func TestCollectionService_OpenCases(t *testing.T) {
+	now := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	contractDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	exposure := func(id uint64, months uint8, paid int, total int64) domain.AgingExposure {
+		return domain.AgingExposure{
+			TransactionID:          id,
+			CustomerID:             id + 100,
+			TenorMonths:            months,
+			TransactionDate:        contractDate,
+			PaidInstallments:       &paid,
+			TotalInstallmentAmount: decimal.NewFromInt(total),
+			FxRate:                 decimal.NewFromInt(1),
+		}
+	}
+
+	t.Run("Success - Opens Cases Past The Threshold", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindExposures(gomock.Any(), uint64(0), 2).
+			Return([]domain.AgingExposure{exposure(10, 12, 0, 12_000_000), exposure(11, 12, 5, 12_000_000)}, nil)
+		m.collectionRepository.EXPECT().FindExposures(gomock.Any(), uint64(11), 2).
+			Return([]domain.AgingExposure{exposure(12, 6, 1, 6_000_000)}, nil)
+
+		var opened []domain.CollectionCase
+		m.collectionRepository.EXPECT().CreateCase(gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(_ context.Context, collectionCase *domain.CollectionCase) error {
+				opened = append(opened, *collectionCase)
+				return nil
+			})
+
+		run, err := collectionService.OpenCases(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, run.Scanned)
+		assert.Equal(t, 2, run.Opened)
+
+		require.Len(t, opened, 2)
+		// Cicilan pertama jatuh tempo 1 Februari, lima cicilan sudah jatuh tempo
+		assert.Equal(t, uint64(10), opened[0].TransactionID)
+		assert.Equal(t, uint64(110), opened[0].CustomerID)
+		assert.Equal(t, domain.CollectionOpen, opened[0].Status)
+		assert.Equal(t, 120, opened[0].DaysPastDue)
+		assert.True(t, decimal.NewFromInt(5_000_000).Equal(opened[0].OverdueAmount), opened[0].OverdueAmount.String())
+
+		assert.Equal(t, uint64(12), opened[1].TransactionID)
+		assert.Equal(t, 92, opened[1].DaysPastDue)
+		assert.True(t, decimal.NewFromInt(4_000_000).Equal(opened[1].OverdueAmount), opened[1].OverdueAmount.String())
+	})
+
+	t.Run("Failure - Repository Error", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		dbErr := errors.New("connection refused")
+		m.collectionRepository.EXPECT().FindExposures(gomock.Any(), uint64(0), 2).Return(nil, dbErr)
+
+		run, err := collectionService.OpenCases(context.Background(), now)
+
+		assert.Nil(t, run)
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
+
+func TestCollectionService_Assign(t *testing.T) {
+	collectorID := uint64(4)
+	req := dto.CollectionAssignRequest{CollectorID: &collectorID}
+
+	t.Run("Success", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionInProgress}, nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), collectorID).
+			Return(&domain.Customer{ID: collectorID, Role: domain.AdminRole}, nil)
+		m.collectionRepository.EXPECT().UpdateCase(gomock.Any(), gomock.Any(), domain.CollectionInProgress).Return(true, nil)
+
+		collectionCase, err := collectionService.Assign(context.Background(), 7, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, collectorID, *collectionCase.CollectorID)
+	})
+
+	t.Run("Success - Unassign", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionOpen, CollectorID: &collectorID}, nil)
+		m.collectionRepository.EXPECT().UpdateCase(gomock.Any(), gomock.Any(), domain.CollectionOpen).Return(true, nil)
+
+		collectionCase, err := collectionService.Assign(context.Background(), 7, dto.CollectionAssignRequest{})
+
+		require.NoError(t, err)
+		assert.Nil(t, collectionCase.CollectorID)
+	})
+
+	t.Run("Failure - Collector Is Not An Admin", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionOpen}, nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), collectorID).
+			Return(&domain.Customer{ID: collectorID, Role: domain.CustomerRole}, nil)
+
+		_, err := collectionService.Assign(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrCollectorNotFound)
+	})
+
+	t.Run("Failure - Closed Case", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionResolved}, nil)
+
+		_, err := collectionService.Assign(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrCollectionCaseClosed)
+	})
+
+	t.Run("Failure - Moved Concurrently", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionOpen}, nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), collectorID).
+			Return(&domain.Customer{ID: collectorID, Role: domain.AdminRole}, nil)
+		m.collectionRepository.EXPECT().UpdateCase(gomock.Any(), gomock.Any(), domain.CollectionOpen).Return(false, nil)
+
+		_, err := collectionService.Assign(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+	})
+}
+
+func TestCollectionService_UpdateStatus(t *testing.T) {
+	t.Run("Success - Repossessed Closes The Case", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionRepossession}, nil)
+		m.collectionRepository.EXPECT().UpdateCase(gomock.Any(), gomock.Any(), domain.CollectionRepossession).Return(true, nil)
+
+		collectionCase, err := collectionService.UpdateStatus(context.Background(), 7, dto.CollectionCaseStatusRequest{Status: domain.CollectionRepossessed})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CollectionRepossessed, collectionCase.Status)
+		assert.NotNil(t, collectionCase.ClosedAt)
+	})
+
+	t.Run("Failure - Skips Repossession", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionOpen}, nil)
+
+		_, err := collectionService.UpdateStatus(context.Background(), 7, dto.CollectionCaseStatusRequest{Status: domain.CollectionRepossessed})
+
+		assert.ErrorIs(t, err, common.ErrInvalidCollectionMove)
+	})
+
+	t.Run("Failure - Not Found", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).Return(nil, nil)
+
+		_, err := collectionService.UpdateStatus(context.Background(), 7, dto.CollectionCaseStatusRequest{Status: domain.CollectionResolved})
+
+		assert.ErrorIs(t, err, common.ErrCollectionCaseNotFound)
+	})
+}
+
+func TestCollectionService_AddActivity(t *testing.T) {
+	amount := decimal.NewFromInt(1_500_000)
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	t.Run("Success - Promise To Pay", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionOpen}, nil)
+		m.collectionRepository.EXPECT().AddActivity(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, activity *domain.CollectionActivity) (bool, error) {
+				activity.ID = 3
+				return true, nil
+			})
+
+		activity, err := collectionService.AddActivity(context.Background(), 7, 1, dto.CollectionActivityRequest{
+			Type:          domain.CollectionPromiseToPay,
+			Note:          "Akan transfer setelah gajian",
+			PromiseAmount: &amount,
+			PromiseDate:   tomorrow,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), activity.ID)
+		assert.Equal(t, uint64(1), activity.CreatedBy)
+		assert.Equal(t, tomorrow, activity.PromiseDate.Format("2006-01-02"))
+		assert.True(t, amount.Equal(*activity.PromiseAmount))
+	})
+
+	t.Run("Success - Call Ignores Promise Fields", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionInProgress}, nil)
+		m.collectionRepository.EXPECT().AddActivity(gomock.Any(), gomock.Any()).Return(true, nil)
+
+		activity, err := collectionService.AddActivity(context.Background(), 7, 1, dto.CollectionActivityRequest{
+			Type:          domain.CollectionCall,
+			Note:          "Tidak diangkat",
+			PromiseAmount: &amount,
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, activity.PromiseAmount)
+		assert.Nil(t, activity.PromiseDate)
+	})
+
+	t.Run("Failure - Promise In The Past", func(t *testing.T) {
+		_, collectionService := newCollectionService(t)
+
+		_, err := collectionService.AddActivity(context.Background(), 7, 1, dto.CollectionActivityRequest{
+			Type:          domain.CollectionPromiseToPay,
+			Note:          "Janji bayar",
+			PromiseAmount: &amount,
+			PromiseDate:   "2020-01-01",
+		})
+
+		assert.ErrorIs(t, err, common.ErrInvalidPromiseToPay)
+	})
+
+	t.Run("Failure - Promise Without Amount", func(t *testing.T) {
+		_, collectionService := newCollectionService(t)
+
+		_, err := collectionService.AddActivity(context.Background(), 7, 1, dto.CollectionActivityRequest{
+			Type:        domain.CollectionPromiseToPay,
+			Note:        "Janji bayar",
+			PromiseDate: tomorrow,
+		})
+
+		assert.ErrorIs(t, err, common.ErrInvalidPromiseToPay)
+	})
+
+	t.Run("Failure - Closed Case", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionRepossessed}, nil)
+
+		_, err := collectionService.AddActivity(context.Background(), 7, 1, dto.CollectionActivityRequest{Type: domain.CollectionVisit, Note: "Kunjungan"})
+
+		assert.ErrorIs(t, err, common.ErrCollectionCaseClosed)
+	})
+
+	t.Run("Failure - Closed Concurrently", func(t *testing.T) {
+		m, collectionService := newCollectionService(t)
+
+		m.collectionRepository.EXPECT().FindCaseByID(gomock.Any(), uint64(7)).
+			Return(&domain.CollectionCase{ID: 7, Status: domain.CollectionInProgress}, nil)
+		m.collectionRepository.EXPECT().AddActivity(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		_, err := collectionService.AddActivity(context.Background(), 7, 1, dto.CollectionActivityRequest{Type: domain.CollectionVisit, Note: "Kunjungan"})
+
+		assert.ErrorIs(t, err, common.ErrCollectionCaseClosed)
+	})
+}
+
+func TestCollectionService_Dashboard(t *testing.T) {
+	m, collectionService := newCollectionService(t)
+
+	now := time.Date(2025, 6, 1, 15, 30, 0, 0, time.UTC)
+	m.collectionRepository.EXPECT().Dashboard(gomock.Any(), time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)).
+		Return(&domain.CollectionDashboard{Unassigned: 2, Collectors: []domain.CollectorLoad{{CollectorID: 4, ActiveCases: 3}}}, nil)
+
+	dashboard, err := collectionService.Dashboard(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), dashboard.Unassigned)
+	assert.Len(t, dashboard.Collectors, 1)
+}
+
+func TestCollectionCaseStatus_CanMoveTo(t *testing.T) {
+	assert.True(t, domain.CollectionOpen.CanMoveTo(domain.CollectionInProgress))
+	assert.True(t, domain.CollectionOpen.CanMoveTo(domain.CollectionRepossession))
+	assert.True(t, domain.CollectionRepossession.CanMoveTo(domain.CollectionResolved))
+	assert.False(t, domain.CollectionInProgress.CanMoveTo(domain.CollectionOpen))
+	assert.False(t, domain.CollectionInProgress.CanMoveTo(domain.CollectionRepossessed))
+	assert.False(t, domain.CollectionResolved.CanMoveTo(domain.CollectionInProgress))
+	assert.True(t, domain.CollectionRepossessed.Closed())
+	assert.False(t, domain.CollectionRepossession.Closed())
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	communicationsrv "github.com/fazamuttaqien/multifinance/internal/service/communication"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	notifiersrv "github.com/fazamuttaqien/multifinance/internal/service/notifier"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCommunicationService_ListByCustomer_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	communicationRepository := mocks.NewMockCommunicationRepository(ctrl)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-communication-service")
+	communicationService := communicationsrv.NewCommunicationService(communicationRepository, customerRepository, meter, tracer, log)
+
+	params := domain.Params{Page: 1, Limit: 10}
+
+	t.Run("Success", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2}, nil)
+		communicationRepository.EXPECT().FindByCustomer(gomock.Any(), uint64(2), params).
+			Return([]domain.Communication{{ID: 5, CustomerID: 2, Template: domain.TemplateVerificationReminder}}, int64(1), nil)
+
+		res, err := communicationService.ListByCustomer(context.Background(), 2, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.Total)
+		assert.Len(t, res.Data, 1)
+	})
+
+	t.Run("Failure - Customer Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(9)).Return(nil, nil)
+
+		res, err := communicationService.ListByCustomer(context.Background(), 9, params)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
+
+func TestRecordingNotifier_Notify(t *testing.T) {
+	_, _, log := testutil.Telemetry("test-recording-notifier")
+	data := map[string]string{"full_name": "Budi Santoso"}
+
+	t.Run("Records Sent Notification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := servicemocks.NewMockCustomerNotifier(ctrl)
+		communicationRepository := mocks.NewMockCommunicationRepository(ctrl)
+		notifier := notifiersrv.NewRecordingNotifier(next, domain.ChannelEmail, communicationRepository, log)
+
+		next.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplateVerificationReminder, data).Return(nil)
+		communicationRepository.EXPECT().Create(gomock.Any(), &domain.Communication{
+			CustomerID: 2,
+			Channel:    domain.ChannelEmail,
+			Template:   domain.TemplateVerificationReminder,
+			Data:       data,
+			Status:     domain.CommunicationSent,
+		}).Return(nil)
+
+		assert.NoError(t, notifier.Notify(context.Background(), 2, domain.TemplateVerificationReminder, data))
+	})
+
+	t.Run("Records Failure And Returns Send Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := servicemocks.NewMockCustomerNotifier(ctrl)
+		communicationRepository := mocks.NewMockCommunicationRepository(ctrl)
+		notifier := notifiersrv.NewRecordingNotifier(next, domain.ChannelEmail, communicationRepository, log)
+
+		sendErr := errors.New("smtp down")
+		next.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplateVerificationExpired, data).Return(sendErr)
+		communicationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, communication *domain.Communication) error {
+				assert.Equal(t, domain.CommunicationFailed, communication.Status)
+				assert.Equal(t, "smtp down", communication.Error)
+				return nil
+			})
+
+		assert.ErrorIs(t, notifier.Notify(context.Background(), 2, domain.TemplateVerificationExpired, data), sendErr)
+	})
+
+	t.Run("Log Failure Does Not Fail Notification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := servicemocks.NewMockCustomerNotifier(ctrl)
+		communicationRepository := mocks.NewMockCommunicationRepository(ctrl)
+		notifier := notifiersrv.NewRecordingNotifier(next, domain.ChannelEmail, communicationRepository, log)
+
+		next.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		communicationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+		assert.NoError(t, notifier.Notify(context.Background(), 2, domain.TemplateVerificationReminder, data))
+	})
+	t.Run("Skipped Channel Not Recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := servicemocks.NewMockCustomerNotifier(ctrl)
+		communicationRepository := mocks.NewMockCommunicationRepository(ctrl)
+		notifier := notifiersrv.NewRecordingNotifier(next, domain.ChannelPush, communicationRepository, log)
+
+		next.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(common.ErrNotificationSkipped)
+
+		assert.ErrorIs(t, notifier.Notify(context.Background(), 2, domain.TemplateMonthlyStatement, data), common.ErrNotificationSkipped)
+	})
+}
@@ -0,0 +1,167 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	concentrationsrv "github.com/fazamuttaqien/multifinance/internal/service/concentration"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type concentrationMocks struct {
+	concentrationRepository *mocks.MockConcentrationRepository
+	regionRepository        *mocks.MockRegionRepository
+}
+
+func newConcentrationService(t *testing.T) (*concentrationMocks, service.ConcentrationServices) {
+	meter, tracer, log := testutil.Telemetry("test-concentration-service")
+
+	ctrl := gomock.NewController(t)
+	m := &concentrationMocks{
+		concentrationRepository: mocks.NewMockConcentrationRepository(ctrl),
+		regionRepository:        mocks.NewMockRegionRepository(ctrl),
+	}
+	return m, concentrationsrv.NewConcentrationService(m.concentrationRepository, m.regionRepository,
+		concentrationsrv.Config{MinPortfolio: decimal.NewFromInt(1_000_000_000)}, meter, tracer, log)
+}
+
+func TestConcentrationService_CreateLimit(t *testing.T) {
+	t.Run("Success - Asset Category Normalized", func(t *testing.T) {
+		m, concentrationService := newConcentrationService(t)
+
+		m.concentrationRepository.EXPECT().FindLimit(gomock.Any(), domain.ConcentrationAssetCategory, "motor").Return(nil, nil)
+		m.concentrationRepository.EXPECT().CreateLimit(gomock.Any(), gomock.Any()).Return(nil)
+
+		limit, err := concentrationService.CreateLimit(context.Background(), 1, dto.ConcentrationLimitRequest{
+			Dimension:  domain.ConcentrationAssetCategory,
+			Value:      " Motor ",
+			MaxPercent: decimal.NewFromInt(40),
+			Enforce:    true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "motor", limit.Value)
+		assert.Equal(t, uint64(1), limit.UpdatedBy)
+	})
+
+	t.Run("Failure - Unknown Region", func(t *testing.T) {
+		m, concentrationService := newConcentrationService(t)
+
+		m.regionRepository.EXPECT().FindByCode(gomock.Any(), "JKT").Return(nil, nil)
+
+		_, err := concentrationService.CreateLimit(context.Background(), 1, dto.ConcentrationLimitRequest{
+			Dimension:  domain.ConcentrationRegion,
+			Value:      "jkt",
+			MaxPercent: decimal.NewFromInt(30),
+		})
+
+		assert.ErrorIs(t, err, common.ErrRegionNotFound)
+	})
+
+	t.Run("Failure - Already Exists", func(t *testing.T) {
+		m, concentrationService := newConcentrationService(t)
+
+		m.concentrationRepository.EXPECT().FindLimit(gomock.Any(), domain.ConcentrationAssetCategory, "mobil").
+			Return(&domain.ConcentrationLimit{ID: 2}, nil)
+
+		_, err := concentrationService.CreateLimit(context.Background(), 1, dto.ConcentrationLimitRequest{
+			Dimension:  domain.ConcentrationAssetCategory,
+			Value:      "mobil",
+			MaxPercent: decimal.NewFromInt(50),
+		})
+
+		assert.ErrorIs(t, err, common.ErrConcentrationExists)
+	})
+}
+
+func TestConcentrationService_Check(t *testing.T) {
+	motor := domain.ConcentrationLimit{ID: 1, Dimension: domain.ConcentrationAssetCategory, Value: "motor", MaxPercent: decimal.NewFromInt(40), Enforce: true}
+	jakarta := domain.ConcentrationLimit{ID: 2, Dimension: domain.ConcentrationRegion, Value: "JKT", MaxPercent: decimal.NewFromInt(30)}
+
+	t.Run("Success - Within Limit", func(t *testing.T) {
+		m, concentrationService := newConcentrationService(t)
+
+		m.concentrationRepository.EXPECT().FindLimits(gomock.Any()).Return([]domain.ConcentrationLimit{motor, jakarta}, nil)
+		m.concentrationRepository.EXPECT().TotalPrincipal(gomock.Any()).Return(decimal.NewFromInt(10_000_000_000), nil)
+		m.concentrationRepository.EXPECT().SumPrincipalFor(gomock.Any(), domain.ConcentrationAssetCategory, "motor").
+			Return(decimal.NewFromInt(3_000_000_000), nil)
+
+		err := concentrationService.Check(context.Background(), "Motor", "JKT", decimal.NewFromInt(50_000_000))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failure - Exceeds Limit", func(t *testing.T) {
+		m, concentrationService := newConcentrationService(t)
+
+		m.concentrationRepository.EXPECT().FindLimits(gomock.Any()).Return([]domain.ConcentrationLimit{motor}, nil)
+		m.concentrationRepository.EXPECT().TotalPrincipal(gomock.Any()).Return(decimal.NewFromInt(10_000_000_000), nil)
+		m.concentrationRepository.EXPECT().SumPrincipalFor(gomock.Any(), domain.ConcentrationAssetCategory, "motor").
+			Return(decimal.NewFromInt(4_000_000_000), nil)
+
+		err := concentrationService.Check(context.Background(), "motor", "", decimal.NewFromInt(50_000_000))
+		assert.ErrorIs(t, err, common.ErrConcentrationExceeded)
+	})
+
+	t.Run("Success - Limit Not Enforced", func(t *testing.T) {
+		m, concentrationService := newConcentrationService(t)
+
+		// Limit region hanya dipantau, jadi total portofolio tidak perlu dihitung
+		m.concentrationRepository.EXPECT().FindLimits(gomock.Any()).Return([]domain.ConcentrationLimit{jakarta}, nil)
+
+		err := concentrationService.Check(context.Background(), "mobil", "JKT", decimal.NewFromInt(50_000_000))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - Portfolio Below Minimum", func(t *testing.T) {
+		m, concentrationService := newConcentrationService(t)
+
+		m.concentrationRepository.EXPECT().FindLimits(gomock.Any()).Return([]domain.ConcentrationLimit{motor}, nil)
+		m.concentrationRepository.EXPECT().TotalPrincipal(gomock.Any()).Return(decimal.NewFromInt(200_000_000), nil)
+
+		err := concentrationService.Check(context.Background(), "motor", "", decimal.NewFromInt(150_000_000))
+		assert.NoError(t, err)
+	})
+}
+
+func TestConcentrationService_Evaluate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 2, 0, 0, 0, time.UTC)
+	breachedAt := now.Add(-time.Hour)
+
+	m, concentrationService := newConcentrationService(t)
+
+	limits := []domain.ConcentrationLimit{
+		{ID: 1, Dimension: domain.ConcentrationAssetCategory, Value: "motor", MaxPercent: decimal.NewFromInt(40)},
+		{ID: 2, Dimension: domain.ConcentrationRegion, Value: "JKT", MaxPercent: decimal.NewFromInt(30), BreachedAt: &breachedAt},
+		{ID: 3, Dimension: domain.ConcentrationRegion, Value: "SBY", MaxPercent: decimal.NewFromInt(10)},
+	}
+	m.concentrationRepository.EXPECT().FindLimits(gomock.Any()).Return(limits, nil)
+	m.concentrationRepository.EXPECT().TotalPrincipal(gomock.Any()).Return(decimal.NewFromInt(10_000_000_000), nil)
+	m.concentrationRepository.EXPECT().SumPrincipal(gomock.Any(), domain.ConcentrationAssetCategory).Return([]domain.Concentration{
+		{Dimension: domain.ConcentrationAssetCategory, Value: "motor", Principal: decimal.NewFromInt(4_500_000_000)},
+		{Dimension: domain.ConcentrationAssetCategory, Value: "mobil", Principal: decimal.NewFromInt(5_500_000_000)},
+	}, nil)
+	m.concentrationRepository.EXPECT().SumPrincipal(gomock.Any(), domain.ConcentrationRegion).Return([]domain.Concentration{
+		{Dimension: domain.ConcentrationRegion, Value: "JKT", Principal: decimal.NewFromInt(2_500_000_000)},
+	}, nil)
+	m.concentrationRepository.EXPECT().SetBreachedAt(gomock.Any(), uint64(1), &now).Return(nil)
+	m.concentrationRepository.EXPECT().SetBreachedAt(gomock.Any(), uint64(2), nil).Return(nil)
+
+	run, err := concentrationService.Evaluate(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, run.Limits)
+	assert.Equal(t, 1, run.Breached)
+	assert.Equal(t, 1, run.Opened)
+	assert.Equal(t, 1, run.Cleared)
+}
@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	contractsrv "github.com/fazamuttaqien/multifinance/internal/service/contract"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestContractImportService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-contract-import-unit")
+	tenors := []domain.Tenor{{ID: 1, DurationMonths: 6}, {ID: 2, DurationMonths: 12}}
+	cfg := contractsrv.ImportConfig{MaxItems: 3, BatchSize: 2}
+
+	item := func(number string, months uint8) dto.ContractImportItemRequest {
+		return dto.ContractImportItemRequest{
+			ContractNumber:         number,
+			CustomerNIK:            "1234567890123456",
+			TenorMonths:            months,
+			AssetName:              "Honda Vario",
+			OTRAmount:              decimal.NewFromInt(20_000_000),
+			AdminFee:               decimal.NewFromInt(500_000),
+			TotalInterest:          decimal.NewFromInt(2_400_000),
+			TotalInstallmentAmount: decimal.NewFromInt(22_900_000),
+			Status:                 "ACTIVE",
+			TransactionDate:        "2024-03-15",
+		}
+	}
+
+	setup := func(t *testing.T) (*mocks.MockTransactionRepository, *mocks.MockCustomerRepository, *mocks.MockTenorRepository) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockTransactionRepository(ctrl), mocks.NewMockCustomerRepository(ctrl), mocks.NewMockTenorRepository(ctrl)
+	}
+
+	t.Run("Success - Stored In Batches", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		importService := contractsrv.NewContractImportService(transactionRepository, customerRepository, tenorRepository, cfg, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		// NIK yang sama hanya dicari sekali
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), "1234567890123456").Return(&domain.Customer{ID: 7}, nil).Times(1)
+		transactionRepository.EXPECT().CreateMany(gomock.Any(), gomock.Any(), 2).
+			DoAndReturn(func(_ context.Context, transactions []domain.Transaction, _ int) error {
+				require.Len(t, transactions, 2)
+				assert.Equal(t, "KTR-OLD-000001", transactions[0].ContractNumber)
+				assert.Equal(t, uint(2), transactions[1].TenorID)
+				assert.Equal(t, domain.TransactionActive, transactions[0].Status)
+				assert.Equal(t, "2024-03-15", transactions[0].TransactionDate.Format("2006-01-02"))
+				assert.Equal(t, "IDR", transactions[0].Currency)
+				for i := range transactions {
+					transactions[i].ID = uint64(100 + i)
+				}
+				return nil
+			})
+
+		res, err := importService.Import(context.Background(), dto.ContractImportRequest{
+			Contracts: []dto.ContractImportItemRequest{item("ktr-old-000001", 6), item("KTR-OLD-000002", 12)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Imported)
+		assert.Equal(t, uint64(101), res.Contracts[1].ID)
+		assert.Equal(t, uint64(7), res.Contracts[0].CustomerID)
+	})
+
+	t.Run("Failure - Too Many Contracts", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		importService := contractsrv.NewContractImportService(transactionRepository, customerRepository, tenorRepository, cfg, meter, tracer, log)
+
+		_, err := importService.Import(context.Background(), dto.ContractImportRequest{
+			Contracts: []dto.ContractImportItemRequest{item("A", 6), item("B", 6), item("C", 6), item("D", 6)},
+		})
+
+		assert.ErrorIs(t, err, common.ErrBatchTooLarge)
+	})
+
+	t.Run("Failure - Duplicate Within Request", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		importService := contractsrv.NewContractImportService(transactionRepository, customerRepository, tenorRepository, cfg, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), gomock.Any()).Return(&domain.Customer{ID: 7}, nil)
+
+		_, err := importService.Import(context.Background(), dto.ContractImportRequest{
+			Contracts: []dto.ContractImportItemRequest{item("KTR-OLD-000001", 6), item("ktr-old-000001", 6)},
+		})
+
+		assert.ErrorIs(t, err, common.ErrDuplicateContract)
+	})
+
+	t.Run("Failure - Unknown Customer Stores Nothing", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		importService := contractsrv.NewContractImportService(transactionRepository, customerRepository, tenorRepository, cfg, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		_, err := importService.Import(context.Background(), dto.ContractImportRequest{
+			Contracts: []dto.ContractImportItemRequest{item("KTR-OLD-000001", 6)},
+		})
+
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+
+	t.Run("Failure - Paid Installments Beyond Tenor", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		importService := contractsrv.NewContractImportService(transactionRepository, customerRepository, tenorRepository, cfg, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), gomock.Any()).Return(&domain.Customer{ID: 7}, nil)
+
+		invalid := item("KTR-OLD-000001", 6)
+		paid := 7
+		invalid.PaidInstallments = &paid
+		_, err := importService.Import(context.Background(), dto.ContractImportRequest{Contracts: []dto.ContractImportItemRequest{invalid}})
+
+		assert.ErrorIs(t, err, common.ErrInvalidContractImport)
+	})
+
+	t.Run("Failure - Existing Contract Number Mid-Batch", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		importService := contractsrv.NewContractImportService(transactionRepository, customerRepository, tenorRepository, cfg, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), gomock.Any()).Return(&domain.Customer{ID: 7}, nil)
+		transactionRepository.EXPECT().CreateMany(gomock.Any(), gomock.Any(), 2).
+			Return(fmt.Errorf("%w: Duplicate entry 'KTR-OLD-000003'", common.ErrDuplicateContract))
+
+		res, err := importService.Import(context.Background(), dto.ContractImportRequest{
+			Contracts: []dto.ContractImportItemRequest{item("KTR-OLD-000001", 6), item("KTR-OLD-000002", 6), item("KTR-OLD-000003", 6)},
+		})
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrDuplicateContract)
+	})
+}
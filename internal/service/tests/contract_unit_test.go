@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	contractsrv "github.com/fazamuttaqien/multifinance/internal/service/contract"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestContractLookupService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-contract-service-unit")
+	tenors := []domain.Tenor{{ID: 1, DurationMonths: 6}}
+	paid := 2
+
+	setup := func(t *testing.T) (*mocks.MockTransactionRepository, *mocks.MockCustomerRepository, *mocks.MockTenorRepository) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockTransactionRepository(ctrl), mocks.NewMockCustomerRepository(ctrl), mocks.NewMockTenorRepository(ctrl)
+	}
+
+	t.Run("Exact - Customer And Installment Summary", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		contractService := contractsrv.NewContractLookupService(transactionRepository, customerRepository, tenorRepository, testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-20250101-000001").Return(&domain.Transaction{
+			ID:                     5,
+			ContractNumber:         "KTR-20250101-000001",
+			CustomerID:             2,
+			TenorID:                1,
+			Status:                 domain.TransactionActive,
+			TransactionDate:        time.Now().AddDate(0, -4, 0),
+			TotalInstallmentAmount: decimal.NewFromInt(600_000),
+			PaidInstallments:       &paid,
+		}, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2, NIK: "1234567890123456", FullName: "Budi"}, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+
+		res, err := contractService.FindByContractNumber(context.Background(), "KTR-20250101-000001")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Budi", res.Customer.FullName)
+		// Pembayaran yang tercatat menang atas cicilan yang sudah jatuh tempo
+		assert.Equal(t, 2, res.Installments.PaidInstallments)
+		assert.Equal(t, uint8(6), res.Installments.TenorMonths)
+		assert.Equal(t, "100000", res.Installments.MonthlyInstallment.String())
+		assert.Equal(t, "200000", res.Installments.TotalPaid.String())
+		assert.Equal(t, "400000", res.Installments.OutstandingBalance.String())
+		assert.NotNil(t, res.Installments.NextDueDate)
+	})
+
+	t.Run("Exact - Not Found", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		contractService := contractsrv.NewContractLookupService(transactionRepository, customerRepository, tenorRepository, testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-404").Return(nil, nil)
+
+		res, err := contractService.FindByContractNumber(context.Background(), "KTR-404")
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+	})
+
+	t.Run("Prefix - Paginated", func(t *testing.T) {
+		transactionRepository, customerRepository, tenorRepository := setup(t)
+		contractService := contractsrv.NewContractLookupService(transactionRepository, customerRepository, tenorRepository, testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+		params := domain.Params{Page: 1, Limit: 20}
+		transactionRepository.EXPECT().FindPaginatedByContractNumberPrefix(gomock.Any(), "KTR-JKT", params).Return([]domain.Transaction{{
+			ContractNumber:         "KTR-JKT-20250101-000001",
+			TenorID:                1,
+			Status:                 domain.TransactionPaidOff,
+			TotalInstallmentAmount: decimal.NewFromInt(600_000),
+			Customer:               domain.Customer{FullName: "Budi"},
+		}}, int64(1), nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+
+		res, err := contractService.Search(context.Background(), "KTR-JKT", params)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.Total)
+		data, ok := res.Data.([]dto.ContractLookupResponse)
+		require.True(t, ok)
+		require.Len(t, data, 1)
+		assert.Equal(t, "Budi", data[0].Customer.FullName)
+		assert.Equal(t, 6, data[0].Installments.PaidInstallments)
+		assert.True(t, data[0].Installments.OutstandingBalance.IsZero())
+		assert.Nil(t, data[0].Installments.NextDueDate)
+	})
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	customernotesrv "github.com/fazamuttaqien/multifinance/internal/service/customernote"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type customerNoteMocks struct {
+	customerRepository *mocks.MockCustomerRepository
+	noteRepository     *mocks.MockCustomerNoteRepository
+}
+
+func newCustomerNoteService(t *testing.T) (*customerNoteMocks, service.CustomerNoteServices) {
+	meter, tracer, log := testutil.Telemetry("test-customer-note-service")
+
+	ctrl := gomock.NewController(t)
+	m := &customerNoteMocks{
+		customerRepository: mocks.NewMockCustomerRepository(ctrl),
+		noteRepository:     mocks.NewMockCustomerNoteRepository(ctrl),
+	}
+	return m, customernotesrv.NewCustomerNoteService(m.customerRepository, m.noteRepository, meter, tracer, log)
+}
+
+func TestCustomerNoteService_CreateNote(t *testing.T) {
+	t.Run("Success - Author Recorded", func(t *testing.T) {
+		m, noteService := newCustomerNoteService(t)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7}, nil)
+		m.noteRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, note *domain.CustomerNote) error {
+				assert.Equal(t, "Asked for restructuring", note.Body)
+				assert.Equal(t, uint64(1), note.AuthorID)
+				assert.Equal(t, uint64(1), note.UpdatedBy)
+				note.ID = 3
+				return nil
+			})
+
+		note, err := noteService.CreateNote(context.Background(), 7, 1, dto.CustomerNoteRequest{Body: "  Asked for restructuring  "})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), note.ID)
+	})
+
+	t.Run("Failure - Customer Not Found", func(t *testing.T) {
+		m, noteService := newCustomerNoteService(t)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(nil, nil)
+
+		_, err := noteService.CreateNote(context.Background(), 7, 1, dto.CustomerNoteRequest{Body: "Note"})
+
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
+
+func TestCustomerNoteService_UpdateNote(t *testing.T) {
+	current := &domain.CustomerNote{ID: 3, CustomerID: 7, AuthorID: 1, Body: "Prefers email", UpdatedBy: 1}
+
+	t.Run("Success - Previous Version Kept", func(t *testing.T) {
+		m, noteService := newCustomerNoteService(t)
+
+		updated := &domain.CustomerNote{
+			ID: 3, CustomerID: 7, AuthorID: 1, Body: "Prefers WhatsApp", Pinned: true, UpdatedBy: 2,
+			Revisions: []domain.CustomerNoteRevision{{ID: 9, NoteID: 3, Body: "Prefers email", UpdatedBy: 1}},
+		}
+		gomock.InOrder(
+			m.noteRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(current, nil),
+			m.noteRepository.EXPECT().Update(gomock.Any(), &domain.CustomerNote{ID: 3, CustomerID: 7, Body: "Prefers WhatsApp", Pinned: true, UpdatedBy: 2}).Return(true, nil),
+			m.noteRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(updated, nil),
+		)
+
+		note, err := noteService.UpdateNote(context.Background(), 7, 3, 2, dto.CustomerNoteRequest{Body: "Prefers WhatsApp", Pinned: true})
+
+		require.NoError(t, err)
+		assert.Len(t, note.Revisions, 1)
+	})
+
+	t.Run("Success - Unchanged Adds No Revision", func(t *testing.T) {
+		m, noteService := newCustomerNoteService(t)
+
+		m.noteRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(current, nil)
+
+		note, err := noteService.UpdateNote(context.Background(), 7, 3, 2, dto.CustomerNoteRequest{Body: "Prefers email"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), note.UpdatedBy)
+	})
+
+	t.Run("Failure - Note Of Another Customer", func(t *testing.T) {
+		m, noteService := newCustomerNoteService(t)
+
+		m.noteRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(current, nil)
+
+		_, err := noteService.UpdateNote(context.Background(), 8, 3, 2, dto.CustomerNoteRequest{Body: "Prefers WhatsApp"})
+
+		assert.ErrorIs(t, err, common.ErrCustomerNoteNotFound)
+	})
+}
@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	dashboardsrv "github.com/fazamuttaqien/multifinance/internal/service/dashboard"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type dashboardMocks struct {
+	customerRepository      *mocks.MockCustomerRepository
+	customerEventRepository *mocks.MockCustomerEventRepository
+}
+
+func newDashboardService(t *testing.T) (*dashboardMocks, service.DashboardServices) {
+	meter, tracer, log := testutil.Telemetry("test-dashboard-service")
+
+	ctrl := gomock.NewController(t)
+	m := &dashboardMocks{
+		customerRepository:      mocks.NewMockCustomerRepository(ctrl),
+		customerEventRepository: mocks.NewMockCustomerEventRepository(ctrl),
+	}
+	return m, dashboardsrv.NewDashboardService(m.customerRepository, m.customerEventRepository, meter, tracer, log)
+}
+
+func TestDashboardService_Refresh(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	registered := []domain.CustomerEventType{domain.CustomerRegistered}
+
+	t.Run("Success - No Subscribers", func(t *testing.T) {
+		_, dashboardService := newDashboardService(t)
+
+		// Tanpa pelanggan repository tidak disentuh sama sekali
+		snapshot, err := dashboardService.Refresh(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Nil(t, snapshot)
+	})
+
+	t.Run("Success - Follows Registrations", func(t *testing.T) {
+		m, dashboardService := newDashboardService(t)
+
+		updates, unsubscribe := dashboardService.Subscribe(context.Background())
+		defer unsubscribe()
+
+		// Refresh pertama mulai dari event terbaru, berikutnya dari pendaftaran terakhir
+		m.customerEventRepository.EXPECT().LatestID(gomock.Any()).Return(uint64(40), nil)
+		m.customerEventRepository.EXPECT().FindAfter(gomock.Any(), uint64(40), registered, gomock.Any()).
+			Return([]domain.CustomerEvent{{ID: 42, CustomerID: 7, Type: domain.CustomerRegistered, OccurredAt: now}}, nil)
+		m.customerEventRepository.EXPECT().FindAfter(gomock.Any(), uint64(42), registered, gomock.Any()).Return(nil, nil)
+		m.customerRepository.EXPECT().CountByStatus(gomock.Any(), domain.VerificationPending).Return(int64(5), nil).Times(2)
+		m.customerEventRepository.EXPECT().CountSince(gomock.Any(), domain.CustomerTransactionCreated, now.Add(-time.Minute)).Return(int64(12), nil)
+		m.customerEventRepository.EXPECT().CountSince(gomock.Any(), domain.CustomerTransactionCreated, now).Return(int64(3), nil)
+
+		_, err := dashboardService.Refresh(context.Background(), now)
+		require.NoError(t, err)
+		_, err = dashboardService.Refresh(context.Background(), now.Add(time.Minute))
+		require.NoError(t, err)
+
+		first := <-updates
+		require.Len(t, first.Registrations, 1)
+		assert.Equal(t, uint64(7), first.Registrations[0].CustomerID)
+		assert.Equal(t, int64(5), first.VerificationQueue)
+		assert.Equal(t, int64(12), first.TransactionsPerMinute)
+
+		second := <-updates
+		assert.Empty(t, second.Registrations)
+		assert.Equal(t, int64(3), second.TransactionsPerMinute)
+	})
+
+	t.Run("Success - Late Subscriber Gets Counters Only", func(t *testing.T) {
+		m, dashboardService := newDashboardService(t)
+
+		_, unsubscribe := dashboardService.Subscribe(context.Background())
+		defer unsubscribe()
+
+		m.customerEventRepository.EXPECT().LatestID(gomock.Any()).Return(uint64(0), nil)
+		m.customerEventRepository.EXPECT().FindAfter(gomock.Any(), uint64(0), registered, gomock.Any()).
+			Return([]domain.CustomerEvent{{ID: 1, CustomerID: 7, Type: domain.CustomerRegistered}}, nil)
+		m.customerRepository.EXPECT().CountByStatus(gomock.Any(), domain.VerificationPending).Return(int64(1), nil)
+		m.customerEventRepository.EXPECT().CountSince(gomock.Any(), domain.CustomerTransactionCreated, gomock.Any()).Return(int64(0), nil)
+
+		_, err := dashboardService.Refresh(context.Background(), now)
+		require.NoError(t, err)
+
+		late, unsubscribeLate := dashboardService.Subscribe(context.Background())
+		defer unsubscribeLate()
+
+		current := <-late
+		assert.Empty(t, current.Registrations)
+		assert.Equal(t, int64(1), current.VerificationQueue)
+	})
+
+	t.Run("Success - Tenants Kept Apart", func(t *testing.T) {
+		m, dashboardService := newDashboardService(t)
+
+		acme := tenancy.WithTenant(context.Background(), &tenancy.Tenant{ID: "acme"})
+		defaultUpdates, unsubscribeDefault := dashboardService.Subscribe(context.Background())
+		defer unsubscribeDefault()
+		acmeUpdates, unsubscribeAcme := dashboardService.Subscribe(acme)
+		defer unsubscribeAcme()
+
+		// Hanya tenant acme yang di-refresh, pelanggan tenant default tidak menerima apa pun
+		m.customerEventRepository.EXPECT().LatestID(gomock.Any()).Return(uint64(0), nil)
+		m.customerEventRepository.EXPECT().FindAfter(gomock.Any(), uint64(0), registered, gomock.Any()).Return(nil, nil)
+		m.customerRepository.EXPECT().CountByStatus(gomock.Any(), domain.VerificationPending).Return(int64(2), nil)
+		m.customerEventRepository.EXPECT().CountSince(gomock.Any(), domain.CustomerTransactionCreated, gomock.Any()).Return(int64(0), nil)
+
+		_, err := dashboardService.Refresh(acme, now)
+		require.NoError(t, err)
+
+		current := <-acmeUpdates
+		assert.Equal(t, int64(2), current.VerificationQueue)
+		assert.Empty(t, defaultUpdates)
+	})
+
+	t.Run("Failure - Repository Error", func(t *testing.T) {
+		m, dashboardService := newDashboardService(t)
+
+		_, unsubscribe := dashboardService.Subscribe(context.Background())
+		defer unsubscribe()
+
+		repoErr := errors.New("connection refused")
+		m.customerEventRepository.EXPECT().LatestID(gomock.Any()).Return(uint64(0), repoErr)
+
+		snapshot, err := dashboardService.Refresh(context.Background(), now)
+
+		assert.Nil(t, snapshot)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
+
+func TestDashboardService_Subscribe(t *testing.T) {
+	t.Run("Closed On Shutdown", func(t *testing.T) {
+		_, dashboardService := newDashboardService(t)
+
+		// Context job diawasi sejak refresh pertama
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := dashboardService.Refresh(ctx, time.Now())
+		require.NoError(t, err)
+
+		updates, unsubscribe := dashboardService.Subscribe(context.Background())
+		defer unsubscribe()
+		cancel()
+
+		select {
+		case _, ok := <-updates:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("subscription was not closed on shutdown")
+		}
+
+		// Pelanggan baru setelah shutdown langsung ditutup
+		late, _ := dashboardService.Subscribe(context.Background())
+		_, ok := <-late
+		assert.False(t, ok)
+	})
+
+	t.Run("Unsubscribe Twice", func(t *testing.T) {
+		_, dashboardService := newDashboardService(t)
+
+		updates, unsubscribe := dashboardService.Subscribe(context.Background())
+		unsubscribe()
+		unsubscribe()
+
+		_, ok := <-updates
+		assert.False(t, ok)
+	})
+}
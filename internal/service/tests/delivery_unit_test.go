@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	deliverysrv "github.com/fazamuttaqien/multifinance/internal/service/delivery"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newPartnerEndpoint(t *testing.T, status int) (*httptest.Server, *int32) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		assert.Equal(t, "transaction.created", r.Header.Get("X-Webhook-Event"))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestDeliveryService_Dispatch(t *testing.T) {
+	cfg := deliverysrv.Config{MaxAttempts: 3, MaxReplays: 2}
+	meter, tracer, log := testutil.Telemetry("test-delivery-service")
+
+	tests := []struct {
+		name         string
+		status       int
+		wantStatus   domain.DeliveryStatus
+		wantAttempts int
+	}{
+		{"Delivered", http.StatusOK, domain.DeliveryDelivered, 1},
+		{"Server Error Retried Then Dead Lettered", http.StatusBadGateway, domain.DeliveryFailed, 3},
+		{"Client Error Not Retried", http.StatusBadRequest, domain.DeliveryFailed, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			deliveryRepository := mocks.NewMockDeliveryRepository(ctrl)
+			deliveryService := deliverysrv.NewDeliveryService(deliveryRepository, nil, cfg, meter, tracer, log)
+			server, hits := newPartnerEndpoint(t, tt.status)
+
+			deliveryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			deliveryRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			delivery, err := deliveryService.Dispatch(context.Background(), 1, server.URL, "transaction.created", map[string]string{"contract_number": "KTR-1"})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, delivery.Status)
+			assert.Equal(t, tt.wantAttempts, delivery.Attempts)
+			assert.Equal(t, int32(tt.wantAttempts), atomic.LoadInt32(hits))
+		})
+	}
+
+	t.Run("Unencodable Payload Is Poisoned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		deliveryRepository := mocks.NewMockDeliveryRepository(ctrl)
+		deliveryService := deliverysrv.NewDeliveryService(deliveryRepository, nil, cfg, meter, tracer, log)
+
+		deliveryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		delivery, err := deliveryService.Dispatch(context.Background(), 1, "http://127.0.0.1:0", "transaction.created", make(chan int))
+
+		assert.ErrorIs(t, err, common.ErrDeliveryPoisoned)
+		assert.Equal(t, domain.DeliveryPoisoned, delivery.Status)
+	})
+}
+
+func TestDeliveryService_Replay(t *testing.T) {
+	cfg := deliverysrv.Config{MaxAttempts: 1, MaxReplays: 2}
+	meter, tracer, log := testutil.Telemetry("test-delivery-service")
+	ctrl := gomock.NewController(t)
+	deliveryRepository := mocks.NewMockDeliveryRepository(ctrl)
+	deliveryService := deliverysrv.NewDeliveryService(deliveryRepository, nil, cfg, meter, tracer, log)
+	server, _ := newPartnerEndpoint(t, http.StatusOK)
+
+	t.Run("Failed Delivery Is Replayed", func(t *testing.T) {
+		failed := &domain.WebhookDelivery{ID: 1, URL: server.URL, EventType: "transaction.created", Payload: "{}", Status: domain.DeliveryFailed, Attempts: 3}
+		deliveryRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(failed, nil)
+		deliveryRepository.EXPECT().Update(gomock.Any(), failed).Return(nil)
+
+		delivery, err := deliveryService.Replay(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DeliveryDelivered, delivery.Status)
+		assert.Equal(t, 1, delivery.Replays)
+		assert.Equal(t, 4, delivery.Attempts)
+	})
+
+	t.Run("Already Delivered", func(t *testing.T) {
+		deliveryRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.WebhookDelivery{ID: 2, Status: domain.DeliveryDelivered}, nil)
+
+		_, err := deliveryService.Replay(context.Background(), 2)
+		assert.ErrorIs(t, err, common.ErrDeliveryDelivered)
+	})
+
+	t.Run("Replay Budget Exhausted Marks Poisoned", func(t *testing.T) {
+		exhausted := &domain.WebhookDelivery{ID: 3, Status: domain.DeliveryFailed, Replays: 2}
+		deliveryRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(exhausted, nil)
+		deliveryRepository.EXPECT().Update(gomock.Any(), exhausted).Return(nil)
+
+		_, err := deliveryService.Replay(context.Background(), 3)
+		assert.ErrorIs(t, err, common.ErrDeliveryPoisoned)
+		assert.Equal(t, domain.DeliveryPoisoned, exhausted.Status)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		deliveryRepository.EXPECT().FindByID(gomock.Any(), uint64(4)).Return(nil, nil)
+
+		_, err := deliveryService.Replay(context.Background(), 4)
+		assert.ErrorIs(t, err, common.ErrDeliveryNotFound)
+	})
+}
@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	directdebitsrv "github.com/fazamuttaqien/multifinance/internal/service/directdebit"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/fazamuttaqien/multifinance/pkg/paymentgateway"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDirectDebitService_Run_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-direct-debit-service")
+	cfg := directdebitsrv.Config{MaxAttempts: 3, RetryAfter: 24 * time.Hour, BatchSize: 50}
+	chargers := []paymentgateway.Charger{paymentgateway.NewStubCharger()}
+
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	paid := 1
+	contract := domain.Transaction{
+		ID:                     11,
+		CustomerID:             2,
+		ContractNumber:         "KTR-DD-001",
+		Tenor:                  domain.Tenor{DurationMonths: 6},
+		TotalInstallmentAmount: decimal.NewFromInt(600000),
+		Currency:               "IDR",
+		TransactionDate:        start,
+		PaidInstallments:       &paid,
+	}
+	// Cicilan kedua jatuh tempo 10 Maret
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Charges Due Installment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directDebitRepository := mocks.NewMockDirectDebitRepository(ctrl)
+		paymentService := servicemocks.NewMockPaymentServices(ctrl)
+		directDebitService := directdebitsrv.NewDirectDebitService(directDebitRepository, paymentService, chargers, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		directDebitRepository.EXPECT().FindCollectible(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+		directDebitRepository.EXPECT().ListAttempts(gomock.Any(), uint64(11), 2).Return(nil, nil)
+		directDebitRepository.EXPECT().FindMandate(gomock.Any(), uint64(2)).
+			Return(&domain.DirectDebitMandate{CustomerID: 2, Provider: "stub", Token: "tok_1234567890"}, nil)
+
+		recorded := paymentService.EXPECT().RecordPayment(gomock.Any(), "KTR-DD-001", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, payment *domain.Payment) error {
+				assert.Equal(t, "stub-dd-11-2-1", payment.ExternalID)
+				assert.True(t, decimal.NewFromInt(100000).Equal(payment.Amount))
+				return nil
+			})
+		directDebitRepository.EXPECT().CreateAttempt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, attempt *domain.DirectDebitAttempt) error {
+				assert.Equal(t, 1, attempt.Attempt)
+				assert.Equal(t, domain.PaymentPaid, attempt.Status)
+				return nil
+			}).After(recorded)
+
+		run, err := directDebitService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Due)
+		assert.Equal(t, 1, run.Charged)
+	})
+
+	t.Run("Success - Not Yet Due", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directDebitRepository := mocks.NewMockDirectDebitRepository(ctrl)
+		paymentService := servicemocks.NewMockPaymentServices(ctrl)
+		directDebitService := directdebitsrv.NewDirectDebitService(directDebitRepository, paymentService, chargers, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		directDebitRepository.EXPECT().FindCollectible(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+
+		run, err := directDebitService.Run(context.Background(), now.AddDate(0, 0, -1))
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Due)
+	})
+
+	t.Run("Success - Declined Charge Is Recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directDebitRepository := mocks.NewMockDirectDebitRepository(ctrl)
+		paymentService := servicemocks.NewMockPaymentServices(ctrl)
+		directDebitService := directdebitsrv.NewDirectDebitService(directDebitRepository, paymentService, chargers, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		previous := domain.DirectDebitAttempt{Attempt: 1, Status: domain.PaymentFailed, AttemptedAt: now.Add(-25 * time.Hour)}
+		directDebitRepository.EXPECT().FindCollectible(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+		directDebitRepository.EXPECT().ListAttempts(gomock.Any(), uint64(11), 2).Return([]domain.DirectDebitAttempt{previous}, nil)
+		directDebitRepository.EXPECT().FindMandate(gomock.Any(), uint64(2)).
+			Return(&domain.DirectDebitMandate{CustomerID: 2, Provider: "stub", Token: "fail_nsf"}, nil)
+		directDebitRepository.EXPECT().CreateAttempt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, attempt *domain.DirectDebitAttempt) error {
+				assert.Equal(t, 2, attempt.Attempt)
+				assert.Equal(t, "insufficient_funds", attempt.FailureReason)
+				return nil
+			})
+
+		run, err := directDebitService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Failed)
+		assert.Equal(t, 0, run.Charged)
+	})
+
+	t.Run("Success - Retry Policy", func(t *testing.T) {
+		failed := func(n int, at time.Time) domain.DirectDebitAttempt {
+			return domain.DirectDebitAttempt{Attempt: n, Status: domain.PaymentFailed, AttemptedAt: at}
+		}
+
+		cases := map[string]struct {
+			attempts  []domain.DirectDebitAttempt
+			deferred  int
+			exhausted int
+		}{
+			"within retry window": {attempts: []domain.DirectDebitAttempt{failed(1, now.Add(-time.Hour))}, deferred: 1},
+			"pending at gateway":  {attempts: []domain.DirectDebitAttempt{{Attempt: 1, Status: domain.PaymentPending, AttemptedAt: now.AddDate(0, 0, -3)}}, deferred: 1},
+			"attempts exhausted": {
+				attempts:  []domain.DirectDebitAttempt{failed(1, now.AddDate(0, 0, -3)), failed(2, now.AddDate(0, 0, -2)), failed(3, now.AddDate(0, 0, -1))},
+				exhausted: 1,
+			},
+		}
+
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				directDebitRepository := mocks.NewMockDirectDebitRepository(ctrl)
+				paymentService := servicemocks.NewMockPaymentServices(ctrl)
+				directDebitService := directdebitsrv.NewDirectDebitService(directDebitRepository, paymentService, chargers, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+				directDebitRepository.EXPECT().FindCollectible(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+				directDebitRepository.EXPECT().ListAttempts(gomock.Any(), uint64(11), 2).Return(tc.attempts, nil)
+
+				run, err := directDebitService.Run(context.Background(), now)
+
+				require.NoError(t, err)
+				assert.Equal(t, 1, run.Due)
+				assert.Equal(t, tc.deferred, run.Deferred)
+				assert.Equal(t, tc.exhausted, run.Exhausted)
+			})
+		}
+	})
+}
+
+func TestDirectDebitService_SetMandate_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-direct-debit-service")
+	cfg := directdebitsrv.Config{MaxAttempts: 3, RetryAfter: 24 * time.Hour, BatchSize: 50}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directDebitRepository := mocks.NewMockDirectDebitRepository(ctrl)
+		directDebitService := directdebitsrv.NewDirectDebitService(directDebitRepository, servicemocks.NewMockPaymentServices(ctrl),
+			[]paymentgateway.Charger{paymentgateway.NewStubCharger()}, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		directDebitRepository.EXPECT().UpsertMandate(gomock.Any(), &domain.DirectDebitMandate{CustomerID: 2, Provider: "stub", Token: "tok_1234567890"}).Return(nil)
+
+		mandate, err := directDebitService.SetMandate(context.Background(), 2, dto.DirectDebitMandateRequest{Provider: "stub", Token: "tok_1234567890"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "stub", mandate.Provider)
+	})
+
+	t.Run("Failure - Unknown Provider", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directDebitService := directdebitsrv.NewDirectDebitService(mocks.NewMockDirectDebitRepository(ctrl), servicemocks.NewMockPaymentServices(ctrl),
+			nil, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		mandate, err := directDebitService.SetMandate(context.Background(), 2, dto.DirectDebitMandateRequest{Provider: "xendit", Token: "tok_1234567890"})
+
+		assert.ErrorIs(t, err, common.ErrUnknownPaymentProvider)
+		assert.Nil(t, mandate)
+	})
+}
@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	dormancysrv "github.com/fazamuttaqien/multifinance/internal/service/dormancy"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDormancyService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-dormancy-service")
+	now := time.Date(2025, 6, 30, 9, 0, 0, 0, time.UTC)
+	cfg := dormancysrv.Config{InactiveMonths: 12, BatchSize: 50}
+
+	setup := func(t *testing.T) (*mocks.MockCustomerRepository, *mocks.MockDormancyRepository, *servicemocks.MockOTPServices, *servicemocks.MockCustomerNotifier) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockCustomerRepository(ctrl), mocks.NewMockDormancyRepository(ctrl),
+			servicemocks.NewMockOTPServices(ctrl), servicemocks.NewMockCustomerNotifier(ctrl)
+	}
+
+	t.Run("Run - Nothing Inactive Reports Dormant Count", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		dormancyRepository.EXPECT().FindInactive(gomock.Any(), now.AddDate(-1, 0, 0), 50).Return(nil, nil)
+		dormancyRepository.EXPECT().Stats(gomock.Any(), now).Return(&domain.DormancyStats{Dormant: 6, Since: now}, nil)
+
+		run, err := dormancyService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Flagged)
+		assert.Equal(t, int64(6), run.Dormant)
+	})
+
+	t.Run("Run - Repository Error", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		dormancyRepository.EXPECT().FindInactive(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		run, err := dormancyService.Run(context.Background(), now)
+
+		assert.Nil(t, run)
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	dormantAt := now.AddDate(0, -2, 0)
+	dormant := &domain.Customer{
+		ID:        7,
+		BirthDate: time.Date(1990, 4, 12, 0, 0, 0, 0, time.UTC),
+		Email:     "budi@example.com",
+		Phone:     "081234567890",
+		DormantAt: &dormantAt,
+	}
+	req := dto.ReactivationRequest{BirthDate: "1990-04-12", OTPToken: "token"}
+
+	t.Run("Reactivate - Not Dormant", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7}, nil)
+
+		err := dormancyService.Reactivate(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrCustomerNotDormant)
+	})
+
+	t.Run("Reactivate - Birth Date Mismatch Keeps OTP", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(dormant, nil)
+
+		err := dormancyService.Reactivate(context.Background(), 7, dto.ReactivationRequest{BirthDate: "1990-12-04", OTPToken: "token"})
+
+		assert.ErrorIs(t, err, common.ErrIdentityMismatch)
+	})
+
+	t.Run("Reactivate - OTP Required", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(dormant, nil)
+		otpService.EXPECT().Consume(gomock.Any(), "token", domain.OTPReactivation, uint64(7)).Return(nil, common.ErrOTPRequired)
+
+		err := dormancyService.Reactivate(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrOTPRequired)
+	})
+
+	t.Run("Reactivate - OTP Sent To New Contact", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(dormant, nil)
+		otpService.EXPECT().Consume(gomock.Any(), "token", domain.OTPReactivation, uint64(7)).
+			Return(&domain.OTPVerification{Purpose: domain.OTPReactivation, Channel: "SMS", Destination: "089999999999", CustomerID: 7}, nil)
+
+		err := dormancyService.Reactivate(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrContactNotVerified)
+	})
+
+	t.Run("Stats - Window From Days", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		since := now.AddDate(0, 0, -30)
+		dormancyRepository.EXPECT().Stats(gomock.Any(), since).
+			Return(&domain.DormancyStats{Dormant: 6, Since: since, Flagged: 4, Reactivated: 1}, nil)
+
+		stats, err := dormancyService.Stats(context.Background(), 30, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), stats.Flagged)
+		assert.Equal(t, int64(1), stats.Reactivated)
+	})
+
+	t.Run("Stats - Range Out Of Bounds", func(t *testing.T) {
+		customerRepository, dormancyRepository, otpService, notifier := setup(t)
+		dormancyService := dormancysrv.NewDormancyService(nil, customerRepository, dormancyRepository, otpService, notifier, cfg, meter, tracer, log)
+
+		stats, err := dormancyService.Stats(context.Background(), dormancysrv.MaxStatsDays+1, now)
+
+		assert.Nil(t, stats)
+		assert.ErrorIs(t, err, common.ErrInvalidDormancyDays)
+	})
+}
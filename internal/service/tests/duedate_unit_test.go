@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	duedatesrv "github.com/fazamuttaqien/multifinance/internal/service/duedate"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/cache"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// dueDateCache is local only; without Redis the cache behaves like a single instance.
+func dueDateCache(ttl time.Duration) *cache.Cache[domain.DueDateCalendar] {
+	return cache.New[domain.DueDateCalendar](nil, cache.Options{Name: "due_date_calendar", LocalTTL: ttl})
+}
+
+func TestDueDateService_Rules_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-due-date-service")
+
+	// Cicilan pertama jatuh pada Sabtu 15 Maret 2025 dan Senin 17 Maret libur
+	contractDate := time.Date(2025, 2, 15, 9, 0, 0, 0, time.UTC)
+	holidays := []domain.Holiday{{Date: time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), Name: "Cuti Bersama"}}
+
+	t.Run("Next Business Day Skips Weekend And Holiday", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(&domain.DueDatePolicy{Shift: installment.ShiftNext, GraceDays: 3}, nil).Times(1)
+		dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return(holidays, nil).Times(1)
+
+		rules := dueDateService.Rules(context.Background())
+
+		assert.Equal(t, time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC), rules.DueDate(contractDate, 1))
+		assert.Equal(t, 0, rules.Elapsed(contractDate, time.Date(2025, 3, 17, 12, 0, 0, 0, time.UTC)))
+		assert.Equal(t, 1, rules.Elapsed(contractDate, time.Date(2025, 3, 18, 0, 0, 0, 0, time.UTC)))
+
+		schedule := dueDateService.Rules(context.Background()).Schedule(contractDate, 1, 2, decimal.NewFromInt(200))
+		require.Len(t, schedule, 2)
+		assert.Equal(t, time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC), schedule[0].DueDate)
+		assert.Equal(t, time.Date(2025, 4, 15, 9, 0, 0, 0, time.UTC), schedule[1].DueDate)
+	})
+
+	t.Run("Grace Period Counts From Adjusted Due Date", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(&domain.DueDatePolicy{Shift: installment.ShiftNext, GraceDays: 3}, nil)
+		dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return(holidays, nil)
+
+		rules := dueDateService.Rules(context.Background())
+
+		assert.Equal(t, 0, rules.DaysPastDue(contractDate, 0, 6, time.Date(2025, 3, 21, 23, 0, 0, 0, time.UTC)))
+		assert.Equal(t, 4, rules.DaysPastDue(contractDate, 0, 6, time.Date(2025, 3, 22, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, 0, rules.DaysPastDue(contractDate, 6, 6, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Previous Business Day Is Reached Early", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(&domain.DueDatePolicy{Shift: installment.ShiftPrevious}, nil)
+		dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return(holidays, nil)
+
+		rules := dueDateService.Rules(context.Background())
+
+		assert.Equal(t, time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC), rules.DueDate(contractDate, 1))
+		assert.Equal(t, 1, rules.Elapsed(contractDate, time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)))
+		assert.Equal(t, 0, installment.Elapsed(contractDate, time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Business Days Read In Business Timezone", func(t *testing.T) {
+		jakarta, err := time.LoadLocation("Asia/Jakarta")
+		require.NoError(t, err)
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), jakarta, meter, tracer, log)
+
+		dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(&domain.DueDatePolicy{Shift: installment.ShiftNext}, nil)
+		dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return(nil, nil)
+
+		// 14 Maret 17:30 UTC sudah Sabtu 15 Maret di Jakarta
+		late := time.Date(2025, 2, 14, 17, 30, 0, 0, time.UTC)
+		rules := dueDateService.Rules(context.Background())
+
+		assert.Equal(t, time.Date(2025, 3, 16, 17, 30, 0, 0, time.UTC), rules.DueDate(late, 1))
+	})
+
+	t.Run("Nominal Due Dates Without Policy", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(nil, nil)
+		dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return(holidays, nil)
+
+		rules := dueDateService.Rules(context.Background())
+
+		assert.Equal(t, installment.ShiftNone, rules.Shift)
+		assert.Equal(t, installment.DueDate(contractDate, 1), rules.DueDate(contractDate, 1))
+	})
+
+	t.Run("Keeps Cached Rules When Reload Fails", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(0), time.UTC, meter, tracer, log)
+
+		gomock.InOrder(
+			dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(&domain.DueDatePolicy{Shift: installment.ShiftNext}, nil),
+			dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return(holidays, nil),
+			dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(nil, errors.New("connection refused")),
+		)
+
+		ctx := context.Background()
+		assert.Equal(t, time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC), dueDateService.Rules(ctx).DueDate(contractDate, 1))
+		assert.Equal(t, time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC), dueDateService.Rules(ctx).DueDate(contractDate, 1))
+	})
+}
+
+func TestDueDateService_Admin_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-due-date-service")
+
+	t.Run("ListHolidays - Whole Year", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().FindHolidays(gomock.Any(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.Holiday{{Date: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), Name: "Idul Fitri"}}, nil)
+
+		holidays, err := dueDateService.ListHolidays(context.Background(), 2025)
+
+		require.NoError(t, err)
+		assert.Len(t, holidays, 1)
+	})
+
+	t.Run("SetHoliday - Reloads Rules", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		date := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+		dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(&domain.DueDatePolicy{Shift: installment.ShiftNext}, nil).Times(2)
+		gomock.InOrder(
+			dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return(nil, nil),
+			dueDateRepository.EXPECT().UpsertHoliday(gomock.Any(), &domain.Holiday{Date: date, Name: "Idul Fitri", UpdatedBy: 1}).Return(nil),
+			dueDateRepository.EXPECT().FindAllHolidays(gomock.Any()).Return([]domain.Holiday{{Date: date, Name: "Idul Fitri"}}, nil),
+		)
+
+		ctx := context.Background()
+		assert.True(t, dueDateService.Rules(ctx).IsBusinessDay(date))
+
+		holiday, err := dueDateService.SetHoliday(ctx, "2025-03-31", 1, dto.HolidayRequest{Name: "Idul Fitri"})
+
+		require.NoError(t, err)
+		assert.Equal(t, date, holiday.Date)
+		assert.False(t, dueDateService.Rules(ctx).IsBusinessDay(date))
+	})
+
+	t.Run("SetHoliday - Invalid Date", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		holiday, err := dueDateService.SetHoliday(context.Background(), "31-03-2025", 1, dto.HolidayRequest{Name: "Idul Fitri"})
+
+		assert.Nil(t, holiday)
+		assert.ErrorIs(t, err, common.ErrInvalidHolidayDate)
+	})
+
+	t.Run("DeleteHoliday - Not Found", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().DeleteHoliday(gomock.Any(), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)).Return(false, nil)
+
+		err := dueDateService.DeleteHoliday(context.Background(), "2025-03-31")
+
+		assert.ErrorIs(t, err, common.ErrHolidayNotFound)
+	})
+
+	t.Run("GetPolicy - Default Without Saved Policy", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().FindPolicy(gomock.Any()).Return(nil, nil)
+
+		policy, err := dueDateService.GetPolicy(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, installment.ShiftNone, policy.Shift)
+		assert.Zero(t, policy.GraceDays)
+	})
+
+	t.Run("SetPolicy - Success", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		dueDateRepository.EXPECT().SavePolicy(gomock.Any(), &domain.DueDatePolicy{Shift: installment.ShiftPrevious, GraceDays: 5, UpdatedBy: 1}).Return(nil)
+
+		policy, err := dueDateService.SetPolicy(context.Background(), 1, dto.DueDatePolicyRequest{Shift: installment.ShiftPrevious, GraceDays: 5})
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, policy.GraceDays)
+	})
+
+	t.Run("SetPolicy - Unknown Shift", func(t *testing.T) {
+		dueDateRepository := mocks.NewMockDueDateRepository(gomock.NewController(t))
+		dueDateService := duedatesrv.NewDueDateService(dueDateRepository, dueDateCache(time.Minute), time.UTC, meter, tracer, log)
+
+		policy, err := dueDateService.SetPolicy(context.Background(), 1, dto.DueDatePolicyRequest{Shift: "NEAREST_BUSINESS_DAY"})
+
+		assert.Nil(t, policy)
+		assert.ErrorIs(t, err, common.ErrInvalidDueDatePolicy)
+	})
+}
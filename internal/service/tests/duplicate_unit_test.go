@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	duplicatesrv "github.com/fazamuttaqien/multifinance/internal/service/duplicate"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDuplicateService_FindPossibleDuplicates_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	duplicateRepository := mocks.NewMockDuplicateRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-duplicate-service-unit")
+	duplicateService := duplicatesrv.NewDuplicateService(nil, customerRepository, duplicateRepository, meter, tracer, log)
+
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	customer := &domain.Customer{ID: 1, LegalName: "Muhamad Yusuf", BirthPlace: "Bandung", BirthDate: birthDate}
+
+	customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+	duplicateRepository.EXPECT().FindByBirthDate(gomock.Any(), birthDate, uint64(1)).Return([]domain.Customer{
+		{ID: 2, LegalName: "Siti Aminah", BirthPlace: "Bandung", BirthDate: birthDate},
+		{ID: 3, LegalName: "Muhammad Yusuf", BirthPlace: "Bandung", BirthDate: birthDate},
+		{ID: 4, LegalName: "Mohammad Jusuf", BirthPlace: "Bandung", BirthDate: birthDate},
+		{ID: 5, LegalName: "Muhamad Yusuf", BirthPlace: "Bandung", BirthDate: birthDate},
+	}, nil)
+	duplicateRepository.EXPECT().FindResolvedIDs(gomock.Any(), uint64(1)).Return([]uint64{5}, nil)
+
+	candidates, err := duplicateService.FindPossibleDuplicates(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, uint64(3), candidates[0].CustomerID)
+	assert.Equal(t, uint64(4), candidates[1].CustomerID)
+	assert.GreaterOrEqual(t, candidates[1].Score, duplicatesrv.MatchThreshold)
+	assert.Equal(t, 1.0, candidates[0].PlaceScore)
+}
+
+func TestDuplicateService_Resolve_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	duplicateRepository := mocks.NewMockDuplicateRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-duplicate-service-unit")
+	duplicateService := duplicatesrv.NewDuplicateService(nil, customerRepository, duplicateRepository, meter, tracer, log)
+
+	ignore := dto.DuplicateResolutionRequest{Action: domain.DuplicateIgnored, Note: "different person"}
+
+	t.Run("Same Customer", func(t *testing.T) {
+		result, err := duplicateService.Resolve(context.Background(), 3, 3, 1, ignore)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, common.ErrDuplicateSelf)
+	})
+
+	t.Run("Duplicate Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3}, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		result, err := duplicateService.Resolve(context.Background(), 3, 99, 1, ignore)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+
+	t.Run("Already Resolved", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3}, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(4)).Return(&domain.Customer{ID: 4}, nil)
+		duplicateRepository.EXPECT().FindResolution(gomock.Any(), uint64(3), uint64(4)).
+			Return(&domain.DuplicateResolution{ID: 8, CustomerID: 4, DuplicateID: 3}, nil)
+
+		result, err := duplicateService.Resolve(context.Background(), 3, 4, 1, ignore)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, common.ErrDuplicateResolved)
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3}, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(6)).Return(&domain.Customer{ID: 6}, nil)
+		duplicateRepository.EXPECT().FindResolution(gomock.Any(), uint64(3), uint64(6)).Return(nil, nil)
+		duplicateRepository.EXPECT().CreateResolution(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, resolution *domain.DuplicateResolution) error {
+				assert.Equal(t, uint64(1), resolution.ResolvedBy)
+				resolution.ID = 11
+				return nil
+			})
+
+		result, err := duplicateService.Resolve(context.Background(), 3, 6, 1, ignore)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), result.ID)
+		assert.Equal(t, domain.DuplicateIgnored, result.Action)
+	})
+}
@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	emailsrv "github.com/fazamuttaqien/multifinance/internal/service/email"
+	notifiersrv "github.com/fazamuttaqien/multifinance/internal/service/notifier"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEmailService_WithMockRepository(t *testing.T) {
+	templates, err := notifiersrv.LoadTemplates()
+	require.NoError(t, err)
+	meter, tracer, log := testutil.Telemetry("test-email-service")
+	cfg := emailsrv.Config{
+		From:        "Multifinance <no-reply@multifinance.test>",
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		Lease:       5 * time.Minute,
+		BatchSize:   10,
+	}
+
+	setup := func(t *testing.T) (*mocks.MockEmailRepository, *mocks.MockCustomerRepository) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockEmailRepository(ctrl), mocks.NewMockCustomerRepository(ctrl)
+	}
+
+	// claim meniru repository: attempt bertambah dan pesan disembunyikan selama lease
+	claim := func(_ context.Context, message *domain.EmailMessage, leaseUntil time.Time) (bool, error) {
+		message.Attempts++
+		message.NextAttemptAt = leaseUntil
+		return true, nil
+	}
+
+	t.Run("Notify - Renders And Queues In Customer Language", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).
+			Return(&domain.Customer{ID: 2, Email: "budi@example.com", Language: domain.LanguageEnglish}, nil)
+
+		var queued *domain.EmailMessage
+		emailRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message *domain.EmailMessage) error {
+			queued = message
+			return nil
+		})
+
+		err := emailService.Notify(context.Background(), 2, domain.TemplateVerificationExpired, map[string]string{"full_name": "Budi"})
+
+		require.NoError(t, err)
+		require.NotNil(t, queued)
+		assert.Equal(t, "budi@example.com", queued.Recipient)
+		assert.Equal(t, domain.EmailPending, queued.Status)
+		assert.Equal(t, domain.LanguageEnglish, queued.Language)
+		assert.Contains(t, queued.Body, "Hi Budi,")
+		assert.False(t, queued.NextAttemptAt.After(time.Now()))
+	})
+
+	t.Run("Notify - Customer Without Email", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3}, nil)
+
+		err := emailService.Notify(context.Background(), 3, domain.TemplateAccountDormant, nil)
+
+		assert.ErrorIs(t, err, common.ErrCustomerHasNoEmail)
+	})
+
+	t.Run("ProcessPending - Sent", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		emailRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), 10).
+			Return([]domain.EmailMessage{{ID: 5, Recipient: "budi@example.com", Status: domain.EmailPending}}, nil)
+		emailRepository.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(claim)
+		emailRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message *domain.EmailMessage) error {
+			assert.Equal(t, domain.EmailSent, message.Status)
+			assert.Equal(t, "stub-email-5", message.ProviderMessageID)
+			assert.Equal(t, "stub", message.Provider)
+			assert.Equal(t, 1, message.Attempts)
+			assert.NotNil(t, message.SentAt)
+			return nil
+		})
+
+		sent, err := emailService.ProcessPending(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("ProcessPending - Temporary Failure Backs Off", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		// Percobaan kedua gagal, jadi jeda berikutnya dua kali backoff
+		emailRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), 10).
+			Return([]domain.EmailMessage{{ID: 6, Recipient: "fail@example.com", Status: domain.EmailPending, Attempts: 1}}, nil)
+		emailRepository.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(claim)
+		emailRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message *domain.EmailMessage) error {
+			assert.Equal(t, domain.EmailPending, message.Status)
+			assert.Equal(t, 2, message.Attempts)
+			assert.NotEmpty(t, message.LastError)
+			assert.WithinDuration(t, time.Now().Add(2*time.Minute), message.NextAttemptAt, 5*time.Second)
+			return nil
+		})
+
+		sent, err := emailService.ProcessPending(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("ProcessPending - Gives Up After Max Attempts", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		emailRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), 10).
+			Return([]domain.EmailMessage{{ID: 7, Recipient: "fail@example.com", Status: domain.EmailPending, Attempts: 2}}, nil)
+		emailRepository.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(claim)
+		emailRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message *domain.EmailMessage) error {
+			assert.Equal(t, domain.EmailFailed, message.Status)
+			assert.Equal(t, 3, message.Attempts)
+			return nil
+		})
+
+		_, err := emailService.ProcessPending(context.Background())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("ProcessPending - Permanent Rejection Not Retried", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		emailRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), 10).
+			Return([]domain.EmailMessage{{ID: 8, Recipient: "reject@example.com", Status: domain.EmailPending}}, nil)
+		emailRepository.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(claim)
+		emailRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message *domain.EmailMessage) error {
+			assert.Equal(t, domain.EmailFailed, message.Status)
+			assert.Equal(t, 1, message.Attempts)
+			return nil
+		})
+
+		_, err := emailService.ProcessPending(context.Background())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("ProcessPending - Claimed By Another Worker", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		emailRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), 10).
+			Return([]domain.EmailMessage{{ID: 9, Recipient: "budi@example.com", Status: domain.EmailPending}}, nil)
+		emailRepository.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		sent, err := emailService.ProcessPending(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("ProcessPending - Repository Error", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		emailRepository.EXPECT().FindDue(gomock.Any(), gomock.Any(), 10).Return(nil, dbErr)
+
+		_, err := emailService.ProcessPending(context.Background())
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("PreviewTemplate - Sample Data With Overrides", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		preview, err := emailService.PreviewTemplate(context.Background(), domain.TemplateMonthlyStatement,
+			dto.EmailPreviewRequest{Language: "en", Data: map[string]string{"full_name": "Siti"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.LanguageEnglish, preview.Language)
+		assert.Equal(t, "Your account summary for 2025-01", preview.Subject)
+		assert.Contains(t, preview.Body, "Hi Siti,")
+		assert.NotContains(t, preview.Body, "<no value>")
+	})
+
+	t.Run("PreviewTemplate - Default Language", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		preview, err := emailService.PreviewTemplate(context.Background(), domain.TemplateAccountDormant, dto.EmailPreviewRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultLanguage, preview.Language)
+		assert.Contains(t, preview.Body, "selama 12 bulan")
+	})
+
+	t.Run("PreviewTemplate - Unknown Template", func(t *testing.T) {
+		emailRepository, customerRepository := setup(t)
+		emailService := emailsrv.NewEmailService(emailRepository, customerRepository, templates, mailer.NewStubSender(), cfg, meter, tracer, log)
+
+		_, err := emailService.PreviewTemplate(context.Background(), domain.NotificationTemplate("password_reset"), dto.EmailPreviewRequest{})
+
+		assert.ErrorIs(t, err, common.ErrEmailTemplateNotFound)
+	})
+}
@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	exportsrv "github.com/fazamuttaqien/multifinance/internal/service/export"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// flushCounter records how often the export pushed rows through.
+type flushCounter struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushCounter) Flush() error {
+	f.flushes++
+	return nil
+}
+
+func TestExportService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-export-unit")
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*mocks.MockCustomerRepository, *mocks.MockTransactionRepository) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockCustomerRepository(ctrl), mocks.NewMockTransactionRepository(ctrl)
+	}
+
+	t.Run("Success - Customers Without Secrets", func(t *testing.T) {
+		customerRepository, transactionRepository := setup(t)
+		exportService := exportsrv.NewExportService(customerRepository, transactionRepository, meter, tracer, log)
+
+		customerRepository.EXPECT().StreamForExport(gomock.Any(), domain.VerificationVerified, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ domain.VerificationStatus, fn func(domain.Customer) error) error {
+				return fn(domain.Customer{
+					ID:                 3,
+					NIK:                "1234567890123456",
+					FullName:           "Budi, Santoso",
+					Password:           "$2a$10$secret",
+					KtpUrl:             "https://cdn.example.com/ktp.jpg",
+					BirthDate:          time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+					Salary:             decimal.NewFromInt(8_000_000),
+					VerificationStatus: domain.VerificationVerified,
+				})
+			})
+
+		var out flushCounter
+		rows, err := exportService.ExportCustomers(context.Background(), &out, domain.VerificationVerified)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, rows)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[0], "id,nik,full_name"))
+		assert.Contains(t, lines[1], `3,1234567890123456,"Budi, Santoso"`)
+		assert.Contains(t, lines[1], "1990-05-17,8000000.00,VERIFIED")
+		assert.NotContains(t, out.String(), "secret")
+		assert.NotContains(t, out.String(), "ktp.jpg")
+		assert.Equal(t, 1, out.flushes)
+	})
+
+	t.Run("Success - Transactions Flushed Every Batch", func(t *testing.T) {
+		customerRepository, transactionRepository := setup(t)
+		exportService := exportsrv.NewExportService(customerRepository, transactionRepository, meter, tracer, log)
+
+		total := exportsrv.FlushRows*2 + 1
+		transactionRepository.EXPECT().StreamForExport(gomock.Any(), from, to, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ time.Time, fn func(domain.Transaction) error) error {
+				for i := 1; i <= total; i++ {
+					if err := fn(domain.Transaction{ID: uint64(i), ContractNumber: fmt.Sprintf("KTR-%06d", i), Status: domain.TransactionActive, TransactionDate: from}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		var out flushCounter
+		rows, err := exportService.ExportTransactions(context.Background(), &out, from, to)
+
+		require.NoError(t, err)
+		assert.Equal(t, total, rows)
+		assert.Equal(t, total+1, strings.Count(out.String(), "\n"))
+		// Dua kali saat batch penuh, sekali di akhir
+		assert.Equal(t, 3, out.flushes)
+	})
+
+	t.Run("Failure - Writer Error Stops The Stream", func(t *testing.T) {
+		customerRepository, transactionRepository := setup(t)
+		exportService := exportsrv.NewExportService(customerRepository, transactionRepository, meter, tracer, log)
+
+		clientGone := errors.New("connection reset by peer")
+		transactionRepository.EXPECT().StreamForExport(gomock.Any(), from, to, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ time.Time, fn func(domain.Transaction) error) error {
+				for i := 1; ; i++ {
+					if err := fn(domain.Transaction{ID: uint64(i)}); err != nil {
+						return err
+					}
+				}
+			})
+
+		rows, err := exportService.ExportTransactions(context.Background(), failingWriter{err: clientGone}, from, to)
+
+		require.ErrorIs(t, err, clientGone)
+		assert.LessOrEqual(t, rows, exportsrv.FlushRows)
+	})
+
+	t.Run("Failure - Empty Range", func(t *testing.T) {
+		customerRepository, transactionRepository := setup(t)
+		exportService := exportsrv.NewExportService(customerRepository, transactionRepository, meter, tracer, log)
+
+		_, err := exportService.ExportTransactions(context.Background(), &bytes.Buffer{}, to, from)
+
+		assert.ErrorIs(t, err, common.ErrInvalidExportRange)
+	})
+}
+
+// failingWriter accepts nothing, like a response whose client went away.
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
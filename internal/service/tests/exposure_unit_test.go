@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	exposuresrv "github.com/fazamuttaqien/multifinance/internal/service/exposure"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExposureService_Reconcile_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	exposureRepository := mocks.NewMockCustomerExposureRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-exposure-service-unit")
+	exposureService := exposuresrv.NewExposureService(exposureRepository, meter, tracer, log)
+
+	t.Run("Repairs Drifted And Missing Rows", func(t *testing.T) {
+		exposureRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.CustomerExposure{
+			{CustomerID: 1, TenorID: 3, ActivePrincipal: decimal.NewFromInt(41000)},
+			// Berbeda dari jumlah transaksi
+			{CustomerID: 2, TenorID: 3, ActivePrincipal: decimal.NewFromInt(10000)},
+			// Kontraknya sudah lunas semua tapi ringkasan belum nol
+			{CustomerID: 3, TenorID: 6, ActivePrincipal: decimal.NewFromInt(5000)},
+		}, nil)
+		exposureRepository.EXPECT().SumTransactions(gomock.Any()).Return([]domain.CustomerExposure{
+			{CustomerID: 1, TenorID: 3, ActivePrincipal: decimal.RequireFromString("41000.00")},
+			{CustomerID: 2, TenorID: 3, ActivePrincipal: decimal.NewFromInt(12500)},
+			// Belum punya baris ringkasan
+			{CustomerID: 4, TenorID: 3, ActivePrincipal: decimal.NewFromInt(7000)},
+		}, nil)
+		exposureRepository.EXPECT().Repair(gomock.Any(), uint64(2), uint(3)).Return(true, nil)
+		exposureRepository.EXPECT().Repair(gomock.Any(), uint64(3), uint(6)).Return(true, nil)
+		// Kontrak baru masuk di antara dua pembacaan, tidak perlu diperbaiki
+		exposureRepository.EXPECT().Repair(gomock.Any(), uint64(4), uint(3)).Return(false, nil)
+
+		run, err := exposureService.Reconcile(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, run.Checked)
+		assert.Equal(t, 2, run.Repaired)
+	})
+
+	t.Run("Consistent Summary", func(t *testing.T) {
+		rows := []domain.CustomerExposure{{CustomerID: 1, TenorID: 3, ActivePrincipal: decimal.NewFromInt(41000)}}
+		exposureRepository.EXPECT().FindAll(gomock.Any()).Return(rows, nil)
+		exposureRepository.EXPECT().SumTransactions(gomock.Any()).Return(rows, nil)
+
+		run, err := exposureService.Reconcile(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Checked)
+		assert.Zero(t, run.Repaired)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		exposureRepository.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("db down"))
+
+		run, err := exposureService.Reconcile(context.Background())
+
+		assert.Nil(t, run)
+		assert.Error(t, err)
+	})
+}
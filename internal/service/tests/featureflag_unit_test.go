@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	featureflagsrv "github.com/fazamuttaqien/multifinance/internal/service/featureflag"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/cache"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/flags"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// flagCache is local only; without Redis the cache behaves like a single instance.
+func flagCache(ttl time.Duration) *cache.Cache[map[string]domain.FeatureFlag] {
+	return cache.New[map[string]domain.FeatureFlag](nil, cache.Options{Name: "feature_flags", LocalTTL: ttl})
+}
+
+func TestFeatureFlagService_Enabled_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-feature-flag-service")
+	stored := []domain.FeatureFlag{
+		{Key: "approval_workflow", Enabled: true},
+		{Key: "optimistic_limits", PartnerIDs: []uint64{7}},
+	}
+
+	t.Run("Serves Flags From Cache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		featureFlagRepository := mocks.NewMockFeatureFlagRepository(ctrl)
+		featureFlagService := featureflagsrv.NewFeatureFlagService(featureFlagRepository, flagCache(time.Minute), meter, tracer, log)
+
+		featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return(stored, nil).Times(1)
+
+		ctx := context.Background()
+		assert.True(t, featureFlagService.Enabled(ctx, "approval_workflow"))
+		assert.False(t, featureFlagService.Enabled(ctx, "optimistic_limits"))
+		assert.False(t, featureFlagService.Enabled(ctx, "unknown_flag"))
+	})
+
+	t.Run("Enabled For Pilot Partner Only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		featureFlagRepository := mocks.NewMockFeatureFlagRepository(ctrl)
+		featureFlagService := featureflagsrv.NewFeatureFlagService(featureFlagRepository, flagCache(time.Minute), meter, tracer, log)
+
+		featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return(stored, nil).Times(1)
+
+		assert.True(t, featureFlagService.Enabled(flags.WithPartner(context.Background(), 7), "optimistic_limits"))
+		assert.False(t, featureFlagService.Enabled(flags.WithPartner(context.Background(), 8), "optimistic_limits"))
+	})
+
+	t.Run("Keeps Cached Flags When Reload Fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		featureFlagRepository := mocks.NewMockFeatureFlagRepository(ctrl)
+		featureFlagService := featureflagsrv.NewFeatureFlagService(featureFlagRepository, flagCache(0), meter, tracer, log)
+
+		gomock.InOrder(
+			featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return(stored, nil),
+			featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("connection refused")),
+		)
+
+		ctx := context.Background()
+		assert.True(t, featureFlagService.Enabled(ctx, "approval_workflow"))
+		assert.True(t, featureFlagService.Enabled(ctx, "approval_workflow"))
+	})
+
+	t.Run("Flags Kept Per Tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		featureFlagRepository := mocks.NewMockFeatureFlagRepository(ctrl)
+		featureFlagService := featureflagsrv.NewFeatureFlagService(featureFlagRepository, flagCache(0), meter, tracer, log)
+
+		// Tenant acme gagal dimuat sejak awal, flag tenant default tidak boleh dipakai
+		gomock.InOrder(
+			featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return(stored, nil),
+			featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("connection refused")),
+		)
+
+		acme := tenancy.WithTenant(context.Background(), &tenancy.Tenant{ID: "acme"})
+		assert.True(t, featureFlagService.Enabled(context.Background(), "approval_workflow"))
+		assert.False(t, featureFlagService.Enabled(acme, "approval_workflow"))
+	})
+}
+
+func TestFeatureFlagService_SetFlag_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-feature-flag-service")
+
+	t.Run("Success - Reloads Cache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		featureFlagRepository := mocks.NewMockFeatureFlagRepository(ctrl)
+		featureFlagService := featureflagsrv.NewFeatureFlagService(featureFlagRepository, flagCache(time.Minute), meter, tracer, log)
+
+		gomock.InOrder(
+			featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.FeatureFlag{}, nil),
+			featureFlagRepository.EXPECT().Upsert(gomock.Any(), &domain.FeatureFlag{Key: "optimistic_limits", Enabled: true, PartnerIDs: []uint64{}, UpdatedBy: 1}).Return(nil),
+			featureFlagRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.FeatureFlag{{Key: "optimistic_limits", Enabled: true}}, nil),
+		)
+
+		ctx := context.Background()
+		assert.False(t, featureFlagService.Enabled(ctx, "optimistic_limits"))
+
+		flag, err := featureFlagService.SetFlag(ctx, "optimistic_limits", 1, dto.FeatureFlagRequest{Enabled: true})
+		require.NoError(t, err)
+		assert.True(t, flag.Enabled)
+
+		assert.True(t, featureFlagService.Enabled(ctx, "optimistic_limits"))
+	})
+
+	t.Run("Failure - Invalid Key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		featureFlagRepository := mocks.NewMockFeatureFlagRepository(ctrl)
+		featureFlagService := featureflagsrv.NewFeatureFlagService(featureFlagRepository, flagCache(time.Minute), meter, tracer, log)
+
+		flag, err := featureFlagService.SetFlag(context.Background(), "Optimistic-Limits", 1, dto.FeatureFlagRequest{Enabled: true})
+
+		assert.Nil(t, flag)
+		assert.ErrorIs(t, err, common.ErrInvalidFeatureFlag)
+	})
+}
+
+func TestFeatureFlagService_DeleteFlag_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	featureFlagRepository := mocks.NewMockFeatureFlagRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-feature-flag-service")
+	featureFlagService := featureflagsrv.NewFeatureFlagService(featureFlagRepository, flagCache(time.Minute), meter, tracer, log)
+
+	featureFlagRepository.EXPECT().Delete(gomock.Any(), "unknown_flag").Return(false, nil)
+
+	assert.ErrorIs(t, featureFlagService.DeleteFlag(context.Background(), "unknown_flag"), common.ErrFeatureFlagNotFound)
+}
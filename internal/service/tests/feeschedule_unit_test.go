@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	feeschedulesrv "github.com/fazamuttaqien/multifinance/internal/service/feeschedule"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFeeScheduleService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-fee-schedule-service")
+
+	t.Run("Set Schedule", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+		feeScheduleRepository := mocks.NewMockFeeScheduleRepository(ctrl)
+		feeScheduleService := feeschedulesrv.NewFeeScheduleService(partnerRepository, feeScheduleRepository, meter, tracer, log)
+
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Partner{ID: 7}, nil)
+		feeScheduleRepository.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, schedule *domain.PartnerFeeSchedule) error {
+				assert.Equal(t, uint64(7), schedule.PartnerID)
+				assert.Equal(t, uint8(12), schedule.TenorMonths)
+				assert.Equal(t, "0.015", schedule.CommissionRate.String())
+				assert.Equal(t, uint64(1), schedule.UpdatedBy)
+				return nil
+			})
+
+		schedule, err := feeScheduleService.SetSchedule(context.Background(), 7, 1, dto.FeeScheduleRequest{
+			TenorMonths:    12,
+			AdminFeeFlat:   decimal.NewFromInt(250000),
+			AdminFeeRate:   decimal.RequireFromString("0.01"),
+			CommissionRate: decimal.RequireFromString("0.015"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "250000", schedule.AdminFeeFlat.String())
+	})
+
+	t.Run("Set Schedule For Unknown Partner", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+		feeScheduleRepository := mocks.NewMockFeeScheduleRepository(ctrl)
+		feeScheduleService := feeschedulesrv.NewFeeScheduleService(partnerRepository, feeScheduleRepository, meter, tracer, log)
+
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		schedule, err := feeScheduleService.SetSchedule(context.Background(), 99, 1, dto.FeeScheduleRequest{})
+
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, common.ErrPartnerNotFound)
+	})
+
+	t.Run("List Schedules Repository Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+		feeScheduleRepository := mocks.NewMockFeeScheduleRepository(ctrl)
+		feeScheduleService := feeschedulesrv.NewFeeScheduleService(partnerRepository, feeScheduleRepository, meter, tracer, log)
+
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Partner{ID: 7}, nil)
+		feeScheduleRepository.EXPECT().FindByPartner(gomock.Any(), uint64(7)).Return(nil, errors.New("db down"))
+
+		schedules, err := feeScheduleService.ListSchedules(context.Background(), 7)
+
+		assert.Nil(t, schedules)
+		assert.Error(t, err)
+	})
+
+	t.Run("Delete Missing Schedule", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+		feeScheduleRepository := mocks.NewMockFeeScheduleRepository(ctrl)
+		feeScheduleService := feeschedulesrv.NewFeeScheduleService(partnerRepository, feeScheduleRepository, meter, tracer, log)
+
+		feeScheduleRepository.EXPECT().Delete(gomock.Any(), uint64(7), uint8(3)).Return(false, nil)
+
+		err := feeScheduleService.DeleteSchedule(context.Background(), 7, 3)
+
+		assert.ErrorIs(t, err, common.ErrFeeScheduleNotFound)
+	})
+}
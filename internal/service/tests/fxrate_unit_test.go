@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	fxratesrv "github.com/fazamuttaqien/multifinance/internal/service/fxrate"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFxRateService_UploadRates_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fxRateRepository := mocks.NewMockFxRateRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-fx-rate-service-unit")
+	fxRateService := fxratesrv.NewFxRateService(fxRateRepository, meter, tracer, log)
+
+	t.Run("Normalizes And Deduplicates", func(t *testing.T) {
+		rateDate := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+		fxRateRepository.EXPECT().UpsertMany(gomock.Any(), []domain.FxRate{
+			{Currency: "USD", RateDate: rateDate, RateToIDR: decimal.NewFromInt(16300), UploadedBy: 1},
+			{Currency: "SGD", RateDate: rateDate, RateToIDR: decimal.NewFromInt(12600), UploadedBy: 1},
+		}).Return(nil)
+
+		rates, err := fxRateService.UploadRates(context.Background(), 1, dto.FxRateUploadRequest{
+			RateDate: "2025-06-30",
+			Rates: []dto.FxRateItemRequest{
+				{Currency: "usd", RateToIDR: decimal.NewFromInt(16250)},
+				{Currency: "SGD", RateToIDR: decimal.NewFromInt(12600)},
+				{Currency: "USD", RateToIDR: decimal.NewFromInt(16300)},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, rates, 2)
+	})
+
+	t.Run("Rejects Base Currency", func(t *testing.T) {
+		rates, err := fxRateService.UploadRates(context.Background(), 1, dto.FxRateUploadRequest{
+			RateDate: "2025-06-30",
+			Rates:    []dto.FxRateItemRequest{{Currency: "IDR", RateToIDR: decimal.NewFromInt(1)}},
+		})
+
+		assert.Nil(t, rates)
+		assert.ErrorIs(t, err, common.ErrBaseCurrencyRate)
+	})
+}
+
+func TestFxRateService_RateToIDR_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fxRateRepository := mocks.NewMockFxRateRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-fx-rate-service-unit")
+	fxRateService := fxratesrv.NewFxRateService(fxRateRepository, meter, tracer, log)
+	asOf := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Base Currency Needs No Rate", func(t *testing.T) {
+		rate, err := fxRateService.RateToIDR(context.Background(), "", asOf)
+
+		require.NoError(t, err)
+		assert.Equal(t, "1", rate.String())
+	})
+
+	t.Run("Latest Rate", func(t *testing.T) {
+		fxRateRepository.EXPECT().FindLatest(gomock.Any(), "USD", asOf).
+			Return(&domain.FxRate{Currency: "USD", RateToIDR: decimal.NewFromInt(16250)}, nil)
+
+		rate, err := fxRateService.RateToIDR(context.Background(), "usd", asOf)
+
+		require.NoError(t, err)
+		assert.Equal(t, "16250", rate.String())
+	})
+
+	t.Run("Rate Not Found", func(t *testing.T) {
+		fxRateRepository.EXPECT().FindLatest(gomock.Any(), "JPY", asOf).Return(nil, nil)
+
+		_, err := fxRateService.RateToIDR(context.Background(), "JPY", asOf)
+
+		assert.ErrorIs(t, err, common.ErrFxRateNotFound)
+	})
+}
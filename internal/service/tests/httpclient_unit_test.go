@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/httpclient"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyEndpoint answers status until it has been called failures times,
+// then 200 with the request body echoed back.
+func flakyEndpoint(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestHTTPClient(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-http-client")
+	client := func(maxRetries int) *http.Client {
+		return httpclient.New(httpclient.Config{
+			Name:       "partner",
+			Timeout:    5 * time.Second,
+			MaxRetries: maxRetries,
+			Backoff:    time.Millisecond,
+		}, meter, tracer, log)
+	}
+
+	t.Run("Retries Idempotent Request", func(t *testing.T) {
+		server, calls := flakyEndpoint(t, 2, http.StatusBadGateway, nil)
+
+		resp, err := client(2).Get(server.URL)
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Gives Up After Max Retries", func(t *testing.T) {
+		server, calls := flakyEndpoint(t, 5, http.StatusGatewayTimeout, nil)
+
+		resp, err := client(2).Get(server.URL)
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("POST Not Repeated After Ambiguous Failure", func(t *testing.T) {
+		server, calls := flakyEndpoint(t, 1, http.StatusBadGateway, nil)
+
+		resp, err := client(2).Post(server.URL, "application/json", strings.NewReader(`{"a":1}`))
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("POST With Idempotency Key Resends Body", func(t *testing.T) {
+		server, calls := flakyEndpoint(t, 1, http.StatusBadGateway, nil)
+
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"a":1}`))
+		require.NoError(t, err)
+		req.Header.Set(httpclient.IdempotencyKeyHeader, "key-1")
+
+		resp, err := client(2).Do(req)
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, `{"a":1}`, string(body))
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("POST Repeated When Server Was Busy", func(t *testing.T) {
+		server, calls := flakyEndpoint(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+
+		resp, err := client(2).Post(server.URL, "application/json", strings.NewReader(`{"a":1}`))
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("No Retries Configured", func(t *testing.T) {
+		server, calls := flakyEndpoint(t, 1, http.StatusServiceUnavailable, nil)
+
+		resp, err := client(0).Get(server.URL)
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Timeout Covers Retries", func(t *testing.T) {
+		server, _ := flakyEndpoint(t, 100, http.StatusServiceUnavailable, http.Header{"Retry-After": {"10"}})
+		slow := httpclient.New(httpclient.Config{Name: "partner", Timeout: 100 * time.Millisecond, MaxRetries: 3}, meter, tracer, log)
+
+		started := time.Now()
+		_, err := slow.Get(server.URL)
+
+		require.Error(t, err)
+		assert.Less(t, time.Since(started), 2*time.Second)
+	})
+}
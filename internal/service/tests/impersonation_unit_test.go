@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	impersonationsrv "github.com/fazamuttaqien/multifinance/internal/service/impersonation"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImpersonationService_WithMocks(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-impersonation-service")
+	cfg := impersonationsrv.Config{JWTSecret: "secret", TTL: 15 * time.Minute}
+	req := dto.ImpersonationRequest{Reason: "Customer reports a missing limit"}
+
+	t.Run("Success - Start Issues Scoped Token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		impersonationRepository := mocks.NewMockImpersonationRepository(ctrl)
+		impersonationService := impersonationsrv.NewImpersonationService(customerRepository, impersonationRepository, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7, Role: domain.CustomerRole}, nil)
+		impersonationRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session *domain.ImpersonationSession) error {
+				assert.Equal(t, uint64(1), session.AdminID)
+				assert.Equal(t, req.Reason, session.Reason)
+				session.ID = 42
+				return nil
+			})
+
+		resp, err := impersonationService.Start(context.Background(), 1, 7, req)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), resp.SessionID)
+
+		claims := &domain.JwtCustomClaims{}
+		_, err = jwt.ParseWithClaims(resp.Token, claims, func(*jwt.Token) (any, error) { return []byte("secret"), nil })
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), claims.UserID)
+		assert.Equal(t, domain.CustomerRole, claims.Role)
+		assert.Equal(t, uint64(1), claims.ImpersonatorID)
+		assert.Equal(t, uint64(42), claims.ImpersonationID)
+		assert.WithinDuration(t, resp.ExpiresAt, claims.ExpiresAt.Time, time.Second)
+	})
+
+	t.Run("Failure - Admin Account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		impersonationRepository := mocks.NewMockImpersonationRepository(ctrl)
+		impersonationService := impersonationsrv.NewImpersonationService(customerRepository, impersonationRepository, cfg, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2, Role: domain.AdminRole}, nil)
+
+		_, err := impersonationService.Start(context.Background(), 1, 2, req)
+
+		assert.ErrorIs(t, err, common.ErrImpersonationNotAllowed)
+	})
+
+	t.Run("Failure - Authorize Ended Session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		impersonationRepository := mocks.NewMockImpersonationRepository(ctrl)
+		impersonationService := impersonationsrv.NewImpersonationService(nil, impersonationRepository, cfg, meter, tracer, log)
+
+		endedAt := time.Now().Add(-time.Minute)
+		impersonationRepository.EXPECT().FindByID(gomock.Any(), uint64(42)).
+			Return(&domain.ImpersonationSession{ID: 42, ExpiresAt: time.Now().Add(10 * time.Minute), EndedAt: &endedAt}, nil)
+
+		err := impersonationService.Authorize(context.Background(), 42)
+
+		assert.ErrorIs(t, err, common.ErrImpersonationEnded)
+	})
+
+	t.Run("Failure - Authorize Expired Session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		impersonationRepository := mocks.NewMockImpersonationRepository(ctrl)
+		impersonationService := impersonationsrv.NewImpersonationService(nil, impersonationRepository, cfg, meter, tracer, log)
+
+		impersonationRepository.EXPECT().FindByID(gomock.Any(), uint64(42)).
+			Return(&domain.ImpersonationSession{ID: 42, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+		err := impersonationService.Authorize(context.Background(), 42)
+
+		assert.ErrorIs(t, err, common.ErrImpersonationEnded)
+	})
+
+	t.Run("Failure - End Already Ended", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		impersonationRepository := mocks.NewMockImpersonationRepository(ctrl)
+		impersonationService := impersonationsrv.NewImpersonationService(nil, impersonationRepository, cfg, meter, tracer, log)
+
+		impersonationRepository.EXPECT().End(gomock.Any(), uint64(42), gomock.Any()).Return(false, nil)
+
+		err := impersonationService.End(context.Background(), 42)
+
+		assert.ErrorIs(t, err, common.ErrImpersonationNotFound)
+	})
+}
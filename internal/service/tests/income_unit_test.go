@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	incomesrv "github.com/fazamuttaqien/multifinance/internal/service/income"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/pdf"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type incomeMocks struct {
+	incomeRepository   *mocks.MockIncomeVerificationRepository
+	customerRepository *mocks.MockCustomerRepository
+	cloudinaryService  *servicemocks.MockCloudinaryService
+}
+
+func newIncomeService(t *testing.T) (*incomeMocks, service.IncomeVerificationServices) {
+	meter, tracer, log := testutil.Telemetry("test-income-service")
+
+	ctrl := gomock.NewController(t)
+	m := &incomeMocks{
+		incomeRepository:   mocks.NewMockIncomeVerificationRepository(ctrl),
+		customerRepository: mocks.NewMockCustomerRepository(ctrl),
+		cloudinaryService:  servicemocks.NewMockCloudinaryService(ctrl),
+	}
+	cfg := incomesrv.Config{Folder: "bank-statements", MaxSize: 64 * 1024, DiscrepancyThreshold: decimal.RequireFromString("0.3")}
+	return m, incomesrv.NewIncomeVerificationService(m.incomeRepository, m.customerRepository, m.cloudinaryService, cfg, meter, tracer, log)
+}
+
+// statementPDF menggambar mutasi rekening seperti ekspor internet banking,
+// setiap kolom ditulis terpisah pada baris yang sama
+func statementPDF() []byte {
+	rows := [][]string{
+		{"05/01/2025", "TRANSFER GAJI PT MAJU", "9.800.000,00 CR", "11.800.000,00"},
+		{"12/01/2025", "TARIK TUNAI ATM", "1.500.000,00 DB", "10.300.000,00"},
+		{"05/02/2025", "TRANSFER GAJI PT MAJU", "9.800.000,00 CR", "20.100.000,00"},
+		{"05/03/2025", "TRANSFER GAJI PT MAJU", "9.800.000,00 CR", "29.900.000,00"},
+	}
+
+	doc := pdf.New()
+	doc.Text(20, 20, 12, true, pdf.Black, "MUTASI REKENING")
+	doc.Text(20, 28, 9, false, pdf.Gray, "Periode 01/01/2025 - 31/03/2025")
+	for i, row := range rows {
+		y := 40 + float64(i)*7
+		doc.Text(20, y, 9, false, pdf.Black, row[0])
+		doc.Text(45, y, 9, false, pdf.Black, row[1])
+		doc.TextRight(150, y, 9, false, pdf.Black, row[2])
+		doc.TextRight(190, y, 9, false, pdf.Black, row[3])
+	}
+	return doc.Bytes()
+}
+
+func TestIncomeService_Upload(t *testing.T) {
+	customer := &domain.Customer{ID: 3, Salary: decimal.NewFromInt(10000000)}
+
+	t.Run("Success - PDF Close To Declared Salary", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+		data := statementPDF()
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(customer, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), data, "bank-statements", gomock.Any()).
+			Return("https://files.example/statement.pdf", nil)
+		m.incomeRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, verification *domain.IncomeVerification) error {
+				verification.ID = 1
+				return nil
+			})
+
+		verification, err := incomeService.Upload(context.Background(), 3, "../mutasi.pdf", data)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.IncomeMatched, verification.Status)
+		assert.Equal(t, "mutasi.pdf", verification.FileName)
+		assert.Equal(t, 3, verification.MonthsCovered)
+		assert.Equal(t, 4, verification.TransactionCount)
+		assert.True(t, decimal.NewFromInt(9800000).Equal(verification.AverageMonthlyInflow), verification.AverageMonthlyInflow.String())
+		assert.True(t, decimal.RequireFromString("0.02").Equal(verification.Discrepancy), verification.Discrepancy.String())
+		assert.True(t, customer.Salary.Equal(verification.DeclaredSalary))
+	})
+
+	t.Run("Success - CSV Far Below Declared Salary Is Flagged", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+		// Februari tanpa pemasukan tetap dihitung sebagai satu bulan
+		data := []byte("Tanggal;Keterangan;Debit;Kredit\n" +
+			"01/01/2025;Saldo Awal;;\n" +
+			"10/01/2025;TRANSFER MASUK;;4.500.000\n" +
+			"15/02/2025;BELANJA;250.000;\n" +
+			"10/03/2025;TRANSFER MASUK;;4.500.000\n")
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(customer, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), data, "bank-statements", gomock.Any()).
+			Return("https://files.example/statement.csv", nil)
+		m.incomeRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		verification, err := incomeService.Upload(context.Background(), 3, "mutasi.csv", data)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.IncomeFlagged, verification.Status)
+		assert.Equal(t, 3, verification.MonthsCovered)
+		assert.True(t, decimal.NewFromInt(3000000).Equal(verification.AverageMonthlyInflow), verification.AverageMonthlyInflow.String())
+		assert.True(t, decimal.RequireFromString("0.7").Equal(verification.Discrepancy), verification.Discrepancy.String())
+	})
+
+	t.Run("Failure - Unreadable File", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(customer, nil)
+
+		_, err := incomeService.Upload(context.Background(), 3, "photo.png", []byte("\x89PNG\r\n\x1a\n"))
+
+		assert.ErrorIs(t, err, common.ErrStatementUnreadable)
+	})
+
+	t.Run("Failure - No Transactions", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(customer, nil)
+
+		_, err := incomeService.Upload(context.Background(), 3, "mutasi.csv", []byte("date,description,amount\n"))
+
+		assert.ErrorIs(t, err, common.ErrStatementEmpty)
+	})
+
+	t.Run("Failure - Too Large", func(t *testing.T) {
+		_, incomeService := newIncomeService(t)
+
+		_, err := incomeService.Upload(context.Background(), 3, "mutasi.pdf", make([]byte, 64*1024+1))
+
+		assert.ErrorIs(t, err, common.ErrStatementTooLarge)
+	})
+
+	t.Run("Failure - Customer Not Found", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(nil, nil)
+
+		_, err := incomeService.Upload(context.Background(), 3, "mutasi.pdf", statementPDF())
+
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
+
+func TestIncomeService_Review(t *testing.T) {
+	req := dto.IncomeVerificationReviewRequest{Status: domain.IncomeAccepted, Note: "Bonus tahunan"}
+
+	t.Run("Success", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		m.incomeRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).
+			Return(&domain.IncomeVerification{ID: 5, Status: domain.IncomeFlagged}, nil)
+		m.incomeRepository.EXPECT().Review(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, verification *domain.IncomeVerification) (bool, error) {
+				assert.Equal(t, domain.IncomeAccepted, verification.Status)
+				assert.Equal(t, uint64(1), *verification.ReviewerID)
+				assert.NotNil(t, verification.ReviewedAt)
+				return true, nil
+			})
+
+		verification, err := incomeService.Review(context.Background(), 5, 1, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bonus tahunan", verification.ReviewNote)
+	})
+
+	t.Run("Failure - Not Flagged", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		m.incomeRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).
+			Return(&domain.IncomeVerification{ID: 5, Status: domain.IncomeMatched}, nil)
+
+		_, err := incomeService.Review(context.Background(), 5, 1, req)
+
+		assert.ErrorIs(t, err, common.ErrIncomeCheckReviewed)
+	})
+
+	t.Run("Failure - Reviewed Concurrently", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		m.incomeRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).
+			Return(&domain.IncomeVerification{ID: 5, Status: domain.IncomeFlagged}, nil)
+		m.incomeRepository.EXPECT().Review(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		_, err := incomeService.Review(context.Background(), 5, 1, req)
+
+		assert.ErrorIs(t, err, common.ErrIncomeCheckReviewed)
+	})
+
+	t.Run("Failure - Not Found", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		m.incomeRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).Return(nil, nil)
+
+		_, err := incomeService.Review(context.Background(), 5, 1, req)
+
+		assert.ErrorIs(t, err, common.ErrIncomeCheckNotFound)
+	})
+
+	t.Run("Failure - Repository Error", func(t *testing.T) {
+		m, incomeService := newIncomeService(t)
+
+		dbErr := errors.New("connection refused")
+		m.incomeRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).Return(nil, dbErr)
+
+		_, err := incomeService.Review(context.Background(), 5, 1, req)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	maintenancesrv "github.com/fazamuttaqien/multifinance/internal/service/maintenance"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/businesshours"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMaintenanceService_Check(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-maintenance-service")
+
+	hours, err := businesshours.Parse("06:00-22:00", "Asia/Jakarta")
+	require.NoError(t, err)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	cfg := maintenancesrv.Config{Hours: map[domain.GateScope]*businesshours.Hours{domain.GatePartnerTransactions: hours}}
+	noon := time.Date(2025, 3, 10, 12, 0, 0, 0, jakarta)
+
+	t.Run("Open During Business Hours", func(t *testing.T) {
+		maintenanceRepository := mocks.NewMockMaintenanceRepository(gomock.NewController(t))
+		maintenanceService := maintenancesrv.NewMaintenanceService(maintenanceRepository, cfg, meter, tracer, log)
+
+		maintenanceRepository.EXPECT().Find(gomock.Any(), domain.GatePartnerTransactions).Return(nil, nil)
+
+		closure, err := maintenanceService.Check(context.Background(), domain.GatePartnerTransactions, noon)
+
+		require.NoError(t, err)
+		assert.Nil(t, closure)
+	})
+
+	t.Run("Closed By Maintenance Until", func(t *testing.T) {
+		maintenanceRepository := mocks.NewMockMaintenanceRepository(gomock.NewController(t))
+		maintenanceService := maintenancesrv.NewMaintenanceService(maintenanceRepository, cfg, meter, tracer, log)
+
+		until := noon.Add(30 * time.Minute)
+		maintenanceRepository.EXPECT().Find(gomock.Any(), domain.GatePartnerTransactions).
+			Return(&domain.MaintenanceMode{Scope: domain.GatePartnerTransactions, Message: "End of day batch", Until: &until}, nil)
+
+		closure, err := maintenanceService.Check(context.Background(), domain.GatePartnerTransactions, noon)
+
+		require.NoError(t, err)
+		require.NotNil(t, closure)
+		assert.Equal(t, domain.GateReasonMaintenance, closure.Reason)
+		assert.Equal(t, "End of day batch", closure.Message)
+		assert.True(t, closure.RetryAt.Equal(until))
+	})
+
+	t.Run("Expired Maintenance Falls Back To Hours", func(t *testing.T) {
+		maintenanceRepository := mocks.NewMockMaintenanceRepository(gomock.NewController(t))
+		maintenanceService := maintenancesrv.NewMaintenanceService(maintenanceRepository, cfg, meter, tracer, log)
+
+		night := time.Date(2025, 3, 10, 23, 30, 0, 0, jakarta)
+		until := night.Add(-time.Hour)
+		maintenanceRepository.EXPECT().Find(gomock.Any(), domain.GatePartnerTransactions).
+			Return(&domain.MaintenanceMode{Scope: domain.GatePartnerTransactions, Until: &until}, nil)
+
+		closure, err := maintenanceService.Check(context.Background(), domain.GatePartnerTransactions, night)
+
+		require.NoError(t, err)
+		require.NotNil(t, closure)
+		assert.Equal(t, domain.GateReasonBusinessHours, closure.Reason)
+		assert.True(t, closure.RetryAt.Equal(time.Date(2025, 3, 11, 6, 0, 0, 0, jakarta)))
+	})
+
+	t.Run("Closed By Config", func(t *testing.T) {
+		maintenanceRepository := mocks.NewMockMaintenanceRepository(gomock.NewController(t))
+		maintenanceService := maintenancesrv.NewMaintenanceService(maintenanceRepository, maintenancesrv.Config{Enabled: true}, meter, tracer, log)
+
+		closure, err := maintenanceService.Check(context.Background(), domain.GatePartnerTransactions, noon)
+
+		require.NoError(t, err)
+		require.NotNil(t, closure)
+		assert.Equal(t, domain.GateReasonMaintenance, closure.Reason)
+		assert.True(t, closure.RetryAt.IsZero())
+	})
+}
+
+func TestMaintenanceService_SetMode(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-maintenance-service")
+
+	t.Run("Failure - Unknown Scope", func(t *testing.T) {
+		maintenanceRepository := mocks.NewMockMaintenanceRepository(gomock.NewController(t))
+		maintenanceService := maintenancesrv.NewMaintenanceService(maintenanceRepository, maintenancesrv.Config{}, meter, tracer, log)
+
+		_, err := maintenanceService.SetMode(context.Background(), "payments", 1, dto.MaintenanceRequest{Message: "Closed"})
+
+		assert.ErrorIs(t, err, common.ErrUnknownGateScope)
+	})
+
+	t.Run("Failure - Until In The Past", func(t *testing.T) {
+		maintenanceRepository := mocks.NewMockMaintenanceRepository(gomock.NewController(t))
+		maintenanceService := maintenancesrv.NewMaintenanceService(maintenanceRepository, maintenancesrv.Config{}, meter, tracer, log)
+
+		past := time.Now().Add(-time.Minute)
+		_, err := maintenanceService.SetMode(context.Background(), domain.GatePartnerTransactions, 1, dto.MaintenanceRequest{Message: "Closed", Until: &past})
+
+		assert.ErrorIs(t, err, common.ErrInvalidMaintenanceUntil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		maintenanceRepository := mocks.NewMockMaintenanceRepository(gomock.NewController(t))
+		maintenanceService := maintenancesrv.NewMaintenanceService(maintenanceRepository, maintenancesrv.Config{}, meter, tracer, log)
+
+		maintenanceRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		mode, err := maintenanceService.SetMode(context.Background(), domain.GatePartnerTransactions, 1, dto.MaintenanceRequest{Message: "End of day batch"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), mode.UpdatedBy)
+		assert.Nil(t, mode.Until)
+	})
+}
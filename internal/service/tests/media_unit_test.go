@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	mediasrv "github.com/fazamuttaqien/multifinance/internal/service/media"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/cloudinary"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseAssetURL(t *testing.T) {
+	t.Run("Image With Version", func(t *testing.T) {
+		asset, err := cloudinary.ParseAssetURL("https://res.cloudinary.com/demo/image/upload/v1712345678/tenant/multifinance/ktp_1712345678.jpg")
+
+		require.NoError(t, err)
+		assert.Equal(t, cloudinary.Asset{ResourceType: "image", Type: "upload", PublicID: "tenant/multifinance/ktp_1712345678"}, asset)
+	})
+
+	t.Run("Signed Raw Keeps Extension", func(t *testing.T) {
+		asset, err := cloudinary.ParseAssetURL("https://res.cloudinary.com/demo/raw/authenticated/s--abc123--/v1/statements/2025-01.pdf")
+
+		require.NoError(t, err)
+		assert.Equal(t, cloudinary.Asset{ResourceType: "raw", Type: "authenticated", PublicID: "statements/2025-01.pdf"}, asset)
+	})
+
+	t.Run("Not An Asset URL", func(t *testing.T) {
+		_, err := cloudinary.ParseAssetURL("https://example.com/ktp.jpg")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMediaService_CleanupOrphans(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-media-service")
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := mediasrv.Config{Folder: "multifinance", GracePeriod: 24 * time.Hour, BatchSize: 2}
+
+	t.Run("Success - Deletes Old Unreferenced Images Only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cloudinaryService := servicemocks.NewMockCloudinaryService(ctrl)
+		mediaRepository := mocks.NewMockMediaRepository(ctrl)
+		mediaService := mediasrv.NewMediaService(cloudinaryService, mediaRepository, cfg, meter, tracer, log)
+
+		cloudinaryService.EXPECT().ListImages(gomock.Any(), "multifinance", "", 2).Return(&domain.StoredImagePage{
+			Images: []domain.StoredImage{
+				{URL: "https://cdn/orphan.jpg", CreatedAt: now.Add(-48 * time.Hour)},
+				{URL: "https://cdn/ktp.jpg", CreatedAt: now.Add(-48 * time.Hour)},
+				{URL: "https://cdn/uploading.jpg", CreatedAt: now.Add(-time.Minute)},
+			},
+		}, nil)
+		mediaRepository.EXPECT().FindReferenced(gomock.Any(), []string{"https://cdn/orphan.jpg", "https://cdn/ktp.jpg"}).
+			Return(map[string]bool{"https://cdn/ktp.jpg": true}, nil)
+		cloudinaryService.EXPECT().DeleteImage(gomock.Any(), "https://cdn/orphan.jpg").Return(nil)
+
+		run, err := mediaService.CleanupOrphans(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.MediaCleanupRun{Scanned: 3, Deleted: 1}, *run)
+	})
+
+	t.Run("Success - Next Run Resumes From Cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cloudinaryService := servicemocks.NewMockCloudinaryService(ctrl)
+		mediaRepository := mocks.NewMockMediaRepository(ctrl)
+		mediaService := mediasrv.NewMediaService(cloudinaryService, mediaRepository, cfg, meter, tracer, log)
+
+		gomock.InOrder(
+			cloudinaryService.EXPECT().ListImages(gomock.Any(), "multifinance", "", 2).
+				Return(&domain.StoredImagePage{NextCursor: "page-2"}, nil),
+			cloudinaryService.EXPECT().ListImages(gomock.Any(), "multifinance", "page-2", 2).
+				Return(&domain.StoredImagePage{}, nil),
+			cloudinaryService.EXPECT().ListImages(gomock.Any(), "multifinance", "", 2).
+				Return(&domain.StoredImagePage{}, nil),
+		)
+		mediaRepository.EXPECT().FindReferenced(gomock.Any(), gomock.Any()).Return(map[string]bool{}, nil).Times(3)
+
+		for range 3 {
+			_, err := mediaService.CleanupOrphans(context.Background(), now)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Failure - Delete Error Counted And Run Continues", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cloudinaryService := servicemocks.NewMockCloudinaryService(ctrl)
+		mediaRepository := mocks.NewMockMediaRepository(ctrl)
+		mediaService := mediasrv.NewMediaService(cloudinaryService, mediaRepository, cfg, meter, tracer, log)
+
+		cloudinaryService.EXPECT().ListImages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&domain.StoredImagePage{
+			Images: []domain.StoredImage{
+				{URL: "https://cdn/a.jpg", CreatedAt: now.Add(-48 * time.Hour)},
+				{URL: "https://cdn/b.jpg", CreatedAt: now.Add(-48 * time.Hour)},
+			},
+		}, nil)
+		mediaRepository.EXPECT().FindReferenced(gomock.Any(), gomock.Any()).Return(map[string]bool{}, nil)
+		cloudinaryService.EXPECT().DeleteImage(gomock.Any(), "https://cdn/a.jpg").Return(errors.New("rate limited"))
+		cloudinaryService.EXPECT().DeleteImage(gomock.Any(), "https://cdn/b.jpg").Return(nil)
+
+		run, err := mediaService.CleanupOrphans(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.MediaCleanupRun{Scanned: 2, Deleted: 1, Failed: 1}, *run)
+	})
+
+	t.Run("Failure - Database Error Deletes Nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cloudinaryService := servicemocks.NewMockCloudinaryService(ctrl)
+		mediaRepository := mocks.NewMockMediaRepository(ctrl)
+		mediaService := mediasrv.NewMediaService(cloudinaryService, mediaRepository, cfg, meter, tracer, log)
+
+		cloudinaryService.EXPECT().ListImages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&domain.StoredImagePage{
+			Images: []domain.StoredImage{{URL: "https://cdn/a.jpg", CreatedAt: now.Add(-48 * time.Hour)}},
+		}, nil)
+		mediaRepository.EXPECT().FindReferenced(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+		_, err := mediaService.CleanupOrphans(context.Background(), now)
+
+		assert.ErrorContains(t, err, "failed to find referenced images")
+	})
+}
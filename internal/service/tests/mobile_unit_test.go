@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	mobilesrv "github.com/fazamuttaqien/multifinance/internal/service/mobile"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type mobileMocks struct {
+	profileService        *servicemocks.MockProfileServices
+	transactionRepository *mocks.MockTransactionRepository
+	tenorRepository       *mocks.MockTenorRepository
+}
+
+func newMobileService(t *testing.T) (*mobileMocks, service.MobileServices) {
+	meter, tracer, log := testutil.Telemetry("test-mobile-service")
+
+	ctrl := gomock.NewController(t)
+	m := &mobileMocks{
+		profileService:        servicemocks.NewMockProfileServices(ctrl),
+		transactionRepository: mocks.NewMockTransactionRepository(ctrl),
+		tenorRepository:       mocks.NewMockTenorRepository(ctrl),
+	}
+	return m, mobilesrv.NewMobileService(m.profileService, m.transactionRepository, m.tenorRepository,
+		testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+}
+
+func TestMobileService_Home(t *testing.T) {
+	now := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	customer := &domain.Customer{ID: 7, FullName: "Budi", VerificationStatus: domain.VerificationVerified}
+	limits := []dto.LimitDetailResponse{
+		{TenorMonths: 6, Currency: "IDR", LimitAmount: decimal.NewFromInt(10_000_000), UsedAmount: decimal.NewFromInt(4_000_000), RemainingLimit: decimal.NewFromInt(6_000_000), Version: 3},
+	}
+	tenors := []domain.Tenor{{ID: 1, DurationMonths: 12}, {ID: 2, DurationMonths: 6}}
+
+	t.Run("Success - Earliest Unpaid Installment", func(t *testing.T) {
+		m, mobileService := newMobileService(t)
+
+		// Kontrak pertama menunggak cicilan ke-5 (jatuh tempo 10 Juni), kontrak
+		// kedua baru jatuh tempo lagi 1 Juli
+		paid := 4
+		m.profileService.EXPECT().GetMyProfile(gomock.Any(), uint64(7)).Return(customer, nil)
+		m.profileService.EXPECT().GetMyLimits(gomock.Any(), uint64(7)).Return(limits, nil)
+		m.transactionRepository.EXPECT().FindActiveByCustomerID(gomock.Any(), uint64(7)).Return([]domain.Transaction{
+			{ContractNumber: "KTR-001", TenorID: 1, TransactionDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), TotalInstallmentAmount: decimal.NewFromInt(12_000_000), PaidInstallments: &paid},
+			{ContractNumber: "KTR-002", TenorID: 2, TransactionDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), TotalInstallmentAmount: decimal.NewFromInt(3_000_000), Currency: "usd"},
+		}, nil)
+		m.tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+
+		home, err := mobileService.Home(context.Background(), 7, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Budi", home.FullName)
+		assert.Equal(t, 2, home.ActiveContracts)
+		require.Len(t, home.Limits, 1)
+		assert.Equal(t, "6000000", home.Limits[0].RemainingLimit.String())
+		require.NotNil(t, home.NextInstallment)
+		assert.Equal(t, "KTR-001", home.NextInstallment.ContractNumber)
+		assert.Equal(t, 5, home.NextInstallment.Sequence)
+		assert.Equal(t, time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), home.NextInstallment.DueDate)
+		assert.Equal(t, "1000000", home.NextInstallment.Amount.String())
+		assert.True(t, home.NextInstallment.Overdue)
+	})
+
+	t.Run("Success - Nothing Due", func(t *testing.T) {
+		m, mobileService := newMobileService(t)
+
+		m.profileService.EXPECT().GetMyProfile(gomock.Any(), uint64(7)).Return(customer, nil)
+		m.profileService.EXPECT().GetMyLimits(gomock.Any(), uint64(7)).Return([]dto.LimitDetailResponse{}, nil)
+		m.transactionRepository.EXPECT().FindActiveByCustomerID(gomock.Any(), uint64(7)).Return(nil, nil)
+
+		home, err := mobileService.Home(context.Background(), 7, now)
+
+		require.NoError(t, err)
+		assert.Zero(t, home.ActiveContracts)
+		assert.Empty(t, home.Limits)
+		assert.Nil(t, home.NextInstallment)
+	})
+
+	t.Run("Failure - Lookup Error", func(t *testing.T) {
+		m, mobileService := newMobileService(t)
+
+		repoErr := errors.New("connection refused")
+		m.profileService.EXPECT().GetMyProfile(gomock.Any(), uint64(7)).Return(customer, nil).AnyTimes()
+		m.profileService.EXPECT().GetMyLimits(gomock.Any(), uint64(7)).Return(limits, nil).AnyTimes()
+		m.transactionRepository.EXPECT().FindActiveByCustomerID(gomock.Any(), uint64(7)).Return(nil, repoErr)
+
+		home, err := mobileService.Home(context.Background(), 7, now)
+
+		assert.Nil(t, home)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	notifiersrv "github.com/fazamuttaqien/multifinance/internal/service/notifier"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationTemplates_Render(t *testing.T) {
+	templates, err := notifiersrv.LoadTemplates()
+	require.NoError(t, err)
+
+	data := map[string]string{"full_name": "Budi Santoso", "deadline": "2025-04-20"}
+
+	t.Run("Indonesian", func(t *testing.T) {
+		subject, body, err := templates.Render(domain.TemplateVerificationReminder, domain.LanguageIndonesian, data)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Lengkapi verifikasi akun Anda", subject)
+		assert.Contains(t, body, "Halo Budi Santoso,")
+		assert.Contains(t, body, "sampai 2025-04-20")
+	})
+
+	t.Run("English", func(t *testing.T) {
+		subject, body, err := templates.Render(domain.TemplateVerificationReminder, domain.LanguageEnglish, data)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Complete your account verification", subject)
+		assert.Contains(t, body, "Hi Budi Santoso,")
+	})
+
+	t.Run("Optional Variable Omitted", func(t *testing.T) {
+		_, body, err := templates.Render(domain.TemplateVerificationReminder, domain.LanguageEnglish, map[string]string{"full_name": "Budi Santoso"})
+
+		require.NoError(t, err)
+		assert.NotContains(t, body, "cancelled automatically")
+		assert.NotContains(t, body, "<no value>")
+	})
+
+	t.Run("Unknown Language Falls Back", func(t *testing.T) {
+		subject, _, err := templates.Render(domain.TemplateVerificationExpired, domain.Language("fr"), data)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Pendaftaran Anda dibatalkan", subject)
+	})
+
+	t.Run("Every Template Renders", func(t *testing.T) {
+		for _, name := range domain.NotificationTemplates {
+			for _, language := range []domain.Language{domain.LanguageIndonesian, domain.LanguageEnglish} {
+				subject, body, err := templates.Render(name, language, data)
+				require.NoError(t, err, "%s/%s", language, name)
+				assert.NotEmpty(t, subject, "%s/%s", language, name)
+				assert.NotEmpty(t, body, "%s/%s", language, name)
+			}
+		}
+	})
+}
+
+func TestLogNotifier_Notify(t *testing.T) {
+	templates, err := notifiersrv.LoadTemplates()
+	require.NoError(t, err)
+	_, _, log := testutil.Telemetry("test-log-notifier")
+
+	t.Run("Uses Customer Language", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		notifier := notifiersrv.NewLogNotifier(customerRepository, templates, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2, Language: domain.LanguageEnglish}, nil)
+
+		assert.NoError(t, notifier.Notify(context.Background(), 2, domain.TemplateVerificationExpired, map[string]string{"full_name": "Budi"}))
+	})
+
+	t.Run("Customer Not Found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		notifier := notifiersrv.NewLogNotifier(customerRepository, templates, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(9)).Return(nil, nil)
+
+		assert.ErrorIs(t, notifier.Notify(context.Background(), 9, domain.TemplateVerificationExpired, nil), common.ErrCustomerNotFound)
+	})
+}
+
+func TestPushTemplates_Render(t *testing.T) {
+	templates, err := notifiersrv.LoadPushTemplates()
+	require.NoError(t, err)
+
+	data := map[string]string{"contract_number": "KTR-001", "installment": "2", "due_date": "2025-03-10", "amount": "IDR 100.000,00", "reason": "KTP buram"}
+
+	for _, name := range domain.PushTemplates {
+		for _, language := range []domain.Language{domain.LanguageIndonesian, domain.LanguageEnglish} {
+			title, body, err := templates.Render(name, language, data)
+			require.NoError(t, err, "%s/%s", language, name)
+			assert.NotEmpty(t, title, "%s/%s", language, name)
+			assert.NotEmpty(t, body, "%s/%s", language, name)
+		}
+	}
+}
+
+func TestChannelNotifier_Notify(t *testing.T) {
+	_, _, log := testutil.Telemetry("test-channel-notifier")
+	data := map[string]string{"full_name": "Budi Santoso"}
+
+	setup := func(t *testing.T) (*mocks.MockNotificationRepository, *servicemocks.MockCustomerNotifier, *servicemocks.MockCustomerNotifier, service.CustomerNotifier) {
+		ctrl := gomock.NewController(t)
+		notificationRepository := mocks.NewMockNotificationRepository(ctrl)
+		email := servicemocks.NewMockCustomerNotifier(ctrl)
+		push := servicemocks.NewMockCustomerNotifier(ctrl)
+		notifier := notifiersrv.NewChannelNotifier(map[domain.NotificationChannel]service.CustomerNotifier{
+			domain.ChannelEmail: email,
+			domain.ChannelPush:  push,
+		}, notificationRepository, log)
+		return notificationRepository, email, push, notifier
+	}
+
+	t.Run("Every Channel By Default", func(t *testing.T) {
+		notificationRepository, email, push, notifier := setup(t)
+
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).Return(nil, nil)
+		email.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplateVerificationApproved, data).Return(nil)
+		push.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplateVerificationApproved, data).Return(nil)
+
+		assert.NoError(t, notifier.Notify(context.Background(), 2, domain.TemplateVerificationApproved, data))
+	})
+
+	t.Run("Disabled Channel Not Used", func(t *testing.T) {
+		notificationRepository, email, _, notifier := setup(t)
+
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).
+			Return(&domain.NotificationPreferences{CustomerID: 2, Email: true, Push: false}, nil)
+		email.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplatePaymentReminder, data).Return(nil)
+
+		assert.NoError(t, notifier.Notify(context.Background(), 2, domain.TemplatePaymentReminder, data))
+	})
+
+	t.Run("One Channel Delivered", func(t *testing.T) {
+		notificationRepository, email, push, notifier := setup(t)
+
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).Return(nil, nil)
+		email.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("no email"))
+		push.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, notifier.Notify(context.Background(), 2, domain.TemplatePaymentReminder, data))
+	})
+
+	t.Run("Every Channel Failed", func(t *testing.T) {
+		notificationRepository, email, push, notifier := setup(t)
+
+		sendErr := errors.New("smtp down")
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).Return(nil, nil)
+		email.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sendErr)
+		push.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(common.ErrNotificationSkipped)
+
+		assert.ErrorIs(t, notifier.Notify(context.Background(), 2, domain.TemplatePaymentReminder, data), sendErr)
+	})
+
+	t.Run("Preference Lookup Failure Sends Anyway", func(t *testing.T) {
+		notificationRepository, email, push, notifier := setup(t)
+
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).Return(nil, errors.New("db down"))
+		email.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		push.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, notifier.Notify(context.Background(), 2, domain.TemplateVerificationApproved, data))
+	})
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	onboardingsrv "github.com/fazamuttaqien/multifinance/internal/service/onboarding"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/apikey"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOnboardingService_Register(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-onboarding-service")
+	onboardingService := onboardingsrv.NewOnboardingService(partnerRepository, meter, tracer, log)
+
+	t.Run("Issues Sandbox Key", func(t *testing.T) {
+		var stored *domain.Partner
+		partnerRepository.EXPECT().FindByEmail(gomock.Any(), "dealer@example.com").Return(nil, nil)
+		partnerRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, p *domain.Partner) error {
+				p.ID = 10
+				stored = p
+				return nil
+			})
+
+		res, err := onboardingService.Register(context.Background(), dto.RegisterPartnerRequest{
+			Name:  "Dealer",
+			Email: " Dealer@Example.com ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), res.PartnerID)
+		assert.True(t, res.Sandbox)
+		assert.True(t, apikey.IsSandbox(res.APIKey))
+		assert.Equal(t, domain.PartnerSandbox, stored.Status)
+		assert.Equal(t, apikey.Hash(res.APIKey), stored.SandboxKeyHash, "only the hash should be stored")
+	})
+
+	t.Run("Email Already Registered", func(t *testing.T) {
+		partnerRepository.EXPECT().FindByEmail(gomock.Any(), "dealer@example.com").Return(&domain.Partner{ID: 10}, nil)
+
+		res, err := onboardingService.Register(context.Background(), dto.RegisterPartnerRequest{Name: "Dealer", Email: "dealer@example.com"})
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrPartnerExists)
+	})
+}
+
+func TestOnboardingService_PromoteAndAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-onboarding-service")
+	onboardingService := onboardingsrv.NewOnboardingService(partnerRepository, meter, tracer, log)
+
+	sandboxKey, sandboxHash, _, err := apikey.Generate(apikey.SandboxPrefix)
+	require.NoError(t, err)
+	partner := &domain.Partner{ID: 5, Status: domain.PartnerSandbox, SandboxKeyHash: sandboxHash}
+
+	partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).Return(partner, nil)
+	partnerRepository.EXPECT().Update(gomock.Any(), partner).Return(nil)
+
+	res, err := onboardingService.Promote(context.Background(), 5)
+	require.NoError(t, err)
+	assert.False(t, res.Sandbox)
+	assert.Equal(t, string(domain.PartnerProduction), res.Status)
+	assert.NotNil(t, partner.PromotedAt)
+
+	t.Run("Live Key", func(t *testing.T) {
+		partnerRepository.EXPECT().FindByAPIKeyHash(gomock.Any(), apikey.Hash(res.APIKey)).Return(partner, nil)
+
+		found, sandbox, err := onboardingService.Authenticate(context.Background(), res.APIKey)
+		require.NoError(t, err)
+		assert.Equal(t, partner.ID, found.ID)
+		assert.False(t, sandbox)
+	})
+
+	t.Run("Sandbox Key Still Works", func(t *testing.T) {
+		partnerRepository.EXPECT().FindByAPIKeyHash(gomock.Any(), sandboxHash).Return(partner, nil)
+
+		_, sandbox, err := onboardingService.Authenticate(context.Background(), sandboxKey)
+		require.NoError(t, err)
+		assert.True(t, sandbox)
+	})
+
+	t.Run("Promote Twice", func(t *testing.T) {
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).Return(partner, nil)
+
+		_, err := onboardingService.Promote(context.Background(), 5)
+		assert.ErrorIs(t, err, common.ErrPartnerPromoted)
+	})
+
+	t.Run("Unknown Key", func(t *testing.T) {
+		_, _, err := onboardingService.Authenticate(context.Background(), "not-a-key")
+		assert.ErrorIs(t, err, common.ErrInvalidAPIKey)
+	})
+}
+
+func TestOnboardingService_UpdateSecurity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-onboarding-service")
+	onboardingService := onboardingsrv.NewOnboardingService(partnerRepository, meter, tracer, log)
+
+	fingerprint := strings.Repeat("AB:", 31) + "AB"
+
+	t.Run("Normalizes Addresses And Fingerprint", func(t *testing.T) {
+		var stored *domain.Partner
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Partner{ID: 7}, nil)
+		partnerRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, p *domain.Partner) error {
+				stored = p
+				return nil
+			})
+
+		res, err := onboardingService.UpdateSecurity(context.Background(), 7, dto.PartnerSecurityRequest{
+			AllowedCIDRs:          []string{" 203.0.113.7 ", "10.1.2.3/8", "10.0.0.0/8", "2001:db8::/32"},
+			RequireClientCert:     true,
+			ClientCertFingerprint: fingerprint,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"203.0.113.7/32", "10.0.0.0/8", "2001:db8::/32"}, res.AllowedCIDRs)
+		assert.Equal(t, strings.Repeat("ab", 32), stored.Security.ClientCertFingerprint)
+		assert.True(t, stored.Security.RequireClientCert)
+	})
+
+	t.Run("Signing Secret Issued Once And Kept", func(t *testing.T) {
+		var stored *domain.Partner
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Partner{ID: 7}, nil)
+		partnerRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, p *domain.Partner) error {
+				stored = p
+				return nil
+			})
+
+		res, err := onboardingService.UpdateSecurity(context.Background(), 7, dto.PartnerSecurityRequest{RequireSignature: true})
+		require.NoError(t, err)
+		assert.Len(t, res.SigningSecret, 64)
+		assert.Equal(t, res.SigningSecret, stored.Security.SigningSecret)
+
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(stored, nil)
+		partnerRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		again, err := onboardingService.UpdateSecurity(context.Background(), 7, dto.PartnerSecurityRequest{RequireSignature: true, AllowedCIDRs: []string{"10.0.0.0/8"}})
+		require.NoError(t, err)
+		assert.Empty(t, again.SigningSecret)
+		assert.Equal(t, res.SigningSecret, stored.Security.SigningSecret)
+	})
+
+	t.Run("Invalid CIDR", func(t *testing.T) {
+		res, err := onboardingService.UpdateSecurity(context.Background(), 7, dto.PartnerSecurityRequest{AllowedCIDRs: []string{"10.0.0.0/33"}})
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrInvalidCIDR)
+	})
+
+	t.Run("Invalid Fingerprint", func(t *testing.T) {
+		res, err := onboardingService.UpdateSecurity(context.Background(), 7, dto.PartnerSecurityRequest{RequireClientCert: true, ClientCertFingerprint: "abc"})
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrInvalidFingerprint)
+	})
+
+	t.Run("Fingerprint Empty After Normalizing", func(t *testing.T) {
+		res, err := onboardingService.UpdateSecurity(context.Background(), 7, dto.PartnerSecurityRequest{RequireClientCert: true, ClientCertFingerprint: "  :: "})
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrInvalidFingerprint)
+	})
+
+	t.Run("Partner Not Found", func(t *testing.T) {
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(8)).Return(nil, nil)
+
+		res, err := onboardingService.UpdateSecurity(context.Background(), 8, dto.PartnerSecurityRequest{})
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrPartnerNotFound)
+	})
+}
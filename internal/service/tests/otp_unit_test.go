@@ -0,0 +1,233 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	otpsrv "github.com/fazamuttaqien/multifinance/internal/service/otp"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/otp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingSender struct {
+	channel     string
+	destination string
+	code        string
+	err         error
+}
+
+func (s *recordingSender) Channel() string { return s.channel }
+
+func (s *recordingSender) Send(_ context.Context, destination, code string) error {
+	s.destination, s.code = destination, code
+	return s.err
+}
+
+var otpConfig = otpsrv.Config{
+	TTL:             5 * time.Minute,
+	VerificationTTL: 15 * time.Minute,
+	MaxAttempts:     3,
+	MaxRequests:     3,
+	RequestWindow:   15 * time.Minute,
+}
+
+func TestOTPService_Request_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-otp-service")
+
+	t.Run("Success - Sends Code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		sender := &recordingSender{channel: otp.ChannelSMS}
+		otpService := otpsrv.NewOTPService(otpRepository, []otp.Sender{sender}, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().CountRequest(gomock.Any(), "SMS:+6281234567890", otpConfig.RequestWindow).Return(int64(1), nil)
+		otpRepository.EXPECT().SaveChallenge(gomock.Any(), gomock.Any()).Return(nil)
+
+		challenge, err := otpService.Request(context.Background(), 0, dto.OTPRequest{
+			Purpose: "REGISTRATION", Channel: otp.ChannelSMS, Destination: "+62 812-3456-7890",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "+6281234567890", sender.destination)
+		assert.Len(t, sender.code, otp.CodeLength)
+		assert.Equal(t, otp.Hash(challenge.ID, sender.code), challenge.CodeHash)
+	})
+
+	t.Run("Failure - Login Required", func(t *testing.T) {
+		otpService := otpsrv.NewOTPService(mocks.NewMockOTPRepository(gomock.NewController(t)), nil, otpConfig, meter, tracer, log)
+
+		_, err := otpService.Request(context.Background(), 0, dto.OTPRequest{
+			Purpose: "CHANGE_PASSWORD", Channel: otp.ChannelEmail, Destination: "budi@example.com",
+		})
+
+		assert.ErrorIs(t, err, common.ErrOTPLoginRequired)
+	})
+
+	t.Run("Failure - Invalid Destination", func(t *testing.T) {
+		sender := &recordingSender{channel: otp.ChannelEmail}
+		otpService := otpsrv.NewOTPService(mocks.NewMockOTPRepository(gomock.NewController(t)), []otp.Sender{sender}, otpConfig, meter, tracer, log)
+
+		_, err := otpService.Request(context.Background(), 0, dto.OTPRequest{
+			Purpose: "REGISTRATION", Channel: otp.ChannelEmail, Destination: "not-an-email",
+		})
+
+		assert.ErrorIs(t, err, common.ErrInvalidOTPDestination)
+	})
+
+	t.Run("Failure - Rate Limited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		otpService := otpsrv.NewOTPService(otpRepository, []otp.Sender{&recordingSender{channel: otp.ChannelEmail}}, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().CountRequest(gomock.Any(), "EMAIL:budi@example.com", gomock.Any()).Return(int64(4), nil)
+
+		_, err := otpService.Request(context.Background(), 0, dto.OTPRequest{
+			Purpose: "REGISTRATION", Channel: otp.ChannelEmail, Destination: "Budi@Example.com",
+		})
+
+		assert.ErrorIs(t, err, common.ErrOTPRateLimited)
+	})
+
+	t.Run("Failure - Delivery Drops Challenge", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		sender := &recordingSender{channel: otp.ChannelSMS, err: errors.New("gateway down")}
+		otpService := otpsrv.NewOTPService(otpRepository, []otp.Sender{sender}, otpConfig, meter, tracer, log)
+
+		var saved *domain.OTPChallenge
+		otpRepository.EXPECT().CountRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		otpRepository.EXPECT().SaveChallenge(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, challenge *domain.OTPChallenge) error {
+				saved = challenge
+				return nil
+			})
+		otpRepository.EXPECT().DeleteChallenge(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, id string) (bool, error) {
+				assert.Equal(t, saved.ID, id)
+				return true, nil
+			})
+
+		_, err := otpService.Request(context.Background(), 0, dto.OTPRequest{
+			Purpose: "REGISTRATION", Channel: otp.ChannelSMS, Destination: "+6281234567890",
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestOTPService_Verify_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-otp-service")
+	challenge := &domain.OTPChallenge{
+		ID:          "a1b2c3",
+		Purpose:     domain.OTPChangePassword,
+		Channel:     otp.ChannelEmail,
+		Destination: "budi@example.com",
+		CustomerID:  7,
+		CodeHash:    otp.Hash("a1b2c3", "123456"),
+	}
+
+	t.Run("Success - Issues Token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		otpService := otpsrv.NewOTPService(otpRepository, nil, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().FindChallenge(gomock.Any(), "a1b2c3").Return(challenge, nil)
+		otpRepository.EXPECT().DeleteChallenge(gomock.Any(), "a1b2c3").Return(true, nil)
+		otpRepository.EXPECT().SaveVerification(gomock.Any(), gomock.Any()).Return(nil)
+
+		verification, err := otpService.Verify(context.Background(), dto.OTPVerifyRequest{ChallengeID: "a1b2c3", Code: "123456"})
+
+		require.NoError(t, err)
+		assert.Len(t, verification.Token, 64)
+		assert.Equal(t, domain.OTPChangePassword, verification.Purpose)
+		assert.Equal(t, uint64(7), verification.CustomerID)
+	})
+
+	t.Run("Failure - Wrong Code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		otpService := otpsrv.NewOTPService(otpRepository, nil, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().FindChallenge(gomock.Any(), "a1b2c3").Return(challenge, nil)
+		otpRepository.EXPECT().IncrementAttempts(gomock.Any(), "a1b2c3").Return(1, nil)
+
+		_, err := otpService.Verify(context.Background(), dto.OTPVerifyRequest{ChallengeID: "a1b2c3", Code: "654321"})
+
+		assert.ErrorIs(t, err, common.ErrOTPInvalid)
+	})
+
+	t.Run("Failure - Attempts Exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		otpService := otpsrv.NewOTPService(otpRepository, nil, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().FindChallenge(gomock.Any(), "a1b2c3").Return(challenge, nil)
+		otpRepository.EXPECT().IncrementAttempts(gomock.Any(), "a1b2c3").Return(otpConfig.MaxAttempts, nil)
+		otpRepository.EXPECT().DeleteChallenge(gomock.Any(), "a1b2c3").Return(true, nil)
+
+		_, err := otpService.Verify(context.Background(), dto.OTPVerifyRequest{ChallengeID: "a1b2c3", Code: "654321"})
+
+		assert.ErrorIs(t, err, common.ErrOTPAttemptsExceeded)
+	})
+
+	t.Run("Failure - Already Used", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		otpService := otpsrv.NewOTPService(otpRepository, nil, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().FindChallenge(gomock.Any(), "a1b2c3").Return(challenge, nil)
+		otpRepository.EXPECT().DeleteChallenge(gomock.Any(), "a1b2c3").Return(false, nil)
+
+		_, err := otpService.Verify(context.Background(), dto.OTPVerifyRequest{ChallengeID: "a1b2c3", Code: "123456"})
+
+		assert.ErrorIs(t, err, common.ErrOTPInvalid)
+	})
+}
+
+func TestOTPService_Consume_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-otp-service")
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		otpService := otpsrv.NewOTPService(otpRepository, nil, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().TakeVerification(gomock.Any(), "token").
+			Return(&domain.OTPVerification{Token: "token", Purpose: domain.OTPRegistration}, nil)
+
+		_, err := otpService.Consume(context.Background(), "token", domain.OTPRegistration, 0)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failure - Other Purpose", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otpRepository := mocks.NewMockOTPRepository(ctrl)
+		otpService := otpsrv.NewOTPService(otpRepository, nil, otpConfig, meter, tracer, log)
+
+		otpRepository.EXPECT().TakeVerification(gomock.Any(), "token").
+			Return(&domain.OTPVerification{Token: "token", Purpose: domain.OTPRegistration}, nil)
+
+		_, err := otpService.Consume(context.Background(), "token", domain.OTPChangePassword, 7)
+
+		assert.ErrorIs(t, err, common.ErrOTPRequired)
+	})
+
+	t.Run("Failure - Missing Token", func(t *testing.T) {
+		otpService := otpsrv.NewOTPService(mocks.NewMockOTPRepository(gomock.NewController(t)), nil, otpConfig, meter, tracer, log)
+
+		_, err := otpService.Consume(context.Background(), "", domain.OTPRegistration, 0)
+
+		assert.ErrorIs(t, err, common.ErrOTPRequired)
+	})
+}
@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	blacklistrepo "github.com/fazamuttaqien/multifinance/internal/repository/blacklist"
+	concentrationrepo "github.com/fazamuttaqien/multifinance/internal/repository/concentration"
+	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
+	feeschedulerepo "github.com/fazamuttaqien/multifinance/internal/repository/feeschedule"
+	fxraterepo "github.com/fazamuttaqien/multifinance/internal/repository/fxrate"
+	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
+	partnerrepo "github.com/fazamuttaqien/multifinance/internal/repository/partner"
+	regionrepo "github.com/fazamuttaqien/multifinance/internal/repository/region"
+	restrictionrepo "github.com/fazamuttaqien/multifinance/internal/repository/restriction"
+	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
+	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	blacklistsrv "github.com/fazamuttaqien/multifinance/internal/service/blacklist"
+	concentrationsrv "github.com/fazamuttaqien/multifinance/internal/service/concentration"
+	fxratesrv "github.com/fazamuttaqien/multifinance/internal/service/fxrate"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	partnersrv "github.com/fazamuttaqien/multifinance/internal/service/partner"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+
+	"github.com/shopspring/decimal"
+	noop_metric "go.opentelemetry.io/otel/metric/noop"
+	noop_trace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// benchCustomers is the size of the seeded customer pool. Uncontended
+// benchmarks spread requests across the pool, contended ones hit a single row.
+const benchCustomers = 64
+
+// benchLimit is large enough that benchmark iterations never exhaust it.
+var benchLimit = decimal.NewFromInt(1_000_000_000_000)
+
+type partnerBench struct {
+	service service.PartnerServices
+	niks    []string
+	tenor   uint8
+}
+
+// newPartnerBench wires the partner service against a fresh MySQL schema with
+// a seeded customer pool. The testing package calls each benchmark again for
+// every b.N, so every round starts without transactions, exposure rows or
+// contract sequences left over from the previous one. It skips when Docker
+// is not available.
+func newPartnerBench(b *testing.B) *partnerBench {
+	b.Helper()
+
+	db := testutil.NewDatabase(b, "loan_system_service_partner_bench").DB
+
+	log := zap.NewNop()
+	tracer := noop_trace.NewTracerProvider().Tracer("bench-partner-service-tracer")
+	meter := noop_metric.NewMeterProvider().Meter("bench-partner-service-meter")
+
+	tenors := testutil.SeedTenors(b, db, 6)
+	niks := make([]string, 0, benchCustomers)
+	for range benchCustomers {
+		customer := testutil.NewCustomer().Create(b, db)
+		testutil.SeedLimit(b, db, customer.ID, tenors[0].ID, benchLimit)
+		niks = append(niks, customer.NIK)
+	}
+
+	// Tanpa PartnerID kuota tidak pernah dipanggil, mock cukup sebagai pengisi
+	quotaService := servicemocks.NewMockPartnerQuotaServices(gomock.NewController(b))
+	partnerService := partnersrv.NewPartnerService(
+		db,
+		customerrepo.NewCustomerRepository(db, meter, log),
+		tenorrepo.NewTenorRepository(db, meter, log),
+		limitrepo.NewLimitRepository(db, meter, log),
+		transactionrepo.NewTransactionRepository(db, meter, log),
+		feeschedulerepo.NewFeeScheduleRepository(db, meter, log),
+		blacklistsrv.NewBlacklistService(blacklistrepo.NewBlacklistRepository(db, meter, log), meter, tracer, log),
+		fxratesrv.NewFxRateService(fxraterepo.NewFxRateRepository(db, meter, log), meter, tracer, log),
+		quotaService,
+		concentrationsrv.NewConcentrationService(
+			concentrationrepo.NewConcentrationRepository(db, meter, log),
+			regionrepo.NewRegionRepository(db, meter, log),
+			concentrationsrv.Config{},
+			meter, tracer, log,
+		),
+		partnerrepo.NewPartnerRepository(db, meter, log),
+		regionrepo.NewRegionRepository(db, meter, log),
+		exposurerepo.NewCustomerExposureRepository(db, meter, log),
+		restrictionrepo.NewCustomerRestrictionRepository(db, meter, log),
+		60,
+		partnersrv.Affordability{},
+		meter,
+		tracer,
+		log,
+	)
+
+	return &partnerBench{service: partnerService, niks: niks, tenor: tenors[0].DurationMonths}
+}
+
+func (pb *partnerBench) checkLimitRequest(nik string) dto.CheckLimitRequest {
+	return dto.CheckLimitRequest{
+		CustomerNIK:       nik,
+		TenorMonths:       pb.tenor,
+		TransactionAmount: decimal.NewFromInt(1_000_000),
+	}
+}
+
+func (pb *partnerBench) createTransactionRequest(nik string) dto.CreateTransactionRequest {
+	return dto.CreateTransactionRequest{
+		CustomerNIK: nik,
+		TenorMonths: pb.tenor,
+		AssetName:   "Benchmark Asset",
+		OTRAmount:   decimal.NewFromInt(1_000_000),
+		AdminFee:    adminFee(10_000),
+	}
+}
+
+func BenchmarkCheckLimit(b *testing.B) {
+	pb := newPartnerBench(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pb.service.CheckLimit(ctx, pb.checkLimitRequest(pb.niks[i%len(pb.niks)])); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCheckLimit_Parallel(b *testing.B) {
+	pb := newPartnerBench(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		i := 0
+		for p.Next() {
+			if _, err := pb.service.CheckLimit(ctx, pb.checkLimitRequest(pb.niks[i%len(pb.niks)])); err != nil {
+				b.Error(err)
+				return
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkCreateTransaction(b *testing.B) {
+	pb := newPartnerBench(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pb.service.CreateTransaction(ctx, pb.createTransactionRequest(pb.niks[i%len(pb.niks)])); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCreateTransaction_Contended sends every request for the same
+// customer, so each one queues on the limit row lock and the contract
+// sequence row. Regressions here usually mean the locked section grew.
+func BenchmarkCreateTransaction_Contended(b *testing.B) {
+	pb := newPartnerBench(b)
+	ctx := context.Background()
+	req := pb.createTransactionRequest(pb.niks[0])
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			if _, err := pb.service.CreateTransaction(ctx, req); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	partnerdebugsrv "github.com/fazamuttaqien/multifinance/internal/service/partnerdebug"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/telemetry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPartnerDebugService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-partner-debug-service")
+	now := time.Date(2025, 6, 30, 9, 0, 0, 0, time.UTC)
+	cfg := partnerdebugsrv.Config{Retention: 24 * time.Hour, Cap: 3, MaxBodyBytes: 128, RedactFields: []string{"nik", "birth_date"}}
+
+	setup := func(t *testing.T) *mocks.MockPartnerDebugRepository {
+		return mocks.NewMockPartnerDebugRepository(gomock.NewController(t))
+	}
+
+	t.Run("SetMode - Enabled Until Retention", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		debugRepository.EXPECT().SetDebugUntil(gomock.Any(), uint64(7), true, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint64, _ bool, until *time.Time) (bool, error) {
+				require.NotNil(t, until)
+				assert.Equal(t, now.Add(24*time.Hour), *until)
+				return true, nil
+			})
+
+		until, err := debugService.SetMode(context.Background(), 7, true, true, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(24*time.Hour), *until)
+	})
+
+	t.Run("SetMode - Disabled Clears Until", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		debugRepository.EXPECT().SetDebugUntil(gomock.Any(), uint64(7), false, (*time.Time)(nil)).Return(true, nil)
+
+		until, err := debugService.SetMode(context.Background(), 7, false, false, now)
+
+		require.NoError(t, err)
+		assert.Nil(t, until)
+	})
+
+	t.Run("SetMode - Partner Not Found", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		debugRepository.EXPECT().SetDebugUntil(gomock.Any(), uint64(7), true, gomock.Any()).Return(false, nil)
+
+		_, err := debugService.SetMode(context.Background(), 7, true, true, now)
+
+		assert.ErrorIs(t, err, common.ErrPartnerNotFound)
+	})
+
+	t.Run("Record - Sanitizes And Trims To Cap", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		var stored *domain.PartnerDebugRecord
+		gomock.InOrder(
+			debugRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, record *domain.PartnerDebugRecord) error {
+					stored = record
+					return nil
+				}),
+			debugRepository.EXPECT().Trim(gomock.Any(), uint64(7), true, 3).Return(int64(1), nil),
+		)
+
+		err := debugService.Record(context.Background(), &domain.PartnerDebugRecord{
+			PartnerID: 7,
+			Sandbox:   true,
+			RequestHeaders: map[string]string{
+				"X-Api-Key":    "sk_test_secret",
+				"X-Signature":  "abc",
+				"Content-Type": "application/json",
+			},
+			RequestBody:  `{"customer":{"NIK":"3201234567890001","birthDate":"1990-04-12"},"amount":1000}`,
+			ResponseBody: "<html>bad gateway</html>",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, telemetry.Redacted, stored.RequestHeaders["X-Api-Key"])
+		assert.Equal(t, telemetry.Redacted, stored.RequestHeaders["X-Signature"])
+		assert.Equal(t, "application/json", stored.RequestHeaders["Content-Type"])
+		assert.JSONEq(t, `{"customer":{"NIK":"[REDACTED]","birthDate":"[REDACTED]"},"amount":1000}`, stored.RequestBody)
+		assert.Equal(t, "[non-JSON body of 24 bytes omitted]", stored.ResponseBody)
+	})
+
+	t.Run("Record - Truncates Large Body", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		var stored *domain.PartnerDebugRecord
+		debugRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, record *domain.PartnerDebugRecord) error {
+				stored = record
+				return nil
+			})
+		debugRepository.EXPECT().Trim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+		err := debugService.Record(context.Background(), &domain.PartnerDebugRecord{
+			PartnerID:   7,
+			RequestBody: `{"note":"` + strings.Repeat("a", 300) + `"}`,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(stored.RequestBody, "...[truncated]"))
+		assert.Len(t, stored.RequestBody, 128+len("...[truncated]"))
+	})
+
+	t.Run("Record - Repository Error", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		debugRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		err := debugService.Record(context.Background(), &domain.PartnerDebugRecord{PartnerID: 7})
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+
+	t.Run("GetRecord - Not Found", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		debugRepository.EXPECT().FindByID(gomock.Any(), uint64(7), false, uint64(4)).Return(nil, nil)
+
+		record, err := debugService.GetRecord(context.Background(), 7, false, 4)
+
+		assert.Nil(t, record)
+		assert.ErrorIs(t, err, common.ErrDebugRecordNotFound)
+	})
+
+	t.Run("Purge - Deletes Past Retention", func(t *testing.T) {
+		debugRepository := setup(t)
+		debugService := partnerdebugsrv.NewPartnerDebugService(debugRepository, cfg, meter, tracer, log)
+
+		debugRepository.EXPECT().DeleteBefore(gomock.Any(), now.Add(-24*time.Hour)).Return(int64(12), nil)
+
+		deleted, err := debugService.Purge(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), deleted)
+	})
+}
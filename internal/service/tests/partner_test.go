@@ -2,8 +2,8 @@ package service_test
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,25 +11,39 @@ import (
 	"github.com/fazamuttaqien/multifinance/internal/dto"
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
+	blacklistrepo "github.com/fazamuttaqien/multifinance/internal/repository/blacklist"
+	concentrationrepo "github.com/fazamuttaqien/multifinance/internal/repository/concentration"
 	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
+	feeschedulerepo "github.com/fazamuttaqien/multifinance/internal/repository/feeschedule"
+	fxraterepo "github.com/fazamuttaqien/multifinance/internal/repository/fxrate"
 	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
+	partnerrepo "github.com/fazamuttaqien/multifinance/internal/repository/partner"
+	regionrepo "github.com/fazamuttaqien/multifinance/internal/repository/region"
+	restrictionrepo "github.com/fazamuttaqien/multifinance/internal/repository/restriction"
+	risktierrepo "github.com/fazamuttaqien/multifinance/internal/repository/risktier"
 	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
 	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
 	"github.com/fazamuttaqien/multifinance/internal/service"
+	blacklistsrv "github.com/fazamuttaqien/multifinance/internal/service/blacklist"
+	concentrationsrv "github.com/fazamuttaqien/multifinance/internal/service/concentration"
+	fxratesrv "github.com/fazamuttaqien/multifinance/internal/service/fxrate"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
 	partnersrv "github.com/fazamuttaqien/multifinance/internal/service/partner"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
 	noop_trace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
 
 	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type PartnerServiceTestSuite struct {
@@ -42,6 +56,10 @@ type PartnerServiceTestSuite struct {
 	tenorRepository       repository.TenorRepository
 	limitRepository       repository.LimitRepository
 	transactionRepository repository.TransactionRepository
+	feeScheduleRepository repository.FeeScheduleRepository
+	blacklistService      service.BlacklistServices
+	fxRateService         service.FxRateServices
+	quotaService          *servicemocks.MockPartnerQuotaServices
 
 	meter  metric.Meter
 	tracer trace.Tracer
@@ -49,43 +67,7 @@ type PartnerServiceTestSuite struct {
 }
 
 func (suite *PartnerServiceTestSuite) SetupSuite() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-
-	db, err := sql.Open("mysql", dsn)
-	suite.Require().NoError(err)
-
-	testDbName := "loan_system_test"
-
-	// Drop database jika sudah ada
-	_, err = db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDbName))
-	suite.Require().NoError(err)
-
-	// Buat database untuk testing
-	_, err = db.Exec(fmt.Sprintf("CREATE DATABASE %s", testDbName))
-	suite.Require().NoError(err)
-
-	db.Close()
-
-	// Connect ke test database
-	testDSN := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-		testDbName,
-	)
-
-	gormDB, err := gorm.Open(mysql.Open(testDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	suite.Require().NoError(err)
-
-	suite.db = gormDB
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_service_partner_test").DB
 	suite.ctx = context.Background()
 
 	// Setup logging and tracing
@@ -95,87 +77,77 @@ func (suite *PartnerServiceTestSuite) SetupSuite() {
 	noopMeterProvider := noop_metric.NewMeterProvider()
 	suite.meter = noopMeterProvider.Meter("test-partner-service-meter")
 
-	// Auto migrate models
-	err = suite.db.AutoMigrate(
-		&model.Customer{},
-		&model.Tenor{},
-		&model.CustomerLimit{},
-		&model.Transaction{},
+	// Initialize repositories
+	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.log)
+	suite.tenorRepository = tenorrepo.NewTenorRepository(suite.db, suite.meter, suite.log)
+	suite.limitRepository = limitrepo.NewLimitRepository(suite.db, suite.meter, suite.log)
+	suite.transactionRepository = transactionrepo.NewTransactionRepository(suite.db, suite.meter, suite.log)
+	suite.feeScheduleRepository = feeschedulerepo.NewFeeScheduleRepository(suite.db, suite.meter, suite.log)
+	suite.blacklistService = blacklistsrv.NewBlacklistService(
+		blacklistrepo.NewBlacklistRepository(suite.db, suite.meter, suite.log),
+		suite.meter, suite.tracer, suite.log,
+	)
+	suite.fxRateService = fxratesrv.NewFxRateService(
+		fxraterepo.NewFxRateRepository(suite.db, suite.meter, suite.log),
+		suite.meter, suite.tracer, suite.log,
 	)
-	suite.Require().NoError(err)
 
-	// Initialize repositories
-	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.tracer, suite.log)
-	suite.tenorRepository = tenorrepo.NewTenorRepository(suite.db, suite.meter, suite.tracer, suite.log)
-	suite.limitRepository = limitrepo.NewLimitRepository(suite.db, suite.meter, suite.tracer, suite.log)
-	suite.transactionRepository = transactionrepo.NewTransactionRepository(suite.db, suite.meter, suite.tracer, suite.log)
+}
 
-	// Initialize service
-	suite.partnerService = partnersrv.NewPartnerService(
+func (suite *PartnerServiceTestSuite) SetupTest() {
+	testutil.Reset(suite.T(), suite.db)
+
+	// Kuota partner disimpan di Redis, jadi di sini cukup di-mock per test
+	suite.quotaService = servicemocks.NewMockPartnerQuotaServices(gomock.NewController(suite.T()))
+	suite.partnerService = suite.newPartnerService(partnersrv.Affordability{})
+}
+
+func (suite *PartnerServiceTestSuite) newPartnerService(affordability partnersrv.Affordability) service.PartnerServices {
+	return partnersrv.NewPartnerService(
 		suite.db,
 		suite.customerRepository,
 		suite.tenorRepository,
 		suite.limitRepository,
 		suite.transactionRepository,
+		suite.feeScheduleRepository,
+		suite.blacklistService,
+		suite.fxRateService,
+		suite.quotaService,
+		concentrationsrv.NewConcentrationService(
+			concentrationrepo.NewConcentrationRepository(suite.db, suite.meter, suite.log),
+			regionrepo.NewRegionRepository(suite.db, suite.meter, suite.log),
+			concentrationsrv.Config{},
+			suite.meter, suite.tracer, suite.log,
+		),
+		partnerrepo.NewPartnerRepository(suite.db, suite.meter, suite.log),
+		regionrepo.NewRegionRepository(suite.db, suite.meter, suite.log),
+		exposurerepo.NewCustomerExposureRepository(suite.db, suite.meter, suite.log),
+		restrictionrepo.NewCustomerRestrictionRepository(suite.db, suite.meter, suite.log),
+		60,
+		affordability,
 		suite.meter,
 		suite.tracer,
 		suite.log,
 	)
 }
 
-func (suite *PartnerServiceTestSuite) TearDownSuite() {
-	if suite.db != nil {
-		sqlDB, err := suite.db.DB()
-		if err == nil {
-			sqlDB.Close()
-		}
-	}
-}
-
-func (suite *PartnerServiceTestSuite) SetupTest() {
-	// Clean up database sebelum setiap test
-	suite.db.Exec("DELETE FROM transactions")
-	suite.db.Exec("DELETE FROM customer_limits")
-	suite.db.Exec("DELETE FROM customers")
-	suite.db.Exec("DELETE FROM tenors")
-}
-
 func (suite *PartnerServiceTestSuite) seedTestData() (customer *model.Customer, tenor *model.Tenor, limit *model.CustomerLimit) {
 	// Create test customer
-	customer = &model.Customer{
-		NIK:                "1234567890123456",
-		FullName:           "John Smith",
-		LegalName:          "John Smith",
-		BirthPlace:         "Jakarta",
-		Password:           "johnsmith123",
-		Role:               "customer",
-		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-		Salary:             5000000,
-		KtpPhotoUrl:        "https://example.com/ktp.jpg",
-		SelfiePhotoUrl:     "https://example.com/selfie.jpg",
-		VerificationStatus: model.VerificationVerified,
-		CreatedAt:          time.Now(),
-		UpdatedAt:          time.Now(),
-	}
-	err := suite.db.Create(customer).Error
-	suite.Require().NoError(err)
+	customer = testutil.NewCustomer().
+		WithNIK("1234567890123456").
+		WithName("John Smith").
+		WithPassword("johnsmith123").
+		Create(suite.T(), suite.db)
 
 	// Create test tenor
 	tenor = &model.Tenor{
 		DurationMonths: 6,
 		Description:    "Create test tenor for Customer1",
 	}
-	err = suite.db.Create(tenor).Error
-	suite.Require().NoError(err)
+	suite.Require().NoError(suite.db.Create(tenor).Error)
 
 	// Create test limit
-	limit = &model.CustomerLimit{
-		CustomerID:  customer.ID,
-		TenorID:     tenor.ID,
-		LimitAmount: 50000,
-	}
-	err = suite.db.Create(limit).Error
-	suite.Require().NoError(err)
+	limit = testutil.SeedLimit(suite.T(), suite.db, customer.ID, tenor.ID, decimal.NewFromInt(50000))
 
 	return customer, tenor, limit
 }
@@ -187,7 +159,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Success_Approved() {
 	req := dto.CheckLimitRequest{
 		CustomerNIK:       customer.NIK,
 		TenorMonths:       tenor.DurationMonths,
-		TransactionAmount: 30000,
+		TransactionAmount: decimal.NewFromInt(30000),
 	}
 
 	// Act
@@ -197,7 +169,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Success_Approved() {
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Equal(suite.T(), "approved", result.Status)
-	assert.Equal(suite.T(), float64(50000), result.RemainingLimit)
+	assert.Equal(suite.T(), "50000", result.RemainingLimit.String())
 	assert.Equal(suite.T(), "Limit is sufficient.", result.Message)
 }
 
@@ -213,10 +185,10 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Success_Rejected_Insufficie
 		CustomerID:             customer.ID,
 		TenorID:                tenor.ID,
 		AssetName:              "Existing Asset",
-		OTRAmount:              40000,
-		AdminFee:               1000,
-		TotalInstallmentAmount: 7000,
-		TotalInterest:          2000,
+		OTRAmount:              decimal.NewFromInt(40000),
+		AdminFee:               decimal.NewFromInt(1000),
+		TotalInstallmentAmount: decimal.NewFromInt(7000),
+		TotalInterest:          decimal.NewFromInt(2000),
 		ContractNumber:         contractNumber,
 		TransactionDate:        time.Now(),
 		Status:                 model.TransactionActive,
@@ -227,7 +199,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Success_Rejected_Insufficie
 	req := dto.CheckLimitRequest{
 		CustomerNIK:       customer.NIK,
 		TenorMonths:       tenor.DurationMonths,
-		TransactionAmount: 15000, // Total would be 55000, exceeding limit of 50000
+		TransactionAmount: decimal.NewFromInt(15000), // Total would be 55000, exceeding limit of 50000
 	}
 
 	// Act
@@ -237,7 +209,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Success_Rejected_Insufficie
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Equal(suite.T(), "rejected", result.Status)
-	assert.True(suite.T(), result.RemainingLimit < req.TransactionAmount)
+	assert.True(suite.T(), result.RemainingLimit.LessThan(req.TransactionAmount))
 	assert.Equal(suite.T(), "Insufficient limit for this transaction.", result.Message)
 }
 
@@ -246,7 +218,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Failure_CustomerNotFound()
 	req := dto.CheckLimitRequest{
 		CustomerNIK:       "nonexistent",
 		TenorMonths:       6,
-		TransactionAmount: 30000,
+		TransactionAmount: decimal.NewFromInt(30000),
 	}
 
 	// Act
@@ -268,7 +240,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Failure_CustomerNotVerified
 		Role:               "customer",
 		BirthPlace:         "Jakarta",
 		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
-		Salary:             5000000,
+		Salary:             decimal.NewFromInt(5000000),
 		VerificationStatus: model.VerificationPending, // Not verified
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
@@ -279,7 +251,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Failure_CustomerNotVerified
 	req := dto.CheckLimitRequest{
 		CustomerNIK:       customer.NIK,
 		TenorMonths:       6,
-		TransactionAmount: 30000,
+		TransactionAmount: decimal.NewFromInt(30000),
 	}
 
 	// Act
@@ -310,7 +282,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Failure_TenorNotFound() {
 	req := dto.CheckLimitRequest{
 		CustomerNIK:       customer.NIK,
 		TenorMonths:       99, // Nonexistent tenor
-		TransactionAmount: 30000,
+		TransactionAmount: decimal.NewFromInt(30000),
 	}
 
 	// Act
@@ -349,7 +321,7 @@ func (suite *PartnerServiceTestSuite) TestCheckLimit_Failure_LimitNotSet() {
 	req := dto.CheckLimitRequest{
 		CustomerNIK:       customer.NIK,
 		TenorMonths:       tenor.DurationMonths,
-		TransactionAmount: 30000,
+		TransactionAmount: decimal.NewFromInt(30000),
 	}
 
 	// Act
@@ -369,8 +341,8 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success() {
 		CustomerNIK: customer.NIK,
 		TenorMonths: tenor.DurationMonths,
 		AssetName:   "Test Asset",
-		OTRAmount:   40000,
-		AdminFee:    1000,
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
 	}
 
 	// Act
@@ -382,8 +354,8 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success() {
 	assert.Equal(suite.T(), customer.ID, result.CustomerID)
 	assert.Equal(suite.T(), tenor.ID, result.TenorID)
 	assert.Equal(suite.T(), "Test Asset", result.AssetName)
-	assert.Equal(suite.T(), float64(40000), result.OTRAmount)
-	assert.Equal(suite.T(), float64(1000), result.AdminFee)
+	assert.Equal(suite.T(), "40000", result.OTRAmount.String())
+	assert.Equal(suite.T(), "1000", result.AdminFee.String())
 	assert.Equal(suite.T(), domain.TransactionActive, result.Status)
 
 	// Verify transaction is saved in database
@@ -393,6 +365,473 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success() {
 	assert.Equal(suite.T(), result.AssetName, savedTransaction.AssetName)
 }
 
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_Restricted() {
+	customer, tenor, _ := suite.seedTestData()
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Test Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
+	}
+
+	suite.Run("Tenor Frozen", func() {
+		freeze := &model.CustomerRestriction{CustomerID: customer.ID, TenorID: &tenor.ID, Reason: string(domain.RestrictionDelinquency), CreatedBy: 1}
+		suite.Require().NoError(suite.db.Create(freeze).Error)
+
+		result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+		assert.Nil(suite.T(), result)
+		assert.ErrorIs(suite.T(), err, common.ErrLimitFrozen)
+
+		check, err := suite.partnerService.CheckLimit(suite.ctx, dto.CheckLimitRequest{CustomerNIK: customer.NIK, TenorMonths: tenor.DurationMonths, TransactionAmount: decimal.NewFromInt(100)})
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "rejected", check.Status)
+		assert.Equal(suite.T(), "Limit for this tenor is frozen.", check.Message)
+	})
+
+	suite.Run("Account Suspended", func() {
+		suspension := &model.CustomerRestriction{CustomerID: customer.ID, Reason: string(domain.RestrictionFraudSuspected), CreatedBy: 1}
+		suite.Require().NoError(suite.db.Create(suspension).Error)
+
+		result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+		assert.Nil(suite.T(), result)
+		assert.ErrorIs(suite.T(), err, common.ErrCustomerSuspended)
+	})
+
+	suite.Run("Expired Restrictions Ignored", func() {
+		expired := time.Now().Add(-time.Minute)
+		suite.Require().NoError(suite.db.Model(&model.CustomerRestriction{}).Where("customer_id = ?", customer.ID).Update("expires_at", expired).Error)
+
+		result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), customer.ID, result.CustomerID)
+	})
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_Dormant() {
+	customer, tenor, _ := suite.seedTestData()
+	dormantAt := time.Now().AddDate(0, -1, 0)
+	suite.Require().NoError(suite.db.Model(&model.Customer{}).Where("id = ?", customer.ID).Update("dormant_at", dormantAt).Error)
+
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Test Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
+	})
+
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, common.ErrCustomerDormant)
+
+	check, err := suite.partnerService.CheckLimit(suite.ctx, dto.CheckLimitRequest{CustomerNIK: customer.NIK, TenorMonths: tenor.DurationMonths, TransactionAmount: decimal.NewFromInt(100)})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "rejected", check.Status)
+}
+
+func (suite *PartnerServiceTestSuite) TestCheckLimit_Success_ReadsExposureSummary() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Summarised Asset",
+		OTRAmount:   decimal.NewFromInt(20000),
+		AdminFee:    adminFee(1000),
+	}
+	_, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+	suite.Require().NoError(err)
+
+	// Ringkasan ikut diperbarui bersama transaksi
+	var exposure model.CustomerExposure
+	suite.Require().NoError(suite.db.Where("customer_id = ? AND tenor_id = ?", customer.ID, tenor.ID).First(&exposure).Error)
+	assert.Equal(suite.T(), "21000", exposure.ActivePrincipal.String())
+
+	// CheckLimit membaca ringkasan, bukan menjumlahkan transaksi
+	suite.Require().NoError(suite.db.Model(&exposure).Update("active_principal", decimal.NewFromInt(45000)).Error)
+
+	// Act
+	result, err := suite.partnerService.CheckLimit(suite.ctx, dto.CheckLimitRequest{
+		CustomerNIK:       customer.NIK,
+		TenorMonths:       tenor.DurationMonths,
+		TransactionAmount: decimal.NewFromInt(10000),
+	})
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "rejected", result.Status)
+	assert.Equal(suite.T(), "5000", result.RemainingLimit.String())
+}
+
+func (suite *PartnerServiceTestSuite) TestCheckLimit_Failure_ProductRules() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	suite.Require().NoError(suite.db.Model(tenor).Updates(map[string]any{
+		"min_amount":       decimal.NewFromInt(10000),
+		"max_amount":       decimal.NewFromInt(45000),
+		"asset_categories": `["motor"]`,
+	}).Error)
+
+	testCases := []struct {
+		name     string
+		amount   int64
+		category string
+		expected error
+	}{
+		{"Below Minimum Amount", 5000, "motor", common.ErrAmountOutsideProduct},
+		{"Above Maximum Amount", 48000, "motor", common.ErrAmountOutsideProduct},
+		{"Asset Category Not Allowed", 30000, "elektronik", common.ErrAssetNotAllowed},
+		{"Asset Category Missing", 30000, "", common.ErrAssetNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			req := dto.CheckLimitRequest{
+				CustomerNIK:       customer.NIK,
+				TenorMonths:       tenor.DurationMonths,
+				TransactionAmount: decimal.NewFromInt(tc.amount),
+				AssetCategory:     tc.category,
+			}
+
+			// Act
+			result, err := suite.partnerService.CheckLimit(suite.ctx, req)
+
+			// Assert
+			assert.ErrorIs(suite.T(), err, tc.expected)
+			assert.Nil(suite.T(), result)
+		})
+	}
+
+	// Kategori dicocokkan tanpa membedakan huruf besar
+	result, err := suite.partnerService.CheckLimit(suite.ctx, dto.CheckLimitRequest{
+		CustomerNIK:       customer.NIK,
+		TenorMonths:       tenor.DurationMonths,
+		TransactionAmount: decimal.NewFromInt(30000),
+		AssetCategory:     "Motor",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "approved", result.Status)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_AgeNotEligible() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	suite.Require().NoError(suite.db.Model(tenor).Update("max_age", 30).Error)
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Test Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, common.ErrAgeNotEligible)
+	assert.Nil(suite.T(), result)
+
+	var count int64
+	suite.db.Model(&model.Transaction{}).Where("customer_id = ?", customer.ID).Count(&count)
+	assert.Zero(suite.T(), count)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_AgeAtTenorEnd() {
+	req := func(customer *model.Customer, tenor *model.Tenor) dto.CreateTransactionRequest {
+		return dto.CreateTransactionRequest{
+			CustomerNIK: customer.NIK,
+			TenorMonths: tenor.DurationMonths,
+			AssetName:   "Test Asset",
+			OTRAmount:   decimal.NewFromInt(40000),
+			AdminFee:    adminFee(1000),
+		}
+	}
+
+	suite.Run("Failure - Older Than Default At Contract End", func() {
+		customer, tenor, _ := suite.seedTestData()
+		// Berusia 60 saat kontrak dibuat, 61 saat cicilan ke-6 jatuh tempo
+		suite.Require().NoError(suite.db.Model(customer).Update("birth_date", time.Now().AddDate(-60, -9, 0)).Error)
+
+		result, err := suite.partnerService.CreateTransaction(suite.ctx, req(customer, tenor))
+
+		assert.ErrorIs(suite.T(), err, common.ErrAgeAtTenorEnd)
+		assert.Nil(suite.T(), result)
+	})
+
+	suite.Run("Success - Product Threshold Overrides Default", func() {
+		testutil.Reset(suite.T(), suite.db)
+		customer, tenor, _ := suite.seedTestData()
+		suite.Require().NoError(suite.db.Model(customer).Update("birth_date", time.Now().AddDate(-60, -9, 0)).Error)
+		suite.Require().NoError(suite.db.Model(tenor).Update("max_age_at_end", 65).Error)
+
+		result, err := suite.partnerService.CreateTransaction(suite.ctx, req(customer, tenor))
+
+		assert.NoError(suite.T(), err)
+		assert.NotNil(suite.T(), result)
+	})
+}
+
+func (suite *PartnerServiceTestSuite) TestDebtServiceRatio() {
+	// Gaji fixture 5.000.000, rasio 0,1% membatasi cicilan 5.000 per bulan
+	affordability := func(ratio string) partnersrv.Affordability {
+		return partnersrv.Affordability{
+			TierFor:   func(decimal.Decimal) string { return "LOW" },
+			MaxRatios: map[string]decimal.Decimal{"LOW": decimal.RequireFromString(ratio)},
+		}
+	}
+
+	suite.Run("Rejected - Limit Check Over Ratio", func() {
+		customer, tenor, _ := suite.seedTestData()
+		partnerService := suite.newPartnerService(affordability("0.001"))
+
+		// Cicilan (30.000 + bunga) / 6 bulan melebihi 5.000
+		result, err := partnerService.CheckLimit(suite.ctx, dto.CheckLimitRequest{
+			CustomerNIK:       customer.NIK,
+			TenorMonths:       tenor.DurationMonths,
+			TransactionAmount: decimal.NewFromInt(30000),
+		})
+
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "rejected", result.Status)
+		assert.Equal(suite.T(), "Monthly installments would exceed the allowed share of the customer's salary.", result.Message)
+	})
+
+	suite.Run("Failure - Existing Installments Count Toward Ratio", func() {
+		testutil.Reset(suite.T(), suite.db)
+		customer, tenor, _ := suite.seedTestData()
+		partnerService := suite.newPartnerService(affordability("0.002"))
+
+		// Kontrak aktif sudah memakai 9.000 dari 10.000 per bulan
+		suite.Require().NoError(suite.db.Create(&model.Transaction{
+			CustomerID:             customer.ID,
+			TenorID:                tenor.ID,
+			AssetName:              "Existing Asset",
+			OTRAmount:              decimal.NewFromInt(30000),
+			TotalInstallmentAmount: decimal.NewFromInt(54000),
+			ContractNumber:         "KTR-EXISTING-1",
+			TransactionDate:        time.Now(),
+			Status:                 model.TransactionActive,
+		}).Error)
+
+		req := dto.CreateTransactionRequest{
+			CustomerNIK: customer.NIK,
+			TenorMonths: tenor.DurationMonths,
+			AssetName:   "Test Asset",
+			OTRAmount:   decimal.NewFromInt(10000),
+			AdminFee:    adminFee(1000),
+		}
+		result, err := partnerService.CreateTransaction(suite.ctx, req)
+
+		assert.ErrorIs(suite.T(), err, common.ErrDebtServiceRatioExceeded)
+		assert.Nil(suite.T(), result)
+	})
+
+	suite.Run("Success - Tier Without Ratio", func() {
+		testutil.Reset(suite.T(), suite.db)
+		customer, tenor, _ := suite.seedTestData()
+		partnerService := suite.newPartnerService(partnersrv.Affordability{
+			TierFor:   func(decimal.Decimal) string { return "HIGH" },
+			MaxRatios: map[string]decimal.Decimal{"LOW": decimal.RequireFromString("0.001")},
+		})
+
+		result, err := partnerService.CreateTransaction(suite.ctx, dto.CreateTransactionRequest{
+			CustomerNIK: customer.NIK,
+			TenorMonths: tenor.DurationMonths,
+			AssetName:   "Test Asset",
+			OTRAmount:   decimal.NewFromInt(30000),
+			AdminFee:    adminFee(1000),
+		})
+
+		assert.NoError(suite.T(), err)
+		assert.NotNil(suite.T(), result)
+	})
+
+	suite.Run("Rejected - Assigned Risk Tier Takes Precedence", func() {
+		testutil.Reset(suite.T(), suite.db)
+		customer, tenor, _ := suite.seedTestData()
+		suite.Require().NoError(suite.db.Create(&model.CustomerRiskTier{
+			CustomerID:   customer.ID,
+			Tier:         string(domain.RiskTierLow),
+			Salary:       customer.Salary,
+			CalculatedAt: time.Now(),
+		}).Error)
+
+		// Pita gaji memberi HIGH tanpa rasio, tetapi job sudah menilai customer LOW
+		partnerService := suite.newPartnerService(partnersrv.Affordability{
+			TierFor:   func(decimal.Decimal) string { return "HIGH" },
+			MaxRatios: map[string]decimal.Decimal{"LOW": decimal.RequireFromString("0.001")},
+			RiskTiers: risktierrepo.NewRiskTierRepository(suite.db, suite.meter, suite.log),
+		})
+
+		result, err := partnerService.CheckLimit(suite.ctx, dto.CheckLimitRequest{
+			CustomerNIK:       customer.NIK,
+			TenorMonths:       tenor.DurationMonths,
+			TransactionAmount: decimal.NewFromInt(30000),
+		})
+
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "rejected", result.Status)
+	})
+}
+
+func (suite *PartnerServiceTestSuite) seedPartner() *model.Partner {
+	partner := &model.Partner{
+		Name:             "Dealer Jaya",
+		Email:            "dealer@example.com",
+		SandboxKeyHash:   fmt.Sprintf("%064d", 1),
+		SandboxKeyPrefix: "sbx_test",
+	}
+	suite.Require().NoError(suite.db.Create(partner).Error)
+	return partner
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_AdminFeeFromSchedule() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	partner := suite.seedPartner()
+
+	// Jadwal default partner dan jadwal khusus tenor 6 bulan
+	suite.Require().NoError(suite.feeScheduleRepository.Upsert(suite.ctx, &domain.PartnerFeeSchedule{
+		PartnerID: partner.ID, AdminFeeFlat: decimal.NewFromInt(9999), UpdatedBy: 1,
+	}))
+	suite.Require().NoError(suite.feeScheduleRepository.Upsert(suite.ctx, &domain.PartnerFeeSchedule{
+		PartnerID:      partner.ID,
+		TenorMonths:    tenor.DurationMonths,
+		AdminFeeFlat:   decimal.NewFromInt(500),
+		AdminFeeRate:   decimal.RequireFromString("0.02"),
+		CommissionRate: decimal.RequireFromString("0.015"),
+		UpdatedBy:      1,
+	}))
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Scheduled Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		PartnerID:   &partner.ID,
+	}
+	suite.quotaService.EXPECT().Reserve(gomock.Any(), partner.ID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "1300", result.AdminFee.String())
+	assert.Equal(suite.T(), "600", result.CommissionAmount.String())
+
+	var savedTransaction model.Transaction
+	suite.Require().NoError(suite.db.First(&savedTransaction, result.ID).Error)
+	assert.Equal(suite.T(), "600", savedTransaction.CommissionAmount.String())
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_PartnerAdminFeeWins() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	partner := suite.seedPartner()
+	suite.Require().NoError(suite.feeScheduleRepository.Upsert(suite.ctx, &domain.PartnerFeeSchedule{
+		PartnerID:      partner.ID,
+		AdminFeeFlat:   decimal.NewFromInt(500),
+		CommissionRate: decimal.RequireFromString("0.01"),
+		UpdatedBy:      1,
+	}))
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Own Fee Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(750),
+		PartnerID:   &partner.ID,
+	}
+	suite.quotaService.EXPECT().Reserve(gomock.Any(), partner.ID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "750", result.AdminFee.String())
+	assert.Equal(suite.T(), "400", result.CommissionAmount.String())
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_DailyQuotaExceeded() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	partner := suite.seedPartner()
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Over Quota Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(750),
+		PartnerID:   &partner.ID,
+	}
+	suite.quotaService.EXPECT().
+		Reserve(gomock.Any(), partner.ID, gomock.Any(), gomock.Any()).
+		Return(nil, common.ErrDailyCountQuotaExceeded)
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, common.ErrDailyCountQuotaExceeded)
+
+	var count int64
+	suite.Require().NoError(suite.db.Model(&model.Transaction{}).Count(&count).Error)
+	assert.Zero(suite.T(), count)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_SandboxSkipsQuota() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	partner := suite.seedPartner()
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Sandbox Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(750),
+		PartnerID:   &partner.ID,
+		Sandbox:     true,
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.IsSandbox)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_AdminFeeRequired() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "No Fee Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, common.ErrAdminFeeRequired)
+}
+
 func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_InsufficientLimit() {
 	// Arrange
 	customer, tenor, _ := suite.seedTestData()
@@ -401,8 +840,8 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_Insufficient
 		CustomerNIK: customer.NIK,
 		TenorMonths: tenor.DurationMonths,
 		AssetName:   "Expensive Asset",
-		OTRAmount:   60000, // Exceeds limit of 50000
-		AdminFee:    0,
+		OTRAmount:   decimal.NewFromInt(60000), // Exceeds limit of 50000
+		AdminFee:    adminFee(0),
 	}
 
 	// Act
@@ -419,14 +858,79 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_Insufficient
 	assert.Equal(suite.T(), int64(0), count)
 }
 
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_Blacklisted() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	_, err := suite.blacklistService.CreateEntry(suite.ctx, dto.BlacklistEntryRequest{
+		NIK:      customer.NIK,
+		FullName: "Someone Else",
+		Reason:   "Fraudulent application",
+		Source:   "internal",
+		Severity: domain.SeverityBlock,
+	})
+	suite.Require().NoError(err)
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Test Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, common.ErrBlacklisted)
+
+	var transactions, logs int64
+	suite.db.Model(&model.Transaction{}).Count(&transactions)
+	suite.db.Model(&model.ScreeningLog{}).Where("customer_id = ? AND outcome = ?", customer.ID, model.ScreeningBlocked).Count(&logs)
+	assert.Equal(suite.T(), int64(0), transactions)
+	assert.Equal(suite.T(), int64(1), logs)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_Flagged() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	_, err := suite.blacklistService.CreateEntry(suite.ctx, dto.BlacklistEntryRequest{
+		FullName: customer.LegalName,
+		Reason:   "Name appears on watchlist",
+		Source:   "OJK",
+		Severity: domain.SeverityFlag,
+	})
+	suite.Require().NoError(err)
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Test Asset",
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+
+	var logs int64
+	suite.db.Model(&model.ScreeningLog{}).Where("customer_id = ? AND outcome = ?", customer.ID, model.ScreeningFlagged).Count(&logs)
+	assert.Equal(suite.T(), int64(1), logs)
+}
+
 func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_CustomerNotFound() {
 	// Arrange
 	req := dto.CreateTransactionRequest{
 		CustomerNIK: "nonexistent",
 		TenorMonths: 6,
 		AssetName:   "Test Asset",
-		OTRAmount:   40000,
-		AdminFee:    1000,
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
 	}
 
 	// Act
@@ -458,8 +962,8 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_CustomerNotV
 		CustomerNIK: customer.NIK,
 		TenorMonths: 6,
 		AssetName:   "Test Asset",
-		OTRAmount:   40000,
-		AdminFee:    1000,
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
 	}
 
 	// Act
@@ -491,8 +995,8 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_TenorNotFoun
 		CustomerNIK: customer.NIK,
 		TenorMonths: 99, // Nonexistent tenor
 		AssetName:   "Test Asset",
-		OTRAmount:   40000,
-		AdminFee:    1000,
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
 	}
 
 	// Act
@@ -532,8 +1036,8 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_LimitNotSet(
 		CustomerNIK: customer.NIK,
 		TenorMonths: tenor.DurationMonths,
 		AssetName:   "Test Asset",
-		OTRAmount:   40000,
-		AdminFee:    1000,
+		OTRAmount:   decimal.NewFromInt(40000),
+		AdminFee:    adminFee(1000),
 	}
 
 	// Act
@@ -554,10 +1058,10 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_WithExisting
 		CustomerID:             customer.ID,
 		TenorID:                tenor.ID,
 		AssetName:              "Existing Asset",
-		OTRAmount:              20000,
-		AdminFee:               500,
-		TotalInstallmentAmount: 3500,
-		TotalInterest:          1000,
+		OTRAmount:              decimal.NewFromInt(20000),
+		AdminFee:               decimal.NewFromInt(500),
+		TotalInstallmentAmount: decimal.NewFromInt(3500),
+		TotalInterest:          decimal.NewFromInt(1000),
 		Status:                 model.TransactionActive,
 	}
 	err := suite.db.Create(existingTx).Error
@@ -567,8 +1071,8 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_WithExisting
 		CustomerNIK: customer.NIK,
 		TenorMonths: tenor.DurationMonths,
 		AssetName:   "New Asset",
-		OTRAmount:   25000, // Total would be 45000, still within limit of 50000
-		AdminFee:    500,
+		OTRAmount:   decimal.NewFromInt(25000), // Total would be 45000, still within limit of 50000
+		AdminFee:    adminFee(500),
 	}
 
 	// Act
@@ -578,7 +1082,7 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_WithExisting
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Equal(suite.T(), "New Asset", result.AssetName)
-	assert.Equal(suite.T(), float64(25000), result.OTRAmount)
+	assert.Equal(suite.T(), "25000", result.OTRAmount.String())
 
 	// Verify both transactions exist
 	var count int64
@@ -586,7 +1090,319 @@ func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_WithExisting
 	assert.Equal(suite.T(), int64(2), count)
 }
 
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_ForeignCurrency() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	_, err := suite.fxRateService.UploadRates(suite.ctx, 1, dto.FxRateUploadRequest{
+		RateDate: time.Now().AddDate(0, 0, -1).Format("2006-01-02"),
+		Rates:    []dto.FxRateItemRequest{{Currency: "SGD", RateToIDR: decimal.NewFromInt(10)}},
+	})
+	suite.Require().NoError(err)
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Imported Asset",
+		OTRAmount:   decimal.NewFromInt(4000), // 40000 IDR, within limit of 50000
+		AdminFee:    adminFee(100),
+		Currency:    "sgd",
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "SGD", result.Currency)
+	assert.Equal(suite.T(), "10", result.FxRate.String())
+
+	// Used amount is reported in IDR
+	used, err := suite.transactionRepository.SumActivePrincipalByCustomerIDAndTenorID(suite.ctx, customer.ID, tenor.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "41000", used.String())
+
+	// A second SGD contract no longer fits the IDR limit
+	_, err = suite.partnerService.CreateTransaction(suite.ctx, req)
+	assert.ErrorIs(suite.T(), err, common.ErrInsufficientLimit)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_FxRateNotFound() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Imported Asset",
+		OTRAmount:   decimal.NewFromInt(100),
+		AdminFee:    adminFee(0),
+		Currency:    "USD",
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, common.ErrFxRateNotFound)
+}
+
+func (suite *PartnerServiceTestSuite) seedPromotion(promotion model.Promotion) {
+	promotion.Active = true
+	promotion.ValidFrom = time.Now().Add(-time.Hour)
+	if promotion.ValidUntil.IsZero() {
+		promotion.ValidUntil = time.Now().Add(24 * time.Hour)
+	}
+	promotion.UpdatedBy = 1
+	suite.Require().NoError(suite.db.Create(&promotion).Error)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_PromoOnAdminFee() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	suite.seedPromotion(model.Promotion{
+		Code:           "HEMAT50",
+		Target:         model.PromotionAdminFee,
+		DiscountType:   model.DiscountPercent,
+		DiscountValue:  decimal.RequireFromString("0.5"),
+		MaxPerCustomer: 1,
+	})
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Promo Asset",
+		OTRAmount:   decimal.NewFromInt(10000),
+		AdminFee:    adminFee(1000),
+		PromoCode:   "hemat50",
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "500", result.AdminFee.String())
+	assert.Equal(suite.T(), "500", result.DiscountAmount.String())
+	assert.Equal(suite.T(), "11700", result.TotalInstallmentAmount.String())
+
+	var redemption model.PromotionRedemption
+	suite.Require().NoError(suite.db.Where("transaction_id = ?", result.ID).First(&redemption).Error)
+	assert.Equal(suite.T(), customer.ID, redemption.CustomerID)
+	assert.Equal(suite.T(), "500", redemption.DiscountAmount.String())
+
+	// Kuota per customer sudah terpakai
+	_, err = suite.partnerService.CreateTransaction(suite.ctx, req)
+	assert.ErrorIs(suite.T(), err, common.ErrPromotionExhausted)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_PromoOnInterest() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	suite.seedPromotion(model.Promotion{
+		Code:          "BUNGA300",
+		Target:        model.PromotionInterest,
+		DiscountType:  model.DiscountFlat,
+		DiscountValue: decimal.NewFromInt(300),
+	})
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Promo Asset",
+		OTRAmount:   decimal.NewFromInt(10000),
+		AdminFee:    adminFee(1000),
+		PromoCode:   "BUNGA300",
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "1000", result.AdminFee.String())
+	assert.Equal(suite.T(), "900", result.TotalInterest.String())
+	assert.Equal(suite.T(), "11900", result.TotalInstallmentAmount.String())
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Failure_PromoNotApplicable() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	suite.seedPromotion(model.Promotion{
+		Code:          "TENOR12",
+		Target:        model.PromotionAdminFee,
+		DiscountType:  model.DiscountPercent,
+		DiscountValue: decimal.NewFromInt(1),
+		TenorMonths:   12,
+	})
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Promo Asset",
+		OTRAmount:   decimal.NewFromInt(10000),
+		AdminFee:    adminFee(1000),
+		PromoCode:   "TENOR12",
+	}
+
+	// Act
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, common.ErrPromotionNotApplicable)
+
+	req.PromoCode = "UNKNOWN"
+	_, err = suite.partnerService.CreateTransaction(suite.ctx, req)
+	assert.ErrorIs(suite.T(), err, common.ErrPromotionNotFound)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_SequentialContractNumbers() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	partner := suite.seedPartner()
+	suite.Require().NoError(suite.db.Create(&model.Region{Code: "JKT", Name: "Jakarta", Active: true}).Error)
+	day := time.Now().Format("20060102")
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Numbered Asset",
+		OTRAmount:   decimal.NewFromInt(5000),
+		AdminFee:    adminFee(500),
+	}
+
+	// Act & Assert
+	first, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "KTR-"+day+"-000001", first.ContractNumber)
+
+	second, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "KTR-"+day+"-000002", second.ContractNumber)
+
+	// Region punya sequence sendiri
+	req.RegionCode = "jkt"
+	regional, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "KTR-JKT-"+day+"-000001", regional.ContractNumber)
+	assert.Equal(suite.T(), "JKT", regional.RegionCode)
+
+	// Transaksi yang ditolak tidak memakai nomor
+	req.RegionCode = "BDG"
+	_, err = suite.partnerService.CreateTransaction(suite.ctx, req)
+	assert.ErrorIs(suite.T(), err, common.ErrRegionNotFound)
+
+	// Sandbox tidak menghabiskan nomor kontrak produksi
+	req.RegionCode = "JKT"
+	req.PartnerID = &partner.ID
+	req.Sandbox = true
+	sandbox, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "SBX-KTR-JKT-"+day+"-000001", sandbox.ContractNumber)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_TakenContractNumbers() {
+	// Arrange
+	customer, tenor, _ := suite.seedTestData()
+	day := time.Now().Format("20060102")
+
+	// Kontrak yang dibuat di luar sequence, misalnya hasil migrasi
+	takeNumbers := func(numbers ...int) {
+		for _, number := range numbers {
+			suite.Require().NoError(suite.db.Create(&model.Transaction{
+				CustomerID:             customer.ID,
+				TenorID:                tenor.ID,
+				AssetName:              "Migrated Asset",
+				OTRAmount:              decimal.NewFromInt(100),
+				TotalInstallmentAmount: decimal.NewFromInt(100),
+				ContractNumber:         fmt.Sprintf("KTR-%s-%06d", day, number),
+				TransactionDate:        time.Now(),
+				Status:                 model.TransactionPaidOff,
+			}).Error)
+		}
+	}
+
+	req := dto.CreateTransactionRequest{
+		CustomerNIK: customer.NIK,
+		TenorMonths: tenor.DurationMonths,
+		AssetName:   "Numbered Asset",
+		OTRAmount:   decimal.NewFromInt(5000),
+		AdminFee:    adminFee(500),
+	}
+
+	// Act & Assert
+	takeNumbers(1)
+	result, err := suite.partnerService.CreateTransaction(suite.ctx, req)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "KTR-"+day+"-000002", result.ContractNumber)
+
+	// Setelah tiga nomor berturut-turut terpakai percobaan dihentikan
+	takeNumbers(3, 4, 5)
+	result, err = suite.partnerService.CreateTransaction(suite.ctx, req)
+	assert.ErrorIs(suite.T(), err, common.ErrDuplicateContract)
+	assert.Nil(suite.T(), result)
+
+	var count int64
+	suite.Require().NoError(suite.db.Model(&model.Transaction{}).Where("asset_name = ?", "Numbered Asset").Count(&count).Error)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func (suite *PartnerServiceTestSuite) TestCreateTransaction_Success_ConcurrentContractNumbers() {
+	// Arrange
+	tenor := &model.Tenor{DurationMonths: 6, Description: "Concurrent tenor"}
+	suite.Require().NoError(suite.db.Create(tenor).Error)
+
+	const workers = 5
+	niks := make([]string, workers)
+	for i := range niks {
+		customer := testutil.NewCustomer().
+			WithName(fmt.Sprintf("Customer %d", i)).
+			WithPassword("password123").
+			Create(suite.T(), suite.db)
+		testutil.SeedLimit(suite.T(), suite.db, customer.ID, tenor.ID, decimal.NewFromInt(50000))
+		niks[i] = customer.NIK
+	}
+
+	// Act
+	var wg sync.WaitGroup
+	numbers := make([]string, workers)
+	errs := make([]error, workers)
+	for i, nik := range niks {
+		wg.Add(1)
+		go func(i int, nik string) {
+			defer wg.Done()
+			result, err := suite.partnerService.CreateTransaction(suite.ctx, dto.CreateTransactionRequest{
+				CustomerNIK: nik,
+				TenorMonths: tenor.DurationMonths,
+				AssetName:   "Concurrent Asset",
+				OTRAmount:   decimal.NewFromInt(10000),
+				AdminFee:    adminFee(500),
+			})
+			errs[i] = err
+			if result != nil {
+				numbers[i] = result.ContractNumber
+			}
+		}(i, nik)
+	}
+	wg.Wait()
+
+	// Assert
+	day := time.Now().Format("20060102")
+	expected := make([]string, workers)
+	for i := range expected {
+		suite.Require().NoError(errs[i])
+		expected[i] = fmt.Sprintf("KTR-%s-%06d", day, i+1)
+	}
+	assert.ElementsMatch(suite.T(), expected, numbers)
+}
+
 // Test runner function
 func TestPartnerServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(PartnerServiceTestSuite))
 }
+
+func adminFee(amount int64) *decimal.Decimal {
+	fee := decimal.NewFromInt(amount)
+	return &fee
+}
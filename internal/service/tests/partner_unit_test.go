@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	partnersrv "github.com/fazamuttaqien/multifinance/internal/service/partner"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartnerAffordability_ParseMaxRatios(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ratios, err := partnersrv.ParseMaxRatios("low:0.3, HIGH:0.4")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]decimal.Decimal{
+			"LOW":  decimal.RequireFromString("0.3"),
+			"HIGH": decimal.RequireFromString("0.4"),
+		}, ratios)
+	})
+
+	t.Run("Success - Empty Disables Check", func(t *testing.T) {
+		ratios, err := partnersrv.ParseMaxRatios(" ")
+
+		require.NoError(t, err)
+		assert.Nil(t, ratios)
+	})
+
+	t.Run("Failure - Malformed Entry", func(t *testing.T) {
+		_, err := partnersrv.ParseMaxRatios("LOW")
+		assert.Error(t, err)
+	})
+
+	t.Run("Failure - Ratio Out Of Range", func(t *testing.T) {
+		_, err := partnersrv.ParseMaxRatios("LOW:1.5")
+		assert.Error(t, err)
+
+		_, err = partnersrv.ParseMaxRatios("LOW:0")
+		assert.Error(t, err)
+	})
+}
+
+func TestPartnerService_InvalidAmounts(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-partner-service")
+	// Nilai yang tidak valid ditolak sebelum database disentuh
+	partnerService := partnersrv.NewPartnerService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, partnersrv.Affordability{}, meter, tracer, log)
+	negative := decimal.NewFromInt(-1)
+
+	testCases := []struct {
+		name     string
+		req      dto.CreateTransactionRequest
+		field    string
+		expected error
+	}{
+		{"Zero OTR Amount", dto.CreateTransactionRequest{OTRAmount: decimal.Zero}, "otr_amount", common.ErrAmountNotPositive},
+		{"Negative OTR Amount", dto.CreateTransactionRequest{OTRAmount: decimal.NewFromInt(-5000)}, "otr_amount", common.ErrAmountNotPositive},
+		{"Negative Admin Fee", dto.CreateTransactionRequest{OTRAmount: decimal.NewFromInt(5000), AdminFee: &negative}, "admin_fee", common.ErrNegativeAdminFee},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := partnerService.CreateTransaction(context.Background(), tc.req)
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.expected)
+			var fieldErr *common.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tc.field, fieldErr.Field)
+		})
+	}
+
+	t.Run("Zero Check Limit Amount", func(t *testing.T) {
+		result, err := partnerService.CheckLimit(context.Background(), dto.CheckLimitRequest{TransactionAmount: decimal.Zero})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, common.ErrAmountNotPositive)
+	})
+}
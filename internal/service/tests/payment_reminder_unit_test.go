@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	remindersrv "github.com/fazamuttaqien/multifinance/internal/service/reminder"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPaymentReminderService_Run_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-payment-reminder-service")
+	cfg := remindersrv.Config{DaysBefore: 3, BatchSize: 50}
+
+	paid := 1
+	contract := domain.Transaction{
+		ID:                     11,
+		CustomerID:             2,
+		ContractNumber:         "KTR-RM-001",
+		Tenor:                  domain.Tenor{DurationMonths: 6},
+		TotalInstallmentAmount: decimal.NewFromInt(600000),
+		Currency:               "IDR",
+		TransactionDate:        time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		PaidInstallments:       &paid,
+		Customer:               domain.Customer{ID: 2, FullName: "Budi Santoso"},
+	}
+
+	setup := func(t *testing.T) (*mocks.MockPaymentReminderRepository, *servicemocks.MockCustomerNotifier) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockPaymentReminderRepository(ctrl), servicemocks.NewMockCustomerNotifier(ctrl)
+	}
+
+	t.Run("Reminds Installment Due Within Window", func(t *testing.T) {
+		paymentReminderRepository, notifier := setup(t)
+		reminderService := remindersrv.NewPaymentReminderService(paymentReminderRepository, notifier, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		// Cicilan kedua jatuh tempo 10 Maret, tiga hari lagi
+		now := time.Date(2025, 3, 7, 8, 0, 0, 0, time.UTC)
+		paymentReminderRepository.EXPECT().FindUpcoming(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+		paymentReminderRepository.EXPECT().Claim(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, reminder *domain.PaymentReminder) (bool, error) {
+			assert.Equal(t, uint64(11), reminder.TransactionID)
+			assert.Equal(t, 2, reminder.Installment)
+			assert.Equal(t, "2025-03-10", reminder.DueDate.Format(time.DateOnly))
+			reminder.ID = 5
+			return true, nil
+		})
+		notifier.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplatePaymentReminder, map[string]string{
+			"full_name":       "Budi Santoso",
+			"contract_number": "KTR-RM-001",
+			"installment":     "2",
+			"due_date":        "2025-03-10",
+			"amount":          "IDR 100.000,00",
+		}).Return(nil)
+
+		run, err := reminderService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.PaymentReminderRun{Due: 1, Sent: 1}, run)
+	})
+
+	t.Run("Skips Installment Outside Window", func(t *testing.T) {
+		paymentReminderRepository, notifier := setup(t)
+		reminderService := remindersrv.NewPaymentReminderService(paymentReminderRepository, notifier, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+		paymentReminderRepository.EXPECT().FindUpcoming(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+
+		run, err := reminderService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Due)
+	})
+
+	t.Run("Already Reminded", func(t *testing.T) {
+		paymentReminderRepository, notifier := setup(t)
+		reminderService := remindersrv.NewPaymentReminderService(paymentReminderRepository, notifier, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+		paymentReminderRepository.EXPECT().FindUpcoming(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+		paymentReminderRepository.EXPECT().Claim(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		run, err := reminderService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.PaymentReminderRun{Due: 1}, run)
+	})
+
+	t.Run("Failed Notification Releases Claim", func(t *testing.T) {
+		paymentReminderRepository, notifier := setup(t)
+		reminderService := remindersrv.NewPaymentReminderService(paymentReminderRepository, notifier, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		now := time.Date(2025, 3, 8, 8, 0, 0, 0, time.UTC)
+		paymentReminderRepository.EXPECT().FindUpcoming(gomock.Any(), uint64(0), 50).Return([]domain.Transaction{contract}, nil)
+		paymentReminderRepository.EXPECT().Claim(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, reminder *domain.PaymentReminder) (bool, error) {
+			reminder.ID = 5
+			return true, nil
+		})
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+		paymentReminderRepository.EXPECT().Release(gomock.Any(), uint64(5)).Return(nil)
+
+		run, err := reminderService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.PaymentReminderRun{Due: 1, Failed: 1}, run)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		paymentReminderRepository, notifier := setup(t)
+		reminderService := remindersrv.NewPaymentReminderService(paymentReminderRepository, notifier, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		paymentReminderRepository.EXPECT().FindUpcoming(gomock.Any(), uint64(0), 50).Return(nil, dbErr)
+
+		_, err := reminderService.Run(context.Background(), time.Now())
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	paymentrepo "github.com/fazamuttaqien/multifinance/internal/repository/payment"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	paymentsrv "github.com/fazamuttaqien/multifinance/internal/service/payment"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/paymentgateway"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PaymentServiceTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	ctx context.Context
+
+	paymentService service.PaymentServices
+}
+
+func (suite *PaymentServiceTestSuite) SetupSuite() {
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_service_payment_test").DB
+	suite.ctx = context.Background()
+
+	meter, tracer, log := testutil.Telemetry("test-payment-service")
+	suite.paymentService = paymentsrv.NewPaymentService(
+		suite.db,
+		paymentrepo.NewPaymentRepository(suite.db, meter, log),
+		[]paymentgateway.Verifier{paymentgateway.NewMidtrans(midtransServerKey)},
+		meter, tracer, log,
+	)
+}
+
+func (suite *PaymentServiceTestSuite) SetupTest() {
+	testutil.Reset(suite.T(), suite.db)
+}
+
+// seedContract creates an active six month contract of 600.000 in total
+// installments, so every 100.000 collected covers one installment.
+func (suite *PaymentServiceTestSuite) seedContract(contractNumber string) *model.Transaction {
+	customer := testutil.NewCustomer().WithNIK("3201010101010001").Create(suite.T(), suite.db)
+	tenor := testutil.SeedTenors(suite.T(), suite.db, 6)[0]
+
+	transaction := &model.Transaction{
+		ContractNumber:         contractNumber,
+		CustomerID:             customer.ID,
+		TenorID:                tenor.ID,
+		AssetName:              "Motor",
+		OTRAmount:              decimal.NewFromInt(500000),
+		AdminFee:               decimal.NewFromInt(10000),
+		TotalInterest:          decimal.NewFromInt(90000),
+		TotalInstallmentAmount: decimal.NewFromInt(600000),
+		Status:                 model.TransactionActive,
+		Currency:               "IDR",
+		FxRate:                 decimal.NewFromInt(1),
+	}
+	suite.Require().NoError(suite.db.Create(transaction).Error)
+
+	return transaction
+}
+
+func (suite *PaymentServiceTestSuite) reload(id uint64) model.Transaction {
+	var transaction model.Transaction
+	suite.Require().NoError(suite.db.First(&transaction, id).Error)
+	return transaction
+}
+
+func (suite *PaymentServiceTestSuite) TestHandleCallback_Settlement_UpdatesInstallments() {
+	contract := suite.seedContract("CN-PAY-001")
+
+	callback, err := suite.paymentService.HandleCallback(suite.ctx, "midtrans", nil,
+		midtransCallback(suite.T(), "CN-PAY-001", "mt-1", "settlement", "250000.00"))
+
+	suite.Require().NoError(err)
+	suite.Equal(domain.CallbackProcessed, callback.Outcome)
+
+	updated := suite.reload(contract.ID)
+	suite.Require().NotNil(updated.PaidInstallments)
+	suite.Equal(2, *updated.PaidInstallments)
+	suite.Equal(model.TransactionActive, updated.Status)
+
+	var payment model.Payment
+	suite.Require().NoError(suite.db.Where("provider = ? AND external_id = ?", "midtrans", "mt-1").First(&payment).Error)
+	suite.Equal(model.PaymentPaid, payment.Status)
+	suite.NotNil(payment.PaidAt)
+}
+
+func (suite *PaymentServiceTestSuite) TestHandleCallback_Replay_IsNotReprocessed() {
+	contract := suite.seedContract("CN-PAY-002")
+	body := midtransCallback(suite.T(), "CN-PAY-002", "mt-2", "settlement", "100000.00")
+
+	_, err := suite.paymentService.HandleCallback(suite.ctx, "midtrans", nil, body)
+	suite.Require().NoError(err)
+
+	callback, err := suite.paymentService.HandleCallback(suite.ctx, "midtrans", nil, body)
+	suite.Require().NoError(err)
+	suite.Equal(domain.CallbackDuplicate, callback.Outcome)
+
+	updated := suite.reload(contract.ID)
+	suite.Equal(1, *updated.PaidInstallments)
+
+	var archived int64
+	suite.db.Model(&model.PaymentCallback{}).Count(&archived)
+	suite.Equal(int64(1), archived)
+}
+
+func (suite *PaymentServiceTestSuite) TestHandleCallback_FullRepayment_PaysOffContract() {
+	contract := suite.seedContract("CN-PAY-003")
+
+	for _, n := range []struct{ id, status string }{
+		{"mt-3", "pending"},
+		{"mt-3", "settlement"},
+		{"mt-4", "settlement"},
+	} {
+		_, err := suite.paymentService.HandleCallback(suite.ctx, "midtrans", nil,
+			midtransCallback(suite.T(), "CN-PAY-003", n.id, n.status, "300000.00"))
+		suite.Require().NoError(err)
+	}
+
+	updated := suite.reload(contract.ID)
+	suite.Equal(6, *updated.PaidInstallments)
+	suite.Equal(model.TransactionPaidOff, updated.Status)
+
+	var payments int64
+	suite.db.Model(&model.Payment{}).Count(&payments)
+	suite.Equal(int64(2), payments)
+}
+
+func (suite *PaymentServiceTestSuite) TestHandleCallback_VirtualAccount_MatchesContract() {
+	contract := suite.seedContract("CN-PAY-004")
+	number := "8808800000000004"
+	suite.Require().NoError(suite.db.Model(contract).Updates(map[string]any{
+		"virtual_account_bank":   "BCA",
+		"virtual_account_number": number,
+	}).Error)
+
+	// Reference transfer bank tidak sama dengan nomor kontrak
+	var notification map[string]any
+	suite.Require().NoError(json.Unmarshal(midtransCallback(suite.T(), "VA-TRF-991", "mt-6", "settlement", "100000.00"), &notification))
+	notification["va_numbers"] = []map[string]string{{"bank": "bca", "va_number": number}}
+	body, err := json.Marshal(notification)
+	suite.Require().NoError(err)
+
+	callback, err := suite.paymentService.HandleCallback(suite.ctx, "midtrans", nil, body)
+
+	suite.Require().NoError(err)
+	suite.Equal(domain.CallbackProcessed, callback.Outcome)
+	suite.Equal(1, *suite.reload(contract.ID).PaidInstallments)
+}
+
+func (suite *PaymentServiceTestSuite) TestHandleCallback_UnknownContract_IsArchivedUnmatched() {
+	callback, err := suite.paymentService.HandleCallback(suite.ctx, "midtrans", nil,
+		midtransCallback(suite.T(), "CN-MISSING", "mt-5", "settlement", "100000.00"))
+
+	suite.Require().NoError(err)
+	suite.Equal(domain.CallbackUnmatched, callback.Outcome)
+
+	var archived model.PaymentCallback
+	suite.Require().NoError(suite.db.First(&archived, callback.ID).Error)
+	suite.Equal(model.CallbackUnmatched, archived.Outcome)
+	suite.Contains(archived.Payload, "CN-MISSING")
+
+	var payments int64
+	suite.db.Model(&model.Payment{}).Count(&payments)
+	suite.Zero(payments)
+}
+
+func TestPaymentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PaymentServiceTestSuite))
+}
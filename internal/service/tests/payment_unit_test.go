@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	paymentsrv "github.com/fazamuttaqien/multifinance/internal/service/payment"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/paymentgateway"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	midtransServerKey = "SB-Mid-server-test"
+	xenditCallbackKey = "xnd-callback-test"
+)
+
+// midtransCallback builds a Midtrans notification signed with midtransServerKey.
+func midtransCallback(t testing.TB, orderID, transactionID, status, grossAmount string) []byte {
+	statusCode := "200"
+	if status == "pending" {
+		statusCode = "201"
+	}
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + midtransServerKey))
+
+	body, err := json.Marshal(map[string]string{
+		"transaction_id":     transactionID,
+		"transaction_status": status,
+		"transaction_time":   "2026-10-01 10:00:00",
+		"status_code":        statusCode,
+		"order_id":           orderID,
+		"gross_amount":       grossAmount,
+		"currency":           "IDR",
+		"signature_key":      hex.EncodeToString(sum[:]),
+	})
+	require.NoError(t, err)
+	return body
+}
+
+func xenditSignature(body []byte) http.Header {
+	mac := hmac.New(sha256.New, []byte(xenditCallbackKey))
+	mac.Write(body)
+	header := make(http.Header)
+	header.Set(paymentgateway.XenditSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return header
+}
+
+func TestPaymentService_HandleCallback_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-payment-service")
+	verifiers := []paymentgateway.Verifier{
+		paymentgateway.NewMidtrans(midtransServerKey),
+		paymentgateway.NewXendit(xenditCallbackKey),
+	}
+
+	t.Run("Unknown Provider", func(t *testing.T) {
+		paymentRepository := mocks.NewMockPaymentRepository(gomock.NewController(t))
+		paymentService := paymentsrv.NewPaymentService(nil, paymentRepository, verifiers, meter, tracer, log)
+
+		callback, err := paymentService.HandleCallback(context.Background(), "doku", http.Header{}, []byte(`{}`))
+
+		assert.Nil(t, callback)
+		assert.ErrorIs(t, err, common.ErrUnknownPaymentProvider)
+	})
+
+	t.Run("Midtrans Signature Mismatch Is Archived", func(t *testing.T) {
+		paymentRepository := mocks.NewMockPaymentRepository(gomock.NewController(t))
+		paymentService := paymentsrv.NewPaymentService(nil, paymentRepository, verifiers, meter, tracer, log)
+
+		// Nominal diubah setelah ditandatangani
+		var notification map[string]string
+		require.NoError(t, json.Unmarshal(midtransCallback(t, "CN-001", "mt-1", "settlement", "100000.00"), &notification))
+		notification["gross_amount"] = "999999.00"
+		tampered, err := json.Marshal(notification)
+		require.NoError(t, err)
+
+		paymentRepository.EXPECT().ArchiveCallback(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, callback *domain.PaymentCallback) (bool, error) {
+				assert.Equal(t, "midtrans", callback.Provider)
+				assert.Equal(t, domain.CallbackRejected, callback.Outcome)
+				assert.Nil(t, callback.EventID)
+				assert.Equal(t, string(tampered), callback.Payload)
+				return true, nil
+			})
+
+		callback, err := paymentService.HandleCallback(context.Background(), "midtrans", http.Header{}, tampered)
+
+		assert.Nil(t, callback)
+		assert.ErrorIs(t, err, common.ErrInvalidCallbackSignature)
+	})
+
+	t.Run("Xendit Missing Signature", func(t *testing.T) {
+		paymentRepository := mocks.NewMockPaymentRepository(gomock.NewController(t))
+		paymentService := paymentsrv.NewPaymentService(nil, paymentRepository, verifiers, meter, tracer, log)
+
+		paymentRepository.EXPECT().ArchiveCallback(gomock.Any(), gomock.Any()).Return(true, nil)
+
+		callback, err := paymentService.HandleCallback(context.Background(), "xendit", http.Header{}, []byte(`{"id":"inv-1"}`))
+
+		assert.Nil(t, callback)
+		assert.ErrorIs(t, err, common.ErrInvalidCallbackSignature)
+	})
+
+	t.Run("Xendit Signed But Malformed", func(t *testing.T) {
+		paymentRepository := mocks.NewMockPaymentRepository(gomock.NewController(t))
+		paymentService := paymentsrv.NewPaymentService(nil, paymentRepository, verifiers, meter, tracer, log)
+
+		body := []byte(`{"id":"inv-1","status":"PAID"}`)
+		paymentRepository.EXPECT().ArchiveCallback(gomock.Any(), gomock.Any()).Return(true, nil)
+
+		callback, err := paymentService.HandleCallback(context.Background(), "xendit", xenditSignature(body), body)
+
+		assert.Nil(t, callback)
+		assert.ErrorIs(t, err, common.ErrInvalidCallbackPayload)
+	})
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	pendingexpirysrv "github.com/fazamuttaqien/multifinance/internal/service/pendingexpiry"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPendingExpiryService_Run_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-pending-expiry-service")
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	cfg := pendingexpirysrv.Config{RemindAfter: 7 * 24 * time.Hour, RejectAfter: 30 * 24 * time.Hour, BatchSize: 50}
+
+	submitted := now.AddDate(0, 0, -10)
+	oldest := now.AddDate(0, 0, -40)
+	stale := domain.Customer{ID: 2, FullName: "Budi Santoso", DocumentsSubmittedAt: &submitted}
+	expired := domain.Customer{ID: 3, FullName: "Siti Aminah", DocumentsSubmittedAt: &oldest}
+
+	t.Run("Success - Reminds And Rejects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pendingExpiryRepository := mocks.NewMockPendingExpiryRepository(ctrl)
+		notifier := servicemocks.NewMockCustomerNotifier(ctrl)
+		pendingExpiryService := pendingexpirysrv.NewPendingExpiryService(pendingExpiryRepository, notifier, cfg, meter, tracer, log)
+
+		pendingExpiryRepository.EXPECT().FindUnreminded(gomock.Any(), now.AddDate(0, 0, -7), 50).Return([]domain.Customer{stale}, nil)
+		notifier.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplateVerificationReminder,
+			map[string]string{"full_name": "Budi Santoso", "deadline": "2025-04-20"}).Return(nil)
+		pendingExpiryRepository.EXPECT().MarkReminded(gomock.Any(), uint64(2), now).Return(nil)
+
+		// Jeda setelah pengingat = 30 - 7 hari
+		pendingExpiryRepository.EXPECT().FindExpired(gomock.Any(), now.AddDate(0, 0, -30), now.AddDate(0, 0, -23), 50).Return([]domain.Customer{expired}, nil)
+		pendingExpiryRepository.EXPECT().Reject(gomock.Any(), uint64(3)).Return(true, nil)
+		notifier.EXPECT().Notify(gomock.Any(), uint64(3), domain.TemplateVerificationExpired, gomock.Any()).Return(nil)
+
+		pendingExpiryRepository.EXPECT().Stats(gomock.Any()).Return(int64(4), &oldest, nil)
+
+		run, err := pendingExpiryService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Reminded)
+		assert.Equal(t, 1, run.Rejected)
+		assert.Equal(t, int64(4), run.Pending)
+		assert.Equal(t, &oldest, run.OldestSubmittedAt)
+	})
+
+	t.Run("Success - Failed Reminder Is Not Marked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pendingExpiryRepository := mocks.NewMockPendingExpiryRepository(ctrl)
+		notifier := servicemocks.NewMockCustomerNotifier(ctrl)
+		pendingExpiryService := pendingexpirysrv.NewPendingExpiryService(pendingExpiryRepository, notifier, cfg, meter, tracer, log)
+
+		pendingExpiryRepository.EXPECT().FindUnreminded(gomock.Any(), gomock.Any(), gomock.Any()).Return([]domain.Customer{stale}, nil)
+		notifier.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplateVerificationReminder, gomock.Any()).Return(errors.New("smtp down"))
+		pendingExpiryRepository.EXPECT().FindExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		pendingExpiryRepository.EXPECT().Stats(gomock.Any()).Return(int64(1), &submitted, nil)
+
+		run, err := pendingExpiryService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Reminded)
+	})
+
+	t.Run("Success - Decided Meanwhile Is Not Notified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pendingExpiryRepository := mocks.NewMockPendingExpiryRepository(ctrl)
+		notifier := servicemocks.NewMockCustomerNotifier(ctrl)
+		pendingExpiryService := pendingexpirysrv.NewPendingExpiryService(pendingExpiryRepository, notifier, cfg, meter, tracer, log)
+
+		pendingExpiryRepository.EXPECT().FindUnreminded(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		pendingExpiryRepository.EXPECT().FindExpired(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]domain.Customer{expired}, nil)
+		pendingExpiryRepository.EXPECT().Reject(gomock.Any(), uint64(3)).Return(false, nil)
+		pendingExpiryRepository.EXPECT().Stats(gomock.Any()).Return(int64(0), nil, nil)
+
+		run, err := pendingExpiryService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Rejected)
+	})
+
+	t.Run("Success - Auto Reject Disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pendingExpiryRepository := mocks.NewMockPendingExpiryRepository(ctrl)
+		notifier := servicemocks.NewMockCustomerNotifier(ctrl)
+		remindOnly := pendingexpirysrv.Config{RemindAfter: cfg.RemindAfter}
+		pendingExpiryService := pendingexpirysrv.NewPendingExpiryService(pendingExpiryRepository, notifier, remindOnly, meter, tracer, log)
+
+		pendingExpiryRepository.EXPECT().FindUnreminded(gomock.Any(), gomock.Any(), 100).Return([]domain.Customer{stale}, nil)
+		notifier.EXPECT().Notify(gomock.Any(), uint64(2), domain.TemplateVerificationReminder,
+			map[string]string{"full_name": "Budi Santoso"}).Return(nil)
+		pendingExpiryRepository.EXPECT().MarkReminded(gomock.Any(), uint64(2), now).Return(nil)
+		pendingExpiryRepository.EXPECT().Stats(gomock.Any()).Return(int64(1), &submitted, nil)
+
+		run, err := pendingExpiryService.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, run.Reminded)
+		assert.Equal(t, 0, run.Rejected)
+	})
+
+	t.Run("Failure - Repository Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pendingExpiryRepository := mocks.NewMockPendingExpiryRepository(ctrl)
+		notifier := servicemocks.NewMockCustomerNotifier(ctrl)
+		pendingExpiryService := pendingexpirysrv.NewPendingExpiryService(pendingExpiryRepository, notifier, cfg, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		pendingExpiryRepository.EXPECT().FindUnreminded(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		run, err := pendingExpiryService.Run(context.Background(), now)
+
+		assert.ErrorIs(t, err, dbErr)
+		assert.Nil(t, run)
+	})
+}
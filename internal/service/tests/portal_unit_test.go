@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	portalsrv "github.com/fazamuttaqien/multifinance/internal/service/portal"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/apikey"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPartnerPortalService_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+	transactionRepository := mocks.NewMockTransactionRepository(ctrl)
+	deliveryRepository := mocks.NewMockDeliveryRepository(ctrl)
+	apiUsageRepository := mocks.NewMockAPIUsageRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-portal-service")
+	portalService := portalsrv.NewPartnerPortalService(partnerRepository, transactionRepository, deliveryRepository, apiUsageRepository, meter, tracer, log)
+
+	params := domain.Params{Page: 1, Limit: 10}
+
+	t.Run("ListTransactions - Scoped To Key Environment", func(t *testing.T) {
+		transactions := []domain.Transaction{{ID: 3, ContractNumber: "KTR-20250101-000003", IsSandbox: true}}
+		transactionRepository.EXPECT().FindPaginatedByPartnerID(gomock.Any(), uint64(7), true, params).Return(transactions, int64(1), nil)
+
+		res, err := portalService.ListTransactions(context.Background(), 7, true, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.Total)
+		assert.Equal(t, transactions, res.Data)
+	})
+
+	t.Run("ListDeliveries - Success", func(t *testing.T) {
+		deliveries := []domain.WebhookDelivery{{ID: 5, PartnerID: 7, Status: domain.DeliveryFailed}}
+		deliveryRepository.EXPECT().FindPaginatedByPartnerID(gomock.Any(), uint64(7), params).Return(deliveries, int64(1), nil)
+
+		res, err := portalService.ListDeliveries(context.Background(), 7, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, deliveries, res.Data)
+	})
+
+	t.Run("GetUsage - Oldest Day First", func(t *testing.T) {
+		now := time.Date(2025, 3, 10, 23, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+		apiUsageRepository.EXPECT().FindByPartner(gomock.Any(), uint64(7), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint64, days []time.Time) ([]domain.APIUsage, error) {
+				require.Len(t, days, 3)
+				// 23:30 WIB masih tanggal 10 di UTC
+				assert.Equal(t, "2025-03-08", days[0].Format(time.DateOnly))
+				assert.Equal(t, "2025-03-10", days[2].Format(time.DateOnly))
+				return []domain.APIUsage{{Day: days[0]}, {Day: days[1], Allowed: 4}, {Day: days[2], Allowed: 9, Throttled: 1}}, nil
+			})
+
+		usage, err := portalService.GetUsage(context.Background(), 7, 3, now)
+
+		require.NoError(t, err)
+		assert.Len(t, usage, 3)
+	})
+
+	t.Run("GetUsage - Range Out Of Bounds", func(t *testing.T) {
+		usage, err := portalService.GetUsage(context.Background(), 7, portalsrv.MaxUsageDays+1, time.Now())
+
+		assert.Nil(t, usage)
+		assert.ErrorIs(t, err, common.ErrInvalidUsageDays)
+	})
+
+	t.Run("RegenerateAPIKey - Live Key Only", func(t *testing.T) {
+		partner := &domain.Partner{
+			ID:               7,
+			Status:           domain.PartnerProduction,
+			SandboxKeyHash:   "sandbox-hash",
+			SandboxKeyPrefix: "mf_test_abcd",
+			LiveKeyHash:      "old-live-hash",
+		}
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(partner, nil)
+		partnerRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated *domain.Partner) error {
+			assert.Equal(t, "sandbox-hash", updated.SandboxKeyHash)
+			assert.NotEqual(t, "old-live-hash", updated.LiveKeyHash)
+			return nil
+		})
+
+		res, err := portalService.RegenerateAPIKey(context.Background(), 7, false)
+
+		require.NoError(t, err)
+		assert.False(t, res.Sandbox)
+		assert.Equal(t, apikey.Hash(res.APIKey), partner.LiveKeyHash)
+	})
+
+	t.Run("RegenerateAPIKey - Partner Not Found", func(t *testing.T) {
+		partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(9)).Return(nil, nil)
+
+		res, err := portalService.RegenerateAPIKey(context.Background(), 9, true)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrPartnerNotFound)
+	})
+}
@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	preferencesrv "github.com/fazamuttaqien/multifinance/internal/service/preference"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPreferenceService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-preference-service")
+
+	t.Run("GetPreferences - Defaults To Every Channel", func(t *testing.T) {
+		notificationRepository := mocks.NewMockNotificationRepository(gomock.NewController(t))
+		preferenceService := preferencesrv.NewPreferenceService(notificationRepository, meter, tracer, log)
+
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).Return(nil, nil)
+
+		preferences, err := preferenceService.GetPreferences(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.True(t, preferences.Email)
+		assert.True(t, preferences.Push)
+	})
+
+	t.Run("SetPreferences - Keeps Omitted Channel", func(t *testing.T) {
+		notificationRepository := mocks.NewMockNotificationRepository(gomock.NewController(t))
+		preferenceService := preferencesrv.NewPreferenceService(notificationRepository, meter, tracer, log)
+
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).
+			Return(&domain.NotificationPreferences{CustomerID: 2, Email: false, Push: true}, nil)
+		notificationRepository.EXPECT().SavePreferences(gomock.Any(), &domain.NotificationPreferences{CustomerID: 2, Email: false, Push: false}).Return(nil)
+
+		push := false
+		preferences, err := preferenceService.SetPreferences(context.Background(), 2, dto.NotificationPreferencesRequest{Push: &push})
+
+		require.NoError(t, err)
+		assert.False(t, preferences.Email)
+		assert.False(t, preferences.Push)
+	})
+
+	t.Run("SetPreferences - Repository Error", func(t *testing.T) {
+		notificationRepository := mocks.NewMockNotificationRepository(gomock.NewController(t))
+		preferenceService := preferencesrv.NewPreferenceService(notificationRepository, meter, tracer, log)
+
+		dbErr := errors.New("connection refused")
+		notificationRepository.EXPECT().FindPreferences(gomock.Any(), uint64(2)).Return(nil, dbErr)
+
+		_, err := preferenceService.SetPreferences(context.Background(), 2, dto.NotificationPreferencesRequest{})
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
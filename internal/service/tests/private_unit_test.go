@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	privatesrv "github.com/fazamuttaqien/multifinance/internal/service/private"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/password"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPrivateService_ChangePassword_WithMocks(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-private-service")
+	hashed, err := password.HashPassword("oldpassword1")
+	require.NoError(t, err)
+
+	req := dto.ChangePasswordRequest{CurrentPassword: "oldpassword1", NewPassword: "newpassword1", OTPToken: "token"}
+
+	t.Run("Failure - Wrong Current Password Keeps OTP", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		otpService := servicemocks.NewMockOTPServices(ctrl)
+		privateService := privatesrv.NewPrivateService(nil, "secret", customerRepository, otpService, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7, Password: hashed}, nil)
+
+		wrong := req
+		wrong.CurrentPassword = "typo-password"
+		err := privateService.ChangePassword(context.Background(), 7, wrong)
+
+		assert.ErrorIs(t, err, common.ErrInvalidCredentials)
+	})
+
+	t.Run("Failure - OTP Not Verified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		otpService := servicemocks.NewMockOTPServices(ctrl)
+		privateService := privatesrv.NewPrivateService(nil, "secret", customerRepository, otpService, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7, Password: hashed}, nil)
+		otpService.EXPECT().Consume(gomock.Any(), "token", domain.OTPChangePassword, uint64(7)).Return(nil, common.ErrOTPRequired)
+
+		err := privateService.ChangePassword(context.Background(), 7, req)
+
+		assert.ErrorIs(t, err, common.ErrOTPRequired)
+	})
+}
+
+func TestPrivateService_Login_WithMocks(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-private-service")
+	hashed, err := password.HashPassword("seed-admin-pass")
+	require.NoError(t, err)
+
+	admin := &domain.Customer{ID: 1, NIK: "0000000000000001", Role: domain.AdminRole, Password: hashed, MustChangePassword: true}
+
+	t.Run("Success - Seeded Admin Must Change Password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		privateService := privatesrv.NewPrivateService(nil, "secret", customerRepository, nil, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), admin.NIK).Return(admin, nil)
+
+		res, err := privateService.Login(context.Background(), dto.LoginRequest{NIK: admin.NIK, Password: "seed-admin-pass"})
+
+		require.NoError(t, err)
+		assert.True(t, res.MustChangePassword)
+
+		claims := &domain.JwtCustomClaims{}
+		_, err = jwt.ParseWithClaims(res.Token, claims, func(*jwt.Token) (any, error) { return []byte("secret"), nil })
+		require.NoError(t, err)
+		assert.Equal(t, domain.AdminRole, claims.Role)
+		assert.True(t, claims.MustChangePassword)
+	})
+
+	t.Run("Failure - Wrong Admin Password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		privateService := privatesrv.NewPrivateService(nil, "secret", customerRepository, nil, meter, tracer, log)
+
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), admin.NIK).Return(admin, nil)
+
+		_, err := privateService.Login(context.Background(), dto.LoginRequest{NIK: admin.NIK, Password: "admin123"})
+
+		assert.ErrorIs(t, err, common.ErrInvalidCredentials)
+	})
+
+	t.Run("Failure - Closed Account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		customerRepository := mocks.NewMockCustomerRepository(ctrl)
+		privateService := privatesrv.NewPrivateService(nil, "secret", customerRepository, nil, meter, tracer, log)
+
+		closedAt := time.Now().Add(-time.Hour)
+		closed := &domain.Customer{ID: 7, NIK: "3201010101900007", Role: domain.CustomerRole, Password: hashed, ClosedAt: &closedAt}
+		customerRepository.EXPECT().FindByNIK(gomock.Any(), closed.NIK).Return(closed, nil)
+
+		_, err := privateService.Login(context.Background(), dto.LoginRequest{NIK: closed.NIK, Password: "seed-admin-pass"})
+
+		assert.ErrorIs(t, err, common.ErrAccountClosed)
+	})
+}
@@ -2,32 +2,41 @@ package service_test
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
 	"github.com/fazamuttaqien/multifinance/internal/model"
 	"github.com/fazamuttaqien/multifinance/internal/repository"
+	blacklistrepo "github.com/fazamuttaqien/multifinance/internal/repository/blacklist"
 	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
 	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
+	referralrepo "github.com/fazamuttaqien/multifinance/internal/repository/referral"
 	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
 	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
 	"github.com/fazamuttaqien/multifinance/internal/service"
+	blacklistsrv "github.com/fazamuttaqien/multifinance/internal/service/blacklist"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
 	profilesrv "github.com/fazamuttaqien/multifinance/internal/service/profile"
+	referralsrv "github.com/fazamuttaqien/multifinance/internal/service/referral"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
 	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel/metric"
 	noop_metric "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
 	noop_trace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type ProfileServiceTestSuite struct {
@@ -40,6 +49,8 @@ type ProfileServiceTestSuite struct {
 	tenorRepository       repository.TenorRepository
 	limitRepository       repository.LimitRepository
 	transactionRepository repository.TransactionRepository
+	blacklistService      service.BlacklistServices
+	referralService       service.ReferralServices
 
 	meter  metric.Meter
 	tracer trace.Tracer
@@ -47,36 +58,7 @@ type ProfileServiceTestSuite struct {
 }
 
 func (suite *ProfileServiceTestSuite) SetupSuite() {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-	)
-	sqlDB, err := sql.Open("mysql", dsn)
-	suite.Require().NoError(err)
-	defer sqlDB.Close()
-
-	testDbName := "loan_system_test"
-
-	_, err = sqlDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", testDbName))
-	suite.Require().NoError(err)
-	_, err = sqlDB.Exec(fmt.Sprintf("CREATE DATABASE %s", testDbName))
-	suite.Require().NoError(err)
-
-	testDSN := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		common.GetEnv("MYSQL_USER", "root"),
-		common.GetEnv("MYSQL_PASSWORD", "rootpassword123"),
-		common.GetEnv("MYSQL_HOST", "127.0.0.1"),
-		common.GetEnv("MYSQL_PORT", "3306"),
-		testDbName,
-	)
-	gormDB, err := gorm.Open(mysql.Open(testDSN), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	suite.Require().NoError(err)
-
-	suite.db = gormDB
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_service_profile_test").DB
 	suite.ctx = context.Background()
 
 	suite.log = zap.NewNop()
@@ -85,33 +67,40 @@ func (suite *ProfileServiceTestSuite) SetupSuite() {
 	noopMeterProvider := noop_metric.NewMeterProvider()
 	suite.meter = noopMeterProvider.Meter("test-profile-service-meter")
 
-	err = suite.db.AutoMigrate(&model.Customer{}, &model.Tenor{}, &model.CustomerLimit{}, &model.Transaction{})
-	suite.Require().NoError(err)
+	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.log)
+	suite.tenorRepository = tenorrepo.NewTenorRepository(suite.db, suite.meter, suite.log)
+	suite.limitRepository = limitrepo.NewLimitRepository(suite.db, suite.meter, suite.log)
+	suite.transactionRepository = transactionrepo.NewTransactionRepository(suite.db, suite.meter, suite.log)
+	suite.blacklistService = blacklistsrv.NewBlacklistService(
+		blacklistrepo.NewBlacklistRepository(suite.db, suite.meter, suite.log),
+		suite.meter, suite.tracer, suite.log,
+	)
 
-	suite.customerRepository = customerrepo.NewCustomerRepository(suite.db, suite.meter, suite.tracer, suite.log)
-	suite.tenorRepository = tenorrepo.NewTenorRepository(suite.db, suite.meter, suite.tracer, suite.log)
-	suite.limitRepository = limitrepo.NewLimitRepository(suite.db, suite.meter, suite.tracer, suite.log)
-	suite.transactionRepository = transactionrepo.NewTransactionRepository(suite.db, suite.meter, suite.tracer, suite.log)
+	suite.referralService = referralsrv.NewReferralService(
+		suite.customerRepository,
+		referralrepo.NewReferralRepository(suite.db, suite.meter, suite.log),
+		referralsrv.Config{IPWindow: 24 * time.Hour, MaxPerIP: 3},
+		suite.meter, suite.tracer, suite.log,
+	)
 
-	suite.profileService = profilesrv.NewProfileService(suite.db, suite.customerRepository, suite.limitRepository, suite.tenorRepository, suite.transactionRepository, suite.meter, suite.tracer, suite.log)
-}
+	// OTP disimpan di Redis, di tes ini token berformat "CHANNEL:tujuan" dianggap
+	// sudah terverifikasi ke tujuan tersebut
+	otpService := servicemocks.NewMockOTPServices(gomock.NewController(suite.T()))
+	otpService.EXPECT().Consume(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token string, purpose domain.OTPPurpose, customerID uint64) (*domain.OTPVerification, error) {
+			channel, destination, ok := strings.Cut(token, ":")
+			if !ok {
+				return nil, common.ErrOTPRequired
+			}
+			return &domain.OTPVerification{Purpose: purpose, Channel: channel, Destination: destination, CustomerID: customerID}, nil
+		}).AnyTimes()
 
-func (suite *ProfileServiceTestSuite) TearDownSuite() {
-	if suite.db != nil {
-		sqlDB, err := suite.db.DB()
-		suite.Require().NoError(err, "Failed to get underlying sql.DB")
-		suite.Require().NoError(sqlDB.Close(), "Failed to close database connection")
-	}
+	suite.profileService = profilesrv.NewProfileService(suite.db, suite.customerRepository, suite.limitRepository, suite.tenorRepository, suite.transactionRepository, suite.blacklistService, nil, suite.referralService, otpService, testutil.DueDateRules(installment.Rules{}), suite.meter, suite.tracer, suite.log)
 }
 
 func (suite *ProfileServiceTestSuite) AfterTest(suiteName, testName string) {
 	// Dijalankan setelah setiap tes untuk membersihkan data
-	suite.db.Exec("SET FOREIGN_KEY_CHECKS = 0")
-	suite.db.Exec("TRUNCATE TABLE transactions")
-	suite.db.Exec("TRUNCATE TABLE customer_limits")
-	suite.db.Exec("TRUNCATE TABLE tenors")
-	suite.db.Exec("TRUNCATE TABLE customers")
-	suite.db.Exec("SET FOREIGN_KEY_CHECKS = 1")
+	testutil.Reset(suite.T(), suite.db)
 }
 
 func (suite *ProfileServiceTestSuite) seedCustomer() *model.Customer {
@@ -123,7 +112,7 @@ func (suite *ProfileServiceTestSuite) seedCustomer() *model.Customer {
 		Role:           "customer",
 		BirthPlace:     "Bandung",
 		BirthDate:      time.Date(1995, 5, 5, 0, 0, 0, 0, time.UTC),
-		Salary:         10000000,
+		Salary:         decimal.NewFromInt(10000000),
 		KtpPhotoUrl:    "https://example.com/ktp.jpg",
 		SelfiePhotoUrl: "https://example.com/selfie.jpg",
 	}
@@ -144,13 +133,15 @@ func (suite *ProfileServiceTestSuite) TestRegister() {
 			Role:       "customer",
 			BirthPlace: "Jakarta",
 			BirthDate:  birthDate,
-			Salary:     5000000,
+			Salary:     decimal.NewFromInt(5000000),
 			KtpUrl:     "https://example.com/ktp.jpg",
 			SelfieUrl:  "https://example.com/selfie.jpg",
+			Email:      "john@example.com",
+			Phone:      "+6281100000001",
 		}
 
 		// Act
-		customer, err := suite.profileService.Create(suite.ctx, req)
+		customer, err := suite.profileService.Create(suite.ctx, req, dto.RegistrationChecks{OTPToken: "EMAIL:john@example.com"})
 
 		// Assert
 		assert.NoError(t, err)
@@ -165,6 +156,37 @@ func (suite *ProfileServiceTestSuite) TestRegister() {
 		err = suite.db.First(&savedCustomer, "nik = ?", req.NIK).Error
 		assert.NoError(t, err)
 		assert.Equal(t, "John Smith", savedCustomer.FullName)
+		suite.Require().NotNil(savedCustomer.Email)
+		assert.Equal(t, "john@example.com", *savedCustomer.Email)
+	})
+
+	suite.T().Run("Failure - Email already registered", func(t *testing.T) {
+		req := &domain.Customer{NIK: "1234567890123457", Email: "john@example.com", Phone: "+6281100000002"}
+
+		customer, err := suite.profileService.Create(suite.ctx, req, dto.RegistrationChecks{OTPToken: "SMS:+6281100000002"})
+
+		assert.ErrorIs(t, err, common.ErrEmailExists)
+		assert.Nil(t, customer)
+	})
+
+	suite.T().Run("Failure - OTP verified another contact", func(t *testing.T) {
+		birthDate, _ := time.Parse("2006-01-02", "2000-01-01")
+		req := &domain.Customer{
+			NIK:        "1234567890123458",
+			FullName:   "Jack Smith",
+			LegalName:  "Jack Smith",
+			Password:   "jacksmith123",
+			BirthPlace: "Jakarta",
+			BirthDate:  birthDate,
+			Salary:     decimal.NewFromInt(5000000),
+			Email:      "jack@example.com",
+			Phone:      "+6281100000003",
+		}
+
+		customer, err := suite.profileService.Create(suite.ctx, req, dto.RegistrationChecks{OTPToken: "EMAIL:someone@example.com"})
+
+		assert.ErrorIs(t, err, common.ErrContactNotVerified)
+		assert.Nil(t, customer)
 	})
 
 	suite.T().Run("Failure - NIK already exists", func(t *testing.T) {
@@ -173,7 +195,7 @@ func (suite *ProfileServiceTestSuite) TestRegister() {
 		req := &domain.Customer{NIK: "1122334455667788"}
 
 		// Act
-		customer, err := suite.profileService.Create(suite.ctx, req)
+		customer, err := suite.profileService.Create(suite.ctx, req, dto.RegistrationChecks{OTPToken: "EMAIL:jane@example.com"})
 
 		// Assert
 		assert.Error(t, err)
@@ -182,6 +204,104 @@ func (suite *ProfileServiceTestSuite) TestRegister() {
 	})
 }
 
+func (suite *ProfileServiceTestSuite) TestRegister_ConcurrentSameNIK() {
+	const attempts = 8
+	nik := "3100000000000001"
+
+	var wg sync.WaitGroup
+	errs := make([]error, attempts)
+	start := make(chan struct{})
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			email := fmt.Sprintf("racer%d@example.com", i)
+			req := &domain.Customer{
+				NIK:        nik,
+				FullName:   "Racer",
+				LegalName:  "Racer",
+				Password:   "racer123",
+				BirthPlace: "Jakarta",
+				BirthDate:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				Salary:     decimal.NewFromInt(5000000),
+				Email:      email,
+				Phone:      fmt.Sprintf("+62812000000%02d", i),
+			}
+			<-start
+			_, errs[i] = suite.profileService.Create(suite.ctx, req, dto.RegistrationChecks{OTPToken: "EMAIL:" + email})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(suite.T(), err, common.ErrNIKExists)
+	}
+	assert.Equal(suite.T(), 1, succeeded)
+
+	var count int64
+	suite.db.Model(&model.Customer{}).Where("nik = ?", nik).Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func (suite *ProfileServiceTestSuite) TestRegisterWithReferral() {
+	referrer := suite.seedCustomer()
+	code, err := suite.referralService.GetMyCode(suite.ctx, referrer.ID)
+	suite.Require().NoError(err)
+
+	newCustomer := func(nik, legalName, deviceID string) *domain.Customer {
+		return &domain.Customer{
+			NIK:                  nik,
+			FullName:             legalName,
+			LegalName:            legalName,
+			Password:             "secret123",
+			BirthPlace:           "Jakarta",
+			BirthDate:            time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			Salary:               decimal.NewFromInt(5000000),
+			KtpUrl:               "https://example.com/ktp.jpg",
+			SelfieUrl:            "https://example.com/selfie.jpg",
+			Email:                nik + "@example.com",
+			RegistrationIP:       "10.0.0.1",
+			RegistrationDeviceID: deviceID,
+		}
+	}
+
+	suite.Run("Success - Accepted Referral", func() {
+		customer, err := suite.profileService.Create(suite.ctx, newCustomer("2000000000000001", "Budi", "device-1"), dto.RegistrationChecks{ReferralCode: code.Code, OTPToken: "EMAIL:2000000000000001@example.com"})
+		suite.Require().NoError(err)
+
+		var referral model.Referral
+		suite.Require().NoError(suite.db.First(&referral, "referee_id = ?", customer.ID).Error)
+		assert.Equal(suite.T(), referrer.ID, referral.ReferrerID)
+		assert.Equal(suite.T(), model.ReferralAccepted, referral.Status)
+	})
+
+	suite.Run("Rejected - Same Device", func() {
+		customer, err := suite.profileService.Create(suite.ctx, newCustomer("2000000000000002", "Citra", "device-1"), dto.RegistrationChecks{ReferralCode: strings.ToLower(code.Code), OTPToken: "EMAIL:2000000000000002@example.com"})
+		suite.Require().NoError(err)
+
+		var referral model.Referral
+		suite.Require().NoError(suite.db.First(&referral, "referee_id = ?", customer.ID).Error)
+		assert.Equal(suite.T(), model.ReferralRejected, referral.Status)
+		assert.Equal(suite.T(), referralsrv.RejectDuplicateDevice, referral.RejectReason)
+	})
+
+	suite.Run("Failure - Unknown Code", func() {
+		customer, err := suite.profileService.Create(suite.ctx, newCustomer("2000000000000003", "Dewi", "device-3"), dto.RegistrationChecks{ReferralCode: "NOPE1234", OTPToken: "EMAIL:2000000000000003@example.com"})
+		assert.ErrorIs(suite.T(), err, common.ErrReferralCodeNotFound)
+		assert.Nil(suite.T(), customer)
+
+		var count int64
+		suite.db.Model(&model.Customer{}).Where("nik = ?", "2000000000000003").Count(&count)
+		assert.Zero(suite.T(), count)
+	})
+}
+
 func (suite *ProfileServiceTestSuite) TestGetMyLimits() {
 	suite.T().Run("Success - Returns all limits with correct calculation", func(t *testing.T) {
 		// Arrange
@@ -195,20 +315,20 @@ func (suite *ProfileServiceTestSuite) TestGetMyLimits() {
 		suite.db.Create(&model.CustomerLimit{
 			CustomerID:  customer.ID,
 			TenorID:     tenor3.ID,
-			LimitAmount: 1000,
+			LimitAmount: decimal.NewFromInt(1000),
 		})
 
 		suite.db.Create(&model.CustomerLimit{
 			CustomerID:  customer.ID,
 			TenorID:     tenor6.ID,
-			LimitAmount: 5000,
+			LimitAmount: decimal.NewFromInt(5000),
 		})
 
 		suite.db.Create(&model.Transaction{
 			CustomerID: customer.ID,
 			TenorID:    tenor3.ID,
-			OTRAmount:  250,
-			AdminFee:   0,
+			OTRAmount:  decimal.NewFromInt(250),
+			AdminFee:   decimal.NewFromInt(0),
 			Status:     model.TransactionActive,
 		})
 
@@ -224,14 +344,14 @@ func (suite *ProfileServiceTestSuite) TestGetMyLimits() {
 		})
 
 		assert.Equal(t, uint8(3), limits[0].TenorMonths)
-		assert.Equal(t, float64(1000), limits[0].LimitAmount)
-		assert.Equal(t, float64(250), limits[0].UsedAmount)
-		assert.Equal(t, float64(750), limits[0].RemainingLimit)
+		assert.Equal(t, "1000", limits[0].LimitAmount.String())
+		assert.Equal(t, "250", limits[0].UsedAmount.String())
+		assert.Equal(t, "750", limits[0].RemainingLimit.String())
 
 		assert.Equal(t, uint8(6), limits[1].TenorMonths)
-		assert.Equal(t, float64(5000), limits[1].LimitAmount)
-		assert.Equal(t, float64(0), limits[1].UsedAmount)
-		assert.Equal(t, float64(5000), limits[1].RemainingLimit)
+		assert.Equal(t, "5000", limits[1].LimitAmount.String())
+		assert.Equal(t, "0", limits[1].UsedAmount.String())
+		assert.Equal(t, "5000", limits[1].RemainingLimit.String())
 	})
 }
 
@@ -249,7 +369,7 @@ func (suite *ProfileServiceTestSuite) TestGetMyTransactions() {
 				TenorID:        tenor.ID,
 				ContractNumber: contractNumber,
 				AssetName:      fmt.Sprintf("Asset %d", i+1),
-				OTRAmount:      float64(100 * (i + 1)),
+				OTRAmount:      decimal.NewFromInt(int64(100 * (i + 1))),
 				Status:         model.TransactionActive,
 			}
 			err := suite.db.Create(tx).Error
@@ -273,33 +393,110 @@ func (suite *ProfileServiceTestSuite) TestGetMyTransactions() {
 }
 
 func (suite *ProfileServiceTestSuite) TestUpdateProfile() {
+	// NIK unik, satu customer dipakai bergantian oleh setiap skenario
+	customer := suite.seedCustomer()
+
 	suite.T().Run("Success - Update customer profile", func(t *testing.T) {
 		// Arrange
-		customer := suite.seedCustomer()
 		req := domain.Customer{
 			FullName: "New Full Name",
-			Salary:   15000000,
+			Salary:   customer.Salary,
+			Version:  1,
 		}
 
 		// Act
-		err := suite.profileService.Update(suite.ctx, customer.ID, req)
+		err := suite.profileService.Update(suite.ctx, customer.ID, req, dto.ContactChecks{})
 
 		// Assert
 		assert.NoError(t, err)
 		var updatedCustomer model.Customer
 		suite.db.First(&updatedCustomer, customer.ID)
 		assert.Equal(t, "New Full Name", updatedCustomer.FullName)
-		assert.Equal(t, float64(15000000), updatedCustomer.Salary)
+		assert.Equal(t, customer.Salary, updatedCustomer.Salary)
 		assert.Equal(t, customer.LegalName, updatedCustomer.LegalName)
 	})
 
+	suite.T().Run("Success - Salary omitted keeps current salary", func(t *testing.T) {
+		// Act
+		err := suite.profileService.Update(suite.ctx, customer.ID, domain.Customer{FullName: "Name Only", Version: 2}, dto.ContactChecks{})
+
+		// Assert
+		assert.NoError(t, err)
+		var updatedCustomer model.Customer
+		suite.db.First(&updatedCustomer, customer.ID)
+		assert.Equal(t, "Name Only", updatedCustomer.FullName)
+		assert.Equal(t, customer.Salary, updatedCustomer.Salary)
+		assert.Equal(t, uint64(3), updatedCustomer.Version)
+	})
+
+	suite.T().Run("Failure - Stale version", func(t *testing.T) {
+		// Versi 2 sudah ditimpa oleh skenario sebelumnya
+		err := suite.profileService.Update(suite.ctx, customer.ID, domain.Customer{FullName: "Overwritten", Version: 2}, dto.ContactChecks{})
+
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+		var unchanged model.Customer
+		suite.db.First(&unchanged, customer.ID)
+		assert.Equal(t, "Name Only", unchanged.FullName)
+		assert.Equal(t, uint64(3), unchanged.Version)
+	})
+
+	suite.T().Run("Failure - Salary change requires proof", func(t *testing.T) {
+		// Arrange
+		req := domain.Customer{
+			FullName: "Another Name",
+			Salary:   customer.Salary.Add(decimal.NewFromInt(5000000)),
+			Version:  3,
+		}
+
+		// Act
+		err := suite.profileService.Update(suite.ctx, customer.ID, req, dto.ContactChecks{})
+
+		// Assert
+		assert.ErrorIs(t, err, common.ErrSalaryNeedsProof)
+		var unchanged model.Customer
+		suite.db.First(&unchanged, customer.ID)
+		assert.Equal(t, "Name Only", unchanged.FullName)
+		assert.Equal(t, customer.Salary, unchanged.Salary)
+	})
+
+	suite.T().Run("Failure - New email without OTP", func(t *testing.T) {
+		req := domain.Customer{FullName: "Name Only", Email: "jane.new@example.com", Version: 3}
+
+		err := suite.profileService.Update(suite.ctx, customer.ID, req, dto.ContactChecks{})
+
+		assert.ErrorIs(t, err, common.ErrOTPRequired)
+		var unchanged model.Customer
+		suite.db.First(&unchanged, customer.ID)
+		assert.Nil(t, unchanged.Email)
+	})
+
+	suite.T().Run("Failure - OTP verified another email", func(t *testing.T) {
+		req := domain.Customer{FullName: "Name Only", Email: "jane.new@example.com", Version: 3}
+
+		err := suite.profileService.Update(suite.ctx, customer.ID, req, dto.ContactChecks{EmailOTPToken: "EMAIL:other@example.com"})
+
+		assert.ErrorIs(t, err, common.ErrContactNotVerified)
+	})
+
+	suite.T().Run("Success - New email confirmed by OTP", func(t *testing.T) {
+		req := domain.Customer{FullName: "Name Only", Email: "jane.new@example.com", Version: 3}
+
+		err := suite.profileService.Update(suite.ctx, customer.ID, req, dto.ContactChecks{EmailOTPToken: "EMAIL:jane.new@example.com"})
+
+		assert.NoError(t, err)
+		var updated model.Customer
+		suite.db.First(&updated, customer.ID)
+		suite.Require().NotNil(updated.Email)
+		assert.Equal(t, "jane.new@example.com", *updated.Email)
+	})
+
 	suite.T().Run("Failure - Customer not found", func(t *testing.T) {
 		// Arrange
 		nonExistentID := uint64(999)
 		req := domain.Customer{FullName: "New Name"}
 
 		// Act
-		err := suite.profileService.Update(suite.ctx, nonExistentID, req)
+		err := suite.profileService.Update(suite.ctx, nonExistentID, req, dto.ContactChecks{})
 
 		// Assert
 		assert.Error(t, err)
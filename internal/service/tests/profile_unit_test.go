@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	profilesrv "github.com/fazamuttaqien/multifinance/internal/service/profile"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProfileService_GetMyTransactionDetail_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tenorRepository := mocks.NewMockTenorRepository(ctrl)
+	transactionRepository := mocks.NewMockTransactionRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-profile-service-unit")
+	profileService := profilesrv.NewProfileService(nil, nil, nil, tenorRepository, transactionRepository, nil, nil, nil, nil, testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	contractDate := time.Now().AddDate(0, -2, -1)
+	transaction := &domain.Transaction{
+		ID:                     10,
+		ContractNumber:         "KTR-001",
+		CustomerID:             2,
+		TenorID:                2,
+		AssetName:              "Honda Beat",
+		OTRAmount:              decimal.NewFromInt(10_000_000),
+		AdminFee:               decimal.NewFromInt(200_000),
+		TotalInterest:          decimal.NewFromInt(1_200_000),
+		TotalInstallmentAmount: decimal.NewFromInt(11_400_000),
+		Status:                 domain.TransactionActive,
+		TransactionDate:        contractDate,
+	}
+
+	t.Run("Active Contract", func(t *testing.T) {
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-001").Return(transaction, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{
+			{ID: 1, DurationMonths: 3, Description: "3 Months"},
+			{ID: 2, DurationMonths: 6, Description: "6 Months"},
+		}, nil)
+
+		detail, err := profileService.GetMyTransactionDetail(context.Background(), 2, "KTR-001")
+
+		require.NoError(t, err)
+		assert.Equal(t, "6 Months", detail.TenorDescription)
+		assert.Equal(t, "1900000", detail.MonthlyInstallment.String())
+		assert.Equal(t, "3800000", detail.TotalPaid.String())
+		assert.Equal(t, "7600000", detail.OutstandingBalance.String())
+		assert.Zero(t, detail.Penalty)
+		require.Len(t, detail.Installments, 6)
+		assert.Equal(t, "PAID", detail.Installments[1].Status)
+		assert.Equal(t, "UPCOMING", detail.Installments[2].Status)
+		require.NotNil(t, detail.NextDueDate)
+		assert.True(t, detail.NextDueDate.Equal(contractDate.AddDate(0, 3, 0)))
+	})
+
+	t.Run("Virtual Account", func(t *testing.T) {
+		number := "8808800000000010"
+		withAccount := *transaction
+		withAccount.VirtualAccountBank = "BCA"
+		withAccount.VirtualAccountNumber = &number
+
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-001").Return(&withAccount, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{{ID: 2, DurationMonths: 6}}, nil)
+
+		detail, err := profileService.GetMyTransactionDetail(context.Background(), 2, "KTR-001")
+
+		require.NoError(t, err)
+		assert.Equal(t, "BCA", detail.VirtualAccountBank)
+		assert.Equal(t, number, detail.VirtualAccountNumber)
+	})
+
+	t.Run("Other Customer's Contract", func(t *testing.T) {
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-001").Return(transaction, nil)
+
+		detail, err := profileService.GetMyTransactionDetail(context.Background(), 3, "KTR-001")
+
+		assert.Nil(t, detail)
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+	})
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	promotionsrv "github.com/fazamuttaqien/multifinance/internal/service/promotion"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPromotionService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-promotion-service")
+
+	validFrom := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	req := dto.PromotionRequest{
+		Target:         string(domain.PromotionAdminFee),
+		DiscountType:   string(domain.DiscountPercent),
+		DiscountValue:  decimal.RequireFromString("0.5"),
+		ValidFrom:      validFrom,
+		ValidUntil:     validFrom.AddDate(0, 1, 0),
+		MaxPerCustomer: 1,
+		Active:         true,
+	}
+
+	t.Run("Set Promotion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		promotionRepository := mocks.NewMockPromotionRepository(ctrl)
+		promotionService := promotionsrv.NewPromotionService(mocks.NewMockPartnerRepository(ctrl), promotionRepository, meter, tracer, log)
+
+		promotionRepository.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, promotion *domain.Promotion) error {
+				assert.Equal(t, "HEMAT50", promotion.Code)
+				assert.Equal(t, domain.PromotionAdminFee, promotion.Target)
+				assert.Equal(t, 1, promotion.MaxPerCustomer)
+				assert.Equal(t, uint64(1), promotion.UpdatedBy)
+				return nil
+			})
+
+		promotion, err := promotionService.SetPromotion(context.Background(), "hemat50", 1, req)
+
+		require.NoError(t, err)
+		assert.True(t, promotion.Active)
+	})
+
+	t.Run("Set Promotion With Invalid Code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		promotionService := promotionsrv.NewPromotionService(mocks.NewMockPartnerRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), meter, tracer, log)
+
+		promotion, err := promotionService.SetPromotion(context.Background(), "hemat-50", 1, req)
+
+		assert.ErrorIs(t, err, common.ErrInvalidPromoCode)
+		assert.Nil(t, promotion)
+	})
+
+	t.Run("Set Promotion Above Hundred Percent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		promotionService := promotionsrv.NewPromotionService(mocks.NewMockPartnerRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), meter, tracer, log)
+
+		invalid := req
+		invalid.DiscountValue = decimal.NewFromInt(50)
+
+		_, err := promotionService.SetPromotion(context.Background(), "HEMAT50", 1, invalid)
+
+		assert.ErrorIs(t, err, common.ErrInvalidPromotion)
+	})
+
+	t.Run("Set Promotion For Unknown Partner", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		partnerRepository := mocks.NewMockPartnerRepository(ctrl)
+		promotionService := promotionsrv.NewPromotionService(partnerRepository, mocks.NewMockPromotionRepository(ctrl), meter, tracer, log)
+
+		partnerID := uint64(99)
+		scoped := req
+		scoped.PartnerID = &partnerID
+		partnerRepository.EXPECT().FindByID(gomock.Any(), partnerID).Return(nil, nil)
+
+		_, err := promotionService.SetPromotion(context.Background(), "HEMAT50", 1, scoped)
+
+		assert.ErrorIs(t, err, common.ErrPartnerNotFound)
+	})
+
+	t.Run("Deactivate Unknown Promotion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		promotionRepository := mocks.NewMockPromotionRepository(ctrl)
+		promotionService := promotionsrv.NewPromotionService(mocks.NewMockPartnerRepository(ctrl), promotionRepository, meter, tracer, log)
+
+		promotionRepository.EXPECT().Deactivate(gomock.Any(), "HEMAT50").Return(false, nil)
+
+		err := promotionService.DeactivatePromotion(context.Background(), "hemat50")
+
+		assert.ErrorIs(t, err, common.ErrPromotionNotFound)
+	})
+}
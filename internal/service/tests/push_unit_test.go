@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	notifiersrv "github.com/fazamuttaqien/multifinance/internal/service/notifier"
+	pushsrv "github.com/fazamuttaqien/multifinance/internal/service/push"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/push"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingPushSender mencatat pesan push yang dikirim dan bisa dibuat gagal
+type recordingPushSender struct {
+	sent []push.Message
+	err  error
+}
+
+func (s *recordingPushSender) Provider() string { return "test" }
+
+func (s *recordingPushSender) Send(_ context.Context, msg push.Message) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.sent = append(s.sent, msg)
+	return "msg-" + msg.Token, nil
+}
+
+func TestPushService_WithMockRepository(t *testing.T) {
+	templates, err := notifiersrv.LoadPushTemplates()
+	require.NoError(t, err)
+	meter, tracer, log := testutil.Telemetry("test-push-service")
+
+	setup := func(t *testing.T) (*mocks.MockNotificationRepository, *mocks.MockCustomerRepository) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockNotificationRepository(ctrl), mocks.NewMockCustomerRepository(ctrl)
+	}
+
+	t.Run("RegisterDevice - Success", func(t *testing.T) {
+		notificationRepository, customerRepository := setup(t)
+		pushService := pushsrv.NewPushService(notificationRepository, customerRepository, templates, push.NewStubSender(), meter, tracer, log)
+
+		notificationRepository.EXPECT().UpsertDevice(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, device *domain.Device) error {
+			assert.Equal(t, uint64(2), device.CustomerID)
+			assert.Equal(t, domain.PlatformAndroid, device.Platform)
+			assert.False(t, device.LastSeenAt.IsZero())
+			device.ID = 8
+			return nil
+		})
+
+		device, err := pushService.RegisterDevice(context.Background(), 2, dto.DeviceRequest{Token: "fcm-token", Platform: "ANDROID"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), device.ID)
+	})
+
+	t.Run("UnregisterDevice - Not Found", func(t *testing.T) {
+		notificationRepository, customerRepository := setup(t)
+		pushService := pushsrv.NewPushService(notificationRepository, customerRepository, templates, push.NewStubSender(), meter, tracer, log)
+
+		notificationRepository.EXPECT().DeleteDevice(gomock.Any(), uint64(2), "unknown").Return(false, nil)
+
+		err := pushService.UnregisterDevice(context.Background(), 2, "unknown")
+
+		assert.ErrorIs(t, err, common.ErrDeviceNotFound)
+	})
+
+	t.Run("Notify - Template Not Pushed", func(t *testing.T) {
+		notificationRepository, customerRepository := setup(t)
+		pushService := pushsrv.NewPushService(notificationRepository, customerRepository, templates, push.NewStubSender(), meter, tracer, log)
+
+		err := pushService.Notify(context.Background(), 2, domain.TemplateMonthlyStatement, nil)
+
+		assert.ErrorIs(t, err, common.ErrNotificationSkipped)
+	})
+
+	t.Run("Notify - No Registered Device", func(t *testing.T) {
+		notificationRepository, customerRepository := setup(t)
+		pushService := pushsrv.NewPushService(notificationRepository, customerRepository, templates, push.NewStubSender(), meter, tracer, log)
+
+		notificationRepository.EXPECT().FindDevices(gomock.Any(), uint64(2)).Return(nil, nil)
+
+		err := pushService.Notify(context.Background(), 2, domain.TemplateVerificationApproved, nil)
+
+		assert.ErrorIs(t, err, common.ErrNotificationSkipped)
+	})
+
+	t.Run("Notify - Sends To Every Device In Customer Language", func(t *testing.T) {
+		notificationRepository, customerRepository := setup(t)
+		sender := &recordingPushSender{}
+		pushService := pushsrv.NewPushService(notificationRepository, customerRepository, templates, sender, meter, tracer, log)
+
+		notificationRepository.EXPECT().FindDevices(gomock.Any(), uint64(2)).
+			Return([]domain.Device{{ID: 1, Token: "phone"}, {ID: 2, Token: "tablet"}}, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).
+			Return(&domain.Customer{ID: 2, Language: domain.LanguageEnglish}, nil)
+
+		err := pushService.Notify(context.Background(), 2, domain.TemplatePaymentReminder, map[string]string{
+			"full_name":       "Budi",
+			"contract_number": "KTR-001",
+			"installment":     "2",
+			"due_date":        "2025-03-10",
+			"amount":          "IDR 100,000.00",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, sender.sent, 2)
+		assert.Equal(t, "Installment due on 2025-03-10", sender.sent[0].Title)
+		assert.Equal(t, "Installment 2 of KTR-001 for IDR 100,000.00 is due on 2025-03-10.", sender.sent[0].Body)
+		assert.Equal(t, map[string]string{"template": "payment_reminder", "contract_number": "KTR-001"}, sender.sent[0].Data)
+	})
+
+	t.Run("Notify - Invalid Token Removed", func(t *testing.T) {
+		notificationRepository, customerRepository := setup(t)
+		pushService := pushsrv.NewPushService(notificationRepository, customerRepository, templates, push.NewStubSender(), meter, tracer, log)
+
+		notificationRepository.EXPECT().FindDevices(gomock.Any(), uint64(2)).
+			Return([]domain.Device{{ID: 1, Token: "invalid-old-phone"}, {ID: 2, Token: "new-phone"}}, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2}, nil)
+		notificationRepository.EXPECT().DeleteDeviceByToken(gomock.Any(), "invalid-old-phone").Return(nil)
+
+		err := pushService.Notify(context.Background(), 2, domain.TemplateVerificationRejected, map[string]string{"reason": "KTP buram"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Notify - Every Device Failed", func(t *testing.T) {
+		notificationRepository, customerRepository := setup(t)
+		sendErr := errors.New("fcm unavailable")
+		pushService := pushsrv.NewPushService(notificationRepository, customerRepository, templates, &recordingPushSender{err: sendErr}, meter, tracer, log)
+
+		notificationRepository.EXPECT().FindDevices(gomock.Any(), uint64(2)).Return([]domain.Device{{ID: 1, Token: "phone"}}, nil)
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2}, nil)
+
+		err := pushService.Notify(context.Background(), 2, domain.TemplateVerificationApproved, nil)
+
+		assert.ErrorIs(t, err, sendErr)
+	})
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	quotasrv "github.com/fazamuttaqien/multifinance/internal/service/quota"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type quotaMocks struct {
+	partnerRepository *mocks.MockPartnerRepository
+	quotaRepository   *mocks.MockPartnerQuotaRepository
+	counterRepository *mocks.MockQuotaCounterRepository
+}
+
+func newQuotaService(t *testing.T) (*quotaMocks, service.PartnerQuotaServices) {
+	meter, tracer, log := testutil.Telemetry("test-quota-service")
+
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	m := &quotaMocks{
+		partnerRepository: mocks.NewMockPartnerRepository(ctrl),
+		quotaRepository:   mocks.NewMockPartnerQuotaRepository(ctrl),
+		counterRepository: mocks.NewMockQuotaCounterRepository(ctrl),
+	}
+	cfg := quotasrv.Config{Location: jakarta, AlertPercent: 80}
+	return m, quotasrv.NewQuotaService(m.partnerRepository, m.quotaRepository, m.counterRepository, cfg, meter, tracer, log)
+}
+
+func TestQuotaService_Reserve(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	// 23:30 WIB masih tanggal 10, walaupun di UTC sudah lewat 16:00
+	now := time.Date(2025, 3, 10, 23, 30, 0, 0, jakarta)
+	midnight := time.Date(2025, 3, 11, 0, 0, 0, 0, jakarta)
+	quota := &domain.PartnerQuota{PartnerID: 7, DailyCount: 10, DailyVolume: decimal.NewFromInt(1000000)}
+
+	t.Run("Success - Reserved", func(t *testing.T) {
+		m, quotaService := newQuotaService(t)
+
+		m.quotaRepository.EXPECT().FindByPartner(gomock.Any(), uint64(7)).Return(quota, nil)
+		m.counterRepository.EXPECT().Reserve(gomock.Any(), *quota, "20250310", gomock.Any(), midnight).
+			Return(&domain.QuotaUsage{Count: 8, Volume: decimal.NewFromInt(850000)}, true, nil)
+
+		reservation, err := quotaService.Reserve(context.Background(), 7, decimal.NewFromInt(250000), now)
+
+		require.NoError(t, err)
+		require.NotNil(t, reservation)
+		assert.Equal(t, "20250310", reservation.Day)
+		assert.True(t, reservation.Volume.Equal(decimal.NewFromInt(250000)))
+	})
+
+	t.Run("Success - No Quota Configured", func(t *testing.T) {
+		m, quotaService := newQuotaService(t)
+
+		m.quotaRepository.EXPECT().FindByPartner(gomock.Any(), uint64(7)).Return(nil, nil)
+
+		reservation, err := quotaService.Reserve(context.Background(), 7, decimal.NewFromInt(250000), now)
+
+		require.NoError(t, err)
+		assert.Nil(t, reservation)
+	})
+
+	t.Run("Failure - Count Exceeded", func(t *testing.T) {
+		m, quotaService := newQuotaService(t)
+
+		m.quotaRepository.EXPECT().FindByPartner(gomock.Any(), uint64(7)).Return(quota, nil)
+		m.counterRepository.EXPECT().Reserve(gomock.Any(), *quota, "20250310", gomock.Any(), midnight).
+			Return(&domain.QuotaUsage{Count: 10, Volume: decimal.NewFromInt(500000)}, false, nil)
+
+		reservation, err := quotaService.Reserve(context.Background(), 7, decimal.NewFromInt(1000), now)
+
+		assert.Nil(t, reservation)
+		assert.ErrorIs(t, err, common.ErrDailyCountQuotaExceeded)
+	})
+
+	t.Run("Failure - Volume Exceeded", func(t *testing.T) {
+		m, quotaService := newQuotaService(t)
+
+		m.quotaRepository.EXPECT().FindByPartner(gomock.Any(), uint64(7)).Return(quota, nil)
+		m.counterRepository.EXPECT().Reserve(gomock.Any(), *quota, "20250310", gomock.Any(), midnight).
+			Return(&domain.QuotaUsage{Count: 3, Volume: decimal.NewFromInt(900000)}, false, nil)
+
+		reservation, err := quotaService.Reserve(context.Background(), 7, decimal.NewFromInt(200000), now)
+
+		assert.Nil(t, reservation)
+		assert.ErrorIs(t, err, common.ErrDailyVolumeQuotaExceeded)
+	})
+}
+
+func TestQuotaService_Release(t *testing.T) {
+	t.Run("Success - Nil Reservation", func(t *testing.T) {
+		_, quotaService := newQuotaService(t)
+
+		assert.NoError(t, quotaService.Release(context.Background(), nil))
+	})
+
+	t.Run("Success - Released", func(t *testing.T) {
+		m, quotaService := newQuotaService(t)
+
+		reservation := &domain.QuotaReservation{PartnerID: 7, Day: "20250310", Volume: decimal.NewFromInt(250000)}
+		m.counterRepository.EXPECT().Release(gomock.Any(), uint64(7), "20250310", reservation.Volume).Return(nil)
+
+		assert.NoError(t, quotaService.Release(context.Background(), reservation))
+	})
+}
+
+func TestQuotaService_SetQuota(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m, quotaService := newQuotaService(t)
+
+		m.partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Partner{ID: 7}, nil)
+		m.quotaRepository.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil)
+
+		quota, err := quotaService.SetQuota(context.Background(), 7, 1, dto.PartnerQuotaRequest{DailyCount: 50, DailyVolume: decimal.NewFromInt(5000000)})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(50), quota.DailyCount)
+		assert.Equal(t, uint64(1), quota.UpdatedBy)
+	})
+
+	t.Run("Failure - Partner Not Found", func(t *testing.T) {
+		m, quotaService := newQuotaService(t)
+
+		m.partnerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(nil, nil)
+
+		quota, err := quotaService.SetQuota(context.Background(), 7, 1, dto.PartnerQuotaRequest{DailyCount: 50})
+
+		assert.Nil(t, quota)
+		assert.ErrorIs(t, err, common.ErrPartnerNotFound)
+	})
+}
+
+func TestQuotaService_DeleteQuota(t *testing.T) {
+	m, quotaService := newQuotaService(t)
+
+	m.quotaRepository.EXPECT().Delete(gomock.Any(), uint64(7)).Return(false, nil)
+
+	assert.ErrorIs(t, quotaService.DeleteQuota(context.Background(), 7), common.ErrPartnerQuotaNotFound)
+}
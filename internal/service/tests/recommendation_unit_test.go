@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	recommendationsrv "github.com/fazamuttaqien/multifinance/internal/service/recommendation"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecommendationService_Recommend_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	tenorRepository := mocks.NewMockTenorRepository(ctrl)
+	limitRepository := mocks.NewMockLimitRepository(ctrl)
+	riskTierRepository := mocks.NewMockRiskTierRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-recommendation-service-unit")
+	recommendationService := recommendationsrv.NewRecommendationService(customerRepository, tenorRepository, limitRepository, riskTierRepository, recommendationsrv.DefaultRules(), meter, tracer, log)
+
+	t.Run("Success - Capped By Tier", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(&domain.Customer{ID: 1, Salary: decimal.NewFromInt(10_000_000)}, nil)
+		riskTierRepository.EXPECT().FindByCustomerID(gomock.Any(), uint64(1)).Return(nil, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{
+			{ID: 3, DurationMonths: 24},
+			{ID: 1, DurationMonths: 3},
+		}, nil)
+		limitRepository.EXPECT().FindAllByCustomerID(gomock.Any(), uint64(1), gomock.Any()).Return([]domain.CustomerLimit{
+			{CustomerID: 1, TenorID: 3, LimitAmount: decimal.NewFromInt(40_000_000), Version: 4},
+		}, nil)
+
+		res, err := recommendationService.Recommend(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "MEDIUM", res.RiskTier)
+		require.Len(t, res.Limits, 2)
+
+		// 10jt x 30% x 3 bulan = 9jt, di bawah plafon tier MEDIUM
+		assert.Equal(t, uint8(3), res.Limits[0].TenorMonths)
+		assert.Equal(t, "9000000", res.Limits[0].LimitAmount.String())
+		assert.False(t, res.Limits[0].Capped)
+		assert.Equal(t, uint64(0), res.Limits[0].Version)
+
+		// 10jt x 30% x 24 bulan = 72jt, dibatasi 50jt
+		assert.Equal(t, uint8(24), res.Limits[1].TenorMonths)
+		assert.Equal(t, "50000000", res.Limits[1].LimitAmount.String())
+		assert.True(t, res.Limits[1].Capped)
+		assert.Equal(t, uint64(4), res.Limits[1].Version)
+	})
+
+	t.Run("Success - Assigned Tier Overrides Salary Band", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2, Salary: decimal.NewFromInt(10_000_000)}, nil)
+		riskTierRepository.EXPECT().FindByCustomerID(gomock.Any(), uint64(2)).Return(&domain.CustomerRiskTier{CustomerID: 2, Tier: domain.RiskTierLow}, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{{ID: 1, DurationMonths: 12}}, nil)
+		limitRepository.EXPECT().FindAllByCustomerID(gomock.Any(), uint64(2), gomock.Any()).Return(nil, nil)
+
+		res, err := recommendationService.Recommend(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, "LOW", res.RiskTier)
+		// 10jt x 30% x 12 bulan = 36jt, dibatasi plafon tier LOW 10jt
+		require.Len(t, res.Limits, 1)
+		assert.Equal(t, "10000000", res.Limits[0].LimitAmount.String())
+		assert.True(t, res.Limits[0].Capped)
+	})
+
+	t.Run("Success - Unmatched Assignment Falls Back To Salary Band", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3, Salary: decimal.NewFromInt(25_000_000)}, nil)
+		riskTierRepository.EXPECT().FindByCustomerID(gomock.Any(), uint64(3)).Return(&domain.CustomerRiskTier{CustomerID: 3}, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(nil, nil)
+		limitRepository.EXPECT().FindAllByCustomerID(gomock.Any(), uint64(3), gomock.Any()).Return(nil, nil)
+
+		res, err := recommendationService.Recommend(context.Background(), 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, "HIGH", res.RiskTier)
+	})
+
+	t.Run("Failure - Customer Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		res, err := recommendationService.Recommend(context.Background(), 99)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
+
+func TestRecommendationRules_ParseTiers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tiers, err := recommendationsrv.ParseTiers("low:0:5000000, HIGH:15000000:90000000")
+
+		require.NoError(t, err)
+		assert.Equal(t, []recommendationsrv.Tier{
+			{Name: "LOW", MinSalary: decimal.NewFromInt(0), MaxLimit: decimal.NewFromInt(5_000_000)},
+			{Name: "HIGH", MinSalary: decimal.NewFromInt(15_000_000), MaxLimit: decimal.NewFromInt(90_000_000)},
+		}, tiers)
+	})
+
+	t.Run("Failure - Malformed Entry", func(t *testing.T) {
+		_, err := recommendationsrv.ParseTiers("LOW:0")
+		assert.Error(t, err)
+	})
+
+	t.Run("Failure - Empty", func(t *testing.T) {
+		_, err := recommendationsrv.ParseTiers(" ")
+		assert.Error(t, err)
+	})
+}
+
+func TestRecommendationRules_TierName(t *testing.T) {
+	rules := recommendationsrv.DefaultRules()
+
+	assert.Equal(t, "LOW", rules.TierName(decimal.NewFromInt(5_000_000)))
+	assert.Equal(t, "MEDIUM", rules.TierName(decimal.NewFromInt(8_000_000)))
+	assert.Equal(t, "HIGH", rules.TierName(decimal.NewFromInt(25_000_000)))
+	assert.Equal(t, "", recommendationsrv.Rules{}.TierName(decimal.NewFromInt(5_000_000)))
+}
@@ -0,0 +1,184 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	referencedatasrv "github.com/fazamuttaqien/multifinance/internal/service/referencedata"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/refdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func writeReferenceFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestLoadReferenceData(t *testing.T) {
+	t.Run("YAML And JSON", func(t *testing.T) {
+		dir := writeReferenceFiles(t, map[string]string{
+			"asset_categories.json": `[{"code": "Motor", "name": "Sepeda Motor"}]`,
+			"regions.yaml":          "- code: jkt\n  name: Jakarta\n- code: BDG\n  name: Bandung\n  active: false\n",
+			"tenors.yml":            "- duration_months: 6\n  min_amount: 500000\n  max_amount: \"15000000\"\n  asset_categories: [MOTOR]\n",
+			"holidays.yaml":         "- date: 2025-12-25\n  name: Natal\n",
+		})
+
+		data, err := refdata.Load(dir)
+
+		require.NoError(t, err)
+		assert.Equal(t, "motor", data.AssetCategories[0].Code)
+		assert.Equal(t, "JKT", data.Regions[0].Code)
+		require.NotNil(t, data.Regions[1].Active)
+		assert.False(t, *data.Regions[1].Active)
+		assert.Equal(t, "15000000", data.Tenors[0].MaxAmount.String())
+		assert.Equal(t, []string{"motor"}, data.Tenors[0].AssetCategories)
+		assert.Equal(t, "2025-12-25", data.Holidays[0].Date)
+	})
+
+	t.Run("Shipped Reference Data", func(t *testing.T) {
+		data, err := refdata.Load(filepath.Join("..", "..", "..", "reference"))
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, data.Tenors)
+	})
+
+	t.Run("Missing Files Are Not Managed", func(t *testing.T) {
+		data, err := refdata.Load(writeReferenceFiles(t, map[string]string{
+			"holidays.yaml": "- date: 2025-01-01\n  name: Tahun Baru\n",
+		}))
+
+		require.NoError(t, err)
+		assert.Empty(t, data.Tenors)
+		assert.Len(t, data.Holidays, 1)
+		assert.False(t, data.Empty())
+	})
+
+	t.Run("Empty Dir Disables Loading", func(t *testing.T) {
+		data, err := refdata.Load("")
+
+		require.NoError(t, err)
+		assert.True(t, data.Empty())
+	})
+
+	t.Run("Tenor Names Undeclared Category", func(t *testing.T) {
+		_, err := refdata.Load(writeReferenceFiles(t, map[string]string{
+			"asset_categories.yaml": "- code: motor\n  name: Sepeda Motor\n",
+			"tenors.yaml":           "- duration_months: 6\n  asset_categories: [mobil]\n",
+		}))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `asset category "mobil" is not listed`)
+	})
+
+	t.Run("Every Problem Reported", func(t *testing.T) {
+		_, err := refdata.Load(writeReferenceFiles(t, map[string]string{
+			"regions.yaml":  "- code: J1\n  name: Jakarta\n",
+			"tenors.yaml":   "- duration_months: 6\n- duration_months: 6\n  min_age: 30\n  max_age: 20\n",
+			"holidays.yaml": "- date: 25-12-2025\n  name: Natal\n",
+		}))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `regions[0]: code "J1"`)
+		assert.Contains(t, err.Error(), "tenors[1]: 6 months is listed twice")
+		assert.Contains(t, err.Error(), "tenors[1]: min_age is above")
+		assert.Contains(t, err.Error(), `holidays[0]: date "25-12-2025"`)
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		_, err := refdata.Load(writeReferenceFiles(t, map[string]string{
+			"tenors.yaml": "- duration_months: 6\n  max_ammount: 1000\n",
+		}))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "max_ammount")
+	})
+
+	t.Run("Same Kind Twice", func(t *testing.T) {
+		_, err := refdata.Load(writeReferenceFiles(t, map[string]string{
+			"regions.yaml": "[]",
+			"regions.json": "[]",
+		}))
+
+		assert.ErrorContains(t, err, "only one of")
+	})
+}
+
+func TestReferenceDataService_Apply(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-reference-data-service")
+
+	t.Run("Success - Writes Every Kind In Order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		assetCategoryRepository := mocks.NewMockAssetCategoryRepository(ctrl)
+		regionRepository := mocks.NewMockRegionRepository(ctrl)
+		dueDateRepository := mocks.NewMockDueDateRepository(ctrl)
+		tenorService := servicemocks.NewMockTenorServices(ctrl)
+		referenceDataService := referencedatasrv.NewReferenceDataService(assetCategoryRepository, regionRepository, dueDateRepository, tenorService, meter, tracer, log)
+
+		inactive := false
+		data := &refdata.Set{
+			AssetCategories: []refdata.AssetCategory{{Code: "motor", Name: "Sepeda Motor"}},
+			Regions: []refdata.Region{
+				{Code: "JKT", Name: "Jakarta"},
+				{Code: "BDG", Name: "Bandung", Active: &inactive},
+			},
+			Tenors:   []refdata.Tenor{{DurationMonths: 6, Description: "6 Months", AssetCategories: []string{"motor"}}},
+			Holidays: []refdata.Holiday{{Date: "2025-12-25", Name: "Natal"}},
+		}
+
+		gomock.InOrder(
+			assetCategoryRepository.EXPECT().Upsert(gomock.Any(), &domain.AssetCategory{Code: "motor", Name: "Sepeda Motor"}).Return(nil),
+			regionRepository.EXPECT().Upsert(gomock.Any(), &domain.Region{Code: "JKT", Name: "Jakarta", Active: true}).Return(nil),
+			regionRepository.EXPECT().Upsert(gomock.Any(), &domain.Region{Code: "BDG", Name: "Bandung", Active: false}).Return(nil),
+			tenorService.EXPECT().SeedTenors(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, tenors []dto.CreateTenorRequest) error {
+					require.Len(t, tenors, 1)
+					assert.Equal(t, uint8(6), tenors[0].DurationMonths)
+					assert.Equal(t, []string{"motor"}, tenors[0].AssetCategories)
+					return nil
+				}),
+			dueDateRepository.EXPECT().UpsertHoliday(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, holiday *domain.Holiday) error {
+					assert.True(t, holiday.Date.Equal(time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)))
+					assert.Equal(t, "Natal", holiday.Name)
+					return nil
+				}),
+		)
+
+		result, err := referenceDataService.Apply(context.Background(), data)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReferenceDataResult{AssetCategories: 1, Regions: 2, Tenors: 1, Holidays: 1}, *result)
+	})
+
+	t.Run("Failure - Invalid Data Writes Nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		referenceDataService := referencedatasrv.NewReferenceDataService(
+			mocks.NewMockAssetCategoryRepository(ctrl),
+			mocks.NewMockRegionRepository(ctrl),
+			mocks.NewMockDueDateRepository(ctrl),
+			servicemocks.NewMockTenorServices(ctrl),
+			meter, tracer, log,
+		)
+
+		_, err := referenceDataService.Apply(context.Background(), &refdata.Set{
+			Tenors: []refdata.Tenor{{DurationMonths: 6, AssetCategories: []string{"motor"}}},
+		})
+
+		assert.ErrorContains(t, err, "is not listed in asset_categories")
+	})
+}
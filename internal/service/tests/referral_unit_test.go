@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	referralsrv "github.com/fazamuttaqien/multifinance/internal/service/referral"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReferralService_GetMyCode_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-referral-service")
+
+	t.Run("Existing Code", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		referralRepository := mocks.NewMockReferralRepository(ctrl)
+		referralService := referralsrv.NewReferralService(mocks.NewMockCustomerRepository(ctrl), referralRepository, referralsrv.Config{}, meter, tracer, log)
+
+		referralRepository.EXPECT().FindCode(gomock.Any(), uint64(7)).Return(&domain.ReferralCode{CustomerID: 7, Code: "BUDI2345"}, nil)
+
+		code, err := referralService.GetMyCode(context.Background(), 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, "BUDI2345", code.Code)
+	})
+
+	t.Run("Generates Code And Retries Collision", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		referralRepository := mocks.NewMockReferralRepository(ctrl)
+		referralService := referralsrv.NewReferralService(mocks.NewMockCustomerRepository(ctrl), referralRepository, referralsrv.Config{}, meter, tracer, log)
+
+		gomock.InOrder(
+			referralRepository.EXPECT().FindCode(gomock.Any(), uint64(7)).Return(nil, nil),
+			referralRepository.EXPECT().CreateCode(gomock.Any(), gomock.Any()).Return(false, nil),
+			referralRepository.EXPECT().FindCode(gomock.Any(), uint64(7)).Return(nil, nil),
+			referralRepository.EXPECT().CreateCode(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, code *domain.ReferralCode) (bool, error) {
+					assert.Equal(t, uint64(7), code.CustomerID)
+					assert.Regexp(t, `^[A-HJ-NP-Z2-9]{8}$`, code.Code)
+					return true, nil
+				}),
+		)
+
+		code, err := referralService.GetMyCode(context.Background(), 7)
+
+		require.NoError(t, err)
+		assert.Len(t, code.Code, 8)
+	})
+}
+
+func TestReferralService_Record_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-referral-service")
+	birthDate := time.Date(1995, 5, 5, 0, 0, 0, 0, time.UTC)
+	code := &domain.ReferralCode{CustomerID: 1, Code: "BUDI2345"}
+	referrer := &domain.Customer{ID: 1, LegalName: "Budi Santoso", BirthDate: birthDate, RegistrationDeviceID: "device-a"}
+
+	tests := []struct {
+		name     string
+		referee  domain.Customer
+		byDevice int64
+		byIP     int64
+		status   domain.ReferralStatus
+		reason   string
+	}{
+		{
+			name:    "Accepted",
+			referee: domain.Customer{ID: 2, LegalName: "Citra", BirthDate: birthDate, RegistrationDeviceID: "device-b", RegistrationIP: "10.0.0.2"},
+			byIP:    2,
+			status:  domain.ReferralAccepted,
+		},
+		{
+			name:    "Same Person",
+			referee: domain.Customer{ID: 2, LegalName: "budi santoso ", BirthDate: birthDate, RegistrationDeviceID: "device-b"},
+			status:  domain.ReferralRejected,
+			reason:  referralsrv.RejectSelfReferral,
+		},
+		{
+			name:    "Referrer Device",
+			referee: domain.Customer{ID: 2, LegalName: "Citra", BirthDate: birthDate, RegistrationDeviceID: "device-a"},
+			status:  domain.ReferralRejected,
+			reason:  referralsrv.RejectSelfReferral,
+		},
+		{
+			name:     "Device Already Referred",
+			referee:  domain.Customer{ID: 2, LegalName: "Citra", BirthDate: birthDate, RegistrationDeviceID: "device-b"},
+			byDevice: 1,
+			status:   domain.ReferralRejected,
+			reason:   referralsrv.RejectDuplicateDevice,
+		},
+		{
+			name:    "Too Many From IP",
+			referee: domain.Customer{ID: 2, LegalName: "Citra", BirthDate: birthDate, RegistrationIP: "10.0.0.2"},
+			byIP:    3,
+			status:  domain.ReferralRejected,
+			reason:  referralsrv.RejectDuplicateIP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			customerRepository := mocks.NewMockCustomerRepository(ctrl)
+			referralRepository := mocks.NewMockReferralRepository(ctrl)
+			referralService := referralsrv.NewReferralService(customerRepository, referralRepository, referralsrv.Config{IPWindow: time.Hour, MaxPerIP: 3}, meter, tracer, log)
+
+			customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(referrer, nil)
+			referralRepository.EXPECT().CountByFingerprint(gomock.Any(), tt.referee.RegistrationDeviceID, tt.referee.RegistrationIP, gomock.Any()).
+				Return(tt.byDevice, tt.byIP, nil).AnyTimes()
+			referralRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			referee := tt.referee
+			referral, err := referralService.Record(context.Background(), code, &referee)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, referral.Status)
+			assert.Equal(t, tt.reason, referral.RejectReason)
+			assert.Equal(t, "BUDI2345", referral.Code)
+		})
+	}
+}
+
+func TestReferralService_Resolve_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	referralRepository := mocks.NewMockReferralRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-referral-service")
+	referralService := referralsrv.NewReferralService(mocks.NewMockCustomerRepository(ctrl), referralRepository, referralsrv.Config{}, meter, tracer, log)
+
+	t.Run("Normalizes Code", func(t *testing.T) {
+		referralRepository.EXPECT().FindByCode(gomock.Any(), "BUDI2345").Return(&domain.ReferralCode{CustomerID: 1, Code: "BUDI2345"}, nil)
+
+		code, err := referralService.Resolve(context.Background(), " budi2345 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), code.CustomerID)
+	})
+
+	t.Run("Unknown Code", func(t *testing.T) {
+		referralRepository.EXPECT().FindByCode(gomock.Any(), "NOPE1234").Return(nil, nil)
+
+		code, err := referralService.Resolve(context.Background(), "NOPE1234")
+
+		assert.Nil(t, code)
+		assert.ErrorIs(t, err, common.ErrReferralCodeNotFound)
+	})
+}
@@ -0,0 +1,335 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	reportsrv "github.com/fazamuttaqien/multifinance/internal/service/report"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportService_InterestAccrual_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportRepository := mocks.NewMockReportRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-report-service-unit")
+	reportService := reportsrv.NewReportService(reportRepository, mocks.NewMockRegionRepository(ctrl), testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	t.Run("Totals By Tenor And Segment", func(t *testing.T) {
+		month := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		reportRepository.EXPECT().InterestAccrual(gomock.Any(), month).Return([]domain.InterestAccrual{
+			{TenorID: 3, TenorMonths: 3, Segment: domain.PortfolioDirect, ContractCount: 2, AccruedInterest: decimal.RequireFromString("400000.50")},
+			{TenorID: 3, TenorMonths: 3, Segment: domain.PortfolioPartner, ContractCount: 1, AccruedInterest: decimal.NewFromInt(100000)},
+			{TenorID: 6, TenorMonths: 6, Segment: domain.PortfolioDirect, ContractCount: 4, AccruedInterest: decimal.RequireFromString("250000.25")},
+		}, nil)
+
+		report, err := reportService.InterestAccrual(context.Background(), "2025-06")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-06", report.Month)
+		assert.Equal(t, "IDR", report.Currency)
+		assert.Len(t, report.Rows, 3)
+
+		require.Len(t, report.ByTenor, 2)
+		assert.Equal(t, uint8(3), report.ByTenor[0].TenorMonths)
+		assert.Equal(t, int64(3), report.ByTenor[0].ContractCount)
+		assert.Equal(t, "500000.5", report.ByTenor[0].AccruedInterest.String())
+
+		require.Len(t, report.BySegment, 2)
+		assert.Equal(t, "DIRECT", report.BySegment[0].Segment)
+		assert.Equal(t, int64(6), report.BySegment[0].ContractCount)
+		assert.Equal(t, "650000.75", report.BySegment[0].AccruedInterest.String())
+
+		assert.Equal(t, int64(7), report.ContractCount)
+		assert.Equal(t, "750000.75", report.TotalInterest.String())
+	})
+
+	t.Run("Empty Month", func(t *testing.T) {
+		reportRepository.EXPECT().InterestAccrual(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		report, err := reportService.InterestAccrual(context.Background(), "2020-01")
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Rows)
+		assert.NotNil(t, report.ByTenor)
+		assert.True(t, report.TotalInterest.IsZero())
+	})
+
+	t.Run("Invalid Month", func(t *testing.T) {
+		report, err := reportService.InterestAccrual(context.Background(), "06-2025")
+
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, common.ErrInvalidReportMonth)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		reportRepository.EXPECT().InterestAccrual(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+		report, err := reportService.InterestAccrual(context.Background(), "2025-06")
+
+		assert.Nil(t, report)
+		assert.Error(t, err)
+	})
+}
+
+func TestReportService_PartnerCommission_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportRepository := mocks.NewMockReportRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-report-service-unit")
+	reportService := reportsrv.NewReportService(reportRepository, mocks.NewMockRegionRepository(ctrl), testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	t.Run("Totals Across Partners", func(t *testing.T) {
+		month := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		reportRepository.EXPECT().PartnerCommissions(gomock.Any(), month).Return([]domain.PartnerCommission{
+			{PartnerID: 1, PartnerName: "Dealer Jaya", ContractCount: 3, OTRAmount: decimal.NewFromInt(30000000), AdminFee: decimal.NewFromInt(600000), Commission: decimal.NewFromInt(300000)},
+			{PartnerID: 2, PartnerName: "Motor Makmur", ContractCount: 1, OTRAmount: decimal.NewFromInt(15000000), AdminFee: decimal.RequireFromString("225000.25"), Commission: decimal.NewFromInt(0)},
+		}, nil)
+
+		report, err := reportService.PartnerCommission(context.Background(), "2025-06")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-06", report.Month)
+		assert.Equal(t, "IDR", report.Currency)
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, "Motor Makmur", report.Rows[1].PartnerName)
+		assert.Equal(t, int64(4), report.ContractCount)
+		assert.Equal(t, "45000000", report.TotalOTRAmount.String())
+		assert.Equal(t, "825000.25", report.TotalAdminFee.String())
+		assert.Equal(t, "300000", report.TotalCommission.String())
+	})
+
+	t.Run("Empty Month", func(t *testing.T) {
+		reportRepository.EXPECT().PartnerCommissions(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		report, err := reportService.PartnerCommission(context.Background(), "2020-01")
+
+		require.NoError(t, err)
+		assert.NotNil(t, report.Rows)
+		assert.Empty(t, report.Rows)
+		assert.True(t, report.TotalCommission.IsZero())
+	})
+
+	t.Run("Invalid Month", func(t *testing.T) {
+		report, err := reportService.PartnerCommission(context.Background(), "2025/06")
+
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, common.ErrInvalidReportMonth)
+	})
+}
+
+func TestReportService_ReferralConversion_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportRepository := mocks.NewMockReportRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-report-service-unit")
+	reportService := reportsrv.NewReportService(reportRepository, mocks.NewMockRegionRepository(ctrl), testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	t.Run("Conversion Rate Over Accepted Referrals", func(t *testing.T) {
+		month := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		reportRepository.EXPECT().ReferralConversions(gomock.Any(), month).Return([]domain.ReferralConversion{
+			{ReferrerID: 1, ReferrerName: "Budi", Code: "BUDI2345", Referred: 4, Rejected: 1, Converted: 1},
+			{ReferrerID: 2, ReferrerName: "Citra", Code: "CITRA678", Referred: 1, Rejected: 1},
+		}, nil)
+
+		report, err := reportService.ReferralConversion(context.Background(), "2025-06")
+
+		require.NoError(t, err)
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, "0.3333", report.Rows[0].ConversionRate.String())
+		assert.True(t, report.Rows[1].ConversionRate.IsZero())
+		assert.Equal(t, int64(5), report.Referred)
+		assert.Equal(t, int64(2), report.Rejected)
+		assert.Equal(t, int64(1), report.Converted)
+	})
+
+	t.Run("Invalid Month", func(t *testing.T) {
+		report, err := reportService.ReferralConversion(context.Background(), "06-2025")
+
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, common.ErrInvalidReportMonth)
+	})
+}
+
+func TestReportService_Aging_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportRepository := mocks.NewMockReportRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-report-service-unit")
+	reportService := reportsrv.NewReportService(reportRepository, mocks.NewMockRegionRepository(ctrl), testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	now := time.Date(2025, 6, 15, 8, 30, 0, 0, time.UTC)
+	unpaid := 0
+	exposures := []domain.AgingExposure{
+		// Dua cicilan sudah jatuh tempo dan dianggap lunas
+		{TransactionID: 1, TenorMonths: 3, TransactionDate: time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), TotalInstallmentAmount: decimal.NewFromInt(300000), FxRate: decimal.NewFromInt(1)},
+		// Cicilan pertama jatuh tempo 1 April, terlambat 75 hari
+		{TransactionID: 2, TenorMonths: 3, TransactionDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), PaidInstallments: &unpaid, TotalInstallmentAmount: decimal.NewFromInt(300000), FxRate: decimal.NewFromInt(1)},
+		// Kontrak valas, terlambat 14 hari
+		{TransactionID: 3, TenorMonths: 6, TransactionDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), PaidInstallments: &unpaid, TotalInstallmentAmount: decimal.NewFromInt(600), FxRate: decimal.NewFromInt(16000)},
+	}
+
+	t.Run("Refresh Snapshot", func(t *testing.T) {
+		snapshotDate := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+		reportRepository.EXPECT().AgingExposures(gomock.Any()).Return(exposures, nil)
+		reportRepository.EXPECT().SaveAgingSnapshot(gomock.Any(), snapshotDate, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ time.Time, rows []domain.AgingSnapshotRow) error {
+				require.Len(t, rows, 3)
+				assert.Equal(t, snapshotDate, rows[0].SnapshotDate)
+				assert.Equal(t, domain.AgingCurrent, rows[0].Bucket)
+				assert.Equal(t, int64(1), rows[0].ContractCount)
+				assert.Equal(t, "100000", rows[0].Outstanding.String())
+				assert.Equal(t, domain.Aging61To90, rows[1].Bucket)
+				assert.Equal(t, "300000", rows[1].Outstanding.String())
+				assert.Equal(t, uint8(6), rows[2].TenorMonths)
+				assert.Equal(t, domain.Aging1To30, rows[2].Bucket)
+				assert.Equal(t, "9600000", rows[2].Outstanding.String())
+				return nil
+			})
+
+		require.NoError(t, reportService.RefreshAgingSnapshot(context.Background(), now))
+	})
+
+	t.Run("Refresh Snapshot - Within Grace Period", func(t *testing.T) {
+		graceRules := testutil.DueDateRules(installment.NewRules(installment.ShiftNone, 14, time.UTC, nil))
+		graceService := reportsrv.NewReportService(reportRepository, mocks.NewMockRegionRepository(ctrl), graceRules, meter, tracer, log)
+
+		reportRepository.EXPECT().AgingExposures(gomock.Any()).Return(exposures, nil)
+		reportRepository.EXPECT().SaveAgingSnapshot(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ time.Time, rows []domain.AgingSnapshotRow) error {
+				require.Len(t, rows, 3)
+				assert.Equal(t, domain.Aging61To90, rows[1].Bucket)
+				// Terlambat 14 hari masih dalam masa tenggang
+				assert.Equal(t, uint8(6), rows[2].TenorMonths)
+				assert.Equal(t, domain.AgingCurrent, rows[2].Bucket)
+				return nil
+			})
+
+		require.NoError(t, graceService.RefreshAgingSnapshot(context.Background(), now))
+	})
+
+	t.Run("Served From Snapshot", func(t *testing.T) {
+		snapshotDate := time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC)
+		reportRepository.EXPECT().LatestAgingSnapshot(gomock.Any(), time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)).Return([]domain.AgingSnapshotRow{
+			{SnapshotDate: snapshotDate, TenorMonths: 3, Bucket: domain.AgingCurrent, ContractCount: 4, Outstanding: decimal.NewFromInt(400000)},
+			{SnapshotDate: snapshotDate, TenorMonths: 3, Bucket: domain.AgingOver90, ContractCount: 1, Outstanding: decimal.NewFromInt(250000)},
+			{SnapshotDate: snapshotDate, TenorMonths: 6, Bucket: domain.AgingCurrent, ContractCount: 2, Outstanding: decimal.NewFromInt(100000)},
+		}, nil)
+
+		report, err := reportService.Aging(context.Background(), "2025-06-15")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-06-14", report.AsOf)
+		assert.Equal(t, "snapshot", report.Source)
+		require.Len(t, report.Portfolio, 5)
+		assert.Equal(t, int64(6), report.Portfolio[0].ContractCount)
+		assert.Equal(t, "500000", report.Portfolio[0].Outstanding.String())
+		assert.Equal(t, "90+", report.Portfolio[4].Bucket)
+		assert.Equal(t, int64(1), report.Portfolio[4].ContractCount)
+		require.Len(t, report.ByTenor, 2)
+		assert.Len(t, report.ByTenor[1].Buckets, 5)
+		assert.Equal(t, int64(7), report.ContractCount)
+		assert.Equal(t, "750000", report.TotalOutstanding.String())
+	})
+
+	t.Run("Computed Live Without Snapshot", func(t *testing.T) {
+		reportRepository.EXPECT().LatestAgingSnapshot(gomock.Any(), gomock.Any()).Return(nil, nil)
+		reportRepository.EXPECT().AgingExposures(gomock.Any()).Return(exposures, nil)
+
+		report, err := reportService.Aging(context.Background(), "2025-06-15")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-06-15", report.AsOf)
+		assert.Equal(t, "live", report.Source)
+		assert.Equal(t, int64(3), report.ContractCount)
+		assert.Equal(t, "10000000", report.TotalOutstanding.String())
+	})
+
+	t.Run("Invalid Date", func(t *testing.T) {
+		report, err := reportService.Aging(context.Background(), "15-06-2025")
+
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, common.ErrInvalidReportDate)
+	})
+}
+
+func TestReportService_RegionPerformance_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportRepository := mocks.NewMockReportRepository(ctrl)
+	regionRepository := mocks.NewMockRegionRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-report-service-unit")
+	reportService := reportsrv.NewReportService(reportRepository, regionRepository, testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	now := time.Now()
+	unpaid := 0
+
+	t.Run("Volume And Delinquency By Region", func(t *testing.T) {
+		month := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		regionRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Region{
+			{Code: "BDG", Name: "Bandung", Active: true},
+			{Code: "JKT", Name: "Jakarta", Active: true},
+		}, nil)
+		reportRepository.EXPECT().RegionVolumes(gomock.Any(), month).Return([]domain.RegionVolume{
+			{RegionCode: "", ContractCount: 1, OTRAmount: decimal.NewFromInt(5000000)},
+			{RegionCode: "JKT", ContractCount: 3, OTRAmount: decimal.NewFromInt(45000000)},
+		}, nil)
+		reportRepository.EXPECT().AgingExposures(gomock.Any()).Return([]domain.AgingExposure{
+			// Belum ada cicilan jatuh tempo
+			{TransactionID: 1, TenorMonths: 3, TransactionDate: now.AddDate(0, 0, -10), TotalInstallmentAmount: decimal.NewFromInt(300000), FxRate: decimal.NewFromInt(1), RegionCode: "JKT"},
+			// Cicilan pertama sudah lewat jatuh tempo
+			{TransactionID: 2, TenorMonths: 6, TransactionDate: now.AddDate(0, -1, -5), PaidInstallments: &unpaid, TotalInstallmentAmount: decimal.NewFromInt(600000), FxRate: decimal.NewFromInt(1), RegionCode: "JKT"},
+			{TransactionID: 3, TenorMonths: 3, TransactionDate: now.AddDate(0, 0, -10), TotalInstallmentAmount: decimal.NewFromInt(300000), FxRate: decimal.NewFromInt(1)},
+		}, nil)
+
+		report, err := reportService.RegionPerformance(context.Background(), "2025-06")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-06", report.Month)
+		require.Len(t, report.Rows, 3)
+
+		assert.Equal(t, "BDG", report.Rows[0].RegionCode)
+		assert.Equal(t, int64(0), report.Rows[0].ContractCount)
+		assert.True(t, report.Rows[0].DelinquencyRate.IsZero())
+
+		jakarta := report.Rows[1]
+		assert.Equal(t, "Jakarta", jakarta.RegionName)
+		assert.Equal(t, int64(3), jakarta.ContractCount)
+		assert.Equal(t, int64(2), jakarta.ActiveContracts)
+		assert.Equal(t, int64(1), jakarta.DelinquentContracts)
+		assert.Equal(t, "900000", jakarta.Outstanding.String())
+		assert.Equal(t, "600000", jakarta.DelinquentOutstanding.String())
+		assert.Equal(t, "0.6667", jakarta.DelinquencyRate.String())
+
+		assert.Equal(t, "", report.Rows[2].RegionCode)
+		assert.Equal(t, "Unassigned", report.Rows[2].RegionName)
+		assert.Equal(t, int64(1), report.Rows[2].ContractCount)
+
+		assert.Equal(t, int64(4), report.ContractCount)
+		assert.Equal(t, "50000000", report.TotalOTRAmount.String())
+		assert.Equal(t, "1200000", report.TotalOutstanding.String())
+		assert.Equal(t, "0.5", report.DelinquencyRate.String())
+	})
+
+	t.Run("Invalid Month", func(t *testing.T) {
+		report, err := reportService.RegionPerformance(context.Background(), "06-2025")
+
+		assert.Nil(t, report)
+		assert.ErrorIs(t, err, common.ErrInvalidReportMonth)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		regionRepository.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("db down"))
+
+		report, err := reportService.RegionPerformance(context.Background(), "2025-06")
+
+		assert.Nil(t, report)
+		assert.Error(t, err)
+	})
+}
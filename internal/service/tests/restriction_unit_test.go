@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	restrictionsrv "github.com/fazamuttaqien/multifinance/internal/service/restriction"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCustomerRestrictionService_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	tenorRepository := mocks.NewMockTenorRepository(ctrl)
+	restrictionRepository := mocks.NewMockCustomerRestrictionRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-restriction-service")
+	// Jalur yang diuji di sini berhenti sebelum transaksi database dibuka
+	restrictionService := restrictionsrv.NewCustomerRestrictionService(nil, customerRepository, tenorRepository, restrictionRepository, meter, tracer, log)
+
+	t.Run("Suspend - Expiry In The Past", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+
+		restriction, err := restrictionService.Suspend(context.Background(), 7, 1, dto.RestrictionRequest{Reason: domain.RestrictionFraudSuspected, ExpiresAt: &past})
+
+		assert.Nil(t, restriction)
+		assert.ErrorIs(t, err, common.ErrInvalidRestrictionExpiry)
+	})
+
+	t.Run("Suspend - Customer Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		restriction, err := restrictionService.Suspend(context.Background(), 99, 1, dto.RestrictionRequest{Reason: domain.RestrictionLegalHold})
+
+		assert.Nil(t, restriction)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+
+	t.Run("FreezeLimit - Tenor Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7, NIK: "1234567890123456"}, nil)
+		tenorRepository.EXPECT().FindByDuration(gomock.Any(), uint8(9)).Return(nil, nil)
+
+		restriction, err := restrictionService.FreezeLimit(context.Background(), 7, 9, 1, dto.RestrictionRequest{Reason: domain.RestrictionDelinquency})
+
+		assert.Nil(t, restriction)
+		assert.ErrorIs(t, err, common.ErrTenorNotFound)
+	})
+
+	t.Run("ListRestrictions - Success", func(t *testing.T) {
+		tenorID := uint(2)
+		restrictions := []domain.CustomerRestriction{
+			{ID: 2, CustomerID: 7, TenorID: &tenorID, TenorMonths: 6, Reason: domain.RestrictionDelinquency},
+			{ID: 1, CustomerID: 7, Reason: domain.RestrictionKYCReview},
+		}
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(7)).Return(&domain.Customer{ID: 7}, nil)
+		restrictionRepository.EXPECT().FindByCustomer(gomock.Any(), uint64(7)).Return(restrictions, nil)
+
+		result, err := restrictionService.ListRestrictions(context.Background(), 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, restrictions, result)
+	})
+
+	t.Run("ExpireDue - Nothing Due", func(t *testing.T) {
+		now := time.Now()
+		restrictionRepository.EXPECT().FindExpired(gomock.Any(), now, gomock.Any()).Return(nil, nil)
+
+		lifted, err := restrictionService.ExpireDue(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Zero(t, lifted)
+	})
+}
+
+func TestBlockingRestriction(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	sixMonths, twelveMonths := uint(2), uint(3)
+
+	frozen := domain.CustomerRestriction{ID: 1, TenorID: &sixMonths, Reason: domain.RestrictionDelinquency}
+	expiredSuspension := domain.CustomerRestriction{ID: 2, Reason: domain.RestrictionKYCReview, ExpiresAt: &past}
+	liftedSuspension := domain.CustomerRestriction{ID: 3, Reason: domain.RestrictionOther, LiftedAt: &past}
+	suspension := domain.CustomerRestriction{ID: 4, Reason: domain.RestrictionFraudSuspected}
+
+	t.Run("Frozen Tenor Only", func(t *testing.T) {
+		restrictions := []domain.CustomerRestriction{frozen, expiredSuspension, liftedSuspension}
+
+		assert.Equal(t, uint64(1), domain.BlockingRestriction(restrictions, sixMonths, now).ID)
+		assert.Nil(t, domain.BlockingRestriction(restrictions, twelveMonths, now))
+	})
+
+	t.Run("Suspension Wins Over Freeze", func(t *testing.T) {
+		restrictions := []domain.CustomerRestriction{frozen, suspension}
+
+		assert.Equal(t, uint64(4), domain.BlockingRestriction(restrictions, sixMonths, now).ID)
+		assert.Equal(t, uint64(4), domain.BlockingRestriction(restrictions, twelveMonths, now).ID)
+	})
+}
@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	restructuringsrv "github.com/fazamuttaqien/multifinance/internal/service/restructuring"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRestructuringService_Propose_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	transactionRepository := mocks.NewMockTransactionRepository(ctrl)
+	tenorRepository := mocks.NewMockTenorRepository(ctrl)
+	restructuringRepository := mocks.NewMockRestructuringRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-restructuring-service-unit")
+	restructuringService := restructuringsrv.NewRestructuringService(nil, transactionRepository, tenorRepository, restructuringRepository, testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	tenors := []domain.Tenor{
+		{ID: 1, DurationMonths: 3},
+		{ID: 2, DurationMonths: 6},
+		{ID: 3, DurationMonths: 9},
+		{ID: 4, DurationMonths: 12},
+		{ID: 5, DurationMonths: 24},
+	}
+	// Dua cicilan sudah jatuh tempo: 6 bulan x 1.900.000
+	transaction := &domain.Transaction{
+		ID:                     10,
+		ContractNumber:         "KTR-001",
+		CustomerID:             7,
+		TenorID:                2,
+		OTRAmount:              decimal.NewFromInt(10_000_000),
+		AdminFee:               decimal.NewFromInt(200_000),
+		TotalInterest:          decimal.NewFromInt(1_200_000),
+		TotalInstallmentAmount: decimal.NewFromInt(11_400_000),
+		Status:                 domain.TransactionActive,
+		TransactionDate:        time.Now().AddDate(0, -2, -1),
+	}
+
+	t.Run("Extend Tenor", func(t *testing.T) {
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-001").Return(transaction, nil)
+		restructuringRepository.EXPECT().FindPendingByTransactionID(gomock.Any(), uint64(10)).Return(nil, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		restructuringRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := restructuringService.Propose(context.Background(), 1, dto.RestructuringRequest{
+			ContractNumber: "KTR-001",
+			Type:           domain.RestructuringExtendTenor,
+			NewTenorMonths: 12,
+			Reason:         "Customer income dropped",
+		})
+
+		require.NoError(t, err)
+		r := result.Restructuring
+		assert.Equal(t, domain.RestructuringPending, r.Status)
+		assert.Equal(t, uint8(2), r.PaidInstallments)
+		assert.Equal(t, uint(2), r.OriginalTenorID)
+		assert.Equal(t, "1900000", r.OriginalMonthlyInstallment.String())
+		assert.Equal(t, uint(4), r.NewTenorID)
+		assert.Equal(t, "2400000", r.NewTotalInterest.String())
+		assert.Equal(t, "12600000", r.NewTotalInstallment.String())
+		assert.Equal(t, "880000", r.NewMonthlyInstallment.String())
+
+		require.Len(t, result.Schedule, 10)
+		assert.Equal(t, 3, result.Schedule[0].Sequence)
+		assert.Equal(t, 12, result.Schedule[9].Sequence)
+		assert.Equal(t, "880000", result.Schedule[9].Amount.String())
+	})
+
+	t.Run("Reduce Installment Picks Shortest Tenor", func(t *testing.T) {
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-001").Return(transaction, nil)
+		restructuringRepository.EXPECT().FindPendingByTransactionID(gomock.Any(), uint64(10)).Return(nil, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		restructuringRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := restructuringService.Propose(context.Background(), 1, dto.RestructuringRequest{
+			ContractNumber:    "KTR-001",
+			Type:              domain.RestructuringReduceInstallment,
+			TargetInstallment: decimal.NewFromInt(1_000_000),
+			Reason:            "Customer income dropped",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint8(12), result.Restructuring.NewTenorMonths)
+	})
+
+	t.Run("No Feasible Tenor", func(t *testing.T) {
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-001").Return(transaction, nil)
+		restructuringRepository.EXPECT().FindPendingByTransactionID(gomock.Any(), uint64(10)).Return(nil, nil)
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+
+		result, err := restructuringService.Propose(context.Background(), 1, dto.RestructuringRequest{
+			ContractNumber:    "KTR-001",
+			Type:              domain.RestructuringReduceInstallment,
+			TargetInstallment: decimal.NewFromInt(100_000),
+			Reason:            "Customer income dropped",
+		})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, common.ErrNoFeasibleTenor)
+	})
+
+	t.Run("Pending Restructuring Exists", func(t *testing.T) {
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-001").Return(transaction, nil)
+		restructuringRepository.EXPECT().FindPendingByTransactionID(gomock.Any(), uint64(10)).
+			Return(&domain.Restructuring{ID: 3, Status: domain.RestructuringPending}, nil)
+
+		result, err := restructuringService.Propose(context.Background(), 1, dto.RestructuringRequest{
+			ContractNumber: "KTR-001",
+			Type:           domain.RestructuringExtendTenor,
+			NewTenorMonths: 12,
+			Reason:         "Customer income dropped",
+		})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, common.ErrRestructureExists)
+	})
+
+	t.Run("Transaction Not Active", func(t *testing.T) {
+		paidOff := *transaction
+		paidOff.Status = domain.TransactionPaidOff
+		transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-002").Return(&paidOff, nil)
+
+		result, err := restructuringService.Propose(context.Background(), 1, dto.RestructuringRequest{
+			ContractNumber: "KTR-002",
+			Type:           domain.RestructuringExtendTenor,
+			NewTenorMonths: 12,
+			Reason:         "Customer income dropped",
+		})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, common.ErrTransactionNotActive)
+	})
+}
+
+func TestRestructuringService_GetRestructuring_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	restructuringRepository := mocks.NewMockRestructuringRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-restructuring-service-unit")
+	restructuringService := restructuringsrv.NewRestructuringService(nil, nil, nil, restructuringRepository, testutil.DueDateRules(installment.Rules{}), meter, tracer, log)
+
+	restructuringRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+	result, err := restructuringService.GetRestructuring(context.Background(), 99)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, common.ErrRestructureNotFound)
+}
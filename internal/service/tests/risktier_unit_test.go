@@ -0,0 +1,267 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	risktiersrv "github.com/fazamuttaqien/multifinance/internal/service/risktier"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type riskTierMocks struct {
+	riskTierRepository *mocks.MockRiskTierRepository
+	customerRepository *mocks.MockCustomerRepository
+}
+
+func newRiskTierService(t *testing.T, batchSize int) (*riskTierMocks, service.RiskTierServices) {
+	meter, tracer, log := testutil.Telemetry("test-risk-tier-service")
+
+	ctrl := gomock.NewController(t)
+	m := &riskTierMocks{
+		riskTierRepository: mocks.NewMockRiskTierRepository(ctrl),
+		customerRepository: mocks.NewMockCustomerRepository(ctrl),
+	}
+	cfg := risktiersrv.Config{BatchSize: batchSize}
+	return m, risktiersrv.NewRiskTierService(m.riskTierRepository, m.customerRepository, testutil.DueDateRules(installment.Rules{}), cfg, meter, tracer, log)
+}
+
+func intPtr(v int) *int { return &v }
+
+func decimalPtr(v int64) *decimal.Decimal {
+	d := decimal.NewFromInt(v)
+	return &d
+}
+
+// riskTierRules menurunkan customer yang menunggak lebih dari 30 hari ke LOW,
+// lalu memberi HIGH untuk gaji besar dengan skor bagus dan MEDIUM untuk sisanya
+// yang bergaji minimal 8jt
+func riskTierRules() []domain.RiskTierRule {
+	return []domain.RiskTierRule{
+		{ID: 1, Priority: 1, Tier: domain.RiskTierLow, MinDaysPastDue: intPtr(31)},
+		{ID: 2, Priority: 2, Tier: domain.RiskTierHigh, MinSalary: decimalPtr(20_000_000), MinScore: intPtr(700)},
+		{ID: 3, Priority: 3, Tier: domain.RiskTierMedium, MinSalary: decimalPtr(8_000_000)},
+	}
+}
+
+func TestRiskTierRule_Matches(t *testing.T) {
+	rule := domain.RiskTierRule{MinSalary: decimalPtr(8_000_000), MaxSalary: decimalPtr(20_000_000), MinScore: intPtr(600), MaxDaysPastDue: intPtr(0)}
+	salary := decimal.NewFromInt(10_000_000)
+
+	assert.True(t, rule.Matches(salary, intPtr(600), 0))
+	assert.True(t, rule.Matches(decimal.NewFromInt(20_000_000), intPtr(800), 0))
+	assert.False(t, rule.Matches(decimal.NewFromInt(7_999_999), intPtr(650), 0))
+	assert.False(t, rule.Matches(salary, intPtr(599), 0))
+	assert.False(t, rule.Matches(salary, nil, 0), "customer without a score must not match a score bound")
+	assert.False(t, rule.Matches(salary, intPtr(650), 1))
+	assert.True(t, domain.RiskTierRule{}.Matches(decimal.Zero, nil, 400))
+}
+
+func TestRiskTierService_Recalculate(t *testing.T) {
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Grades Every Batch", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 2)
+		customers := []domain.Customer{
+			{ID: 1, Salary: decimal.NewFromInt(25_000_000)},
+			{ID: 2, Salary: decimal.NewFromInt(25_000_000)},
+			{ID: 3, Salary: decimal.NewFromInt(5_000_000)},
+		}
+
+		m.riskTierRepository.EXPECT().FindRules(gomock.Any()).Return(riskTierRules(), nil)
+		m.riskTierRepository.EXPECT().FindCustomers(gomock.Any(), uint64(0), 2).Return(customers[:2], nil)
+		m.riskTierRepository.EXPECT().FindActiveExposures(gomock.Any(), []uint64{1, 2}).Return([]domain.AgingExposure{
+			// Belum ada cicilan terbayar sejak Desember, cicilan pertama jatuh tempo 10 Januari
+			{TransactionID: 7, CustomerID: 2, TenorMonths: 6, TransactionDate: time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC), PaidInstallments: intPtr(0)},
+			{TransactionID: 8, CustomerID: 1, TenorMonths: 6, TransactionDate: time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC), PaidInstallments: intPtr(2)},
+		}, nil)
+		m.riskTierRepository.EXPECT().FindByCustomerIDs(gomock.Any(), []uint64{1, 2}).Return([]domain.CustomerRiskTier{
+			{CustomerID: 1, Tier: domain.RiskTierHigh, CreditScore: intPtr(750)},
+			{CustomerID: 2, Tier: domain.RiskTierHigh, CreditScore: intPtr(750)},
+		}, nil)
+		m.riskTierRepository.EXPECT().FindCustomers(gomock.Any(), uint64(2), 2).Return(customers[2:], nil)
+		m.riskTierRepository.EXPECT().FindActiveExposures(gomock.Any(), []uint64{3}).Return(nil, nil)
+		m.riskTierRepository.EXPECT().FindByCustomerIDs(gomock.Any(), []uint64{3}).Return(nil, nil)
+
+		saved := make(map[uint64]domain.CustomerRiskTier)
+		m.riskTierRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Times(3).
+			DoAndReturn(func(_ context.Context, tier *domain.CustomerRiskTier) error {
+				saved[tier.CustomerID] = *tier
+				return nil
+			})
+
+		run, err := riskTierService.Recalculate(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, run.Customers)
+		assert.Equal(t, 2, run.Changed)
+
+		assert.Equal(t, domain.RiskTierHigh, saved[1].Tier)
+		assert.Equal(t, uint64(2), *saved[1].RuleID)
+		assert.Equal(t, 0, saved[1].DaysPastDue)
+		assert.Equal(t, 750, *saved[1].CreditScore)
+
+		assert.Equal(t, domain.RiskTierLow, saved[2].Tier)
+		assert.Equal(t, 50, saved[2].DaysPastDue)
+		assert.Equal(t, 750, *saved[2].CreditScore, "the stored score is kept")
+
+		assert.Equal(t, domain.RiskTier(""), saved[3].Tier)
+		assert.Nil(t, saved[3].RuleID)
+		assert.Equal(t, now, saved[3].CalculatedAt)
+	})
+
+	t.Run("Failure - Repository Error", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 2)
+
+		dbErr := errors.New("connection refused")
+		m.riskTierRepository.EXPECT().FindRules(gomock.Any()).Return(riskTierRules(), nil)
+		m.riskTierRepository.EXPECT().FindCustomers(gomock.Any(), uint64(0), 2).Return(nil, dbErr)
+
+		_, err := riskTierService.Recalculate(context.Background(), now)
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
+
+func TestRiskTierService_Rules(t *testing.T) {
+	req := dto.RiskTierRuleRequest{Priority: 5, Tier: domain.RiskTierMedium, MinSalary: decimalPtr(8_000_000), MaxSalary: decimalPtr(20_000_000)}
+
+	t.Run("Success - Create", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 10)
+
+		m.riskTierRepository.EXPECT().CreateRule(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, rule *domain.RiskTierRule) error {
+				rule.ID = 4
+				return nil
+			})
+
+		rule, err := riskTierService.CreateRule(context.Background(), 1, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), rule.ID)
+		assert.Equal(t, domain.RiskTierMedium, rule.Tier)
+		assert.Equal(t, uint64(1), rule.UpdatedBy)
+		assert.Nil(t, rule.MinScore)
+	})
+
+	t.Run("Failure - Minimum Above Maximum", func(t *testing.T) {
+		_, riskTierService := newRiskTierService(t, 10)
+
+		invalid := req
+		invalid.MinScore, invalid.MaxScore = intPtr(800), intPtr(700)
+		_, err := riskTierService.CreateRule(context.Background(), 1, invalid)
+
+		assert.ErrorIs(t, err, common.ErrInvalidRiskTierRule)
+	})
+
+	t.Run("Failure - Negative Salary", func(t *testing.T) {
+		_, riskTierService := newRiskTierService(t, 10)
+
+		invalid := req
+		invalid.MinSalary = decimalPtr(-1)
+		_, err := riskTierService.UpdateRule(context.Background(), 4, 1, invalid)
+
+		assert.ErrorIs(t, err, common.ErrInvalidRiskTierRule)
+	})
+
+	t.Run("Success - Update Clears Bounds", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 10)
+
+		m.riskTierRepository.EXPECT().FindRuleByID(gomock.Any(), uint64(4)).
+			Return(&domain.RiskTierRule{ID: 4, Tier: domain.RiskTierLow, MinScore: intPtr(300)}, nil)
+		m.riskTierRepository.EXPECT().UpdateRule(gomock.Any(), gomock.Any()).Return(nil)
+
+		rule, err := riskTierService.UpdateRule(context.Background(), 4, 2, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RiskTierMedium, rule.Tier)
+		assert.Nil(t, rule.MinScore)
+		assert.Equal(t, uint64(2), rule.UpdatedBy)
+	})
+
+	t.Run("Failure - Update Not Found", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 10)
+
+		m.riskTierRepository.EXPECT().FindRuleByID(gomock.Any(), uint64(9)).Return(nil, nil)
+
+		_, err := riskTierService.UpdateRule(context.Background(), 9, 1, req)
+
+		assert.ErrorIs(t, err, common.ErrRiskTierRuleNotFound)
+	})
+
+	t.Run("Failure - Delete Not Found", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 10)
+
+		m.riskTierRepository.EXPECT().DeleteRule(gomock.Any(), uint64(9)).Return(false, nil)
+
+		err := riskTierService.DeleteRule(context.Background(), 9)
+
+		assert.ErrorIs(t, err, common.ErrRiskTierRuleNotFound)
+	})
+}
+
+func TestRiskTierService_SetCreditScore(t *testing.T) {
+	t.Run("Success - Regrades At Once", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 10)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).
+			Return(&domain.Customer{ID: 5, Salary: decimal.NewFromInt(22_000_000)}, nil)
+		m.riskTierRepository.EXPECT().FindRules(gomock.Any()).Return(riskTierRules(), nil)
+		m.riskTierRepository.EXPECT().FindActiveExposures(gomock.Any(), []uint64{5}).Return(nil, nil)
+		m.riskTierRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		tier, err := riskTierService.SetCreditScore(context.Background(), 5, dto.CreditScoreRequest{Score: intPtr(720)})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RiskTierHigh, tier.Tier)
+		assert.Equal(t, 720, *tier.CreditScore)
+	})
+
+	t.Run("Success - Clearing Score Falls Through To Salary Rule", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 10)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).
+			Return(&domain.Customer{ID: 5, Salary: decimal.NewFromInt(22_000_000)}, nil)
+		m.riskTierRepository.EXPECT().FindRules(gomock.Any()).Return(riskTierRules(), nil)
+		m.riskTierRepository.EXPECT().FindActiveExposures(gomock.Any(), []uint64{5}).Return(nil, nil)
+		m.riskTierRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		tier, err := riskTierService.SetCreditScore(context.Background(), 5, dto.CreditScoreRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RiskTierMedium, tier.Tier)
+		assert.Nil(t, tier.CreditScore)
+	})
+
+	t.Run("Failure - Customer Not Found", func(t *testing.T) {
+		m, riskTierService := newRiskTierService(t, 10)
+
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(5)).Return(nil, nil)
+
+		_, err := riskTierService.SetCreditScore(context.Background(), 5, dto.CreditScoreRequest{Score: intPtr(720)})
+
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
+
+func TestRiskTierService_GetCustomerTier(t *testing.T) {
+	m, riskTierService := newRiskTierService(t, 10)
+
+	m.riskTierRepository.EXPECT().FindByCustomerID(gomock.Any(), uint64(5)).Return(nil, nil)
+
+	_, err := riskTierService.GetCustomerTier(context.Background(), 5)
+
+	assert.ErrorIs(t, err, common.ErrRiskTierNotCalculated)
+}
@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	salarychangesrv "github.com/fazamuttaqien/multifinance/internal/service/salarychange"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSalaryChangeService_RequestChange_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	salaryChangeRepository := mocks.NewMockSalaryChangeRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-salary-change-service-unit")
+	salaryChangeService := salarychangesrv.NewSalaryChangeService(nil, customerRepository, salaryChangeRepository, meter, tracer, log)
+
+	customer := &domain.Customer{ID: 1, Salary: decimal.NewFromInt(10000000)}
+
+	t.Run("Success - Pending Change Created", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+		salaryChangeRepository.EXPECT().FindPendingByCustomerID(gomock.Any(), uint64(1)).Return(nil, nil)
+		salaryChangeRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		change, err := salaryChangeService.RequestChange(context.Background(), 1, decimal.NewFromInt(15000000), "https://example.com/payslip.jpg")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.SalaryChangePending, change.Status)
+		assert.Equal(t, "10000000", change.CurrentSalary.String())
+		assert.Equal(t, "15000000", change.RequestedSalary.String())
+	})
+
+	t.Run("Failure - Salary Unchanged", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+
+		change, err := salaryChangeService.RequestChange(context.Background(), 1, decimal.NewFromInt(10000000), "https://example.com/payslip.jpg")
+
+		assert.Nil(t, change)
+		assert.ErrorIs(t, err, common.ErrSalaryUnchanged)
+	})
+
+	t.Run("Failure - Change Already Pending", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+		salaryChangeRepository.EXPECT().FindPendingByCustomerID(gomock.Any(), uint64(1)).
+			Return(&domain.SalaryChange{ID: 9, Status: domain.SalaryChangePending}, nil)
+
+		change, err := salaryChangeService.RequestChange(context.Background(), 1, decimal.NewFromInt(15000000), "https://example.com/payslip.jpg")
+
+		assert.Nil(t, change)
+		assert.ErrorIs(t, err, common.ErrSalaryChangeExists)
+	})
+
+	t.Run("Failure - Customer Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		change, err := salaryChangeService.RequestChange(context.Background(), 99, decimal.NewFromInt(15000000), "https://example.com/payslip.jpg")
+
+		assert.Nil(t, change)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
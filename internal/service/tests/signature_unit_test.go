@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	signaturesrv "github.com/fazamuttaqien/multifinance/internal/service/signature"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/requestsig"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSignatureService_Verify(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-signature-service")
+	cfg := signaturesrv.Config{Tolerance: 5 * time.Minute}
+
+	partner := &domain.Partner{ID: 3, Security: domain.PartnerSecurity{RequireSignature: true, SigningSecret: "partner-secret"}}
+	body := []byte(`{"customer_nik":"1234567890123456"}`)
+
+	signed := func(at time.Time, nonce string) domain.SignedRequest {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		return domain.SignedRequest{
+			Nonce:     nonce,
+			Timestamp: timestamp,
+			Signature: requestsig.Sign("partner-secret", timestamp, nonce, "POST", "/api/v1/partner-api/transactions", body),
+			Method:    "POST",
+			Path:      "/api/v1/partner-api/transactions",
+			Body:      body,
+		}
+	}
+
+	t.Run("Success - Fresh Nonce", func(t *testing.T) {
+		nonceRepository := mocks.NewMockNonceRepository(gomock.NewController(t))
+		signatureService := signaturesrv.NewSignatureService(nonceRepository, cfg, meter, tracer, log)
+
+		nonceRepository.EXPECT().Claim(gomock.Any(), uint64(3), "nonce-0000000001", 10*time.Minute).Return(true, nil)
+
+		err := signatureService.Verify(context.Background(), partner, signed(time.Now(), "nonce-0000000001"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failure - Replayed Nonce", func(t *testing.T) {
+		nonceRepository := mocks.NewMockNonceRepository(gomock.NewController(t))
+		signatureService := signaturesrv.NewSignatureService(nonceRepository, cfg, meter, tracer, log)
+
+		nonceRepository.EXPECT().Claim(gomock.Any(), uint64(3), "nonce-0000000001", gomock.Any()).Return(false, nil)
+
+		err := signatureService.Verify(context.Background(), partner, signed(time.Now(), "nonce-0000000001"))
+
+		assert.ErrorIs(t, err, common.ErrNonceReused)
+	})
+
+	t.Run("Failure - Stale Timestamp", func(t *testing.T) {
+		nonceRepository := mocks.NewMockNonceRepository(gomock.NewController(t))
+		signatureService := signaturesrv.NewSignatureService(nonceRepository, cfg, meter, tracer, log)
+
+		err := signatureService.Verify(context.Background(), partner, signed(time.Now().Add(-10*time.Minute), "nonce-0000000002"))
+
+		assert.ErrorIs(t, err, common.ErrSignatureExpired)
+	})
+
+	t.Run("Failure - Tampered Body Keeps Nonce", func(t *testing.T) {
+		nonceRepository := mocks.NewMockNonceRepository(gomock.NewController(t))
+		signatureService := signaturesrv.NewSignatureService(nonceRepository, cfg, meter, tracer, log)
+
+		req := signed(time.Now(), "nonce-0000000003")
+		req.Body = []byte(`{"customer_nik":"6543210987654321"}`)
+		err := signatureService.Verify(context.Background(), partner, req)
+
+		assert.ErrorIs(t, err, common.ErrInvalidSignature)
+	})
+
+	t.Run("Unsigned Request", func(t *testing.T) {
+		nonceRepository := mocks.NewMockNonceRepository(gomock.NewController(t))
+		signatureService := signaturesrv.NewSignatureService(nonceRepository, cfg, meter, tracer, log)
+
+		err := signatureService.Verify(context.Background(), partner, domain.SignedRequest{Method: "POST", Body: body})
+		assert.ErrorIs(t, err, common.ErrSignatureRequired)
+
+		optional := &domain.Partner{ID: 4}
+		err = signatureService.Verify(context.Background(), optional, domain.SignedRequest{Method: "POST", Body: body})
+		assert.NoError(t, err)
+	})
+}
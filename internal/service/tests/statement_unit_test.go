@@ -0,0 +1,388 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	servicemocks "github.com/fazamuttaqien/multifinance/internal/service/mocks"
+	statementsrv "github.com/fazamuttaqien/multifinance/internal/service/statement"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/pdf"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type statementMocks struct {
+	profileService             *servicemocks.MockProfileServices
+	customerRepository         *mocks.MockCustomerRepository
+	statementRepository        *mocks.MockStatementRepository
+	monthlyStatementRepository *mocks.MockMonthlyStatementRepository
+	cloudinaryService          *servicemocks.MockCloudinaryService
+	notifier                   *servicemocks.MockCustomerNotifier
+}
+
+func statementDetail(contractNumber string, installments int) *dto.TransactionDetailResponse {
+	detail := &dto.TransactionDetailResponse{
+		ContractNumber:         contractNumber,
+		AssetName:              "Motor Listrik",
+		Status:                 string(domain.TransactionActive),
+		Currency:               "IDR",
+		TransactionDate:        time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		TenorMonths:            uint8(installments),
+		OTRAmount:              decimal.NewFromInt(1500000),
+		TotalInstallmentAmount: decimal.NewFromInt(1620000),
+		MonthlyInstallment:     decimal.NewFromInt(1620000).Div(decimal.NewFromInt(int64(installments))),
+		OutstandingBalance:     decimal.NewFromInt(1620000),
+	}
+	for i := 1; i <= installments; i++ {
+		detail.Installments = append(detail.Installments, dto.InstallmentDetailResponse{
+			Sequence: i,
+			DueDate:  detail.TransactionDate.AddDate(0, i, 0),
+			Amount:   detail.MonthlyInstallment,
+			Status:   "UNPAID",
+		})
+	}
+	return detail
+}
+
+func TestStatementService_WithMocks(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-statement-service")
+	ctx := context.Background()
+
+	templates, err := statementsrv.LoadTemplates()
+	require.NoError(t, err)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	cfg := statementsrv.Config{
+		Branding: statementsrv.Branding{
+			CompanyName: "PT Multifinance Indonesia",
+			Address:     "Jl. Sudirman 1, Jakarta",
+			Contact:     "halo@multifinance.id",
+			Color:       pdf.Color{R: 0.04, G: 0.37, B: 0.65},
+		},
+		SyncMaxInstallments: 12,
+		Folder:              "statements",
+		BatchSize:           5,
+		Location:            jakarta,
+		MonthlyBatchSize:    50,
+	}
+	customer := &domain.Customer{ID: 1, NIK: "3271010101900001", LegalName: "Budi Santoso", Language: domain.LanguageIndonesian}
+
+	setup := func(t *testing.T) (statementMocks, func() service.StatementServices) {
+		ctrl := gomock.NewController(t)
+		m := statementMocks{
+			profileService:             servicemocks.NewMockProfileServices(ctrl),
+			customerRepository:         mocks.NewMockCustomerRepository(ctrl),
+			statementRepository:        mocks.NewMockStatementRepository(ctrl),
+			monthlyStatementRepository: mocks.NewMockMonthlyStatementRepository(ctrl),
+			cloudinaryService:          servicemocks.NewMockCloudinaryService(ctrl),
+			notifier:                   servicemocks.NewMockCustomerNotifier(ctrl),
+		}
+		return m, func() service.StatementServices {
+			return statementsrv.NewStatementService(m.profileService, m.customerRepository, m.statementRepository, m.monthlyStatementRepository, m.cloudinaryService, m.notifier, templates, cfg, meter, tracer, log)
+		}
+	}
+
+	t.Run("Small Statement Is Rendered And Stored", func(t *testing.T) {
+		m, newService := setup(t)
+
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-1").Return(statementDetail("CN-1", 6), nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+		m.statementRepository.EXPECT().FindByContractNumber(gomock.Any(), "CN-1").Return(nil, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", gomock.Any()).
+			DoAndReturn(func(_ context.Context, data []byte, _, publicID string) (string, error) {
+				assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+				assert.Contains(t, publicID, "CN-1-")
+				return "https://files.example/CN-1.pdf", nil
+			})
+		m.statementRepository.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.TransactionStatement) error {
+				assert.Equal(t, domain.StatementReady, statement.Status)
+				assert.Equal(t, "https://files.example/CN-1.pdf", statement.URL)
+				assert.Len(t, statement.Fingerprint, 64)
+				return nil
+			})
+
+		statement, document, err := newService().GetStatement(ctx, 1, "CN-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatementReady, statement.Status)
+		require.NotNil(t, document)
+		assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+		// Isi halaman tidak dikompresi, jadi teks hasil template bisa dicek langsung
+		assert.Contains(t, string(document), "(Laporan Kontrak Pembiayaan)")
+		assert.Contains(t, string(document), "(PT Multifinance Indonesia)")
+		assert.Contains(t, string(document), "(327101******0001)")
+		assert.Contains(t, string(document), "(IDR 1.500.000,00)")
+		assert.NotContains(t, string(document), "3271010101900001")
+	})
+
+	t.Run("English Customer Gets English Layout", func(t *testing.T) {
+		m, newService := setup(t)
+		english := *customer
+		english.Language = domain.LanguageEnglish
+
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-1").Return(statementDetail("CN-1", 3), nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(&english, nil)
+		m.statementRepository.EXPECT().FindByContractNumber(gomock.Any(), "CN-1").Return(nil, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", gomock.Any()).Return("https://files.example/CN-1.pdf", nil)
+		m.statementRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, document, err := newService().GetStatement(ctx, 1, "CN-1")
+
+		require.NoError(t, err)
+		assert.Contains(t, string(document), "(Financing Contract Statement)")
+		assert.Contains(t, string(document), "(IDR 1,500,000.00)")
+	})
+
+	t.Run("Large Statement Is Queued", func(t *testing.T) {
+		m, newService := setup(t)
+
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-2").Return(statementDetail("CN-2", 24), nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+		m.statementRepository.EXPECT().FindByContractNumber(gomock.Any(), "CN-2").Return(nil, nil)
+		m.statementRepository.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.TransactionStatement) error {
+				assert.Equal(t, domain.StatementPending, statement.Status)
+				return nil
+			})
+
+		statement, document, err := newService().GetStatement(ctx, 1, "CN-2")
+
+		require.NoError(t, err)
+		assert.Nil(t, document)
+		assert.Equal(t, domain.StatementPending, statement.Status)
+	})
+
+	t.Run("Unchanged Statement Is Reused", func(t *testing.T) {
+		// Fingerprint diambil dari render pertama agar statement kedua dianggap sama
+		m, newService := setup(t)
+		detail := statementDetail("CN-3", 6)
+		var saved domain.TransactionStatement
+
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-3").Return(detail, nil).Times(2)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil).Times(2)
+		m.statementRepository.EXPECT().FindByContractNumber(gomock.Any(), "CN-3").Return(nil, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", gomock.Any()).Return("https://files.example/CN-3.pdf", nil)
+		m.statementRepository.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.TransactionStatement) error {
+				saved = *statement
+				return nil
+			})
+
+		statementService := newService()
+		_, _, err := statementService.GetStatement(ctx, 1, "CN-3")
+		require.NoError(t, err)
+
+		m.statementRepository.EXPECT().FindByContractNumber(gomock.Any(), "CN-3").Return(&saved, nil)
+
+		statement, document, err := statementService.GetStatement(ctx, 1, "CN-3")
+
+		require.NoError(t, err)
+		assert.Nil(t, document)
+		assert.Equal(t, "https://files.example/CN-3.pdf", statement.URL)
+	})
+
+	t.Run("Changed Contract Is Rendered Again", func(t *testing.T) {
+		m, newService := setup(t)
+		existing := &domain.TransactionStatement{ID: 9, ContractNumber: "CN-4", Fingerprint: "stale", Status: domain.StatementReady, URL: "https://files.example/old.pdf"}
+
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-4").Return(statementDetail("CN-4", 6), nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+		m.statementRepository.EXPECT().FindByContractNumber(gomock.Any(), "CN-4").Return(existing, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", gomock.Any()).Return("https://files.example/new.pdf", nil)
+		m.statementRepository.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.TransactionStatement) error {
+				assert.Equal(t, uint64(9), statement.ID)
+				assert.Equal(t, "https://files.example/new.pdf", statement.URL)
+				return nil
+			})
+
+		_, document, err := newService().GetStatement(ctx, 1, "CN-4")
+
+		require.NoError(t, err)
+		assert.NotNil(t, document)
+	})
+
+	t.Run("Upload Failure Still Returns PDF", func(t *testing.T) {
+		m, newService := setup(t)
+
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-5").Return(statementDetail("CN-5", 6), nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+		m.statementRepository.EXPECT().FindByContractNumber(gomock.Any(), "CN-5").Return(nil, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", gomock.Any()).Return("", errors.New("cloudinary down"))
+		m.statementRepository.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.TransactionStatement) error {
+				assert.Equal(t, domain.StatementFailed, statement.Status)
+				assert.Equal(t, "cloudinary down", statement.Error)
+				return nil
+			})
+
+		statement, document, err := newService().GetStatement(ctx, 1, "CN-5")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatementFailed, statement.Status)
+		assert.NotNil(t, document)
+	})
+
+	t.Run("Foreign Contract Is Not Found", func(t *testing.T) {
+		m, newService := setup(t)
+
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-X").Return(nil, common.ErrTransactionNotFound)
+
+		statement, document, err := newService().GetStatement(ctx, 1, "CN-X")
+
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+		assert.Nil(t, statement)
+		assert.Nil(t, document)
+	})
+
+	t.Run("Process Pending", func(t *testing.T) {
+		m, newService := setup(t)
+		pending := []domain.TransactionStatement{
+			{ID: 1, ContractNumber: "CN-6", CustomerID: 1, Status: domain.StatementPending},
+			{ID: 2, ContractNumber: "CN-7", CustomerID: 1, Status: domain.StatementPending},
+		}
+
+		m.statementRepository.EXPECT().FindPending(gomock.Any(), 5).Return(pending, nil)
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-6").Return(statementDetail("CN-6", 60), nil)
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-7").Return(nil, common.ErrTransactionNotFound)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(1)).Return(customer, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", gomock.Any()).
+			DoAndReturn(func(_ context.Context, data []byte, _, _ string) (string, error) {
+				// 60 angsuran tidak muat di satu halaman
+				assert.Contains(t, string(data), "/Count 3")
+				return "https://files.example/CN-6.pdf", nil
+			})
+
+		var saved []domain.TransactionStatement
+		m.statementRepository.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.TransactionStatement) error {
+				saved = append(saved, *statement)
+				return nil
+			}).Times(2)
+
+		ready, err := newService().ProcessPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, ready)
+		require.Len(t, saved, 2)
+		assert.Equal(t, domain.StatementReady, saved[0].Status)
+		assert.Equal(t, "https://files.example/CN-6.pdf", saved[0].URL)
+		assert.Len(t, saved[0].Fingerprint, 64)
+		assert.Equal(t, domain.StatementFailed, saved[1].Status)
+		assert.Equal(t, common.ErrTransactionNotFound.Error(), saved[1].Error)
+	})
+
+	t.Run("Monthly Statement Covers Previous Month", func(t *testing.T) {
+		m, newService := setup(t)
+		// 1 Maret 01:00 WIB masih 28 Februari di UTC, periodenya tetap Februari
+		now := time.Date(2025, 2, 28, 18, 0, 0, 0, time.UTC)
+		from := time.Date(2025, 2, 1, 0, 0, 0, 0, jakarta)
+		to := time.Date(2025, 3, 1, 0, 0, 0, 0, jakarta)
+		paidAt := time.Date(2025, 2, 10, 3, 0, 0, 0, time.UTC)
+		recipient := *customer
+		recipient.FullName = "Budi"
+
+		m.monthlyStatementRepository.EXPECT().FindRecipients(gomock.Any(), "2025-02", from, to, 50).Return([]domain.Customer{recipient}, nil)
+		m.monthlyStatementRepository.EXPECT().FindContracts(gomock.Any(), uint64(1)).Return([]domain.Transaction{
+			{ID: 10, ContractNumber: "CN-OLD", Status: domain.TransactionActive, Currency: "IDR", TransactionDate: time.Date(2024, 11, 5, 0, 0, 0, 0, jakarta), Tenor: domain.Tenor{DurationMonths: 12}},
+			{ID: 11, ContractNumber: "CN-NEW", Status: domain.TransactionApproved, Currency: "IDR", OTRAmount: decimal.NewFromInt(2000000), AssetName: "Kulkas", TransactionDate: time.Date(2025, 2, 14, 0, 0, 0, 0, jakarta), Tenor: domain.Tenor{DurationMonths: 6}},
+			{ID: 12, ContractNumber: "CN-DONE", Status: domain.TransactionPaidOff, Currency: "IDR", TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, jakarta), Tenor: domain.Tenor{DurationMonths: 12}},
+		}, nil)
+		m.monthlyStatementRepository.EXPECT().FindPayments(gomock.Any(), uint64(1), from, to).Return([]domain.Payment{
+			{TransactionID: 10, Amount: decimal.NewFromInt(135000), Currency: "IDR", Status: domain.PaymentPaid, PaidAt: &paidAt},
+		}, nil)
+
+		old := statementDetail("CN-OLD", 12)
+		old.OutstandingBalance = decimal.NewFromInt(1200000)
+		fresh := statementDetail("CN-NEW", 6)
+		fresh.OutstandingBalance = decimal.NewFromInt(2100000)
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-OLD").Return(old, nil)
+		m.profileService.EXPECT().GetMyTransactionDetail(gomock.Any(), uint64(1), "CN-NEW").Return(fresh, nil)
+
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", "monthly-1-2025-02").
+			DoAndReturn(func(_ context.Context, data []byte, _, _ string) (string, error) {
+				assert.Contains(t, string(data), "(Ringkasan Akun Bulanan)")
+				assert.Contains(t, string(data), "(CN-NEW)")
+				assert.Contains(t, string(data), "(IDR 135.000,00)")
+				assert.Contains(t, string(data), "(6 bulan)")
+				assert.NotContains(t, string(data), "CN-DONE")
+				return "https://files.example/monthly-1-2025-02.pdf", nil
+			})
+		m.notifier.EXPECT().Notify(gomock.Any(), uint64(1), domain.TemplateMonthlyStatement, map[string]string{
+			"full_name":        "Budi",
+			"period":           "2025-02",
+			"statement_url":    "https://files.example/monthly-1-2025-02.pdf",
+			"new_transactions": "1",
+			"payments":         "IDR 135.000,00",
+			"outstanding":      "IDR 3.300.000,00",
+		}).Return(nil)
+		m.monthlyStatementRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.MonthlyStatement) (bool, error) {
+				assert.Equal(t, domain.CommunicationSent, statement.Status)
+				assert.Equal(t, "2025-02", statement.Period)
+				assert.Equal(t, "https://files.example/monthly-1-2025-02.pdf", statement.URL)
+				return true, nil
+			})
+
+		run, err := newService().SendMonthlyStatements(ctx, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.MonthlyStatementRun{Period: "2025-02", Sent: 1}, run)
+	})
+
+	t.Run("Monthly Statement Failure Is Recorded", func(t *testing.T) {
+		m, newService := setup(t)
+		now := time.Date(2025, 3, 15, 0, 0, 0, 0, jakarta)
+
+		m.monthlyStatementRepository.EXPECT().FindRecipients(gomock.Any(), "2025-02", gomock.Any(), gomock.Any(), 50).Return([]domain.Customer{*customer}, nil)
+		m.monthlyStatementRepository.EXPECT().FindContracts(gomock.Any(), uint64(1)).Return(nil, nil)
+		m.monthlyStatementRepository.EXPECT().FindPayments(gomock.Any(), uint64(1), gomock.Any(), gomock.Any()).Return(nil, nil)
+		m.cloudinaryService.EXPECT().UploadFile(gomock.Any(), gomock.Any(), "statements", "monthly-1-2025-02").Return("https://files.example/m.pdf", nil)
+		m.notifier.EXPECT().Notify(gomock.Any(), uint64(1), domain.TemplateMonthlyStatement, gomock.Any()).Return(errors.New("smtp down"))
+		m.monthlyStatementRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, statement *domain.MonthlyStatement) (bool, error) {
+				assert.Equal(t, domain.CommunicationFailed, statement.Status)
+				assert.Contains(t, statement.Error, "smtp down")
+				return true, nil
+			})
+
+		run, err := newService().SendMonthlyStatements(ctx, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, run.Sent)
+		assert.Equal(t, 1, run.Failed)
+	})
+
+	t.Run("Monthly Statement Opt Out", func(t *testing.T) {
+		m, newService := setup(t)
+
+		m.monthlyStatementRepository.EXPECT().SetOptOut(gomock.Any(), uint64(1), true).Return(true, nil)
+
+		assert.NoError(t, newService().SetMonthlyStatementOptOut(ctx, 1, true))
+	})
+
+	t.Run("Monthly Statement Opt Out Unknown Customer", func(t *testing.T) {
+		m, newService := setup(t)
+
+		m.monthlyStatementRepository.EXPECT().SetOptOut(gomock.Any(), uint64(99), false).Return(false, nil)
+		m.customerRepository.EXPECT().FindByID(gomock.Any(), uint64(99)).Return(nil, nil)
+
+		err := newService().SetMonthlyStatementOptOut(ctx, 99, false)
+
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	tenorsrv "github.com/fazamuttaqien/multifinance/internal/service/tenor"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTenorService_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-tenor-service")
+
+	t.Run("Create Tenor With Default Description", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{{ID: 1, DurationMonths: 3}}, nil)
+		tenorRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, tenor *domain.Tenor) error {
+				tenor.ID = 8
+				return nil
+			})
+
+		tenor, err := tenorService.CreateTenor(context.Background(), dto.CreateTenorRequest{DurationMonths: 18})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(8), tenor.ID)
+		assert.Equal(t, "18 Months", tenor.Description)
+		assert.True(t, tenor.Active)
+	})
+
+	t.Run("Create Tenor With Product Rules", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(nil, nil)
+		tenorRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		tenor, err := tenorService.CreateTenor(context.Background(), dto.CreateTenorRequest{
+			DurationMonths: 24,
+			TenorProductRequest: dto.TenorProductRequest{
+				MinAmount:       decimal.NewFromInt(5000000),
+				MaxAmount:       decimal.NewFromInt(50000000),
+				MinAge:          21,
+				MaxAge:          55,
+				MaxAgeAtEnd:     65,
+				AssetCategories: []string{" Motor", "mobil", "MOTOR", ""},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"motor", "mobil"}, tenor.AssetCategories)
+		assert.Equal(t, uint8(55), tenor.MaxAge)
+		assert.Equal(t, uint8(65), tenor.MaxAgeAtEnd)
+		assert.True(t, tenor.MinAmount.Equal(decimal.NewFromInt(5000000)))
+	})
+
+	t.Run("Create Tenor Rejects Inverted Bounds", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(nil, nil)
+
+		_, err := tenorService.CreateTenor(context.Background(), dto.CreateTenorRequest{
+			DurationMonths:      24,
+			TenorProductRequest: dto.TenorProductRequest{MinAge: 60, MaxAge: 55},
+		})
+
+		assert.ErrorIs(t, err, common.ErrInvalidTenorProduct)
+	})
+
+	t.Run("Create Tenor Rejects Age At End Below Minimum Age", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return(nil, nil)
+
+		_, err := tenorService.CreateTenor(context.Background(), dto.CreateTenorRequest{
+			DurationMonths:      24,
+			TenorProductRequest: dto.TenorProductRequest{MinAge: 30, MaxAgeAtEnd: 25},
+		})
+
+		assert.ErrorIs(t, err, common.ErrInvalidTenorProduct)
+	})
+
+	t.Run("Create Tenor Rejects Inactive Duplicate", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{{ID: 1, DurationMonths: 18, Active: false}}, nil)
+
+		_, err := tenorService.CreateTenor(context.Background(), dto.CreateTenorRequest{DurationMonths: 18})
+
+		assert.ErrorIs(t, err, common.ErrTenorExists)
+	})
+
+	t.Run("Deactivate Tenor", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindByID(gomock.Any(), uint(4)).Return(&domain.Tenor{ID: 4, DurationMonths: 6, Description: "6 Months", Active: true}, nil)
+		tenorRepository.EXPECT().Update(gomock.Any(), domain.Tenor{ID: 4, DurationMonths: 6, Description: "6 Months", Active: false}).Return(true, nil)
+
+		tenor, err := tenorService.DeactivateTenor(context.Background(), 4)
+
+		require.NoError(t, err)
+		assert.False(t, tenor.Active)
+	})
+
+	t.Run("Delete Tenor In Use", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().IsReferenced(gomock.Any(), uint(4)).Return(true, nil)
+
+		err := tenorService.DeleteTenor(context.Background(), 4)
+
+		assert.ErrorIs(t, err, common.ErrTenorInUse)
+	})
+
+	t.Run("Delete Tenor Not Found", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().IsReferenced(gomock.Any(), uint(99)).Return(false, nil)
+		tenorRepository.EXPECT().Delete(gomock.Any(), uint(99)).Return(false, nil)
+
+		err := tenorService.DeleteTenor(context.Background(), 99)
+
+		assert.ErrorIs(t, err, common.ErrTenorNotFound)
+	})
+
+	t.Run("Seed Upserts Tenors", func(t *testing.T) {
+		tenorRepository := mocks.NewMockTenorRepository(gomock.NewController(t))
+		tenorService := tenorsrv.NewTenorService(tenorRepository, meter, tracer, log)
+
+		tenorRepository.EXPECT().FindAll(gomock.Any()).Return([]domain.Tenor{{ID: 1, DurationMonths: 1, Description: "Old", Active: false}}, nil).Times(2)
+		tenorRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, tenor domain.Tenor) (bool, error) {
+				assert.Equal(t, uint(1), tenor.ID)
+				assert.Equal(t, "1 Months", tenor.Description)
+				assert.Equal(t, []string{"motor"}, tenor.AssetCategories)
+				assert.False(t, tenor.Active)
+				return true, nil
+			})
+		tenorRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, tenor *domain.Tenor) error {
+				assert.Equal(t, uint8(2), tenor.DurationMonths)
+				return nil
+			})
+
+		err := tenorService.SeedTenors(context.Background(), []dto.CreateTenorRequest{
+			{DurationMonths: 1, TenorProductRequest: dto.TenorProductRequest{AssetCategories: []string{"Motor"}}},
+			{DurationMonths: 2, Description: "2 Months"},
+		})
+
+		assert.NoError(t, err)
+	})
+}
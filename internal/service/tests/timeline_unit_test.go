@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	timelinesrv "github.com/fazamuttaqien/multifinance/internal/service/timeline"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCustomerTimelineService_GetTimeline_WithMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	customerEventRepository := mocks.NewMockCustomerEventRepository(ctrl)
+	customerRepository := mocks.NewMockCustomerRepository(ctrl)
+	meter, tracer, log := testutil.Telemetry("test-timeline-service")
+	timelineService := timelinesrv.NewCustomerTimelineService(customerEventRepository, customerRepository, meter, tracer, log)
+
+	params := domain.Params{Page: 1, Limit: 50}
+
+	t.Run("Success", func(t *testing.T) {
+		events := []domain.CustomerEvent{
+			{ID: 1, CustomerID: 2, Type: domain.CustomerRegistered},
+			{ID: 4, CustomerID: 2, Type: domain.CustomerTransactionCreated, ReferenceID: "KTR-20250101-000001"},
+		}
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(2)).Return(&domain.Customer{ID: 2}, nil)
+		customerEventRepository.EXPECT().FindByCustomer(gomock.Any(), uint64(2), params).Return(events, int64(2), nil)
+
+		res, err := timelineService.GetTimeline(context.Background(), 2, params)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), res.Total)
+		assert.Equal(t, events, res.Data)
+	})
+
+	t.Run("Failure - Customer Not Found", func(t *testing.T) {
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(9)).Return(nil, nil)
+
+		res, err := timelineService.GetTimeline(context.Background(), 9, params)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrCustomerNotFound)
+	})
+
+	t.Run("Failure - Repository Error", func(t *testing.T) {
+		repoErr := errors.New("connection reset")
+		customerRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.Customer{ID: 3}, nil)
+		customerEventRepository.EXPECT().FindByCustomer(gomock.Any(), uint64(3), params).Return(nil, int64(0), repoErr)
+
+		res, err := timelineService.GetTimeline(context.Background(), 3, params)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	virtualaccountsrv "github.com/fazamuttaqien/multifinance/internal/service/virtualaccount"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/virtualaccount"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type failingProvider struct{ failFor uint64 }
+
+func (p failingProvider) Bank() string { return "BNI" }
+
+func (p failingProvider) Open(_ context.Context, req virtualaccount.Request) (string, error) {
+	if req.TransactionID == p.failFor {
+		return "", errors.New("bank timeout")
+	}
+	return "9880000000000042", nil
+}
+
+func TestVirtualAccountService_ProvisionPending_WithMockRepository(t *testing.T) {
+	meter, tracer, log := testutil.Telemetry("test-virtual-account-service")
+	cfg := virtualaccountsrv.Config{BatchSize: 50}
+
+	t.Run("Stub Numbers Are Assigned", func(t *testing.T) {
+		virtualAccountRepository := mocks.NewMockVirtualAccountRepository(gomock.NewController(t))
+		stub, err := virtualaccount.NewStub("BCA", "88088")
+		require.NoError(t, err)
+		virtualAccountService := virtualaccountsrv.NewVirtualAccountService(virtualAccountRepository, stub, cfg, meter, tracer, log)
+
+		virtualAccountRepository.EXPECT().FindUnprovisioned(gomock.Any(), 50).
+			Return([]domain.Transaction{{ID: 7, ContractNumber: "CN-7"}, {ID: 12345, ContractNumber: "CN-12345"}}, nil)
+		virtualAccountRepository.EXPECT().Assign(gomock.Any(), uint64(7), "BCA", "8808800000000007").Return(true, nil)
+		virtualAccountRepository.EXPECT().Assign(gomock.Any(), uint64(12345), "BCA", "8808800000012345").Return(true, nil)
+
+		provisioned, err := virtualAccountService.ProvisionPending(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, provisioned)
+	})
+
+	t.Run("Provider Failure Skips Contract", func(t *testing.T) {
+		virtualAccountRepository := mocks.NewMockVirtualAccountRepository(gomock.NewController(t))
+		virtualAccountService := virtualaccountsrv.NewVirtualAccountService(virtualAccountRepository, failingProvider{failFor: 1}, cfg, meter, tracer, log)
+
+		virtualAccountRepository.EXPECT().FindUnprovisioned(gomock.Any(), 50).
+			Return([]domain.Transaction{{ID: 1}, {ID: 42}}, nil)
+		virtualAccountRepository.EXPECT().Assign(gomock.Any(), uint64(42), "BNI", "9880000000000042").Return(true, nil)
+
+		provisioned, err := virtualAccountService.ProvisionPending(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, provisioned)
+	})
+
+	t.Run("Already Assigned Is Not Counted", func(t *testing.T) {
+		virtualAccountRepository := mocks.NewMockVirtualAccountRepository(gomock.NewController(t))
+		virtualAccountService := virtualaccountsrv.NewVirtualAccountService(virtualAccountRepository, failingProvider{}, cfg, meter, tracer, log)
+
+		virtualAccountRepository.EXPECT().FindUnprovisioned(gomock.Any(), 50).Return([]domain.Transaction{{ID: 42}}, nil)
+		virtualAccountRepository.EXPECT().Assign(gomock.Any(), uint64(42), "BNI", gomock.Any()).Return(false, nil)
+
+		provisioned, err := virtualAccountService.ProvisionPending(context.Background())
+
+		require.NoError(t, err)
+		assert.Zero(t, provisioned)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		virtualAccountRepository := mocks.NewMockVirtualAccountRepository(gomock.NewController(t))
+		virtualAccountService := virtualaccountsrv.NewVirtualAccountService(virtualAccountRepository, failingProvider{}, cfg, meter, tracer, log)
+
+		virtualAccountRepository.EXPECT().FindUnprovisioned(gomock.Any(), 50).Return(nil, errors.New("db down"))
+
+		_, err := virtualAccountService.ProvisionPending(context.Background())
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/repository/mocks"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	writeoffsrv "github.com/fazamuttaqien/multifinance/internal/service/writeoff"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type writeOffMocks struct {
+	transactionRepository *mocks.MockTransactionRepository
+	tenorRepository       *mocks.MockTenorRepository
+	writeOffRepository    *mocks.MockWriteOffRepository
+	ledgerRepository      *mocks.MockLedgerRepository
+}
+
+func newWriteOffService(t *testing.T) (*writeOffMocks, service.WriteOffServices) {
+	meter, tracer, log := testutil.Telemetry("test-write-off-service")
+
+	ctrl := gomock.NewController(t)
+	m := &writeOffMocks{
+		transactionRepository: mocks.NewMockTransactionRepository(ctrl),
+		tenorRepository:       mocks.NewMockTenorRepository(ctrl),
+		writeOffRepository:    mocks.NewMockWriteOffRepository(ctrl),
+		ledgerRepository:      mocks.NewMockLedgerRepository(ctrl),
+	}
+	return m, writeoffsrv.NewWriteOffService(nil, m.transactionRepository, m.tenorRepository, m.writeOffRepository, m.ledgerRepository,
+		testutil.DueDateRules(installment.Rules{}), writeoffsrv.Config{MinDaysPastDue: 180}, meter, tracer, log)
+}
+
+func TestWriteOffService_Propose(t *testing.T) {
+	tenors := []domain.Tenor{{ID: 4, DurationMonths: 12}}
+	contract := func(paid int) *domain.Transaction {
+		return &domain.Transaction{
+			ID:                     10,
+			ContractNumber:         "KTR-010",
+			CustomerID:             7,
+			TenorID:                4,
+			TotalInstallmentAmount: decimal.NewFromInt(12_000_000),
+			Status:                 domain.TransactionActive,
+			TransactionDate:        time.Now().AddDate(-1, 0, -5),
+			PaidInstallments:       &paid,
+			FxRate:                 decimal.NewFromInt(1),
+		}
+	}
+
+	t.Run("Success - Unpaid Balance Proposed", func(t *testing.T) {
+		m, writeOffService := newWriteOffService(t)
+
+		m.transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-010").Return(contract(2), nil)
+		m.writeOffRepository.EXPECT().FindPendingByTransactionID(gomock.Any(), uint64(10)).Return(nil, nil)
+		m.tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+		m.writeOffRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		writeOff, err := writeOffService.Propose(context.Background(), 1, dto.WriteOffRequest{ContractNumber: "KTR-010", Reason: "Customer unreachable"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.WriteOffPending, writeOff.Status)
+		assert.Equal(t, uint64(7), writeOff.CustomerID)
+		assert.Equal(t, "10000000", writeOff.Amount.String())
+		assert.GreaterOrEqual(t, writeOff.DaysPastDue, 180)
+		assert.True(t, writeOff.Outstanding().Equal(writeOff.Amount))
+	})
+
+	t.Run("Failure - Not Past Due Long Enough", func(t *testing.T) {
+		m, writeOffService := newWriteOffService(t)
+
+		m.transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-010").Return(contract(9), nil)
+		m.writeOffRepository.EXPECT().FindPendingByTransactionID(gomock.Any(), uint64(10)).Return(nil, nil)
+		m.tenorRepository.EXPECT().FindAll(gomock.Any()).Return(tenors, nil)
+
+		_, err := writeOffService.Propose(context.Background(), 1, dto.WriteOffRequest{ContractNumber: "KTR-010", Reason: "Customer unreachable"})
+
+		assert.ErrorIs(t, err, common.ErrWriteOffNotEligible)
+	})
+
+	t.Run("Failure - Already Pending", func(t *testing.T) {
+		m, writeOffService := newWriteOffService(t)
+
+		m.transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-010").Return(contract(2), nil)
+		m.writeOffRepository.EXPECT().FindPendingByTransactionID(gomock.Any(), uint64(10)).Return(&domain.WriteOff{ID: 3}, nil)
+
+		_, err := writeOffService.Propose(context.Background(), 1, dto.WriteOffRequest{ContractNumber: "KTR-010", Reason: "Customer unreachable"})
+
+		assert.ErrorIs(t, err, common.ErrWriteOffExists)
+	})
+
+	t.Run("Failure - Contract Not Active", func(t *testing.T) {
+		m, writeOffService := newWriteOffService(t)
+
+		written := contract(2)
+		written.Status = domain.TransactionWrittenOff
+		m.transactionRepository.EXPECT().FindByContractNumber(gomock.Any(), "KTR-010").Return(written, nil)
+
+		_, err := writeOffService.Propose(context.Background(), 1, dto.WriteOffRequest{ContractNumber: "KTR-010", Reason: "Customer unreachable"})
+
+		assert.ErrorIs(t, err, common.ErrTransactionNotActive)
+	})
+}
+
+func TestWriteOffService_RecordRecovery_Validation(t *testing.T) {
+	_, writeOffService := newWriteOffService(t)
+
+	t.Run("Failure - Future Date", func(t *testing.T) {
+		_, err := writeOffService.RecordRecovery(context.Background(), 1, 2, dto.WriteOffRecoveryRequest{
+			Amount:     decimal.NewFromInt(500_000),
+			ReceivedOn: time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+		})
+		assert.ErrorIs(t, err, common.ErrInvalidRecovery)
+	})
+
+	t.Run("Failure - Zero Amount", func(t *testing.T) {
+		_, err := writeOffService.RecordRecovery(context.Background(), 1, 2, dto.WriteOffRecoveryRequest{Amount: decimal.Zero})
+		assert.ErrorIs(t, err, common.ErrInvalidRecovery)
+	})
+}
+
+func TestWriteOffService_Report(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		m, writeOffService := newWriteOffService(t)
+
+		to := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+		m.writeOffRepository.EXPECT().Report(gomock.Any(), from, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]domain.WriteOffPeriod{{Period: from, Count: 2, Amount: decimal.NewFromInt(8_000_000), Recovered: decimal.NewFromInt(1_000_000)}}, nil)
+
+		periods, err := writeOffService.Report(context.Background(), from, to)
+
+		require.NoError(t, err)
+		require.Len(t, periods, 1)
+		assert.Equal(t, "0.125", periods[0].RecoveryRate().String())
+	})
+
+	t.Run("Failure - Range Too Long", func(t *testing.T) {
+		_, writeOffService := newWriteOffService(t)
+
+		_, err := writeOffService.Report(context.Background(), from, from.AddDate(2, 0, 0))
+		assert.ErrorIs(t, err, common.ErrInvalidWriteOffPeriod)
+	})
+
+	t.Run("Failure - Reversed Range", func(t *testing.T) {
+		_, writeOffService := newWriteOffService(t)
+
+		_, err := writeOffService.Report(context.Background(), from, from.AddDate(0, -1, 0))
+		assert.ErrorIs(t, err, common.ErrInvalidWriteOffPeriod)
+	})
+}
+
+func TestWriteOffService_Ledger(t *testing.T) {
+	m, writeOffService := newWriteOffService(t)
+
+	posting := domain.NewPosting(domain.LedgerWriteOff, 3, 10, domain.LedgerBadDebtExpense, domain.LedgerLoanReceivable,
+		decimal.NewFromInt(10_000_000), time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), "Write-off KTR-010")
+	m.writeOffRepository.EXPECT().FindByID(gomock.Any(), uint64(3)).Return(&domain.WriteOff{ID: 3, TransactionID: 10}, nil)
+	m.ledgerRepository.EXPECT().FindByTransactionID(gomock.Any(), uint64(10)).Return(posting, nil)
+
+	entries, err := writeOffService.Ledger(context.Background(), 3)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, domain.LedgerBadDebtExpense, entries[0].Account)
+	assert.True(t, entries[0].Debit.Equal(entries[1].Credit))
+	assert.True(t, entries[0].Credit.IsZero())
+	assert.True(t, entries[1].Debit.IsZero())
+}
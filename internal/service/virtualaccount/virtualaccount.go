@@ -0,0 +1,172 @@
+package virtualaccountsrv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/virtualaccount"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Config bounds how many contracts a single provisioning run handles.
+type Config struct {
+	BatchSize int
+}
+
+type virtualAccountService struct {
+	virtualAccountRepository repository.VirtualAccountRepository
+	provider                 virtualaccount.Provider
+	cfg                      Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration metric.Float64Histogram
+	operationCount    metric.Int64Counter
+	errorCount        metric.Int64Counter
+	provisionedCount  metric.Int64Counter
+}
+
+// ProvisionPending implements VirtualAccountServices.
+func (s *virtualAccountService) ProvisionPending(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ProvisionVirtualAccounts")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.String("virtual_account.bank", s.provider.Bank()))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "provision_virtual_accounts"), attribute.String("service", "virtual_account")))
+
+	contracts, err := s.virtualAccountRepository.FindUnprovisioned(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return 0, s.recordError(ctx, span, start, "provision_virtual_accounts", "repository_error", fmt.Errorf("failed to find contracts without virtual account: %w", err))
+	}
+
+	provisioned, failed := 0, 0
+	for _, contract := range contracts {
+		number, err := s.provider.Open(ctx, virtualaccount.Request{
+			TransactionID:  contract.ID,
+			ContractNumber: contract.ContractNumber,
+			CustomerName:   contract.Customer.FullName,
+			Amount:         contract.TotalInstallmentAmount,
+			Currency:       contract.Currency,
+		})
+		if err != nil {
+			// Kontrak yang gagal dicoba lagi pada putaran berikutnya
+			s.log.Warn("Failed to open virtual account",
+				zap.Uint64("transaction_id", contract.ID),
+				zap.String("bank", s.provider.Bank()),
+				zap.Error(err),
+			)
+			failed++
+			continue
+		}
+
+		assigned, err := s.virtualAccountRepository.Assign(ctx, contract.ID, s.provider.Bank(), number)
+		if err != nil {
+			return provisioned, s.recordError(ctx, span, start, "provision_virtual_accounts", "repository_error", fmt.Errorf("failed to assign virtual account: %w", err))
+		}
+		if assigned {
+			provisioned++
+		}
+	}
+
+	s.provisionedCount.Add(ctx, int64(provisioned), metric.WithAttributes(attribute.String("bank", s.provider.Bank()), attribute.String("status", "success")))
+	s.provisionedCount.Add(ctx, int64(failed), metric.WithAttributes(attribute.String("bank", s.provider.Bank()), attribute.String("status", "error")))
+
+	span.SetAttributes(
+		attribute.Int("virtual_account.provisioned", provisioned),
+		attribute.Int("virtual_account.failed", failed),
+	)
+	s.recordSuccess(ctx, span, start, "provision_virtual_accounts",
+		zap.String("bank", s.provider.Bank()),
+		zap.Int("provisioned", provisioned),
+		zap.Int("failed", failed),
+	)
+
+	return provisioned, nil
+}
+
+func (s *virtualAccountService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Virtual account operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "virtual_account"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "virtual_account"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *virtualAccountService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "virtual_account"), attribute.String("status", "success")))
+
+	s.log.Info("Virtual account operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewVirtualAccountService(
+	virtualAccountRepository repository.VirtualAccountRepository,
+	provider virtualaccount.Provider,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.VirtualAccountServices {
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	provisionedCount, _ := meter.Int64Counter(
+		"service.virtual_accounts.provisioned",
+		metric.WithDescription("Number of virtual accounts opened by bank and outcome"),
+		metric.WithUnit("{account}"),
+	)
+
+	return &virtualAccountService{
+		virtualAccountRepository: virtualAccountRepository,
+		provider:                 provider,
+		cfg:                      cfg,
+		meter:                    meter,
+		tracer:                   tracer,
+		log:                      log,
+		operationDuration:        operationDuration,
+		operationCount:           operationCount,
+		errorCount:               errorCount,
+		provisionedCount:         provisionedCount,
+	}
+}
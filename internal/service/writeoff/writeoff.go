@@ -0,0 +1,629 @@
+package writeoffsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/dto"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/repository"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
+	ledgerrepo "github.com/fazamuttaqien/multifinance/internal/repository/ledger"
+	restrictionrepo "github.com/fazamuttaqien/multifinance/internal/repository/restriction"
+	writeoffrepo "github.com/fazamuttaqien/multifinance/internal/repository/writeoff"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// maxReportMonths bounds the write-off report to two years of months.
+const maxReportMonths = 24
+
+// Config sets how far behind a contract must be before it can be written
+// off.
+type Config struct {
+	MinDaysPastDue int
+}
+
+type writeOffService struct {
+	db                    *gorm.DB
+	transactionRepository repository.TransactionRepository
+	tenorRepository       repository.TenorRepository
+	writeOffRepository    repository.WriteOffRepository
+	ledgerRepository      repository.LedgerRepository
+	dueDateRules          service.DueDateRules
+	cfg                   Config
+
+	meter  metric.Meter
+	tracer trace.Tracer
+	log    *zap.Logger
+
+	operationDuration  metric.Float64Histogram
+	operationCount     metric.Int64Counter
+	errorCount         metric.Int64Counter
+	writeOffsReviewed  metric.Int64Counter
+	recoveriesRecorded metric.Int64Counter
+}
+
+// Propose implements WriteOffServices.
+func (s *writeOffService) Propose(ctx context.Context, makerID uint64, req dto.WriteOffRequest) (*domain.WriteOff, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ProposeWriteOff")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("transaction.contract_number", req.ContractNumber),
+		attribute.Int64("maker.id", int64(makerID)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "propose_write_off"), attribute.String("service", "write_off")))
+
+	transaction, err := s.transactionRepository.FindByContractNumber(ctx, req.ContractNumber)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "repository_error", fmt.Errorf("failed to get transaction: %w", err))
+	}
+	if transaction == nil || transaction.IsSandbox {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "transaction_not_found", common.ErrTransactionNotFound)
+	}
+	if transaction.Status != domain.TransactionActive {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "transaction_not_active", common.ErrTransactionNotActive)
+	}
+
+	// Satu pengajuan write-off per kontrak sampai direview
+	pending, err := s.writeOffRepository.FindPendingByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "repository_error", fmt.Errorf("failed to check pending write-off: %w", err))
+	}
+	if pending != nil {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "write_off_pending", common.ErrWriteOffExists)
+	}
+
+	tenors, err := s.tenorRepository.FindAll(ctx)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "repository_error", fmt.Errorf("failed to get tenors: %w", err))
+	}
+
+	var months int
+	for _, tenor := range tenors {
+		if tenor.ID == transaction.TenorID {
+			months = int(tenor.DurationMonths)
+			break
+		}
+	}
+	if months == 0 {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "tenor_not_found", common.ErrTenorNotFound)
+	}
+
+	days, unpaid := balance(transaction, months, s.dueDateRules.Rules(ctx), time.Now())
+	if days < s.cfg.MinDaysPastDue || !unpaid.IsPositive() {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "not_eligible", common.ErrWriteOffNotEligible)
+	}
+
+	writeOff := &domain.WriteOff{
+		TransactionID:   transaction.ID,
+		CustomerID:      transaction.CustomerID,
+		Status:          domain.WriteOffPending,
+		DaysPastDue:     days,
+		Amount:          unpaid,
+		RecoveredAmount: decimal.Zero,
+		Reason:          req.Reason,
+		RequestedBy:     makerID,
+		Transaction:     transaction,
+	}
+
+	if err := s.writeOffRepository.Create(ctx, writeOff); err != nil {
+		return nil, s.recordError(ctx, span, start, "propose_write_off", "repository_error", fmt.Errorf("failed to create write-off: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "propose_write_off",
+		zap.Uint64("write_off_id", writeOff.ID),
+		zap.Uint64("transaction_id", transaction.ID),
+		zap.String("amount", unpaid.String()),
+	)
+
+	return writeOff, nil
+}
+
+// Review implements WriteOffServices.
+func (s *writeOffService) Review(ctx context.Context, id, checkerID uint64, req dto.WriteOffReviewRequest) (*domain.WriteOff, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ReviewWriteOff")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("write_off.id", int64(id)),
+		attribute.Int64("checker.id", int64(checkerID)),
+		attribute.String("write_off.decision", string(req.Status)),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "review_write_off"), attribute.String("service", "write_off")))
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, s.recordError(ctx, span, start, "review_write_off", "transaction_begin_error", tx.Error)
+	}
+	defer tx.Rollback()
+
+	writeOffTx := writeoffrepo.NewWriteOffRepository(tx, s.meter, s.log)
+
+	// 1. Kunci pengajuan agar tidak direview dua kali
+	writeOff, err := writeOffTx.FindByIDWithLock(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "review_write_off", "repository_error", fmt.Errorf("failed to get write-off: %w", err))
+	}
+	if writeOff == nil {
+		return nil, s.recordError(ctx, span, start, "review_write_off", "write_off_not_found", common.ErrWriteOffNotFound)
+	}
+	if writeOff.Status != domain.WriteOffPending {
+		return nil, s.recordError(ctx, span, start, "review_write_off", "already_reviewed", common.ErrWriteOffReviewed)
+	}
+
+	// 2. Maker-checker: pengaju tidak boleh menyetujui pengajuannya sendiri
+	if writeOff.RequestedBy == checkerID {
+		return nil, s.recordError(ctx, span, start, "review_write_off", "self_review", common.ErrWriteOffSelf)
+	}
+
+	now := time.Now()
+	if req.Status == domain.WriteOffApproved {
+		if err := s.approve(ctx, tx, writeOff, checkerID, now); err != nil {
+			if errors.Is(err, common.ErrTransactionNotActive) {
+				return nil, s.recordError(ctx, span, start, "review_write_off", "transaction_not_active", err)
+			}
+			return nil, s.recordError(ctx, span, start, "review_write_off", "approve_error", err)
+		}
+	}
+
+	writeOff.Status = req.Status
+	writeOff.ReviewerID = &checkerID
+	writeOff.ReviewNote = req.Note
+	writeOff.ReviewedAt = &now
+
+	if err := writeOffTx.Update(ctx, writeOff); err != nil {
+		return nil, s.recordError(ctx, span, start, "review_write_off", "repository_error", fmt.Errorf("failed to update write-off: %w", err))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "review_write_off", "transaction_commit_error", err)
+	}
+
+	s.writeOffsReviewed.Add(ctx, 1, metric.WithAttributes(attribute.String("decision", string(req.Status))))
+	s.recordSuccess(ctx, span, start, "review_write_off",
+		zap.Uint64("write_off_id", id),
+		zap.String("decision", string(req.Status)),
+	)
+
+	return writeOff, nil
+}
+
+// approve takes the contract off the books inside the review transaction:
+// the contract stops counting as active, the unpaid balance moves from loan
+// receivable to bad debt expense and the customer is suspended so the limit
+// the contract used cannot be drawn again.
+func (s *writeOffService) approve(ctx context.Context, tx *gorm.DB, writeOff *domain.WriteOff, checkerID uint64, now time.Time) error {
+	result := tx.Model(&model.Transaction{}).
+		Where("id = ? AND status = ?", writeOff.TransactionID, model.TransactionActive).
+		Update("status", model.TransactionWrittenOff)
+	if result.Error != nil {
+		return fmt.Errorf("failed to write off transaction: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrTransactionNotActive
+	}
+
+	exposureTx := exposurerepo.NewCustomerExposureRepository(tx, s.meter, s.log)
+	if err := exposureTx.Recompute(ctx, writeOff.CustomerID); err != nil {
+		return fmt.Errorf("failed to recompute customer exposure: %w", err)
+	}
+
+	contractNumber := ""
+	if writeOff.Transaction != nil {
+		contractNumber = writeOff.Transaction.ContractNumber
+	}
+
+	ledgerTx := ledgerrepo.NewLedgerRepository(tx, s.meter, s.log)
+	posting := domain.NewPosting(domain.LedgerWriteOff, writeOff.ID, writeOff.TransactionID,
+		domain.LedgerBadDebtExpense, domain.LedgerLoanReceivable, writeOff.Amount, day(now),
+		"Write-off "+contractNumber)
+	if err := ledgerTx.Post(ctx, posting); err != nil {
+		return fmt.Errorf("failed to post write-off: %w", err)
+	}
+
+	eventTx := customereventrepo.NewCustomerEventRepository(tx, s.meter, s.log)
+	if err := eventTx.Append(ctx, &domain.CustomerEvent{
+		CustomerID:  writeOff.CustomerID,
+		Type:        domain.CustomerWrittenOff,
+		ActorID:     &checkerID,
+		ReferenceID: contractNumber,
+		Data: map[string]string{
+			"write_off_id": strconv.FormatUint(writeOff.ID, 10),
+			"amount":       writeOff.Amount.String(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to record write-off event: %w", err)
+	}
+
+	// Suspensi yang sudah aktif dibiarkan, cukup satu per customer
+	restrictionTx := restrictionrepo.NewCustomerRestrictionRepository(tx, s.meter, s.log)
+	active, err := restrictionTx.FindActive(ctx, writeOff.CustomerID, now)
+	if err != nil {
+		return fmt.Errorf("failed to get customer restrictions: %w", err)
+	}
+	for _, restriction := range active {
+		if restriction.IsSuspension() {
+			return nil
+		}
+	}
+
+	restriction := &domain.CustomerRestriction{
+		CustomerID: writeOff.CustomerID,
+		Reason:     domain.RestrictionDelinquency,
+		Note:       "Contract " + contractNumber + " written off",
+		CreatedBy:  checkerID,
+	}
+	if err := restrictionTx.Create(ctx, restriction); err != nil {
+		return fmt.Errorf("failed to suspend customer: %w", err)
+	}
+
+	return eventTx.Append(ctx, &domain.CustomerEvent{
+		CustomerID:  writeOff.CustomerID,
+		Type:        domain.CustomerSuspended,
+		ActorID:     &checkerID,
+		ReferenceID: strconv.FormatUint(restriction.ID, 10),
+		Data: map[string]string{
+			"reason": string(restriction.Reason),
+			"note":   restriction.Note,
+		},
+	})
+}
+
+// GetWriteOff implements WriteOffServices.
+func (s *writeOffService) GetWriteOff(ctx context.Context, id uint64) (*domain.WriteOff, error) {
+	ctx, span := s.tracer.Start(ctx, "service.GetWriteOff")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("write_off.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_write_off"), attribute.String("service", "write_off")))
+
+	writeOff, err := s.writeOffRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "get_write_off", "repository_error", fmt.Errorf("failed to get write-off: %w", err))
+	}
+	if writeOff == nil {
+		return nil, s.recordError(ctx, span, start, "get_write_off", "write_off_not_found", common.ErrWriteOffNotFound)
+	}
+
+	s.recordSuccess(ctx, span, start, "get_write_off", zap.Uint64("write_off_id", id))
+
+	return writeOff, nil
+}
+
+// ListWriteOffs implements WriteOffServices.
+func (s *writeOffService) ListWriteOffs(ctx context.Context, params domain.Params) (*domain.Paginated, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListWriteOffs")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int("pagination.page", params.Page),
+		attribute.Int("pagination.limit", params.Limit),
+		attribute.String("filter.status", params.Value("status")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_write_offs"), attribute.String("service", "write_off")))
+
+	writeOffs, total, err := s.writeOffRepository.FindPaginated(ctx, params)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_write_offs", "repository_error", fmt.Errorf("failed to list write-offs: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_write_offs", zap.Int64("total", total))
+
+	return params.Paginated(writeOffs, total), nil
+}
+
+// RecordRecovery implements WriteOffServices.
+func (s *writeOffService) RecordRecovery(ctx context.Context, id, adminID uint64, req dto.WriteOffRecoveryRequest) (*domain.WriteOffRecovery, error) {
+	ctx, span := s.tracer.Start(ctx, "service.RecordWriteOffRecovery")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.Int64("write_off.id", int64(id)),
+		attribute.Int64("admin.id", int64(adminID)),
+		attribute.String("recovery.amount", req.Amount.String()),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "record_recovery"), attribute.String("service", "write_off")))
+
+	today := day(time.Now())
+	receivedOn := today
+	if req.ReceivedOn != "" {
+		parsed, err := time.Parse("2006-01-02", req.ReceivedOn)
+		if err != nil {
+			return nil, s.recordError(ctx, span, start, "record_recovery", "invalid_recovery", common.ErrInvalidRecovery)
+		}
+		receivedOn = parsed
+	}
+	if !req.Amount.IsPositive() || receivedOn.After(today) {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "invalid_recovery", common.ErrInvalidRecovery)
+	}
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "transaction_begin_error", tx.Error)
+	}
+	defer tx.Rollback()
+
+	writeOffTx := writeoffrepo.NewWriteOffRepository(tx, s.meter, s.log)
+
+	// Kunci write-off supaya dua recovery tidak melewati sisa saldo bersamaan
+	writeOff, err := writeOffTx.FindByIDWithLock(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "repository_error", fmt.Errorf("failed to get write-off: %w", err))
+	}
+	if writeOff == nil {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "write_off_not_found", common.ErrWriteOffNotFound)
+	}
+	if writeOff.Status != domain.WriteOffApproved {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "write_off_not_approved", common.ErrWriteOffNotApproved)
+	}
+	if req.Amount.GreaterThan(writeOff.Outstanding()) {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "exceeds_balance", common.ErrRecoveryExceedsBalance)
+	}
+
+	recovery := &domain.WriteOffRecovery{
+		WriteOffID: writeOff.ID,
+		Amount:     req.Amount,
+		ReceivedOn: receivedOn,
+		Reference:  req.Reference,
+		Note:       req.Note,
+		CreatedBy:  adminID,
+	}
+	if err := writeOffTx.AddRecovery(ctx, recovery); err != nil {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "repository_error", fmt.Errorf("failed to add recovery: %w", err))
+	}
+
+	writeOff.RecoveredAmount = writeOff.RecoveredAmount.Add(req.Amount)
+	if err := writeOffTx.Update(ctx, writeOff); err != nil {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "repository_error", fmt.Errorf("failed to update write-off: %w", err))
+	}
+
+	description := "Recovery"
+	if writeOff.Transaction != nil {
+		description += " " + writeOff.Transaction.ContractNumber
+	}
+	posting := domain.NewPosting(domain.LedgerWriteOffRecovery, recovery.ID, writeOff.TransactionID,
+		domain.LedgerCash, domain.LedgerBadDebtRecovery, recovery.Amount, receivedOn, description)
+	if err := ledgerrepo.NewLedgerRepository(tx, s.meter, s.log).Post(ctx, posting); err != nil {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "repository_error", fmt.Errorf("failed to post recovery: %w", err))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, s.recordError(ctx, span, start, "record_recovery", "transaction_commit_error", err)
+	}
+
+	s.recoveriesRecorded.Add(ctx, 1, metric.WithAttributes(attribute.String("service", "write_off")))
+	s.recordSuccess(ctx, span, start, "record_recovery",
+		zap.Uint64("write_off_id", id),
+		zap.Uint64("recovery_id", recovery.ID),
+		zap.String("outstanding", writeOff.Outstanding().String()),
+	)
+
+	return recovery, nil
+}
+
+// ListRecoveries implements WriteOffServices.
+func (s *writeOffService) ListRecoveries(ctx context.Context, id uint64) ([]domain.WriteOffRecovery, error) {
+	ctx, span := s.tracer.Start(ctx, "service.ListWriteOffRecoveries")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("write_off.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "list_recoveries"), attribute.String("service", "write_off")))
+
+	writeOff, err := s.writeOffRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_recoveries", "repository_error", fmt.Errorf("failed to get write-off: %w", err))
+	}
+	if writeOff == nil {
+		return nil, s.recordError(ctx, span, start, "list_recoveries", "write_off_not_found", common.ErrWriteOffNotFound)
+	}
+
+	recoveries, err := s.writeOffRepository.FindRecoveries(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "list_recoveries", "repository_error", fmt.Errorf("failed to list recoveries: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "list_recoveries", zap.Int("count", len(recoveries)))
+
+	return recoveries, nil
+}
+
+// Ledger implements WriteOffServices.
+func (s *writeOffService) Ledger(ctx context.Context, id uint64) ([]domain.LedgerEntry, error) {
+	ctx, span := s.tracer.Start(ctx, "service.WriteOffLedger")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(attribute.Int64("write_off.id", int64(id)))
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "write_off_ledger"), attribute.String("service", "write_off")))
+
+	writeOff, err := s.writeOffRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "write_off_ledger", "repository_error", fmt.Errorf("failed to get write-off: %w", err))
+	}
+	if writeOff == nil {
+		return nil, s.recordError(ctx, span, start, "write_off_ledger", "write_off_not_found", common.ErrWriteOffNotFound)
+	}
+
+	entries, err := s.ledgerRepository.FindByTransactionID(ctx, writeOff.TransactionID)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "write_off_ledger", "repository_error", fmt.Errorf("failed to get ledger entries: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "write_off_ledger", zap.Int("count", len(entries)))
+
+	return entries, nil
+}
+
+// Report implements WriteOffServices.
+func (s *writeOffService) Report(ctx context.Context, from, to time.Time) ([]domain.WriteOffPeriod, error) {
+	ctx, span := s.tracer.Start(ctx, "service.WriteOffReport")
+	defer span.End()
+
+	start := time.Now()
+	span.SetAttributes(
+		attribute.String("report.from", from.Format("2006-01")),
+		attribute.String("report.to", to.Format("2006-01")),
+	)
+	s.operationCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "write_off_report"), attribute.String("service", "write_off")))
+
+	from = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if to.Before(from) || !to.Before(from.AddDate(0, maxReportMonths, 0)) {
+		return nil, s.recordError(ctx, span, start, "write_off_report", "invalid_period", common.ErrInvalidWriteOffPeriod)
+	}
+
+	periods, err := s.writeOffRepository.Report(ctx, from, to)
+	if err != nil {
+		return nil, s.recordError(ctx, span, start, "write_off_report", "repository_error", fmt.Errorf("failed to build write-off report: %w", err))
+	}
+
+	s.recordSuccess(ctx, span, start, "write_off_report", zap.Int("periods", len(periods)))
+
+	return periods, nil
+}
+
+// balance returns how many days the contract is past due at asOf and the
+// installments not paid yet, due or not, in IDR.
+func balance(transaction *domain.Transaction, months int, rules installment.Rules, asOf time.Time) (int, decimal.Decimal) {
+	paid := min(rules.Elapsed(transaction.TransactionDate, asOf), months)
+	if transaction.PaidInstallments != nil {
+		paid = min(max(*transaction.PaidInstallments, 0), months)
+	}
+
+	// Jadwal dibuat untuk seluruh tenor supaya pembulatannya sama dengan tagihan
+	unpaid := decimal.Zero
+	for _, inst := range rules.Schedule(transaction.TransactionDate, 1, months, transaction.TotalInstallmentAmount) {
+		if inst.Sequence > paid {
+			unpaid = unpaid.Add(inst.Amount)
+		}
+	}
+	// Kontrak lama tanpa kurs sudah dalam rupiah
+	if transaction.FxRate.IsPositive() {
+		unpaid = unpaid.Mul(transaction.FxRate).Round(2)
+	}
+
+	return rules.DaysPastDue(transaction.TransactionDate, paid, months, asOf), unpaid
+}
+
+// day truncates t to midnight UTC of its calendar day, matching how DATE
+// columns are read back.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *writeOffService) recordError(ctx context.Context, span trace.Span, start time.Time, operation, errorType string, err error) error {
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+
+	s.log.Error("Write-off operation failed",
+		zap.String("operation", operation),
+		zap.String("error_type", errorType),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.Error(err),
+	)
+
+	s.errorCount.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "write_off"), attribute.String("error_type", errorType)))
+	s.operationDuration.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "write_off"), attribute.String("status", "error")))
+
+	return err
+}
+
+func (s *writeOffService) recordSuccess(ctx context.Context, span trace.Span, start time.Time, operation string, fields ...zap.Field) {
+	duration := float64(time.Since(start).Milliseconds())
+	s.operationDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("operation", operation), attribute.String("service", "write_off"), attribute.String("status", "success")))
+
+	s.log.Info("Write-off operation completed", append(fields,
+		zap.String("operation", operation),
+		zap.Float64("duration_ms", duration),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)...)
+
+	span.SetStatus(codes.Ok, "Operation completed successfully")
+}
+
+func NewWriteOffService(
+	db *gorm.DB,
+	transactionRepository repository.TransactionRepository,
+	tenorRepository repository.TenorRepository,
+	writeOffRepository repository.WriteOffRepository,
+	ledgerRepository repository.LedgerRepository,
+	dueDateRules service.DueDateRules,
+	cfg Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	log *zap.Logger,
+) service.WriteOffServices {
+	if cfg.MinDaysPastDue <= 0 {
+		cfg.MinDaysPastDue = 180
+	}
+
+	operationDuration, _ := meter.Float64Histogram(
+		"service.operation.duration",
+		metric.WithDescription("Duration of service operations"),
+		metric.WithUnit("ms"),
+	)
+
+	operationCount, _ := meter.Int64Counter(
+		"service.operation.count",
+		metric.WithDescription("Number of service operations"),
+		metric.WithUnit("{operation}"),
+	)
+
+	errorCount, _ := meter.Int64Counter(
+		"service.error.count",
+		metric.WithDescription("Number of service errors"),
+		metric.WithUnit("{error}"),
+	)
+
+	writeOffsReviewed, _ := meter.Int64Counter(
+		"service.write_offs.reviewed",
+		metric.WithDescription("Number of write-offs reviewed"),
+		metric.WithUnit("{write_off}"),
+	)
+
+	recoveriesRecorded, _ := meter.Int64Counter(
+		"service.write_off_recoveries.recorded",
+		metric.WithDescription("Number of recoveries recorded on written-off contracts"),
+		metric.WithUnit("{recovery}"),
+	)
+
+	return &writeOffService{
+		db:                    db,
+		transactionRepository: transactionRepository,
+		tenorRepository:       tenorRepository,
+		writeOffRepository:    writeOffRepository,
+		ledgerRepository:      ledgerRepository,
+		dueDateRules:          dueDateRules,
+		cfg:                   cfg,
+		meter:                 meter,
+		tracer:                tracer,
+		log:                   log,
+		operationDuration:     operationDuration,
+		operationCount:        operationCount,
+		errorCount:            errorCount,
+		writeOffsReviewed:     writeOffsReviewed,
+		recoveriesRecorded:    recoveriesRecorded,
+	}
+}
@@ -0,0 +1,79 @@
+package testutil
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// AuthCookie signs a JWT for the given user and wraps it in the "private"
+// cookie read by the JWT auth middleware.
+func AuthCookie(t testing.TB, secret string, userID uint64, role domain.Role) *http.Cookie {
+	t.Helper()
+
+	return signedCookie(t, secret, &domain.JwtCustomClaims{
+		UserID: userID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+}
+
+// ImpersonationCookie is AuthCookie for a token issued to adminID acting as
+// customerID through the given impersonation session.
+func ImpersonationCookie(t testing.TB, secret string, adminID, customerID, sessionID uint64) *http.Cookie {
+	t.Helper()
+
+	return signedCookie(t, secret, &domain.JwtCustomClaims{
+		UserID:          customerID,
+		Role:            domain.CustomerRole,
+		ImpersonatorID:  adminID,
+		ImpersonationID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+}
+
+// PasswordChangeCookie is AuthCookie for an account that must change its
+// password before using anything else, like the seeded admin.
+func PasswordChangeCookie(t testing.TB, secret string, userID uint64, role domain.Role) *http.Cookie {
+	t.Helper()
+
+	return signedCookie(t, secret, &domain.JwtCustomClaims{
+		UserID:             userID,
+		Role:               role,
+		MustChangePassword: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+}
+
+// TenantCookie is AuthCookie for a token issued by the given tenant.
+func TenantCookie(t testing.TB, secret, tenantID string, userID uint64, role domain.Role) *http.Cookie {
+	t.Helper()
+
+	return signedCookie(t, secret, &domain.JwtCustomClaims{
+		UserID: userID,
+		Role:   role,
+		Tenant: tenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+}
+
+func signedCookie(t testing.TB, secret string, claims *domain.JwtCustomClaims) *http.Cookie {
+	t.Helper()
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	return &http.Cookie{Name: "private", Value: signed}
+}
@@ -0,0 +1,167 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	tcmysql "github.com/testcontainers/testcontainers-go/modules/mysql"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+const (
+	mysqlImage    = "mysql:8.0"
+	redisImage    = "redis:7-alpine"
+	mysqlPassword = "rootpassword123"
+)
+
+// MySQLServer describes a running MySQL server reachable with root credentials.
+type MySQLServer struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+}
+
+// DSN returns the connection string for the given database name. An empty
+// name connects to the server without selecting a schema.
+func (s MySQLServer) DSN(dbName string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+		s.User, s.Password, s.Host, s.Port, dbName)
+}
+
+// ErrDockerUnavailable is returned when no container runtime is reachable, so
+// suites can skip instead of failing on machines without Docker.
+var ErrDockerUnavailable = errors.New("docker is not available")
+
+var (
+	mysqlOnce   sync.Once
+	mysqlServer MySQLServer
+	mysqlErr    error
+
+	redisOnce sync.Once
+	redisAddr string
+	redisErr  error
+)
+
+// StartMySQL starts a single MySQL container shared by every suite in the
+// test binary. Containers are reaped by testcontainers once the process exits.
+//
+// Setting TEST_MYSQL_HOST skips the container and points the harness at an
+// already running server (TEST_MYSQL_PORT, TEST_MYSQL_USER and
+// TEST_MYSQL_PASSWORD are honoured as well).
+func StartMySQL(ctx context.Context) (MySQLServer, error) {
+	mysqlOnce.Do(func() {
+		if host := common.GetEnv("TEST_MYSQL_HOST", ""); host != "" {
+			mysqlServer = MySQLServer{
+				Host:     host,
+				Port:     common.GetEnv("TEST_MYSQL_PORT", "3306"),
+				User:     common.GetEnv("TEST_MYSQL_USER", "root"),
+				Password: common.GetEnv("TEST_MYSQL_PASSWORD", mysqlPassword),
+			}
+			return
+		}
+
+		if err := checkDocker(ctx); err != nil {
+			mysqlErr = err
+			return
+		}
+
+		container, err := tcmysql.Run(ctx, mysqlImage,
+			tcmysql.WithUsername("root"),
+			tcmysql.WithPassword(mysqlPassword),
+			tcmysql.WithDatabase("loan_system_test"),
+		)
+		if err != nil {
+			mysqlErr = fmt.Errorf("failed to start mysql container: %w", err)
+			return
+		}
+
+		host, err := container.Host(ctx)
+		if err != nil {
+			mysqlErr = fmt.Errorf("failed to resolve mysql host: %w", err)
+			return
+		}
+		port, err := container.MappedPort(ctx, "3306/tcp")
+		if err != nil {
+			mysqlErr = fmt.Errorf("failed to resolve mysql port: %w", err)
+			return
+		}
+
+		mysqlServer = MySQLServer{Host: host, Port: port.Port(), User: "root", Password: mysqlPassword}
+	})
+
+	return mysqlServer, mysqlErr
+}
+
+// StartRedis starts a single Redis container shared by every suite in the test
+// binary and returns its address. TEST_REDIS_ADDRESS overrides the container.
+func StartRedis(ctx context.Context) (string, error) {
+	redisOnce.Do(func() {
+		if addr := common.GetEnv("TEST_REDIS_ADDRESS", ""); addr != "" {
+			redisAddr = addr
+			return
+		}
+
+		if err := checkDocker(ctx); err != nil {
+			redisErr = err
+			return
+		}
+
+		container, err := tcredis.Run(ctx, redisImage)
+		if err != nil {
+			redisErr = fmt.Errorf("failed to start redis container: %w", err)
+			return
+		}
+
+		endpoint, err := container.PortEndpoint(ctx, "6379/tcp", "")
+		if err != nil {
+			redisErr = fmt.Errorf("failed to resolve redis endpoint: %w", err)
+			return
+		}
+		redisAddr = endpoint
+	})
+
+	return redisAddr, redisErr
+}
+
+// NewRedisClient returns a client connected to the shared Redis container.
+// The selected logical database is flushed so each caller starts empty.
+func NewRedisClient(ctx context.Context) (*redis.Client, error) {
+	addr, err := StartRedis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.FlushDB(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to flush redis: %w", err)
+	}
+
+	return client, nil
+}
+
+func checkDocker(ctx context.Context) (err error) {
+	// testcontainers panics when it cannot resolve a Docker host.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrDockerUnavailable, r)
+		}
+	}()
+
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDockerUnavailable, err)
+	}
+	defer provider.Close()
+
+	if err := provider.Health(ctx); err != nil {
+		return fmt.Errorf("%w: %v", ErrDockerUnavailable, err)
+	}
+	return nil
+}
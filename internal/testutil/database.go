@@ -0,0 +1,105 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	noop_metric "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	noop_trace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Database is an isolated, migrated schema on the shared MySQL server.
+type Database struct {
+	DB   *gorm.DB
+	Name string
+}
+
+// NewDatabase creates a fresh schema named name, runs the model migrations
+// and registers a cleanup that drops the schema once t finishes.
+func NewDatabase(t testing.TB, name string) *Database {
+	t.Helper()
+
+	ctx := context.Background()
+	server, err := StartMySQL(ctx)
+	if errors.Is(err, ErrDockerUnavailable) {
+		t.Skipf("skipping integration suite: %v", err)
+	}
+	require.NoError(t, err)
+
+	admin, err := sql.Open("mysql", server.DSN(""))
+	require.NoError(t, err)
+	defer admin.Close()
+
+	_, err = admin.ExecContext(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", name))
+	require.NoError(t, err)
+	_, err = admin.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE `%s`", name))
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(mysql.Open(server.DSN(name)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, model.AutoMigrate(gormDB))
+
+	db := &Database{DB: gormDB, Name: name}
+	t.Cleanup(func() { db.drop(server) })
+
+	return db
+}
+
+// Truncate empties the given tables, ignoring foreign key constraints.
+func Truncate(t testing.TB, db *gorm.DB, tables ...string) {
+	t.Helper()
+
+	require.NoError(t, db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SET FOREIGN_KEY_CHECKS = 0").Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SET FOREIGN_KEY_CHECKS = 1")
+
+		for _, table := range tables {
+			if err := conn.Exec(fmt.Sprintf("TRUNCATE TABLE `%s`", table)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// Reset truncates every table owned by the core models.
+func Reset(t testing.TB, db *gorm.DB) {
+	t.Helper()
+	Truncate(t, db, "concentration_limits", "ledger_entries", "write_off_recoveries", "write_offs", "collection_activities", "collection_cases", "partner_debug_records", "aml_cases", "customer_restrictions", "customer_events", "customer_exposures", "contract_sequences", "regions", "transaction_attachments", "customer_note_revisions", "customer_notes", "monthly_statements", "transaction_statements", "transaction_batch_items", "transaction_batches", "partner_quotas", "impersonation_requests", "impersonation_sessions", "referrals", "referral_codes", "promotion_redemptions", "promotions", "direct_debit_attempts", "direct_debit_mandates", "payment_callbacks", "payments", "partner_fee_schedules", "fx_rates", "restructurings", "duplicate_resolutions", "screening_logs", "blacklist_entries", "customer_risk_tiers", "risk_tier_rules", "income_verifications", "salary_changes", "webhook_deliveries", "transaction_keys", "transactions", "customer_limit_history", "customer_limits", "tenors", "customers", "partners")
+}
+
+func (d *Database) drop(server MySQLServer) {
+	if sqlDB, err := d.DB.DB(); err == nil {
+		sqlDB.Close()
+	}
+
+	admin, err := sql.Open("mysql", server.DSN(""))
+	if err != nil {
+		return
+	}
+	defer admin.Close()
+	admin.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", d.Name))
+}
+
+// Telemetry returns no-op instrumentation suitable for constructing
+// repositories, services and handlers in tests.
+func Telemetry(name string) (metric.Meter, trace.Tracer, *zap.Logger) {
+	meter := noop_metric.NewMeterProvider().Meter(name)
+	tracer := noop_trace.NewTracerProvider().Tracer(name)
+	return meter, tracer, zap.NewNop()
+}
@@ -0,0 +1,20 @@
+package testutil
+
+import (
+	"context"
+
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/installment"
+)
+
+type fixedRules installment.Rules
+
+func (r fixedRules) Rules(context.Context) installment.Rules {
+	return installment.Rules(r)
+}
+
+// DueDateRules always hands out rules; the zero Rules keep nominal due
+// dates, as before any policy is configured.
+func DueDateRules(rules installment.Rules) service.DueDateRules {
+	return fixedRules(rules)
+}
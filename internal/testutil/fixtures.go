@@ -0,0 +1,145 @@
+package testutil
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/pkg/password"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultTenorDurations mirrors the master tenors seeded by the application.
+var DefaultTenorDurations = []uint8{1, 2, 3, 6, 9, 12, 24}
+
+// SeedTenors inserts tenors for the given durations (DefaultTenorDurations when
+// none are given) and returns them in the same order.
+func SeedTenors(t testing.TB, db *gorm.DB, durations ...uint8) []model.Tenor {
+	t.Helper()
+
+	if len(durations) == 0 {
+		durations = DefaultTenorDurations
+	}
+
+	tenors := make([]model.Tenor, len(durations))
+	for i, d := range durations {
+		tenors[i] = model.Tenor{DurationMonths: d, Description: fmt.Sprintf("%d Months", d)}
+	}
+
+	require.NoError(t, db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "duration_months"}},
+		DoNothing: true,
+	}).Create(&tenors).Error)
+
+	return tenors
+}
+
+// SeedAdmin inserts an administrator with the given plain text password.
+func SeedAdmin(t testing.TB, db *gorm.DB, plainPassword string) *model.Customer {
+	t.Helper()
+
+	hashed, err := password.HashPassword(plainPassword)
+	require.NoError(t, err)
+
+	return NewCustomer().
+		WithNIK("1010010110100101").
+		WithName("Administrator").
+		WithRole(model.AdminRole).
+		WithPassword(hashed).
+		Create(t, db)
+}
+
+// SeedLimit sets the limit of a customer for a tenor.
+func SeedLimit(t testing.TB, db *gorm.DB, customerID uint64, tenorID uint, amount decimal.Decimal) *model.CustomerLimit {
+	t.Helper()
+
+	limit := &model.CustomerLimit{CustomerID: customerID, TenorID: tenorID, LimitAmount: amount}
+	require.NoError(t, db.Create(limit).Error)
+	return limit
+}
+
+var nikSequence atomic.Uint64
+
+// CustomerBuilder builds customer rows with sensible defaults. Every builder
+// gets a unique NIK so fixtures never collide on the unique index.
+type CustomerBuilder struct {
+	customer model.Customer
+}
+
+// NewCustomer returns a builder for a verified customer.
+func NewCustomer() *CustomerBuilder {
+	seq := nikSequence.Add(1)
+	return &CustomerBuilder{customer: model.Customer{
+		NIK:                fmt.Sprintf("99%014d", seq),
+		FullName:           "John Doe",
+		LegalName:          "John Doe",
+		Password:           "johndoe123",
+		Role:               model.CustomerRole,
+		BirthPlace:         "Jakarta",
+		BirthDate:          time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:             decimal.NewFromInt(5000000),
+		KtpPhotoUrl:        "https://example.com/ktp.jpg",
+		SelfiePhotoUrl:     "https://example.com/selfie.jpg",
+		VerificationStatus: model.VerificationVerified,
+	}}
+}
+
+func (b *CustomerBuilder) WithNIK(nik string) *CustomerBuilder {
+	b.customer.NIK = nik
+	return b
+}
+
+func (b *CustomerBuilder) WithName(name string) *CustomerBuilder {
+	b.customer.FullName = name
+	b.customer.LegalName = name
+	return b
+}
+
+func (b *CustomerBuilder) WithPassword(password string) *CustomerBuilder {
+	b.customer.Password = password
+	return b
+}
+
+func (b *CustomerBuilder) WithRole(role model.Role) *CustomerBuilder {
+	b.customer.Role = role
+	return b
+}
+
+func (b *CustomerBuilder) WithSalary(salary decimal.Decimal) *CustomerBuilder {
+	b.customer.Salary = salary
+	return b
+}
+
+func (b *CustomerBuilder) WithBirthDate(birthDate time.Time) *CustomerBuilder {
+	b.customer.BirthDate = birthDate
+	return b
+}
+
+func (b *CustomerBuilder) WithStatus(status model.VerificationStatus) *CustomerBuilder {
+	b.customer.VerificationStatus = status
+	return b
+}
+
+func (b *CustomerBuilder) WithCreatedAt(createdAt time.Time) *CustomerBuilder {
+	b.customer.CreatedAt = createdAt
+	return b
+}
+
+// Build returns the customer without persisting it.
+func (b *CustomerBuilder) Build() model.Customer {
+	return b.customer
+}
+
+// Create persists the customer and returns the stored row.
+func (b *CustomerBuilder) Create(t testing.TB, db *gorm.DB) *model.Customer {
+	t.Helper()
+
+	customer := b.customer
+	require.NoError(t, db.Create(&customer).Error)
+	return &customer
+}
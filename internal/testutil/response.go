@@ -0,0 +1,31 @@
+package testutil
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+)
+
+// DecodeEnvelope reads a responder envelope from body, decoding its data
+// field into data when data is not nil. The returned envelope carries the
+// meta and error parts; its Data is left empty.
+func DecodeEnvelope(t testing.TB, body io.Reader, data any) responder.Envelope {
+	t.Helper()
+
+	var raw struct {
+		Data  json.RawMessage  `json:"data"`
+		Meta  responder.Meta   `json:"meta"`
+		Error *responder.Error `json:"error"`
+	}
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		t.Fatalf("failed to decode response envelope: %v", err)
+	}
+	if data != nil && len(raw.Data) > 0 {
+		if err := json.Unmarshal(raw.Data, data); err != nil {
+			t.Fatalf("failed to decode response data: %v", err)
+		}
+	}
+	return responder.Envelope{Meta: raw.Meta, Error: raw.Error}
+}
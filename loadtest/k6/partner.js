@@ -0,0 +1,166 @@
+// Load scenarios for the partner API key endpoints. Thresholds come from
+// loadtest/baseline.json so k6 exits non-zero when p95 latency or the error
+// rate regresses past the committed budget.
+//
+//   go run ./cmd/seed -customers 500 -seed 42 -nik-file loadtest/niks.json
+//   k6 run -e API_KEY=... loadtest/k6/partner.js
+//
+// Environment:
+//   BASE_URL        API prefix, default http://localhost:3001/api/v2
+//   API_KEY         partner API key (X-API-Key), required
+//   SIGNING_SECRET  partner signing secret, only when the partner requires signatures
+//   NIK_FILE        JSON array of verified NIKs, default ../niks.json
+//   SCENARIO        run a single scenario by name, default all of them
+//   TENOR_MONTHS    tenor used by every request, default 6
+import http from 'k6/http';
+import crypto from 'k6/crypto';
+import { check } from 'k6';
+import { SharedArray } from 'k6/data';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:3001/api/v2';
+const API_KEY = __ENV.API_KEY;
+const SIGNING_SECRET = __ENV.SIGNING_SECRET || '';
+const TENOR_MONTHS = parseInt(__ENV.TENOR_MONTHS || '6', 10);
+
+const baseline = JSON.parse(open('../baseline.json')).k6;
+const niks = new SharedArray('niks', () => JSON.parse(open(__ENV.NIK_FILE || '../niks.json')));
+
+// Limit habis (422) adalah jawaban bisnis yang valid, bukan error beban
+http.setResponseCallback(http.expectedStatuses(200, 201, 422));
+
+const scenarios = {
+  check_limit: {
+    executor: 'constant-arrival-rate',
+    exec: 'checkLimit',
+    rate: 50,
+    timeUnit: '1s',
+    duration: '1m',
+    preAllocatedVUs: 20,
+    maxVUs: 100,
+  },
+  create_transaction: {
+    executor: 'ramping-arrival-rate',
+    exec: 'createTransaction',
+    startRate: 5,
+    timeUnit: '1s',
+    stages: [
+      { target: 20, duration: '30s' },
+      { target: 20, duration: '1m' },
+    ],
+    preAllocatedVUs: 20,
+    maxVUs: 100,
+    startTime: '1m',
+  },
+  // Semua VU memakai satu NIK sehingga antre di lock limit yang sama
+  create_transaction_contended: {
+    executor: 'constant-vus',
+    exec: 'createTransactionContended',
+    vus: 10,
+    duration: '30s',
+    startTime: '2m30s',
+  },
+};
+
+function selectedScenarios() {
+  if (!__ENV.SCENARIO) {
+    return scenarios;
+  }
+  const picked = scenarios[__ENV.SCENARIO];
+  if (!picked) {
+    throw new Error(`unknown scenario ${__ENV.SCENARIO}`);
+  }
+  return { [__ENV.SCENARIO]: Object.assign({}, picked, { startTime: '0s' }) };
+}
+
+function thresholds(names) {
+  const result = {};
+  for (const name of names) {
+    const budget = baseline[name];
+    result[`http_req_duration{scenario:${name}}`] = [`p(95)<${budget.p95_ms}`];
+    result[`http_req_failed{scenario:${name}}`] = [`rate<${budget.max_error_rate}`];
+  }
+  return result;
+}
+
+const active = selectedScenarios();
+
+export const options = {
+  scenarios: active,
+  thresholds: thresholds(Object.keys(active)),
+  summaryTrendStats: ['avg', 'p(50)', 'p(95)', 'p(99)', 'max'],
+};
+
+export function setup() {
+  if (!API_KEY) {
+    throw new Error('API_KEY is required');
+  }
+  if (niks.length === 0) {
+    throw new Error('NIK file is empty, seed with -nik-file first');
+  }
+}
+
+function randomNonce() {
+  const alphabet = 'ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789';
+  let nonce = '';
+  for (let i = 0; i < 32; i++) {
+    nonce += alphabet[Math.floor(Math.random() * alphabet.length)];
+  }
+  return nonce;
+}
+
+// Mengikuti pkg/requestsig: timestamp \n nonce \n METHOD \n path \n hex(sha256(body))
+function signedHeaders(method, url, body) {
+  const headers = { 'Content-Type': 'application/json', 'X-API-Key': API_KEY };
+  if (!SIGNING_SECRET) {
+    return headers;
+  }
+
+  const path = url.replace(/^https?:\/\/[^/]+/, '');
+  const timestamp = `${Math.floor(Date.now() / 1000)}`;
+  const nonce = randomNonce();
+  const canonical = [timestamp, nonce, method, path, crypto.sha256(body, 'hex')].join('\n');
+
+  headers['X-Timestamp'] = timestamp;
+  headers['X-Nonce'] = nonce;
+  headers['X-Signature'] = crypto.hmac('sha256', SIGNING_SECRET, canonical, 'hex');
+  return headers;
+}
+
+function post(path, payload) {
+  const url = `${BASE_URL}${path}`;
+  const body = JSON.stringify(payload);
+  return http.post(url, body, { headers: signedHeaders('POST', url, body) });
+}
+
+function pickNIK() {
+  return niks[Math.floor(Math.random() * niks.length)];
+}
+
+export function checkLimit() {
+  const res = post('/partner-api/check-limit', {
+    customer_nik: pickNIK(),
+    tenor_months: TENOR_MONTHS,
+    transaction_amount: '100000',
+  });
+  check(res, { 'check-limit answered': (r) => r.status === 200 || r.status === 422 });
+}
+
+function transaction(nik) {
+  return post('/partner-api/transactions', {
+    customer_nik: nik,
+    tenor_months: TENOR_MONTHS,
+    asset_name: 'Load Test Asset',
+    otr_amount: '100000',
+    admin_fee: '0',
+  });
+}
+
+export function createTransaction() {
+  const res = transaction(pickNIK());
+  check(res, { 'transaction accepted or limited': (r) => r.status === 201 || r.status === 422 });
+}
+
+export function createTransactionContended() {
+  const res = transaction(niks[0]);
+  check(res, { 'transaction accepted or limited': (r) => r.status === 201 || r.status === 422 });
+}
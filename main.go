@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -11,25 +14,38 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/fazamuttaqien/multifinance/bootstrap"
 	"github.com/fazamuttaqien/multifinance/config"
 	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
 	redisdb "github.com/fazamuttaqien/multifinance/infra/redis"
 	"github.com/fazamuttaqien/multifinance/internal/model"
-	"github.com/fazamuttaqien/multifinance/pkg/cloudinary"
+	assetcategoryrepo "github.com/fazamuttaqien/multifinance/internal/repository/assetcategory"
+	duedaterepo "github.com/fazamuttaqien/multifinance/internal/repository/duedate"
+	regionrepo "github.com/fazamuttaqien/multifinance/internal/repository/region"
+	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
+	referencedatasrv "github.com/fazamuttaqien/multifinance/internal/service/referencedata"
+	tenorsrv "github.com/fazamuttaqien/multifinance/internal/service/tenor"
+	"github.com/fazamuttaqien/multifinance/pkg/job"
 	"github.com/fazamuttaqien/multifinance/pkg/password"
-	ratelimiter "github.com/fazamuttaqien/multifinance/pkg/rate-limiter"
+	"github.com/fazamuttaqien/multifinance/pkg/refdata"
 	"github.com/fazamuttaqien/multifinance/pkg/telemetry"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
 	"github.com/fazamuttaqien/multifinance/presenter"
 	"github.com/fazamuttaqien/multifinance/router"
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/shopspring/decimal"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 func main() {
+	check := flag.Bool("check", false, "validate configuration and dependencies, then exit")
+	seedOnly := flag.Bool("seed", false, "migrate and seed the admin and reference data, then exit")
+	flag.Parse()
+
 	slog.Info("Starting application setup...")
 
 	ctx := context.Background()
@@ -38,94 +54,54 @@ func main() {
 		slog.Error("No .env file found, using system environment variables", "error", err)
 	}
 
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		panic(fmt.Sprintf("Failed to load configuration: %v", err))
-	}
-
-	tel, err := telemetry.New(ctx, cfg)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to initialize monitoring: %v", err))
-	}
-
-	db, err := mysqldb.InitializeDatabase()
+	app, err := bootstrap.Start(ctx, bootstrap.Options{
+		Check: *check,
+		Seed: func(ctx context.Context, app *bootstrap.App) error {
+			if err := SeedReferenceData(ctx, app.DB, app.Telemetry, app.ReferenceData); err != nil {
+				return err
+			}
+			return SeedAdmin(ctx, app.DB, app.Config.ADMIN_PASSWORD)
+		},
+	})
 	if err != nil {
-		slog.Error("Failed to initialize database", "error", err)
+		var bootErr *bootstrap.Error
+		if errors.As(err, &bootErr) {
+			slog.Error("Startup failed", "step", bootErr.Step, "error", bootErr.Err, "hint", bootErr.Hint)
+		} else {
+			slog.Error("Startup failed", "error", err)
+		}
 		os.Exit(1)
 	}
 
-	redisClient := redisdb.MonitorRedis(cfg)
-	if redisClient == nil {
-		panic("Failed to connect to Redis (MonitorRedis returned nil)")
-	}
-	go redisdb.WatchConnectionRedis(&redisClient, cfg)
-
 	defer func() {
 		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancelShutdown()
 
-		zap.L().Info("Closing MySQL Connection...")
-		if err := mysqldb.Close(db, shutdownCtx); err != nil {
-			zap.L().Error("Error disconnecting from MySQL", zap.Error(err))
-		} else {
-			zap.L().Info("Disconnected from MySQL.")
-		}
-
-		zap.L().Info("Closing Redis connection...")
-		if err := redisClient.Close(); err != nil {
-			zap.L().Error("Error disconnecting from Redis", zap.Error(err))
-		} else {
-			zap.L().Info("Disconnected from Redis.")
-		}
-
-		zap.L().Info("Shutting down monitoring...")
-		if err := tel.Shutdown(shutdownCtx); err != nil {
-			zap.L().Error("Error during monitoring shutdown", zap.Error(err))
-		} else {
-			zap.L().Info("Monitoring shutdown complete.")
-		}
+		app.Close(shutdownCtx)
 	}()
 
-	if err := model.AutoMigrate(db); err != nil {
-		slog.Error("Failed to migrate database", "error", err)
-		os.Exit(1)
+	app.LogSummary(app.Telemetry.Log, *check)
+	if *check || *seedOnly {
+		return
 	}
-	slog.Info("Database migration completed!")
 
-	SeedTenors(db)
-	SeedAdmin(db)
+	cfg, tel, db, cld := app.Config, app.Telemetry, app.DB, app.Cloudinary
+	go redisdb.WatchConnectionRedis(&app.Redis, cfg)
 
 	mysqldb.EnableDebugMode(db)
 
-	if err := mysqldb.Ping(db, ctx); err != nil {
-		slog.Error("Database ping failed", "error", err)
-		os.Exit(1)
-	}
-	slog.Info("Database connection successful!")
-
-	stats := mysqldb.GetStats(db)
-	slog.Info("Database stats:", "stats", stats)
-
-	cld, err := cloudinary.InitCloudinary(cfg)
-	if err != nil {
-		slog.Error("Failed to initialize Cloudinary service:", "error", err)
-		os.Exit(1)
-	}
-
-	rps := 100.0 / (15 * 60)
-	limiter := ratelimiter.NewRateLimiter(redisClient, rps, 100, 15*time.Minute)
-	if limiter == nil {
-		panic("Failed to initialize rate limiter")
-	}
-
 	store := session.New(session.Config{
 		Expiration:     24 * time.Hour,
 		CookieSecure:   false,
 		CookieSameSite: "Strict",
 	})
 
-	presenter := presenter.NewPresenter(db, cld, tel, cfg, store)
-	router := router.NewRouter(presenter, db, tel, cfg, limiter, store)
+	presenter := presenter.NewPresenter(db, cld, tel, cfg, store, app.Redis)
+	router := router.NewRouter(presenter, db, tel, cfg, app.Limiter, store, app.Tenants)
+
+	jobs := append(perTenant(app.Tenants, presenter.Jobs), presenter.ProcessJobs...)
+	jobCtx, stopJobs := context.WithCancel(ctx)
+	waitJobs := job.Start(jobCtx, tel.Logger(telemetry.ModuleJob), jobs...)
 
 	addr := ":" + cfg.SERVER_PORT
 
@@ -133,7 +109,7 @@ func main() {
 
 	go func() {
 		zap.L().Info("Server starting", zap.String("address", addr))
-		if err := router.Listen(addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := listen(router, addr, cfg); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			listenErr <- err
 		} else {
 			listenErr <- nil
@@ -154,6 +130,7 @@ func main() {
 	}
 
 	zap.L().Info("Starting graceful shutdown...")
+	stopJobs()
 	shutdownTimeout := 10 * time.Second
 	if err := router.ShutdownWithTimeout(shutdownTimeout); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -165,21 +142,87 @@ func main() {
 		zap.L().Info("Server gracefully stopped.")
 	}
 
+	zap.L().Info("Waiting for background jobs...")
+	waitJobs()
+
 	zap.L().Info("Application shutdown complete.")
 }
 
+// perTenant makes every job run once for each tenant, with the tenant in
+// the context, on every tick.
+func perTenant(tenants *tenancy.Registry, jobs []job.Job) []job.Job {
+	scoped := make([]job.Job, len(jobs))
+	for i, j := range jobs {
+		run := j.Run
+		j.Run = func(ctx context.Context) error {
+			return tenants.Each(ctx, run)
+		}
+		scoped[i] = j
+	}
+	return scoped
+}
+
+// listen serves plain HTTP unless a TLS certificate is configured. With a
+// client CA, certificates are verified when presented but not demanded, so
+// only partners pinned to a certificate are forced onto mutual TLS.
+func listen(app *fiber.App, addr string, cfg *config.Config) error {
+	if cfg.TLS_CERT_FILE == "" || cfg.TLS_KEY_FILE == "" {
+		return app.Listen(addr)
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLS_CERT_FILE, cfg.TLS_KEY_FILE)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.TLS_CLIENT_CA_FILE != "" {
+		caPEM, err := os.ReadFile(cfg.TLS_CLIENT_CA_FILE)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return errors.New("client CA file contains no certificates")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	ln, err := tls.Listen("tcp", addr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	return app.Listener(ln)
+}
+
 const (
 	AdminID  uint64 = 1
 	AdminNIK string = "1010010110100101"
 )
 
-func SeedAdmin(db *gorm.DB) {
-	slog.Info("Checking for admin user...")
+// errAdminPasswordMissing stops the first start instead of creating an admin
+// with a password everyone knows.
+var errAdminPasswordMissing = errors.New("no admin user exists and ADMIN_PASSWORD is not set")
+
+// SeedAdmin creates the first admin with adminPassword, which must be changed
+// on first login. Once an admin exists the password is not needed and not
+// applied again.
+func SeedAdmin(ctx context.Context, db *gorm.DB, adminPassword string) error {
+	slog.Info("Checking for admin user...", "tenant", tenancy.ID(ctx))
+
+	db = db.WithContext(ctx)
 
 	var adminUser model.Customer
 	err := db.First(&adminUser, AdminID).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if adminPassword == "" {
+			return errAdminPasswordMissing
+		}
 		slog.Info("Admin user not found, creating one...")
 
 		newAdmin := model.Customer{
@@ -190,51 +233,90 @@ func SeedAdmin(db *gorm.DB) {
 			LegalName:          "System Administrator",
 			BirthPlace:         "System",
 			BirthDate:          time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
-			Salary:             99999999,
+			Salary:             decimal.NewFromInt(99999999),
 			KtpPhotoUrl:        "https://via.placeholder.com/150",
 			SelfiePhotoUrl:     "https://via.placeholder.com/150",
 			VerificationStatus: model.VerificationVerified,
+			// Password dari konfigurasi hanya untuk login pertama
+			MustChangePassword: true,
 		}
 
-		hashPassword, err := password.HashPassword("admin123")
+		hashPassword, err := password.HashPassword(adminPassword)
 		if err != nil {
-			slog.Error("Failed to hash admin password", "error", err)
+			return fmt.Errorf("failed to hash admin password: %w", err)
 		}
 
 		newAdmin.Password = hashPassword
 		if err := db.Create(&newAdmin).Error; err != nil {
-			slog.Error("Failed to seed admin user", "error", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to seed admin user: %w", err)
 		}
 		slog.Info("Admin user created successfully.")
 	} else if err != nil {
-		slog.Error("Error checking for admin user", "error", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to check for admin user: %w", err)
 	} else {
 		slog.Info("Admin user already exists.")
 	}
+
+	return nil
 }
 
-func SeedTenors(db *gorm.DB) {
-	slog.Info("Seeding master tenors...")
-
-	tenors := []model.Tenor{
-		{ID: 1, DurationMonths: 1, Description: "1 Months"},
-		{ID: 2, DurationMonths: 2, Description: "2 Months"},
-		{ID: 3, DurationMonths: 3, Description: "3 Months"},
-		{ID: 4, DurationMonths: 6, Description: "6 Months"},
-		{ID: 5, DurationMonths: 9, Description: "9 Months"},
-		{ID: 6, DurationMonths: 12, Description: "12 Months"},
-		{ID: 7, DurationMonths: 24, Description: "24 Months"},
+// SeedReferenceData writes the tenors, regions, asset categories and
+// holidays of REFERENCE_DATA_DIR. Running it again changes nothing unless
+// the files changed.
+func SeedReferenceData(ctx context.Context, db *gorm.DB, tel *telemetry.OpenTelemetry, data *refdata.Set) error {
+	if data.Empty() {
+		slog.Info("No reference data to seed, REFERENCE_DATA_DIR is empty or unset.")
+		return nil
 	}
-
-	if err := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "duration_months"}},
-		DoNothing: true,
-	}).Create(&tenors).Error; err != nil {
-		slog.Error("Failed to seed tenors", "error", err)
-		os.Exit(1)
+	slog.Info("Seeding reference data...", "tenant", tenancy.ID(ctx))
+
+	repositoryLog := tel.Logger(telemetry.ModuleRepository)
+	serviceLog := tel.Logger(telemetry.ModuleService)
+
+	// Tenor ditulis lewat service yang sama dengan endpoint admin agar aturan produknya diperiksa dengan cara yang sama
+	tenorService := tenorsrv.NewTenorService(
+		tenorrepo.NewTenorRepository(
+			db,
+			tel.MeterProvider.Meter("tenor-repository-meter"),
+			repositoryLog,
+		),
+		tel.MeterProvider.Meter("tenor-service-meter"),
+		tel.TracerProvider.Tracer("tenor-service-trace"),
+		serviceLog,
+	)
+
+	referenceDataService := referencedatasrv.NewReferenceDataService(
+		assetcategoryrepo.NewAssetCategoryRepository(
+			db,
+			tel.MeterProvider.Meter("asset-category-repository-meter"),
+			repositoryLog,
+		),
+		regionrepo.NewRegionRepository(
+			db,
+			tel.MeterProvider.Meter("region-repository-meter"),
+			repositoryLog,
+		),
+		duedaterepo.NewDueDateRepository(
+			db,
+			tel.MeterProvider.Meter("due-date-repository-meter"),
+			repositoryLog,
+		),
+		tenorService,
+		tel.MeterProvider.Meter("reference-data-service-meter"),
+		tel.TracerProvider.Tracer("reference-data-service-trace"),
+		serviceLog,
+	)
+
+	result, err := referenceDataService.Apply(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to seed reference data: %w", err)
 	}
 
-	slog.Info("Master tenors seeded successfully.")
+	slog.Info("Reference data seeded successfully.",
+		"asset_categories", result.AssetCategories,
+		"regions", result.Regions,
+		"tenors", result.Tenors,
+		"holidays", result.Holidays,
+	)
+	return nil
 }
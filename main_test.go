@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	"github.com/fazamuttaqien/multifinance/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// seedLock mirrors the bootstrap lock that guards SeedAdmin at start-up.
+const seedLock = "multifinance.bootstrap.test"
+
+type SeedAdminTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	ctx context.Context
+}
+
+func (suite *SeedAdminTestSuite) SetupSuite() {
+	suite.db = testutil.NewDatabase(suite.T(), "loan_system_seed_admin_test").DB
+	suite.ctx = context.Background()
+}
+
+func (suite *SeedAdminTestSuite) SetupTest() {
+	testutil.Reset(suite.T(), suite.db)
+}
+
+func (suite *SeedAdminTestSuite) admins() []model.Customer {
+	var admins []model.Customer
+	require.NoError(suite.T(), suite.db.Where("role = ?", model.AdminRole).Find(&admins).Error)
+	return admins
+}
+
+func (suite *SeedAdminTestSuite) TestConcurrentSeedersCreateOneAdmin() {
+	// Arrange
+	const instances = 6
+	errs := make([]error, instances)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+
+	// Act: setiap instance menunggu lock seperti langkah seed di bootstrap
+	for i := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = mysqldb.WithLock(suite.ctx, suite.db, seedLock, time.Minute, func(ctx context.Context) error {
+				return SeedAdmin(ctx, suite.db, "initial-admin-password")
+			})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// Assert
+	for i, err := range errs {
+		assert.NoError(suite.T(), err, "instance %d", i)
+	}
+	admins := suite.admins()
+	suite.Require().Len(admins, 1)
+	assert.Equal(suite.T(), AdminID, admins[0].ID)
+	assert.True(suite.T(), admins[0].MustChangePassword)
+}
+
+func (suite *SeedAdminTestSuite) TestFailsClosedWithoutPassword() {
+	// Act
+	err := mysqldb.WithLock(suite.ctx, suite.db, seedLock, time.Minute, func(ctx context.Context) error {
+		return SeedAdmin(ctx, suite.db, "")
+	})
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, errAdminPasswordMissing)
+	assert.Empty(suite.T(), suite.admins())
+}
+
+func (suite *SeedAdminTestSuite) TestExistingAdminNeedsNoPassword() {
+	// Arrange
+	suite.Require().NoError(SeedAdmin(suite.ctx, suite.db, "initial-admin-password"))
+
+	// Act
+	err := SeedAdmin(suite.ctx, suite.db, "")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), suite.admins(), 1)
+}
+
+func (suite *SeedAdminTestSuite) TestFailsClosedWhileLockIsHeld() {
+	// Arrange: instance lain masih memegang lock
+	held := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- mysqldb.WithLock(suite.ctx, suite.db, seedLock, time.Minute, func(context.Context) error {
+			close(held)
+			<-release
+			return nil
+		})
+	}()
+	<-held
+
+	// Act
+	seeded := false
+	err := mysqldb.WithLock(suite.ctx, suite.db, seedLock, time.Second, func(ctx context.Context) error {
+		seeded = true
+		return SeedAdmin(ctx, suite.db, "initial-admin-password")
+	})
+	close(release)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, mysqldb.ErrLockTimeout)
+	assert.False(suite.T(), seeded)
+	assert.Empty(suite.T(), suite.admins())
+	assert.NoError(suite.T(), <-done)
+}
+
+func TestSeedAdminTestSuite(t *testing.T) {
+	suite.Run(t, new(SeedAdminTestSuite))
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/flags"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// NewAPIKeyMiddleware authenticates partner integrations by the X-API-Key
+// header and stores the partner and its sandbox flag in the request locals.
+func NewAPIKeyMiddleware(onboardingService service.PartnerOnboardingServices) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(APIKeyHeader)
+		if key == "" {
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Missing API key")
+		}
+
+		partner, sandbox, err := onboardingService.Authenticate(c.UserContext(), key)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidAPIKey) {
+				return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Invalid API key")
+			}
+			return responder.Fail(c, fiber.StatusInternalServerError, "auth_error", "Failed to authenticate API key")
+		}
+
+		c.Locals("partner", partner)
+		c.Locals("sandbox", sandbox)
+		c.SetUserContext(flags.WithPartner(c.UserContext(), partner.ID))
+		return c.Next()
+	}
+}
+
+// GetPartnerFromLocals returns the partner authenticated by NewAPIKeyMiddleware
+// and whether the request was made with its sandbox key.
+func GetPartnerFromLocals(c *fiber.Ctx) (*domain.Partner, bool, error) {
+	partner, ok := c.Locals("partner").(*domain.Partner)
+	if !ok {
+		return nil, false, errors.New("partner not found in context")
+	}
+	sandbox, _ := c.Locals("sandbox").(bool)
+	return partner, sandbox, nil
+}
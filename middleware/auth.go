@@ -4,24 +4,41 @@ import (
 	"errors"
 
 	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 func NewJWTAuthMiddleware(secret string) fiber.Handler {
+	return newJWTAuth(secret, false)
+}
+
+// NewPasswordChangeAuthMiddleware is NewJWTAuthMiddleware that also accepts
+// tokens of accounts that must change their password first. It guards the
+// routes such an account needs to do so, and to log out.
+func NewPasswordChangeAuthMiddleware(secret string) fiber.Handler {
+	return newJWTAuth(secret, true)
+}
+
+func newJWTAuth(secret string, allowPasswordChange bool) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		tokenStr := c.Cookies("private")
 		if tokenStr == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing auth token cookie"})
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Missing auth token cookie")
 		}
 
-		claims := &domain.JwtCustomClaims{}
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
-			return []byte(secret), nil
-		})
-
-		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid or expired JWT"})
+		claims, ok := parseClaims(tokenStr, secret)
+		if !ok {
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Invalid or expired JWT")
+		}
+		if !sameTenant(c, claims) {
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Token was issued for another tenant")
+		}
+		if claims.Impersonated() && !readOnlyMethod(c.Method()) {
+			return failImpersonationWrite(c)
+		}
+		if claims.MustChangePassword && !allowPasswordChange {
+			return failPasswordChangeRequired(c)
 		}
 
 		c.Locals("user", claims)
@@ -29,11 +46,61 @@ func NewJWTAuthMiddleware(secret string) fiber.Handler {
 	}
 }
 
+// NewOptionalJWTAuthMiddleware sets the user claims when a valid auth cookie
+// is present and lets anonymous requests through untouched, for routes that
+// serve both visitors and logged-in customers.
+func NewOptionalJWTAuthMiddleware(secret string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if tokenStr := c.Cookies("private"); tokenStr != "" {
+			// Token tenant lain diperlakukan seperti pengunjung anonim
+			if claims, ok := parseClaims(tokenStr, secret); ok && sameTenant(c, claims) {
+				if claims.Impersonated() && !readOnlyMethod(c.Method()) {
+					return failImpersonationWrite(c)
+				}
+				if claims.MustChangePassword {
+					return failPasswordChangeRequired(c)
+				}
+				c.Locals("user", claims)
+			}
+		}
+		return c.Next()
+	}
+}
+
+func parseClaims(tokenStr, secret string) (*domain.JwtCustomClaims, bool) {
+	claims := &domain.JwtCustomClaims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, false
+	}
+	return claims, true
+}
+
+// readOnlyMethod reports whether an impersonation token may be used for the
+// request. Impersonating admins can look but never act as the customer.
+func readOnlyMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func failImpersonationWrite(c *fiber.Ctx) error {
+	return responder.Fail(c, fiber.StatusForbidden, "impersonation_read_only", "Impersonation sessions are read-only")
+}
+
+func failPasswordChangeRequired(c *fiber.Ctx) error {
+	return responder.Fail(c, fiber.StatusForbidden, "password_change_required", "Change the initial password before using this account")
+}
+
 func RequireRole(allowedRoles ...domain.Role) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		userClaims, ok := c.Locals("user").(*domain.JwtCustomClaims)
 		if !ok {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Could not parse user claims"})
+			return responder.Fail(c, fiber.StatusInternalServerError, "auth_error", "Could not parse user claims")
 		}
 
 		for _, role := range allowedRoles {
@@ -42,7 +109,7 @@ func RequireRole(allowedRoles ...domain.Role) fiber.Handler {
 			}
 		}
 
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied: insufficient permissions"})
+		return responder.Fail(c, fiber.StatusForbidden, "forbidden", "Access denied: insufficient permissions")
 	}
 }
 
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewAccountClosureMiddleware rejects customer tokens issued before the
+// account was closed. It must run after the JWT middleware. Tokens are
+// stateless, so the account is checked on every request.
+func NewAccountClosureMiddleware(closureService service.AccountClosureServices) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("user").(*domain.JwtCustomClaims)
+		if !ok || claims.Role != domain.CustomerRole {
+			return c.Next()
+		}
+
+		if err := closureService.Authorize(c.UserContext(), claims.UserID); err != nil {
+			if errors.Is(err, common.ErrAccountClosed) {
+				return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Account has been closed")
+			}
+			return responder.Fail(c, fiber.StatusInternalServerError, "auth_error", "Failed to check account status")
+		}
+
+		return c.Next()
+	}
+}
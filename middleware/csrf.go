@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"net/http"
 
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
@@ -28,24 +29,24 @@ func NewCustomCSRFMiddleware(store *session.Store) fiber.Handler {
 		// Ambil sesi dari store
 		sess, err := store.Get(c)
 		if err != nil {
-			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "Session error"})
+			return responder.Fail(c, http.StatusInternalServerError, "session_error", "Session error")
 		}
 
 		// Ambil token CSRF yang tersimpan di sesi
 		storedToken := sess.Get("csrf_token")
 		if storedToken == nil {
-			return c.Status(http.StatusForbidden).JSON(fiber.Map{"error": "CSRF token not found in session"})
+			return responder.Fail(c, http.StatusForbidden, "csrf_error", "CSRF token not found in session")
 		}
 
 		// Ambil token CSRF yang dikirim oleh klien (dari header)
 		clientToken := c.Get("X-CSRF-Token")
 		if clientToken == "" {
-			return c.Status(http.StatusForbidden).JSON(fiber.Map{"error": "CSRF token missing from request header"})
+			return responder.Fail(c, http.StatusForbidden, "csrf_error", "CSRF token missing from request header")
 		}
 
 		// Bandingkan token
 		if clientToken != storedToken.(string) {
-			return c.Status(http.StatusForbidden).JSON(fiber.Map{"error": "CSRF token mismatch"})
+			return responder.Fail(c, http.StatusForbidden, "csrf_error", "CSRF token mismatch")
 		}
 
 		return c.Next()
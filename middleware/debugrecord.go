@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// NewDebugRecorderMiddleware stores the request and response of partners
+// whose API key has debug mode on. It must run after NewAPIKeyMiddleware;
+// requests of other keys pass through untouched.
+func NewDebugRecorderMiddleware(debugService service.PartnerDebugServices) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		partner, sandbox, err := GetPartnerFromLocals(c)
+		if err != nil || !partner.Debugging(sandbox, time.Now()) {
+			return c.Next()
+		}
+
+		start := time.Now()
+		err = c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		headers := make(map[string]string)
+		for name, values := range c.GetReqHeaders() {
+			headers[name] = strings.Join(values, ", ")
+		}
+
+		record := &domain.PartnerDebugRecord{
+			PartnerID:      partner.ID,
+			Sandbox:        sandbox,
+			Method:         c.Method(),
+			Path:           c.OriginalURL(),
+			StatusCode:     status,
+			RequestHeaders: headers,
+			RequestBody:    string(c.Body()),
+			ResponseBody:   string(c.Response().Body()),
+			DurationMs:     time.Since(start).Milliseconds(),
+			RequestID:      c.GetRespHeader(fiber.HeaderXRequestID),
+		}
+		if spanContext := trace.SpanContextFromContext(c.UserContext()); spanContext.HasTraceID() {
+			record.TraceID = spanContext.TraceID().String()
+		}
+
+		// Kegagalan menyimpan rekaman tidak boleh mengubah respons ke partner,
+		// dan deadline request tidak ikut membatalkan penyimpanannya
+		if recordErr := debugService.Record(context.WithoutCancel(c.UserContext()), record); recordErr != nil {
+			zap.L().Error("Failed to record partner debug request",
+				zap.Uint64("partner_id", partner.ID),
+				zap.String("path", c.Path()),
+				zap.Error(recordErr),
+			)
+		}
+
+		return err
+	}
+}
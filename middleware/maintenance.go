@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// NewMaintenanceGateMiddleware answers 503 while scope is in maintenance or
+// outside its business hours. The response carries Retry-After and the
+// reopening time whenever it is known.
+func NewMaintenanceGateMiddleware(maintenanceService service.MaintenanceServices, scope domain.GateScope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		now := time.Now()
+		closure, err := maintenanceService.Check(c.UserContext(), scope, now)
+		if err != nil {
+			// Redis bermasalah tidak boleh ikut menghentikan transaksi partner
+			zap.L().Error("Failed to check maintenance gate, letting request through",
+				zap.String("scope", string(scope)),
+				zap.Error(err),
+			)
+			return c.Next()
+		}
+		if closure == nil {
+			return c.Next()
+		}
+
+		details := fiber.Map{"scope": closure.Scope}
+		if !closure.RetryAt.IsZero() {
+			retryAfter := int(closure.RetryAt.Sub(now).Seconds()) + 1
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+			details["retry_at"] = closure.RetryAt.UTC()
+			details["retry_after_seconds"] = retryAfter
+		}
+
+		return responder.FailWithDetails(c, fiber.StatusServiceUnavailable, closure.Reason, closure.Message, details)
+	}
+}
@@ -191,7 +191,11 @@ func (m *OtelMiddleware) Handle() fiber.Handler {
 		)
 
 		// Record response size
-		resContentLength := int64(len(c.Response().Body()))
+		// Body() pada respons stream akan membaca seluruh stream ke memori
+		var resContentLength int64
+		if !c.Response().IsBodyStream() {
+			resContentLength = int64(len(c.Response().Body()))
+		}
 		m.httpRequestSize.Record(ctx, resContentLength,
 			metric.WithAttributes(
 				attribute.String("http.method", method),
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/netip"
+
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewPartnerNetworkMiddleware enforces the partner's IP allowlist and client
+// certificate pin on live-key requests. It must run after NewAPIKeyMiddleware.
+func NewPartnerNetworkMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		partner, sandbox, err := GetPartnerFromLocals(c)
+		if err != nil {
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Partner not authenticated")
+		}
+		if sandbox {
+			return c.Next()
+		}
+
+		security := partner.Security
+		if len(security.AllowedCIDRs) > 0 && !addressAllowed(c.IP(), security.AllowedCIDRs) {
+			return responder.Fail(c, fiber.StatusForbidden, "forbidden", "Request address is not allowed for this API key")
+		}
+
+		if security.RequireClientCert {
+			// Sidik jari kosong di sisi mana pun tidak pernah dianggap cocok
+			presented := clientCertFingerprint(c)
+			if presented == "" || security.ClientCertFingerprint == "" || presented != security.ClientCertFingerprint {
+				return responder.Fail(c, fiber.StatusForbidden, "forbidden", "A valid client certificate is required for this API key")
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+func addressAllowed(ip string, cidrs []string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientCertFingerprint returns the SHA-256 of the leaf certificate verified
+// during the TLS handshake, or "" when the request did not present one.
+func clientCertFingerprint(c *fiber.Ctx) string {
+	state := c.Context().TLSConnectionState()
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.PeerCertificates) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(state.PeerCertificates[0].Raw)
+	return hex.EncodeToString(sum[:])
+}
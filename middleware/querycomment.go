@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"sync"
+
+	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewQueryCommentMiddleware exposes the matched route to the SQL comments
+// added by mysqldb.QueryComments. It must run after otelfiber so the trace
+// context is already on the user context.
+func NewQueryCommentMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		route := &requestRoute{c: c}
+		c.SetUserContext(mysqldb.WithRoute(c.UserContext(), route.String))
+
+		err := c.Next()
+		route.freeze()
+		return err
+	}
+}
+
+// requestRoute membaca route dari context Fiber selama request berjalan, lalu
+// menyimpan nilai terakhirnya karena context Fiber dipakai ulang setelah
+// request selesai sementara query dari goroutine latar bisa masih berjalan
+type requestRoute struct {
+	mu    sync.Mutex
+	c     *fiber.Ctx
+	route string
+}
+
+func (r *requestRoute) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.c != nil {
+		r.route = r.c.Route().Path
+	}
+	return r.route
+}
+
+func (r *requestRoute) freeze() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.route = r.c.Route().Path
+	r.c = nil
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/internal/service"
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/fazamuttaqien/multifinance/pkg/requestsig"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewRequestSignatureMiddleware checks the X-Nonce, X-Timestamp and
+// X-Signature headers of partner requests so a captured request cannot be
+// replayed. Unsigned requests pass unless the partner requires signatures.
+// It must run after NewAPIKeyMiddleware.
+func NewRequestSignatureMiddleware(signatureService service.RequestSignatureServices) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		partner, _, err := GetPartnerFromLocals(c)
+		if err != nil {
+			return responder.Fail(c, fiber.StatusUnauthorized, "auth_error", "Partner not authenticated")
+		}
+
+		err = signatureService.Verify(c.UserContext(), partner, domain.SignedRequest{
+			Nonce:     c.Get(requestsig.HeaderNonce),
+			Timestamp: c.Get(requestsig.HeaderTimestamp),
+			Signature: c.Get(requestsig.HeaderSignature),
+			Method:    c.Method(),
+			Path:      c.Path(),
+			Body:      c.Body(),
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, common.ErrNonceReused):
+				return responder.Fail(c, fiber.StatusConflict, "replay_detected", err.Error())
+			case errors.Is(err, common.ErrSignatureRequired),
+				errors.Is(err, common.ErrInvalidSignature),
+				errors.Is(err, common.ErrSignatureExpired):
+				return responder.Fail(c, fiber.StatusUnauthorized, "signature_error", err.Error())
+			}
+			return responder.Fail(c, fiber.StatusInternalServerError, "signature_error", "Failed to verify request signature")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/fazamuttaqien/multifinance/internal/domain"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewTenantMiddleware resolves the tenant of the request and puts it in the
+// user context. A host mapped to a tenant decides; the X-Tenant-ID header is
+// only consulted on other hosts, such as a shared partner API host, and
+// requests with neither are served by the default tenant.
+func NewTenantMiddleware(tenants *tenancy.Registry) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requested := c.Get(tenancy.Header)
+
+		tenant, mapped := tenants.ByHost(c.Hostname())
+		switch {
+		case mapped:
+			// Header yang berbeda dari host kemungkinan besar salah konfigurasi di klien
+			if requested != "" && requested != tenant.ID {
+				return responder.Fail(c, fiber.StatusBadRequest, "tenant_mismatch", "X-Tenant-ID does not match the host")
+			}
+		case requested != "":
+			var ok bool
+			if tenant, ok = tenants.Get(requested); !ok {
+				return responder.Fail(c, fiber.StatusBadRequest, "unknown_tenant", "Unknown tenant")
+			}
+		default:
+			tenant = tenants.Default()
+		}
+
+		c.Locals("tenant", tenant)
+		c.SetUserContext(tenancy.WithTenant(c.UserContext(), tenant))
+		return c.Next()
+	}
+}
+
+// GetTenantFromLocals returns the tenant resolved by NewTenantMiddleware.
+func GetTenantFromLocals(c *fiber.Ctx) (*tenancy.Tenant, error) {
+	tenant, ok := c.Locals("tenant").(*tenancy.Tenant)
+	if !ok {
+		return nil, errors.New("tenant not found in context")
+	}
+	return tenant, nil
+}
+
+// sameTenant reports whether claims were issued by the tenant serving the
+// request. Tokens issued before tenancy carry no tenant and belong to the
+// default one.
+func sameTenant(c *fiber.Ctx, claims *domain.JwtCustomClaims) bool {
+	issuer := claims.Tenant
+	if issuer == "" {
+		issuer = tenancy.DefaultID
+	}
+	return issuer == tenancy.ID(c.UserContext())
+}
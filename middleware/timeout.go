@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// NewTimeoutMiddleware puts a deadline of timeout on c.UserContext(), so
+// service and repository calls made for the request abort once the client
+// has effectively given up. A request that failed after its deadline passed
+// is answered 504 and counted under group. A zero timeout disables it.
+func NewTimeoutMiddleware(group string, timeout time.Duration) fiber.Handler {
+	if timeout <= 0 {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+
+	meter := otel.GetMeterProvider().Meter("fiber-middleware")
+	timeoutCounter, _ := meter.Int64Counter(
+		"http.server.timeout.count",
+		metric.WithDescription("Number of HTTP requests that ran past their deadline"),
+		metric.WithUnit("{request}"),
+	)
+
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return err
+		}
+
+		timeoutCounter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("route_group", group),
+			attribute.String("method", c.Method()),
+			attribute.String("route", c.Route().Path),
+		))
+
+		// Handler yang tetap selesai sukses walau lewat deadline dibiarkan,
+		// hanya kegagalan akibat context habis yang diganti menjadi 504
+		if err == nil && c.Response().StatusCode() < fiber.StatusInternalServerError {
+			return nil
+		}
+
+		zap.L().Warn("Request exceeded its deadline",
+			zap.String("route_group", group),
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.Duration("timeout", timeout),
+			zap.Error(err),
+		)
+
+		return responder.Fail(c, fiber.StatusGatewayTimeout, "request_timeout", "Request took too long to process, please retry")
+	}
+}
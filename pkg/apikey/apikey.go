@@ -0,0 +1,34 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	SandboxPrefix = "mf_test_"
+	LivePrefix    = "mf_live_"
+)
+
+// Generate returns a new random key with the given prefix, its SHA-256 hash
+// for storage and a short display prefix safe to show in dashboards.
+func Generate(prefix string) (plain, hash, display string, err error) {
+	buf := make([]byte, 24)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+
+	plain = prefix + hex.EncodeToString(buf)
+	return plain, Hash(plain), plain[:len(prefix)+6], nil
+}
+
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsSandbox(plain string) bool {
+	return strings.HasPrefix(plain, SandboxPrefix)
+}
@@ -0,0 +1,71 @@
+// Package apiversion mounts the same routes under several /api/{version}
+// prefixes and tells clients of retiring versions when to move on, using the
+// Deprecation (RFC 9745), Sunset (RFC 8594) and successor-version Link
+// headers.
+package apiversion
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const localsKey = "api_version"
+
+type Version struct {
+	Name string
+	// Deprecation is when the version was, or will be, deprecated; zero
+	// means it is current.
+	Deprecation time.Time
+	// Sunset is when the version stops being served; zero means no date has
+	// been announced.
+	Sunset time.Time
+	// Successor is the prefix clients should migrate to, e.g. "/api/v2".
+	Successor string
+}
+
+func (v Version) Prefix() string {
+	return "/api/" + v.Name
+}
+
+// Middleware records the version on the request and adds the lifecycle
+// headers for deprecated versions.
+func Middleware(v Version) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(localsKey, v.Name)
+
+		if !v.Deprecation.IsZero() {
+			c.Set("Deprecation", fmt.Sprintf("@%d", v.Deprecation.Unix()))
+		}
+		if !v.Sunset.IsZero() {
+			c.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if v.Successor != "" && (!v.Deprecation.IsZero() || !v.Sunset.IsZero()) {
+			// Path setelah prefix dipertahankan agar klien langsung dapat padanannya
+			path := strings.TrimPrefix(c.Path(), v.Prefix())
+			c.Append(fiber.HeaderLink, fmt.Sprintf(`<%s%s>; rel="successor-version"`, v.Successor, path))
+		}
+
+		return c.Next()
+	}
+}
+
+// Current returns the version the request was routed through, or "" outside
+// a versioned group.
+func Current(c *fiber.Ctx) string {
+	name, _ := c.Locals(localsKey).(string)
+	return name
+}
+
+// Map picks the representation of data for the request's version. Versions
+// without an entry in mappers get data unchanged, so a contract change only
+// needs a mapper for the versions that still speak the old shape.
+func Map[T any](c *fiber.Ctx, data T, mappers map[string]func(T) any) any {
+	if mapper, ok := mappers[Current(c)]; ok {
+		return mapper(data)
+	}
+	return data
+}
@@ -0,0 +1,223 @@
+// Package bankstatement reads the transactions out of a bank statement
+// exported as CSV or PDF and summarises how much money flowed into the
+// account each month. It only needs the date, description and amount of each
+// row, so it works with most Indonesian bank layouts without a per-bank
+// template.
+package bankstatement
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnreadable means the file is neither a CSV with recognisable columns
+	// nor a PDF whose text could be extracted.
+	ErrUnreadable = errors.New("bank statement could not be read")
+	// ErrNoTransactions means the file was read but no row looked like a
+	// dated transaction.
+	ErrNoTransactions = errors.New("bank statement has no transactions")
+)
+
+// Transaction is one row of the statement. Amount is always positive;
+// Credit tells money coming in apart from money going out.
+type Transaction struct {
+	Date        time.Time
+	Description string
+	Amount      decimal.Decimal
+	Credit      bool
+}
+
+// Summary describes the period a statement covers and the money that came
+// into the account during it.
+type Summary struct {
+	From         time.Time
+	To           time.Time
+	Months       int
+	Transactions int
+	TotalInflow  decimal.Decimal
+	// AverageMonthlyInflow spreads TotalInflow over every calendar month
+	// between From and To, including months without any credit.
+	AverageMonthlyInflow decimal.Decimal
+}
+
+// Parse detects the format of data and returns its transactions in the
+// order they appear.
+func Parse(data []byte) ([]Transaction, error) {
+	var (
+		transactions []Transaction
+		err          error
+	)
+	if bytes.HasPrefix(data, []byte("%PDF-")) {
+		transactions, err = parsePDF(data)
+	} else {
+		transactions, err = parseCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) == 0 {
+		return nil, ErrNoTransactions
+	}
+	return transactions, nil
+}
+
+// Summarize totals the credits of transactions. It returns a zero Summary
+// when there are no transactions.
+func Summarize(transactions []Transaction) Summary {
+	if len(transactions) == 0 {
+		return Summary{}
+	}
+
+	summary := Summary{
+		From:         transactions[0].Date,
+		To:           transactions[0].Date,
+		Transactions: len(transactions),
+		TotalInflow:  decimal.Zero,
+	}
+	for _, transaction := range transactions {
+		if transaction.Date.Before(summary.From) {
+			summary.From = transaction.Date
+		}
+		if transaction.Date.After(summary.To) {
+			summary.To = transaction.Date
+		}
+		if transaction.Credit {
+			summary.TotalInflow = summary.TotalInflow.Add(transaction.Amount)
+		}
+	}
+
+	summary.Months = (summary.To.Year()-summary.From.Year())*12 + int(summary.To.Month()-summary.From.Month()) + 1
+	summary.AverageMonthlyInflow = summary.TotalInflow.Div(decimal.NewFromInt(int64(summary.Months))).Round(2)
+
+	return summary
+}
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"2/1/2006",
+	"02-01-2006",
+	"02.01.2006",
+	"02 Jan 2006",
+	"2 Jan 2006",
+	"02-Jan-2006",
+}
+
+// parseDate menerima format tanggal yang umum di mutasi rekening, hari selalu di depan bulan
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+var amountPattern = regexp.MustCompile(`^[+-]?\d[\d.,]*$`)
+
+// parseAmount reads amounts written with either dot or comma grouping. The
+// separator that appears last is the decimal point when both are used; a
+// lone separator followed by exactly three digits is a thousands separator.
+func parseAmount(s string) (decimal.Decimal, bool) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "Rp"), "IDR")
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if !amountPattern.MatchString(s) {
+		return decimal.Zero, false
+	}
+	s = strings.TrimPrefix(s, "+")
+
+	lastDot, lastComma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastComma > lastDot {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.Replace(s, ",", ".", 1)
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case lastComma >= 0:
+		s = normaliseSeparator(s, ",")
+	case lastDot >= 0:
+		s = normaliseSeparator(s, ".")
+	}
+
+	amount, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, false
+	}
+	return amount, true
+}
+
+func normaliseSeparator(s, sep string) string {
+	if strings.Count(s, sep) > 1 || len(s)-strings.LastIndex(s, sep) == 4 {
+		return strings.ReplaceAll(s, sep, "")
+	}
+	return strings.Replace(s, sep, ".", 1)
+}
+
+// parseLine membaca satu baris teks mutasi: tanggal di awal, lalu keterangan,
+// lalu nominal yang diikuti penanda CR/DB atau diawali tanda +/-. Kolom saldo
+// setelah nominal diabaikan.
+func parseLine(line string) (Transaction, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return Transaction{}, false
+	}
+
+	// Tanggal bisa satu token (02/01/2025) atau tiga token (02 Jan 2025)
+	date, ok := parseDate(fields[0])
+	rest := fields[1:]
+	if !ok && len(fields) >= 5 {
+		date, ok = parseDate(strings.Join(fields[:3], " "))
+		rest = fields[3:]
+	}
+	if !ok {
+		return Transaction{}, false
+	}
+
+	for i, field := range rest {
+		if i == 0 {
+			continue
+		}
+
+		marker := ""
+		if i+1 < len(rest) {
+			marker = strings.ToUpper(rest[i+1])
+		}
+		if marker == "CR" || marker == "DB" {
+			amount, ok := parseAmount(field)
+			if !ok {
+				continue
+			}
+			return Transaction{
+				Date:        date,
+				Description: strings.Join(rest[:i], " "),
+				Amount:      amount.Abs(),
+				Credit:      marker == "CR",
+			}, true
+		}
+
+		if strings.HasPrefix(field, "+") || strings.HasPrefix(field, "-") {
+			amount, ok := parseAmount(field)
+			if !ok {
+				continue
+			}
+			return Transaction{
+				Date:        date,
+				Description: strings.Join(rest[:i], " "),
+				Amount:      amount.Abs(),
+				Credit:      amount.IsPositive(),
+			}, true
+		}
+	}
+
+	return Transaction{}, false
+}
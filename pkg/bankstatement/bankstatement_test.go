@@ -0,0 +1,269 @@
+package bankstatement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func transaction(on time.Time, description, amount string, credit bool) Transaction {
+	return Transaction{Date: on, Description: description, Amount: decimal.RequireFromString(amount), Credit: credit}
+}
+
+// assertTransactions membandingkan nominal sebagai angka, bukan representasi decimal
+func assertTransactions(t *testing.T, want, got []Transaction) {
+	t.Helper()
+
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.True(t, want[i].Date.Equal(got[i].Date), "row %d date: want %s, got %s", i, want[i].Date, got[i].Date)
+		assert.Equal(t, want[i].Description, got[i].Description, "row %d description", i)
+		assert.True(t, want[i].Amount.Equal(got[i].Amount), "row %d amount: want %s, got %s", i, want[i].Amount, got[i].Amount)
+		assert.Equal(t, want[i].Credit, got[i].Credit, "row %d credit", i)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"1.500.000,00", "1500000", true},
+		{"1,500,000.00", "1500000", true},
+		{"1.500", "1500", true},
+		{"1,500", "1500", true},
+		{"1,5", "1.5", true},
+		{"12.50", "12.5", true},
+		{"Rp 250.000", "250000", true},
+		{"IDR1,000", "1000", true},
+		{"-75.000", "-75000", true},
+		{"+12,50", "12.5", true},
+		{"2 500 000", "2500000", true},
+		{"0", "0", true},
+		{"", "", false},
+		{"CR", "", false},
+		{"12a", "", false},
+		{"--5", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := parseAmount(tc.input)
+
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.True(t, decimal.RequireFromString(tc.want).Equal(got), "got %s", got)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	cases := []struct {
+		input string
+		want  time.Time
+		ok    bool
+	}{
+		{"2026-10-05", date(2026, time.October, 5), true},
+		{"05/10/2026", date(2026, time.October, 5), true},
+		{"5/10/2026", date(2026, time.October, 5), true},
+		{"05-10-2026", date(2026, time.October, 5), true},
+		{"05.10.2026", date(2026, time.October, 5), true},
+		{"05 Oct 2026", date(2026, time.October, 5), true},
+		{"5 Oct 2026", date(2026, time.October, 5), true},
+		{"05-Oct-2026", date(2026, time.October, 5), true},
+		{" 05/10/2026 ", date(2026, time.October, 5), true},
+		// Hari selalu di depan bulan
+		{"10/31/2026", time.Time{}, false},
+		{"SALDO AWAL", time.Time{}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := parseDate(tc.input)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.True(t, tc.want.Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	cases := []struct {
+		name string
+		line string
+		want *Transaction
+	}{
+		{
+			name: "Credit Marker With Balance",
+			line: "01/10/2026 TRSF GAJI PT MAJU 15.000.000,00 CR 15.250.000,00",
+			want: &Transaction{Date: date(2026, time.October, 1), Description: "TRSF GAJI PT MAJU", Amount: decimal.NewFromInt(15000000), Credit: true},
+		},
+		{
+			name: "Debit Marker Lowercase",
+			line: "02/10/2026 TARIK TUNAI ATM 500.000,00 db 14.750.000,00",
+			want: &Transaction{Date: date(2026, time.October, 2), Description: "TARIK TUNAI ATM", Amount: decimal.NewFromInt(500000)},
+		},
+		{
+			name: "Three Token Date",
+			line: "05 Oct 2026 BI-FAST DARI ANNISA +1.250.000 16.000.000",
+			want: &Transaction{Date: date(2026, time.October, 5), Description: "BI-FAST DARI ANNISA", Amount: decimal.NewFromInt(1250000), Credit: true},
+		},
+		{
+			name: "Signed Debit",
+			line: "2026-10-06 QRIS KOPI -75.000",
+			want: &Transaction{Date: date(2026, time.October, 6), Description: "QRIS KOPI", Amount: decimal.NewFromInt(75000)},
+		},
+		{
+			name: "Description Number Before Amount",
+			line: "07/10/2026 TRSF 1234 DARI BUDI 200.000,00 CR",
+			want: &Transaction{Date: date(2026, time.October, 7), Description: "TRSF 1234 DARI BUDI", Amount: decimal.NewFromInt(200000), Credit: true},
+		},
+		{name: "Opening Balance", line: "SALDO AWAL 250.000,00"},
+		{name: "Header", line: "TANGGAL KETERANGAN MUTASI SALDO"},
+		{name: "Date Without Amount", line: "08/10/2026 BIAYA ADMIN BULANAN"},
+		{name: "Too Short", line: "08/10/2026 +5"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseLine(tc.line)
+
+			if tc.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assertTransactions(t, []Transaction{*tc.want}, []Transaction{got})
+		})
+	}
+}
+
+func TestParse_CSVLayouts(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want []Transaction
+	}{
+		{
+			name: "Separate Debit And Credit Columns",
+			data: "\xef\xbb\xbfTanggal,Keterangan,Debit,Kredit,Saldo\n" +
+				"30/09/2026,SALDO AWAL,,,250.000\n" +
+				"01/10/2026,TRSF GAJI,,\"15.000.000,00\",\"15.250.000,00\"\n" +
+				"02/10/2026,TARIK TUNAI,\"500.000,00\",,\"14.750.000,00\"\n",
+			want: []Transaction{
+				transaction(date(2026, time.October, 1), "TRSF GAJI", "15000000", true),
+				transaction(date(2026, time.October, 2), "TARIK TUNAI", "500000", false),
+			},
+		},
+		{
+			name: "Amount With Type Column And Semicolons",
+			data: "Tgl;Uraian;Mutasi;D/K;Saldo\n" +
+				"01.10.2026;BI-FAST MASUK;1.250.000,00;K;1.500.000,00\n" +
+				"03.10.2026;PEMBAYARAN LISTRIK;350.000,00;D;1.150.000,00\n" +
+				"04.10.2026;BUNGA;1.234,56;CR;1.151.234,56\n",
+			want: []Transaction{
+				transaction(date(2026, time.October, 1), "BI-FAST MASUK", "1250000", true),
+				transaction(date(2026, time.October, 3), "PEMBAYARAN LISTRIK", "350000", false),
+				transaction(date(2026, time.October, 4), "BUNGA", "1234.56", true),
+			},
+		},
+		{
+			name: "Signed Amount",
+			data: "Date,Description,Amount\n" +
+				"2026-10-01,Salary,\"8,000,000.00\"\n" +
+				"2026-10-02,Groceries,-425000\n" +
+				"2026-10-03,Zero Fee,0\n",
+			want: []Transaction{
+				transaction(date(2026, time.October, 1), "Salary", "8000000", true),
+				transaction(date(2026, time.October, 2), "Groceries", "425000", false),
+			},
+		},
+		{
+			name: "Short Rows",
+			data: "Tanggal,Jumlah,Keterangan\n05/10/2026,+100.000\n",
+			want: []Transaction{transaction(date(2026, time.October, 5), "", "100000", true)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse([]byte(tc.data))
+
+			require.NoError(t, err)
+			assertTransactions(t, tc.want, got)
+		})
+	}
+}
+
+func TestParse_CSVErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"Empty", "", ErrUnreadable},
+		{"No Date Column", "Keterangan,Jumlah\nGAJI,100\n", ErrUnreadable},
+		{"No Amount Column", "Tanggal,Keterangan\n01/10/2026,GAJI\n", ErrUnreadable},
+		{"Bare Quote", "Tanggal,Jumlah\n01/10/2026,\"100\"x\n", ErrUnreadable},
+		{"Header Only", "Tanggal,Keterangan,Jumlah\n", ErrNoTransactions},
+		{"No Dated Rows", "Tanggal,Keterangan,Jumlah\nSALDO AWAL,,100\n", ErrNoTransactions},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse([]byte(tc.data))
+
+			assert.ErrorIs(t, err, tc.err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("Average Includes Months Without Credit", func(t *testing.T) {
+		summary := Summarize([]Transaction{
+			transaction(date(2026, time.August, 25), "GAJI", "9000000", true),
+			transaction(date(2026, time.July, 3), "BELANJA", "2500000", false),
+			transaction(date(2026, time.October, 1), "GAJI", "9000000", true),
+			transaction(date(2026, time.October, 2), "BONUS", "1000000.005", true),
+		})
+
+		assert.True(t, date(2026, time.July, 3).Equal(summary.From))
+		assert.True(t, date(2026, time.October, 2).Equal(summary.To))
+		assert.Equal(t, 4, summary.Months)
+		assert.Equal(t, 4, summary.Transactions)
+		assert.Equal(t, "19000000.005", summary.TotalInflow.String())
+		assert.Equal(t, "4750000", summary.AverageMonthlyInflow.String())
+	})
+
+	t.Run("Across Years", func(t *testing.T) {
+		summary := Summarize([]Transaction{
+			transaction(date(2025, time.November, 30), "GAJI", "1000", true),
+			transaction(date(2026, time.February, 1), "GAJI", "1000", true),
+		})
+
+		assert.Equal(t, 4, summary.Months)
+		assert.Equal(t, "500", summary.AverageMonthlyInflow.String())
+	})
+
+	t.Run("Debits Only", func(t *testing.T) {
+		summary := Summarize([]Transaction{transaction(date(2026, time.October, 1), "TARIK", "100", false)})
+
+		assert.Equal(t, 1, summary.Months)
+		assert.True(t, summary.TotalInflow.IsZero())
+		assert.True(t, summary.AverageMonthlyInflow.IsZero())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, Summary{}, Summarize(nil))
+	})
+}
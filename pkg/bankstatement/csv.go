@@ -0,0 +1,139 @@
+package bankstatement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Nama kolom dikenali dalam bahasa Indonesia maupun Inggris
+var (
+	dateHeaders        = []string{"date", "tanggal", "tgl", "transaction date", "tanggal transaksi"}
+	descriptionHeaders = []string{"description", "keterangan", "deskripsi", "remarks", "uraian"}
+	creditHeaders      = []string{"credit", "kredit", "cr", "mutasi kredit"}
+	debitHeaders       = []string{"debit", "db", "mutasi debit"}
+	amountHeaders      = []string{"amount", "jumlah", "nominal", "mutasi"}
+	typeHeaders        = []string{"type", "jenis", "db/cr", "cr/db", "d/k"}
+)
+
+type csvColumns struct {
+	date, description, credit, debit, amount, kind int
+}
+
+// parseCSV reads a statement whose first row names its columns. It needs a
+// date column and either separate credit and debit columns or a single
+// amount column, signed or paired with a CR/DB type column. Rows whose date
+// does not parse, such as opening balance lines, are skipped.
+func parseCSV(data []byte) ([]Transaction, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comma = detectComma(data)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrUnreadable
+	}
+	columns, ok := findColumns(header)
+	if !ok {
+		return nil, ErrUnreadable
+	}
+
+	var transactions []Transaction
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrUnreadable
+		}
+
+		if transaction, ok := columns.transaction(record); ok {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	return transactions, nil
+}
+
+// detectComma memilih pemisah yang paling banyak muncul di baris header,
+// ekspor bank lokal sering memakai titik koma
+func detectComma(data []byte) rune {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func findColumns(header []string) (csvColumns, bool) {
+	index := func(names []string) int {
+		for i, column := range header {
+			column = strings.ToLower(strings.TrimSpace(column))
+			for _, name := range names {
+				if column == name {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+
+	columns := csvColumns{
+		date:        index(dateHeaders),
+		description: index(descriptionHeaders),
+		credit:      index(creditHeaders),
+		debit:       index(debitHeaders),
+		amount:      index(amountHeaders),
+		kind:        index(typeHeaders),
+	}
+	if columns.date < 0 || (columns.credit < 0 && columns.amount < 0) {
+		return csvColumns{}, false
+	}
+	return columns, true
+}
+
+func (c csvColumns) transaction(record []string) (Transaction, bool) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	date, ok := parseDate(field(c.date))
+	if !ok {
+		return Transaction{}, false
+	}
+	transaction := Transaction{Date: date, Description: field(c.description)}
+
+	if c.credit >= 0 {
+		if amount, ok := parseAmount(field(c.credit)); ok && !amount.IsZero() {
+			transaction.Amount, transaction.Credit = amount.Abs(), true
+			return transaction, true
+		}
+		if amount, ok := parseAmount(field(c.debit)); ok && !amount.IsZero() {
+			transaction.Amount = amount.Abs()
+			return transaction, true
+		}
+		return Transaction{}, false
+	}
+
+	amount, ok := parseAmount(field(c.amount))
+	if !ok || amount.IsZero() {
+		return Transaction{}, false
+	}
+	transaction.Amount = amount.Abs()
+	switch strings.ToUpper(field(c.kind)) {
+	case "CR", "C", "K", "KREDIT", "CREDIT":
+		transaction.Credit = true
+	case "DB", "D", "DEBIT":
+		transaction.Credit = false
+	default:
+		transaction.Credit = amount.IsPositive()
+	}
+	return transaction, true
+}
@@ -0,0 +1,506 @@
+package bankstatement
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxStreamSize caps every decompressed stream and maxDecodedSize all of
+// them together, so a small upload cannot expand into an unbounded amount of
+// memory. A statement that needs more is rejected.
+const (
+	maxStreamSize  = 8 << 20
+	maxDecodedSize = 32 << 20
+)
+
+// maxArrayDepth bounds nested arrays in a content stream. Text operators
+// never nest them, so deeper nesting only shows up in hostile files.
+const maxArrayDepth = 32
+
+// lineTolerance is how far apart in points two pieces of text may sit
+// vertically and still be read as the same line.
+const lineTolerance = 2.0
+
+// parsePDF extracts the text of every page and reads each line that starts
+// with a date. Only text drawn with single byte fonts can be recovered, which
+// covers statements exported by internet banking; scanned statements and
+// fonts without a readable encoding yield ErrUnreadable, as do truncated or
+// oversized streams. Streams are found by scanning for their keywords, so
+// the cross-reference table, often wrong in re-saved files, is never read.
+func parsePDF(data []byte) ([]Transaction, error) {
+	streams, err := contentStreams(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		transactions []Transaction
+		readable     bool
+	)
+	for _, content := range streams {
+		lines, err := textLines(content)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			readable = true
+			if transaction, ok := parseLine(line); ok {
+				transactions = append(transactions, transaction)
+			}
+		}
+	}
+
+	if !readable {
+		return nil, ErrUnreadable
+	}
+	return transactions, nil
+}
+
+// contentStreams returns the decoded streams that can hold page text. Fonts,
+// images and cross-reference streams are skipped.
+func contentStreams(data []byte) ([][]byte, error) {
+	var (
+		streams [][]byte
+		decoded int
+	)
+	for offset := 0; ; {
+		at := bytes.Index(data[offset:], []byte("stream"))
+		if at < 0 {
+			break
+		}
+		at += offset
+		// Dictionary stream selalu sesudah kata kunci sebelumnya, jadi pencarian
+		// mundur tidak perlu melewatinya dan setiap byte hanya diperiksa sekali
+		from := offset
+		offset = at + len("stream")
+
+		// "endstream" juga mengandung kata stream
+		if at >= 3 && string(data[at-3:at]) == "end" {
+			continue
+		}
+		dictEnd := bytes.LastIndex(data[from:at], []byte(">>"))
+		objStart := bytes.LastIndex(data[from:at], []byte("obj"))
+		if dictEnd < 0 || objStart < 0 || objStart > dictEnd {
+			continue
+		}
+		dict := string(data[from+objStart : from+dictEnd])
+
+		body := data[offset:]
+		body = bytes.TrimPrefix(body, []byte("\r"))
+		body = bytes.TrimPrefix(body, []byte("\n"))
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			// File terpotong di tengah stream
+			return nil, ErrUnreadable
+		}
+		body = body[:end]
+		offset += end
+
+		if !isContentDict(dict) {
+			continue
+		}
+		if strings.Contains(dict, "/FlateDecode") {
+			var err error
+			if body, err = inflate(body); err != nil {
+				return nil, err
+			}
+		} else if strings.Contains(dict, "/Filter") {
+			continue
+		}
+		if decoded += len(body); decoded > maxDecodedSize {
+			return nil, ErrUnreadable
+		}
+		streams = append(streams, body)
+	}
+	return streams, nil
+}
+
+// inflate decompresses a FlateDecode stream. Corrupt or truncated data and
+// streams that expand beyond maxStreamSize are unreadable.
+func inflate(body []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, ErrUnreadable
+	}
+	defer reader.Close()
+
+	// Satu byte lebih dari batas untuk membedakan stream yang pas dengan yang kepanjangan
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxStreamSize+1))
+	if err != nil || len(decoded) > maxStreamSize {
+		return nil, ErrUnreadable
+	}
+	return decoded, nil
+}
+
+func isContentDict(dict string) bool {
+	for _, skip := range []string{"/Length1", "/Length2", "/Length3", "/XRef", "/ObjStm", "/Metadata", "/Image", "/FontFile"} {
+		if strings.Contains(dict, skip) {
+			return false
+		}
+	}
+	return !strings.Contains(dict, "/Subtype") || strings.Contains(dict, "/Form")
+}
+
+type textRun struct {
+	x, y float64
+	seq  int
+	text string
+}
+
+// matrix is a PDF transformation matrix [a b c d e f].
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// translate returns the matrix moved by tx, ty in its own coordinate space,
+// as the Td operator does.
+func (m matrix) translate(tx, ty float64) matrix {
+	return matrix{m[0], m[1], m[2], m[3], tx*m[0] + ty*m[2] + m[4], tx*m[1] + ty*m[3] + m[5]}
+}
+
+// textLines runs the text operators of a content stream and groups the text
+// it draws into lines, top to bottom and left to right.
+func textLines(content []byte) ([]string, error) {
+	var (
+		runs       []textRun
+		operands   []any
+		textMatrix = identity
+		lineMatrix = identity
+		leading    float64
+		fontSize   = 10.0
+	)
+
+	number := func(i int) float64 {
+		if i < 0 || i >= len(operands) {
+			return 0
+		}
+		value, _ := operands[i].(float64)
+		return value
+	}
+	show := func(text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		runs = append(runs, textRun{x: textMatrix[4], y: textMatrix[5], seq: len(runs), text: text})
+		// Lebar glyph tidak diketahui, perkiraan setengah ukuran font cukup untuk menjaga urutan
+		textMatrix = textMatrix.translate(float64(len(text))*fontSize*0.5, 0)
+	}
+	nextLine := func() {
+		lineMatrix = lineMatrix.translate(0, -leading)
+		textMatrix = lineMatrix
+	}
+
+	lexer := &contentLexer{data: content}
+	for {
+		token, ok := lexer.next()
+		if !ok {
+			break
+		}
+		operator, isOperator := token.(contentOperator)
+		if !isOperator {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch operator {
+		case "BT":
+			textMatrix, lineMatrix = identity, identity
+		case "Tf":
+			if size := number(len(operands) - 1); size > 0 {
+				fontSize = size
+			}
+		case "TL":
+			leading = number(0)
+		case "Td", "TD":
+			if operator == "TD" {
+				leading = -number(1)
+			}
+			lineMatrix = lineMatrix.translate(number(0), number(1))
+			textMatrix = lineMatrix
+		case "Tm":
+			for i := range lineMatrix {
+				lineMatrix[i] = number(i)
+			}
+			textMatrix = lineMatrix
+		case "T*":
+			nextLine()
+		case "Tj", "'", "\"":
+			if operator != "Tj" {
+				nextLine()
+			}
+			if len(operands) > 0 {
+				text, _ := operands[len(operands)-1].(string)
+				show(text)
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				parts, _ := operands[len(operands)-1].([]any)
+				var b strings.Builder
+				for _, part := range parts {
+					switch part := part.(type) {
+					case string:
+						b.WriteString(part)
+					case float64:
+						// Jarak renggang yang besar menandai spasi antar kata
+						if part < -200 {
+							b.WriteByte(' ')
+						}
+					}
+				}
+				show(b.String())
+			}
+		}
+		operands = operands[:0]
+	}
+	if lexer.err != nil {
+		return nil, lexer.err
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		if diff := runs[i].y - runs[j].y; diff > lineTolerance || diff < -lineTolerance {
+			return runs[i].y > runs[j].y
+		}
+		if runs[i].x != runs[j].x {
+			return runs[i].x < runs[j].x
+		}
+		return runs[i].seq < runs[j].seq
+	})
+
+	var (
+		lines []string
+		line  []string
+		lineY float64
+	)
+	for _, run := range runs {
+		if len(line) > 0 && (run.y-lineY > lineTolerance || lineY-run.y > lineTolerance) {
+			lines = append(lines, strings.Join(line, " "))
+			line = nil
+		}
+		if len(line) == 0 {
+			lineY = run.y
+		}
+		line = append(line, strings.TrimSpace(run.text))
+	}
+	if len(line) > 0 {
+		lines = append(lines, strings.Join(line, " "))
+	}
+	return lines, nil
+}
+
+type contentOperator string
+
+// contentLexer splits a content stream into operands (numbers, strings,
+// names and arrays) and operators. It stops with err set when the stream
+// nests arrays deeper than maxArrayDepth.
+type contentLexer struct {
+	data  []byte
+	pos   int
+	depth int
+	err   error
+}
+
+func (l *contentLexer) next() (any, bool) {
+	// Penanda dictionary dan delimiter yang berdiri sendiri dilewati di loop,
+	// bukan rekursi, agar stream berisi jutaan delimiter tidak menghabiskan stack
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.data) || l.err != nil {
+			return nil, false
+		}
+		c := l.data[l.pos]
+		if (c == '<' || c == '>') && l.peek(1) == c {
+			l.pos += 2
+			continue
+		}
+		if c != '(' && c != '<' && c != '[' && c != '/' && isDelimiter(c) {
+			// ] atau ) yang berdiri sendiri, lewati saja
+			l.pos++
+			continue
+		}
+		break
+	}
+
+	switch c := l.data[l.pos]; {
+	case c == '(':
+		return l.literalString(), true
+	case c == '<':
+		return l.hexString(), true
+	case c == '[':
+		return l.array()
+	case c == '/':
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.data) && !isDelimiter(l.data[l.pos]) {
+			l.pos++
+		}
+		return contentName(l.data[start:l.pos]), true
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.data) && !isDelimiter(l.data[l.pos]) {
+			l.pos++
+		}
+		value, _ := strconv.ParseFloat(string(l.data[start:l.pos]), 64)
+		return value, true
+	default:
+		start := l.pos
+		for l.pos < len(l.data) && !isDelimiter(l.data[l.pos]) {
+			l.pos++
+		}
+		// Gambar inline berisi data biner sampai penanda EI
+		if string(l.data[start:l.pos]) == "ID" {
+			if end := bytes.Index(l.data[l.pos:], []byte("EI")); end >= 0 {
+				l.pos += end + 2
+			} else {
+				l.pos = len(l.data)
+			}
+		}
+		return contentOperator(l.data[start:l.pos]), true
+	}
+}
+
+type contentName string
+
+func (l *contentLexer) array() (any, bool) {
+	if l.depth++; l.depth > maxArrayDepth {
+		l.err = ErrUnreadable
+		return nil, false
+	}
+	defer func() { l.depth-- }()
+
+	l.pos++
+	var items []any
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.data) {
+			return items, true
+		}
+		if l.data[l.pos] == ']' {
+			l.pos++
+			return items, true
+		}
+		item, ok := l.next()
+		if !ok {
+			return items, l.err == nil
+		}
+		items = append(items, item)
+	}
+}
+
+func (l *contentLexer) peek(offset int) byte {
+	if l.pos+offset < len(l.data) {
+		return l.data[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *contentLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		case isSpace(c):
+			l.pos++
+		default:
+			return
+		}
+	}
+}
+
+// literalString decodes a (string) with its escapes. Bytes are read as
+// Latin-1, which matches WinAnsi for every character a statement uses.
+func (l *contentLexer) literalString() string {
+	l.pos++
+	var (
+		b     strings.Builder
+		depth = 1
+	)
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return b.String()
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return b.String()
+			}
+			escaped := l.data[l.pos]
+			l.pos++
+			switch escaped {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				if escaped == '\r' && l.peek(0) == '\n' {
+					l.pos++
+				}
+				continue
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					value := int(escaped - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(value)
+				} else {
+					c = escaped
+				}
+			}
+		}
+		b.WriteRune(rune(c))
+	}
+	return b.String()
+}
+
+func (l *contentLexer) hexString() string {
+	l.pos++
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isSpace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(digits); i += 2 {
+		value, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if err != nil {
+			return b.String()
+		}
+		b.WriteRune(rune(value))
+	}
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+func isDelimiter(c byte) bool {
+	return isSpace(c) || strings.IndexByte("()<>[]{}/%", c) >= 0
+}
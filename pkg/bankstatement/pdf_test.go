@@ -0,0 +1,312 @@
+package bankstatement
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/pkg/pdf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pdfStream struct {
+	dict string
+	data []byte
+}
+
+func plain(content string) pdfStream {
+	return pdfStream{data: []byte(content)}
+}
+
+func compressed(content string) pdfStream {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write([]byte(content))
+	w.Close()
+	return pdfStream{dict: " /Filter /FlateDecode", data: b.Bytes()}
+}
+
+// pdfFile menyusun PDF minimal dengan satu objek per stream dan tabel xref
+// yang benar
+func pdfFile(streams ...pdfStream) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.5\n%\xe2\xe3\xcf\xd3\n")
+
+	var offsets []int
+	for i, s := range streams {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n<< /Length %d%s >>\nstream\n", i+1, len(s.data), s.dict)
+		b.Write(s.data)
+		b.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(streams)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d >>\nstartxref\n%d\n%%%%EOF\n", len(streams)+1, xref)
+	return b.Bytes()
+}
+
+func TestParse_PDFLayouts(t *testing.T) {
+	october := func(day int) time.Time { return date(2026, time.October, day) }
+
+	cases := []struct {
+		name string
+		data func() []byte
+		want []Transaction
+	}{
+		{
+			// Tabel per kolom seperti yang ditulis pkg/pdf: setiap sel satu BT..ET
+			name: "Table Cells Positioned With Td",
+			data: func() []byte {
+				doc := pdf.New()
+				doc.Text(15, 20, 14, true, pdf.Black, "REKENING KORAN OKTOBER 2026")
+				rows := [][]string{
+					{"TANGGAL", "KETERANGAN", "MUTASI", "", "SALDO"},
+					{"01/10/2026", "TRSF GAJI PT MAJU", "15.000.000,00", "CR", "15.250.000,00"},
+					{"02/10/2026", "TARIK TUNAI (ATM)", "500.000,00", "DB", "14.750.000,00"},
+				}
+				for i, row := range rows {
+					y := 40 + float64(i)*6
+					doc.Text(15, y, 9, false, pdf.Black, row[0])
+					doc.Text(40, y, 9, false, pdf.Black, row[1])
+					doc.TextRight(140, y, 9, false, pdf.Black, row[2])
+					doc.Text(142, y, 9, false, pdf.Black, row[3])
+					doc.TextRight(195, y, 9, false, pdf.Black, row[4])
+				}
+				doc.AddPage()
+				doc.Text(15, 20, 9, false, pdf.Black, "03/10/2026")
+				doc.Text(40, 20, 9, false, pdf.Black, "BIAYA ADM")
+				doc.TextRight(140, 20, 9, false, pdf.Black, "15.000,00")
+				doc.Text(142, 20, 9, false, pdf.Black, "DB")
+				return doc.Bytes()
+			},
+			want: []Transaction{
+				transaction(october(1), "TRSF GAJI PT MAJU", "15000000", true),
+				transaction(october(2), "TARIK TUNAI (ATM)", "500000", false),
+				transaction(october(3), "BIAYA ADM", "15000", false),
+			},
+		},
+		{
+			name: "Compressed Lines With TJ Spacing And Leading",
+			data: func() []byte {
+				return pdfFile(compressed(`BT /F1 9 Tf 12 TL 50 700 Td
+					[(01/10/2026)-250(GAJI)-300(PT)-250(MAJU)-400(15.000.000,00)-300(CR)] TJ
+					T* (02/10/2026 TARIK TUNAI 500.000,00 DB 14.500.000,00) Tj
+					(03/10/2026 TRSF DARI\040BUDI 200.000,00 CR) '
+					0 0 (04/10/2026 BUNGA 1.234,56 CR) " ET`))
+			},
+			want: []Transaction{
+				transaction(october(1), "GAJI PT MAJU", "15000000", true),
+				transaction(october(2), "TARIK TUNAI", "500000", false),
+				transaction(october(3), "TRSF DARI BUDI", "200000", true),
+				transaction(october(4), "BUNGA", "1234.56", true),
+			},
+		},
+		{
+			// Kolom digambar satu per satu dengan Tm, urutan baris ditentukan posisi
+			name: "Columns Drawn Out Of Order With Tm",
+			data: func() []byte {
+				return pdfFile(
+					compressed(`BT /F1 9 Tf
+						1 0 0 1 50 690 Tm (06 Oct 2026) Tj
+						1 0 0 1 50 700 Tm (05 Oct 2026) Tj
+						1 0 0 1 300 700 Tm (+1.250.000) Tj
+						1 0 0 1 300 690.5 Tm (-75.000) Tj
+						1 0 0 1 120 700 Tm <42492D46415354> Tj
+						1 0 0 1 120 690 Tm (QRIS) Tj
+						ET`),
+					plain(`BT 1 0 0 1 50 100 Tm (Halaman 1 dari 1) Tj ET`),
+				)
+			},
+			want: []Transaction{
+				transaction(october(5), "BI-FAST", "1250000", true),
+				transaction(october(6), "QRIS", "75000", false),
+			},
+		},
+		{
+			name: "Fonts And Images Skipped",
+			data: func() []byte {
+				return pdfFile(
+					pdfStream{dict: " /Length1 12", data: []byte("01/10/2026 FONT 1,00 CR")},
+					pdfStream{dict: " /Subtype /Image /Width 1", data: []byte("01/10/2026 IMAGE 1,00 CR")},
+					pdfStream{dict: " /Filter /DCTDecode", data: []byte("01/10/2026 JPEG 1,00 CR")},
+					plain(`BT (07/10/2026 SETOR TUNAI 300.000,00 CR) Tj ET`),
+				)
+			},
+			want: []Transaction{transaction(october(7), "SETOR TUNAI", "300000", true)},
+		},
+		{
+			name: "Bad Xref Offsets",
+			data: func() []byte {
+				data := pdfFile(plain(`BT (08/10/2026 SETOR 100.000,00 CR) Tj ET`))
+				// Offset xref dan objek menunjuk ke tempat yang salah
+				data = bytes.ReplaceAll(data, []byte(" 00000 n "), []byte(" 00000 x "))
+				at := bytes.LastIndex(data, []byte("startxref\n"))
+				return append(data[:at], "startxref\n99999999\n%%EOF\n"...)
+			},
+			want: []Transaction{transaction(october(8), "SETOR", "100000", true)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.data())
+
+			require.NoError(t, err)
+			assertTransactions(t, tc.want, got)
+		})
+	}
+}
+
+func TestParse_PDFErrors(t *testing.T) {
+	statement := `BT /F1 9 Tf 50 700 Td (01/10/2026 GAJI 15.000.000,00 CR) Tj ET`
+
+	cases := []struct {
+		name string
+		data func() []byte
+		err  error
+	}{
+		{
+			name: "Header Only",
+			data: func() []byte { return []byte("%PDF-1.4\n") },
+			err:  ErrUnreadable,
+		},
+		{
+			name: "Scanned Pages Only",
+			data: func() []byte {
+				return pdfFile(pdfStream{dict: " /Subtype /Image /Filter /DCTDecode", data: []byte{0xff, 0xd8, 0xff}})
+			},
+			err: ErrUnreadable,
+		},
+		{
+			name: "Text Without Transactions",
+			data: func() []byte { return pdfFile(plain(`BT (Tidak ada mutasi) Tj ET`)) },
+			err:  ErrNoTransactions,
+		},
+		{
+			name: "Truncated Inside Stream",
+			data: func() []byte {
+				data := pdfFile(plain(statement), plain(statement))
+				return data[:bytes.LastIndex(data, []byte("endstream"))-10]
+			},
+			err: ErrUnreadable,
+		},
+		{
+			name: "Truncated Compressed Data",
+			data: func() []byte {
+				s := compressed(statement)
+				s.data = s.data[:len(s.data)-6]
+				return pdfFile(s)
+			},
+			err: ErrUnreadable,
+		},
+		{
+			name: "Corrupt Compressed Data",
+			data: func() []byte {
+				return pdfFile(pdfStream{dict: " /Filter /FlateDecode", data: []byte(statement)})
+			},
+			err: ErrUnreadable,
+		},
+		{
+			name: "Stream Over Size Limit",
+			data: func() []byte {
+				return pdfFile(compressed(statement + strings.Repeat(" ", maxStreamSize)))
+			},
+			err: ErrUnreadable,
+		},
+		{
+			name: "Streams Over Total Limit",
+			data: func() []byte {
+				// Masing-masing di bawah batas per stream, totalnya tidak
+				page := compressed(statement + strings.Repeat(" ", maxStreamSize-len(statement)))
+				streams := make([]pdfStream, maxDecodedSize/maxStreamSize+1)
+				for i := range streams {
+					streams[i] = page
+				}
+				return pdfFile(streams...)
+			},
+			err: ErrUnreadable,
+		},
+		{
+			name: "Deeply Nested Arrays",
+			data: func() []byte {
+				return pdfFile(plain(statement + strings.Repeat("[", maxArrayDepth+1) + "] TJ"))
+			},
+			err: ErrUnreadable,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.data())
+
+			assert.ErrorIs(t, err, tc.err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestParse_PDFHostileContent(t *testing.T) {
+	statement := `BT (01/10/2026 GAJI 15.000.000,00 CR) Tj ET`
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		// Sebelumnya setiap delimiter menambah satu frame rekursi
+		{"Million Stray Delimiters", pdfFile(compressed(strings.Repeat(")]}>", 1<<20) + statement))},
+		{"Million Dictionary Markers", pdfFile(compressed(strings.Repeat("<<>>", 1<<20) + statement))},
+		{"Arrays At Depth Limit", pdfFile(plain(strings.Repeat("[", maxArrayDepth) + strings.Repeat("]", maxArrayDepth) + " " + statement))},
+		{"Unterminated Strings And Arrays", pdfFile(plain(statement + " [(abc <4142 (\\"))},
+		// Setiap kata stream hanya dicari mundur sampai kata kunci sebelumnya
+		{"Stream Keywords Without Dictionary", bytes.Replace(pdfFile(plain(statement)), []byte("\n1 0 obj"), append(bytes.Repeat([]byte("stream "), 1<<20), "\n1 0 obj"...), 1)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			got, err := Parse(tc.data)
+
+			require.NoError(t, err)
+			assertTransactions(t, []Transaction{transaction(date(2026, time.October, 1), "GAJI", "15000000", true)}, got)
+			assert.Less(t, time.Since(start), 5*time.Second)
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add(pdfFile(compressed(`BT 12 TL 50 700 Td [(01/10/2026)-300(GAJI)-300(1.000,00)-300(CR)] TJ T* (02/10/2026 ATM 50.000 DB) Tj ET`)))
+	f.Add(pdfFile(plain(`BT 1 0 0 1 50 700 Tm <30312F31302F32303236> Tj (\101\102 +1.000) ' ET`)))
+	f.Add(pdfFile(plain(`q 1 0 0 1 0 0 cm BI /W 1 /H 1 ID \x00\xff EI Q`)))
+	f.Add([]byte("%PDF-1.7\n1 0 obj << >> stream\n"))
+	f.Add([]byte("Tanggal;Keterangan;Mutasi;D/K\n01/10/2026;GAJI;1.000,00;K\n"))
+	f.Add([]byte("Date,Description,Debit,Credit\n2026-10-01,Salary,,\"1,000.00\"\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		transactions, err := Parse(data)
+		if err != nil {
+			if !errors.Is(err, ErrUnreadable) && !errors.Is(err, ErrNoTransactions) {
+				t.Fatalf("Parse returned unexpected error %v", err)
+			}
+			return
+		}
+		if len(transactions) == 0 {
+			t.Fatal("Parse returned no transactions without an error")
+		}
+		for _, transaction := range transactions {
+			if transaction.Amount.IsNegative() {
+				t.Fatalf("Parse returned negative amount %s", transaction.Amount)
+			}
+		}
+		Summarize(transactions)
+	})
+}
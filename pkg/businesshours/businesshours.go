@@ -0,0 +1,94 @@
+package businesshours
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidHours = errors.New("business hours must look like 06:00-22:00")
+
+// Hours is a daily opening window in a fixed time zone. A window whose close
+// is before its open, such as 22:00-04:00, runs past midnight.
+type Hours struct {
+	open     time.Duration
+	close    time.Duration
+	location *time.Location
+}
+
+// Parse reads an "HH:MM-HH:MM" window in the named time zone. An empty spec
+// returns nil, which is always open.
+func Parse(spec, timezone string) (*Hours, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid business hours time zone %q: %w", timezone, err)
+	}
+
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return nil, ErrInvalidHours
+	}
+	openAt, err := clock(from)
+	if err != nil {
+		return nil, err
+	}
+	closeAt, err := clock(to)
+	if err != nil {
+		return nil, err
+	}
+	if openAt == closeAt {
+		return nil, ErrInvalidHours
+	}
+
+	return &Hours{open: openAt, close: closeAt, location: location}, nil
+}
+
+func clock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, ErrInvalidHours
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Open reports whether now falls inside the window. A nil Hours is always open.
+func (h *Hours) Open(now time.Time) bool {
+	if h == nil {
+		return true
+	}
+
+	offset := h.sinceMidnight(now)
+	if h.open < h.close {
+		return offset >= h.open && offset < h.close
+	}
+	return offset >= h.open || offset < h.close
+}
+
+// NextOpen returns when the window opens next after now, or now itself when
+// it is already open.
+func (h *Hours) NextOpen(now time.Time) time.Time {
+	if h.Open(now) {
+		return now
+	}
+
+	local := now.In(h.location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, h.location)
+	next := midnight.Add(h.open)
+	if !next.After(local) {
+		next = midnight.AddDate(0, 0, 1).Add(h.open)
+	}
+	return next
+}
+
+func (h *Hours) sinceMidnight(now time.Time) time.Duration {
+	local := now.In(h.location)
+	return time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+}
@@ -0,0 +1,301 @@
+// Package cache keeps read-mostly values in two tiers: a small in-process
+// map in front of Redis. Concurrent misses for the same key share a single
+// load, and invalidations are published over Redis so every instance drops
+// its local copy instead of waiting for it to expire.
+//
+// A Cache created without a Redis client works on the local tier alone,
+// which is what tests and single-instance setups use.
+//
+// Keys are scoped to the tenant in the context, see tenancy.Key, so tenants
+// never see each other's values.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// flushAll is published to drop every key of a cache.
+const flushAll = "*"
+
+// Options configures one cache. Name prefixes the Redis keys and names the
+// invalidation channel, so it must be unique per value type.
+type Options struct {
+	Name       string
+	LocalTTL   time.Duration
+	RemoteTTL  time.Duration
+	MaxEntries int
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache holds values of one type. Values are shared between callers, so
+// anything mutable must be copied before it is modified.
+type Cache[V any] struct {
+	client *redis.Client
+	opts   Options
+	group  singleflight.Group
+
+	mu    sync.RWMutex
+	local map[string]entry[V]
+}
+
+func New[V any](client *redis.Client, opts Options) *Cache[V] {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 1024
+	}
+	if opts.RemoteTTL <= 0 {
+		opts.RemoteTTL = opts.LocalTTL
+	}
+
+	return &Cache[V]{
+		client: client,
+		opts:   opts,
+		local:  make(map[string]entry[V]),
+	}
+}
+
+// Get returns the value of key, trying the local tier, then Redis, then
+// load. A failing Redis is skipped rather than failing the read; an error
+// from load is returned and nothing is cached.
+func (c *Cache[V]) Get(ctx context.Context, key string, load func(ctx context.Context) (V, error)) (V, error) {
+	key = tenancy.Key(ctx, key)
+	if value, ok := c.getLocal(key); ok {
+		return value, nil
+	}
+
+	result, err, _ := c.group.Do(key, func() (any, error) {
+		// Pemanggil lain mungkin sudah mengisi cache selama menunggu giliran
+		if value, ok := c.getLocal(key); ok {
+			return value, nil
+		}
+
+		if value, ok := c.getRemote(ctx, key); ok {
+			c.setLocal(key, value)
+			return value, nil
+		}
+
+		value, err := load(ctx)
+		if err != nil {
+			return value, err
+		}
+
+		c.setRemote(ctx, key, value)
+		c.setLocal(key, value)
+		return value, nil
+	})
+
+	value, _ := result.(V)
+	return value, err
+}
+
+// Invalidate drops keys from both tiers and tells the other instances to
+// drop them too. Without keys the whole cache is flushed, for every tenant.
+func (c *Cache[V]) Invalidate(ctx context.Context, keys ...string) error {
+	scoped := make([]string, len(keys))
+	for i, key := range keys {
+		scoped[i] = tenancy.Key(ctx, key)
+	}
+	keys = scoped
+
+	c.mu.Lock()
+	if len(keys) == 0 {
+		clear(c.local)
+	} else {
+		for _, key := range keys {
+			delete(c.local, key)
+		}
+	}
+	c.mu.Unlock()
+
+	if c.client == nil {
+		return nil
+	}
+
+	if len(keys) == 0 {
+		// SCAN cukup untuk jumlah key yang kecil, cache ini bukan untuk data besar
+		iter := c.client.Scan(ctx, 0, c.remoteKey("*"), 100).Iterator()
+		for iter.Next(ctx) {
+			if err := c.client.Del(ctx, iter.Val()).Err(); err != nil {
+				return err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		return c.client.Publish(ctx, c.channel(), flushAll).Err()
+	}
+
+	remoteKeys := make([]string, len(keys))
+	for i, key := range keys {
+		remoteKeys[i] = c.remoteKey(key)
+	}
+	if err := c.client.Del(ctx, remoteKeys...).Err(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := c.client.Publish(ctx, c.channel(), key).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cache[V]) getLocal(key string) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.local[key]
+	if !ok || !time.Now().Before(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *Cache[V]) setLocal(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, exists := c.local[key]; !exists && len(c.local) >= c.opts.MaxEntries {
+		c.evict(now)
+	}
+	c.local[key] = entry[V]{value: value, expiresAt: now.Add(c.opts.LocalTTL)}
+}
+
+// evict makes room for one entry: expired entries go first, otherwise the
+// one closest to expiring. Callers hold mu.
+func (c *Cache[V]) evict(now time.Time) {
+	var oldest string
+	var oldestAt time.Time
+	for key, e := range c.local {
+		if !now.Before(e.expiresAt) {
+			delete(c.local, key)
+			continue
+		}
+		if oldest == "" || e.expiresAt.Before(oldestAt) {
+			oldest, oldestAt = key, e.expiresAt
+		}
+	}
+	if len(c.local) >= c.opts.MaxEntries {
+		delete(c.local, oldest)
+	}
+}
+
+func (c *Cache[V]) getRemote(ctx context.Context, key string) (V, bool) {
+	var value V
+	if c.client == nil {
+		return value, false
+	}
+
+	payload, err := c.client.Get(ctx, c.remoteKey(key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			zap.L().Warn("Cache read from Redis failed", zap.String("cache", c.opts.Name), zap.String("key", key), zap.Error(err))
+		}
+		return value, false
+	}
+
+	if err := json.Unmarshal(payload, &value); err != nil {
+		zap.L().Warn("Cache entry in Redis is unreadable", zap.String("cache", c.opts.Name), zap.String("key", key), zap.Error(err))
+		return value, false
+	}
+	return value, true
+}
+
+func (c *Cache[V]) setRemote(ctx context.Context, key string, value V) {
+	if c.client == nil {
+		return
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		zap.L().Warn("Cache entry cannot be encoded", zap.String("cache", c.opts.Name), zap.String("key", key), zap.Error(err))
+		return
+	}
+
+	if err := c.client.Set(ctx, c.remoteKey(key), payload, c.opts.RemoteTTL).Err(); err != nil {
+		zap.L().Warn("Cache write to Redis failed", zap.String("cache", c.opts.Name), zap.String("key", key), zap.Error(err))
+	}
+}
+
+func (c *Cache[V]) remoteKey(key string) string {
+	return "cache:" + c.opts.Name + ":" + key
+}
+
+func (c *Cache[V]) channel() string {
+	return "cache-invalidate:" + c.opts.Name
+}
+
+// drop removes a key announced by another instance.
+func (c *Cache[V]) drop(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key == flushAll {
+		clear(c.local)
+		return
+	}
+	delete(c.local, key)
+}
+
+// Invalidator is implemented by every Cache so Listen can serve caches of
+// different value types.
+type Invalidator interface {
+	channel() string
+	drop(key string)
+}
+
+// Listen subscribes to the invalidation channels of caches and applies what
+// other instances publish until ctx is cancelled or the subscription fails.
+// Local entries are flushed when the subscription starts, since messages
+// published while it was down have been missed.
+func Listen(ctx context.Context, client *redis.Client, caches ...Invalidator) error {
+	if client == nil || len(caches) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	byChannel := make(map[string]Invalidator, len(caches))
+	channels := make([]string, len(caches))
+	for i, c := range caches {
+		byChannel[c.channel()] = c
+		channels[i] = c.channel()
+	}
+
+	pubsub := client.Subscribe(ctx, channels...)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+	for _, c := range caches {
+		c.drop(flushAll)
+	}
+
+	for {
+		msg, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if c, ok := byChannel[msg.Channel]; ok {
+			c.drop(msg.Payload)
+		}
+	}
+}
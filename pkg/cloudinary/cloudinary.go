@@ -2,6 +2,11 @@ package cloudinary
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
 
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/fazamuttaqien/multifinance/config"
@@ -28,8 +33,9 @@ type UploadResponse struct {
 	Error   string `json:"error,omitempty"`
 }
 
-// InitCloudinary creates a new Cloudinary service
-func InitCloudinary(cfg *config.Config) (*cloudinary.Cloudinary, error) {
+// InitCloudinary creates a new Cloudinary service whose upload and admin
+// APIs send their requests through client
+func InitCloudinary(cfg *config.Config, client *http.Client) (*cloudinary.Cloudinary, error) {
 	cld, err := cloudinary.NewFromParams(
 		cfg.CLOUDINARY_CLOUD,
 		cfg.CLOUDINARY_API_KEY,
@@ -38,6 +44,53 @@ func InitCloudinary(cfg *config.Config) (*cloudinary.Cloudinary, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Cloudinary: %w", err)
 	}
+	cld.Upload.Client = *client
+	cld.Admin.Client = *client
 
 	return cld, nil
 }
+
+// Asset identifies a stored asset the way the upload and admin APIs address
+// it.
+type Asset struct {
+	ResourceType string
+	Type         string
+	PublicID     string
+}
+
+// ParseAssetURL reads the asset a delivery URL such as
+// https://res.cloudinary.com/<cloud>/image/upload/v1712345678/folder/name.jpg
+// points at. The signature and version segments are skipped; the file
+// extension is dropped except for raw assets, where it is part of the public
+// ID.
+func ParseAssetURL(rawURL string) (Asset, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid asset URL: %w", err)
+	}
+
+	// Segmen: cloud, resource type, delivery type, lalu public ID
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 4 {
+		return Asset{}, fmt.Errorf("not a Cloudinary asset URL: %s", rawURL)
+	}
+	asset := Asset{ResourceType: segments[1], Type: segments[2]}
+	rest := segments[3:]
+	if len(rest) > 1 && strings.HasPrefix(rest[0], "s--") {
+		rest = rest[1:]
+	}
+	if len(rest) > 1 && versionSegment.MatchString(rest[0]) {
+		rest = rest[1:]
+	}
+
+	asset.PublicID = strings.Join(rest, "/")
+	if asset.ResourceType != "raw" {
+		asset.PublicID = strings.TrimSuffix(asset.PublicID, path.Ext(asset.PublicID))
+	}
+	if asset.PublicID == "" {
+		return Asset{}, fmt.Errorf("not a Cloudinary asset URL: %s", rawURL)
+	}
+	return asset, nil
+}
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
@@ -8,15 +8,176 @@ import (
 )
 
 var (
-	ErrCustomerNotFound   = errors.New("customer not found")
-	ErrTenorNotFound      = errors.New("tenor not found")
-	ErrLimitNotSet        = errors.New("limit for this tenor is not set for the customer")
-	ErrInvalidLimitAmount = errors.New("limit amount cannot be negative")
-	ErrInsufficientLimit  = errors.New("insufficient limit for this transaction")
-	ErrNIKExists          = errors.New("NIK already exists")
-	ErrInvalidCredentials = errors.New("invalid nik or password")
+	ErrCustomerNotFound         = errors.New("customer not found")
+	ErrTenorNotFound            = errors.New("tenor not found")
+	ErrTenorExists              = errors.New("tenor with this duration already exists")
+	ErrTenorInUse               = errors.New("tenor is referenced by limits or transactions")
+	ErrInvalidTenorProduct      = errors.New("minimum amount and age cannot exceed their maximum")
+	ErrAmountOutsideProduct     = errors.New("transaction amount is outside the tenor's allowed range")
+	ErrAgeNotEligible           = errors.New("customer age is outside the tenor's allowed range")
+	ErrAgeAtTenorEnd            = errors.New("customer would be older than the tenor allows when the contract ends")
+	ErrDebtServiceRatioExceeded = errors.New("monthly installments would exceed the allowed share of the customer's salary")
+	ErrAssetNotAllowed          = errors.New("asset category is not financed under this tenor")
+	ErrLimitNotSet              = errors.New("limit for this tenor is not set for the customer")
+	ErrInvalidLimitAmount       = errors.New("limit amount cannot be negative")
+	ErrInvalidEffectiveDate     = errors.New("limit effective date cannot be in the past")
+	ErrInsufficientLimit        = errors.New("insufficient limit for this transaction")
+	ErrNIKExists                = errors.New("NIK already exists")
+	ErrInvalidCredentials       = errors.New("invalid nik or password")
+	ErrPartnerNotFound          = errors.New("partner not found")
+	ErrPartnerExists            = errors.New("partner email already registered")
+	ErrPartnerPromoted          = errors.New("partner is already in production")
+	ErrInvalidAPIKey            = errors.New("invalid api key")
+	ErrDeliveryNotFound         = errors.New("webhook delivery not found")
+	ErrDeliveryDelivered        = errors.New("webhook delivery already succeeded")
+	ErrDeliveryPoisoned         = errors.New("webhook delivery is poisoned and cannot be replayed")
+	ErrSalaryNeedsProof         = errors.New("salary changes require a payslip and admin approval")
+	ErrSalaryUnchanged          = errors.New("requested salary is the same as the current salary")
+	ErrSalaryChangeExists       = errors.New("a salary change is already pending review")
+	ErrSalaryChangeNotFound     = errors.New("salary change not found")
+	ErrSalaryChangeReviewed     = errors.New("salary change has already been reviewed")
+	ErrStatementTooLarge        = errors.New("bank statement exceeds the maximum file size")
+	ErrStatementUnreadable      = errors.New("bank statement must be a CSV or a text PDF with dated amounts")
+	ErrStatementEmpty           = errors.New("bank statement has no transactions")
+	ErrIncomeCheckNotFound      = errors.New("income verification not found")
+	ErrIncomeCheckReviewed      = errors.New("income verification is not awaiting review")
+	ErrIncomeReviewPending      = errors.New("a flagged income verification must be reviewed first")
+	ErrRiskTierRuleNotFound     = errors.New("risk tier rule not found")
+	ErrInvalidRiskTierRule      = errors.New("risk tier rule bounds must not be negative and a minimum must not exceed its maximum")
+	ErrRiskTierNotCalculated    = errors.New("customer has not been given a risk tier yet")
+	ErrBlacklistNotFound        = errors.New("blacklist entry not found")
+	ErrBlacklisted              = errors.New("action blocked by blacklist screening")
+	ErrDuplicateSelf            = errors.New("customer cannot be a duplicate of itself")
+	ErrDuplicateResolved        = errors.New("duplicate pair has already been resolved")
+	ErrTransactionNotFound      = errors.New("transaction not found")
+	ErrTransactionNotActive     = errors.New("only active transactions can be restructured")
+	ErrRestructureExists        = errors.New("a restructuring is already pending for this transaction")
+	ErrRestructureNotFound      = errors.New("restructuring not found")
+	ErrRestructureReviewed      = errors.New("restructuring has already been reviewed")
+	ErrRestructureSelf          = errors.New("restructuring must be approved by a different admin")
+	ErrNoFeasibleTenor          = errors.New("no available tenor satisfies the requested terms")
+	ErrFxRateNotFound           = errors.New("no exchange rate available for this currency")
+	ErrInvalidCurrency          = errors.New("currency must be a three letter ISO 4217 code")
+	ErrBaseCurrencyRate         = errors.New("IDR is the book currency and cannot be given a rate")
+	ErrInvalidReportMonth       = errors.New("month must be formatted as YYYY-MM")
+	ErrInvalidReportDate        = errors.New("date must be formatted as YYYY-MM-DD")
+	ErrInvalidCIDR              = errors.New("allowed addresses must be IP addresses or CIDR ranges")
+	ErrInvalidFingerprint       = errors.New("client certificate fingerprint must be a SHA-256 hex digest")
+	ErrFeatureFlagNotFound      = errors.New("feature flag not found")
+	ErrInvalidFeatureFlag       = errors.New("feature flag key must be lowercase letters, digits and underscores")
+	ErrFeeScheduleNotFound      = errors.New("fee schedule not found")
+	ErrAdminFeeRequired         = errors.New("admin fee is required when the partner has no fee schedule")
+	ErrUnknownPaymentProvider   = errors.New("payment provider is not configured")
+	ErrInvalidCallbackSignature = errors.New("payment callback signature is invalid")
+	ErrInvalidCallbackPayload   = errors.New("payment callback payload is malformed")
+	ErrDirectDebitNotFound      = errors.New("direct debit is not enabled for this customer")
+	ErrPromotionNotFound        = errors.New("promotion not found")
+	ErrInvalidPromoCode         = errors.New("promo code must be 3 to 32 uppercase letters or digits")
+	ErrInvalidPromotion         = errors.New("percentage discounts must be between 0 and 1")
+	ErrPromotionNotApplicable   = errors.New("promo code is not valid for this transaction")
+	ErrPromotionExhausted       = errors.New("promo code has reached its redemption limit")
+	ErrReferralCodeNotFound     = errors.New("referral code not found")
+	ErrUnknownOTPChannel        = errors.New("OTP channel is not available")
+	ErrInvalidOTPDestination    = errors.New("OTP destination does not match the channel")
+	ErrOTPLoginRequired         = errors.New("log in to request an OTP for this purpose")
+	ErrOTPRateLimited           = errors.New("too many OTP requests, try again later")
+	ErrOTPInvalid               = errors.New("OTP code is invalid or expired")
+	ErrOTPAttemptsExceeded      = errors.New("too many wrong OTP codes, request a new one")
+	ErrOTPRequired              = errors.New("a verified OTP token is required for this action")
+	ErrEmailExists              = errors.New("email already registered")
+	ErrPhoneExists              = errors.New("phone number already registered")
+	ErrContactNotVerified       = errors.New("contact does not match the OTP verified destination")
+	ErrImpersonationNotAllowed  = errors.New("only customer accounts can be impersonated")
+	ErrImpersonationNotFound    = errors.New("impersonation session not found")
+	ErrImpersonationEnded       = errors.New("impersonation session has ended")
+	ErrSignatureRequired        = errors.New("request must be signed with X-Nonce, X-Timestamp and X-Signature")
+	ErrInvalidSignature         = errors.New("request signature is invalid")
+	ErrSignatureExpired         = errors.New("request timestamp is outside the accepted window")
+	ErrNonceReused              = errors.New("request nonce has already been used")
+	ErrUnknownGateScope         = errors.New("unknown maintenance scope")
+	ErrMaintenanceNotFound      = errors.New("scope is not in maintenance")
+	ErrInvalidMaintenanceUntil  = errors.New("maintenance end time must be in the future")
+	ErrPartnerQuotaNotFound     = errors.New("partner quota not found")
+	ErrDailyCountQuotaExceeded  = errors.New("partner has reached its daily transaction count")
+	ErrDailyVolumeQuotaExceeded = errors.New("transaction would exceed the partner's daily volume")
+	ErrBatchTooLarge            = errors.New("batch has more items than allowed")
+	ErrBatchNotFound            = errors.New("transaction batch not found")
+	ErrDuplicateContract        = errors.New("contract number already exists")
+	ErrInvalidContractImport    = errors.New("contract cannot be imported")
+	ErrCustomerNoteNotFound     = errors.New("customer note not found")
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrAttachmentTooLarge       = errors.New("attachment exceeds the maximum file size")
+	ErrUnsupportedAttachment    = errors.New("attachment must be a PDF, JPEG or PNG file")
+	ErrRegionNotFound           = errors.New("region not found or inactive")
+	ErrInvalidRegionCode        = errors.New("region code must be 2 to 8 uppercase letters")
+	ErrInvalidUsageDays         = errors.New("usage range must be between 1 and 31 days")
+	ErrCustomerSuspended        = errors.New("customer account is suspended")
+	ErrLimitFrozen              = errors.New("limit for this tenor is frozen")
+	ErrRestrictionExists        = errors.New("an active restriction of this kind already exists")
+	ErrRestrictionNotFound      = errors.New("no active restriction of this kind")
+	ErrInvalidRestrictionExpiry = errors.New("restriction expiry must be in the future")
+	ErrCustomerDormant          = errors.New("customer account is dormant and must be reactivated")
+	ErrCustomerNotDormant       = errors.New("customer account is not dormant")
+	ErrAccountClosed            = errors.New("customer account is closed")
+	ErrActiveContracts          = errors.New("account has contracts that are still running")
+	ErrOutstandingBalance       = errors.New("account still has an outstanding balance")
+	ErrCalendarTokenInvalid     = errors.New("calendar link is invalid or has been revoked")
+	ErrCalendarTokenNotFound    = errors.New("contract has no active calendar link")
+	ErrInvalidHolidayDate       = errors.New("holiday date must be formatted as YYYY-MM-DD")
+	ErrHolidayNotFound          = errors.New("holiday not found")
+	ErrInvalidDueDatePolicy     = errors.New("due date shift must be NONE, NEXT_BUSINESS_DAY or PREVIOUS_BUSINESS_DAY")
+	ErrCustomerHasNoEmail       = errors.New("customer has no email address")
+	ErrEmailTemplateNotFound    = errors.New("email template not found")
+	ErrDeviceNotFound           = errors.New("device is not registered")
+	ErrNotificationSkipped      = errors.New("notification is not sent on this channel")
+	ErrIdentityMismatch         = errors.New("identity details do not match our records")
+	ErrInvalidDormancyDays      = errors.New("dormancy stats range must be between 1 and 365 days")
+	ErrAMLCaseNotFound          = errors.New("AML case not found")
+	ErrInvalidAMLTransition     = errors.New("AML case cannot move to the requested status")
+	ErrInvalidAMLExportRange    = errors.New("AML export range must be valid YYYY-MM-DD dates spanning at most 366 days")
+	ErrCollectionCaseNotFound   = errors.New("collection case not found")
+	ErrInvalidCollectionMove    = errors.New("collection case cannot move to the requested status")
+	ErrCollectionCaseClosed     = errors.New("collection case is already closed")
+	ErrCollectorNotFound        = errors.New("collector must be an existing admin")
+	ErrInvalidPromiseToPay      = errors.New("promise to pay needs a positive amount and a date that is not in the past")
+	ErrWriteOffNotFound         = errors.New("write-off not found")
+	ErrWriteOffExists           = errors.New("a write-off is already pending for this transaction")
+	ErrWriteOffReviewed         = errors.New("write-off has already been reviewed")
+	ErrWriteOffSelf             = errors.New("write-off must be approved by a different admin")
+	ErrWriteOffNotEligible      = errors.New("contract is not past due long enough to be written off")
+	ErrWriteOffNotApproved      = errors.New("recoveries can only be recorded on an approved write-off")
+	ErrInvalidRecovery          = errors.New("recovery needs a positive amount received on a day that is not in the future")
+	ErrRecoveryExceedsBalance   = errors.New("recovery exceeds the written-off amount still outstanding")
+	ErrInvalidWriteOffPeriod    = errors.New("write-off report range must be valid YYYY-MM months spanning at most 24 months")
+	ErrConcentrationNotFound    = errors.New("concentration limit not found")
+	ErrConcentrationExists      = errors.New("a concentration limit is already set for this value")
+	ErrConcentrationExceeded    = errors.New("transaction would exceed a portfolio concentration limit")
+	ErrInvalidExportRange       = errors.New("export range must be valid YYYY-MM-DD dates spanning at most 366 days")
+	ErrUnknownLogModule         = errors.New("unknown log module")
+	ErrInvalidLogLevel          = errors.New("log level must be one of debug, info, warn, error, dpanic, panic or fatal")
+	ErrDebugRecordNotFound      = errors.New("debug record not found")
+	ErrVersionConflict          = errors.New("record was modified by another request, reload and retry")
+	ErrLimitBelowUsage          = errors.New("limit is below the amount the customer has already used")
+	ErrAmountNotPositive        = errors.New("amount must be greater than zero")
+	ErrNegativeAdminFee         = errors.New("admin fee cannot be negative")
 )
 
+// FieldError ties a business rule violation to the request field that
+// caused it, so handlers can point the client at the value to fix. Err is
+// one of the sentinel errors above and matches with errors.Is.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 func GetEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
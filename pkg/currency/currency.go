@@ -0,0 +1,54 @@
+// Package currency holds ISO 4217 code handling and the arithmetic used to
+// move amounts between a foreign currency and the IDR book currency. Rates are
+// always quoted as the IDR value of one unit of the foreign currency.
+package currency
+
+import (
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/shopspring/decimal"
+)
+
+// IDR is the book currency; limits, exposure and reports are settled in it.
+const IDR = "IDR"
+
+// Normalize upper-cases a currency code and falls back to IDR when empty.
+func Normalize(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return IDR
+	}
+	return code
+}
+
+// IsBase reports whether code is the IDR book currency and needs no rate.
+func IsBase(code string) bool {
+	return Normalize(code) == IDR
+}
+
+// Valid reports whether code looks like a three letter ISO 4217 code.
+func Valid(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ToIDR converts amount in a foreign currency into IDR using rateToIDR.
+func ToIDR(amount, rateToIDR decimal.Decimal) decimal.Decimal {
+	return money.Round(amount.Mul(rateToIDR))
+}
+
+// FromIDR converts an IDR amount into the foreign currency quoted by rateToIDR.
+func FromIDR(amount, rateToIDR decimal.Decimal) decimal.Decimal {
+	if !rateToIDR.IsPositive() {
+		return decimal.Zero
+	}
+	return amount.DivRound(rateToIDR, money.Scale)
+}
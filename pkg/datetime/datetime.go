@@ -0,0 +1,137 @@
+// Package datetime keeps instants in UTC and only converts them at the edge.
+// Requests may carry any ISO 8601 offset and are stored as UTC; customer
+// facing responses are rendered in the zone and language the client asks for
+// with the X-Timezone and Accept-Language headers.
+//
+// Calendar dates such as a birth date are not instants. They are parsed and
+// kept as midnight UTC and never converted, so they read the same day in
+// every zone.
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	localsKey = "locale"
+
+	// HeaderTimezone names the IANA zone, e.g. Asia/Jakarta, a client wants
+	// its timestamps in.
+	HeaderTimezone = "X-Timezone"
+)
+
+var ErrInvalidTimezone = errors.New("time zone must be an IANA name such as Asia/Jakarta")
+
+// ParseDate reads a date-only value such as "1990-05-17" as midnight UTC.
+func ParseDate(value string) (time.Time, error) {
+	return time.ParseInLocation(time.DateOnly, strings.TrimSpace(value), time.UTC)
+}
+
+// Date drops the time of day and zone of t, keeping the calendar date it
+// shows, as ParseDate would have returned it.
+func Date(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var months = map[string][12]string{
+	"id": {"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+	"en": {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+}
+
+// Locale is the zone and language a request wants its timestamps in.
+type Locale struct {
+	Location *time.Location
+	Language string
+}
+
+// Time returns t in the locale's zone. JSON still renders it as RFC 3339,
+// now with the client's offset.
+func (l Locale) Time(t time.Time) time.Time {
+	return t.In(l.Location)
+}
+
+// TimePtr is Time for optional timestamps.
+func (l Locale) TimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := l.Time(*t)
+	return &local
+}
+
+// Format renders t for display, such as "17 Oktober 2026 14:05 WIB".
+// Languages without month names fall back to English.
+func (l Locale) Format(t time.Time) string {
+	names, ok := months[l.Language]
+	if !ok {
+		names = months["en"]
+	}
+	local := l.Time(t)
+	return fmt.Sprintf("%d %s %d %s", local.Day(), names[local.Month()-1], local.Year(), local.Format("15:04 MST"))
+}
+
+var locations sync.Map
+
+// LoadLocation is time.LoadLocation for client input: "Local" would expose
+// the server's zone and is rejected, and loaded zones are cached.
+func LoadLocation(name string) (*time.Location, error) {
+	if cached, ok := locations.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	if name == "" || strings.EqualFold(name, "Local") {
+		return nil, ErrInvalidTimezone
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	locations.Store(name, location)
+	return location, nil
+}
+
+// Middleware resolves the request's Locale. Without X-Timezone timestamps
+// are shown in defaultLocation; languages lists the supported languages with
+// the default first. An unknown zone is answered 400 instead of silently
+// shifting every timestamp.
+func Middleware(defaultLocation *time.Location, languages ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		locale := Locale{Location: defaultLocation, Language: languages[0]}
+
+		if name := strings.TrimSpace(c.Get(HeaderTimezone)); name != "" {
+			location, err := LoadLocation(name)
+			if err != nil {
+				return responder.Fail(c, fiber.StatusBadRequest, "invalid_timezone", fmt.Sprintf("%s: %s", HeaderTimezone, err.Error()))
+			}
+			locale.Location = location
+		}
+		if language := c.AcceptsLanguages(languages...); language != "" {
+			locale.Language = language
+		}
+
+		c.Locals(localsKey, locale)
+		c.Set(fiber.HeaderContentLanguage, locale.Language)
+		// Representasi berubah per header, cache dan ETag harus membedakannya
+		c.Vary(fiber.HeaderAcceptLanguage, HeaderTimezone)
+
+		return c.Next()
+	}
+}
+
+// Current returns the request's Locale, or UTC in English outside
+// Middleware.
+func Current(c *fiber.Ctx) Locale {
+	if locale, ok := c.Locals(localsKey).(Locale); ok {
+		return locale
+	}
+	return Locale{Location: time.UTC, Language: "en"}
+}
@@ -0,0 +1,17 @@
+package flags
+
+import "context"
+
+type partnerKey struct{}
+
+// WithPartner records the partner a request is made for, so feature flags
+// piloted with that partner are evaluated as on.
+func WithPartner(ctx context.Context, partnerID uint64) context.Context {
+	return context.WithValue(ctx, partnerKey{}, partnerID)
+}
+
+// PartnerFrom returns the partner recorded by WithPartner.
+func PartnerFrom(ctx context.Context) (uint64, bool) {
+	partnerID, ok := ctx.Value(partnerKey{}).(uint64)
+	return partnerID, ok
+}
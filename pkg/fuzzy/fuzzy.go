@@ -0,0 +1,131 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize lowercases s, strips diacritics so "José" and "Jose" compare
+// equal however the accent was encoded, drops everything except letters,
+// digits and spaces, and collapses repeated whitespace.
+func Normalize(s string) string {
+	var b strings.Builder
+	// NFD memisahkan huruf dari tanda diakritiknya, tanda itu lalu dibuang
+	// bersama tanda baca karena bukan huruf
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Levenshtein returns the edit distance between a and b counted in runes.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// Similarity returns 1 for identical normalized strings and approaches 0 as
+// the edit distance grows towards the longer string's length. A string that
+// normalizes to nothing, such as a missing birth place, is no evidence of a
+// match and scores 0, like in PhoneticSimilarity.
+func Similarity(a, b string) float64 {
+	a, b = Normalize(a), Normalize(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	longest := max(len([]rune(a)), len([]rune(b)))
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// Soundex returns the four character American Soundex code of a single word.
+func Soundex(word string) string {
+	word = strings.ToUpper(Normalize(word))
+	if word == "" {
+		return ""
+	}
+
+	codes := map[rune]rune{
+		'B': '1', 'F': '1', 'P': '1', 'V': '1',
+		'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+		'D': '3', 'T': '3',
+		'L': '4',
+		'M': '5', 'N': '5',
+		'R': '6',
+	}
+
+	runes := []rune(word)
+	out := []rune{runes[0]}
+	last := codes[runes[0]]
+	for _, r := range runes[1:] {
+		code, ok := codes[r]
+		if ok && code != last {
+			out = append(out, code)
+			if len(out) == 4 {
+				break
+			}
+		}
+		// H dan W tidak memutus kode yang sama, vokal memutusnya
+		if r != 'H' && r != 'W' {
+			last = code
+		}
+	}
+
+	for len(out) < 4 {
+		out = append(out, '0')
+	}
+	return string(out)
+}
+
+// PhoneticSimilarity compares names word by word using Soundex, so spelling
+// variants such as "Muhamad Yusuf" and "Mohammad Jusuf" still score high.
+func PhoneticSimilarity(a, b string) float64 {
+	wa, wb := strings.Fields(Normalize(a)), strings.Fields(Normalize(b))
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+
+	matched := 0
+	for i := 0; i < min(len(wa), len(wb)); i++ {
+		if Soundex(wa[i]) == Soundex(wb[i]) {
+			matched++
+		}
+	}
+	return float64(matched) / float64(max(len(wa), len(wb)))
+}
+
+// NameSimilarity takes the better of the edit distance and phonetic scores.
+func NameSimilarity(a, b string) float64 {
+	return max(Similarity(a, b), PhoneticSimilarity(a, b))
+}
@@ -0,0 +1,163 @@
+package fuzzy
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// matchThreshold sama dengan duplicate.MatchThreshold, batas skor nama yang
+// dilaporkan sebagai kemungkinan duplikat
+const matchThreshold = 0.75
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Case And Spacing", "  Siti   NURHALIZA ", "siti nurhaliza"},
+		{"Tabs And Newlines", "Budi\tSantoso\n", "budi santoso"},
+		{"Punctuation And Titles", "Muh. Yusuf, S.E.", "muh yusuf se"},
+		{"Digits Kept", "Jl. 17 Agustus", "jl 17 agustus"},
+		{"Composed Diacritics", "José Nguyễn", "jose nguyen"},
+		{"Decomposed Diacritics", "Jose\u0301 Nguye\u0302\u0303n", "jose nguyen"},
+		{"Cedilla And Umlaut", "Ölçer Müller", "olcer muller"},
+		{"Tilde", "Ñandu", "nandu"},
+		{"Non Latin Script", "王小明", "王小明"},
+		{"Only Punctuation", "—.,!?", ""},
+		{"Empty", "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Normalize(tc.input))
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"budi", "budi", 0},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		// Dihitung per rune, bukan per byte
+		{"josé", "jose", 1},
+		{"日本", "日本語", 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.want, Levenshtein(tc.a, tc.b))
+			assert.Equal(t, tc.want, Levenshtein(tc.b, tc.a))
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"Identical After Normalizing", "Budi Santoso", "budi  santoso.", 1},
+		{"Diacritics Ignored", "José Ramos", "Jose Ramos", 1},
+		{"One Letter Added", "Siti Nurhaliza", "Siti Nurhalizah", 1 - 1.0/15},
+		{"Half Different", "Budi", "Andi", 0.5},
+		{"Both Empty", "", "", 0},
+		{"One Empty", "Budi", "", 0},
+		{"Only Punctuation", "-", "Bandung", 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, Similarity(tc.a, tc.b), 1e-9)
+			assert.InDelta(t, tc.want, Similarity(tc.b, tc.a), 1e-9)
+		})
+	}
+}
+
+func TestSoundex(t *testing.T) {
+	cases := []struct {
+		word string
+		want string
+	}{
+		{"Robert", "R163"},
+		{"Rupert", "R163"},
+		{"Rubin", "R150"},
+		{"Ashcraft", "A261"},
+		{"Tymczak", "T522"},
+		{"Pfister", "P236"},
+		{"Honeyman", "H555"},
+		{"Lee", "L000"},
+		{"Muhamad", "M530"},
+		{"Mohammad", "M530"},
+		{"Élodie", "E430"},
+		{"Дмитрий", "Д000"},
+		{"!!", ""},
+		{"", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.word, func(t *testing.T) {
+			got := Soundex(tc.word)
+
+			assert.Equal(t, tc.want, got)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
+
+func TestPhoneticSimilarity(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"Spelling Variants", "Muhamad Yusuf", "Mohamad Yusup", 1},
+		{"One Word Differs", "Muhamad Yusuf", "Mohammad Jusuf", 0.5},
+		{"Extra Word", "Budi", "Budi Santoso", 0.5},
+		{"Word Order Matters", "Budi Santoso", "Santoso Budi", 0},
+		{"Both Empty", "", "", 0},
+		{"One Empty", "Budi", " ", 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, PhoneticSimilarity(tc.a, tc.b), 1e-9)
+		})
+	}
+}
+
+func TestNameSimilarity_Threshold(t *testing.T) {
+	cases := []struct {
+		name  string
+		a, b  string
+		match bool
+	}{
+		{"Trailing Letter", "SITI NURHALIZA", "Siti Nurhalizah", true},
+		{"Accent Dropped", "José Ramos", "Jose Ramos", true},
+		{"Phonetic Variant", "Muhamad Yusuf", "Mohamad Yusup", true},
+		{"Close Spelling", "Muhamad Yusuf", "Mohammad Jusuf", true},
+		{"Same First Name", "Siti Aminah", "Siti Rahmah", false},
+		{"Different People", "Budi Santoso", "Andi Wijaya", false},
+		{"Missing Surname", "Budi", "Budi Santoso", false},
+		{"Both Empty", "", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			score := NameSimilarity(tc.a, tc.b)
+
+			assert.Equal(t, tc.match, score >= matchThreshold, "score %.4f", score)
+			assert.Equal(t, score, NameSimilarity(tc.b, tc.a))
+		})
+	}
+}
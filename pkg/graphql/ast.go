@@ -0,0 +1,118 @@
+package graphql
+
+// Document is a parsed request: its operations and the fragments they
+// spread.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is one query, mutation or subscription of a document.
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// VariableDefinition declares a $variable of an operation.
+type VariableDefinition struct {
+	Name    string
+	Type    *TypeRef
+	Default Value
+	Loc     Location
+}
+
+// TypeRef is a type as written in a variable definition, e.g. [ID!]!.
+type TypeRef struct {
+	Name    string
+	Elem    *TypeRef
+	NonNull bool
+}
+
+func (t *TypeRef) String() string {
+	s := t.Name
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// Selection is a FieldSelection, FragmentSpread or InlineFragment.
+type Selection interface {
+	location() Location
+}
+
+// FieldSelection is a field as selected by the query.
+type FieldSelection struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// ResponseKey is the key of the field in the result.
+func (f *FieldSelection) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Loc        Location
+}
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Loc           Location
+}
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Loc           Location
+}
+
+func (f *FieldSelection) location() Location { return f.Loc }
+func (f *FragmentSpread) location() Location { return f.Loc }
+func (f *InlineFragment) location() Location { return f.Loc }
+
+type Argument struct {
+	Name  string
+	Value Value
+	Loc   Location
+}
+
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Loc       Location
+}
+
+// Value is a literal as parsed: int64, float64, string, bool, nil, Enum,
+// Variable, []Value or []*ObjectField.
+type Value any
+
+// Variable is a $name reference inside a value.
+type Variable string
+
+// Enum is an unquoted name used as a value.
+type Enum string
+
+type ObjectField struct {
+	Name  string
+	Value Value
+}
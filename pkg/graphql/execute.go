@@ -0,0 +1,539 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Request is the body of a GraphQL POST.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is absent when the request
+// failed before execution, and null when a non-null root field failed.
+type Response struct {
+	Data   any
+	Errors []*Error
+
+	executed bool
+}
+
+// Executed reports whether the request got past parsing and validation.
+func (r *Response) Executed() bool {
+	return r.executed
+}
+
+func (r *Response) MarshalJSON() ([]byte, error) {
+	type response struct {
+		Data   *any     `json:"data,omitempty"`
+		Errors []*Error `json:"errors,omitempty"`
+	}
+	out := response{Errors: r.Errors}
+	if r.executed {
+		out.Data = &r.Data
+	}
+	return json.Marshal(out)
+}
+
+// OrderedMap is a result object. It marshals its keys in the order they
+// were selected, as the spec requires.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedMap() *OrderedMap {
+	return &OrderedMap{values: map[string]any{}}
+}
+
+func (m *OrderedMap) set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get returns the value of key.
+func (m *OrderedMap) Get(key string) any {
+	return m.values[key]
+}
+
+// Keys returns the keys in selection order.
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		b.Write(k)
+		b.WriteByte(':')
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Execute parses, validates and runs req. It never returns a Go error:
+// every failure is reported in Response.Errors.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	if op.Type != "query" {
+		return &Response{Errors: []*Error{{
+			Message:    fmt.Sprintf("%s operations are not supported", op.Type),
+			Locations:  []Location{op.Loc},
+			Extensions: map[string]any{"code": CodeValidationFailed},
+		}}}
+	}
+
+	if errs := s.validate(doc, op); len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	variables, errs := coerceVariables(op, req.Variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	e := &executor{ctx: ctx, doc: doc, variables: variables}
+	data, ok := e.selectionSet(s.Query, nil, op.SelectionSet, nil)
+	res := &Response{Errors: e.errors, executed: true}
+	if ok {
+		res.Data = data
+	}
+	return res
+}
+
+func asError(err error) *Error {
+	var gqlErr *Error
+	if errors.As(err, &gqlErr) {
+		return gqlErr
+	}
+	return &Error{Message: err.Error(), Extensions: map[string]any{"code": CodeInternal}}
+}
+
+func validationError(loc Location, format string, args ...any) *Error {
+	return &Error{
+		Message:    fmt.Sprintf(format, args...),
+		Locations:  []Location{loc},
+		Extensions: map[string]any{"code": CodeValidationFailed},
+	}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, &Error{
+				Message:    "Must provide operation name if query contains multiple operations",
+				Extensions: map[string]any{"code": CodeBadUserInput},
+			}
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{
+		Message:    fmt.Sprintf("Unknown operation named %q", name),
+		Extensions: map[string]any{"code": CodeBadUserInput},
+	}
+}
+
+// inputType resolves a type written in a variable definition.
+func inputType(ref *TypeRef) (Type, bool) {
+	var t Type
+	if ref.Elem != nil {
+		elem, ok := inputType(ref.Elem)
+		if !ok {
+			return nil, false
+		}
+		t = ListOf(elem)
+	} else {
+		scalar, ok := builtinScalars[ref.Name]
+		if !ok {
+			return nil, false
+		}
+		t = scalar
+	}
+	if ref.NonNull {
+		t = NonNullOf(t)
+	}
+	return t, true
+}
+
+func coerceVariables(op *Operation, values map[string]any) (map[string]any, []*Error) {
+	coerced := map[string]any{}
+	var errs []*Error
+	for _, def := range op.Variables {
+		t, _ := inputType(def.Type)
+		value, provided := values[def.Name]
+		if !provided && def.Default != nil {
+			value, provided = literal(def.Default, nil), true
+		}
+		if !provided {
+			if _, nonNull := t.(*NonNull); nonNull {
+				errs = append(errs, &Error{
+					Message:    fmt.Sprintf("Variable \"$%s\" of required type \"%s\" was not provided", def.Name, def.Type),
+					Locations:  []Location{def.Loc},
+					Extensions: map[string]any{"code": CodeBadUserInput},
+				})
+			}
+			continue
+		}
+		v, err := coerceInput(t, value)
+		if err != nil {
+			errs = append(errs, &Error{
+				Message:    fmt.Sprintf("Variable \"$%s\" got invalid value: %v", def.Name, err),
+				Locations:  []Location{def.Loc},
+				Extensions: map[string]any{"code": CodeBadUserInput},
+			})
+			continue
+		}
+		coerced[def.Name] = v
+	}
+	return coerced, errs
+}
+
+// literal turns a parsed value into a Go value, reading variables from
+// variables. An undefined variable is nil.
+func literal(v Value, variables map[string]any) any {
+	switch x := v.(type) {
+	case Variable:
+		return variables[string(x)]
+	case Enum:
+		return string(x)
+	case []Value:
+		list := make([]any, len(x))
+		for i, item := range x {
+			list[i] = literal(item, variables)
+		}
+		return list
+	case []*ObjectField:
+		obj := make(map[string]any, len(x))
+		for _, f := range x {
+			obj[f.Name] = literal(f.Value, variables)
+		}
+		return obj
+	}
+	return v
+}
+
+// coerceInput checks value against t and converts it to the Go value
+// resolvers receive.
+func coerceInput(t Type, value any) (any, error) {
+	if nn, ok := t.(*NonNull); ok {
+		if value == nil {
+			return nil, fmt.Errorf("expected non-null value of type %s", t)
+		}
+		return coerceInput(nn.Of, value)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	switch x := t.(type) {
+	case *List:
+		items, ok := value.([]any)
+		if !ok {
+			// Nilai tunggal diperlakukan sebagai list berisi satu elemen
+			item, err := coerceInput(x.Of, value)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		list := make([]any, len(items))
+		for i, item := range items {
+			v, err := coerceInput(x.Of, item)
+			if err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+			list[i] = v
+		}
+		return list, nil
+	case *Scalar:
+		return x.ParseValue(value)
+	}
+	return nil, fmt.Errorf("type %s cannot be used as input", t)
+}
+
+type executor struct {
+	ctx       context.Context
+	doc       *Document
+	variables map[string]any
+	errors    []*Error
+}
+
+func (e *executor) addError(err *Error) {
+	e.errors = append(e.errors, err)
+}
+
+// included evaluates @skip and @include.
+func (e *executor) included(directives []*Directive) bool {
+	for _, d := range directives {
+		if d.Name != "skip" && d.Name != "include" {
+			continue
+		}
+		cond := false
+		for _, arg := range d.Arguments {
+			if arg.Name == "if" {
+				cond, _ = literal(arg.Value, e.variables).(bool)
+			}
+		}
+		if d.Name == "skip" && cond || d.Name == "include" && !cond {
+			return false
+		}
+	}
+	return true
+}
+
+type fieldGroup struct {
+	key    string
+	fields []*FieldSelection
+}
+
+// collectFields flattens fragments into the fields selected on obj,
+// grouped by response key in selection order.
+func (e *executor) collectFields(obj *Object, set []Selection, groups []*fieldGroup, visited map[string]bool) []*fieldGroup {
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *FieldSelection:
+			if !e.included(s.Directives) {
+				continue
+			}
+			key := s.ResponseKey()
+			found := false
+			for _, g := range groups {
+				if g.key == key {
+					g.fields = append(g.fields, s)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*FieldSelection{s}})
+			}
+		case *FragmentSpread:
+			if visited[s.Name] || !e.included(s.Directives) {
+				continue
+			}
+			visited[s.Name] = true
+			fragment := e.doc.Fragments[s.Name]
+			if fragment.TypeCondition != obj.Name {
+				continue
+			}
+			groups = e.collectFields(obj, fragment.SelectionSet, groups, visited)
+		case *InlineFragment:
+			if !e.included(s.Directives) || s.TypeCondition != "" && s.TypeCondition != obj.Name {
+				continue
+			}
+			groups = e.collectFields(obj, s.SelectionSet, groups, visited)
+		}
+	}
+	return groups
+}
+
+// selectionSet resolves set on source. It returns false when a non-null
+// field failed, so the null has to bubble up to the parent.
+func (e *executor) selectionSet(obj *Object, source any, set []Selection, path []any) (*OrderedMap, bool) {
+	result := newOrderedMap()
+	for _, group := range e.collectFields(obj, set, nil, map[string]bool{}) {
+		fieldPath := append(append([]any{}, path...), group.key)
+		value, ok := e.field(obj, source, group.fields, fieldPath)
+		if !ok {
+			return nil, false
+		}
+		result.set(group.key, value)
+	}
+	return result, true
+}
+
+func (e *executor) field(obj *Object, source any, fields []*FieldSelection, path []any) (value any, ok bool) {
+	ast := fields[0]
+	if ast.Name == "__typename" {
+		return obj.Name, true
+	}
+	def := obj.Fields[ast.Name]
+
+	fail := func(err *Error) (any, bool) {
+		err.Locations = []Location{ast.Loc}
+		err.Path = path
+		e.addError(err)
+		_, nonNull := def.Type.(*NonNull)
+		return nil, !nonNull
+	}
+
+	args, err := e.arguments(def, ast)
+	if err != nil {
+		return fail(&Error{Message: err.Error(), Extensions: map[string]any{"code": CodeBadUserInput}})
+	}
+
+	resolved, err := e.resolve(def, ast.Name, ResolveParams{Context: e.ctx, Source: source, Args: args, Path: path})
+	if err != nil {
+		var resolverErr *ResolverError
+		if errors.As(err, &resolverErr) {
+			return fail(&Error{Message: resolverErr.Message, Extensions: map[string]any{"code": resolverErr.Code}})
+		}
+		return fail(&Error{Message: "Internal server error", Extensions: map[string]any{"code": CodeInternal}})
+	}
+
+	return e.complete(def.Type, fields, resolved, path)
+}
+
+func (e *executor) resolve(def *Field, name string, p ResolveParams) (value any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("resolver for %s panicked: %v", name, r)
+		}
+	}()
+	if def.Resolve != nil {
+		return def.Resolve(p)
+	}
+	return defaultResolve(def, name, p.Source)
+}
+
+func (e *executor) arguments(def *Field, ast *FieldSelection) (map[string]any, error) {
+	args := map[string]any{}
+	for name, arg := range def.Args {
+		var value any
+		provided := false
+		for _, a := range ast.Arguments {
+			if a.Name != name {
+				continue
+			}
+			if v, isVar := a.Value.(Variable); isVar {
+				value, provided = e.variables[string(v)]
+			} else {
+				value, provided = literal(a.Value, e.variables), true
+			}
+		}
+		if !provided && arg.Default != nil {
+			value, provided = arg.Default, true
+		}
+		if !provided {
+			if _, nonNull := arg.Type.(*NonNull); nonNull {
+				return nil, fmt.Errorf("argument %q of type %s is required", name, arg.Type)
+			}
+			continue
+		}
+		coerced, err := coerceInput(arg.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q has invalid value: %w", name, err)
+		}
+		args[name] = coerced
+	}
+	return args, nil
+}
+
+// complete shapes a resolved value after t. It returns false when the
+// value is null but t is non-null; the error is already recorded then.
+func (e *executor) complete(t Type, fields []*FieldSelection, value any, path []any) (any, bool) {
+	nn, nonNull := t.(*NonNull)
+	if nonNull {
+		t = nn.Of
+	}
+
+	result, ok := e.completeNullable(t, fields, value, path)
+	if !ok {
+		return nil, !nonNull
+	}
+	if result == nil && nonNull {
+		e.addError(&Error{
+			Message:    fmt.Sprintf("Cannot return null for non-nullable field %s", fields[0].Name),
+			Locations:  []Location{fields[0].Loc},
+			Path:       path,
+			Extensions: map[string]any{"code": CodeInternal},
+		})
+		return nil, false
+	}
+	return result, true
+}
+
+func (e *executor) completeNullable(t Type, fields []*FieldSelection, value any, path []any) (any, bool) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, true
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, true
+	}
+
+	switch x := t.(type) {
+	case *Scalar:
+		serialized, err := x.Serialize(rv.Interface())
+		if err != nil {
+			e.addError(&Error{
+				Message:    err.Error(),
+				Locations:  []Location{fields[0].Loc},
+				Path:       path,
+				Extensions: map[string]any{"code": CodeInternal},
+			})
+			return nil, true
+		}
+		return serialized, true
+
+	case *List:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.addError(&Error{
+				Message:    fmt.Sprintf("expected a list for field %s, got %T", fields[0].Name, value),
+				Locations:  []Location{fields[0].Loc},
+				Path:       path,
+				Extensions: map[string]any{"code": CodeInternal},
+			})
+			return nil, true
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, true
+		}
+		list := make([]any, rv.Len())
+		for i := range list {
+			item, ok := e.complete(x.Of, fields, rv.Index(i).Interface(), append(append([]any{}, path...), i))
+			if !ok {
+				return nil, false
+			}
+			list[i] = item
+		}
+		return list, true
+
+	case *Object:
+		var set []Selection
+		for _, f := range fields {
+			set = append(set, f.SelectionSet...)
+		}
+		// Sumber dikirim apa adanya supaya resolver relasi bisa membaca pointer
+		result, ok := e.selectionSet(x, value, set, path)
+		if !ok {
+			return nil, false
+		}
+		return result, true
+	}
+	return nil, true
+}
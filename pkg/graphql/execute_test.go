@@ -0,0 +1,355 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type LimitResponse struct {
+	TenorMonths int  `json:"tenor_months"`
+	Active      bool `json:"active"`
+}
+
+type CustomerResponse struct {
+	ID       uint64          `json:"id"`
+	FullName string          `json:"full_name"`
+	Email    *string         `json:"email"`
+	JoinedAt time.Time       `json:"joined_at"`
+	Limits   []LimitResponse `json:"limits"`
+	Secret   string          `json:"-"`
+}
+
+var testCustomers = map[string]*CustomerResponse{
+	"1": {ID: 1, FullName: "Budi", JoinedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Limits: []LimitResponse{{TenorMonths: 6, Active: true}, {TenorMonths: 12}}},
+	"2": {ID: 2, FullName: "Annisa"},
+}
+
+// testSchema is a small schema exercising every kind of type and failure.
+func testSchema() *Schema {
+	customer := ObjectOf(CustomerResponse{})
+
+	return &Schema{
+		MaxDepth:      4,
+		MaxComplexity: 50,
+		Query: &Object{Name: "Query", Fields: map[string]*Field{
+			"customer": {
+				Type: customer,
+				Args: map[string]*Arg{"id": {Type: NonNullOf(ID)}},
+				Resolve: func(p ResolveParams) (any, error) {
+					return testCustomers[p.Args["id"].(string)], nil
+				},
+			},
+			"customers": {
+				Type: NonNullOf(ListOf(NonNullOf(customer))),
+				Args: map[string]*Arg{"limit": {Type: Int, Default: 10}},
+				Resolve: func(p ResolveParams) (any, error) {
+					all := []*CustomerResponse{testCustomers["1"], testCustomers["2"]}
+					return all[:min(p.Args["limit"].(int), len(all))], nil
+				},
+			},
+			"me": {
+				Type:    customer,
+				Resolve: func(ResolveParams) (any, error) { return testCustomers["1"], nil },
+			},
+			"echo": {
+				Type: String,
+				Args: map[string]*Arg{
+					"value": {Type: ListOf(NonNullOf(Int))},
+					"at":    {Type: DateTime},
+					"flag":  {Type: Boolean},
+				},
+				Resolve: func(p ResolveParams) (any, error) {
+					return fmt.Sprint(p.Args["value"], p.Args["at"], p.Args["flag"]), nil
+				},
+			},
+			"forbidden": {
+				Type: String,
+				Resolve: func(ResolveParams) (any, error) {
+					return nil, NewError("FORBIDDEN", "Admins only", errors.New("role customer"))
+				},
+			},
+			"broken": {
+				Type:    String,
+				Resolve: func(ResolveParams) (any, error) { return nil, errors.New("dial tcp: connection refused") },
+			},
+			"panics": {
+				Type:    String,
+				Resolve: func(ResolveParams) (any, error) { panic("nil map") },
+			},
+			"required": {
+				Type:    NonNullOf(customer),
+				Resolve: func(ResolveParams) (any, error) { return nil, nil },
+			},
+		}},
+	}
+}
+
+func execute(t *testing.T, query string, variables map[string]any) (*Response, string) {
+	t.Helper()
+
+	res := testSchema().Execute(context.Background(), Request{Query: query, Variables: variables})
+	body, err := json.Marshal(res)
+	require.NoError(t, err)
+	return res, string(body)
+}
+
+func TestExecute_Selection(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		want      string
+	}{
+		{
+			name:  "Fields In Selection Order",
+			query: `{ me { full_name id email joined_at } }`,
+			want:  `{"data":{"me":{"full_name":"Budi","id":1,"email":null,"joined_at":"2026-01-02T03:04:05Z"}}}`,
+		},
+		{
+			name:  "Aliases And Typename",
+			query: `{ first: customer(id: 1) { __typename name: full_name } second: customer(id: "2") { full_name } }`,
+			want:  `{"data":{"first":{"__typename":"Customer","name":"Budi"},"second":{"full_name":"Annisa"}}}`,
+		},
+		{
+			name:  "Lists",
+			query: `{ customers(limit: 1) { id limits { tenor_months active } } }`,
+			want:  `{"data":{"customers":[{"id":1,"limits":[{"tenor_months":6,"active":true},{"tenor_months":12,"active":false}]}]}}`,
+		},
+		{
+			name:  "Argument Default",
+			query: `{ customers { id } }`,
+			want:  `{"data":{"customers":[{"id":1},{"id":2}]}}`,
+		},
+		{
+			name:  "Missing Object Is Null",
+			query: `{ customer(id: 99) { id } }`,
+			want:  `{"data":{"customer":null}}`,
+		},
+		{
+			name:  "Named Fragment Merged With Fields",
+			query: `{ me { id ...profile } } fragment profile on Customer { id full_name }`,
+			want:  `{"data":{"me":{"id":1,"full_name":"Budi"}}}`,
+		},
+		{
+			name:  "Fragment Spread Twice",
+			query: `{ me { ...profile ...profile } } fragment profile on Customer { full_name }`,
+			want:  `{"data":{"me":{"full_name":"Budi"}}}`,
+		},
+		{
+			name:  "Inline Fragments",
+			query: `{ me { ... { id } ... on Customer { full_name } } }`,
+			want:  `{"data":{"me":{"id":1,"full_name":"Budi"}}}`,
+		},
+		{
+			name:      "Skip And Include",
+			query:     `query($hide: Boolean!) { me { id @skip(if: $hide) full_name @include(if: $hide) ...f @include(if: false) } } fragment f on Customer { email }`,
+			variables: map[string]any{"hide": true},
+			want:      `{"data":{"me":{"full_name":"Budi"}}}`,
+		},
+		{
+			name:      "Variables Coerced",
+			query:     `query($ids: [Int!], $at: DateTime, $flag: Boolean = true) { echo(value: $ids, at: $at, flag: $flag) }`,
+			variables: map[string]any{"ids": []any{float64(6), float64(12)}, "at": "2026-10-17T09:00:00+07:00"},
+			want:      `{"data":{"echo":"[6 12] 2026-10-17 09:00:00 +0700 +0700 true"}}`,
+		},
+		{
+			name:  "Single Value As List",
+			query: `{ echo(value: 6) }`,
+			want:  `{"data":{"echo":"[6] <nil> <nil>"}}`,
+		},
+		{
+			name:  "Literal Null",
+			query: `{ echo(value: null, flag: false) }`,
+			want:  `{"data":{"echo":"<nil> <nil> false"}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, body := execute(t, tc.query, tc.variables)
+
+			assert.True(t, res.Executed())
+			assert.JSONEq(t, tc.want, body)
+		})
+	}
+}
+
+func TestExecute_OrderedKeys(t *testing.T) {
+	res, body := execute(t, `{ me { joined_at id full_name } }`, nil)
+
+	me := res.Data.(*OrderedMap).Get("me").(*OrderedMap)
+	assert.Equal(t, []string{"joined_at", "id", "full_name"}, me.Keys())
+	// JSONEq mengabaikan urutan, jadi body dibandingkan persis
+	assert.Equal(t, `{"data":{"me":{"joined_at":"2026-01-02T03:04:05Z","id":1,"full_name":"Budi"}}}`, body)
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		// query selects the failing field next to me, which still resolves
+		query string
+		data  string
+		err   Error
+	}{
+		{
+			name:  "Resolver Error Code",
+			query: `{ me { id } forbidden }`,
+			data:  `{"me":{"id":1},"forbidden":null}`,
+			err: Error{Message: "Admins only", Locations: []Location{{1, 13}}, Path: []any{"forbidden"},
+				Extensions: map[string]any{"code": "FORBIDDEN"}},
+		},
+		{
+			name:  "Internal Error Hidden",
+			query: `{ me { id } broken }`,
+			data:  `{"me":{"id":1},"broken":null}`,
+			err: Error{Message: "Internal server error", Locations: []Location{{1, 13}}, Path: []any{"broken"},
+				Extensions: map[string]any{"code": CodeInternal}},
+		},
+		{
+			name:  "Panic Recovered",
+			query: `{ me { id } panics }`,
+			data:  `{"me":{"id":1},"panics":null}`,
+			err: Error{Message: "Internal server error", Locations: []Location{{1, 13}}, Path: []any{"panics"},
+				Extensions: map[string]any{"code": CodeInternal}},
+		},
+		{
+			name:  "Non-Null Bubbles To Data",
+			query: `{ me { id } required { id } }`,
+			data:  `null`,
+			err: Error{Message: "Cannot return null for non-nullable field required", Locations: []Location{{1, 13}},
+				Path: []any{"required"}, Extensions: map[string]any{"code": CodeInternal}},
+		},
+		{
+			name:  "Invalid Argument",
+			query: `{ me { id } echo(value: 2147483648) }`,
+			data:  `{"me":{"id":1},"echo":null}`,
+			err: Error{Message: `argument "value" has invalid value: Int cannot represent value 2147483648`,
+				Locations: []Location{{1, 13}}, Path: []any{"echo"}, Extensions: map[string]any{"code": CodeBadUserInput}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, body := execute(t, tc.query, nil)
+
+			assert.True(t, res.Executed())
+			require.Len(t, res.Errors, 1)
+			assert.Equal(t, tc.err, *res.Errors[0])
+
+			var out struct{ Data json.RawMessage }
+			require.NoError(t, json.Unmarshal([]byte(body), &out))
+			assert.JSONEq(t, tc.data, string(out.Data))
+		})
+	}
+}
+
+func TestExecute_RejectedBeforeExecution(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		operation string
+		variables map[string]any
+		want      string
+		code      string
+	}{
+		{
+			name:  "Syntax Error",
+			query: `{ me { id }`,
+			want:  "Syntax Error: unterminated selection set opened at 1:1",
+			code:  CodeParseFailed,
+		},
+		{
+			name:  "Mutation",
+			query: `mutation { me { id } }`,
+			want:  "mutation operations are not supported",
+			code:  CodeValidationFailed,
+		},
+		{
+			name:  "Several Operations Without Name",
+			query: `query A { me { id } } query B { me { full_name } }`,
+			want:  "Must provide operation name if query contains multiple operations",
+			code:  CodeBadUserInput,
+		},
+		{
+			name:      "Unknown Operation",
+			query:     `query A { me { id } }`,
+			operation: "B",
+			want:      `Unknown operation named "B"`,
+			code:      CodeBadUserInput,
+		},
+		{
+			name:  "Required Variable Missing",
+			query: `query($id: ID!) { customer(id: $id) { id } }`,
+			want:  `Variable "$id" of required type "ID!" was not provided`,
+			code:  CodeBadUserInput,
+		},
+		{
+			name:      "Variable Of Wrong Type",
+			query:     `query($limit: Int) { customers(limit: $limit) { id } }`,
+			variables: map[string]any{"limit": "ten"},
+			want:      `Variable "$limit" got invalid value: Int cannot represent non-integer value ten`,
+			code:      CodeBadUserInput,
+		},
+		{
+			name:      "Variable List Item Null",
+			query:     `query($ids: [Int!]) { echo(value: $ids) }`,
+			variables: map[string]any{"ids": []any{float64(1), nil}},
+			want:      `Variable "$ids" got invalid value: at index 1: expected non-null value of type Int!`,
+			code:      CodeBadUserInput,
+		},
+		{
+			name:      "Variable Not RFC 3339",
+			query:     `query($at: DateTime) { echo(at: $at) }`,
+			variables: map[string]any{"at": "17/10/2026"},
+			want:      `Variable "$at" got invalid value: DateTime cannot represent "17/10/2026", expected RFC 3339`,
+			code:      CodeBadUserInput,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := testSchema().Execute(context.Background(), Request{Query: tc.query, OperationName: tc.operation, Variables: tc.variables})
+
+			assert.False(t, res.Executed())
+			require.Len(t, res.Errors, 1)
+			assert.Equal(t, tc.want, res.Errors[0].Message)
+			assert.Equal(t, tc.code, res.Errors[0].Code())
+
+			// Tanpa eksekusi key data tidak ada sama sekali
+			body, err := json.Marshal(res)
+			require.NoError(t, err)
+			assert.NotContains(t, string(body), `"data"`)
+		})
+	}
+}
+
+func TestExecute_SelectsNamedOperation(t *testing.T) {
+	res := testSchema().Execute(context.Background(), Request{
+		Query:         `query A { me { id } } query B { me { full_name } }`,
+		OperationName: "B",
+	})
+	body, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"data":{"me":{"full_name":"Budi"}}}`, string(body))
+}
+
+func TestObjectOf(t *testing.T) {
+	obj := ObjectOf(&CustomerResponse{})
+
+	assert.Equal(t, "Customer", obj.Name)
+	assert.Equal(t, "Int!", obj.Fields["id"].Type.String())
+	assert.Equal(t, "String", obj.Fields["email"].Type.String())
+	assert.Equal(t, "DateTime!", obj.Fields["joined_at"].Type.String())
+	assert.Equal(t, "[Limit!]", obj.Fields["limits"].Type.String())
+	assert.NotContains(t, obj.Fields, "Secret")
+	assert.Panics(t, func() { ObjectOf("not a struct") })
+}
@@ -0,0 +1,697 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "<EOF>"
+	case tokenString:
+		return strconv.Quote(t.value)
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// Parse parses a query document. Errors carry the line and column of the
+// offending token.
+func Parse(source string) (*Document, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	doc := &Document{Fragments: map[string]*Fragment{}}
+	for p.peek().kind != tokenEOF {
+		t := p.peek()
+		switch {
+		case t.kind == tokenPunct && t.value == "{":
+			set, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: set, Loc: t.loc})
+		case t.kind == tokenName && (t.value == "query" || t.value == "mutation" || t.value == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case t.kind == tokenName && t.value == "fragment":
+			fragment, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				return nil, syntaxError(fragment.Loc, "there can be only one fragment named %q", fragment.Name)
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, syntaxError(t.loc, "unexpected %s", t)
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, syntaxError(Location{Line: 1, Column: 1}, "document has no operation")
+	}
+	return doc, nil
+}
+
+func syntaxError(loc Location, format string, args ...any) *Error {
+	return &Error{
+		Message:    "Syntax Error: " + fmt.Sprintf(format, args...),
+		Locations:  []Location{loc},
+		Extensions: map[string]any{"code": CodeParseFailed},
+	}
+}
+
+// maxNesting bounds how deep selection sets, list types and input values
+// may nest, so a hostile document is rejected before the recursive parser
+// and validator walk it. Schema.MaxDepth is the limit meant for clients.
+const maxNesting = 64
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// nest enters one level of nesting opened by t. Every successful call is
+// paired with p.depth-- when the level closes.
+func (p *parser) nest(t token) error {
+	p.depth++
+	if p.depth > maxNesting {
+		return syntaxError(t.loc, "document is nested deeper than %d levels", maxNesting)
+	}
+	return nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) peekPunct(value string) bool {
+	t := p.peek()
+	return t.kind == tokenPunct && t.value == value
+}
+
+func (p *parser) expectPunct(value string) (token, error) {
+	t := p.next()
+	if t.kind != tokenPunct || t.value != value {
+		return t, syntaxError(t.loc, "expected %q, found %s", value, t)
+	}
+	return t, nil
+}
+
+func (p *parser) name() (token, error) {
+	t := p.next()
+	if t.kind != tokenName {
+		return t, syntaxError(t.loc, "expected name, found %s", t)
+	}
+	return t, nil
+}
+
+func (p *parser) operation() (*Operation, error) {
+	t := p.next()
+	op := &Operation{Type: t.value, Loc: t.loc}
+	if p.peek().kind == tokenName {
+		op.Name = p.next().value
+	}
+
+	if p.peekPunct("(") {
+		p.next()
+		for !p.peekPunct(")") {
+			def, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, def)
+		}
+		p.next()
+	}
+	// Directive pada operasi tidak dipakai, cukup dilewati
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	set, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.SelectionSet = set
+	return op, nil
+}
+
+func (p *parser) variableDefinition() (*VariableDefinition, error) {
+	start, err := p.expectPunct("$")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expectPunct(":"); err != nil {
+		return nil, err
+	}
+	typ, err := p.typeRef()
+	if err != nil {
+		return nil, err
+	}
+
+	def := &VariableDefinition{Name: name.value, Type: typ, Loc: start.loc}
+	if p.peekPunct("=") {
+		p.next()
+		if def.Default, err = p.value(true); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+func (p *parser) typeRef() (*TypeRef, error) {
+	var ref *TypeRef
+	if p.peekPunct("[") {
+		if err := p.nest(p.next()); err != nil {
+			return nil, err
+		}
+		defer func() { p.depth-- }()
+		elem, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		ref = &TypeRef{Elem: elem}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		ref = &TypeRef{Name: name.value}
+	}
+	if p.peekPunct("!") {
+		p.next()
+		ref.NonNull = true
+	}
+	return ref, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	start := p.next()
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name.value == "on" {
+		return nil, syntaxError(name.loc, "unexpected %s", name)
+	}
+	on, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if on.value != "on" {
+		return nil, syntaxError(on.loc, "expected \"on\", found %s", on)
+	}
+	typeCondition, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	directives, err := p.directives()
+	if err != nil {
+		return nil, err
+	}
+	set, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &Fragment{
+		Name:          name.value,
+		TypeCondition: typeCondition.value,
+		Directives:    directives,
+		SelectionSet:  set,
+		Loc:           start.loc,
+	}, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	open, err := p.expectPunct("{")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.nest(open); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+	var set []Selection
+	for !p.peekPunct("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, syntaxError(p.peek().loc, "unterminated selection set opened at %d:%d", open.loc.Line, open.loc.Column)
+		}
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, selection)
+	}
+	p.next()
+	if len(set) == 0 {
+		return nil, syntaxError(open.loc, "selection set cannot be empty")
+	}
+	return set, nil
+}
+
+func (p *parser) selection() (Selection, error) {
+	if p.peekPunct("...") {
+		return p.fragmentSelection()
+	}
+
+	first, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	field := &FieldSelection{Name: first.value, Loc: first.loc}
+	if p.peekPunct(":") {
+		p.next()
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		field.Alias, field.Name = first.value, name.value
+	}
+	if field.Arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peekPunct("{") {
+		if field.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) fragmentSelection() (Selection, error) {
+	spread := p.next()
+
+	if t := p.peek(); t.kind == tokenName && t.value != "on" {
+		p.next()
+		directives, err := p.directives()
+		if err != nil {
+			return nil, err
+		}
+		return &FragmentSpread{Name: t.value, Directives: directives, Loc: spread.loc}, nil
+	}
+
+	inline := &InlineFragment{Loc: spread.loc}
+	if t := p.peek(); t.kind == tokenName && t.value == "on" {
+		p.next()
+		typeCondition, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		inline.TypeCondition = typeCondition.value
+	}
+	var err error
+	if inline.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if inline.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*Argument, error) {
+	if !p.peekPunct("(") {
+		return nil, nil
+	}
+	p.next()
+	var args []*Argument
+	for !p.peekPunct(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, &Argument{Name: name.value, Value: value, Loc: name.loc})
+	}
+	p.next()
+	if len(args) == 0 {
+		return nil, syntaxError(p.peek().loc, "argument list cannot be empty")
+	}
+	return args, nil
+}
+
+func (p *parser) directives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.peekPunct("@") {
+		at := p.next()
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments(false)
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, &Directive{Name: name.value, Arguments: args, Loc: at.loc})
+	}
+	return directives, nil
+}
+
+// value parses a literal. constant rejects variables, as in default values.
+func (p *parser) value(constant bool) (Value, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenInt:
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, syntaxError(t.loc, "invalid int %s", t.value)
+		}
+		return n, nil
+	case tokenFloat:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, syntaxError(t.loc, "invalid float %s", t.value)
+		}
+		return f, nil
+	case tokenString:
+		return t.value, nil
+	case tokenName:
+		switch t.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return Enum(t.value), nil
+	case tokenPunct:
+		switch t.value {
+		case "$":
+			if constant {
+				return nil, syntaxError(t.loc, "unexpected variable in constant value")
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			return Variable(name.value), nil
+		case "[":
+			if err := p.nest(t); err != nil {
+				return nil, err
+			}
+			defer func() { p.depth-- }()
+			list := []Value{}
+			for !p.peekPunct("]") {
+				item, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			p.next()
+			return list, nil
+		case "{":
+			if err := p.nest(t); err != nil {
+				return nil, err
+			}
+			defer func() { p.depth-- }()
+			fields := []*ObjectField{}
+			for !p.peekPunct("}") {
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if _, err := p.expectPunct(":"); err != nil {
+					return nil, err
+				}
+				value, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, &ObjectField{Name: name.value, Value: value})
+			}
+			p.next()
+			return fields, nil
+		}
+	}
+	return nil, syntaxError(t.loc, "unexpected %s", t)
+}
+
+// lex splits source into tokens. Commas, whitespace and comments are
+// insignificant in GraphQL and are dropped here.
+func lex(source string) ([]token, error) {
+	source = strings.TrimPrefix(source, "\uFEFF")
+	var tokens []token
+	line, lineStart := 1, 0
+	// Kolom dihitung bertahap dari token sebelumnya agar baris yang sangat
+	// panjang tidak membuat lexer kuadratik
+	col, colStart := 1, 0
+	column := func(i int) int {
+		if colStart < lineStart {
+			col, colStart = 1, lineStart
+		}
+		col += utf8.RuneCountInString(source[colStart:i])
+		colStart = i
+		return col
+	}
+	for i := 0; i < len(source); {
+		loc := Location{Line: line, Column: column(i)}
+		c := source[i]
+		switch {
+		case c == '\n':
+			i++
+			line, lineStart = line+1, i
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(source[i:], "..."):
+			tokens = append(tokens, token{kind: tokenPunct, value: "...", loc: loc})
+			i += 3
+		case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunct, value: string(c), loc: loc})
+			i++
+		case c == '_' || isLetter(c):
+			j := i + 1
+			for j < len(source) && (source[j] == '_' || isLetter(source[j]) || isDigit(source[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenName, value: source[i:j], loc: loc})
+			i = j
+		case c == '-' || isDigit(c):
+			j, kind, err := lexNumber(source, i, loc)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: kind, value: source[i:j], loc: loc})
+			i = j
+		case strings.HasPrefix(source[i:], `"""`):
+			end := strings.Index(source[i+3:], `"""`)
+			for end >= 0 && source[i+3+end-1] == '\\' {
+				next := strings.Index(source[i+3+end+3:], `"""`)
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += 3 + next
+			}
+			if end < 0 {
+				return nil, syntaxError(loc, "unterminated string")
+			}
+			raw := source[i+3 : i+3+end]
+			tokens = append(tokens, token{kind: tokenString, value: blockString(raw), loc: loc})
+			line += strings.Count(raw, "\n")
+			if n := strings.LastIndexByte(raw, '\n'); n >= 0 {
+				lineStart = i + 3 + n + 1
+			}
+			i += 3 + end + 3
+		case c == '"':
+			value, n, err := lexString(source[i:], loc)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, loc: loc})
+			i += n
+		default:
+			r, _ := utf8.DecodeRuneInString(source[i:])
+			return nil, syntaxError(loc, "unexpected character %q", r)
+		}
+	}
+	loc := Location{Line: line, Column: column(len(source))}
+	return append(tokens, token{kind: tokenEOF, loc: loc}), nil
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+func lexNumber(source string, i int, loc Location) (int, tokenKind, error) {
+	j, kind := i, tokenInt
+	if source[j] == '-' {
+		j++
+	}
+	digits := func() bool {
+		start := j
+		for j < len(source) && isDigit(source[j]) {
+			j++
+		}
+		return j > start
+	}
+	if j < len(source) && source[j] == '0' {
+		j++
+		if j < len(source) && isDigit(source[j]) {
+			return 0, 0, syntaxError(loc, "invalid number, unexpected digit after 0")
+		}
+	} else if !digits() {
+		return 0, 0, syntaxError(loc, "invalid number %q", source[i:j])
+	}
+	if j < len(source) && source[j] == '.' {
+		j++
+		kind = tokenFloat
+		if !digits() {
+			return 0, 0, syntaxError(loc, "invalid number %q", source[i:j])
+		}
+	}
+	if j < len(source) && (source[j] == 'e' || source[j] == 'E') {
+		j++
+		kind = tokenFloat
+		if j < len(source) && (source[j] == '+' || source[j] == '-') {
+			j++
+		}
+		if !digits() {
+			return 0, 0, syntaxError(loc, "invalid number %q", source[i:j])
+		}
+	}
+	if j < len(source) && (source[j] == '_' || isLetter(source[j]) || source[j] == '.') {
+		return 0, 0, syntaxError(loc, "invalid number %q", source[i:j+1])
+	}
+	return j, kind, nil
+}
+
+// lexString reads a quoted string at the start of s and returns its value
+// and the number of bytes it spans.
+func lexString(s string, loc Location) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			return b.String(), i + 1, nil
+		case c == '\n' || c == '\r':
+			return "", 0, syntaxError(loc, "unterminated string")
+		case c == '\\':
+			if i+1 >= len(s) {
+				return "", 0, syntaxError(loc, "unterminated string")
+			}
+			switch s[i+1] {
+			case '"', '\\', '/':
+				b.WriteByte(s[i+1])
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if i+6 > len(s) {
+					return "", 0, syntaxError(loc, "invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(s[i+2:i+6], 16, 32)
+				if err != nil {
+					return "", 0, syntaxError(loc, "invalid unicode escape \\u%s", s[i+2:i+6])
+				}
+				b.WriteRune(rune(r))
+				i += 4
+			default:
+				return "", 0, syntaxError(loc, "invalid escape \\%c", s[i+1])
+			}
+			i += 2
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, syntaxError(loc, "unterminated string")
+}
+
+// blockString strips the common indentation and the blank first and last
+// lines of a """block string""".
+func blockString(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(strings.ReplaceAll(raw, `\"""`, `"""`), "\r\n", "\n"), "\n")
+	indent := -1
+	for _, l := range lines[1:] {
+		trimmed := strings.TrimLeft(l, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(l) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
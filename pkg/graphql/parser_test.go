@@ -0,0 +1,225 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Document(t *testing.T) {
+	doc, err := Parse(`
+		# Dashboard nasabah
+		query Customer($id: ID!, $tenors: [Int!] = [6, 12]) @cache {
+			customer(id: $id) {
+				name: full_name
+				...limits @include(if: true)
+				... on Customer { id }
+			}
+		}
+		fragment limits on Customer { limits { tenor_months } }`)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Operations, 1)
+	op := doc.Operations[0]
+	assert.Equal(t, "query", op.Type)
+	assert.Equal(t, "Customer", op.Name)
+	assert.Equal(t, Location{Line: 3, Column: 3}, op.Loc)
+
+	require.Len(t, op.Variables, 2)
+	assert.Equal(t, "id", op.Variables[0].Name)
+	assert.Equal(t, "ID!", op.Variables[0].Type.String())
+	assert.Equal(t, "[Int!]", op.Variables[1].Type.String())
+	assert.Equal(t, []Value{int64(6), int64(12)}, op.Variables[1].Default)
+
+	require.Len(t, op.SelectionSet, 1)
+	customer := op.SelectionSet[0].(*FieldSelection)
+	assert.Equal(t, "customer", customer.Name)
+	require.Len(t, customer.Arguments, 1)
+	assert.Equal(t, Variable("id"), customer.Arguments[0].Value)
+
+	require.Len(t, customer.SelectionSet, 3)
+	name := customer.SelectionSet[0].(*FieldSelection)
+	assert.Equal(t, "name", name.ResponseKey())
+	assert.Equal(t, "full_name", name.Name)
+
+	spread := customer.SelectionSet[1].(*FragmentSpread)
+	assert.Equal(t, "limits", spread.Name)
+	require.Len(t, spread.Directives, 1)
+	assert.Equal(t, "include", spread.Directives[0].Name)
+
+	inline := customer.SelectionSet[2].(*InlineFragment)
+	assert.Equal(t, "Customer", inline.TypeCondition)
+
+	fragment := doc.Fragments["limits"]
+	require.NotNil(t, fragment)
+	assert.Equal(t, "Customer", fragment.TypeCondition)
+	assert.Equal(t, Location{Line: 10, Column: 3}, fragment.Loc)
+}
+
+func TestParse_ShorthandQuery(t *testing.T) {
+	doc, err := Parse("\uFEFF{ me { id } }")
+	require.NoError(t, err)
+
+	require.Len(t, doc.Operations, 1)
+	assert.Equal(t, "query", doc.Operations[0].Type)
+	assert.Empty(t, doc.Operations[0].Name)
+}
+
+func TestParse_Values(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  Value
+	}{
+		{"Int", "-42", int64(-42)},
+		{"Zero", "0", int64(0)},
+		{"Float", "1.5e3", float64(1500)},
+		{"String", `"Jl. Sudirman \"No. 1\"\n"`, "Jl. Sudirman \"No. 1\"\n"},
+		{"Unicode Escape", `"Rp\u00a0100"`, "Rp\u00a0100"},
+		{"Block String", "\"\"\"\n    Baris satu\n      Baris dua\n  \"\"\"", "Baris satu\n  Baris dua"},
+		{"Escaped Block Quote", `"""say \""" done"""`, `say """ done`},
+		{"True", "true", true},
+		{"Null", "null", nil},
+		{"Enum", "ACTIVE", Enum("ACTIVE")},
+		{"Variable", "$status", Variable("status")},
+		{"Empty List", "[]", []Value{}},
+		{"List", `[1, "dua", $tiga]`, []Value{int64(1), "dua", Variable("tiga")}},
+		{"Object", `{month: "2026-10", page: 2}`, []*ObjectField{{Name: "month", Value: "2026-10"}, {Name: "page", Value: int64(2)}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := Parse("{ echo(value: " + tc.value + ") }")
+			require.NoError(t, err)
+
+			field := doc.Operations[0].SelectionSet[0].(*FieldSelection)
+			require.Len(t, field.Arguments, 1)
+			assert.Equal(t, tc.want, field.Arguments[0].Value)
+		})
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		want   string
+		loc    Location
+	}{
+		{"Empty", "", "Syntax Error: document has no operation", Location{1, 1}},
+		{"Only Fragment", "fragment f on Customer { id }", "Syntax Error: document has no operation", Location{1, 1}},
+		{"Unterminated Selection", "{ me { id }", "Syntax Error: unterminated selection set opened at 1:1", Location{1, 12}},
+		{"Empty Selection", "{ }", "Syntax Error: selection set cannot be empty", Location{1, 1}},
+		{"Empty Arguments", "{ customer() { id } }", "Syntax Error: argument list cannot be empty", Location{1, 14}},
+		{"Missing Colon", "{ customer(id 1) { id } }", `Syntax Error: expected ":", found "1"`, Location{1, 15}},
+		{"Unexpected Character", "{ me { id ? } }", `Syntax Error: unexpected character '?'`, Location{1, 11}},
+		{"Unknown Definition", "schema { query: Query }", `Syntax Error: unexpected "schema"`, Location{1, 1}},
+		{"Unterminated String", "{ echo(value: \"abc\n\") }", "Syntax Error: unterminated string", Location{1, 15}},
+		{"Unterminated Block String", `{ echo(value: """abc) }`, "Syntax Error: unterminated string", Location{1, 15}},
+		{"Bad Escape", `{ echo(value: "\x") }`, `Syntax Error: invalid escape \x`, Location{1, 15}},
+		{"Bad Unicode Escape", `{ echo(value: "\u12") }`, `Syntax Error: invalid unicode escape \u12")`, Location{1, 15}},
+		{"Leading Zero", "{ echo(value: 007) }", "Syntax Error: invalid number, unexpected digit after 0", Location{1, 15}},
+		{"Dangling Exponent", "{ echo(value: 1e) }", `Syntax Error: invalid number "1e"`, Location{1, 15}},
+		{"Name After Number", "{ echo(value: 12abc) }", `Syntax Error: invalid number "12a"`, Location{1, 15}},
+		{"Int Overflow", "{ echo(value: 99999999999999999999) }", "Syntax Error: invalid int 99999999999999999999", Location{1, 15}},
+		{"Variable In Default", "query($a: Int = $b) { me { id } }", "Syntax Error: unexpected variable in constant value", Location{1, 17}},
+		{"Fragment Named On", "fragment on on Customer { id } { me { id } }", `Syntax Error: unexpected "on"`, Location{1, 10}},
+		{"Fragment Without On", "fragment f Customer { id } { me { id } }", `Syntax Error: expected "on", found "Customer"`, Location{1, 12}},
+		{"Duplicate Fragment", "{ me { ...f } } fragment f on Customer { id } fragment f on Customer { id }", `Syntax Error: there can be only one fragment named "f"`, Location{1, 47}},
+		{"Unclosed List Type", "query($a: [Int) { me { id } }", `Syntax Error: expected "]", found ")"`, Location{1, 15}},
+		{"Column Counts Runes", `{ echo(value: "é") ? }`, `Syntax Error: unexpected character '?'`, Location{1, 20}},
+		{"Location After Block String", "{ echo(value: \"\"\"a\n  bc\"\"\" ?) }", `Syntax Error: unexpected character '?'`, Location{2, 9}},
+		{"Location On Later Line", "{\n  me {\n    id\n  }\n  @\n}", `Syntax Error: expected name, found "@"`, Location{5, 3}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := Parse(tc.source)
+			assert.Nil(t, doc)
+
+			var gqlErr *Error
+			require.True(t, errors.As(err, &gqlErr), "got %v", err)
+			assert.Equal(t, tc.want, gqlErr.Message)
+			assert.Equal(t, []Location{tc.loc}, gqlErr.Locations)
+			assert.Equal(t, CodeParseFailed, gqlErr.Code())
+		})
+	}
+}
+
+func TestParse_NestingLimit(t *testing.T) {
+	nested := func(levels int) string {
+		return "{" + strings.Repeat("a {", levels-1) + " a " + strings.Repeat("}", levels)
+	}
+	list := func(levels int) string {
+		return "{ echo(value: " + strings.Repeat("[", levels) + strings.Repeat("]", levels) + ") }"
+	}
+	object := func(levels int) string {
+		return "{ echo(value: " + strings.Repeat("{a: ", levels) + "1" + strings.Repeat("}", levels) + ") }"
+	}
+	listType := func(levels int) string {
+		return "query($a: " + strings.Repeat("[", levels) + "Int" + strings.Repeat("]", levels) + ") { me { id } }"
+	}
+
+	for name, build := range map[string]func(int) string{
+		"Selection Sets": nested,
+		"Lists":          func(levels int) string { return list(levels - 1) },
+		"Input Objects":  func(levels int) string { return object(levels - 1) },
+		"List Types":     listType,
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Nilai argumen sudah berada satu level di dalam selection set operasi
+			_, err := Parse(build(maxNesting))
+			assert.NoError(t, err)
+
+			_, err = Parse(build(maxNesting + 1))
+			assert.ErrorContains(t, err, "document is nested deeper than 64 levels")
+		})
+	}
+
+	t.Run("Huge Document", func(t *testing.T) {
+		_, err := Parse(nested(100000))
+		assert.ErrorContains(t, err, "document is nested deeper than 64 levels")
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	seeds := []string{
+		`{ me { id full_name } }`,
+		`query Q($id: ID!, $n: [Int!] = [1, 2]) { customer(id: $id) { ...f limits @skip(if: false) { tenor_months } } } fragment f on Customer { id }`,
+		`{ a: customers(limit: 2) { ... on Customer { __typename } } }`,
+		`{ echo(value: {a: [1.5e3, "xé", """blok"""], b: null, c: ENUM}) }`,
+		`mutation { me { id } }`,
+		`{ me { ...a } } fragment a on Customer { ...a }`,
+		`{ echo(value: "unterminated`,
+		`{{{{[[[[`,
+		"\uFEFF# komentar\n{ me { id } }",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	schema := testSchema()
+	f.Fuzz(func(t *testing.T, source string) {
+		doc, err := Parse(source)
+		if err != nil {
+			var gqlErr *Error
+			if !errors.As(err, &gqlErr) || gqlErr.Code() != CodeParseFailed || len(gqlErr.Locations) != 1 {
+				t.Fatalf("Parse(%q) returned %v, want a located syntax error", source, err)
+			}
+			return
+		}
+		if len(doc.Operations) == 0 {
+			t.Fatalf("Parse(%q) returned a document without operations", source)
+		}
+
+		// Dokumen yang lolos parse tidak boleh membuat validasi atau eksekusi panik
+		res := schema.Execute(context.Background(), Request{Query: source})
+		if !res.Executed() && len(res.Errors) == 0 {
+			t.Fatalf("Execute(%q) rejected the query without errors", source)
+		}
+	})
+}
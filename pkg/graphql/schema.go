@@ -0,0 +1,452 @@
+// Package graphql executes GraphQL queries against a schema of resolvers.
+//
+// It implements the query subset of the spec that read-only APIs need:
+// variables, aliases, fragments, @skip/@include and __typename, with
+// errors reported in the standard {data, errors} envelope. Mutations,
+// subscriptions, interfaces and introspection are not supported; writes
+// stay on the REST endpoints.
+//
+// Object types can be declared by hand or derived from response structs
+// with ObjectOf, which names fields after their JSON keys so a GraphQL
+// client sees the same names as a REST client.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error codes reported in extensions.code.
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeInternal         = "INTERNAL_SERVER_ERROR"
+)
+
+// Location is a line and column of the query, both starting at 1.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is one entry of the errors of a response.
+type Error struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Code returns extensions.code.
+func (e *Error) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// ResolverError is returned by a resolver to report message to the client
+// under code. Any other resolver error is reported as an internal error
+// without its message.
+type ResolverError struct {
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *ResolverError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *ResolverError) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns a ResolverError wrapping err.
+func NewError(code, message string, err error) error {
+	return &ResolverError{Code: code, Message: message, Err: err}
+}
+
+// Type is a Scalar, *Object, *List or *NonNull.
+type Type interface {
+	String() string
+}
+
+// Scalar is a leaf type. Serialize turns a resolved Go value into its JSON
+// representation and ParseValue coerces an argument or variable.
+type Scalar struct {
+	Name       string
+	Serialize  func(v any) (any, error)
+	ParseValue func(v any) (any, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+
+// List is [Of].
+type List struct{ Of Type }
+
+func (l *List) String() string { return "[" + l.Of.String() + "]" }
+
+// NonNull is Of!.
+type NonNull struct{ Of Type }
+
+func (n *NonNull) String() string { return n.Of.String() + "!" }
+
+// ListOf returns [t].
+func ListOf(t Type) *List { return &List{Of: t} }
+
+// NonNullOf returns t!.
+func NonNullOf(t Type) *NonNull { return &NonNull{Of: t} }
+
+// Object is an output type with named fields.
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+func (o *Object) String() string { return o.Name }
+
+// Field is a field of an Object. A nil Resolve reads the field from the
+// source value: a struct field with the matching JSON key or a map entry.
+type Field struct {
+	Type    Type
+	Args    map[string]*Arg
+	Resolve ResolveFunc
+
+	index []int
+}
+
+// Arg is an argument of a field. Default is used when the argument is
+// omitted and is coerced like a supplied value.
+type Arg struct {
+	Type    Type
+	Default any
+}
+
+// ResolveFunc produces the value of a field.
+type ResolveFunc func(p ResolveParams) (any, error)
+
+// ResolveParams is what a resolver is given.
+type ResolveParams struct {
+	Context context.Context
+	// Source is the value of the parent object, nil on the root.
+	Source any
+	// Args holds the coerced arguments: int, float64, string, bool,
+	// time.Time or []any of those. Omitted arguments without a default
+	// are absent.
+	Args map[string]any
+	// Path is the response path of the field.
+	Path []any
+}
+
+// Schema is the root query type and the limits applied to every request.
+type Schema struct {
+	Query *Object
+	// MaxDepth rejects queries nesting fields deeper than this. Zero
+	// means unlimited.
+	MaxDepth int
+	// MaxComplexity rejects queries selecting more fields than this,
+	// counting the fields of a fragment every time it is spread, so
+	// aliases and nested spreads cannot multiply the work. Zero means
+	// unlimited.
+	MaxComplexity int
+}
+
+// Built-in scalars. Decimal amounts are served as strings so no precision
+// is lost, the same way the REST responses encode them.
+var (
+	Int = &Scalar{
+		Name:       "Int",
+		Serialize:  serializeInt,
+		ParseValue: parseInt,
+	}
+	Float = &Scalar{
+		Name:       "Float",
+		Serialize:  serializeFloat,
+		ParseValue: parseFloat,
+	}
+	String = &Scalar{
+		Name:       "String",
+		Serialize:  serializeString,
+		ParseValue: parseString,
+	}
+	Boolean = &Scalar{
+		Name: "Boolean",
+		Serialize: func(v any) (any, error) {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Bool {
+				return rv.Bool(), nil
+			}
+			return nil, fmt.Errorf("cannot represent %T as Boolean", v)
+		},
+		ParseValue: func(v any) (any, error) {
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent %v", v)
+		},
+	}
+	ID = &Scalar{
+		Name:       "ID",
+		Serialize:  serializeString,
+		ParseValue: parseID,
+	}
+	DateTime = &Scalar{
+		Name: "DateTime",
+		Serialize: func(v any) (any, error) {
+			if t, ok := v.(time.Time); ok {
+				return t.Format(time.RFC3339), nil
+			}
+			return nil, fmt.Errorf("cannot represent %T as DateTime", v)
+		},
+		ParseValue: func(v any) (any, error) {
+			// Variabel sudah dikonversi sebelum dipakai sebagai argumen
+			if t, ok := v.(time.Time); ok {
+				return t, nil
+			}
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("DateTime cannot represent %v", v)
+			}
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return nil, fmt.Errorf("DateTime cannot represent %q, expected RFC 3339", s)
+			}
+			return t, nil
+		},
+	}
+)
+
+var builtinScalars = map[string]*Scalar{
+	"Int": Int, "Float": Float, "String": String, "Boolean": Boolean, "ID": ID, "DateTime": DateTime,
+}
+
+func serializeInt(v any) (any, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), nil
+	}
+	return nil, fmt.Errorf("cannot represent %T as Int", v)
+}
+
+func serializeFloat(v any) (any, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("cannot represent %T as Float", v)
+}
+
+func serializeString(v any) (any, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case time.Time:
+		return s.Format(time.RFC3339), nil
+	case fmt.Stringer:
+		return s.String(), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return nil, fmt.Errorf("cannot represent %T as String", v)
+}
+
+func parseInt(v any) (any, error) {
+	var n float64
+	switch x := v.(type) {
+	case int:
+		n = float64(x)
+	case int64:
+		n = float64(x)
+	case float64:
+		n = x
+	default:
+		return nil, fmt.Errorf("Int cannot represent non-integer value %v", v)
+	}
+	if n != math.Trunc(n) || n > math.MaxInt32 || n < math.MinInt32 {
+		return nil, fmt.Errorf("Int cannot represent value %v", v)
+	}
+	return int(n), nil
+}
+
+func parseFloat(v any) (any, error) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), nil
+	case int64:
+		return float64(x), nil
+	case float64:
+		return x, nil
+	}
+	return nil, fmt.Errorf("Float cannot represent non-numeric value %v", v)
+}
+
+func parseString(v any) (any, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("String cannot represent a non-string value %v", v)
+}
+
+func parseID(v any) (any, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case int:
+		return strconv.Itoa(x), nil
+	case int64:
+		return strconv.FormatInt(x, 10), nil
+	case float64:
+		if x == math.Trunc(x) {
+			return strconv.FormatFloat(x, 'f', 0, 64), nil
+		}
+	}
+	return nil, fmt.Errorf("ID cannot represent value %v", v)
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// ObjectOf derives an object type from the struct sample, named after the
+// struct without its Response suffix. Every exported field with a JSON key
+// becomes a field of that name; nested structs become objects of their
+// own, slices become lists and pointers become nullable. time.Time is a
+// DateTime and any other fmt.Stringer, such as decimal.Decimal, a String.
+// Fields of other kinds, such as maps, are left out.
+//
+// Fields can be added or replaced on the result to resolve relations.
+func ObjectOf(sample any) *Object {
+	t := reflect.TypeOf(sample)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("graphql: ObjectOf needs a struct, got %s", t))
+	}
+	return objectOf(t, map[reflect.Type]*Object{})
+}
+
+func objectOf(t reflect.Type, seen map[reflect.Type]*Object) *Object {
+	if obj, ok := seen[t]; ok {
+		return obj
+	}
+	obj := &Object{Name: strings.TrimSuffix(t.Name(), "Response"), Fields: map[string]*Field{}}
+	seen[t] = obj
+	addStructFields(obj, t, nil, seen)
+	return obj
+}
+
+func addStructFields(obj *Object, t reflect.Type, index []int, seen map[reflect.Type]*Object) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && sf.Tag.Get("json") == "" {
+			addStructFields(obj, sf.Type, fieldIndex, seen)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		typ := typeOf(sf.Type, seen)
+		if typ == nil {
+			continue
+		}
+		if sf.Type.Kind() != reflect.Pointer && sf.Type.Kind() != reflect.Slice {
+			typ = NonNullOf(typ)
+		}
+		obj.Fields[name] = &Field{Type: typ, index: fieldIndex}
+	}
+}
+
+// typeOf maps a Go type to a GraphQL type, nil when it has no counterpart.
+func typeOf(t reflect.Type, seen map[reflect.Type]*Object) Type {
+	switch {
+	case t == timeType:
+		return DateTime
+	case t.Implements(stringerType):
+		return String
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeOf(t.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		elem := typeOf(t.Elem(), seen)
+		if elem == nil {
+			return nil
+		}
+		if t.Elem().Kind() != reflect.Pointer {
+			elem = NonNullOf(elem)
+		}
+		return ListOf(elem)
+	case reflect.Struct:
+		return objectOf(t, seen)
+	case reflect.String:
+		return String
+	case reflect.Bool:
+		return Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Int
+	case reflect.Float32, reflect.Float64:
+		return Float
+	}
+	return nil
+}
+
+// defaultResolve reads the field named name from source.
+func defaultResolve(field *Field, name string, source any) (any, error) {
+	if m, ok := source.(map[string]any); ok {
+		return m[name], nil
+	}
+
+	v := reflect.ValueOf(source)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if field.index == nil || v.Kind() != reflect.Struct {
+		return nil, errors.New("no resolver for field " + name)
+	}
+	f, err := v.FieldByIndexErr(field.index)
+	if err != nil {
+		return nil, nil
+	}
+	return f.Interface(), nil
+}
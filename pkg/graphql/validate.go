@@ -0,0 +1,186 @@
+package graphql
+
+// validate checks op against the schema before anything is resolved, so
+// a bad query fails as a whole instead of half way.
+func (s *Schema) validate(doc *Document, op *Operation) []*Error {
+	v := &validator{schema: s, doc: doc, defined: map[string]bool{}}
+
+	for _, def := range op.Variables {
+		if v.defined[def.Name] {
+			v.errorf(def.Loc, "There can be only one variable named \"$%s\"", def.Name)
+		}
+		v.defined[def.Name] = true
+		if _, ok := inputType(def.Type); !ok {
+			v.errorf(def.Loc, "Variable \"$%s\" cannot be of type \"%s\"", def.Name, def.Type)
+		}
+	}
+
+	v.selectionSet(s.Query, op.SelectionSet, 1, map[string]bool{})
+	return v.errors
+}
+
+type validator struct {
+	schema  *Schema
+	doc     *Document
+	defined map[string]bool
+	errors  []*Error
+
+	// fields counts the selected fields against MaxComplexity.
+	fields     int
+	tooComplex bool
+}
+
+func (v *validator) errorf(loc Location, format string, args ...any) {
+	v.errors = append(v.errors, validationError(loc, format, args...))
+}
+
+func namedType(t Type) Type {
+	for {
+		switch x := t.(type) {
+		case *NonNull:
+			t = x.Of
+		case *List:
+			t = x.Of
+		default:
+			return t
+		}
+	}
+}
+
+// selectionSet validates set on obj. spreading holds the fragments being
+// expanded, to reject cycles.
+func (v *validator) selectionSet(obj *Object, set []Selection, depth int, spreading map[string]bool) {
+	for _, selection := range set {
+		// Sisa query tidak perlu diperiksa, fragment bertingkat bisa meledak
+		if v.tooComplex {
+			return
+		}
+		switch s := selection.(type) {
+		case *FieldSelection:
+			v.field(obj, s, depth, spreading)
+		case *FragmentSpread:
+			v.directives(s.Directives)
+			fragment, ok := v.doc.Fragments[s.Name]
+			if !ok {
+				v.errorf(s.Loc, "Unknown fragment %q", s.Name)
+				continue
+			}
+			if spreading[s.Name] {
+				v.errorf(s.Loc, "Cannot spread fragment %q within itself", s.Name)
+				continue
+			}
+			if fragment.TypeCondition != obj.Name {
+				v.errorf(s.Loc, "Fragment %q cannot be spread here as objects of type %q can never be of type %q", s.Name, obj.Name, fragment.TypeCondition)
+				continue
+			}
+			spreading[s.Name] = true
+			v.selectionSet(obj, fragment.SelectionSet, depth, spreading)
+			delete(spreading, s.Name)
+		case *InlineFragment:
+			v.directives(s.Directives)
+			if s.TypeCondition != "" && s.TypeCondition != obj.Name {
+				v.errorf(s.Loc, "Fragment cannot be spread here as objects of type %q can never be of type %q", obj.Name, s.TypeCondition)
+				continue
+			}
+			v.selectionSet(obj, s.SelectionSet, depth, spreading)
+		}
+	}
+}
+
+func (v *validator) field(obj *Object, f *FieldSelection, depth int, spreading map[string]bool) {
+	v.directives(f.Directives)
+	v.fields++
+	if v.schema.MaxComplexity > 0 && v.fields > v.schema.MaxComplexity {
+		v.errorf(f.Loc, "Query selects more than the maximum of %d fields", v.schema.MaxComplexity)
+		v.tooComplex = true
+		return
+	}
+	if v.schema.MaxDepth > 0 && depth > v.schema.MaxDepth {
+		v.errorf(f.Loc, "Query is nested deeper than the maximum depth of %d", v.schema.MaxDepth)
+		return
+	}
+
+	if f.Name == "__typename" {
+		if len(f.SelectionSet) > 0 {
+			v.errorf(f.Loc, "Field \"__typename\" must not have a selection since type \"String!\" has no subfields")
+		}
+		return
+	}
+
+	def, ok := obj.Fields[f.Name]
+	if !ok {
+		v.errorf(f.Loc, "Cannot query field %q on type %q", f.Name, obj.Name)
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, arg := range f.Arguments {
+		if seen[arg.Name] {
+			v.errorf(arg.Loc, "There can be only one argument named %q", arg.Name)
+		}
+		seen[arg.Name] = true
+		if _, ok := def.Args[arg.Name]; !ok {
+			v.errorf(arg.Loc, "Unknown argument %q on field \"%s.%s\"", arg.Name, obj.Name, f.Name)
+		}
+		v.variables(arg.Loc, arg.Value)
+	}
+	for name, arg := range def.Args {
+		if _, nonNull := arg.Type.(*NonNull); nonNull && arg.Default == nil && !seen[name] {
+			v.errorf(f.Loc, "Field \"%s.%s\" argument %q of type %q is required, but it was not provided", obj.Name, f.Name, name, arg.Type)
+		}
+	}
+
+	child, isObject := namedType(def.Type).(*Object)
+	switch {
+	case isObject && len(f.SelectionSet) == 0:
+		v.errorf(f.Loc, "Field %q of type %q must have a selection of subfields", f.Name, def.Type)
+	case !isObject && len(f.SelectionSet) > 0:
+		v.errorf(f.Loc, "Field %q must not have a selection since type %q has no subfields", f.Name, def.Type)
+	case isObject:
+		v.selectionSet(child, f.SelectionSet, depth+1, spreading)
+	}
+}
+
+func (v *validator) directives(directives []*Directive) {
+	for _, d := range directives {
+		if d.Name != "skip" && d.Name != "include" {
+			v.errorf(d.Loc, "Unknown directive \"@%s\"", d.Name)
+			continue
+		}
+		hasIf := false
+		for _, arg := range d.Arguments {
+			if arg.Name != "if" {
+				v.errorf(arg.Loc, "Unknown argument %q on directive \"@%s\"", arg.Name, d.Name)
+				continue
+			}
+			hasIf = true
+			v.variables(arg.Loc, arg.Value)
+			if _, isVar := arg.Value.(Variable); !isVar {
+				if _, isBool := arg.Value.(bool); !isBool {
+					v.errorf(arg.Loc, "Directive \"@%s\" argument \"if\" must be a Boolean", d.Name)
+				}
+			}
+		}
+		if !hasIf {
+			v.errorf(d.Loc, "Directive \"@%s\" argument \"if\" of type \"Boolean!\" is required", d.Name)
+		}
+	}
+}
+
+// variables reports variables used in value but not defined.
+func (v *validator) variables(loc Location, value Value) {
+	switch x := value.(type) {
+	case Variable:
+		if !v.defined[string(x)] {
+			v.errors = append(v.errors, validationError(loc, "Variable \"$%s\" is not defined", x))
+		}
+	case []Value:
+		for _, item := range x {
+			v.variables(loc, item)
+		}
+	case []*ObjectField:
+		for _, f := range x {
+			v.variables(loc, f.Value)
+		}
+	}
+}
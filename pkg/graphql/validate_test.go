@@ -0,0 +1,227 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateQuery returns the validation errors of the only operation of
+// query.
+func validateQuery(t *testing.T, schema *Schema, query string) []*Error {
+	t.Helper()
+
+	doc, err := Parse(query)
+	require.NoError(t, err)
+	return schema.validate(doc, doc.Operations[0])
+}
+
+func messages(errs []*Error) []string {
+	out := make([]string, len(errs))
+	for i, err := range errs {
+		out[i] = err.Message
+	}
+	return out
+}
+
+func TestValidate_Rules(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "Valid",
+			query: `query($id: ID!) { customer(id: $id) { __typename id ...f } } fragment f on Customer { limits { active } }`,
+		},
+		{
+			name:  "Unknown Field",
+			query: `{ me { password } }`,
+			want:  []string{`Cannot query field "password" on type "Customer"`},
+		},
+		{
+			name:  "Object Without Selection",
+			query: `{ me }`,
+			want:  []string{`Field "me" of type "Customer" must have a selection of subfields`},
+		},
+		{
+			name:  "Scalar With Selection",
+			query: `{ me { full_name { first } } }`,
+			want:  []string{`Field "full_name" must not have a selection since type "String!" has no subfields`},
+		},
+		{
+			name:  "Typename With Selection",
+			query: `{ me { __typename { id } } }`,
+			want:  []string{`Field "__typename" must not have a selection since type "String!" has no subfields`},
+		},
+		{
+			name:  "Unknown Argument",
+			query: `{ customers(offset: 2) { id } }`,
+			want:  []string{`Unknown argument "offset" on field "Query.customers"`},
+		},
+		{
+			name:  "Duplicate Argument",
+			query: `{ customers(limit: 1, limit: 2) { id } }`,
+			want:  []string{`There can be only one argument named "limit"`},
+		},
+		{
+			name:  "Required Argument Missing",
+			query: `{ customer { id } }`,
+			want:  []string{`Field "Query.customer" argument "id" of type "ID!" is required, but it was not provided`},
+		},
+		{
+			name:  "Undefined Variable",
+			query: `{ customers(limit: $n) { id } echo(value: [1, $m]) }`,
+			want:  []string{`Variable "$n" is not defined`, `Variable "$m" is not defined`},
+		},
+		{
+			name:  "Duplicate Variable",
+			query: `query($n: Int, $n: Int) { customers(limit: $n) { id } }`,
+			want:  []string{`There can be only one variable named "$n"`},
+		},
+		{
+			name:  "Variable Of Unknown Type",
+			query: `query($c: Customer) { me { id } }`,
+			want:  []string{`Variable "$c" cannot be of type "Customer"`},
+		},
+		{
+			name:  "Unknown Fragment",
+			query: `{ me { ...missing } }`,
+			want:  []string{`Unknown fragment "missing"`},
+		},
+		{
+			name:  "Fragment Cycle",
+			query: `{ me { ...a } } fragment a on Customer { id ...b } fragment b on Customer { ...a }`,
+			want:  []string{`Cannot spread fragment "a" within itself`},
+		},
+		{
+			name:  "Fragment On Other Type",
+			query: `{ me { ...l } } fragment l on Limit { active }`,
+			want:  []string{`Fragment "l" cannot be spread here as objects of type "Customer" can never be of type "Limit"`},
+		},
+		{
+			name:  "Inline Fragment On Other Type",
+			query: `{ me { ... on Limit { active } } }`,
+			want:  []string{`Fragment cannot be spread here as objects of type "Customer" can never be of type "Limit"`},
+		},
+		{
+			name:  "Unknown Directive",
+			query: `{ me { id @deprecated } }`,
+			want:  []string{`Unknown directive "@deprecated"`},
+		},
+		{
+			name:  "Directive Without If",
+			query: `{ me { id @skip } }`,
+			want:  []string{`Directive "@skip" argument "if" of type "Boolean!" is required`},
+		},
+		{
+			name:  "Directive If Not Boolean",
+			query: `{ me { id @include(if: "yes") } }`,
+			want:  []string{`Directive "@include" argument "if" must be a Boolean`},
+		},
+		{
+			name:  "Every Problem Reported",
+			query: `{ me { password } customers(offset: 1) { id } }`,
+			want: []string{
+				`Cannot query field "password" on type "Customer"`,
+				`Unknown argument "offset" on field "Query.customers"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateQuery(t, testSchema(), tc.query)
+
+			assert.Equal(t, tc.want, nilIfEmpty(messages(errs)))
+			for _, err := range errs {
+				assert.Equal(t, CodeValidationFailed, err.Code())
+				assert.Len(t, err.Locations, 1)
+			}
+		})
+	}
+}
+
+func nilIfEmpty(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func TestValidate_MaxDepth(t *testing.T) {
+	// Skema uji mengizinkan kedalaman 4: customers > limits adalah 2
+	schema := testSchema()
+
+	assert.Empty(t, validateQuery(t, schema, `{ customers { limits { active } } }`))
+
+	schema.MaxDepth = 1
+	errs := validateQuery(t, schema, `{ customers { id limits { active } } }`)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "Query is nested deeper than the maximum depth of 1", errs[0].Message)
+	assert.Equal(t, []Location{{1, 15}}, errs[0].Locations)
+
+	t.Run("Fragments Count Where Spread", func(t *testing.T) {
+		schema.MaxDepth = 2
+		errs := validateQuery(t, schema, `{ customers { ...f } } fragment f on Customer { limits { active } }`)
+		assert.Equal(t, []string{"Query is nested deeper than the maximum depth of 2"}, messages(errs))
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		schema.MaxDepth = 0
+		assert.Empty(t, validateQuery(t, schema, `{ customers { limits { active } } }`))
+	})
+}
+
+func TestValidate_MaxComplexity(t *testing.T) {
+	schema := testSchema()
+	schema.MaxComplexity = 5
+
+	cases := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{"At Limit", `{ me { id full_name limits { active } } }`, false},
+		{"Over Limit", `{ me { id full_name email limits { active } } }`, true},
+		{"Aliases Count", `{ a: me { id } b: me { id } c: me { id } }`, true},
+		{"Typename Counts", `{ me { __typename id full_name email joined_at } }`, true},
+		{"Every Spread Counts", `{ me { ...f ...f ...f } } fragment f on Customer { id }`, false},
+		{"Spreads Over Limit", `{ me { ...f ...f ...f } } fragment f on Customer { id full_name }`, true},
+		{"Skipped Fields Count", `{ me { id @skip(if: true) full_name @skip(if: true) email joined_at limits { active } } }`, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateQuery(t, schema, tc.query)
+			if !tc.wantErr {
+				assert.Empty(t, errs)
+				return
+			}
+			// Hanya satu error meski banyak field melewati batas
+			assert.Equal(t, []string{"Query selects more than the maximum of 5 fields"}, messages(errs))
+		})
+	}
+}
+
+func TestValidate_FragmentBombStopsEarly(t *testing.T) {
+	// Setiap fragment memanggil fragment berikutnya dua kali: 2^30 field
+	var query strings.Builder
+	query.WriteString("{ me { ...f0 } }")
+	for i := range 30 {
+		fmt.Fprintf(&query, " fragment f%d on Customer { ...f%d ...f%d }", i, i+1, i+1)
+	}
+	query.WriteString(" fragment f30 on Customer { id }")
+
+	start := time.Now()
+	res := testSchema().Execute(context.Background(), Request{Query: query.String()})
+
+	assert.False(t, res.Executed())
+	assert.Equal(t, []string{"Query selects more than the maximum of 50 fields"}, messages(res.Errors))
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,286 @@
+// Package httpclient builds the http.Client used for calls to external
+// services such as Cloudinary, partner webhooks, email and push providers.
+// Every client gets its own connection pool, an overall timeout, retries of
+// calls that are safe to repeat, and a client span and metrics per attempt
+// named after the remote service.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// IdempotencyKeyHeader marks a request the remote service deduplicates, so
+// it is retried like an idempotent method.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxRetryAfter caps the wait a Retry-After header can ask for.
+const maxRetryAfter = 30 * time.Second
+
+// Config describes the client of one remote service. Zero values fall back
+// to the defaults noted on each field.
+type Config struct {
+	// Name identifies the remote service in spans, metrics and logs, e.g.
+	// "cloudinary".
+	Name string
+	// Timeout bounds a whole call, retries included. Default 30s.
+	Timeout time.Duration
+	// MaxRetries is the number of attempts after the first one. Zero
+	// disables retries, for callers that retry on their own schedule.
+	MaxRetries int
+	// Backoff is the wait before the first retry and doubles for each
+	// further one. Default 200ms.
+	Backoff time.Duration
+	// MaxIdleConnsPerHost is the number of kept-alive connections per host.
+	// Default 16.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout closes kept-alive connections unused this long.
+	// Default 90s.
+	IdleConnTimeout time.Duration
+}
+
+// New returns a client for the remote service described by cfg.
+func New(cfg Config, meter metric.Meter, tracer trace.Tracer, log *zap.Logger) *http.Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = 200 * time.Millisecond
+	}
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = 16
+	}
+	if cfg.IdleConnTimeout <= 0 {
+		cfg.IdleConnTimeout = 90 * time.Second
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.MaxIdleConns = 0
+	base.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	base.IdleConnTimeout = cfg.IdleConnTimeout
+
+	requestCount, _ := meter.Int64Counter(
+		"http.client.request.count",
+		metric.WithDescription("Number of outbound HTTP attempts"),
+		metric.WithUnit("{request}"),
+	)
+
+	requestDuration, _ := meter.Float64Histogram(
+		"http.client.request.duration",
+		metric.WithDescription("Duration of outbound HTTP attempts"),
+		metric.WithUnit("ms"),
+	)
+
+	retryCount, _ := meter.Int64Counter(
+		"http.client.retry.count",
+		metric.WithDescription("Number of outbound HTTP attempts repeated after a failure"),
+		metric.WithUnit("{retry}"),
+	)
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &transport{
+			base:            base,
+			cfg:             cfg,
+			tracer:          tracer,
+			log:             log,
+			propagator:      otel.GetTextMapPropagator(),
+			requestCount:    requestCount,
+			requestDuration: requestDuration,
+			retryCount:      retryCount,
+		},
+	}
+}
+
+type transport struct {
+	base       http.RoundTripper
+	cfg        Config
+	tracer     trace.Tracer
+	log        *zap.Logger
+	propagator propagation.TextMapPropagator
+
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	retryCount      metric.Int64Counter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		// Body hanya bisa dikirim ulang bila bisa dibuat kembali
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.attempt(ctx, req, attempt)
+
+		wait, retry := t.retryAfter(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		t.retryCount.Add(ctx, 1, metric.WithAttributes(attribute.String("peer.service", t.cfg.Name)))
+		t.log.Warn("Retrying outbound request",
+			zap.String("peer.service", t.cfg.Name),
+			zap.String("method", req.Method),
+			zap.String("host", req.URL.Host),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("wait", wait),
+			zap.Error(failure(resp, err)),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends req once inside a client span.
+func (t *transport) attempt(ctx context.Context, req *http.Request, attempt int) (*http.Response, error) {
+	ctx, span := t.tracer.Start(ctx, "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("peer.service", t.cfg.Name),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("url.path", req.URL.Path),
+			attribute.Int("http.request.resend_count", attempt),
+		),
+	)
+	defer span.End()
+
+	// Header disalin agar request milik pemanggil tidak ikut berubah
+	req = req.Clone(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := float64(time.Since(start).Nanoseconds()) / 1e6
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("peer.service", t.cfg.Name),
+		attribute.String("method", req.Method),
+		attribute.Int("status_code", status),
+	)
+	t.requestCount.Add(ctx, 1, attrs)
+	t.requestDuration.Record(ctx, duration, attrs)
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case status >= 500:
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		span.SetStatus(codes.Error, http.StatusText(status))
+	default:
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+
+	return resp, err
+}
+
+// retryAfter decides whether the attempt is worth repeating and how long to
+// wait first. A request that never reached the server is always repeated;
+// one that may have been processed only when it is idempotent.
+func (t *transport) retryAfter(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= t.cfg.MaxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	wait := t.cfg.Backoff << attempt
+	if err != nil {
+		if notSent(err) {
+			return wait, true
+		}
+		return wait, idempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		// Server yang menolak karena sibuk belum memproses apa pun
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = after
+		}
+		return wait, true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return wait, idempotent(req)
+	}
+	return 0, false
+}
+
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// notSent reports whether err happened before the request left this host,
+// i.e. while resolving or dialling.
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = time.Until(at)
+	} else {
+		return 0, false
+	}
+	return max(0, min(wait, maxRetryAfter)), true
+}
+
+func failure(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("status %d", resp.StatusCode)
+}
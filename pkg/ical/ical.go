@@ -0,0 +1,142 @@
+// Package ical writes iCalendar (RFC 5545) feeds of all-day events, enough
+// for phone and desktop calendars to subscribe to.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLineOctets is the longest content line allowed before it must be folded.
+const maxLineOctets = 75
+
+// Calendar is a feed of events published under a single name.
+type Calendar struct {
+	ProdID string
+	Name   string
+	// RefreshInterval hints how often subscribers should fetch the feed again.
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Event is an all-day event on Date. UID must stay the same across fetches
+// so calendars update the event instead of adding a copy.
+type Event struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	// Reminder, when positive, raises an alarm that long before the day starts.
+	Reminder time.Duration
+}
+
+// Write encodes cal to w with CRLF line endings. stamp is written as the
+// DTSTAMP of every event.
+func (cal Calendar) Write(w io.Writer, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", cal.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME", escape(cal.Name))
+	}
+	if cal.RefreshInterval > 0 {
+		line("REFRESH-INTERVAL;VALUE=DURATION", duration(cal.RefreshInterval))
+		line("X-PUBLISHED-TTL", duration(cal.RefreshInterval))
+	}
+
+	for _, event := range cal.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", event.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE", event.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		line("TRANSP", "TRANSPARENT")
+		if event.Reminder > 0 {
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("DESCRIPTION", escape(event.Summary))
+			line("TRIGGER", "-"+duration(event.Reminder))
+			line("END", "VALARM")
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// escape applies the TEXT value escaping of RFC 5545 section 3.3.11. Every
+// line break, including a bare CR, becomes \n so it cannot end the content
+// line early.
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// duration formats d as an RFC 5545 duration rounded down to whole minutes.
+func duration(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	days, minutes := minutes/(24*60), minutes%(24*60)
+	hours, minutes := minutes/60, minutes%60
+
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		b.WriteString(strconv.FormatInt(days, 10) + "D")
+	}
+	if hours > 0 || minutes > 0 || days == 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			b.WriteString(strconv.FormatInt(hours, 10) + "H")
+		}
+		if minutes > 0 || hours == 0 {
+			b.WriteString(strconv.FormatInt(minutes, 10) + "M")
+		}
+	}
+	return b.String()
+}
+
+// writeFolded writes content as one logical line, folded every 75 octets
+// without splitting a UTF-8 sequence.
+func writeFolded(w *bufio.Writer, content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		// Mundur sampai awal karakter UTF-8 agar karakter tidak terpotong
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		// Tanpa awal karakter sama sekali teksnya bukan UTF-8 yang valid,
+		// potong apa adanya supaya loop tetap maju
+		if cut == 0 {
+			cut = limit
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// Baris lanjutan diawali spasi yang ikut dihitung
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,231 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var wib = time.FixedZone("WIB", 7*60*60)
+
+func TestCalendar_Write(t *testing.T) {
+	cal := Calendar{
+		ProdID:          "-//Multifinance//Installment Calendar//ID",
+		Name:            "Cicilan KTR-2026-0001",
+		RefreshInterval: 12 * time.Hour,
+		Events: []Event{
+			{
+				UID:         "KTR-2026-0001-3@multifinance",
+				Date:        time.Date(2026, time.November, 5, 0, 0, 0, 0, wib),
+				Summary:     "Cicilan 3/12: Honda Vario",
+				Description: "Kontrak KTR-2026-0001, Rp 1.250.000,00; bayar ke virtual account\nBCA 8808123456",
+				Reminder:    24*time.Hour + 30*time.Minute,
+			},
+			{
+				UID:     "KTR-2026-0001-4@multifinance",
+				Date:    time.Date(2026, time.December, 31, 0, 0, 0, 0, wib),
+				Summary: "Cicilan 4/12: Honda Vario",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, cal.Write(&buf, time.Date(2026, time.October, 17, 9, 30, 0, 0, wib)))
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Multifinance//Installment Calendar//ID",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Cicilan KTR-2026-0001",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT12H",
+		"X-PUBLISHED-TTL:PT12H",
+		"BEGIN:VEVENT",
+		"UID:KTR-2026-0001-3@multifinance",
+		"DTSTAMP:20261017T023000Z",
+		"DTSTART;VALUE=DATE:20261105",
+		"DTEND;VALUE=DATE:20261106",
+		"SUMMARY:Cicilan 3/12: Honda Vario",
+		"DESCRIPTION:Kontrak KTR-2026-0001\\, Rp 1.250.000\\,00\\; bayar ke virtual acc",
+		" ount\\nBCA 8808123456",
+		"TRANSP:TRANSPARENT",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Cicilan 3/12: Honda Vario",
+		"TRIGGER:-P1DT30M",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:KTR-2026-0001-4@multifinance",
+		"DTSTAMP:20261017T023000Z",
+		"DTSTART;VALUE=DATE:20261231",
+		"DTEND;VALUE=DATE:20270101",
+		"SUMMARY:Cicilan 4/12: Honda Vario",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n") + "\r\n"
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestCalendar_WriteEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Calendar{ProdID: "-//Test//EN"}.Write(&buf, time.Now()))
+
+	assert.Equal(t, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\nCALSCALE:GREGORIAN\r\n"+
+		"METHOD:PUBLISH\r\nEND:VCALENDAR\r\n", buf.String())
+}
+
+func TestCalendar_WriteDates(t *testing.T) {
+	cases := []struct {
+		name  string
+		date  time.Time
+		start string
+		end   string
+	}{
+		// Tanggal dibaca di zona waktunya sendiri, bukan UTC yang masih hari sebelumnya
+		{"Local Midnight", time.Date(2026, time.November, 5, 0, 0, 0, 0, wib), "20261105", "20261106"},
+		{"Late In The Day", time.Date(2026, time.November, 5, 23, 59, 0, 0, wib), "20261105", "20261106"},
+		{"UTC", time.Date(2026, time.November, 5, 0, 0, 0, 0, time.UTC), "20261105", "20261106"},
+		{"End Of Month", time.Date(2026, time.April, 30, 0, 0, 0, 0, wib), "20260430", "20260501"},
+		{"Leap Day", time.Date(2028, time.February, 28, 0, 0, 0, 0, wib), "20280228", "20280229"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cal := Calendar{Events: []Event{{UID: "1", Date: tc.date, Summary: "Cicilan"}}}
+			require.NoError(t, cal.Write(&buf, tc.date))
+
+			out := buf.String()
+			assert.Contains(t, out, "\r\nDTSTART;VALUE=DATE:"+tc.start+"\r\n")
+			assert.Contains(t, out, "\r\nDTEND;VALUE=DATE:"+tc.end+"\r\n")
+			// Acara seharian memakai tanggal mengambang tanpa TZID
+			assert.NotContains(t, out, "TZID")
+			assert.Contains(t, out, "\r\nDTSTAMP:"+tc.date.UTC().Format("20060102T150405Z")+"\r\n")
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"Plain", "Cicilan 3/12: Honda Vario", "Cicilan 3/12: Honda Vario"},
+		{"Backslash", `C:\kontrak`, `C:\\kontrak`},
+		{"Semicolon And Comma", "Rp 1.250.000,00; lunas", `Rp 1.250.000\,00\; lunas`},
+		{"CRLF", "baris 1\r\nbaris 2", `baris 1\nbaris 2`},
+		{"LF", "baris 1\nbaris 2", `baris 1\nbaris 2`},
+		{"Bare CR", "baris 1\rbaris 2", `baris 1\nbaris 2`},
+		{"Escaped Before Escaping", `\n`, `\\n`},
+		{"Unicode", "Jatuh tempo — ✓", "Jatuh tempo — ✓"},
+		{"Empty", "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, escape(tc.value))
+		})
+	}
+}
+
+func TestDuration(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "PT0M"},
+		{30 * time.Second, "PT0M"},
+		{15 * time.Minute, "PT15M"},
+		{2 * time.Hour, "PT2H"},
+		{2*time.Hour + 5*time.Minute + 59*time.Second, "PT2H5M"},
+		{24 * time.Hour, "P1D"},
+		{24*time.Hour + 30*time.Minute, "P1DT30M"},
+		{49 * time.Hour, "P2DT1H"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.d.String(), func(t *testing.T) {
+			assert.Equal(t, tc.want, duration(tc.d))
+		})
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"Short", "SUMMARY:Cicilan", []string{"SUMMARY:Cicilan"}},
+		{"Exactly 75 Octets", strings.Repeat("a", 75), []string{strings.Repeat("a", 75)}},
+		{"76 Octets", strings.Repeat("a", 76), []string{strings.Repeat("a", 75), " a"}},
+		{
+			// Baris lanjutan memuat 74 octet karena spasi pembukanya ikut dihitung
+			name:    "Continuations Hold 74 Octets",
+			content: strings.Repeat("a", 75+74+1),
+			want:    []string{strings.Repeat("a", 75), " " + strings.Repeat("a", 74), " a"},
+		},
+		{
+			name:    "Two Byte Character Kept Whole",
+			content: strings.Repeat("a", 74) + "é",
+			want:    []string{strings.Repeat("a", 74), " é"},
+		},
+		{
+			name:    "Four Byte Character Kept Whole",
+			content: strings.Repeat("a", 73) + "😀b",
+			want:    []string{strings.Repeat("a", 73), " 😀b"},
+		},
+		{
+			name:    "Character Ending At The Limit",
+			content: strings.Repeat("a", 73) + "é" + "b",
+			want:    []string{strings.Repeat("a", 73) + "é", " b"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, strings.Join(tc.want, "\r\n")+"\r\n", folded(tc.content))
+		})
+	}
+}
+
+func TestWriteFolded_Unfolds(t *testing.T) {
+	for name, content := range map[string]string{
+		"ASCII":         strings.Repeat("0123456789", 50),
+		"Mixed Width":   strings.Repeat("Cicilan ké-3 ✓ 😀 ", 40),
+		"Invalid UTF-8": strings.Repeat("\x80", 300),
+	} {
+		t.Run(name, func(t *testing.T) {
+			out := folded(content)
+
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			for i, line := range lines {
+				assert.LessOrEqual(t, len(line), maxLineOctets, "line %d", i)
+				if utf8.ValidString(content) {
+					assert.True(t, utf8.ValidString(line), "line %d splits a character", i)
+				}
+			}
+			// Membuka lipatan sesuai RFC 5545: hapus CRLF beserta satu spasi
+			assert.Equal(t, content, strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""))
+		})
+	}
+}
+
+func folded(content string) string {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeFolded(w, content)
+	w.Flush()
+	return buf.String()
+}
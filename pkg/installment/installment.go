@@ -0,0 +1,225 @@
+// Package installment derives monthly installment schedules from contract
+// terms. Contracts are repaid in equal monthly installments due on the same
+// day of the month as the contract date, unless Rules move them off weekends
+// and holidays.
+package installment
+
+import (
+	"time"
+
+	"github.com/fazamuttaqien/multifinance/pkg/money"
+	"github.com/shopspring/decimal"
+)
+
+// Installment is a single monthly payment of a contract.
+type Installment struct {
+	Sequence int
+	DueDate  time.Time
+	Amount   decimal.Decimal
+}
+
+// Elapsed returns how many monthly due dates after start have been reached by asOf.
+func Elapsed(start, asOf time.Time) int {
+	months := (asOf.Year()-start.Year())*12 + int(asOf.Month()) - int(start.Month())
+	if asOf.Day() < start.Day() {
+		months--
+	}
+	return max(months, 0)
+}
+
+// DueDate returns the due date of the given installment sequence.
+func DueDate(start time.Time, sequence int) time.Time {
+	return start.AddDate(0, sequence, 0)
+}
+
+// DaysPastDue returns how many calendar days the first unpaid installment is
+// overdue at asOf. It is zero while that installment is not yet due or once
+// all months installments are paid.
+func DaysPastDue(start time.Time, paid, months int, asOf time.Time) int {
+	if paid >= months {
+		return 0
+	}
+
+	dueYear, dueMonth, dueDay := DueDate(start, paid+1).Date()
+	year, month, day := asOf.Date()
+	due := time.Date(dueYear, dueMonth, dueDay, 0, 0, 0, 0, time.UTC)
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	return max(int(today.Sub(due).Hours()/24), 0)
+}
+
+// Schedule splits total evenly over the installments numbered from to to,
+// inclusive. The last installment absorbs the rounding difference so the
+// amounts always add up to total.
+func Schedule(start time.Time, from, to int, total decimal.Decimal) []Installment {
+	if to < from {
+		return []Installment{}
+	}
+
+	count := to - from + 1
+	amount := total.DivRound(decimal.NewFromInt(int64(count)), money.Scale)
+
+	installments := make([]Installment, 0, count)
+	for i := 0; i < count; i++ {
+		sequence := from + i
+		value := amount
+		if i == count-1 {
+			value = money.Round(total.Sub(amount.Mul(decimal.NewFromInt(int64(count - 1)))))
+		}
+
+		installments = append(installments, Installment{
+			Sequence: sequence,
+			DueDate:  DueDate(start, sequence),
+			Amount:   value,
+		})
+	}
+
+	return installments
+}
+
+// Shift says which way a due date that falls on a weekend or holiday moves.
+type Shift string
+
+const (
+	// ShiftNone keeps due dates on their nominal day.
+	ShiftNone Shift = "NONE"
+	// ShiftNext moves a due date forward to the next business day.
+	ShiftNext Shift = "NEXT_BUSINESS_DAY"
+	// ShiftPrevious moves a due date back to the previous business day.
+	ShiftPrevious Shift = "PREVIOUS_BUSINESS_DAY"
+)
+
+// maxShiftDays bounds how far a due date may move, so a calendar full of
+// holidays cannot loop forever.
+const maxShiftDays = 31
+
+// Valid reports whether s is one of the known shifts.
+func (s Shift) Valid() bool {
+	switch s {
+	case ShiftNone, ShiftNext, ShiftPrevious:
+		return true
+	}
+	return false
+}
+
+// Rules adjust the nominal due dates for business days and allow a grace
+// period before an installment counts as overdue. The zero Rules keep the
+// nominal due dates without grace, matching the package level functions.
+type Rules struct {
+	Shift     Shift
+	GraceDays int
+	// Location decides which calendar day a due date falls on; nil uses the
+	// location of the due date itself.
+	Location *time.Location
+
+	holidays map[string]struct{}
+}
+
+// NewRules returns rules that treat the calendar days of holidays, read in
+// location, as non-business days alongside Saturdays and Sundays.
+func NewRules(shift Shift, graceDays int, location *time.Location, holidays []time.Time) Rules {
+	rules := Rules{
+		Shift:     shift,
+		GraceDays: max(graceDays, 0),
+		Location:  location,
+		holidays:  make(map[string]struct{}, len(holidays)),
+	}
+	for _, holiday := range holidays {
+		rules.holidays[holiday.Format(time.DateOnly)] = struct{}{}
+	}
+	return rules
+}
+
+// IsBusinessDay reports whether the calendar day of t is neither a weekend
+// nor a holiday.
+func (r Rules) IsBusinessDay(t time.Time) bool {
+	t = r.local(t)
+	if weekday := t.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	_, holiday := r.holidays[t.Format(time.DateOnly)]
+	return !holiday
+}
+
+// DueDate returns the due date of the given installment sequence after
+// moving it off non-business days according to Shift.
+func (r Rules) DueDate(start time.Time, sequence int) time.Time {
+	due := DueDate(start, sequence)
+
+	step := 0
+	switch r.Shift {
+	case ShiftNext:
+		step = 1
+	case ShiftPrevious:
+		step = -1
+	default:
+		return due
+	}
+
+	shifted := due
+	for i := 0; i < maxShiftDays && !r.IsBusinessDay(shifted); i++ {
+		shifted = shifted.AddDate(0, 0, step)
+	}
+	if !r.IsBusinessDay(shifted) {
+		return due
+	}
+	return shifted
+}
+
+// Elapsed returns how many adjusted due dates after start have been reached
+// by asOf. The grace period does not delay a due date from being reached.
+func (r Rules) Elapsed(start, asOf time.Time) int {
+	months := Elapsed(start, asOf)
+	if r.Shift != ShiftNext && r.Shift != ShiftPrevious {
+		return months
+	}
+
+	// Pergeseran tidak pernah melewati satu bulan, cukup periksa tetangga terdekat
+	today := r.day(asOf)
+	for months > 0 && r.day(r.DueDate(start, months)).After(today) {
+		months--
+	}
+	for !r.day(r.DueDate(start, months+1)).After(today) {
+		months++
+	}
+	return months
+}
+
+// DaysPastDue returns how many calendar days the first unpaid installment is
+// overdue at asOf, counted from its adjusted due date. It stays zero until
+// GraceDays have passed since that date.
+func (r Rules) DaysPastDue(start time.Time, paid, months int, asOf time.Time) int {
+	if paid >= months {
+		return 0
+	}
+
+	days := int(r.day(asOf).Sub(r.day(r.DueDate(start, paid+1))).Hours() / 24)
+	if days <= r.GraceDays {
+		return 0
+	}
+	return days
+}
+
+// Schedule is like the package level Schedule with the due dates adjusted
+// for business days.
+func (r Rules) Schedule(start time.Time, from, to int, total decimal.Decimal) []Installment {
+	installments := Schedule(start, from, to, total)
+	for i := range installments {
+		installments[i].DueDate = r.DueDate(start, installments[i].Sequence)
+	}
+	return installments
+}
+
+func (r Rules) local(t time.Time) time.Time {
+	if r.Location == nil {
+		return t
+	}
+	return t.In(r.Location)
+}
+
+// day returns midnight UTC of the calendar day of t, so days can be
+// subtracted without daylight saving shifts.
+func (r Rules) day(t time.Time) time.Time {
+	year, month, day := r.local(t).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
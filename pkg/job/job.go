@@ -0,0 +1,67 @@
+// Package job runs background work on a fixed interval next to the HTTP
+// server. Each job runs once at start-up and then on every tick; a run that
+// is still going when the next tick fires simply delays that tick.
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start launches every job in its own goroutine until ctx is cancelled. The
+// returned wait blocks until all of them have returned.
+func Start(ctx context.Context, log *zap.Logger, jobs ...Job) (wait func()) {
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		if j.Interval <= 0 {
+			log.Warn("Background job disabled", zap.String("job", j.Name))
+			continue
+		}
+
+		wg.Add(1)
+		go func(j Job) {
+			defer wg.Done()
+			loop(ctx, log, j)
+		}(j)
+	}
+	return wg.Wait
+}
+
+func loop(ctx context.Context, log *zap.Logger, j Job) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	log.Info("Background job started", zap.String("job", j.Name), zap.Duration("interval", j.Interval))
+	for {
+		runOnce(ctx, log, j)
+
+		select {
+		case <-ctx.Done():
+			log.Info("Background job stopped", zap.String("job", j.Name))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce keeps a panicking job from taking the whole process down.
+func runOnce(ctx context.Context, log *zap.Logger, j Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Background job panicked", zap.String("job", j.Name), zap.Any("panic", r))
+		}
+	}()
+
+	if err := j.Run(ctx); err != nil && ctx.Err() == nil {
+		log.Error("Background job failed", zap.String("job", j.Name), zap.Error(err))
+	}
+}
@@ -0,0 +1,100 @@
+// Package mailer hands transactional email to an external provider. Every
+// provider implements Sender, so the email queue only decides when a message
+// is tried again and never how it travels.
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var ErrUnknownProvider = errors.New("unknown email provider")
+
+// Config selects a provider by name, "stub", "smtp" or "ses", and carries
+// the settings of each. HTTPClient is used by the HTTP based providers; nil
+// gets a plain client.
+type Config struct {
+	Provider   string
+	SMTP       SMTPConfig
+	SES        SESConfig
+	HTTPClient *http.Client
+}
+
+// New returns the Sender named by cfg.Provider.
+func New(cfg Config) (Sender, error) {
+	switch cfg.Provider {
+	case "stub":
+		return NewStubSender(), nil
+	case "smtp":
+		if cfg.SMTP.Host == "" || cfg.SMTP.Port == 0 {
+			return nil, errors.New("smtp provider needs a host and port")
+		}
+		return NewSMTP(cfg.SMTP), nil
+	case "ses":
+		if cfg.SES.Region == "" || cfg.SES.AccessKeyID == "" || cfg.SES.SecretAccessKey == "" {
+			return nil, errors.New("ses provider needs a region and access key")
+		}
+		return NewSES(cfg.SES, cfg.HTTPClient), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
+
+// Message is a plain-text email. ID is unique per queued message and is sent
+// as the Message-ID where the provider allows it, so a message resent after
+// an ambiguous failure can be recognised downstream.
+type Message struct {
+	ID      string
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers a Message and returns the provider's ID for it.
+type Sender interface {
+	Provider() string
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+// PermanentError marks a rejection that will fail the same way when retried,
+// such as an unknown recipient or a malformed message.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// IsPermanent reports whether err should not be retried.
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+type stubSender struct{}
+
+// NewStubSender accepts every message except those addressed to a mailbox
+// starting with "fail", which fail temporarily, or "reject", which the
+// provider refuses for good.
+func NewStubSender() Sender {
+	return stubSender{}
+}
+
+func (stubSender) Provider() string {
+	return "stub"
+}
+
+func (stubSender) Send(_ context.Context, msg Message) (string, error) {
+	switch {
+	case strings.HasPrefix(msg.To, "fail"):
+		return "", fmt.Errorf("stub mailbox %s is temporarily unavailable", msg.To)
+	case strings.HasPrefix(msg.To, "reject"):
+		return "", &PermanentError{fmt.Errorf("stub mailbox %s does not exist", msg.To)}
+	}
+	return "stub-" + msg.ID, nil
+}
@@ -0,0 +1,172 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPConfig points at a relay. Port 465 is spoken over implicit TLS; other
+// ports are upgraded with STARTTLS when the server offers it.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+type smtpSender struct {
+	cfg SMTPConfig
+}
+
+// NewSMTP sends through an SMTP relay, authenticating with PLAIN when a
+// username is configured.
+func NewSMTP(cfg SMTPConfig) Sender {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	return &smtpSender{cfg: cfg}
+}
+
+func (s *smtpSender) Provider() string {
+	return "smtp"
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg Message) (string, error) {
+	messageID := "<" + msg.ID + "@" + domainOf(msg.From) + ">"
+
+	data, err := compose(msg, messageID)
+	if err != nil {
+		return "", &PermanentError{err}
+	}
+
+	// Envelope SMTP hanya menerima alamat tanpa nama tampilan
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return "", &PermanentError{fmt.Errorf("invalid sender address: %w", err)}
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return "", &PermanentError{fmt.Errorf("invalid recipient address: %w", err)}
+	}
+
+	if err := s.deliver(ctx, from.Address, to.Address, data); err != nil {
+		// Balasan 5xx dari server SMTP tidak akan berubah bila dicoba lagi
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code >= 500 {
+			return "", &PermanentError{err}
+		}
+		return "", err
+	}
+
+	return messageID, nil
+}
+
+func (s *smtpSender) deliver(ctx context.Context, from, to string, data []byte) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := net.Dialer{Timeout: s.cfg.Timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	if s.cfg.Port == 465 {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.cfg.Host})
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// compose renders msg as an RFC 5322 message with a quoted-printable UTF-8
+// body.
+func compose(msg Message, messageID string) ([]byte, error) {
+	for _, header := range []string{msg.From, msg.To, msg.Subject} {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, fmt.Errorf("email header contains a line break")
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("From: " + msg.From + "\r\n")
+	buf.WriteString("To: " + msg.To + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("Message-ID: " + messageID + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	// Dalam mode teks, writer quoted-printable sudah mengubah baris baru menjadi CRLF
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func domainOf(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return strings.TrimSuffix(address[i+1:], ">")
+	}
+	return "localhost"
+}
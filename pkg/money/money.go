@@ -0,0 +1,77 @@
+// Package money holds the decimal arithmetic shared by every monetary amount.
+// Amounts are shopspring decimals persisted in DECIMAL(18,2) columns and are
+// rounded half away from zero to two places whenever a result is stored or
+// shown, so repeated sums never drift the way binary floats do.
+package money
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+)
+
+// Scale is the number of decimal places kept on every stored amount.
+const Scale = 2
+
+// MonthlyInterestRate is the flat monthly interest charged on the OTR amount.
+var MonthlyInterestRate = decimal.RequireFromString("0.02")
+
+// Round rounds an amount to Scale decimal places, half away from zero.
+func Round(amount decimal.Decimal) decimal.Decimal {
+	return amount.Round(Scale)
+}
+
+// FlatInterest returns the total flat interest of a contract over its tenor.
+func FlatInterest(otr decimal.Decimal, months uint8) decimal.Decimal {
+	return Round(otr.Mul(MonthlyInterestRate).Mul(decimal.NewFromInt(int64(months))))
+}
+
+// Sum adds amounts without intermediate rounding.
+func Sum(amounts ...decimal.Decimal) decimal.Decimal {
+	return decimal.Sum(decimal.Zero, amounts...)
+}
+
+// Format writes amount rounded to Scale places with grouped thousands, using
+// the given thousands and decimal separators.
+func Format(amount decimal.Decimal, thousands, point string) string {
+	fixed := Round(amount).StringFixed(Scale)
+
+	sign := ""
+	if strings.HasPrefix(fixed, "-") {
+		sign, fixed = "-", fixed[1:]
+	}
+	whole, fraction, _ := strings.Cut(fixed, ".")
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(digit)
+	}
+
+	return sign + b.String() + point + fraction
+}
+
+// NewValidator returns a validator whose numeric tags (gt, gte, required, ...)
+// also apply to decimal fields. Fields are named after their JSON key in
+// validation errors, the name a client knows them by.
+func NewValidator() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		if amount, ok := field.Interface().(decimal.Decimal); ok {
+			return amount.InexactFloat64()
+		}
+		return nil
+	}, decimal.Decimal{})
+	return validate
+}
@@ -0,0 +1,157 @@
+package money
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		name   string
+		amount string
+		want   string
+	}{
+		{"Half Up", "1.005", "1.01"},
+		{"Below Half", "1.004", "1.00"},
+		{"Half Away From Zero When Negative", "-1.005", "-1.01"},
+		{"Negative Below Half", "-1.004", "-1.00"},
+		{"Not Bankers Rounding", "2.345", "2.35"},
+		{"Long Tail", "0.00999", "0.01"},
+		{"Small Negative To Zero", "-0.004", "0.00"},
+		{"Carry Into Whole Units", "999999.995", "1000000.00"},
+		{"Largest DECIMAL(18,2)", "9999999999999999.99", "9999999999999999.99"},
+		{"Zero Decimal Currency Stays Whole", "15000000", "15000000.00"},
+		{"Zero", "0", "0.00"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Round(decimal.RequireFromString(tc.amount))
+
+			assert.Equal(t, tc.want, got.StringFixed(Scale))
+			assert.LessOrEqual(t, -got.Exponent(), int32(Scale))
+		})
+	}
+}
+
+func TestFlatInterest(t *testing.T) {
+	cases := []struct {
+		name   string
+		otr    string
+		months uint8
+		want   string
+	}{
+		{"Whole Rupiah", "10000000", 12, "2400000.00"},
+		{"Rounded Once At The End", "1234567.89", 6, "148148.15"},
+		{"Half Cent", "1000000.25", 1, "20000.01"},
+		{"Negative Reversal", "-1000000.25", 1, "-20000.01"},
+		{"Longest Tenor", "250000000", 255, "1275000000.00"},
+		{"No Months", "10000000", 0, "0.00"},
+		{"No Amount", "0", 12, "0.00"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FlatInterest(decimal.RequireFromString(tc.otr), tc.months)
+
+			assert.Equal(t, tc.want, got.StringFixed(Scale))
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	cases := []struct {
+		name    string
+		amounts []string
+		want    string
+	}{
+		{"None", nil, "0"},
+		{"No Intermediate Rounding", []string{"0.004", "0.004", "0.004"}, "0.012"},
+		{"Float Drift Free", []string{"0.1", "0.2"}, "0.3"},
+		{"Negative Amounts Cancel", []string{"1500000.50", "-1500000.50"}, "0"},
+		{"Refund Larger Than Charge", []string{"100", "-250.75"}, "-150.75"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			amounts := make([]decimal.Decimal, len(tc.amounts))
+			for i, amount := range tc.amounts {
+				amounts[i] = decimal.RequireFromString(amount)
+			}
+
+			assert.True(t, decimal.RequireFromString(tc.want).Equal(Sum(amounts...)), "got %s", Sum(amounts...))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		name      string
+		amount    string
+		thousands string
+		point     string
+		want      string
+	}{
+		{"Indonesian Separators", "1234567.891", ".", ",", "1.234.567,89"},
+		{"English Separators", "1234567.895", ",", ".", "1,234,567.90"},
+		{"No Grouping Below Thousand", "999.5", ".", ",", "999,50"},
+		{"Group Boundary", "1000", ".", ",", "1.000,00"},
+		{"Rounding Adds A Group", "999999.995", ".", ",", "1.000.000,00"},
+		{"Negative", "-1234.5", ".", ",", "-1.234,50"},
+		{"Negative Rounds Away From Zero", "-0.005", ".", ",", "-0,01"},
+		{"Negative Rounded To Zero Has No Sign", "-0.004", ".", ",", "0,00"},
+		{"Zero Decimal Currency", "15000000", ",", ".", "15,000,000.00"},
+		{"Zero", "0", ".", ",", "0,00"},
+		{"Empty Thousands Separator", "1234567.8", "", ",", "1234567,80"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Format(decimal.RequireFromString(tc.amount), tc.thousands, tc.point))
+		})
+	}
+}
+
+func TestNewValidator(t *testing.T) {
+	type limit struct {
+		TenorMonths uint8           `json:"tenor_months" validate:"required"`
+		LimitAmount decimal.Decimal `json:"limit_amount" validate:"gt=0"`
+		Deposit     decimal.Decimal `json:"deposit" validate:"gte=0"`
+		Internal    string          `json:"-" validate:"required"`
+	}
+
+	cases := []struct {
+		name   string
+		input  limit
+		failed []string
+	}{
+		{"Valid", limit{TenorMonths: 6, LimitAmount: decimal.RequireFromString("0.01"), Internal: "x"}, nil},
+		{"Zero Limit", limit{TenorMonths: 6, Internal: "x"}, []string{"limit_amount"}},
+		{"Negative Amounts", limit{TenorMonths: 6, LimitAmount: decimal.NewFromInt(-5000000), Deposit: decimal.RequireFromString("-0.01"), Internal: "x"}, []string{"limit_amount", "deposit"}},
+		{"Field Without JSON Name", limit{TenorMonths: 6, LimitAmount: decimal.NewFromInt(1)}, []string{"Internal"}},
+	}
+
+	validate := NewValidator()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate.Struct(tc.input)
+			if tc.failed == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var errs validator.ValidationErrors
+			require.True(t, errors.As(err, &errs), "got %v", err)
+			fields := make([]string, len(errs))
+			for i, fieldErr := range errs {
+				fields[i] = fieldErr.Field()
+			}
+			assert.Equal(t, tc.failed, fields)
+		})
+	}
+}
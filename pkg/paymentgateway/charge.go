@@ -0,0 +1,54 @@
+package paymentgateway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ChargeRequest debits a customer's stored payment method. IdempotencyKey
+// is unique per attempt so a retried HTTP call never charges twice.
+type ChargeRequest struct {
+	IdempotencyKey string
+	Token          string
+	Reference      string
+	Amount         decimal.Decimal
+	Currency       string
+}
+
+// Charge is the gateway's answer to a ChargeRequest. A PENDING charge is
+// settled later through the provider's callback.
+type Charge struct {
+	ExternalID    string
+	Status        Status
+	FailureReason string
+}
+
+// Charger initiates direct debits against a payment method the customer
+// has authorised with the gateway.
+type Charger interface {
+	Provider() string
+	Charge(ctx context.Context, req ChargeRequest) (*Charge, error)
+}
+
+type stubCharger struct{}
+
+// NewStubCharger approves every charge except those made with a token
+// starting with "fail", which are declined for insufficient funds.
+func NewStubCharger() Charger {
+	return stubCharger{}
+}
+
+func (stubCharger) Provider() string {
+	return "stub"
+}
+
+func (stubCharger) Charge(_ context.Context, req ChargeRequest) (*Charge, error) {
+	charge := &Charge{ExternalID: "stub-" + req.IdempotencyKey, Status: StatusPaid}
+	if strings.HasPrefix(req.Token, "fail") {
+		charge.Status = StatusFailed
+		charge.FailureReason = "insufficient_funds"
+	}
+	return charge, nil
+}
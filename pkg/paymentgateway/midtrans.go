@@ -0,0 +1,112 @@
+package paymentgateway
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Midtrans sends transaction_time in Jakarta time without a zone.
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+type midtransNotification struct {
+	TransactionID     string `json:"transaction_id"`
+	TransactionStatus string `json:"transaction_status"`
+	TransactionTime   string `json:"transaction_time"`
+	FraudStatus       string `json:"fraud_status"`
+	StatusCode        string `json:"status_code"`
+	OrderID           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	SignatureKey      string `json:"signature_key"`
+	PermataVANumber   string `json:"permata_va_number"`
+	VANumbers         []struct {
+		Bank     string `json:"bank"`
+		VANumber string `json:"va_number"`
+	} `json:"va_numbers"`
+}
+
+type midtrans struct {
+	serverKey string
+}
+
+// NewMidtrans verifies Midtrans HTTP notifications, whose signature_key is
+// SHA-512(order_id + status_code + gross_amount + server key).
+func NewMidtrans(serverKey string) Verifier {
+	return &midtrans{serverKey: serverKey}
+}
+
+func (m *midtrans) Provider() string {
+	return "midtrans"
+}
+
+func (m *midtrans) Verify(_ http.Header, body []byte) (*Notification, error) {
+	var n midtransNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	sum := sha512.Sum512([]byte(n.OrderID + n.StatusCode + n.GrossAmount + m.serverKey))
+	expected := hex.EncodeToString(sum[:])
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(n.SignatureKey))) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	if n.TransactionID == "" || n.OrderID == "" || n.TransactionStatus == "" {
+		return nil, fmt.Errorf("%w: transaction_id, order_id and transaction_status are required", ErrInvalidPayload)
+	}
+	amount, err := decimal.NewFromString(n.GrossAmount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: gross_amount: %v", ErrInvalidPayload, err)
+	}
+
+	occurredAt, err := time.ParseInLocation("2006-01-02 15:04:05", n.TransactionTime, jakarta)
+	if err != nil {
+		occurredAt = time.Now()
+	}
+
+	currency := n.Currency
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	virtualAccount := n.PermataVANumber
+	if len(n.VANumbers) > 0 {
+		virtualAccount = n.VANumbers[0].VANumber
+	}
+
+	return &Notification{
+		EventID:        n.TransactionID + ":" + n.TransactionStatus,
+		ExternalID:     n.TransactionID,
+		Reference:      n.OrderID,
+		VirtualAccount: virtualAccount,
+		Status:         midtransStatus(n.TransactionStatus, n.FraudStatus),
+		Amount:         amount,
+		Currency:       strings.ToUpper(currency),
+		OccurredAt:     occurredAt,
+	}, nil
+}
+
+func midtransStatus(status, fraud string) Status {
+	switch status {
+	case "settlement":
+		return StatusPaid
+	case "capture":
+		// Pembayaran kartu yang ditahan fraud check belum dianggap lunas
+		if fraud == "" || fraud == "accept" {
+			return StatusPaid
+		}
+		return StatusPending
+	case "deny", "cancel", "expire", "failure":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
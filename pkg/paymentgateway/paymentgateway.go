@@ -0,0 +1,50 @@
+// Package paymentgateway verifies and normalises payment notifications sent
+// by external payment gateways. Each gateway signs its callbacks differently,
+// so every provider implements Verifier and the payment service only ever
+// sees a Notification.
+package paymentgateway
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidSignature = errors.New("payment callback signature is invalid")
+	ErrInvalidPayload   = errors.New("payment callback payload is malformed")
+)
+
+type Status string
+
+const (
+	StatusPending Status = "PENDING"
+	StatusPaid    Status = "PAID"
+	StatusFailed  Status = "FAILED"
+)
+
+// Notification is a verified gateway callback. EventID is unique per
+// delivery of a state change, so a replayed callback carries the same
+// EventID as the original. Reference is the order ID we gave the gateway,
+// which is the contract number. VirtualAccount is set when the customer paid
+// into a virtual account and identifies the contract on its own.
+type Notification struct {
+	EventID        string
+	ExternalID     string
+	Reference      string
+	VirtualAccount string
+	Status         Status
+	Amount         decimal.Decimal
+	Currency       string
+	OccurredAt     time.Time
+}
+
+// Verifier authenticates a raw callback and parses it into a Notification.
+// It returns ErrInvalidSignature when the callback was not signed by the
+// gateway and ErrInvalidPayload when the body cannot be understood.
+type Verifier interface {
+	Provider() string
+	Verify(header http.Header, body []byte) (*Notification, error)
+}
@@ -0,0 +1,197 @@
+// Package pdf writes small PDF documents made of text, lines and filled
+// rectangles on A4 pages. It only uses the standard Helvetica fonts, which
+// every PDF reader ships, so no font data has to be embedded.
+//
+// Coordinates are in millimetres from the top left corner of the page.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PageWidth and PageHeight are the A4 page size in millimetres.
+	PageWidth  = 210.0
+	PageHeight = 297.0
+
+	pointsPerMM = 72 / 25.4
+)
+
+// Color is an RGB color with components between 0 and 1.
+type Color struct {
+	R, G, B float64
+}
+
+var (
+	Black = Color{0, 0, 0}
+	Gray  = Color{0.45, 0.45, 0.45}
+	White = Color{1, 1, 1}
+)
+
+// ParseHexColor parses colors written as #RRGGBB.
+func ParseHexColor(hex string) (Color, error) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return Color{}, fmt.Errorf("color %q is not #RRGGBB", hex)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("color %q is not #RRGGBB: %w", hex, err)
+	}
+	return Color{
+		R: float64(value>>16&0xff) / 255,
+		G: float64(value>>8&0xff) / 255,
+		B: float64(value&0xff) / 255,
+	}, nil
+}
+
+// Document collects the content streams of its pages.
+type Document struct {
+	pages []*bytes.Buffer
+}
+
+// New returns an empty document. Call AddPage before drawing.
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage starts a new page; drawing calls go to the last page added.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// PageCount returns the number of pages added so far.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text draws s with its baseline at y. Characters outside Latin-1 are
+// replaced with '?' because the standard fonts cannot render them.
+func (d *Document) Text(x, y, size float64, bold bool, color Color, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT %s rg /%s %s Tf %s %s Td (%s) Tj ET\n",
+		color.fill(), font, num(size), num(x*pointsPerMM), num((PageHeight-y)*pointsPerMM), escape(s))
+}
+
+// TextRight draws s so that it ends at x.
+func (d *Document) TextRight(x, y, size float64, bold bool, color Color, s string) {
+	d.Text(x-TextWidth(s, size, bold), y, size, bold, color, s)
+}
+
+// Line draws a line of the given width in millimetres.
+func (d *Document) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(d.page(), "%s RG %s w %s %s m %s %s l S\n",
+		color.fill(), num(width*pointsPerMM),
+		num(x1*pointsPerMM), num((PageHeight-y1)*pointsPerMM),
+		num(x2*pointsPerMM), num((PageHeight-y2)*pointsPerMM))
+}
+
+// Rect fills the rectangle whose top left corner is at x, y.
+func (d *Document) Rect(x, y, w, h float64, color Color) {
+	fmt.Fprintf(d.page(), "%s rg %s %s %s %s re f\n",
+		color.fill(), num(x*pointsPerMM), num((PageHeight-y-h)*pointsPerMM), num(w*pointsPerMM), num(h*pointsPerMM))
+}
+
+// Bytes returns the finished document.
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*bytes.Buffer{{}}
+	}
+
+	// Objek 1 katalog, 2 daftar halaman, 3-4 font, lalu pasangan halaman dan isinya
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth*pointsPerMM), num(PageHeight*pointsPerMM), 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// TextWidth estimates the width of s in millimetres. Digits use their exact
+// Helvetica width so right aligned amounts line up; other glyphs use an
+// average that is close enough for layout.
+func TextWidth(s string, size float64, bold bool) float64 {
+	units := 0.0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			units += 556
+		case r == '.' || r == ',' || r == ' ' || r == 'i' || r == 'l':
+			units += 278
+		case r >= 'A' && r <= 'Z':
+			units += 667
+		default:
+			units += 520
+		}
+	}
+	if bold {
+		units *= 1.06
+	}
+	return units * size / 1000 / pointsPerMM
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+func (c Color) fill() string {
+	return num(c.R) + " " + num(c.G) + " " + num(c.B)
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// escape menyiapkan string literal PDF dengan encoding WinAnsi
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 256:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Package push delivers notifications to the mobile app through a push
+// provider. Every provider implements Sender, so the notification service
+// never depends on a provider's wire format.
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown push provider")
+	// ErrInvalidToken means the device token is no longer registered with the
+	// provider, usually because the app was uninstalled, and should be dropped.
+	ErrInvalidToken = errors.New("push token is no longer valid")
+)
+
+// Message is a notification for a single device. Data is delivered to the
+// app alongside the visible title and body.
+type Message struct {
+	Token string
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Sender delivers a Message and returns the provider's ID for it.
+type Sender interface {
+	Provider() string
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+// Config selects a provider by name, "stub" or "fcm". HTTPClient is used
+// by FCM; nil gets a plain client.
+type Config struct {
+	Provider   string
+	FCM        FCMConfig
+	HTTPClient *http.Client
+}
+
+// New returns the Sender named by cfg.Provider.
+func New(cfg Config) (Sender, error) {
+	switch cfg.Provider {
+	case "stub":
+		return NewStubSender(), nil
+	case "fcm":
+		return NewFCM(cfg.FCM, cfg.HTTPClient)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
+
+type stubSender struct{}
+
+// NewStubSender accepts every message except those for a token starting
+// with "invalid", which it reports as unregistered.
+func NewStubSender() Sender {
+	return stubSender{}
+}
+
+func (stubSender) Provider() string {
+	return "stub"
+}
+
+func (stubSender) Send(_ context.Context, msg Message) (string, error) {
+	if strings.HasPrefix(msg.Token, "invalid") {
+		return "", ErrInvalidToken
+	}
+	return "stub-" + msg.Token, nil
+}
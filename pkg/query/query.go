@@ -0,0 +1,213 @@
+// Package query turns list endpoint query strings into safe GORM clauses.
+//
+// Each endpoint declares a Spec listing the filters it accepts, the columns
+// it can be sorted by and its page size bounds. Spec.Parse validates the
+// request against it, so repositories only ever see column names chosen by
+// the code, never by the caller.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrInvalidSort   = errors.New("invalid sort")
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// Filter maps a query parameter to a column compared by equality.
+type Filter struct {
+	Column string
+	// Values lists the accepted values, matched case-insensitively and
+	// stored in the spelling given here. Empty accepts any value.
+	Values []string
+	// Normalize rewrites the raw value before it is checked, e.g. ToUpper.
+	Normalize func(string) string
+	// Validate rejects values that Values cannot express.
+	Validate func(string) bool
+}
+
+// Spec declares what a list endpoint accepts.
+type Spec struct {
+	// Filters is keyed by query parameter name.
+	Filters map[string]Filter
+	// Sorts maps the keys accepted by ?sort= to columns.
+	Sorts        map[string]string
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Condition is a validated column = value filter.
+type Condition struct {
+	Column string
+	Value  string
+}
+
+// Order is a validated sort column.
+type Order struct {
+	Column string
+	Desc   bool
+}
+
+// Query is a parsed and validated list request.
+type Query struct {
+	Page    int
+	Limit   int
+	Filters []Condition
+	Sort    []Order
+}
+
+// Paginated is the standard envelope of a list response.
+type Paginated struct {
+	Data       any
+	Total      int64
+	Page       int
+	Limit      int
+	TotalPages int
+}
+
+// Parse reads page, limit, sort and the declared filters from the request.
+// Filters left empty are skipped; undeclared query parameters are ignored.
+//
+// Sort takes comma separated keys, each optionally prefixed with "-" for
+// descending order, e.g. ?sort=-created_at,id.
+func (s Spec) Parse(c *fiber.Ctx) (Query, error) {
+	filters := make(map[string]string, len(s.Filters))
+	for name := range s.Filters {
+		filters[name] = c.Query(name)
+	}
+	return s.Build(c.QueryInt("page", 1), c.QueryInt("limit", 0), filters, c.Query("sort"))
+}
+
+// Build validates values that were not read from a query string, such as
+// GraphQL arguments, the same way Parse does. A limit of zero or less
+// means the default page size.
+func (s Spec) Build(page, limit int, filters map[string]string, sortKeys string) (Query, error) {
+	defaultLimit, maxLimit := s.DefaultLimit, s.MaxLimit
+	if defaultLimit <= 0 {
+		defaultLimit = DefaultLimit
+	}
+	if maxLimit <= 0 {
+		maxLimit = MaxLimit
+	}
+
+	q := Query{
+		Page:  max(page, 1),
+		Limit: limit,
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	}
+	q.Limit = min(q.Limit, maxLimit)
+
+	for name, raw := range filters {
+		filter, ok := s.Filters[name]
+		raw = strings.TrimSpace(raw)
+		if !ok || raw == "" {
+			continue
+		}
+		value, ok := filter.accept(raw)
+		if !ok {
+			return Query{}, fmt.Errorf("%w: unsupported %s %q", ErrInvalidFilter, name, raw)
+		}
+		q.Filters = append(q.Filters, Condition{Column: filter.Column, Value: value})
+	}
+	// Urutan map tidak tetap, diurutkan supaya query yang dihasilkan stabil
+	sort.Slice(q.Filters, func(i, j int) bool { return q.Filters[i].Column < q.Filters[j].Column })
+
+	for _, key := range strings.Split(sortKeys, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		desc := strings.HasPrefix(key, "-")
+		column, ok := s.Sorts[strings.TrimPrefix(key, "-")]
+		if !ok {
+			return Query{}, fmt.Errorf("%w: cannot sort by %q", ErrInvalidSort, strings.TrimPrefix(key, "-"))
+		}
+		q.Sort = append(q.Sort, Order{Column: column, Desc: desc})
+	}
+
+	return q, nil
+}
+
+func (f Filter) accept(raw string) (string, bool) {
+	if f.Normalize != nil {
+		raw = f.Normalize(raw)
+	}
+	if f.Validate != nil && !f.Validate(raw) {
+		return "", false
+	}
+	if len(f.Values) == 0 {
+		return raw, true
+	}
+	for _, v := range f.Values {
+		if strings.EqualFold(v, raw) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Value returns the filter value for column, or "" when it is not filtered.
+func (q Query) Value(column string) string {
+	for _, cond := range q.Filters {
+		if cond.Column == column {
+			return cond.Value
+		}
+	}
+	return ""
+}
+
+// Offset is the number of rows skipped before the current page.
+func (q Query) Offset() int {
+	return (max(q.Page, 1) - 1) * q.Limit
+}
+
+// Filter applies the filters to db. Call it on both the count and the
+// select query.
+func (q Query) Filter(db *gorm.DB) *gorm.DB {
+	for _, cond := range q.Filters {
+		db = db.Where(clause.Eq{Column: clause.Column{Name: cond.Column}, Value: cond.Value})
+	}
+	return db
+}
+
+// Paginate applies the requested sort and the page window to db. It is
+// called directly rather than through db.Scopes, because scopes run after
+// the clauses chained here; the repository's own default order is chained
+// afterwards and acts as the tiebreaker.
+func (q Query) Paginate(db *gorm.DB) *gorm.DB {
+	for _, order := range q.Sort {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: order.Column}, Desc: order.Desc})
+	}
+	return db.Limit(q.Limit).Offset(q.Offset())
+}
+
+// Paginated wraps one page of data in the standard envelope.
+func (q Query) Paginated(data any, total int64) *Paginated {
+	totalPages := 0
+	if q.Limit > 0 {
+		totalPages = int(math.Ceil(float64(total) / float64(q.Limit)))
+	}
+	return &Paginated{
+		Data:       data,
+		Total:      total,
+		Page:       q.Page,
+		Limit:      q.Limit,
+		TotalPages: totalPages,
+	}
+}
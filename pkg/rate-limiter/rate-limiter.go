@@ -2,6 +2,7 @@ package ratelimiter
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
@@ -117,3 +118,68 @@ func (rl *RateLimiter) RateLimitMiddleware() fiber.Handler {
 		return c.Next()
 	}
 }
+
+// Fields of the daily usage hash written by KeyedRateLimitMiddleware.
+const (
+	UsageAllowed   = "allowed"
+	UsageThrottled = "throttled"
+)
+
+// UsageRetention is how long daily usage counters are kept in Redis.
+const UsageRetention = 31 * 24 * time.Hour
+
+// PartnerKey is the limiter key of a partner authenticated by API key, so
+// every integration server of one partner shares a single bucket.
+func PartnerKey(partnerID uint64) string {
+	return "partner:" + strconv.FormatUint(partnerID, 10)
+}
+
+// UsageKey is the Redis hash counting the requests of key on the UTC day
+// containing t.
+func UsageKey(key string, t time.Time) string {
+	return "ratelimit:usage:" + key + ":" + t.UTC().Format("20060102")
+}
+
+// KeyedRateLimitMiddleware limits requests by the key keyFunc returns instead
+// of the client IP, and counts allowed and throttled requests per key and day
+// under UsageKey. Requests without a key pass through uncounted.
+func (rl *RateLimiter) KeyedRateLimitMiddleware(keyFunc func(c *fiber.Ctx) string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := keyFunc(c)
+		if key == "" {
+			return c.Next()
+		}
+
+		allowed := rl.GetLimiter(key).Allow()
+		rl.recordUsage(key, allowed)
+
+		if !allowed {
+			zap.L().Warn("Rate limit exceeded", zap.String("key", key))
+
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"message": "Too many requests, please try again later.",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+func (rl *RateLimiter) recordUsage(key string, allowed bool) {
+	field := UsageAllowed
+	if !allowed {
+		field = UsageThrottled
+	}
+
+	// Sama seperti state limiter, counter ditulis di background agar tidak
+	// menambah latensi request
+	go func(usageKey string) {
+		ctx := context.Background()
+		pipe := rl.client.TxPipeline()
+		pipe.HIncrBy(ctx, usageKey, field, 1)
+		pipe.Expire(ctx, usageKey, UsageRetention)
+		if _, err := pipe.Exec(ctx); err != nil {
+			zap.L().Error("Error recording rate limit usage to Redis", zap.String("key", key), zap.Error(err))
+		}
+	}(UsageKey(key, time.Now()))
+}
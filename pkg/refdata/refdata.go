@@ -0,0 +1,251 @@
+// Package refdata reads the reference data a deployment ships with: tenors,
+// regions, asset categories and holidays. Each kind lives in a file of its
+// own in one directory, named after the kind with a .yaml, .yml or .json
+// extension:
+//
+//	asset_categories.yaml
+//	regions.yaml
+//	tenors.yaml
+//	holidays.json
+//
+// A kind without a file is simply not managed. Load checks every file and
+// the references between them before anything is written, so a bad file
+// stops start-up instead of leaving half of it applied.
+package refdata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+// File names without extension, in the order the kinds are applied.
+const (
+	AssetCategoriesFile = "asset_categories"
+	RegionsFile         = "regions"
+	TenorsFile          = "tenors"
+	HolidaysFile        = "holidays"
+)
+
+// extensions are tried in this order; JSON is read by the YAML decoder,
+// since every JSON document is also YAML.
+var extensions = []string{".yaml", ".yml", ".json"}
+
+var (
+	regionCodePattern   = regexp.MustCompile(`^[A-Z]{2,8}$`)
+	categoryCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+)
+
+// AssetCategory is a kind of asset that may be financed. Tenors and
+// concentration limits refer to it by Code.
+type AssetCategory struct {
+	Code string `yaml:"code"`
+	Name string `yaml:"name"`
+}
+
+// Region is an entry of the regions table. Active defaults to true.
+type Region struct {
+	Code   string `yaml:"code"`
+	Name   string `yaml:"name"`
+	Active *bool  `yaml:"active"`
+}
+
+// Tenor is a financing product, matched on DurationMonths. The product
+// rules mean the same as in dto.TenorProductRequest.
+type Tenor struct {
+	DurationMonths  uint8           `yaml:"duration_months"`
+	Description     string          `yaml:"description"`
+	MinAmount       decimal.Decimal `yaml:"min_amount"`
+	MaxAmount       decimal.Decimal `yaml:"max_amount"`
+	MinAge          uint8           `yaml:"min_age"`
+	MaxAge          uint8           `yaml:"max_age"`
+	MaxAgeAtEnd     uint8           `yaml:"max_age_at_end"`
+	AssetCategories []string        `yaml:"asset_categories"`
+}
+
+// Holiday is a day on which installments do not fall due. Date is written
+// as YYYY-MM-DD.
+type Holiday struct {
+	Date string `yaml:"date"`
+	Name string `yaml:"name"`
+}
+
+// Set is the reference data of one directory.
+type Set struct {
+	AssetCategories []AssetCategory
+	Regions         []Region
+	Tenors          []Tenor
+	Holidays        []Holiday
+}
+
+// Load reads the reference data in dir and validates it. An empty dir
+// returns an empty set.
+func Load(dir string) (*Set, error) {
+	set := &Set{}
+	if dir == "" {
+		return set, nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	errs := []error{
+		read(dir, AssetCategoriesFile, &set.AssetCategories),
+		read(dir, RegionsFile, &set.Regions),
+		read(dir, TenorsFile, &set.Tenors),
+		read(dir, HolidaysFile, &set.Holidays),
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := set.Validate(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Empty reports whether the set manages no kind at all.
+func (s *Set) Empty() bool {
+	return len(s.AssetCategories) == 0 && len(s.Regions) == 0 && len(s.Tenors) == 0 && len(s.Holidays) == 0
+}
+
+// Validate checks every entry and that each asset category a tenor names is
+// declared. Codes are normalised the way the services store them: region
+// codes upper case, category codes lower case. Every problem is reported at
+// once.
+func (s *Set) Validate() error {
+	var errs []error
+
+	categories := make(map[string]bool, len(s.AssetCategories))
+	for i := range s.AssetCategories {
+		c := &s.AssetCategories[i]
+		c.Code = strings.ToLower(strings.TrimSpace(c.Code))
+		switch {
+		case !categoryCodePattern.MatchString(c.Code):
+			errs = append(errs, fmt.Errorf("%s[%d]: code %q must be lowercase letters, digits, dashes and underscores", AssetCategoriesFile, i, c.Code))
+		case categories[c.Code]:
+			errs = append(errs, fmt.Errorf("%s[%d]: code %q is listed twice", AssetCategoriesFile, i, c.Code))
+		}
+		if err := checkName(c.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%s[%d]: %w", AssetCategoriesFile, i, err))
+		}
+		categories[c.Code] = true
+	}
+
+	regions := make(map[string]bool, len(s.Regions))
+	for i := range s.Regions {
+		r := &s.Regions[i]
+		r.Code = strings.ToUpper(strings.TrimSpace(r.Code))
+		switch {
+		case !regionCodePattern.MatchString(r.Code):
+			errs = append(errs, fmt.Errorf("%s[%d]: code %q must be 2 to 8 letters", RegionsFile, i, r.Code))
+		case regions[r.Code]:
+			errs = append(errs, fmt.Errorf("%s[%d]: code %q is listed twice", RegionsFile, i, r.Code))
+		}
+		if err := checkName(r.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%s[%d]: %w", RegionsFile, i, err))
+		}
+		regions[r.Code] = true
+	}
+
+	durations := make(map[uint8]bool, len(s.Tenors))
+	for i := range s.Tenors {
+		t := &s.Tenors[i]
+		switch {
+		case t.DurationMonths == 0:
+			errs = append(errs, fmt.Errorf("%s[%d]: duration_months must be greater than zero", TenorsFile, i))
+		case durations[t.DurationMonths]:
+			errs = append(errs, fmt.Errorf("%s[%d]: %d months is listed twice", TenorsFile, i, t.DurationMonths))
+		}
+		durations[t.DurationMonths] = true
+
+		if len(t.Description) > 50 {
+			errs = append(errs, fmt.Errorf("%s[%d]: description is longer than 50 characters", TenorsFile, i))
+		}
+		if t.MinAmount.IsNegative() || t.MaxAmount.IsNegative() {
+			errs = append(errs, fmt.Errorf("%s[%d]: amounts must not be negative", TenorsFile, i))
+		}
+		if t.MaxAmount.IsPositive() && t.MinAmount.GreaterThan(t.MaxAmount) {
+			errs = append(errs, fmt.Errorf("%s[%d]: min_amount is above max_amount", TenorsFile, i))
+		}
+		if (t.MaxAge > 0 && t.MinAge > t.MaxAge) || (t.MaxAgeAtEnd > 0 && t.MinAge > t.MaxAgeAtEnd) {
+			errs = append(errs, fmt.Errorf("%s[%d]: min_age is above max_age or max_age_at_end", TenorsFile, i))
+		}
+
+		for j, category := range t.AssetCategories {
+			category = strings.ToLower(strings.TrimSpace(category))
+			t.AssetCategories[j] = category
+			// Kategori yang tidak terdaftar membuat tenor tidak bisa dipakai untuk aset apa pun
+			if !categories[category] {
+				errs = append(errs, fmt.Errorf("%s[%d]: asset category %q is not listed in %s", TenorsFile, i, category, AssetCategoriesFile))
+			}
+		}
+	}
+
+	dates := make(map[string]bool, len(s.Holidays))
+	for i, h := range s.Holidays {
+		if _, err := time.Parse(time.DateOnly, h.Date); err != nil {
+			errs = append(errs, fmt.Errorf("%s[%d]: date %q is not YYYY-MM-DD", HolidaysFile, i, h.Date))
+		} else if dates[h.Date] {
+			errs = append(errs, fmt.Errorf("%s[%d]: date %s is listed twice", HolidaysFile, i, h.Date))
+		}
+		if err := checkName(h.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%s[%d]: %w", HolidaysFile, i, err))
+		}
+		dates[h.Date] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return errors.New("name is empty")
+	case len(name) > 100:
+		return errors.New("name is longer than 100 characters")
+	}
+	return nil
+}
+
+// read decodes the file of one kind into out, leaving out empty when the
+// kind has no file. Unknown fields are rejected so a misspelt key does not
+// silently fall back to its zero value.
+func read(dir, name string, out any) error {
+	var found []string
+	for _, ext := range extensions {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("%s: only one of %s may exist", name, strings.Join(found, ", "))
+	}
+
+	data, err := os.ReadFile(found[0])
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse %s: %w", found[0], err)
+	}
+	return nil
+}
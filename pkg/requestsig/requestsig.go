@@ -0,0 +1,59 @@
+package requestsig
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+const (
+	HeaderNonce     = "X-Nonce"
+	HeaderTimestamp = "X-Timestamp"
+	HeaderSignature = "X-Signature"
+)
+
+var noncePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{16,64}$`)
+
+// GenerateSecret returns a new signing secret for a partner. Unlike API keys
+// it is stored as is, since the server needs it to recompute signatures.
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Sign returns the hex HMAC-SHA256 of the canonical request:
+//
+//	timestamp \n nonce \n METHOD \n path \n hex(sha256(body))
+//
+// The timestamp is the X-Timestamp header value in Unix seconds.
+func Sign(secret, timestamp, nonce, method, path string, body []byte) string {
+	bodySum := sha256.Sum256(body)
+	canonical := strings.Join([]string{
+		timestamp,
+		nonce,
+		strings.ToUpper(method),
+		path,
+		hex.EncodeToString(bodySum[:]),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches the request, in constant time.
+func Verify(secret, signature, timestamp, nonce, method, path string, body []byte) bool {
+	expected := Sign(secret, timestamp, nonce, method, path, body)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+// ValidNonce reports whether nonce is 16 to 64 URL-safe characters.
+func ValidNonce(nonce string) bool {
+	return noncePattern.MatchString(nonce)
+}
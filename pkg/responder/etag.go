@@ -0,0 +1,48 @@
+package responder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ETag returns a strong entity tag for data. It hashes the rendered payload
+// rather than an updated_at column, because several columns are written with
+// UpdateColumn and would otherwise change without moving the tag.
+func ETag(data any) (string, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// NotModified sets etag on the response and reports whether the request's
+// If-None-Match already names it, in which case the caller answers 304
+// without a body. Clients are asked to revalidate on every use.
+func NotModified(c *fiber.Ctx, etag string) bool {
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+
+	return Matches(c.Get(fiber.HeaderIfNoneMatch), etag)
+}
+
+// Matches reports whether a comma separated If-None-Match or If-Match header
+// lists etag or "*". An empty header matches nothing.
+func Matches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		// Perbandingan weak sesuai RFC 9110, prefix W/ diabaikan
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
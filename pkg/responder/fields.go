@@ -0,0 +1,81 @@
+package responder
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is one entry of the details of a validation failure.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// FieldErrors lists the fields err complains about: every failed tag of a
+// validator.ValidationErrors, or the field of a common.FieldError. It
+// returns nil for any other error.
+func FieldErrors(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			fields[i] = FieldError{
+				Field:   fieldPath(fe.Namespace()),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: ruleMessage(fe.Tag(), fe.Param()),
+			}
+		}
+		return fields
+	}
+
+	var fieldErr *common.FieldError
+	if errors.As(err, &fieldErr) {
+		return []FieldError{{Field: fieldErr.Field, Message: fieldErr.Err.Error()}}
+	}
+	return nil
+}
+
+// fieldPath drops the struct name the validator puts in front of every
+// namespace, leaving e.g. "limits[0].limit_amount".
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+func ruleMessage(tag, param string) string {
+	switch tag {
+	case "required", "required_if", "required_with":
+		return "is required"
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "lt":
+		return "must be less than " + param
+	case "lte":
+		return "must be at most " + param
+	case "len":
+		return "must be exactly " + param + " characters long"
+	case "min":
+		return "must be at least " + param + " long"
+	case "max":
+		return "must be at most " + param + " long"
+	case "oneof":
+		return "must be one of " + param
+	case "numeric":
+		return "must contain digits only"
+	case "alpha":
+		return "must contain letters only"
+	case "email":
+		return "must be a valid email address"
+	}
+	return "failed the " + tag + " rule"
+}
@@ -0,0 +1,76 @@
+// Package responder renders every JSON response in the same envelope:
+//
+//	{"data": ..., "meta": {"request_id": ..., "pagination": ...}, "error": {"code": ..., "message": ...}}
+//
+// Successful responses carry data, failed ones carry error; meta is always
+// present so clients can quote the request ID when reporting a problem.
+package responder
+
+import (
+	"github.com/fazamuttaqien/multifinance/pkg/query"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Envelope struct {
+	Data  any    `json:"data,omitempty"`
+	Meta  Meta   `json:"meta"`
+	Error *Error `json:"error,omitempty"`
+}
+
+type Meta struct {
+	RequestID  string      `json:"request_id,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// Success writes data with the given status. A *query.Paginated is unwrapped
+// so its rows become data and its counters move to meta.pagination.
+func Success(c *fiber.Ctx, status int, data any) error {
+	env := Envelope{Data: data, Meta: meta(c)}
+	if page, ok := data.(*query.Paginated); ok && page != nil {
+		env.Data = page.Data
+		env.Meta.Pagination = &Pagination{
+			Page:       page.Page,
+			Limit:      page.Limit,
+			Total:      page.Total,
+			TotalPages: page.TotalPages,
+		}
+	}
+	return c.Status(status).JSON(env)
+}
+
+// Fail writes an error envelope. Code is a stable machine readable value such
+// as "validation_error"; message is meant for humans.
+func Fail(c *fiber.Ctx, status int, code, message string) error {
+	return c.Status(status).JSON(Envelope{
+		Meta:  meta(c),
+		Error: &Error{Code: code, Message: message},
+	})
+}
+
+// FailWithDetails is Fail with machine readable details the client can act
+// on, such as when to retry.
+func FailWithDetails(c *fiber.Ctx, status int, code, message string, details any) error {
+	return c.Status(status).JSON(Envelope{
+		Meta:  meta(c),
+		Error: &Error{Code: code, Message: message, Details: details},
+	})
+}
+
+// meta reads the request ID set by the requestid middleware on the response.
+func meta(c *fiber.Ctx) Meta {
+	return Meta{RequestID: c.GetRespHeader(fiber.HeaderXRequestID)}
+}
@@ -0,0 +1,288 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// resolution is the size of one rolling bucket. Windows shorter than a
+// bucket are rounded up to one bucket.
+const resolution = time.Minute
+
+// Objective is one SLI with its target. An Objective without Latency
+// measures availability: a request is good unless it answered 5xx. With
+// Latency set, a request is good when it answered within Latency, and 5xx
+// answers are left to the availability objective. A p95 latency SLO is an
+// Objective with Target 0.95.
+type Objective struct {
+	Name    string
+	Target  float64
+	Latency time.Duration
+}
+
+// Alert is a multi-window burn-rate rule: it fires when the error budget
+// burns faster than BurnRate over both the Short and the Long window.
+type Alert struct {
+	Severity string
+	Short    time.Duration
+	Long     time.Duration
+	BurnRate float64
+}
+
+// DefaultAlerts pages on a burn that spends 2% of a 30 day budget in an
+// hour and opens a ticket for 5% in six hours, following the SRE workbook.
+var DefaultAlerts = []Alert{
+	{Severity: "page", Short: 5 * time.Minute, Long: time.Hour, BurnRate: 14.4},
+	{Severity: "ticket", Short: 30 * time.Minute, Long: 6 * time.Hour, BurnRate: 6},
+}
+
+// Validate reports an objective whose target cannot have an error budget.
+func (o Objective) Validate() error {
+	if o.Name == "" {
+		return errors.New("objective name is empty")
+	}
+	if o.Target <= 0 || o.Target >= 1 {
+		return fmt.Errorf("objective %s: target %v must be between 0 and 1", o.Name, o.Target)
+	}
+	if o.Latency < 0 {
+		return fmt.Errorf("objective %s: latency must not be negative", o.Name)
+	}
+	return nil
+}
+
+type bucket struct {
+	slot  int64
+	good  int64
+	total int64
+}
+
+type series struct {
+	objective Objective
+	buckets   []bucket
+}
+
+func (s *series) add(now time.Time, good bool) {
+	slot := now.UnixNano() / int64(resolution)
+	b := &s.buckets[slot%int64(len(s.buckets))]
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+	b.total++
+	if good {
+		b.good++
+	}
+}
+
+// ratio returns the share of good events over window, and false when the
+// window saw no events.
+func (s *series) ratio(now time.Time, window time.Duration) (float64, bool) {
+	slot := now.UnixNano() / int64(resolution)
+	slots := max(int64((window+resolution-1)/resolution), 1)
+
+	var good, total int64
+	for _, b := range s.buckets {
+		if b.slot > slot-slots && b.slot <= slot {
+			good += b.good
+			total += b.total
+		}
+	}
+	if total == 0 {
+		return 1, false
+	}
+	return float64(good) / float64(total), true
+}
+
+// burnRate is how many times faster than allowed the error budget is spent
+// over window. 1 spends the whole budget exactly at the end of the period.
+func (s *series) burnRate(now time.Time, window time.Duration) float64 {
+	ratio, _ := s.ratio(now, window)
+	return (1 - ratio) / (1 - s.objective.Target)
+}
+
+// Tracker computes rolling SLIs for a set of objectives and exports them,
+// together with the burn rates and alert state, as OTel metrics.
+type Tracker struct {
+	mu     sync.Mutex
+	series []*series
+	alerts []Alert
+	window time.Duration
+	now    func() time.Time
+	events metric.Int64Counter
+}
+
+// New registers the SLO metrics on meter. Every objective keeps buckets for
+// the longest alert window, which is also the window the remaining error
+// budget is reported over.
+func New(meter metric.Meter, objectives []Objective, alerts []Alert) (*Tracker, error) {
+	if len(alerts) == 0 {
+		alerts = DefaultAlerts
+	}
+
+	t := &Tracker{alerts: alerts, now: time.Now}
+	for _, alert := range alerts {
+		t.window = max(t.window, alert.Short, alert.Long)
+	}
+
+	size := int((t.window + resolution - 1) / resolution)
+	for _, objective := range objectives {
+		if err := objective.Validate(); err != nil {
+			return nil, err
+		}
+		t.series = append(t.series, &series{objective: objective, buckets: make([]bucket, size)})
+	}
+
+	var err error
+	t.events, err = meter.Int64Counter(
+		"slo.events",
+		metric.WithDescription("Requests counted against an SLO, by whether they were good"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ratio, err := meter.Float64ObservableGauge(
+		"slo.success_ratio",
+		metric.WithDescription("Share of good requests over the rolling window"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	burnRate, err := meter.Float64ObservableGauge(
+		"slo.burn_rate",
+		metric.WithDescription("Error budget burn rate over the rolling window"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := meter.Float64ObservableGauge(
+		"slo.error_budget.remaining",
+		metric.WithDescription("Share of the error budget left over the longest alert window"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	firing, err := meter.Int64ObservableGauge(
+		"slo.alert",
+		metric.WithDescription("1 while a burn-rate alert fires, with the severity as attribute"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		now := t.now()
+		for _, s := range t.series {
+			name := attribute.String("slo", s.objective.Name)
+			target := attribute.Float64("slo.target", s.objective.Target)
+
+			for _, window := range t.windows() {
+				attrs := metric.WithAttributes(name, target, attribute.String("window", window.String()))
+				value, _ := s.ratio(now, window)
+				o.ObserveFloat64(ratio, value, attrs)
+				o.ObserveFloat64(burnRate, s.burnRate(now, window), attrs)
+			}
+
+			o.ObserveFloat64(budget, 1-s.burnRate(now, t.window), metric.WithAttributes(name, target))
+
+			for _, alert := range t.alerts {
+				var value int64
+				if s.burnRate(now, alert.Short) > alert.BurnRate && s.burnRate(now, alert.Long) > alert.BurnRate {
+					value = 1
+				}
+				o.ObserveInt64(firing, value, metric.WithAttributes(name, target,
+					attribute.String("severity", alert.Severity),
+					attribute.Float64("burn_rate.threshold", alert.BurnRate),
+					attribute.String("window.short", alert.Short.String()),
+					attribute.String("window.long", alert.Long.String()),
+				))
+			}
+		}
+		return nil
+	}, ratio, burnRate, budget, firing)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// windows lists each distinct alert window once, in alert order.
+func (t *Tracker) windows() []time.Duration {
+	var windows []time.Duration
+	seen := make(map[time.Duration]bool)
+	for _, alert := range t.alerts {
+		for _, window := range []time.Duration{alert.Short, alert.Long} {
+			if !seen[window] {
+				seen[window] = true
+				windows = append(windows, window)
+			}
+		}
+	}
+	return windows
+}
+
+// Record counts one request against every objective.
+func (t *Tracker) Record(ctx context.Context, status int, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for _, s := range t.series {
+		var good bool
+		if s.objective.Latency == 0 {
+			good = status < fiber.StatusInternalServerError
+		} else {
+			// Latency hanya dihitung untuk request yang berhasil, error
+			// sudah masuk ke objective availability
+			if status >= fiber.StatusInternalServerError {
+				continue
+			}
+			good = latency <= s.objective.Latency
+		}
+
+		s.add(now, good)
+		t.events.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("slo", s.objective.Name),
+			attribute.Bool("good", good),
+		))
+	}
+}
+
+// Middleware records every request of the routes it is mounted on.
+func (t *Tracker) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// Error handler belum berjalan, jadi status diambil dari error
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		t.Record(c.UserContext(), status, time.Since(start))
+		return err
+	}
+}
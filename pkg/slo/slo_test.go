@@ -0,0 +1,382 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var start = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// testTracker returns a tracker on a manual clock and the reader its
+// metrics are collected from.
+func testTracker(t *testing.T, objectives []Objective, alerts []Alert) (*Tracker, *sdkmetric.ManualReader, *time.Time) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test-slo")
+
+	tracker, err := New(meter, objectives, alerts)
+	require.NoError(t, err)
+
+	now := start
+	tracker.now = func() time.Time { return now }
+	return tracker, reader, &now
+}
+
+// record counts good and bad availability events at the tracker's clock.
+func record(tracker *Tracker, good, bad int) {
+	for i := 0; i < good; i++ {
+		tracker.Record(context.Background(), http.StatusOK, time.Millisecond)
+	}
+	for i := 0; i < bad; i++ {
+		tracker.Record(context.Background(), http.StatusInternalServerError, time.Millisecond)
+	}
+}
+
+// gauges collects the gauges named name for objective slo as floats, keyed
+// by the value of attribute key.
+func gauges(t *testing.T, reader *sdkmetric.ManualReader, name, slo, key string) map[string]float64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	out := map[string]float64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[float64]:
+				for _, dp := range data.DataPoints {
+					if v, _ := dp.Attributes.Value("slo"); v.AsString() == slo {
+						k, _ := dp.Attributes.Value(attribute.Key(key))
+						out[k.Emit()] = dp.Value
+					}
+				}
+			case metricdata.Gauge[int64]:
+				for _, dp := range data.DataPoints {
+					if v, _ := dp.Attributes.Value("slo"); v.AsString() == slo {
+						k, _ := dp.Attributes.Value(attribute.Key(key))
+						out[k.Emit()] = float64(dp.Value)
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+func TestObjective_Validate(t *testing.T) {
+	cases := []struct {
+		name      string
+		objective Objective
+		wantErr   string
+	}{
+		{name: "Availability", objective: Objective{Name: "availability", Target: 0.999}},
+		{name: "Latency", objective: Objective{Name: "latency", Target: 0.95, Latency: 300 * time.Millisecond}},
+		{name: "Empty Name", objective: Objective{Target: 0.99}, wantErr: "name is empty"},
+		{name: "Zero Target", objective: Objective{Name: "a", Target: 0}, wantErr: "between 0 and 1"},
+		{name: "Full Target", objective: Objective{Name: "a", Target: 1}, wantErr: "between 0 and 1"},
+		{name: "Negative Latency", objective: Objective{Name: "a", Target: 0.9, Latency: -time.Second}, wantErr: "must not be negative"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.objective.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew_RejectsInvalidObjective(t *testing.T) {
+	meter := sdkmetric.NewMeterProvider().Meter("test-slo")
+	_, err := New(meter, []Objective{{Name: "availability", Target: 1}}, nil)
+	assert.Error(t, err)
+}
+
+func TestSeries_Ratio(t *testing.T) {
+	cases := []struct {
+		name   string
+		good   int
+		bad    int
+		ratio  float64
+		counts bool
+	}{
+		{name: "No Events", ratio: 1, counts: false},
+		{name: "All Good", good: 10, ratio: 1, counts: true},
+		{name: "All Bad", bad: 4, ratio: 0, counts: true},
+		{name: "Mixed", good: 3, bad: 1, ratio: 0.75, counts: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker, _, _ := testTracker(t, []Objective{{Name: "availability", Target: 0.99}}, nil)
+			record(tracker, tc.good, tc.bad)
+
+			ratio, ok := tracker.series[0].ratio(start, time.Hour)
+			assert.Equal(t, tc.counts, ok)
+			assert.InDelta(t, tc.ratio, ratio, 1e-9)
+		})
+	}
+}
+
+func TestSeries_BurnRate(t *testing.T) {
+	cases := []struct {
+		name   string
+		target float64
+		good   int
+		bad    int
+		want   float64
+	}{
+		// (1 - ratio) / (1 - target)
+		{name: "No Errors", target: 0.99, good: 100, want: 0},
+		{name: "Exactly On Budget", target: 0.99, good: 99, bad: 1, want: 1},
+		{name: "Twice The Budget", target: 0.99, good: 98, bad: 2, want: 2},
+		{name: "Page Threshold", target: 0.999, good: 9856, bad: 144, want: 14.4},
+		{name: "All Errors", target: 0.9, bad: 5, want: 10},
+		{name: "No Traffic Burns Nothing", target: 0.99, want: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker, _, _ := testTracker(t, []Objective{{Name: "availability", Target: tc.target}}, nil)
+			record(tracker, tc.good, tc.bad)
+
+			assert.InDelta(t, tc.want, tracker.series[0].burnRate(start, time.Hour), 1e-9)
+		})
+	}
+}
+
+func TestSeries_WindowExpiry(t *testing.T) {
+	tracker, _, now := testTracker(t, []Objective{{Name: "availability", Target: 0.99}}, nil)
+
+	record(tracker, 0, 1)
+	*now = start.Add(2 * time.Minute)
+	record(tracker, 1, 0)
+	s := tracker.series[0]
+
+	cases := []struct {
+		name   string
+		window time.Duration
+		ratio  float64
+	}{
+		{name: "Current Minute Only", window: time.Minute, ratio: 1},
+		{name: "Shorter Than A Bucket Rounds Up", window: 10 * time.Second, ratio: 1},
+		{name: "Two Minutes Misses The First Event", window: 2 * time.Minute, ratio: 1},
+		{name: "Three Minutes Sees Both", window: 3 * time.Minute, ratio: 0.5},
+		{name: "Partial Minute Rounds Up", window: 2*time.Minute + time.Second, ratio: 0.5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ratio, _ := s.ratio(*now, tc.window)
+			assert.InDelta(t, tc.ratio, ratio, 1e-9)
+		})
+	}
+
+	t.Run("Everything Expires", func(t *testing.T) {
+		_, ok := s.ratio(start.Add(7*time.Hour), 6*time.Hour)
+		assert.False(t, ok)
+	})
+}
+
+func TestSeries_BucketRollover(t *testing.T) {
+	// Jendela terpanjang tiga menit, jadi hanya ada tiga bucket
+	alerts := []Alert{{Severity: "page", Short: time.Minute, Long: 3 * time.Minute, BurnRate: 2}}
+	tracker, _, now := testTracker(t, []Objective{{Name: "availability", Target: 0.9}}, alerts)
+	s := tracker.series[0]
+	require.Len(t, s.buckets, 3)
+
+	record(tracker, 0, 5)
+
+	// Tiga menit kemudian slot yang sama dipakai ulang dan isinya dibuang
+	*now = start.Add(3 * time.Minute)
+	record(tracker, 2, 0)
+
+	ratio, ok := s.ratio(*now, 3*time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, 1.0, ratio)
+
+	var total int64
+	for _, b := range s.buckets {
+		total += b.total
+	}
+	assert.Equal(t, int64(2), total)
+}
+
+func TestTracker_Record(t *testing.T) {
+	objectives := []Objective{
+		{Name: "availability", Target: 0.99},
+		{Name: "latency", Target: 0.95, Latency: 300 * time.Millisecond},
+	}
+
+	cases := []struct {
+		name         string
+		status       int
+		latency      time.Duration
+		availability float64
+		latencyRatio float64
+		latencyCount bool
+	}{
+		{name: "Fast Success", status: http.StatusOK, latency: 100 * time.Millisecond, availability: 1, latencyRatio: 1, latencyCount: true},
+		{name: "At Latency Bound", status: http.StatusOK, latency: 300 * time.Millisecond, availability: 1, latencyRatio: 1, latencyCount: true},
+		{name: "Slow Success", status: http.StatusOK, latency: time.Second, availability: 1, latencyRatio: 0, latencyCount: true},
+		{name: "Client Error Is Good", status: http.StatusBadRequest, latency: time.Millisecond, availability: 1, latencyRatio: 1, latencyCount: true},
+		{name: "Server Error Skips Latency", status: http.StatusServiceUnavailable, latency: time.Millisecond, availability: 0, latencyRatio: 1, latencyCount: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker, _, _ := testTracker(t, objectives, nil)
+			tracker.Record(context.Background(), tc.status, tc.latency)
+
+			availability, _ := tracker.series[0].ratio(start, time.Hour)
+			latency, counted := tracker.series[1].ratio(start, time.Hour)
+			assert.Equal(t, tc.availability, availability)
+			assert.Equal(t, tc.latencyRatio, latency)
+			assert.Equal(t, tc.latencyCount, counted)
+		})
+	}
+}
+
+func TestTracker_MultiWindowAlerts(t *testing.T) {
+	objectives := []Objective{{Name: "availability", Target: 0.99}}
+
+	// Trafik diputar per menit, perMinute menentukan pola error-nya
+	run := func(t *testing.T, minutes int, perMinute func(minute int) (good, bad int)) *sdkmetric.ManualReader {
+		tracker, reader, now := testTracker(t, objectives, DefaultAlerts)
+		for minute := 0; minute < minutes; minute++ {
+			*now = start.Add(time.Duration(minute) * time.Minute)
+			good, bad := perMinute(minute)
+			record(tracker, good, bad)
+		}
+		return reader
+	}
+
+	cases := []struct {
+		name      string
+		minutes   int
+		perMinute func(minute int) (good, bad int)
+		page      float64
+		ticket    float64
+	}{
+		{
+			name:      "Healthy",
+			minutes:   60,
+			perMinute: func(int) (int, int) { return 100, 0 },
+		},
+		{
+			name:    "Short Spike Does Not Page",
+			minutes: 60,
+			perMinute: func(minute int) (int, int) {
+				// 50% error hanya di lima menit terakhir: burn 5m = 50, burn 1h ≈ 4.2
+				if minute >= 55 {
+					return 50, 50
+				}
+				return 100, 0
+			},
+		},
+		{
+			name:      "Sustained Burn Pages And Tickets",
+			minutes:   60,
+			perMinute: func(int) (int, int) { return 80, 20 },
+			page:      1,
+			ticket:    1,
+		},
+		{
+			name:    "Recovered Burn Stops Paging",
+			minutes: 70,
+			perMinute: func(minute int) (int, int) {
+				// Burn 1 jam masih tinggi, tapi lima menit terakhir sudah bersih
+				if minute >= 60 {
+					return 100, 0
+				}
+				return 80, 20
+			},
+			page:   0,
+			ticket: 1,
+		},
+		{
+			name:      "Slow Burn Only Tickets",
+			minutes:   60,
+			perMinute: func(int) (int, int) { return 90, 10 },
+			page:      0,
+			ticket:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := run(t, tc.minutes, tc.perMinute)
+
+			alerts := gauges(t, reader, "slo.alert", "availability", "severity")
+			assert.Equal(t, map[string]float64{"page": tc.page, "ticket": tc.ticket}, alerts)
+		})
+	}
+
+	t.Run("Reported Burn Rates And Budget", func(t *testing.T) {
+		reader := run(t, 60, func(int) (int, int) { return 98, 2 })
+
+		burn := gauges(t, reader, "slo.burn_rate", "availability", "window")
+		assert.Len(t, burn, 4)
+		for window, value := range burn {
+			assert.InDelta(t, 2, value, 1e-9, window)
+		}
+
+		ratio := gauges(t, reader, "slo.success_ratio", "availability", "window")
+		assert.InDelta(t, 0.98, ratio["5m0s"], 1e-9)
+
+		// Budget dihitung atas jendela terpanjang (6 jam): burn 2 berarti minus satu budget
+		budget := gauges(t, reader, "slo.error_budget.remaining", "availability", "slo")
+		assert.InDelta(t, -1, budget["availability"], 1e-9)
+	})
+}
+
+func TestTracker_Windows(t *testing.T) {
+	tracker, _, _ := testTracker(t, nil, []Alert{
+		{Severity: "page", Short: 5 * time.Minute, Long: time.Hour, BurnRate: 14.4},
+		{Severity: "ticket", Short: time.Hour, Long: 6 * time.Hour, BurnRate: 6},
+	})
+
+	assert.Equal(t, []time.Duration{5 * time.Minute, time.Hour, 6 * time.Hour}, tracker.windows())
+	assert.Equal(t, 6*time.Hour, tracker.window)
+}
+
+func TestTracker_Middleware(t *testing.T) {
+	tracker, _, _ := testTracker(t, []Objective{{Name: "availability", Target: 0.99}}, nil)
+
+	app := fiber.New()
+	app.Use(tracker.Middleware())
+	app.Get("/ok", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/unavailable", func(*fiber.Ctx) error { return fiber.ErrServiceUnavailable })
+	app.Get("/not-found", func(*fiber.Ctx) error { return fiber.ErrNotFound })
+	app.Get("/panic", func(*fiber.Ctx) error { return errors.New("boom") })
+
+	for _, path := range []string{"/ok", "/unavailable", "/not-found", "/panic"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// 503 dan error biasa dihitung buruk, 404 tidak
+	ratio, ok := tracker.series[0].ratio(start, time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, 0.5, ratio)
+}
@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// correlateCore lets the OTLP bridge attach log records to their trace.
+// The bridge only reads the trace from a context.Context field, while the
+// code base logs the "trace_id" and "span_id" strings of the active span,
+// so those are turned back into a span context before the entry is
+// written. Entries that already carry a context are left alone.
+type correlateCore struct {
+	zapcore.Core
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+func newCorrelateCore(core zapcore.Core) zapcore.Core {
+	return &correlateCore{Core: core}
+}
+
+func (c *correlateCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &correlateCore{Core: c.Core.With(fields), traceID: c.traceID, spanID: c.spanID}
+	clone.scan(fields)
+	return clone
+}
+
+func (c *correlateCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *correlateCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	ids := correlateCore{traceID: c.traceID, spanID: c.spanID}
+	for _, field := range fields {
+		if _, ok := field.Interface.(context.Context); ok {
+			return c.Core.Write(entry, fields)
+		}
+	}
+	ids.scan(fields)
+	if !ids.traceID.IsValid() {
+		return c.Core.Write(entry, fields)
+	}
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: ids.traceID,
+		SpanID:  ids.spanID,
+	}))
+	return c.Core.Write(entry, append(fields[:len(fields):len(fields)], zap.Any("context", ctx)))
+}
+
+// scan picks up the trace and span IDs among fields, ignoring values that
+// are not valid hex IDs.
+func (c *correlateCore) scan(fields []zapcore.Field) {
+	for _, field := range fields {
+		if field.Type != zapcore.StringType {
+			continue
+		}
+		switch field.Key {
+		case "trace_id":
+			if id, err := trace.TraceIDFromHex(field.String); err == nil {
+				c.traceID = id
+			}
+		case "span_id":
+			if id, err := trace.SpanIDFromHex(field.String); err == nil {
+				c.spanID = id
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package telemetry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fazamuttaqien/multifinance/pkg/common"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Modules that can be given their own log level. ModuleApp covers every
+// logger that is not handed out per module, including the global zap.L().
+const (
+	ModuleApp        = "app"
+	ModuleHandler    = "handler"
+	ModuleService    = "service"
+	ModuleRepository = "repository"
+	ModuleSQL        = "sql"
+	ModuleJob        = "job"
+	// ModuleHTTP covers outbound calls made through pkg/httpclient
+	ModuleHTTP = "http"
+)
+
+// Modules lists every module in the order they are reported.
+var Modules = []string{ModuleApp, ModuleHandler, ModuleService, ModuleRepository, ModuleSQL, ModuleJob, ModuleHTTP}
+
+// LogLevels holds the level of each module. Levels are atomic, so a change
+// made through Set applies at once to every logger already handed out for
+// that module. The set of modules is fixed at construction. Changes are not
+// persisted and only affect this instance.
+type LogLevels struct {
+	levels map[string]zap.AtomicLevel
+}
+
+// ParseLogLevels reads a comma separated list of module=level pairs such as
+// "handler=info,repository=warn,sql=error". Modules left out get fallback.
+func ParseLogLevels(spec string, fallback zapcore.Level) (map[string]zapcore.Level, error) {
+	levels := make(map[string]zapcore.Level, len(Modules))
+	for _, module := range Modules {
+		levels[module] = fallback
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		module, name, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q: expected module=level", pair)
+		}
+		module = strings.ToLower(strings.TrimSpace(module))
+		if !slices.Contains(Modules, module) {
+			return nil, fmt.Errorf("%q: %w", module, common.ErrUnknownLogModule)
+		}
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return nil, fmt.Errorf("%q: %w", name, common.ErrInvalidLogLevel)
+		}
+		levels[module] = level
+	}
+
+	return levels, nil
+}
+
+func NewLogLevels(levels map[string]zapcore.Level) *LogLevels {
+	l := &LogLevels{levels: make(map[string]zap.AtomicLevel, len(Modules))}
+	for _, module := range Modules {
+		l.levels[module] = zap.NewAtomicLevelAt(levels[module])
+	}
+	return l
+}
+
+// Levels returns the current level of every module.
+func (l *LogLevels) Levels() map[string]string {
+	levels := make(map[string]string, len(l.levels))
+	for module, level := range l.levels {
+		levels[module] = level.Level().String()
+	}
+	return levels
+}
+
+// SetLevel changes the level of module and returns the level it replaced.
+func (l *LogLevels) SetLevel(module, name string) (string, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return "", common.ErrInvalidLogLevel
+	}
+
+	atomic, ok := l.levels[strings.ToLower(module)]
+	if !ok {
+		return "", common.ErrUnknownLogModule
+	}
+	previous := atomic.Level().String()
+	atomic.SetLevel(level)
+	return previous, nil
+}
+
+// level returns the atomic level of module, falling back to ModuleApp.
+func (l *LogLevels) level(module string) zap.AtomicLevel {
+	if level, ok := l.levels[module]; ok {
+		return level
+	}
+	return l.levels[ModuleApp]
+}
+
+// levelCore drops entries below the level of its module before they reach
+// the wrapped core, which itself accepts every level.
+type levelCore struct {
+	zapcore.Core
+	level zap.AtomicLevel
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+func withLevel(level zap.AtomicLevel) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, level: level}
+	})
+}
@@ -0,0 +1,123 @@
+package telemetry
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces the value of every field whose key is redacted.
+const Redacted = "[REDACTED]"
+
+// redactCore masks fields by key before they reach the wrapped core, both
+// fields attached with With and fields passed on each entry. Keys and
+// configured names are split into words at dots, separators and camelCase
+// boundaries, and a key is masked when a name appears in it as a run of
+// whole words. So "nik" masks "NIK", "customer_nik" and "customer.nik" but
+// not "nikah", and "birth_date" masks "birthDate". Only top level fields
+// are inspected: a struct logged with zap.Any or zap.Object is written as
+// is.
+//
+// Each output core is wrapped on its own, because a core that delegates
+// Check hands the entry to the inner core and its Write never sees it.
+type redactCore struct {
+	zapcore.Core
+	names [][]string
+}
+
+// newRedactCore wraps core so the fields in names never reach it. It
+// returns core unchanged when names is empty.
+func newRedactCore(core zapcore.Core, names []string) zapcore.Core {
+	var words [][]string
+	for _, name := range names {
+		if w := keyWords(name); len(w) > 0 {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 {
+		return core
+	}
+	return &redactCore{Core: core, names: words}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), names: c.names}
+}
+
+func (c *redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redact(fields))
+}
+
+// redact returns fields with the sensitive ones masked, copying the slice
+// only when something has to change.
+func (c *redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, field := range fields {
+		if !c.sensitive(field.Key) {
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = zap.String(field.Key, Redacted)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// sensitive reports whether one of the configured names is a run of whole
+// words of key.
+func (c *redactCore) sensitive(key string) bool {
+	words := keyWords(key)
+	for _, name := range c.names {
+		for i := 0; i+len(name) <= len(words); i++ {
+			if slices.Equal(words[i:i+len(name)], name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keyWords splits key into lowercase words at dots, separators and
+// camelCase boundaries: "customer.birthDate" is [customer birth date] and
+// "NIKHash" is [nik hash].
+func keyWords(key string) []string {
+	var words []string
+	runes := []rune(key)
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			words = append(words, strings.ToLower(string(runes[start:end])))
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == '_' || r == '-' || r == ' ':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			// Batas kata: "birthDate" sebelum D, "NIKHash" sebelum H
+			if !unicode.IsUpper(prev) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				flush(i)
+				start = i
+			}
+		}
+	}
+	flush(len(runes))
+	return words
+}
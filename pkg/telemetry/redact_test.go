@@ -0,0 +1,113 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactCore_Keys(t *testing.T) {
+	names := []string{"nik", "password", "birth_date"}
+
+	cases := []struct {
+		key      string
+		redacted bool
+	}{
+		{"nik", true},
+		{"NIK", true},
+		{"customer_nik", true},
+		{"customer.nik", true},
+		{"customerNik", true},
+		{"customer-nik", true},
+		{"nik_hash", true},
+		{"password", true},
+		{"new_password", true},
+		{"passwordHash", true},
+		{"birth_date", true},
+		{"birthDate", true},
+		{"customer.birthDate", true},
+		{"BirthDate", true},
+		{"nikah", false},
+		{"unik", false},
+		{"customer_id", false},
+		{"birth_place", false},
+		{"date", false},
+		{"passwords", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			log := zap.New(newRedactCore(core, names))
+
+			log.Info("registered", zap.String(tc.key, "3201010101010001"))
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			value := entries[0].ContextMap()[tc.key]
+			if tc.redacted {
+				assert.Equal(t, Redacted, value)
+			} else {
+				assert.Equal(t, "3201010101010001", value)
+			}
+		})
+	}
+}
+
+func TestRedactCore_With(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newRedactCore(core, []string{"nik"})).
+		With(zap.String("customer_nik", "3201010101010001"), zap.Uint64("customer_id", 7))
+
+	log.Info("limit checked", zap.String("nik", "3201010101010002"))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, Redacted, fields["customer_nik"])
+	assert.Equal(t, Redacted, fields["nik"])
+	assert.Equal(t, uint64(7), fields["customer_id"])
+}
+
+func TestRedactCore_KeepsCallerFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newRedactCore(core, []string{"nik"}))
+
+	fields := []zap.Field{zap.String("nik", "3201010101010001")}
+	log.Info("registered", fields...)
+
+	// Slice milik pemanggil tidak ikut diubah
+	assert.Equal(t, "3201010101010001", fields[0].String)
+	assert.Equal(t, Redacted, logs.All()[0].ContextMap()["nik"])
+}
+
+func TestRedactCore_Level(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	log := zap.New(newRedactCore(core, []string{"nik"}))
+
+	log.Info("dropped", zap.String("nik", "3201010101010001"))
+	log.Warn("kept", zap.String("nik", "3201010101010001"))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0].Message)
+}
+
+func TestNewRedactCore_NoNames(t *testing.T) {
+	core, _ := observer.New(zapcore.DebugLevel)
+
+	assert.Same(t, core, newRedactCore(core, nil))
+	assert.Same(t, core, newRedactCore(core, []string{"", " _ "}))
+}
+
+func TestKeyWords(t *testing.T) {
+	assert.Equal(t, []string{"customer", "birth", "date"}, keyWords("customer.birthDate"))
+	assert.Equal(t, []string{"nik", "hash"}, keyWords("NIKHash"))
+	assert.Equal(t, []string{"customer", "nik"}, keyWords("customer__nik"))
+	assert.Equal(t, []string{"ktp2", "url"}, keyWords("ktp2Url"))
+	assert.Empty(t, keyWords("._-"))
+}
@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig limits repetitive logs. Within each Tick, the first
+// Initial entries with the same level and message are kept and after that
+// only every Thereafter-th one. Warnings and errors are never sampled. A
+// zero Initial turns sampling off.
+type SamplingConfig struct {
+	Tick       time.Duration
+	Initial    int
+	Thereafter int
+}
+
+// sampleCore sends debug and info entries through a sampler and every
+// other level straight to the wrapped core.
+type sampleCore struct {
+	zapcore.Core
+	sampled zapcore.Core
+}
+
+func newSampleCore(core zapcore.Core, cfg SamplingConfig) zapcore.Core {
+	if cfg.Initial <= 0 {
+		return core
+	}
+	if cfg.Tick <= 0 {
+		cfg.Tick = time.Second
+	}
+	return &sampleCore{
+		Core:    core,
+		sampled: zapcore.NewSamplerWithOptions(core, cfg.Tick, cfg.Initial, cfg.Thereafter),
+	}
+}
+
+func (c *sampleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sampleCore{Core: c.Core.With(fields), sampled: c.sampled.With(fields)}
+}
+
+func (c *sampleCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < zapcore.WarnLevel {
+		return c.sampled.Check(entry, checked)
+	}
+	return c.Core.Check(entry, checked)
+}
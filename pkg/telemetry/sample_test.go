@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSampleCore(t *testing.T) {
+	cfg := SamplingConfig{Tick: time.Minute, Initial: 2, Thereafter: 3}
+
+	t.Run("Samples Repeated Info", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := zap.New(newSampleCore(core, cfg))
+
+		for i := 1; i <= 10; i++ {
+			log.Info("polling", zap.Int("n", i))
+		}
+
+		// Dua pertama lolos, setelah itu hanya setiap entri ketiga
+		var kept []int64
+		for _, entry := range logs.All() {
+			kept = append(kept, entry.ContextMap()["n"].(int64))
+		}
+		assert.Equal(t, []int64{1, 2, 5, 8}, kept)
+	})
+
+	t.Run("Counts Messages Separately", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := zap.New(newSampleCore(core, cfg))
+
+		for i := 0; i < 3; i++ {
+			log.Debug("cache hit")
+			log.Debug("cache miss")
+		}
+
+		assert.Equal(t, 2, logs.FilterMessage("cache hit").Len())
+		assert.Equal(t, 2, logs.FilterMessage("cache miss").Len())
+	})
+
+	t.Run("Never Samples Warnings And Errors", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := zap.New(newSampleCore(core, cfg))
+
+		for i := 0; i < 10; i++ {
+			log.Warn("slow query")
+			log.Error("webhook failed")
+		}
+
+		assert.Equal(t, 10, logs.FilterMessage("slow query").Len())
+		assert.Equal(t, 10, logs.FilterMessage("webhook failed").Len())
+	})
+
+	t.Run("With Keeps Fields On Both Paths", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := zap.New(newSampleCore(core, cfg)).With(zap.String("tenant", "acme"))
+
+		log.Info("sampled path")
+		log.Error("direct path")
+
+		entries := logs.All()
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			assert.Equal(t, "acme", entry.ContextMap()["tenant"])
+		}
+	})
+
+	t.Run("Respects Core Level", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		log := zap.New(newSampleCore(core, cfg))
+
+		log.Debug("dropped")
+		log.Info("kept")
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "kept", logs.All()[0].Message)
+	})
+}
+
+func TestNewSampleCore_Disabled(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	sampled := newSampleCore(core, SamplingConfig{Initial: 0, Thereafter: 10})
+	assert.Same(t, core, sampled)
+
+	log := zap.New(sampled)
+	for i := 0; i < 5; i++ {
+		log.Info("polling")
+	}
+	assert.Equal(t, 5, logs.Len())
+}
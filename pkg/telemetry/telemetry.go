@@ -31,12 +31,21 @@ import (
 )
 
 type OpenTelemetry struct {
+	// Log is the ModuleApp logger, use Logger for the other modules.
 	Log            *zap.Logger
+	LogLevels      *LogLevels
 	TracerProvider *sdktrace.TracerProvider
 	LoggerProvider *sdklog.LoggerProvider
 	MeterProvider  *sdkmetric.MeterProvider
 	Meter          metric.Meter
 	Shutdown       func(context.Context) error
+
+	root *zap.Logger
+}
+
+// Logger returns the logger of module, filtered by that module's level.
+func (t *OpenTelemetry) Logger(module string) *zap.Logger {
+	return t.root.Named(module).WithOptions(withLevel(t.LogLevels.level(module)))
 }
 
 // New menginisialisasi semua komponen telemetri
@@ -85,8 +94,24 @@ func New(ctx context.Context, cfg *config.Config) (*OpenTelemetry, error) {
 		propagation.Baggage{},
 	))
 
+	// Level default dari LOG_LEVEL, LOG_LEVELS menimpa per modul
+	var fallback zapcore.Level
+	if err := fallback.UnmarshalText([]byte(cfg.LOG_LEVEL)); err != nil {
+		fallback = zapcore.InfoLevel // fallback
+	}
+	levels, err := ParseLogLevels(cfg.LOG_LEVELS, fallback)
+	if err != nil {
+		conn.Close()
+		tracerProvider.Shutdown(context.Background())
+		loggerProvider.Shutdown(context.Background())
+		meterProvider.Shutdown(context.Background())
+		return nil, fmt.Errorf("invalid LOG_LEVELS: %w", err)
+	}
+	logLevels := NewLogLevels(levels)
+
 	// Buat Zap logger yang terintegrasi
-	log := NewZapLogger(cfg, loggerProvider)
+	root := NewZapLogger(cfg, loggerProvider)
+	log := root.WithOptions(withLevel(logLevels.level(ModuleApp)))
 
 	// Daftarkan logger yang dibuat oleh New sebagai global
 	zap.ReplaceGlobals(log)
@@ -157,11 +182,13 @@ func New(ctx context.Context, cfg *config.Config) (*OpenTelemetry, error) {
 
 	return &OpenTelemetry{
 		Log:            log,
+		LogLevels:      logLevels,
 		TracerProvider: tracerProvider,
 		LoggerProvider: loggerProvider,
 		MeterProvider:  meterProvider,
 		Meter:          appMeter,
 		Shutdown:       shutdown,
+		root:           root,
 	}, nil
 }
 
@@ -257,13 +284,10 @@ func NewOTLPClient(endpoint string) (*grpc.ClientConn, error) {
 	return conn, nil
 }
 
-// Ditambahkan field context langsung dari provider
+// Ditambahkan field context langsung dari provider. Logger yang dihasilkan
+// menerima semua level, penyaringan dilakukan per modul oleh LogLevels.
+// LOG_OTLP_LEVEL hanya membatasi log yang dikirim via OTLP.
 func NewZapLogger(cfg *config.Config, loggerProvider *sdklog.LoggerProvider) *zap.Logger {
-	var level zapcore.Level
-	if err := level.UnmarshalText([]byte(cfg.LOG_LEVEL)); err != nil {
-		level = zapcore.InfoLevel // fallback
-	}
-
 	var encoderConfig zapcore.EncoderConfig
 	if cfg.DEVELOPMENT_MODE {
 		encoderConfig = zap.NewDevelopmentEncoderConfig()
@@ -291,18 +315,45 @@ func NewZapLogger(cfg *config.Config, loggerProvider *sdklog.LoggerProvider) *za
 		encoder = zapcore.NewJSONEncoder(encoderConfig)
 	}
 
+	// Field sensitif (NIK, password, tanggal lahir) disamarkan di setiap output
+	redactFields := cfg.LogRedactFields()
+
+	// Stdout dan OTLP bisa dinyalakan terpisah, misalnya stdout dimatikan
+	// di production karena log sudah dicari lewat backend observability
+	var cores []zapcore.Core
+
 	// Core 1: Output ke stdout
-	stdoutSink := zapcore.AddSync(os.Stdout)
-	stdoutCore := zapcore.NewCore(encoder, stdoutSink, level)
+	if cfg.LOG_STDOUT {
+		stdoutSink := zapcore.AddSync(os.Stdout)
+		cores = append(cores, newRedactCore(zapcore.NewCore(encoder, stdoutSink, zapcore.DebugLevel), redactFields))
+	}
 
-	// Core 2: Kirim log via OpenTelemetry LoggerProvider
-	otelCore := otelzap.NewCore(
-		cfg.SERVICE_NAME, // Atribut nama layanan bisa ditambahkan otomatis oleh resource provider juga
-		otelzap.WithLoggerProvider(loggerProvider), // Mengarahkan log ke pipeline OTel
-	)
+	// Core 2: Kirim log via OpenTelemetry LoggerProvider, dikaitkan ke trace
+	// lewat field trace_id/span_id supaya bisa dicari bersama span-nya
+	if cfg.LOG_OTLP && loggerProvider != nil {
+		otlpLevel, err := zapcore.ParseLevel(cfg.LOG_OTLP_LEVEL)
+		if err != nil {
+			otlpLevel = zapcore.InfoLevel // fallback
+		}
+		var otelCore zapcore.Core = newCorrelateCore(otelzap.NewCore(
+			cfg.SERVICE_NAME, // Atribut nama layanan bisa ditambahkan otomatis oleh resource provider juga
+			otelzap.WithLoggerProvider(loggerProvider), // Mengarahkan log ke pipeline OTel
+		))
+		if leveled, err := zapcore.NewIncreaseLevelCore(otelCore, otlpLevel); err == nil {
+			otelCore = leveled
+		}
+		cores = append(cores, newRedactCore(otelCore, redactFields))
+	}
+
+	// Gabungkan core yang aktif: log bisa ke stdout, OTLP, atau keduanya
+	core := zapcore.NewTee(cores...)
 
-	// Gabungkan kedua core: log akan ke stdout DAN dikirim via OTLP
-	core := zapcore.NewTee(stdoutCore, otelCore)
+	// Log debug/info yang berulang disampling agar tidak membanjiri output saat beban tinggi
+	core = newSampleCore(core, SamplingConfig{
+		Tick:       cfg.LOG_SAMPLING_TICK,
+		Initial:    cfg.LOG_SAMPLING_INITIAL,
+		Thereafter: cfg.LOG_SAMPLING_THEREAFTER,
+	})
 
 	// Tambahkan Caller dan Stacktrace
 	opts := []zap.Option{
@@ -0,0 +1,185 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/fazamuttaqien/multifinance/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// memoryExporter keeps exported log records in memory instead of sending
+// them to a collector.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+// exported returns the records whose body is message.
+func (e *memoryExporter) exported(message string) []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []sdklog.Record
+	for _, r := range e.records {
+		if r.Body().AsString() == message {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func testLogConfig() *config.Config {
+	return &config.Config{
+		SERVICE_NAME:   "multifinance-test",
+		LOG_STDOUT:     true,
+		LOG_OTLP:       true,
+		LOG_OTLP_LEVEL: "info",
+	}
+}
+
+// newTestLogger builds the logger of cfg over an in-memory exporter and
+// returns a function that stops capturing stdout and returns what was
+// written to it.
+func newTestLogger(t *testing.T, cfg *config.Config) (*zap.Logger, *memoryExporter, func() string) {
+	t.Helper()
+
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	// Core stdout memegang os.Stdout saat logger dibuat, jadi ditukar dulu
+	read, write, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = write
+	log := NewZapLogger(cfg, provider)
+	os.Stdout = stdout
+
+	output := make(chan string, 1)
+	go func() {
+		data, _ := io.ReadAll(read)
+		output <- string(data)
+	}()
+
+	stop := func() string {
+		log.Sync()
+		write.Close()
+		return <-output
+	}
+	t.Cleanup(func() { write.Close() })
+	return log, exporter, stop
+}
+
+func TestZapLogger_OTLPRecordCarriesTrace(t *testing.T) {
+	log, exporter, stop := newTestLogger(t, testLogConfig())
+	defer stop()
+
+	tracer := sdktrace.NewTracerProvider().Tracer("test-log-bridge")
+	ctx, span := tracer.Start(context.Background(), "service.CheckLimit")
+	defer span.End()
+	sc := trace.SpanContextFromContext(ctx)
+
+	// Pola yang dipakai repository dan service: ID trace sebagai string
+	log.Info("Limit checked",
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	)
+	// ID yang dipasang lewat With ikut terbawa ke setiap entri
+	log.With(zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String())).
+		Info("Limit cached")
+	log.Info("Job tick")
+
+	for _, message := range []string{"Limit checked", "Limit cached"} {
+		records := exporter.exported(message)
+		require.Len(t, records, 1, message)
+		assert.Equal(t, sc.TraceID(), records[0].TraceID(), message)
+		assert.Equal(t, sc.SpanID(), records[0].SpanID(), message)
+		assert.Equal(t, "info", records[0].SeverityText(), message)
+	}
+
+	untraced := exporter.exported("Job tick")
+	require.Len(t, untraced, 1)
+	assert.False(t, untraced[0].TraceID().IsValid())
+}
+
+func TestZapLogger_InvalidTraceIDIsIgnored(t *testing.T) {
+	log, exporter, stop := newTestLogger(t, testLogConfig())
+	defer stop()
+
+	log.Info("Bad trace", zap.String("trace_id", "not-a-trace-id"))
+
+	records := exporter.exported("Bad trace")
+	require.Len(t, records, 1)
+	assert.False(t, records[0].TraceID().IsValid())
+}
+
+func TestZapLogger_OutputsConfiguredSeparately(t *testing.T) {
+	cases := []struct {
+		name   string
+		stdout bool
+		otlp   bool
+	}{
+		{"Stdout And OTLP", true, true},
+		{"OTLP Only", false, true},
+		{"Stdout Only", true, false},
+		{"Neither", false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := testLogConfig()
+			cfg.LOG_STDOUT, cfg.LOG_OTLP = tc.stdout, tc.otlp
+			log, exporter, stop := newTestLogger(t, cfg)
+
+			log.Info("Customer registered")
+			output := stop()
+
+			if tc.stdout {
+				assert.Contains(t, output, "Customer registered")
+			} else {
+				assert.Empty(t, output)
+			}
+			if tc.otlp {
+				assert.Len(t, exporter.exported("Customer registered"), 1)
+			} else {
+				assert.Empty(t, exporter.exported("Customer registered"))
+			}
+		})
+	}
+}
+
+func TestZapLogger_OTLPLevel(t *testing.T) {
+	cfg := testLogConfig()
+	cfg.LOG_OTLP_LEVEL = "warn"
+	log, exporter, stop := newTestLogger(t, cfg)
+
+	log.Info("Cache refreshed")
+	log.Warn("Slow query")
+	output := stop()
+
+	// LOG_OTLP_LEVEL hanya membatasi OTLP, stdout tetap menerima info
+	assert.Empty(t, exporter.exported("Cache refreshed"))
+	assert.Len(t, exporter.exported("Slow query"), 1)
+	assert.Contains(t, output, "Cache refreshed")
+	assert.Contains(t, output, "Slow query")
+}
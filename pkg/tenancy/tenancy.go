@@ -0,0 +1,215 @@
+// Package tenancy lets one deployment serve several finance companies under
+// their own brand. A tenant is resolved once per request, from the hostname
+// or the X-Tenant-ID header, and travels in the context from there: the
+// database routes the request's statements to the tenant's schema, Redis
+// keys and Cloudinary folders are prefixed with its ID, and documents carry
+// its branding.
+//
+// The default tenant is the deployment as configured without a tenants
+// file. Its keys and folders are not prefixed, so existing data stays where
+// it is when more tenants are added.
+package tenancy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultID is the ID of the default tenant.
+const DefaultID = "default"
+
+// Header selects the tenant on hosts that are not mapped to one, e.g. a
+// shared partner API host.
+const Header = "X-Tenant-ID"
+
+var idPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,31}$`)
+
+// Branding is what a tenant shows to its customers.
+type Branding struct {
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address"`
+	Contact     string `json:"contact"`
+	// Color is a hex color such as #1F4E79
+	Color   string `json:"color"`
+	LogoURL string `json:"logo_url"`
+}
+
+// Tenant is one white-label finance company.
+type Tenant struct {
+	ID    string   `json:"id"`
+	Hosts []string `json:"hosts"`
+	// Database is the MySQL schema holding the tenant's data
+	Database         string   `json:"database"`
+	CloudinaryFolder string   `json:"cloudinary_folder"`
+	Branding         Branding `json:"branding"`
+}
+
+// Registry holds the tenants a deployment serves.
+type Registry struct {
+	tenants []*Tenant
+	byID    map[string]*Tenant
+	byHost  map[string]*Tenant
+}
+
+// NewRegistry returns a registry of def and others. def is given the ID
+// DefaultID; IDs, hosts and databases must be unique.
+func NewRegistry(def Tenant, others ...Tenant) (*Registry, error) {
+	def.ID = DefaultID
+
+	r := &Registry{
+		byID:   make(map[string]*Tenant, len(others)+1),
+		byHost: make(map[string]*Tenant),
+	}
+	databases := make(map[string]string, len(others)+1)
+
+	var errs []error
+	for _, t := range append([]Tenant{def}, others...) {
+		switch {
+		case !idPattern.MatchString(t.ID):
+			errs = append(errs, fmt.Errorf("tenant %q: id must be lowercase letters, digits and dashes", t.ID))
+			continue
+		case r.byID[t.ID] != nil:
+			errs = append(errs, fmt.Errorf("tenant %q: defined twice", t.ID))
+			continue
+		}
+
+		// Skema sama berarti data bercampur, jadi setiap tenant wajib punya skema sendiri
+		if t.Database == "" {
+			errs = append(errs, fmt.Errorf("tenant %q: database is empty", t.ID))
+		} else if owner, ok := databases[t.Database]; ok {
+			errs = append(errs, fmt.Errorf("tenant %q: database %s already belongs to tenant %q", t.ID, t.Database, owner))
+		} else {
+			databases[t.Database] = t.ID
+		}
+
+		t.Hosts = slices.Clone(t.Hosts)
+		for i, host := range t.Hosts {
+			host = normalizeHost(host)
+			t.Hosts[i] = host
+			if owner, ok := r.byHost[host]; ok {
+				errs = append(errs, fmt.Errorf("tenant %q: host %s already belongs to tenant %q", t.ID, host, owner.ID))
+				continue
+			}
+			r.byHost[host] = &t
+		}
+
+		r.tenants = append(r.tenants, &t)
+		r.byID[t.ID] = &t
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Load reads the tenants other than def from the JSON array in path. An
+// empty path serves def alone.
+func Load(path string, def Tenant) (*Registry, error) {
+	if path == "" {
+		return NewRegistry(def)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var others []Tenant
+	if err := json.Unmarshal(data, &others); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return NewRegistry(def, others...)
+}
+
+// Default returns the default tenant.
+func (r *Registry) Default() *Tenant {
+	return r.byID[DefaultID]
+}
+
+// All returns every tenant, the default one first.
+func (r *Registry) All() []*Tenant {
+	return r.tenants
+}
+
+// Get returns the tenant with id.
+func (r *Registry) Get(id string) (*Tenant, bool) {
+	t, ok := r.byID[id]
+	return t, ok
+}
+
+// ByHost returns the tenant host is mapped to. The port is ignored.
+func (r *Registry) ByHost(host string) (*Tenant, bool) {
+	t, ok := r.byHost[normalizeHost(host)]
+	return t, ok
+}
+
+// Each runs fn once per tenant with the tenant in ctx, e.g. for background
+// jobs. A failing tenant does not stop the others; their errors are joined.
+func (r *Registry) Each(ctx context.Context, fn func(ctx context.Context) error) error {
+	var errs []error
+	for _, t := range r.tenants {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := fn(WithTenant(ctx, t)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", t.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+type tenantKey struct{}
+
+// WithTenant records the tenant a request or job works for.
+func WithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// FromContext returns the tenant recorded by WithTenant.
+func FromContext(ctx context.Context) (*Tenant, bool) {
+	t, ok := ctx.Value(tenantKey{}).(*Tenant)
+	return t, ok && t != nil
+}
+
+// ID returns the ID of the tenant in ctx, DefaultID when there is none.
+func ID(ctx context.Context) string {
+	if t, ok := FromContext(ctx); ok {
+		return t.ID
+	}
+	return DefaultID
+}
+
+// Key scopes a Redis key to the tenant in ctx. Keys of the default tenant
+// are returned unchanged.
+func Key(ctx context.Context, key string) string {
+	id := ID(ctx)
+	if id == DefaultID {
+		return key
+	}
+	return "tenant:" + id + ":" + key
+}
+
+// Folder places a Cloudinary folder under the folder of the tenant in ctx,
+// if it has one.
+func Folder(ctx context.Context, folder string) string {
+	t, ok := FromContext(ctx)
+	if !ok || t.CloudinaryFolder == "" {
+		return folder
+	}
+	return strings.TrimSuffix(t.CloudinaryFolder, "/") + "/" + folder
+}
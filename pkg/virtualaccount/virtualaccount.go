@@ -0,0 +1,79 @@
+// Package virtualaccount opens bank virtual accounts that customers pay their
+// installments into. Each bank integration implements Provider; the stub
+// provider derives numbers locally for development and testing.
+package virtualaccount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// NumberLength is the length of the virtual account numbers issued by the
+// stub, matching the 16 digits most Indonesian banks use.
+const NumberLength = 16
+
+var (
+	ErrUnknownProvider = errors.New("unknown virtual account provider")
+	ErrInvalidPrefix   = errors.New("virtual account prefix must be 1-8 digits")
+)
+
+// Request describes the contract a virtual account is opened for.
+// TransactionID is stable and unique, so providers may derive the number
+// from it.
+type Request struct {
+	TransactionID  uint64
+	ContractNumber string
+	CustomerName   string
+	Amount         decimal.Decimal
+	Currency       string
+}
+
+type Provider interface {
+	Bank() string
+	Open(ctx context.Context, req Request) (string, error)
+}
+
+// New returns the provider registered under name. Bank integrations are
+// added here as they are contracted.
+func New(name, bank, prefix string) (Provider, error) {
+	switch name {
+	case "stub":
+		return NewStub(bank, prefix)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+}
+
+type stub struct {
+	bank   string
+	prefix string
+}
+
+// NewStub issues numbers made of prefix, usually the company code assigned
+// by the bank, followed by the zero padded transaction ID.
+func NewStub(bank, prefix string) (Provider, error) {
+	if len(prefix) == 0 || len(prefix) > 8 {
+		return nil, ErrInvalidPrefix
+	}
+	for _, r := range prefix {
+		if r < '0' || r > '9' {
+			return nil, ErrInvalidPrefix
+		}
+	}
+	return &stub{bank: bank, prefix: prefix}, nil
+}
+
+func (s *stub) Bank() string {
+	return s.bank
+}
+
+func (s *stub) Open(_ context.Context, req Request) (string, error) {
+	number := fmt.Sprintf("%s%0*d", s.prefix, NumberLength-len(s.prefix), req.TransactionID)
+	if len(number) > NumberLength {
+		return "", fmt.Errorf("transaction %d does not fit a %d digit virtual account", req.TransactionID, NumberLength)
+	}
+	return number, nil
+}
@@ -1,25 +1,199 @@
 package presenter
 
 import (
+	"context"
+	"net/http"
+	"time"
+
 	"github.com/fazamuttaqien/multifinance/config"
+	"github.com/fazamuttaqien/multifinance/internal/domain"
 	adminhandler "github.com/fazamuttaqien/multifinance/internal/handler/admin"
+	amlhandler "github.com/fazamuttaqien/multifinance/internal/handler/aml"
+	attachmenthandler "github.com/fazamuttaqien/multifinance/internal/handler/attachment"
+	batchhandler "github.com/fazamuttaqien/multifinance/internal/handler/batch"
+	blacklisthandler "github.com/fazamuttaqien/multifinance/internal/handler/blacklist"
+	calendarhandler "github.com/fazamuttaqien/multifinance/internal/handler/calendar"
+	closurehandler "github.com/fazamuttaqien/multifinance/internal/handler/closure"
+	collectionhandler "github.com/fazamuttaqien/multifinance/internal/handler/collection"
+	communicationhandler "github.com/fazamuttaqien/multifinance/internal/handler/communication"
+	concentrationhandler "github.com/fazamuttaqien/multifinance/internal/handler/concentration"
+	contracthandler "github.com/fazamuttaqien/multifinance/internal/handler/contract"
+	customernotehandler "github.com/fazamuttaqien/multifinance/internal/handler/customernote"
+	dashboardhandler "github.com/fazamuttaqien/multifinance/internal/handler/dashboard"
+	deliveryhandler "github.com/fazamuttaqien/multifinance/internal/handler/delivery"
+	directdebithandler "github.com/fazamuttaqien/multifinance/internal/handler/directdebit"
+	dormancyhandler "github.com/fazamuttaqien/multifinance/internal/handler/dormancy"
+	duedatehandler "github.com/fazamuttaqien/multifinance/internal/handler/duedate"
+	duplicatehandler "github.com/fazamuttaqien/multifinance/internal/handler/duplicate"
+	emailhandler "github.com/fazamuttaqien/multifinance/internal/handler/email"
+	exporthandler "github.com/fazamuttaqien/multifinance/internal/handler/export"
+	featureflaghandler "github.com/fazamuttaqien/multifinance/internal/handler/featureflag"
+	feeschedulehandler "github.com/fazamuttaqien/multifinance/internal/handler/feeschedule"
+	fxratehandler "github.com/fazamuttaqien/multifinance/internal/handler/fxrate"
+	graphqlhandler "github.com/fazamuttaqien/multifinance/internal/handler/graphql"
+	impersonationhandler "github.com/fazamuttaqien/multifinance/internal/handler/impersonation"
+	incomehandler "github.com/fazamuttaqien/multifinance/internal/handler/income"
+	loglevelhandler "github.com/fazamuttaqien/multifinance/internal/handler/loglevel"
+	maintenancehandler "github.com/fazamuttaqien/multifinance/internal/handler/maintenance"
+	mobilehandler "github.com/fazamuttaqien/multifinance/internal/handler/mobile"
+	notificationhandler "github.com/fazamuttaqien/multifinance/internal/handler/notification"
+	onboardinghandler "github.com/fazamuttaqien/multifinance/internal/handler/onboarding"
+	otphandler "github.com/fazamuttaqien/multifinance/internal/handler/otp"
 	partnerhandler "github.com/fazamuttaqien/multifinance/internal/handler/partner"
+	partnerdebughandler "github.com/fazamuttaqien/multifinance/internal/handler/partnerdebug"
+	paymenthandler "github.com/fazamuttaqien/multifinance/internal/handler/payment"
+	portalhandler "github.com/fazamuttaqien/multifinance/internal/handler/portal"
 	privatehandler "github.com/fazamuttaqien/multifinance/internal/handler/private"
 	profilehandler "github.com/fazamuttaqien/multifinance/internal/handler/profile"
+	promotionhandler "github.com/fazamuttaqien/multifinance/internal/handler/promotion"
+	quotahandler "github.com/fazamuttaqien/multifinance/internal/handler/quota"
+	recommendationhandler "github.com/fazamuttaqien/multifinance/internal/handler/recommendation"
+	referralhandler "github.com/fazamuttaqien/multifinance/internal/handler/referral"
+	regionhandler "github.com/fazamuttaqien/multifinance/internal/handler/region"
+	reporthandler "github.com/fazamuttaqien/multifinance/internal/handler/report"
+	restrictionhandler "github.com/fazamuttaqien/multifinance/internal/handler/restriction"
+	restructuringhandler "github.com/fazamuttaqien/multifinance/internal/handler/restructuring"
+	risktierhandler "github.com/fazamuttaqien/multifinance/internal/handler/risktier"
+	salarychangehandler "github.com/fazamuttaqien/multifinance/internal/handler/salarychange"
+	statementhandler "github.com/fazamuttaqien/multifinance/internal/handler/statement"
+	tenanthandler "github.com/fazamuttaqien/multifinance/internal/handler/tenant"
+	tenorhandler "github.com/fazamuttaqien/multifinance/internal/handler/tenor"
+	timelinehandler "github.com/fazamuttaqien/multifinance/internal/handler/timeline"
+	writeoffhandler "github.com/fazamuttaqien/multifinance/internal/handler/writeoff"
+	"github.com/fazamuttaqien/multifinance/internal/model"
+	amlrepo "github.com/fazamuttaqien/multifinance/internal/repository/aml"
+	apiusagerepo "github.com/fazamuttaqien/multifinance/internal/repository/apiusage"
+	attachmentrepo "github.com/fazamuttaqien/multifinance/internal/repository/attachment"
+	batchrepo "github.com/fazamuttaqien/multifinance/internal/repository/batch"
+	blacklistrepo "github.com/fazamuttaqien/multifinance/internal/repository/blacklist"
+	calendarrepo "github.com/fazamuttaqien/multifinance/internal/repository/calendar"
+	closurerepo "github.com/fazamuttaqien/multifinance/internal/repository/closure"
+	collectionrepo "github.com/fazamuttaqien/multifinance/internal/repository/collection"
+	communicationrepo "github.com/fazamuttaqien/multifinance/internal/repository/communication"
+	concentrationrepo "github.com/fazamuttaqien/multifinance/internal/repository/concentration"
 	customerrepo "github.com/fazamuttaqien/multifinance/internal/repository/customer"
+	customereventrepo "github.com/fazamuttaqien/multifinance/internal/repository/customerevent"
+	customernoterepo "github.com/fazamuttaqien/multifinance/internal/repository/customernote"
+	deliveryrepo "github.com/fazamuttaqien/multifinance/internal/repository/delivery"
+	directdebitrepo "github.com/fazamuttaqien/multifinance/internal/repository/directdebit"
+	dormancyrepo "github.com/fazamuttaqien/multifinance/internal/repository/dormancy"
+	duedaterepo "github.com/fazamuttaqien/multifinance/internal/repository/duedate"
+	duplicaterepo "github.com/fazamuttaqien/multifinance/internal/repository/duplicate"
+	emailrepo "github.com/fazamuttaqien/multifinance/internal/repository/email"
+	exposurerepo "github.com/fazamuttaqien/multifinance/internal/repository/exposure"
+	featureflagrepo "github.com/fazamuttaqien/multifinance/internal/repository/featureflag"
+	feeschedulerepo "github.com/fazamuttaqien/multifinance/internal/repository/feeschedule"
+	fxraterepo "github.com/fazamuttaqien/multifinance/internal/repository/fxrate"
+	impersonationrepo "github.com/fazamuttaqien/multifinance/internal/repository/impersonation"
+	incomerepo "github.com/fazamuttaqien/multifinance/internal/repository/income"
+	ledgerrepo "github.com/fazamuttaqien/multifinance/internal/repository/ledger"
 	limitrepo "github.com/fazamuttaqien/multifinance/internal/repository/limit"
+	maintenancerepo "github.com/fazamuttaqien/multifinance/internal/repository/maintenance"
+	mediarepo "github.com/fazamuttaqien/multifinance/internal/repository/media"
+	monthlystatementrepo "github.com/fazamuttaqien/multifinance/internal/repository/monthlystatement"
+	noncerepo "github.com/fazamuttaqien/multifinance/internal/repository/nonce"
+	notificationrepo "github.com/fazamuttaqien/multifinance/internal/repository/notification"
+	otprepo "github.com/fazamuttaqien/multifinance/internal/repository/otp"
+	partnerrepo "github.com/fazamuttaqien/multifinance/internal/repository/partner"
+	partnerdebugrepo "github.com/fazamuttaqien/multifinance/internal/repository/partnerdebug"
+	paymentrepo "github.com/fazamuttaqien/multifinance/internal/repository/payment"
+	pendingexpiryrepo "github.com/fazamuttaqien/multifinance/internal/repository/pendingexpiry"
+	promotionrepo "github.com/fazamuttaqien/multifinance/internal/repository/promotion"
+	quotarepo "github.com/fazamuttaqien/multifinance/internal/repository/quota"
+	quotacounterrepo "github.com/fazamuttaqien/multifinance/internal/repository/quotacounter"
+	referralrepo "github.com/fazamuttaqien/multifinance/internal/repository/referral"
+	regionrepo "github.com/fazamuttaqien/multifinance/internal/repository/region"
+	reminderrepo "github.com/fazamuttaqien/multifinance/internal/repository/reminder"
+	reportrepo "github.com/fazamuttaqien/multifinance/internal/repository/report"
+	restrictionrepo "github.com/fazamuttaqien/multifinance/internal/repository/restriction"
+	restructuringrepo "github.com/fazamuttaqien/multifinance/internal/repository/restructuring"
+	risktierrepo "github.com/fazamuttaqien/multifinance/internal/repository/risktier"
+	salarychangerepo "github.com/fazamuttaqien/multifinance/internal/repository/salarychange"
+	statementrepo "github.com/fazamuttaqien/multifinance/internal/repository/statement"
 	tenorrepo "github.com/fazamuttaqien/multifinance/internal/repository/tenor"
 	transactionrepo "github.com/fazamuttaqien/multifinance/internal/repository/transaction"
+	virtualaccountrepo "github.com/fazamuttaqien/multifinance/internal/repository/virtualaccount"
+	writeoffrepo "github.com/fazamuttaqien/multifinance/internal/repository/writeoff"
+	"github.com/fazamuttaqien/multifinance/internal/service"
 	adminsrv "github.com/fazamuttaqien/multifinance/internal/service/admin"
+	amlsrv "github.com/fazamuttaqien/multifinance/internal/service/aml"
+	attachmentsrv "github.com/fazamuttaqien/multifinance/internal/service/attachment"
+	batchsrv "github.com/fazamuttaqien/multifinance/internal/service/batch"
+	blacklistsrv "github.com/fazamuttaqien/multifinance/internal/service/blacklist"
+	calendarsrv "github.com/fazamuttaqien/multifinance/internal/service/calendar"
+	closuresrv "github.com/fazamuttaqien/multifinance/internal/service/closure"
 	cloudinarysrv "github.com/fazamuttaqien/multifinance/internal/service/cloudinary"
+	collectionsrv "github.com/fazamuttaqien/multifinance/internal/service/collection"
+	communicationsrv "github.com/fazamuttaqien/multifinance/internal/service/communication"
+	concentrationsrv "github.com/fazamuttaqien/multifinance/internal/service/concentration"
+	contractsrv "github.com/fazamuttaqien/multifinance/internal/service/contract"
+	customernotesrv "github.com/fazamuttaqien/multifinance/internal/service/customernote"
+	dashboardsrv "github.com/fazamuttaqien/multifinance/internal/service/dashboard"
+	deliverysrv "github.com/fazamuttaqien/multifinance/internal/service/delivery"
+	directdebitsrv "github.com/fazamuttaqien/multifinance/internal/service/directdebit"
+	dormancysrv "github.com/fazamuttaqien/multifinance/internal/service/dormancy"
+	duedatesrv "github.com/fazamuttaqien/multifinance/internal/service/duedate"
+	duplicatesrv "github.com/fazamuttaqien/multifinance/internal/service/duplicate"
+	emailsrv "github.com/fazamuttaqien/multifinance/internal/service/email"
+	exportsrv "github.com/fazamuttaqien/multifinance/internal/service/export"
+	exposuresrv "github.com/fazamuttaqien/multifinance/internal/service/exposure"
+	featureflagsrv "github.com/fazamuttaqien/multifinance/internal/service/featureflag"
+	feeschedulesrv "github.com/fazamuttaqien/multifinance/internal/service/feeschedule"
+	fxratesrv "github.com/fazamuttaqien/multifinance/internal/service/fxrate"
+	impersonationsrv "github.com/fazamuttaqien/multifinance/internal/service/impersonation"
+	incomesrv "github.com/fazamuttaqien/multifinance/internal/service/income"
+	maintenancesrv "github.com/fazamuttaqien/multifinance/internal/service/maintenance"
+	mediasrv "github.com/fazamuttaqien/multifinance/internal/service/media"
+	mobilesrv "github.com/fazamuttaqien/multifinance/internal/service/mobile"
+	notifiersrv "github.com/fazamuttaqien/multifinance/internal/service/notifier"
+	onboardingsrv "github.com/fazamuttaqien/multifinance/internal/service/onboarding"
+	otpsrv "github.com/fazamuttaqien/multifinance/internal/service/otp"
 	partnersrv "github.com/fazamuttaqien/multifinance/internal/service/partner"
+	partnerdebugsrv "github.com/fazamuttaqien/multifinance/internal/service/partnerdebug"
+	paymentsrv "github.com/fazamuttaqien/multifinance/internal/service/payment"
+	pendingexpirysrv "github.com/fazamuttaqien/multifinance/internal/service/pendingexpiry"
+	portalsrv "github.com/fazamuttaqien/multifinance/internal/service/portal"
+	preferencesrv "github.com/fazamuttaqien/multifinance/internal/service/preference"
 	privatesrv "github.com/fazamuttaqien/multifinance/internal/service/private"
 	profilesrv "github.com/fazamuttaqien/multifinance/internal/service/profile"
+	promotionsrv "github.com/fazamuttaqien/multifinance/internal/service/promotion"
+	pushsrv "github.com/fazamuttaqien/multifinance/internal/service/push"
+	quotasrv "github.com/fazamuttaqien/multifinance/internal/service/quota"
+	recommendationsrv "github.com/fazamuttaqien/multifinance/internal/service/recommendation"
+	referralsrv "github.com/fazamuttaqien/multifinance/internal/service/referral"
+	regionsrv "github.com/fazamuttaqien/multifinance/internal/service/region"
+	remindersrv "github.com/fazamuttaqien/multifinance/internal/service/reminder"
+	reportsrv "github.com/fazamuttaqien/multifinance/internal/service/report"
+	restrictionsrv "github.com/fazamuttaqien/multifinance/internal/service/restriction"
+	restructuringsrv "github.com/fazamuttaqien/multifinance/internal/service/restructuring"
+	risktiersrv "github.com/fazamuttaqien/multifinance/internal/service/risktier"
+	salarychangesrv "github.com/fazamuttaqien/multifinance/internal/service/salarychange"
+	signaturesrv "github.com/fazamuttaqien/multifinance/internal/service/signature"
+	statementsrv "github.com/fazamuttaqien/multifinance/internal/service/statement"
+	tenorsrv "github.com/fazamuttaqien/multifinance/internal/service/tenor"
+	timelinesrv "github.com/fazamuttaqien/multifinance/internal/service/timeline"
+	virtualaccountsrv "github.com/fazamuttaqien/multifinance/internal/service/virtualaccount"
+	writeoffsrv "github.com/fazamuttaqien/multifinance/internal/service/writeoff"
+	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/businesshours"
+	"github.com/fazamuttaqien/multifinance/pkg/cache"
+	"github.com/fazamuttaqien/multifinance/pkg/httpclient"
+	"github.com/fazamuttaqien/multifinance/pkg/job"
+	"github.com/fazamuttaqien/multifinance/pkg/mailer"
+	"github.com/fazamuttaqien/multifinance/pkg/otp"
+	"github.com/fazamuttaqien/multifinance/pkg/paymentgateway"
+	"github.com/fazamuttaqien/multifinance/pkg/pdf"
+	"github.com/fazamuttaqien/multifinance/pkg/push"
+	"github.com/fazamuttaqien/multifinance/pkg/virtualaccount"
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
 
 	"github.com/fazamuttaqien/multifinance/pkg/telemetry"
 
 	"github.com/cloudinary/cloudinary-go/v2"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -28,6 +202,68 @@ type Presenter struct {
 	PartnerPresenter *partnerhandler.PartnerHandler
 	ProfilePresenter *profilehandler.ProfileHandler
 	PrivatePresenter *privatehandler.PrivateHandler
+
+	OnboardingPresenter     *onboardinghandler.OnboardingHandler
+	DeliveryPresenter       *deliveryhandler.DeliveryHandler
+	SalaryChangePresenter   *salarychangehandler.SalaryChangeHandler
+	IncomePresenter         *incomehandler.IncomeVerificationHandler
+	RiskTierPresenter       *risktierhandler.RiskTierHandler
+	RecommendationPresenter *recommendationhandler.RecommendationHandler
+	BlacklistPresenter      *blacklisthandler.BlacklistHandler
+	DuplicatePresenter      *duplicatehandler.DuplicateHandler
+	RestructuringPresenter  *restructuringhandler.RestructuringHandler
+	FxRatePresenter         *fxratehandler.FxRateHandler
+	ReportPresenter         *reporthandler.ReportHandler
+	CommunicationPresenter  *communicationhandler.CommunicationHandler
+	FeatureFlagPresenter    *featureflaghandler.FeatureFlagHandler
+	FeeSchedulePresenter    *feeschedulehandler.FeeScheduleHandler
+	PaymentPresenter        *paymenthandler.PaymentHandler
+	DirectDebitPresenter    *directdebithandler.DirectDebitHandler
+	PromotionPresenter      *promotionhandler.PromotionHandler
+	ReferralPresenter       *referralhandler.ReferralHandler
+	OTPPresenter            *otphandler.OTPHandler
+	ImpersonationPresenter  *impersonationhandler.ImpersonationHandler
+	MaintenancePresenter    *maintenancehandler.MaintenanceHandler
+	QuotaPresenter          *quotahandler.QuotaHandler
+	BatchPresenter          *batchhandler.BatchHandler
+	TenorPresenter          *tenorhandler.TenorHandler
+	StatementPresenter      *statementhandler.StatementHandler
+	CalendarPresenter       *calendarhandler.CalendarHandler
+	MobilePresenter         *mobilehandler.MobileHandler
+	DueDatePresenter        *duedatehandler.DueDateHandler
+	EmailPresenter          *emailhandler.EmailHandler
+	NotificationPresenter   *notificationhandler.NotificationHandler
+	CustomerNotePresenter   *customernotehandler.CustomerNoteHandler
+	AttachmentPresenter     *attachmenthandler.AttachmentHandler
+	RegionPresenter         *regionhandler.RegionHandler
+	TimelinePresenter       *timelinehandler.CustomerTimelineHandler
+	PortalPresenter         *portalhandler.PartnerPortalHandler
+	RestrictionPresenter    *restrictionhandler.CustomerRestrictionHandler
+	DormancyPresenter       *dormancyhandler.DormancyHandler
+	AccountClosurePresenter *closurehandler.AccountClosureHandler
+	AMLPresenter            *amlhandler.AMLHandler
+	CollectionPresenter     *collectionhandler.CollectionHandler
+	WriteOffPresenter       *writeoffhandler.WriteOffHandler
+	ConcentrationPresenter  *concentrationhandler.ConcentrationHandler
+	DashboardPresenter      *dashboardhandler.DashboardHandler
+	TenantPresenter         *tenanthandler.TenantHandler
+	LogLevelPresenter       *loglevelhandler.LogLevelHandler
+	PartnerDebugPresenter   *partnerdebughandler.PartnerDebugHandler
+	ContractPresenter       *contracthandler.ContractLookupHandler
+	ContractImportPresenter *contracthandler.ContractImportHandler
+	ExportPresenter         *exporthandler.ExportHandler
+	GraphQLPresenter        *graphqlhandler.GraphQLHandler
+	APIKeyAuth              fiber.Handler
+	RequestSignature        fiber.Handler
+	ImpersonationAudit      fiber.Handler
+	AccountClosureGate      fiber.Handler
+	PartnerTransactionGate  fiber.Handler
+	DebugRecorder           fiber.Handler
+
+	// Jobs are started by main alongside the HTTP server, once per tenant.
+	// ProcessJobs serve the process itself and run once.
+	Jobs        []job.Job
+	ProcessJobs []job.Job
 }
 
 func NewPresenter(
@@ -36,53 +272,742 @@ func NewPresenter(
 	tel *telemetry.OpenTelemetry,
 	cfg *config.Config,
 	store *session.Store,
+	redisClient *redis.Client,
 ) Presenter {
+	// Tiap lapisan memakai logger modulnya sendiri agar levelnya bisa diatur terpisah
+	repositoryLog := tel.Logger(telemetry.ModuleRepository)
+	serviceLog := tel.Logger(telemetry.ModuleService)
+	handlerLog := tel.Logger(telemetry.ModuleHandler)
+
+	// Setiap layanan eksternal mendapat pool koneksi sendiri dan namanya muncul di span serta metrik
+	httpClientMeter := tel.MeterProvider.Meter("http-client-meter")
+	httpClientTracer := tel.TracerProvider.Tracer("http-client-trace")
+	httpClientLog := tel.Logger(telemetry.ModuleHTTP)
+	outboundClient := func(name string, timeout time.Duration, maxRetries int) *http.Client {
+		return httpclient.New(httpclient.Config{
+			Name:                name,
+			Timeout:             timeout,
+			MaxRetries:          maxRetries,
+			Backoff:             cfg.HTTP_CLIENT_BACKOFF,
+			MaxIdleConnsPerHost: cfg.HTTP_CLIENT_MAX_IDLE_CONNS,
+		}, httpClientMeter, httpClientTracer, httpClientLog)
+	}
+
 	// Repository
 	customerRepositoryMeter := tel.MeterProvider.Meter("customer-repository-meter")
-	customerRepositoryTracer := tel.TracerProvider.Tracer("customer-repository-tracer")
 	customerRepository := customerrepo.NewCustomerRepository(
 		db,
 		customerRepositoryMeter,
-		customerRepositoryTracer,
-		tel.Log,
+		repositoryLog,
 	)
 
 	limitRepositoryMeter := tel.MeterProvider.Meter("limit-repository-meter")
-	limitRepositoryTracer := tel.TracerProvider.Tracer("limit-repository-tracer")
 	limitRepository := limitrepo.NewLimitRepository(
 		db,
 		limitRepositoryMeter,
-		limitRepositoryTracer,
-		tel.Log,
+		repositoryLog,
 	)
 
 	tenorRepositoryMeter := tel.MeterProvider.Meter("limit-repository-meter")
-	tenorRepositoryTracer := tel.TracerProvider.Tracer("limit-repository-tracer")
-	tenorRepository := tenorrepo.NewTenorRepository(
-		db,
-		tenorRepositoryMeter,
-		tenorRepositoryTracer,
-		tel.Log,
+	// Cache dua tingkat: memori lokal di depan Redis, invalidasi disebar ke semua instance
+	tenorCache := cache.New[*domain.Tenor](redisClient, cache.Options{
+		Name:      "tenors",
+		LocalTTL:  cfg.TENOR_CACHE_TTL,
+		RemoteTTL: cfg.CACHE_REMOTE_TTL,
+	})
+	tenorCatalogCache := cache.New[[]domain.Tenor](redisClient, cache.Options{
+		Name:      "tenor_catalog",
+		LocalTTL:  cfg.TENOR_CACHE_TTL,
+		RemoteTTL: cfg.CACHE_REMOTE_TTL,
+	})
+	featureFlagCache := cache.New[map[string]domain.FeatureFlag](redisClient, cache.Options{
+		Name:      "feature_flags",
+		LocalTTL:  cfg.FEATURE_FLAG_CACHE_TTL,
+		RemoteTTL: cfg.CACHE_REMOTE_TTL,
+	})
+	dueDateCalendarCache := cache.New[domain.DueDateCalendar](redisClient, cache.Options{
+		Name:      "due_date_calendar",
+		LocalTTL:  cfg.DUE_DATE_CACHE_TTL,
+		RemoteTTL: cfg.CACHE_REMOTE_TTL,
+	})
+
+	tenorRepository := tenorrepo.NewCachedTenorRepository(
+		tenorrepo.NewTenorRepository(
+			db,
+			tenorRepositoryMeter,
+			repositoryLog,
+		),
+		tenorCache,
+		tenorCatalogCache,
+		repositoryLog,
 	)
 
 	transactionRepositoryMeter := tel.MeterProvider.Meter("limit-repository-meter")
-	transactionRepositoryTracer := tel.TracerProvider.Tracer("limit-repository-tracer")
 	transactionRepository := transactionrepo.NewTransactionRepository(
 		db,
 		transactionRepositoryMeter,
-		transactionRepositoryTracer,
-		tel.Log,
+		repositoryLog,
+	)
+
+	partnerRepositoryMeter := tel.MeterProvider.Meter("partner-repository-meter")
+	partnerRepository := partnerrepo.NewPartnerRepository(
+		db,
+		partnerRepositoryMeter,
+		repositoryLog,
+	)
+
+	deliveryRepositoryMeter := tel.MeterProvider.Meter("delivery-repository-meter")
+	deliveryRepository := deliveryrepo.NewDeliveryRepository(
+		db,
+		deliveryRepositoryMeter,
+		repositoryLog,
+	)
+
+	salaryChangeRepositoryMeter := tel.MeterProvider.Meter("salary-change-repository-meter")
+	salaryChangeRepository := salarychangerepo.NewSalaryChangeRepository(
+		db,
+		salaryChangeRepositoryMeter,
+		repositoryLog,
+	)
+
+	incomeRepositoryMeter := tel.MeterProvider.Meter("income-repository-meter")
+	incomeRepository := incomerepo.NewIncomeVerificationRepository(
+		db,
+		incomeRepositoryMeter,
+		repositoryLog,
+	)
+
+	riskTierRepositoryMeter := tel.MeterProvider.Meter("risk-tier-repository-meter")
+	riskTierRepository := risktierrepo.NewRiskTierRepository(
+		db,
+		riskTierRepositoryMeter,
+		repositoryLog,
+	)
+
+	blacklistRepositoryMeter := tel.MeterProvider.Meter("blacklist-repository-meter")
+	blacklistRepository := blacklistrepo.NewBlacklistRepository(
+		db,
+		blacklistRepositoryMeter,
+		repositoryLog,
+	)
+
+	duplicateRepositoryMeter := tel.MeterProvider.Meter("duplicate-repository-meter")
+	duplicateRepository := duplicaterepo.NewDuplicateRepository(
+		db,
+		duplicateRepositoryMeter,
+		repositoryLog,
+	)
+
+	restructuringRepositoryMeter := tel.MeterProvider.Meter("restructuring-repository-meter")
+	restructuringRepository := restructuringrepo.NewRestructuringRepository(
+		db,
+		restructuringRepositoryMeter,
+		repositoryLog,
+	)
+
+	fxRateRepositoryMeter := tel.MeterProvider.Meter("fx-rate-repository-meter")
+	fxRateRepository := fxraterepo.NewFxRateRepository(
+		db,
+		fxRateRepositoryMeter,
+		repositoryLog,
+	)
+
+	reportRepositoryMeter := tel.MeterProvider.Meter("report-repository-meter")
+	reportRepository := reportrepo.NewReportRepository(
+		db,
+		reportRepositoryMeter,
+		repositoryLog,
+	)
+
+	pendingExpiryRepositoryMeter := tel.MeterProvider.Meter("pending-expiry-repository-meter")
+	pendingExpiryRepository := pendingexpiryrepo.NewPendingExpiryRepository(
+		db,
+		pendingExpiryRepositoryMeter,
+		repositoryLog,
+	)
+
+	communicationRepositoryMeter := tel.MeterProvider.Meter("communication-repository-meter")
+	communicationRepository := communicationrepo.NewCommunicationRepository(
+		db,
+		communicationRepositoryMeter,
+		repositoryLog,
+	)
+
+	featureFlagRepositoryMeter := tel.MeterProvider.Meter("feature-flag-repository-meter")
+	featureFlagRepository := featureflagrepo.NewFeatureFlagRepository(
+		db,
+		featureFlagRepositoryMeter,
+		repositoryLog,
+	)
+
+	feeScheduleRepositoryMeter := tel.MeterProvider.Meter("fee-schedule-repository-meter")
+	feeScheduleRepository := feeschedulerepo.NewFeeScheduleRepository(
+		db,
+		feeScheduleRepositoryMeter,
+		repositoryLog,
+	)
+
+	promotionRepositoryMeter := tel.MeterProvider.Meter("promotion-repository-meter")
+	promotionRepository := promotionrepo.NewPromotionRepository(
+		db,
+		promotionRepositoryMeter,
+		repositoryLog,
+	)
+
+	referralRepositoryMeter := tel.MeterProvider.Meter("referral-repository-meter")
+	referralRepository := referralrepo.NewReferralRepository(
+		db,
+		referralRepositoryMeter,
+		repositoryLog,
+	)
+
+	otpRepositoryMeter := tel.MeterProvider.Meter("otp-repository-meter")
+	otpRepositoryTracer := tel.TracerProvider.Tracer("otp-repository-tracer")
+	otpRepository := otprepo.NewOTPRepository(
+		redisClient,
+		otpRepositoryMeter,
+		otpRepositoryTracer,
+		repositoryLog,
+	)
+
+	maintenanceRepositoryMeter := tel.MeterProvider.Meter("maintenance-repository-meter")
+	maintenanceRepositoryTracer := tel.TracerProvider.Tracer("maintenance-repository-tracer")
+	maintenanceRepository := maintenancerepo.NewMaintenanceRepository(
+		redisClient,
+		maintenanceRepositoryMeter,
+		maintenanceRepositoryTracer,
+		repositoryLog,
+	)
+
+	quotaRepositoryMeter := tel.MeterProvider.Meter("quota-repository-meter")
+	quotaRepository := quotarepo.NewQuotaRepository(
+		db,
+		quotaRepositoryMeter,
+		repositoryLog,
+	)
+
+	quotaCounterRepositoryMeter := tel.MeterProvider.Meter("quota-counter-repository-meter")
+	quotaCounterRepositoryTracer := tel.TracerProvider.Tracer("quota-counter-repository-tracer")
+	quotaCounterRepository := quotacounterrepo.NewQuotaCounterRepository(
+		redisClient,
+		quotaCounterRepositoryMeter,
+		quotaCounterRepositoryTracer,
+		repositoryLog,
+	)
+
+	statementRepositoryMeter := tel.MeterProvider.Meter("statement-repository-meter")
+	statementRepository := statementrepo.NewStatementRepository(
+		db,
+		statementRepositoryMeter,
+		repositoryLog,
+	)
+
+	calendarRepositoryMeter := tel.MeterProvider.Meter("calendar-repository-meter")
+	calendarRepository := calendarrepo.NewCalendarTokenRepository(
+		db,
+		calendarRepositoryMeter,
+		repositoryLog,
+	)
+
+	dueDateRepositoryMeter := tel.MeterProvider.Meter("due-date-repository-meter")
+	dueDateRepository := duedaterepo.NewDueDateRepository(
+		db,
+		dueDateRepositoryMeter,
+		repositoryLog,
+	)
+
+	emailRepositoryMeter := tel.MeterProvider.Meter("email-repository-meter")
+	emailRepository := emailrepo.NewEmailRepository(
+		db,
+		emailRepositoryMeter,
+		repositoryLog,
+	)
+
+	notificationRepositoryMeter := tel.MeterProvider.Meter("notification-repository-meter")
+	notificationRepository := notificationrepo.NewNotificationRepository(
+		db,
+		notificationRepositoryMeter,
+		repositoryLog,
+	)
+
+	paymentReminderRepositoryMeter := tel.MeterProvider.Meter("payment-reminder-repository-meter")
+	paymentReminderRepository := reminderrepo.NewPaymentReminderRepository(
+		db,
+		paymentReminderRepositoryMeter,
+		repositoryLog,
+	)
+
+	monthlyStatementRepositoryMeter := tel.MeterProvider.Meter("monthly-statement-repository-meter")
+	monthlyStatementRepository := monthlystatementrepo.NewMonthlyStatementRepository(
+		db,
+		monthlyStatementRepositoryMeter,
+		repositoryLog,
+	)
+
+	batchRepositoryMeter := tel.MeterProvider.Meter("batch-repository-meter")
+	batchRepository := batchrepo.NewBatchRepository(
+		db,
+		batchRepositoryMeter,
+		repositoryLog,
+	)
+
+	nonceRepositoryMeter := tel.MeterProvider.Meter("nonce-repository-meter")
+	nonceRepositoryTracer := tel.TracerProvider.Tracer("nonce-repository-tracer")
+	nonceRepository := noncerepo.NewNonceRepository(
+		redisClient,
+		nonceRepositoryMeter,
+		nonceRepositoryTracer,
+		repositoryLog,
+	)
+
+	impersonationRepositoryMeter := tel.MeterProvider.Meter("impersonation-repository-meter")
+	impersonationRepository := impersonationrepo.NewImpersonationRepository(
+		db,
+		impersonationRepositoryMeter,
+		repositoryLog,
+	)
+
+	virtualAccountRepositoryMeter := tel.MeterProvider.Meter("virtual-account-repository-meter")
+	virtualAccountRepository := virtualaccountrepo.NewVirtualAccountRepository(
+		db,
+		virtualAccountRepositoryMeter,
+		repositoryLog,
+	)
+
+	directDebitRepositoryMeter := tel.MeterProvider.Meter("direct-debit-repository-meter")
+	directDebitRepository := directdebitrepo.NewDirectDebitRepository(
+		db,
+		directDebitRepositoryMeter,
+		repositoryLog,
+	)
+
+	paymentRepositoryMeter := tel.MeterProvider.Meter("payment-repository-meter")
+	paymentRepository := paymentrepo.NewPaymentRepository(
+		db,
+		paymentRepositoryMeter,
+		repositoryLog,
+	)
+
+	customerNoteRepositoryMeter := tel.MeterProvider.Meter("customer-note-repository-meter")
+	customerNoteRepository := customernoterepo.NewCustomerNoteRepository(
+		db,
+		customerNoteRepositoryMeter,
+		repositoryLog,
+	)
+
+	attachmentRepositoryMeter := tel.MeterProvider.Meter("attachment-repository-meter")
+	attachmentRepository := attachmentrepo.NewTransactionAttachmentRepository(
+		db,
+		attachmentRepositoryMeter,
+		repositoryLog,
+	)
+
+	regionRepositoryMeter := tel.MeterProvider.Meter("region-repository-meter")
+	regionRepository := regionrepo.NewRegionRepository(
+		db,
+		regionRepositoryMeter,
+		repositoryLog,
+	)
+
+	customerEventRepositoryMeter := tel.MeterProvider.Meter("customer-event-repository-meter")
+	customerEventRepository := customereventrepo.NewCustomerEventRepository(
+		db,
+		customerEventRepositoryMeter,
+		repositoryLog,
+	)
+
+	apiUsageRepositoryMeter := tel.MeterProvider.Meter("api-usage-repository-meter")
+	apiUsageRepositoryTracer := tel.TracerProvider.Tracer("api-usage-repository-tracer")
+	apiUsageRepository := apiusagerepo.NewAPIUsageRepository(
+		redisClient,
+		apiUsageRepositoryMeter,
+		apiUsageRepositoryTracer,
+		repositoryLog,
+	)
+
+	restrictionRepositoryMeter := tel.MeterProvider.Meter("restriction-repository-meter")
+	restrictionRepository := restrictionrepo.NewCustomerRestrictionRepository(
+		db,
+		restrictionRepositoryMeter,
+		repositoryLog,
+	)
+
+	dormancyRepositoryMeter := tel.MeterProvider.Meter("dormancy-repository-meter")
+	dormancyRepository := dormancyrepo.NewDormancyRepository(
+		db,
+		dormancyRepositoryMeter,
+		repositoryLog,
+	)
+
+	closureRepositoryMeter := tel.MeterProvider.Meter("closure-repository-meter")
+	closureRepository := closurerepo.NewAccountClosureRepository(
+		db,
+		closureRepositoryMeter,
+		repositoryLog,
+	)
+
+	amlRepositoryMeter := tel.MeterProvider.Meter("aml-repository-meter")
+	amlRepository := amlrepo.NewAMLRepository(
+		db,
+		amlRepositoryMeter,
+		repositoryLog,
+	)
+
+	collectionRepositoryMeter := tel.MeterProvider.Meter("collection-repository-meter")
+	collectionRepository := collectionrepo.NewCollectionRepository(
+		db,
+		collectionRepositoryMeter,
+		repositoryLog,
+	)
+
+	writeOffRepositoryMeter := tel.MeterProvider.Meter("write-off-repository-meter")
+	writeOffRepository := writeoffrepo.NewWriteOffRepository(
+		db,
+		writeOffRepositoryMeter,
+		repositoryLog,
+	)
+
+	ledgerRepositoryMeter := tel.MeterProvider.Meter("ledger-repository-meter")
+	ledgerRepository := ledgerrepo.NewLedgerRepository(
+		db,
+		ledgerRepositoryMeter,
+		repositoryLog,
+	)
+
+	concentrationRepositoryMeter := tel.MeterProvider.Meter("concentration-repository-meter")
+	concentrationRepository := concentrationrepo.NewConcentrationRepository(
+		db,
+		concentrationRepositoryMeter,
+		repositoryLog,
+	)
+
+	partnerDebugRepositoryMeter := tel.MeterProvider.Meter("partner-debug-repository-meter")
+	partnerDebugRepository := partnerdebugrepo.NewPartnerDebugRepository(
+		db,
+		partnerDebugRepositoryMeter,
+		repositoryLog,
+	)
+
+	mediaRepositoryMeter := tel.MeterProvider.Meter("media-repository-meter")
+	mediaRepository := mediarepo.NewMediaRepository(
+		db,
+		mediaRepositoryMeter,
+		repositoryLog,
+	)
+
+	exposureRepositoryMeter := tel.MeterProvider.Meter("exposure-repository-meter")
+	exposureRepository := exposurerepo.NewCustomerExposureRepository(
+		db,
+		exposureRepositoryMeter,
+		repositoryLog,
 	)
 
 	// Service
-	adminServiceMeter := tel.MeterProvider.Meter("admin-service-meter")
-	adminServiceTracer := tel.TracerProvider.Tracer("admin-service-trace")
-	adminService := adminsrv.NewAdminService(
+	featureFlagServiceMeter := tel.MeterProvider.Meter("feature-flag-service-meter")
+	featureFlagServiceTracer := tel.TracerProvider.Tracer("feature-flag-service-trace")
+	featureFlagService := featureflagsrv.NewFeatureFlagService(
+		featureFlagRepository,
+		featureFlagCache,
+		featureFlagServiceMeter,
+		featureFlagServiceTracer,
+		serviceLog,
+	)
+
+	feeScheduleServiceMeter := tel.MeterProvider.Meter("fee-schedule-service-meter")
+	feeScheduleServiceTracer := tel.TracerProvider.Tracer("fee-schedule-service-trace")
+	feeScheduleService := feeschedulesrv.NewFeeScheduleService(
+		partnerRepository,
+		feeScheduleRepository,
+		feeScheduleServiceMeter,
+		feeScheduleServiceTracer,
+		serviceLog,
+	)
+
+	promotionServiceMeter := tel.MeterProvider.Meter("promotion-service-meter")
+	promotionServiceTracer := tel.TracerProvider.Tracer("promotion-service-trace")
+	promotionService := promotionsrv.NewPromotionService(
+		partnerRepository,
+		promotionRepository,
+		promotionServiceMeter,
+		promotionServiceTracer,
+		serviceLog,
+	)
+
+	referralServiceMeter := tel.MeterProvider.Meter("referral-service-meter")
+	referralServiceTracer := tel.TracerProvider.Tracer("referral-service-trace")
+	referralService := referralsrv.NewReferralService(
+		customerRepository,
+		referralRepository,
+		referralsrv.Config{
+			IPWindow: cfg.REFERRAL_IP_WINDOW,
+			MaxPerIP: cfg.REFERRAL_MAX_PER_IP,
+		},
+		referralServiceMeter,
+		referralServiceTracer,
+		serviceLog,
+	)
+
+	// Belum ada gateway SMS/email, kode OTP hanya dicatat di log di luar production
+	var otpSenders []otp.Sender
+	if cfg.ENVIRONMENT != "production" {
+		otpSenders = append(otpSenders,
+			otp.NewLogSender(otp.ChannelSMS, serviceLog),
+			otp.NewLogSender(otp.ChannelEmail, serviceLog),
+		)
+	}
+
+	otpServiceMeter := tel.MeterProvider.Meter("otp-service-meter")
+	otpServiceTracer := tel.TracerProvider.Tracer("otp-service-trace")
+	otpService := otpsrv.NewOTPService(
+		otpRepository,
+		otpSenders,
+		otpsrv.Config{
+			TTL:             cfg.OTP_TTL,
+			VerificationTTL: cfg.OTP_VERIFICATION_TTL,
+			MaxAttempts:     cfg.OTP_MAX_ATTEMPTS,
+			MaxRequests:     cfg.OTP_MAX_REQUESTS,
+			RequestWindow:   cfg.OTP_REQUEST_WINDOW,
+		},
+		otpServiceMeter,
+		otpServiceTracer,
+		serviceLog,
+	)
+
+	// Jam operasional kosong berarti scope buka sepanjang hari
+	partnerTransactionHours, err := businesshours.Parse(cfg.PARTNER_TRANSACTION_HOURS, cfg.BUSINESS_TIMEZONE)
+	if err != nil {
+		serviceLog.Fatal("Invalid PARTNER_TRANSACTION_HOURS", zap.Error(err))
+	}
+
+	maintenanceServiceMeter := tel.MeterProvider.Meter("maintenance-service-meter")
+	maintenanceServiceTracer := tel.TracerProvider.Tracer("maintenance-service-trace")
+	maintenanceService := maintenancesrv.NewMaintenanceService(
+		maintenanceRepository,
+		maintenancesrv.Config{
+			Enabled: cfg.MAINTENANCE_MODE,
+			Hours: map[domain.GateScope]*businesshours.Hours{
+				domain.GatePartnerTransactions: partnerTransactionHours,
+			},
+		},
+		maintenanceServiceMeter,
+		maintenanceServiceTracer,
+		serviceLog,
+	)
+
+	// Kuota harian partner direset pada tengah malam zona waktu bisnis
+	businessLocation, err := time.LoadLocation(cfg.BUSINESS_TIMEZONE)
+	if err != nil {
+		serviceLog.Fatal("Invalid BUSINESS_TIMEZONE", zap.Error(err))
+	}
+
+	dueDateServiceMeter := tel.MeterProvider.Meter("due-date-service-meter")
+	dueDateServiceTracer := tel.TracerProvider.Tracer("due-date-service-trace")
+	dueDateService := duedatesrv.NewDueDateService(
+		dueDateRepository,
+		dueDateCalendarCache,
+		businessLocation,
+		dueDateServiceMeter,
+		dueDateServiceTracer,
+		serviceLog,
+	)
+
+	quotaServiceMeter := tel.MeterProvider.Meter("quota-service-meter")
+	quotaServiceTracer := tel.TracerProvider.Tracer("quota-service-trace")
+	quotaService := quotasrv.NewQuotaService(
+		partnerRepository,
+		quotaRepository,
+		quotaCounterRepository,
+		quotasrv.Config{
+			Location:     businessLocation,
+			AlertPercent: cfg.PARTNER_QUOTA_ALERT_PERCENT,
+		},
+		quotaServiceMeter,
+		quotaServiceTracer,
+		serviceLog,
+	)
+
+	signatureServiceMeter := tel.MeterProvider.Meter("signature-service-meter")
+	signatureServiceTracer := tel.TracerProvider.Tracer("signature-service-trace")
+	signatureService := signaturesrv.NewSignatureService(
+		nonceRepository,
+		signaturesrv.Config{Tolerance: cfg.PARTNER_SIGNATURE_TOLERANCE},
+		signatureServiceMeter,
+		signatureServiceTracer,
+		serviceLog,
+	)
+
+	customerNoteServiceMeter := tel.MeterProvider.Meter("customer-note-service-meter")
+	customerNoteServiceTracer := tel.TracerProvider.Tracer("customer-note-service-trace")
+	customerNoteService := customernotesrv.NewCustomerNoteService(
+		customerRepository,
+		customerNoteRepository,
+		customerNoteServiceMeter,
+		customerNoteServiceTracer,
+		serviceLog,
+	)
+
+	impersonationServiceMeter := tel.MeterProvider.Meter("impersonation-service-meter")
+	impersonationServiceTracer := tel.TracerProvider.Tracer("impersonation-service-trace")
+	impersonationService := impersonationsrv.NewImpersonationService(
+		customerRepository,
+		impersonationRepository,
+		impersonationsrv.Config{
+			JWTSecret: cfg.JWT_SECRET_KEY,
+			TTL:       cfg.IMPERSONATION_TTL,
+		},
+		impersonationServiceMeter,
+		impersonationServiceTracer,
+		serviceLog,
+	)
+
+	// Provider hanya aktif bila kuncinya dikonfigurasi
+	var paymentVerifiers []paymentgateway.Verifier
+	if cfg.PAYMENT_MIDTRANS_SERVER_KEY != "" {
+		paymentVerifiers = append(paymentVerifiers, paymentgateway.NewMidtrans(cfg.PAYMENT_MIDTRANS_SERVER_KEY))
+	}
+	if cfg.PAYMENT_XENDIT_CALLBACK_KEY != "" {
+		paymentVerifiers = append(paymentVerifiers, paymentgateway.NewXendit(cfg.PAYMENT_XENDIT_CALLBACK_KEY))
+	}
+
+	paymentServiceMeter := tel.MeterProvider.Meter("payment-service-meter")
+	paymentServiceTracer := tel.TracerProvider.Tracer("payment-service-trace")
+	paymentService := paymentsrv.NewPaymentService(
+		db,
+		paymentRepository,
+		paymentVerifiers,
+		paymentServiceMeter,
+		paymentServiceTracer,
+		serviceLog,
+	)
+
+	// Belum ada integrasi charge ke bank, stub hanya dipakai di luar production
+	var paymentChargers []paymentgateway.Charger
+	if cfg.ENVIRONMENT != "production" {
+		paymentChargers = append(paymentChargers, paymentgateway.NewStubCharger())
+	}
+
+	directDebitServiceMeter := tel.MeterProvider.Meter("direct-debit-service-meter")
+	directDebitServiceTracer := tel.TracerProvider.Tracer("direct-debit-service-trace")
+	directDebitService := directdebitsrv.NewDirectDebitService(
+		directDebitRepository,
+		paymentService,
+		paymentChargers,
+		dueDateService,
+		directdebitsrv.Config{
+			MaxAttempts: cfg.DIRECT_DEBIT_MAX_ATTEMPTS,
+			RetryAfter:  cfg.DIRECT_DEBIT_RETRY_AFTER,
+			BatchSize:   cfg.DIRECT_DEBIT_BATCH,
+		},
+		directDebitServiceMeter,
+		directDebitServiceTracer,
+		serviceLog,
+	)
+
+	virtualAccountProvider, err := virtualaccount.New(cfg.VIRTUAL_ACCOUNT_PROVIDER, cfg.VIRTUAL_ACCOUNT_BANK, cfg.VIRTUAL_ACCOUNT_PREFIX)
+	if err != nil {
+		serviceLog.Fatal("Failed to configure virtual account provider", zap.Error(err))
+	}
+
+	virtualAccountServiceMeter := tel.MeterProvider.Meter("virtual-account-service-meter")
+	virtualAccountServiceTracer := tel.TracerProvider.Tracer("virtual-account-service-trace")
+	virtualAccountService := virtualaccountsrv.NewVirtualAccountService(
+		virtualAccountRepository,
+		virtualAccountProvider,
+		virtualaccountsrv.Config{BatchSize: cfg.VIRTUAL_ACCOUNT_BATCH},
+		virtualAccountServiceMeter,
+		virtualAccountServiceTracer,
+		serviceLog,
+	)
+
+	fxRateServiceMeter := tel.MeterProvider.Meter("fx-rate-service-meter")
+	fxRateServiceTracer := tel.TracerProvider.Tracer("fx-rate-service-trace")
+	fxRateService := fxratesrv.NewFxRateService(
+		fxRateRepository,
+		fxRateServiceMeter,
+		fxRateServiceTracer,
+		serviceLog,
+	)
+
+	reportServiceMeter := tel.MeterProvider.Meter("report-service-meter")
+	reportServiceTracer := tel.TracerProvider.Tracer("report-service-trace")
+	reportService := reportsrv.NewReportService(
+		reportRepository,
+		regionRepository,
+		dueDateService,
+		reportServiceMeter,
+		reportServiceTracer,
+		serviceLog,
+	)
+
+	blacklistServiceMeter := tel.MeterProvider.Meter("blacklist-service-meter")
+	blacklistServiceTracer := tel.TracerProvider.Tracer("blacklist-service-trace")
+	blacklistService := blacklistsrv.NewBlacklistService(
+		blacklistRepository,
+		blacklistServiceMeter,
+		blacklistServiceTracer,
+		serviceLog,
+	)
+
+	duplicateServiceMeter := tel.MeterProvider.Meter("duplicate-service-meter")
+	duplicateServiceTracer := tel.TracerProvider.Tracer("duplicate-service-trace")
+	duplicateService := duplicatesrv.NewDuplicateService(
 		db,
 		customerRepository,
-		adminServiceMeter,
-		adminServiceTracer,
-		tel.Log,
+		duplicateRepository,
+		duplicateServiceMeter,
+		duplicateServiceTracer,
+		serviceLog,
+	)
+
+	restructuringServiceMeter := tel.MeterProvider.Meter("restructuring-service-meter")
+	restructuringServiceTracer := tel.TracerProvider.Tracer("restructuring-service-trace")
+	restructuringService := restructuringsrv.NewRestructuringService(
+		db,
+		transactionRepository,
+		tenorRepository,
+		restructuringRepository,
+		dueDateService,
+		restructuringServiceMeter,
+		restructuringServiceTracer,
+		serviceLog,
+	)
+
+	recommendationRules := recommendationsrv.DefaultRules()
+	recommendationRules.SalaryRatio = cfg.LIMIT_RECOMMENDATION_RATIO
+	recommendationRules.Rounding = decimal.NewFromFloat(cfg.LIMIT_RECOMMENDATION_ROUNDING)
+	if tiers, err := recommendationsrv.ParseTiers(cfg.LIMIT_RECOMMENDATION_TIERS); err != nil {
+		serviceLog.Warn("Invalid LIMIT_RECOMMENDATION_TIERS, using default tiers", zap.Error(err))
+	} else {
+		recommendationRules.Tiers = tiers
+	}
+
+	// Rasio cicilan per tier memakai tier yang sama dengan rekomendasi limit
+	maxRatios, err := partnersrv.ParseMaxRatios(cfg.DSR_MAX_RATIOS)
+	if err != nil {
+		serviceLog.Fatal("Invalid DSR_MAX_RATIOS", zap.Error(err))
+	}
+
+	concentrationServiceMeter := tel.MeterProvider.Meter("concentration-service-meter")
+	concentrationServiceTracer := tel.TracerProvider.Tracer("concentration-service-trace")
+	concentrationService := concentrationsrv.NewConcentrationService(
+		concentrationRepository,
+		regionRepository,
+		concentrationsrv.Config{
+			MinPortfolio: decimal.NewFromInt(int64(cfg.CONCENTRATION_MIN_PORTFOLIO)),
+		},
+		concentrationServiceMeter,
+		concentrationServiceTracer,
+		serviceLog,
+	)
+
+	dashboardServiceMeter := tel.MeterProvider.Meter("dashboard-service-meter")
+	dashboardServiceTracer := tel.TracerProvider.Tracer("dashboard-service-trace")
+	dashboardService := dashboardsrv.NewDashboardService(
+		customerRepository,
+		customerEventRepository,
+		dashboardServiceMeter,
+		dashboardServiceTracer,
+		serviceLog,
 	)
 
 	partnerServiceMeter := tel.MeterProvider.Meter("partner-service-meter")
@@ -93,9 +1018,47 @@ func NewPresenter(
 		tenorRepository,
 		limitRepository,
 		transactionRepository,
+		feeScheduleRepository,
+		blacklistService,
+		fxRateService,
+		quotaService,
+		concentrationService,
+		partnerRepository,
+		regionRepository,
+		exposureRepository,
+		restrictionRepository,
+		uint8(cfg.MAX_AGE_AT_TENOR_END),
+		partnersrv.Affordability{
+			TierFor:   recommendationRules.TierName,
+			MaxRatios: maxRatios,
+			RiskTiers: riskTierRepository,
+		},
 		partnerServiceMeter,
 		partnerServiceTracer,
-		tel.Log,
+		serviceLog,
+	)
+
+	tenorServiceMeter := tel.MeterProvider.Meter("tenor-service-meter")
+	tenorServiceTracer := tel.TracerProvider.Tracer("tenor-service-trace")
+	tenorService := tenorsrv.NewTenorService(
+		tenorRepository,
+		tenorServiceMeter,
+		tenorServiceTracer,
+		serviceLog,
+	)
+
+	batchServiceMeter := tel.MeterProvider.Meter("batch-service-meter")
+	batchServiceTracer := tel.TracerProvider.Tracer("batch-service-trace")
+	batchService := batchsrv.NewBatchService(
+		batchRepository,
+		partnerService,
+		batchsrv.Config{
+			MaxItems:  cfg.TRANSACTION_BATCH_MAX_ITEMS,
+			BatchSize: cfg.TRANSACTION_BATCH_SIZE,
+		},
+		batchServiceMeter,
+		batchServiceTracer,
+		serviceLog,
 	)
 
 	profileServiceMeter := tel.MeterProvider.Meter("profile-service-meter")
@@ -106,9 +1069,14 @@ func NewPresenter(
 		limitRepository,
 		tenorRepository,
 		transactionRepository,
+		blacklistService,
+		fxRateService,
+		referralService,
+		otpService,
+		dueDateService,
 		profileServiceMeter,
 		profileServiceTracer,
-		tel.Log,
+		serviceLog,
 	)
 
 	privateServiceMeter := tel.MeterProvider.Meter("private-service-meter")
@@ -117,13 +1085,513 @@ func NewPresenter(
 		db,
 		cfg.JWT_SECRET_KEY,
 		customerRepository,
+		otpService,
 		privateServiceMeter,
 		privateServiceTracer,
-		tel.Log,
+		serviceLog,
+	)
+
+	onboardingServiceMeter := tel.MeterProvider.Meter("onboarding-service-meter")
+	onboardingServiceTracer := tel.TracerProvider.Tracer("onboarding-service-trace")
+	onboardingService := onboardingsrv.NewOnboardingService(
+		partnerRepository,
+		onboardingServiceMeter,
+		onboardingServiceTracer,
+		serviceLog,
+	)
+
+	deliveryServiceMeter := tel.MeterProvider.Meter("delivery-service-meter")
+	deliveryServiceTracer := tel.TracerProvider.Tracer("delivery-service-trace")
+	deliveryService := deliverysrv.NewDeliveryService(
+		deliveryRepository,
+		// Pengiriman ulang webhook diatur delivery service sendiri
+		outboundClient("webhook", cfg.WEBHOOK_TIMEOUT, 0),
+		deliverysrv.Config{
+			MaxAttempts: cfg.WEBHOOK_MAX_ATTEMPTS,
+			MaxReplays:  cfg.WEBHOOK_MAX_REPLAYS,
+			Backoff:     cfg.WEBHOOK_BACKOFF,
+		},
+		deliveryServiceMeter,
+		deliveryServiceTracer,
+		serviceLog,
+	)
+
+	salaryChangeServiceMeter := tel.MeterProvider.Meter("salary-change-service-meter")
+	salaryChangeServiceTracer := tel.TracerProvider.Tracer("salary-change-service-trace")
+	salaryChangeService := salarychangesrv.NewSalaryChangeService(
+		db,
+		customerRepository,
+		salaryChangeRepository,
+		salaryChangeServiceMeter,
+		salaryChangeServiceTracer,
+		serviceLog,
+	)
+
+	recommendationServiceMeter := tel.MeterProvider.Meter("recommendation-service-meter")
+	recommendationServiceTracer := tel.TracerProvider.Tracer("recommendation-service-trace")
+	recommendationService := recommendationsrv.NewRecommendationService(
+		customerRepository,
+		tenorRepository,
+		limitRepository,
+		riskTierRepository,
+		recommendationRules,
+		recommendationServiceMeter,
+		recommendationServiceTracer,
+		serviceLog,
 	)
 
 	cloudinaryService := cloudinarysrv.NewCloudinaryService(cld)
 
+	statementTemplates, err := statementsrv.LoadTemplates()
+	if err != nil {
+		serviceLog.Fatal("Failed to load statement templates", zap.Error(err))
+	}
+	brandColor, err := pdf.ParseHexColor(cfg.STATEMENT_BRAND_COLOR)
+	if err != nil {
+		serviceLog.Fatal("Invalid STATEMENT_BRAND_COLOR", zap.Error(err))
+	}
+
+	notificationTemplates, err := notifiersrv.LoadTemplates()
+	if err != nil {
+		serviceLog.Fatal("Failed to load notification templates", zap.Error(err))
+	}
+
+	emailSender, err := mailer.New(mailer.Config{
+		Provider: cfg.EMAIL_PROVIDER,
+		SMTP: mailer.SMTPConfig{
+			Host:     cfg.EMAIL_SMTP_HOST,
+			Port:     cfg.EMAIL_SMTP_PORT,
+			Username: cfg.EMAIL_SMTP_USERNAME,
+			Password: cfg.EMAIL_SMTP_PASSWORD,
+		},
+		SES: mailer.SESConfig{
+			Region:          cfg.EMAIL_SES_REGION,
+			AccessKeyID:     cfg.EMAIL_SES_ACCESS_KEY_ID,
+			SecretAccessKey: cfg.EMAIL_SES_SECRET_ACCESS_KEY,
+		},
+		HTTPClient: outboundClient("ses", cfg.HTTP_CLIENT_TIMEOUT, cfg.HTTP_CLIENT_MAX_RETRIES),
+	})
+	if err != nil {
+		serviceLog.Fatal("Failed to configure email provider", zap.Error(err))
+	}
+
+	emailServiceMeter := tel.MeterProvider.Meter("email-service-meter")
+	emailServiceTracer := tel.TracerProvider.Tracer("email-service-trace")
+	emailService := emailsrv.NewEmailService(
+		emailRepository,
+		customerRepository,
+		notificationTemplates,
+		emailSender,
+		emailsrv.Config{
+			From:        cfg.EMAIL_FROM,
+			MaxAttempts: cfg.EMAIL_MAX_ATTEMPTS,
+			Backoff:     cfg.EMAIL_RETRY_BACKOFF,
+			BatchSize:   cfg.EMAIL_DISPATCH_BATCH,
+		},
+		emailServiceMeter,
+		emailServiceTracer,
+		serviceLog,
+	)
+
+	pushTemplates, err := notifiersrv.LoadPushTemplates()
+	if err != nil {
+		serviceLog.Fatal("Failed to load push templates", zap.Error(err))
+	}
+
+	pushSender, err := push.New(push.Config{
+		Provider: cfg.PUSH_PROVIDER,
+		FCM: push.FCMConfig{
+			CredentialsFile: cfg.PUSH_FCM_CREDENTIALS_FILE,
+		},
+		HTTPClient: outboundClient("fcm", cfg.HTTP_CLIENT_TIMEOUT, cfg.HTTP_CLIENT_MAX_RETRIES),
+	})
+	if err != nil {
+		serviceLog.Fatal("Failed to configure push provider", zap.Error(err))
+	}
+
+	pushServiceMeter := tel.MeterProvider.Meter("push-service-meter")
+	pushServiceTracer := tel.TracerProvider.Tracer("push-service-trace")
+	pushService := pushsrv.NewPushService(
+		notificationRepository,
+		customerRepository,
+		pushTemplates,
+		pushSender,
+		pushServiceMeter,
+		pushServiceTracer,
+		serviceLog,
+	)
+
+	preferenceServiceMeter := tel.MeterProvider.Meter("preference-service-meter")
+	preferenceServiceTracer := tel.TracerProvider.Tracer("preference-service-trace")
+	preferenceService := preferencesrv.NewPreferenceService(
+		notificationRepository,
+		preferenceServiceMeter,
+		preferenceServiceTracer,
+		serviceLog,
+	)
+
+	// Email masuk antrean lebih dulu, pengiriman ke provider dilakukan job email-dispatch.
+	// Push dikirim langsung dan hanya untuk template di domain.PushTemplates
+	notifierService := notifiersrv.NewChannelNotifier(
+		map[domain.NotificationChannel]service.CustomerNotifier{
+			domain.ChannelEmail: notifiersrv.NewRecordingNotifier(emailService, domain.ChannelEmail, communicationRepository, serviceLog),
+			domain.ChannelPush:  notifiersrv.NewRecordingNotifier(pushService, domain.ChannelPush, communicationRepository, serviceLog),
+		},
+		notificationRepository,
+		serviceLog,
+	)
+
+	adminServiceMeter := tel.MeterProvider.Meter("admin-service-meter")
+	adminServiceTracer := tel.TracerProvider.Tracer("admin-service-trace")
+	adminService := adminsrv.NewAdminService(
+		db,
+		customerRepository,
+		limitRepository,
+		notifierService,
+		fxRateService,
+		adminServiceMeter,
+		adminServiceTracer,
+		serviceLog,
+	)
+
+	paymentReminderServiceMeter := tel.MeterProvider.Meter("payment-reminder-service-meter")
+	paymentReminderServiceTracer := tel.TracerProvider.Tracer("payment-reminder-service-trace")
+	paymentReminderService := remindersrv.NewPaymentReminderService(
+		paymentReminderRepository,
+		notifierService,
+		dueDateService,
+		remindersrv.Config{
+			DaysBefore: cfg.PAYMENT_REMINDER_DAYS_BEFORE,
+			BatchSize:  cfg.PAYMENT_REMINDER_BATCH,
+		},
+		paymentReminderServiceMeter,
+		paymentReminderServiceTracer,
+		serviceLog,
+	)
+
+	statementServiceMeter := tel.MeterProvider.Meter("statement-service-meter")
+	statementServiceTracer := tel.TracerProvider.Tracer("statement-service-trace")
+	statementService := statementsrv.NewStatementService(
+		profileService,
+		customerRepository,
+		statementRepository,
+		monthlyStatementRepository,
+		cloudinaryService,
+		notifierService,
+		statementTemplates,
+		statementsrv.Config{
+			Branding: statementsrv.Branding{
+				CompanyName: cfg.STATEMENT_COMPANY_NAME,
+				Address:     cfg.STATEMENT_COMPANY_ADDRESS,
+				Contact:     cfg.STATEMENT_COMPANY_CONTACT,
+				Color:       brandColor,
+			},
+			SyncMaxInstallments: cfg.STATEMENT_SYNC_INSTALLMENTS,
+			Folder:              cfg.STATEMENT_FOLDER,
+			BatchSize:           cfg.STATEMENT_BATCH_SIZE,
+			Location:            businessLocation,
+			MonthlyBatchSize:    cfg.MONTHLY_STATEMENT_BATCH,
+		},
+		statementServiceMeter,
+		statementServiceTracer,
+		serviceLog,
+	)
+
+	calendarServiceMeter := tel.MeterProvider.Meter("calendar-service-meter")
+	calendarServiceTracer := tel.TracerProvider.Tracer("calendar-service-trace")
+	calendarService := calendarsrv.NewCalendarService(
+		db,
+		profileService,
+		customerRepository,
+		calendarRepository,
+		calendarsrv.Config{
+			Location: businessLocation,
+			Reminder: cfg.CALENDAR_REMINDER,
+		},
+		calendarServiceMeter,
+		calendarServiceTracer,
+		serviceLog,
+	)
+
+	mobileServiceMeter := tel.MeterProvider.Meter("mobile-service-meter")
+	mobileServiceTracer := tel.TracerProvider.Tracer("mobile-service-trace")
+	mobileService := mobilesrv.NewMobileService(
+		profileService,
+		transactionRepository,
+		tenorRepository,
+		dueDateService,
+		mobileServiceMeter,
+		mobileServiceTracer,
+		serviceLog,
+	)
+
+	attachmentServiceMeter := tel.MeterProvider.Meter("attachment-service-meter")
+	attachmentServiceTracer := tel.TracerProvider.Tracer("attachment-service-trace")
+	attachmentService := attachmentsrv.NewTransactionAttachmentService(
+		attachmentRepository,
+		cloudinaryService,
+		attachmentsrv.Config{
+			Folder:  cfg.ATTACHMENT_FOLDER,
+			MaxSize: int64(cfg.ATTACHMENT_MAX_SIZE),
+		},
+		attachmentServiceMeter,
+		attachmentServiceTracer,
+		serviceLog,
+	)
+
+	incomeServiceMeter := tel.MeterProvider.Meter("income-service-meter")
+	incomeServiceTracer := tel.TracerProvider.Tracer("income-service-trace")
+	incomeService := incomesrv.NewIncomeVerificationService(
+		incomeRepository,
+		customerRepository,
+		cloudinaryService,
+		incomesrv.Config{
+			Folder:               cfg.INCOME_STATEMENT_FOLDER,
+			MaxSize:              int64(cfg.INCOME_STATEMENT_MAX_SIZE),
+			DiscrepancyThreshold: decimal.NewFromFloat(cfg.INCOME_DISCREPANCY_THRESHOLD),
+		},
+		incomeServiceMeter,
+		incomeServiceTracer,
+		serviceLog,
+	)
+
+	riskTierServiceMeter := tel.MeterProvider.Meter("risk-tier-service-meter")
+	riskTierServiceTracer := tel.TracerProvider.Tracer("risk-tier-service-trace")
+	riskTierService := risktiersrv.NewRiskTierService(
+		riskTierRepository,
+		customerRepository,
+		dueDateService,
+		risktiersrv.Config{
+			BatchSize: cfg.RISK_TIER_BATCH,
+		},
+		riskTierServiceMeter,
+		riskTierServiceTracer,
+		serviceLog,
+	)
+
+	regionServiceMeter := tel.MeterProvider.Meter("region-service-meter")
+	regionServiceTracer := tel.TracerProvider.Tracer("region-service-trace")
+	regionService := regionsrv.NewRegionService(
+		partnerRepository,
+		regionRepository,
+		regionServiceMeter,
+		regionServiceTracer,
+		serviceLog,
+	)
+
+	exposureServiceMeter := tel.MeterProvider.Meter("exposure-service-meter")
+	exposureServiceTracer := tel.TracerProvider.Tracer("exposure-service-trace")
+	exposureService := exposuresrv.NewExposureService(
+		exposureRepository,
+		exposureServiceMeter,
+		exposureServiceTracer,
+		serviceLog,
+	)
+
+	timelineServiceMeter := tel.MeterProvider.Meter("timeline-service-meter")
+	timelineServiceTracer := tel.TracerProvider.Tracer("timeline-service-trace")
+	timelineService := timelinesrv.NewCustomerTimelineService(
+		customerEventRepository,
+		customerRepository,
+		timelineServiceMeter,
+		timelineServiceTracer,
+		serviceLog,
+	)
+
+	contractServiceMeter := tel.MeterProvider.Meter("contract-service-meter")
+	contractServiceTracer := tel.TracerProvider.Tracer("contract-service-trace")
+	contractService := contractsrv.NewContractLookupService(
+		transactionRepository,
+		customerRepository,
+		tenorRepository,
+		dueDateService,
+		contractServiceMeter,
+		contractServiceTracer,
+		serviceLog,
+	)
+	contractImportService := contractsrv.NewContractImportService(
+		transactionRepository,
+		customerRepository,
+		tenorRepository,
+		contractsrv.ImportConfig{
+			MaxItems:  cfg.CONTRACT_IMPORT_MAX_ITEMS,
+			BatchSize: cfg.CONTRACT_IMPORT_BATCH_SIZE,
+		},
+		contractServiceMeter,
+		contractServiceTracer,
+		serviceLog,
+	)
+
+	exportServiceMeter := tel.MeterProvider.Meter("export-service-meter")
+	exportServiceTracer := tel.TracerProvider.Tracer("export-service-trace")
+	exportService := exportsrv.NewExportService(
+		customerRepository,
+		transactionRepository,
+		exportServiceMeter,
+		exportServiceTracer,
+		serviceLog,
+	)
+
+	restrictionServiceMeter := tel.MeterProvider.Meter("restriction-service-meter")
+	restrictionServiceTracer := tel.TracerProvider.Tracer("restriction-service-trace")
+	restrictionService := restrictionsrv.NewCustomerRestrictionService(
+		db,
+		customerRepository,
+		tenorRepository,
+		restrictionRepository,
+		restrictionServiceMeter,
+		restrictionServiceTracer,
+		serviceLog,
+	)
+
+	dormancyServiceMeter := tel.MeterProvider.Meter("dormancy-service-meter")
+	dormancyServiceTracer := tel.TracerProvider.Tracer("dormancy-service-trace")
+	dormancyService := dormancysrv.NewDormancyService(
+		db,
+		customerRepository,
+		dormancyRepository,
+		otpService,
+		notifierService,
+		dormancysrv.Config{
+			InactiveMonths: cfg.DORMANCY_INACTIVE_MONTHS,
+			BatchSize:      cfg.DORMANCY_BATCH,
+		},
+		dormancyServiceMeter,
+		dormancyServiceTracer,
+		serviceLog,
+	)
+
+	closureServiceMeter := tel.MeterProvider.Meter("closure-service-meter")
+	closureServiceTracer := tel.TracerProvider.Tracer("closure-service-trace")
+	closureService := closuresrv.NewAccountClosureService(
+		db,
+		customerRepository,
+		closureRepository,
+		notifierService,
+		closuresrv.Config{
+			CoolingOff: cfg.ACCOUNT_CLOSURE_COOLING_OFF,
+			BatchSize:  cfg.ACCOUNT_CLOSURE_BATCH,
+		},
+		closureServiceMeter,
+		closureServiceTracer,
+		serviceLog,
+	)
+
+	amlServiceMeter := tel.MeterProvider.Meter("aml-service-meter")
+	amlServiceTracer := tel.TracerProvider.Tracer("aml-service-trace")
+	amlService := amlsrv.NewAMLService(
+		db,
+		amlRepository,
+		amlsrv.Config{
+			LargeAmount: decimal.NewFromInt(int64(cfg.AML_LARGE_AMOUNT)),
+			RapidCount:  cfg.AML_RAPID_COUNT,
+			RapidWindow: cfg.AML_RAPID_WINDOW,
+			BatchSize:   cfg.AML_SCREENING_BATCH,
+		},
+		amlServiceMeter,
+		amlServiceTracer,
+		serviceLog,
+	)
+
+	collectionServiceMeter := tel.MeterProvider.Meter("collection-service-meter")
+	collectionServiceTracer := tel.TracerProvider.Tracer("collection-service-trace")
+	collectionService := collectionsrv.NewCollectionService(
+		collectionRepository,
+		customerRepository,
+		dueDateService,
+		collectionsrv.Config{
+			MinDaysPastDue: cfg.COLLECTION_MIN_DAYS_PAST_DUE,
+			BatchSize:      cfg.COLLECTION_BATCH,
+		},
+		collectionServiceMeter,
+		collectionServiceTracer,
+		serviceLog,
+	)
+
+	writeOffServiceMeter := tel.MeterProvider.Meter("write-off-service-meter")
+	writeOffServiceTracer := tel.TracerProvider.Tracer("write-off-service-trace")
+	writeOffService := writeoffsrv.NewWriteOffService(
+		db,
+		transactionRepository,
+		tenorRepository,
+		writeOffRepository,
+		ledgerRepository,
+		dueDateService,
+		writeoffsrv.Config{
+			MinDaysPastDue: cfg.WRITE_OFF_MIN_DAYS_PAST_DUE,
+		},
+		writeOffServiceMeter,
+		writeOffServiceTracer,
+		serviceLog,
+	)
+
+	partnerDebugServiceMeter := tel.MeterProvider.Meter("partner-debug-service-meter")
+	partnerDebugServiceTracer := tel.TracerProvider.Tracer("partner-debug-service-trace")
+	partnerDebugService := partnerdebugsrv.NewPartnerDebugService(
+		partnerDebugRepository,
+		partnerdebugsrv.Config{
+			Retention:    cfg.PARTNER_DEBUG_RETENTION,
+			Cap:          cfg.PARTNER_DEBUG_CAP,
+			MaxBodyBytes: cfg.PARTNER_DEBUG_MAX_BODY,
+			RedactFields: cfg.LogRedactFields(),
+		},
+		partnerDebugServiceMeter,
+		partnerDebugServiceTracer,
+		serviceLog,
+	)
+
+	mediaServiceMeter := tel.MeterProvider.Meter("media-service-meter")
+	mediaServiceTracer := tel.TracerProvider.Tracer("media-service-trace")
+	mediaService := mediasrv.NewMediaService(
+		cloudinaryService,
+		mediaRepository,
+		mediasrv.Config{
+			Folder:      "multifinance",
+			GracePeriod: cfg.MEDIA_CLEANUP_GRACE_PERIOD,
+			BatchSize:   cfg.MEDIA_CLEANUP_BATCH,
+		},
+		mediaServiceMeter,
+		mediaServiceTracer,
+		serviceLog,
+	)
+
+	portalServiceMeter := tel.MeterProvider.Meter("portal-service-meter")
+	portalServiceTracer := tel.TracerProvider.Tracer("portal-service-trace")
+	portalService := portalsrv.NewPartnerPortalService(
+		partnerRepository,
+		transactionRepository,
+		deliveryRepository,
+		apiUsageRepository,
+		portalServiceMeter,
+		portalServiceTracer,
+		serviceLog,
+	)
+
+	communicationServiceMeter := tel.MeterProvider.Meter("communication-service-meter")
+	communicationServiceTracer := tel.TracerProvider.Tracer("communication-service-trace")
+	communicationService := communicationsrv.NewCommunicationService(
+		communicationRepository,
+		customerRepository,
+		communicationServiceMeter,
+		communicationServiceTracer,
+		serviceLog,
+	)
+
+	pendingExpiryServiceMeter := tel.MeterProvider.Meter("pending-expiry-service-meter")
+	pendingExpiryServiceTracer := tel.TracerProvider.Tracer("pending-expiry-service-trace")
+	pendingExpiryService := pendingexpirysrv.NewPendingExpiryService(
+		pendingExpiryRepository,
+		notifierService,
+		pendingexpirysrv.Config{
+			RemindAfter: time.Duration(cfg.PENDING_REMIND_AFTER_DAYS) * 24 * time.Hour,
+			RejectAfter: time.Duration(cfg.PENDING_REJECT_AFTER_DAYS) * 24 * time.Hour,
+			BatchSize:   cfg.PENDING_EXPIRY_BATCH,
+		},
+		pendingExpiryServiceMeter,
+		pendingExpiryServiceTracer,
+		serviceLog,
+	)
+
 	// Handler
 	adminHandlerMeter := tel.MeterProvider.Meter("admin-handler-meter")
 	adminHandlerTracer := tel.TracerProvider.Tracer("admin-handler-trace")
@@ -131,16 +1599,17 @@ func NewPresenter(
 		adminService,
 		adminHandlerMeter,
 		adminHandlerTracer,
-		tel.Log,
+		handlerLog,
 	)
 
 	partnerHandlerMeter := tel.MeterProvider.Meter("partner-handler-meter")
 	partnerHandlerTracer := tel.TracerProvider.Tracer("partner-handler-trace")
 	partnerHandler := partnerhandler.NewPartnerHandler(
 		partnerService,
+		deliveryService,
 		partnerHandlerMeter,
 		partnerHandlerTracer,
-		tel.Log,
+		handlerLog,
 	)
 
 	profileHandlerMeter := tel.MeterProvider.Meter("profile-handler-meter")
@@ -150,7 +1619,7 @@ func NewPresenter(
 		cloudinaryService,
 		profileHandlerMeter,
 		profileHandlerTracer,
-		tel.Log,
+		handlerLog,
 	)
 
 	privateHandlerMeter := tel.MeterProvider.Meter("private-handler-meter")
@@ -160,13 +1629,710 @@ func NewPresenter(
 		store,
 		privateHandlerMeter,
 		privateHandlerTracer,
-		tel.Log,
+		handlerLog,
+	)
+
+	onboardingHandlerMeter := tel.MeterProvider.Meter("onboarding-handler-meter")
+	onboardingHandlerTracer := tel.TracerProvider.Tracer("onboarding-handler-trace")
+	onboardingHandler := onboardinghandler.NewOnboardingHandler(
+		onboardingService,
+		onboardingHandlerMeter,
+		onboardingHandlerTracer,
+		handlerLog,
+	)
+
+	deliveryHandlerMeter := tel.MeterProvider.Meter("delivery-handler-meter")
+	deliveryHandlerTracer := tel.TracerProvider.Tracer("delivery-handler-trace")
+	deliveryHandler := deliveryhandler.NewDeliveryHandler(
+		deliveryService,
+		deliveryHandlerMeter,
+		deliveryHandlerTracer,
+		handlerLog,
+	)
+
+	salaryChangeHandlerMeter := tel.MeterProvider.Meter("salary-change-handler-meter")
+	salaryChangeHandlerTracer := tel.TracerProvider.Tracer("salary-change-handler-trace")
+	salaryChangeHandler := salarychangehandler.NewSalaryChangeHandler(
+		salaryChangeService,
+		cloudinaryService,
+		salaryChangeHandlerMeter,
+		salaryChangeHandlerTracer,
+		handlerLog,
+	)
+
+	incomeHandlerMeter := tel.MeterProvider.Meter("income-handler-meter")
+	incomeHandlerTracer := tel.TracerProvider.Tracer("income-handler-trace")
+	incomeHandler := incomehandler.NewIncomeVerificationHandler(
+		incomeService,
+		incomeHandlerMeter,
+		incomeHandlerTracer,
+		handlerLog,
+	)
+
+	riskTierHandlerMeter := tel.MeterProvider.Meter("risk-tier-handler-meter")
+	riskTierHandlerTracer := tel.TracerProvider.Tracer("risk-tier-handler-trace")
+	riskTierHandler := risktierhandler.NewRiskTierHandler(
+		riskTierService,
+		riskTierHandlerMeter,
+		riskTierHandlerTracer,
+		handlerLog,
+	)
+
+	recommendationHandlerMeter := tel.MeterProvider.Meter("recommendation-handler-meter")
+	recommendationHandlerTracer := tel.TracerProvider.Tracer("recommendation-handler-trace")
+	recommendationHandler := recommendationhandler.NewRecommendationHandler(
+		recommendationService,
+		recommendationHandlerMeter,
+		recommendationHandlerTracer,
+		handlerLog,
+	)
+
+	blacklistHandlerMeter := tel.MeterProvider.Meter("blacklist-handler-meter")
+	blacklistHandlerTracer := tel.TracerProvider.Tracer("blacklist-handler-trace")
+	blacklistHandler := blacklisthandler.NewBlacklistHandler(
+		blacklistService,
+		blacklistHandlerMeter,
+		blacklistHandlerTracer,
+		handlerLog,
+	)
+
+	duplicateHandlerMeter := tel.MeterProvider.Meter("duplicate-handler-meter")
+	duplicateHandlerTracer := tel.TracerProvider.Tracer("duplicate-handler-trace")
+	duplicateHandler := duplicatehandler.NewDuplicateHandler(
+		duplicateService,
+		duplicateHandlerMeter,
+		duplicateHandlerTracer,
+		handlerLog,
+	)
+
+	restructuringHandlerMeter := tel.MeterProvider.Meter("restructuring-handler-meter")
+	restructuringHandlerTracer := tel.TracerProvider.Tracer("restructuring-handler-trace")
+	restructuringHandler := restructuringhandler.NewRestructuringHandler(
+		restructuringService,
+		restructuringHandlerMeter,
+		restructuringHandlerTracer,
+		handlerLog,
+	)
+
+	fxRateHandlerMeter := tel.MeterProvider.Meter("fx-rate-handler-meter")
+	fxRateHandlerTracer := tel.TracerProvider.Tracer("fx-rate-handler-trace")
+	fxRateHandler := fxratehandler.NewFxRateHandler(
+		fxRateService,
+		fxRateHandlerMeter,
+		fxRateHandlerTracer,
+		handlerLog,
 	)
 
+	reportHandlerMeter := tel.MeterProvider.Meter("report-handler-meter")
+	reportHandlerTracer := tel.TracerProvider.Tracer("report-handler-trace")
+	reportHandler := reporthandler.NewReportHandler(
+		reportService,
+		reportHandlerMeter,
+		reportHandlerTracer,
+		handlerLog,
+	)
+
+	communicationHandlerMeter := tel.MeterProvider.Meter("communication-handler-meter")
+	communicationHandlerTracer := tel.TracerProvider.Tracer("communication-handler-trace")
+	communicationHandler := communicationhandler.NewCommunicationHandler(
+		communicationService,
+		communicationHandlerMeter,
+		communicationHandlerTracer,
+		handlerLog,
+	)
+
+	featureFlagHandlerMeter := tel.MeterProvider.Meter("feature-flag-handler-meter")
+	featureFlagHandlerTracer := tel.TracerProvider.Tracer("feature-flag-handler-trace")
+	featureFlagHandler := featureflaghandler.NewFeatureFlagHandler(
+		featureFlagService,
+		featureFlagHandlerMeter,
+		featureFlagHandlerTracer,
+		handlerLog,
+	)
+
+	feeScheduleHandlerMeter := tel.MeterProvider.Meter("fee-schedule-handler-meter")
+	feeScheduleHandlerTracer := tel.TracerProvider.Tracer("fee-schedule-handler-trace")
+	feeScheduleHandler := feeschedulehandler.NewFeeScheduleHandler(
+		feeScheduleService,
+		feeScheduleHandlerMeter,
+		feeScheduleHandlerTracer,
+		handlerLog,
+	)
+
+	promotionHandlerMeter := tel.MeterProvider.Meter("promotion-handler-meter")
+	promotionHandlerTracer := tel.TracerProvider.Tracer("promotion-handler-trace")
+	promotionHandler := promotionhandler.NewPromotionHandler(
+		promotionService,
+		promotionHandlerMeter,
+		promotionHandlerTracer,
+		handlerLog,
+	)
+
+	referralHandlerMeter := tel.MeterProvider.Meter("referral-handler-meter")
+	referralHandlerTracer := tel.TracerProvider.Tracer("referral-handler-trace")
+	referralHandler := referralhandler.NewReferralHandler(
+		referralService,
+		referralHandlerMeter,
+		referralHandlerTracer,
+		handlerLog,
+	)
+
+	otpHandlerMeter := tel.MeterProvider.Meter("otp-handler-meter")
+	otpHandlerTracer := tel.TracerProvider.Tracer("otp-handler-trace")
+	otpHandler := otphandler.NewOTPHandler(
+		otpService,
+		otpHandlerMeter,
+		otpHandlerTracer,
+		handlerLog,
+	)
+
+	maintenanceHandlerMeter := tel.MeterProvider.Meter("maintenance-handler-meter")
+	maintenanceHandlerTracer := tel.TracerProvider.Tracer("maintenance-handler-trace")
+	maintenanceHandler := maintenancehandler.NewMaintenanceHandler(
+		maintenanceService,
+		maintenanceHandlerMeter,
+		maintenanceHandlerTracer,
+		handlerLog,
+	)
+
+	quotaHandlerMeter := tel.MeterProvider.Meter("quota-handler-meter")
+	quotaHandlerTracer := tel.TracerProvider.Tracer("quota-handler-trace")
+	quotaHandler := quotahandler.NewQuotaHandler(
+		quotaService,
+		quotaHandlerMeter,
+		quotaHandlerTracer,
+		handlerLog,
+	)
+
+	tenorHandlerMeter := tel.MeterProvider.Meter("tenor-handler-meter")
+	tenorHandlerTracer := tel.TracerProvider.Tracer("tenor-handler-trace")
+	tenorHandler := tenorhandler.NewTenorHandler(
+		tenorService,
+		tenorHandlerMeter,
+		tenorHandlerTracer,
+		handlerLog,
+	)
+
+	statementHandlerMeter := tel.MeterProvider.Meter("statement-handler-meter")
+	statementHandlerTracer := tel.TracerProvider.Tracer("statement-handler-trace")
+	statementHandler := statementhandler.NewStatementHandler(
+		statementService,
+		statementHandlerMeter,
+		statementHandlerTracer,
+		handlerLog,
+	)
+
+	calendarHandlerMeter := tel.MeterProvider.Meter("calendar-handler-meter")
+	calendarHandlerTracer := tel.TracerProvider.Tracer("calendar-handler-trace")
+	calendarHandler := calendarhandler.NewCalendarHandler(
+		calendarService,
+		calendarHandlerMeter,
+		calendarHandlerTracer,
+		handlerLog,
+	)
+
+	mobileHandlerMeter := tel.MeterProvider.Meter("mobile-handler-meter")
+	mobileHandlerTracer := tel.TracerProvider.Tracer("mobile-handler-trace")
+	mobileHandler := mobilehandler.NewMobileHandler(
+		mobileService,
+		mobileHandlerMeter,
+		mobileHandlerTracer,
+		handlerLog,
+	)
+
+	dueDateHandlerMeter := tel.MeterProvider.Meter("due-date-handler-meter")
+	dueDateHandlerTracer := tel.TracerProvider.Tracer("due-date-handler-trace")
+	dueDateHandler := duedatehandler.NewDueDateHandler(
+		dueDateService,
+		dueDateHandlerMeter,
+		dueDateHandlerTracer,
+		handlerLog,
+	)
+
+	emailHandlerMeter := tel.MeterProvider.Meter("email-handler-meter")
+	emailHandlerTracer := tel.TracerProvider.Tracer("email-handler-trace")
+	emailHandler := emailhandler.NewEmailHandler(
+		emailService,
+		emailHandlerMeter,
+		emailHandlerTracer,
+		handlerLog,
+	)
+
+	notificationHandlerMeter := tel.MeterProvider.Meter("notification-handler-meter")
+	notificationHandlerTracer := tel.TracerProvider.Tracer("notification-handler-trace")
+	notificationHandler := notificationhandler.NewNotificationHandler(
+		pushService,
+		preferenceService,
+		notificationHandlerMeter,
+		notificationHandlerTracer,
+		handlerLog,
+	)
+
+	batchHandlerMeter := tel.MeterProvider.Meter("batch-handler-meter")
+	batchHandlerTracer := tel.TracerProvider.Tracer("batch-handler-trace")
+	batchHandler := batchhandler.NewBatchHandler(
+		batchService,
+		batchHandlerMeter,
+		batchHandlerTracer,
+		handlerLog,
+	)
+
+	impersonationHandlerMeter := tel.MeterProvider.Meter("impersonation-handler-meter")
+	impersonationHandlerTracer := tel.TracerProvider.Tracer("impersonation-handler-trace")
+	impersonationHandler := impersonationhandler.NewImpersonationHandler(
+		impersonationService,
+		impersonationHandlerMeter,
+		impersonationHandlerTracer,
+		handlerLog,
+	)
+
+	directDebitHandlerMeter := tel.MeterProvider.Meter("direct-debit-handler-meter")
+	directDebitHandlerTracer := tel.TracerProvider.Tracer("direct-debit-handler-trace")
+	directDebitHandler := directdebithandler.NewDirectDebitHandler(
+		directDebitService,
+		directDebitHandlerMeter,
+		directDebitHandlerTracer,
+		handlerLog,
+	)
+
+	customerNoteHandlerMeter := tel.MeterProvider.Meter("customer-note-handler-meter")
+	customerNoteHandlerTracer := tel.TracerProvider.Tracer("customer-note-handler-trace")
+	customerNoteHandler := customernotehandler.NewCustomerNoteHandler(
+		customerNoteService,
+		customerNoteHandlerMeter,
+		customerNoteHandlerTracer,
+		handlerLog,
+	)
+
+	attachmentHandlerMeter := tel.MeterProvider.Meter("attachment-handler-meter")
+	attachmentHandlerTracer := tel.TracerProvider.Tracer("attachment-handler-trace")
+	attachmentHandler := attachmenthandler.NewAttachmentHandler(
+		attachmentService,
+		attachmentHandlerMeter,
+		attachmentHandlerTracer,
+		handlerLog,
+	)
+
+	regionHandlerMeter := tel.MeterProvider.Meter("region-handler-meter")
+	regionHandlerTracer := tel.TracerProvider.Tracer("region-handler-trace")
+	regionHandler := regionhandler.NewRegionHandler(
+		regionService,
+		regionHandlerMeter,
+		regionHandlerTracer,
+		handlerLog,
+	)
+
+	timelineHandlerMeter := tel.MeterProvider.Meter("timeline-handler-meter")
+	timelineHandlerTracer := tel.TracerProvider.Tracer("timeline-handler-trace")
+	timelineHandler := timelinehandler.NewCustomerTimelineHandler(
+		timelineService,
+		timelineHandlerMeter,
+		timelineHandlerTracer,
+		handlerLog,
+	)
+
+	contractHandlerMeter := tel.MeterProvider.Meter("contract-handler-meter")
+	contractHandlerTracer := tel.TracerProvider.Tracer("contract-handler-trace")
+	contractHandler := contracthandler.NewContractLookupHandler(
+		contractService,
+		contractHandlerMeter,
+		contractHandlerTracer,
+		handlerLog,
+	)
+	contractImportHandler := contracthandler.NewContractImportHandler(
+		contractImportService,
+		contractHandlerMeter,
+		contractHandlerTracer,
+		handlerLog,
+	)
+
+	graphQLHandlerMeter := tel.MeterProvider.Meter("graphql-handler-meter")
+	graphQLHandlerTracer := tel.TracerProvider.Tracer("graphql-handler-trace")
+	graphQLHandler := graphqlhandler.NewGraphQLHandler(
+		adminService,
+		profileService,
+		contractService,
+		reportService,
+		graphQLHandlerMeter,
+		graphQLHandlerTracer,
+		handlerLog,
+	)
+
+	exportHandlerMeter := tel.MeterProvider.Meter("export-handler-meter")
+	exportHandlerTracer := tel.TracerProvider.Tracer("export-handler-trace")
+	exportHandler := exporthandler.NewExportHandler(
+		exportService,
+		exportHandlerMeter,
+		exportHandlerTracer,
+		handlerLog,
+	)
+
+	restrictionHandlerMeter := tel.MeterProvider.Meter("restriction-handler-meter")
+	restrictionHandlerTracer := tel.TracerProvider.Tracer("restriction-handler-trace")
+	restrictionHandler := restrictionhandler.NewCustomerRestrictionHandler(
+		restrictionService,
+		restrictionHandlerMeter,
+		restrictionHandlerTracer,
+		handlerLog,
+	)
+
+	dormancyHandlerMeter := tel.MeterProvider.Meter("dormancy-handler-meter")
+	dormancyHandlerTracer := tel.TracerProvider.Tracer("dormancy-handler-trace")
+	dormancyHandler := dormancyhandler.NewDormancyHandler(
+		dormancyService,
+		dormancyHandlerMeter,
+		dormancyHandlerTracer,
+		handlerLog,
+	)
+
+	closureHandlerMeter := tel.MeterProvider.Meter("closure-handler-meter")
+	closureHandlerTracer := tel.TracerProvider.Tracer("closure-handler-trace")
+	closureHandler := closurehandler.NewAccountClosureHandler(
+		closureService,
+		store,
+		closureHandlerMeter,
+		closureHandlerTracer,
+		handlerLog,
+	)
+
+	amlHandlerMeter := tel.MeterProvider.Meter("aml-handler-meter")
+	amlHandlerTracer := tel.TracerProvider.Tracer("aml-handler-trace")
+	amlHandler := amlhandler.NewAMLHandler(
+		amlService,
+		amlHandlerMeter,
+		amlHandlerTracer,
+		handlerLog,
+	)
+
+	collectionHandlerMeter := tel.MeterProvider.Meter("collection-handler-meter")
+	collectionHandlerTracer := tel.TracerProvider.Tracer("collection-handler-trace")
+	collectionHandler := collectionhandler.NewCollectionHandler(
+		collectionService,
+		collectionHandlerMeter,
+		collectionHandlerTracer,
+		handlerLog,
+	)
+
+	writeOffHandlerMeter := tel.MeterProvider.Meter("write-off-handler-meter")
+	writeOffHandlerTracer := tel.TracerProvider.Tracer("write-off-handler-trace")
+	writeOffHandler := writeoffhandler.NewWriteOffHandler(
+		writeOffService,
+		writeOffHandlerMeter,
+		writeOffHandlerTracer,
+		handlerLog,
+	)
+
+	concentrationHandlerMeter := tel.MeterProvider.Meter("concentration-handler-meter")
+	concentrationHandlerTracer := tel.TracerProvider.Tracer("concentration-handler-trace")
+	concentrationHandler := concentrationhandler.NewConcentrationHandler(
+		concentrationService,
+		concentrationHandlerMeter,
+		concentrationHandlerTracer,
+		handlerLog,
+	)
+
+	dashboardHandlerMeter := tel.MeterProvider.Meter("dashboard-handler-meter")
+	dashboardHandlerTracer := tel.TracerProvider.Tracer("dashboard-handler-trace")
+	dashboardHandler := dashboardhandler.NewDashboardHandler(
+		dashboardService,
+		cfg.DASHBOARD_HEARTBEAT,
+		dashboardHandlerMeter,
+		dashboardHandlerTracer,
+		handlerLog,
+	)
+
+	tenantHandlerMeter := tel.MeterProvider.Meter("tenant-handler-meter")
+	tenantHandlerTracer := tel.TracerProvider.Tracer("tenant-handler-trace")
+	tenantHandler := tenanthandler.NewTenantHandler(
+		tenantHandlerMeter,
+		tenantHandlerTracer,
+		handlerLog,
+	)
+
+	partnerDebugHandlerMeter := tel.MeterProvider.Meter("partner-debug-handler-meter")
+	partnerDebugHandlerTracer := tel.TracerProvider.Tracer("partner-debug-handler-trace")
+	partnerDebugHandler := partnerdebughandler.NewPartnerDebugHandler(
+		partnerDebugService,
+		partnerDebugHandlerMeter,
+		partnerDebugHandlerTracer,
+		handlerLog,
+	)
+
+	logLevelHandlerMeter := tel.MeterProvider.Meter("log-level-handler-meter")
+	logLevelHandlerTracer := tel.TracerProvider.Tracer("log-level-handler-trace")
+	logLevelHandler := loglevelhandler.NewLogLevelHandler(
+		tel.LogLevels,
+		logLevelHandlerMeter,
+		logLevelHandlerTracer,
+		handlerLog,
+	)
+
+	portalHandlerMeter := tel.MeterProvider.Meter("portal-handler-meter")
+	portalHandlerTracer := tel.TracerProvider.Tracer("portal-handler-trace")
+	portalHandler := portalhandler.NewPartnerPortalHandler(
+		portalService,
+		portalHandlerMeter,
+		portalHandlerTracer,
+		handlerLog,
+	)
+
+	paymentHandlerMeter := tel.MeterProvider.Meter("payment-handler-meter")
+	paymentHandlerTracer := tel.TracerProvider.Tracer("payment-handler-trace")
+	paymentHandler := paymenthandler.NewPaymentHandler(
+		paymentService,
+		paymentHandlerMeter,
+		paymentHandlerTracer,
+		handlerLog,
+	)
+
+	// Job partisi hanya jalan bila tabel transactions memang dipartisi
+	var partitionInterval time.Duration
+	if cfg.TRANSACTION_PARTITIONING {
+		partitionInterval = cfg.PARTITION_INTERVAL
+	}
+
 	return Presenter{
 		AdminPresenter:   adminHandler,
 		PartnerPresenter: partnerHandler,
 		ProfilePresenter: profileHandler,
 		PrivatePresenter: privateHandler,
+
+		OnboardingPresenter:     onboardingHandler,
+		DeliveryPresenter:       deliveryHandler,
+		SalaryChangePresenter:   salaryChangeHandler,
+		IncomePresenter:         incomeHandler,
+		RiskTierPresenter:       riskTierHandler,
+		RecommendationPresenter: recommendationHandler,
+		BlacklistPresenter:      blacklistHandler,
+		DuplicatePresenter:      duplicateHandler,
+		RestructuringPresenter:  restructuringHandler,
+		FxRatePresenter:         fxRateHandler,
+		ReportPresenter:         reportHandler,
+		CommunicationPresenter:  communicationHandler,
+		FeatureFlagPresenter:    featureFlagHandler,
+		FeeSchedulePresenter:    feeScheduleHandler,
+		PaymentPresenter:        paymentHandler,
+		DirectDebitPresenter:    directDebitHandler,
+		PromotionPresenter:      promotionHandler,
+		ReferralPresenter:       referralHandler,
+		OTPPresenter:            otpHandler,
+		ImpersonationPresenter:  impersonationHandler,
+		MaintenancePresenter:    maintenanceHandler,
+		QuotaPresenter:          quotaHandler,
+		BatchPresenter:          batchHandler,
+		TenorPresenter:          tenorHandler,
+		StatementPresenter:      statementHandler,
+		CalendarPresenter:       calendarHandler,
+		MobilePresenter:         mobileHandler,
+		DueDatePresenter:        dueDateHandler,
+		EmailPresenter:          emailHandler,
+		NotificationPresenter:   notificationHandler,
+		CustomerNotePresenter:   customerNoteHandler,
+		AttachmentPresenter:     attachmentHandler,
+		RegionPresenter:         regionHandler,
+		TimelinePresenter:       timelineHandler,
+		PortalPresenter:         portalHandler,
+		RestrictionPresenter:    restrictionHandler,
+		DormancyPresenter:       dormancyHandler,
+		AccountClosurePresenter: closureHandler,
+		AMLPresenter:            amlHandler,
+		CollectionPresenter:     collectionHandler,
+		WriteOffPresenter:       writeOffHandler,
+		ConcentrationPresenter:  concentrationHandler,
+		DashboardPresenter:      dashboardHandler,
+		TenantPresenter:         tenantHandler,
+		LogLevelPresenter:       logLevelHandler,
+		PartnerDebugPresenter:   partnerDebugHandler,
+		ContractPresenter:       contractHandler,
+		ContractImportPresenter: contractImportHandler,
+		ExportPresenter:         exportHandler,
+		GraphQLPresenter:        graphQLHandler,
+		APIKeyAuth:              middleware.NewAPIKeyMiddleware(onboardingService),
+		RequestSignature:        middleware.NewRequestSignatureMiddleware(signatureService),
+		ImpersonationAudit:      middleware.NewImpersonationMiddleware(impersonationService),
+		AccountClosureGate:      middleware.NewAccountClosureMiddleware(closureService),
+		PartnerTransactionGate:  middleware.NewMaintenanceGateMiddleware(maintenanceService, domain.GatePartnerTransactions),
+		DebugRecorder:           middleware.NewDebugRecorderMiddleware(partnerDebugService),
+
+		Jobs: []job.Job{
+			{
+				Name:     "pending-expiry",
+				Interval: cfg.PENDING_EXPIRY_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := pendingExpiryService.Run(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "aging-snapshot",
+				Interval: cfg.AGING_SNAPSHOT_INTERVAL,
+				Run: func(ctx context.Context) error {
+					return reportService.RefreshAgingSnapshot(ctx, time.Now())
+				},
+			},
+			{
+				Name:     "virtual-account-provisioning",
+				Interval: cfg.VIRTUAL_ACCOUNT_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := virtualAccountService.ProvisionPending(ctx)
+					return err
+				},
+			},
+			{
+				Name:     "direct-debit",
+				Interval: cfg.DIRECT_DEBIT_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := directDebitService.Run(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "transaction-batch",
+				Interval: cfg.TRANSACTION_BATCH_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := batchService.ProcessPending(ctx)
+					return err
+				},
+			},
+			{
+				Name:     "statement-render",
+				Interval: cfg.STATEMENT_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := statementService.ProcessPending(ctx)
+					return err
+				},
+			},
+			{
+				Name:     "email-dispatch",
+				Interval: cfg.EMAIL_DISPATCH_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := emailService.ProcessPending(ctx)
+					return err
+				},
+			},
+			{
+				Name:     "payment-reminder",
+				Interval: cfg.PAYMENT_REMINDER_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := paymentReminderService.Run(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "exposure-reconcile",
+				Interval: cfg.EXPOSURE_RECONCILE_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := exposureService.Reconcile(ctx)
+					return err
+				},
+			},
+			{
+				Name:     "restriction-expiry",
+				Interval: cfg.RESTRICTION_EXPIRY_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := restrictionService.ExpireDue(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "dormancy",
+				Interval: cfg.DORMANCY_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := dormancyService.Run(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "risk-tier",
+				Interval: cfg.RISK_TIER_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := riskTierService.Recalculate(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "account-anonymization",
+				Interval: cfg.ACCOUNT_CLOSURE_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := closureService.AnonymizeDue(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "aml-screening",
+				Interval: cfg.AML_SCREENING_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := amlService.Screen(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "collection-cases",
+				Interval: cfg.COLLECTION_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := collectionService.OpenCases(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "concentration-limits",
+				Interval: cfg.CONCENTRATION_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := concentrationService.Evaluate(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				// Tiap instance membaca event sendiri untuk pelanggan stream-nya
+				Name:     "dashboard-refresh",
+				Interval: cfg.DASHBOARD_REFRESH_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := dashboardService.Refresh(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "partner-debug-purge",
+				Interval: cfg.PARTNER_DEBUG_PURGE_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := partnerDebugService.Purge(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "media-cleanup",
+				Interval: cfg.MEDIA_CLEANUP_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := mediaService.CleanupOrphans(ctx, time.Now())
+					return err
+				},
+			},
+			{
+				Name:     "transaction-partitions",
+				Interval: partitionInterval,
+				Run: func(ctx context.Context) error {
+					_, err := model.ExtendTransactionPartitions(ctx, db, time.Now(), cfg.TRANSACTION_PARTITIONS_AHEAD)
+					return err
+				},
+			},
+			{
+				Name:     "monthly-statement",
+				Interval: cfg.MONTHLY_STATEMENT_INTERVAL,
+				Run: func(ctx context.Context) error {
+					_, err := statementService.SendMonthlyStatements(ctx, time.Now())
+					return err
+				},
+			},
+		},
+		ProcessJobs: []job.Job{
+			{
+				// Key cache sudah memuat tenant, satu langganan melayani semuanya
+				Name:     "cache-invalidation",
+				Interval: cfg.CACHE_RESUBSCRIBE_INTERVAL,
+				Run: func(ctx context.Context) error {
+					return cache.Listen(ctx, redisClient, tenorCache, tenorCatalogCache, featureFlagCache, dueDateCalendarCache)
+				},
+			},
+		},
 	}
 }
@@ -2,23 +2,30 @@ package router
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/fazamuttaqien/multifinance/config"
 	mysqldb "github.com/fazamuttaqien/multifinance/infra/mysql"
 	"github.com/fazamuttaqien/multifinance/internal/domain"
 	"github.com/fazamuttaqien/multifinance/middleware"
+	"github.com/fazamuttaqien/multifinance/pkg/apiversion"
+	"github.com/fazamuttaqien/multifinance/pkg/datetime"
 	ratelimiter "github.com/fazamuttaqien/multifinance/pkg/rate-limiter"
+	"github.com/fazamuttaqien/multifinance/pkg/responder"
+	"github.com/fazamuttaqien/multifinance/pkg/slo"
 	"github.com/fazamuttaqien/multifinance/pkg/telemetry"
+	"github.com/fazamuttaqien/multifinance/pkg/tenancy"
 	"github.com/fazamuttaqien/multifinance/presenter"
 
 	"github.com/gofiber/contrib/otelfiber/v2"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/helmet"
+	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/gofiber/fiber/v2/utils"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -31,16 +38,55 @@ func NewRouter(
 	cfg *config.Config,
 	limiter *ratelimiter.RateLimiter,
 	store *session.Store,
+	tenants *tenancy.Registry,
 ) *fiber.App {
 
 	jwtAuth := middleware.NewJWTAuthMiddleware(cfg.JWT_SECRET_KEY)
+	passwordChangeAuth := middleware.NewPasswordChangeAuthMiddleware(cfg.JWT_SECRET_KEY)
+	optionalJWTAuth := middleware.NewOptionalJWTAuthMiddleware(cfg.JWT_SECRET_KEY)
 	customCSRF := middleware.NewCustomCSRFMiddleware(store)
 	requireAdmin := middleware.RequireRole(domain.AdminRole)
 	requireCustomer := middleware.RequireRole(domain.CustomerRole)
+	partnerNetwork := middleware.NewPartnerNetworkMiddleware()
+	// Partner API key dibatasi per partner, bukan per IP, dan counternya
+	// dilaporkan kembali ke partner lewat /partner-api/me/usage
+	partnerRateLimit := limiter.KeyedRateLimitMiddleware(func(c *fiber.Ctx) string {
+		partner, _, err := middleware.GetPartnerFromLocals(c)
+		if err != nil {
+			return ""
+		}
+		return tenancy.Key(c.UserContext(), ratelimiter.PartnerKey(partner.ID))
+	})
+	bodyLimit := middleware.NewBodyLimitMiddleware(middleware.BodyLimitConfig{
+		MaxBytes:          cfg.BODY_LIMIT_JSON,
+		MultipartMaxBytes: cfg.BODY_LIMIT_UPLOAD,
+		MaxJSONDepth:      cfg.JSON_MAX_DEPTH,
+	})
 	// requirePartner := middleware.RequireRole(domain.PartnerRole)
 
+	// BUSINESS_TIMEZONE sudah divalidasi saat bootstrap
+	businessLocation, err := time.LoadLocation(cfg.BUSINESS_TIMEZONE)
+	if err != nil {
+		businessLocation = time.UTC
+	}
+	localize := datetime.Middleware(businessLocation, string(domain.DefaultLanguage), string(domain.LanguageEnglish))
+
+	// Deadline per kelompok route, registrasi lebih longgar karena ada upload dokumen
+	checkLimitTimeout := middleware.NewTimeoutMiddleware("check-limit", cfg.REQUEST_TIMEOUT_CHECK_LIMIT)
+	transactionTimeout := middleware.NewTimeoutMiddleware("transaction", cfg.REQUEST_TIMEOUT_TRANSACTION)
+	registrationTimeout := middleware.NewTimeoutMiddleware("registration", cfg.REQUEST_TIMEOUT_REGISTRATION)
+
+	// SLI endpoint partner, burn rate dan alert-nya diekspor via OTel
+	partnerSLO := func(c *fiber.Ctx) error { return c.Next() }
+	tracker, err := slo.New(otel.GetMeterProvider().Meter("slo"), partnerObjectives(cfg), nil)
+	if err != nil {
+		zap.L().Error("Partner SLO tracking is disabled", zap.Error(err))
+	} else {
+		partnerSLO = tracker.Middleware()
+	}
+
 	app := fiber.New(fiber.Config{
-		BodyLimit:    10 * 1024 * 1024,
+		BodyLimit:    max(cfg.BODY_LIMIT_JSON, cfg.BODY_LIMIT_UPLOAD),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -49,14 +95,18 @@ func NewRouter(
 
 	// 1. Recovery dari panic
 	app.Use(recover.New(recover.Config{EnableStackTrace: true}))
-	// 2. Security Headers
-	app.Use(helmet.New())
-	// 3. CORS
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "http://localhost:5000",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-User-Id, X-User-Email, X-User-Name",
-		AllowMethods: "GET, POST, PUT, DELETE, PATCH, OPTIONS",
-	}))
+	// 2. Request ID, dikembalikan di header X-Request-ID dan meta.request_id
+	app.Use(requestid.New())
+	security := middleware.SecurityConfig{
+		AllowOrigins:     cfg.CORSOrigins(),
+		AllowCredentials: cfg.CORS_ALLOW_CREDENTIALS,
+		HSTSMaxAge:       cfg.HSTS_MAX_AGE,
+		HSTSPreload:      cfg.HSTS_PRELOAD,
+	}
+	// 3. Security Headers
+	app.Use(middleware.NewSecurityHeadersMiddleware(security))
+	// 4. CORS, origin diatur lewat CORS_ALLOW_ORIGINS
+	app.Use(middleware.NewCORSMiddleware(security))
 
 	// (Opsional, karena punya Zap)
 	app.Use(logger.New(logger.Config{
@@ -67,7 +117,20 @@ func NewRouter(
 	app.Use(otelfiber.Middleware(
 		otelfiber.WithTracerProvider(tel.TracerProvider),
 		otelfiber.WithPropagators(otel.GetTextMapPropagator()),
+		// Ekspor CSV dan dashboard realtime di-stream; otelfiber membaca body
+		// respons untuk ukurannya sehingga seluruh stream akan tertampung di
+		// memori. Handler keduanya membuat span dan metriknya sendiri.
+		otelfiber.WithNext(func(c *fiber.Ctx) bool {
+			path := strings.TrimSuffix(c.Path(), "/")
+			return strings.HasSuffix(path, "/admin/customers/export") || strings.HasSuffix(path, "/admin/transactions/export") ||
+				strings.HasSuffix(path, "/admin/dashboard/stream")
+		}),
 	))
+	// Tenant ditentukan sebelum handler atau middleware apa pun menyentuh data
+	app.Use(middleware.NewTenantMiddleware(tenants))
+	if cfg.MYSQL_QUERY_COMMENTS {
+		app.Use(middleware.NewQueryCommentMiddleware())
+	}
 
 	if !cfg.REQUESTS_METRIC {
 		zap.L().Info("Enabling HTTP request metrics middleware")
@@ -93,66 +156,372 @@ func NewRouter(
 		})
 	})
 
-	api := app.Group("/api/v1")
+	// Callback payment gateway tidak berversi karena URL-nya didaftarkan di
+	// dashboard provider, keasliannya dijamin tanda tangan bukan session
+	app.Post("/callbacks/payments/:provider", bodyLimit, presenter.PaymentPresenter.HandleCallback)
 
-	api.Use(limiter.RateLimitMiddleware())
-
-	authAPI := api.Group("/auth")
-	{
-		authAPI.Post("/register", customCSRF, presenter.ProfilePresenter.Register)
-		authAPI.Post("/login", presenter.PrivatePresenter.Login)
-		authAPI.Post("/logout", jwtAuth, customCSRF, presenter.PrivatePresenter.Logout)
-		authAPI.Get("/csrf-token", func(c *fiber.Ctx) error {
-			sess, err := store.Get(c)
-			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Session error"})
-			}
-
-			token := sess.Get("csrf_token")
-			if token == nil {
-				newToken, err := middleware.GenerateCSRFToken()
+	// Semua versi memakai route yang sama, perbedaan kontrak ditangani per DTO
+	// lewat apiversion.Map
+	routes := func(api fiber.Router) {
+		authAPI := api.Group("/auth")
+		{
+			authAPI.Post("/register", registrationTimeout, customCSRF, presenter.ProfilePresenter.Register)
+			authAPI.Post("/login", presenter.PrivatePresenter.Login)
+			authAPI.Post("/logout", passwordChangeAuth, customCSRF, presenter.PrivatePresenter.Logout)
+			// Satu-satunya jalan keluar untuk akun yang wajib mengganti password,
+			// termasuk admin hasil seed yang tidak punya route /me
+			authAPI.Put("/password", passwordChangeAuth, customCSRF, presenter.PrivatePresenter.ChangePassword)
+			authAPI.Get("/csrf-token", func(c *fiber.Ctx) error {
+				sess, err := store.Get(c)
 				if err != nil {
-					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate CSRF token"})
+					return responder.Fail(c, fiber.StatusInternalServerError, "session_error", "Session error")
 				}
-				sess.Set("csrf_token", newToken)
-				sess.Save()
-				token = newToken
-			}
-			return c.JSON(fiber.Map{"csrf_token": token})
-		})
-	}
 
-	customersAPI := api.Group("/me", jwtAuth, requireCustomer)
-	{
-		customersAPI.Get("/profile", presenter.ProfilePresenter.GetMyProfile)
-		customersAPI.Put("/profile", customCSRF, presenter.ProfilePresenter.UpdateMyProfile)
-		customersAPI.Put("/profile", presenter.ProfilePresenter.UpdateMyProfile)
-		customersAPI.Get("/limits", presenter.ProfilePresenter.GetMyLimits)
-		customersAPI.Get("/transactions", presenter.ProfilePresenter.GetMyTransactions)
-	}
+				token := sess.Get("csrf_token")
+				if token == nil {
+					newToken, err := middleware.GenerateCSRFToken()
+					if err != nil {
+						return responder.Fail(c, fiber.StatusInternalServerError, "csrf_error", "Failed to generate CSRF token")
+					}
+					sess.Set("csrf_token", newToken)
+					sess.Save()
+					token = newToken
+				}
+				return responder.Success(c, fiber.StatusOK, fiber.Map{"csrf_token": token})
+			})
+		}
 
-	adminAPI := api.Group("/admin", jwtAuth, customCSRF, requireAdmin)
+		// OTP registrasi diminta sebelum login, tujuan lain butuh cookie customer
+		otpAPI := api.Group("/otp", optionalJWTAuth, presenter.ImpersonationAudit, customCSRF)
+		{
+			otpAPI.Post("/request", presenter.OTPPresenter.Request)
+			otpAPI.Post("/verify", presenter.OTPPresenter.Verify)
+		}
 
-	adminCustomersAPI := adminAPI.Group("/customers")
-	{
-		adminCustomersAPI.Post("/:customerId/limits", presenter.AdminPresenter.SetLimits)
-		adminCustomersAPI.Get("/", presenter.AdminPresenter.ListCustomers)
-		adminCustomersAPI.Get("/:customerId", presenter.AdminPresenter.GetCustomerByID)
-		adminCustomersAPI.Post("/:customerId/verify", presenter.AdminPresenter.VerifyCustomer)
+		// Branding white-label dibutuhkan halaman login, jadi tanpa autentikasi
+		api.Get("/tenant", presenter.TenantPresenter.Branding)
+
+		// Aplikasi kalender tidak membawa cookie login, feed diotorisasi token di
+		// URL-nya sehingga didaftarkan sebelum grup /me beserta middleware-nya
+		api.Get("/me/transactions/:contractNumber/calendar.ics", presenter.CalendarPresenter.Feed)
+
+		customersAPI := api.Group("/me", jwtAuth, presenter.ImpersonationAudit, presenter.AccountClosureGate, requireCustomer, localize)
+		{
+			customersAPI.Get("/profile", presenter.ProfilePresenter.GetMyProfile)
+			customersAPI.Put("/profile", customCSRF, presenter.ProfilePresenter.UpdateMyProfile)
+			customersAPI.Put("/profile", presenter.ProfilePresenter.UpdateMyProfile)
+			customersAPI.Get("/limits", presenter.ProfilePresenter.GetMyLimits)
+			customersAPI.Get("/transactions", presenter.ProfilePresenter.GetMyTransactions)
+			customersAPI.Get("/transactions/:contractNumber", presenter.ProfilePresenter.GetMyTransactionDetail)
+			customersAPI.Get("/transactions/:contractNumber/statement", presenter.StatementPresenter.GetStatement)
+			customersAPI.Post("/transactions/:contractNumber/calendar-link", customCSRF, presenter.CalendarPresenter.CreateLink)
+			customersAPI.Delete("/transactions/:contractNumber/calendar-link", customCSRF, presenter.CalendarPresenter.RevokeLink)
+			customersAPI.Post("/salary-changes", customCSRF, presenter.SalaryChangePresenter.RequestChange)
+			customersAPI.Post("/income-verifications", customCSRF, presenter.IncomePresenter.Upload)
+			customersAPI.Get("/income-verifications", presenter.IncomePresenter.ListMine)
+			customersAPI.Get("/direct-debit", presenter.DirectDebitPresenter.GetMandate)
+			customersAPI.Put("/direct-debit", customCSRF, presenter.DirectDebitPresenter.SetMandate)
+			customersAPI.Delete("/direct-debit", customCSRF, presenter.DirectDebitPresenter.DeleteMandate)
+			customersAPI.Post("/devices", customCSRF, presenter.NotificationPresenter.RegisterDevice)
+			customersAPI.Delete("/devices", customCSRF, presenter.NotificationPresenter.UnregisterDevice)
+			customersAPI.Get("/notification-preferences", presenter.NotificationPresenter.GetPreferences)
+			customersAPI.Put("/notification-preferences", customCSRF, presenter.NotificationPresenter.SetPreferences)
+			customersAPI.Get("/referral-code", presenter.ReferralPresenter.GetMyCode)
+			customersAPI.Put("/monthly-statement", customCSRF, presenter.StatementPresenter.SetMonthlyStatement)
+			customersAPI.Put("/password", customCSRF, presenter.PrivatePresenter.ChangePassword)
+			customersAPI.Post("/reactivate", customCSRF, presenter.DormancyPresenter.Reactivate)
+			customersAPI.Post("/close-account", customCSRF, presenter.AccountClosurePresenter.CloseAccount)
+		}
+
+		// Satu endpoint baca untuk admin dan customer; field yang boleh dibaca
+		// tiap peran diperiksa di resolver masing-masing. GET melayani query
+		// yang sama tanpa CSRF, termasuk untuk sesi impersonasi yang read-only
+		api.Post("/graphql", jwtAuth, presenter.ImpersonationAudit, presenter.AccountClosureGate, customCSRF,
+			middleware.RequireRole(domain.AdminRole, domain.CustomerRole), localize, presenter.GraphQLPresenter.Query)
+		api.Get("/graphql", jwtAuth, presenter.ImpersonationAudit, presenter.AccountClosureGate,
+			middleware.RequireRole(domain.AdminRole, domain.CustomerRole), localize, presenter.GraphQLPresenter.Query)
+
+		adminAPI := api.Group("/admin", jwtAuth, customCSRF, requireAdmin)
+
+		adminCustomersAPI := adminAPI.Group("/customers")
+		{
+			adminCustomersAPI.Post("/:customerId/limits", presenter.AdminPresenter.SetLimits)
+			adminCustomersAPI.Get("/:customerId/limits", presenter.AdminPresenter.GetLimitsAt)
+			adminCustomersAPI.Get("/:customerId/limit-history", presenter.AdminPresenter.GetLimitHistory)
+			adminCustomersAPI.Get("/", presenter.AdminPresenter.ListCustomers)
+			adminCustomersAPI.Get("/export", presenter.ExportPresenter.ExportCustomers)
+			adminCustomersAPI.Get("/:customerId", presenter.AdminPresenter.GetCustomerByID)
+			adminCustomersAPI.Get("/:customerId/limit-recommendations", presenter.RecommendationPresenter.RecommendLimits)
+			adminCustomersAPI.Get("/:customerId/communications", presenter.CommunicationPresenter.ListByCustomer)
+			adminCustomersAPI.Get("/:customerId/timeline", presenter.TimelinePresenter.GetTimeline)
+			adminCustomersAPI.Get("/:customerId/possible-duplicates", presenter.DuplicatePresenter.PossibleDuplicates)
+			adminCustomersAPI.Post("/:customerId/possible-duplicates/:duplicateId/resolve", presenter.DuplicatePresenter.Resolve)
+			adminCustomersAPI.Post("/:customerId/verify", presenter.AdminPresenter.VerifyCustomer)
+			adminCustomersAPI.Get("/:customerId/income-verifications", presenter.IncomePresenter.ListByCustomer)
+			adminCustomersAPI.Get("/:customerId/risk-tier", presenter.RiskTierPresenter.GetCustomerTier)
+			adminCustomersAPI.Put("/:customerId/credit-score", presenter.RiskTierPresenter.SetCreditScore)
+			adminCustomersAPI.Post("/:customerId/impersonate", presenter.ImpersonationPresenter.Start)
+			adminCustomersAPI.Get("/:customerId/notes", presenter.CustomerNotePresenter.ListNotes)
+			adminCustomersAPI.Post("/:customerId/notes", presenter.CustomerNotePresenter.CreateNote)
+			adminCustomersAPI.Put("/:customerId/notes/:noteId", presenter.CustomerNotePresenter.UpdateNote)
+			adminCustomersAPI.Get("/:customerId/restrictions", presenter.RestrictionPresenter.ListRestrictions)
+			adminCustomersAPI.Post("/:customerId/suspension", presenter.RestrictionPresenter.Suspend)
+			adminCustomersAPI.Delete("/:customerId/suspension", presenter.RestrictionPresenter.Unsuspend)
+			adminCustomersAPI.Post("/:customerId/limits/:tenorMonths/freeze", presenter.RestrictionPresenter.FreezeLimit)
+			adminCustomersAPI.Delete("/:customerId/limits/:tenorMonths/freeze", presenter.RestrictionPresenter.UnfreezeLimit)
+		}
+
+		adminTenorsAPI := adminAPI.Group("/tenors")
+		{
+			adminTenorsAPI.Get("/", presenter.TenorPresenter.ListTenors)
+			adminTenorsAPI.Post("/", presenter.TenorPresenter.CreateTenor)
+			adminTenorsAPI.Put("/:id", presenter.TenorPresenter.UpdateTenor)
+			adminTenorsAPI.Post("/:id/deactivate", presenter.TenorPresenter.DeactivateTenor)
+			adminTenorsAPI.Delete("/:id", presenter.TenorPresenter.DeleteTenor)
+		}
+
+		adminTransactionsAPI := adminAPI.Group("/transactions")
+		{
+			adminTransactionsAPI.Get("/by-contract/:number", presenter.ContractPresenter.FindByContractNumber)
+			adminTransactionsAPI.Post("/import", presenter.ContractImportPresenter.ImportContracts)
+			adminTransactionsAPI.Get("/export", presenter.ExportPresenter.ExportTransactions)
+			adminTransactionsAPI.Get("/:id/attachments", presenter.AttachmentPresenter.ListAllAttachments)
+			adminTransactionsAPI.Delete("/:id/attachments/:attachmentId", presenter.AttachmentPresenter.DeleteAttachment)
+		}
+
+		adminImpersonationsAPI := adminAPI.Group("/impersonations")
+		{
+			adminImpersonationsAPI.Delete("/:id", presenter.ImpersonationPresenter.End)
+			adminImpersonationsAPI.Get("/:id/requests", presenter.ImpersonationPresenter.ListRequests)
+		}
+
+		adminPartnersAPI := adminAPI.Group("/partners")
+		{
+			adminPartnersAPI.Post("/:partnerId/promote", presenter.OnboardingPresenter.Promote)
+			adminPartnersAPI.Get("/:partnerId/security", presenter.OnboardingPresenter.GetSecurity)
+			adminPartnersAPI.Put("/:partnerId/security", presenter.OnboardingPresenter.UpdateSecurity)
+			adminPartnersAPI.Get("/:partnerId/fee-schedules", presenter.FeeSchedulePresenter.ListSchedules)
+			adminPartnersAPI.Put("/:partnerId/fee-schedules", presenter.FeeSchedulePresenter.SetSchedule)
+			adminPartnersAPI.Delete("/:partnerId/fee-schedules/:tenorMonths", presenter.FeeSchedulePresenter.DeleteSchedule)
+			adminPartnersAPI.Get("/:partnerId/quota", presenter.QuotaPresenter.GetQuota)
+			adminPartnersAPI.Put("/:partnerId/quota", presenter.QuotaPresenter.SetQuota)
+			adminPartnersAPI.Delete("/:partnerId/quota", presenter.QuotaPresenter.DeleteQuota)
+			adminPartnersAPI.Put("/:partnerId/region", presenter.RegionPresenter.SetPartnerRegion)
+		}
+
+		adminDeliveriesAPI := adminAPI.Group("/deliveries")
+		{
+			adminDeliveriesAPI.Get("/", presenter.DeliveryPresenter.ListDeliveries)
+			adminDeliveriesAPI.Post("/:id/replay", presenter.DeliveryPresenter.Replay)
+		}
+
+		adminSalaryChangesAPI := adminAPI.Group("/salary-changes")
+		{
+			adminSalaryChangesAPI.Get("/", presenter.SalaryChangePresenter.ListChanges)
+			adminSalaryChangesAPI.Post("/:id/review", presenter.SalaryChangePresenter.Review)
+		}
+
+		adminIncomeVerificationsAPI := adminAPI.Group("/income-verifications")
+		{
+			adminIncomeVerificationsAPI.Get("/", presenter.IncomePresenter.ListVerifications)
+			adminIncomeVerificationsAPI.Post("/:id/review", presenter.IncomePresenter.Review)
+		}
+
+		adminRiskTierRulesAPI := adminAPI.Group("/risk-tier-rules")
+		{
+			adminRiskTierRulesAPI.Get("/", presenter.RiskTierPresenter.ListRules)
+			adminRiskTierRulesAPI.Post("/", presenter.RiskTierPresenter.CreateRule)
+			adminRiskTierRulesAPI.Put("/:id", presenter.RiskTierPresenter.UpdateRule)
+			adminRiskTierRulesAPI.Delete("/:id", presenter.RiskTierPresenter.DeleteRule)
+		}
+
+		adminRestructuringsAPI := adminAPI.Group("/restructurings")
+		{
+			adminRestructuringsAPI.Get("/", presenter.RestructuringPresenter.ListRestructurings)
+			adminRestructuringsAPI.Post("/", presenter.RestructuringPresenter.Propose)
+			adminRestructuringsAPI.Get("/:id", presenter.RestructuringPresenter.GetRestructuring)
+			adminRestructuringsAPI.Post("/:id/review", presenter.RestructuringPresenter.Review)
+		}
+
+		adminFxRatesAPI := adminAPI.Group("/fx-rates")
+		{
+			adminFxRatesAPI.Get("/", presenter.FxRatePresenter.ListRates)
+			adminFxRatesAPI.Post("/", presenter.FxRatePresenter.UploadRates)
+		}
+
+		adminFeatureFlagsAPI := adminAPI.Group("/feature-flags")
+		{
+			adminFeatureFlagsAPI.Get("/", presenter.FeatureFlagPresenter.ListFlags)
+			adminFeatureFlagsAPI.Put("/:key", presenter.FeatureFlagPresenter.SetFlag)
+			adminFeatureFlagsAPI.Delete("/:key", presenter.FeatureFlagPresenter.DeleteFlag)
+		}
+
+		adminDueDatesAPI := adminAPI.Group("/due-dates")
+		{
+			adminDueDatesAPI.Get("/policy", presenter.DueDatePresenter.GetPolicy)
+			adminDueDatesAPI.Put("/policy", presenter.DueDatePresenter.SetPolicy)
+			adminDueDatesAPI.Get("/holidays", presenter.DueDatePresenter.ListHolidays)
+			adminDueDatesAPI.Put("/holidays/:date", presenter.DueDatePresenter.SetHoliday)
+			adminDueDatesAPI.Delete("/holidays/:date", presenter.DueDatePresenter.DeleteHoliday)
+		}
+
+		adminEmailTemplatesAPI := adminAPI.Group("/email-templates")
+		{
+			adminEmailTemplatesAPI.Post("/:template/preview", presenter.EmailPresenter.PreviewTemplate)
+		}
+
+		adminMaintenanceAPI := adminAPI.Group("/maintenance")
+		{
+			adminMaintenanceAPI.Get("/", presenter.MaintenancePresenter.ListModes)
+			adminMaintenanceAPI.Put("/:scope", presenter.MaintenancePresenter.SetMode)
+			adminMaintenanceAPI.Delete("/:scope", presenter.MaintenancePresenter.ClearMode)
+		}
+
+		adminPromotionsAPI := adminAPI.Group("/promotions")
+		{
+			adminPromotionsAPI.Get("/", presenter.PromotionPresenter.ListPromotions)
+			adminPromotionsAPI.Put("/:code", presenter.PromotionPresenter.SetPromotion)
+			adminPromotionsAPI.Delete("/:code", presenter.PromotionPresenter.DeactivatePromotion)
+		}
+
+		adminRegionsAPI := adminAPI.Group("/regions")
+		{
+			adminRegionsAPI.Get("/", presenter.RegionPresenter.ListRegions)
+			adminRegionsAPI.Put("/:code", presenter.RegionPresenter.SetRegion)
+		}
+
+		adminReportsAPI := adminAPI.Group("/reports")
+		{
+			adminReportsAPI.Get("/interest-accrual", presenter.ReportPresenter.InterestAccrual)
+			adminReportsAPI.Get("/aging", presenter.ReportPresenter.Aging)
+			adminReportsAPI.Get("/partner-commission", presenter.ReportPresenter.PartnerCommission)
+			adminReportsAPI.Get("/referrals", presenter.ReportPresenter.ReferralConversion)
+			adminReportsAPI.Get("/regions", presenter.ReportPresenter.RegionPerformance)
+			adminReportsAPI.Get("/dormancy", presenter.DormancyPresenter.Stats)
+		}
+
+		adminLogLevelsAPI := adminAPI.Group("/log-levels")
+		{
+			adminLogLevelsAPI.Get("/", presenter.LogLevelPresenter.ListLevels)
+			adminLogLevelsAPI.Put("/:module", presenter.LogLevelPresenter.SetLevel)
+		}
+
+		adminAMLAPI := adminAPI.Group("/aml-cases")
+		{
+			adminAMLAPI.Get("/", presenter.AMLPresenter.ListCases)
+			adminAMLAPI.Get("/export", presenter.AMLPresenter.Export)
+			adminAMLAPI.Get("/:id", presenter.AMLPresenter.GetCase)
+			adminAMLAPI.Put("/:id/status", presenter.AMLPresenter.UpdateStatus)
+		}
+
+		adminCollectionsAPI := adminAPI.Group("/collections")
+		{
+			adminCollectionsAPI.Get("/dashboard", presenter.CollectionPresenter.Dashboard)
+			adminCollectionsAPI.Get("/cases", presenter.CollectionPresenter.ListCases)
+			adminCollectionsAPI.Get("/cases/:id", presenter.CollectionPresenter.GetCase)
+			adminCollectionsAPI.Put("/cases/:id/assignee", presenter.CollectionPresenter.Assign)
+			adminCollectionsAPI.Put("/cases/:id/status", presenter.CollectionPresenter.UpdateStatus)
+			adminCollectionsAPI.Get("/cases/:id/activities", presenter.CollectionPresenter.ListActivities)
+			adminCollectionsAPI.Post("/cases/:id/activities", presenter.CollectionPresenter.AddActivity)
+		}
+
+		adminWriteOffsAPI := adminAPI.Group("/write-offs")
+		{
+			adminWriteOffsAPI.Get("/", presenter.WriteOffPresenter.ListWriteOffs)
+			adminWriteOffsAPI.Post("/", presenter.WriteOffPresenter.Propose)
+			adminWriteOffsAPI.Get("/report", presenter.WriteOffPresenter.Report)
+			adminWriteOffsAPI.Get("/:id", presenter.WriteOffPresenter.GetWriteOff)
+			adminWriteOffsAPI.Post("/:id/review", presenter.WriteOffPresenter.Review)
+			adminWriteOffsAPI.Get("/:id/recoveries", presenter.WriteOffPresenter.ListRecoveries)
+			adminWriteOffsAPI.Post("/:id/recoveries", presenter.WriteOffPresenter.RecordRecovery)
+			adminWriteOffsAPI.Get("/:id/ledger", presenter.WriteOffPresenter.Ledger)
+		}
+
+		adminConcentrationAPI := adminAPI.Group("/concentration-limits")
+		{
+			adminConcentrationAPI.Get("/", presenter.ConcentrationPresenter.ListLimits)
+			adminConcentrationAPI.Post("/", presenter.ConcentrationPresenter.CreateLimit)
+			adminConcentrationAPI.Get("/report", presenter.ConcentrationPresenter.Report)
+			adminConcentrationAPI.Put("/:id", presenter.ConcentrationPresenter.UpdateLimit)
+			adminConcentrationAPI.Delete("/:id", presenter.ConcentrationPresenter.DeleteLimit)
+		}
+
+		adminAPI.Get("/dashboard/stream", presenter.DashboardPresenter.Stream)
+
+		adminBlacklistAPI := adminAPI.Group("/blacklist")
+		{
+			adminBlacklistAPI.Get("/", presenter.BlacklistPresenter.ListEntries)
+			adminBlacklistAPI.Post("/", presenter.BlacklistPresenter.CreateEntry)
+			adminBlacklistAPI.Get("/screening-logs", presenter.BlacklistPresenter.ListScreeningLogs)
+			adminBlacklistAPI.Get("/:id", presenter.BlacklistPresenter.GetEntry)
+			adminBlacklistAPI.Put("/:id", presenter.BlacklistPresenter.UpdateEntry)
+			adminBlacklistAPI.Delete("/:id", presenter.BlacklistPresenter.DeleteEntry)
+		}
+
+		partnerAPI := api.Group("/partners", jwtAuth, presenter.ImpersonationAudit, presenter.AccountClosureGate, customCSRF, requireCustomer)
+		{
+			partnerAPI.Post("/transactions", transactionTimeout, presenter.PartnerTransactionGate, presenter.PartnerPresenter.CreateTransaction)
+			partnerAPI.Post("/transactions/batch", presenter.PartnerTransactionGate, presenter.BatchPresenter.SubmitBatch)
+			partnerAPI.Get("/batches/:id", presenter.BatchPresenter.GetBatch)
+			partnerAPI.Post("/transactions/:id/attachments", presenter.AttachmentPresenter.UploadAttachment)
+			partnerAPI.Get("/transactions/:id/attachments", presenter.AttachmentPresenter.ListAttachments)
+			partnerAPI.Post("/check-limit", checkLimitTimeout, presenter.PartnerPresenter.CheckLimit)
+		}
+
+		// Integrasi partner via API key, sandbox key tidak memengaruhi limit riil
+		partnerKeyAPI := api.Group("/partner-api", partnerSLO)
+		{
+			partnerKeyAPI.Post("/register", registrationTimeout, presenter.OnboardingPresenter.Register)
+			partnerKeyAPI.Post("/check-limit", checkLimitTimeout, presenter.APIKeyAuth, presenter.DebugRecorder, partnerRateLimit, partnerNetwork, presenter.RequestSignature, presenter.PartnerPresenter.CheckLimit)
+			partnerKeyAPI.Post("/transactions", transactionTimeout, presenter.PartnerTransactionGate, presenter.APIKeyAuth, presenter.DebugRecorder, partnerRateLimit, partnerNetwork, presenter.RequestSignature, presenter.PartnerPresenter.CreateTransaction)
+			partnerKeyAPI.Post("/transactions/batch", presenter.PartnerTransactionGate, presenter.APIKeyAuth, presenter.DebugRecorder, partnerRateLimit, partnerNetwork, presenter.RequestSignature, presenter.BatchPresenter.SubmitBatch)
+			partnerKeyAPI.Get("/batches/:id", presenter.APIKeyAuth, presenter.DebugRecorder, partnerRateLimit, partnerNetwork, presenter.RequestSignature, presenter.BatchPresenter.GetBatch)
+			partnerKeyAPI.Post("/transactions/:id/attachments", presenter.APIKeyAuth, presenter.DebugRecorder, partnerRateLimit, partnerNetwork, presenter.RequestSignature, presenter.AttachmentPresenter.UploadAttachment)
+			partnerKeyAPI.Get("/transactions/:id/attachments", presenter.APIKeyAuth, presenter.DebugRecorder, partnerRateLimit, partnerNetwork, presenter.RequestSignature, presenter.AttachmentPresenter.ListAttachments)
+		}
+
+		// Portal swalayan partner, semua data dibatasi pada partner pemilik key
+		partnerPortalAPI := partnerKeyAPI.Group("/me", presenter.APIKeyAuth, partnerRateLimit, partnerNetwork, presenter.RequestSignature)
+		{
+			partnerPortalAPI.Get("/transactions", presenter.PortalPresenter.ListTransactions)
+			partnerPortalAPI.Get("/deliveries", presenter.PortalPresenter.ListDeliveries)
+			partnerPortalAPI.Get("/usage", presenter.PortalPresenter.GetUsage)
+			partnerPortalAPI.Post("/api-key/regenerate", presenter.PortalPresenter.RegenerateAPIKey)
+			// Mode debug berlaku per API key, rekamannya hanya bisa dibaca key yang sama
+			partnerPortalAPI.Get("/debug", presenter.PartnerDebugPresenter.GetMode)
+			partnerPortalAPI.Put("/debug", presenter.PartnerDebugPresenter.SetMode)
+			partnerPortalAPI.Get("/debug/records", presenter.PartnerDebugPresenter.ListRecords)
+			partnerPortalAPI.Get("/debug/records/:id", presenter.PartnerDebugPresenter.GetRecord)
+		}
+	}
+
+	versions := []apiversion.Version{
+		{
+			Name:        "v1",
+			Deprecation: cfg.API_V1_DEPRECATED_AT,
+			Sunset:      cfg.API_V1_SUNSET_AT,
+			Successor:   "/api/v2",
+		},
+		{Name: "v2"},
+	}
+	for _, version := range versions {
+		api := app.Group(version.Prefix(), apiversion.Middleware(version))
+		api.Use(limiter.RateLimitMiddleware())
+		api.Use(bodyLimit)
+		routes(api)
 	}
 
-	partnerAPI := api.Group("/partners", jwtAuth, customCSRF, requireCustomer)
+	// Backend-for-frontend aplikasi mobile, berversi sendiri karena mengikuti
+	// rilis aplikasi, bukan kontrak API umum. Respons dikompresi karena
+	// aplikasi sering dipakai di jaringan lambat.
+	mobileAPI := app.Group("/api/mobile/v1", limiter.RateLimitMiddleware(), bodyLimit, compress.New(compress.Config{Level: compress.LevelBestSpeed}),
+		jwtAuth, presenter.ImpersonationAudit, presenter.AccountClosureGate, requireCustomer, localize)
 	{
-		partnerAPI.Post("/transactions", presenter.PartnerPresenter.CreateTransaction)
-		partnerAPI.Post("/check-limit", presenter.PartnerPresenter.CheckLimit)
+		mobileAPI.Get("/home", presenter.MobilePresenter.Home)
 	}
 
 	app.Use(func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   true,
-			"message": "Resource not found",
-			"path":    c.Path(),
-		})
+		return responder.Fail(c, fiber.StatusNotFound, "not_found", "Resource not found: "+c.Path())
 	})
 
 	return app
@@ -176,10 +545,16 @@ func ErrorCustomHandler(log *zap.Logger) fiber.ErrorHandler {
 			zap.Int("status_code", code),
 		)
 
-		return c.Status(code).JSON(fiber.Map{
-			"error":   true,
-			"message": message,
-			"code":    code,
-		})
+		return responder.Fail(c, code, strings.ReplaceAll(strings.ToLower(utils.StatusMessage(code)), " ", "_"), message)
+	}
+}
+
+// partnerObjectives are the SLIs of the partner API: availability, and the
+// share of requests answered within SLO_PARTNER_LATENCY, which is the p95
+// latency objective at the default target.
+func partnerObjectives(cfg *config.Config) []slo.Objective {
+	return []slo.Objective{
+		{Name: "partner-api.availability", Target: cfg.SLO_PARTNER_AVAILABILITY},
+		{Name: "partner-api.latency", Target: cfg.SLO_PARTNER_LATENCY_TARGET, Latency: cfg.SLO_PARTNER_LATENCY},
 	}
 }